@@ -5,12 +5,17 @@ import (
 	"os"
 
 	"github.com/drand/drand/v2/internal/drand-cli"
+	"github.com/drand/drand/v2/internal/net"
 )
 
 func main() {
 	app := drand.CLI()
 	if err := app.Run(os.Args); err != nil {
 		fmt.Printf("%+v\n", err)
+		if id := net.RequestIDFromError(err); id != "" {
+			// the logs of the nodes involved carry the same ID
+			fmt.Printf("request ID: %s\n", id)
+		}
 		os.Exit(1)
 	}
 }
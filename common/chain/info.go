@@ -65,6 +65,18 @@ func (c *Info) HashString() string {
 	return hex.EncodeToString(c.Hash())
 }
 
+// ChainHash returns the value of Hash as a typed chain hash
+func (c *Info) ChainHash() common.ChainHash {
+	// Hash is a SHA-256 digest, it always has the size of a chain hash
+	h, _ := common.ChainHashFromBytes(c.Hash())
+	return h
+}
+
+// BeaconID returns the canonical id of the beacon of the chain
+func (c *Info) BeaconID() common.BeaconID {
+	return common.BeaconID(c.ID).Canonical()
+}
+
 // Equal indicates if two Chain Info objects are equivalent
 func (c *Info) Equal(c2 *Info) bool {
 	return c.GenesisTime == c2.GenesisTime &&
@@ -10,6 +10,7 @@ import (
 
 	"github.com/drand/drand/v2/protobuf/drand"
 
+	"github.com/drand/drand/v2/common"
 	"github.com/drand/drand/v2/common/key"
 	"github.com/drand/drand/v2/crypto"
 	"github.com/drand/drand/v2/internal/test"
@@ -44,6 +45,9 @@ func TestChainInfo(t *testing.T) {
 	require.Equal(t, c1, c12)
 	require.Equal(t, c1.HashString(), hex.EncodeToString(h12))
 	require.Equal(t, c1.GetSchemeName(), g1.Scheme.Name)
+	require.Equal(t, h1, c1.ChainHash().Bytes())
+	require.Equal(t, common.BeaconID(beaconID), c1.BeaconID())
+	require.Equal(t, g1.BeaconID(), c1.BeaconID())
 
 	_, g2 := test.BatchIdentities(t, 5, sch, beaconID)
 	c2 := NewChainInfo(g2)
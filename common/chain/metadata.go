@@ -0,0 +1,124 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// InternalNamespacePrefix starts the metadata namespaces used by drand itself, which the extensions can't write to
+const InternalNamespacePrefix = "drand."
+
+// ErrMetadataUnsupported is returned for the stores which can't keep metadata next to the beacons
+var ErrMetadataUnsupported = errors.New("the chain store can't keep metadata")
+
+// maxNamespaceLen bounds the size of the namespaces
+const maxNamespaceLen = 64
+
+// MetadataStore is implemented by the stores able to keep arbitrary values next to the beacons, such as the results
+// of computations over the chain that are worth caching. GetMetadata returns a nil value for unknown keys, and
+// ListMetadata the values of the keys starting with the prefix. It is used through a namespaced Metadata.
+type MetadataStore interface {
+	GetMetadata(ctx context.Context, key string) ([]byte, error)
+	PutMetadata(ctx context.Context, key string, value []byte) error
+	DeleteMetadata(ctx context.Context, key string) error
+	ListMetadata(ctx context.Context, prefix string) (map[string][]byte, error)
+}
+
+// MetadataBatchStore is implemented by the metadata stores able to set several values at once, in a single write
+type MetadataBatchStore interface {
+	PutMetadataBatch(ctx context.Context, values map[string][]byte) error
+}
+
+// Metadata is a namespace of the metadata kept in the chain store of a beacon, in which a component of drand or an
+// extension keeps its own state, such as checkpoints, cursors or schema versions, instead of a side file. The
+// namespaces are isolated from each other, and each beacon has its own store.
+type Metadata struct {
+	store  MetadataStore
+	prefix string
+}
+
+// NewMetadata returns the given namespace of the metadata of the store. Namespaces are made of lowercase letters,
+// digits, dots, dashes and underscores. The stores which don't implement MetadataStore, such as the PostgreSQL one,
+// return ErrMetadataUnsupported.
+func NewMetadata(store Store, namespace string) (*Metadata, error) {
+	if err := ValidateNamespace(namespace); err != nil {
+		return nil, err
+	}
+	ms, ok := store.(MetadataStore)
+	if !ok {
+		return nil, ErrMetadataUnsupported
+	}
+	return &Metadata{store: ms, prefix: namespace + "/"}, nil
+}
+
+// ValidateNamespace checks that the namespace is well-formed
+func ValidateNamespace(namespace string) error {
+	if namespace == "" || len(namespace) > maxNamespaceLen {
+		return fmt.Errorf("a metadata namespace has between 1 and %d characters", maxNamespaceLen)
+	}
+	for _, c := range namespace {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '.' && c != '-' && c != '_' {
+			return fmt.Errorf("invalid metadata namespace %q: only lowercase letters, digits, '.', '-' and '_' are allowed", namespace)
+		}
+	}
+	return nil
+}
+
+// Get returns the value of the key, nil if it isn't set
+func (m *Metadata) Get(ctx context.Context, key string) ([]byte, error) {
+	if key == "" {
+		return nil, errors.New("empty metadata key")
+	}
+	return m.store.GetMetadata(ctx, m.prefix+key)
+}
+
+// Put sets the value of the key
+func (m *Metadata) Put(ctx context.Context, key string, value []byte) error {
+	if key == "" {
+		return errors.New("empty metadata key")
+	}
+	return m.store.PutMetadata(ctx, m.prefix+key, value)
+}
+
+// PutBatch sets the values of the keys, in a single write if the store supports it
+func (m *Metadata) PutBatch(ctx context.Context, values map[string][]byte) error {
+	prefixed := make(map[string][]byte, len(values))
+	for k, v := range values {
+		if k == "" {
+			return errors.New("empty metadata key")
+		}
+		prefixed[m.prefix+k] = v
+	}
+	if bs, ok := m.store.(MetadataBatchStore); ok {
+		return bs.PutMetadataBatch(ctx, prefixed)
+	}
+	for k, v := range prefixed {
+		if err := m.store.PutMetadata(ctx, k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete removes the key, if it is set
+func (m *Metadata) Delete(ctx context.Context, key string) error {
+	if key == "" {
+		return errors.New("empty metadata key")
+	}
+	return m.store.DeleteMetadata(ctx, m.prefix+key)
+}
+
+// List returns the values of all the keys of the namespace
+func (m *Metadata) List(ctx context.Context) (map[string][]byte, error) {
+	values, err := m.store.ListMetadata(ctx, m.prefix)
+	if err != nil {
+		return nil, err
+	}
+	listed := make(map[string][]byte, len(values))
+	for k, v := range values {
+		listed[strings.TrimPrefix(k, m.prefix)] = v
+	}
+	return listed, nil
+}
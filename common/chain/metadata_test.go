@@ -0,0 +1,50 @@
+package chain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/drand/drand/v2/common/chain"
+	"github.com/drand/drand/v2/internal/chain/memdb"
+)
+
+func TestMetadataNamespaces(t *testing.T) {
+	ctx := context.Background()
+	store := memdb.NewStore(10)
+
+	a, err := chain.NewMetadata(store, "ext")
+	require.NoError(t, err)
+	b, err := chain.NewMetadata(store, "ext2")
+	require.NoError(t, err)
+
+	require.NoError(t, a.Put(ctx, "cursor", []byte("1")))
+	require.NoError(t, a.Put(ctx, "schema", []byte("2")))
+	require.NoError(t, b.Put(ctx, "cursor", []byte("3")))
+
+	// the namespaces are isolated from each other
+	value, err := a.Get(ctx, "cursor")
+	require.NoError(t, err)
+	require.Equal(t, []byte("1"), value)
+	listed, err := a.List(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"cursor": []byte("1"), "schema": []byte("2")}, listed)
+	listed, err = b.List(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"cursor": []byte("3")}, listed)
+
+	require.NoError(t, a.Delete(ctx, "cursor"))
+	value, err = a.Get(ctx, "cursor")
+	require.NoError(t, err)
+	require.Nil(t, value)
+	value, err = b.Get(ctx, "cursor")
+	require.NoError(t, err)
+	require.Equal(t, []byte("3"), value)
+
+	require.Error(t, a.Put(ctx, "", nil))
+	for _, ns := range []string{"", "Ext", "a/b", "ext space"} {
+		_, err := chain.NewMetadata(store, ns)
+		require.Error(t, err, ns)
+	}
+}
@@ -0,0 +1,47 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/drand/drand/v2/common"
+)
+
+// Store is an interface to store Beacons packets where they can also be
+// retrieved to be delivered to end clients. It is the interface implemented by
+// the chain store drivers registered with the plugin package.
+type Store interface {
+	Len(context.Context) (int, error)
+	Put(context.Context, *common.Beacon) error
+	Last(context.Context) (*common.Beacon, error)
+	Get(ctx context.Context, round common.Round) (*common.Beacon, error)
+	Cursor(context.Context, func(context.Context, Cursor) error) error
+	Close() error
+	Del(ctx context.Context, round common.Round) error
+	SaveTo(ctx context.Context, w io.Writer) error
+}
+
+// Cursor iterates over items in sorted key order. This starts from the
+// first key/value pair and updates the k/v variables to the
+// next key/value on each iteration.
+//
+// The loop finishes at the end of the cursor when a nil key is returned.
+//
+//	for k, v := c.First(); k != nil; k, v = c.Next() {
+//	    fmt.Printf("A %s is %s.\n", k, v)
+//	}
+type Cursor interface {
+	First(context.Context) (*common.Beacon, error)
+	Next(context.Context) (*common.Beacon, error)
+	Seek(ctx context.Context, round common.Round) (*common.Beacon, error)
+	Last(context.Context) (*common.Beacon, error)
+}
+
+// ErrNoBeaconStored is the error we get when a sync is called too early and
+// there are no beacon above the requested round
+var ErrNoBeaconStored = errors.New("no beacon stored above requested round")
+
+// ErrNoBeaconSaved is the error returned when no beacon have been saved in the
+// database yet.
+var ErrNoBeaconSaved = errors.New("beacon not found in database")
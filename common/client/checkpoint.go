@@ -0,0 +1,224 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/chain"
+	"github.com/drand/drand/v2/crypto"
+)
+
+// ErrBrokenChain is returned when the previous signature of a beacon isn't the signature of the round before it
+var ErrBrokenChain = errors.New("broken chain: the previous signature doesn't match the previous round")
+
+// CheckpointVerifyingClient is a Client verifying that the beacons of a chained scheme link back to a trusted
+// checkpoint, instead of walking the whole chain back to genesis: the history before the checkpoint is trusted
+// without being verified, which makes a new deployment useful right away. UpgradeToFull verifies that history,
+// typically in the background, after which the chain is verified from genesis.
+// Every beacon served is always verified against the public key of the chain, whatever the mode.
+type CheckpointVerifyingClient struct {
+	Client
+	checkpoint *common.Beacon
+	full       atomic.Bool
+
+	infoLk sync.Mutex
+	info   *chain.Info
+	sch    *crypto.Scheme
+
+	// lastLk is held while walking the chain forward, so that each round is only fetched once
+	lastLk sync.Mutex
+	// last is the most recent beacon whose chain was verified back to the checkpoint
+	last *common.Beacon
+}
+
+// NewCheckpointVerifying returns a Client serving the beacons of the given source after verifying them, starting
+// from the given trusted checkpoint. The checkpoint itself is verified against the public key of the chain.
+func NewCheckpointVerifying(src Client, checkpoint *common.Beacon) *CheckpointVerifyingClient {
+	return &CheckpointVerifyingClient{
+		Client:     src,
+		checkpoint: checkpoint,
+		last:       checkpoint,
+	}
+}
+
+// Get returns the given round from the source, after verifying it
+func (c *CheckpointVerifyingClient) Get(ctx context.Context, round common.Round) (Result, error) {
+	r, err := c.Client.Get(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.verifyResult(ctx, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Watch relays the beacons of the source which could be verified
+func (c *CheckpointVerifyingClient) Watch(ctx context.Context) <-chan Result {
+	out := make(chan Result)
+	go func() {
+		defer close(out)
+		for r := range c.Client.Watch(ctx) {
+			if err := c.verifyResult(ctx, r); err != nil {
+				continue
+			}
+			select {
+			case out <- r:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func (c *CheckpointVerifyingClient) Info(ctx context.Context) (*chain.Info, error) {
+	info, _, err := c.chainInfo(ctx)
+	return info, err
+}
+
+func (c *CheckpointVerifyingClient) RoundAt(t time.Time) uint64 {
+	return c.Client.RoundAt(t)
+}
+
+// FullyVerified returns whether the history before the checkpoint was verified by UpgradeToFull
+func (c *CheckpointVerifyingClient) FullyVerified() bool {
+	return c.full.Load()
+}
+
+// UpgradeToFull verifies the history of the chain from genesis up to the checkpoint, after which the chain is
+// verified from genesis. It takes a time linear in the checkpoint round and is meant to run in the background.
+func (c *CheckpointVerifyingClient) UpgradeToFull(ctx context.Context) error {
+	info, sch, err := c.chainInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	previous := chainGenesis(info)
+	for round := uint64(1); round <= c.checkpoint.Round; round++ {
+		b, err := c.fetch(ctx, sch, info, round)
+		if err != nil {
+			return err
+		}
+		if err := link(sch, previous, b); err != nil {
+			return err
+		}
+		previous = b
+	}
+	if !bytes.Equal(previous.Signature, c.checkpoint.Signature) {
+		return fmt.Errorf("%w: the chain leads to another signature than the checkpoint", ErrBrokenChain)
+	}
+	c.full.Store(true)
+	return nil
+}
+
+func (c *CheckpointVerifyingClient) verifyResult(ctx context.Context, r Result) error {
+	info, sch, err := c.chainInfo(ctx)
+	if err != nil {
+		return err
+	}
+	if err := verify(sch, info, r); err != nil {
+		return err
+	}
+	// the rounds before the checkpoint are trusted, and the unchained ones don't link to each other
+	if !isChained(sch) || r.GetRound() <= c.checkpoint.Round {
+		return nil
+	}
+	return c.verifyForward(ctx, sch, info, toBeacon(r))
+}
+
+// verifyForward walks the chain from the last verified beacon up to the given one
+func (c *CheckpointVerifyingClient) verifyForward(ctx context.Context, sch *crypto.Scheme, info *chain.Info, b *common.Beacon) error {
+	c.lastLk.Lock()
+	defer c.lastLk.Unlock()
+
+	if b.Round <= c.last.Round {
+		return nil
+	}
+	previous := c.last
+	for round := previous.Round + 1; round < b.Round; round++ {
+		next, err := c.fetch(ctx, sch, info, round)
+		if err != nil {
+			return err
+		}
+		if err := link(sch, previous, next); err != nil {
+			return err
+		}
+		// we keep the progress even if we fail later on
+		previous, c.last = next, next
+	}
+	if err := link(sch, previous, b); err != nil {
+		return err
+	}
+	c.last = b
+	return nil
+}
+
+// fetch gets the given round from the source and verifies its signature
+func (c *CheckpointVerifyingClient) fetch(ctx context.Context, sch *crypto.Scheme, info *chain.Info, round uint64) (*common.Beacon, error) {
+	r, err := c.Client.Get(ctx, common.Round(round))
+	if err != nil {
+		return nil, fmt.Errorf("unable to get round %d: %w", round, err)
+	}
+	if r.GetRound() != round {
+		return nil, fmt.Errorf("asked for round %d, got %d", round, r.GetRound())
+	}
+	if err := verify(sch, info, r); err != nil {
+		return nil, fmt.Errorf("invalid round %d: %w", round, err)
+	}
+	return toBeacon(r), nil
+}
+
+// chainInfo returns the info of the chain, verifying the checkpoint against it the first time
+func (c *CheckpointVerifyingClient) chainInfo(ctx context.Context) (*chain.Info, *crypto.Scheme, error) {
+	c.infoLk.Lock()
+	defer c.infoLk.Unlock()
+	if c.info != nil {
+		return c.info, c.sch, nil
+	}
+
+	info, err := c.Client.Info(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	sch, err := crypto.SchemeFromName(info.GetSchemeName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := sch.VerifyBeacon(c.checkpoint, info.PublicKey); err != nil {
+		return nil, nil, fmt.Errorf("invalid checkpoint %d: %w", c.checkpoint.Round, err)
+	}
+	c.info, c.sch = info, sch
+	return info, sch, nil
+}
+
+// link checks that the beacon follows the previous one, for chained schemes
+func link(sch *crypto.Scheme, previous, b *common.Beacon) error {
+	if isChained(sch) && !bytes.Equal(previous.Signature, b.PreviousSig) {
+		return fmt.Errorf("%w: round %d", ErrBrokenChain, b.Round)
+	}
+	return nil
+}
+
+func isChained(sch *crypto.Scheme) bool {
+	return sch.Name == crypto.DefaultSchemeID
+}
+
+// chainGenesis returns the genesis beacon of the chain, whose signature is the genesis seed
+func chainGenesis(info *chain.Info) *common.Beacon {
+	return &common.Beacon{Round: 0, Signature: info.GenesisSeed}
+}
+
+func toBeacon(r Result) *common.Beacon {
+	b := &common.Beacon{Round: r.GetRound(), Signature: r.GetSignature()}
+	if p, ok := r.(previousSignature); ok {
+		b.PreviousSig = p.GetPreviousSignature()
+	}
+	return b
+}
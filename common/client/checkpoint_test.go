@@ -0,0 +1,115 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/drand/kyber"
+	"github.com/drand/kyber/share"
+	"github.com/drand/kyber/sign/tbls"
+	"github.com/drand/kyber/util/random"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/chain"
+	"github.com/drand/drand/v2/common/client"
+	"github.com/drand/drand/v2/crypto"
+)
+
+// chainSource serves a chain of beacons signed in advance, counting the rounds fetched
+type chainSource struct {
+	client.Client
+	info    *chain.Info
+	beacons []*common.Beacon
+	fetched int
+}
+
+func newChainSource(t *testing.T, secret kyber.Scalar, seed string, rounds int) *chainSource {
+	sch, err := crypto.SchemeFromName(crypto.DefaultSchemeID)
+	require.NoError(t, err)
+	s := &chainSource{info: &chain.Info{
+		PublicKey:   sch.KeyGroup.Point().Mul(secret, nil),
+		Period:      time.Second,
+		Scheme:      sch.Name,
+		GenesisTime: time.Now().Unix(),
+		GenesisSeed: []byte(seed),
+	}}
+	previous := s.info.GenesisSeed
+	s.beacons = []*common.Beacon{{Round: 0, Signature: previous}}
+	for round := uint64(1); round <= uint64(rounds); round++ {
+		msg := sch.DigestBeacon(&common.Beacon{Round: round, PreviousSig: previous})
+		sig, err := sch.ThresholdScheme.Sign(&share.PriShare{I: 0, V: secret}, msg)
+		require.NoError(t, err)
+		sigShare := tbls.SigShare(sig)
+		b := &common.Beacon{Round: round, PreviousSig: previous, Signature: sigShare.Value()}
+		s.beacons = append(s.beacons, b)
+		previous = b.Signature
+	}
+	return s
+}
+
+func (s *chainSource) Info(context.Context) (*chain.Info, error) {
+	return s.info, nil
+}
+
+func (s *chainSource) Get(_ context.Context, round common.Round) (client.Result, error) {
+	if round.Uint64() >= uint64(len(s.beacons)) {
+		return nil, errors.New("round not available")
+	}
+	s.fetched++
+	return s.beacons[round], nil
+}
+
+func TestCheckpointVerifyingOnlyWalksFromCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	src := newChainSource(t, newSecret(t), "seed", 20)
+	c := client.NewCheckpointVerifying(src, src.beacons[15])
+
+	r, err := c.Get(ctx, 18)
+	require.NoError(t, err)
+	require.Equal(t, uint64(18), r.GetRound())
+	// rounds 16 and 17 are fetched to link round 18 to the checkpoint, nothing before it
+	require.Equal(t, 3, src.fetched)
+
+	// the rounds before the checkpoint are served after checking their signature only
+	_, err = c.Get(ctx, 3)
+	require.NoError(t, err)
+	require.Equal(t, 4, src.fetched)
+	require.False(t, c.FullyVerified())
+
+	require.NoError(t, c.UpgradeToFull(ctx))
+	require.True(t, c.FullyVerified())
+}
+
+func TestCheckpointVerifyingDetectsBrokenChain(t *testing.T) {
+	ctx := context.Background()
+	secret := newSecret(t)
+	src := newChainSource(t, secret, "seed", 10)
+	// a checkpoint validly signed, but from another history than the one served by the source
+	other := newChainSource(t, secret, "another seed", 10)
+
+	c := client.NewCheckpointVerifying(src, other.beacons[5])
+	_, err := c.Get(ctx, 7)
+	require.ErrorIs(t, err, client.ErrBrokenChain)
+
+	// the checkpoint must lead back to genesis to upgrade to full verification
+	c = client.NewCheckpointVerifying(src, other.beacons[5])
+	require.ErrorIs(t, c.UpgradeToFull(ctx), client.ErrBrokenChain)
+	require.False(t, c.FullyVerified())
+
+	// a beacon which doesn't belong to the chain is refused
+	c = client.NewCheckpointVerifying(src, src.beacons[5])
+	_, err = c.Get(ctx, 7)
+	require.NoError(t, err)
+	src.beacons[8] = other.beacons[8]
+	_, err = c.Get(ctx, 8)
+	require.ErrorIs(t, err, client.ErrBrokenChain)
+}
+
+func newSecret(t *testing.T) kyber.Scalar {
+	sch, err := crypto.SchemeFromName(crypto.DefaultSchemeID)
+	require.NoError(t, err)
+	return sch.KeyGroup.Scalar().Pick(random.New())
+}
@@ -0,0 +1,241 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/chain"
+	"github.com/drand/drand/v2/crypto"
+)
+
+// MaxTrackedRounds is the number of recent rounds a ForkDetector remembers the signature of
+const MaxTrackedRounds = 1024
+
+// ErrFork is returned when independent sources served different valid signatures for the same round
+var ErrFork = errors.New("fork detected: different signatures served for the same round")
+
+// Fork is the observation of two different signatures for the same round, both valid against the public key of
+// the chain. It never happens on a healthy network: one of the sources follows another chain history, or worse,
+// the group key was compromised.
+type Fork struct {
+	Round common.Round
+	// Sources are the sources which served the conflicting signatures
+	Sources    [2]string
+	Signatures [2][]byte
+}
+
+func (f *Fork) String() string {
+	return fmt.Sprintf("round %d: %s served %x while %s served %x",
+		f.Round, f.Sources[0], f.Signatures[0], f.Sources[1], f.Signatures[1])
+}
+
+type observation struct {
+	source    string
+	signature []byte
+}
+
+// ForkDetector cross-checks the signatures served for the same rounds by independent sources, and reports as soon
+// as two of them disagree. It is an early warning system for a catastrophic key compromise.
+type ForkDetector struct {
+	sync.Mutex
+	seen   map[common.Round]observation
+	rounds []common.Round
+	onFork func(*Fork)
+}
+
+// NewForkDetector returns a detector calling onFork for each fork it observes
+func NewForkDetector(onFork func(*Fork)) *ForkDetector {
+	return &ForkDetector{
+		seen:   make(map[common.Round]observation),
+		onFork: onFork,
+	}
+}
+
+// Observe records the signature served by the given source for the given round. The caller must have verified it
+// against the public key of the chain first, so that an invalid answer isn't taken for a fork. It returns the fork
+// if the signature differs from the one seen before for this round, nil otherwise.
+func (d *ForkDetector) Observe(source string, round common.Round, signature []byte) *Fork {
+	d.Lock()
+	seen, ok := d.seen[round]
+	if !ok {
+		if len(d.rounds) == MaxTrackedRounds {
+			delete(d.seen, d.rounds[0])
+			d.rounds = d.rounds[1:]
+		}
+		d.seen[round] = observation{source: source, signature: signature}
+		d.rounds = append(d.rounds, round)
+	}
+	d.Unlock()
+
+	if !ok || bytes.Equal(seen.signature, signature) {
+		return nil
+	}
+	fork := &Fork{
+		Round:      round,
+		Sources:    [2]string{seen.source, source},
+		Signatures: [2][]byte{seen.signature, signature},
+	}
+	if d.onFork != nil {
+		d.onFork(fork)
+	}
+	return fork
+}
+
+// crossCheckingClient gets every round from all its sources, and refuses to serve it unless they agree
+type crossCheckingClient struct {
+	sources  map[string]Client
+	names    []string
+	detector *ForkDetector
+
+	infoLk sync.Mutex
+	info   *chain.Info
+	sch    *crypto.Scheme
+}
+
+// NewCrossChecking returns a Client getting each round from all the given independent sources, by name, and
+// checking with the given detector that they all served the same signature for it. The sources which fail to
+// serve a valid beacon are ignored, as long as at least one of them does.
+func NewCrossChecking(sources map[string]Client, detector *ForkDetector) (Client, error) {
+	if len(sources) == 0 {
+		return nil, errors.New("no source to cross-check")
+	}
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return &crossCheckingClient{sources: sources, names: names, detector: detector}, nil
+}
+
+func (c *crossCheckingClient) Get(ctx context.Context, round common.Round) (Result, error) {
+	info, sch, err := c.chainInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, len(c.names))
+	errs := make([]error, len(c.names))
+	var wg sync.WaitGroup
+	for i, name := range c.names {
+		wg.Add(1)
+		go func(i int, src Client) {
+			defer wg.Done()
+			results[i], errs[i] = src.Get(ctx, round)
+		}(i, c.sources[name])
+	}
+	wg.Wait()
+
+	// when asking for the latest round, the sources might be at different rounds: we compare the ones they have
+	// in common and return the most recent one
+	var latest Result
+	var forkErr error
+	for i, r := range results {
+		if errs[i] != nil || r == nil {
+			continue
+		}
+		if err := verify(sch, info, r); err != nil {
+			errs[i] = err
+			continue
+		}
+		if fork := c.detector.Observe(c.names[i], common.Round(r.GetRound()), r.GetSignature()); fork != nil {
+			forkErr = fmt.Errorf("%w: %s", ErrFork, fork)
+		}
+		if latest == nil || r.GetRound() > latest.GetRound() {
+			latest = r
+		}
+	}
+	if forkErr != nil {
+		return nil, forkErr
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no source served a valid beacon for round %d: %w", round, errors.Join(errs...))
+	}
+	return latest, nil
+}
+
+// Watch relays the beacons of the first source, after cross-checking them with all the sources
+func (c *crossCheckingClient) Watch(ctx context.Context) <-chan Result {
+	out := make(chan Result)
+	go func() {
+		defer close(out)
+		for r := range c.sources[c.names[0]].Watch(ctx) {
+			checked, err := c.Get(ctx, common.Round(r.GetRound()))
+			if err != nil {
+				continue
+			}
+			select {
+			case out <- checked:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func (c *crossCheckingClient) Info(ctx context.Context) (*chain.Info, error) {
+	info, _, err := c.chainInfo(ctx)
+	return info, err
+}
+
+func (c *crossCheckingClient) RoundAt(t time.Time) uint64 {
+	c.infoLk.Lock()
+	info := c.info
+	c.infoLk.Unlock()
+	if info == nil {
+		return c.sources[c.names[0]].RoundAt(t)
+	}
+	return common.CurrentRound(t.Unix(), info.Period, info.GenesisTime)
+}
+
+func (c *crossCheckingClient) Close() error {
+	var errs []error
+	for _, name := range c.names {
+		errs = append(errs, c.sources[name].Close())
+	}
+	return errors.Join(errs...)
+}
+
+// chainInfo returns the chain info all the sources agree on
+func (c *crossCheckingClient) chainInfo(ctx context.Context) (*chain.Info, *crypto.Scheme, error) {
+	c.infoLk.Lock()
+	defer c.infoLk.Unlock()
+	if c.info != nil {
+		return c.info, c.sch, nil
+	}
+
+	var info *chain.Info
+	for _, name := range c.names {
+		i, err := c.sources[name].Info(ctx)
+		if err != nil {
+			continue
+		}
+		if info != nil && !info.Equal(i) {
+			return nil, nil, fmt.Errorf("%s serves the info of another chain", name)
+		}
+		info = i
+	}
+	if info == nil {
+		return nil, nil, errors.New("no source served the chain info")
+	}
+	sch, err := crypto.SchemeFromName(info.GetSchemeName())
+	if err != nil {
+		return nil, nil, err
+	}
+	c.info, c.sch = info, sch
+	return info, sch, nil
+}
+
+type previousSignature interface {
+	GetPreviousSignature() []byte
+}
+
+func verify(sch *crypto.Scheme, info *chain.Info, r Result) error {
+	return sch.VerifyBeacon(toBeacon(r), info.PublicKey)
+}
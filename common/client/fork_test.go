@@ -0,0 +1,98 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/drand/kyber"
+	"github.com/drand/kyber/share"
+	"github.com/drand/kyber/sign/tbls"
+	"github.com/drand/kyber/util/random"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/chain"
+	"github.com/drand/drand/v2/common/client"
+	"github.com/drand/drand/v2/crypto"
+)
+
+func TestForkDetector(t *testing.T) {
+	var forks []*client.Fork
+	d := client.NewForkDetector(func(f *client.Fork) { forks = append(forks, f) })
+
+	require.Nil(t, d.Observe("a", 1, []byte{1}))
+	require.Nil(t, d.Observe("b", 1, []byte{1}))
+	fork := d.Observe("c", 1, []byte{2})
+	require.NotNil(t, fork)
+	require.Equal(t, [2]string{"a", "c"}, fork.Sources)
+	require.Equal(t, [2][]byte{{1}, {2}}, fork.Signatures)
+	require.Equal(t, []*client.Fork{fork}, forks)
+
+	// only the most recent rounds are tracked
+	for round := uint64(2); round < client.MaxTrackedRounds+2; round++ {
+		require.Nil(t, d.Observe("a", common.Round(round), []byte{1}))
+	}
+	require.Nil(t, d.Observe("c", 1, []byte{3}))
+}
+
+// staticSource serves beacons it signs itself, chaining them from its own previous signature
+type staticSource struct {
+	client.Client
+	info     *chain.Info
+	sch      *crypto.Scheme
+	secret   kyber.Scalar
+	previous []byte
+}
+
+func (s *staticSource) Info(context.Context) (*chain.Info, error) {
+	return s.info, nil
+}
+
+func (s *staticSource) Get(_ context.Context, round common.Round) (client.Result, error) {
+	msg := s.sch.DigestBeacon(&common.Beacon{Round: round.Uint64(), PreviousSig: s.previous})
+	sig, err := s.sch.ThresholdScheme.Sign(&share.PriShare{I: 0, V: s.secret}, msg)
+	if err != nil {
+		return nil, err
+	}
+	sigShare := tbls.SigShare(sig)
+	return &common.Beacon{Round: round.Uint64(), PreviousSig: s.previous, Signature: sigShare.Value()}, nil
+}
+
+func (s *staticSource) Close() error {
+	return nil
+}
+
+func TestCrossCheckingDetectsFork(t *testing.T) {
+	sch, err := crypto.SchemeFromName(crypto.DefaultSchemeID)
+	require.NoError(t, err)
+	secret := sch.KeyGroup.Scalar().Pick(random.New())
+	info := &chain.Info{
+		PublicKey:   sch.KeyGroup.Point().Mul(secret, nil),
+		Period:      time.Second,
+		Scheme:      sch.Name,
+		GenesisTime: time.Now().Unix(),
+		GenesisSeed: []byte("seed"),
+	}
+	source := func(previous string) *staticSource {
+		return &staticSource{info: info, sch: sch, secret: secret, previous: []byte(previous)}
+	}
+
+	var forks []*client.Fork
+	detector := client.NewForkDetector(func(f *client.Fork) { forks = append(forks, f) })
+	c, err := client.NewCrossChecking(map[string]client.Client{"a": source("history"), "b": source("history")}, detector)
+	require.NoError(t, err)
+	r, err := c.Get(context.Background(), 42)
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), r.GetRound())
+	require.Empty(t, forks)
+
+	// a source following another history than the others serves a valid but different signature
+	c, err = client.NewCrossChecking(map[string]client.Client{"a": source("history"), "b": source("forked history")}, detector)
+	require.NoError(t, err)
+	_, err = c.Get(context.Background(), 43)
+	require.True(t, errors.Is(err, client.ErrFork))
+	require.Len(t, forks, 1)
+	require.Equal(t, common.Round(43), forks[0].Round)
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"time"
 
+	"github.com/drand/drand/v2/common"
 	"github.com/drand/drand/v2/common/chain"
 	"github.com/drand/drand/v2/common/log"
 )
@@ -14,7 +15,7 @@ type Client interface {
 	// Get returns the randomness at `round` or an error.
 	// Requesting round = 0 will return randomness for the most
 	// recent known round, bounded at a minimum to the `RoundAt(time.Now())`
-	Get(ctx context.Context, round uint64) (Result, error)
+	Get(ctx context.Context, round common.Round) (Result, error)
 
 	// Watch returns new randomness as it becomes available.
 	Watch(ctx context.Context) <-chan Result
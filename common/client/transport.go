@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/chain"
+	"github.com/drand/drand/v2/common/log"
+)
+
+// Transport is how a Client reaches the drand network. Implementing it is all it takes to route the drand traffic
+// over another infrastructure, such as a service mesh or a peer-to-peer network: NewFromTransport turns it into a
+// Client, which the verifying clients of this package wrap like any other.
+// The transport doesn't have to verify what it serves, that is the job of the layers above it.
+type Transport interface {
+	// Get returns the randomness at `round`, or the most recent one for round 0.
+	Get(ctx context.Context, round common.Round) (Result, error)
+	// Watch returns new randomness as it becomes available, until the context is canceled.
+	Watch(ctx context.Context) <-chan Result
+	// Info returns the parameters of the chain.
+	Info(ctx context.Context) (*chain.Info, error)
+}
+
+// transportClient is a Client over a Transport. It caches the info of the chain, which never changes, and halts the
+// requests in flight when it is closed.
+type transportClient struct {
+	t Transport
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	infoLk sync.Mutex
+	info   *chain.Info
+}
+
+// NewFromTransport returns a Client serving the randomness fetched through the given transport. Closing the client
+// closes the transport if it implements io.Closer, and the logger is passed on to it if it implements LoggingClient.
+func NewFromTransport(t Transport) Client {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &transportClient{t: t, ctx: ctx, cancel: cancel}
+}
+
+func (c *transportClient) Get(ctx context.Context, round common.Round) (Result, error) {
+	ctx, cancel := c.bind(ctx)
+	defer cancel()
+	return c.t.Get(ctx, round)
+}
+
+func (c *transportClient) Watch(ctx context.Context) <-chan Result {
+	ctx, cancel := c.bind(ctx)
+	out := make(chan Result)
+	go func() {
+		defer cancel()
+		defer close(out)
+		for r := range c.t.Watch(ctx) {
+			select {
+			case out <- r:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func (c *transportClient) Info(ctx context.Context) (*chain.Info, error) {
+	c.infoLk.Lock()
+	defer c.infoLk.Unlock()
+	if c.info != nil {
+		return c.info, nil
+	}
+
+	ctx, cancel := c.bind(ctx)
+	defer cancel()
+	info, err := c.t.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.info = info
+	return info, nil
+}
+
+// RoundAt returns the round at the given time, or 0 if the info of the chain can't be fetched
+func (c *transportClient) RoundAt(t time.Time) uint64 {
+	info, err := c.Info(c.ctx)
+	if err != nil {
+		return 0
+	}
+	return common.CurrentRound(t.Unix(), info.Period, info.GenesisTime)
+}
+
+func (c *transportClient) SetLog(l log.Logger) {
+	if lc, ok := c.t.(LoggingClient); ok {
+		lc.SetLog(l)
+	}
+}
+
+func (c *transportClient) Close() error {
+	c.cancel()
+	if closer, ok := c.t.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// bind returns a context canceled when either the given context is or the client is closed
+func (c *transportClient) bind(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(c.ctx, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
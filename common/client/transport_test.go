@@ -0,0 +1,79 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/chain"
+	"github.com/drand/drand/v2/common/client"
+)
+
+// chainTransport serves the beacons of a chainSource through the Transport interface only
+type chainTransport struct {
+	src    *chainSource
+	infos  int
+	closed bool
+}
+
+func (t *chainTransport) Get(ctx context.Context, round common.Round) (client.Result, error) {
+	return t.src.Get(ctx, round)
+}
+
+func (t *chainTransport) Watch(ctx context.Context) <-chan client.Result {
+	out := make(chan client.Result)
+	go func() {
+		defer close(out)
+		for _, b := range t.src.beacons[1:] {
+			select {
+			case out <- b:
+			case <-ctx.Done():
+				return
+			}
+		}
+		<-ctx.Done()
+	}()
+	return out
+}
+
+func (t *chainTransport) Info(ctx context.Context) (*chain.Info, error) {
+	t.infos++
+	return t.src.Info(ctx)
+}
+
+func (t *chainTransport) Close() error {
+	t.closed = true
+	return nil
+}
+
+func TestTransportClient(t *testing.T) {
+	ctx := context.Background()
+	src := newChainSource(t, newSecret(t), "seed", 10)
+	tr := &chainTransport{src: src}
+	c := client.NewFromTransport(tr)
+
+	// the verification layers work over any transport
+	verifying := client.NewCheckpointVerifying(c, src.beacons[5])
+	r, err := verifying.Get(ctx, 8)
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), r.GetRound())
+
+	info := src.info
+	require.Equal(t, uint64(3), c.RoundAt(time.Unix(info.GenesisTime, 0).Add(2*info.Period)))
+	// the info of the chain is only fetched once
+	require.Equal(t, 1, tr.infos)
+
+	results := c.Watch(ctx)
+	r = <-results
+	require.Equal(t, uint64(1), r.GetRound())
+
+	// closing the client halts the watch and closes the transport
+	require.NoError(t, c.Close())
+	for r := range results {
+		require.NotNil(t, r)
+	}
+	require.True(t, tr.closed)
+}
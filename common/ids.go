@@ -0,0 +1,114 @@
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// BeaconID identifies a beacon, i.e. one of the randomness chains a node runs. Its distinct type keeps it from being
+// swapped with the other string arguments of a call.
+type BeaconID string
+
+// Canonical returns the beacon id as it is used internally, with the empty id translated to DefaultBeaconID
+func (id BeaconID) Canonical() BeaconID {
+	return BeaconID(GetCanonicalBeaconID(string(id)))
+}
+
+// IsDefault indicates if the beacon id is the default one
+func (id BeaconID) IsDefault() bool {
+	return IsDefaultBeaconID(string(id))
+}
+
+// Equal indicates if the two beacon ids are equivalent, handling the default values too
+func (id BeaconID) Equal(other BeaconID) bool {
+	return CompareBeaconIDs(string(id), string(other))
+}
+
+func (id BeaconID) String() string {
+	return string(id)
+}
+
+// Round is the number of a round of a chain. Its distinct type keeps it from being swapped with the other integer
+// arguments of a call, such as a time or a period.
+type Round uint64
+
+// Uint64 returns the round as the number used by the protocol and the stores
+func (r Round) Uint64() uint64 {
+	return uint64(r)
+}
+
+// Next returns the round following this one
+func (r Round) Next() Round {
+	return r + 1
+}
+
+// Time returns the UNIX time at which the round is produced on the chain of the given period and genesis time
+func (r Round) Time(period time.Duration, genesis int64) int64 {
+	return TimeOfRound(period, genesis, r)
+}
+
+func (r Round) String() string {
+	return strconv.FormatUint(uint64(r), 10)
+}
+
+// ChainHash is the hash identifying a chain, as returned by the Hash of its info. Its distinct type keeps it from
+// being swapped with the other byte slices of a call, such as a signature or a randomness, and being an array, it is
+// comparable and can key a map.
+type ChainHash [sha256.Size]byte
+
+// ChainHashFromBytes returns the chain hash of the given bytes, as returned by the Hash of the info of a chain
+func ChainHashFromBytes(b []byte) (ChainHash, error) {
+	var h ChainHash
+	if len(b) != len(h) {
+		return h, fmt.Errorf("%w: %x has %d bytes, expected %d", ErrInvalidChainHash, b, len(b), len(h))
+	}
+	copy(h[:], b)
+	return h, nil
+}
+
+// ParseChainHash decodes the chain hash from its hex form
+func ParseChainHash(s string) (ChainHash, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return ChainHash{}, fmt.Errorf("%w: %s is not hex: %w", ErrInvalidChainHash, s, err)
+	}
+	return ChainHashFromBytes(b)
+}
+
+// Bytes returns the chain hash as the byte slice used by the protocol
+func (h ChainHash) Bytes() []byte {
+	return append([]byte(nil), h[:]...)
+}
+
+// IsZero indicates if the chain hash isn't set
+func (h ChainHash) IsZero() bool {
+	return h == ChainHash{}
+}
+
+// Equal indicates if the two chain hashes are the same
+func (h ChainHash) Equal(other ChainHash) bool {
+	return h == other
+}
+
+// String returns the hex form of the chain hash, as used in the URLs of the HTTP API
+func (h ChainHash) String() string {
+	return hex.EncodeToString(h[:])
+}
+
+// MarshalText encodes the chain hash in hex, so that it reads the same in JSON and TOML as in the URLs
+func (h ChainHash) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+// UnmarshalText decodes the chain hash from its hex form
+func (h *ChainHash) UnmarshalText(text []byte) error {
+	parsed, err := ParseChainHash(string(text))
+	if err != nil {
+		return err
+	}
+	*h = parsed
+	return nil
+}
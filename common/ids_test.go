@@ -0,0 +1,62 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBeaconID(t *testing.T) {
+	require.Equal(t, BeaconID(DefaultBeaconID), BeaconID("").Canonical())
+	require.Equal(t, BeaconID("beacon_5s"), BeaconID("beacon_5s").Canonical())
+	require.True(t, BeaconID("").IsDefault())
+	require.False(t, BeaconID("beacon_5s").IsDefault())
+	require.True(t, BeaconID("").Equal(DefaultBeaconID))
+	require.False(t, BeaconID("beacon_5s").Equal(""))
+	require.Equal(t, "beacon_5s", BeaconID("beacon_5s").String())
+}
+
+func TestRound(t *testing.T) {
+	r := Round(41)
+	require.Equal(t, uint64(41), r.Uint64())
+	require.Equal(t, Round(42), r.Next())
+	require.Equal(t, "41", r.String())
+	require.Equal(t, TimeOfRound(3*time.Second, 1000, 41), r.Time(3*time.Second, 1000))
+}
+
+func TestChainHash(t *testing.T) {
+	s := strings.Repeat("ab", 32)
+	h, err := ParseChainHash(s)
+	require.NoError(t, err)
+	require.Equal(t, s, h.String())
+	require.False(t, h.IsZero())
+	require.True(t, ChainHash{}.IsZero())
+
+	fromBytes, err := ChainHashFromBytes(h.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, h, fromBytes)
+	_, err = ChainHashFromBytes(h.Bytes()[1:])
+	require.True(t, errors.Is(err, ErrInvalidChainHash))
+
+	// the hashes are comparable and can key a map
+	hashes := map[ChainHash]string{h: "default"}
+	require.Equal(t, "default", hashes[fromBytes])
+
+	_, err = ParseChainHash("zz")
+	require.True(t, errors.Is(err, ErrInvalidChainHash))
+	_, err = ParseChainHash("abcd")
+	require.True(t, errors.Is(err, ErrInvalidChainHash))
+
+	buff, err := json.Marshal(struct{ Hash ChainHash }{h})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"Hash":"`+s+`"}`, string(buff))
+
+	var decoded struct{ Hash ChainHash }
+	require.NoError(t, json.Unmarshal(buff, &decoded))
+	require.True(t, h.Equal(decoded.Hash))
+	require.Error(t, json.Unmarshal([]byte(`{"Hash":"abcd"}`), &decoded))
+}
@@ -0,0 +1,145 @@
+package key
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// SRVScheme is the scheme of the node addresses resolved through the DNS SRV records of a service name, e.g.
+// srv://_drand._tcp.example.org, rather than being a fixed host:port pair.
+const SRVScheme = "srv"
+
+// maxHostnameLength is the longest DNS name, without its trailing dot
+const maxHostnameLength = 253
+
+// ErrInvalidAddress is returned for the node addresses which are neither a host:port pair nor a SRV service name
+var ErrInvalidAddress = errors.New("invalid address")
+
+// IsSRVAddress returns true if the given node address is a service name to resolve through DNS SRV records
+func IsSRVAddress(addr string) bool {
+	return strings.HasPrefix(addr, SRVScheme+"://")
+}
+
+// ValidateAddress checks that the given node address is either a host:port pair, the host being an IP address or
+// a DNS name and the port a number, or a SRV service name. The address doesn't need to be normalized.
+func ValidateAddress(addr string) error {
+	_, err := parseAddress(addr)
+	return err
+}
+
+// NormalizeAddress checks the given node address like ValidateAddress does and returns its normal form: without
+// surrounding spaces, with the IP addresses in their canonical form and the ports without leading zeros, e.g.
+// "[::ffff:10.0.0.1]:0443" becomes "10.0.0.1:443". The DNS names are kept as they are.
+func NormalizeAddress(addr string) (string, error) {
+	return parseAddress(strings.TrimSpace(addr))
+}
+
+// normalizeStoredAddress returns the normal form of an address read from disk. The addresses accepted before they
+// were validated strictly, e.g. an internationalized host name or an IPv6 address with a zone, are kept as they are,
+// so that the nodes keep loading the groups and the keys they stored.
+func normalizeStoredAddress(addr string) (string, error) {
+	normalized, err := NormalizeAddress(addr)
+	if err == nil {
+		return normalized, nil
+	}
+	if IsSRVAddress(addr) {
+		if strings.TrimPrefix(addr, SRVScheme+"://") == "" {
+			return "", err
+		}
+		return addr, nil
+	}
+	if _, _, splitErr := net.SplitHostPort(addr); splitErr != nil {
+		return "", err
+	}
+	return addr, nil
+}
+
+// parseAddress returns the normal form of a valid address
+func parseAddress(addr string) (string, error) {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w %q: %s", ErrInvalidAddress, addr, fmt.Sprintf(format, args...))
+	}
+
+	switch {
+	case addr == "":
+		return "", fmt.Errorf("%w: empty address", ErrInvalidAddress)
+	case strings.ContainsAny(addr, " \t\r\n"):
+		return "", invalid("it contains spaces")
+	case IsSRVAddress(addr):
+		name := strings.TrimPrefix(addr, SRVScheme+"://")
+		if name == "" {
+			return "", invalid("missing service name")
+		}
+		if err := validateHostname(name); err != nil {
+			return "", invalid("invalid service name: %v", err)
+		}
+		return addr, nil
+	case strings.Contains(addr, "://"):
+		return "", invalid("expected host:port without a scheme")
+	case strings.ContainsAny(addr, "/?#@"):
+		return "", invalid("expected host:port without a path, a query or credentials")
+	}
+
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", invalid("expected host:port")
+	}
+	portNumber, err := strconv.ParseUint(port, 10, 16)
+	if err != nil || portNumber == 0 {
+		return "", invalid("the port must be a number from 1 to 65535")
+	}
+	port = strconv.FormatUint(portNumber, 10)
+
+	if host == "" {
+		return "", invalid("missing host")
+	}
+	if ip, err := netip.ParseAddr(host); err == nil {
+		if ip.Zone() != "" {
+			return "", invalid("IPv6 zones aren't supported")
+		}
+		return net.JoinHostPort(ip.Unmap().String(), port), nil
+	}
+	if strings.Contains(host, ":") {
+		return "", invalid("invalid IPv6 address")
+	}
+	if err := validateHostname(host); err != nil {
+		return "", invalid("%v", err)
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// validateHostname checks that the host is a DNS name. The underscores are allowed for the service names and the
+// names of containers.
+func validateHostname(host string) error {
+	name := strings.TrimSuffix(host, ".")
+	if len(name) > maxHostnameLength {
+		return fmt.Errorf("the host name is longer than %d characters", maxHostnameLength)
+	}
+
+	numeric := true
+	for _, label := range strings.Split(name, ".") {
+		if label == "" || len(label) > 63 {
+			return errors.New("the labels of the host name must be 1 to 63 characters long")
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return fmt.Errorf("the label %q of the host name starts or ends with a hyphen", label)
+		}
+		for _, c := range label {
+			switch {
+			case c >= '0' && c <= '9':
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '-', c == '_':
+				numeric = false
+			default:
+				return fmt.Errorf("the host name contains %q", c)
+			}
+		}
+	}
+	if numeric {
+		return errors.New("invalid IP address")
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package key
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAddress(t *testing.T) {
+	for _, addr := range []string{
+		"127.0.0.1:4444",
+		"drand.example.org:443",
+		"drand.example.org.:443",
+		"drand_node_1:8080",
+		"[2001:db8::1]:443",
+		"srv://_drand._tcp.example.org",
+	} {
+		require.NoError(t, ValidateAddress(addr), addr)
+	}
+
+	for _, addr := range []string{
+		"",
+		"drand.example.org",
+		"https://drand.example.org:443",
+		"drand.example.org:443/public",
+		"user@drand.example.org:443",
+		"drand.example.org:https",
+		"drand.example.org:0",
+		"drand.example.org:70000",
+		":443",
+		" drand.example.org:443",
+		"drand..example.org:443",
+		"-drand.example.org:443",
+		"drand!.example.org:443",
+		"256.0.0.1:443",
+		"2001:db8::1:443",
+		"[fe80::1%eth0]:443",
+		strings.Repeat("a.", 127) + "org:443",
+		"srv://",
+		"srv://_drand._tcp.example.org/path",
+	} {
+		err := ValidateAddress(addr)
+		require.ErrorIs(t, err, ErrInvalidAddress, addr)
+	}
+}
+
+func TestNormalizeAddress(t *testing.T) {
+	for addr, normal := range map[string]string{
+		"127.0.0.1:4444":                  "127.0.0.1:4444",
+		" drand.example.org:0443\n":       "drand.example.org:443",
+		"Drand.Example.org:443":           "Drand.Example.org:443",
+		"[2001:0db8:0:0::1]:443":          "[2001:db8::1]:443",
+		"[::ffff:10.0.0.1]:443":           "10.0.0.1:443",
+		"srv://_drand._tcp.example.org":   "srv://_drand._tcp.example.org",
+		"\tsrv://_drand._tcp.example.org": "srv://_drand._tcp.example.org",
+	} {
+		got, err := NormalizeAddress(addr)
+		require.NoError(t, err, addr)
+		require.Equal(t, normal, got, addr)
+	}
+
+	_, err := NormalizeAddress("https://drand.example.org:443")
+	require.ErrorContains(t, err, "without a scheme")
+}
+
+func TestNormalizeStoredAddress(t *testing.T) {
+	for addr, normal := range map[string]string{
+		"[::ffff:10.0.0.1]:0443":       "10.0.0.1:443",
+		"bücher.example.org:443":       "bücher.example.org:443",
+		"[fe80::1%eth0]:443":           "[fe80::1%eth0]:443",
+		"srv://_drand._tcp.bücher.org": "srv://_drand._tcp.bücher.org",
+	} {
+		got, err := normalizeStoredAddress(addr)
+		require.NoError(t, err, addr)
+		require.Equal(t, normal, got, addr)
+	}
+
+	for _, addr := range []string{"", "drand.example.org", "srv://"} {
+		_, err := normalizeStoredAddress(addr)
+		require.ErrorIs(t, err, ErrInvalidAddress, addr)
+	}
+}
@@ -0,0 +1,145 @@
+package key
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/kyber"
+)
+
+// ShareDestruction attests that a node destroyed its share of the distributed key. It is signed with the longterm
+// key of the node and commits to the destroyed share through its public commitment: the evaluation of the public
+// polynomial at the index of the share, which any member of the group can recompute from its group file.
+type ShareDestruction struct {
+	BeaconID   string
+	Address    string
+	Index      Index
+	Commitment kyber.Point
+	// Timestamp is the UNIX time at which the share was destroyed
+	Timestamp int64
+	Signature []byte
+	Scheme    *crypto.Scheme
+}
+
+// NewShareDestruction returns the attestation of the destruction of the given share at the given time, signed with
+// the given longterm key pair.
+func NewShareDestruction(beaconID common.BeaconID, pair *Pair, s *Share, at time.Time) (*ShareDestruction, error) {
+	if s.Share.I < 0 {
+		return nil, fmt.Errorf("invalid share index %d", s.Share.I)
+	}
+	d := &ShareDestruction{
+		BeaconID:   beaconID.String(),
+		Address:    pair.Public.Address(),
+		Index:      Index(s.Share.I),
+		Commitment: s.PubPoly().Eval(s.Share.I).V,
+		Timestamp:  at.Unix(),
+		Scheme:     pair.Scheme(),
+	}
+	sig, err := d.Scheme.AuthScheme.Sign(pair.Key, d.message())
+	if err != nil {
+		return nil, err
+	}
+	d.Signature = sig
+	return d, nil
+}
+
+// Verify checks that the attestation was signed by the node at its address in the given group, and that it
+// commits to the share this node held in that group.
+func (d *ShareDestruction) Verify(group *Group) error {
+	if group.PublicKey == nil {
+		return errors.New("the group file doesn't contain a distributed public key")
+	}
+	var node *Node
+	for _, n := range group.Nodes {
+		if n.Address() == d.Address {
+			node = n
+			break
+		}
+	}
+	if node == nil {
+		return fmt.Errorf("%s is not part of the group", d.Address)
+	}
+	if node.Index != d.Index {
+		return fmt.Errorf("%s has index %d in the group, not %d", d.Address, node.Index, d.Index)
+	}
+
+	expected := group.PublicKey.PubPoly(group.Scheme).Eval(int(d.Index)).V
+	if !expected.Equal(d.Commitment) {
+		return errors.New("the commitment doesn't match the share of the node in the group")
+	}
+	if err := group.Scheme.AuthScheme.Verify(node.Key, d.message(), d.Signature); err != nil {
+		return fmt.Errorf("invalid destruction signature: %w", err)
+	}
+	return nil
+}
+
+func (d *ShareDestruction) message() []byte {
+	msg := []byte("drand-share-destruction:" + d.BeaconID + ":" + d.Address + ":")
+	msg = binary.BigEndian.AppendUint32(msg, d.Index)
+	commitment, _ := d.Commitment.MarshalBinary()
+	msg = append(msg, commitment...)
+	return binary.BigEndian.AppendUint64(msg, uint64(d.Timestamp))
+}
+
+// ShareDestructionTOML is the TOML representation of a ShareDestruction
+type ShareDestructionTOML struct {
+	BeaconID   string
+	Address    string
+	Index      Index
+	Commitment string
+	Timestamp  int64
+	Signature  string
+	SchemeName string
+}
+
+// TOML returns a TOML-compatible version of this attestation
+func (d *ShareDestruction) TOML() interface{} {
+	return &ShareDestructionTOML{
+		BeaconID:   d.BeaconID,
+		Address:    d.Address,
+		Index:      d.Index,
+		Commitment: PointToString(d.Commitment),
+		Timestamp:  d.Timestamp,
+		Signature:  hex.EncodeToString(d.Signature),
+		SchemeName: d.Scheme.Name,
+	}
+}
+
+// FromTOML initializes the attestation from the given TOML-compatible interface
+func (d *ShareDestruction) FromTOML(i interface{}) error {
+	t, ok := i.(*ShareDestructionTOML)
+	if !ok {
+		return errors.New("invalid struct received for share destruction")
+	}
+	sch, err := crypto.GetSchemeByID(t.SchemeName)
+	if err != nil {
+		return err
+	}
+	commitment, err := StringToPoint(sch.KeyGroup, t.Commitment)
+	if err != nil {
+		return fmt.Errorf("share destruction commitment corrupted: %w", err)
+	}
+	sig, err := hex.DecodeString(t.Signature)
+	if err != nil {
+		return fmt.Errorf("share destruction signature corrupted: %w", err)
+	}
+
+	d.BeaconID = t.BeaconID
+	d.Address = t.Address
+	d.Index = t.Index
+	d.Commitment = commitment
+	d.Timestamp = t.Timestamp
+	d.Signature = sig
+	d.Scheme = sch
+	return nil
+}
+
+// TOMLValue returns an empty TOML-compatible interface of a ShareDestruction
+func (d *ShareDestruction) TOMLValue() interface{} {
+	return &ShareDestructionTOML{}
+}
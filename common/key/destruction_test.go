@@ -0,0 +1,53 @@
+package key
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/drand/kyber"
+	"github.com/drand/kyber/share"
+	"github.com/drand/kyber/share/dkg"
+	"github.com/drand/kyber/util/random"
+)
+
+func TestShareDestruction(t *testing.T) {
+	ps, group := BatchIdentities(t, 3)
+	sch := group.Scheme
+	priPoly := share.NewPriPoly(sch.KeyGroup, group.Threshold, nil, random.New())
+	_, commits := priPoly.Commit(sch.KeyGroup.Point().Base()).Info()
+	group.PublicKey = &DistPublic{Coefficients: commits}
+
+	s := &Share{
+		DistKeyShare: dkg.DistKeyShare{
+			Commits: commits,
+			Share:   priPoly.Eval(1),
+		},
+		Scheme: sch,
+	}
+	d, err := NewShareDestruction("default", ps[1], s, time.Unix(1700000000, 0))
+	require.NoError(t, err)
+	require.NoError(t, d.Verify(group))
+
+	// the attestation survives a round trip through its TOML representation
+	d2 := new(ShareDestruction)
+	require.NoError(t, d2.FromTOML(d.TOML()))
+	require.NoError(t, d2.Verify(group))
+	require.Equal(t, d.Timestamp, d2.Timestamp)
+
+	// tampering with the timestamp invalidates the signature
+	d2.Timestamp++
+	require.Error(t, d2.Verify(group))
+
+	// another node can't claim the destruction of that share
+	d3, err := NewShareDestruction("default", ps[2], s, time.Unix(1700000000, 0))
+	require.NoError(t, err)
+	require.Error(t, d3.Verify(group))
+
+	// nor can a node attest the destruction of a share it didn't hold
+	s.Commits = []kyber.Point{sch.KeyGroup.Point().Pick(random.New())}
+	d4, err := NewShareDestruction("default", ps[1], s, time.Unix(1700000000, 0))
+	require.NoError(t, err)
+	require.Error(t, d4.Verify(group))
+}
@@ -101,6 +101,11 @@ func (g *Group) DKGNodes() []dkg.Node {
 	return dnodes
 }
 
+// BeaconID returns the canonical id of the beacon of the group
+func (g *Group) BeaconID() common2.BeaconID {
+	return common2.BeaconID(g.ID).Canonical()
+}
+
 // Hash provides a compact hash of a group
 func (g *Group) Hash() []byte {
 	h := hashFunc()
@@ -338,7 +343,7 @@ func (g *Group) TOMLValue() interface{} {
 // key.
 // Note: only used in tests
 func LoadGroup(list []*Node, genesis int64, public *DistPublic, period time.Duration,
-	transition int64, sch *crypto.Scheme, beaconID string) *Group {
+	transition int64, sch *crypto.Scheme, beaconID common2.BeaconID) *Group {
 	return &Group{
 		Nodes:          list,
 		Threshold:      len(public.Coefficients),
@@ -348,7 +353,7 @@ func LoadGroup(list []*Node, genesis int64, public *DistPublic, period time.Dura
 		GenesisTime:    genesis,
 		TransitionTime: transition,
 		Scheme:         sch,
-		ID:             beaconID,
+		ID:             beaconID.String(),
 	}
 }
 
@@ -482,3 +487,49 @@ func (g *Group) UnsignedIdentities() []*Node {
 	}
 	return unsigned
 }
+
+// GroupDiff describes how the membership and parameters of a group changed from one group file to the next.
+type GroupDiff struct {
+	// Added contains the nodes of the new group that were not part of the old one
+	Added []*Node
+	// Removed contains the nodes of the old group that are not part of the new one
+	Removed []*Node
+	// OldThreshold and NewThreshold are the thresholds of the respective groups
+	OldThreshold int
+	NewThreshold int
+	// OldCatchupPeriod and NewCatchupPeriod are the catchup periods of the respective groups
+	OldCatchupPeriod time.Duration
+	NewCatchupPeriod time.Duration
+}
+
+// ThresholdChanged returns true if the two groups do not share the same threshold
+func (d *GroupDiff) ThresholdChanged() bool {
+	return d.OldThreshold != d.NewThreshold
+}
+
+// CatchupPeriodChanged returns true if the two groups do not share the same catchup period
+func (d *GroupDiff) CatchupPeriodChanged() bool {
+	return d.OldCatchupPeriod != d.NewCatchupPeriod
+}
+
+// Diff computes the changes required to go from the group g to the group next.
+// Nodes are matched by address and public key, regardless of their index.
+func (g *Group) Diff(next *Group) *GroupDiff {
+	diff := GroupDiff{
+		OldThreshold:     g.Threshold,
+		NewThreshold:     next.Threshold,
+		OldCatchupPeriod: g.CatchupPeriod,
+		NewCatchupPeriod: next.CatchupPeriod,
+	}
+	for _, n := range next.Nodes {
+		if g.Find(n.Identity) == nil {
+			diff.Added = append(diff.Added, n)
+		}
+	}
+	for _, n := range g.Nodes {
+		if next.Find(n.Identity) == nil {
+			diff.Removed = append(diff.Removed, n)
+		}
+	}
+	return &diff
+}
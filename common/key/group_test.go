@@ -166,6 +166,19 @@ func TestGroupSaveLoad(t *testing.T) {
 	require.Equal(t, transition, loaded.TransitionTime)
 
 	require.Equal(t, group.Hash(), loaded.Hash())
+
+	// the addresses are normalized on load, and the malformed ones are reported with the file
+	group.Nodes[0].Addr = "[::ffff:10.0.0.1]:0443"
+	require.NoError(t, Save(groupPath, group, false))
+	require.NoError(t, Load(groupPath, loaded))
+	require.Equal(t, "10.0.0.1:443", loaded.Nodes[0].Addr)
+	require.Equal(t, group.Hash(), loaded.Hash())
+
+	group.Nodes[0].Addr = "10.0.0.1"
+	require.NoError(t, Save(groupPath, group, false))
+	err = Load(groupPath, &Group{})
+	require.ErrorIs(t, err, ErrInvalidAddress)
+	require.ErrorContains(t, err, groupPath)
 }
 
 // BatchIdentities generates n identities
@@ -281,3 +294,24 @@ ID = "default"
 	// even though there are 12 indexes, we expect the len to be 10 as some are missing
 	require.Equal(t, 8, g.Len())
 }
+
+func TestGroupDiff(t *testing.T) {
+	ids := newIds(t, 5)
+	old := &Group{Threshold: 2, CatchupPeriod: time.Second, Nodes: ids[:3]}
+	// the nodes kept are re-indexed, which shouldn't count as a change
+	kept := &Node{Identity: ids[1].Identity, Index: 0}
+	next := &Group{Threshold: 3, CatchupPeriod: time.Second, Nodes: []*Node{kept, ids[2], ids[3], ids[4]}}
+
+	diff := old.Diff(next)
+	require.Equal(t, []*Node{ids[3], ids[4]}, diff.Added)
+	require.Equal(t, []*Node{ids[0]}, diff.Removed)
+	require.True(t, diff.ThresholdChanged())
+	require.Equal(t, 2, diff.OldThreshold)
+	require.Equal(t, 3, diff.NewThreshold)
+	require.False(t, diff.CatchupPeriodChanged())
+
+	same := old.Diff(old)
+	require.Empty(t, same.Added)
+	require.Empty(t, same.Removed)
+	require.False(t, same.ThresholdChanged())
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
-	"net"
 
 	"github.com/drand/drand/v2/crypto"
 	proto "github.com/drand/drand/v2/protobuf/drand"
@@ -175,7 +174,10 @@ func (i *Identity) FromTOML(t interface{}) error {
 	if err != nil {
 		return fmt.Errorf("decoding public key: %w", err)
 	}
-	i.Addr = ptoml.Address
+	i.Addr, err = normalizeStoredAddress(ptoml.Address)
+	if err != nil {
+		return err
+	}
 	if ptoml.Signature != "" {
 		i.Signature, err = hex.DecodeString(ptoml.Signature)
 	}
@@ -233,8 +235,7 @@ type protoIdentity interface {
 // IdentityFromProto creates an identity from its wire representation and
 // verifies it validity.
 func IdentityFromProto(n protoIdentity, targetScheme *crypto.Scheme) (*Identity, error) {
-	_, _, err := net.SplitHostPort(n.GetAddress())
-	if err != nil {
+	if err := ValidateAddress(n.GetAddress()); err != nil {
 		return nil, err
 	}
 	if targetScheme == nil {
@@ -0,0 +1,136 @@
+package key
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/drand/drand/v2/crypto"
+)
+
+// MnemonicWords is the number of words of the mnemonics generated, encoding 256 bits of entropy
+const MnemonicWords = 24
+
+// mnemonicIdentityDomain separates the identity keys derived from a mnemonic from any other key derived from it
+const mnemonicIdentityDomain = "drand-identity:"
+
+//go:embed mnemonic_english.txt
+var mnemonicWordlist string
+
+// mnemonicWords is the BIP39 English wordlist, and mnemonicIndex the index of each of its words
+var mnemonicWords, mnemonicIndex = func() ([]string, map[string]int) {
+	words := strings.Fields(mnemonicWordlist)
+	index := make(map[string]int, len(words))
+	for i, w := range words {
+		index[w] = i
+	}
+	return words, index
+}()
+
+// NewMnemonic returns a fresh BIP39 mnemonic, from which the identity key pair of a node can be derived again and
+// again with NewKeyPairFromMnemonic
+func NewMnemonic() (string, error) {
+	entropy := make([]byte, MnemonicWords*11*32/33/8)
+	if _, err := io.ReadFull(rand.Reader, entropy); err != nil {
+		return "", err
+	}
+	return entropyToMnemonic(entropy), nil
+}
+
+// entropyToMnemonic encodes the entropy as words of 11 bits each, the last bits being a checksum of the entropy
+func entropyToMnemonic(entropy []byte) string {
+	checksumBits := len(entropy) * 8 / 32
+	digest := sha256.Sum256(entropy)
+
+	n := new(big.Int).SetBytes(entropy)
+	n.Lsh(n, uint(checksumBits))
+	n.Or(n, big.NewInt(int64(digest[0]>>(8-checksumBits))))
+
+	words := make([]string, (len(entropy)*8+checksumBits)/11)
+	mask := big.NewInt(1<<11 - 1)
+	for i := len(words) - 1; i >= 0; i-- {
+		words[i] = mnemonicWords[new(big.Int).And(n, mask).Int64()]
+		n.Rsh(n, 11)
+	}
+	return strings.Join(words, " ")
+}
+
+// ValidateMnemonic checks that the mnemonic is made of words of the BIP39 English wordlist, and that its checksum is
+// correct, to catch the typos made when writing it down or typing it back
+func ValidateMnemonic(mnemonic string) error {
+	words := strings.Fields(mnemonic)
+	switch len(words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return fmt.Errorf("a mnemonic has 12, 15, 18, 21 or 24 words, not %d", len(words))
+	}
+
+	n := new(big.Int)
+	for _, w := range words {
+		i, ok := mnemonicIndex[strings.ToLower(w)]
+		if !ok {
+			return fmt.Errorf("%q isn't a mnemonic word", w)
+		}
+		n.Lsh(n, 11)
+		n.Or(n, big.NewInt(int64(i)))
+	}
+
+	checksumBits := len(words) * 11 / 33
+	checksum := new(big.Int).And(n, big.NewInt(1<<checksumBits-1)).Int64()
+	entropy := n.Rsh(n, uint(checksumBits)).FillBytes(make([]byte, checksumBits*32/8))
+	digest := sha256.Sum256(entropy)
+	if int64(digest[0]>>(8-checksumBits)) != checksum {
+		return errors.New("invalid mnemonic checksum")
+	}
+	return nil
+}
+
+// NewKeyPairFromMnemonic derives the identity key pair of a node from its mnemonic, so that it can be recovered from
+// the written down words if the key files are lost. The seed of the mnemonic is derived as in BIP39, and the key from
+// the seed with HKDF, using the name of the scheme as context: the same mnemonic gives unrelated keys for each scheme.
+// The shares can't be recovered this way, they are only known after a DKG.
+func NewKeyPairFromMnemonic(mnemonic, address string, targetScheme *crypto.Scheme) (*Pair, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	if targetScheme == nil {
+		var err error
+		targetScheme, err = crypto.GetSchemeFromEnv()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	normalized := strings.ToLower(strings.Join(strings.Fields(mnemonic), " "))
+	seed := pbkdf2.Key([]byte(normalized), []byte("mnemonic"), 2048, 64, sha512.New)
+	// we read twice the size of the scalars so that the bias of the reduction modulo the group order is negligible
+	kdf := hkdf.New(sha256.New, seed, nil, []byte(mnemonicIdentityDomain+targetScheme.Name))
+	buff := make([]byte, 2*targetScheme.KeyGroup.ScalarLen())
+	if _, err := io.ReadFull(kdf, buff); err != nil {
+		return nil, err
+	}
+	key := targetScheme.KeyGroup.Scalar().SetBytes(buff)
+	if key.Equal(targetScheme.KeyGroup.Scalar().Zero()) {
+		return nil, errors.New("invalid key derived from the mnemonic")
+	}
+
+	p := &Pair{
+		Key: key,
+		Public: &Identity{
+			Key:    targetScheme.KeyGroup.Point().Mul(key, nil),
+			Addr:   address,
+			Scheme: targetScheme,
+		},
+	}
+	err := p.SelfSign()
+	return p, err
+}
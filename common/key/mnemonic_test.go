@@ -0,0 +1,71 @@
+package key
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/drand/drand/v2/crypto"
+)
+
+func TestMnemonicEncoding(t *testing.T) {
+	// vectors from the BIP39 reference implementation
+	vectors := []struct {
+		entropy  []byte
+		mnemonic string
+	}{
+		{bytes.Repeat([]byte{0x00}, 16), strings.Repeat("abandon ", 11) + "about"},
+		{bytes.Repeat([]byte{0x7f}, 16), "legal winner thank year wave sausage worth useful legal winner thank yellow"},
+		{bytes.Repeat([]byte{0x80}, 16), "letter advice cage absurd amount doctor acoustic avoid letter advice cage above"},
+		{bytes.Repeat([]byte{0xff}, 16), strings.Repeat("zoo ", 11) + "wrong"},
+		{bytes.Repeat([]byte{0x00}, 32), strings.Repeat("abandon ", 23) + "art"},
+		{bytes.Repeat([]byte{0xff}, 32), strings.Repeat("zoo ", 23) + "vote"},
+	}
+	for _, v := range vectors {
+		require.Equal(t, v.mnemonic, entropyToMnemonic(v.entropy))
+		require.NoError(t, ValidateMnemonic(v.mnemonic))
+	}
+
+	m, err := NewMnemonic()
+	require.NoError(t, err)
+	require.Len(t, strings.Fields(m), MnemonicWords)
+	require.NoError(t, ValidateMnemonic(m))
+
+	require.Error(t, ValidateMnemonic(strings.Repeat("abandon ", 12)))
+	require.Error(t, ValidateMnemonic(strings.Repeat("abandon ", 10)+"about"))
+	require.Error(t, ValidateMnemonic(strings.Repeat("abandon ", 11)+"drand"))
+}
+
+func TestKeyPairFromMnemonic(t *testing.T) {
+	m, err := NewMnemonic()
+	require.NoError(t, err)
+
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	kp, err := NewKeyPairFromMnemonic(m, testAddr, sch)
+	require.NoError(t, err)
+	require.NoError(t, kp.Public.ValidSignature())
+
+	// the key doesn't depend on the address nor on the formatting of the words
+	recovered, err := NewKeyPairFromMnemonic("  "+strings.ToUpper(strings.ReplaceAll(m, " ", "\n"))+"\n", "127.0.0.1:81", sch)
+	require.NoError(t, err)
+	require.True(t, kp.Key.Equal(recovered.Key))
+	require.True(t, kp.Public.Key.Equal(recovered.Public.Key))
+
+	// but it does on the scheme
+	for _, name := range crypto.ListSchemes() {
+		other, err := crypto.SchemeFromName(name)
+		require.NoError(t, err)
+		if other.Name == sch.Name {
+			continue
+		}
+		kp2, err := NewKeyPairFromMnemonic(m, testAddr, other)
+		require.NoError(t, err)
+		require.False(t, kp.Key.Equal(kp2.Key))
+	}
+
+	_, err = NewKeyPairFromMnemonic(strings.Repeat("abandon ", 12), testAddr, sch)
+	require.Error(t, err)
+}
@@ -28,6 +28,8 @@ type Store interface {
 	SaveGroup(*Group) error
 	LoadGroup() (*Group, error)
 	Reset() error
+	// WipeShare overwrites the private share before deleting it, keeping the group file
+	WipeShare() error
 	TestWrite() error
 }
 
@@ -42,6 +44,15 @@ const publicExtension = ".public"
 const groupFileName = "drand_group.toml"
 const shareFileName = "dist_key.private"
 
+// PrivateFiles returns the paths of the files of the file store of the given beacon holding private material: the
+// private key and the share, which may not exist yet
+func PrivateFiles(baseFolder string, beaconID common.BeaconID) []string {
+	return []string{
+		path.Join(baseFolder, beaconID.String(), FolderName, keyFileName) + privateExtension,
+		path.Join(baseFolder, beaconID.String(), GroupFolderName, shareFileName),
+	}
+}
+
 // Tomler represents any struct that can be (un)marshaled into/from toml format
 type Tomler interface {
 	TOML() interface{}
@@ -70,7 +81,7 @@ func NewFileStores(baseFolder string) (map[string]Store, error) {
 
 	for _, f := range fi {
 		if f.IsDir() {
-			fileStores[f.Name()] = NewFileStore(baseFolder, f.Name())
+			fileStores[f.Name()] = NewFileStore(baseFolder, common.BeaconID(f.Name()))
 		}
 	}
 
@@ -89,13 +100,13 @@ func NewFileStores(baseFolder string) (map[string]Store, error) {
 
 // NewFileStore is used to create the config folder and all the subfolders.
 // If a folder already exists, we simply check the rights
-func NewFileStore(baseFolder, beaconID string) Store {
-	beaconID = common.GetCanonicalBeaconID(beaconID)
+func NewFileStore(baseFolder string, beaconID common.BeaconID) Store {
+	id := beaconID.Canonical().String()
 
-	store := &fileStore{baseFolder: baseFolder, beaconID: beaconID}
+	store := &fileStore{baseFolder: baseFolder, beaconID: id}
 
-	keyFolder := fs.CreateSecureFolder(path.Join(baseFolder, beaconID, FolderName))
-	groupFolder := fs.CreateSecureFolder(path.Join(baseFolder, beaconID, GroupFolderName))
+	keyFolder := fs.CreateSecureFolder(path.Join(baseFolder, id, FolderName))
+	groupFolder := fs.CreateSecureFolder(path.Join(baseFolder, id, GroupFolderName))
 
 	store.privateKeyFile = path.Join(keyFolder, keyFileName) + privateExtension
 	store.publicKeyFile = path.Join(keyFolder, keyFileName) + publicExtension
@@ -177,6 +188,13 @@ func (f *fileStore) Reset() error {
 	return nil
 }
 
+func (f *fileStore) WipeShare() error {
+	if err := fs.SecureDelete(f.shareFile); err != nil {
+		return fmt.Errorf("drand: err wiping share file: %w", err)
+	}
+	return nil
+}
+
 // Save the given Tomler interface to the given path. If secure is true, the
 // file will have a 0700 security.
 // TODO: move that to fs/
@@ -202,7 +220,10 @@ func Load(filePath string, t Tomler) error {
 	if _, err = toml.DecodeFile(filePath, tomlValue); err != nil {
 		return err
 	}
-	return t.FromTOML(tomlValue)
+	if err := t.FromTOML(tomlValue); err != nil {
+		return fmt.Errorf("loading %s: %w", filePath, err)
+	}
+	return nil
 }
 
 // Delete the resource denoted by the given path. If it is a file, it deletes
@@ -21,7 +21,7 @@ func TestKeysSaveLoad(t *testing.T) {
 
 	tmp := path.Join(t.TempDir(), "drand-key")
 
-	store := NewFileStore(tmp, beaconID).(*fileStore)
+	store := NewFileStore(tmp, commonutils.BeaconID(beaconID)).(*fileStore)
 	require.Equal(t, tmp, store.baseFolder)
 
 	// test loading saving private public key
@@ -80,9 +80,9 @@ func TestTwoStores(t *testing.T) {
 
 	tmp := path.Join(t.TempDir(), "drand-key-2")
 
-	store1 := NewFileStore(tmp, beaconID).(*fileStore)
+	store1 := NewFileStore(tmp, commonutils.BeaconID(beaconID)).(*fileStore)
 	require.Equal(t, tmp, store1.baseFolder)
-	store2 := NewFileStore(tmp, beaconID+"2").(*fileStore)
+	store2 := NewFileStore(tmp, commonutils.BeaconID(beaconID+"2")).(*fileStore)
 	require.Equal(t, tmp, store2.baseFolder)
 
 	stores, err := NewFileStores(tmp)
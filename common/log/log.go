@@ -3,7 +3,9 @@ package log
 import (
 	"context"
 	"os"
+	"strings"
 	"sync"
+	"sync/atomic"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -12,6 +14,8 @@ import (
 // log is the implementation of Logger
 type log struct {
 	*zap.SugaredLogger
+	// the levels shared by the loggers derived from the same one, nil if they can't be changed
+	levels *levels
 }
 
 // Logger is an interface that can log to different levels.
@@ -36,15 +40,15 @@ type Logger interface {
 }
 
 func (l *log) AddCallerSkip(skip int) Logger {
-	return &log{l.WithOptions(zap.AddCallerSkip(skip))}
+	return &log{l.WithOptions(zap.AddCallerSkip(skip)), l.levels}
 }
 
 func (l *log) With(args ...interface{}) Logger {
-	return &log{l.SugaredLogger.With(args...)}
+	return &log{l.SugaredLogger.With(args...), l.levels}
 }
 
 func (l *log) Named(s string) Logger {
-	return &log{l.SugaredLogger.Named(s)}
+	return &log{l.SugaredLogger.Named(s), l.levels}
 }
 
 const (
@@ -56,6 +60,17 @@ const (
 	WarnLevel  = int(zapcore.WarnLevel)
 )
 
+// ParseLevel returns the level of the given name, e.g. debug or info
+func ParseLevel(name string) (int, error) {
+	level, err := zapcore.ParseLevel(name)
+	return int(level), err
+}
+
+// LevelName returns the name of the level, e.g. debug or info
+func LevelName(level int) string {
+	return zapcore.Level(level).String()
+}
+
 // DefaultLevel is the default level where statements are logged. Change the
 // value of this variable before init() to change the level of the default
 // logger.
@@ -79,16 +94,18 @@ func ConfigureDefaultLogger(output zapcore.WriteSyncer, level int, jsonFormat bo
 	if jsonFormat {
 		encoder = getJSONEncoder()
 	}
-	zap.ReplaceGlobals(newZapLogger(output, encoder, level))
+	logger, _ := newZapLogger(output, encoder, level)
+	zap.ReplaceGlobals(logger)
 }
 
 // DefaultLogger is the default logger that only logs at the `DefaultLevel`.
 func DefaultLogger() Logger {
 	isDefaultLoggerSet.Do(func() {
-		zap.ReplaceGlobals(newZapLogger(nil, getJSONEncoder(), DefaultLevel))
+		logger, _ := newZapLogger(nil, getJSONEncoder(), DefaultLevel)
+		zap.ReplaceGlobals(logger)
 	})
 
-	return &log{zap.S()}
+	return &log{SugaredLogger: zap.S()}
 }
 
 // New returns a logger that prints statements at the given level.
@@ -97,18 +114,154 @@ func New(output zapcore.WriteSyncer, level int, isJSON bool) Logger {
 	if isJSON {
 		encoder = getJSONEncoder()
 	}
-	l := newZapLogger(output, encoder, level)
-	return &log{l.Sugar()}
+	l, levels := newZapLogger(output, encoder, level)
+	return &log{l.Sugar(), levels}
+}
+
+// SetLevel changes the level of a logger returned by New, and of all the loggers derived from it, except the named
+// ones given their own level with SetNamedLevel. It returns false if the level of the logger can't be changed.
+func SetLevel(l Logger, level int) bool {
+	impl, ok := l.(*log)
+	if !ok || impl.levels == nil {
+		return false
+	}
+	impl.levels.root.SetLevel(zapcore.Level(level))
+	return true
+}
+
+// GetLevel returns the level of a logger returned by New, or false if it isn't known
+func GetLevel(l Logger) (int, bool) {
+	impl, ok := l.(*log)
+	if !ok || impl.levels == nil {
+		return 0, false
+	}
+	return int(impl.levels.root.Level()), true
+}
+
+// SetNamedLevel gives its own level to the loggers derived from the given one with Named(name), e.g. to debug a
+// single subsystem, and to the loggers derived from them in turn unless they are given their own level too.
+// It returns false if the level of the logger can't be changed.
+func SetNamedLevel(l Logger, name string, level int) bool {
+	impl, ok := l.(*log)
+	if !ok || impl.levels == nil {
+		return false
+	}
+	impl.levels.setNamed(name, zapcore.Level(level), true)
+	return true
+}
+
+// ResetNamedLevel makes the loggers given their own level with SetNamedLevel follow the level of their parent again
+func ResetNamedLevel(l Logger, name string) bool {
+	impl, ok := l.(*log)
+	if !ok || impl.levels == nil {
+		return false
+	}
+	impl.levels.setNamed(name, 0, false)
+	return true
+}
+
+// NamedLevels returns the names given their own level with SetNamedLevel, with their level
+func NamedLevels(l Logger) map[string]int {
+	named := make(map[string]int)
+	impl, ok := l.(*log)
+	if !ok || impl.levels == nil {
+		return named
+	}
+	for name, level := range *impl.levels.named.Load() {
+		named[name] = int(level)
+	}
+	return named
+}
+
+// levels are the level of the loggers derived from the same one, and the levels of the named ones which have their
+// own. The named levels are replaced as a whole, so that the logging calls don't take a lock.
+type levels struct {
+	root  zap.AtomicLevel
+	named atomic.Pointer[map[string]zapcore.Level]
+	// serializes the changes of the named levels
+	sync.Mutex
+}
+
+func newLevels(level zapcore.Level) *levels {
+	lv := &levels{root: zap.NewAtomicLevelAt(level)}
+	lv.named.Store(&map[string]zapcore.Level{})
+	return lv
+}
+
+func (lv *levels) setNamed(name string, level zapcore.Level, set bool) {
+	lv.Lock()
+	defer lv.Unlock()
+
+	named := make(map[string]zapcore.Level)
+	for n, l := range *lv.named.Load() {
+		named[n] = l
+	}
+	if set {
+		named[name] = level
+	} else {
+		delete(named, name)
+	}
+	lv.named.Store(&named)
+}
+
+// Enabled tells whether any of the loggers logs at the given level
+func (lv *levels) Enabled(level zapcore.Level) bool {
+	if lv.root.Enabled(level) {
+		return true
+	}
+	for _, l := range *lv.named.Load() {
+		if l.Enabled(level) {
+			return true
+		}
+	}
+	return false
+}
+
+// forLogger returns the level of the logger with the given name, the one of its most specific name with its own
+// level, e.g. "Follow" for the logger named "drand.default.Follow.sync"
+func (lv *levels) forLogger(loggerName string) zapcore.Level {
+	named := *lv.named.Load()
+	if len(named) > 0 && loggerName != "" {
+		parts := strings.Split(loggerName, ".")
+		for i := len(parts) - 1; i >= 0; i-- {
+			if level, ok := named[parts[i]]; ok {
+				return level
+			}
+		}
+	}
+	return lv.root.Level()
+}
+
+// levelsCore filters the entries written by the wrapped core by the level of the logger they are logged with
+type levelsCore struct {
+	zapcore.Core
+	levels *levels
+}
+
+func (c *levelsCore) Enabled(level zapcore.Level) bool {
+	return c.levels.Enabled(level)
+}
+
+func (c *levelsCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelsCore{c.Core.With(fields), c.levels}
+}
+
+func (c *levelsCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.levels.forLogger(ent.LoggerName).Enabled(ent.Level) {
+		return ce
+	}
+	return ce.AddCore(ent, c)
 }
 
-func newZapLogger(output zapcore.WriteSyncer, encoder zapcore.Encoder, level int) *zap.Logger {
+func newZapLogger(output zapcore.WriteSyncer, encoder zapcore.Encoder, level int) (*zap.Logger, *levels) {
 	if output == nil {
 		output = os.Stdout
 	}
 
-	core := zapcore.NewCore(encoder, output, zapcore.Level(level))
+	lv := newLevels(zapcore.Level(level))
+	core := &levelsCore{zapcore.NewCore(encoder, output, zapcore.DebugLevel), lv}
 	logger := zap.New(core, zap.WithCaller(true))
-	return logger
+	return logger, lv
 }
 
 func getJSONEncoder() zapcore.Encoder {
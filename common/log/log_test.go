@@ -105,3 +105,56 @@ func requireContains(t *testing.T, r io.Reader, outs []string, present bool) {
 	}
 	require.NotContains(t, string(out), "Ignored key without a value.")
 }
+
+func TestSetLevel(t *testing.T) {
+	var b bytes.Buffer
+	writer := bufio.NewWriter(&b)
+	logger := New(zapcore.AddSync(writer), InfoLevel, true)
+	named := logger.Named("derived")
+
+	named.Debug("msg=", "hidden")
+	require.True(t, SetLevel(logger, DebugLevel))
+	level, ok := GetLevel(named)
+	require.True(t, ok)
+	require.Equal(t, DebugLevel, level)
+	named.Debug("msg=", "shown")
+	writer.Flush()
+
+	require.NotContains(t, b.String(), "hidden")
+	require.Contains(t, b.String(), "shown")
+	require.False(t, SetLevel(DefaultLogger(), DebugLevel))
+}
+
+func TestSetNamedLevel(t *testing.T) {
+	var b bytes.Buffer
+	writer := bufio.NewWriter(&b)
+	logger := New(zapcore.AddSync(writer), InfoLevel, true)
+	follow := logger.Named("default").Named("Follow")
+	syncLog := follow.Named("sync")
+	dkg := logger.Named("dkg")
+
+	require.True(t, SetNamedLevel(logger, "Follow", DebugLevel))
+	require.Equal(t, map[string]int{"Follow": DebugLevel}, NamedLevels(logger))
+	follow.Debug("msg=", "follow-shown")
+	syncLog.Debug("msg=", "sync-shown")
+	dkg.Debug("msg=", "dkg-hidden")
+
+	// the most specific name with its own level wins
+	require.True(t, SetNamedLevel(logger, "sync", ErrorLevel))
+	syncLog.Warn("msg=", "sync-hidden")
+
+	require.True(t, ResetNamedLevel(logger, "Follow"))
+	require.True(t, ResetNamedLevel(logger, "sync"))
+	require.Empty(t, NamedLevels(logger))
+	follow.Debug("msg=", "follow-hidden")
+	syncLog.Warn("msg=", "sync-back")
+	writer.Flush()
+
+	for _, shown := range []string{"follow-shown", "sync-shown", "sync-back"} {
+		require.Contains(t, b.String(), shown)
+	}
+	for _, hidden := range []string{"dkg-hidden", "sync-hidden", "follow-hidden"} {
+		require.NotContains(t, b.String(), hidden)
+	}
+	require.False(t, SetNamedLevel(DefaultLogger(), "Follow", DebugLevel))
+}
@@ -15,7 +15,7 @@ const maxTimeBuffer = int64(1 << timeBufferBits)
 const TimeOfRoundErrorValue = math.MaxInt64 - maxTimeBuffer
 
 // TimeOfRound is returning the time the current round should happen
-func TimeOfRound(period time.Duration, genesis int64, round uint64) int64 {
+func TimeOfRound(period time.Duration, genesis int64, round Round) int64 {
 	if round == 0 {
 		return genesis
 	}
@@ -28,7 +28,7 @@ func TimeOfRound(period time.Duration, genesis int64, round uint64) int64 {
 		return TimeOfRoundErrorValue
 	}
 	// - 1 because genesis time is for 1st round already
-	delta := (round - 1) * uint64(period.Seconds())
+	delta := (round.Uint64() - 1) * uint64(period.Seconds())
 
 	val := genesis + int64(delta)
 	if val > math.MaxInt64-maxTimeBuffer {
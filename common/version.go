@@ -3,6 +3,8 @@ package common
 import (
 	"fmt"
 	"os"
+	"runtime"
+	"runtime/debug"
 	"strings"
 
 	pbcommon "github.com/drand/drand/v2/protobuf/drand"
@@ -64,6 +66,29 @@ func (v Version) ToProto() *pbcommon.NodeVersion {
 	return &pbcommon.NodeVersion{Minor: v.Minor, Major: v.Major, Patch: v.Patch}
 }
 
+// BuildInfo describes the running binary, reporting v as its version
+func (v Version) BuildInfo() *pbcommon.BuildInfo {
+	commit := COMMIT
+	if commit == "" {
+		// binaries built without our ldflags may still have the VCS info embedded by the go toolchain
+		if info, ok := debug.ReadBuildInfo(); ok {
+			for _, s := range info.Settings {
+				if s.Key == "vcs.revision" {
+					commit = s.Value
+				}
+			}
+		}
+	}
+
+	return &pbcommon.BuildInfo{
+		NodeVersion: v.ToProto(),
+		Commit:      commit,
+		BuildDate:   BUILDDATE,
+		BuildTags:   BUILDTAGS,
+		GoVersion:   runtime.Version(),
+	}
+}
+
 func (v Version) String() string {
 	pre := ""
 	if strings.Contains(BUILDTAGS, "insecure") {
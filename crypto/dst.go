@@ -0,0 +1,59 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/drand/kyber"
+)
+
+// ErrDSTMismatch is returned when a scheme doesn't hash the beacons onto its signature group as the released builds
+// of drand do, e.g. because of a custom build changing its domain separation tag. The partials signed with it can't
+// be verified by the rest of the group.
+var ErrDSTMismatch = errors.New("the domain separation tag of the scheme differs from the released one")
+
+// dstProbe is the message hashed onto the signature group to fingerprint the hash to curve of a scheme
+var dstProbe = []byte("drand domain separation tag probe")
+
+// knownDSTFingerprints are the fingerprints of the schemes of the released builds
+var knownDSTFingerprints = map[string]string{
+	DefaultSchemeID:            "2c02926724325abd",
+	UnchainedSchemeID:          "2c02926724325abd",
+	SigsOnG1ID:                 "a2d86a16806eeeee",
+	ShortSigSchemeID:           "b92b8ed75545724b",
+	BN254UnchainedOnG1SchemeID: "c5fe74c505a6159f",
+}
+
+// DSTFingerprint fingerprints how the scheme actually hashes the beacons onto its signature group, from the point a
+// fixed message is hashed to, so that it covers both the domain separation tag and the hash to curve implementation.
+func (s *Scheme) DSTFingerprint() (string, error) {
+	p, ok := s.SigGroup.Point().(kyber.HashablePoint)
+	if !ok {
+		return "", fmt.Errorf("the signature group of %s can't hash to curve", s.Name)
+	}
+	buff, err := p.Hash(dstProbe).MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	h := sha256.Sum256(buff)
+	return hex.EncodeToString(h[:8]), nil
+}
+
+// CheckDST checks that the scheme hashes the beacons onto its signature group as the released builds do
+func CheckDST(s *Scheme) error {
+	fingerprint, err := s.DSTFingerprint()
+	if err != nil {
+		return err
+	}
+	expected, ok := knownDSTFingerprints[s.Name]
+	if !ok {
+		return fmt.Errorf("no known domain separation tag for scheme %q", s.Name)
+	}
+	if fingerprint != expected {
+		return fmt.Errorf("%w: scheme %q uses %q with fingerprint %s, expected %s", ErrDSTMismatch, s.Name, s.DST,
+			fingerprint, expected)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package crypto_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/drand/drand/v2/crypto"
+)
+
+func TestCheckDST(t *testing.T) {
+	for _, id := range crypto.ListSchemes() {
+		sch, err := crypto.SchemeFromName(id)
+		require.NoError(t, err)
+		require.NoError(t, crypto.CheckDST(sch), id)
+	}
+
+	// a custom build hashing the signatures on G1 with the DST of G2, as the deprecated scheme does
+	sch := crypto.NewPedersenBLSUnchainedG1()
+	sch.SigGroup = crypto.NewPedersenBLSUnchainedSwapped().SigGroup
+	require.ErrorIs(t, crypto.CheckDST(sch), crypto.ErrDSTMismatch)
+
+	sch.Name = "custom-scheme"
+	err := crypto.CheckDST(sch)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, crypto.ErrDSTMismatch)
+}
+
+func TestDSTFingerprint(t *testing.T) {
+	chained, err := crypto.NewPedersenBLSChained().DSTFingerprint()
+	require.NoError(t, err)
+	unchained, err := crypto.NewPedersenBLSUnchained().DSTFingerprint()
+	require.NoError(t, err)
+	g1, err := crypto.NewPedersenBLSUnchainedG1().DSTFingerprint()
+	require.NoError(t, err)
+	swapped, err := crypto.NewPedersenBLSUnchainedSwapped().DSTFingerprint()
+	require.NoError(t, err)
+
+	// the schemes signing on G2 share the RFC9380 DST, the ones signing on G1 only differ by their DST
+	require.Equal(t, chained, unchained)
+	require.NotEqual(t, g1, swapped)
+}
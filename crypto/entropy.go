@@ -0,0 +1,40 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// entropyDomain separates the bytes derived from the beacons for the entropy streams from any other use of their
+// randomness
+const entropyDomain = "drand-entropy-stream-v1"
+
+// MaxEntropyPerRound is the most bytes that can be derived from a single beacon, the output limit of HKDF-SHA256
+const MaxEntropyPerRound = 255 * sha256.Size
+
+// DeriveEntropy derives n bytes from the randomness of a round of a chain, for the entropy streams. It is
+// HKDF-SHA256 (RFC 5869) with the randomness as the input key material, the chain hash as the salt, and the string
+// "drand-entropy-stream-v1" followed by the round as a big-endian uint64 as the info. The bytes of different chains
+// and rounds are thus independent, and differ from the randomness itself.
+//
+// The bytes are public: anyone can derive them from the beacon. They're meant to be mixed with a local source of
+// entropy, e.g. to seed a VM, never to be used alone as a secret.
+func DeriveEntropy(chainHash []byte, round uint64, randomness []byte, n int) ([]byte, error) {
+	if n <= 0 || n > MaxEntropyPerRound {
+		return nil, fmt.Errorf("can't derive %d bytes from a beacon, expected between 1 and %d", n, MaxEntropyPerRound)
+	}
+
+	info := make([]byte, len(entropyDomain)+8)
+	copy(info, entropyDomain)
+	binary.BigEndian.PutUint64(info[len(entropyDomain):], round)
+
+	out := make([]byte, n)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, randomness, chainHash, info), out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,48 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriveEntropy(t *testing.T) {
+	chainHash := bytes.Repeat([]byte{0xab}, 32)
+	randomness := bytes.Repeat([]byte{0x01}, 32)
+
+	out, err := DeriveEntropy(chainHash, 42, randomness, 64)
+	require.NoError(t, err)
+	require.Len(t, out, 64)
+
+	// the first block of HKDF-SHA256, computed by hand as documented
+	prk := hmac.New(sha256.New, chainHash)
+	prk.Write(randomness)
+	info := append([]byte("drand-entropy-stream-v1"), binary.BigEndian.AppendUint64(nil, 42)...)
+	expand := hmac.New(sha256.New, prk.Sum(nil))
+	expand.Write(info)
+	expand.Write([]byte{1})
+	require.Equal(t, expand.Sum(nil), out[:32])
+
+	// a prefix of a longer output
+	short, err := DeriveEntropy(chainHash, 42, randomness, 16)
+	require.NoError(t, err)
+	require.Equal(t, out[:16], short)
+
+	other, err := DeriveEntropy(chainHash, 43, randomness, 64)
+	require.NoError(t, err)
+	require.NotEqual(t, out, other)
+	other, err = DeriveEntropy(bytes.Repeat([]byte{0xcd}, 32), 42, randomness, 64)
+	require.NoError(t, err)
+	require.NotEqual(t, out, other)
+
+	_, err = DeriveEntropy(chainHash, 42, randomness, 0)
+	require.Error(t, err)
+	_, err = DeriveEntropy(chainHash, 42, randomness, MaxEntropyPerRound+1)
+	require.Error(t, err)
+	_, err = DeriveEntropy(chainHash, 42, randomness, MaxEntropyPerRound)
+	require.NoError(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/cipher"
 	"crypto/sha256"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"hash"
 	"os"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/drand/kyber"
 	bls "github.com/drand/kyber-bls12381"
+	"github.com/drand/kyber/pairing"
 	bn254 "github.com/drand/kyber/pairing/bn254"
 	"github.com/drand/kyber/sign"
 
@@ -52,6 +54,8 @@ type Scheme struct {
 	SigGroup kyber.Group
 	// KeyGroup is the group used to create the keys
 	KeyGroup kyber.Group
+	// Pairing is the pairing suite the SigGroup and the KeyGroup are taken from
+	Pairing pairing.Suite
 	// ThresholdScheme is the signature scheme used, defining over which curve the signature
 	// and keys respectively are.
 	ThresholdScheme sign.ThresholdScheme
@@ -63,6 +67,8 @@ type Scheme struct {
 	IdentityHash func() hash.Hash `toml:"-"`
 	// the DigestBeacon is used to generate the bytes that are getting signed
 	DigestBeacon func(hashableBeacon) []byte `toml:"-"`
+	// DST is the domain separation tag used to hash the digests of the beacons onto the SigGroup
+	DST []byte `toml:"-"`
 }
 
 // VerifyBeacon is verifying the aggregated beacon against the provided group public key
@@ -70,6 +76,56 @@ func (s *Scheme) VerifyBeacon(b SignedBeacon, pubkey kyber.Point) error {
 	return s.ThresholdScheme.VerifyRecovered(pubkey, s.DigestBeacon(b), b.GetSignature())
 }
 
+// hashablePoint is a point the digests of the beacons are hashed onto, as the signature schemes do
+type hashablePoint interface {
+	Hash([]byte) kyber.Point
+}
+
+// VerifyBeacons verifies the beacons, all signed by the provided group public key, at once: it checks that a random
+// linear combination of their signatures is the signature of the same combination of their digests, which takes two
+// pairings instead of two per beacon. The random coefficients keep invalid signatures from cancelling each other out.
+// When the check fails, the beacons are verified one by one to tell which one is invalid.
+func (s *Scheme) VerifyBeacons(bs []SignedBeacon, pubkey kyber.Point) error {
+	if len(bs) == 1 {
+		return s.VerifyBeacon(bs[0], pubkey)
+	}
+	sigs := s.SigGroup.Point().Null()
+	digests := s.SigGroup.Point().Null()
+	coefficient := make([]byte, 8)
+	for _, b := range bs {
+		sig := s.SigGroup.Point()
+		if err := sig.UnmarshalBinary(b.GetSignature()); err != nil {
+			return fmt.Errorf("invalid signature of round %d: %w", b.GetRound(), err)
+		}
+		hashable, ok := s.SigGroup.Point().(hashablePoint)
+		if !ok {
+			return fmt.Errorf("the signatures of %s can't be verified in batches", s.Name)
+		}
+		digest := hashable.Hash(s.DigestBeacon(b))
+
+		random.Bytes(coefficient, random.New())
+		r := s.SigGroup.Scalar().SetBytes(coefficient)
+		sigs.Add(sigs, sig.Mul(r, sig))
+		digests.Add(digests, digest.Mul(r, digest))
+	}
+
+	var valid bool
+	if s.KeyGroup.String() == s.Pairing.G1().String() {
+		valid = s.Pairing.Pair(pubkey, digests).Equal(s.Pairing.Pair(s.KeyGroup.Point().Base(), sigs))
+	} else {
+		valid = s.Pairing.Pair(digests, pubkey).Equal(s.Pairing.Pair(sigs, s.KeyGroup.Point().Base()))
+	}
+	if valid {
+		return nil
+	}
+	for _, b := range bs {
+		if err := s.VerifyBeacon(b, pubkey); err != nil {
+			return fmt.Errorf("round %d doesn't verify: %w", b.GetRound(), err)
+		}
+	}
+	return errors.New("the beacons don't verify as a batch")
+}
+
 func (s *Scheme) String() string {
 	if s != nil {
 		return s.Name
@@ -120,11 +176,13 @@ func NewPedersenBLSChained() (cs *Scheme) {
 		Name:            DefaultSchemeID,
 		SigGroup:        SigGroup,
 		KeyGroup:        KeyGroup,
+		Pairing:         Pairing,
 		ThresholdScheme: ThresholdScheme,
 		AuthScheme:      AuthScheme,
 		DKGAuthScheme:   DKGAuthScheme,
 		IdentityHash:    IdentityHashFunc,
 		DigestBeacon:    DigestFunc,
+		DST:             []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_"),
 	}
 }
 
@@ -157,11 +215,13 @@ func NewPedersenBLSUnchained() (cs *Scheme) {
 		Name:            UnchainedSchemeID,
 		SigGroup:        SigGroup,
 		KeyGroup:        KeyGroup,
+		Pairing:         Pairing,
 		ThresholdScheme: ThresholdScheme,
 		AuthScheme:      AuthScheme,
 		DKGAuthScheme:   DKGAuthScheme,
 		IdentityHash:    IdentityHashFunc,
 		DigestBeacon:    DigestFunc,
+		DST:             []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_"),
 	}
 }
 
@@ -201,11 +261,13 @@ func NewPedersenBLSUnchainedSwapped() (cs *Scheme) {
 		Name:            ShortSigSchemeID,
 		SigGroup:        SigGroup,
 		KeyGroup:        KeyGroup,
+		Pairing:         Pairing,
 		ThresholdScheme: ThresholdScheme,
 		AuthScheme:      AuthScheme,
 		DKGAuthScheme:   DKGAuthScheme,
 		IdentityHash:    IdentityHashFunc,
 		DigestBeacon:    DigestFunc,
+		DST:             []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_"),
 	}
 }
 
@@ -242,11 +304,13 @@ func NewPedersenBLSUnchainedG1() (cs *Scheme) {
 		Name:            SigsOnG1ID,
 		SigGroup:        SigGroup,
 		KeyGroup:        KeyGroup,
+		Pairing:         Pairing,
 		ThresholdScheme: ThresholdScheme,
 		AuthScheme:      AuthScheme,
 		DKGAuthScheme:   DKGAuthScheme,
 		IdentityHash:    IdentityHashFunc,
 		DigestBeacon:    DigestFunc,
+		DST:             []byte("BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_NUL_"),
 	}
 }
 
@@ -280,11 +344,13 @@ func NewPedersenBLSBN254UnchainedOnG1Scheme() (cs *Scheme) {
 		Name:            BN254UnchainedOnG1SchemeID,
 		SigGroup:        SigGroup,
 		KeyGroup:        KeyGroup,
+		Pairing:         Pairing,
 		ThresholdScheme: ThresholdScheme,
 		AuthScheme:      AuthScheme,
 		DKGAuthScheme:   DKGAuthScheme,
 		IdentityHash:    IdentityHashFunc,
 		DigestBeacon:    DigestFunc,
+		DST:             []byte("BLS_SIG_BN254G1_XMD:KECCAK-256_SSWU_RO_NUL_"),
 	}
 }
 
@@ -312,6 +378,19 @@ func ListSchemes() []string {
 	return schemeIDs
 }
 
+// deprecatedSchemeIDs are the schemes still supported for the existing networks, but not to be used by new ones
+var deprecatedSchemeIDs = []string{ShortSigSchemeID}
+
+// IsDeprecatedScheme returns whether the scheme with the given id is deprecated
+func IsDeprecatedScheme(id string) bool {
+	for _, d := range deprecatedSchemeIDs {
+		if d == id {
+			return true
+		}
+	}
+	return false
+}
+
 // GetSchemeByID allows the user to retrieve the scheme configuration looking by its ID. It will return a boolean which indicates
 // if the scheme was found or not. In addition to it, if the received ID is an empty string,
 // it will return the default defined scheme
@@ -205,3 +205,51 @@ func TestGetSchemeByID(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyBench(t *testing.T) {
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+
+	_, err = crypto.NewVerifyBench(sch, 0)
+	require.Error(t, err)
+
+	bench, err := crypto.NewVerifyBench(sch, 8)
+	require.NoError(t, err)
+	for _, parallelism := range []int{1, 3} {
+		result, err := bench.Run(parallelism)
+		require.NoError(t, err)
+		require.Equal(t, sch.Name, result.Scheme)
+		require.Equal(t, parallelism, result.Parallelism)
+		require.Equal(t, 8, result.Beacons)
+		require.Positive(t, result.PerSecond())
+
+		result, err = bench.RunBatched(parallelism, 3)
+		require.NoError(t, err)
+		require.Equal(t, 3, result.BatchSize)
+		require.Equal(t, 8, result.Beacons)
+	}
+}
+
+func TestVerifyBeacons(t *testing.T) {
+	for _, id := range crypto.ListSchemes() {
+		t.Run(id, func(t *testing.T) {
+			sch, err := crypto.SchemeFromName(id)
+			require.NoError(t, err)
+			secret := sch.KeyGroup.Scalar().Pick(random.New())
+			public := sch.KeyGroup.Point().Mul(secret, nil)
+
+			var beacons []crypto.SignedBeacon
+			for round := uint64(1); round <= 4; round++ {
+				b := &common.Beacon{Round: round, PreviousSig: []byte{byte(round)}}
+				b.Signature, err = sch.AuthScheme.Sign(secret, sch.DigestBeacon(b))
+				require.NoError(t, err)
+				beacons = append(beacons, b)
+			}
+			require.NoError(t, sch.VerifyBeacons(beacons, public))
+
+			// a signature of another round is caught, and told apart
+			beacons[2].(*common.Beacon).Signature = beacons[1].GetSignature()
+			require.ErrorContains(t, sch.VerifyBeacons(beacons, public), "round 3")
+		})
+	}
+}
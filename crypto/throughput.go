@@ -0,0 +1,134 @@
+package crypto
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/drand/kyber"
+	"github.com/drand/kyber/util/random"
+)
+
+// VerifyThroughput is how long verifying a batch of beacons of a scheme took on the local hardware, spread over a
+// number of goroutines, each verifying the beacons one at a time or several at once
+type VerifyThroughput struct {
+	Scheme      string        `json:"scheme"`
+	Parallelism int           `json:"parallelism"`
+	BatchSize   int           `json:"batch_size"`
+	Beacons     int           `json:"beacons"`
+	Elapsed     time.Duration `json:"elapsed"`
+}
+
+// PerSecond returns the number of beacons verified per second
+func (t *VerifyThroughput) PerSecond() float64 {
+	if t.Elapsed <= 0 {
+		return 0
+	}
+	return float64(t.Beacons) / t.Elapsed.Seconds()
+}
+
+type benchBeacon struct {
+	round       uint64
+	previousSig []byte
+	signature   []byte
+}
+
+func (b *benchBeacon) GetRound() uint64             { return b.round }
+func (b *benchBeacon) GetPreviousSignature() []byte { return b.previousSig }
+func (b *benchBeacon) GetSignature() []byte         { return b.signature }
+
+// VerifyBench holds beacons of a scheme signed with a fresh key, to measure how fast they are verified
+type VerifyBench struct {
+	scheme  *Scheme
+	public  kyber.Point
+	beacons []*benchBeacon
+}
+
+// NewVerifyBench signs the given number of beacons of the scheme with a fresh key
+func NewVerifyBench(sch *Scheme, beacons int) (*VerifyBench, error) {
+	if beacons <= 0 {
+		return nil, fmt.Errorf("the number of beacons to verify must be positive, got %d", beacons)
+	}
+	secret := sch.KeyGroup.Scalar().Pick(random.New())
+	public := sch.KeyGroup.Point().Mul(secret, nil)
+
+	bench := &VerifyBench{
+		scheme:  sch,
+		public:  public,
+		beacons: make([]*benchBeacon, beacons),
+	}
+	previousSig := make([]byte, 8)
+	for i := range bench.beacons {
+		b := &benchBeacon{round: uint64(i + 1), previousSig: previousSig}
+		sig, err := sch.AuthScheme.Sign(secret, sch.DigestBeacon(b))
+		if err != nil {
+			return nil, fmt.Errorf("unable to sign the beacons to verify: %w", err)
+		}
+		b.signature = sig
+		bench.beacons[i] = b
+		previousSig = make([]byte, 8)
+		binary.BigEndian.PutUint64(previousSig, b.round)
+	}
+	return bench, nil
+}
+
+// Run verifies all the beacons one at a time spread over the given number of goroutines, and returns how long it took
+func (v *VerifyBench) Run(parallelism int) (*VerifyThroughput, error) {
+	return v.RunBatched(parallelism, 1)
+}
+
+// RunBatched verifies all the beacons in batches of the given size, as Scheme.VerifyBeacons does, spread over the
+// given number of goroutines, and returns how long it took
+func (v *VerifyBench) RunBatched(parallelism, batchSize int) (*VerifyThroughput, error) {
+	if parallelism <= 0 {
+		parallelism = 1
+	}
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	batches := make([][]SignedBeacon, 0, len(v.beacons)/batchSize+1)
+	for i := 0; i < len(v.beacons); i += batchSize {
+		batch := make([]SignedBeacon, 0, batchSize)
+		for _, b := range v.beacons[i:min(i+batchSize, len(v.beacons))] {
+			batch = append(batch, b)
+		}
+		batches = append(batches, batch)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, parallelism)
+	start := time.Now()
+	for w := 0; w < parallelism; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < len(batches); i += parallelism {
+				if batchSize > 1 {
+					if err := v.scheme.VerifyBeacons(batches[i], v.public); err != nil {
+						errs[w] = err
+						return
+					}
+				} else if err := v.scheme.VerifyBeacon(batches[i][0], v.public); err != nil {
+					errs[w] = fmt.Errorf("round %d doesn't verify: %w", batches[i][0].GetRound(), err)
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &VerifyThroughput{
+		Scheme:      v.scheme.Name,
+		Parallelism: parallelism,
+		BatchSize:   batchSize,
+		Beacons:     len(v.beacons),
+		Elapsed:     elapsed,
+	}, nil
+}
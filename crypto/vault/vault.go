@@ -1,21 +1,38 @@
 package vault
 
 import (
+	"container/list"
+	"errors"
+	"fmt"
 	"sync"
 
+	"github.com/drand/drand/v2/common"
 	"github.com/drand/drand/v2/common/chain"
 	"github.com/drand/drand/v2/common/key"
 	"github.com/drand/drand/v2/common/log"
 	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/kyber"
 	"github.com/drand/kyber/share"
+	"github.com/drand/kyber/sign/tbls"
 )
 
+// DefaultPublicKeyCacheSize is the default number of member public keys kept precomputed to verify partials. It
+// covers the whole group of every network currently running.
+const DefaultPublicKeyCacheSize = 64
+
 // CryptoSafe holds the cryptographic information to generate a partial beacon
 type CryptoSafe interface {
 	// SignPartial returns the partial signature
 	SignPartial(msg []byte) ([]byte, error)
 }
 
+// PartialSigner signs the partials of the node in place of its share, e.g. with a key kept by a hardware module. It
+// is given the current group and the index of the node in it, and returns the partial signature with its index. The
+// node doesn't need to hold its share locally: its index is the one of its identity in the group.
+type PartialSigner interface {
+	SignPartial(group *key.Group, index int, msg []byte) ([]byte, error)
+}
+
 // Vault stores the information necessary to validate partial beacon, full
 // beacons and to sign new partial beacons (it implements CryptoSafe interface).
 // Vault is thread safe when using the methods.
@@ -31,19 +48,67 @@ type Vault struct {
 	chain *chain.Info
 	// to know the threshold, transition time etc
 	group *key.Group
+	// the public keys of the members, evaluated from pub
+	keys *publicKeyCache
+	// signs the partials in place of the share, if set
+	signer PartialSigner
+	// the identity of the node, whose index in the group is the one of the partials of the signer
+	self *key.Identity
+	// the group and share taking over from the current ones at a round, set ahead of a transition
+	next *nextInfo
+}
+
+// nextInfo holds the group and share of the node from the round of a transition, so that the partials of the rounds
+// following it are signed and verified with them even when the round before the transition isn't stored yet
+type nextInfo struct {
+	from  common.Round
+	share *key.Share
+	pub   *share.PubPoly
+	group *key.Group
+	keys  *publicKeyCache
 }
 
 func NewVault(l log.Logger, currentGroup *key.Group, ks *key.Share, sch *crypto.Scheme) *Vault {
-	return &Vault{
+	v := &Vault{
 		log:    l,
 		Scheme: sch,
 		chain:  chain.NewChainInfo(currentGroup),
 		share:  ks,
 		pub:    currentGroup.PublicKey.PubPoly(sch),
 		group:  currentGroup,
+		keys:   newPublicKeyCache(DefaultPublicKeyCacheSize),
+	}
+	v.keys.precompute(v.pub, v.group)
+	return v
+}
+
+// SetPublicKeyCache changes the number of member public keys kept precomputed, 0 meaning the default, and
+// registers a function called on every lookup in the cache.
+func (v *Vault) SetPublicKeyCache(size int, observe func(hit bool)) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if size <= 0 {
+		size = DefaultPublicKeyCacheSize
+	}
+	v.keys = newPublicKeyCache(size)
+	v.keys.observe = observe
+	v.keys.precompute(v.pub, v.group)
+	if v.next != nil {
+		v.next.keys = newPublicKeyCache(size)
+		v.next.keys.observe = observe
+		v.next.keys.precompute(v.next.pub, v.next.group)
 	}
 }
 
+// SetSigner delegates the signature of the partials to the given signer rather than to the share, the index of the
+// partials being the one of the given identity of the node in the group
+func (v *Vault) SetSigner(s PartialSigner, self *key.Identity) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.signer = s
+	v.self = self
+}
+
 // GetGroup returns the current group
 func (v *Vault) GetGroup() *key.Group {
 	v.mu.RLock()
@@ -66,15 +131,54 @@ func (v *Vault) GetInfo() *chain.Info {
 // SignPartial implemements the CryptoSafe interface
 func (v *Vault) SignPartial(msg []byte) ([]byte, error) {
 	v.mu.RLock()
-	defer v.mu.RUnlock()
-	return v.Scheme.ThresholdScheme.Sign(v.share.PrivateShare(), msg)
+	ks, group, signer, self := v.share, v.group, v.signer, v.self
+	v.mu.RUnlock()
+	return v.sign(ks, group, signer, self, msg)
+}
+
+// sign signs the message with the given share, or the given signer if set. The external signer may be remote, it is
+// called without holding the lock.
+func (v *Vault) sign(ks *key.Share, group *key.Group, signer PartialSigner, self *key.Identity, msg []byte) ([]byte, error) {
+	if signer != nil {
+		index, err := indexIn(ks, group, self)
+		if err != nil {
+			return nil, err
+		}
+		return signer.SignPartial(group, index, msg)
+	}
+	if ks == nil {
+		return nil, errNoShare
+	}
+	return v.Scheme.ThresholdScheme.Sign(ks.PrivateShare(), msg)
+}
+
+var errNoShare = errors.New("no share to sign the partials with")
+
+// indexIn returns the index of the node in the group: the one of its identity when it is known, as an external
+// signer may hold the share in place of the node, or else the one of its share
+func indexIn(ks *key.Share, group *key.Group, self *key.Identity) (int, error) {
+	if self != nil {
+		node := group.Find(self)
+		if node == nil {
+			return 0, fmt.Errorf("%s isn't a member of the group", self.Address())
+		}
+		return int(node.Index), nil
+	}
+	if ks == nil {
+		return 0, errNoShare
+	}
+	return ks.Share.I, nil
 }
 
-// Index returns the index of the share
+// Index returns the index of the node in the current group, -1 if it isn't known
 func (v *Vault) Index() int {
 	v.mu.RLock()
 	defer v.mu.RUnlock()
-	return v.share.Share.I
+	index, err := indexIn(v.share, v.group, v.self)
+	if err != nil {
+		return -1
+	}
+	return index
 }
 
 func (v *Vault) SetInfo(newGroup *key.Group, ks *key.Share) {
@@ -82,7 +186,171 @@ func (v *Vault) SetInfo(newGroup *key.Group, ks *key.Share) {
 	defer v.mu.Unlock()
 	v.share = ks
 	v.group = newGroup
-	v.pub = newGroup.PublicKey.PubPoly(v.Scheme)
+	if v.next != nil && v.next.group == newGroup {
+		v.pub, v.keys = v.next.pub, v.next.keys
+	} else {
+		v.pub = newGroup.PublicKey.PubPoly(v.Scheme)
+		v.keys.precompute(v.pub, v.group)
+	}
+	v.next = nil
 	// v.chain info is constant
 	// v.Scheme cannot change either
 }
+
+// SetNextInfo sets the group and share used for the rounds starting at the given one, until SetInfo makes them the
+// current ones. The nodes don't switch at the same time: a partial of the first round of the new group may be signed,
+// or received, before the round preceding it is stored.
+func (v *Vault) SetNextInfo(newGroup *key.Group, ks *key.Share, from common.Round) {
+	pub := newGroup.PublicKey.PubPoly(v.Scheme)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	keys := newPublicKeyCache(v.keys.size)
+	keys.observe = v.keys.observe
+	keys.precompute(pub, newGroup)
+	v.next = &nextInfo{from: from, share: ks, pub: pub, group: newGroup, keys: keys}
+}
+
+// at returns the share, public polynomial, group and public keys to use for the given round
+func (v *Vault) at(round common.Round) (*key.Share, *share.PubPoly, *key.Group, *publicKeyCache) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.next != nil && round >= v.next.from {
+		return v.next.share, v.next.pub, v.next.group, v.next.keys
+	}
+	return v.share, v.pub, v.group, v.keys
+}
+
+// GroupAt returns the group producing the given round
+func (v *Vault) GroupAt(round common.Round) *key.Group {
+	_, _, group, _ := v.at(round)
+	return group
+}
+
+// PubAt returns the public polynomial the partials of the given round are verified and recovered with
+func (v *Vault) PubAt(round common.Round) *share.PubPoly {
+	_, pub, _, _ := v.at(round)
+	return pub
+}
+
+// SignPartialAt returns the partial signature of the node over the message of the given round
+func (v *Vault) SignPartialAt(round common.Round, msg []byte) ([]byte, error) {
+	ks, _, group, _ := v.at(round)
+	v.mu.RLock()
+	signer, self := v.signer, v.self
+	v.mu.RUnlock()
+	return v.sign(ks, group, signer, self, msg)
+}
+
+// VerifyPartialAt verifies the given partial signature over the message of the given round
+func (v *Vault) VerifyPartialAt(round common.Round, msg, sig []byte) error {
+	sh := tbls.SigShare(sig)
+	i, err := sh.Index()
+	if err != nil {
+		return err
+	}
+	_, pub, _, keys := v.at(round)
+	return v.Scheme.ThresholdScheme.VerifyRecovered(keys.get(pub, i), msg, sh.Value())
+}
+
+// VerifyPartial verifies the given partial signature over msg against the public key of the member that produced it,
+// using the precomputed public key when it is cached.
+func (v *Vault) VerifyPartial(msg, sig []byte) error {
+	sh := tbls.SigShare(sig)
+	i, err := sh.Index()
+	if err != nil {
+		return err
+	}
+	v.mu.RLock()
+	pub := v.keys.get(v.pub, i)
+	v.mu.RUnlock()
+	return v.Scheme.ThresholdScheme.VerifyRecovered(pub, msg, sh.Value())
+}
+
+// PublicKeyCacheStats returns the number of lookups of member public keys served from the cache and of the ones
+// which had to be computed.
+func (v *Vault) PublicKeyCacheStats() (hits, misses uint64) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.keys.stats()
+}
+
+// publicKeyCache is an LRU cache of the public keys of the members of the group, evaluated from the public
+// polynomial. Evaluating the polynomial is the costly part of verifying a partial apart from the pairing.
+type publicKeyCache struct {
+	sync.Mutex
+	size    int
+	keys    map[int]*list.Element
+	order   *list.List
+	hits    uint64
+	misses  uint64
+	observe func(hit bool)
+}
+
+type cachedPublicKey struct {
+	index int
+	key   kyber.Point
+}
+
+func newPublicKeyCache(size int) *publicKeyCache {
+	return &publicKeyCache{
+		size:  size,
+		keys:  make(map[int]*list.Element),
+		order: list.New(),
+	}
+}
+
+// precompute drops the cached keys and evaluates the ones of the members of the given group
+func (c *publicKeyCache) precompute(pub *share.PubPoly, group *key.Group) {
+	c.Lock()
+	defer c.Unlock()
+	c.keys = make(map[int]*list.Element)
+	c.order.Init()
+	for _, n := range group.Nodes {
+		c.put(int(n.Index), pub.Eval(int(n.Index)).V)
+	}
+}
+
+func (c *publicKeyCache) get(pub *share.PubPoly, i int) kyber.Point {
+	c.Lock()
+	defer c.Unlock()
+	if e, ok := c.keys[i]; ok {
+		c.order.MoveToFront(e)
+		c.record(true)
+		return e.Value.(*cachedPublicKey).key
+	}
+	c.record(false)
+	k := pub.Eval(i).V
+	c.put(i, k)
+	return k
+}
+
+func (c *publicKeyCache) put(i int, k kyber.Point) {
+	if e, ok := c.keys[i]; ok {
+		e.Value.(*cachedPublicKey).key = k
+		c.order.MoveToFront(e)
+		return
+	}
+	c.keys[i] = c.order.PushFront(&cachedPublicKey{index: i, key: k})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.keys, oldest.Value.(*cachedPublicKey).index)
+	}
+}
+
+func (c *publicKeyCache) record(hit bool) {
+	if hit {
+		c.hits++
+	} else {
+		c.misses++
+	}
+	if c.observe != nil {
+		c.observe(hit)
+	}
+}
+
+func (c *publicKeyCache) stats() (hits, misses uint64) {
+	c.Lock()
+	defer c.Unlock()
+	return c.hits, c.misses
+}
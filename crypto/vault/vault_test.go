@@ -0,0 +1,176 @@
+package vault
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/drand/drand/v2/common/key"
+	"github.com/drand/drand/v2/common/testlogger"
+	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/test"
+	"github.com/drand/kyber/share"
+	"github.com/drand/kyber/share/dkg"
+	"github.com/drand/kyber/util/random"
+)
+
+func TestVaultVerifyPartialWithCachedKeys(t *testing.T) {
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+
+	n, thr := 5, 3
+	pri := share.NewPriPoly(sch.KeyGroup, thr, sch.KeyGroup.Scalar().Pick(random.New()), random.New())
+	_, commits := pri.Commit(sch.KeyGroup.Point().Base()).Info()
+	shares := pri.Shares(n)
+
+	// only the first three members are in the group, the others are computed on demand
+	_, group := test.BatchIdentities(t, 3, sch, "default")
+	group.Threshold = thr
+	group.PublicKey = &key.DistPublic{Coefficients: commits}
+	v := NewVault(testlogger.New(t), group, &key.Share{DistKeyShare: dkg.DistKeyShare{Share: shares[0], Commits: commits}, Scheme: sch}, sch)
+
+	var lookups []bool
+	v.SetPublicKeyCache(3, func(hit bool) {
+		lookups = append(lookups, hit)
+	})
+
+	msg := []byte("round message")
+	for _, i := range []int{1, 4, 4} {
+		sig, err := sch.ThresholdScheme.Sign(shares[i], msg)
+		require.NoError(t, err)
+		require.NoError(t, v.VerifyPartial(msg, sig))
+		require.Error(t, v.VerifyPartial([]byte("another message"), sig))
+	}
+	require.Equal(t, []bool{true, true, false, true, true, true}, lookups)
+
+	hits, misses := v.PublicKeyCacheStats()
+	require.Equal(t, uint64(5), hits)
+	require.Equal(t, uint64(1), misses)
+	// the cache is bounded, member 4 evicted the least recently used member
+	require.Len(t, v.keys.keys, 3)
+}
+
+// keySigner signs the partials with a share kept outside of the vault, as a hardware module would
+type keySigner struct {
+	sch   *crypto.Scheme
+	share *share.PriShare
+}
+
+func (s *keySigner) SignPartial(_ *key.Group, index int, msg []byte) ([]byte, error) {
+	if index != s.share.I {
+		return nil, errors.New("unknown index")
+	}
+	return s.sch.ThresholdScheme.Sign(s.share, msg)
+}
+
+func TestVaultExternalSigner(t *testing.T) {
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+
+	n, thr := 3, 2
+	pri := share.NewPriPoly(sch.KeyGroup, thr, sch.KeyGroup.Scalar().Pick(random.New()), random.New())
+	_, commits := pri.Commit(sch.KeyGroup.Point().Base()).Info()
+	shares := pri.Shares(n)
+
+	_, group := test.BatchIdentities(t, n, sch, "default")
+	group.Threshold = thr
+	group.PublicKey = &key.DistPublic{Coefficients: commits}
+	v := NewVault(testlogger.New(t), group, &key.Share{DistKeyShare: dkg.DistKeyShare{Share: shares[0], Commits: commits}, Scheme: sch}, sch)
+
+	msg := []byte("round message")
+	v.SetSigner(&keySigner{sch: sch, share: shares[0]}, group.Nodes[0].Identity)
+	sig, err := v.SignPartial(msg)
+	require.NoError(t, err)
+	require.NoError(t, v.VerifyPartial(msg, sig))
+
+	// the node doesn't need its share when the signer holds it, its index is the one of its identity in the group
+	v = NewVault(testlogger.New(t), group, nil, sch)
+	v.SetSigner(&keySigner{sch: sch, share: shares[1]}, group.Nodes[1].Identity)
+	require.Equal(t, 1, v.Index())
+	sig, err = v.SignPartial(msg)
+	require.NoError(t, err)
+	require.NoError(t, v.VerifyPartial(msg, sig))
+
+	// a signer holding the wrong key produces partials which don't verify
+	v.SetSigner(&keySigner{sch: sch, share: &share.PriShare{I: shares[1].I, V: shares[2].V}}, group.Nodes[1].Identity)
+	sig, err = v.SignPartial(msg)
+	require.NoError(t, err)
+	require.Error(t, v.VerifyPartial(msg, sig))
+}
+
+func TestVaultNextInfoFromTransitionRound(t *testing.T) {
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+
+	n, thr := 3, 2
+	secret := sch.KeyGroup.Scalar().Pick(random.New())
+	newVault := func() (*key.Group, *key.Share, []*share.PriShare) {
+		pri := share.NewPriPoly(sch.KeyGroup, thr, secret, random.New())
+		_, commits := pri.Commit(sch.KeyGroup.Point().Base()).Info()
+		shares := pri.Shares(n)
+		_, group := test.BatchIdentities(t, n, sch, "default")
+		group.Threshold = thr
+		group.PublicKey = &key.DistPublic{Coefficients: commits}
+		return group, &key.Share{DistKeyShare: dkg.DistKeyShare{Share: shares[0], Commits: commits}, Scheme: sch}, shares
+	}
+	group, ks, shares := newVault()
+	// the refreshed shares share the secret of the group, not its public polynomial
+	refreshed, refreshedShare, refreshedShares := newVault()
+	v := NewVault(testlogger.New(t), group, ks, sch)
+	v.SetNextInfo(refreshed, refreshedShare, 10)
+
+	msg := []byte("round message")
+	oldSig, err := sch.ThresholdScheme.Sign(shares[1], msg)
+	require.NoError(t, err)
+	newSig, err := sch.ThresholdScheme.Sign(refreshedShares[1], msg)
+	require.NoError(t, err)
+
+	// the rounds before the transition are verified with the current polynomial, the following ones with the next one
+	require.NoError(t, v.VerifyPartialAt(9, msg, oldSig))
+	require.Error(t, v.VerifyPartialAt(9, msg, newSig))
+	require.NoError(t, v.VerifyPartialAt(10, msg, newSig))
+	require.Error(t, v.VerifyPartialAt(10, msg, oldSig))
+	require.Equal(t, refreshed, v.GroupAt(10))
+
+	sig, err := v.SignPartialAt(10, msg)
+	require.NoError(t, err)
+	require.NoError(t, v.VerifyPartialAt(10, msg, sig))
+	sig, err = v.SignPartialAt(9, msg)
+	require.NoError(t, err)
+	require.NoError(t, v.VerifyPartialAt(9, msg, sig))
+
+	// once the transition is made, the next group is the current one
+	v.SetInfo(refreshed, refreshedShare)
+	require.NoError(t, v.VerifyPartialAt(9, msg, newSig))
+	require.Equal(t, refreshed, v.GetGroup())
+}
+
+// reentrantSigner calls back into the vault while signing, as a signer reloading its configuration could
+type reentrantSigner struct {
+	keySigner
+	v *Vault
+}
+
+func (s *reentrantSigner) SignPartial(group *key.Group, index int, msg []byte) ([]byte, error) {
+	s.v.SetPublicKeyCache(0, nil)
+	return s.keySigner.SignPartial(group, index, msg)
+}
+
+func TestVaultSignerCalledWithoutLock(t *testing.T) {
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+
+	pri := share.NewPriPoly(sch.KeyGroup, 2, sch.KeyGroup.Scalar().Pick(random.New()), random.New())
+	_, commits := pri.Commit(sch.KeyGroup.Point().Base()).Info()
+	shares := pri.Shares(3)
+	_, group := test.BatchIdentities(t, 3, sch, "default")
+	group.Threshold = 2
+	group.PublicKey = &key.DistPublic{Coefficients: commits}
+
+	v := NewVault(testlogger.New(t), group, nil, sch)
+	v.SetSigner(&reentrantSigner{keySigner: keySigner{sch: sch, share: shares[0]}, v: v}, group.Nodes[0].Identity)
+	sig, err := v.SignPartial([]byte("round message"))
+	require.NoError(t, err)
+	require.NoError(t, v.VerifyPartial([]byte("round message"), sig))
+}
@@ -115,7 +115,7 @@ func (l *LocalNode) Start(dbEngineType chain.StorageType, pgDSN func() string, m
 	}
 
 	conf := core.NewConfig(l.log, opts...)
-	ks := key.NewFileStore(conf.ConfigFolderMB(), l.beaconID)
+	ks := key.NewFileStore(conf.ConfigFolderMB(), common2.BeaconID(l.beaconID))
 	err := ks.SaveKeyPair(l.priv)
 	if err != nil {
 		return err
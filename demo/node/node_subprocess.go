@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/BurntSushi/toml"
+	"github.com/drand/drand/v2/common"
 	pdkg "github.com/drand/drand/v2/protobuf/dkg"
 	clock "github.com/jonboulle/clockwork"
 	json "github.com/nikkolasg/hexjson"
@@ -128,7 +129,7 @@ func (n *NodeProc) setup() {
 	runCommand(newKey)
 
 	config := core.NewConfig(n.lg, core.WithConfigFolder(n.base))
-	n.store = key.NewFileStore(config.ConfigFolderMB(), n.beaconID)
+	n.store = key.NewFileStore(config.ConfigFolderMB(), common.BeaconID(n.beaconID))
 
 	// verify it's done
 	n.priv, err = n.store.LoadKeyPair()
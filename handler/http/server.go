@@ -3,12 +3,15 @@ package http
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -16,13 +19,19 @@ import (
 	json "github.com/nikkolasg/hexjson"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
 
 	"github.com/drand/drand/v2/common"
 	chain2 "github.com/drand/drand/v2/common/chain"
 	client2 "github.com/drand/drand/v2/common/client"
 	"github.com/drand/drand/v2/common/log"
 	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/crypto"
 	"github.com/drand/drand/v2/internal/metrics"
+	"github.com/drand/drand/v2/internal/net"
+	"github.com/drand/drand/v2/protobuf/drand"
 )
 
 const (
@@ -32,6 +41,19 @@ const (
 	roundNumSize        = 64
 	chainHashParamKey   = "chainHash"
 	roundParamKey       = "round"
+	timestampParamKey   = "timestamp"
+	randomnessParamKey  = "randomness"
+	maxStaleParamKey    = "max_stale"
+	// the beacons shared are kept that many periods, in case the instances stop sharing them
+	sharedLatestPeriods = 10
+	// sharedCacheTimeout bounds the time spent getting and sharing the latest beacon, which is served anyway
+	sharedCacheTimeout = 200 * time.Millisecond
+	// sharedCacheRetry is how long an instance lagging behind the chain waits before asking the shared cache for the
+	// expected round again
+	sharedCacheRetry = time.Second
+	// prefetchRetryBackoff is how long a prefetching watch waits before being opened again when the chain info, and so
+	// the time of the next round, isn't known yet
+	prefetchRetryBackoff = 5 * time.Second
 )
 
 var (
@@ -52,6 +74,107 @@ type DrandHandler struct {
 	log     log.Logger
 	version string
 	state   sync.RWMutex
+	// the latest endpoints refuse to serve a beacon older than that many periods, 0 if there is no limit
+	maxStalePeriods uint64
+	// whether the endpoints of the v1 API missing from the v2 one are served
+	v1Compat bool
+	// sheds the requests for a given round while the node is late producing the rounds, nil if it never does
+	shedder *net.LoadShedder
+	// limits the requests by the tier of their API key, nil if they aren't
+	quotas *net.Quotas
+	// shares the latest beacons with the other instances serving the API, nil if they aren't shared
+	sharedCache SharedCache
+	cors        CORS
+	secure      SecurityHeaders
+	// how long before the next round a failed watch is opened again when prefetching the rounds, 0 if they aren't
+	prefetchLead time.Duration
+}
+
+// CORS configures the cross-origin requests the browsers let web pages make to the HTTP API
+type CORS struct {
+	// AllowedOrigins are the origins allowed to read the responses, "*" allowing any of them. Empty allows any origin.
+	AllowedOrigins []string
+	// AllowedHeaders are the request headers allowed in addition to the CORS-safelisted ones
+	AllowedHeaders []string
+	// MaxAge is how long the browsers may cache the response to a preflight request, their own default if 0
+	MaxAge time.Duration
+}
+
+// Validate checks that the allowed origins are either "*" or an origin, such as https://example.com
+func (c CORS) Validate() error {
+	for _, origin := range c.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			return fmt.Errorf("invalid CORS origin %q, expected a scheme and a host such as https://example.com", origin)
+		}
+	}
+	if c.MaxAge < 0 {
+		return fmt.Errorf("the CORS max-age can't be negative")
+	}
+	return nil
+}
+
+// allowedOrigin returns the value of the Access-Control-Allow-Origin header to answer the given origin with, empty
+// if it isn't allowed
+func (c CORS) allowedOrigin(origin string) string {
+	if len(c.AllowedOrigins) == 0 {
+		return "*"
+	}
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == "*" {
+			return "*"
+		}
+		if origin != "" && strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return origin
+		}
+	}
+	return ""
+}
+
+// SecurityHeaders configures the standard security headers set on the responses of the HTTP API
+type SecurityHeaders struct {
+	// Enabled sets X-Content-Type-Options, X-Frame-Options, Referrer-Policy and a Content-Security-Policy denying
+	// everything, as nothing served by the API is meant to be rendered by a browser
+	Enabled bool
+	// HSTSMaxAge sets Strict-Transport-Security when positive, for the APIs only reachable over TLS
+	HSTSMaxAge time.Duration
+}
+
+// GroupClient is implemented by the clients able to return the group file of their chain, which the v1 API serves
+type GroupClient interface {
+	Group(ctx context.Context) (*drand.GroupPacket, error)
+}
+
+// TimeTravelClient is implemented by the clients able to return the beacon which was the latest one at a given time
+type TimeTravelClient interface {
+	GetAt(ctx context.Context, at time.Time) (client2.Result, error)
+}
+
+// RandomnessLookupClient is implemented by the clients able to return the beacon of a given randomness
+type RandomnessLookupClient interface {
+	GetByRandomness(ctx context.Context, randomness []byte) (client2.Result, error)
+}
+
+// StaleBeaconResponse is served by the latest endpoints instead of a beacon older than allowed, so that consumers
+// behind a broken relay fail loudly instead of silently using old randomness.
+type StaleBeaconResponse struct {
+	Error string `json:"error"`
+	// Round is the last round available
+	Round uint64 `json:"round"`
+	// Expected is the round the network should be at
+	Expected uint64 `json:"expected"`
+}
+
+// SharedCache shares the latest beacons with the other instances serving the API behind a load balancer, so that
+// they all serve the latest round as soon as one of them has it
+type SharedCache interface {
+	// Get returns the value of the key, nil if there is none
+	Get(ctx context.Context, key string) ([]byte, error)
+	// SetNX sets the value of the key if it isn't set yet, expiring after the ttl, and returns whether it was set
+	SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
 }
 
 type BeaconHandler struct {
@@ -69,7 +192,17 @@ type BeaconHandler struct {
 	pending     []chan []byte
 	context     context.Context
 	latestRound uint64
-	version     string
+	// the beacon of the latest round received by the watch, kept to serve it when prefetching
+	latestData []byte
+	version    string
+
+	// the latest round we shared, and the latest beacon shared by the other instances, verified, with when the shared
+	// cache was last asked for it
+	sharedLk      sync.Mutex
+	sharedRound   uint64
+	shared        []byte
+	sharedLatest  uint64
+	sharedChecked time.Time
 }
 
 // New creates an HTTP handler for the public Drand API
@@ -101,6 +234,14 @@ func New(ctx context.Context, version string) (*DrandHandler, error) {
 		"/{"+chainHashParamKey+"}/public/{"+roundParamKey+"}",
 		instrument(handler.PublicRand, chainHashParamKey+".PublicRand"),
 	)
+	mux.HandleFunc(
+		"/{"+chainHashParamKey+"}/public/at/{"+timestampParamKey+"}",
+		instrument(handler.PublicRandAt, chainHashParamKey+".PublicRandAt"),
+	)
+	mux.HandleFunc(
+		"/{"+chainHashParamKey+"}/public/randomness/{"+randomnessParamKey+"}",
+		instrument(handler.PublicRandByRandomness, chainHashParamKey+".PublicRandByRandomness"),
+	)
 	mux.HandleFunc(
 		"/{"+chainHashParamKey+"}/info",
 		instrument(handler.ChainInfo, chainHashParamKey+".ChainInfo"),
@@ -110,6 +251,11 @@ func New(ctx context.Context, version string) (*DrandHandler, error) {
 		instrument(handler.Health, chainHashParamKey+".Health"),
 	)
 
+	mux.HandleFunc(
+		"/{"+chainHashParamKey+"}/group",
+		instrument(handler.Group, chainHashParamKey+".Group"),
+	)
+
 	mux.HandleFunc(
 		"/public/latest",
 		instrument(handler.LatestRand, "LatestRand"),
@@ -118,6 +264,14 @@ func New(ctx context.Context, version string) (*DrandHandler, error) {
 		"/public/{"+roundParamKey+"}",
 		instrument(handler.PublicRand, roundParamKey+".PublicRand"),
 	)
+	mux.HandleFunc(
+		"/public/at/{"+timestampParamKey+"}",
+		instrument(handler.PublicRandAt, "PublicRandAt"),
+	)
+	mux.HandleFunc(
+		"/public/randomness/{"+randomnessParamKey+"}",
+		instrument(handler.PublicRandByRandomness, "PublicRandByRandomness"),
+	)
 	mux.HandleFunc(
 		"/info",
 		instrument(handler.ChainInfo, "ChainInfo"),
@@ -126,10 +280,18 @@ func New(ctx context.Context, version string) (*DrandHandler, error) {
 		"/health",
 		instrument(handler.Health, "Health"),
 	)
+	mux.HandleFunc(
+		"/group",
+		instrument(handler.Group, "Group"),
+	)
 	mux.HandleFunc(
 		"/chains",
 		instrument(handler.ChainHashes, "ChainHashes"),
 	)
+	mux.HandleFunc(
+		"/chains/latest",
+		instrument(handler.LatestRandAll, "LatestRandAll"),
+	)
 
 	handler.httpHandler = promhttp.InstrumentHandlerCounter(
 		metrics.HTTPCallCounter,
@@ -137,13 +299,13 @@ func New(ctx context.Context, version string) (*DrandHandler, error) {
 			metrics.HTTPLatency,
 			promhttp.InstrumentHandlerInFlight(
 				metrics.HTTPInFlight,
-				mux)))
+				handler.withHeaders(handler.withQuotas(mux)))))
 
 	return handler, nil
 }
 
 // RegisterNewBeaconHandler add a new handler for a beacon process using its chain hash
-func (h *DrandHandler) RegisterNewBeaconHandler(c client2.Client, chainHash string) *BeaconHandler {
+func (h *DrandHandler) RegisterNewBeaconHandler(c client2.Client, chainHash common.ChainHash) *BeaconHandler {
 	h.state.Lock()
 	defer h.state.Unlock()
 
@@ -157,12 +319,109 @@ func (h *DrandHandler) RegisterNewBeaconHandler(c client2.Client, chainHash stri
 		log:         h.log,
 	}
 
-	h.beacons[chainHash] = bh
+	h.beacons[chainHash.String()] = bh
 	h.log.Infow("New beacon handler registered", "chainHash", chainHash)
+	if h.prefetchLead > 0 {
+		h.startPrefetch(bh)
+	}
 
 	return bh
 }
 
+// SetMaxStalePeriods makes the latest endpoints refuse to serve a beacon older than the given number of periods,
+// unless the request sets its own limit with the max_stale query parameter. Zero disables the check.
+func (h *DrandHandler) SetMaxStalePeriods(periods uint64) {
+	h.state.Lock()
+	defer h.state.Unlock()
+
+	h.maxStalePeriods = periods
+}
+
+// SetV1Compat serves the endpoints of the legacy v1 API which the v2 one dropped, such as /group, for the consumers
+// which didn't migrate yet. The other endpoints already have the same paths and JSON fields in both versions.
+func (h *DrandHandler) SetV1Compat(enabled bool) {
+	h.state.Lock()
+	defer h.state.Unlock()
+
+	h.v1Compat = enabled
+}
+
+// SetLoadShedder sheds the requests for a given round while the node is late producing the rounds, answering them
+// with a 503 and a Retry-After header. The requests for the latest round are always served. Nil disables it.
+func (h *DrandHandler) SetLoadShedder(s *net.LoadShedder) {
+	h.state.Lock()
+	defer h.state.Unlock()
+
+	h.shedder = s
+}
+
+// SetQuotas limits the requests by the tier of the API key given in their X-API-Key header, or as a bearer token,
+// answering the ones over their limits with a 429 and a Retry-After header, and the ones with an unknown key with a
+// 401. Nil disables it.
+func (h *DrandHandler) SetQuotas(q *net.Quotas) {
+	h.state.Lock()
+	defer h.state.Unlock()
+
+	h.quotas = q
+}
+
+// SetSharedCache shares the latest beacons with the other instances serving the API through the cache: the latest
+// endpoints serve the latest beacon out of ours and the shared one. Nil disables it.
+func (h *DrandHandler) SetSharedCache(c SharedCache) {
+	h.state.Lock()
+	defer h.state.Unlock()
+
+	h.sharedCache = c
+}
+
+// SetPrefetch makes the handler watch the chains it serves persistently, rather than from the first request waiting
+// for the next round, and keep their latest round to serve it without asking the node again. A failed watch is opened
+// again lead before the next round is due rather than right away, so that a relay doesn't keep reconnecting to an
+// unavailable upstream between the rounds while it is ready when the round is produced. The rounds are shared with
+// the other instances serving the API as soon as they arrive. Zero disables it.
+func (h *DrandHandler) SetPrefetch(lead time.Duration) {
+	h.state.Lock()
+	defer h.state.Unlock()
+
+	h.prefetchLead = lead
+	if lead <= 0 {
+		return
+	}
+	for _, bh := range h.beacons {
+		h.startPrefetch(bh)
+	}
+}
+
+// startPrefetch starts watching the chain of the beacon handler, if it isn't already watched
+func (h *DrandHandler) startPrefetch(bh *BeaconHandler) {
+	go bh.startOnce.Do(func() {
+		h.start(bh)
+	})
+}
+
+// prefetch returns how long before the next round a failed watch is opened again, 0 if the rounds aren't prefetched
+func (h *DrandHandler) prefetch() time.Duration {
+	h.state.RLock()
+	defer h.state.RUnlock()
+	return h.prefetchLead
+}
+
+// SetCORS configures the cross-origin requests allowed to the HTTP API. The zero value allows any origin.
+func (h *DrandHandler) SetCORS(cors CORS) {
+	h.state.Lock()
+	defer h.state.Unlock()
+
+	h.cors = cors
+}
+
+// SetSecurityHeaders configures the standard security headers set on the responses
+func (h *DrandHandler) SetSecurityHeaders(secure SecurityHeaders) {
+	h.state.Lock()
+	defer h.state.Unlock()
+
+	h.secure = secure
+}
+
 func (h *DrandHandler) GetHTTPHandler() http.Handler {
 	return h.httpHandler
 }
@@ -171,11 +430,19 @@ func (h *DrandHandler) SetHTTPHandler(newHandler http.Handler) {
 	h.httpHandler = newHandler
 }
 
-func (h *DrandHandler) RemoveBeaconHandler(chainHash string) {
+func (h *DrandHandler) RemoveBeaconHandler(chainHash common.ChainHash) {
 	h.state.Lock()
 	defer h.state.Unlock()
 
-	delete(h.beacons, chainHash)
+	delete(h.beacons, chainHash.String())
+}
+
+// RemoveDefaultBeaconHandler stops serving the default beacon on the paths without a chain hash
+func (h *DrandHandler) RemoveDefaultBeaconHandler() {
+	h.state.Lock()
+	defer h.state.Unlock()
+
+	delete(h.beacons, common.DefaultChainHash)
 }
 
 func (h *DrandHandler) RegisterDefaultBeaconHandler(bh *BeaconHandler) {
@@ -190,11 +457,80 @@ func withCommonHeaders(version string, h func(http.ResponseWriter, *http.Request
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Server", version)
 		w.Header().Set("Content-Type", "application/json")
-		w.Header().Set("Access-Control-Allow-Origin", "*")
 		h(w, r)
 	}
 }
 
+// withHeaders sets the CORS and security headers configured on the responses, and answers the CORS preflight
+// requests
+func (h *DrandHandler) withHeaders(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.state.RLock()
+		cors := h.cors
+		secure := h.secure
+		h.state.RUnlock()
+
+		header := w.Header()
+		if secure.Enabled {
+			header.Set("X-Content-Type-Options", "nosniff")
+			header.Set("X-Frame-Options", "DENY")
+			header.Set("Referrer-Policy", "no-referrer")
+			header.Set("Content-Security-Policy", "default-src 'none'; frame-ancestors 'none'")
+		}
+		if secure.HSTSMaxAge > 0 {
+			header.Set("Strict-Transport-Security", fmt.Sprintf("max-age=%d", int64(secure.HSTSMaxAge.Seconds())))
+		}
+
+		origin := r.Header.Get("Origin")
+		allowed := cors.allowedOrigin(origin)
+		if allowed != "" {
+			header.Set("Access-Control-Allow-Origin", allowed)
+		}
+		if allowed != "*" {
+			// the response depends on the origin, caches must not serve it to other ones
+			header.Add("Vary", "Origin")
+		}
+
+		if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		// a preflight request, which is answered even if the origin isn't allowed: the browser enforces it
+		header.Set("Access-Control-Allow-Methods", "GET, HEAD, OPTIONS")
+		if len(cors.AllowedHeaders) > 0 {
+			header.Set("Access-Control-Allow-Headers", strings.Join(cors.AllowedHeaders, ", "))
+		}
+		if cors.MaxAge > 0 {
+			header.Set("Access-Control-Max-Age", strconv.FormatInt(int64(cors.MaxAge.Seconds()), roundNumBase))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// withQuotas refuses the requests over the limits of the tier of their API key
+func (h *DrandHandler) withQuotas(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.state.RLock()
+		quotas := h.quotas
+		h.state.RUnlock()
+
+		apiKey := r.Header.Get(net.APIKeyHeader)
+		if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && apiKey == "" {
+			apiKey = bearer
+		}
+		_, retryAfter, err := quotas.Allow(apiKey, r.RemoteAddr)
+		switch {
+		case errors.Is(err, net.ErrUnknownAPIKey):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		case err != nil:
+			w.Header().Set("Retry-After", net.RetryAfterSeconds(retryAfter))
+			http.Error(w, err.Error(), http.StatusTooManyRequests)
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
 func (h *DrandHandler) start(bh *BeaconHandler) {
 	bh.pendingLk.Lock()
 	defer bh.pendingLk.Unlock()
@@ -248,22 +584,36 @@ func (h *DrandHandler) watchWithTimeout(bh *BeaconHandler, ready chan bool) {
 			h.log.Warnw("", "http_server", "random stream round failed")
 			bh.pendingLk.Lock()
 			bh.latestRound = 0
+			bh.latestData = nil
 			bh.pendingLk.Unlock()
 			// backoff on failures a bit to not fall into a tight loop.
 			// TODO: tuning.
-			time.Sleep(watchConnectBackoff)
+			delay := watchConnectBackoff
+			if lead := h.prefetch(); lead > 0 {
+				bh.chainInfoLk.RLock()
+				delay = reopenDelay(time.Now(), bh.chainInfo, lead)
+				bh.chainInfoLk.RUnlock()
+			}
+			select {
+			case <-bh.context.Done():
+			case <-time.After(delay):
+			}
 			return
 		}
 
 		b, _ := json.Marshal(next)
 		bh.pendingLk.Lock()
 		if bh.latestRound+1 != next.GetRound() && bh.latestRound != 0 {
-			// we missed a round, or similar. don't send bad data to peers.
+			// we missed a round, or similar. don't send bad data to peers, they fetch the round themselves.
 			h.log.Warnw("", "http_server", "unexpected round for watch",
 				"err", fmt.Sprintf("expected %d, saw %d", bh.latestRound+1, next.GetRound()))
-			b = []byte{}
+			b = nil
 		}
 		bh.latestRound = next.GetRound()
+		prefetching := h.prefetch() > 0
+		if prefetching {
+			bh.latestData = b
+		}
 		pending := bh.pending
 		bh.pending = make([]chan []byte, 0)
 
@@ -271,15 +621,57 @@ func (h *DrandHandler) watchWithTimeout(bh *BeaconHandler, ready chan bool) {
 			waiter <- b
 		}
 		bh.pendingLk.Unlock()
+
+		if prefetching && len(b) > 0 {
+			h.warmSharedCache(bh, b, next.GetRound())
+		}
 	}
 }
 
+// reopenDelay returns how long a failed prefetching watch waits before being opened again: until lead before the next
+// round is due, or a short backoff if it is due sooner
+func reopenDelay(now time.Time, info *chain2.Info, lead time.Duration) time.Duration {
+	if info == nil {
+		return prefetchRetryBackoff
+	}
+	next := dateOfRound(common.CurrentRound(now.Unix(), info.Period, info.GenesisTime)+1, info)
+	if delay := next.Sub(now) - lead; delay > watchConnectBackoff {
+		return delay
+	}
+	return watchConnectBackoff
+}
+
+// warmSharedCache shares the beacon just received by the watch with the other instances serving the API
+func (h *DrandHandler) warmSharedCache(bh *BeaconHandler, data []byte, round uint64) {
+	ctx, cancel := context.WithTimeout(bh.context, h.timeout)
+	defer cancel()
+	info, err := h.beaconInfo(ctx, bh)
+	if err != nil {
+		return
+	}
+	h.shareLatest(ctx, bh, info, data, round, round)
+}
+
+// prefetched returns the beacon of the round if it is the latest one received by the watch, nil otherwise
+func (bh *BeaconHandler) prefetched(round uint64) []byte {
+	bh.pendingLk.RLock()
+	defer bh.pendingLk.RUnlock()
+	if round == 0 || bh.latestRound != round || len(bh.latestData) == 0 {
+		return nil
+	}
+	return bh.latestData
+}
+
 func (h *DrandHandler) getChainInfo(ctx context.Context, chainHash []byte) (*chain2.Info, error) {
 	bh, err := h.getBeaconHandler(chainHash)
 	if err != nil {
 		return nil, err
 	}
+	return h.beaconInfo(ctx, bh)
+}
 
+// beaconInfo returns the chain info of the beacon handler, fetched from its client the first time
+func (h *DrandHandler) beaconInfo(ctx context.Context, bh *BeaconHandler) (*chain2.Info, error) {
 	bh.chainInfoLk.RLock()
 	if bh.chainInfo != nil {
 		// we want to return a copy in case it changes
@@ -323,6 +715,9 @@ func (h *DrandHandler) getRand(ctx context.Context, chainHash []byte, info *chai
 	bh.startOnce.Do(func() {
 		h.start(bh)
 	})
+	if data := bh.prefetched(round); data != nil {
+		return data, nil
+	}
 
 	// First see if we should get on the synchronized 'wait for next release' bandwagon.
 	var block bool
@@ -344,8 +739,11 @@ func (h *DrandHandler) getRand(ctx context.Context, chainHash []byte, info *chai
 		if block {
 			select {
 			case r := <-ch:
-				span.RecordError(fmt.Errorf("blocked request fulfilled for round %d", round))
-				return r, nil
+				// the watch skipped rounds, the round is fetched below
+				if len(r) > 0 {
+					span.RecordError(fmt.Errorf("blocked request fulfilled for round %d", round))
+					return r, nil
+				}
 			case <-ctx.Done():
 				bh.pendingLk.Lock()
 				defer bh.pendingLk.Unlock()
@@ -375,7 +773,7 @@ func (h *DrandHandler) getRand(ctx context.Context, chainHash []byte, info *chai
 
 	ctx, cancel := context.WithTimeout(ctx, h.timeout)
 	defer cancel()
-	resp, err := bh.client.Get(ctx, round)
+	resp, err := bh.client.Get(ctx, common.Round(round))
 	if err != nil {
 		return nil, err
 	}
@@ -397,6 +795,15 @@ func (h *DrandHandler) PublicRand(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.state.RLock()
+	shedder := h.shedder
+	h.state.RUnlock()
+	if shed, retryAfter := shedder.Shed(net.TrafficRound); shed {
+		w.Header().Set("Retry-After", net.RetryAfterSeconds(retryAfter))
+		http.Error(w, "the node is shedding load, retry later", http.StatusServiceUnavailable)
+		return
+	}
+
 	chainHashHex, err := readChainHash(r)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
@@ -446,30 +853,108 @@ func (h *DrandHandler) PublicRand(w http.ResponseWriter, r *http.Request) {
 	http.ServeContent(w, r, "rand.json", roundExpectedTime, bytes.NewReader(data))
 }
 
-func (h *DrandHandler) LatestRand(w http.ResponseWriter, r *http.Request) {
-	chainHashHex, err := readChainHash(r)
+// PublicRandAt serves the beacon which was the latest one of the chain at the time in the path, given as a UNIX time
+// in seconds or in the RFC 3339 format
+func (h *DrandHandler) PublicRandAt(w http.ResponseWriter, r *http.Request) {
+	at, err := readTimestamp(r)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	h.state.RLock()
+	shedder := h.shedder
+	h.state.RUnlock()
+	if shed, retryAfter := shedder.Shed(net.TrafficRound); shed {
+		w.Header().Set("Retry-After", net.RetryAfterSeconds(retryAfter))
+		http.Error(w, "the node is shedding load, retry later", http.StatusServiceUnavailable)
+		return
+	}
+
+	chainHashHex, err := readChainHash(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	bh, err := h.getBeaconHandler(chainHashHex)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusNotFound)
 		return
 	}
+	tc, ok := bh.client.(TimeTravelClient)
+	if !ok {
+		http.Error(w, "time-travel queries not available", http.StatusNotFound)
+		return
+	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
 	defer cancel()
+	resp, err := tc.GetAt(ctx, at)
+	if status.Code(err) == codes.Unavailable {
+		// the node lags behind the chain, another one may have the beacon
+		w.Header().Set("Cache-Control", "no-cache")
+		http.Error(w, "the beacon of this time isn't available yet", http.StatusServiceUnavailable)
+		return
+	} else if err != nil {
+		h.log.Debugw("", "http_server", "failed to get randomness at time", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.Path), "err", err)
+		http.Error(w, "no beacon at this time", http.StatusNotFound)
+		return
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		h.log.Warnw("", "http_server", "failed to marshal randomness", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.Path), "err", err)
+		return
+	}
+
+	// the beacon of a time never changes
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=604800, immutable")
+	_, _ = w.Write(data)
+}
 
-	resp, err := bh.client.Get(ctx, 0)
+// PublicRandByRandomness serves the beacon of the randomness in the path, given in hex, so that the systems which
+// only recorded the randomness they used can get back the beacon to verify it
+func (h *DrandHandler) PublicRandByRandomness(w http.ResponseWriter, r *http.Request) {
+	randomness, err := hex.DecodeString(chi.URLParam(r, randomnessParamKey))
+	if err != nil || len(randomness) != sha256.Size {
+		http.Error(w, "invalid randomness, expected a SHA-256 hash in hex", http.StatusBadRequest)
+		return
+	}
 
+	h.state.RLock()
+	shedder := h.shedder
+	h.state.RUnlock()
+	if shed, retryAfter := shedder.Shed(net.TrafficRound); shed {
+		w.Header().Set("Retry-After", net.RetryAfterSeconds(retryAfter))
+		http.Error(w, "the node is shedding load, retry later", http.StatusServiceUnavailable)
+		return
+	}
+
+	chainHashHex, err := readChainHash(r)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		h.log.Warnw("", "http_server", "failed to get randomness", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.Path), "err", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	bh, err := h.getBeaconHandler(chainHashHex)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	lc, ok := bh.client.(RandomnessLookupClient)
+	if !ok {
+		http.Error(w, "randomness lookups not available", http.StatusNotFound)
 		return
 	}
 
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+	resp, err := lc.GetByRandomness(ctx, randomness)
+	if err != nil {
+		h.log.Debugw("", "http_server", "failed to get beacon of randomness", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.Path), "err", err)
+		http.Error(w, "no beacon with this randomness", http.StatusNotFound)
+		return
+	}
 	data, err := json.Marshal(resp)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -477,7 +962,35 @@ func (h *DrandHandler) LatestRand(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	info, err := h.getChainInfo(r.Context(), chainHashHex)
+	// the beacon of a randomness never changes
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=604800, immutable")
+	_, _ = w.Write(data)
+}
+
+func (h *DrandHandler) LatestRand(w http.ResponseWriter, r *http.Request) {
+	chainHashHex, err := readChainHash(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	bh, err := h.getBeaconHandler(chainHashHex)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	maxStale, err := h.readMaxStale(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	info, err := h.getChainInfo(ctx, chainHashHex)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		h.log.Warn(
@@ -489,8 +1002,39 @@ func (h *DrandHandler) LatestRand(w http.ResponseWriter, r *http.Request) {
 		)
 		return
 	}
+	expected := common.CurrentRound(time.Now().Unix(), info.Period, info.GenesisTime)
+
+	// the latest round received by the watch is served as is when it is the expected one
+	data, latest := bh.prefetched(expected), expected
+	if data == nil {
+		resp, err := bh.client.Get(ctx, 0)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			h.log.Warnw("", "http_server", "failed to get randomness", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.Path), "err", err)
+			return
+		}
+
+		data, err = json.Marshal(resp)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			h.log.Warnw("", "http_server", "failed to marshal randomness", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.Path), "err", err)
+			return
+		}
+		latest = resp.GetRound()
+	}
+
+	data, round := h.shareLatest(r.Context(), bh, info, data, latest, expected)
+	if maxStale > 0 && expected > round+maxStale {
+		h.log.Warnw("", "http_server", "refusing to serve stale latest rand",
+			"client", r.RemoteAddr, "req", url.PathEscape(r.URL.Path), "round", round, "expected", expected)
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		b, _ := json.Marshal(StaleBeaconResponse{Error: "stale", Round: round, Expected: expected})
+		_, _ = w.Write(b)
+		return
+	}
 
-	roundTime := dateOfRound(resp.GetRound(), info)
+	roundTime := dateOfRound(round, info)
 	nextTime := time.Now()
 	next := roundTime.Add(info.Period)
 	if next.After(nextTime) {
@@ -513,6 +1057,179 @@ func (h *DrandHandler) LatestRand(w http.ResponseWriter, r *http.Request) {
 	_, _ = w.Write(data)
 }
 
+// shareLatest returns the latest beacon of the chain out of ours and the one of the expected round shared by the other
+// instances serving the API, with its round, and shares ours. The cache is only asked for the expected round when we
+// lag behind the chain, at most once per sharedCacheRetry, and the beacons it holds are verified before being served.
+// Each round is shared once, by the first instance which has it.
+func (h *DrandHandler) shareLatest(ctx context.Context, bh *BeaconHandler, info *chain2.Info, data []byte,
+	round, expected uint64) ([]byte, uint64) {
+	h.state.RLock()
+	cache := h.sharedCache
+	h.state.RUnlock()
+	if cache == nil {
+		return data, round
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sharedCacheTimeout)
+	defer cancel()
+	h.share(ctx, cache, bh, info, data, round)
+	if round < expected {
+		if shared, sharedRound := h.sharedBeacon(ctx, cache, bh, info, expected); sharedRound > round {
+			return shared, sharedRound
+		}
+	}
+	return data, round
+}
+
+// sharedCacheKey returns the key of the beacon of the round of the chain in the shared cache
+func sharedCacheKey(info *chain2.Info, round uint64) string {
+	return fmt.Sprintf("drand:beacon:%s:%d", info.HashString(), round)
+}
+
+// share shares our beacon of the round, unless it was already
+func (h *DrandHandler) share(ctx context.Context, cache SharedCache, bh *BeaconHandler, info *chain2.Info, data []byte,
+	round uint64) {
+	bh.sharedLk.Lock()
+	if round <= bh.sharedRound {
+		bh.sharedLk.Unlock()
+		return
+	}
+	bh.sharedRound = round
+	bh.sharedLk.Unlock()
+
+	if _, err := cache.SetNX(ctx, sharedCacheKey(info, round), data, info.Period*sharedLatestPeriods); err != nil {
+		h.log.Debugw("unable to share the latest beacon", "err", err)
+	}
+}
+
+// sharedBeacon returns the latest beacon shared by the other instances, asking the cache for the expected round if it
+// wasn't asked for a while
+func (h *DrandHandler) sharedBeacon(ctx context.Context, cache SharedCache, bh *BeaconHandler, info *chain2.Info,
+	expected uint64) ([]byte, uint64) {
+	bh.sharedLk.Lock()
+	if bh.sharedLatest >= expected || time.Since(bh.sharedChecked) < sharedCacheRetry {
+		defer bh.sharedLk.Unlock()
+		return bh.shared, bh.sharedLatest
+	}
+	bh.sharedChecked = time.Now()
+	bh.sharedLk.Unlock()
+
+	shared, err := cache.Get(ctx, sharedCacheKey(info, expected))
+	if err != nil {
+		h.log.Debugw("unable to get the shared latest beacon", "err", err)
+	}
+	if shared != nil {
+		if shared, err = verifiedSharedBeacon(info, shared, expected); err != nil {
+			h.log.Warnw("ignoring an invalid beacon in the shared cache", "round", expected, "err", err)
+		}
+	}
+
+	bh.sharedLk.Lock()
+	defer bh.sharedLk.Unlock()
+	if shared != nil && expected > bh.sharedLatest {
+		bh.shared, bh.sharedLatest = shared, expected
+	}
+	return bh.shared, bh.sharedLatest
+}
+
+// verifiedSharedBeacon checks that the beacon shared is the one of the round of the chain, and returns it as we serve
+// it, its randomness derived from its signature
+func verifiedSharedBeacon(info *chain2.Info, data []byte, round uint64) ([]byte, error) {
+	var beacon drand.PublicRandResponse
+	if err := json.Unmarshal(data, &beacon); err != nil {
+		return nil, err
+	}
+	if beacon.GetRound() != round {
+		return nil, fmt.Errorf("round %d shared for round %d", beacon.GetRound(), round)
+	}
+	sch, err := crypto.SchemeFromName(info.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	if err := sch.VerifyBeacon(&beacon, info.PublicKey); err != nil {
+		return nil, err
+	}
+	beacon.Metadata = nil
+	beacon.Randomness = crypto.RandomnessFromSignature(beacon.GetSignature())
+	return json.Marshal(&beacon)
+}
+
+// LatestRandAll serves the latest beacon of each of the chains we serve, by beacon ID, so that consumers mixing
+// the randomness of several chains can get it in a single round trip. The chains that can't be reached are left out.
+func (h *DrandHandler) LatestRandAll(w http.ResponseWriter, r *http.Request) {
+	h.state.RLock()
+	chainHashes := make([]string, 0, len(h.beacons))
+	for chainHash := range h.beacons {
+		if chainHash != common.DefaultChainHash {
+			chainHashes = append(chainHashes, chainHash)
+		}
+	}
+	h.state.RUnlock()
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	var lk sync.Mutex
+	var wg sync.WaitGroup
+	latest := make(map[string]client2.Result, len(chainHashes))
+	nextTime := time.Time{}
+	for _, chainHash := range chainHashes {
+		wg.Add(1)
+		go func(chainHash string) {
+			defer wg.Done()
+			chainHashHex, err := hex.DecodeString(chainHash)
+			if err != nil {
+				return
+			}
+			bh, err := h.getBeaconHandler(chainHashHex)
+			if err != nil {
+				return
+			}
+			info, err := h.getChainInfo(ctx, chainHashHex)
+			if err != nil {
+				h.log.Warnw("", "http_server", "unable to get info from chainhash", "chainHash", chainHash, "err", err)
+				return
+			}
+			resp, err := bh.client.Get(ctx, 0)
+			if err != nil {
+				h.log.Warnw("", "http_server", "failed to get randomness", "chainHash", chainHash, "err", err)
+				return
+			}
+
+			next := dateOfRound(resp.GetRound(), info).Add(info.Period)
+			lk.Lock()
+			defer lk.Unlock()
+			latest[common.GetCanonicalBeaconID(info.ID)] = resp
+			if nextTime.IsZero() || next.Before(nextTime) {
+				nextTime = next
+			}
+		}(chainHash)
+	}
+	wg.Wait()
+
+	if len(latest) == 0 && len(chainHashes) > 0 {
+		w.WriteHeader(http.StatusInternalServerError)
+		h.log.Warnw("", "http_server", "failed to get randomness of all chains", "client", r.RemoteAddr)
+		return
+	}
+
+	data, err := json.Marshal(latest)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		h.log.Warnw("", "http_server", "failed to marshal randomness", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.Path), "err", err)
+		return
+	}
+
+	// the response changes as soon as any of the chains produces a new beacon
+	if remaining := time.Until(nextTime); remaining > 0 {
+		w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d, public", int(math.Ceil(remaining.Seconds()))))
+		w.Header().Set("Expires", nextTime.Format(http.TimeFormat))
+	} else {
+		w.Header().Set("Cache-Control", "no-cache")
+	}
+	_, _ = w.Write(data)
+}
+
 func (h *DrandHandler) ChainInfo(w http.ResponseWriter, r *http.Request) {
 	chainHashHex, err := readChainHash(r)
 	if err != nil {
@@ -542,6 +1259,54 @@ func (h *DrandHandler) ChainInfo(w http.ResponseWriter, r *http.Request) {
 	http.ServeContent(w, r, "info.json", time.Unix(info.GenesisTime, 0), bytes.NewReader(chainBuff.Bytes()))
 }
 
+// Group serves the group file of the chain in the JSON format of the v1 API, if the v1 compatibility is enabled
+func (h *DrandHandler) Group(w http.ResponseWriter, r *http.Request) {
+	h.state.RLock()
+	v1Compat := h.v1Compat
+	h.state.RUnlock()
+	if !v1Compat {
+		http.NotFound(w, r)
+		return
+	}
+
+	chainHashHex, err := readChainHash(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	bh, err := h.getBeaconHandler(chainHashHex)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	gc, ok := bh.client.(GroupClient)
+	if !ok {
+		http.Error(w, "group not available", http.StatusNotFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+	group, err := gc.Group(ctx)
+	if err != nil || group == nil {
+		h.log.Warnw("", "http_server", "failed to serve group", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.Path), "err", err)
+		http.Error(w, "group not found", http.StatusNotFound)
+		return
+	}
+
+	// the v1 API used the field names of the protobuf messages
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(group)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		h.log.Warnw("", "http_server", "failed to marshal group", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.Path), "err", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "max-age=300")
+	_, _ = w.Write(data)
+}
+
 func (h *DrandHandler) Health(w http.ResponseWriter, r *http.Request) {
 	chainHashHex, err := readChainHash(r)
 	if err != nil {
@@ -627,8 +1392,36 @@ func readRound(r *http.Request) (uint64, error) {
 	return strconv.ParseUint(round, roundNumBase, roundNumSize)
 }
 
+// readTimestamp returns the time in the path, given as a UNIX time in seconds or in the RFC 3339 format
+func readTimestamp(r *http.Request) (time.Time, error) {
+	param := chi.URLParam(r, timestampParamKey)
+	if secs, err := strconv.ParseInt(param, roundNumBase, roundNumSize); err == nil {
+		return time.Unix(secs, 0), nil
+	}
+	at, err := time.Parse(time.RFC3339, param)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q, expected a UNIX time or an RFC 3339 date", param)
+	}
+	return at, nil
+}
+
+// readMaxStale returns the staleness limit of the request, or the default one of the handler
+func (h *DrandHandler) readMaxStale(r *http.Request) (uint64, error) {
+	maxStale := r.URL.Query().Get(maxStaleParamKey)
+	if maxStale == "" {
+		h.state.RLock()
+		defer h.state.RUnlock()
+		return h.maxStalePeriods, nil
+	}
+	periods, err := strconv.ParseUint(maxStale, roundNumBase, roundNumSize)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s parameter: %w", maxStaleParamKey, err)
+	}
+	return periods, nil
+}
+
 func dateOfRound(round uint64, info *chain2.Info) time.Time {
-	return time.Unix(common.TimeOfRound(info.Period, info.GenesisTime, round), 0)
+	return time.Unix(common.TimeOfRound(info.Period, info.GenesisTime, common.Round(round)), 0)
 }
 
 func (h *DrandHandler) getBeaconHandler(chainHash []byte) (*BeaconHandler, error) {
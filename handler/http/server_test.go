@@ -1,11 +1,17 @@
 package http_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -13,12 +19,15 @@ import (
 	json "github.com/nikkolasg/hexjson"
 	"github.com/stretchr/testify/require"
 
+	"github.com/drand/drand/v2/common"
 	"github.com/drand/drand/v2/common/client"
 	"github.com/drand/drand/v2/common/log"
 	"github.com/drand/drand/v2/common/testlogger"
 	"github.com/drand/drand/v2/crypto"
 	dhttp "github.com/drand/drand/v2/handler/http"
+	dnet "github.com/drand/drand/v2/internal/net"
 	"github.com/drand/drand/v2/internal/test"
+	"github.com/drand/drand/v2/protobuf/drand"
 	"github.com/drand/drand/v2/test/mock"
 )
 
@@ -97,7 +106,7 @@ func TestHTTPWaiting(t *testing.T) {
 	info, err := c.Info(ctx)
 	require.NoError(t, err)
 
-	handler.RegisterNewBeaconHandler(c, info.HashString())
+	handler.RegisterNewBeaconHandler(c, info.ChainHash())
 
 	listener, err := net.Listen("tcp", "127.0.0.1:0")
 	require.NoError(t, err)
@@ -109,7 +118,7 @@ func TestHTTPWaiting(t *testing.T) {
 	time.Sleep(50 * time.Millisecond)
 
 	// The first request will trigger background watch. 1 get (1969)
-	u := fmt.Sprintf("http://%s/%s/public/1", listener.Addr().String(), info.HashString())
+	u := fmt.Sprintf("http://%s/%s/public/1", listener.Addr().String(), info.ChainHash())
 	next := getWithCtx(ctx, u, t)
 
 	validateBodyFormat(next.Body, 1969)
@@ -186,7 +195,7 @@ func TestHTTPWatchFuture(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	handler.RegisterNewBeaconHandler(c, info.HashString())
+	handler.RegisterNewBeaconHandler(c, info.ChainHash())
 
 	listener, err := net.Listen("tcp", ":0")
 	if err != nil {
@@ -199,7 +208,7 @@ func TestHTTPWatchFuture(t *testing.T) {
 	time.Sleep(50 * time.Millisecond)
 
 	// watching sets latest round, future rounds should become inaccessible.
-	u := fmt.Sprintf("http://%s/%s/public/2000", listener.Addr().String(), info.HashString())
+	u := fmt.Sprintf("http://%s/%s/public/2000", listener.Addr().String(), info.ChainHash())
 	resp := getWithCtx(ctx, u, t)
 	defer func() { _ = resp.Body.Close() }()
 	if resp.StatusCode != http.StatusNotFound {
@@ -224,7 +233,7 @@ func TestHTTPHealth(t *testing.T) {
 	info, err := c.Info(ctx)
 	require.NoError(t, err)
 
-	handler.RegisterNewBeaconHandler(c, info.HashString())
+	handler.RegisterNewBeaconHandler(c, info.ChainHash())
 
 	listener, err := net.Listen("tcp", ":0")
 	require.NoError(t, err)
@@ -272,7 +281,7 @@ func TestHTTP404(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	handler.RegisterNewBeaconHandler(c, info.HashString())
+	handler.RegisterNewBeaconHandler(c, info.ChainHash())
 
 	listener, err := net.Listen("tcp", "localhost:0")
 	if err != nil {
@@ -303,3 +312,552 @@ func TestHTTP404(t *testing.T) {
 		t.Fatal("response should 404 on beacon hash that doesn't exist")
 	}
 }
+
+func TestHTTPLatestStale(t *testing.T) {
+	lg := testlogger.New(t)
+	ctx := log.ToContext(context.Background(), lg)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// the mock serves rounds from an hour ago
+	c, _ := withClient(t, clock.NewFakeClockAt(time.Now().Add(-time.Hour)))
+
+	handler, err := dhttp.New(ctx, "")
+	require.NoError(t, err)
+
+	info, err := c.Info(ctx)
+	require.NoError(t, err)
+
+	handler.RegisterNewBeaconHandler(c, info.ChainHash())
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	server := http.Server{Handler: handler.GetHTTPHandler()}
+	go func() { _ = server.Serve(listener) }()
+	defer func() { _ = server.Shutdown(ctx) }()
+
+	time.Sleep(50 * time.Millisecond)
+
+	latest := fmt.Sprintf("http://%s/%s/public/latest", listener.Addr().String(), info.ChainHash())
+	resp := getWithCtx(ctx, latest, t)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "no staleness limit by default")
+	resp.Body.Close()
+
+	resp = getWithCtx(ctx, latest+"?max_stale=10", t)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	var stale dhttp.StaleBeaconResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&stale))
+	resp.Body.Close()
+	require.Equal(t, "stale", stale.Error)
+	require.Greater(t, stale.Expected, stale.Round+10)
+
+	// the limit of the request takes precedence over the default one of the handler
+	handler.SetMaxStalePeriods(10)
+	resp = getWithCtx(ctx, latest, t)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	resp.Body.Close()
+	resp = getWithCtx(ctx, latest+"?max_stale=100000", t)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func TestHTTPLatestRandAll(t *testing.T) {
+	lg := testlogger.New(t)
+	ctx := log.ToContext(context.Background(), lg)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	c, _ := withClient(t, clock.NewFakeClockAt(time.Now()))
+
+	handler, err := dhttp.New(ctx, "")
+	require.NoError(t, err)
+
+	info, err := c.Info(ctx)
+	require.NoError(t, err)
+
+	bh := handler.RegisterNewBeaconHandler(c, info.ChainHash())
+	handler.RegisterDefaultBeaconHandler(bh)
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	server := http.Server{Handler: handler.GetHTTPHandler()}
+	go func() { _ = server.Serve(listener) }()
+	defer func() { _ = server.Shutdown(ctx) }()
+
+	time.Sleep(50 * time.Millisecond)
+
+	resp := getWithCtx(ctx, fmt.Sprintf("http://%s/chains/latest", listener.Addr().String()), t)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	latest := make(map[string]map[string]interface{})
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&latest))
+	// the default chain is only listed once, by its beacon ID
+	require.Len(t, latest, 1)
+	require.Contains(t, latest, "default")
+	require.NotZero(t, latest["default"]["round"])
+}
+
+// groupClient serves a fixed group file along with the beacons of the mock
+type groupClient struct {
+	client.Client
+	group *drand.GroupPacket
+}
+
+func (g *groupClient) Group(context.Context) (*drand.GroupPacket, error) {
+	return g.group, nil
+}
+
+func TestHTTPV1Group(t *testing.T) {
+	lg := testlogger.New(t)
+	ctx := log.ToContext(context.Background(), lg)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	c, _ := withClient(t, clock.NewFakeClock())
+
+	handler, err := dhttp.New(ctx, "")
+	require.NoError(t, err)
+
+	info, err := c.Info(ctx)
+	require.NoError(t, err)
+
+	group := &drand.GroupPacket{
+		Nodes:       []*drand.Node{{Public: &drand.Identity{Address: "127.0.0.1:8080"}, Index: 0}},
+		Threshold:   1,
+		Period:      uint32(info.Period.Seconds()),
+		GenesisTime: uint64(info.GenesisTime),
+		SchemeID:    info.Scheme,
+	}
+	bh := handler.RegisterNewBeaconHandler(&groupClient{Client: c, group: group}, info.ChainHash())
+	handler.RegisterDefaultBeaconHandler(bh)
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	server := http.Server{Handler: handler.GetHTTPHandler()}
+	go func() { _ = server.Serve(listener) }()
+	defer func() { _ = server.Shutdown(ctx) }()
+
+	time.Sleep(50 * time.Millisecond)
+
+	endpoints := []string{
+		fmt.Sprintf("http://%s/group", listener.Addr().String()),
+		fmt.Sprintf("http://%s/%s/group", listener.Addr().String(), info.HashString()),
+	}
+	for _, u := range endpoints {
+		resp := getWithCtx(ctx, u, t)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode, "the v1 endpoints are opt-in")
+		resp.Body.Close()
+	}
+
+	handler.SetV1Compat(true)
+	for _, u := range endpoints {
+		resp := getWithCtx(ctx, u, t)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		body := make(map[string]any)
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		resp.Body.Close()
+
+		// the fields keep their v1 names
+		require.Len(t, body["nodes"], 1)
+		require.Equal(t, float64(1), body["threshold"])
+		require.Equal(t, fmt.Sprint(info.GenesisTime), body["genesis_time"])
+		require.Equal(t, info.Scheme, body["schemeID"])
+	}
+}
+
+func TestHTTPLoadShedding(t *testing.T) {
+	lg := testlogger.New(t)
+	ctx := log.ToContext(context.Background(), lg)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	c, _ := withClient(t, clock.NewFakeClockAt(time.Now().Add(-time.Hour)))
+
+	handler, err := dhttp.New(ctx, "")
+	require.NoError(t, err)
+
+	info, err := c.Info(ctx)
+	require.NoError(t, err)
+
+	handler.RegisterNewBeaconHandler(c, info.ChainHash())
+
+	// the chain is produced way later than the threshold
+	shedder := dnet.NewLoadShedder(clock.NewRealClock(), time.Second)
+	shedder.Observe(info.HashString(), time.Now().Add(-time.Minute), time.Now(), info.Period)
+	handler.SetLoadShedder(shedder)
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	server := http.Server{Handler: handler.GetHTTPHandler()}
+	go func() { _ = server.Serve(listener) }()
+	defer func() { _ = server.Shutdown(ctx) }()
+
+	time.Sleep(50 * time.Millisecond)
+
+	resp := getWithCtx(ctx, fmt.Sprintf("http://%s/%s/public/1", listener.Addr().String(), info.HashString()), t)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.Equal(t, dnet.RetryAfterSeconds(info.Period), resp.Header.Get("Retry-After"))
+	resp.Body.Close()
+
+	resp = getWithCtx(ctx, fmt.Sprintf("http://%s/%s/public/latest", listener.Addr().String(), info.HashString()), t)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "the latest round is always served")
+	resp.Body.Close()
+
+	handler.SetLoadShedder(nil)
+	resp = getWithCtx(ctx, fmt.Sprintf("http://%s/%s/public/1", listener.Addr().String(), info.HashString()), t)
+	require.NotEqual(t, http.StatusServiceUnavailable, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func TestHTTPQuotas(t *testing.T) {
+	lg := testlogger.New(t)
+	ctx := log.ToContext(context.Background(), lg)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	c, _ := withClient(t, clock.NewFakeClockAt(time.Now().Add(-time.Hour)))
+
+	handler, err := dhttp.New(ctx, "")
+	require.NoError(t, err)
+
+	info, err := c.Info(ctx)
+	require.NoError(t, err)
+
+	handler.RegisterNewBeaconHandler(c, info.ChainHash())
+
+	quotas, err := dnet.NewQuotas(clock.NewRealClock(),
+		[]dnet.QuotaTier{{Name: "gold", Rate: 100, Burst: 100}, {Name: dnet.AnonymousTier, Rate: 0.001, Burst: 1}},
+		[]dnet.APIKey{{Client: "acme", Tier: "gold", Key: "acme-key"}})
+	require.NoError(t, err)
+	handler.SetQuotas(quotas)
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	server := http.Server{Handler: handler.GetHTTPHandler()}
+	go func() { _ = server.Serve(listener) }()
+	defer func() { _ = server.Shutdown(ctx) }()
+
+	time.Sleep(50 * time.Millisecond)
+
+	get := func(apiKey string) *http.Response {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+			fmt.Sprintf("http://%s/%s/info", listener.Addr().String(), info.HashString()), http.NoBody)
+		require.NoError(t, err)
+		if apiKey != "" {
+			req.Header.Set("Authorization", "Bearer "+apiKey)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	require.Equal(t, http.StatusOK, get("").StatusCode)
+	resp := get("")
+	require.Equal(t, http.StatusTooManyRequests, resp.StatusCode, "the anonymous tier allows a single request")
+	require.NotEmpty(t, resp.Header.Get("Retry-After"))
+
+	for i := 0; i < 5; i++ {
+		require.Equal(t, http.StatusOK, get("acme-key").StatusCode)
+	}
+	require.Equal(t, http.StatusUnauthorized, get("stolen-key").StatusCode)
+
+	handler.SetQuotas(nil)
+	require.Equal(t, http.StatusOK, get("").StatusCode)
+}
+
+// randomnessClient resolves the randomness of the beacons it knows along with the beacons of the mock
+type randomnessClient struct {
+	client.Client
+	beacons []client.Result
+}
+
+func (r *randomnessClient) GetByRandomness(_ context.Context, randomness []byte) (client.Result, error) {
+	for _, b := range r.beacons {
+		if bytes.Equal(b.GetRandomness(), randomness) {
+			return b, nil
+		}
+	}
+	return nil, errors.New("no beacon with this randomness")
+}
+
+func TestHTTPPublicRandByRandomness(t *testing.T) {
+	lg := testlogger.New(t)
+	ctx := log.ToContext(context.Background(), lg)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	c, _ := withClient(t, clock.NewFakeClock())
+
+	handler, err := dhttp.New(ctx, "")
+	require.NoError(t, err)
+
+	info, err := c.Info(ctx)
+	require.NoError(t, err)
+	b, err := c.Get(ctx, 1)
+	require.NoError(t, err)
+
+	handler.RegisterNewBeaconHandler(&randomnessClient{Client: c, beacons: []client.Result{b}}, info.ChainHash())
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	server := http.Server{Handler: handler.GetHTTPHandler()}
+	go func() { _ = server.Serve(listener) }()
+	defer func() { _ = server.Shutdown(ctx) }()
+
+	time.Sleep(50 * time.Millisecond)
+
+	lookup := fmt.Sprintf("http://%s/%s/public/randomness/", listener.Addr().String(), info.ChainHash())
+	resp := getWithCtx(ctx, lookup+hex.EncodeToString(b.GetRandomness()), t)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Contains(t, resp.Header.Get("Cache-Control"), "immutable")
+	var beacon struct {
+		Round uint64 `json:"round"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&beacon))
+	resp.Body.Close()
+	require.Equal(t, b.GetRound(), beacon.Round)
+
+	resp = getWithCtx(ctx, lookup+strings.Repeat("00", 32), t)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = getWithCtx(ctx, lookup+"deadbeef", t)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+}
+
+type mapCache struct {
+	sync.Mutex
+	values map[string][]byte
+	gets   int
+}
+
+func (m *mapCache) Get(_ context.Context, key string) ([]byte, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.gets++
+	return m.values[key], nil
+}
+
+func (m *mapCache) SetNX(_ context.Context, key string, value []byte, _ time.Duration) (bool, error) {
+	m.Lock()
+	defer m.Unlock()
+	if _, ok := m.values[key]; ok {
+		return false, nil
+	}
+	m.values[key] = value
+	return true, nil
+}
+
+func TestHTTPSharedCache(t *testing.T) {
+	lg := testlogger.New(t)
+	ctx := log.ToContext(context.Background(), lg)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// the mock serves rounds from an hour ago
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	l, s := mock.NewMockGRPCPublicServer(t, lg, "127.0.0.1:0", false, sch, clock.NewFakeClockAt(time.Now().Add(-time.Hour)))
+	go l.Start()
+	defer l.Stop(ctx)
+	mockServer := s.(*mock.Server)
+	c := mock.NewGrpcClient(mockServer)
+
+	handler, err := dhttp.New(ctx, "")
+	require.NoError(t, err)
+
+	info, err := c.Info(ctx)
+	require.NoError(t, err)
+
+	handler.RegisterNewBeaconHandler(c, info.ChainHash())
+	cache := &mapCache{values: make(map[string][]byte)}
+	handler.SetSharedCache(cache)
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	server := http.Server{Handler: handler.GetHTTPHandler()}
+	go func() { _ = server.Serve(listener) }()
+	defer func() { _ = server.Shutdown(ctx) }()
+
+	time.Sleep(50 * time.Millisecond)
+
+	latest := func() uint64 {
+		resp := getWithCtx(ctx, fmt.Sprintf("http://%s/%s/public/latest", listener.Addr().String(), info.HashString()), t)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var beacon struct {
+			Round uint64 `json:"round"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&beacon))
+		return beacon.Round
+	}
+	key := func(round uint64) string {
+		return fmt.Sprintf("drand:beacon:%s:%d", info.HashString(), round)
+	}
+	share := func(round uint64, data []byte) {
+		cache.Lock()
+		defer cache.Unlock()
+		cache.values[key(round)] = data
+	}
+	expected := func() uint64 {
+		return common.CurrentRound(time.Now().Unix(), info.Period, info.GenesisTime)
+	}
+
+	// an invalid beacon shared for the round expected isn't served
+	forged, err := json.Marshal(&drand.PublicRandResponse{Round: expected(), Signature: []byte{1, 2, 3}})
+	require.NoError(t, err)
+	share(expected(), forged)
+	share(expected()+1, forged)
+	ours := latest()
+	require.Less(t, ours, expected())
+
+	// our latest beacon is shared with the other instances
+	cache.Lock()
+	require.Contains(t, string(cache.values[key(ours)]), fmt.Sprintf(`"round":%d`, ours))
+	cache.Unlock()
+
+	// a valid beacon of the round expected shared by another instance is served instead of ours, once the cache is
+	// asked again
+	time.Sleep(time.Second)
+	now := expected()
+	for _, round := range []uint64{now, now + 1} {
+		data, err := json.Marshal(mockServer.SignedBeacon(round))
+		require.NoError(t, err)
+		share(round, data)
+	}
+	require.GreaterOrEqual(t, latest(), now)
+
+	// and kept, the cache isn't asked for it on every request
+	cache.Lock()
+	gets := cache.gets
+	cache.Unlock()
+	require.GreaterOrEqual(t, latest(), now)
+	cache.Lock()
+	require.Equal(t, gets, cache.gets)
+	cache.Unlock()
+}
+
+func TestHTTPCORSAndSecurityHeaders(t *testing.T) {
+	lg := testlogger.New(t)
+	ctx := log.ToContext(context.Background(), lg)
+
+	handler, err := dhttp.New(ctx, "")
+	require.NoError(t, err)
+
+	serve := func(method, origin string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/chains", http.NoBody)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		rec := httptest.NewRecorder()
+		handler.GetHTTPHandler().ServeHTTP(rec, req)
+		return rec
+	}
+
+	// any origin by default, without security headers
+	rec := serve(http.MethodGet, "https://example.com", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+	require.Empty(t, rec.Header().Get("X-Content-Type-Options"))
+
+	cors := dhttp.CORS{
+		AllowedOrigins: []string{"https://example.com"},
+		AllowedHeaders: []string{"X-Requested-With"},
+		MaxAge:         10 * time.Minute,
+	}
+	require.NoError(t, cors.Validate())
+	handler.SetCORS(cors)
+	handler.SetSecurityHeaders(dhttp.SecurityHeaders{Enabled: true, HSTSMaxAge: time.Hour})
+
+	rec = serve(http.MethodGet, "https://example.com", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "https://example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	require.Equal(t, "Origin", rec.Header().Get("Vary"))
+	require.Equal(t, "nosniff", rec.Header().Get("X-Content-Type-Options"))
+	require.Equal(t, "DENY", rec.Header().Get("X-Frame-Options"))
+	require.Equal(t, "max-age=3600", rec.Header().Get("Strict-Transport-Security"))
+
+	rec = serve(http.MethodGet, "https://evil.example", nil)
+	require.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+
+	rec = serve(http.MethodOptions, "https://example.com", http.Header{"Access-Control-Request-Method": {"GET"}})
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.Equal(t, "https://example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	require.Equal(t, "X-Requested-With", rec.Header().Get("Access-Control-Allow-Headers"))
+	require.Equal(t, "600", rec.Header().Get("Access-Control-Max-Age"))
+	require.Contains(t, rec.Header().Get("Access-Control-Allow-Methods"), "GET")
+
+	require.Error(t, dhttp.CORS{AllowedOrigins: []string{"example.com"}}.Validate())
+	require.Error(t, dhttp.CORS{AllowedOrigins: []string{"https://example.com/path"}}.Validate())
+	require.NoError(t, dhttp.CORS{AllowedOrigins: []string{"*"}}.Validate())
+}
+
+func TestHTTPPrefetch(t *testing.T) {
+	lg := testlogger.New(t)
+	ctx := log.ToContext(context.Background(), lg)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	c, push := withClient(t, clock.NewFakeClockAt(time.Now()))
+
+	handler, err := dhttp.New(ctx, "")
+	require.NoError(t, err)
+
+	info, err := c.Info(ctx)
+	require.NoError(t, err)
+
+	cache := &mapCache{values: make(map[string][]byte)}
+	handler.SetSharedCache(cache)
+	handler.RegisterNewBeaconHandler(c, info.ChainHash())
+	// the watch is opened without waiting for a request
+	handler.SetPrefetch(time.Second)
+	time.Sleep(100 * time.Millisecond)
+
+	// the round received is shared as soon as it arrives
+	push(false)
+	prefix := "drand:beacon:" + info.HashString() + ":"
+	var shared []byte
+	require.Eventually(t, func() bool {
+		cache.Lock()
+		defer cache.Unlock()
+		for key, value := range cache.values {
+			if strings.HasPrefix(key, prefix) {
+				shared = value
+			}
+		}
+		return shared != nil
+	}, time.Second, 10*time.Millisecond)
+	var beacon struct {
+		Round uint64 `json:"round"`
+	}
+	require.NoError(t, json.Unmarshal(shared, &beacon))
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	server := http.Server{Handler: handler.GetHTTPHandler()}
+	go func() { _ = server.Serve(listener) }()
+	defer func() { _ = server.Shutdown(ctx) }()
+
+	// and served from memory
+	resp := getWithCtx(ctx, fmt.Sprintf("http://%s/%s/public/%d", listener.Addr().String(), info.HashString(), beacon.Round), t)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, validateBodyFormat(resp.Body, float64(beacon.Round)))
+
+	// the round received after a gap isn't kept, it is never served empty
+	_, err = c.Get(ctx, 0)
+	require.NoError(t, err)
+	push(false)
+	time.Sleep(100 * time.Millisecond)
+	gap := getWithCtx(ctx, fmt.Sprintf("http://%s/%s/public/%d", listener.Addr().String(), info.HashString(), beacon.Round+2), t)
+	defer gap.Body.Close()
+	body, err := io.ReadAll(gap.Body)
+	require.NoError(t, err)
+	require.False(t, gap.StatusCode == http.StatusOK && len(body) == 0, "empty response for round %d", beacon.Round+2)
+}
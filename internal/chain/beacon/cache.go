@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"sort"
 
 	"github.com/drand/drand/v2/common/log"
 	"github.com/drand/drand/v2/crypto"
@@ -89,6 +90,21 @@ func (c *partialCache) GetRoundCache(round uint64, previous []byte) *roundCache
 	return c.rounds[id]
 }
 
+// Packets returns the partials held by the cache
+func (c *partialCache) Packets() []*drand.PartialBeaconPacket {
+	var packets []*drand.PartialBeaconPacket
+	for _, round := range c.rounds {
+		for _, sig := range round.sigs {
+			packets = append(packets, &drand.PartialBeaconPacket{
+				Round:             round.round,
+				PreviousSignature: round.prev,
+				PartialSig:        sig,
+			})
+		}
+	}
+	return packets
+}
+
 // newRoundCache creates a new round cache given p. If the signer of the partial
 // already has more than `
 func (c *partialCache) getCache(id string, p *drand.PartialBeaconPacket) (*roundCache, error) {
@@ -166,15 +182,26 @@ func (r *roundCache) Len() int {
 	return len(r.sigs)
 }
 
-// Partials provides all cached partial signatures
+// Partials provides all cached partial signatures, ordered by the index of their signer
 func (r *roundCache) Partials() [][]byte {
-	partials := make([][]byte, 0, len(r.sigs))
-	for _, sig := range r.sigs {
-		partials = append(partials, sig)
+	indexes := r.Indexes()
+	partials := make([][]byte, 0, len(indexes))
+	for _, idx := range indexes {
+		partials = append(partials, r.sigs[idx])
 	}
 	return partials
 }
 
+// Indexes provides the indexes of the signers of the cached partial signatures, in increasing order
+func (r *roundCache) Indexes() []int {
+	indexes := make([]int, 0, len(r.sigs))
+	for idx := range r.sigs {
+		indexes = append(indexes, idx)
+	}
+	sort.Ints(indexes)
+	return indexes
+}
+
 func (r *roundCache) flushIndex(idx int) {
 	delete(r.sigs, idx)
 }
@@ -2,6 +2,7 @@ package beacon
 
 import (
 	"context"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -46,6 +47,35 @@ func TestStoreCallback(t *testing.T) {
 	require.False(t, checkOne(doneCh))
 }
 
+func TestStoreCallbackFlush(t *testing.T) {
+	dir := t.TempDir()
+	ctx, _, _ := context2.PrevSignatureMattersOnContext(t, context.Background())
+	l := testlogger.New(t)
+	bbstore, err := boltdb.NewBoltStore(ctx, l, dir, nil)
+	require.NoError(t, err)
+	cb := NewCallbackStore(l, bbstore)
+
+	release := make(chan struct{})
+	var called atomic.Int32
+	cb.AddCallback("slow", func(b *common.Beacon, closed bool) {
+		if closed {
+			return
+		}
+		<-release
+		called.Add(1)
+	})
+	require.NoError(t, cb.Put(ctx, &common.Beacon{Round: 1}))
+
+	// the callback is still blocked, so the flush can't complete
+	short, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, cb.Flush(short), context.DeadlineExceeded)
+
+	close(release)
+	require.NoError(t, cb.Flush(ctx))
+	require.Equal(t, int32(1), called.Load())
+}
+
 func checkOne(ch chan bool) bool {
 	select {
 	case <-ch:
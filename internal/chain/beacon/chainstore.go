@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
+	"sync/atomic"
 
 	"github.com/drand/drand/v2/common/tracer"
 
@@ -21,8 +23,7 @@ import (
 )
 
 const (
-	defaultPartialChanBuffer = 10
-	defaultNewBeaconBuffer   = 100
+	defaultNewBeaconBuffer = 100
 )
 
 // chainStore implements CallbackStore, Syncer and deals with reconstructing the
@@ -38,7 +39,13 @@ type chainStore struct {
 	ticker      *ticker
 	ctx         context.Context
 	ctxCancel   context.CancelFunc
-	newPartials chan partialInfo
+	newPartials *partialInbox
+	// lastStored is the last round stored, the partials of the rounds following it are aggregated first
+	lastStored atomic.Uint64
+	// journal keeps the partials of the rounds in flight across restarts, nil if they aren't kept
+	journal *partialJournal
+	// participation records whose partials each round aggregated is made of, nil if it isn't audited
+	participation *participationLog
 	// catchupBeacons is used to notify the Handler when a node has aggregated a
 	// beacon.
 	catchupBeacons chan *common.Beacon
@@ -81,6 +88,11 @@ func newChainStore(ctx context.Context, l log.Logger, cf *Config, cl net.Protoco
 		Client:      cl,
 		Clock:       cf.Clock,
 		NodeAddr:    cf.Public.Address(),
+
+		MaxInFlight:      cf.SyncMaxInFlight,
+		MemoryBudget:     cf.SyncMemoryBudget,
+		TombstonesFolder: cf.TombstonesFolder,
+		Sources:          cf.SyncSources,
 	})
 	if err != nil {
 		span.RecordError(err)
@@ -100,16 +112,41 @@ func newChainStore(ctx context.Context, l log.Logger, cf *Config, cl net.Protoco
 		ticker:          t,
 		ctx:             ctx,
 		ctxCancel:       ctxCancel,
-		newPartials:     make(chan partialInfo, defaultPartialChanBuffer),
+		newPartials:     newPartialInbox(common.GetCanonicalBeaconID(cf.Group.ID)),
 		catchupBeacons:  make(chan *common.Beacon, 1),
 		beaconStoredAgg: make(chan *common.Beacon, defaultNewBeaconBuffer),
 	}
+	if last, err := cbs.Last(ctx); err == nil {
+		cs.lastStored.Store(last.GetRound())
+	}
+	if cf.PartialsFolder != "" {
+		if err := cs.restorePartials(cf.PartialsFolder); err != nil {
+			ctxCancel()
+			syncm.Stop()
+			span.RecordError(err)
+			return nil, err
+		}
+	}
+	if cf.ParticipationFolder != "" {
+		if cs.participation, err = openParticipationLog(cf.ParticipationFolder); err != nil {
+			ctxCancel()
+			syncm.Stop()
+			if cs.journal != nil {
+				_ = cs.journal.close()
+			}
+			span.RecordError(err)
+			return nil, err
+		}
+	}
 	// we add callbacks to notify each time a final beacon is stored on the
 	// database so to update the latest view
 	cbs.AddCallback("chainstore", func(b *common.Beacon, closed bool) {
 		if closed {
 			return
 		}
+		if b.Round > cs.lastStored.Load() {
+			cs.lastStored.Store(b.Round)
+		}
 		cs.beaconStoredAgg <- b
 	})
 	// TODO maybe look if it's worth having multiple workers there
@@ -121,10 +158,57 @@ func newChainStore(ctx context.Context, l log.Logger, cf *Config, cl net.Protoco
 
 func (c *chainStore) NewValidPartial(ctx context.Context, addr string, p *drand.PartialBeaconPacket) {
 	spanCtx := oteltrace.SpanContextFromContext(ctx)
-	c.newPartials <- partialInfo{
+	c.newPartials.push(partialInfo{
 		spanContext: spanCtx,
 		addr:        addr,
 		p:           p,
+	}, c.lastStored.Load()+1)
+}
+
+// restorePartials queues the partials the previous run journaled for the rounds in flight, so that they are aggregated
+// as if they were just received, and keeps journaling the partials received from now on.
+func (c *chainStore) restorePartials(folder string) error {
+	journal, partials, err := openPartialJournal(c.l, folder, c.crypto.GetGroup().Hash())
+	if err != nil {
+		return err
+	}
+	c.journal = journal
+
+	restored := 0
+	for _, p := range partials {
+		// the journal is only trusted as much as the network, a corrupted partial would fail the recovery of its round
+		msg := c.crypto.DigestBeacon(p)
+		if err := c.crypto.VerifyPartialAt(common.Round(p.GetRound()), msg, p.GetPartialSig()); err != nil {
+			c.l.Warnw("ignoring an invalid partial of the journal", "round", p.GetRound(), "err", err)
+			continue
+		}
+		c.newPartials.push(partialInfo{addr: "journal", p: p}, c.lastStored.Load()+1)
+		restored++
+	}
+	if restored > 0 {
+		c.l.Infow("restored the partials of the previous run", "partials", restored)
+	}
+	return nil
+}
+
+// journalPartial keeps the partial, which was added to the cache, across restarts
+func (c *chainStore) journalPartial(p *drand.PartialBeaconPacket) {
+	if c.journal == nil {
+		return
+	}
+	if err := c.journal.append(p); err != nil && !errors.Is(err, errPartialJournalClosed) {
+		c.l.Warnw("unable to journal a partial", "round", p.GetRound(), "err", err)
+	}
+}
+
+// compactJournal drops the partials of the rounds flushed from the cache from the journal
+func (c *chainStore) compactJournal(cache *partialCache) {
+	if c.journal == nil {
+		return
+	}
+	err := c.journal.reset(c.crypto.GetGroup().Hash(), cache.Packets())
+	if err != nil && !errors.Is(err, errPartialJournalClosed) {
+		c.l.Warnw("unable to compact the journal of partials", "err", err)
 	}
 }
 
@@ -133,6 +217,32 @@ func (c *chainStore) Stop() {
 	c.syncm.Stop()
 	c.RemoveCallback("chainstore")
 	_ = c.CallbackStore.Close()
+	if c.journal != nil {
+		_ = c.journal.close()
+	}
+	if c.participation != nil {
+		_ = c.participation.close()
+	}
+}
+
+// recordParticipation records the signers of the partials aggregated into the round, if the participation is audited
+func (c *chainStore) recordParticipation(round uint64, signers []int) {
+	if c.participation == nil {
+		return
+	}
+	r := &RoundParticipation{Round: round}
+	group := c.crypto.GetGroup()
+	for _, idx := range signers {
+		r.Indexes = append(r.Indexes, uint32(idx))
+		addr := ""
+		if node := group.Node(uint32(idx)); node != nil {
+			addr = node.Address()
+		}
+		r.Addresses = append(r.Addresses, addr)
+	}
+	if err := c.participation.record(r); err != nil && !errors.Is(err, os.ErrClosed) {
+		c.l.Warnw("unable to record the participation", "round", round, "err", err)
+	}
 }
 
 // we store partials that are up to this amount of rounds more than the last
@@ -160,7 +270,12 @@ func (c *chainStore) runAggregator() {
 			return
 		case lastBeacon = <-c.beaconStoredAgg:
 			cache.FlushRounds(lastBeacon.Round)
-		case partial := <-c.newPartials:
+			c.compactJournal(cache)
+		case <-c.newPartials.ready:
+			partial, ok := c.newPartials.pop()
+			if !ok {
+				break
+			}
 			ctx, span := tracer.NewSpanFromSpanContext(c.ctx, partial.spanContext, "c.runAggregator")
 
 			span.SetAttributes(
@@ -206,8 +321,9 @@ func (c *chainStore) runAggregator() {
 			// participate in the randomness generation. Previous beacons can be
 			// verified using the single distributed public key point from the
 			// crypto store.
-			thr := c.crypto.GetGroup().Threshold
-			n := c.crypto.GetGroup().Len()
+			group := c.crypto.GroupAt(common.Round(pRound))
+			thr := group.Threshold
+			n := group.Len()
 
 			select {
 			case <-ctx.Done():
@@ -223,6 +339,7 @@ func (c *chainStore) runAggregator() {
 				span.RecordError(err)
 				break
 			}
+			c.journalPartial(partial.p)
 			roundCache := cache.GetRoundCache(partial.p.GetRound(), partial.p.GetPreviousSignature())
 			if roundCache == nil {
 				c.l.Errorw("no round cache", "from", partial.addr, "partial_round", partial.p.GetRound())
@@ -241,13 +358,20 @@ func (c *chainStore) runAggregator() {
 
 			msg := c.crypto.DigestBeacon(roundCache)
 
-			finalSig, err := c.crypto.Scheme.ThresholdScheme.Recover(c.crypto.GetPub(), msg, roundCache.Partials(), thr, n)
+			partials := roundCache.Partials()
+			var signers []int
+			if c.participation != nil {
+				// we aggregate exactly a threshold of partials, so that we know whose partials the signature is made of
+				signers, partials = roundCache.Indexes()[:thr], partials[:thr]
+			}
+			pub := c.crypto.PubAt(common.Round(pRound))
+			finalSig, err := c.crypto.Scheme.ThresholdScheme.Recover(pub, msg, partials, thr, n)
 			if err != nil {
 				c.l.Errorw("invalid_recovery", "error", err, "round", pRound, "got", fmt.Sprintf("%d/%d", roundCache.Len(), n))
 				span.RecordError(errors.New("invalid recovery"))
 				break
 			}
-			if err := c.crypto.Scheme.ThresholdScheme.VerifyRecovered(c.crypto.GetPub().Commit(), msg, finalSig); err != nil {
+			if err := c.crypto.Scheme.ThresholdScheme.VerifyRecovered(pub.Commit(), msg, finalSig); err != nil {
 				c.l.Errorw("invalid_sig", "error", err, "round", pRound)
 				span.RecordError(errors.New("invalid signature"))
 				span.End()
@@ -267,6 +391,7 @@ func (c *chainStore) runAggregator() {
 			c.l.Infow("", "aggregated_beacon", newBeacon.Round)
 			span.AddEvent("calling tryAppend")
 			if c.tryAppend(ctx, lastBeacon, newBeacon) {
+				c.recordParticipation(newBeacon.Round, signers)
 				lastBeacon = newBeacon
 				span.End()
 				break
@@ -380,6 +505,14 @@ func (c *chainStore) ValidateChain(ctx context.Context, upTo uint64, cb func(r,
 	return c.syncm.CheckPastBeacons(ctx, upTo, cb)
 }
 
+// InjectBeacon asks the sync manager to store a beacon obtained out-of-band, once verified against the chain
+func (c *chainStore) InjectBeacon(ctx context.Context, b *common.Beacon) (bool, error) {
+	ctx, span := tracer.NewSpan(ctx, "c.InjectBeacon")
+	defer span.End()
+
+	return c.syncm.InjectBeacon(ctx, b)
+}
+
 func (c *chainStore) AppendedBeaconNoSync() chan *common.Beacon {
 	return c.catchupBeacons
 }
@@ -17,6 +17,13 @@ const MaxPartialsPerNode = 100
 // a sync mechanism.
 const MaxCatchupBuffer = 1000
 
+// MaxLastBeaconFailures is the number of rounds in a row the beacon loop can fail to load the last beacon from the
+// store before giving up, so that the store can be reopened.
+const MaxLastBeaconFailures = 10
+
 // CallbackWorkerQueue is the length of the channel that the callback worker
 // uses to dispatch beacons to its workers.
 const CallbackWorkerQueue = 100
+
+// drainCallbackID is the ID of the callback waiting for the round in progress to be stored while draining
+const drainCallbackID = "drain"
@@ -0,0 +1,145 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/drand/kyber"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/log"
+	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/chain"
+)
+
+// FenceFileName is the name of the marker written next to the database before its first write, and removed when it
+// is closed cleanly. Finding it when opening the database means the last writes might have been torn by a crash.
+const FenceFileName = "write.fence"
+
+// DefaultRepairDepth is the maximum number of rounds at the end of the chain checked after an unclean shutdown
+const DefaultRepairDepth = 16
+
+// FencedStore writes a marker before the first write to the underlying store, and removes it once the store is
+// closed cleanly, so that an unclean shutdown can be detected the next time the store is opened. The marker of an
+// unclean shutdown is kept until the store is repaired, so that the repair isn't lost if it is put off.
+type FencedStore struct {
+	chain.Store
+	marker  string
+	unclean atomic.Bool
+
+	once sync.Once
+	err  error
+}
+
+// NewFencedStore returns a store fencing the writes to the given store with a marker in the given folder, and
+// whether the marker of a previous run was still there, meaning that the store wasn't closed cleanly.
+func NewFencedStore(s chain.Store, folder string) (*FencedStore, bool, error) {
+	f := &FencedStore{Store: s, marker: path.Join(folder, FenceFileName)}
+	_, err := os.Stat(f.marker)
+	switch {
+	case err == nil:
+		f.unclean.Store(true)
+		return f, true, nil
+	case errors.Is(err, os.ErrNotExist):
+		return f, false, nil
+	default:
+		return nil, false, fmt.Errorf("unable to check the write fence: %w", err)
+	}
+}
+
+// Unclean returns whether the store wasn't closed cleanly the last time, and wasn't repaired since
+func (f *FencedStore) Unclean() bool {
+	return f.unclean.Load()
+}
+
+// MarkRepaired records that the last rounds of the store were checked after an unclean shutdown, so that its marker
+// is removed once it is closed cleanly
+func (f *FencedStore) MarkRepaired() {
+	f.unclean.Store(false)
+}
+
+// fence makes sure the marker is on disk before the first write goes through
+func (f *FencedStore) fence(round uint64) error {
+	f.once.Do(func() {
+		fd, err := os.OpenFile(f.marker, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+		if err != nil {
+			f.err = fmt.Errorf("unable to write the write fence: %w", err)
+			return
+		}
+		defer fd.Close()
+		// the round of the first write is only informative, finding the marker is what matters
+		if _, err := fd.WriteString(strconv.FormatUint(round, 10)); err != nil {
+			f.err = fmt.Errorf("unable to write the write fence: %w", err)
+			return
+		}
+		if err := fd.Sync(); err != nil {
+			f.err = fmt.Errorf("unable to sync the write fence: %w", err)
+		}
+	})
+	return f.err
+}
+
+func (f *FencedStore) Put(ctx context.Context, b *common.Beacon) error {
+	if err := f.fence(b.Round); err != nil {
+		return err
+	}
+	return f.Store.Put(ctx, b)
+}
+
+func (f *FencedStore) Del(ctx context.Context, round common.Round) error {
+	if err := f.fence(round.Uint64()); err != nil {
+		return err
+	}
+	return f.Store.Del(ctx, round)
+}
+
+// Close closes the underlying store, and only removes the marker if it was closed cleanly and isn't waiting for a
+// repair
+func (f *FencedStore) Close() error {
+	if err := f.Store.Close(); err != nil {
+		return err
+	}
+	if f.Unclean() {
+		return nil
+	}
+	if err := os.Remove(f.marker); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("unable to remove the write fence: %w", err)
+	}
+	return nil
+}
+
+// RepairTail checks the last rounds of the store against the public key of the chain, going back from the last one
+// until a valid beacon is found or depth rounds were checked, and deletes the invalid ones so that they are synced
+// again from the other nodes. It returns the rounds deleted, from the most recent one.
+// It is meant to run after an unclean shutdown, before the store is used, to fix a torn last write.
+func RepairTail(ctx context.Context, l log.Logger, store chain.Store, sch *crypto.Scheme, pub kyber.Point, depth int) ([]uint64, error) {
+	if sch.Name == crypto.DefaultSchemeID {
+		ctx = chain.SetPreviousRequiredOnContext(ctx)
+	}
+	last, err := store.Last(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the last beacon: %w", err)
+	}
+
+	var deleted []uint64
+	for round := last.Round; round > 0 && len(deleted) < depth; round-- {
+		b, err := store.Get(ctx, common.Round(round))
+		if err == nil {
+			if err = sch.VerifyBeacon(b, pub); err == nil {
+				break
+			}
+		}
+		l.Warnw("deleting an invalid beacon left by an unclean shutdown", "round", round, "err", err)
+		if err := store.Del(ctx, common.Round(round)); err != nil {
+			return deleted, fmt.Errorf("unable to delete round %d: %w", round, err)
+		}
+		deleted = append(deleted, round)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,111 @@
+package beacon
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/drand/kyber/share"
+	"github.com/drand/kyber/sign/tbls"
+	"github.com/drand/kyber/util/random"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/testlogger"
+	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/chain"
+	"github.com/drand/drand/v2/internal/chain/boltdb"
+)
+
+func TestFencedStoreDetectsUncleanShutdown(t *testing.T) {
+	ctx := context.Background()
+	l := testlogger.New(t)
+	dir := t.TempDir()
+	marker := path.Join(dir, FenceFileName)
+
+	bstore, err := boltdb.NewBoltStore(ctx, l, dir, nil)
+	require.NoError(t, err)
+	s, unclean, err := NewFencedStore(bstore, dir)
+	require.NoError(t, err)
+	require.False(t, unclean)
+	// nothing is written until the first write
+	require.NoFileExists(t, marker)
+
+	require.NoError(t, s.Put(ctx, chain.GenesisBeacon([]byte("seed"))))
+	require.FileExists(t, marker)
+	require.NoError(t, s.Close())
+	require.NoFileExists(t, marker)
+
+	// a crash leaves the marker behind
+	bstore, err = boltdb.NewBoltStore(ctx, l, dir, nil)
+	require.NoError(t, err)
+	s, unclean, err = NewFencedStore(bstore, dir)
+	require.NoError(t, err)
+	require.False(t, unclean)
+	require.NoError(t, s.Put(ctx, &common.Beacon{Round: 1, Signature: []byte("sig")}))
+	require.NoError(t, bstore.Close())
+
+	bstore, err = boltdb.NewBoltStore(ctx, l, dir, nil)
+	require.NoError(t, err)
+	s, unclean, err = NewFencedStore(bstore, dir)
+	require.NoError(t, err)
+	require.True(t, unclean)
+	require.True(t, s.Unclean())
+	// the marker is kept until the store is repaired
+	require.NoError(t, s.Close())
+	require.FileExists(t, marker)
+
+	bstore, err = boltdb.NewBoltStore(ctx, l, dir, nil)
+	require.NoError(t, err)
+	s, unclean, err = NewFencedStore(bstore, dir)
+	require.NoError(t, err)
+	require.True(t, unclean)
+	s.MarkRepaired()
+	require.False(t, s.Unclean())
+	require.NoError(t, s.Close())
+	_, err = os.Stat(marker)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestRepairTail(t *testing.T) {
+	ctx := context.Background()
+	l := testlogger.New(t)
+	sch, err := crypto.SchemeFromName(crypto.DefaultSchemeID)
+	require.NoError(t, err)
+	ctx = chain.SetPreviousRequiredOnContext(ctx)
+
+	secret := sch.KeyGroup.Scalar().Pick(random.New())
+	pub := sch.KeyGroup.Point().Mul(secret, nil)
+
+	store, err := boltdb.NewBoltStore(ctx, l, t.TempDir(), nil)
+	require.NoError(t, err)
+	defer store.Close()
+
+	previous := []byte("seed")
+	require.NoError(t, store.Put(ctx, chain.GenesisBeacon(previous)))
+	for round := uint64(1); round <= 10; round++ {
+		msg := sch.DigestBeacon(&common.Beacon{Round: round, PreviousSig: previous})
+		sig, err := sch.ThresholdScheme.Sign(&share.PriShare{I: 0, V: secret}, msg)
+		require.NoError(t, err)
+		sigShare := tbls.SigShare(sig)
+		b := &common.Beacon{Round: round, PreviousSig: previous, Signature: sigShare.Value()}
+		require.NoError(t, store.Put(ctx, b))
+		previous = b.Signature
+	}
+
+	// an intact chain is left untouched
+	deleted, err := RepairTail(ctx, l, store, sch, pub, DefaultRepairDepth)
+	require.NoError(t, err)
+	require.Empty(t, deleted)
+
+	// a torn last write
+	require.NoError(t, store.Put(ctx, &common.Beacon{Round: 11, PreviousSig: previous, Signature: previous[:10]}))
+	deleted, err = RepairTail(ctx, l, store, sch, pub, DefaultRepairDepth)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{11}, deleted)
+
+	last, err := store.Last(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), last.Round)
+}
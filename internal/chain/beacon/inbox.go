@@ -0,0 +1,90 @@
+package beacon
+
+import (
+	"sync"
+
+	"github.com/drand/drand/v2/internal/metrics"
+)
+
+// MaxCurrentPartials is the maximum number of partials for the next two rounds to store waiting to be aggregated.
+// A well-behaved group sends at most one partial per node and per round, so this is only reached during floods.
+const MaxCurrentPartials = 256
+
+// MaxOtherPartials is the maximum number of partials for any other round waiting to be aggregated. These are
+// mostly useless to the aggregator, so we keep a tight bound on the memory they can use when a peer replays history.
+const MaxOtherPartials = 32
+
+// partialInbox is the bounded queue of the valid partials waiting for the aggregation loop. Partials for the round
+// following the last one stored and the one after it are always dequeued first, whatever the time says: a node
+// lagging behind the wall clock aggregates the rounds it misses first. Each class has its own bound: when a queue is
+// full, its oldest partial is shed so that the beacon loop never blocks on a flood of partials.
+type partialInbox struct {
+	sync.Mutex
+	beaconID string
+	current  []partialInfo
+	other    []partialInfo
+	// ready holds a token whenever the inbox isn't empty
+	ready chan struct{}
+}
+
+func newPartialInbox(beaconID string) *partialInbox {
+	return &partialInbox{
+		beaconID: beaconID,
+		ready:    make(chan struct{}, 1),
+	}
+}
+
+// push queues the given partial, prioritizing it if it is for the given round to store next or the one after it
+func (i *partialInbox) push(p partialInfo, nextRound uint64) {
+	i.Lock()
+	defer i.Unlock()
+
+	if round := p.p.GetRound(); round == nextRound || round == nextRound+1 {
+		if len(i.current) == MaxCurrentPartials {
+			metrics.PartialShed(i.beaconID, "current")
+			i.current = i.current[1:]
+		}
+		i.current = append(i.current, p)
+	} else {
+		if len(i.other) == MaxOtherPartials {
+			metrics.PartialShed(i.beaconID, "other")
+			i.other = i.other[1:]
+		}
+		i.other = append(i.other, p)
+	}
+	i.notify()
+}
+
+// pop returns the next partial to aggregate, if any
+func (i *partialInbox) pop() (partialInfo, bool) {
+	i.Lock()
+	defer i.Unlock()
+
+	var p partialInfo
+	switch {
+	case len(i.current) > 0:
+		p, i.current = i.current[0], i.current[1:]
+	case len(i.other) > 0:
+		p, i.other = i.other[0], i.other[1:]
+	default:
+		return p, false
+	}
+	if len(i.current)+len(i.other) > 0 {
+		i.notify()
+	}
+	return p, true
+}
+
+// Len returns the number of partials waiting in the inbox
+func (i *partialInbox) Len() int {
+	i.Lock()
+	defer i.Unlock()
+	return len(i.current) + len(i.other)
+}
+
+func (i *partialInbox) notify() {
+	select {
+	case i.ready <- struct{}{}:
+	default:
+	}
+}
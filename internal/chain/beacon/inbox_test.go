@@ -0,0 +1,43 @@
+package beacon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	proto "github.com/drand/drand/v2/protobuf/drand"
+)
+
+func TestPartialInboxPrioritizesCurrentRound(t *testing.T) {
+	inbox := newPartialInbox("default")
+	inbox.push(partialInfo{p: &proto.PartialBeaconPacket{Round: 3}}, 10)
+	inbox.push(partialInfo{p: &proto.PartialBeaconPacket{Round: 10}}, 10)
+	inbox.push(partialInfo{p: &proto.PartialBeaconPacket{Round: 50}}, 10)
+	inbox.push(partialInfo{p: &proto.PartialBeaconPacket{Round: 11}}, 10)
+
+	var rounds []uint64
+	for {
+		p, ok := inbox.pop()
+		if !ok {
+			break
+		}
+		rounds = append(rounds, p.p.GetRound())
+	}
+	require.Equal(t, []uint64{10, 11, 3, 50}, rounds)
+}
+
+func TestPartialInboxShedsOldestWhenFull(t *testing.T) {
+	inbox := newPartialInbox("default")
+	for i := 0; i < MaxOtherPartials+5; i++ {
+		inbox.push(partialInfo{p: &proto.PartialBeaconPacket{Round: uint64(100 + i)}}, 10)
+	}
+	require.Equal(t, MaxOtherPartials, inbox.Len())
+
+	p, ok := inbox.pop()
+	require.True(t, ok)
+	require.Equal(t, uint64(105), p.p.GetRound())
+
+	// the inbox keeps signaling while it isn't empty
+	<-inbox.ready
+	require.Equal(t, MaxOtherPartials-1, inbox.Len())
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/drand/drand/v2/common/tracer"
 	"github.com/drand/drand/v2/crypto/vault"
 	"github.com/drand/drand/v2/internal/chain"
+	"github.com/drand/drand/v2/internal/crash"
 	"github.com/drand/drand/v2/internal/metrics"
 	"github.com/drand/drand/v2/internal/net"
 	proto "github.com/drand/drand/v2/protobuf/drand"
@@ -33,6 +35,26 @@ type Config struct {
 	Group *key.Group
 	// Clock to use - useful to testing
 	Clock clock.Clock
+	// PublicKeyCacheSize is the number of member public keys kept precomputed to verify partials, 0 for the default
+	PublicKeyCacheSize int
+	// SyncMaxInFlight is the number of beacons buffered per stream when syncing, 0 for the default
+	SyncMaxInFlight int
+	// SyncMemoryBudget is the memory, in bytes, the beacons buffered while syncing can use, 0 for the default
+	SyncMemoryBudget int64
+	// SyncSources restricts and orders the peers the chain is synced from, nil to sync from any of them
+	SyncSources *SyncSources
+	// PartialsFolder is the folder where the partials of the rounds in flight, and the ones to push again to the peers,
+	// are kept across restarts, none if empty
+	PartialsFolder string
+	// ParticipationFolder is the folder where the signers of the partials aggregated into each round are recorded, none
+	// if empty
+	ParticipationFolder string
+	// TombstonesFolder is the folder where the beacons replaced when correcting the chain are kept, none if empty
+	TombstonesFolder string
+	// Signer signs the partials in place of the share, if set
+	Signer vault.PartialSigner
+	// RetryPartials tells whether the partials which failed to reach a peer are pushed again, they always are if nil
+	RetryPartials func() bool
 }
 
 // Handler holds the logic to initiate, and react to the tBLS protocol. Each time
@@ -50,6 +72,8 @@ type Handler struct {
 	chain            *chainStore
 	ticker           *ticker
 	thresholdMonitor *metrics.ThresholdMonitor
+	shareUsage       *metrics.ShareUsageMonitor
+	retries          *partialRetries
 
 	ctx       context.Context
 	ctxCancel context.CancelFunc
@@ -62,6 +86,16 @@ type Handler struct {
 	stopped bool
 	version common.Version
 	l       log.Logger
+
+	// the last round we sign partials for, 0 if there is none
+	lastPartialRound uint64
+	// no partials are signed while paused
+	paused bool
+
+	// closed when the beacon loop exits because of an unrecoverable error, which is then failure
+	failed     chan struct{}
+	failedOnce sync.Once
+	failure    error
 }
 
 // NewHandler returns a fresh handler ready to serve and create randomness
@@ -87,6 +121,13 @@ func NewHandler(ctx context.Context, c net.ProtocolClient, s chain.Store, conf *
 	addr := conf.Public.Address()
 
 	v := vault.NewVault(l, conf.Group, conf.Share, conf.Group.Scheme)
+	beaconID := common.GetCanonicalBeaconID(conf.Group.ID)
+	v.SetPublicKeyCache(conf.PublicKeyCacheSize, func(hit bool) {
+		metrics.PublicKeyCacheLookup(beaconID, hit)
+	})
+	if conf.Signer != nil {
+		v.SetSigner(conf.Signer, conf.Public.Identity)
+	}
 	// insert genesis beacon
 	if err := s.Put(ctx, chain.GenesisBeacon(conf.Group.GenesisSeed)); err != nil {
 		span.RecordError(err)
@@ -114,6 +155,12 @@ func NewHandler(ctx context.Context, c net.ProtocolClient, s chain.Store, conf *
 		l:                l,
 		version:          version,
 		thresholdMonitor: metrics.NewThresholdMonitor(conf.Group.ID, l, conf.Group.Len(), conf.Group.Threshold),
+		shareUsage:       metrics.NewShareUsageMonitor(conf.Group.ID, l, conf.Group.Period),
+		retries:          newPartialRetries(beaconID, c, conf.Clock, l),
+		failed:           make(chan struct{}),
+	}
+	if conf.PartialsFolder != "" {
+		handler.retries.restore(ctx, conf.PartialsFolder, conf.Group)
 	}
 	return handler, nil
 }
@@ -163,7 +210,7 @@ func (h *Handler) ProcessPartialBeacon(ctx context.Context, p *proto.PartialBeac
 		return nil, err
 	}
 
-	node := h.crypto.GetGroup().Node(uint32(idx))
+	node := h.crypto.GroupAt(common.Round(pRound)).Node(uint32(idx))
 	if node == nil {
 		err := fmt.Errorf("attempted to process beacon from node of index %d, but it was not in the group file", uint32(idx))
 		span.RecordError(err)
@@ -178,9 +225,9 @@ func (h *Handler) ProcessPartialBeacon(ctx context.Context, p *proto.PartialBeac
 	}
 
 	// verify if request is valid
-	span.AddEvent("h.crypto.ThresholdScheme.VerifyPartial")
-	err = h.crypto.ThresholdScheme.VerifyPartial(h.crypto.GetPub(), msg, p.GetPartialSig())
-	span.AddEvent("h.crypto.ThresholdScheme.VerifyPartial - done")
+	span.AddEvent("h.crypto.VerifyPartial")
+	err = h.crypto.VerifyPartialAt(common.Round(pRound), msg, p.GetPartialSig())
+	span.AddEvent("h.crypto.VerifyPartial - done")
 
 	if err != nil {
 		h.l.Errorw("",
@@ -219,6 +266,61 @@ func (h *Handler) ProcessPartialBeacon(ctx context.Context, p *proto.PartialBeac
 	return new(proto.Empty), nil
 }
 
+// ShareUsage returns the number of partials signed with the local share and how many of them were anomalous
+func (h *Handler) ShareUsage() (signed, anomalies uint64) {
+	return h.shareUsage.Counts()
+}
+
+// FailingNodes returns the number of members we recently failed to send our partials to
+func (h *Handler) FailingNodes() int {
+	return h.thresholdMonitor.FailingNodes()
+}
+
+// IsFailing returns whether we recently failed to send our partials to the member at the given address
+func (h *Handler) IsFailing(addr string) bool {
+	return h.thresholdMonitor.IsFailing(addr)
+}
+
+// StopPartialsAfter makes the handler stop signing partials for the rounds after the given one, while it keeps
+// following the chain. It is used by nodes leaving the group.
+func (h *Handler) StopPartialsAfter(round uint64) {
+	h.Lock()
+	defer h.Unlock()
+
+	h.lastPartialRound = round
+}
+
+// WipeShare drops the share the handler signs the partials with, once it was wiped from the disk. The handler keeps
+// the group to verify the partials and beacons of the others.
+func (h *Handler) WipeShare() {
+	h.Lock()
+	defer h.Unlock()
+
+	h.conf.Share = nil
+	h.crypto.SetInfo(h.crypto.GetGroup(), nil)
+}
+
+// SetSyncSources replaces the rules restricting the peers the chain is synced from, nil to sync from any of them
+func (h *Handler) SetSyncSources(sources *SyncSources) {
+	h.chain.syncm.SetSources(sources)
+}
+
+// SetPaused makes the handler stop or resume signing partials, while it keeps following the chain and serving it
+func (h *Handler) SetPaused(paused bool) {
+	h.Lock()
+	defer h.Unlock()
+
+	h.paused = paused
+}
+
+// IsPaused returns whether the handler is paused
+func (h *Handler) IsPaused() bool {
+	h.Lock()
+	defer h.Unlock()
+
+	return h.paused
+}
+
 // Store returns the store associated with this beacon handler
 func (h *Handler) Store() CallbackStore {
 	return h.chain
@@ -277,7 +379,7 @@ func (h *Handler) Transition(ctx context.Context, prevGroup *key.Group) error {
 
 	targetTime := h.conf.Group.TransitionTime
 	tRound := common.CurrentRound(targetTime, h.conf.Group.Period, h.conf.Group.GenesisTime)
-	tTime := common.TimeOfRound(h.conf.Group.Period, h.conf.Group.GenesisTime, tRound)
+	tTime := common.TimeOfRound(h.conf.Group.Period, h.conf.Group.GenesisTime, common.Round(tRound))
 	if tTime != targetTime {
 		h.l.Fatalw("", "transition_time", "invalid_offset", "expected_time", tTime, "got_time", targetTime)
 		return nil
@@ -305,12 +407,15 @@ func (h *Handler) TransitionNewGroup(ctx context.Context, newShare *key.Share, n
 
 	targetTime := newGroup.TransitionTime
 	tRound := common.CurrentRound(targetTime, h.conf.Group.Period, h.conf.Group.GenesisTime)
-	tTime := common.TimeOfRound(h.conf.Group.Period, h.conf.Group.GenesisTime, tRound)
+	tTime := common.TimeOfRound(h.conf.Group.Period, h.conf.Group.GenesisTime, common.Round(tRound))
 	if tTime != targetTime {
 		h.l.Fatalw("", "transition_time", "invalid_offset", "expected_time", tTime, "got_time", targetTime)
 		return
 	}
 	h.l.Infow("Preparing transition to new group", "at_round", tRound)
+	// the partials of the rounds of the new group are signed and verified with its share and public polynomial from
+	// now on, as the other nodes may sign them before we store the round preceding the transition
+	h.crypto.SetNextInfo(newGroup, newShare, common.Round(tRound))
 	// register a callback such that when the round happening just before the
 	// transition is stored, then it switches the current share to the new one
 	targetRound := tRound - 1
@@ -355,12 +460,21 @@ func (h *Handler) run(startTime int64) {
 	h.Lock()
 	h.running = true
 	h.Unlock()
+	var current roundInfo
+	defer crash.Recover(h.l, crash.Scope{
+		Module:   "beacon",
+		BeaconID: common.GetCanonicalBeaconID(h.conf.Group.ID),
+		Details: func() map[string]string {
+			return map[string]string{"round": strconv.FormatUint(current.round, 10)}
+		},
+	}, h.fail)
 
 	chanTick := h.ticker.ChannelAt(startTime)
 	h.l.Infow("starting handler run", "startTime", startTime, "current time", h.conf.Clock.Now().Unix())
 
-	var current roundInfo
 	setServing := sync.Once{}
+	// the store failing every round means it is broken, which only reopening it can fix
+	lastFailures := 0
 
 	for {
 		select {
@@ -385,8 +499,13 @@ func (h *Handler) run(startTime int64) {
 				if err != nil {
 					span.RecordError(err)
 					h.l.Errorw("", "beacon_loop", "loading_last", "err", err)
+					lastFailures++
+					if lastFailures >= MaxLastBeaconFailures {
+						h.fail(fmt.Errorf("unable to load the last beacon %d rounds in a row: %w", lastFailures, err))
+					}
 					return
 				}
+				lastFailures = 0
 				h.l.Debugw("", "beacon_loop", "new_round", "round", current.round, "lastbeacon", lastBeacon.Round)
 				h.broadcastNextPartial(ctx, current, lastBeacon)
 				// if the next round of the last beacon we generated is not the round we
@@ -465,17 +584,40 @@ func (h *Handler) broadcastNextPartial(ctx context.Context, current roundInfo, u
 		round = current.round
 	}
 
+	h.Lock()
+	lastPartialRound := h.lastPartialRound
+	paused := h.paused
+	h.Unlock()
+	if lastPartialRound > 0 && round > lastPartialRound {
+		h.l.Debugw("not signing partial, this node is leaving the group", "round", round, "last_round", lastPartialRound)
+		return
+	}
+	if paused {
+		h.l.Debugw("not signing partial, this node is paused", "round", round)
+		return
+	}
+
 	msg := h.crypto.DigestBeacon(&common.Beacon{
 		Round:       round,
 		PreviousSig: previousSig,
 	})
 
-	currSig, err := h.crypto.SignPartial(msg)
-	if err != nil {
+	currSig, err := h.crypto.SignPartialAt(common.Round(round), msg)
+	if err == nil && h.conf.Signer != nil {
+		// an external signer may be misconfigured, its partials are checked before being broadcast
+		err = h.crypto.VerifyPartialAt(common.Round(round), msg, currSig)
+	}
+	if err != nil && h.conf.Signer != nil {
+		// an external signer may be unreachable for a while, the node keeps running until it is back
+		span.RecordError(err)
+		h.l.Errorw("external signer failed to create the partial signature", "err", err, "round", round)
+		return
+	} else if err != nil {
 		span.RecordError(err)
 		h.l.Fatalw("err creating partial signature", "err", err, "round", round)
 		return
 	}
+	h.shareUsage.Signed(round, h.conf.Clock.Now())
 	h.l.Debugw("", "broadcast_partial", round, "prev_sig", shortSigStr(previousSig), "msg_sign", shortSigStr(msg))
 	metadata := proto.NewMetadata(h.version.ToProto())
 	metadata.BeaconID = beaconID
@@ -488,6 +630,7 @@ func (h *Handler) broadcastNextPartial(ctx context.Context, current roundInfo, u
 	}
 
 	h.chain.NewValidPartial(ctx, h.addr, packet)
+	expiry := time.Unix(common.TimeOfRound(h.conf.Group.Period, h.conf.Group.GenesisTime, common.Round(round+1)), 0)
 	for _, id := range h.crypto.GetGroup().Nodes {
 		select {
 		case <-ctx.Done():
@@ -520,6 +663,10 @@ func (h *Handler) broadcastNextPartial(ctx context.Context, current roundInfo, u
 				h.thresholdMonitor.ReportFailure(beaconID, i.Address())
 				span.RecordError(err)
 				h.l.Errorw("error sending partial", "round", round, "err", err, "to", i.Address())
+				// the partial is still useful to the peer until the end of the period of its round
+				if h.conf.RetryPartials == nil || h.conf.RetryPartials() {
+					h.retries.enqueue(h.ctx, i, packet, expiry)
+				}
 				return
 			}
 			metrics.SuccessfulPartial(beaconID, i.Address())
@@ -527,6 +674,33 @@ func (h *Handler) broadcastNextPartial(ctx context.Context, current roundInfo, u
 	}
 }
 
+// fail stops the beacon loop because of an unrecoverable error, and reports it to whoever supervises the handler
+func (h *Handler) fail(err error) {
+	h.failedOnce.Do(func() {
+		h.l.Errorw("beacon loop failed", "err", err)
+		h.Lock()
+		h.failure = err
+		h.running = false
+		h.Unlock()
+		h.ctxCancel()
+		close(h.failed)
+	})
+}
+
+// Failed returns a channel closed when the beacon loop exits because of an unrecoverable error, such as a panic or a
+// store failing every round. It isn't closed when the handler is stopped. The handler must then be stopped and
+// replaced by a new one.
+func (h *Handler) Failed() <-chan struct{} {
+	return h.failed
+}
+
+// Err returns the error which made the beacon loop fail, nil if it didn't
+func (h *Handler) Err() error {
+	h.Lock()
+	defer h.Unlock()
+	return h.failure
+}
+
 // Stop the beacon loop from aggregating  further randomness, but it
 // finishes the one it is aggregating currently.
 func (h *Handler) Stop(ctx context.Context) {
@@ -538,6 +712,8 @@ func (h *Handler) Stop(ctx context.Context) {
 	if h.stopped {
 		return
 	}
+	// the queues of the partials to push again are journaled before their workers stop
+	h.retries.close()
 	h.ctxCancel()
 
 	h.ticker.Stop()
@@ -549,6 +725,53 @@ func (h *Handler) Stop(ctx context.Context) {
 	h.l.Infow("beacon handler stopped", "time", h.conf.Clock.Now())
 }
 
+// Drain stops signing the partials of the rounds after the one in progress, and waits until that round is stored and
+// the callbacks were called with it, or until the context is done, before stopping the handler. The partials of the
+// other members are still processed meanwhile, so that the node contributes to the round in progress.
+func (h *Handler) Drain(ctx context.Context) error {
+	ctx, span := tracer.NewSpan(ctx, "h.Drain")
+	defer span.End()
+
+	defer h.Stop(ctx)
+	if !h.IsRunning() {
+		return nil
+	}
+
+	round := common.CurrentRound(h.conf.Clock.Now().Unix(), h.conf.Group.Period, h.conf.Group.GenesisTime)
+	h.Lock()
+	if h.lastPartialRound == 0 || round < h.lastPartialRound {
+		h.lastPartialRound = round
+	}
+	h.Unlock()
+
+	// registered before reading the last beacon, so that the round isn't missed if it's stored in between
+	stored := make(chan struct{}, 1)
+	h.chain.AddCallback(drainCallbackID, func(b *common.Beacon, closed bool) {
+		if closed || b.Round < round {
+			return
+		}
+		select {
+		case stored <- struct{}{}:
+		default:
+		}
+	})
+	defer h.chain.RemoveCallback(drainCallbackID)
+
+	h.l.Infow("draining the beacon handler", "round", round)
+	if last, err := h.chain.Last(ctx); err != nil || last.Round < round {
+		select {
+		case <-stored:
+		case <-ctx.Done():
+			return fmt.Errorf("round %d wasn't stored while draining: %w", round, ctx.Err())
+		}
+	}
+	if err := h.chain.Flush(ctx); err != nil {
+		return fmt.Errorf("unable to flush the callbacks while draining: %w", err)
+	}
+	h.l.Infow("beacon handler drained", "round", round)
+	return nil
+}
+
 // StopAt will stop the handler at the given time. It is useful when
 // transitioning for a resharing.
 func (h *Handler) StopAt(ctx context.Context, stopTime int64) error {
@@ -612,6 +835,15 @@ func (h *Handler) CorrectChain(ctx context.Context, faultyBeacons []uint64, peer
 	return h.chain.RunReSync(ctx, faultyBeacons, peers, cb)
 }
 
+// InjectBeacon stores a beacon obtained out-of-band, e.g. from the store of another node, once verified against the
+// chain. It returns false if the store already had it.
+func (h *Handler) InjectBeacon(ctx context.Context, b *common.Beacon) (bool, error) {
+	ctx, span := tracer.NewSpan(ctx, "h.InjectBeacon")
+	defer span.End()
+
+	return h.chain.InjectBeacon(ctx, b)
+}
+
 func shortSigStr(sig []byte) string {
 	maxi := 3
 	if len(sig) < maxi {
@@ -633,6 +633,45 @@ func TestProcessingPartialBeaconWithNonExistentIndexDoesntSegfault(t *testing.T)
 	require.Error(t, err, "attempted to process beacon from node of index 25958, but it was not in the group file")
 }
 
+func TestHandlerFail(t *testing.T) {
+	ctx := context.Background()
+	bt := NewBeaconTest(ctx, t, clock.NewFakeClock(), 3, 2, 30*time.Second, 0, "default")
+	h := bt.nodes[0].handler
+
+	select {
+	case <-h.Failed():
+		t.Fatal("the handler shouldn't have failed yet")
+	default:
+	}
+	require.NoError(t, h.Err())
+
+	failure := errors.New("store closed")
+	h.fail(failure)
+	h.fail(errors.New("reported once"))
+
+	select {
+	case <-h.Failed():
+	default:
+		t.Fatal("the handler should have failed")
+	}
+	require.ErrorIs(t, h.Err(), failure)
+	require.False(t, h.IsRunning())
+}
+
+func TestHandlerWipeShare(t *testing.T) {
+	ctx := context.Background()
+	bt := NewBeaconTest(ctx, t, clock.NewFakeClock(), 3, 2, 30*time.Second, 0, "default")
+	h := bt.nodes[0].handler
+
+	_, err := h.crypto.SignPartial([]byte("hello"))
+	require.NoError(t, err)
+
+	h.WipeShare()
+	_, err = h.crypto.SignPartial([]byte("hello"))
+	require.Error(t, err)
+	require.Equal(t, bt.group, h.crypto.GetGroup())
+}
+
 func TestSyncChainWithoutMetadata(t *testing.T) {
 	logger := testlogger.New(t)
 	expectedBeaconID := "someGreatBeacon"
@@ -0,0 +1,224 @@
+package beacon
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/drand/drand/v2/common/log"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// PartialsFileName is the name of the journal of the partials received for the rounds in flight, which are restored
+// when the beacon loop restarts so that a short restart doesn't lose the contributions collected for a round.
+const PartialsFileName = "partials.journal"
+
+// maxJournalRecord bounds the size of a record of the journal, far above the size of a partial, so that a corrupted
+// length doesn't make us allocate a huge buffer
+const maxJournalRecord = 64 << 10
+
+var errPartialJournalClosed = errors.New("the journal of partials is closed")
+
+// partialJournal appends the partials received to a file, and rewrites it with the partials still useful each time a
+// round is aggregated. The file starts with the hash of the group the partials were verified against, and the partials
+// are only restored if the group didn't change in between.
+// The records aren't synced to disk one by one: they survive a restart of the daemon, not of the machine.
+type partialJournal struct {
+	sync.Mutex
+	l    log.Logger
+	file string
+	w    *os.File
+	buf  *bufio.Writer
+	// set once the journal is closed, after which it isn't written anymore
+	closed bool
+}
+
+// openPartialJournal restores the partials of the journal in the given folder if it was written for the given group,
+// and starts a new journal in its place.
+func openPartialJournal(l log.Logger, folder string, groupHash []byte) (*partialJournal, []*drand.PartialBeaconPacket, error) {
+	j, records, err := openJournal(l, path.Join(folder, PartialsFileName), groupHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	var partials []*drand.PartialBeaconPacket
+	for _, record := range records {
+		p := new(drand.PartialBeaconPacket)
+		if err := proto.Unmarshal(record, p); err != nil {
+			l.Warnw("the journal of partials holds an invalid record", "file", j.file, "restored", len(partials), "err", err)
+			break
+		}
+		partials = append(partials, p)
+	}
+	return j, partials, nil
+}
+
+// openJournal restores the records of the journal file if it was written for the given group, and starts a new
+// journal in its place. The partials journaled for the rounds in flight and the ones waiting to be pushed again to a
+// peer are kept in their own file.
+func openJournal(l log.Logger, file string, groupHash []byte) (*partialJournal, [][]byte, error) {
+	j := &partialJournal{l: l, file: file}
+	records, err := j.load(groupHash)
+	if err != nil {
+		// the journal is only an optimization, the partials are lost as they would be without it
+		l.Warnw("unable to restore the partials of the previous run", "file", j.file, "err", err)
+		records = nil
+	}
+	if err := j.resetRecords(groupHash, nil); err != nil {
+		return nil, nil, err
+	}
+	return j, records, nil
+}
+
+// load reads the records of the journal, stopping at the first torn one
+func (j *partialJournal) load(groupHash []byte) ([][]byte, error) {
+	fd, err := os.Open(j.file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	r := bufio.NewReader(fd)
+	hash, err := readJournalRecord(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the header: %w", err)
+	}
+	if !bytes.Equal(hash, groupHash) {
+		j.l.Infow("ignoring the partials of the previous run, they were for another group", "file", j.file)
+		return nil, nil
+	}
+
+	var records [][]byte
+	for {
+		record, err := readJournalRecord(r)
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		} else if err != nil {
+			j.l.Warnw("the journal of partials ends with a torn record", "file", j.file, "restored", len(records), "err", err)
+			return records, nil
+		}
+		records = append(records, record)
+	}
+}
+
+// append adds the partial to the journal
+func (j *partialJournal) append(p *drand.PartialBeaconPacket) error {
+	record, err := proto.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	j.Lock()
+	defer j.Unlock()
+	if j.closed {
+		return errPartialJournalClosed
+	}
+	if err := writeJournalRecord(j.buf, record); err != nil {
+		return err
+	}
+	return j.buf.Flush()
+}
+
+// reset replaces the journal by one holding only the given partials, for the given group
+func (j *partialJournal) reset(groupHash []byte, partials []*drand.PartialBeaconPacket) error {
+	records := make([][]byte, 0, len(partials))
+	for _, p := range partials {
+		record, err := proto.Marshal(p)
+		if err != nil {
+			return fmt.Errorf("unable to write the journal of partials: %w", err)
+		}
+		records = append(records, record)
+	}
+	return j.resetRecords(groupHash, records)
+}
+
+// resetRecords replaces the journal by one holding only the given records, for the given group
+func (j *partialJournal) resetRecords(groupHash []byte, records [][]byte) error {
+	j.Lock()
+	defer j.Unlock()
+	if j.closed {
+		return errPartialJournalClosed
+	}
+
+	tmp := j.file + ".tmp"
+	fd, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("unable to write the journal of partials: %w", err)
+	}
+	buf := bufio.NewWriter(fd)
+	err = writeJournalRecord(buf, groupHash)
+	for _, record := range records {
+		if err != nil {
+			break
+		}
+		err = writeJournalRecord(buf, record)
+	}
+	if err == nil {
+		err = buf.Flush()
+	}
+	if err == nil {
+		err = os.Rename(tmp, j.file)
+	}
+	if err != nil {
+		_ = fd.Close()
+		_ = os.Remove(tmp)
+		return fmt.Errorf("unable to write the journal of partials: %w", err)
+	}
+
+	// the file descriptor follows the rename
+	if j.w != nil {
+		_ = j.w.Close()
+	}
+	j.w, j.buf = fd, buf
+	return nil
+}
+
+// close closes the journal, which is kept on disk for the next run
+func (j *partialJournal) close() error {
+	j.Lock()
+	defer j.Unlock()
+	if j.closed {
+		return nil
+	}
+	j.closed = true
+	if j.w == nil {
+		return nil
+	}
+	err := j.w.Close()
+	j.w, j.buf = nil, nil
+	return err
+}
+
+func writeJournalRecord(w io.Writer, record []byte) error {
+	var size [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(size[:], uint64(len(record)))
+	if _, err := w.Write(size[:n]); err != nil {
+		return err
+	}
+	_, err := w.Write(record)
+	return err
+}
+
+func readJournalRecord(r *bufio.Reader) ([]byte, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if size > maxJournalRecord {
+		return nil, fmt.Errorf("record of %d bytes is too large", size)
+	}
+	record := make([]byte, size)
+	if _, err := io.ReadFull(r, record); err != nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return record, nil
+}
@@ -0,0 +1,78 @@
+package beacon
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/drand/drand/v2/common/testlogger"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+func TestPartialJournalRestoresPartials(t *testing.T) {
+	l := testlogger.New(t)
+	dir := t.TempDir()
+	group := []byte("group hash")
+	partial := func(round uint64, sig string) *drand.PartialBeaconPacket {
+		return &drand.PartialBeaconPacket{Round: round, PreviousSignature: []byte("prev"), PartialSig: []byte(sig)}
+	}
+
+	j, restored, err := openPartialJournal(l, dir, group)
+	require.NoError(t, err)
+	require.Empty(t, restored)
+	require.NoError(t, j.append(partial(10, "a")))
+	require.NoError(t, j.append(partial(10, "b")))
+	require.NoError(t, j.append(partial(11, "c")))
+	require.NoError(t, j.close())
+	require.ErrorIs(t, j.append(partial(11, "d")), errPartialJournalClosed)
+
+	j, restored, err = openPartialJournal(l, dir, group)
+	require.NoError(t, err)
+	require.Len(t, restored, 3)
+	for i, sig := range []string{"a", "b", "c"} {
+		require.Equal(t, sig, string(restored[i].GetPartialSig()))
+	}
+
+	// once round 10 is aggregated, only the partials of round 11 are kept
+	require.NoError(t, j.reset(group, []*drand.PartialBeaconPacket{partial(11, "c")}))
+	require.NoError(t, j.append(partial(11, "d")))
+	require.NoError(t, j.close())
+	j, restored, err = openPartialJournal(l, dir, group)
+	require.NoError(t, err)
+	require.Len(t, restored, 2)
+	require.True(t, proto.Equal(partial(11, "d"), restored[1]))
+	require.NoError(t, j.close())
+}
+
+func TestPartialJournalIgnoresOtherGroupAndTornRecords(t *testing.T) {
+	l := testlogger.New(t)
+	dir := t.TempDir()
+	p := &drand.PartialBeaconPacket{Round: 3, PreviousSignature: []byte("prev"), PartialSig: []byte("sig")}
+
+	j, _, err := openPartialJournal(l, dir, []byte("old group"))
+	require.NoError(t, err)
+	require.NoError(t, j.append(p))
+	require.NoError(t, j.close())
+
+	// the partials were verified against another group
+	j, restored, err := openPartialJournal(l, dir, []byte("new group"))
+	require.NoError(t, err)
+	require.Empty(t, restored)
+	require.NoError(t, j.append(p))
+	require.NoError(t, j.append(p))
+	require.NoError(t, j.close())
+
+	// a crash in the middle of a write leaves a torn record at the end
+	file := path.Join(dir, PartialsFileName)
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(file, info.Size()-2))
+
+	j, restored, err = openPartialJournal(l, dir, []byte("new group"))
+	require.NoError(t, err)
+	require.Len(t, restored, 1)
+	require.NoError(t, j.close())
+}
@@ -0,0 +1,278 @@
+package beacon
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	clock "github.com/jonboulle/clockwork"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/drand/drand/v2/common/key"
+	"github.com/drand/drand/v2/common/log"
+	"github.com/drand/drand/v2/internal/metrics"
+	"github.com/drand/drand/v2/internal/net"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// PartialRetryInterval is how long we wait before pushing again the partials which couldn't be delivered to a peer
+var PartialRetryInterval = 500 * time.Millisecond
+
+// PartialRetriesFileName is the name of the journal of the partials waiting to be pushed again to a peer, which are
+// queued again when the beacon loop restarts
+const PartialRetriesFileName = "partial_retries.journal"
+
+// MaxPendingPartialsPerNode is the maximum number of partials waiting to be pushed again to any node. Since they
+// expire at the end of the period of their round, only a couple of them are pending at any time, unless the
+// network is catching up.
+const MaxPendingPartialsPerNode = 10
+
+type pendingPartial struct {
+	packet *drand.PartialBeaconPacket
+	expiry time.Time
+}
+
+// partialRetries pushes again the partials which failed to reach a peer because of a transient failure. A partial
+// is retried until the end of the period of its round: past it, the network moved on without it. Each peer has its
+// own queue, processed by a worker which only runs while the queue isn't empty.
+//
+// The queues are journaled next to the partials of the rounds in flight, so that a short restart of the node doesn't
+// lose the partials it still has time to deliver.
+type partialRetries struct {
+	sync.Mutex
+	beaconID string
+	client   net.ProtocolClient
+	clock    clock.Clock
+	l        log.Logger
+	pending  map[string][]pendingPartial
+	// journal keeps the queues across restarts, nil if they aren't kept
+	journal   *partialJournal
+	groupHash []byte
+}
+
+func newPartialRetries(beaconID string, client net.ProtocolClient, c clock.Clock, l log.Logger) *partialRetries {
+	return &partialRetries{
+		beaconID: beaconID,
+		client:   client,
+		clock:    c,
+		l:        l,
+		pending:  make(map[string][]pendingPartial),
+	}
+}
+
+// enqueue schedules the given partial to be pushed again to the given peer until the given expiry time
+func (r *partialRetries) enqueue(ctx context.Context, peer key.Identity, packet *drand.PartialBeaconPacket, expiry time.Time) {
+	r.Lock()
+	defer r.Unlock()
+
+	addr := peer.Address()
+	queue, running := r.pending[addr]
+	if len(queue) == MaxPendingPartialsPerNode {
+		metrics.PartialRetryDropped(r.beaconID, addr)
+		queue = queue[1:]
+	}
+	r.pending[addr] = append(queue, pendingPartial{packet: packet, expiry: expiry})
+	r.persist()
+	if !running {
+		go r.work(ctx, peer)
+	}
+}
+
+// work pushes the pending partials of the given peer until there are none left
+func (r *partialRetries) work(ctx context.Context, peer key.Identity) {
+	addr := peer.Address()
+	for {
+		select {
+		case <-ctx.Done():
+			r.Lock()
+			delete(r.pending, addr)
+			r.Unlock()
+			return
+		case <-r.clock.After(PartialRetryInterval):
+		}
+
+		r.Lock()
+		queue := append([]pendingPartial(nil), r.pending[addr]...)
+		r.Unlock()
+
+		delivered := 0
+		for _, p := range queue {
+			if !r.clock.Now().Before(p.expiry) {
+				r.l.Debugw("dropping partial, its round is over", "round", p.packet.GetRound(), "to", addr)
+				metrics.PartialRetryExpired(r.beaconID, addr)
+				delivered++
+				continue
+			}
+			tctx, cancel := context.WithDeadline(ctx, p.expiry)
+			err := r.client.PartialBeacon(tctx, &peer, p.packet)
+			cancel()
+			if err != nil {
+				// the peer is still unreachable, no need to try the next ones before the next retry
+				r.l.Debugw("error pushing partial again", "round", p.packet.GetRound(), "to", addr, "err", err)
+				break
+			}
+			r.l.Debugw("partial recovered", "round", p.packet.GetRound(), "to", addr)
+			metrics.PartialRecovered(r.beaconID, addr)
+			delivered++
+		}
+
+		r.Lock()
+		// new partials may have been queued meanwhile, and old ones dropped to make room for them
+		remaining := dropDelivered(r.pending[addr], queue[:delivered])
+		if len(remaining) == 0 {
+			delete(r.pending, addr)
+		} else {
+			r.pending[addr] = remaining
+		}
+		if delivered > 0 {
+			r.persist()
+		}
+		r.Unlock()
+		if len(remaining) == 0 {
+			return
+		}
+	}
+}
+
+// restore queues again the partials journaled by the previous run in the given folder, and keeps journaling the
+// queues from now on. The partials whose round is over, or for the peers which aren't part of the group anymore, are
+// dropped. The queues aren't kept if the journal can't be written.
+func (r *partialRetries) restore(ctx context.Context, folder string, group *key.Group) {
+	journal, records, err := openJournal(r.l, path.Join(folder, PartialRetriesFileName), group.Hash())
+	if err != nil {
+		r.l.Warnw("unable to journal the partials to push again, they won't survive a restart", "err", err)
+		return
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	r.journal, r.groupHash = journal, group.Hash()
+
+	now := r.clock.Now()
+	restored := 0
+	for _, record := range records {
+		addr, p, err := decodePendingPartial(record)
+		if err != nil {
+			r.l.Warnw("ignoring an invalid record of the journal of partials to push again", "err", err)
+			continue
+		}
+		if !now.Before(p.expiry) {
+			continue
+		}
+		var peer *key.Identity
+		for _, n := range group.Nodes {
+			if n.Address() == addr {
+				peer = n.Identity
+				break
+			}
+		}
+		if peer == nil {
+			continue
+		}
+		queue, running := r.pending[addr]
+		if len(queue) == MaxPendingPartialsPerNode {
+			continue
+		}
+		r.pending[addr] = append(queue, p)
+		if !running {
+			go r.work(ctx, *peer)
+		}
+		restored++
+	}
+	if restored > 0 {
+		r.l.Infow("restored the partials to push again of the previous run", "partials", restored)
+	}
+	r.persist()
+}
+
+// persist rewrites the journal with the queues, it must be called with the lock held
+func (r *partialRetries) persist() {
+	if r.journal == nil {
+		return
+	}
+	var records [][]byte
+	for addr, queue := range r.pending {
+		for _, p := range queue {
+			record, err := encodePendingPartial(addr, p)
+			if err != nil {
+				r.l.Warnw("unable to journal a partial to push again", "round", p.packet.GetRound(), "to", addr, "err", err)
+				continue
+			}
+			records = append(records, record)
+		}
+	}
+	if err := r.journal.resetRecords(r.groupHash, records); err != nil && !errors.Is(err, errPartialJournalClosed) {
+		r.l.Warnw("unable to journal the partials to push again", "err", err)
+	}
+}
+
+// close stops journaling the queues, the journal is kept on disk for the next run
+func (r *partialRetries) close() {
+	r.Lock()
+	defer r.Unlock()
+	if r.journal != nil {
+		_ = r.journal.close()
+	}
+}
+
+// encodePendingPartial returns the record of the journal of the partial to push to the given peer: the address of
+// the peer and the expiry of the partial, followed by the partial
+func encodePendingPartial(addr string, p pendingPartial) ([]byte, error) {
+	packet, err := proto.Marshal(p.packet)
+	if err != nil {
+		return nil, err
+	}
+	record := binary.AppendUvarint(nil, uint64(len(addr)))
+	record = append(record, addr...)
+	record = binary.BigEndian.AppendUint64(record, uint64(p.expiry.UnixNano()))
+	return append(record, packet...), nil
+}
+
+func decodePendingPartial(record []byte) (string, pendingPartial, error) {
+	size, n := binary.Uvarint(record)
+	//nolint:mnd // the expiry is 8 bytes long
+	if n <= 0 || uint64(len(record)-n) < size+8 {
+		return "", pendingPartial{}, fmt.Errorf("record of %d bytes is too short", len(record))
+	}
+	record = record[n:]
+	addr := string(record[:size])
+	expiry := time.Unix(0, int64(binary.BigEndian.Uint64(record[size:])))
+	packet := new(drand.PartialBeaconPacket)
+	if err := proto.Unmarshal(record[size+8:], packet); err != nil {
+		return "", pendingPartial{}, err
+	}
+	return addr, pendingPartial{packet: packet, expiry: expiry}, nil
+}
+
+// pendingRounds returns the rounds of the partials waiting to be pushed again to the given peer
+func (r *partialRetries) pendingRounds(addr string) []uint64 {
+	r.Lock()
+	defer r.Unlock()
+
+	rounds := make([]uint64, 0, len(r.pending[addr]))
+	for _, p := range r.pending[addr] {
+		rounds = append(rounds, p.packet.GetRound())
+	}
+	return rounds
+}
+
+func dropDelivered(queue, delivered []pendingPartial) []pendingPartial {
+	remaining := make([]pendingPartial, 0, len(queue))
+	for _, p := range queue {
+		done := false
+		for _, d := range delivered {
+			if p.packet == d.packet {
+				done = true
+				break
+			}
+		}
+		if !done {
+			remaining = append(remaining, p)
+		}
+	}
+	return remaining
+}
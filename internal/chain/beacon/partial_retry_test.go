@@ -0,0 +1,138 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	clock "github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drand/drand/v2/common/key"
+	"github.com/drand/drand/v2/common/testlogger"
+	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/net"
+	proto "github.com/drand/drand/v2/protobuf/drand"
+)
+
+type flakyPartialClient struct {
+	net.ProtocolClient
+	sync.Mutex
+	failures  int
+	delivered []uint64
+}
+
+func (f *flakyPartialClient) PartialBeacon(_ context.Context, _ net.Peer, p *proto.PartialBeaconPacket, _ ...net.CallOption) error {
+	f.Lock()
+	defer f.Unlock()
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("connection refused")
+	}
+	f.delivered = append(f.delivered, p.GetRound())
+	return nil
+}
+
+func (f *flakyPartialClient) deliveredRounds() []uint64 {
+	f.Lock()
+	defer f.Unlock()
+	return append([]uint64(nil), f.delivered...)
+}
+
+func TestPartialRetriesRecoverTransientFailures(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fakeClock := clock.NewFakeClockAt(time.Unix(1700000000, 0))
+	client := &flakyPartialClient{failures: 1}
+	retries := newPartialRetries("default", client, fakeClock, testlogger.New(t))
+	peer := key.Identity{Addr: "peer:1234"}
+
+	retries.enqueue(ctx, peer, &proto.PartialBeaconPacket{Round: 10}, fakeClock.Now().Add(3*time.Second))
+	retries.enqueue(ctx, peer, &proto.PartialBeaconPacket{Round: 11}, fakeClock.Now().Add(6*time.Second))
+	require.Equal(t, []uint64{10, 11}, retries.pendingRounds(peer.Addr))
+
+	// the first retry fails, the peer is still down
+	fakeClock.BlockUntil(1)
+	fakeClock.Advance(PartialRetryInterval)
+	fakeClock.BlockUntil(1)
+	require.Empty(t, client.deliveredRounds())
+	require.Equal(t, []uint64{10, 11}, retries.pendingRounds(peer.Addr))
+
+	// by the second retry, the round 10 is over and only the round 11 is still worth delivering
+	fakeClock.Advance(4 * time.Second)
+	require.Eventually(t, func() bool {
+		return len(retries.pendingRounds(peer.Addr)) == 0
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, []uint64{11}, client.deliveredRounds())
+}
+
+func TestPartialRetriesAreBounded(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	fakeClock := clock.NewFakeClock()
+	retries := newPartialRetries("default", &flakyPartialClient{}, fakeClock, testlogger.New(t))
+	peer := key.Identity{Addr: "peer:1234"}
+
+	for round := uint64(1); round <= MaxPendingPartialsPerNode+2; round++ {
+		retries.enqueue(ctx, peer, &proto.PartialBeaconPacket{Round: round}, fakeClock.Now().Add(time.Minute))
+	}
+	rounds := retries.pendingRounds(peer.Addr)
+	require.Len(t, rounds, MaxPendingPartialsPerNode)
+	require.Equal(t, uint64(3), rounds[0])
+
+	// stopping the handler drops the pending partials
+	cancel()
+	require.Eventually(t, func() bool {
+		return len(retries.pendingRounds(peer.Addr)) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestPartialRetriesSurviveRestarts(t *testing.T) {
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	peer, err := key.NewKeyPair("peer:1234", sch)
+	require.NoError(t, err)
+	gone, err := key.NewKeyPair("gone:1234", sch)
+	require.NoError(t, err)
+	group := &key.Group{Scheme: sch, Threshold: 1, Nodes: []*key.Node{{Identity: peer.Public}}}
+	dir := t.TempDir()
+	fakeClock := clock.NewFakeClockAt(time.Unix(1700000000, 0))
+	l := testlogger.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	retries := newPartialRetries("default", &flakyPartialClient{failures: 100}, fakeClock, l)
+	retries.restore(ctx, dir, group)
+	retries.enqueue(ctx, *peer.Public, &proto.PartialBeaconPacket{Round: 10}, fakeClock.Now().Add(3*time.Second))
+	retries.enqueue(ctx, *peer.Public, &proto.PartialBeaconPacket{Round: 11}, fakeClock.Now().Add(time.Minute))
+	retries.enqueue(ctx, *gone.Public, &proto.PartialBeaconPacket{Round: 11}, fakeClock.Now().Add(time.Minute))
+	retries.close()
+	cancel()
+	require.Eventually(t, func() bool {
+		return len(retries.pendingRounds(peer.Public.Addr))+len(retries.pendingRounds(gone.Public.Addr)) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	// the node restarts once the round 10 is over, and the peer which left the group is forgotten
+	fakeClock.Advance(5 * time.Second)
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	client := &flakyPartialClient{}
+	restarted := newPartialRetries("default", client, fakeClock, l)
+	restarted.restore(ctx, dir, group)
+	require.Equal(t, []uint64{11}, restarted.pendingRounds(peer.Public.Addr))
+	require.Empty(t, restarted.pendingRounds(gone.Public.Addr))
+
+	fakeClock.BlockUntil(1)
+	fakeClock.Advance(PartialRetryInterval)
+	require.Eventually(t, func() bool {
+		return len(restarted.pendingRounds(peer.Public.Addr)) == 0
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, []uint64{11}, client.deliveredRounds())
+	restarted.close()
+
+	// the partials delivered aren't pushed again by the next run
+	again := newPartialRetries("default", client, fakeClock, l)
+	again.restore(ctx, dir, group)
+	require.Empty(t, again.pendingRounds(peer.Public.Addr))
+	again.close()
+}
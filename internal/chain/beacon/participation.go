@@ -0,0 +1,162 @@
+package beacon
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sync"
+)
+
+// ParticipationFileName is the name of the file recording, for each round aggregated by the node, the members whose
+// partials were aggregated into its signature, when the participation is audited
+const ParticipationFileName = "participation.jsonl"
+
+// MaxParticipationFileSize is the size past which the participation file is rotated. A record takes a few hundred
+// bytes for the groups currently running, the file holds the rounds of several weeks.
+var MaxParticipationFileSize int64 = 64 << 20
+
+// ParticipationFilesKept is the number of rotated participation files kept, named after the participation file with
+// the suffixes .1, the most recent, to .ParticipationFilesKept, the oldest, which is dropped at the next rotation
+const ParticipationFilesKept = 3
+
+// RoundParticipation records the members whose partials were aggregated into the signature of a round, as one line
+// of the participation file. The addresses are the ones the indexes had in the group when the round was aggregated.
+type RoundParticipation struct {
+	Round     uint64   `json:"round"`
+	Indexes   []uint32 `json:"indexes"`
+	Addresses []string `json:"addresses"`
+}
+
+// participationLog appends the participation of each round aggregated to the participation file, rotated once it
+// reaches MaxParticipationFileSize. The records aren't synced to disk one by one, and a record torn by a crash is
+// skipped when reading the file back.
+type participationLog struct {
+	sync.Mutex
+	folder string
+	fd     *os.File
+	size   int64
+}
+
+func openParticipationLog(folder string) (*participationLog, error) {
+	p := &participationLog{folder: folder}
+	if err := p.open(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *participationLog) open() error {
+	fd, err := os.OpenFile(path.Join(p.folder, ParticipationFileName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("unable to open the participation file: %w", err)
+	}
+	info, err := fd.Stat()
+	if err != nil {
+		_ = fd.Close()
+		return fmt.Errorf("unable to open the participation file: %w", err)
+	}
+	p.fd, p.size = fd, info.Size()
+	return nil
+}
+
+// rotate shifts the rotated files, dropping the oldest one, and starts a new participation file. It must be called
+// with the lock held.
+func (p *participationLog) rotate() error {
+	if err := p.fd.Close(); err != nil {
+		return err
+	}
+	p.fd = nil
+	for i := ParticipationFilesKept - 1; i >= 1; i-- {
+		err := os.Rename(rotatedParticipationFile(p.folder, i), rotatedParticipationFile(p.folder, i+1))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	if err := os.Rename(path.Join(p.folder, ParticipationFileName), rotatedParticipationFile(p.folder, 1)); err != nil {
+		return err
+	}
+	return p.open()
+}
+
+func rotatedParticipationFile(folder string, i int) string {
+	return path.Join(folder, fmt.Sprintf("%s.%d", ParticipationFileName, i))
+}
+
+func (p *participationLog) record(r *RoundParticipation) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	if p.fd == nil {
+		return os.ErrClosed
+	}
+	if p.size > 0 && p.size+int64(len(line))+1 > MaxParticipationFileSize {
+		if err := p.rotate(); err != nil {
+			return fmt.Errorf("unable to rotate the participation file: %w", err)
+		}
+	}
+	n, err := p.fd.Write(append(line, '\n'))
+	p.size += int64(n)
+	return err
+}
+
+func (p *participationLog) close() error {
+	p.Lock()
+	defer p.Unlock()
+	if p.fd == nil {
+		return nil
+	}
+	err := p.fd.Close()
+	p.fd = nil
+	return err
+}
+
+// ReadParticipation calls fn for each round recorded in the participation files of the given folder, the rotated ones
+// first, from the round from to the round to included, the last one recorded if to is 0. It stops once fn returns false.
+func ReadParticipation(folder string, from, to uint64, fn func(*RoundParticipation) bool) error {
+	files := make([]string, 0, ParticipationFilesKept+1)
+	for i := ParticipationFilesKept; i >= 1; i-- {
+		files = append(files, rotatedParticipationFile(folder, i))
+	}
+	files = append(files, path.Join(folder, ParticipationFileName))
+
+	for _, file := range files {
+		more, err := readParticipationFile(file, from, to, fn)
+		if err != nil || !more {
+			return err
+		}
+	}
+	return nil
+}
+
+func readParticipationFile(file string, from, to uint64, fn func(*RoundParticipation) bool) (bool, error) {
+	fd, err := os.Open(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return true, nil
+	} else if err != nil {
+		return false, fmt.Errorf("unable to open the participation file: %w", err)
+	}
+	defer fd.Close()
+
+	scanner := bufio.NewScanner(fd)
+	for scanner.Scan() {
+		var r RoundParticipation
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			// a line torn by a crash
+			continue
+		}
+		if r.Round < from || (to != 0 && r.Round > to) {
+			continue
+		}
+		if !fn(&r) {
+			return false, nil
+		}
+	}
+	return true, scanner.Err()
+}
@@ -0,0 +1,96 @@
+package beacon
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParticipationLog(t *testing.T) {
+	dir := t.TempDir()
+	p, err := openParticipationLog(dir)
+	require.NoError(t, err)
+	for round := uint64(1); round <= 4; round++ {
+		require.NoError(t, p.record(&RoundParticipation{Round: round, Indexes: []uint32{0, uint32(round)}, Addresses: []string{"a", "b"}}))
+	}
+	require.NoError(t, p.close())
+	require.ErrorIs(t, p.record(&RoundParticipation{Round: 5}), os.ErrClosed)
+
+	// a crash in the middle of a write leaves a torn line at the end
+	fd, err := os.OpenFile(path.Join(dir, ParticipationFileName), os.O_WRONLY|os.O_APPEND, 0o600)
+	require.NoError(t, err)
+	_, err = fd.WriteString(`{"round":5,"ind`)
+	require.NoError(t, err)
+	require.NoError(t, fd.Close())
+
+	var rounds []uint64
+	require.NoError(t, ReadParticipation(dir, 2, 0, func(r *RoundParticipation) bool {
+		require.Equal(t, []uint32{0, uint32(r.Round)}, r.Indexes)
+		rounds = append(rounds, r.Round)
+		return true
+	}))
+	require.Equal(t, []uint64{2, 3, 4}, rounds)
+
+	rounds = nil
+	require.NoError(t, ReadParticipation(dir, 0, 2, func(r *RoundParticipation) bool {
+		rounds = append(rounds, r.Round)
+		return true
+	}))
+	require.Equal(t, []uint64{1, 2}, rounds)
+
+	// the reading stops when asked to
+	rounds = nil
+	require.NoError(t, ReadParticipation(dir, 0, 0, func(r *RoundParticipation) bool {
+		rounds = append(rounds, r.Round)
+		return len(rounds) < 2
+	}))
+	require.Equal(t, []uint64{1, 2}, rounds)
+
+	// nothing was recorded for another beacon
+	require.NoError(t, ReadParticipation(t.TempDir(), 0, 0, func(*RoundParticipation) bool {
+		t.Fatal("unexpected round")
+		return false
+	}))
+}
+
+func TestParticipationLogRotation(t *testing.T) {
+	defer func(size int64) { MaxParticipationFileSize = size }(MaxParticipationFileSize)
+	MaxParticipationFileSize = 200
+
+	dir := t.TempDir()
+	p, err := openParticipationLog(dir)
+	require.NoError(t, err)
+	for round := uint64(1); round <= 20; round++ {
+		require.NoError(t, p.record(&RoundParticipation{Round: round, Indexes: []uint32{0, 1}, Addresses: []string{"a", "b"}}))
+	}
+	require.NoError(t, p.close())
+
+	// the oldest rotated files were dropped, the rounds left are read in order across the files
+	for i := 1; i <= ParticipationFilesKept; i++ {
+		info, err := os.Stat(rotatedParticipationFile(dir, i))
+		require.NoError(t, err)
+		require.LessOrEqual(t, info.Size(), MaxParticipationFileSize)
+	}
+	_, err = os.Stat(rotatedParticipationFile(dir, ParticipationFilesKept+1))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	var rounds []uint64
+	require.NoError(t, ReadParticipation(dir, 0, 0, func(r *RoundParticipation) bool {
+		rounds = append(rounds, r.Round)
+		return true
+	}))
+	require.NotEmpty(t, rounds)
+	require.Less(t, len(rounds), 20)
+	require.Equal(t, uint64(20), rounds[len(rounds)-1])
+	for i := 1; i < len(rounds); i++ {
+		require.Equal(t, rounds[i-1]+1, rounds[i])
+	}
+
+	// a reopened log keeps counting the size of the current file
+	p, err = openParticipationLog(dir)
+	require.NoError(t, err)
+	require.NotZero(t, p.size)
+	require.NoError(t, p.close())
+}
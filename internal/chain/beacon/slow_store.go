@@ -0,0 +1,135 @@
+package beacon
+
+import (
+	"context"
+	"time"
+
+	clock "github.com/jonboulle/clockwork"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/log"
+	"github.com/drand/drand/v2/internal/chain"
+	"github.com/drand/drand/v2/internal/metrics"
+)
+
+// slowStore logs and counts the operations of the underlying store taking longer than a threshold, so that the
+// latency added to the rounds by the storage, e.g. bolt on a network filesystem, can be told apart from the network.
+type slowStore struct {
+	chain.Store
+	l         log.Logger
+	clock     clock.Clock
+	beaconID  string
+	backend   string
+	threshold time.Duration
+}
+
+// NewSlowStore returns a store reporting the operations of the given store taking longer than the threshold. The
+// store is returned as is if the threshold isn't positive.
+func NewSlowStore(s chain.Store, l log.Logger, cl clock.Clock, beaconID, backend string, threshold time.Duration) chain.Store {
+	if threshold <= 0 {
+		return s
+	}
+	return &slowStore{
+		Store:     s,
+		l:         l,
+		clock:     cl,
+		beaconID:  beaconID,
+		backend:   backend,
+		threshold: threshold,
+	}
+}
+
+// observe reports the operation started at the given time if it took longer than the threshold, which it returns
+func (s *slowStore) observe(op string, start time.Time, round uint64, err error) bool {
+	took := s.clock.Since(start)
+	if took < s.threshold {
+		return false
+	}
+	metrics.SlowStoreOperation(s.beaconID, s.backend, op)
+	s.l.Warnw("slow store operation",
+		"op", op,
+		"backend", s.backend,
+		"round", round,
+		"took", took,
+		"threshold", s.threshold,
+		"err", err,
+	)
+	return true
+}
+
+func (s *slowStore) Put(ctx context.Context, b *common.Beacon) error {
+	start := s.clock.Now()
+	err := s.Store.Put(ctx, b)
+	s.observe("put", start, b.Round, err)
+	return err
+}
+
+func (s *slowStore) Get(ctx context.Context, round common.Round) (*common.Beacon, error) {
+	start := s.clock.Now()
+	b, err := s.Store.Get(ctx, round)
+	s.observe("get", start, round.Uint64(), err)
+	return b, err
+}
+
+func (s *slowStore) Last(ctx context.Context) (*common.Beacon, error) {
+	start := s.clock.Now()
+	b, err := s.Store.Last(ctx)
+	s.observe("last", start, roundOf(b), err)
+	return b, err
+}
+
+func (s *slowStore) Del(ctx context.Context, round common.Round) error {
+	start := s.clock.Now()
+	err := s.Store.Del(ctx, round)
+	s.observe("del", start, round.Uint64(), err)
+	return err
+}
+
+// Cursor reports the moves of the cursor taking longer than the threshold, rather than the whole scan whose
+// duration also depends on the work done by the callback on each beacon
+func (s *slowStore) Cursor(ctx context.Context, fn func(context.Context, chain.Cursor) error) error {
+	return s.Store.Cursor(ctx, func(ctx context.Context, c chain.Cursor) error {
+		return fn(ctx, &slowCursor{Cursor: c, s: s})
+	})
+}
+
+type slowCursor struct {
+	chain.Cursor
+	s *slowStore
+}
+
+func (c *slowCursor) First(ctx context.Context) (*common.Beacon, error) {
+	start := c.s.clock.Now()
+	b, err := c.Cursor.First(ctx)
+	c.s.observe("cursor_first", start, roundOf(b), err)
+	return b, err
+}
+
+func (c *slowCursor) Next(ctx context.Context) (*common.Beacon, error) {
+	start := c.s.clock.Now()
+	b, err := c.Cursor.Next(ctx)
+	c.s.observe("cursor_next", start, roundOf(b), err)
+	return b, err
+}
+
+func (c *slowCursor) Seek(ctx context.Context, round common.Round) (*common.Beacon, error) {
+	start := c.s.clock.Now()
+	b, err := c.Cursor.Seek(ctx, round)
+	c.s.observe("cursor_seek", start, round.Uint64(), err)
+	return b, err
+}
+
+func (c *slowCursor) Last(ctx context.Context) (*common.Beacon, error) {
+	start := c.s.clock.Now()
+	b, err := c.Cursor.Last(ctx)
+	c.s.observe("cursor_last", start, roundOf(b), err)
+	return b, err
+}
+
+// roundOf returns the round of the beacon, 0 if there is none
+func roundOf(b *common.Beacon) uint64 {
+	if b == nil {
+		return 0
+	}
+	return b.Round
+}
@@ -0,0 +1,50 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	clock "github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/testlogger"
+	"github.com/drand/drand/v2/internal/chain"
+	"github.com/drand/drand/v2/internal/chain/memdb"
+)
+
+func TestSlowStore(t *testing.T) {
+	ctx := context.Background()
+	l := testlogger.New(t)
+	cl := clock.NewFakeClock()
+	mem := memdb.NewStore(10)
+
+	require.Equal(t, mem, NewSlowStore(mem, l, cl, "default", "memdb", 0))
+
+	s := NewSlowStore(mem, l, cl, "default", "memdb", time.Second)
+	ss, ok := s.(*slowStore)
+	require.True(t, ok)
+
+	require.NoError(t, s.Put(ctx, &common.Beacon{Round: 1, Signature: []byte("sig")}))
+	b, err := s.Get(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), b.Round)
+	_, err = s.Get(ctx, 2)
+	require.Error(t, err)
+
+	err = s.Cursor(ctx, func(ctx context.Context, c chain.Cursor) error {
+		_, ok := c.(*slowCursor)
+		require.True(t, ok)
+		b, err := c.First(ctx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), b.Round)
+		return nil
+	})
+	require.NoError(t, err)
+
+	start := cl.Now()
+	require.False(t, ss.observe("put", start, 1, nil))
+	cl.Advance(time.Second)
+	require.True(t, ss.observe("put", start, 1, nil))
+}
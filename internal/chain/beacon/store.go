@@ -29,6 +29,8 @@ type CallbackStore interface {
 	chain.Store
 	AddCallback(id string, fn CallbackFunc)
 	RemoveCallback(id string)
+	// Flush waits until the callbacks were called with all the beacons stored so far
+	Flush(ctx context.Context) error
 }
 
 // appendStore is a store that only appends new block with a round +1 from the
@@ -171,7 +173,7 @@ func (d *discrepancyStore) Put(ctx context.Context, b *common.Beacon) error {
 
 	storageTime := d.clock.Now()
 
-	expected := common.TimeOfRound(d.group.Period, d.group.GenesisTime, b.Round) * 1e9
+	expected := common.TimeOfRound(d.group.Period, d.group.GenesisTime, common.Round(b.Round)) * 1e9
 	discrepancy := float64(actual.UnixNano()-expected) / float64(time.Millisecond)
 
 	beaconID := common.GetCanonicalBeaconID(d.group.ID)
@@ -204,6 +206,8 @@ type cbPair struct {
 	cb    CallbackFunc
 	b     *common.Beacon
 	close bool
+	// flushed is closed once the jobs queued before this one are done, instead of calling the callback
+	flushed chan struct{}
 }
 
 // NewCallbackStore returns a Store that uses a pool of worker to dispatch the
@@ -280,6 +284,32 @@ func (c *callbackStore) RemoveCallback(id string) {
 	}
 }
 
+// Flush waits until the workers called the callbacks with all the beacons stored so far, or the context is done
+func (c *callbackStore) Flush(ctx context.Context) error {
+	c.RLock()
+	flushed := make([]chan struct{}, 0, len(c.newJob))
+	for _, jobChan := range c.newJob {
+		done := make(chan struct{})
+		select {
+		case jobChan <- cbPair{flushed: done}:
+		case <-ctx.Done():
+			c.RUnlock()
+			return ctx.Err()
+		}
+		flushed = append(flushed, done)
+	}
+	c.RUnlock()
+
+	for _, done := range flushed {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
 func (c *callbackStore) Close() error {
 	close(c.stopping)
 	return c.Store.Close()
@@ -294,6 +324,10 @@ func (c *callbackStore) runWorker(jobChan chan cbPair) {
 			if !ok {
 				return
 			}
+			if newJob.flushed != nil {
+				close(newJob.flushed)
+				continue
+			}
 			newJob.cb(newJob.b, newJob.close)
 		}
 	}
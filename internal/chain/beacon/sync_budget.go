@@ -0,0 +1,82 @@
+package beacon
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	cl "github.com/jonboulle/clockwork"
+
+	"github.com/drand/drand/v2/internal/metrics"
+)
+
+// DefaultSyncMemoryBudget is the default amount of memory, in bytes, that the beacons buffered by all the syncs of
+// a sync manager can use.
+const DefaultSyncMemoryBudget = 32 << 20
+
+// syncThrottleDelay is how long a sync waits before checking again if the memory budget allows it to start
+var syncThrottleDelay = 100 * time.Millisecond
+
+// syncBudget bounds the memory used by the beacons buffered while syncing. Each stream of beacons from a peer
+// reserves enough memory for its whole buffer before starting, and new streams are throttled until enough memory
+// is released by the others, so that catching up on a long chain can't exhaust the memory of small instances.
+type syncBudget struct {
+	sync.Mutex
+	beaconID string
+	clock    cl.Clock
+	max      int64
+	used     int64
+}
+
+func newSyncBudget(beaconID string, c cl.Clock, max int64) *syncBudget {
+	if max <= 0 {
+		max = DefaultSyncMemoryBudget
+	}
+	metrics.SyncMemoryBudget(beaconID, max)
+	return &syncBudget{
+		beaconID: beaconID,
+		clock:    c,
+		max:      max,
+	}
+}
+
+// acquire reserves the given amount of memory, waiting until it fits in the budget. A reservation bigger than the
+// whole budget is only granted when nothing else is reserved.
+func (b *syncBudget) acquire(ctx context.Context, size int64) error {
+	throttled := false
+	for {
+		b.Lock()
+		if b.used == 0 || b.used+size <= b.max {
+			b.used += size
+			metrics.SyncMemoryUsed(b.beaconID, b.used)
+			b.Unlock()
+			return nil
+		}
+		b.Unlock()
+
+		if !throttled {
+			throttled = true
+			metrics.SyncThrottled(b.beaconID)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-b.clock.After(syncThrottleDelay):
+		}
+	}
+}
+
+// release gives back memory reserved with acquire
+func (b *syncBudget) release(size int64) {
+	b.Lock()
+	defer b.Unlock()
+	b.used -= size
+	metrics.SyncMemoryUsed(b.beaconID, b.used)
+}
+
+// Used returns the amount of memory currently reserved
+func (b *syncBudget) Used() int64 {
+	b.Lock()
+	defer b.Unlock()
+	return b.used
+}
@@ -0,0 +1,47 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	clock "github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncBudgetThrottles(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fakeClock := clock.NewFakeClockAt(time.Unix(1700000000, 0))
+	budget := newSyncBudget("default", fakeClock, 100)
+
+	// a reservation bigger than the budget is granted when nothing else is reserved
+	require.NoError(t, budget.acquire(ctx, 150))
+	require.Equal(t, int64(150), budget.Used())
+
+	acquired := make(chan error, 1)
+	go func() {
+		acquired <- budget.acquire(ctx, 60)
+	}()
+	fakeClock.BlockUntil(1)
+	select {
+	case <-acquired:
+		t.Fatal("acquired memory beyond the budget")
+	default:
+	}
+
+	budget.release(150)
+	fakeClock.Advance(syncThrottleDelay)
+	require.NoError(t, <-acquired)
+	require.Equal(t, int64(60), budget.Used())
+
+	// canceling the context stops waiting
+	throttledCtx, throttledCancel := context.WithCancel(ctx)
+	go func() {
+		acquired <- budget.acquire(throttledCtx, 60)
+	}()
+	fakeClock.BlockUntil(1)
+	throttledCancel()
+	require.ErrorIs(t, <-acquired, context.Canceled)
+	require.Equal(t, int64(60), budget.Used())
+}
@@ -1,11 +1,13 @@
 package beacon
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
-	"math/rand"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	cl "github.com/jonboulle/clockwork"
@@ -20,6 +22,9 @@ import (
 	"github.com/drand/drand/v2/internal/chain"
 	chainerrors "github.com/drand/drand/v2/internal/chain/errors"
 	dcontext "github.com/drand/drand/v2/internal/context"
+	"github.com/drand/drand/v2/internal/crash"
+	"github.com/drand/drand/v2/internal/iolimit"
+	"github.com/drand/drand/v2/internal/metrics"
 	"github.com/drand/drand/v2/internal/net"
 	proto "github.com/drand/drand/v2/protobuf/drand"
 )
@@ -49,6 +54,15 @@ type SyncManager struct {
 	newSyncedBeacon chan *commonutils.Beacon
 	// we need to know our current daemon address
 	nodeAddr string
+	// maximum number of beacons buffered per stream from a peer
+	maxInFlight int
+	// bounds the memory used by the beacons buffered by all the streams
+	budget *syncBudget
+	// keeps the beacons replaced when correcting the chain, nil if they aren't kept
+	tombstones *tombstoneLog
+	// restricts and orders the peers synced from, nil to sync from any of them
+	sourcesLock sync.Mutex
+	sources     *SyncSources
 }
 
 // sync manager will renew sync if nothing happens for factor*period time
@@ -68,6 +82,14 @@ type SyncConfig struct {
 	BoltdbStore chain.Store
 	Info        *public.Info
 	NodeAddr    string
+	// MaxInFlight is the maximum number of beacons received from a peer and waiting to be stored, 0 for the default
+	MaxInFlight int
+	// MemoryBudget is the memory, in bytes, that the beacons buffered by all the syncs can use, 0 for the default
+	MemoryBudget int64
+	// TombstonesFolder is the folder where the beacons replaced when correcting the chain are kept, none if empty
+	TombstonesFolder string
+	// Sources restricts and orders the peers synced from, nil to sync from any of them
+	Sources *SyncSources
 }
 
 // NewSyncManager returns a sync manager that will use the given store to store
@@ -78,8 +100,20 @@ func NewSyncManager(ctx context.Context, c *SyncConfig) (*SyncManager, error) {
 		return nil, err
 	}
 
+	var tombstones *tombstoneLog
+	if c.TombstonesFolder != "" {
+		if tombstones, err = openTombstoneLog(c.TombstonesFolder); err != nil {
+			return nil, err
+		}
+	}
+
 	ctx, ctxCancel := context.WithCancel(ctx)
 
+	maxInFlight := c.MaxInFlight
+	if maxInFlight <= 0 {
+		maxInFlight = net.MaxSyncBuffer
+	}
+
 	return &SyncManager{
 		ctx:             ctx,
 		ctxCancel:       ctxCancel,
@@ -95,11 +129,18 @@ func NewSyncManager(ctx context.Context, c *SyncConfig) (*SyncManager, error) {
 		factor:          syncExpiryFactor,
 		newReq:          make(chan RequestInfo, syncQueueRequest),
 		newSyncedBeacon: make(chan *commonutils.Beacon, 1),
+		maxInFlight:     maxInFlight,
+		budget:          newSyncBudget(commonutils.GetCanonicalBeaconID(c.Info.ID), c.Clock, c.MemoryBudget),
+		tombstones:      tombstones,
+		sources:         c.Sources,
 	}, nil
 }
 
 func (s *SyncManager) Stop() {
 	s.ctxCancel()
+	if s.tombstones != nil {
+		_ = s.tombstones.close()
+	}
 }
 
 type RequestInfo struct {
@@ -128,8 +169,27 @@ func NewRequestInfo(ctx context.Context, upTo uint64, nodes []net.Peer) RequestI
 	}
 }
 
+// crashScope returns the scope of the panics recovered in the syncer, with the details given as key/value pairs
+func (s *SyncManager) crashScope(details ...string) crash.Scope {
+	return crash.Scope{
+		Module:   "sync",
+		BeaconID: commonutils.GetCanonicalBeaconID(s.info.ID),
+		Details: func() map[string]string {
+			d := make(map[string]string, len(details)/2)
+			for i := 0; i+1 < len(details); i += 2 {
+				d[details[i]] = details[i+1]
+			}
+			return d
+		},
+	}
+}
+
 // Run handles non-blocking sync requests coming from the regular operation of the daemon
 func (s *SyncManager) Run() {
+	defer crash.Recover(s.log, s.crashScope(), func(error) {
+		// the requests must keep being served, the daemon would block sending them otherwise
+		go s.Run()
+	})
 	// no need to sync until genesis time
 	for s.clock.Now().Unix() < s.info.GenesisTime {
 		s.clock.Sleep(time.Second)
@@ -176,6 +236,8 @@ func (s *SyncManager) Run() {
 				cancel()
 				ctx, cancel = context.WithCancel(s.ctx)
 				go func() {
+					defer crash.Recover(s.log, s.crashScope("from", strconv.FormatUint(request.from, 10),
+						"up_to", strconv.FormatUint(request.upTo, 10)), nil)
 					if err := s.Sync(ctx, request); err != nil {
 						s.log.Errorw("sync was unsuccessful", "from", request.from, "to", request.upTo, "err", err)
 					} else {
@@ -226,7 +288,7 @@ func (s *SyncManager) CheckPastBeacons(ctx context.Context, upTo uint64, cb func
 			cb(i, upTo)
 		}
 
-		b, err := s.store.Get(ctx, i)
+		b, err := s.store.Get(ctx, commonutils.Round(i))
 		if err != nil {
 			// this is not to be logged as an error since the goal here is to detect errors in the store.
 			logger.Infow("unable to fetch from local store", "round", i, "err", err)
@@ -236,6 +298,10 @@ func (s *SyncManager) CheckPastBeacons(ctx context.Context, upTo uint64, cb func
 			}
 			continue
 		}
+		// the check can be throttled not to starve the storage of new rounds
+		if err := iolimit.FromContext(ctx).Wait(ctx, len(b.Signature)+len(b.PreviousSig)+8); err != nil {
+			return nil, err
+		}
 		// verify the signature validity
 		if err = s.scheme.VerifyBeacon(b, s.info.PublicKey); err != nil {
 			// this is not to be logged as an error since the goal here is to detect invalid beacons.
@@ -296,6 +362,111 @@ func (s *SyncManager) CorrectPastBeacons(ctx context.Context, faultyBeacons []ui
 	return nil
 }
 
+// ErrBeaconNotLinked is returned when an injected beacon doesn't link to the rounds stored around it
+var ErrBeaconNotLinked = errors.New("the beacon doesn't link to the rounds stored around it")
+
+// InjectBeacon stores a beacon obtained out-of-band, e.g. from the store of another node, after checking it as strictly
+// as a synced one: it must verify against the public key of the chain and, for chained schemes, link to the rounds
+// stored before and after it. A beacon following the last one stored is appended to the chain, one filling a gap or
+// replacing an invalid round is written in place. It returns false if the store already had the beacon.
+func (s *SyncManager) InjectBeacon(ctx context.Context, b *commonutils.Beacon) (bool, error) {
+	ctx, span := tracer.NewSpan(ctx, "syncManager.InjectBeacon")
+	defer span.End()
+
+	if b.Round == 0 {
+		return false, errors.New("the genesis beacon can't be injected")
+	}
+	isChained := s.scheme.Name == crypto.DefaultSchemeID
+	if !isChained {
+		b.PreviousSig = nil
+	}
+	if err := s.scheme.VerifyBeacon(b, s.info.PublicKey); err != nil {
+		return false, fmt.Errorf("invalid beacon %d: %w", b.Round, err)
+	}
+
+	// a trimmed store can't read a round when the one before is missing, its last one included: the beacon then fills
+	// a gap before the last round
+	last, err := s.store.Last(ctx)
+	if err != nil && !errors.Is(err, chainerrors.ErrNoBeaconStored) {
+		return false, err
+	}
+	appending := err == nil && b.Round == last.Round+1
+	if err == nil && b.Round > last.Round+1 {
+		return false, fmt.Errorf("round %d is after the next round to store, %d", b.Round, last.Round+1)
+	}
+
+	stored, err := s.storedBeacon(ctx, b.Round)
+	if err != nil {
+		return false, err
+	}
+	if stored != nil && s.scheme.VerifyBeacon(stored, s.info.PublicKey) == nil {
+		if !stored.Equal(b) {
+			return false, fmt.Errorf("another valid beacon is stored for round %d", b.Round)
+		}
+		return false, nil
+	}
+
+	if isChained {
+		prev, err := s.storedBeacon(ctx, b.Round-1)
+		if err != nil {
+			return false, err
+		}
+		if prev != nil && !bytes.Equal(prev.Signature, b.PreviousSig) {
+			return false, fmt.Errorf("%w: invalid previous signature for %d", ErrBeaconNotLinked, b.Round)
+		}
+		next, err := s.storedBeacon(ctx, b.Round+1)
+		if err != nil {
+			return false, err
+		}
+		if next != nil && !bytes.Equal(next.PreviousSig, b.Signature) {
+			return false, fmt.Errorf("%w: round %d doesn't follow %d", ErrBeaconNotLinked, b.Round+1, b.Round)
+		}
+	}
+
+	if appending {
+		// through the chain, so that it is handled as any new beacon
+		err = s.store.Put(ctx, b)
+	} else {
+		err = s.replace(ctx, b, stored, "inject")
+	}
+	if err != nil {
+		return false, err
+	}
+	s.log.Infow("injected a beacon", "round", b.Round)
+	return true, nil
+}
+
+// replace writes the beacon in place of the one stored for its round, if any, which is first kept in the tombstones
+// so that correcting the chain doesn't destroy the evidence of what was wrong. The beacon isn't written if the one
+// it replaces can't be kept.
+func (s *SyncManager) replace(ctx context.Context, b, stored *commonutils.Beacon, reason string) error {
+	if s.tombstones != nil && stored != nil && !stored.Equal(b) {
+		err := s.tombstones.record(&Tombstone{
+			Round:       stored.Round,
+			Signature:   stored.Signature,
+			PreviousSig: stored.PreviousSig,
+			ReplacedBy:  b.Signature,
+			RoundTime:   commonutils.TimeOfRound(s.info.Period, s.info.GenesisTime, commonutils.Round(stored.Round)),
+			ReplacedAt:  s.clock.Now().Unix(),
+			Reason:      reason,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to keep the beacon replaced for round %d: %w", b.Round, err)
+		}
+		s.log.Warnw("replaced a stored beacon, kept in the tombstones", "round", b.Round, "reason", reason)
+	}
+	return s.insecureStore.Put(ctx, b)
+}
+
+// storedBeacon returns the beacon stored for the round, nil if there is none
+func (s *SyncManager) storedBeacon(ctx context.Context, round uint64) (*commonutils.Beacon, error) {
+	b, err := s.insecureStore.Get(ctx, commonutils.Round(round))
+	if errors.Is(err, chainerrors.ErrNoBeaconStored) {
+		return nil, nil
+	}
+	return b, err
+}
+
 // ReSync handles resyncs that where necessarily launched by a CLI.
 func (s *SyncManager) ReSync(ctx context.Context, from, to uint64, nodes []net.Peer) error {
 	ctx, span := tracer.NewSpan(ctx, "syncManager.ReSync")
@@ -331,6 +502,14 @@ func (s *SyncManager) ReSync(ctx context.Context, from, to uint64, nodes []net.P
 	return err
 }
 
+// SetSources replaces the rules restricting the peers synced from, nil to sync from any of them. The syncs already
+// running keep the previous rules.
+func (s *SyncManager) SetSources(sources *SyncSources) {
+	s.sourcesLock.Lock()
+	defer s.sourcesLock.Unlock()
+	s.sources = sources
+}
+
 // Sync will launch the requested sync with the requested peers and returns once done, even if it failed
 //
 //nolint:gocritic // Request size is correct, no need for a pointer.
@@ -339,9 +518,16 @@ func (s *SyncManager) Sync(ctx context.Context, request RequestInfo) error {
 	defer span.End()
 
 	s.log.Debugw("starting new sync", "sync_manager", "start sync", "up_to", request.upTo, "nodes", peersToString(request.nodes))
-	// shuffle through the nodes
-	for _, n := range rand.Perm(len(request.nodes)) {
-		if request.nodes[n].Address() == s.nodeAddr {
+	// shuffle through the nodes allowed, the preferred ones first
+	s.sourcesLock.Lock()
+	sources := s.sources
+	s.sourcesLock.Unlock()
+	nodes := sources.Order(request.nodes)
+	if len(nodes) < len(request.nodes) {
+		s.log.Debugw("skipping the nodes denied as sync sources", "denied", len(request.nodes)-len(nodes))
+	}
+	for _, node := range nodes {
+		if node.Address() == s.nodeAddr {
 			// we ignore our own node
 			s.log.Debugw("skipping sync with our own node", "sync_manager", "sync")
 			continue
@@ -352,8 +538,7 @@ func (s *SyncManager) Sync(ctx context.Context, request RequestInfo) error {
 			s.log.Debugw("sync canceled early", "source", "ctx", "err?", ctx.Err())
 			return fmt.Errorf("ctx done: sync canceled")
 		default:
-			node := request.nodes[n]
-			if s.tryNode(ctx, request.from, request.upTo, node) {
+			if s.tryNode(ctx, request.from, request.upTo, nil, node) {
 				// we stop as soon as we've done a successful sync with a node
 				return nil
 			}
@@ -363,12 +548,131 @@ func (s *SyncManager) Sync(ctx context.Context, request RequestInfo) error {
 	return ErrFailedAll
 }
 
+// followEnd is the reason a sync from an upstream ended while following
+type followEnd int
+
+const (
+	followEnded followEnd = iota
+	followStalled
+	followFailedBack
+	followPathChanged
+)
+
+// Follow syncs the chain from the upstreams, in their order of priority, until the context is canceled. The relay path
+// is given to the upstreams for them to refuse relaying the chain in a loop, and the sync is restarted with the new
+// path each time pathChanged is notified. An upstream which doesn't serve the new rounds is given up after a few
+// periods, so that a loop which formed while the syncs were running, before the upstreams could detect it, doesn't
+// stall the chain forever.
+func (s *SyncManager) Follow(ctx context.Context, up *Upstreams, relayPath func() []string, pathChanged <-chan struct{}) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		peer := up.Current()
+		s.log.Debugw("following upstream", "upstream", peer.Address())
+
+		attempt, cancel := context.WithCancel(ctx)
+		ended := make(chan followEnd, 1)
+		go func() {
+			defer crash.Recover(s.log, s.crashScope("upstream", peer.Address()), func(error) {
+				cancel()
+				ended <- followEnded
+			})
+			ended <- s.watchUpstream(attempt, cancel, up, pathChanged)
+		}()
+		s.tryNode(attempt, 0, 0, relayPath(), peer)
+		cancel()
+
+		switch <-ended {
+		case followFailedBack:
+			s.log.Infow("failing back to a preferred upstream", "from", peer.Address(), "to", up.Current().Address())
+			continue
+		case followPathChanged:
+			continue
+		case followStalled:
+			s.log.Warnw("upstream stopped serving new rounds", "upstream", peer.Address())
+		case followEnded:
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if up.Failed() {
+			s.log.Warnw("failing over to the next upstream", "from", peer.Address(), "to", up.Current().Address())
+			continue
+		}
+		// we give the same upstream another chance after a period
+		select {
+		case <-s.clock.After(s.period):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// watchUpstream watches the progress of the sync from the current upstream, until the sync ends or is canceled because
+// the upstream stalled, a preferred upstream is back, or the relay path changed
+func (s *SyncManager) watchUpstream(ctx context.Context, cancel context.CancelFunc, up *Upstreams, pathChanged <-chan struct{}) followEnd {
+	ticker := s.clock.NewTicker(s.period)
+	defer ticker.Stop()
+
+	last := s.lastRound(ctx)
+	progressed := s.clock.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return followEnded
+		case <-pathChanged:
+			cancel()
+			return followPathChanged
+		case <-ticker.Chan():
+		}
+
+		now := s.clock.Now()
+		if round := s.lastRound(ctx); round > last {
+			last, progressed = round, now
+			up.Synced()
+		} else if expected := commonutils.CurrentRound(now.Unix(), s.period, s.info.GenesisTime); expected > last &&
+			now.Sub(progressed) >= time.Duration(s.factor)*s.period {
+			cancel()
+			return followStalled
+		}
+
+		for i, p := range up.Preferred() {
+			if up.Probed(i, s.upToDate(ctx, p, last)) {
+				cancel()
+				return followFailedBack
+			}
+		}
+	}
+}
+
+// upToDate returns true if the peer has stored the given round at least
+func (s *SyncManager) upToDate(ctx context.Context, p net.Peer, round uint64) bool {
+	ctx, cancel := context.WithTimeout(ctx, s.period)
+	defer cancel()
+	resp, err := s.client.Status(ctx, p, &proto.StatusRequest{Metadata: &proto.Metadata{BeaconID: s.info.ID}})
+	if err != nil {
+		s.log.Debugw("unable to probe upstream", "upstream", p.Address(), "err", err)
+		return false
+	}
+	return !resp.GetChainStore().GetIsEmpty() && resp.GetChainStore().GetLastStored() >= round
+}
+
+func (s *SyncManager) lastRound(ctx context.Context) uint64 {
+	last, err := s.store.Last(ctx)
+	if err != nil {
+		return 0
+	}
+	return last.Round
+}
+
 // tryNode tries to sync up with the given peer up to the given round, starting
 // from the last beacon in the store. It returns true if the objective was
-// reached (store.Last() returns upTo) and false otherwise.
+// reached (store.Last() returns upTo) and false otherwise. The relay path is
+// given to the peer for it to refuse relaying the chain in a loop.
 //
 //nolint:gocyclo,funlen
-func (s *SyncManager) tryNode(global context.Context, from, upTo uint64, peer net.Peer) bool {
+func (s *SyncManager) tryNode(global context.Context, from, upTo uint64, relayPath []string, peer net.Peer) bool {
 	global, span := tracer.NewSpan(global, "syncManager.tryNode")
 	defer span.End()
 
@@ -378,11 +682,13 @@ func (s *SyncManager) tryNode(global context.Context, from, upTo uint64, peer ne
 		attribute.String("addr", peer.Address()),
 	)
 
-	logger := s.log.Named("tryNode")
+	// the peer logs the sync with the same request ID as ours
+	requestID := net.NewRequestID()
+	logger := s.log.Named("tryNode").With("request_id", requestID)
 
 	// we put a cancel to still keep the global context open but stop with this
 	// peer if things go sideway
-	cnode, cancel := context.WithCancel(global)
+	cnode, cancel := context.WithCancel(net.WithRequestID(global, requestID))
 	defer cancel()
 
 	// if from > 0 then we're doing a ReSync, not a plain Sync.
@@ -406,8 +712,18 @@ func (s *SyncManager) tryNode(global context.Context, from, upTo uint64, peer ne
 	req := &proto.SyncRequest{
 		FromRound: from,
 		Metadata:  &proto.Metadata{BeaconID: s.info.ID},
+		RelayPath: relayPath,
 	}
 
+	// we reserve the memory of the whole buffer of the stream before starting it
+	reservation := int64(s.maxInFlight) * s.beaconSize()
+	if err := s.budget.acquire(cnode, reservation); err != nil {
+		logger.Debugw("sync canceled while throttled", "with_peer", peer.Address(), "err", err)
+		return false
+	}
+	defer s.budget.release(reservation)
+	cnode = dcontext.SetSyncBuffer(cnode, s.maxInFlight)
+
 	beaconCh, err := s.client.SyncChain(cnode, peer, req)
 	if err != nil {
 		span.RecordError(errors.New("unable_to_sync"))
@@ -458,6 +774,7 @@ func (s *SyncManager) tryNode(global context.Context, from, upTo uint64, peer ne
 				cnode = dcontext.SetSkipLogs(cnode, true)
 			}
 
+			metrics.SyncInFlight(commonutils.GetCanonicalBeaconID(s.info.ID), len(beaconCh))
 			beacon := protoToBeacon(beaconPacket)
 
 			// verify the signature validity
@@ -470,7 +787,12 @@ func (s *SyncManager) tryNode(global context.Context, from, upTo uint64, peer ne
 
 			if isResync {
 				logger.Debugw("Resync Put: trying to save beacon", "beacon", beacon.Round)
-				if err := s.insecureStore.Put(cnode, beacon); err != nil {
+				stored, err := s.storedBeacon(cnode, beacon.Round)
+				if err != nil {
+					// an unreadable beacon can't be kept, it is still replaced
+					logger.Warnw("Resync Put: unable to read the beacon replaced", "round", beacon.Round, "err", err)
+				}
+				if err := s.replace(cnode, beacon, stored, "resync"); err != nil {
 					span.RecordError(err)
 					logger.Errorw("Resync Put: unable to save", "with_peer", peer.Address(), "err", err)
 					span.End()
@@ -512,6 +834,12 @@ func (s *SyncManager) tryNode(global context.Context, from, upTo uint64, peer ne
 	}
 }
 
+// beaconSize is an upper bound of the memory used by a beacon received while syncing
+func (s *SyncManager) beaconSize() int64 {
+	// the signature and the previous one, plus the round, the metadata and the decoding overhead
+	return int64(2*s.scheme.SigGroup.PointLen() + 128)
+}
+
 // SyncRequest is an interface representing any kind of request to sync.
 // Those exist in both the protocol API and the public API.
 type SyncRequest interface {
@@ -580,7 +908,7 @@ func SyncChain(l log.Logger, store CallbackStore, req SyncRequest, stream SyncSt
 
 		// first sync up from the store itself
 		err = store.Cursor(ctx, func(ctx context.Context, c chain.Cursor) error {
-			bb, err := c.Seek(ctx, fromRound)
+			bb, err := c.Seek(ctx, commonutils.Round(fromRound))
 			for ; bb != nil; bb, err = c.Next(ctx) {
 				// This is needed since send will use a pointer and could result in pointer reassignment
 				bb := bb
@@ -0,0 +1,183 @@
+package beacon
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	gonet "net"
+	"strings"
+
+	"github.com/drand/drand/v2/internal/net"
+)
+
+// regionPrefix marks a rule matching the peers labeled with a region
+const regionPrefix = "region:"
+
+// SyncSources restricts and orders the peers the chain is synced from, when following a chain and when catching up
+// with the group, so that the operators can keep the sync traffic within their region or away from metered peers.
+// A rule is either an address, with or without its port, a CIDR, or "region:<name>" matching the peers labeled with
+// the region. CIDRs only match the peers whose address is an IP. The nil SyncSources allows all the peers.
+type SyncSources struct {
+	// prefer are the rules of the peers tried first
+	prefer []syncRule
+	// deny are the rules of the peers never synced from
+	deny []syncRule
+	// regions are the rules labeling the peers with their region, in the order they were given
+	regions []regionRule
+}
+
+type syncRule struct {
+	addr   string
+	net    *gonet.IPNet
+	region string
+}
+
+type regionRule struct {
+	rule   syncRule
+	region string
+}
+
+// ParseSyncSources parses the rules of the preferred and denied peers, and the labels of the regions of the peers,
+// given as "<address|CIDR>=<region>". It returns nil if there are no rules.
+func ParseSyncSources(prefer, deny, regions []string) (*SyncSources, error) {
+	s := new(SyncSources)
+	var err error
+	if s.prefer, err = parseSyncRules(prefer); err != nil {
+		return nil, err
+	}
+	if s.deny, err = parseSyncRules(deny); err != nil {
+		return nil, err
+	}
+	for _, label := range regions {
+		target, region, ok := strings.Cut(label, "=")
+		if !ok || target == "" || region == "" {
+			return nil, fmt.Errorf("invalid region label %q, expected <address|CIDR>=<region>", label)
+		}
+		rule, err := parseSyncRule(target)
+		if err != nil {
+			return nil, err
+		}
+		if rule.region != "" {
+			return nil, fmt.Errorf("invalid region label %q: a region can't be labeled with a region", label)
+		}
+		s.regions = append(s.regions, regionRule{rule: rule, region: region})
+	}
+	if len(s.prefer) == 0 && len(s.deny) == 0 {
+		return nil, nil
+	}
+	return s, nil
+}
+
+func parseSyncRules(rules []string) ([]syncRule, error) {
+	parsed := make([]syncRule, 0, len(rules))
+	for _, r := range rules {
+		rule, err := parseSyncRule(r)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, rule)
+	}
+	return parsed, nil
+}
+
+func parseSyncRule(rule string) (syncRule, error) {
+	switch {
+	case rule == "":
+		return syncRule{}, errors.New("empty sync source rule")
+	case strings.HasPrefix(rule, regionPrefix):
+		region := strings.TrimPrefix(rule, regionPrefix)
+		if region == "" {
+			return syncRule{}, fmt.Errorf("invalid sync source rule %q: no region", rule)
+		}
+		return syncRule{region: region}, nil
+	case strings.Contains(rule, "/"):
+		_, ipNet, err := gonet.ParseCIDR(rule)
+		if err != nil {
+			return syncRule{}, fmt.Errorf("invalid sync source rule %q: %w", rule, err)
+		}
+		return syncRule{net: ipNet}, nil
+	default:
+		return syncRule{addr: rule}, nil
+	}
+}
+
+// region returns the region the peer is labeled with, empty if none
+func (s *SyncSources) region(addr string) string {
+	for _, r := range s.regions {
+		if r.rule.matches(addr, "") {
+			return r.region
+		}
+	}
+	return ""
+}
+
+func (r *syncRule) matches(addr, region string) bool {
+	switch {
+	case r.region != "":
+		return r.region == region
+	case r.net != nil:
+		host, _, err := gonet.SplitHostPort(addr)
+		if err != nil {
+			host = addr
+		}
+		ip := gonet.ParseIP(host)
+		return ip != nil && r.net.Contains(ip)
+	default:
+		if r.addr == addr {
+			return true
+		}
+		host, _, err := gonet.SplitHostPort(addr)
+		return err == nil && r.addr == host
+	}
+}
+
+func matchesAny(rules []syncRule, addr, region string) bool {
+	for i := range rules {
+		if rules[i].matches(addr, region) {
+			return true
+		}
+	}
+	return false
+}
+
+// Allowed returns whether the chain can be synced from the peer
+func (s *SyncSources) Allowed(addr string) bool {
+	if s == nil {
+		return true
+	}
+	return !matchesAny(s.deny, addr, s.region(addr))
+}
+
+// Filter returns the peers the chain can be synced from, in the same order
+func (s *SyncSources) Filter(peers []net.Peer) []net.Peer {
+	if s == nil {
+		return peers
+	}
+	allowed := make([]net.Peer, 0, len(peers))
+	for _, p := range peers {
+		if s.Allowed(p.Address()) {
+			allowed = append(allowed, p)
+		}
+	}
+	return allowed
+}
+
+// Order returns the peers the chain can be synced from in the order to try them: the preferred ones first, then the
+// others, each shuffled so that the load spreads over them
+func (s *SyncSources) Order(peers []net.Peer) []net.Peer {
+	var preferred, others []net.Peer
+	for _, i := range rand.Perm(len(peers)) {
+		p := peers[i]
+		switch {
+		case s == nil:
+			others = append(others, p)
+		case !s.Allowed(p.Address()):
+			continue
+		case matchesAny(s.prefer, p.Address(), s.region(p.Address())):
+			preferred = append(preferred, p)
+		default:
+			others = append(others, p)
+		}
+	}
+	return append(preferred, others...)
+}
@@ -0,0 +1,86 @@
+package beacon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/drand/drand/v2/internal/net"
+)
+
+func addresses(peers []net.Peer) []string {
+	addrs := make([]string, 0, len(peers))
+	for _, p := range peers {
+		addrs = append(addrs, p.Address())
+	}
+	return addrs
+}
+
+func TestSyncSources(t *testing.T) {
+	peers := []net.Peer{
+		net.CreatePeer("10.0.0.1:4444"),
+		net.CreatePeer("10.0.0.2:4444"),
+		net.CreatePeer("192.168.1.1:4444"),
+		net.CreatePeer("eu.drand.example:4444"),
+		net.CreatePeer("metered.example:4444"),
+		net.CreatePeer("other.example:4444"),
+	}
+
+	// no rules keeps all the peers
+	sources, err := ParseSyncSources(nil, nil, nil)
+	require.NoError(t, err)
+	require.Nil(t, sources)
+	require.Len(t, sources.Order(peers), len(peers))
+	require.Equal(t, peers, sources.Filter(peers))
+	require.True(t, sources.Allowed("metered.example:4444"))
+
+	sources, err = ParseSyncSources(
+		[]string{"region:eu"},
+		[]string{"metered.example", "192.168.0.0/16"},
+		[]string{"10.0.0.0/24=eu", "eu.drand.example:4444=eu"},
+	)
+	require.NoError(t, err)
+	require.False(t, sources.Allowed("metered.example:4444"))
+	require.False(t, sources.Allowed("192.168.1.1:4444"))
+	require.True(t, sources.Allowed("10.0.0.1:4444"))
+	require.Equal(t, []string{"10.0.0.1:4444", "10.0.0.2:4444", "eu.drand.example:4444", "other.example:4444"},
+		addresses(sources.Filter(peers)))
+
+	// the peers of the region come first, the denied ones are dropped
+	for i := 0; i < 10; i++ {
+		ordered := addresses(sources.Order(peers))
+		require.Len(t, ordered, 4)
+		require.ElementsMatch(t, []string{"10.0.0.1:4444", "10.0.0.2:4444", "eu.drand.example:4444"}, ordered[:3])
+		require.Equal(t, "other.example:4444", ordered[3])
+	}
+
+	sources, err = ParseSyncSources([]string{"192.168.1.1"}, nil, nil)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		ordered := addresses(sources.Order(peers))
+		require.Len(t, ordered, len(peers))
+		require.Equal(t, "192.168.1.1:4444", ordered[0])
+	}
+
+	// a region can be denied too
+	sources, err = ParseSyncSources(nil, []string{"region:us"}, []string{"10.0.0.2=us"})
+	require.NoError(t, err)
+	require.NotContains(t, addresses(sources.Order(peers)), "10.0.0.2:4444")
+	require.Len(t, sources.Order(peers), len(peers)-1)
+}
+
+func TestParseSyncSourcesInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		prefer, deny, regions []string
+	}{
+		{prefer: []string{""}},
+		{prefer: []string{"region:"}},
+		{deny: []string{"10.0.0.0/33"}},
+		{regions: []string{"10.0.0.0/8"}},
+		{deny: []string{"a:1"}, regions: []string{"=eu"}},
+		{deny: []string{"a:1"}, regions: []string{"region:eu=us"}},
+	} {
+		_, err := ParseSyncSources(tc.prefer, tc.deny, tc.regions)
+		require.Error(t, err, tc)
+	}
+}
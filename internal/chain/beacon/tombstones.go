@@ -0,0 +1,99 @@
+package beacon
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sync"
+)
+
+// TombstonesFileName is the name of the file keeping the beacons replaced in the store when correcting the chain, so
+// that the evidence of what was wrong isn't lost
+const TombstonesFileName = "tombstones.jsonl"
+
+// Tombstone is a beacon replaced in the store, as one line of the tombstones file, with the signature of the beacon
+// replacing it. The times are UNIX timestamps.
+type Tombstone struct {
+	Round       uint64 `json:"round"`
+	Signature   []byte `json:"signature"`
+	PreviousSig []byte `json:"previous_signature,omitempty"`
+	ReplacedBy  []byte `json:"replaced_by"`
+	// RoundTime is the time the round was due
+	RoundTime  int64 `json:"round_time"`
+	ReplacedAt int64 `json:"replaced_at"`
+	// Reason is how the beacon was replaced: "resync" when correcting the chain from the peers, "inject" when a
+	// beacon obtained out-of-band was injected
+	Reason string `json:"reason"`
+}
+
+// tombstoneLog appends the beacons replaced to the tombstones file. Unlike the participation file, each record is
+// synced to disk before the beacon it keeps is overwritten.
+type tombstoneLog struct {
+	sync.Mutex
+	fd *os.File
+}
+
+func openTombstoneLog(folder string) (*tombstoneLog, error) {
+	fd, err := os.OpenFile(path.Join(folder, TombstonesFileName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open the tombstones file: %w", err)
+	}
+	return &tombstoneLog{fd: fd}, nil
+}
+
+func (t *tombstoneLog) record(ts *Tombstone) error {
+	line, err := json.Marshal(ts)
+	if err != nil {
+		return err
+	}
+
+	t.Lock()
+	defer t.Unlock()
+	if t.fd == nil {
+		return os.ErrClosed
+	}
+	if _, err := t.fd.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return t.fd.Sync()
+}
+
+func (t *tombstoneLog) close() error {
+	t.Lock()
+	defer t.Unlock()
+	if t.fd == nil {
+		return nil
+	}
+	err := t.fd.Close()
+	t.fd = nil
+	return err
+}
+
+// ReadTombstones calls fn for each beacon replaced recorded in the tombstones file of the given folder, from the round
+// from to the round to included, the last one recorded if to is 0
+func ReadTombstones(folder string, from, to uint64, fn func(*Tombstone)) error {
+	fd, err := os.Open(path.Join(folder, TombstonesFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("unable to open the tombstones file: %w", err)
+	}
+	defer fd.Close()
+
+	scanner := bufio.NewScanner(fd)
+	for scanner.Scan() {
+		var ts Tombstone
+		if err := json.Unmarshal(scanner.Bytes(), &ts); err != nil {
+			// a line torn by a crash
+			continue
+		}
+		if ts.Round < from || (to != 0 && ts.Round > to) {
+			continue
+		}
+		fn(&ts)
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,35 @@
+package beacon
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTombstoneLog(t *testing.T) {
+	dir := t.TempDir()
+	ts, err := openTombstoneLog(dir)
+	require.NoError(t, err)
+	for round := uint64(1); round <= 3; round++ {
+		require.NoError(t, ts.record(&Tombstone{Round: round, Signature: []byte{byte(round)}, ReplacedBy: []byte{0xff}, Reason: "resync"}))
+	}
+	require.NoError(t, ts.close())
+	require.ErrorIs(t, ts.record(&Tombstone{Round: 4}), os.ErrClosed)
+
+	// the tombstones are kept across restarts
+	ts, err = openTombstoneLog(dir)
+	require.NoError(t, err)
+	require.NoError(t, ts.record(&Tombstone{Round: 2, Signature: []byte{0xff}, ReplacedBy: []byte{2}, Reason: "inject"}))
+	require.NoError(t, ts.close())
+
+	var replaced []*Tombstone
+	require.NoError(t, ReadTombstones(dir, 2, 0, func(t *Tombstone) { replaced = append(replaced, t) }))
+	require.Len(t, replaced, 3)
+	require.Equal(t, []byte{2}, replaced[0].Signature)
+	require.Equal(t, "inject", replaced[2].Reason)
+	require.Equal(t, []byte{2}, replaced[2].ReplacedBy)
+
+	// nothing was replaced for another beacon
+	require.NoError(t, ReadTombstones(t.TempDir(), 0, 0, func(*Tombstone) { t.Fatal("unexpected tombstone") }))
+}
@@ -0,0 +1,110 @@
+package beacon
+
+import (
+	"sync"
+	"time"
+
+	cl "github.com/jonboulle/clockwork"
+
+	"github.com/drand/drand/v2/internal/net"
+)
+
+// UpstreamFailoverThreshold is the number of syncs in a row an upstream can fail before the follower fails over to the
+// next one
+const UpstreamFailoverThreshold = 3
+
+// UpstreamFailbackPeriods is the number of periods a preferred upstream must be up-to-date for in a row before the
+// follower fails back to it
+const UpstreamFailbackPeriods = 5
+
+// Upstreams are the nodes a follower syncs from, in order of priority. The follower syncs from a single upstream at a
+// time, the preferred one available. It only fails over to the next one after a few failed syncs in a row, and only
+// fails back to a preferred one once it has been up-to-date for a few periods in a row, so that an upstream which comes
+// and goes doesn't make the follower switch back and forth.
+type Upstreams struct {
+	sync.Mutex
+	clock cl.Clock
+	peers []net.Peer
+	// failback is how long a preferred upstream must be up-to-date for before failing back to it
+	failback time.Duration
+	current  int
+	failures int
+	// upSince holds, for each upstream preferred to the current one, since when it is up-to-date, zero if it isn't
+	upSince []time.Time
+}
+
+// NewUpstreams returns the upstreams of a chain of the given period, in order of priority
+func NewUpstreams(clock cl.Clock, period time.Duration, peers []net.Peer) *Upstreams {
+	return &Upstreams{
+		clock:    clock,
+		peers:    peers,
+		failback: UpstreamFailbackPeriods * period,
+		upSince:  make([]time.Time, len(peers)),
+	}
+}
+
+// Current returns the upstream to sync from
+func (u *Upstreams) Current() net.Peer {
+	u.Lock()
+	defer u.Unlock()
+	return u.peers[u.current]
+}
+
+// Preferred returns the upstreams preferred to the current one, in order of priority
+func (u *Upstreams) Preferred() []net.Peer {
+	u.Lock()
+	defer u.Unlock()
+	return u.peers[:u.current]
+}
+
+// Synced records that the current upstream served new beacons
+func (u *Upstreams) Synced() {
+	u.Lock()
+	defer u.Unlock()
+	u.failures = 0
+}
+
+// Failed records that a sync from the current upstream failed, and returns true if it fails over to the next one. After
+// the last upstream, it starts again from the first one.
+func (u *Upstreams) Failed() bool {
+	u.Lock()
+	defer u.Unlock()
+	u.failures++
+	if u.failures < UpstreamFailoverThreshold {
+		return false
+	}
+	u.switchTo((u.current + 1) % len(u.peers))
+	return true
+}
+
+// Probed records whether the i-th upstream, preferred to the current one, is up-to-date, and returns true if it fails
+// back to it
+func (u *Upstreams) Probed(i int, upToDate bool) bool {
+	u.Lock()
+	defer u.Unlock()
+	if i >= u.current {
+		return false
+	}
+	if !upToDate {
+		u.upSince[i] = time.Time{}
+		return false
+	}
+	now := u.clock.Now()
+	if u.upSince[i].IsZero() {
+		u.upSince[i] = now
+	}
+	if now.Sub(u.upSince[i]) < u.failback {
+		return false
+	}
+	u.switchTo(i)
+	return true
+}
+
+// switchTo makes the i-th upstream the current one. It must be called with the lock held.
+func (u *Upstreams) switchTo(i int) {
+	u.current = i
+	u.failures = 0
+	for j := range u.upSince {
+		u.upSince[j] = time.Time{}
+	}
+}
@@ -0,0 +1,52 @@
+package beacon
+
+import (
+	"testing"
+	"time"
+
+	clock "github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+
+	"github.com/drand/drand/v2/internal/net"
+)
+
+func TestUpstreamsFailoverAndFailback(t *testing.T) {
+	fakeClock := clock.NewFakeClockAt(time.Unix(1700000000, 0))
+	period := 3 * time.Second
+	peers := []net.Peer{net.CreatePeer("a:1"), net.CreatePeer("b:1"), net.CreatePeer("c:1")}
+	up := NewUpstreams(fakeClock, period, peers)
+	require.Equal(t, "a:1", up.Current().Address())
+	require.Empty(t, up.Preferred())
+
+	// a few failures in a row are needed to fail over, a sync resets the count
+	for i := 0; i < UpstreamFailoverThreshold-1; i++ {
+		require.False(t, up.Failed())
+	}
+	up.Synced()
+	for i := 0; i < UpstreamFailoverThreshold-1; i++ {
+		require.False(t, up.Failed())
+	}
+	require.True(t, up.Failed())
+	require.Equal(t, "b:1", up.Current().Address())
+	require.Len(t, up.Preferred(), 1)
+
+	// the preferred upstream must be up-to-date for a while in a row before failing back to it
+	require.False(t, up.Probed(0, true))
+	fakeClock.Advance(UpstreamFailbackPeriods * period / 2)
+	require.False(t, up.Probed(0, false))
+	fakeClock.Advance(UpstreamFailbackPeriods * period / 2)
+	require.False(t, up.Probed(0, true))
+	fakeClock.Advance(UpstreamFailbackPeriods*period - time.Second)
+	require.False(t, up.Probed(0, true))
+	fakeClock.Advance(time.Second)
+	require.True(t, up.Probed(0, true))
+	require.Equal(t, "a:1", up.Current().Address())
+
+	// after the last upstream, we start again from the first one
+	for _, next := range []string{"b:1", "c:1", "a:1"} {
+		for i := 0; i < UpstreamFailoverThreshold; i++ {
+			up.Failed()
+		}
+		require.Equal(t, next, up.Current().Address())
+	}
+}
@@ -0,0 +1,60 @@
+package boltdb
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	json "github.com/nikkolasg/hexjson"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/log"
+	"github.com/drand/drand/v2/internal/chain"
+)
+
+// archiveOpenTimeout bounds the wait for the lock of an archive opened by another process
+const archiveOpenTimeout = time.Second
+
+// OpenArchive opens a bolt database file, such as a backup written by SaveTo, read-only so that the beacons it holds
+// can be imported without modifying it. Both the trimmed and the untrimmed formats are supported, the context tells
+// whether the beacons read need their previous signature, as for NewBoltStore.
+func OpenArchive(ctx context.Context, l log.Logger, file string) (chain.Store, error) {
+	// bolt creates the file when it doesn't exist, even read-only
+	if _, err := os.Stat(file); err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(file, BoltStoreOpenPerm, &bolt.Options{ReadOnly: true, Timeout: archiveOpenTimeout})
+	if err != nil {
+		return nil, err
+	}
+
+	// the untrimmed format stores the beacons as JSON, the trimmed one only their signature
+	trimmed := false
+	err = db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(beaconBucket)
+		if bucket == nil {
+			return fmt.Errorf("%s doesn't hold any beacon", file)
+		}
+		_, value := bucket.Cursor().First()
+		trimmed = json.Unmarshal(value, &common.Beacon{}) != nil
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	if trimmed {
+		return &trimmedStore{
+			log:              l,
+			db:               db,
+			requiresPrevious: chain.PreviousRequiredFromContext(ctx),
+		}, nil
+	}
+	return &BoltStore{
+		log: l,
+		db:  db,
+	}, nil
+}
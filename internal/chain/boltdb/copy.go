@@ -0,0 +1,173 @@
+package boltdb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/drand/drand/v2/internal/iolimit"
+)
+
+// copyBatchSize is the number of keys copied per transaction by copyDB
+const copyBatchSize = 1024
+
+// copyDB copies the buckets of src into dst, which is compacted as a result, in batches of keys each read in its own
+// transaction of src and throttled by the limiter. A throttled copy can last for hours on a large chain: a read
+// transaction held for all that time would keep bolt from reusing the pages freed meanwhile and from growing its
+// memory map for the new rounds, stalling their storage. The rounds stored during the copy may be left out of it.
+func copyDB(ctx context.Context, src, dst *bolt.DB, limiter *iolimit.Limiter) error {
+	var buckets [][]byte
+	err := src.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, _ *bolt.Bucket) error {
+			buckets = append(buckets, bytes.Clone(name))
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, name := range buckets {
+		err := dst.Update(func(tx *bolt.Tx) error {
+			_, err := tx.CreateBucketIfNotExists(name)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		var after []byte
+		for {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			var keys, values [][]byte
+			size := 0
+			err := src.View(func(tx *bolt.Tx) error {
+				c := tx.Bucket(name).Cursor()
+				k, v := c.First()
+				if after != nil {
+					k, v = c.Seek(after)
+					if bytes.Equal(k, after) {
+						k, v = c.Next()
+					}
+				}
+				for ; k != nil && len(keys) < copyBatchSize; k, v = c.Next() {
+					if v == nil {
+						// drand doesn't nest buckets
+						continue
+					}
+					keys = append(keys, bytes.Clone(k))
+					values = append(values, bytes.Clone(v))
+					size += len(k) + len(v)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if len(keys) == 0 {
+				break
+			}
+			if err := limiter.Wait(ctx, size); err != nil {
+				return err
+			}
+			err = dst.Update(func(tx *bolt.Tx) error {
+				bucket := tx.Bucket(name)
+				for i := range keys {
+					if err := bucket.Put(keys[i], values[i]); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			after = keys[len(keys)-1]
+		}
+	}
+	return dst.Sync()
+}
+
+// saveThrottled writes a copy of db to w, throttled by the limiter. The copy is built by copyDB in a temporary file,
+// next to w when it is a file, before being written to w.
+func saveThrottled(ctx context.Context, db *bolt.DB, w io.Writer, limiter *iolimit.Limiter) error {
+	dir := os.TempDir()
+	if f, ok := w.(*os.File); ok {
+		dir = filepath.Dir(f.Name())
+	}
+	tmp, err := os.CreateTemp(dir, "drand-backup-*.db")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	_ = tmp.Close()
+	defer os.Remove(tmpPath)
+
+	dst, err := bolt.Open(tmpPath, BoltStoreOpenPerm, &bolt.Options{NoSync: true})
+	if err != nil {
+		return err
+	}
+	if err := copyDB(ctx, db, dst, limiter); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+
+	copied, err := os.Open(tmpPath)
+	if err != nil {
+		return err
+	}
+	defer copied.Close()
+	_, err = io.Copy(limiter.Writer(ctx, w), copied)
+	return err
+}
+
+// Compact rewrites the bolt database of the given folder without its free pages, throttled by the limiter carried by
+// the context, if any. The database must not be in use: the daemon has to be stopped. It returns the size of the
+// database before and after the compaction.
+func Compact(ctx context.Context, folder string) (before, after int64, err error) {
+	dbPath := path.Join(folder, BoltFileName)
+	info, err := os.Stat(dbPath)
+	if err != nil {
+		return 0, 0, err
+	}
+	src, err := bolt.Open(dbPath, BoltStoreOpenPerm, &bolt.Options{ReadOnly: true, Timeout: archiveOpenTimeout})
+	if err != nil {
+		return 0, 0, fmt.Errorf("unable to open %s, is the daemon still running? %w", dbPath, err)
+	}
+	defer src.Close()
+
+	compactedPath := dbPath + ".compact"
+	dst, err := bolt.Open(compactedPath, BoltStoreOpenPerm, &bolt.Options{NoSync: true})
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := copyDB(ctx, src, dst, iolimit.FromContext(ctx)); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(compactedPath)
+		return 0, 0, err
+	}
+	if err := dst.Close(); err != nil {
+		_ = os.Remove(compactedPath)
+		return 0, 0, err
+	}
+	compacted, err := os.Stat(compactedPath)
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := os.Rename(compactedPath, dbPath); err != nil {
+		_ = os.Remove(compactedPath)
+		return 0, 0, err
+	}
+	return info.Size(), compacted.Size(), nil
+}
@@ -0,0 +1,160 @@
+package boltdb
+
+import (
+	"bytes"
+	"context"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/drand/drand/v2/common/tracer"
+)
+
+// metadataBucket holds the values stored next to the beacons, it is kept apart so that it doesn't count as beacons
+var metadataBucket = []byte("metadata")
+
+// GetMetadata implements the chain.MetadataStore interface
+func (b *BoltStore) GetMetadata(ctx context.Context, key string) ([]byte, error) {
+	_, span := tracer.NewSpan(ctx, "boltStore.GetMetadata")
+	defer span.End()
+
+	return getMetadata(b.db, key)
+}
+
+// PutMetadata implements the chain.MetadataStore interface
+func (b *BoltStore) PutMetadata(ctx context.Context, key string, value []byte) error {
+	_, span := tracer.NewSpan(ctx, "boltStore.PutMetadata")
+	defer span.End()
+
+	return putMetadata(b.db, key, value)
+}
+
+// PutMetadataBatch implements the chain.MetadataBatchStore interface
+func (b *BoltStore) PutMetadataBatch(ctx context.Context, values map[string][]byte) error {
+	_, span := tracer.NewSpan(ctx, "boltStore.PutMetadataBatch")
+	defer span.End()
+
+	return putMetadataBatch(b.db, values)
+}
+
+// GetMetadata implements the chain.MetadataStore interface
+func (b *trimmedStore) GetMetadata(ctx context.Context, key string) ([]byte, error) {
+	_, span := tracer.NewSpan(ctx, "boltTrimmedStore.GetMetadata")
+	defer span.End()
+
+	return getMetadata(b.db, key)
+}
+
+// PutMetadata implements the chain.MetadataStore interface
+func (b *trimmedStore) PutMetadata(ctx context.Context, key string, value []byte) error {
+	_, span := tracer.NewSpan(ctx, "boltTrimmedStore.PutMetadata")
+	defer span.End()
+
+	return putMetadata(b.db, key, value)
+}
+
+// PutMetadataBatch implements the chain.MetadataBatchStore interface
+func (b *trimmedStore) PutMetadataBatch(ctx context.Context, values map[string][]byte) error {
+	_, span := tracer.NewSpan(ctx, "boltTrimmedStore.PutMetadataBatch")
+	defer span.End()
+
+	return putMetadataBatch(b.db, values)
+}
+
+// DeleteMetadata implements the chain.MetadataStore interface
+func (b *BoltStore) DeleteMetadata(ctx context.Context, key string) error {
+	_, span := tracer.NewSpan(ctx, "boltStore.DeleteMetadata")
+	defer span.End()
+
+	return deleteMetadata(b.db, key)
+}
+
+// ListMetadata implements the chain.MetadataStore interface
+func (b *BoltStore) ListMetadata(ctx context.Context, prefix string) (map[string][]byte, error) {
+	_, span := tracer.NewSpan(ctx, "boltStore.ListMetadata")
+	defer span.End()
+
+	return listMetadata(b.db, prefix)
+}
+
+// DeleteMetadata implements the chain.MetadataStore interface
+func (b *trimmedStore) DeleteMetadata(ctx context.Context, key string) error {
+	_, span := tracer.NewSpan(ctx, "boltTrimmedStore.DeleteMetadata")
+	defer span.End()
+
+	return deleteMetadata(b.db, key)
+}
+
+// ListMetadata implements the chain.MetadataStore interface
+func (b *trimmedStore) ListMetadata(ctx context.Context, prefix string) (map[string][]byte, error) {
+	_, span := tracer.NewSpan(ctx, "boltTrimmedStore.ListMetadata")
+	defer span.End()
+
+	return listMetadata(b.db, prefix)
+}
+
+func getMetadata(db *bolt.DB, key string) ([]byte, error) {
+	var value []byte
+	err := db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(metadataBucket)
+		if bucket == nil {
+			return nil
+		}
+		if v := bucket.Get([]byte(key)); v != nil {
+			// the slice is only valid during the transaction
+			value = append([]byte{}, v...)
+		}
+		return nil
+	})
+	return value, err
+}
+
+func putMetadata(db *bolt.DB, key string, value []byte) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(metadataBucket)
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte(key), value)
+	})
+}
+
+func putMetadataBatch(db *bolt.DB, values map[string][]byte) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(metadataBucket)
+		if err != nil {
+			return err
+		}
+		for k, v := range values {
+			if err := bucket.Put([]byte(k), v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func deleteMetadata(db *bolt.DB, key string) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(metadataBucket)
+		if bucket == nil {
+			return nil
+		}
+		return bucket.Delete([]byte(key))
+	})
+}
+
+func listMetadata(db *bolt.DB, prefix string) (map[string][]byte, error) {
+	values := make(map[string][]byte)
+	err := db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(metadataBucket)
+		if bucket == nil {
+			return nil
+		}
+		c := bucket.Cursor()
+		for k, v := c.Seek([]byte(prefix)); k != nil && bytes.HasPrefix(k, []byte(prefix)); k, v = c.Next() {
+			values[string(k)] = append([]byte{}, v...)
+		}
+		return nil
+	})
+	return values, err
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/drand/drand/v2/common/log"
 	"github.com/drand/drand/v2/internal/chain"
 	chainerrors "github.com/drand/drand/v2/internal/chain/errors"
+	"github.com/drand/drand/v2/internal/iolimit"
 )
 
 // BoltStore implements the Store interface using the kv storage boltdb (native
@@ -224,7 +225,7 @@ func (b *BoltStore) Last(ctx context.Context) (*common.Beacon, error) {
 }
 
 // Get returns the beacon saved at this round
-func (b *BoltStore) Get(ctx context.Context, round uint64) (*common.Beacon, error) {
+func (b *BoltStore) Get(ctx context.Context, round common.Round) (*common.Beacon, error) {
 	ctx, span := tracer.NewSpan(ctx, "boltStore.Get")
 	defer span.End()
 
@@ -237,7 +238,7 @@ func (b *BoltStore) Get(ctx context.Context, round uint64) (*common.Beacon, erro
 	beacon := &common.Beacon{}
 	err := b.db.View(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket(beaconBucket)
-		v := bucket.Get(chain.RoundToBytes(round))
+		v := bucket.Get(chain.RoundToBytes(round.Uint64()))
 		if v == nil {
 			return chainerrors.ErrNoBeaconStored
 		}
@@ -246,7 +247,7 @@ func (b *BoltStore) Get(ctx context.Context, round uint64) (*common.Beacon, erro
 	return beacon, err
 }
 
-func (b *BoltStore) Del(ctx context.Context, round uint64) error {
+func (b *BoltStore) Del(ctx context.Context, round common.Round) error {
 	ctx, span := tracer.NewSpan(ctx, "boltStore.Del")
 	defer span.End()
 
@@ -258,7 +259,7 @@ func (b *BoltStore) Del(ctx context.Context, round uint64) error {
 
 	return b.db.Update(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket(beaconBucket)
-		return bucket.Delete(chain.RoundToBytes(round))
+		return bucket.Delete(chain.RoundToBytes(round.Uint64()))
 	})
 }
 
@@ -287,7 +288,7 @@ func (b *BoltStore) Cursor(ctx context.Context, fn func(context.Context, chain.C
 	return err
 }
 
-// SaveTo saves the bolt database to an alternate file.
+// SaveTo saves the bolt database to an alternate file, throttled by the limiter carried by the context, if any.
 func (b *BoltStore) SaveTo(ctx context.Context, w io.Writer) error {
 	ctx, span := tracer.NewSpan(ctx, "boltStore.SaveTo")
 	defer span.End()
@@ -298,6 +299,9 @@ func (b *BoltStore) SaveTo(ctx context.Context, w io.Writer) error {
 	default:
 	}
 
+	if limiter := iolimit.FromContext(ctx); limiter != nil {
+		return saveThrottled(ctx, b.db, w, limiter)
+	}
 	return b.db.View(func(tx *bolt.Tx) error {
 		_, err := tx.WriteTo(w)
 		return err
@@ -348,7 +352,7 @@ func (c *boltCursor) Next(ctx context.Context) (*common.Beacon, error) {
 	return b, err
 }
 
-func (c *boltCursor) Seek(ctx context.Context, round uint64) (*common.Beacon, error) {
+func (c *boltCursor) Seek(ctx context.Context, round common.Round) (*common.Beacon, error) {
 	ctx, span := tracer.NewSpan(ctx, "boltCursor.Seek")
 	defer span.End()
 
@@ -358,7 +362,7 @@ func (c *boltCursor) Seek(ctx context.Context, round uint64) (*common.Beacon, er
 	default:
 	}
 
-	k, v := c.Cursor.Seek(chain.RoundToBytes(round))
+	k, v := c.Cursor.Seek(chain.RoundToBytes(round.Uint64()))
 	if k == nil {
 		return nil, chainerrors.ErrNoBeaconStored
 	}
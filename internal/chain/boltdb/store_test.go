@@ -3,14 +3,19 @@ package boltdb
 import (
 	"context"
 	"errors"
+	"os"
+	"path"
 	"testing"
 
+	clock "github.com/jonboulle/clockwork"
 	"github.com/stretchr/testify/require"
 
 	"github.com/drand/drand/v2/common"
+	public "github.com/drand/drand/v2/common/chain"
 	"github.com/drand/drand/v2/common/testlogger"
 	"github.com/drand/drand/v2/internal/chain"
 	chainerrors "github.com/drand/drand/v2/internal/chain/errors"
+	"github.com/drand/drand/v2/internal/iolimit"
 )
 
 func TestStoreBoltOrder(t *testing.T) {
@@ -107,7 +112,7 @@ func TestStoreBolt(t *testing.T) {
 	require.NoError(t, store.Put(ctx, b1))
 
 	require.NoError(t, store.Put(ctx, b1))
-	bb1, err := store.Get(ctx, b1.Round)
+	bb1, err := store.Get(ctx, common.Round(b1.Round))
 	require.NoError(t, err)
 	require.Equal(t, b1, bb1)
 	err = store.Close()
@@ -202,7 +207,7 @@ func TestStore_Cursor(t *testing.T) {
 		for key, orig := range beacons {
 			t.Logf("seeking beacon %d\n", key)
 
-			b, err := c.Seek(ctx, uint64(key))
+			b, err := c.Seek(ctx, common.Round(key))
 			require.NoError(t, err)
 			require.NotNil(t, b)
 			require.Equal(t, orig, b)
@@ -244,3 +249,111 @@ func TestShouldUseTrimmedBolt(t *testing.T) {
 		})
 	}
 }
+
+func TestStoreBoltMetadata(t *testing.T) {
+	ctx := context.Background()
+	l := testlogger.New(t)
+	trimmed, err := newTrimmedStore(ctx, l, t.TempDir(), nil)
+	require.NoError(t, err)
+	untrimmed, err := NewBoltStore(IsATest(ctx), l, t.TempDir(), nil)
+	require.NoError(t, err)
+
+	for _, store := range []chain.Store{trimmed, untrimmed} {
+		ms, ok := store.(chain.MetadataStore)
+		require.True(t, ok)
+
+		value, err := ms.GetMetadata(ctx, "key")
+		require.NoError(t, err)
+		require.Nil(t, value)
+
+		require.NoError(t, ms.PutMetadata(ctx, "key", []byte("value")))
+		value, err = ms.GetMetadata(ctx, "key")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), value)
+
+		md, err := public.NewMetadata(store, "ext")
+		require.NoError(t, err)
+		require.NoError(t, md.Put(ctx, "cursor", []byte("12")))
+		listed, err := md.List(ctx)
+		require.NoError(t, err)
+		require.Equal(t, map[string][]byte{"cursor": []byte("12")}, listed)
+		require.NoError(t, md.Delete(ctx, "cursor"))
+		value, err = md.Get(ctx, "cursor")
+		require.NoError(t, err)
+		require.Nil(t, value)
+
+		// metadata don't count as beacons
+		sLen, err := store.Len(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 0, sLen)
+		require.NoError(t, store.Close())
+	}
+}
+
+func TestOpenArchive(t *testing.T) {
+	ctx := context.Background()
+	for _, file := range []string{"./testdata/trimmed.db", "./testdata/untrimmed.db"} {
+		t.Run(file, func(t *testing.T) {
+			archive, err := OpenArchive(ctx, testlogger.New(t), file)
+			require.NoError(t, err)
+			defer func() {
+				require.NoError(t, archive.Close())
+			}()
+
+			last, err := archive.Last(ctx)
+			require.NoError(t, err)
+			require.NotZero(t, last.Round)
+			b, err := archive.Get(ctx, common.Round(last.Round))
+			require.NoError(t, err)
+			require.Equal(t, last.Signature, b.Signature)
+
+			// the archive is never modified
+			require.Error(t, archive.Put(ctx, &common.Beacon{Round: last.Round + 1, Signature: []byte("signature")}))
+		})
+	}
+
+	_, err := OpenArchive(ctx, testlogger.New(t), "./testdata/missing.db")
+	require.Error(t, err)
+}
+
+func TestThrottledSaveToAndCompact(t *testing.T) {
+	ctx := context.Background()
+	l := testlogger.New(t)
+	folder := t.TempDir()
+	store, err := NewBoltStore(ctx, l, folder, nil)
+	require.NoError(t, err)
+	rounds := uint64(copyBatchSize + 10)
+	for i := uint64(1); i <= rounds; i++ {
+		require.NoError(t, store.Put(ctx, &common.Beacon{Round: i, Signature: []byte{byte(i), byte(i >> 8)}}))
+	}
+	ms := store.(chain.MetadataStore)
+	require.NoError(t, ms.PutMetadata(ctx, "key", []byte("value")))
+
+	// the throttled backup copies the database in batches
+	backup, err := os.Create(path.Join(t.TempDir(), "backup.db"))
+	require.NoError(t, err)
+	limiter := iolimit.NewLimiter(clock.NewRealClock(), 1<<30)
+	require.NoError(t, store.SaveTo(iolimit.WithLimiter(ctx, limiter), backup))
+	require.NoError(t, backup.Close())
+	require.NoError(t, store.Close())
+
+	archive, err := OpenArchive(ctx, l, backup.Name())
+	require.NoError(t, err)
+	last, err := archive.Last(ctx)
+	require.NoError(t, err)
+	require.Equal(t, rounds, last.Round)
+	value, err := archive.(chain.MetadataStore).GetMetadata(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+	require.NoError(t, archive.Close())
+
+	before, after, err := Compact(ctx, folder)
+	require.NoError(t, err)
+	require.LessOrEqual(t, after, before)
+	store, err = NewBoltStore(ctx, l, folder, nil)
+	require.NoError(t, err)
+	defer store.Close()
+	b, err := store.Get(ctx, copyBatchSize+1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(copyBatchSize+1), b.Round)
+}
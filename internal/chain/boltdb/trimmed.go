@@ -15,6 +15,7 @@ import (
 	"github.com/drand/drand/v2/common/log"
 	"github.com/drand/drand/v2/internal/chain"
 	chainerrors "github.com/drand/drand/v2/internal/chain/errors"
+	"github.com/drand/drand/v2/internal/iolimit"
 )
 
 // trimmedStore implements the Store interface using the kv storage boltdb (native
@@ -151,7 +152,7 @@ func (b *trimmedStore) Last(ctx context.Context) (*common.Beacon, error) {
 }
 
 // Get returns the beacon saved at this round
-func (b *trimmedStore) Get(ctx context.Context, round uint64) (*common.Beacon, error) {
+func (b *trimmedStore) Get(ctx context.Context, round common.Round) (*common.Beacon, error) {
 	ctx, span := tracer.NewSpan(ctx, "boltTrimmedStore.Get")
 	defer span.End()
 
@@ -164,7 +165,7 @@ func (b *trimmedStore) Get(ctx context.Context, round uint64) (*common.Beacon, e
 	var beacon *common.Beacon
 	err := b.db.View(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket(beaconBucket)
-		b, err := b.getBeacon(ctx, bucket, round, true)
+		b, err := b.getBeacon(ctx, bucket, round.Uint64(), true)
 		if err != nil {
 			return err
 		}
@@ -214,7 +215,7 @@ func (b *trimmedStore) getBeacon(ctx context.Context, bucket *bolt.Bucket, round
 	return &beacon, nil
 }
 
-func (b *trimmedStore) Del(ctx context.Context, round uint64) error {
+func (b *trimmedStore) Del(ctx context.Context, round common.Round) error {
 	ctx, span := tracer.NewSpan(ctx, "boltTrimmedStore.Del")
 	defer span.End()
 
@@ -226,7 +227,7 @@ func (b *trimmedStore) Del(ctx context.Context, round uint64) error {
 
 	return b.db.Update(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket(beaconBucket)
-		return bucket.Delete(chain.RoundToBytes(round))
+		return bucket.Delete(chain.RoundToBytes(round.Uint64()))
 	})
 }
 
@@ -255,11 +256,14 @@ func (b *trimmedStore) Cursor(ctx context.Context, fn func(context.Context, chai
 	return err
 }
 
-// SaveTo saves the bolt database to an alternate file.
+// SaveTo saves the bolt database to an alternate file, throttled by the limiter carried by the context, if any.
 func (b *trimmedStore) SaveTo(ctx context.Context, w io.Writer) error {
 	_, span := tracer.NewSpan(ctx, "boltTrimmedStore.SaveTo")
 	defer span.End()
 
+	if limiter := iolimit.FromContext(ctx); limiter != nil {
+		return saveThrottled(ctx, b.db, w, limiter)
+	}
 	return b.db.View(func(tx *bolt.Tx) error {
 		_, err := tx.WriteTo(w)
 		return err
@@ -287,7 +291,7 @@ func (c *trimmedBoltCursor) Next(ctx context.Context) (*common.Beacon, error) {
 	return c.store.getCursorBeacon(ctx, c.Bucket(), c.Cursor.Next)
 }
 
-func (c *trimmedBoltCursor) Seek(ctx context.Context, round uint64) (*common.Beacon, error) {
+func (c *trimmedBoltCursor) Seek(ctx context.Context, round common.Round) (*common.Beacon, error) {
 	ctx, span := tracer.NewSpan(ctx, "boltTrimmedStore.Cursor.Seek")
 	defer span.End()
 
@@ -297,13 +301,13 @@ func (c *trimmedBoltCursor) Seek(ctx context.Context, round uint64) (*common.Bea
 	default:
 	}
 
-	_, v := c.Cursor.Seek(chain.RoundToBytes(round))
+	_, v := c.Cursor.Seek(chain.RoundToBytes(round.Uint64()))
 	if v == nil {
 		return nil, chainerrors.ErrNoBeaconStored
 	}
 
 	b := common.Beacon{
-		Round:     round,
+		Round:     round.Uint64(),
 		Signature: v,
 	}
 
@@ -150,7 +150,7 @@ func TestTrimmedStoreBolt(t *testing.T) {
 	require.NoError(t, store.Put(ctx, b1))
 
 	require.NoError(t, store.Put(ctx, b1))
-	bb1, err := store.Get(ctx, b1.Round)
+	bb1, err := store.Get(ctx, common.Round(b1.Round))
 	require.NoError(t, err)
 	require.Equal(t, b1, bb1)
 	err = store.Close()
@@ -253,7 +253,7 @@ func TestTrimmedStore_Cursor(t *testing.T) {
 		for key, orig := range beacons {
 			t.Logf("seeking beacon %d\n", key)
 
-			b, err := c.Seek(ctx, uint64(key))
+			b, err := c.Seek(ctx, common.Round(key))
 			require.NoError(t, err)
 			require.NotNil(t, b)
 			require.True(t, orig.Equal(b))
@@ -1,11 +1,13 @@
 package errors
 
-import "errors"
+import (
+	public "github.com/drand/drand/v2/common/chain"
+)
 
 // ErrNoBeaconStored is the error we get when a sync is called too early and
 // there are no beacon above the requested round
-var ErrNoBeaconStored = errors.New("no beacon stored above requested round")
+var ErrNoBeaconStored = public.ErrNoBeaconStored
 
 // ErrNoBeaconSaved is the error returned when no beacon have been saved in the
 // database yet.
-var ErrNoBeaconSaved = errors.New("beacon not found in database")
+var ErrNoBeaconSaved = public.ErrNoBeaconSaved
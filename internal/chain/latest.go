@@ -0,0 +1,31 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/drand/drand/v2/common"
+	chainerrors "github.com/drand/drand/v2/internal/chain/errors"
+)
+
+// ErrRoundNotReached is returned by StoredAt when the store doesn't reach the round yet, as on a node lagging behind
+// the chain
+var ErrRoundNotReached = errors.New("the store doesn't reach the round yet")
+
+// StoredAt returns the beacon of the given round, ErrRoundNotReached if the last round of the store is before it, or
+// ErrNoBeaconStored if the round falls in a gap of the store. A closer round is never returned in its place: the
+// beacon of the round was produced, the node just doesn't have it. The genesis beacon is never returned.
+func StoredAt(ctx context.Context, s Store, round uint64) (*common.Beacon, error) {
+	if round == 0 {
+		return nil, chainerrors.ErrNoBeaconStored
+	}
+	last, err := s.Last(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if last.Round < round {
+		return nil, fmt.Errorf("%w: the last round stored is %d, not %d", ErrRoundNotReached, last.Round, round)
+	}
+	return s.Get(ctx, common.Round(round))
+}
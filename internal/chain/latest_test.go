@@ -0,0 +1,39 @@
+package chain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/internal/chain"
+	chainerrors "github.com/drand/drand/v2/internal/chain/errors"
+	"github.com/drand/drand/v2/internal/chain/memdb"
+)
+
+func TestStoredAt(t *testing.T) {
+	ctx := context.Background()
+	store := memdb.NewStore(10)
+
+	require.NoError(t, store.Put(ctx, chain.GenesisBeacon([]byte("genesis"))))
+	_, err := chain.StoredAt(ctx, store, 0)
+	require.ErrorIs(t, err, chainerrors.ErrNoBeaconStored, "the genesis beacon isn't randomness")
+	_, err = chain.StoredAt(ctx, store, 3)
+	require.ErrorIs(t, err, chain.ErrRoundNotReached)
+
+	// round 4 is missing from the store
+	for _, round := range []uint64{1, 2, 3, 5, 6} {
+		require.NoError(t, store.Put(ctx, &common.Beacon{Round: round, Signature: []byte{byte(round)}}))
+	}
+
+	for _, round := range []uint64{2, 5, 6} {
+		b, err := chain.StoredAt(ctx, store, round)
+		require.NoError(t, err)
+		require.Equal(t, round, b.Round)
+	}
+	_, err = chain.StoredAt(ctx, store, 4)
+	require.ErrorIs(t, err, chainerrors.ErrNoBeaconStored, "a gap isn't filled with the round before it")
+	_, err = chain.StoredAt(ctx, store, 9)
+	require.ErrorIs(t, err, chain.ErrRoundNotReached, "a lagging node doesn't serve its last round in place of the one due")
+}
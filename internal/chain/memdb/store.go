@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strings"
 	"sync"
 
 	"github.com/drand/drand/v2/common/tracer"
@@ -19,6 +20,7 @@ type Store struct {
 	storeMtx   *sync.RWMutex
 	store      []*common.Beacon
 	bufferSize int
+	metadata   map[string][]byte
 }
 
 // NewStore returns a new store that provides the CRUD based API needed for
@@ -33,6 +35,7 @@ func NewStore(bufferSize int) *Store {
 		storeMtx:   &sync.RWMutex{},
 		store:      make([]*common.Beacon, 0, bufferSize),
 		bufferSize: bufferSize,
+		metadata:   make(map[string][]byte),
 	}
 }
 
@@ -94,7 +97,7 @@ func (s *Store) Last(ctx context.Context) (*common.Beacon, error) {
 	return result, nil
 }
 
-func (s *Store) Get(ctx context.Context, round uint64) (*common.Beacon, error) {
+func (s *Store) Get(ctx context.Context, round common.Round) (*common.Beacon, error) {
 	_, span := tracer.NewSpan(ctx, "memDB.Get")
 	defer span.End()
 
@@ -102,7 +105,7 @@ func (s *Store) Get(ctx context.Context, round uint64) (*common.Beacon, error) {
 	defer s.storeMtx.RUnlock()
 
 	for _, beacon := range s.store {
-		if beacon.Round == round {
+		if beacon.Round == round.Uint64() {
 			return beacon, nil
 		}
 	}
@@ -125,7 +128,7 @@ func (s *Store) Close() error {
 	return nil
 }
 
-func (s *Store) Del(ctx context.Context, round uint64) error {
+func (s *Store) Del(ctx context.Context, round common.Round) error {
 	_, span := tracer.NewSpan(ctx, "memDB.Del")
 	defer span.End()
 
@@ -134,7 +137,7 @@ func (s *Store) Del(ctx context.Context, round uint64) error {
 
 	foundIdx := -1
 	for idx, beacon := range s.store {
-		if beacon.Round == round {
+		if beacon.Round == round.Uint64() {
 			foundIdx = idx
 			break
 		}
@@ -149,6 +152,72 @@ func (s *Store) Del(ctx context.Context, round uint64) error {
 	return nil
 }
 
+// GetMetadata implements the chain.MetadataStore interface
+func (s *Store) GetMetadata(ctx context.Context, key string) ([]byte, error) {
+	_, span := tracer.NewSpan(ctx, "memDB.GetMetadata")
+	defer span.End()
+
+	s.storeMtx.RLock()
+	defer s.storeMtx.RUnlock()
+
+	return s.metadata[key], nil
+}
+
+// PutMetadata implements the chain.MetadataStore interface
+func (s *Store) PutMetadata(ctx context.Context, key string, value []byte) error {
+	_, span := tracer.NewSpan(ctx, "memDB.PutMetadata")
+	defer span.End()
+
+	s.storeMtx.Lock()
+	defer s.storeMtx.Unlock()
+
+	s.metadata[key] = value
+	return nil
+}
+
+// PutMetadataBatch implements the chain.MetadataBatchStore interface
+func (s *Store) PutMetadataBatch(ctx context.Context, values map[string][]byte) error {
+	_, span := tracer.NewSpan(ctx, "memDB.PutMetadataBatch")
+	defer span.End()
+
+	s.storeMtx.Lock()
+	defer s.storeMtx.Unlock()
+
+	for k, v := range values {
+		s.metadata[k] = v
+	}
+	return nil
+}
+
+// DeleteMetadata implements the chain.MetadataStore interface
+func (s *Store) DeleteMetadata(ctx context.Context, key string) error {
+	_, span := tracer.NewSpan(ctx, "memDB.DeleteMetadata")
+	defer span.End()
+
+	s.storeMtx.Lock()
+	defer s.storeMtx.Unlock()
+
+	delete(s.metadata, key)
+	return nil
+}
+
+// ListMetadata implements the chain.MetadataStore interface
+func (s *Store) ListMetadata(ctx context.Context, prefix string) (map[string][]byte, error) {
+	_, span := tracer.NewSpan(ctx, "memDB.ListMetadata")
+	defer span.End()
+
+	s.storeMtx.RLock()
+	defer s.storeMtx.RUnlock()
+
+	values := make(map[string][]byte)
+	for k, v := range s.metadata {
+		if strings.HasPrefix(k, prefix) {
+			values[k] = v
+		}
+	}
+	return values, nil
+}
+
 func (s *Store) SaveTo(ctx context.Context, _ io.Writer) error {
 	_, span := tracer.NewSpan(ctx, "memDB.SaveTo")
 	defer span.End()
@@ -198,7 +267,7 @@ func (m *memDBCursor) Next(ctx context.Context) (*common.Beacon, error) {
 	return result, nil
 }
 
-func (m *memDBCursor) Seek(ctx context.Context, round uint64) (*common.Beacon, error) {
+func (m *memDBCursor) Seek(ctx context.Context, round common.Round) (*common.Beacon, error) {
 	_, span := tracer.NewSpan(ctx, "memDB.Cursor.Seek")
 	defer span.End()
 
@@ -206,7 +275,7 @@ func (m *memDBCursor) Seek(ctx context.Context, round uint64) (*common.Beacon, e
 	defer m.s.storeMtx.RUnlock()
 
 	for idx, beacon := range m.s.store {
-		if beacon.Round != round {
+		if beacon.Round != round.Uint64() {
 			continue
 		}
 
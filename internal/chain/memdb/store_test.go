@@ -105,7 +105,7 @@ func TestStoreBolt(t *testing.T) {
 	require.NoError(t, s.Put(ctx, b1))
 
 	require.NoError(t, s.Put(ctx, b1))
-	bb1, err := s.Get(ctx, b1.Round)
+	bb1, err := s.Get(ctx, common.Round(b1.Round))
 	require.NoError(t, err)
 	require.Equal(t, b1, bb1)
 	err = s.Close()
@@ -197,7 +197,7 @@ func TestStore_Cursor(t *testing.T) {
 		for key, orig := range beacons {
 			t.Logf("seeking beacon %d\n", key)
 
-			b, err := c.Seek(ctx, uint64(key))
+			b, err := c.Seek(ctx, common.Round(key))
 			require.NoError(t, err)
 			require.NotNil(t, b)
 			require.Equal(t, orig, b)
@@ -18,6 +18,9 @@ import (
 )
 
 // Store represents access to the postgres database for beacon management.
+// It doesn't keep metadata next to the beacons: chain.NewMetadata returns chain.ErrMetadataUnsupported for it, and the
+// features relying on it, such as the annotations and the randomness index, are unavailable, and the statistics
+// aren't cached.
 type Store struct {
 	log              log.Logger
 	db               *sqlx.DB
@@ -261,11 +264,11 @@ func (p *Store) Last(ctx context.Context) (*common.Beacon, error) {
 }
 
 // Get returns the specified beacon from the configured beacon table.
-func (p *Store) Get(ctx context.Context, round uint64) (*common.Beacon, error) {
+func (p *Store) Get(ctx context.Context, round common.Round) (*common.Beacon, error) {
 	ctx, span := tracer.NewSpan(ctx, "pgStore.Get")
 	defer span.End()
 
-	return p.get(ctx, round, true)
+	return p.get(ctx, round.Uint64(), true)
 }
 
 func (p *Store) get(ctx context.Context, round uint64, canFetchPrevious bool) (*common.Beacon, error) {
@@ -298,7 +301,7 @@ func (p *Store) get(ctx context.Context, round uint64, canFetchPrevious bool) (*
 }
 
 // Del removes the specified round from the beacon table.
-func (p *Store) Del(ctx context.Context, round uint64) error {
+func (p *Store) Del(ctx context.Context, round common.Round) error {
 	ctx, span := tracer.NewSpan(ctx, "pgStore.Del")
 	defer span.End()
 
@@ -320,7 +323,54 @@ func (p *Store) Del(ctx context.Context, round uint64) error {
 		Round uint64 `db:"round"`
 	}{
 		ID:    p.beaconID,
-		Round: round,
+		Round: round.Uint64(),
+	}
+
+	_, err := p.db.NamedExecContext(ctx, query, data)
+	return err
+}
+
+// DeleteBeaconID removes the beacon and, by cascade, all its rounds from the database.
+func (p *Store) DeleteBeaconID(ctx context.Context) error {
+	ctx, span := tracer.NewSpan(ctx, "pgStore.DeleteBeaconID")
+	defer span.End()
+
+	const query = `
+	DELETE FROM
+		beacons
+	WHERE
+		id = :id`
+
+	data := struct {
+		ID int `db:"id"`
+	}{
+		ID: p.beaconID,
+	}
+
+	_, err := p.db.NamedExecContext(ctx, query, data)
+	return err
+}
+
+// RenameBeaconID moves the beacon and its rounds under another name, e.g. to archive them, so that a new beacon
+// with the same name doesn't start from them.
+func (p *Store) RenameBeaconID(ctx context.Context, name string) error {
+	ctx, span := tracer.NewSpan(ctx, "pgStore.RenameBeaconID")
+	defer span.End()
+
+	const query = `
+	UPDATE
+		beacons
+	SET
+		name = :name
+	WHERE
+		id = :id`
+
+	data := struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}{
+		ID:   p.beaconID,
+		Name: name,
 	}
 
 	_, err := p.db.NamedExecContext(ctx, query, data)
@@ -475,7 +525,7 @@ func (c *cursor) Next(ctx context.Context) (*common.Beacon, error) {
 }
 
 // Seek searches the beacon table for the specified round
-func (c *cursor) Seek(ctx context.Context, round uint64) (*common.Beacon, error) {
+func (c *cursor) Seek(ctx context.Context, round common.Round) (*common.Beacon, error) {
 	ctx, span := tracer.NewSpan(ctx, "pgStore.Cursor.Seek")
 	defer span.End()
 
@@ -501,7 +551,7 @@ func (c *cursor) Seek(ctx context.Context, round uint64) (*common.Beacon, error)
 		Round uint64 `db:"round"`
 	}{
 		ID:    c.store.beaconID,
-		Round: round,
+		Round: round.Uint64(),
 	}
 
 	ret, err := c.store.getBeacon(ctx, true, query, data)
@@ -154,7 +154,7 @@ func TestStore_Cursor(t *testing.T) {
 		for key, orig := range beacons {
 			t.Logf("seeking beacon %d\n", key)
 
-			b, err := c.Seek(ctx, uint64(key))
+			b, err := c.Seek(ctx, common.Round(key))
 			require.NoError(t, err)
 			require.NotNil(t, b)
 			require.True(t, orig.Equal(b))
@@ -205,6 +205,29 @@ func Test_WithReservedIdentifier(t *testing.T) {
 	doStorePgTest(ctx, t, store, l, db, beaconName, prevMatters)
 }
 
+func Test_RenameBeaconID(t *testing.T) {
+	ctx := context.Background()
+	l, db := test.NewUnit(t, c, t.Name())
+
+	store, err := pgdb.NewStore(ctx, l, db, "beacon")
+	require.NoError(t, err)
+	require.NoError(t, store.Put(ctx, &common.Beacon{Round: 1, Signature: []byte{0x01}}))
+	require.NoError(t, store.RenameBeaconID(ctx, "beacon-archived"))
+
+	// a beacon with the same name starts from no round
+	fresh, err := pgdb.NewStore(ctx, l, db, "beacon")
+	require.NoError(t, err)
+	ln, err := fresh.Len(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, ln)
+
+	archived, err := pgdb.NewStore(ctx, l, db, "beacon-archived")
+	require.NoError(t, err)
+	ln, err = archived.Len(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, ln)
+}
+
 //nolint:funlen // We want this to be lengthy function
 func doStorePgTest(ctx context.Context, t *testing.T, dbStore *pgdb.Store, l log.Logger, db *sqlx.DB, beaconName string, prevMatters bool) {
 	var sig0 = []byte{0x00, 0x01, 0x02}
@@ -270,7 +293,7 @@ func doStorePgTest(ctx context.Context, t *testing.T, dbStore *pgdb.Store, l log
 	require.NoError(t, dbStore.Put(ctx, b1))
 
 	require.NoError(t, dbStore.Put(ctx, b1))
-	bb1, err := dbStore.Get(ctx, b1.Round)
+	bb1, err := dbStore.Get(ctx, common.Round(b1.Round))
 	require.NoError(t, err)
 	require.True(t, b1.Equal(bb1))
 
@@ -0,0 +1,169 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/drand/drand/v2/common"
+	public "github.com/drand/drand/v2/common/chain"
+	"github.com/drand/drand/v2/common/log"
+	chainerrors "github.com/drand/drand/v2/internal/chain/errors"
+)
+
+// RandomnessIndexNamespace is the namespace of the metadata of the chain store indexing the rounds by randomness
+const RandomnessIndexNamespace = public.InternalNamespacePrefix + "randomness"
+
+// indexedKey keeps the last round indexed by the backfill. The randomness is indexed in hex, so it can't collide with it.
+const indexedKey = "indexed"
+
+// indexBatch is how many rounds the backfill indexes in a single write, which also records its progress so that it
+// isn't lost if it is interrupted
+const indexBatch = 1000
+
+// RandomnessIndex resolves the randomness of a beacon, i.e. the hash of its signature, back to the beacon, for the
+// systems which only recorded the randomness they used. The index is kept in the metadata of the chain store. The
+// beacons are indexed as they are put in the store returned by Indexing, gaps filled included, and the ones stored
+// before by a backfill running in the background, so that looking a beacon up doesn't index anything.
+type RandomnessIndex struct {
+	// serializes the backfills
+	sync.Mutex
+	store Store
+	meta  *public.Metadata
+}
+
+// NewRandomnessIndex returns the index of the randomness of the store. It returns public.ErrMetadataUnsupported if
+// the store can't keep it.
+func NewRandomnessIndex(store Store) (*RandomnessIndex, error) {
+	meta, err := public.NewMetadata(store, RandomnessIndexNamespace)
+	if err != nil {
+		return nil, err
+	}
+	return &RandomnessIndex{store: store, meta: meta}, nil
+}
+
+// Lookup returns the beacon of the given randomness, or ErrNoBeaconStored if none of the indexed beacons has it
+func (x *RandomnessIndex) Lookup(ctx context.Context, randomness []byte) (*common.Beacon, error) {
+	value, err := x.meta.Get(ctx, hex.EncodeToString(randomness))
+	if err != nil {
+		return nil, err
+	}
+	if len(value) != 8 {
+		return nil, fmt.Errorf("no beacon with the randomness %x: %w", randomness, chainerrors.ErrNoBeaconStored)
+	}
+	b, err := x.store.Get(ctx, common.Round(BytesToRound(value)))
+	if err != nil {
+		return nil, err
+	}
+	// the round may have been deleted and stored again since it was indexed
+	if !bytes.Equal(b.GetRandomness(), randomness) {
+		return nil, fmt.Errorf("no beacon with the randomness %x: %w", randomness, chainerrors.ErrNoBeaconStored)
+	}
+	return b, nil
+}
+
+// Index indexes the randomness of the beacon
+func (x *RandomnessIndex) Index(ctx context.Context, b *common.Beacon) error {
+	return x.meta.Put(ctx, hex.EncodeToString(b.GetRandomness()), RoundToBytes(b.Round))
+}
+
+// Backfill indexes the rounds stored after the last one it indexed, in batches. It covers the rounds stored before
+// the index existed, or which failed to be indexed when they were put.
+func (x *RandomnessIndex) Backfill(ctx context.Context) error {
+	x.Lock()
+	defer x.Unlock()
+
+	indexed := uint64(0)
+	value, err := x.meta.Get(ctx, indexedKey)
+	if err != nil {
+		return err
+	}
+	if len(value) == 8 {
+		indexed = BytesToRound(value)
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		batch, err := x.readBatch(ctx, indexed+1)
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		// the metadata is written outside of the cursor, bolt can't open a write transaction within a read one
+		values := make(map[string][]byte, len(batch)+1)
+		for _, b := range batch {
+			values[hex.EncodeToString(b.GetRandomness())] = RoundToBytes(b.Round)
+		}
+		indexed = batch[len(batch)-1].Round
+		values[indexedKey] = RoundToBytes(indexed)
+		if err := x.meta.PutBatch(ctx, values); err != nil {
+			return err
+		}
+	}
+}
+
+// Indexing returns the store indexing the randomness of the beacons put in it, and backfills the index in the
+// background until it is closed
+func (x *RandomnessIndex) Indexing(s Store, l log.Logger) Store {
+	ctx, cancel := context.WithCancel(context.Background())
+	is := &indexingStore{Store: s, index: x, l: l, cancel: cancel, done: make(chan struct{})}
+	go func() {
+		defer close(is.done)
+		if err := x.Backfill(ctx); err != nil && ctx.Err() == nil {
+			l.Warnw("unable to index the randomness of the rounds stored", "err", err)
+		}
+	}()
+	return is
+}
+
+// indexingStore indexes the randomness of the beacons put in the store
+type indexingStore struct {
+	Store
+	index  *RandomnessIndex
+	l      log.Logger
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func (s *indexingStore) Put(ctx context.Context, b *common.Beacon) error {
+	if err := s.Store.Put(ctx, b); err != nil {
+		return err
+	}
+	// the beacon is stored: failing to index it only keeps it from being looked up by its randomness until the
+	// next backfill reaches it
+	if err := s.index.Index(ctx, b); err != nil {
+		s.l.Warnw("unable to index the randomness of the beacon", "round", b.Round, "err", err)
+	}
+	return nil
+}
+
+func (s *indexingStore) Close() error {
+	s.cancel()
+	<-s.done
+	return s.Store.Close()
+}
+
+// readBatch reads up to indexBatch beacons starting at the given round
+func (x *RandomnessIndex) readBatch(ctx context.Context, from uint64) ([]*common.Beacon, error) {
+	var batch []*common.Beacon
+	err := x.store.Cursor(ctx, func(ctx context.Context, c Cursor) error {
+		b, err := c.Seek(ctx, common.Round(from))
+		for ; err == nil && b != nil && len(batch) < indexBatch; b, err = c.Next(ctx) {
+			if b.Round >= from {
+				batch = append(batch, b)
+			}
+		}
+		return err
+	})
+	if err != nil && !errors.Is(err, chainerrors.ErrNoBeaconStored) {
+		return nil, err
+	}
+	return batch, nil
+}
@@ -0,0 +1,61 @@
+package chain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/testlogger"
+	"github.com/drand/drand/v2/internal/chain"
+	chainerrors "github.com/drand/drand/v2/internal/chain/errors"
+	"github.com/drand/drand/v2/internal/chain/memdb"
+)
+
+func TestRandomnessIndex(t *testing.T) {
+	ctx := context.Background()
+	store := memdb.NewStore(2000)
+	index, err := chain.NewRandomnessIndex(store)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(ctx, chain.GenesisBeacon([]byte("genesis"))))
+	beaconAt := func(round uint64) *common.Beacon {
+		return &common.Beacon{Round: round, Signature: []byte{byte(round >> 8), byte(round)}}
+	}
+	// more rounds than a batch of the backfill, stored before the index
+	for round := uint64(1); round <= 1500; round++ {
+		require.NoError(t, store.Put(ctx, beaconAt(round)))
+	}
+	_, err = index.Lookup(ctx, beaconAt(1).GetRandomness())
+	require.ErrorIs(t, err, chainerrors.ErrNoBeaconStored, "looking up doesn't index")
+
+	require.NoError(t, index.Backfill(ctx))
+	for _, round := range []uint64{1, 999, 1000, 1001, 1500} {
+		b, err := index.Lookup(ctx, beaconAt(round).GetRandomness())
+		require.NoError(t, err)
+		require.Equal(t, round, b.Round)
+	}
+
+	// the rounds put in the indexing store are indexed right away
+	indexing := index.Indexing(store, testlogger.New(t))
+	require.NoError(t, indexing.Put(ctx, beaconAt(1501)))
+	b, err := index.Lookup(ctx, beaconAt(1501).GetRandomness())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1501), b.Round)
+
+	_, err = index.Lookup(ctx, beaconAt(2000).GetRandomness())
+	require.ErrorIs(t, err, chainerrors.ErrNoBeaconStored)
+
+	// so are the rounds filling a gap below the last one indexed, and a round stored again with another signature
+	// isn't resolved from its old randomness
+	require.NoError(t, store.Del(ctx, 10))
+	require.NoError(t, indexing.Put(ctx, &common.Beacon{Round: 10, Signature: []byte("resigned")}))
+	b, err = index.Lookup(ctx, (&common.Beacon{Signature: []byte("resigned")}).GetRandomness())
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), b.Round)
+	_, err = index.Lookup(ctx, beaconAt(10).GetRandomness())
+	require.ErrorIs(t, err, chainerrors.ErrNoBeaconStored)
+
+	require.NoError(t, indexing.Close())
+}
@@ -1,11 +1,10 @@
 package chain
 
 import (
-	"context"
 	"encoding/binary"
-	"io"
 
 	"github.com/drand/drand/v2/common"
+	public "github.com/drand/drand/v2/common/chain"
 )
 
 // store contains all the definitions and implementation of the logic that
@@ -14,32 +13,16 @@ import (
 
 // Store is an interface to store Beacons packets where they can also be
 // retrieved to be delivered to end clients.
-type Store interface {
-	Len(context.Context) (int, error)
-	Put(context.Context, *common.Beacon) error
-	Last(context.Context) (*common.Beacon, error)
-	Get(ctx context.Context, round uint64) (*common.Beacon, error)
-	Cursor(context.Context, func(context.Context, Cursor) error) error
-	Close() error
-	Del(ctx context.Context, round uint64) error
-	SaveTo(ctx context.Context, w io.Writer) error
-}
+type Store = public.Store
 
-// Cursor iterates over items in sorted key order. This starts from the
-// first key/value pair and updates the k/v variables to the
-// next key/value on each iteration.
-//
-// The loop finishes at the end of the cursor when a nil key is returned.
-//
-//	for k, v := c.First(); k != nil; k, v = c.Next() {
-//	    fmt.Printf("A %s is %s.\n", k, v)
-//	}
-type Cursor interface {
-	First(context.Context) (*common.Beacon, error)
-	Next(context.Context) (*common.Beacon, error)
-	Seek(ctx context.Context, round uint64) (*common.Beacon, error)
-	Last(context.Context) (*common.Beacon, error)
-}
+// MetadataStore is implemented by the stores able to keep arbitrary values next to the beacons
+type MetadataStore = public.MetadataStore
+
+// MetadataBatchStore is implemented by the metadata stores able to set several values at once
+type MetadataBatchStore = public.MetadataBatchStore
+
+// Cursor iterates over the beacons of a Store in the order of their rounds
+type Cursor = public.Cursor
 
 // StorageType defines the supported storage engines
 type StorageType string
@@ -19,3 +19,21 @@ func IsSkipLogsFromContext(ctx ccontext.Context) bool {
 	}
 	return false
 }
+
+type syncBufferType struct{}
+
+var syncBuffer syncBufferType
+
+// SetSyncBuffer sets the maximum number of beacons received from a peer that can be queued while syncing with it.
+func SetSyncBuffer(ctx ccontext.Context, size int) ccontext.Context {
+	return ccontext.WithValue(ctx, syncBuffer, size)
+}
+
+// SyncBufferFromContext returns the size of the sync buffer set in the context, or the given default if none is.
+func SyncBufferFromContext(ctx ccontext.Context, def int) int {
+	value, ok := ctx.Value(syncBuffer).(int)
+	if ok && value > 0 {
+		return value
+	}
+	return def
+}
@@ -0,0 +1,249 @@
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	mathrand "math/rand"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/chain"
+	"github.com/drand/drand/v2/internal/chain/boltdb"
+	"github.com/drand/drand/v2/internal/chain/memdb"
+	"github.com/drand/drand/v2/internal/chain/postgresdb/pgdb"
+	"github.com/drand/drand/v2/internal/fs"
+	"github.com/drand/drand/v2/internal/metrics"
+	"github.com/drand/drand/v2/plugin"
+)
+
+// StoreBench is how fast a storage engine wrote and read back synthetic beacons on the local hardware, and how much
+// space they took
+type StoreBench struct {
+	Engine  string        `json:"engine"`
+	Beacons int           `json:"beacons"`
+	Write   time.Duration `json:"write"`
+	Read    time.Duration `json:"read"`
+	// BytesPerBeacon is the space taken on disk by each beacon, 0 for the engines keeping them in memory
+	BytesPerBeacon float64 `json:"bytes_per_beacon"`
+}
+
+// WritesPerSecond returns the number of beacons written per second
+func (b *StoreBench) WritesPerSecond() float64 {
+	return perSecond(b.Beacons, b.Write)
+}
+
+// ReadsPerSecond returns the number of beacons read per second
+func (b *StoreBench) ReadsPerSecond() float64 {
+	return perSecond(b.Beacons, b.Read)
+}
+
+// Projected returns the space taken by the beacons of a chain with the given period after the given duration
+func (b *StoreBench) Projected(period, after time.Duration) float64 {
+	if period <= 0 {
+		return 0
+	}
+	return b.BytesPerBeacon * float64(after/period)
+}
+
+func perSecond(n int, d time.Duration) float64 {
+	if d <= 0 {
+		return 0
+	}
+	return float64(n) / d.Seconds()
+}
+
+// BenchStores lists the engines benchmarked by default: the built-in ones the node can open without more
+// configuration, PostgreSQL if a connection to it is configured, and the store drivers compiled into the binary
+func BenchStores(conf *Config) []chain.StorageType {
+	engines := []chain.StorageType{chain.BoltDB, chain.MemDB}
+	if conf.pgConn != nil {
+		engines = append(engines, chain.PostgreSQL)
+	}
+	for _, name := range plugin.Stores() {
+		engines = append(engines, chain.StorageType(name))
+	}
+	return engines
+}
+
+// BenchStore writes the given number of synthetic beacons of the scheme to a fresh store of the engine, reads them
+// back in a random order and measures the space they take. The store is kept in a temporary folder of the
+// configuration folder, so that the disk measured is the one of the node, or under a temporary beacon for
+// PostgreSQL, and removed once done.
+func BenchStore(ctx context.Context, conf *Config, engine chain.StorageType, sch *crypto.Scheme, beacons int) (*StoreBench, error) {
+	if beacons <= 0 {
+		return nil, fmt.Errorf("the number of beacons to write must be positive, got %d", beacons)
+	}
+	if sch.Name == crypto.DefaultSchemeID {
+		ctx = chain.SetPreviousRequiredOnContext(ctx)
+	}
+
+	base := fs.CreateSecureFolder(conf.ConfigFolderMB())
+	if base == "" {
+		return nil, fmt.Errorf("unable to create the folder %s", conf.ConfigFolderMB())
+	}
+	tmp, err := os.MkdirTemp(base, "bench-store-")
+	if err != nil {
+		return nil, fmt.Errorf("unable to write in the folder %s: %w", base, err)
+	}
+	defer os.RemoveAll(tmp)
+	// the stores report their type under the name of the beacon, which is the temporary one here
+	defer metrics.DrandStorageBackend.DeletePartialMatch(prometheus.Labels{"beacon_id": path.Base(tmp)})
+
+	bench := &benchStore{conf: conf, engine: engine, name: path.Base(tmp), folder: path.Join(tmp, DefaultDBFolder)}
+	if fs.CreateSecureFolder(bench.folder) == "" {
+		return nil, fmt.Errorf("unable to create the folder %s", bench.folder)
+	}
+	defer bench.cleanup(ctx)
+
+	store, err := bench.open(ctx, beacons)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open a %s store: %w", engine, err)
+	}
+	genesis := chain.GenesisBeacon([]byte("drand-bench-store"))
+	if err := store.Put(ctx, genesis); err != nil {
+		store.Close()
+		return nil, fmt.Errorf("unable to write to a %s store: %w", engine, err)
+	}
+	// bolt only reports its size once closed
+	if err := store.Close(); err != nil {
+		return nil, err
+	}
+	before, err := bench.size(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if store, err = bench.open(ctx, beacons); err != nil {
+		return nil, fmt.Errorf("unable to reopen a %s store: %w", engine, err)
+	}
+
+	result := &StoreBench{Engine: string(engine), Beacons: beacons}
+	sigLen := sch.SigGroup.PointLen()
+	previous := genesis.Signature
+	start := time.Now()
+	for round := uint64(1); round <= uint64(beacons); round++ {
+		b := &common.Beacon{Round: round, Signature: make([]byte, sigLen)}
+		_, _ = rand.Read(b.Signature)
+		if sch.Name == crypto.DefaultSchemeID {
+			b.PreviousSig = previous
+		}
+		if err := store.Put(ctx, b); err != nil {
+			store.Close()
+			return nil, fmt.Errorf("unable to write round %d to a %s store: %w", round, engine, err)
+		}
+		previous = b.Signature
+	}
+	result.Write = time.Since(start)
+
+	start = time.Now()
+	for _, i := range mathrand.Perm(beacons) {
+		if _, err := store.Get(ctx, common.Round(i+1)); err != nil {
+			store.Close()
+			return nil, fmt.Errorf("unable to read round %d from a %s store: %w", i+1, engine, err)
+		}
+	}
+	result.Read = time.Since(start)
+
+	if err := store.Close(); err != nil {
+		return nil, err
+	}
+	after, err := bench.size(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result.BytesPerBeacon = float64(after-before) / float64(beacons)
+	return result, nil
+}
+
+// benchStore opens the temporary store benchmarked and measures its size
+type benchStore struct {
+	conf   *Config
+	engine chain.StorageType
+	name   string
+	folder string
+	pg     *pgdb.Store
+}
+
+func (b *benchStore) open(ctx context.Context, beacons int) (chain.Store, error) {
+	l := b.conf.Logger()
+	switch b.engine {
+	case chain.BoltDB:
+		return boltdb.NewBoltStore(ctx, l, b.folder, b.conf.boltOpts)
+	case chain.MemDB:
+		return memdb.NewStore(beacons + 1), nil
+	case chain.PostgreSQL:
+		if b.conf.pgConn == nil {
+			return nil, errors.New("no connection to PostgreSQL, set it with --pg-dsn")
+		}
+		store, err := pgdb.NewStore(ctx, l, b.conf.pgConn, b.name)
+		if err != nil {
+			return nil, err
+		}
+		b.pg = store
+		return store, nil
+	default:
+		driver, ok := plugin.LookupStore(string(b.engine))
+		if !ok {
+			return nil, fmt.Errorf("unknown database storage engine type %q", b.engine)
+		}
+		return driver(ctx, l, common.BeaconID(b.name), b.folder)
+	}
+}
+
+// size returns the space taken by the store: the pages used by bolt, which grows its file by steps, the size of the
+// table of the beacons of PostgreSQL, and the size of the folder of the other drivers
+func (b *benchStore) size(ctx context.Context) (int64, error) {
+	switch b.engine {
+	case chain.MemDB:
+		return 0, nil
+	case chain.BoltDB:
+		db, err := bolt.Open(path.Join(b.folder, boltdb.BoltFileName), boltdb.BoltStoreOpenPerm, &bolt.Options{ReadOnly: true})
+		if err != nil {
+			return 0, fmt.Errorf("unable to measure the size of the bolt store: %w", err)
+		}
+		defer db.Close()
+		var size int64
+		err = db.View(func(tx *bolt.Tx) error {
+			size = tx.Size()
+			return nil
+		})
+		return size, err
+	case chain.PostgreSQL:
+		var size int64
+		err := b.conf.pgConn.GetContext(ctx, &size, "SELECT pg_total_relation_size('beacon_details')")
+		if err != nil {
+			return 0, fmt.Errorf("unable to measure the size of the PostgreSQL store: %w", err)
+		}
+		return size, nil
+	default:
+		var size int64
+		err := filepath.Walk(b.folder, func(_ string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() {
+				size += info.Size()
+			}
+			return nil
+		})
+		return size, err
+	}
+}
+
+// cleanup removes the temporary beacon from PostgreSQL, the folder of the other engines being removed with it
+func (b *benchStore) cleanup(ctx context.Context) {
+	if b.pg == nil {
+		return
+	}
+	if err := b.pg.DeleteBeaconID(ctx); err != nil {
+		b.conf.Logger().Warnw("Unable to remove the beacon used to benchmark PostgreSQL", "beacon_id", b.name, "err", err)
+	}
+}
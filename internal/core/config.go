@@ -2,8 +2,15 @@ package core
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -14,8 +21,14 @@ import (
 	"github.com/drand/drand/v2/common"
 	"github.com/drand/drand/v2/common/key"
 	"github.com/drand/drand/v2/common/log"
+	dhttp "github.com/drand/drand/v2/handler/http"
 	"github.com/drand/drand/v2/internal/chain"
+	"github.com/drand/drand/v2/internal/chain/beacon"
 	"github.com/drand/drand/v2/internal/chain/postgresdb/database"
+	"github.com/drand/drand/v2/internal/iolimit"
+	"github.com/drand/drand/v2/internal/net"
+	"github.com/drand/drand/v2/internal/redis"
+	"github.com/drand/drand/v2/plugin"
 )
 
 // ConfigOption is a function that applies a specific setting to a Config.
@@ -43,6 +56,63 @@ type Config struct {
 	clock                 clock.Clock
 	tracesEndpoint        string
 	tracesProbability     float64
+	metricsAddress        string
+	otlpMetricsEndpoint   string
+	otlpMetricsInterval   time.Duration
+	maxRequestSize        int
+	requestTimeout        time.Duration
+	maxStatusNodes        int
+	slowStoreThreshold    time.Duration
+	outboundAddrs         []string
+	maxStalePeriods       uint64
+	prefetchLead          time.Duration
+	heartbeatPeriod       time.Duration
+	subBeacons            []string
+	derivedChains         []string
+	publicKeyCacheSize    int
+	syncMaxInFlight       int
+	syncMemoryBudget      int64
+	syncPrefer            []string
+	syncDeny              []string
+	syncRegions           []string
+	ioLimits              []string
+	beaconIsolation       []string
+	mirrorTarget          string
+	featureFlags          []string
+	parsedFeatures        map[string]map[Feature]bool
+	statusSampleInterval  time.Duration
+	udpListenAddr         string
+	pushTargets           []string
+	acceptPush            []string
+	shareRefreshInterval  time.Duration
+	autoRefresh           bool
+	minRefreshInterval    time.Duration
+	v1Compat              []string
+	shedLatency           time.Duration
+	apiTiers              []string
+	apiKeys               []string
+	redisURL              string
+	shutdownOrder         []string
+	shutdownHooks         *plugin.ShutdownHooks
+	minGenesisDelay       time.Duration
+	controlAuth           bool
+	controlToken          string
+	controlRoles          []string
+	controlRoleTokens     []string
+	cors                  dhttp.CORS
+	securityHeaders       dhttp.SecurityHeaders
+	signer                string
+	publishers            []string
+	loadShedderOnce       sync.Once
+	loadShedder           *net.LoadShedder
+	quotasOnce            sync.Once
+	quotas                *net.Quotas
+	quotasErr             error
+	ioLimitersOnce        sync.Once
+	ioLimiters            map[string]*iolimit.Limiter
+	reloader              ConfigReloader
+	// guards the options ReloadConfig changes while the daemon runs
+	reloadLock sync.RWMutex
 }
 
 // NewConfig returns the config to pass to drand with the default options set
@@ -54,6 +124,12 @@ func NewConfig(l log.Logger, opts ...ConfigOption) *Config {
 		dkgKickoffGracePeriod: DefaultDKGKickoffGracePeriod,
 		dkgPhaseTimeout:       DefaultDKGPhaseTimeout,
 		controlPort:           DefaultControlPort,
+		maxRequestSize:        DefaultMaxRequestSize,
+		requestTimeout:        DefaultRequestTimeout,
+		maxStatusNodes:        DefaultMaxStatusNodes,
+		slowStoreThreshold:    DefaultSlowStoreThreshold,
+		statusSampleInterval:  DefaultStatusSampleInterval,
+		minRefreshInterval:    DefaultMinRefreshInterval,
 		logger:                l,
 		clock:                 clock.NewRealClock(),
 	}
@@ -63,6 +139,97 @@ func NewConfig(l log.Logger, opts ...ConfigOption) *Config {
 	return d
 }
 
+// Validate checks the options which can only be checked once they are all set, and which would otherwise only
+// fail when the daemon starts.
+func (d *Config) Validate() error {
+	if d.privateListenAddr == "" {
+		return errors.New("the private listen address is empty")
+	}
+	if _, err := d.OutboundAddresses(); err != nil {
+		return err
+	}
+	if _, err := d.SubBeacons(); err != nil {
+		return err
+	}
+	if _, err := d.DerivedChains(); err != nil {
+		return err
+	}
+	if _, err := d.SyncSources(); err != nil {
+		return err
+	}
+	if _, err := ParseIOLimits(d.ioLimits); err != nil {
+		return err
+	}
+	if _, err := d.BeaconIsolation(); err != nil {
+		return err
+	}
+	if _, err := ParseFeatureFlags(d.featureFlags); err != nil {
+		return err
+	}
+	if _, err := d.AcceptedPushes(); err != nil {
+		return err
+	}
+	if err := d.cors.Validate(); err != nil {
+		return err
+	}
+	if d.securityHeaders.HSTSMaxAge < 0 {
+		return errors.New("the HSTS max-age can't be negative")
+	}
+	if d.shedLatency < 0 {
+		return errors.New("the latency to shed the public traffic at can't be negative")
+	}
+	if d.minGenesisDelay < 0 {
+		return errors.New("the minimum genesis delay can't be negative")
+	}
+	if d.minRefreshInterval < 0 {
+		return errors.New("the minimum refresh interval can't be negative")
+	}
+	if d.shareRefreshInterval > 0 && d.shareRefreshInterval < d.minRefreshInterval {
+		return fmt.Errorf("the share refresh interval %s is shorter than the minimum refresh interval %s",
+			d.shareRefreshInterval, d.minRefreshInterval)
+	}
+	if d.prefetchLead < 0 {
+		return errors.New("the prefetch lead can't be negative")
+	}
+	if _, err := d.Quotas(); err != nil {
+		return err
+	}
+	if _, _, err := d.ControlRoles(); err != nil {
+		return err
+	}
+	if _, err := d.RedisOptions(); err != nil {
+		return err
+	}
+	if _, err := d.ShutdownOrder(); err != nil {
+		return err
+	}
+	for _, listener := range d.v1Compat {
+		if listener != V1CompatPublic && listener != V1CompatPrivate {
+			return fmt.Errorf("unknown listener %q to serve the v1 API on, expected %s or %s",
+				listener, V1CompatPublic, V1CompatPrivate)
+		}
+	}
+	if d.mirrorTarget != "" && d.mirrorTarget == d.privateListenAddr {
+		return errors.New("the public requests can't be mirrored to the daemon itself")
+	}
+	switch d.dbStorageEngine {
+	case chain.BoltDB, chain.PostgreSQL, chain.MemDB:
+	default:
+		if _, ok := plugin.LookupStore(string(d.dbStorageEngine)); !ok {
+			return fmt.Errorf("unknown database storage engine type %q", d.dbStorageEngine)
+		}
+	}
+	if _, ok := plugin.LookupSigner(d.signer); d.signer != "" && !ok {
+		return fmt.Errorf("unknown signer %q, registered: %v", d.signer, plugin.Signers())
+	}
+	for _, name := range d.publishers {
+		if _, ok := plugin.LookupPublisher(name); !ok {
+			return fmt.Errorf("unknown publisher %q, registered: %v", name, plugin.Publishers())
+		}
+	}
+	return nil
+}
+
 // ConfigFolder returns the folder under which drand stores all its
 // configuration.
 func (d *Config) ConfigFolder() string {
@@ -75,10 +242,21 @@ func (d *Config) ConfigFolderMB() string {
 	return path.Join(d.configFolder, common.MultiBeaconFolder)
 }
 
+// BeaconFolderMB returns the multi-beacon folder holding the keys and the database of the given beacon, which is
+// ConfigFolderMB unless the beacon is isolated in its own folder.
+func (d *Config) BeaconFolderMB(beaconID string) string {
+	// the isolation is validated when the daemon starts
+	isolation, _ := d.BeaconIsolation()
+	if iso, ok := isolation[common.GetCanonicalBeaconID(beaconID)]; ok && iso.Folder != "" {
+		return path.Join(iso.Folder, common.MultiBeaconFolder)
+	}
+	return d.ConfigFolderMB()
+}
+
 // DBFolder returns the folder under which drand stores db file specifically.
 // If beacon id is empty, it will use the default value
 func (d *Config) DBFolder(beaconID string) string {
-	return path.Join(d.ConfigFolderMB(), common.GetCanonicalBeaconID(beaconID), DefaultDBFolder)
+	return path.Join(d.BeaconFolderMB(beaconID), common.GetCanonicalBeaconID(beaconID), DefaultDBFolder)
 }
 
 // PrivateListenAddress returns the given default address or the listen address stored
@@ -237,6 +415,88 @@ func WithControlPort(port string) ConfigOption {
 	}
 }
 
+// WithClock sets the clock the beacons are produced with, e.g. a fake one to control the rounds in tests
+func WithClock(clk clock.Clock) ConfigOption {
+	return func(d *Config) {
+		d.clock = clk
+	}
+}
+
+// ConfigReloader re-reads the configuration of the daemon, for ReloadConfig to apply the options which can change
+// without a restart
+type ConfigReloader func() (*Config, error)
+
+// WithConfigReloader sets how the configuration is re-read when the daemon is asked to reload it, e.g. from the
+// config file it was started with. The daemon can't reload its configuration without one.
+func WithConfigReloader(reloader ConfigReloader) ConfigOption {
+	return func(d *Config) {
+		d.reloader = reloader
+	}
+}
+
+// WithControlAuth requires the calls to the control port to carry a bearer token: the one given by WithControlToken,
+// or else the one kept in the config folder, created on the first start.
+func WithControlAuth(enabled bool) ConfigOption {
+	return func(d *Config) {
+		d.controlAuth = enabled
+	}
+}
+
+// WithControlToken sets the token the calls to the control port must carry, which enables their authentication.
+// Unlike the token kept in the config folder, it can't be rotated.
+func WithControlToken(token string) ConfigOption {
+	return func(d *Config) {
+		d.controlToken = token
+	}
+}
+
+// WithControlRoles restricts the tokens of the control port to the RPCs of their role, given as "role:token", on
+// top of the admin token, allowed to call all of them. The roles are given as "name=Method[,Method...]", * allowing
+// all the RPCs, and the read-only one, allowed to inspect the daemon, exists unless redefined. Giving tokens enables
+// the authentication.
+func WithControlRoles(roles, tokens []string) ConfigOption {
+	return func(d *Config) {
+		d.controlRoles = roles
+		d.controlRoleTokens = tokens
+	}
+}
+
+// ControlAuth returns whether the calls to the control port must carry a token
+func (d *Config) ControlAuth() bool {
+	return d.controlAuth || d.controlToken != "" || len(d.controlRoleTokens) > 0
+}
+
+// ControlRoles returns the roles of the control port and the tokens granted them
+func (d *Config) ControlRoles() ([]net.ControlRole, []net.ControlRoleToken, error) {
+	roles := make([]net.ControlRole, 0, len(d.controlRoles))
+	for _, spec := range d.controlRoles {
+		role, err := net.ParseControlRole(spec)
+		if err != nil {
+			return nil, nil, err
+		}
+		roles = append(roles, role)
+	}
+	tokens := make([]net.ControlRoleToken, 0, len(d.controlRoleTokens))
+	for _, spec := range d.controlRoleTokens {
+		t, err := net.ParseControlRoleToken(spec)
+		if err != nil {
+			return nil, nil, err
+		}
+		tokens = append(tokens, t)
+	}
+	// the references to the roles are checked as they would be by the control port
+	if err := net.NewControlAuth("").SetRoles(roles, tokens); err != nil {
+		return nil, nil, err
+	}
+	return roles, tokens, nil
+}
+
+// ControlToken returns the token given to authenticate the calls to the control port, empty if it is kept in the
+// config folder
+func (d *Config) ControlToken() string {
+	return d.controlToken
+}
+
 func WithNamedLogger(name string) ConfigOption {
 	return func(d *Config) {
 		d.logger = d.logger.Named(name)
@@ -273,3 +533,692 @@ func WithTracesProbability(tracesProbability float64) ConfigOption {
 func (d *Config) TracesProbability() float64 {
 	return d.tracesProbability
 }
+
+// WithMetricsAddress sets the address the Prometheus metrics are served on, as given to LoadBeaconsFromDisk
+func WithMetricsAddress(addr string) ConfigOption {
+	return func(d *Config) {
+		d.metricsAddress = addr
+	}
+}
+
+// MetricsAddress retrieves the address the Prometheus metrics are served on, empty if it isn't configured
+func (d *Config) MetricsAddress() string {
+	return d.metricsAddress
+}
+
+// WithOTLPMetricsEndpoint sets the OpenTelemetry collector the metrics are pushed to, alongside Prometheus
+func WithOTLPMetricsEndpoint(endpoint string) ConfigOption {
+	return func(d *Config) {
+		d.otlpMetricsEndpoint = endpoint
+	}
+}
+
+// OTLPMetricsEndpoint retrieves the OpenTelemetry collector the metrics are pushed to, empty if they aren't
+func (d *Config) OTLPMetricsEndpoint() string {
+	return d.otlpMetricsEndpoint
+}
+
+// WithOTLPMetricsInterval sets the interval between two pushes of the metrics to the OpenTelemetry collector
+func WithOTLPMetricsInterval(interval time.Duration) ConfigOption {
+	return func(d *Config) {
+		d.otlpMetricsInterval = interval
+	}
+}
+
+// OTLPMetricsInterval retrieves the interval between two pushes of the metrics to the OpenTelemetry collector
+func (d *Config) OTLPMetricsInterval() time.Duration {
+	return d.otlpMetricsInterval
+}
+
+// WithMaxRequestSize sets the maximum size in bytes of a message accepted by the control and private gRPC servers.
+func WithMaxRequestSize(size int) ConfigOption {
+	return func(d *Config) {
+		d.maxRequestSize = size
+	}
+}
+
+// WithRequestTimeout sets the server-side deadline applied to the unary calls of the control and private gRPC servers.
+// A zero duration disables it.
+func WithRequestTimeout(timeout time.Duration) ConfigOption {
+	return func(d *Config) {
+		d.requestTimeout = timeout
+	}
+}
+
+// WithMaxStatusNodes sets the maximum number of nodes a single status or remote status request can ask us to contact.
+func WithMaxStatusNodes(n int) ConfigOption {
+	return func(d *Config) {
+		d.maxStatusNodes = n
+	}
+}
+
+// WithOutboundAddresses sets the local addresses the connections to the other nodes originate from, as parsed by
+// net.ParseOutboundAddresses.
+func WithOutboundAddresses(entries []string) ConfigOption {
+	return func(d *Config) {
+		d.outboundAddrs = entries
+	}
+}
+
+// OutboundAddresses returns the local addresses the connections to the other nodes originate from.
+func (d *Config) OutboundAddresses() (net.OutboundAddresses, error) {
+	return net.ParseOutboundAddresses(d.outboundAddrs)
+}
+
+// WithMaxStalePeriods makes the public endpoints serving the latest beacon refuse to serve one older than the
+// given number of periods. Zero disables the check.
+func WithMaxStalePeriods(periods uint64) ConfigOption {
+	return func(d *Config) {
+		d.maxStalePeriods = periods
+	}
+}
+
+// MaxStalePeriods returns the number of periods after which the latest beacon is considered stale, 0 if never.
+func (d *Config) MaxStalePeriods() uint64 {
+	d.reloadLock.RLock()
+	defer d.reloadLock.RUnlock()
+	return d.maxStalePeriods
+}
+
+// WithPrefetchLead makes the HTTP API watch the chains persistently and serve their latest round from memory, opening
+// a failed watch again that long before the next round is due. It is meant for relays. Zero disables it.
+func WithPrefetchLead(lead time.Duration) ConfigOption {
+	return func(d *Config) {
+		d.prefetchLead = lead
+	}
+}
+
+// PrefetchLead returns how long before the next round the HTTP API opens a failed watch again, 0 if it doesn't
+// prefetch the rounds.
+func (d *Config) PrefetchLead() time.Duration {
+	return d.prefetchLead
+}
+
+// WithHeartbeatPeriod makes the node sign a heartbeat with the rest of the group at the given period, letting
+// external watchers check the liveness of the group without following every round. All the members of the group
+// need to use the same period. Zero disables the heartbeats.
+func WithHeartbeatPeriod(period time.Duration) ConfigOption {
+	return func(d *Config) {
+		d.heartbeatPeriod = period
+	}
+}
+
+// HeartbeatPeriod returns the period at which heartbeats are signed, 0 if never.
+func (d *Config) HeartbeatPeriod() time.Duration {
+	return d.heartbeatPeriod
+}
+
+// WithSubBeacons makes the node sign the rounds of randomness streams derived from the distributed key of the
+// group, given as "name=period", with the rest of the group. All the members of the group need to run the same
+// sub-beacons.
+func WithSubBeacons(specs []string) ConfigOption {
+	return func(d *Config) {
+		d.subBeacons = specs
+	}
+}
+
+// SubBeacons returns the sub-beacons the node signs the rounds of.
+func (d *Config) SubBeacons() ([]SubBeacon, error) {
+	return ParseSubBeacons(d.subBeacons)
+}
+
+// WithDerivedChains makes the node derive chains from the beacons of its chains, given as "name=kind:param", e.g.
+// "daily=bucket:24h". See ParseDerivedChains for the kinds.
+func WithDerivedChains(specs []string) ConfigOption {
+	return func(d *Config) {
+		d.derivedChains = specs
+	}
+}
+
+// DerivedChains returns the chains the node derives from the beacons of its chains.
+func (d *Config) DerivedChains() ([]DerivedChain, error) {
+	return ParseDerivedChains(d.derivedChains)
+}
+
+// WithBeaconIsolation gives beacons their own private listener, TLS certificate and folder instead of the ones of the
+// daemon, given as "beacon-id:key=value,...". See ParseBeaconIsolation for the keys.
+func WithBeaconIsolation(specs []string) ConfigOption {
+	return func(d *Config) {
+		d.beaconIsolation = specs
+	}
+}
+
+// BeaconIsolation returns the settings of the isolated beacons, by beacon ID.
+func (d *Config) BeaconIsolation() (map[string]*BeaconIsolation, error) {
+	isolation, err := ParseBeaconIsolation(d.beaconIsolation)
+	if err != nil {
+		return nil, err
+	}
+	for id, iso := range isolation {
+		if iso.PrivateListen != "" && iso.PrivateListen == d.privateListenAddr {
+			return nil, fmt.Errorf("beacon %q can't be isolated on the private listen address of the daemon", id)
+		}
+		if iso.Folder != "" && path.Clean(iso.Folder) == path.Clean(d.configFolder) {
+			return nil, fmt.Errorf("beacon %q can't be isolated in the folder of the daemon", id)
+		}
+	}
+	return isolation, nil
+}
+
+// WithFeatureFlags turns features on or off, given as "[beacon-id:]feature=on|off". See ParseFeatureFlags.
+func WithFeatureFlags(specs []string) ConfigOption {
+	return func(d *Config) {
+		d.featureFlags = specs
+	}
+}
+
+// FeatureFlags returns the features turned on or off by the configuration, as given to WithFeatureFlags
+func (d *Config) FeatureFlags() []string {
+	d.reloadLock.RLock()
+	defer d.reloadLock.RUnlock()
+	return d.featureFlags
+}
+
+// features returns the feature flags of the configuration parsed by ParseFeatureFlags, only parsing them again once
+// they change
+func (d *Config) features() map[string]map[Feature]bool {
+	d.reloadLock.RLock()
+	parsed := d.parsedFeatures
+	d.reloadLock.RUnlock()
+	if parsed != nil {
+		return parsed
+	}
+
+	d.reloadLock.Lock()
+	defer d.reloadLock.Unlock()
+	if d.parsedFeatures == nil {
+		// the flags are validated when the daemon starts, invalid ones are ignored
+		d.parsedFeatures, _ = ParseFeatureFlags(d.featureFlags)
+		if d.parsedFeatures == nil {
+			d.parsedFeatures = make(map[string]map[Feature]bool)
+		}
+	}
+	return d.parsedFeatures
+}
+
+// setFeatureFlags replaces the feature flags of the configuration. It must be called with the reload lock held.
+func (d *Config) setFeatureFlags(specs []string) {
+	d.featureFlags = specs
+	d.parsedFeatures = nil
+}
+
+// WithStatusSampleInterval sets how often the status of the members of the groups is sampled for the availability
+// reports. Zero disables the sampling.
+func WithStatusSampleInterval(interval time.Duration) ConfigOption {
+	return func(d *Config) {
+		d.statusSampleInterval = interval
+	}
+}
+
+// StatusSampleInterval returns how often the status of the members of the groups is sampled, 0 if disabled.
+func (d *Config) StatusSampleInterval() time.Duration {
+	return d.statusSampleInterval
+}
+
+// WithUDPListenAddress answers the UDP queries for the latest beacon received on the given address. Empty disables it.
+func WithUDPListenAddress(addr string) ConfigOption {
+	return func(d *Config) {
+		d.udpListenAddr = addr
+	}
+}
+
+// UDPListenAddress returns the address the UDP queries for the latest beacon are answered on, empty if disabled.
+func (d *Config) UDPListenAddress() string {
+	return d.udpListenAddr
+}
+
+// WithPushTargets pushes each new beacon to the downstream nodes listening on the given private addresses, for the
+// topologies where they can't reach us to sync. The pushes are retried until acknowledged.
+func WithPushTargets(addrs []string) ConfigOption {
+	return func(d *Config) {
+		d.pushTargets = addrs
+	}
+}
+
+// PushTargets returns the private addresses of the downstream nodes the new beacons are pushed to.
+func (d *Config) PushTargets() []string {
+	d.reloadLock.RLock()
+	defer d.reloadLock.RUnlock()
+	return d.pushTargets
+}
+
+// WithAcceptedPushes accepts the beacons pushed by upstream nodes for the chains with the given hex-encoded hashes.
+func WithAcceptedPushes(chainHashes []string) ConfigOption {
+	return func(d *Config) {
+		d.acceptPush = chainHashes
+	}
+}
+
+// AcceptedPushes returns the hashes of the chains whose beacons are accepted when pushed to us.
+func (d *Config) AcceptedPushes() ([][]byte, error) {
+	hashes := make([][]byte, 0, len(d.acceptPush))
+	for _, h := range d.acceptPush {
+		hash, err := hex.DecodeString(h)
+		if err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("invalid chain hash %q to accept pushes for", h)
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, nil
+}
+
+// WithShareRefreshInterval proposes to refresh the shares of the groups the node belongs to at the given interval,
+// 0 to disable it. Only the node coordinating the refreshes of a group should set it.
+func WithShareRefreshInterval(interval time.Duration) ConfigOption {
+	return func(d *Config) {
+		d.shareRefreshInterval = interval
+	}
+}
+
+// ShareRefreshInterval returns how often the node proposes to refresh the shares of its groups, 0 if it doesn't.
+func (d *Config) ShareRefreshInterval() time.Duration {
+	return d.shareRefreshInterval
+}
+
+// WithAutoRefresh accepts the refreshes of the shares proposed for the groups the node belongs to, and executes the
+// ones it leads once accepted, without operator action.
+func WithAutoRefresh(auto bool) ConfigOption {
+	return func(d *Config) {
+		d.autoRefresh = auto
+	}
+}
+
+// AutoRefresh tells whether the node accepts and executes the refreshes of the shares without operator action
+func (d *Config) AutoRefresh() bool {
+	return d.autoRefresh
+}
+
+// WithMinRefreshInterval refuses to refresh the shares of a group less than the given interval after its transition,
+// 0 disabling the check.
+func WithMinRefreshInterval(interval time.Duration) ConfigOption {
+	return func(d *Config) {
+		d.minRefreshInterval = interval
+	}
+}
+
+// MinRefreshInterval returns how long after its transition the shares of a group can be refreshed, 0 if it isn't checked
+func (d *Config) MinRefreshInterval() time.Duration {
+	return d.minRefreshInterval
+}
+
+// The listeners the legacy v1 public API can be served on
+const (
+	// V1CompatPublic is the public HTTP listener
+	V1CompatPublic = "public"
+	// V1CompatPrivate is the private gRPC listener, and the listeners of the isolated beacons
+	V1CompatPrivate = "private"
+)
+
+// WithV1Compat serves the legacy v1 shapes of the public API on the given listeners, V1CompatPublic and
+// V1CompatPrivate, for the consumers which didn't migrate yet.
+func WithV1Compat(listeners []string) ConfigOption {
+	return func(d *Config) {
+		d.v1Compat = listeners
+	}
+}
+
+// V1Compat returns whether the legacy v1 shapes of the public API are served on the given listener.
+func (d *Config) V1Compat(listener string) bool {
+	return slices.Contains(d.v1Compat, listener)
+}
+
+// WithLoadShedding sheds the low-priority public requests while a chain produced by the node is later than the
+// given latency: the range queries first, then the requests for a given round once it is twice as late. Zero
+// disables it.
+func WithLoadShedding(latency time.Duration) ConfigOption {
+	return func(d *Config) {
+		d.shedLatency = latency
+	}
+}
+
+// LoadShedder returns the load shedder shared by the public listeners and the beacons, nil if the load isn't shed.
+func (d *Config) LoadShedder() *net.LoadShedder {
+	d.loadShedderOnce.Do(func() {
+		if d.shedLatency > 0 {
+			d.loadShedder = net.NewLoadShedder(d.clock, d.shedLatency)
+		}
+	})
+	return d.loadShedder
+}
+
+// WithAPIQuotas limits the requests to the public API by tier, given as "name=rate[:burst[:daily]]", for the
+// clients of the API keys, given as "client:tier:key". The requests without a key get the "anonymous" tier, per
+// address, and aren't limited if there is no such tier.
+func WithAPIQuotas(tiers, keys []string) ConfigOption {
+	return func(d *Config) {
+		d.apiTiers = tiers
+		d.apiKeys = keys
+	}
+}
+
+// Quotas returns the quotas shared by the public listeners, nil if the requests aren't limited.
+func (d *Config) Quotas() (*net.Quotas, error) {
+	d.quotasOnce.Do(func() {
+		if len(d.apiTiers) == 0 && len(d.apiKeys) == 0 {
+			return
+		}
+		tiers := make([]net.QuotaTier, 0, len(d.apiTiers))
+		for _, spec := range d.apiTiers {
+			tier, err := net.ParseQuotaTier(spec)
+			if err != nil {
+				d.quotasErr = err
+				return
+			}
+			tiers = append(tiers, tier)
+		}
+		keys := make([]net.APIKey, 0, len(d.apiKeys))
+		for _, spec := range d.apiKeys {
+			k, err := net.ParseAPIKey(spec)
+			if err != nil {
+				d.quotasErr = err
+				return
+			}
+			keys = append(keys, k)
+		}
+		d.quotas, d.quotasErr = net.NewQuotas(d.clock, tiers, keys)
+	})
+	return d.quotas, d.quotasErr
+}
+
+// WithRedis shares the latest beacons and the usage of the tiers of the public API with the other instances serving
+// it behind a load balancer through the Redis at the URL, given as redis://[:password@]host[:port][/db]
+func WithRedis(url string) ConfigOption {
+	return func(d *Config) {
+		d.redisURL = url
+	}
+}
+
+// RedisOptions returns the options to reach the Redis shared with the other instances serving the public API, nil
+// if there is none.
+func (d *Config) RedisOptions() (*redis.Options, error) {
+	if d.redisURL == "" {
+		return nil, nil
+	}
+	opts, err := redis.ParseURL(d.redisURL)
+	if err != nil {
+		return nil, err
+	}
+	return &opts, nil
+}
+
+// The steps of the shutdown of the daemon whose order can be changed. The hooks of the post-store-flush stage run
+// after the beacons step, and the ones of the post-listener-close stage after the listeners step.
+const (
+	// ShutdownBeacons stops the beacon processes and closes their chain stores
+	ShutdownBeacons = "beacons"
+	// ShutdownListeners closes the listeners of the public and private APIs
+	ShutdownListeners = "listeners"
+	// ShutdownServices closes the side services: the mirroring of the requests, the UDP listener and Redis
+	ShutdownServices = "services"
+)
+
+// DefaultShutdownOrder stops the beacon processes while the peers can still be reached, then closes the listeners
+var DefaultShutdownOrder = []string{ShutdownBeacons, ShutdownListeners, ShutdownServices}
+
+// WithMinGenesisDelay refuses the first proposals of a network whose genesis is less than the given delay away, so
+// that the nodes have the time to run the DKG and start before the genesis. 0 disables the check.
+func WithMinGenesisDelay(delay time.Duration) ConfigOption {
+	return func(d *Config) {
+		d.minGenesisDelay = delay
+	}
+}
+
+// MinGenesisDelay returns how far the genesis of a new network must at least be, 0 if it isn't checked
+func (d *Config) MinGenesisDelay() time.Duration {
+	return d.minGenesisDelay
+}
+
+// WithShutdownOrder sets the order of the steps of the shutdown of the daemon, each of ShutdownBeacons,
+// ShutdownListeners and ShutdownServices once. The control listener is always closed last.
+func WithShutdownOrder(steps []string) ConfigOption {
+	return func(d *Config) {
+		d.shutdownOrder = steps
+	}
+}
+
+// ShutdownOrder returns the order of the steps of the shutdown of the daemon
+func (d *Config) ShutdownOrder() ([]string, error) {
+	if len(d.shutdownOrder) == 0 {
+		return DefaultShutdownOrder, nil
+	}
+	seen := make(map[string]bool, len(d.shutdownOrder))
+	for _, step := range d.shutdownOrder {
+		switch step {
+		case ShutdownBeacons, ShutdownListeners, ShutdownServices:
+		default:
+			return nil, fmt.Errorf("unknown shutdown step %q, expected %s, %s or %s", step,
+				ShutdownBeacons, ShutdownListeners, ShutdownServices)
+		}
+		if seen[step] {
+			return nil, fmt.Errorf("the shutdown step %q is given twice", step)
+		}
+		seen[step] = true
+	}
+	if len(seen) != len(DefaultShutdownOrder) {
+		return nil, fmt.Errorf("the shutdown order must list each of %s, %s and %s",
+			ShutdownBeacons, ShutdownListeners, ShutdownServices)
+	}
+	return d.shutdownOrder, nil
+}
+
+// WithShutdownHooks runs the hooks at their stages of the shutdown of the daemon, after the ones registered for them
+// with plugin.RegisterHook, for the library embedders to flush their own state in order with the daemon
+func WithShutdownHooks(hooks *plugin.ShutdownHooks) ConfigOption {
+	return func(d *Config) {
+		d.shutdownHooks = hooks
+	}
+}
+
+// WithCORS configures the cross-origin requests allowed to the public HTTP API, for the browser-based consumers.
+// By default, any origin is allowed.
+func WithCORS(cors dhttp.CORS) ConfigOption {
+	return func(d *Config) {
+		d.cors = cors
+	}
+}
+
+// CORS returns the cross-origin requests allowed to the public HTTP API.
+func (d *Config) CORS() dhttp.CORS {
+	return d.cors
+}
+
+// WithSecurityHeaders sets the standard security headers on the responses of the public HTTP API.
+func WithSecurityHeaders(secure dhttp.SecurityHeaders) ConfigOption {
+	return func(d *Config) {
+		d.securityHeaders = secure
+	}
+}
+
+// SecurityHeaders returns the security headers set on the responses of the public HTTP API.
+func (d *Config) SecurityHeaders() dhttp.SecurityHeaders {
+	return d.securityHeaders
+}
+
+// WithSigner signs the partials of the beacons with the signer registered under the given name, in place of the
+// shares of the node. Empty signs them with the shares.
+func WithSigner(name string) ConfigOption {
+	return func(d *Config) {
+		d.signer = name
+	}
+}
+
+// Signer returns the name of the registered signer the partials are signed with, empty if they are signed with the
+// shares.
+func (d *Config) Signer() string {
+	return d.signer
+}
+
+// WithPublishers publishes each new beacon with the publishers registered under the given names.
+func WithPublishers(names []string) ConfigOption {
+	return func(d *Config) {
+		d.publishers = names
+	}
+}
+
+// Publishers returns the names of the registered publishers the new beacons are published with.
+func (d *Config) Publishers() []string {
+	return d.publishers
+}
+
+// WithMirrorTarget duplicates the public requests received to the shadow daemon listening on the given private
+// address, typically running a newer build, and compares its responses with ours. Empty disables it.
+func WithMirrorTarget(addr string) ConfigOption {
+	return func(d *Config) {
+		d.mirrorTarget = addr
+	}
+}
+
+// MirrorTarget returns the private address of the shadow daemon the public requests are mirrored to, empty if none.
+func (d *Config) MirrorTarget() string {
+	return d.mirrorTarget
+}
+
+// WithPublicKeyCacheSize sets how many public keys of the members of the group are kept precomputed to verify their
+// partials. Zero uses the default, which covers the whole group of every network currently running.
+func WithPublicKeyCacheSize(size int) ConfigOption {
+	return func(d *Config) {
+		d.publicKeyCacheSize = size
+	}
+}
+
+// PublicKeyCacheSize returns how many member public keys are kept precomputed, 0 for the default.
+func (d *Config) PublicKeyCacheSize() int {
+	return d.publicKeyCacheSize
+}
+
+// WithSyncMaxInFlight sets how many beacons received from a peer can wait to be stored while syncing with it.
+// Zero uses the default.
+func WithSyncMaxInFlight(beacons int) ConfigOption {
+	return func(d *Config) {
+		d.syncMaxInFlight = beacons
+	}
+}
+
+// SyncMaxInFlight returns how many beacons are buffered per stream when syncing, 0 for the default.
+func (d *Config) SyncMaxInFlight() int {
+	return d.syncMaxInFlight
+}
+
+// WithSyncMemoryBudget bounds the memory, in bytes, used by the beacons buffered while syncing. Syncs are throttled
+// until enough of it is available, which protects small followers catching up on long chains. Zero uses the default.
+func WithSyncMemoryBudget(bytes int64) ConfigOption {
+	return func(d *Config) {
+		d.syncMemoryBudget = bytes
+	}
+}
+
+// SyncMemoryBudget returns the memory the beacons buffered while syncing can use, 0 for the default.
+func (d *Config) SyncMemoryBudget() int64 {
+	return d.syncMemoryBudget
+}
+
+// WithSyncSources sets the peers the chain is preferably synced from and the ones it is never synced from, both when
+// following a chain and when catching up with the group, and labels the peers with their region, as parsed by
+// beacon.ParseSyncSources.
+func WithSyncSources(prefer, deny, regions []string) ConfigOption {
+	return func(d *Config) {
+		d.syncPrefer = prefer
+		d.syncDeny = deny
+		d.syncRegions = regions
+	}
+}
+
+// SyncSources returns the rules restricting the peers synced from, nil to sync from any of them.
+func (d *Config) SyncSources() (*beacon.SyncSources, error) {
+	d.reloadLock.RLock()
+	defer d.reloadLock.RUnlock()
+	return beacon.ParseSyncSources(d.syncPrefer, d.syncDeny, d.syncRegions)
+}
+
+// WithSlowStoreThreshold sets the duration above which an operation of the chain store is logged and counted as slow.
+// Zero disables it.
+func WithSlowStoreThreshold(threshold time.Duration) ConfigOption {
+	return func(d *Config) {
+		d.slowStoreThreshold = threshold
+	}
+}
+
+// SlowStoreThreshold returns the duration above which an operation of the chain store is slow, 0 if disabled.
+func (d *Config) SlowStoreThreshold() time.Duration {
+	return d.slowStoreThreshold
+}
+
+// The classes of background tasks whose disk I/O can be throttled
+const (
+	// IOClassBackup covers the backups of the database
+	IOClassBackup = "backup"
+	// IOClassCheck covers the checks of the chain for invalid beacons
+	IOClassCheck = "check"
+	// IOClassStats covers the computation of statistics over ranges of rounds
+	IOClassStats = "stats"
+	// IOClassCompact covers the compactions of the database of a stopped node
+	IOClassCompact = "compact"
+	// IOClassExport covers the exports of the chain to the downstream nodes it is pushed to, while they catch up
+	IOClassExport = "export"
+)
+
+// WithIOLimits throttles the disk I/O of the background tasks, given as "class=bytes-per-second", so that they can't
+// starve the storage of new rounds on slow disks. The limit of a class is shared by all its tasks.
+func WithIOLimits(specs []string) ConfigOption {
+	return func(d *Config) {
+		d.ioLimits = specs
+	}
+}
+
+// ParseIOLimits parses I/O limits given as "class=bytes-per-second", e.g. "backup=1048576"
+func ParseIOLimits(specs []string) (map[string]int64, error) {
+	limits := make(map[string]int64, len(specs))
+	for _, spec := range specs {
+		class, rate, found := strings.Cut(spec, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid I/O limit %q, expected class=bytes-per-second", spec)
+		}
+		switch class {
+		case IOClassBackup, IOClassCheck, IOClassStats, IOClassCompact, IOClassExport:
+		default:
+			return nil, fmt.Errorf("unknown I/O class %q, expected one of %s, %s, %s, %s or %s", class,
+				IOClassBackup, IOClassCheck, IOClassStats, IOClassCompact, IOClassExport)
+		}
+		r, err := strconv.ParseInt(rate, 10, 64)
+		if err != nil || r <= 0 {
+			return nil, fmt.Errorf("invalid I/O limit for class %q: expected a positive number of bytes per second", class)
+		}
+		limits[class] = r
+	}
+	return limits, nil
+}
+
+// IOLimiter returns the limiter throttling the disk I/O of the given class of tasks, nil if it isn't limited.
+func (d *Config) IOLimiter(class string) *iolimit.Limiter {
+	d.ioLimitersOnce.Do(func() {
+		d.ioLimiters = make(map[string]*iolimit.Limiter)
+		// the limits are validated when the daemon starts
+		limits, _ := ParseIOLimits(d.ioLimits)
+		for c, rate := range limits {
+			d.ioLimiters[c] = iolimit.NewLimiter(d.clock, rate)
+		}
+	})
+	return d.ioLimiters[class]
+}
+
+// RequestLimits returns the limits enforced on every request received by the control and private gRPC servers.
+func (d *Config) RequestLimits() net.RequestLimits {
+	return net.RequestLimits{
+		MaxRecvMsgSize: d.maxRequestSize,
+		CurrentTimeout: d.RequestTimeout,
+		LongRunning:    LongRunningControlMethods,
+	}
+}
+
+// RequestTimeout returns the server-side deadline applied to the unary calls of the control and private gRPC
+// servers, 0 if there is none. It can change when the configuration is reloaded.
+func (d *Config) RequestTimeout() time.Duration {
+	d.reloadLock.RLock()
+	defer d.reloadLock.RUnlock()
+	return d.requestTimeout
+}
+
+// MaxStatusNodes returns the maximum number of nodes a single status request can ask us to contact.
+func (d *Config) MaxStatusNodes() int {
+	return d.maxStatusNodes
+}
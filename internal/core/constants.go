@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/drand/drand/v2/internal/fs"
+	"github.com/drand/drand/v2/protobuf/drand"
 )
 
 // DefaultConfigFolderName is the name of the folder containing all key materials
@@ -21,6 +22,14 @@ func DefaultConfigFolder() string {
 // It is relative to the DefaultConfigFolder path.
 const DefaultDBFolder = "db"
 
+// DefaultCrashFolder is the name of the folder in which the crash reports of the modules are written.
+// It is relative to the DefaultConfigFolder path.
+const DefaultCrashFolder = "crashes"
+
+// DefaultControlTokenFile is the name of the file keeping the token authenticating the calls to the control port.
+// It is relative to the DefaultConfigFolder path.
+const DefaultControlTokenFile = "control.token"
+
 // DefaultControlPort is the default port the daemon and CLI use to communicate together.
 const DefaultControlPort = "8888"
 
@@ -38,3 +47,68 @@ const DefaultDKGKickoffGracePeriod = 5 * time.Second
 const DefaultDKGTimeout = 24 * time.Hour
 
 const callMaxTimeout = 10 * time.Second
+
+// DefaultMaxRequestSize is the default maximum size in bytes of a message accepted by the control and private
+// gRPC servers. It matches the gRPC default.
+const DefaultMaxRequestSize = 4 << 20
+
+// DefaultRequestTimeout is the default server-side deadline for unary calls on the control and private gRPC servers.
+const DefaultRequestTimeout = time.Minute
+
+// LongRunningControlMethods are the control RPCs which aren't bound by the request timeout: backing up the database
+// and draining the rounds on a shutdown can last much longer, and are bound by the client, or their own timeout, only.
+var LongRunningControlMethods = []string{
+	drand.Control_BackupDatabase_FullMethodName,
+	drand.Control_Shutdown_FullMethodName,
+}
+
+// DefaultMaxStatusNodes is the default maximum number of nodes a single status or remote status request can ask us
+// to contact.
+const DefaultMaxStatusNodes = 256
+
+// DefaultSlowStoreThreshold is the default duration above which an operation of the chain store is logged as slow.
+const DefaultSlowStoreThreshold = 500 * time.Millisecond
+
+// DefaultMirrorMaxInFlight is the maximum number of public requests waiting for the shadow daemon they are mirrored
+// to, beyond which they are dropped.
+const DefaultMirrorMaxInFlight = 64
+
+// mirrorTimeout is how long the shadow daemon has to answer a mirrored request
+const mirrorTimeout = 5 * time.Second
+
+// udpQueryTimeout is how long a UDP query for the latest beacon has to be answered
+const udpQueryTimeout = time.Second
+
+// The delays before pushing the chain to a downstream node again after a failure, doubled at each failure in between
+const (
+	pushRetryBackoff    = time.Second
+	maxPushRetryBackoff = time.Minute
+)
+
+// DefaultMaxBeaconRestarts is the number of times a failed beacon loop is restarted before giving up, unless it
+// becomes stable in between.
+const DefaultMaxBeaconRestarts = 5
+
+// DefaultBeaconRestartBackoff is the delay before restarting a failed beacon loop the first time, doubled at each
+// following restart up to DefaultMaxBeaconRestartBackoff.
+const DefaultBeaconRestartBackoff = time.Second
+
+// DefaultMaxBeaconRestartBackoff is the maximum delay before restarting a failed beacon loop.
+const DefaultMaxBeaconRestartBackoff = 5 * time.Minute
+
+// beaconStableAfter is how long a restarted beacon loop must run to be considered stable, which resets the backoff
+const beaconStableAfter = 10 * time.Minute
+
+// DefaultStatusSampleInterval is the default interval between two samples of the status of the members of the
+// groups, from which the availability reports are computed.
+const DefaultStatusSampleInterval = 5 * time.Minute
+
+// DefaultStatusHistoryRetention is how long the status samples are kept.
+const DefaultStatusHistoryRetention = 90 * 24 * time.Hour
+
+// DefaultMinRefreshInterval is the default minimum time between the transition of a group and a refresh of its shares
+const DefaultMinRefreshInterval = 24 * time.Hour
+
+// DefaultShareRefreshTimeout is the duration after which a scheduled refresh of the shares is aborted if it hasn't
+// completed, e.g. because a member of the group was down.
+const DefaultShareRefreshTimeout = time.Hour
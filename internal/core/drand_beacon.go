@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path"
 	"strings"
 	"sync"
 	"time"
@@ -24,6 +26,7 @@ import (
 	"github.com/drand/drand/v2/internal/metrics"
 	"github.com/drand/drand/v2/internal/net"
 	"github.com/drand/drand/v2/internal/util"
+	"github.com/drand/drand/v2/plugin"
 	"github.com/drand/drand/v2/protobuf/drand"
 )
 
@@ -39,8 +42,14 @@ type BeaconProcess struct {
 	group *key.Group
 	index int
 
-	store       key.Store
-	dbStore     chain.Store
+	store   key.Store
+	dbStore chain.Store
+	// randomnessIndex resolves the randomness back to the beacons, nil if the chain store can't keep it
+	randomnessIndex *chain.RandomnessIndex
+	// dbFence fences the writes to a bolt store to detect unclean shutdowns, nil for the other engines
+	dbFence *beacon.FencedStore
+	// storeRepair holds the repair of an unclean store put off while the FeatureStoreRepair feature is disabled
+	storeRepair pendingRepair
 	privGateway *net.PrivateGateway
 
 	beacon          *beacon.Handler
@@ -60,6 +69,66 @@ type BeaconProcess struct {
 	state  sync.RWMutex
 	exitCh chan bool
 
+	// the most recent notable events of this beacon, reported in the snapshots
+	events eventLog
+	// the rounds stored over the last day, reported in the chain summary
+	dayRounds roundCount
+
+	// the features turned on or off at runtime
+	features featureFlags
+
+	// restarts the beacon loop when it fails
+	supervisor supervisor
+
+	// projects whether the group can reach the threshold
+	quorum quorumWatchdog
+
+	// samples the status of the members of the group for the availability reports
+	statusHistory statusHistory
+
+	// pushes the new beacons to the downstream nodes, if any
+	pushes pushReplication
+	// publishes the new beacons with the registered publishers, if any
+	publishers beaconPublishers
+	// the copy of the chain kept from the beacons pushed to us, if any
+	replica pushReplica
+	// the chain followed, relayed to the nodes following us in turn
+	relay relayState
+	// the sync managers of the chains followed, updated with the sync sources reloaded
+	following followSyncers
+
+	// the result of the last connectivity check to each peer, refreshed in the background
+	connCache  connectivityCache
+	stopProber context.CancelFunc
+
+	// the heartbeats signed with the rest of the group, if enabled
+	heartbeats heartbeats
+	// the randomness streams derived from the distributed key, if any
+	subBeacons subBeacons
+	// the chains derived from the beacons of the chain, if any
+	derived derivedChains
+
+	// the coordinated upgrade this node is taking part in, if any
+	upgrade *upgradePlan
+
+	// the departures from the group announced to this node, with the last round the leavers sign partials for
+	leaving map[string]uint64
+	// wipeOnLeave is set when this node announced its departure and wants its share securely wiped
+	// once a reshare excluding it completes
+	wipeOnLeave bool
+	// the addresses the members of the group announced they are reachable at, by the address they are known by
+	addresses map[string]addressUpdate
+	// the addresses of the members the gateway dials at the address of an override
+	overridden []string
+	// the next reshare forecast by its coordinator, if any
+	forecast *reshareForecast
+	// reminds of the forecast reshare as it approaches
+	forecastWatch forecastWatch
+	// set while the node doesn't sign its partials
+	pause pauseState
+	// serializes the changes of the annotations of the rounds
+	annotationsLock sync.Mutex
+
 	// that cancel function is set when the drand process is following a chain
 	// but not participating. Drand calls the cancel func when the node
 	// participates to a resharing.
@@ -102,6 +171,7 @@ func NewBeaconProcess(ctx context.Context,
 		},
 		exitCh: make(chan bool, 1),
 	}
+	bp.publishFeatures()
 	return bp, nil
 }
 
@@ -116,8 +186,12 @@ func (bp *BeaconProcess) Load(ctx context.Context) error {
 	var err error
 
 	bp.group, err = bp.store.LoadGroup()
-	if err != nil || bp.group == nil {
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		// a group file we can't read isn't a DKG to run again
 		span.RecordError(err)
+		return err
+	}
+	if bp.group == nil {
 		return ErrDKGNotStarted
 	}
 
@@ -132,6 +206,22 @@ func (bp *BeaconProcess) Load(ctx context.Context) error {
 	info := public.NewChainInfo(bp.group)
 	bp.chainHash = info.Hash()
 	checkGroup(bp.log, bp.group)
+	checkDST(bp.log, bp.group.Scheme)
+	if bp.featureEnabled(FeatureAddressPersist) {
+		if err := bp.loadAddresses(bp.group); err != nil {
+			bp.log.Warnw("Unable to load the announced addresses", "err", err)
+		}
+	}
+	bp.reloadAddressOverrides(bp.group)
+	if err := bp.loadReshareForecast(bp.group); err != nil {
+		bp.log.Warnw("Unable to load the reshare forecast", "err", err)
+	}
+	if err := bp.loadUpgrade(); err != nil {
+		bp.log.Warnw("Unable to load the coordinated upgrade", "err", err)
+	}
+	if err := bp.loadLeave(); err != nil {
+		bp.log.Warnw("Unable to load the announced departures", "err", err)
+	}
 	bp.state.Unlock()
 
 	bp.share, err = bp.store.LoadShare()
@@ -182,6 +272,18 @@ func (bp *BeaconProcess) StartBeacon(ctx context.Context, catchup bool) error {
 		return err
 	}
 
+	bp.events.record(bp.opts.clock.Now(), eventBeaconStarted, fmt.Sprintf("catchup: %t", catchup))
+	bp.startConnectivityProber()
+	bp.startHeartbeats()
+	bp.startSubBeacons()
+	bp.startDerivedChains()
+	bp.startQuorumWatchdog()
+	bp.startStatusSampler()
+	bp.startForecastWatch()
+	bp.startPushReplication()
+	bp.startLoadReporting()
+	bp.startPublishers()
+	bp.superviseBeacon(b)
 	return nil
 }
 
@@ -209,6 +311,7 @@ func (bp *BeaconProcess) onDKGCompleted(ctx context.Context, dkgOutput *dkg.Shar
 	if err != nil {
 		return err
 	}
+	bp.events.record(bp.opts.clock.Now(), eventDKGCompleted, fmt.Sprintf("epoch %d", dkgOutput.New.Epoch))
 
 	weWereInLastEpoch := false
 	if dkgOutput.Old != nil {
@@ -267,6 +370,9 @@ func (bp *BeaconProcess) storeDKGOutput(ctx context.Context, group *key.Group, s
 	bp.group = group
 	bp.share = share
 	bp.chainHash = public.NewChainInfo(bp.group).Hash()
+	bp.forgetLeavers(group)
+	bp.forgetAddresses(group)
+	bp.reloadAddressOverrides(group)
 
 	err := bp.store.SaveGroup(group)
 	if err != nil {
@@ -291,6 +397,26 @@ func (bp *BeaconProcess) leaveNetwork(ctx context.Context) error {
 	} else {
 		bp.log.Infow("", "leaving_group", "done", "time", bp.opts.clock.Now())
 	}
+	bp.events.record(bp.opts.clock.Now(), eventLeftGroup, fmt.Sprintf("stopping at %d", timeToStop))
+
+	bp.state.Lock()
+	wipe := bp.wipeOnLeave
+	bp.leaving, bp.wipeOnLeave = nil, false
+	if err := bp.saveLeave(); err != nil {
+		bp.log.Warnw("Unable to remove the departures", "err", err)
+	}
+	bp.state.Unlock()
+	if wipe {
+		bp.log.Infow("Securely wiping share after leaving the group")
+		if err := bp.store.WipeShare(); err != nil {
+			return err
+		}
+		// the share mustn't outlive its file in the memory of the node either
+		bp.state.Lock()
+		bp.share = nil
+		bp.beacon.WipeShare()
+		bp.state.Unlock()
+	}
 	err = bp.store.Reset()
 	return err
 }
@@ -312,6 +438,7 @@ func (bp *BeaconProcess) joinNetwork(ctx context.Context, dkgOutput *dkg.Sharing
 	if err != nil {
 		return err
 	}
+	bp.events.record(bp.opts.clock.Now(), eventJoinedGroup, fmt.Sprintf("epoch %d", dkgOutput.New.Epoch))
 
 	// if no previous DKG then it's an initial DKG
 	// else we need to sync and transition at the right time
@@ -319,6 +446,32 @@ func (bp *BeaconProcess) joinNetwork(ctx context.Context, dkgOutput *dkg.Sharing
 }
 
 // Stop simply stops all drand operations.
+// Drain lets the beacon handler finish the round in progress and flush the callbacks of the chain store, without
+// signing the partials of the next rounds, before stopping it. The timeout defaults to two periods of the beacon.
+func (bp *BeaconProcess) Drain(ctx context.Context, timeout time.Duration) error {
+	ctx, span := tracer.NewSpan(ctx, "bp.Drain")
+	defer span.End()
+
+	bp.state.RLock()
+	handler, group := bp.beacon, bp.group
+	bp.state.RUnlock()
+	if handler == nil || group == nil {
+		return nil
+	}
+
+	if timeout <= 0 {
+		timeout = 2 * group.Period
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := handler.Drain(ctx); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
 func (bp *BeaconProcess) Stop(ctx context.Context) {
 	ctx, span := tracer.NewSpan(ctx, "bp.Stop")
 	defer span.End()
@@ -343,6 +496,9 @@ func (bp *BeaconProcess) Stop(ctx context.Context) {
 	bp.state.RUnlock()
 
 	bp.StopBeacon(ctx)
+	bp.closePushReplica()
+	bp.releaseAddresses()
+	bp.events.record(bp.opts.clock.Now(), eventBeaconStopped, "")
 }
 
 // WaitExit returns a channel that signals when drand stops its operations
@@ -350,6 +506,15 @@ func (bp *BeaconProcess) WaitExit() chan bool {
 	return bp.exitCh
 }
 
+// chainStoreContext returns the context to create the store of a chain of the given scheme with, when the scheme isn't
+// the one of our group: the stores only return the previous signatures of the chained schemes when told to.
+func chainStoreContext(ctx context.Context, sch *crypto.Scheme) context.Context {
+	if sch.Name == crypto.DefaultSchemeID {
+		return chain.SetPreviousRequiredOnContext(ctx)
+	}
+	return ctx
+}
+
 func (bp *BeaconProcess) createDBStore(ctx context.Context) (chain.Store, error) {
 	ctx, span := tracer.NewSpan(ctx, "bp.createDBStore")
 	defer span.End()
@@ -385,12 +550,38 @@ func (bp *BeaconProcess) createDBStore(ctx context.Context) (chain.Store, error)
 		dbStore, err = pgdb.NewStore(ctx, bp.log, bp.opts.pgConn, beaconName)
 
 	default:
-		// we default to "bolt" in the storageTypeFlag, so we return an error to alert users trying to use invalid DB
-		return nil, fmt.Errorf("unknown database storage engine type %q", bp.opts.dbStorageEngine)
+		driver, ok := plugin.LookupStore(string(bp.opts.dbStorageEngine))
+		if !ok {
+			// we default to "bolt" in the storageTypeFlag, so we return an error to alert users trying to use invalid DB
+			return nil, fmt.Errorf("unknown database storage engine type %q", bp.opts.dbStorageEngine)
+		}
+		dbPath := bp.opts.DBFolder(beaconName)
+		fs.CreateSecureFolder(dbPath)
+		dbStore, err = driver(ctx, bp.log, common.BeaconID(beaconName), dbPath)
 	}
 
 	bp.dbStore = dbStore
-	return dbStore, err
+	if err != nil {
+		return dbStore, err
+	}
+	bp.randomnessIndex, _ = chain.NewRandomnessIndex(dbStore)
+	store := dbStore
+	bp.dbFence = nil
+	bp.storeRepair.set(nil)
+	if bp.opts.dbStorageEngine == chain.BoltDB {
+		// postgres writes are transactional and memdb doesn't survive a restart, only bolt needs to be fenced
+		fenced, _, err := beacon.NewFencedStore(dbStore, bp.opts.DBFolder(beaconName))
+		if err != nil {
+			return nil, err
+		}
+		bp.dbFence, store = fenced, fenced
+	}
+	if bp.randomnessIndex != nil {
+		store = bp.randomnessIndex.Indexing(store, bp.log)
+	}
+	// the slow operations are only reported on the path of the rounds, not for the background tasks using dbStore
+	return beacon.NewSlowStore(store, bp.log, bp.opts.clock, beaconName, string(bp.opts.dbStorageEngine),
+		bp.opts.SlowStoreThreshold()), nil
 }
 
 func (bp *BeaconProcess) newBeacon(ctx context.Context) (*beacon.Handler, error) {
@@ -407,16 +598,57 @@ func (bp *BeaconProcess) newBeacon(ctx context.Context) (*beacon.Handler, error)
 		return nil, fmt.Errorf("public key %s not found in group", pub)
 	}
 
+	// running the chain takes over the copy of the chain pushed to us, if any
+	bp.closePushReplica()
 	store, err := bp.createDBStore(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if err := bp.repairUncleanStore(ctx, store, bp.group.Scheme, bp.group.PublicKey.Key()); err != nil {
+		return nil, err
+	}
 
+	syncSources, err := bp.opts.SyncSources()
+	if err != nil {
+		return nil, err
+	}
+	beaconID := common.GetCanonicalBeaconID(bp.getBeaconID())
+	// the files of the beacon loop are kept next to the chain store, whose folder is only created by the engines
+	// storing the chain on disk
+	beaconFolder := path.Join(bp.opts.BeaconFolderMB(beaconID), beaconID)
+	if err := os.MkdirAll(beaconFolder, 0o700); err != nil {
+		return nil, fmt.Errorf("unable to create the folder of the beacon: %w", err)
+	}
 	conf := &beacon.Config{
-		Public: node,
-		Group:  bp.group,
-		Share:  bp.share,
-		Clock:  bp.opts.clock,
+		Public:             node,
+		Group:              bp.group,
+		Share:              bp.share,
+		Clock:              bp.opts.clock,
+		PublicKeyCacheSize: bp.opts.PublicKeyCacheSize(),
+		SyncMaxInFlight:    bp.opts.SyncMaxInFlight(),
+		SyncMemoryBudget:   bp.opts.SyncMemoryBudget(),
+		SyncSources:        syncSources,
+		TombstonesFolder:   beaconFolder,
+		RetryPartials: func() bool {
+			return bp.featureEnabled(FeaturePartialRetry)
+		},
+	}
+	if bp.featureEnabled(FeaturePartialJournal) {
+		conf.PartialsFolder = beaconFolder
+	}
+	if bp.featureEnabled(FeaturePartialAudit) {
+		conf.ParticipationFolder = beaconFolder
+	}
+	if name := bp.opts.Signer(); name != "" {
+		factory, ok := plugin.LookupSigner(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown signer %q", name)
+		}
+		signer, err := factory(bp.log, common.BeaconID(bp.getBeaconID()))
+		if err != nil {
+			return nil, fmt.Errorf("unable to create signer %q: %w", name, err)
+		}
+		conf.Signer = signer
 	}
 
 	if bp.opts.dbStorageEngine == chain.MemDB {
@@ -438,6 +670,10 @@ func (bp *BeaconProcess) newBeacon(ctx context.Context) (*beacon.Handler, error)
 		return nil, err
 	}
 	bp.log.Infow("setting handler")
+	b.SetPaused(bp.pause.paused())
+	if lastRound, leaving := bp.leaving[pub.Addr]; leaving {
+		b.StopPartialsAfter(lastRound)
+	}
 	bp.beacon = b
 	// cancel any sync operations
 	if bp.syncerCancel != nil {
@@ -468,6 +704,17 @@ func (bp *BeaconProcess) StopBeacon(ctx context.Context) {
 	defer bp.state.Unlock()
 
 	bp.closeDKGChannel()
+	bp.stopConnectivityProber()
+	bp.stopHeartbeats()
+	bp.stopSubBeacons()
+	bp.stopDerivedChains()
+	bp.stopQuorumWatchdog()
+	bp.stopStatusSampler()
+	bp.stopForecastWatch()
+	bp.stopPushReplication()
+	bp.stopLoadReporting()
+	bp.stopPublishers()
+	bp.stopSupervisor()
 	if bp.beacon == nil {
 		return
 	}
@@ -486,6 +733,15 @@ func (bp *BeaconProcess) getChainHash() []byte {
 	return bp.chainHash
 }
 
+// requestLog returns the logger of the beacon process tagged with the ID of the request served with the context, if
+// any, so that its handling can be correlated with the logs of the other nodes the request went through
+func (bp *BeaconProcess) requestLog(ctx context.Context) dlog.Logger {
+	if id := net.RequestIDFromContext(ctx); id != "" {
+		return bp.log.With("request_id", id)
+	}
+	return bp.log
+}
+
 func (bp *BeaconProcess) newMetadata() *drand.Metadata {
 	metadata := drand.NewMetadata(bp.version.ToProto())
 	metadata.BeaconID = bp.getBeaconID()
@@ -0,0 +1,220 @@
+package core
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/key"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/internal/net"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// AddressesFileName is the file of the beacon folder keeping the addresses the members of the group announced, when
+// the FeatureAddressPersist feature is enabled
+const AddressesFileName = "addresses.json"
+
+// addressUpdate is the last address a member of the group announced it is reachable at, instead of the address it
+// is known by in the group file
+type addressUpdate struct {
+	NewAddress string `json:"new_address"`
+	Sequence   uint64 `json:"sequence"`
+	Signature  []byte `json:"signature"`
+}
+
+// UpdateAddress announces to all the members of the group that this node is now reachable at a new address, so
+// that they can reach it there without a new group file. The node stays known by the address of the group file,
+// which the announcement is signed for with its identity key.
+func (bp *BeaconProcess) UpdateAddress(ctx context.Context, in *drand.UpdateAddressRequest) (*drand.UpdateAddressResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "bp.UpdateAddress")
+	defer span.End()
+
+	newAddress, err := key.NormalizeAddress(in.GetNewAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	bp.state.RLock()
+	group := bp.group
+	self := bp.priv.Public.Addr
+	bp.state.RUnlock()
+	if group == nil {
+		return nil, errors.New("this node isn't part of a group, there is nobody to announce its address to")
+	}
+	if group.Find(bp.priv.Public) == nil {
+		return nil, fmt.Errorf("%s is not part of the group", self)
+	}
+
+	// the announcements of a node must be applied in order, even across its restarts
+	sequence := uint64(bp.opts.clock.Now().UnixNano())
+	sig, err := bp.priv.Scheme().AuthScheme.Sign(bp.priv.Key, addressMessage(bp.getBeaconID(), self, newAddress, sequence))
+	if err != nil {
+		return nil, err
+	}
+
+	announcement := &drand.AddressAnnouncement{
+		Address:    self,
+		NewAddress: newAddress,
+		Sequence:   sequence,
+		Signature:  sig,
+		Metadata:   bp.newMetadata(),
+	}
+	resp := &drand.UpdateAddressResponse{Sequence: sequence, Failed: make(map[string]string), Metadata: bp.newMetadata()}
+	for _, node := range group.Nodes {
+		if node.Address() == self {
+			continue
+		}
+		if err := bp.privGateway.AnnounceAddress(ctx, net.CreatePeer(node.Address()), announcement); err != nil {
+			bp.log.Warnw("Unable to announce the new address", "to", node.Address(), "err", err)
+			resp.Failed[node.Address()] = err.Error()
+			continue
+		}
+		resp.Reached = append(resp.Reached, node.Address())
+	}
+
+	bp.events.record(bp.opts.clock.Now(), eventAddressUpdated, fmt.Sprintf("%s is now reachable at %s", self, newAddress))
+	bp.log.Infow("Announced the new address to the group", "new_address", newAddress,
+		"reached", len(resp.Reached), "failed", len(resp.Failed))
+	return resp, nil
+}
+
+// AnnounceAddress applies the new address announced by a member of the group: the member is reached at that
+// address from now on, while it stays known by the address of the group file.
+func (bp *BeaconProcess) AnnounceAddress(ctx context.Context, in *drand.AddressAnnouncement) (*drand.Empty, error) {
+	_, span := tracer.NewSpan(ctx, "bp.AnnounceAddress")
+	defer span.End()
+
+	bp.state.Lock()
+	defer bp.state.Unlock()
+
+	if bp.group == nil {
+		return nil, errors.New("no group yet")
+	}
+	update := addressUpdate{NewAddress: in.GetNewAddress(), Sequence: in.GetSequence(), Signature: in.GetSignature()}
+	if err := bp.verifyAddressUpdate(bp.group, in.GetAddress(), &update); err != nil {
+		return nil, err
+	}
+	if last, ok := bp.addresses[in.GetAddress()]; ok && last.Sequence >= update.Sequence {
+		return nil, fmt.Errorf("stale address announcement from %s: sequence %d, already at %d",
+			in.GetAddress(), update.Sequence, last.Sequence)
+	}
+
+	bp.applyAddressUpdate(in.GetAddress(), update)
+	if bp.featureEnabled(FeatureAddressPersist) {
+		if err := bp.saveAddresses(); err != nil {
+			// the new address is applied anyway, it only won't survive a restart
+			bp.log.Warnw("Unable to save the announced addresses", "err", err)
+		}
+	}
+	bp.events.record(bp.opts.clock.Now(), eventAddressUpdated,
+		fmt.Sprintf("%s is now reachable at %s", in.GetAddress(), update.NewAddress))
+
+	bp.log.Infow("Member announced its new address", "member", in.GetAddress(), "new_address", update.NewAddress)
+	return &drand.Empty{Metadata: bp.newMetadata()}, nil
+}
+
+// verifyAddressUpdate checks that the update was signed by the member of the group known by the address
+func (bp *BeaconProcess) verifyAddressUpdate(group *key.Group, addr string, update *addressUpdate) error {
+	var member *key.Node
+	for _, n := range group.Nodes {
+		if n.Address() == addr {
+			member = n
+			break
+		}
+	}
+	if member == nil {
+		return fmt.Errorf("%s is not part of the group", addr)
+	}
+	if update.NewAddress == "" {
+		return fmt.Errorf("no new address announced by %s", addr)
+	}
+	msg := addressMessage(bp.getBeaconID(), addr, update.NewAddress, update.Sequence)
+	if err := group.Scheme.AuthScheme.Verify(member.Key, msg, update.Signature); err != nil {
+		return fmt.Errorf("invalid address announcement signature from %s: %w", addr, err)
+	}
+	return nil
+}
+
+// applyAddressUpdate must be called with the state lock held
+func (bp *BeaconProcess) applyAddressUpdate(addr string, update addressUpdate) {
+	if bp.addresses == nil {
+		bp.addresses = make(map[string]addressUpdate)
+	}
+	bp.addresses[addr] = update
+	bp.privGateway.Redirect(bp.getBeaconID(), addr, update.NewAddress)
+}
+
+// forgetAddresses drops the addresses announced by the nodes which aren't part of the given group anymore, and
+// reaches them at the address they are known by again. It must be called with the state lock held.
+func (bp *BeaconProcess) forgetAddresses(group *key.Group) {
+	for addr := range bp.addresses {
+		if !groupHasAddress(group, addr) {
+			delete(bp.addresses, addr)
+			bp.privGateway.Redirect(bp.getBeaconID(), addr, addr)
+		}
+	}
+}
+
+// releaseAddresses makes the gateway, shared with the other beacons of the daemon, stop using the addresses announced
+// to the beacon and overridden for it, once it is stopped
+func (bp *BeaconProcess) releaseAddresses() {
+	bp.state.Lock()
+	defer bp.state.Unlock()
+	if bp.privGateway == nil {
+		return
+	}
+	for addr := range bp.addresses {
+		bp.privGateway.Redirect(bp.getBeaconID(), addr, addr)
+	}
+	bp.releaseAddressOverrides()
+}
+
+// addressesFile returns the file keeping the addresses announced to the beacon
+func (bp *BeaconProcess) addressesFile() string {
+	beaconID := common.GetCanonicalBeaconID(bp.getBeaconID())
+	return path.Join(bp.opts.BeaconFolderMB(beaconID), beaconID, AddressesFileName)
+}
+
+// saveAddresses must be called with the state lock held
+func (bp *BeaconProcess) saveAddresses() error {
+	buff, err := json.MarshalIndent(bp.addresses, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(bp.addressesFile(), buff, 0o600)
+}
+
+// loadAddresses applies again the addresses announced by the members of the group before the daemon restarted,
+// checking their signatures against the group. It must be called with the state lock held.
+func (bp *BeaconProcess) loadAddresses(group *key.Group) error {
+	buff, err := os.ReadFile(bp.addressesFile())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var saved map[string]addressUpdate
+	if err := json.Unmarshal(buff, &saved); err != nil {
+		return fmt.Errorf("invalid addresses file: %w", err)
+	}
+	for addr, update := range saved {
+		update := update
+		if err := bp.verifyAddressUpdate(group, addr, &update); err != nil {
+			bp.log.Warnw("Ignoring a saved address", "member", addr, "err", err)
+			continue
+		}
+		bp.applyAddressUpdate(addr, update)
+	}
+	return nil
+}
+
+func addressMessage(beaconID, addr, newAddr string, sequence uint64) []byte {
+	msg := []byte("drand-address:" + beaconID + ":" + addr + ":" + newAddr + ":")
+	return binary.BigEndian.AppendUint64(msg, sequence)
+}
@@ -0,0 +1,137 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	public "github.com/drand/drand/v2/common/chain"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// annotationsNamespace is the namespace of the metadata of the chain store keeping the annotations of the rounds
+const annotationsNamespace = public.InternalNamespacePrefix + "annotations"
+
+// maxAnnotationLen bounds the size of a note, the annotations are meant for bookkeeping, not for storing documents
+const maxAnnotationLen = 1024
+
+// annotation is a note attached to a round, kept by round in the metadata of the chain store
+type annotation struct {
+	Note string `json:"note"`
+	Time int64  `json:"time"`
+}
+
+// RoundAnnotations lists the notes the operators attached to the rounds, after attaching a note to a round or removing
+// the ones of a round if asked to. The annotations are kept in the metadata of the chain store, so the beacons
+// themselves are never modified.
+func (bp *BeaconProcess) RoundAnnotations(ctx context.Context, in *drand.RoundAnnotationsRequest) (*drand.RoundAnnotationsResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "bp.RoundAnnotations")
+	defer span.End()
+
+	round := in.GetRound()
+	modify := in.GetNote() != "" || in.GetRemove()
+	if in.GetNote() != "" && in.GetRemove() {
+		return nil, status.Error(codes.InvalidArgument, "can't both attach a note and remove the annotations")
+	}
+	if modify && round == 0 {
+		return nil, status.Error(codes.InvalidArgument, "a round is required to annotate it")
+	}
+	if len(in.GetNote()) > maxAnnotationLen {
+		return nil, status.Errorf(codes.InvalidArgument, "the note is longer than %d bytes", maxAnnotationLen)
+	}
+
+	md, err := bp.Metadata(annotationsNamespace)
+	if errors.Is(err, public.ErrMetadataUnsupported) {
+		return nil, status.Error(codes.Unimplemented, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	bp.annotationsLock.Lock()
+	defer bp.annotationsLock.Unlock()
+
+	annotations := make(map[uint64][]annotation)
+	if round != 0 {
+		notes, err := loadAnnotations(ctx, md, round)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case in.GetRemove():
+			if err := md.Delete(ctx, annotationKey(round)); err != nil {
+				return nil, err
+			}
+			notes = nil
+			bp.log.Infow("Removed the annotations of a round", "round", round)
+		case in.GetNote() != "":
+			notes = append(notes, annotation{Note: in.GetNote(), Time: bp.opts.clock.Now().Unix()})
+			if err := saveAnnotations(ctx, md, round, notes); err != nil {
+				return nil, err
+			}
+			bp.log.Infow("Annotated a round", "round", round, "note", in.GetNote())
+		}
+		annotations[round] = notes
+	} else {
+		values, err := md.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range values {
+			r, err := strconv.ParseUint(k, 10, 64)
+			if err != nil {
+				continue
+			}
+			var notes []annotation
+			if err := json.Unmarshal(v, &notes); err != nil {
+				bp.log.Warnw("Ignoring corrupted annotations", "round", r, "err", err)
+				continue
+			}
+			annotations[r] = notes
+		}
+	}
+
+	rounds := make([]uint64, 0, len(annotations))
+	for r := range annotations {
+		rounds = append(rounds, r)
+	}
+	sort.Slice(rounds, func(i, j int) bool { return rounds[i] < rounds[j] })
+	resp := &drand.RoundAnnotationsResponse{Metadata: bp.newMetadata()}
+	for _, r := range rounds {
+		for _, a := range annotations[r] {
+			resp.Annotations = append(resp.Annotations, &drand.RoundAnnotation{Round: r, Note: a.Note, Time: a.Time})
+		}
+	}
+	return resp, nil
+}
+
+// annotationKey returns the key of the annotations of a round, padded so that the keys sort by round
+func annotationKey(round uint64) string {
+	return fmt.Sprintf("%020d", round)
+}
+
+func loadAnnotations(ctx context.Context, md *public.Metadata, round uint64) ([]annotation, error) {
+	value, err := md.Get(ctx, annotationKey(round))
+	if err != nil || value == nil {
+		return nil, err
+	}
+	var notes []annotation
+	if err := json.Unmarshal(value, &notes); err != nil {
+		return nil, fmt.Errorf("corrupted annotations of round %d: %w", round, err)
+	}
+	return notes, nil
+}
+
+func saveAnnotations(ctx context.Context, md *public.Metadata, round uint64, notes []annotation) error {
+	value, err := json.Marshal(notes)
+	if err != nil {
+		return err
+	}
+	return md.Put(ctx, annotationKey(round), value)
+}
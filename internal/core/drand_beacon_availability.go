@@ -0,0 +1,376 @@
+package core
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/internal/chain/beacon"
+	"github.com/drand/drand/v2/internal/net"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// StatusHistoryFileName is the name of the file, in the folder of the beacon, holding the status samples of the
+// members of the group the availability reports are computed from
+const StatusHistoryFileName = "status_history.jsonl"
+
+// statusSample is the status of a member of the group at a point in time, as one line of the status history
+type statusSample struct {
+	Time    int64  `json:"time"`
+	Address string `json:"address"`
+	// Replied is whether the member answered the status request, Running whether its beacon was running then
+	Replied bool   `json:"replied"`
+	Running bool   `json:"running"`
+	Lag     uint64 `json:"lag"`
+	Error   string `json:"error,omitempty"`
+}
+
+// statusHistory samples the status of the members of the group in the background and keeps the samples on disk for
+// DefaultStatusHistoryRetention. Its zero value is ready to use.
+type statusHistory struct {
+	sync.Mutex
+	stop       context.CancelFunc
+	lastPruned time.Time
+}
+
+// statusHistoryFile returns the file holding the status history of the beacon
+func (bp *BeaconProcess) statusHistoryFile() string {
+	beaconID := common.GetCanonicalBeaconID(bp.getBeaconID())
+	return path.Join(bp.opts.BeaconFolderMB(beaconID), beaconID, StatusHistoryFileName)
+}
+
+// startStatusSampler samples the status of the members of the group at each sample interval, until the beacon is
+// stopped.
+func (bp *BeaconProcess) startStatusSampler() {
+	interval := bp.opts.StatusSampleInterval()
+	if interval <= 0 {
+		return
+	}
+
+	bp.statusHistory.Lock()
+	defer bp.statusHistory.Unlock()
+	if bp.statusHistory.stop != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	bp.statusHistory.stop = cancel
+
+	go func() {
+		ticker := bp.opts.clock.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.Chan():
+			}
+			if err := bp.recordStatusSamples(ctx); err != nil {
+				bp.log.Warnw("Unable to record the status of the group", "err", err)
+			}
+		}
+	}()
+}
+
+// stopStatusSampler stops sampling the status of the members of the group
+func (bp *BeaconProcess) stopStatusSampler() {
+	bp.statusHistory.Lock()
+	defer bp.statusHistory.Unlock()
+
+	if bp.statusHistory.stop != nil {
+		bp.statusHistory.stop()
+		bp.statusHistory.stop = nil
+	}
+}
+
+// recordStatusSamples asks the members of the group for their status, like RemoteStatus does, and appends the
+// samples to the status history, dropping the ones older than DefaultStatusHistoryRetention once a day
+func (bp *BeaconProcess) recordStatusSamples(ctx context.Context) error {
+	samples := bp.sampleStatus(ctx)
+	if len(samples) == 0 {
+		return nil
+	}
+
+	bp.statusHistory.Lock()
+	defer bp.statusHistory.Unlock()
+
+	file := bp.statusHistoryFile()
+	now := bp.opts.clock.Now()
+	if now.Sub(bp.statusHistory.lastPruned) > 24*time.Hour {
+		if err := pruneStatusHistory(file, now.Add(-DefaultStatusHistoryRetention).Unix()); err != nil {
+			return err
+		}
+		bp.statusHistory.lastPruned = now
+	}
+	return appendStatusSamples(file, samples)
+}
+
+// sampleStatus returns the status of each member of the group, ourself included
+func (bp *BeaconProcess) sampleStatus(ctx context.Context) []statusSample {
+	ctx, span := tracer.NewSpan(ctx, "bp.sampleStatus")
+	defer span.End()
+
+	bp.state.RLock()
+	group := bp.group
+	self := bp.priv.Public.Addr
+	bp.state.RUnlock()
+	if group == nil {
+		return nil
+	}
+
+	now := bp.opts.clock.Now().Unix()
+	samples := make([]statusSample, len(group.Nodes))
+	var wg sync.WaitGroup
+	for i, node := range group.Nodes {
+		wg.Add(1)
+		go func(i int, addr string) {
+			defer wg.Done()
+			tc, cancel := context.WithTimeout(ctx, callMaxTimeout)
+			defer cancel()
+
+			var resp *drand.StatusResponse
+			var err error
+			req := &drand.StatusRequest{Metadata: bp.newMetadata()}
+			if addr == self {
+				resp, err = bp.Status(tc, req)
+			} else {
+				resp, err = bp.privGateway.Status(tc, net.CreatePeer(addr), req)
+			}
+			samples[i] = newStatusSample(now, addr, resp, err)
+		}(i, node.Address())
+	}
+	wg.Wait()
+	return samples
+}
+
+func newStatusSample(at int64, addr string, resp *drand.StatusResponse, err error) statusSample {
+	sample := statusSample{Time: at, Address: addr}
+	if err != nil {
+		sample.Error = err.Error()
+		return sample
+	}
+	sample.Replied = true
+	sample.Running = resp.GetBeacon().GetIsRunning()
+	if expected, last := resp.GetChainStore().GetExpectedLast(), resp.GetChainStore().GetLastStored(); expected > last {
+		sample.Lag = expected - last
+	}
+	return sample
+}
+
+func appendStatusSamples(file string, samples []statusSample) error {
+	fd, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("unable to open the status history: %w", err)
+	}
+	defer fd.Close()
+
+	w := bufio.NewWriter(fd)
+	enc := json.NewEncoder(w)
+	for i := range samples {
+		if err := enc.Encode(&samples[i]); err != nil {
+			return fmt.Errorf("unable to write the status history: %w", err)
+		}
+	}
+	return w.Flush()
+}
+
+// readStatusHistory calls fn for each sample of the status history, skipping the lines which can't be read
+func readStatusHistory(file string, fn func(*statusSample)) error {
+	fd, err := os.Open(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("unable to open the status history: %w", err)
+	}
+	defer fd.Close()
+
+	scanner := bufio.NewScanner(fd)
+	for scanner.Scan() {
+		var sample statusSample
+		if err := json.Unmarshal(scanner.Bytes(), &sample); err != nil {
+			// a line torn by a crash
+			continue
+		}
+		fn(&sample)
+	}
+	return scanner.Err()
+}
+
+// pruneStatusHistory drops the samples taken before the given UNIX time from the status history
+func pruneStatusHistory(file string, before int64) error {
+	var kept []statusSample
+	pruned := false
+	err := readStatusHistory(file, func(s *statusSample) {
+		if s.Time < before {
+			pruned = true
+			return
+		}
+		kept = append(kept, *s)
+	})
+	if err != nil || !pruned {
+		return err
+	}
+
+	tmp := file + ".tmp"
+	_ = os.Remove(tmp)
+	if err := appendStatusSamples(tmp, kept); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
+
+// computeAvailability sums up the samples of the status history taken in the given window, per member
+func computeAvailability(file string, from, to int64) ([]*drand.MemberAvailability, error) {
+	type totals struct {
+		samples, up, replied uint32
+		lag, maxLag          uint64
+	}
+	members := make(map[string]*totals)
+	err := readStatusHistory(file, func(s *statusSample) {
+		if s.Time < from || s.Time > to {
+			return
+		}
+		t, ok := members[s.Address]
+		if !ok {
+			t = new(totals)
+			members[s.Address] = t
+		}
+		t.samples++
+		if !s.Replied {
+			return
+		}
+		t.replied++
+		t.lag += s.Lag
+		t.maxLag = max(t.maxLag, s.Lag)
+		if s.Running {
+			t.up++
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := make([]*drand.MemberAvailability, 0, len(members))
+	for addr, t := range members {
+		m := &drand.MemberAvailability{
+			Address:       addr,
+			Samples:       t.samples,
+			UpSamples:     t.up,
+			UptimePercent: 100 * float64(t.up) / float64(t.samples),
+			MaxLag:        t.maxLag,
+		}
+		if t.replied > 0 {
+			m.AverageLag = float64(t.lag) / float64(t.replied)
+		}
+		report = append(report, m)
+	}
+	sort.Slice(report, func(i, j int) bool { return report[i].Address < report[j].Address })
+	return report, nil
+}
+
+// AvailabilityReport computes the availability of each member of the group over the requested window, the last day
+// by default, from the status history
+func (bp *BeaconProcess) AvailabilityReport(ctx context.Context, in *drand.AvailabilityReportRequest) (*drand.AvailabilityReportResponse, error) {
+	_, span := tracer.NewSpan(ctx, "bp.AvailabilityReport")
+	defer span.End()
+
+	to := in.GetTo()
+	if to == 0 {
+		to = bp.opts.clock.Now().Unix()
+	}
+	from := in.GetFrom()
+	if from == 0 {
+		from = to - int64((24 * time.Hour).Seconds())
+	}
+	if from >= to {
+		return nil, status.Errorf(codes.InvalidArgument, "the window must start before it ends")
+	}
+
+	bp.statusHistory.Lock()
+	members, err := computeAvailability(bp.statusHistoryFile(), from, to)
+	bp.statusHistory.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return &drand.AvailabilityReportResponse{
+		From:     from,
+		To:       to,
+		Members:  members,
+		Metadata: bp.newMetadata(),
+	}, nil
+}
+
+// MaxPartialAuditRounds is the maximum number of rounds returned by PartialAudit at once, keeping the response well
+// under the maximum size of a gRPC message. The rest of the range is requested from the next round returned.
+var MaxPartialAuditRounds = 5000
+
+// PartialAudit returns the members whose partials were aggregated into each round of the requested range, for the
+// rounds the node aggregated while the partial-audit feature was enabled, at most MaxPartialAuditRounds at once
+func (bp *BeaconProcess) PartialAudit(ctx context.Context, in *drand.PartialAuditRequest) (*drand.PartialAuditResponse, error) {
+	_, span := tracer.NewSpan(ctx, "bp.PartialAudit")
+	defer span.End()
+
+	from, to := in.GetFromRound(), in.GetToRound()
+	if to != 0 && from > to {
+		return nil, status.Errorf(codes.InvalidArgument, "the range must start before it ends")
+	}
+
+	beaconID := common.GetCanonicalBeaconID(bp.getBeaconID())
+	var rounds []*drand.RoundParticipation
+	var next uint64
+	err := beacon.ReadParticipation(path.Join(bp.opts.BeaconFolderMB(beaconID), beaconID), from, to,
+		func(r *beacon.RoundParticipation) bool {
+			if len(rounds) == MaxPartialAuditRounds {
+				next = r.Round
+				return false
+			}
+			rounds = append(rounds, &drand.RoundParticipation{Round: r.Round, Indexes: r.Indexes, Addresses: r.Addresses})
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &drand.PartialAuditResponse{Rounds: rounds, NextRound: next, Metadata: bp.newMetadata()}, nil
+}
+
+// Tombstones returns the beacons of the requested range replaced in the store when correcting the chain, as kept with
+// the beacons replacing them
+func (bp *BeaconProcess) Tombstones(ctx context.Context, in *drand.TombstonesRequest) (*drand.TombstonesResponse, error) {
+	_, span := tracer.NewSpan(ctx, "bp.Tombstones")
+	defer span.End()
+
+	from, to := in.GetFromRound(), in.GetToRound()
+	if to != 0 && from > to {
+		return nil, status.Errorf(codes.InvalidArgument, "the range must start before it ends")
+	}
+
+	beaconID := common.GetCanonicalBeaconID(bp.getBeaconID())
+	var tombstones []*drand.Tombstone
+	err := beacon.ReadTombstones(path.Join(bp.opts.BeaconFolderMB(beaconID), beaconID), from, to,
+		func(t *beacon.Tombstone) {
+			tombstones = append(tombstones, &drand.Tombstone{
+				Round:             t.Round,
+				Signature:         t.Signature,
+				PreviousSignature: t.PreviousSig,
+				ReplacedBy:        t.ReplacedBy,
+				RoundTime:         t.RoundTime,
+				ReplacedAt:        t.ReplacedAt,
+				Reason:            t.Reason,
+			})
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &drand.TombstonesResponse{Tombstones: tombstones, Metadata: bp.newMetadata()}, nil
+}
@@ -0,0 +1,145 @@
+package core
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/gzip"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// The optional endpoints reported in the capabilities of a node, when they are served
+const (
+	endpointSubBeacons       = "sub-beacons"
+	endpointDerivedChains    = "derived-chains"
+	endpointHeartbeat        = "heartbeat"
+	endpointV1Compat         = "v1-compat"
+	endpointUDP              = "udp"
+	endpointPush             = "push"
+	endpointRandomnessLookup = "randomness-lookup"
+	// endpointGossip is the stream of the new beacons the relays, such as the gossip relay, subscribe to
+	endpointGossip = "gossip"
+)
+
+// timelockSchemes are the schemes whose beacons can be used for timelock encryption: the unchained ones on BLS12-381
+var timelockSchemes = []string{crypto.UnchainedSchemeID, crypto.ShortSigSchemeID, crypto.SigsOnG1ID}
+
+// minCompatibleVersion returns the oldest version of the protocol the given version interoperates with, as checked
+// by common.Version.IsCompatible: the immediate minor predecessor, or v1.5.8 for the v2.0 releases
+func minCompatibleVersion(v common.Version) common.Version {
+	switch {
+	case v.Major == 2 && v.Minor == 0:
+		//nolint:mnd
+		return common.Version{Major: 1, Minor: 5, Patch: 8}
+	case v.Minor == 0:
+		return common.Version{Major: v.Major}
+	}
+	return common.Version{Major: v.Major, Minor: v.Minor - 1}
+}
+
+// onControlPort tells whether the RPC served was called on the control port rather than on the public service, as
+// some RPCs are served on both, disclosing more to the operator
+func onControlPort(ctx context.Context) bool {
+	method, _ := grpc.Method(ctx)
+	return strings.HasPrefix(method, "/"+drand.Control_ServiceDesc.ServiceName+"/")
+}
+
+// GetCapabilities lists what the node supports for the beacon, served alone on its isolated listener if it has one.
+// The storage engine, the features and the beacons of the node are only listed on the control port.
+func (bp *BeaconProcess) GetCapabilities(ctx context.Context, _ *drand.CapabilitiesRequest) (*drand.Capabilities, error) {
+	_, span := tracer.NewSpan(ctx, "bp.GetCapabilities")
+	defer span.End()
+
+	caps := &drand.Capabilities{
+		NodeVersion:          bp.version.ToProto(),
+		MinCompatibleVersion: minCompatibleVersion(bp.version).ToProto(),
+		Schemes:              crypto.ListSchemes(),
+		Endpoints:            []string{endpointGossip},
+		Metadata:             bp.newMetadata(),
+	}
+	private := onControlPort(ctx)
+	if private {
+		caps.Storage = string(bp.opts.dbStorageEngine)
+		caps.BeaconIds = []string{bp.getBeaconID()}
+	}
+
+	bp.state.RLock()
+	if bp.group != nil && bp.group.Scheme != nil {
+		caps.Scheme = bp.group.Scheme.Name
+		caps.Timelock = slices.Contains(timelockSchemes, caps.Scheme)
+	}
+	randomnessLookup := bp.randomnessIndex != nil
+	bp.state.RUnlock()
+
+	if encoding.GetCompressor(gzip.Name) != nil {
+		caps.Compressors = append(caps.Compressors, gzip.Name)
+	}
+
+	if len(bp.opts.subBeacons) > 0 {
+		caps.Endpoints = append(caps.Endpoints, endpointSubBeacons)
+	}
+	if len(bp.opts.derivedChains) > 0 {
+		caps.Endpoints = append(caps.Endpoints, endpointDerivedChains)
+	}
+	if bp.opts.HeartbeatPeriod() > 0 {
+		caps.Endpoints = append(caps.Endpoints, endpointHeartbeat)
+	}
+	if bp.opts.V1Compat(V1CompatPublic) || bp.opts.V1Compat(V1CompatPrivate) {
+		caps.Endpoints = append(caps.Endpoints, endpointV1Compat)
+	}
+	if bp.opts.UDPListenAddress() != "" {
+		caps.Endpoints = append(caps.Endpoints, endpointUDP)
+	}
+	if len(bp.opts.acceptPush) > 0 {
+		caps.Endpoints = append(caps.Endpoints, endpointPush)
+	}
+	if randomnessLookup {
+		caps.Endpoints = append(caps.Endpoints, endpointRandomnessLookup)
+	}
+
+	if !private {
+		return caps, nil
+	}
+	for _, name := range featureNames() {
+		if bp.featureEnabled(Feature(name)) {
+			caps.Features = append(caps.Features, name)
+		}
+	}
+	return caps, nil
+}
+
+// GetCapabilities lists what the node supports for the beacon in the metadata of the request, along with the beacons
+// it runs on the control port. It is served on both the control and the public services.
+func (dd *DrandDaemon) GetCapabilities(ctx context.Context, in *drand.CapabilitiesRequest) (*drand.Capabilities, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.GetCapabilities")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+	caps, err := bp.GetCapabilities(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	if !onControlPort(ctx) {
+		return caps, nil
+	}
+	caps.BeaconIds = nil
+	dd.state.RLock()
+	for id := range dd.beaconProcesses {
+		caps.BeaconIds = append(caps.BeaconIds, id)
+	}
+	dd.state.RUnlock()
+	sort.Strings(caps.BeaconIds)
+	return caps, nil
+}
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/drand/drand/v2/common"
+	public "github.com/drand/drand/v2/common/chain"
+	"github.com/drand/drand/v2/common/log"
+	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/chain"
+	"github.com/drand/drand/v2/internal/chain/beacon"
+	"github.com/drand/drand/v2/internal/net"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// checkpointFromProto returns the beacon of the checkpoint, verified against the public key of the chain
+func checkpointFromProto(cp *drand.Checkpoint, info *public.Info, sch *crypto.Scheme) (*common.Beacon, error) {
+	b := &common.Beacon{
+		Round:       cp.GetRound(),
+		Signature:   cp.GetSignature(),
+		PreviousSig: cp.GetPreviousSignature(),
+	}
+	if err := sch.VerifyBeacon(b, info.PublicKey); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint %d: %w", b.Round, err)
+	}
+	if sch.Name != crypto.DefaultSchemeID {
+		// unchained beacons are stored without their previous signature
+		b.PreviousSig = nil
+	}
+	return b, nil
+}
+
+// startFromCheckpoint stores the checkpoint so that following the chain only syncs and verifies the rounds after it.
+// It returns the first round missing before the checkpoint, which is the checkpoint round itself when nothing is
+// missing. Nothing is stored if we already went past the checkpoint.
+func startFromCheckpoint(ctx context.Context, l log.Logger, store chain.Store, checkpoint *common.Beacon) (uint64, error) {
+	last, err := store.Last(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if last.Round >= checkpoint.Round {
+		l.Infow("already synced past the checkpoint, ignoring it", "last", last.Round, "checkpoint", checkpoint.Round)
+		return checkpoint.Round, nil
+	}
+	if err := store.Put(ctx, checkpoint); err != nil {
+		return 0, fmt.Errorf("unable to store the checkpoint: %w", err)
+	}
+	l.Infow("following from the checkpoint, the rounds before it aren't verified",
+		"checkpoint", checkpoint.Round, "missing_from", last.Round+1)
+	return last.Round + 1, nil
+}
+
+// backfill syncs and verifies the rounds between from and the checkpoint in the background, upgrading a chain
+// followed from a checkpoint to a fully verified one. The returned channel is closed once it is over.
+func backfill(ctx context.Context, l log.Logger, syncer *beacon.SyncManager, from, checkpoint uint64, peers []net.Peer) chan struct{} {
+	done := make(chan struct{})
+	if from >= checkpoint {
+		close(done)
+		return done
+	}
+
+	go func() {
+		defer close(done)
+		l.Infow("starting to verify the rounds before the checkpoint", "from", from, "to", checkpoint-1)
+		if err := syncer.ReSync(ctx, from, checkpoint-1, peers); err != nil {
+			l.Errorw("unable to verify the rounds before the checkpoint", "err", err)
+			return
+		}
+		l.Infow("the rounds before the checkpoint are verified, the chain is fully verified", "checkpoint", checkpoint)
+	}()
+	return done
+}
@@ -0,0 +1,259 @@
+package core
+
+import (
+	"context"
+	"errors"
+	gonet "net"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/internal/net"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// connectivityProbePeriod is how often the connectivity to the members of the group is checked in the background
+const connectivityProbePeriod = time.Minute
+
+// connectivity error classes reported in the Status responses
+const (
+	connErrorTimeout = "timeout"
+	connErrorRefused = "refused"
+	connErrorTLS     = "tls"
+	connErrorDNS     = "dns"
+	connErrorUnknown = "unknown"
+)
+
+type connectivityResult struct {
+	ok          bool
+	err         error
+	rtt         time.Duration
+	checkedAt   time.Time
+	lastSuccess time.Time
+}
+
+// connectivityCache holds the result of the last connectivity check to each peer, so that Status doesn't have to
+// contact all of them in the request path. Its zero value is ready to use.
+type connectivityCache struct {
+	sync.Mutex
+	results map[string]connectivityResult
+}
+
+func (c *connectivityCache) put(addr string, err error, rtt time.Duration, at time.Time) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.results == nil {
+		c.results = make(map[string]connectivityResult)
+	}
+	r := connectivityResult{ok: err == nil, err: err, rtt: rtt, checkedAt: at, lastSuccess: c.results[addr].lastSuccess}
+	if r.ok {
+		r.lastSuccess = at
+	}
+	c.results[addr] = r
+}
+
+// get returns the cached results for the given peers, along with the peers which were never checked
+func (c *connectivityCache) get(addrs []string) (results map[string]connectivityResult, missing []string) {
+	c.Lock()
+	defer c.Unlock()
+
+	results = make(map[string]connectivityResult, len(addrs))
+	for _, addr := range addrs {
+		if r, ok := c.results[addr]; ok {
+			results[addr] = r
+		} else {
+			missing = append(missing, addr)
+		}
+	}
+	return results, missing
+}
+
+// connectivityTargets returns the peers to report the connectivity to for the given Status request. A list made
+// of only ourself stands for all the nodes of the group. The caller must hold the state lock.
+func (bp *BeaconProcess) connectivityTargets(nodeList []*drand.Address) []string {
+	self := bp.priv.Public.Addr
+	if len(nodeList) == 1 && nodeList[0].GetAddress() == self && bp.beacon != nil && bp.group != nil {
+		bp.log.Debugw("Empty node connectivity list, populating with group file")
+		return bp.groupPeers()
+	}
+
+	targets := make([]string, 0, len(nodeList))
+	for _, addr := range nodeList {
+		remoteAddress := addr.GetAddress()
+		if remoteAddress == "" {
+			bp.log.Warnw("Skipping empty address", "addr", addr)
+			continue
+		}
+		if remoteAddress == self {
+			// Skipping ourselves for the connectivity test
+			continue
+		}
+		targets = append(targets, remoteAddress)
+	}
+	return targets
+}
+
+// groupPeers returns the addresses of the other members of the group. The caller must hold the state lock.
+func (bp *BeaconProcess) groupPeers() []string {
+	if bp.group == nil {
+		return nil
+	}
+	peers := make([]string, 0, len(bp.group.Nodes))
+	for _, node := range bp.group.Nodes {
+		if node.Address() != bp.priv.Public.Addr {
+			peers = append(peers, node.Address())
+		}
+	}
+	return peers
+}
+
+// connectivity returns the connectivity to the given peers and the time of the oldest check reported. Unless
+// forceRefresh is set, only the peers which were never checked are contacted. It must be called without holding
+// the state lock.
+func (bp *BeaconProcess) connectivity(ctx context.Context, addrs []string, forceRefresh bool) (
+	map[string]bool, map[string]*drand.PeerConnectivity, time.Time) {
+	toCheck := addrs
+	if !forceRefresh {
+		_, toCheck = bp.connCache.get(addrs)
+	}
+	bp.checkConnectivity(ctx, toCheck)
+
+	return bp.cachedConnectivity(addrs)
+}
+
+// cachedConnectivity returns the cached connectivity to the given peers, its details and the time of the oldest
+// check reported
+func (bp *BeaconProcess) cachedConnectivity(addrs []string) (map[string]bool, map[string]*drand.PeerConnectivity, time.Time) {
+	results, _ := bp.connCache.get(addrs)
+	conns := make(map[string]bool, len(results))
+	details := make(map[string]*drand.PeerConnectivity, len(results))
+	var oldest time.Time
+	for addr, r := range results {
+		conns[addr] = r.ok
+		details[addr] = r.toProto()
+		if oldest.IsZero() || r.checkedAt.Before(oldest) {
+			oldest = r.checkedAt
+		}
+	}
+	return conns, details, oldest
+}
+
+func (r connectivityResult) toProto() *drand.PeerConnectivity {
+	p := &drand.PeerConnectivity{
+		Ok:        r.ok,
+		RttMs:     r.rtt.Milliseconds(),
+		CheckedAt: r.checkedAt.Unix(),
+	}
+	if !r.lastSuccess.IsZero() {
+		p.LastSuccess = r.lastSuccess.Unix()
+	}
+	if r.err != nil {
+		p.ErrorClass = classifyConnError(r.err)
+		p.Error = r.err.Error()
+	}
+	return p
+}
+
+// classifyConnError tells what kind of problem prevented reaching a peer, so that operators know what to fix.
+// The gRPC errors only carry the description of the underlying dialing error, so we have to look at it.
+func classifyConnError(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) || status.Code(err) == codes.DeadlineExceeded {
+		return connErrorTimeout
+	}
+	var dnsErr *gonet.DNSError
+	if errors.As(err, &dnsErr) {
+		return connErrorDNS
+	}
+
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "no such host"), strings.Contains(msg, "lookup "):
+		return connErrorDNS
+	case strings.Contains(msg, "tls"), strings.Contains(msg, "x509"), strings.Contains(msg, "certificate"):
+		return connErrorTLS
+	case strings.Contains(msg, "connection refused"):
+		return connErrorRefused
+	case strings.Contains(msg, "timeout"), strings.Contains(msg, "deadline exceeded"):
+		return connErrorTimeout
+	default:
+		return connErrorUnknown
+	}
+}
+
+// checkConnectivity contacts the given peers concurrently and caches the results
+func (bp *BeaconProcess) checkConnectivity(ctx context.Context, addrs []string) {
+	if len(addrs) == 0 {
+		return
+	}
+	bp.log.Debugw("Starting remote network connectivity check", "for_nodes", addrs)
+
+	var wg sync.WaitGroup
+	for _, remoteAddress := range addrs {
+		wg.Add(1)
+		go func(remoteAddress string) {
+			defer wg.Done()
+			ctx, span := tracer.NewSpan(ctx, "bp.checkConnectivity.sendingHome")
+			span.SetAttributes(attribute.String("nodeAddr", remoteAddress))
+			defer span.End()
+
+			// Simply try to ping him see if he replies
+			tc, cancel := context.WithTimeout(ctx, callMaxTimeout)
+			defer cancel()
+			bp.log.Debugw("Sending Check request", "for_node", remoteAddress)
+			start := time.Now()
+			err := bp.privGateway.Check(tc, net.CreatePeer(remoteAddress))
+			rtt := time.Since(start)
+			if err != nil {
+				bp.log.Debugw("Status request failed", "remote", remoteAddress, "error", err)
+			}
+			bp.connCache.put(remoteAddress, err, rtt, bp.opts.clock.Now())
+		}(remoteAddress)
+	}
+	wg.Wait()
+	bp.log.Debugw("Done with connectivity check", "nodes", len(addrs))
+}
+
+// startConnectivityProber refreshes the connectivity to the members of the group in the background,
+// until the beacon is stopped.
+func (bp *BeaconProcess) startConnectivityProber() {
+	bp.state.Lock()
+	defer bp.state.Unlock()
+
+	if bp.stopProber != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	bp.stopProber = cancel
+
+	go func() {
+		ticker := bp.opts.clock.NewTicker(connectivityProbePeriod)
+		defer ticker.Stop()
+		for {
+			bp.state.RLock()
+			peers := bp.groupPeers()
+			bp.state.RUnlock()
+			bp.checkConnectivity(ctx, peers)
+			bp.publishHealth(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.Chan():
+			}
+		}
+	}()
+}
+
+// stopConnectivityProber must be called with the state lock held
+func (bp *BeaconProcess) stopConnectivityProber() {
+	if bp.stopProber != nil {
+		bp.stopProber()
+		bp.stopProber = nil
+	}
+}
@@ -5,10 +5,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	clock "github.com/jonboulle/clockwork"
-	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/drand/drand/v2/common"
 	public "github.com/drand/drand/v2/common/chain"
@@ -18,6 +18,7 @@ import (
 	"github.com/drand/drand/v2/internal/chain"
 	"github.com/drand/drand/v2/internal/chain/beacon"
 	"github.com/drand/drand/v2/internal/fs"
+	"github.com/drand/drand/v2/internal/iolimit"
 	"github.com/drand/drand/v2/internal/net"
 	"github.com/drand/drand/v2/protobuf/drand"
 )
@@ -88,6 +89,7 @@ func (bp *BeaconProcess) BackupDatabase(ctx context.Context, req *drand.BackupDB
 	}
 	defer w.Close()
 
+	ctx = iolimit.WithLimiter(ctx, bp.opts.IOLimiter(IOClassBackup))
 	return &drand.BackupDBResponse{Metadata: bp.newMetadata()}, inst.Store().SaveTo(ctx, w)
 }
 
@@ -97,12 +99,13 @@ func (bp *BeaconProcess) PingPong(ctx context.Context, _ *drand.Ping) (*drand.Po
 	_, span := tracer.NewSpan(ctx, "bp.Ping")
 	defer span.End()
 
-	return &drand.Pong{Metadata: bp.newMetadata()}, nil
+	return &drand.Pong{Metadata: bp.newMetadata(), BuildInfo: bp.version.BuildInfo()}, nil
 }
 
 func (bp *BeaconProcess) RemoteStatus(ctx context.Context, in *drand.RemoteStatusRequest) (*drand.RemoteStatusResponse, error) {
 	ctx, span := tracer.NewSpan(ctx, "bp.RemoteStatus")
 	defer span.End()
+	logger := bp.requestLog(ctx)
 
 	replies := make(map[string]*drand.StatusResponse)
 	nodes := in.GetAddresses()
@@ -117,54 +120,155 @@ func (bp *BeaconProcess) RemoteStatus(ctx context.Context, in *drand.RemoteStatu
 			}
 		}
 	}
-	bp.log.Debugw("Starting remote status request", "for_nodes", nodes)
+	logger.Debugw("Starting remote status request", "for_nodes", nodes)
 	for _, addr := range nodes {
 		remoteAddress := addr.GetAddress()
 		if remoteAddress == "" {
-			bp.log.Errorw("Received empty address during remote status", "addr", addr)
+			logger.Errorw("Received empty address during remote status", "addr", addr)
 			continue
 		}
 
 		var err error
 		var resp *drand.StatusResponse
 		statusReq := &drand.StatusRequest{
-			CheckConn: nodes,
-			Metadata:  bp.newMetadata(),
+			CheckConn:    nodes,
+			Metadata:     bp.newMetadata(),
+			ForceRefresh: in.GetForceRefresh(),
 		}
 		if remoteAddress == bp.priv.Public.Addr {
 			// it's ourself
 			resp, err = bp.Status(ctx, statusReq)
 		} else {
-			bp.log.Debugw("Sending status request", "for_node", remoteAddress)
+			logger.Debugw("Sending status request", "for_node", remoteAddress)
 			p := net.CreatePeer(remoteAddress)
 			resp, err = bp.privGateway.Status(ctx, p, statusReq)
 		}
 		if err != nil {
-			bp.log.Warnw("Status request failed", "for_node", addr, "error", err)
+			logger.Warnw("Status request failed", "for_node", addr, "error", err)
 		} else {
 			replies[remoteAddress] = resp
 		}
 	}
 
-	bp.log.Debugw("Done with remote status request", "replies_length", len(replies))
+	logger.Debugw("Done with remote status request", "replies_length", len(replies))
 	return &drand.RemoteStatusResponse{
 		Statuses: replies,
 	}, nil
 }
 
-// Status responds with the actual status of drand process
+// GroupBuildInfo asks all the nodes of the group for the build they're running, flagging the ones that differ
+// from ours or are outdated, e.g. before a coordinated upgrade or DKG.
+func (bp *BeaconProcess) GroupBuildInfo(ctx context.Context, _ *drand.GroupBuildInfoRequest) (*drand.GroupBuildInfoResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "bp.GroupBuildInfo")
+	defer span.End()
+
+	bp.state.RLock()
+	group := bp.group
+	self := bp.priv.Public.Addr
+	bp.state.RUnlock()
+	if group == nil {
+		return nil, errors.New("no group yet")
+	}
+
+	local := bp.version.BuildInfo()
+	nodes := make([]*drand.NodeBuildInfo, 0, len(group.Nodes))
+
+	// all the nodes are asked at once under a single timeout, so that a few unreachable ones can't add up
+	// past the deadline of the control call
+	tc, cancel := context.WithTimeout(ctx, callMaxTimeout)
+	defer cancel()
+	var wg sync.WaitGroup
+	for _, node := range group.Nodes {
+		info := &drand.NodeBuildInfo{Address: node.Address()}
+		nodes = append(nodes, info)
+		if node.Address() == self {
+			info.BuildInfo = local
+			continue
+		}
+
+		wg.Add(1)
+		go func(info *drand.NodeBuildInfo) {
+			defer wg.Done()
+			req := &drand.StatusRequest{Metadata: bp.newMetadata()}
+			resp, err := bp.privGateway.Status(tc, net.CreatePeer(info.Address), req)
+			if err != nil {
+				bp.requestLog(ctx).Debugw("Status request failed", "remote", info.Address, "error", err)
+				info.Error = err.Error()
+				return
+			}
+			info.BuildInfo = resp.GetBuildInfo()
+		}(info)
+	}
+	wg.Wait()
+
+	compareBuildInfos(local, nodes)
+	return &drand.GroupBuildInfoResponse{Local: local, Nodes: nodes}, nil
+}
+
+// compareBuildInfos flags the nodes that aren't running the same build as the local one, and the ones running
+// an older version than the most recent one seen in the group. Nodes that didn't report any build info are both.
+func compareBuildInfos(local *drand.BuildInfo, nodes []*drand.NodeBuildInfo) {
+	latest := local.GetNodeVersion()
+	for _, n := range nodes {
+		if v := n.GetBuildInfo().GetNodeVersion(); v != nil && versionLess(latest, v) {
+			latest = v
+		}
+	}
+
+	for _, n := range nodes {
+		if n.Error != "" {
+			continue
+		}
+		info := n.GetBuildInfo()
+		if info == nil || info.NodeVersion == nil {
+			n.Mismatch = true
+			n.Outdated = true
+			continue
+		}
+		n.Mismatch = versionLess(info.NodeVersion, local.NodeVersion) || versionLess(local.NodeVersion, info.NodeVersion) ||
+			info.Commit != local.Commit || info.BuildTags != local.BuildTags
+		n.Outdated = versionLess(info.NodeVersion, latest)
+	}
+}
+
+func versionLess(a, b *drand.NodeVersion) bool {
+	if a.GetMajor() != b.GetMajor() {
+		return a.GetMajor() < b.GetMajor()
+	}
+	if a.GetMinor() != b.GetMinor() {
+		return a.GetMinor() < b.GetMinor()
+	}
+	return a.GetPatch() < b.GetPatch()
+}
+
+// Status responds with the actual status of drand process. The connectivity to the other nodes is served from
+// a cache refreshed in the background, unless the request forces a refresh.
 func (bp *BeaconProcess) Status(ctx context.Context, in *drand.StatusRequest) (*drand.StatusResponse, error) {
 	ctx, span := tracer.NewSpan(ctx, "bp.Status")
 	defer span.End()
 
 	bp.state.RLock()
-	defer bp.state.RUnlock()
+	packet := bp.status(ctx)
+	peers := bp.connectivityTargets(in.GetCheckConn())
+	bp.state.RUnlock()
+
+	// we don't hold the lock while contacting the peers
+	if conns, details, checkedAt := bp.connectivity(ctx, peers, in.GetForceRefresh()); len(conns) > 0 {
+		packet.Connections = conns
+		packet.ConnectionDetails = details
+		packet.ConnectionsCheckedAt = checkedAt.Unix()
+	}
+	return packet, nil
+}
 
-	bp.log.Debugw("Processing incoming Status request")
+// status returns the local state of the beacon process. It must be called with the state lock held.
+func (bp *BeaconProcess) status(ctx context.Context) *drand.StatusResponse {
+	bp.requestLog(ctx).Debugw("Processing incoming Status request")
 
 	dkgStatus := drand.DkgStatus{}
 	beaconStatus := drand.BeaconStatus{}
 	chainStore := drand.ChainStoreStatus{}
+	shareUsage := drand.ShareUsageStatus{}
 
 	// Beacon status
 	beaconStatus.Status = uint32(BeaconNotInited)
@@ -176,6 +280,9 @@ func (bp *BeaconProcess) Status(ctx context.Context, in *drand.StatusRequest) (*
 		beaconStatus.IsStopped = bp.beacon.IsStopped()
 		beaconStatus.IsRunning = bp.beacon.IsRunning()
 		beaconStatus.IsServing = bp.beacon.IsServing()
+		beaconStatus.IsPaused = bp.pause.paused()
+		beaconStatus.PauseReason = bp.pause.reason
+		shareUsage.PartialsSigned, shareUsage.Anomalies = bp.beacon.ShareUsage()
 
 		// Chain store
 		lastBeacon, err := bp.beacon.Store().Last(ctx)
@@ -185,62 +292,78 @@ func (bp *BeaconProcess) Status(ctx context.Context, in *drand.StatusRequest) (*
 			chainStore.LastStored = lastBeacon.GetRound()
 			chainStore.ExpectedLast = common.CurrentRound(bp.opts.clock.Now().Unix(), bp.group.Period, bp.group.GenesisTime)
 		}
+	} else if store, info := bp.relayedChain(); store != nil {
+		// the nodes following us rely on the progress of the chain we relay to pick their upstream
+		lastBeacon, err := store.Last(ctx)
+		if err == nil && lastBeacon != nil {
+			chainStore.IsEmpty = false
+			chainStore.LastStored = lastBeacon.GetRound()
+			chainStore.ExpectedLast = common.CurrentRound(bp.opts.clock.Now().Unix(), info.Period, info.GenesisTime)
+		}
 	}
 
-	// remote network connectivity
-	nodeList := in.GetCheckConn()
-	// in case of a remote nodelist made of only ourself, instead we test all nodes in the group file
-	if len(nodeList) == 1 && nodeList[0].Address == bp.priv.Public.Addr && bp.beacon != nil && bp.group != nil {
-		bp.log.Debugw("Empty node connectivity list, populating with group file")
-		for _, node := range bp.group.Nodes {
-			nodeList = append(nodeList, &drand.Address{Address: node.Address()})
-		}
+	packet := &drand.StatusResponse{
+		Dkg:        &dkgStatus,
+		ChainStore: &chainStore,
+		Beacon:     &beaconStatus,
+		ShareUsage: &shareUsage,
+		BuildInfo:  bp.version.BuildInfo(),
+		Upgrade:    bp.upgradeStatus(),
+		Leaving:    bp.leavingStatus(),
+
+		HealthScore: bp.healthScore(ctx),
+		Dst:         bp.schemeDST(),
+
+		ReshareForecast: bp.reshareForecastStatus(),
+		Resources:       bp.resourceUsage(),
 	}
+	return packet
+}
 
-	bp.log.Debugw("Starting remote network connectivity check", "for_nodes", nodeList)
-	resp := make(map[string]bool)
-	for _, addr := range nodeList {
-		remoteAddress := addr.GetAddress()
-		if remoteAddress == "" {
-			bp.log.Warnw("Skipping empty address", "addr", addr)
-			continue
-		}
-		if remoteAddress == bp.priv.Public.Addr {
-			// Skipping ourselves for the connectivity test
-			continue
-		}
+// RoundMessage returns the message this node signs a partial over for the given round. For chained schemes, it
+// commits to the previous signature given in the request, or to the one in the local chain store.
+func (bp *BeaconProcess) RoundMessage(ctx context.Context, in *drand.RoundMessageRequest) (*drand.RoundMessageResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "bp.RoundMessage")
+	defer span.End()
+
+	if in.GetRound() == 0 {
+		return nil, errors.New("the genesis round isn't signed")
+	}
+
+	bp.state.RLock()
+	defer bp.state.RUnlock()
 
-		p := net.CreatePeer(remoteAddress)
-		// we use an anonymous function to not leak the defer in the for loop
-		func() {
-			ctx, span := tracer.NewSpan(ctx, "bp.Status.sendingHome")
-			span.SetAttributes(attribute.String("nodeAddr", remoteAddress))
-			defer span.End()
+	sch := bp.priv.Scheme()
+	if bp.group != nil {
+		sch = bp.group.Scheme
+	}
 
-			// Simply try to ping him see if he replies
-			tc, cancel := context.WithTimeout(ctx, callMaxTimeout)
-			defer cancel()
-			bp.log.Debugw("Sending Check request", "for_node", remoteAddress)
-			err := bp.privGateway.Check(tc, p)
+	var previousSig []byte
+	if sch.Name == crypto.DefaultSchemeID {
+		previousSig = in.GetPreviousSignature()
+		if len(previousSig) == 0 {
+			if bp.beacon == nil {
+				return nil, errors.New("no previous signature given and no beacon running to read it from")
+			}
+			previous, err := bp.beacon.Store().Get(ctx, common.Round(in.GetRound()-1))
 			if err != nil {
-				bp.log.Debugw("Status request failed", "remote", addr, "error", err)
-				resp[remoteAddress] = false
-			} else {
-				resp[remoteAddress] = true
+				return nil, fmt.Errorf("unable to get the previous signature of round %d: %w", in.GetRound(), err)
 			}
-		}()
+			previousSig = previous.GetSignature()
+		}
 	}
-	bp.log.Debugw("Done with connectivity check", "response_length", len(resp))
 
-	packet := &drand.StatusResponse{
-		Dkg:        &dkgStatus,
-		ChainStore: &chainStore,
-		Beacon:     &beaconStatus,
-	}
-	if len(resp) > 0 {
-		packet.Connections = resp
-	}
-	return packet, nil
+	msg := sch.DigestBeacon(&common.Beacon{
+		Round:       in.GetRound(),
+		PreviousSig: previousSig,
+	})
+	return &drand.RoundMessageResponse{
+		Round:             in.GetRound(),
+		SchemeID:          sch.Name,
+		PreviousSignature: previousSig,
+		Message:           msg,
+		Metadata:          bp.newMetadata(),
+	}, nil
 }
 
 func (bp *BeaconProcess) ListSchemes(ctx context.Context, _ *drand.ListSchemesRequest) (*drand.ListSchemesResponse, error) {
@@ -250,11 +373,36 @@ func (bp *BeaconProcess) ListSchemes(ctx context.Context, _ *drand.ListSchemesRe
 	return &drand.ListSchemesResponse{Ids: crypto.ListSchemes(), Metadata: bp.newMetadata()}, nil
 }
 
+// ListBeaconIDs responds with the beacon of this process, the daemon lists all of its beacons
 func (bp *BeaconProcess) ListBeaconIDs(ctx context.Context, _ *drand.ListBeaconIDsRequest) (*drand.ListBeaconIDsResponse, error) {
 	_, span := tracer.NewSpan(ctx, "bp.ListBeaconIDs")
 	defer span.End()
 
-	return nil, fmt.Errorf("method bp.ListBeaconIDs not implemented")
+	info := bp.beaconIDInfo()
+	return &drand.ListBeaconIDsResponse{
+		Ids:       []string{info.Id},
+		Metadatas: []*drand.Metadata{{NodeVersion: bp.version.ToProto(), BeaconID: info.Id, ChainHash: info.ChainHash}},
+		Beacons:   []*drand.BeaconIDInfo{info},
+	}, nil
+}
+
+// beaconIDInfo describes the beacon of this process
+func (bp *BeaconProcess) beaconIDInfo() *drand.BeaconIDInfo {
+	bp.state.RLock()
+	defer bp.state.RUnlock()
+
+	info := &drand.BeaconIDInfo{Id: bp.getBeaconID(), Loaded: true}
+	switch {
+	case bp.group != nil:
+		info.Scheme = bp.group.Scheme.Name
+		info.ChainHash = public.NewChainInfo(bp.group).Hash()
+	case bp.priv != nil:
+		info.Scheme = bp.priv.Scheme().Name
+	}
+	if bp.beacon != nil {
+		info.Running = bp.beacon.IsRunning()
+	}
+	return info
 }
 
 // StartFollowChain syncs up with a chain from other nodes
@@ -303,25 +451,27 @@ func (bp *BeaconProcess) StartFollowChain(ctx context.Context, req *drand.StartS
 		}
 		peers = append(peers, net.CreatePeer(addr))
 	}
+	if req.GetCrossCheck() && len(peers) < 2 {
+		return errors.New("cross-checking requires at least two nodes to follow")
+	}
 
-	info, err := bp.chainInfoFromPeers(ctx, peers)
+	// we need to get the hash from the request since we follow a chain we might not know yet
+	info, err := bp.chainInfoFromPeers(ctx, peers, req.GetMetadata().GetChainHash())
 	if err != nil {
 		return err
 	}
 
-	// we need to get the beaconID from the request since we follow a chain we might not know yet
-	hash := req.GetMetadata().GetChainHash()
-	if !bytes.Equal(info.Hash(), hash) {
-		return fmt.Errorf("chain hash mismatch: rcv(%x) != bp(%x)", info.Hash(), hash)
-	}
-
 	logger.Debugw("", "start_follow_chain", "fetched chain info", "hash", fmt.Sprintf("%x", info.GenesisSeed))
 
-	if !common.CompareBeaconIDs(bp.getBeaconID(), info.ID) {
-		return errors.New("invalid beacon id on chain info")
+	// add sch store to handle sch configuration on beacon storing process correctly
+	sch, err := crypto.SchemeFromName(info.GetSchemeName())
+	if err != nil {
+		return err
 	}
 
-	store, err := bp.createDBStore(context.Background())
+	// following the chain takes over the copy of the chain pushed to us, if any
+	bp.closePushReplica()
+	store, err := bp.createDBStore(chainStoreContext(context.Background(), sch))
 	if err != nil {
 		logger.Errorw("", "start_follow_chain", "unable to create store", "err", err)
 		return fmt.Errorf("unable to create store: %w", err)
@@ -334,11 +484,28 @@ func (bp *BeaconProcess) StartFollowChain(ctx context.Context, req *drand.StartS
 		return fmt.Errorf("unable to insert genesis block: %w", err)
 	}
 
-	// add sch store to handle sch configuration on beacon storing process correctly
-	sch, err := crypto.SchemeFromName(info.GetSchemeName())
-	if err != nil {
+	if err := bp.repairUncleanStore(ctx, store, sch, info.PublicKey); err != nil {
 		return err
 	}
+	// the history held by the archives is imported first, only the rounds after it are synced from the nodes
+	if len(req.GetArchives()) > 0 {
+		if _, err := bp.importHistory(ctx, logger, store, info, sch, req.GetArchives(), peers); err != nil {
+			store.Close()
+			return fmt.Errorf("unable to import the history from the archives: %w", err)
+		}
+	}
+	// with a checkpoint, only the rounds after it are synced and verified
+	var checkpoint *common.Beacon
+	missingFrom := uint64(0)
+	if req.GetCheckpoint().GetRound() > 0 {
+		if checkpoint, err = checkpointFromProto(req.GetCheckpoint(), info, sch); err != nil {
+			return err
+		}
+		if missingFrom, err = startFromCheckpoint(ctx, logger, store, checkpoint); err != nil {
+			return err
+		}
+	}
+
 	ss, err := beacon.NewSchemeStore(ctx, store, sch)
 	if err != nil {
 		return err
@@ -354,6 +521,16 @@ func (bp *BeaconProcess) StartFollowChain(ctx context.Context, req *drand.StartS
 	cbStore.AddCallback(addr, cb)
 	defer cbStore.RemoveCallback(addr)
 
+	if req.GetCrossCheck() {
+		crossCheckID := addr + "-cross-check"
+		cbStore.AddCallback(crossCheckID, bp.crossCheckCallback(ctx, logger, peers, info, sch))
+		defer cbStore.RemoveCallback(crossCheckID)
+	}
+
+	syncSources, err := bp.opts.SyncSources()
+	if err != nil {
+		return err
+	}
 	syncer, err := beacon.NewSyncManager(ctx, &beacon.SyncConfig{
 		Log:         logger,
 		Store:       cbStore,
@@ -362,6 +539,10 @@ func (bp *BeaconProcess) StartFollowChain(ctx context.Context, req *drand.StartS
 		Client:      bp.privGateway,
 		Clock:       bp.opts.clock,
 		NodeAddr:    bp.priv.Public.Address(),
+
+		MaxInFlight:  bp.opts.SyncMaxInFlight(),
+		MemoryBudget: bp.opts.SyncMemoryBudget(),
+		Sources:      syncSources,
 	})
 	if err != nil {
 		return err
@@ -369,19 +550,67 @@ func (bp *BeaconProcess) StartFollowChain(ctx context.Context, req *drand.StartS
 
 	go syncer.Run()
 	defer syncer.Stop()
+	bp.following.add(syncer)
+	defer bp.following.remove(syncer)
+
+	// the rounds before the checkpoint are only verified when asked to
+	backfilled := make(chan struct{})
+	if checkpoint != nil && req.GetFullVerification() {
+		backfilled = backfill(ctx, logger, syncer, missingFrom, checkpoint.Round, peers)
+	} else {
+		close(backfilled)
+	}
+
+	// finish waits for the rounds before the checkpoint to be verified, we don't stop it halfway
+	finish := func() error {
+		select {
+		case <-backfilled:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if req.GetPrioritized() {
+		pathChanged := bp.startRelay(cbStore, info)
+		defer bp.stopRelay()
+
+		logger.Debugw("Launching follow from prioritized upstreams now", "upstreams", len(peers))
+		followCtx, stopFollow := context.WithCancel(ctx)
+		defer stopFollow()
+		followed := make(chan error, 1)
+		go func() {
+			upstreams := beacon.NewUpstreams(bp.opts.clock, info.Period, syncSources.Filter(peers))
+			followed <- syncer.Follow(followCtx, upstreams, bp.relayPath, pathChanged)
+		}()
+		select {
+		case <-done:
+			stopFollow()
+			return finish()
+		case err := <-followed:
+			return err
+		}
+	}
 
 	logger.Debugw("Launching follow now")
-	var errChan chan error
+	errChan := make(chan error, 1)
+	fp := newFollowPeers(peers)
+	go bp.refreshFollowPeers(ctx, logger, fp, info)
 
 	for {
 		syncCtx, syncCancel := context.WithCancel(ctx)
-		go func() {
+		go func(peers []net.Peer) {
 			errChan <- syncer.Sync(syncCtx, beacon.NewRequestInfo(ctx, req.GetUpTo(), peers))
-		}() // wait for all the callbacks to be called and progress sent before returning
+		}(fp.get()) // wait for all the callbacks to be called and progress sent before returning
 		select {
+		case <-fp.updated:
+			// restart the sync with the peers refreshed from the group
+			syncCancel()
+			<-errChan
+			continue
 		case <-done:
 			syncCancel()
-			return nil
+			return finish()
 		case <-ctx.Done():
 			syncCancel()
 			return ctx.Err()
@@ -443,7 +672,7 @@ func (bp *BeaconProcess) StartCheckChain(req *drand.StartSyncRequest, stream dra
 	}
 
 	logger.Debugw("validate_and_sync", "up_to", req.UpTo)
-	faultyBeacons, err := bp.beacon.ValidateChain(ctx, req.UpTo, cb)
+	faultyBeacons, err := bp.beacon.ValidateChain(iolimit.WithLimiter(ctx, bp.opts.IOLimiter(IOClassCheck)), req.UpTo, cb)
 	if err != nil {
 		return err
 	}
@@ -490,8 +719,9 @@ func (bp *BeaconProcess) StartCheckChain(req *drand.StartSyncRequest, stream dra
 	}
 }
 
-// chainInfoFromPeers attempts to fetch chain info from one of the passed peers.
-func (bp *BeaconProcess) chainInfoFromPeers(ctx context.Context, peers []net.Peer) (*public.Info, error) {
+// chainInfoFromPeers fetches the chain info from the first of the passed peers serving the chain with the given hash.
+// If none does, the error returned is a net.PeerErrors telling why each peer failed.
+func (bp *BeaconProcess) chainInfoFromPeers(ctx context.Context, peers []net.Peer, hash []byte) (*public.Info, error) {
 	ctx, span := tracer.NewSpan(ctx, "bp.chainInfoFromPeers")
 	defer span.End()
 
@@ -506,25 +736,32 @@ func (bp *BeaconProcess) chainInfoFromPeers(ctx context.Context, peers []net.Pee
 	request := new(drand.ChainInfoRequest)
 	request.Metadata = &drand.Metadata{BeaconID: beaconID, NodeVersion: version.ToProto()}
 
-	var info *public.Info
-	var err error
+	errs := &net.PeerErrors{Op: "get the chain info"}
 	for _, peer := range peers {
-		var ci *drand.ChainInfoPacket
-		ci, err = privGateway.ChainInfo(ctx, peer, request)
+		ci, err := privGateway.ChainInfo(ctx, peer, request)
 		if err != nil {
-			logger.Errorw("", "start_follow_chain", "error getting chain info", "from", peer.Address(), "err", err)
+			errs.Add(peer.Address(), net.PeerReason(err), err)
 			continue
 		}
-		info, err = public.InfoFromProto(ci)
+		info, err := public.InfoFromProto(ci)
 		if err != nil {
-			logger.Errorw("", "start_follow_chain", "invalid chain info", "from", peer.Address(), "err", err)
+			errs.Add(peer.Address(), net.PeerInvalid, err)
+			continue
+		}
+		if !bytes.Equal(info.Hash(), hash) {
+			errs.Add(peer.Address(), net.PeerBadHash, fmt.Errorf("chain hash mismatch: rcv(%x) != bp(%x)", info.Hash(), hash))
+			continue
+		}
+		if !common.CompareBeaconIDs(beaconID, info.ID) {
+			errs.Add(peer.Address(), net.PeerBadBeaconID, fmt.Errorf("invalid beacon id %q on chain info", info.ID))
 			continue
 		}
+		return info, nil
 	}
-	if info == nil {
-		return nil, fmt.Errorf("unable to get chain info successfully. Last err: %w", err)
+	for _, f := range errs.Failures {
+		logger.Errorw("unable to get the chain info", "from", f.Peer, "reason", f.Reason, "err", f.Err)
 	}
-	return info, nil
+	return nil, errs
 }
 
 // sendProgressCallback returns a function that sends SyncProgress on the
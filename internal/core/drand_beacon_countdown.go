@@ -0,0 +1,94 @@
+package core
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/internal/net"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// the targets of a countdown
+const (
+	countdownGenesis    = "genesis"
+	countdownTransition = "transition"
+)
+
+// Countdown returns the time left until the genesis of the chain, or until the transition to the group of the last
+// reshare, along with the readiness of each member of the group. Nothing is counted down once the chain runs with its
+// last group.
+func (bp *BeaconProcess) Countdown(ctx context.Context, _ *drand.CountdownRequest) (*drand.CountdownResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "bp.Countdown")
+	defer span.End()
+
+	bp.state.RLock()
+	group := bp.group
+	self := bp.priv.Public.Addr
+	bp.state.RUnlock()
+	if group == nil {
+		return nil, status.Error(codes.FailedPrecondition, "no group yet, the countdown starts once the DKG is completed")
+	}
+
+	resp := &drand.CountdownResponse{Threshold: uint32(group.Threshold), Metadata: bp.newMetadata()}
+	now := bp.opts.clock.Now().Unix()
+	switch {
+	case now < group.GenesisTime:
+		resp.Target, resp.Time = countdownGenesis, group.GenesisTime
+	case now < group.TransitionTime:
+		resp.Target, resp.Time = countdownTransition, group.TransitionTime
+	default:
+		return resp, nil
+	}
+	resp.SecondsLeft = resp.Time - now
+	resp.Round = common.CurrentRound(resp.Time, group.Period, group.GenesisTime)
+
+	for _, node := range group.Nodes {
+		var st *drand.StatusResponse
+		var err error
+		if node.Address() == self {
+			bp.state.RLock()
+			st = bp.status(ctx)
+			bp.state.RUnlock()
+		} else {
+			func() {
+				tc, cancel := context.WithTimeout(ctx, callMaxTimeout)
+				defer cancel()
+				st, err = bp.privGateway.Status(tc, net.CreatePeer(node.Address()), &drand.StatusRequest{Metadata: bp.newMetadata()})
+			}()
+		}
+
+		readiness := &drand.NodeReadiness{Address: node.Address()}
+		if err != nil {
+			bp.requestLog(ctx).Debugw("Status request failed", "remote", node.Address(), "error", err)
+			readiness.Reason = "unreachable: " + err.Error()
+		} else {
+			readiness.Reason = notReadyReason(st.GetBeacon())
+		}
+		readiness.Ready = readiness.Reason == ""
+		if readiness.Ready {
+			resp.Ready++
+		}
+		resp.Nodes = append(resp.Nodes, readiness)
+	}
+	return resp, nil
+}
+
+// notReadyReason returns why a node whose beacon has the given status isn't ready to produce the beacons at the
+// target of a countdown, or an empty string if it is ready
+func notReadyReason(beacon *drand.BeaconStatus) string {
+	switch {
+	case beacon.GetStatus() != uint32(BeaconInited):
+		return "the beacon isn't set up with the group"
+	case beacon.GetIsStopped():
+		return "the beacon is stopped"
+	case !beacon.GetIsRunning():
+		return "the beacon isn't running"
+	case beacon.GetIsPaused():
+		return "the beacon is paused: " + beacon.GetPauseReason()
+	}
+	return ""
+}
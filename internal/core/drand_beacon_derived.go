@@ -0,0 +1,537 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/key"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/internal/chain"
+	"github.com/drand/drand/v2/internal/chain/boltdb"
+	chainerrors "github.com/drand/drand/v2/internal/chain/errors"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// DerivedChainsFileName is the database the rounds of the derived chains are stored in, so that they are served
+// again on start and the derivation resumes from the last round derived
+const DerivedChainsFileName = "derived_chains.db"
+
+// derivedSpecsBucket maps the names of the derived chains to the definition and the chain hash their rounds were
+// derived with, the rounds of each chain being in a bucket of its own
+var derivedSpecsBucket = []byte("specs")
+
+// derivedCallbackID is the ID of the callback deriving the rounds of the derived chains from the new beacons
+const derivedCallbackID = "derived-chains"
+
+// derivation maps the rounds of a derived chain to the windows of consecutive rounds of the chain they're computed from
+type derivation interface {
+	// validate checks that every window holds at least a round of the chain of the group
+	validate(group *key.Group) error
+	// window returns the first and last rounds of the chain the round of the derived chain is computed from
+	window(round uint64, group *key.Group) (from, to uint64)
+}
+
+// derivations are the kinds of derived chains, with the function parsing their parameter
+var derivations = map[string]func(param string) (derivation, error){
+	"every":  parseEveryDerivation,
+	"bucket": parseBucketDerivation,
+}
+
+// everyDerivation derives a round every that many rounds of the chain
+type everyDerivation uint64
+
+func parseEveryDerivation(param string) (derivation, error) {
+	n, err := strconv.ParseUint(param, 10, 64)
+	if err != nil || n == 0 {
+		return nil, fmt.Errorf("expected a positive number of rounds, got %q", param)
+	}
+	return everyDerivation(n), nil
+}
+
+func (e everyDerivation) validate(*key.Group) error {
+	return nil
+}
+
+func (e everyDerivation) window(round uint64, _ *key.Group) (from, to uint64) {
+	n := uint64(e)
+	return (round-1)*n + 1, round * n
+}
+
+// bucketDerivation derives a round every period of time, the windows being aligned on the multiples of the period
+// since the UNIX epoch so that, e.g., the daily buckets start at midnight UTC. The first window starts before the
+// genesis of the chain.
+type bucketDerivation time.Duration
+
+func parseBucketDerivation(param string) (derivation, error) {
+	d, err := time.ParseDuration(param)
+	if err != nil {
+		return nil, err
+	}
+	if d < time.Second || d%time.Second != 0 {
+		return nil, fmt.Errorf("the period of the buckets must be a whole number of seconds, got %s", d)
+	}
+	return bucketDerivation(d), nil
+}
+
+func (b bucketDerivation) validate(group *key.Group) error {
+	if time.Duration(b) < group.Period {
+		return fmt.Errorf("the period of the buckets %s is shorter than the period of the chain %s",
+			time.Duration(b), group.Period)
+	}
+	return nil
+}
+
+func (b bucketDerivation) window(round uint64, group *key.Group) (from, to uint64) {
+	period := int64(time.Duration(b) / time.Second)
+	start := (group.GenesisTime/period + int64(round) - 1) * period
+	return firstRoundFrom(start, group), firstRoundFrom(start+period, group) - 1
+}
+
+// firstRoundFrom returns the first round of the chain of the group at or after the given UNIX time
+func firstRoundFrom(t int64, group *key.Group) uint64 {
+	if t <= group.GenesisTime {
+		return 1
+	}
+	period := int64(group.Period / time.Second)
+	return uint64((t-group.GenesisTime+period-1)/period) + 1
+}
+
+// DerivedChain is a chain derived from the beacons of the chain, e.g. a digest every Nth round, for the consumers who
+// need lower-frequency randomness. Each node derives its chains on its own, and anyone can verify them from the
+// beacons of the chain.
+type DerivedChain struct {
+	Name  string
+	Kind  string
+	Param string
+
+	derivation derivation
+}
+
+// String returns the definition of the derived chain, as parsed by ParseDerivedChains
+func (d DerivedChain) String() string {
+	return d.Name + "=" + d.Kind + ":" + d.Param
+}
+
+// ParseDerivedChains parses derived chains given as "name=kind:param". The kinds are "every", whose parameter is the
+// number of rounds of the chain per round, e.g. "weekly=every:201600", and "bucket", whose parameter is the period of
+// the rounds, e.g. "daily=bucket:24h".
+func ParseDerivedChains(specs []string) ([]DerivedChain, error) {
+	chains := make([]DerivedChain, 0, len(specs))
+	seen := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		name, def, found := strings.Cut(spec, "=")
+		if !found || !validDerivedChainName(name) {
+			return nil, fmt.Errorf("invalid derived chain %q, expected name=kind:param with a name of letters, "+
+				"digits, '-' and '_'", spec)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("derived chain %q is defined twice", name)
+		}
+		kind, param, _ := strings.Cut(def, ":")
+		parse, ok := derivations[kind]
+		if !ok {
+			return nil, fmt.Errorf("unknown kind %q of derived chain %q", kind, name)
+		}
+		d, err := parse(param)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derived chain %q: %w", name, err)
+		}
+		seen[name] = true
+		chains = append(chains, DerivedChain{Name: name, Kind: kind, Param: param, derivation: d})
+	}
+	return chains, nil
+}
+
+// validDerivedChainName checks that the name can't be confused with the separators of the digests
+func validDerivedChainName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') && c != '-' && c != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// derivedDigest returns the digest of a round of the derived chain, from the digest of its previous round and the
+// signatures of the rounds of its window
+func derivedDigest(beaconID, name string, round uint64, previous []byte, signatures [][]byte) []byte {
+	h := sha256.New()
+	h.Write([]byte("drand-derived:" + common.GetCanonicalBeaconID(beaconID) + ":" + name + ":"))
+	h.Write(binary.BigEndian.AppendUint64(nil, round))
+	h.Write(previous)
+	for _, sig := range signatures {
+		h.Write(sig)
+	}
+	return h.Sum(nil)
+}
+
+// derivedSpec is what the rounds stored of a derived chain were derived with, they are derived again from the first
+// round when either changes
+type derivedSpec struct {
+	Spec      string `json:"spec"`
+	ChainHash []byte `json:"chain_hash"`
+}
+
+// derivedRounds are the rounds of a derived chain, the digests being stored as the signatures of the beacons
+type derivedRounds interface {
+	Put(ctx context.Context, b *common.Beacon) error
+	Get(ctx context.Context, round common.Round) (*common.Beacon, error)
+	Last(ctx context.Context) (*common.Beacon, error)
+}
+
+type derivedStream struct {
+	DerivedChain
+	store derivedRounds
+	// the last round derived, and its digest
+	last   uint64
+	digest []byte
+}
+
+// derivedChains holds the chains derived from the beacons of the chain. Its zero value is ready to use.
+type derivedChains struct {
+	sync.Mutex
+	streams map[string]*derivedStream
+	db      *bolt.DB
+	stop    context.CancelFunc
+}
+
+// boltDerivedRounds stores the rounds of a derived chain in its bucket, by round
+type boltDerivedRounds struct {
+	db     *bolt.DB
+	bucket []byte
+}
+
+func derivedRoundsBucket(name string) []byte {
+	return []byte("chain:" + name)
+}
+
+func (s *boltDerivedRounds) Put(_ context.Context, b *common.Beacon) error {
+	value, err := b.Marshal()
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(s.bucket).Put(binary.BigEndian.AppendUint64(nil, b.Round), value)
+	})
+}
+
+func (s *boltDerivedRounds) Get(_ context.Context, round common.Round) (*common.Beacon, error) {
+	return s.view(func(bucket *bolt.Bucket) []byte {
+		return bucket.Get(binary.BigEndian.AppendUint64(nil, round.Uint64()))
+	})
+}
+
+func (s *boltDerivedRounds) Last(_ context.Context) (*common.Beacon, error) {
+	return s.view(func(bucket *bolt.Bucket) []byte {
+		_, value := bucket.Cursor().Last()
+		return value
+	})
+}
+
+func (s *boltDerivedRounds) view(get func(bucket *bolt.Bucket) []byte) (*common.Beacon, error) {
+	b := new(common.Beacon)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		value := get(tx.Bucket(s.bucket))
+		if value == nil {
+			return chainerrors.ErrNoBeaconStored
+		}
+		return b.Unmarshal(value)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// openDerivedStream resumes the derivation of the derived chain from the last round stored, or from the first round
+// if none is stored for the same definition and chain hash
+func openDerivedStream(ctx context.Context, db *bolt.DB, spec DerivedChain, chainHash []byte) (*derivedStream, error) {
+	bucket := derivedRoundsBucket(spec.Name)
+	err := db.Update(func(tx *bolt.Tx) error {
+		specs, err := tx.CreateBucketIfNotExists(derivedSpecsBucket)
+		if err != nil {
+			return err
+		}
+		want, err := json.Marshal(derivedSpec{Spec: spec.String(), ChainHash: chainHash})
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(specs.Get([]byte(spec.Name)), want) {
+			if err := tx.DeleteBucket(bucket); err != nil && !errors.Is(err, bolt.ErrBucketNotFound) {
+				return err
+			}
+			if err := specs.Put([]byte(spec.Name), want); err != nil {
+				return err
+			}
+		}
+		_, err = tx.CreateBucketIfNotExists(bucket)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stream := &derivedStream{DerivedChain: spec, store: &boltDerivedRounds{db: db, bucket: bucket}, digest: chainHash}
+	last, err := stream.store.Last(ctx)
+	if err == nil {
+		stream.last, stream.digest = last.Round, last.Signature
+	} else if !errors.Is(err, chainerrors.ErrNoBeaconStored) {
+		return nil, err
+	}
+	return stream, nil
+}
+
+func (d *derivedChains) get(name string) (*derivedStream, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	stream, ok := d.streams[name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown derived chain %q", name)
+	}
+	return stream, nil
+}
+
+// startDerivedChains derives the rounds of the configured derived chains from the beacons of the chain, as they are
+// stored, until the beacon is stopped
+func (bp *BeaconProcess) startDerivedChains() {
+	specs, err := ParseDerivedChains(bp.opts.derivedChains)
+	if err != nil {
+		bp.log.Errorw("Invalid derived chains", "err", err)
+		return
+	}
+	if len(specs) == 0 {
+		return
+	}
+
+	bp.state.RLock()
+	b, group, chainHash := bp.beacon, bp.group, bp.chainHash
+	bp.state.RUnlock()
+	if b == nil || group == nil || len(chainHash) == 0 {
+		return
+	}
+
+	bp.derived.Lock()
+	defer bp.derived.Unlock()
+	if bp.derived.stop != nil {
+		return
+	}
+
+	db, err := bolt.Open(bp.derivedChainsFile(), boltdb.BoltStoreOpenPerm, bp.opts.boltOpts)
+	if err != nil {
+		bp.log.Errorw("Unable to open the database of the derived chains", "err", err)
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	bp.derived.db = db
+	bp.derived.streams = make(map[string]*derivedStream, len(specs))
+	for _, spec := range specs {
+		if err := spec.derivation.validate(group); err != nil {
+			bp.log.Errorw("Invalid derived chain", "name", spec.Name, "err", err)
+			continue
+		}
+		stream, err := openDerivedStream(ctx, db, spec, chainHash)
+		if err != nil {
+			bp.log.Errorw("Unable to open the derived chain", "name", spec.Name, "err", err)
+			continue
+		}
+		bp.derived.streams[spec.Name] = stream
+	}
+
+	bp.derived.stop = cancel
+	wake := make(chan struct{}, 1)
+	wake <- struct{}{}
+	b.AddCallback(ctx, derivedCallbackID, func(_ *common.Beacon, closed bool) {
+		if closed {
+			return
+		}
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+	})
+	go bp.runDerivedChains(ctx, b.Store(), group, wake)
+}
+
+func (bp *BeaconProcess) runDerivedChains(ctx context.Context, source chain.Store, group *key.Group, wake chan struct{}) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-wake:
+		}
+
+		bp.derived.Lock()
+		streams := make([]*derivedStream, 0, len(bp.derived.streams))
+		for _, stream := range bp.derived.streams {
+			streams = append(streams, stream)
+		}
+		bp.derived.Unlock()
+
+		for _, stream := range streams {
+			if err := bp.deriveRounds(ctx, source, group, stream); err != nil && ctx.Err() == nil {
+				bp.log.Warnw("Unable to derive the rounds of the derived chain", "name", stream.Name, "err", err)
+			}
+		}
+	}
+}
+
+// stopDerivedChains stops deriving the rounds of the derived chains and closes their database, they aren't served
+// anymore once the beacon is stopped. It must be called with the state lock held.
+func (bp *BeaconProcess) stopDerivedChains() {
+	bp.derived.Lock()
+	defer bp.derived.Unlock()
+
+	if bp.derived.stop == nil {
+		return
+	}
+	bp.derived.stop()
+	bp.derived.stop = nil
+	if bp.beacon != nil {
+		bp.beacon.RemoveCallback(context.Background(), derivedCallbackID)
+	}
+	if err := bp.derived.db.Close(); err != nil {
+		bp.log.Warnw("Unable to close the database of the derived chains", "err", err)
+	}
+	bp.derived.db = nil
+}
+
+// deriveRounds derives and stores the rounds of the derived chain whose windows are stored in the source chain
+func (bp *BeaconProcess) deriveRounds(ctx context.Context, source chain.Store, group *key.Group, stream *derivedStream) error {
+	ctx, span := tracer.NewSpan(ctx, "bp.deriveRounds")
+	defer span.End()
+
+	last, err := source.Last(ctx)
+	if err != nil {
+		return err
+	}
+
+	for ctx.Err() == nil {
+		bp.derived.Lock()
+		round, previous := stream.last+1, stream.digest
+		bp.derived.Unlock()
+
+		from, to := stream.derivation.window(round, group)
+		if to > last.Round {
+			return nil
+		}
+		signatures := make([][]byte, 0, to-from+1)
+		for r := from; r <= to; r++ {
+			b, err := source.Get(ctx, common.Round(r))
+			if err != nil {
+				return fmt.Errorf("can't retrieve round %d of the chain: %w", r, err)
+			}
+			signatures = append(signatures, b.Signature)
+		}
+		digest := derivedDigest(bp.getBeaconID(), stream.Name, round, previous, signatures)
+
+		bp.derived.Lock()
+		err := stream.store.Put(ctx, &common.Beacon{Round: round, Signature: digest, PreviousSig: previous})
+		if err == nil {
+			stream.last, stream.digest = round, digest
+		}
+		bp.derived.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+func (bp *BeaconProcess) derivedChainsFile() string {
+	beaconID := common.GetCanonicalBeaconID(bp.getBeaconID())
+	return path.Join(bp.opts.BeaconFolderMB(beaconID), beaconID, DerivedChainsFileName)
+}
+
+// DerivedChains lists the chains derived from the beacons of the chain by this node
+func (bp *BeaconProcess) DerivedChains(ctx context.Context, _ *drand.DerivedChainsRequest) (*drand.DerivedChainsResponse, error) {
+	_, span := tracer.NewSpan(ctx, "bp.DerivedChains")
+	defer span.End()
+
+	bp.state.RLock()
+	defer bp.state.RUnlock()
+	if bp.group == nil {
+		return nil, ErrNoGroupSetup
+	}
+
+	specs, err := ParseDerivedChains(bp.opts.derivedChains)
+	if err != nil {
+		return nil, err
+	}
+	resp := &drand.DerivedChainsResponse{Metadata: bp.newMetadata()}
+	for _, spec := range specs {
+		info := &drand.DerivedChainInfo{
+			Name:      spec.Name,
+			Kind:      spec.Kind,
+			Param:     spec.Param,
+			ChainHash: bp.chainHash,
+		}
+		if stream, err := bp.derived.get(spec.Name); err == nil {
+			bp.derived.Lock()
+			info.LastRound = stream.last
+			bp.derived.Unlock()
+		}
+		resp.DerivedChains = append(resp.DerivedChains, info)
+	}
+	sort.Slice(resp.DerivedChains, func(i, j int) bool {
+		return resp.DerivedChains[i].GetName() < resp.DerivedChains[j].GetName()
+	})
+	return resp, nil
+}
+
+// DerivedRand returns the round of the derived chain requested, the last one if the round is 0, with the window of
+// rounds of the chain it was computed from
+func (bp *BeaconProcess) DerivedRand(ctx context.Context, in *drand.DerivedRandRequest) (*drand.DerivedRandResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "bp.DerivedRand")
+	defer span.End()
+
+	bp.state.RLock()
+	defer bp.state.RUnlock()
+	if bp.beacon == nil || len(bp.chainHash) == 0 {
+		return nil, errors.New("drand: beacon generation not started yet")
+	}
+
+	stream, err := bp.derived.get(in.GetName())
+	if err != nil {
+		return nil, err
+	}
+	var b *common.Beacon
+	if in.GetRound() == 0 {
+		b, err = stream.store.Last(ctx)
+	} else {
+		b, err = stream.store.Get(ctx, common.Round(in.GetRound()))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't retrieve round %d of derived chain %q: %w", in.GetRound(), stream.Name, err)
+	}
+
+	from, to := stream.derivation.window(b.Round, bp.group)
+	return &drand.DerivedRandResponse{
+		Name:           stream.Name,
+		Round:          b.Round,
+		Digest:         b.Signature,
+		PreviousDigest: b.PreviousSig,
+		FromRound:      from,
+		ToRound:        to,
+		Metadata:       bp.newMetadata(),
+	}, nil
+}
@@ -0,0 +1,98 @@
+package core
+
+import (
+	"context"
+	"errors"
+
+	"github.com/drand/drand/v2/common/log"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/net"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// schemeDST describes how the beacon process hashes the beacons. It must be called with the state lock held.
+func (bp *BeaconProcess) schemeDST() *drand.SchemeDST {
+	switch {
+	case bp.group != nil:
+		return describeDST(bp.group.Scheme)
+	case bp.priv != nil:
+		return describeDST(bp.priv.Scheme())
+	default:
+		return nil
+	}
+}
+
+func describeDST(sch *crypto.Scheme) *drand.SchemeDST {
+	d := &drand.SchemeDST{Scheme: sch.Name, Dst: string(sch.DST)}
+	if fingerprint, err := sch.DSTFingerprint(); err == nil {
+		d.Fingerprint = fingerprint
+	}
+	d.Custom = crypto.CheckDST(sch) != nil
+	return d
+}
+
+// checkDST logs the domain separation tag the beacons are hashed with, as an error if it isn't the one of the
+// released builds since the partials signed with it won't be verifiable by the other nodes
+func checkDST(l log.Logger, sch *crypto.Scheme) {
+	fingerprint, _ := sch.DSTFingerprint()
+	if err := crypto.CheckDST(sch); err != nil {
+		l.Errorw("The scheme doesn't hash the beacons as the released builds do, the partials signed may not be verifiable",
+			"scheme", sch.Name, "dst", string(sch.DST), "fingerprint", fingerprint, "err", err)
+		return
+	}
+	l.Infow("Hashing the beacons with the domain separation tag of the scheme", "scheme", sch.Name, "dst", string(sch.DST),
+		"fingerprint", fingerprint)
+}
+
+// GroupDST asks all the nodes of the group how they hash the beacons, flagging the ones that don't do it as we do,
+// e.g. after a custom build changed the domain separation tag of the scheme.
+func (bp *BeaconProcess) GroupDST(ctx context.Context, _ *drand.GroupDSTRequest) (*drand.GroupDSTResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "bp.GroupDST")
+	defer span.End()
+
+	bp.state.RLock()
+	group := bp.group
+	self := bp.priv.Public.Addr
+	local := bp.schemeDST()
+	bp.state.RUnlock()
+	if group == nil {
+		return nil, errors.New("no group yet")
+	}
+
+	nodes := make([]*drand.NodeDST, 0, len(group.Nodes))
+	for _, node := range group.Nodes {
+		info := &drand.NodeDST{Address: node.Address()}
+		nodes = append(nodes, info)
+		if node.Address() == self {
+			info.Dst = local
+			continue
+		}
+
+		func() {
+			tc, cancel := context.WithTimeout(ctx, callMaxTimeout)
+			defer cancel()
+			resp, err := bp.privGateway.Status(tc, net.CreatePeer(node.Address()), &drand.StatusRequest{Metadata: bp.newMetadata()})
+			if err != nil {
+				bp.requestLog(ctx).Debugw("Status request failed", "remote", node.Address(), "error", err)
+				info.Error = err.Error()
+				return
+			}
+			info.Dst = resp.GetDst()
+		}()
+	}
+
+	compareDSTs(local, nodes)
+	return &drand.GroupDSTResponse{Local: local, Nodes: nodes}, nil
+}
+
+// compareDSTs flags the nodes that don't hash the beacons as the local one. The nodes which don't report it can't be
+// compared, they aren't flagged.
+func compareDSTs(local *drand.SchemeDST, nodes []*drand.NodeDST) {
+	for _, n := range nodes {
+		if n.Error != "" || n.GetDst() == nil {
+			continue
+		}
+		n.Mismatch = n.GetDst().GetScheme() != local.GetScheme() || n.GetDst().GetFingerprint() != local.GetFingerprint()
+	}
+}
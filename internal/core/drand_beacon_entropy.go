@@ -0,0 +1,78 @@
+package core
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/chain/beacon"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// defaultEntropyPerRound is how many bytes are derived from each beacon when the request doesn't say
+const defaultEntropyPerRound = 32
+
+// EntropyStream streams bytes derived from the successive beacons of the chain, starting with the latest one unless
+// the request gives a round. The beacons are the ones of the chain store, which are verified before being stored.
+func (bp *BeaconProcess) EntropyStream(in *drand.EntropyStreamRequest, stream drand.Control_EntropyStreamServer) error {
+	size := int(in.GetBytesPerRound())
+	if size == 0 {
+		size = defaultEntropyPerRound
+	}
+	if size > crypto.MaxEntropyPerRound {
+		return status.Errorf(codes.InvalidArgument, "at most %d bytes can be derived from a beacon", crypto.MaxEntropyPerRound)
+	}
+
+	bp.state.RLock()
+	b := bp.beacon
+	chainHash := bp.chainHash
+	bp.state.RUnlock()
+	if b == nil || len(chainHash) == 0 {
+		return status.Error(codes.FailedPrecondition, "beacon has not started on this node yet")
+	}
+
+	store := b.Store()
+	from := in.GetFromRound()
+	if from == 0 {
+		last, err := store.Last(stream.Context())
+		if err != nil {
+			return err
+		}
+		from = last.Round
+	}
+
+	req := &entropyRequest{fromRound: from, metadata: bp.newMetadata()}
+	return beacon.SyncChain(bp.log.Named("EntropyStream"), store, req, &entropyStream{
+		Control_EntropyStreamServer: stream,
+		chainHash:                   chainHash,
+		size:                        size,
+	})
+}
+
+type entropyRequest struct {
+	fromRound uint64
+	metadata  *drand.Metadata
+}
+
+func (e *entropyRequest) GetFromRound() uint64 {
+	return e.fromRound
+}
+
+func (e *entropyRequest) GetMetadata() *drand.Metadata {
+	return e.metadata
+}
+
+// entropyStream sends the bytes derived from the beacons instead of the beacons
+type entropyStream struct {
+	drand.Control_EntropyStreamServer
+	chainHash []byte
+	size      int
+}
+
+func (e *entropyStream) Send(b *drand.BeaconPacket) error {
+	data, err := crypto.DeriveEntropy(e.chainHash, b.GetRound(), crypto.RandomnessFromSignature(b.GetSignature()), e.size)
+	if err != nil {
+		return err
+	}
+	return e.Control_EntropyStreamServer.Send(&drand.EntropyChunk{Round: b.GetRound(), Data: data})
+}
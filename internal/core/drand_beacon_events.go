@@ -0,0 +1,93 @@
+package core
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// maxRecentEvents is the number of events a beacon process keeps for the snapshots
+const maxRecentEvents = 64
+
+const (
+	eventBeaconStarted       = "beacon_started"
+	eventBeaconStopped       = "beacon_stopped"
+	eventDKGCompleted        = "dkg_completed"
+	eventJoinedGroup         = "joined_group"
+	eventLeftGroup           = "left_group"
+	eventLeaveAnnounced      = "leave_announced"
+	eventUpgradeProposed     = "upgrade_proposed"
+	eventUpgradeScheduled    = "upgrade_scheduled"
+	eventForkDetected        = "fork_detected"
+	eventStoreRepaired       = "store_repaired"
+	eventFeatureChanged      = "feature_changed"
+	eventBeaconCrashed       = "beacon_crashed"
+	eventBeaconGaveUp        = "beacon_gave_up"
+	eventQuorumAtRisk        = "quorum_at_risk"
+	eventQuorumLost          = "quorum_lost"
+	eventQuorumRestored      = "quorum_restored"
+	eventBeaconInjected      = "beacon_injected"
+	eventBeaconPaused        = "beacon_paused"
+	eventBeaconResumed       = "beacon_resumed"
+	eventAddressUpdated      = "address_updated"
+	eventReshareForecast     = "reshare_forecast"
+	eventReshareApproaching  = "reshare_approaching"
+	eventCertificateExpiring = "certificate_expiring"
+	eventHistoryImported     = "history_imported"
+)
+
+// eventLog keeps the most recent notable events of a beacon process. Its zero value is ready to use.
+// It has its own lock so that events can be recorded with or without holding the state lock.
+type eventLog struct {
+	sync.Mutex
+	events []*drand.BeaconEvent
+	// changed is closed when an event is recorded, to wake up the status subscriptions
+	changed chan struct{}
+}
+
+func (e *eventLog) record(at time.Time, kind, detail string) {
+	e.Lock()
+	defer e.Unlock()
+
+	if len(e.events) == maxRecentEvents {
+		e.events = e.events[1:]
+	}
+	e.events = append(e.events, &drand.BeaconEvent{Time: at.Unix(), Kind: kind, Detail: detail})
+	if e.changed != nil {
+		close(e.changed)
+		e.changed = nil
+	}
+}
+
+// watch returns a channel closed when the next event is recorded
+func (e *eventLog) watch() <-chan struct{} {
+	e.Lock()
+	defer e.Unlock()
+
+	if e.changed == nil {
+		e.changed = make(chan struct{})
+	}
+	return e.changed
+}
+
+// recent returns the recorded events, from the oldest to the most recent
+func (e *eventLog) recent() []*drand.BeaconEvent {
+	e.Lock()
+	defer e.Unlock()
+
+	events := make([]*drand.BeaconEvent, len(e.events))
+	copy(events, e.events)
+	return events
+}
+
+// crashEvents returns the recent events of the beacon process for its crash reports
+func (bp *BeaconProcess) crashEvents() []string {
+	recent := bp.events.recent()
+	events := make([]string, 0, len(recent))
+	for _, e := range recent {
+		events = append(events, fmt.Sprintf("%s %s %s", time.Unix(e.GetTime(), 0).UTC().Format(time.RFC3339), e.GetKind(), e.GetDetail()))
+	}
+	return events
+}
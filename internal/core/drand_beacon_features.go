@@ -0,0 +1,206 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/internal/metrics"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// Feature names a behavior which can be turned on and off per beacon, in the config and at runtime through the
+// control port, so that risky new behaviors can be rolled out gradually and rolled back without redeploying.
+type Feature string
+
+// The features which can be turned on and off. New risky behaviors get one here, with their default.
+const (
+	// FeatureForkCrossCheck cross-checks the rounds followed against the other nodes to detect forks, when asked to
+	FeatureForkCrossCheck Feature = "fork-cross-check"
+	// FeatureStoreRepair repairs the last rounds of the store after an unclean shutdown
+	FeatureStoreRepair Feature = "store-repair"
+	// FeaturePartialJournal keeps the partials of the rounds in flight across restarts of the beacon loop
+	FeaturePartialJournal Feature = "partial-journal"
+	// FeaturePartialAudit records the members whose partials were aggregated into each round, to audit the
+	// participation in the signatures and not only the membership of the group
+	FeaturePartialAudit Feature = "partial-audit"
+	// FeatureBeaconInjection accepts the beacons injected through the control port, to repair the chain store by hand
+	FeatureBeaconInjection Feature = "beacon-injection"
+	// FeatureAddressPersist saves the addresses announced by the members of the group, to reach them there after a
+	// restart
+	FeatureAddressPersist Feature = "address-persist"
+	// FeaturePartialRetry pushes again the partials which failed to reach a peer, until the end of their round
+	FeaturePartialRetry Feature = "partial-retry"
+	// FeatureChainPush pushes the new beacons to the downstream nodes configured, and accepts the beacons pushed to us
+	FeatureChainPush Feature = "chain-push"
+)
+
+type featureInfo struct {
+	description string
+	enabled     bool
+}
+
+var knownFeatures = map[Feature]featureInfo{
+	FeatureForkCrossCheck:  {"cross-check the rounds followed against the other nodes to detect forks", true},
+	FeatureStoreRepair:     {"repair the last rounds of the store after an unclean shutdown", true},
+	FeaturePartialJournal:  {"keep the partials of the rounds in flight across restarts", true},
+	FeaturePartialAudit:    {"record the members whose partials were aggregated into each round", false},
+	FeatureBeaconInjection: {"accept the beacons injected through the control port", false},
+	FeatureAddressPersist:  {"save the addresses announced by the members of the group across restarts", false},
+	FeaturePartialRetry:    {"push again the partials which failed to reach a peer until the end of their round", true},
+	FeatureChainPush:       {"push the new beacons downstream and accept the beacons pushed to us", true},
+}
+
+// The sources of the value of a feature, from the lowest precedence to the highest
+const (
+	featureSourceDefault = "default"
+	featureSourceConfig  = "config"
+	featureSourceRuntime = "runtime"
+)
+
+// ParseFeatureFlags parses feature flags given as "feature=on|off" for all the beacons or "beacon-id:feature=on|off"
+// for one of them, e.g. "evmnet:store-repair=off". The flags of a beacon take precedence over the ones for all the
+// beacons, which are under the empty beacon ID.
+func ParseFeatureFlags(specs []string) (map[string]map[Feature]bool, error) {
+	flags := make(map[string]map[Feature]bool)
+	for _, spec := range specs {
+		beaconID, flag := "", spec
+		if id, rest, found := strings.Cut(spec, ":"); found {
+			if id == "" {
+				return nil, fmt.Errorf("invalid feature flag %q, expected [beacon-id:]feature=on|off", spec)
+			}
+			beaconID, flag = common.GetCanonicalBeaconID(id), rest
+		}
+		name, value, found := strings.Cut(flag, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid feature flag %q, expected [beacon-id:]feature=on|off", spec)
+		}
+		if _, ok := knownFeatures[Feature(name)]; !ok {
+			return nil, fmt.Errorf("unknown feature %q, expected one of %s", name, strings.Join(featureNames(), ", "))
+		}
+		enabled, err := parseFeatureValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for feature %q: %w", name, err)
+		}
+		if flags[beaconID] == nil {
+			flags[beaconID] = make(map[Feature]bool)
+		}
+		flags[beaconID][Feature(name)] = enabled
+	}
+	return flags, nil
+}
+
+func parseFeatureValue(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "on":
+		return true, nil
+	case "off":
+		return false, nil
+	default:
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return false, fmt.Errorf("expected on or off, got %q", value)
+		}
+		return enabled, nil
+	}
+}
+
+// featureNames returns the names of the known features, sorted
+func featureNames() []string {
+	names := make([]string, 0, len(knownFeatures))
+	for f := range knownFeatures {
+		names = append(names, string(f))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// featureFlags holds the features turned on or off at runtime, which take precedence over the config until they are
+// reset or the daemon restarts. Its zero value is ready to use.
+type featureFlags struct {
+	sync.RWMutex
+	overrides map[Feature]bool
+}
+
+// feature returns whether the feature is enabled for the beacon, and where that comes from
+func (bp *BeaconProcess) feature(f Feature) (enabled bool, source string) {
+	bp.features.RLock()
+	enabled, ok := bp.features.overrides[f]
+	bp.features.RUnlock()
+	if ok {
+		return enabled, featureSourceRuntime
+	}
+
+	flags := bp.opts.features()
+	if enabled, ok := flags[bp.getBeaconID()][f]; ok {
+		return enabled, featureSourceConfig
+	}
+	if enabled, ok := flags[""][f]; ok {
+		return enabled, featureSourceConfig
+	}
+	return knownFeatures[f].enabled, featureSourceDefault
+}
+
+// featureEnabled returns whether the feature is enabled for the beacon
+func (bp *BeaconProcess) featureEnabled(f Feature) bool {
+	enabled, _ := bp.feature(f)
+	return enabled
+}
+
+// publishFeatures exports whether each feature is enabled for the beacon
+func (bp *BeaconProcess) publishFeatures() {
+	for _, name := range featureNames() {
+		metrics.FeatureEnabled(bp.getBeaconID(), name, bp.featureEnabled(Feature(name)))
+	}
+}
+
+// FeatureFlags turns a feature on or off, or back to its configured value, if the request names one, and lists the
+// features of the beacon
+func (bp *BeaconProcess) FeatureFlags(ctx context.Context, in *drand.FeatureFlagsRequest) (*drand.FeatureFlagsResponse, error) {
+	_, span := tracer.NewSpan(ctx, "bp.FeatureFlags")
+	defer span.End()
+
+	if name := Feature(in.GetName()); name != "" {
+		if _, ok := knownFeatures[name]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown feature %q, expected one of %s",
+				name, strings.Join(featureNames(), ", "))
+		}
+
+		bp.features.Lock()
+		if in.GetReset_() {
+			delete(bp.features.overrides, name)
+		} else {
+			if bp.features.overrides == nil {
+				bp.features.overrides = make(map[Feature]bool)
+			}
+			bp.features.overrides[name] = in.GetEnabled()
+		}
+		bp.features.Unlock()
+
+		enabled, source := bp.feature(name)
+		bp.log.Infow("feature flag changed", "feature", name, "enabled", enabled, "source", source)
+		bp.events.record(bp.opts.clock.Now(), eventFeatureChanged, fmt.Sprintf("%s: %t (%s)", name, enabled, source))
+		bp.publishFeatures()
+		bp.runPendingRepair(ctx)
+	}
+
+	resp := &drand.FeatureFlagsResponse{Metadata: bp.newMetadata()}
+	for _, name := range featureNames() {
+		enabled, source := bp.feature(Feature(name))
+		resp.Features = append(resp.Features, &drand.FeatureFlag{
+			Name:        name,
+			Description: knownFeatures[Feature(name)].description,
+			Enabled:     enabled,
+			Source:      source,
+		})
+	}
+	return resp, nil
+}
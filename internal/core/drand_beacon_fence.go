@@ -0,0 +1,87 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/drand/kyber"
+
+	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/chain"
+	"github.com/drand/drand/v2/internal/chain/beacon"
+)
+
+// pendingRepair holds the repair of a store which wasn't closed cleanly, put off until it is enabled. Its zero value
+// is ready to use.
+type pendingRepair struct {
+	sync.Mutex
+	repair func(context.Context) error
+}
+
+func (p *pendingRepair) set(repair func(context.Context) error) {
+	p.Lock()
+	defer p.Unlock()
+	p.repair = repair
+}
+
+func (p *pendingRepair) take() func(context.Context) error {
+	p.Lock()
+	defer p.Unlock()
+	repair := p.repair
+	p.repair = nil
+	return repair
+}
+
+// repairUncleanStore checks the last rounds of the store if it wasn't closed cleanly the last time it was used, and
+// deletes the invalid ones left by a torn write so that they are synced again. While the FeatureStoreRepair feature is
+// disabled, the repair is put off until it is enabled, and the store stays marked as unclean across restarts.
+func (bp *BeaconProcess) repairUncleanStore(ctx context.Context, store chain.Store, sch *crypto.Scheme, pub kyber.Point) error {
+	fence := bp.dbFence
+	if fence == nil || !fence.Unclean() {
+		return nil
+	}
+	repair := func(ctx context.Context) error {
+		return bp.repairStore(ctx, fence, store, sch, pub)
+	}
+	if !bp.featureEnabled(FeatureStoreRepair) {
+		bp.log.Warnw("the chain store wasn't closed cleanly, but its repair is disabled: it runs once enabled",
+			"feature", FeatureStoreRepair)
+		bp.storeRepair.set(repair)
+		return nil
+	}
+	return repair(ctx)
+}
+
+// runPendingRepair runs the repair of the store put off by repairUncleanStore, if any, once the FeatureStoreRepair
+// feature is enabled
+func (bp *BeaconProcess) runPendingRepair(ctx context.Context) {
+	if !bp.featureEnabled(FeatureStoreRepair) {
+		return
+	}
+	repair := bp.storeRepair.take()
+	if repair == nil {
+		return
+	}
+	if err := repair(ctx); err != nil {
+		bp.log.Errorw("unable to repair the chain store", "err", err)
+	}
+}
+
+func (bp *BeaconProcess) repairStore(ctx context.Context, fence *beacon.FencedStore, store chain.Store,
+	sch *crypto.Scheme, pub kyber.Point) error {
+	bp.log.Warnw("the chain store wasn't closed cleanly, checking its last rounds", "depth", beacon.DefaultRepairDepth)
+	deleted, err := beacon.RepairTail(ctx, bp.log, store, sch, pub, beacon.DefaultRepairDepth)
+	if err != nil {
+		return fmt.Errorf("unable to repair the chain store after an unclean shutdown: %w", err)
+	}
+	fence.MarkRepaired()
+	if len(deleted) == 0 {
+		bp.log.Infow("the last rounds of the chain store are valid")
+		return nil
+	}
+	detail := fmt.Sprintf("deleted rounds %d to %d after an unclean shutdown", deleted[len(deleted)-1], deleted[0])
+	bp.log.Warnw("repaired the chain store", "deleted", len(deleted), "from", deleted[len(deleted)-1])
+	bp.events.record(bp.opts.clock.Now(), eventStoreRepaired, detail)
+	return nil
+}
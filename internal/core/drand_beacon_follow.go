@@ -0,0 +1,148 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	public "github.com/drand/drand/v2/common/chain"
+	"github.com/drand/drand/v2/common/key"
+	"github.com/drand/drand/v2/common/log"
+	"github.com/drand/drand/v2/internal/net"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// FollowPeersRefreshInterval is how often a follow refreshes the peers it syncs from with the members of the group
+// of the chain
+const FollowPeersRefreshInterval = 10 * time.Minute
+
+// followPeers are the peers a follow syncs from: the nodes it was asked to follow at first, then the members of the
+// group of the chain which are up, refreshed periodically so that a sync lasting days survives the members joining
+// and leaving the group without being restarted
+type followPeers struct {
+	sync.Mutex
+	peers []net.Peer
+	// updated is notified when the peers changed, so that the sync running restarts with them
+	updated chan struct{}
+}
+
+func newFollowPeers(peers []net.Peer) *followPeers {
+	return &followPeers{peers: peers, updated: make(chan struct{}, 1)}
+}
+
+func (f *followPeers) get() []net.Peer {
+	f.Lock()
+	defer f.Unlock()
+	return f.peers
+}
+
+// set replaces the peers, and notifies the sync running when they changed, whatever their order. It returns whether
+// they changed.
+func (f *followPeers) set(peers []net.Peer) bool {
+	f.Lock()
+	defer f.Unlock()
+	if samePeers(f.peers, peers) {
+		return false
+	}
+	f.peers = peers
+	select {
+	case f.updated <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// samePeers tells whether the two lists hold the same addresses
+func samePeers(a, b []net.Peer) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	addrs := make(map[string]int, len(a))
+	for _, peer := range a {
+		addrs[peer.Address()]++
+	}
+	for _, peer := range b {
+		if addrs[peer.Address()] == 0 {
+			return false
+		}
+		addrs[peer.Address()]--
+	}
+	return true
+}
+
+// refreshFollowPeers refreshes the peers of the follow at each interval, until the context is canceled
+func (bp *BeaconProcess) refreshFollowPeers(ctx context.Context, l log.Logger, fp *followPeers, info *public.Info) {
+	ticker := bp.opts.clock.NewTicker(FollowPeersRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.Chan():
+		}
+		peers, err := bp.followGroupPeers(ctx, fp.get(), info)
+		if err != nil {
+			l.Warnw("unable to refresh the peers to follow, keeping the current ones", "err", err)
+			continue
+		}
+		if fp.set(peers) {
+			l.Infow("refreshed the peers to follow from the group", "peers", len(peers))
+		}
+	}
+}
+
+// followGroupPeers fetches the group of the chain from the first of the peers serving it, and returns its members
+// which serve the chain, ourselves excluded. The group must be the one of the chain followed.
+func (bp *BeaconProcess) followGroupPeers(ctx context.Context, peers []net.Peer, info *public.Info) ([]net.Peer, error) {
+	bp.state.RLock()
+	privGateway := bp.privGateway
+	self := bp.priv.Public.Address()
+	metadata := &drand.Metadata{BeaconID: bp.beaconID, NodeVersion: bp.version.ToProto()}
+	bp.state.RUnlock()
+
+	errs := &net.PeerErrors{Op: "get the group"}
+	var group *key.Group
+	for _, peer := range peers {
+		resp, err := privGateway.GroupMembership(ctx, peer, &drand.GroupMembershipRequest{Metadata: metadata})
+		if err != nil {
+			errs.Add(peer.Address(), net.PeerReason(err), err)
+			continue
+		}
+		g, err := key.GroupFromProto(resp.GetCurrent().GetGroup(), nil)
+		if err != nil {
+			errs.Add(peer.Address(), net.PeerInvalid, err)
+			continue
+		}
+		if !bytes.Equal(public.NewChainInfo(g).Hash(), info.Hash()) {
+			errs.Add(peer.Address(), net.PeerBadHash, errors.New("the group isn't the one of the chain followed"))
+			continue
+		}
+		group = g
+		break
+	}
+	if group == nil {
+		return nil, errs
+	}
+
+	// the members whose beacon is stopped, or which moved to another chain, are dropped
+	var up []net.Peer
+	for _, node := range group.Nodes {
+		if node.Address() == self {
+			continue
+		}
+		ci, err := privGateway.ChainInfo(ctx, node, &drand.ChainInfoRequest{Metadata: metadata})
+		if err != nil {
+			continue
+		}
+		if nodeInfo, err := public.InfoFromProto(ci); err != nil || !bytes.Equal(nodeInfo.Hash(), info.Hash()) {
+			continue
+		}
+		up = append(up, node)
+	}
+	if len(up) == 0 {
+		return nil, errors.New("none of the members of the group serves the chain")
+	}
+	return up, nil
+}
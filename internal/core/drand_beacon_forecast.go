@@ -0,0 +1,361 @@
+package core
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/key"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/internal/net"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// ReshareForecastFileName is the file of the beacon folder keeping the date of the next reshare forecast by its
+// coordinator
+const ReshareForecastFileName = "reshare_forecast.json"
+
+// reshareForecastInterval is how often the forecast is checked against the reminders and the TLS certificate
+const reshareForecastInterval = 10 * time.Minute
+
+// maxForecastClockSkew is how far ahead of the clock of the node the sequence of an announced forecast can be. The
+// sequence is the time the forecast was made at on its coordinator, so a member can't announce a forecast which the
+// next forecasts of the others couldn't replace.
+const maxForecastClockSkew = 5 * time.Minute
+
+// reshareReminders are how long before the forecast reshare the operator is reminded of it, once each
+var reshareReminders = []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour}
+
+// reshareForecast is the date the coordinator of the group intends to run the next reshare at. A date of 0 is a
+// cleared forecast, kept so that the older announcements are still refused.
+type reshareForecast struct {
+	Coordinator string `json:"coordinator"`
+	At          int64  `json:"at"`
+	Note        string `json:"note,omitempty"`
+	Sequence    uint64 `json:"sequence"`
+	Signature   []byte `json:"signature"`
+}
+
+// forecastWatch reminds the operator of the forecast reshare as it approaches, and checks that the TLS certificate
+// of the node doesn't expire before it. Its zero value is ready to use.
+type forecastWatch struct {
+	sync.Mutex
+	stop context.CancelFunc
+	// the forecast watched, by its sequence, and the number of its reminders already sent
+	sequence uint64
+	reminded int
+	// the expiry of the TLS certificate served at the address of the node, zero if it couldn't be checked
+	tlsExpiry time.Time
+	tlsWarned bool
+}
+
+// ForecastReshare registers the date this node, as the coordinator, intends to run the next reshare of the group at,
+// and announces it to all the members so that they can prepare for it. Any member can forecast the next reshare,
+// the most recent forecast replacing the previous one, as long as the clocks of the members roughly agree. The forecast is cleared the same way if asked to, and only
+// returned when no date is given.
+func (bp *BeaconProcess) ForecastReshare(ctx context.Context, in *drand.ForecastReshareRequest) (*drand.ForecastReshareResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "bp.ForecastReshare")
+	defer span.End()
+
+	resp := &drand.ForecastReshareResponse{Failed: make(map[string]string), Metadata: bp.newMetadata()}
+	if in.GetAt() == 0 && !in.GetClear() {
+		bp.state.RLock()
+		resp.Forecast = bp.reshareForecastStatus()
+		bp.state.RUnlock()
+		return resp, nil
+	}
+
+	now := bp.opts.clock.Now()
+	if !in.GetClear() && in.GetAt() <= now.Unix() {
+		return nil, fmt.Errorf("the reshare must be forecast in the future, not on %s",
+			time.Unix(in.GetAt(), 0).UTC().Format(time.RFC3339))
+	}
+
+	bp.state.Lock()
+	group := bp.group
+	self := bp.priv.Public.Addr
+	if group == nil {
+		bp.state.Unlock()
+		return nil, errors.New("this node isn't part of a group, there is no reshare to forecast")
+	}
+	if group.Find(bp.priv.Public) == nil {
+		bp.state.Unlock()
+		return nil, fmt.Errorf("%s is not part of the group", self)
+	}
+
+	forecast := &reshareForecast{Coordinator: self, Sequence: uint64(now.UnixNano())}
+	// our forecast must still replace the registered one if our clock is behind the one of its coordinator
+	if bp.forecast != nil && bp.forecast.Sequence >= forecast.Sequence {
+		forecast.Sequence = bp.forecast.Sequence + 1
+	}
+	if !in.GetClear() {
+		forecast.At = in.GetAt()
+		forecast.Note = in.GetNote()
+	}
+	msg := forecastMessage(bp.getBeaconID(), forecast)
+	sig, err := bp.priv.Scheme().AuthScheme.Sign(bp.priv.Key, msg)
+	if err != nil {
+		bp.state.Unlock()
+		return nil, err
+	}
+	forecast.Signature = sig
+	bp.setReshareForecast(forecast)
+	resp.Forecast = bp.reshareForecastStatus()
+	bp.state.Unlock()
+
+	announcement := &drand.ReshareForecastAnnouncement{
+		Coordinator: forecast.Coordinator,
+		At:          forecast.At,
+		Note:        forecast.Note,
+		Sequence:    forecast.Sequence,
+		Signature:   forecast.Signature,
+		Metadata:    bp.newMetadata(),
+	}
+	for _, node := range group.Nodes {
+		if node.Address() == self {
+			continue
+		}
+		if err := bp.privGateway.AnnounceReshareForecast(ctx, net.CreatePeer(node.Address()), announcement); err != nil {
+			bp.log.Warnw("Unable to announce the reshare forecast", "to", node.Address(), "err", err)
+			resp.Failed[node.Address()] = err.Error()
+			continue
+		}
+		resp.Reached = append(resp.Reached, node.Address())
+	}
+
+	bp.log.Infow("Announced the reshare forecast to the group", "at", forecast.At,
+		"reached", len(resp.Reached), "failed", len(resp.Failed))
+	return resp, nil
+}
+
+// AnnounceReshareForecast registers the date of the next reshare announced by its coordinator
+func (bp *BeaconProcess) AnnounceReshareForecast(ctx context.Context, in *drand.ReshareForecastAnnouncement) (*drand.Empty, error) {
+	_, span := tracer.NewSpan(ctx, "bp.AnnounceReshareForecast")
+	defer span.End()
+
+	bp.state.Lock()
+	defer bp.state.Unlock()
+
+	if bp.group == nil {
+		return nil, errors.New("no group yet")
+	}
+	forecast := &reshareForecast{
+		Coordinator: in.GetCoordinator(),
+		At:          in.GetAt(),
+		Note:        in.GetNote(),
+		Sequence:    in.GetSequence(),
+		Signature:   in.GetSignature(),
+	}
+	if err := bp.verifyReshareForecast(bp.group, forecast); err != nil {
+		return nil, err
+	}
+	if limit := bp.opts.clock.Now().Add(maxForecastClockSkew); forecast.Sequence > uint64(limit.UnixNano()) {
+		return nil, fmt.Errorf("reshare forecast from %s made in the future, on %s", forecast.Coordinator,
+			time.Unix(0, int64(forecast.Sequence)).UTC().Format(time.RFC3339))
+	}
+	if bp.forecast != nil && bp.forecast.Sequence >= forecast.Sequence {
+		return nil, fmt.Errorf("stale reshare forecast from %s: sequence %d, already at %d",
+			forecast.Coordinator, forecast.Sequence, bp.forecast.Sequence)
+	}
+
+	bp.setReshareForecast(forecast)
+	bp.log.Infow("Coordinator announced a reshare forecast", "coordinator", forecast.Coordinator, "at", forecast.At)
+	return &drand.Empty{Metadata: bp.newMetadata()}, nil
+}
+
+// setReshareForecast must be called with the state lock held
+func (bp *BeaconProcess) setReshareForecast(forecast *reshareForecast) {
+	bp.forecast = forecast
+	if err := bp.saveReshareForecast(); err != nil {
+		// the forecast is registered anyway, it only won't survive a restart
+		bp.log.Warnw("Unable to save the reshare forecast", "err", err)
+	}
+
+	detail := fmt.Sprintf("reshare forecast cleared by %s", forecast.Coordinator)
+	if forecast.At != 0 {
+		detail = fmt.Sprintf("next reshare on %s by %s", time.Unix(forecast.At, 0).UTC().Format(time.RFC3339),
+			forecast.Coordinator)
+	}
+	bp.events.record(bp.opts.clock.Now(), eventReshareForecast, detail)
+}
+
+// verifyReshareForecast checks that the forecast was signed by the member of the group it names as the coordinator
+func (bp *BeaconProcess) verifyReshareForecast(group *key.Group, forecast *reshareForecast) error {
+	var coordinator *key.Node
+	for _, n := range group.Nodes {
+		if n.Address() == forecast.Coordinator {
+			coordinator = n
+			break
+		}
+	}
+	if coordinator == nil {
+		return fmt.Errorf("%s is not part of the group", forecast.Coordinator)
+	}
+	msg := forecastMessage(bp.getBeaconID(), forecast)
+	if err := group.Scheme.AuthScheme.Verify(coordinator.Key, msg, forecast.Signature); err != nil {
+		return fmt.Errorf("invalid reshare forecast signature from %s: %w", forecast.Coordinator, err)
+	}
+	return nil
+}
+
+// reshareForecastStatus returns the forecast reshare, nil if there is none or its date passed. It must be called
+// with the state lock held.
+func (bp *BeaconProcess) reshareForecastStatus() *drand.ReshareForecast {
+	f := bp.forecast
+	if f == nil || f.At <= bp.opts.clock.Now().Unix() {
+		return nil
+	}
+	status := &drand.ReshareForecast{
+		Coordinator:  f.Coordinator,
+		At:           f.At,
+		Note:         f.Note,
+		RegisteredAt: time.Unix(0, int64(f.Sequence)).Unix(),
+	}
+
+	bp.forecastWatch.Lock()
+	defer bp.forecastWatch.Unlock()
+	if bp.forecastWatch.sequence == f.Sequence && !bp.forecastWatch.tlsExpiry.IsZero() {
+		status.TlsExpiry = bp.forecastWatch.tlsExpiry.Unix()
+	}
+	return status
+}
+
+// startForecastWatch checks the forecast reshare now and at each interval, until the beacon is stopped
+func (bp *BeaconProcess) startForecastWatch() {
+	bp.forecastWatch.Lock()
+	defer bp.forecastWatch.Unlock()
+	if bp.forecastWatch.stop != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	bp.forecastWatch.stop = cancel
+
+	go func() {
+		ticker := bp.opts.clock.NewTicker(reshareForecastInterval)
+		defer ticker.Stop()
+		for {
+			bp.checkReshareForecast(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.Chan():
+			}
+		}
+	}()
+}
+
+// stopForecastWatch stops checking the forecast reshare
+func (bp *BeaconProcess) stopForecastWatch() {
+	bp.forecastWatch.Lock()
+	defer bp.forecastWatch.Unlock()
+
+	if bp.forecastWatch.stop != nil {
+		bp.forecastWatch.stop()
+		bp.forecastWatch.stop = nil
+	}
+}
+
+// checkReshareForecast reminds the operator of the forecast reshare once for each of the reshareReminders it gets
+// closer than, and warns when the TLS certificate of the node expires before it. The longterm keys of drand don't
+// expire, the TLS certificate is the only material to renew ahead of the reshare.
+func (bp *BeaconProcess) checkReshareForecast(ctx context.Context) {
+	bp.state.RLock()
+	forecast := bp.forecast
+	self := bp.priv.Public.Addr
+	bp.state.RUnlock()
+
+	now := bp.opts.clock.Now()
+	if forecast == nil || forecast.At <= now.Unix() {
+		return
+	}
+	at := time.Unix(forecast.At, 0)
+
+	// we don't hold the lock while dialing the node
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	expiry, err := net.CertificateExpiry(ctx, self)
+	cancel()
+	if err != nil {
+		bp.log.Debugw("Unable to check the TLS certificate of the node", "addr", self, "err", err)
+	}
+
+	bp.forecastWatch.Lock()
+	defer bp.forecastWatch.Unlock()
+	w := &bp.forecastWatch
+	if w.sequence != forecast.Sequence {
+		w.sequence = forecast.Sequence
+		w.reminded = 0
+		w.tlsWarned = false
+	}
+
+	// only the closest reminder is sent when several are due, e.g. for a reshare forecast the day before
+	for i := len(reshareReminders) - 1; i >= w.reminded; i-- {
+		if at.Sub(now) <= reshareReminders[i] {
+			bp.events.record(now, eventReshareApproaching, fmt.Sprintf("the reshare forecast by %s is in %s",
+				forecast.Coordinator, at.Sub(now).Round(time.Minute)))
+			bp.log.Infow("The forecast reshare is approaching", "coordinator", forecast.Coordinator,
+				"at", at.UTC().Format(time.RFC3339))
+			w.reminded = i + 1
+			break
+		}
+	}
+
+	if err == nil {
+		w.tlsExpiry = expiry
+	}
+	if !w.tlsExpiry.IsZero() && w.tlsExpiry.Before(at) && !w.tlsWarned {
+		detail := fmt.Sprintf("the TLS certificate of %s expires on %s, before the reshare forecast on %s", self,
+			w.tlsExpiry.UTC().Format(time.RFC3339), at.UTC().Format(time.RFC3339))
+		bp.events.record(now, eventCertificateExpiring, detail)
+		bp.log.Warnw("The TLS certificate of the node expires before the forecast reshare",
+			"expiry", w.tlsExpiry.UTC().Format(time.RFC3339), "reshare", at.UTC().Format(time.RFC3339))
+		w.tlsWarned = true
+	}
+}
+
+// reshareForecastFile returns the file keeping the reshare forecast of the beacon
+func (bp *BeaconProcess) reshareForecastFile() string {
+	beaconID := common.GetCanonicalBeaconID(bp.getBeaconID())
+	return path.Join(bp.opts.BeaconFolderMB(beaconID), beaconID, ReshareForecastFileName)
+}
+
+// saveReshareForecast must be called with the state lock held
+func (bp *BeaconProcess) saveReshareForecast() error {
+	buff, err := json.MarshalIndent(bp.forecast, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(bp.reshareForecastFile(), buff, 0o600)
+}
+
+// loadReshareForecast registers again the reshare forecast before the daemon restarted, checking its signature
+// against the group. It must be called with the state lock held.
+func (bp *BeaconProcess) loadReshareForecast(group *key.Group) error {
+	buff, err := os.ReadFile(bp.reshareForecastFile())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var forecast reshareForecast
+	if err := json.Unmarshal(buff, &forecast); err != nil {
+		return fmt.Errorf("invalid reshare forecast file: %w", err)
+	}
+	if err := bp.verifyReshareForecast(group, &forecast); err != nil {
+		return err
+	}
+	bp.forecast = &forecast
+	return nil
+}
+
+func forecastMessage(beaconID string, forecast *reshareForecast) []byte {
+	msg := []byte("drand-reshare-forecast:" + beaconID + ":" + forecast.Coordinator + ":" + forecast.Note + ":")
+	msg = binary.BigEndian.AppendUint64(msg, uint64(forecast.At))
+	return binary.BigEndian.AppendUint64(msg, forecast.Sequence)
+}
@@ -0,0 +1,77 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/drand/drand/v2/common"
+	public "github.com/drand/drand/v2/common/chain"
+	"github.com/drand/drand/v2/common/client"
+	"github.com/drand/drand/v2/common/log"
+	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/chain/beacon"
+	"github.com/drand/drand/v2/internal/metrics"
+	"github.com/drand/drand/v2/internal/net"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// crossCheckCallback returns a callback checking that all the given peers serve the same signature as the one we
+// stored for each new round we follow. While catching up, only the live rounds are checked, not the whole history.
+func (bp *BeaconProcess) crossCheckCallback(ctx context.Context, logger log.Logger, peers []net.Peer, info *public.Info,
+	sch *crypto.Scheme) beacon.CallbackFunc {
+	beaconID := common.GetCanonicalBeaconID(info.ID)
+	detector := client.NewForkDetector(func(f *client.Fork) {
+		logger.Errorw("FORK DETECTED: different valid signatures were served for the same round, "+
+			"the key of the network might be compromised", "round", f.Round, "fork", f.String())
+		metrics.ForkDetected(beaconID)
+		bp.events.record(bp.opts.clock.Now(), eventForkDetected, f.String())
+	})
+
+	return func(b *common.Beacon, closed bool) {
+		if closed || !bp.featureEnabled(FeatureForkCrossCheck) {
+			return
+		}
+		if b.Round+1 < common.CurrentRound(bp.opts.clock.Now().Unix(), info.Period, info.GenesisTime) {
+			return
+		}
+		detector.Observe("local store", common.Round(b.Round), b.Signature)
+		go bp.crossCheck(ctx, logger, detector, peers, info, sch, b.Round)
+	}
+}
+
+// crossCheck fetches the given round from all the given peers and feeds the valid answers to the detector
+func (bp *BeaconProcess) crossCheck(ctx context.Context, logger log.Logger, detector *client.ForkDetector,
+	peers []net.Peer, info *public.Info, sch *crypto.Scheme, round uint64) {
+	request := &drand.PublicRandRequest{
+		Round:    round,
+		Metadata: &drand.Metadata{BeaconID: info.ID, ChainHash: info.Hash(), NodeVersion: bp.version.ToProto()},
+	}
+
+	var wg sync.WaitGroup
+	for _, peer := range peers {
+		wg.Add(1)
+		go func(peer net.Peer) {
+			defer wg.Done()
+			tctx, cancel := context.WithTimeout(ctx, info.Period)
+			defer cancel()
+
+			resp, err := bp.privGateway.PublicRand(tctx, peer, request)
+			if err != nil {
+				logger.Debugw("unable to cross-check round", "round", round, "with", peer.Address(), "err", err)
+				return
+			}
+			if resp.GetRound() != round {
+				return
+			}
+			if err := sch.VerifyBeacon(resp, info.PublicKey); err != nil {
+				// an invalid signature isn't a fork, the peer is just misbehaving
+				logger.Warnw("invalid beacon while cross-checking", "round", round, "from", peer.Address(), "err", err)
+				return
+			}
+			detector.Observe(peer.Address(), common.Round(round), resp.GetSignature())
+		}(peer)
+	}
+	wg.Wait()
+	logger.Debugw(fmt.Sprintf("cross-checked round %d", round), "peers", len(peers))
+}
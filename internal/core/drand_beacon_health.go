@@ -0,0 +1,119 @@
+package core
+
+import (
+	"context"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/internal/dkg"
+	"github.com/drand/drand/v2/internal/metrics"
+)
+
+// The health score sums up the health of a beacon from 0 to 100, so that alerting only needs a single threshold:
+//   - up to 40 points for the lag of the chain store, lost linearly from 1 to maxHealthLag rounds behind,
+//   - up to 30 points for the ratio of the members of the group reachable at the last connectivity check,
+//   - up to 30 points for the partials sent to the other members, lost linearly as the number of members we failed
+//     to send partials to during the last minute approaches the number of failures the group can tolerate,
+//   - minus dkgFailurePenalty points when the last DKG failed, timed out or was aborted.
+//
+// A node without a running beacon has a score of 0.
+const (
+	lagHealthPoints          = 40
+	connectivityHealthPoints = 30
+	partialsHealthPoints     = 30
+	dkgFailurePenalty        = 20
+	maxHealthLag             = 10
+)
+
+// healthInputs are the indicators the health score is computed from
+type healthInputs struct {
+	running bool
+	// lag is the number of rounds the chain store is behind the current round
+	lag uint64
+	// reachable and peers are the number of members reachable at the last check, and the number of members checked
+	reachable, peers int
+	// failing is the number of members we failed to send partials to, tolerated the number of failures the group
+	// can tolerate while still reaching the threshold
+	failing, tolerated int
+	dkgFailed          bool
+}
+
+func computeHealthScore(in *healthInputs) uint32 {
+	if !in.running {
+		return 0
+	}
+
+	var score float64
+	switch {
+	case in.lag <= 1:
+		score += lagHealthPoints
+	case in.lag < maxHealthLag:
+		score += lagHealthPoints * float64(maxHealthLag-in.lag) / float64(maxHealthLag-1)
+	}
+
+	if in.peers == 0 {
+		// nothing was checked yet, or we are alone in the group
+		score += connectivityHealthPoints
+	} else {
+		score += connectivityHealthPoints * float64(in.reachable) / float64(in.peers)
+	}
+
+	switch {
+	case in.failing == 0:
+		score += partialsHealthPoints
+	case in.failing < in.tolerated:
+		score += partialsHealthPoints * float64(in.tolerated-in.failing) / float64(in.tolerated)
+	}
+
+	if in.dkgFailed {
+		score -= dkgFailurePenalty
+	}
+	if score < 0 {
+		return 0
+	}
+	return uint32(score)
+}
+
+// healthScore returns the health score of the beacon. It must be called with the state lock held.
+func (bp *BeaconProcess) healthScore(ctx context.Context) uint32 {
+	if bp.beacon == nil || bp.group == nil {
+		return 0
+	}
+	last, err := bp.beacon.Store().Last(ctx)
+	if err != nil {
+		return 0
+	}
+
+	in := &healthInputs{running: true}
+	expected := common.CurrentRound(bp.opts.clock.Now().Unix(), bp.group.Period, bp.group.GenesisTime)
+	if expected > last.GetRound() {
+		in.lag = expected - last.GetRound()
+	}
+
+	conns, _, _ := bp.cachedConnectivity(bp.groupPeers())
+	in.peers = len(conns)
+	for _, ok := range conns {
+		if ok {
+			in.reachable++
+		}
+	}
+
+	in.failing = bp.beacon.FailingNodes()
+	in.tolerated = bp.group.Len() - bp.group.Threshold
+
+	if state, ok := metrics.DKGState(bp.getBeaconID()); ok {
+		switch dkg.Status(state) {
+		case dkg.Failed, dkg.TimedOut, dkg.Aborted:
+			in.dkgFailed = true
+		}
+	}
+	return computeHealthScore(in)
+}
+
+// publishHealth updates the health score metric of the beacon
+func (bp *BeaconProcess) publishHealth(ctx context.Context) {
+	bp.state.RLock()
+	score := bp.healthScore(ctx)
+	bp.state.RUnlock()
+
+	metrics.HealthScore(bp.getBeaconID(), score)
+}
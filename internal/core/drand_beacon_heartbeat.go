@@ -0,0 +1,299 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/key"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/internal/net"
+	"github.com/drand/drand/v2/protobuf/drand"
+	"github.com/drand/kyber/share"
+)
+
+// heartbeats holds the partial signatures of the heartbeat being signed and the last one the group signed.
+// Its zero value is ready to use.
+type heartbeats struct {
+	sync.Mutex
+	pending *pendingHeartbeat
+	latest  *drand.HeartbeatPacket
+	stop    context.CancelFunc
+}
+
+type pendingHeartbeat struct {
+	packet   *drand.HeartbeatPacket
+	partials map[int][]byte
+}
+
+// heartbeatMessage returns the message the group threshold signs for a heartbeat
+func heartbeatMessage(beaconID string, hb *drand.HeartbeatPacket) []byte {
+	h := sha256.New()
+	h.Write([]byte("drand-heartbeat:" + common.GetCanonicalBeaconID(beaconID) + ":"))
+	h.Write(binary.BigEndian.AppendUint64(nil, hb.GetIndex()))
+	h.Write(binary.BigEndian.AppendUint64(nil, hb.GetTipRound()))
+	h.Write(hb.GetTipSignature())
+	h.Write(hb.GetGroupHash())
+	return h.Sum(nil)
+}
+
+// heartbeatIndex returns the number of heartbeat periods elapsed since the genesis of the chain at the given time
+func heartbeatIndex(now time.Time, genesis int64, period time.Duration) uint64 {
+	elapsed := now.Unix() - genesis
+	if elapsed < 0 {
+		return 0
+	}
+	return uint64(elapsed / int64(period.Seconds()))
+}
+
+// expectedHeartbeat returns the unsigned heartbeat of the given index, committing to the round produced one period
+// before it, as found in our chain store. The caller must hold the state lock.
+func (bp *BeaconProcess) expectedHeartbeat(ctx context.Context, index uint64) (*drand.HeartbeatPacket, error) {
+	at := bp.group.GenesisTime + int64(index)*int64(bp.opts.heartbeatPeriod.Seconds())
+	tipRound := common.CurrentRound(at, bp.group.Period, bp.group.GenesisTime)
+	if tipRound <= 1 {
+		return nil, errors.New("the chain is too young to sign a heartbeat")
+	}
+	tipRound--
+
+	tip, err := bp.beacon.Store().Get(ctx, common.Round(tipRound))
+	if err != nil {
+		return nil, fmt.Errorf("round %d committed to by heartbeat %d isn't available: %w", tipRound, index, err)
+	}
+	return &drand.HeartbeatPacket{
+		Index:        index,
+		Time:         at,
+		TipRound:     tipRound,
+		TipSignature: tip.GetSignature(),
+		GroupHash:    bp.group.Hash(),
+	}, nil
+}
+
+// startHeartbeats signs a heartbeat with the rest of the group at each heartbeat period, until the beacon is stopped.
+func (bp *BeaconProcess) startHeartbeats() {
+	period := bp.opts.heartbeatPeriod
+	if period < time.Second {
+		return
+	}
+
+	bp.heartbeats.Lock()
+	defer bp.heartbeats.Unlock()
+	if bp.heartbeats.stop != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	bp.heartbeats.stop = cancel
+
+	go func() {
+		for {
+			bp.state.RLock()
+			group := bp.group
+			bp.state.RUnlock()
+			if group == nil {
+				return
+			}
+
+			index := heartbeatIndex(bp.opts.clock.Now(), group.GenesisTime, period) + 1
+			at := time.Unix(group.GenesisTime+int64(index)*int64(period.Seconds()), 0)
+			select {
+			case <-ctx.Done():
+				return
+			case <-bp.opts.clock.After(at.Sub(bp.opts.clock.Now())):
+			}
+
+			if err := bp.signHeartbeat(ctx, index); err != nil {
+				bp.log.Warnw("Unable to sign heartbeat", "index", index, "err", err)
+			}
+		}
+	}()
+}
+
+// stopHeartbeats stops signing heartbeats
+func (bp *BeaconProcess) stopHeartbeats() {
+	bp.heartbeats.Lock()
+	defer bp.heartbeats.Unlock()
+
+	if bp.heartbeats.stop != nil {
+		bp.heartbeats.stop()
+		bp.heartbeats.stop = nil
+	}
+}
+
+// signHeartbeat signs our partial of the given heartbeat and sends it to the other members of the group
+func (bp *BeaconProcess) signHeartbeat(ctx context.Context, index uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "bp.signHeartbeat")
+	defer span.End()
+
+	bp.state.RLock()
+	if bp.pause.paused() {
+		bp.state.RUnlock()
+		bp.log.Debugw("not signing heartbeat partial, this node is paused", "index", index)
+		return nil
+	}
+	if bp.beacon == nil || bp.group == nil || bp.share == nil {
+		bp.state.RUnlock()
+		return errors.New("this node isn't running a beacon")
+	}
+	hb, err := bp.expectedHeartbeat(ctx, index)
+	if err != nil {
+		bp.state.RUnlock()
+		return err
+	}
+	msg := heartbeatMessage(bp.getBeaconID(), hb)
+	partial, err := bp.group.Scheme.ThresholdScheme.Sign(bp.share.PrivateShare(), msg)
+	if err != nil {
+		bp.state.RUnlock()
+		return err
+	}
+	bp.addHeartbeatPartial(hb, msg, partial, bp.group, bp.share.PubPoly())
+
+	packet := &drand.PartialHeartbeatPacket{
+		Index:        hb.GetIndex(),
+		TipRound:     hb.GetTipRound(),
+		TipSignature: hb.GetTipSignature(),
+		GroupHash:    hb.GetGroupHash(),
+		PartialSig:   partial,
+		Metadata:     bp.newMetadata(),
+	}
+	nodes := bp.group.Nodes
+	self := bp.priv.Public.Address()
+	bp.state.RUnlock()
+
+	sendToGroup(nodes, self, func(peer net.Peer) {
+		if err := bp.privGateway.PartialHeartbeat(ctx, peer, packet); err != nil {
+			bp.log.Debugw("Unable to send heartbeat partial", "to", peer.Address(), "index", index, "err", err)
+		}
+	})
+	return nil
+}
+
+// sendToGroup calls send concurrently for each of the given nodes but ourself, and waits for all of them to return
+func sendToGroup(nodes []*key.Node, self string, send func(net.Peer)) {
+	var wg sync.WaitGroup
+	for _, node := range nodes {
+		if node.Address() == self {
+			continue
+		}
+		wg.Add(1)
+		go func(node *key.Node) {
+			defer wg.Done()
+			send(net.CreatePeer(node.Address()))
+		}(node)
+	}
+	wg.Wait()
+}
+
+// PartialHeartbeat receives the partial signature of a heartbeat from another member of the group
+func (bp *BeaconProcess) PartialHeartbeat(ctx context.Context, in *drand.PartialHeartbeatPacket) (*drand.Empty, error) {
+	ctx, span := tracer.NewSpan(ctx, "bp.PartialHeartbeat")
+	defer span.End()
+
+	bp.state.RLock()
+	defer bp.state.RUnlock()
+
+	if bp.opts.heartbeatPeriod < time.Second {
+		return nil, errors.New("heartbeats are disabled on this node")
+	}
+	if bp.beacon == nil || bp.group == nil || bp.share == nil {
+		return nil, errors.New("DKG not finished yet")
+	}
+
+	// we accept the partials of the current heartbeat and, to account for clock drifts, of its neighbours
+	current := heartbeatIndex(bp.opts.clock.Now(), bp.group.GenesisTime, bp.opts.heartbeatPeriod)
+	if in.GetIndex()+1 < current || in.GetIndex() > current+1 {
+		return nil, fmt.Errorf("heartbeat %d is too far from the current one %d", in.GetIndex(), current)
+	}
+
+	hb, err := bp.expectedHeartbeat(ctx, in.GetIndex())
+	if err != nil {
+		return nil, err
+	}
+	if in.GetTipRound() != hb.GetTipRound() ||
+		!bytes.Equal(in.GetTipSignature(), hb.GetTipSignature()) ||
+		!bytes.Equal(in.GetGroupHash(), hb.GetGroupHash()) {
+		return nil, fmt.Errorf("heartbeat %d doesn't match our chain tip or group", in.GetIndex())
+	}
+
+	msg := heartbeatMessage(bp.getBeaconID(), hb)
+	pubPoly := bp.share.PubPoly()
+	if err := bp.group.Scheme.ThresholdScheme.VerifyPartial(pubPoly, msg, in.GetPartialSig()); err != nil {
+		return nil, fmt.Errorf("invalid heartbeat partial signature: %w", err)
+	}
+	bp.addHeartbeatPartial(hb, msg, in.GetPartialSig(), bp.group, pubPoly)
+
+	return &drand.Empty{Metadata: bp.newMetadata()}, nil
+}
+
+// addHeartbeatPartial records a valid partial signature of the given heartbeat, and aggregates them once there are
+// enough of them
+func (bp *BeaconProcess) addHeartbeatPartial(hb *drand.HeartbeatPacket, msg, partial []byte,
+	group *key.Group, pubPoly *share.PubPoly) {
+	hbs := &bp.heartbeats
+	hbs.Lock()
+	defer hbs.Unlock()
+
+	if hbs.latest != nil && hbs.latest.GetIndex() >= hb.GetIndex() {
+		return
+	}
+	if hbs.pending == nil || hbs.pending.packet.GetIndex() < hb.GetIndex() {
+		hbs.pending = &pendingHeartbeat{packet: hb, partials: make(map[int][]byte)}
+	} else if hbs.pending.packet.GetIndex() > hb.GetIndex() {
+		return
+	}
+
+	sch := group.Scheme.ThresholdScheme
+	idx, err := sch.IndexOf(partial)
+	if err != nil {
+		return
+	}
+	hbs.pending.partials[idx] = partial
+	if len(hbs.pending.partials) < group.Threshold {
+		return
+	}
+
+	partials := make([][]byte, 0, len(hbs.pending.partials))
+	for _, p := range hbs.pending.partials {
+		partials = append(partials, p)
+	}
+	sig, err := sch.Recover(pubPoly, msg, partials, group.Threshold, group.Len())
+	if err == nil {
+		err = sch.VerifyRecovered(pubPoly.Commit(), msg, sig)
+	}
+	if err != nil {
+		bp.log.Errorw("Unable to aggregate heartbeat", "index", hb.GetIndex(), "err", err)
+		return
+	}
+
+	signed := proto.Clone(hbs.pending.packet).(*drand.HeartbeatPacket)
+	signed.Signature = sig
+	hbs.latest = signed
+	hbs.pending = nil
+	bp.log.Infow("Group signed heartbeat", "index", signed.GetIndex(), "tipRound", signed.GetTipRound())
+}
+
+// Heartbeat returns the last heartbeat signed by the group
+func (bp *BeaconProcess) Heartbeat(ctx context.Context, _ *drand.HeartbeatRequest) (*drand.HeartbeatPacket, error) {
+	_, span := tracer.NewSpan(ctx, "bp.Heartbeat")
+	defer span.End()
+
+	bp.heartbeats.Lock()
+	latest := bp.heartbeats.latest
+	bp.heartbeats.Unlock()
+	if latest == nil {
+		return nil, status.Error(codes.NotFound, "no heartbeat has been signed by the group yet")
+	}
+
+	hb := proto.Clone(latest).(*drand.HeartbeatPacket)
+	hb.Metadata = bp.newMetadata()
+	return hb, nil
+}
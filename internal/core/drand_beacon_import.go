@@ -0,0 +1,231 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/drand/drand/v2/common"
+	public "github.com/drand/drand/v2/common/chain"
+	"github.com/drand/drand/v2/common/log"
+	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/chain"
+	"github.com/drand/drand/v2/internal/chain/beacon"
+	"github.com/drand/drand/v2/internal/chain/boltdb"
+	"github.com/drand/drand/v2/internal/net"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// historySource is a source of the past rounds of a chain a follow imports them from: an archive of the chain, or a
+// peer asked to settle a conflict between the archives
+type historySource struct {
+	name  string
+	store chain.Store
+	// the rounds supplied by the source which were imported, outvoted by another signature, or invalid
+	imported, outvoted, invalid int
+}
+
+// historyCandidate is a beacon supplied for a round, with the sources supplying it
+type historyCandidate struct {
+	beacon  *common.Beacon
+	sources []*historySource
+	// whether the beacon was verified yet, and is valid
+	checked, valid bool
+}
+
+// verify tells whether the beacon is valid for the chain, verifying it only once
+func (c *historyCandidate) verify(info *public.Info, sch *crypto.Scheme) bool {
+	if !c.checked {
+		c.valid = sch.VerifyBeacon(c.beacon, info.PublicKey) == nil
+		c.checked = true
+	}
+	return c.valid
+}
+
+// historyVote collects the beacons supplied by the sources for a round
+type historyVote struct {
+	candidates []*historyCandidate
+}
+
+func (v *historyVote) add(source *historySource, b *common.Beacon) {
+	for _, c := range v.candidates {
+		if bytes.Equal(c.beacon.Signature, b.Signature) {
+			c.sources = append(c.sources, source)
+			return
+		}
+	}
+	v.candidates = append(v.candidates, &historyCandidate{beacon: b, sources: []*historySource{source}})
+}
+
+// ranked returns the candidates supplied by the most sources first, the first supplied first on a tie
+func (v *historyVote) ranked() []*historyCandidate {
+	ranked := append([]*historyCandidate(nil), v.candidates...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return len(ranked[i].sources) > len(ranked[j].sources)
+	})
+	return ranked
+}
+
+// importHistory imports the rounds following the last one of the store from the given archives, backups of the chain
+// taken on other nodes, before following it from the peers, so that a follower can bootstrap from several archives
+// when none holds the full and correct history. The rounds supplied by several archives are cross-verified: when
+// they disagree, the peers are asked for the round as well and the signature supplied by the most sources which
+// verifies is kept. The import stops at the first round no source supplies a valid beacon for, the rest being synced
+// from the peers as usual. It returns the last round imported.
+func (bp *BeaconProcess) importHistory(ctx context.Context, l log.Logger, store chain.Store, info *public.Info,
+	sch *crypto.Scheme, archives []string, peers []net.Peer) (uint64, error) {
+	sources := make([]*historySource, 0, len(archives))
+	defer func() {
+		for _, s := range sources {
+			_ = s.store.Close()
+		}
+	}()
+	for _, file := range archives {
+		archive, err := boltdb.OpenArchive(chainStoreContext(ctx, sch), l, file)
+		if err != nil {
+			return 0, fmt.Errorf("unable to open the archive %s: %w", file, err)
+		}
+		sources = append(sources, &historySource{name: file, store: archive})
+		genesis, err := archive.Get(ctx, 0)
+		if err != nil || !bytes.Equal(genesis.Signature, info.GenesisSeed) {
+			return 0, fmt.Errorf("the archive %s isn't one of the chain followed", file)
+		}
+	}
+
+	ss, err := beacon.NewSchemeStore(ctx, store, sch)
+	if err != nil {
+		return 0, err
+	}
+	last, err := store.Last(ctx)
+	if err != nil {
+		return 0, err
+	}
+	from := last.Round + 1
+	current := common.CurrentRound(bp.opts.clock.Now().Unix(), info.Period, info.GenesisTime)
+	l.Infow("importing the history of the chain from the archives", "archives", len(archives), "from", from)
+
+	asked := make(map[string]*historySource, len(peers))
+	conflicts := 0
+	round := from
+	for ; round <= current; round++ {
+		if err := ctx.Err(); err != nil {
+			return round - 1, err
+		}
+
+		vote := &historyVote{}
+		for _, s := range sources {
+			if b, err := s.store.Get(ctx, common.Round(round)); err == nil {
+				vote.add(s, b)
+			}
+		}
+		if len(vote.candidates) == 0 {
+			break
+		}
+		if len(vote.candidates) > 1 || !vote.candidates[0].verify(info, sch) {
+			// the archives disagree, or the only beacon supplied is invalid: the peers settle it
+			conflicts++
+			bp.askPeersForRound(ctx, l, vote, asked, peers, info, round)
+		}
+
+		var kept *historyCandidate
+		for _, c := range vote.ranked() {
+			for _, s := range c.sources {
+				switch {
+				case !c.verify(info, sch):
+					s.invalid++
+				case kept == nil && ss.Put(ctx, c.beacon) == nil:
+					kept = c
+					s.imported++
+				case kept == c:
+					s.imported++
+				default:
+					s.outvoted++
+				}
+			}
+		}
+		if kept == nil {
+			l.Warnw("no source supplied a valid beacon, stopping the import", "round", round)
+			break
+		}
+		if len(vote.candidates) > 1 {
+			l.Warnw("the sources disagree on a round, keeping the majority", "round", round,
+				"kept", kept.sources[0].name, "votes", len(kept.sources))
+		}
+	}
+
+	imported := round - from
+	summary := make([]string, 0, len(sources)+len(asked))
+	for _, s := range append(sources, sortedSources(asked)...) {
+		summary = append(summary, fmt.Sprintf("%s: %d imported, %d outvoted, %d invalid",
+			s.name, s.imported, s.outvoted, s.invalid))
+		if s.outvoted > 0 || s.invalid > 0 {
+			l.Warnw("source supplied rounds which weren't kept", "source", s.name,
+				"outvoted", s.outvoted, "invalid", s.invalid)
+		}
+	}
+	l.Infow("imported the history of the chain from the archives", "rounds", imported, "last", round-1,
+		"conflicts", conflicts, "sources", summary)
+	if imported > 0 {
+		bp.events.record(bp.opts.clock.Now(), eventHistoryImported, fmt.Sprintf("rounds %d to %d imported, "+
+			"%d conflicts: %s", from, round-1, conflicts, strings.Join(summary, "; ")))
+	}
+	return round - 1, nil
+}
+
+// askPeersForRound adds the beacons of the round served by the given peers to the vote, the peers asked being kept
+// as sources across the rounds
+func (bp *BeaconProcess) askPeersForRound(ctx context.Context, l log.Logger, vote *historyVote,
+	asked map[string]*historySource, peers []net.Peer, info *public.Info, round uint64) {
+	request := &drand.PublicRandRequest{
+		Round:    round,
+		Metadata: &drand.Metadata{BeaconID: info.ID, ChainHash: info.Hash(), NodeVersion: bp.version.ToProto()},
+	}
+
+	var lk sync.Mutex
+	var wg sync.WaitGroup
+	for _, peer := range peers {
+		source, ok := asked[peer.Address()]
+		if !ok {
+			source = &historySource{name: peer.Address()}
+			asked[peer.Address()] = source
+		}
+		wg.Add(1)
+		go func(peer net.Peer, source *historySource) {
+			defer wg.Done()
+			tctx, cancel := context.WithTimeout(ctx, info.Period)
+			defer cancel()
+
+			resp, err := bp.privGateway.PublicRand(tctx, peer, request)
+			if err != nil {
+				l.Debugw("unable to get the round to settle a conflict", "round", round, "from", peer.Address(), "err", err)
+				return
+			}
+			if resp.GetRound() != round {
+				return
+			}
+			lk.Lock()
+			defer lk.Unlock()
+			vote.add(source, &common.Beacon{
+				Round:       resp.GetRound(),
+				Signature:   resp.GetSignature(),
+				PreviousSig: resp.GetPreviousSignature(),
+			})
+		}(peer, source)
+	}
+	wg.Wait()
+}
+
+// sortedSources returns the sources ordered by name
+func sortedSources(sources map[string]*historySource) []*historySource {
+	sorted := make([]*historySource, 0, len(sources))
+	for _, s := range sources {
+		sorted = append(sorted, s)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].name < sorted[j].name
+	})
+	return sorted
+}
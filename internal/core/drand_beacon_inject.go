@@ -0,0 +1,52 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// InjectBeacon stores a beacon obtained out-of-band, e.g. from the store of another node, to repair a gap of the chain
+// store when the peers are unreachable. The beacon is verified against the chain as strictly as a synced one, and the
+// injection is refused unless the beacon-injection feature is enabled, since it bypasses the network.
+func (bp *BeaconProcess) InjectBeacon(ctx context.Context, in *drand.InjectBeaconRequest) (*drand.InjectBeaconResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "bp.InjectBeacon")
+	defer span.End()
+
+	if !bp.featureEnabled(FeatureBeaconInjection) {
+		return nil, status.Errorf(codes.PermissionDenied, "the %s feature is disabled", FeatureBeaconInjection)
+	}
+
+	bp.state.RLock()
+	b := bp.beacon
+	bp.state.RUnlock()
+	if b == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "the beacon isn't running")
+	}
+
+	injected := &common.Beacon{
+		Round:       in.GetRound(),
+		Signature:   in.GetSignature(),
+		PreviousSig: in.GetPreviousSignature(),
+	}
+	stored, err := b.InjectBeacon(ctx, injected)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to inject beacon %d: %v", injected.Round, err)
+	}
+	if stored {
+		bp.log.Warnw("injected a beacon in the chain store", "round", injected.Round)
+		bp.events.record(bp.opts.clock.Now(), eventBeaconInjected, fmt.Sprintf("injected round %d", injected.Round))
+	}
+
+	last, err := b.Store().Last(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &drand.InjectBeaconResponse{Stored: stored, LastRound: last.Round, Metadata: bp.newMetadata()}, nil
+}
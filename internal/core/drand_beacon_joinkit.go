@@ -0,0 +1,144 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/drand/drand/v2/common"
+	chain2 "github.com/drand/drand/v2/common/chain"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// MakeJoinKit returns a bundle signed by the longterm key of this node, with the chain info, the last beacon stored
+// by this node as a verified checkpoint and the addresses of the group members, so that new followers can start from
+// the checkpoint instead of syncing the chain from genesis.
+func (bp *BeaconProcess) MakeJoinKit(ctx context.Context) (*drand.JoinKit, error) {
+	ctx, span := tracer.NewSpan(ctx, "bp.MakeJoinKit")
+	defer span.End()
+
+	bp.state.RLock()
+	defer bp.state.RUnlock()
+
+	if bp.beacon == nil || bp.group == nil {
+		return nil, errors.New("this node isn't running a beacon, there is no chain to join")
+	}
+	last, err := bp.beacon.Store().Last(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the last beacon: %w", err)
+	}
+	if last.GetRound() == 0 {
+		return nil, errors.New("the chain hasn't started yet, there is no checkpoint to join from")
+	}
+	// we never hand out a checkpoint we haven't verified ourselves
+	if err := bp.priv.Scheme().VerifyBeacon(last, bp.group.PublicKey.Key()); err != nil {
+		return nil, fmt.Errorf("the last stored beacon %d is invalid: %w", last.GetRound(), err)
+	}
+
+	kit := &drand.JoinKit{
+		BeaconID:                    common.GetCanonicalBeaconID(bp.getBeaconID()),
+		ChainInfo:                   chain2.NewChainInfo(bp.group).ToProto(nil),
+		CheckpointRound:             last.GetRound(),
+		CheckpointSignature:         last.GetSignature(),
+		CheckpointPreviousSignature: last.GetPreviousSignature(),
+	}
+	for _, n := range bp.group.Nodes {
+		kit.Peers = append(kit.Peers, n.Address())
+	}
+	if err := bp.signJoinKit(kit); err != nil {
+		return nil, err
+	}
+	kit.Metadata = bp.newMetadata()
+
+	return kit, nil
+}
+
+// signJoinKit fills the signer fields of the kit and signs its digest with the longterm key of this node
+func (bp *BeaconProcess) signJoinKit(kit *drand.JoinKit) error {
+	kit.CreatedAt = bp.opts.clock.Now().Unix()
+	kit.SignerAddress = bp.priv.Public.Addr
+	signerKey, err := bp.priv.Public.Key.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	kit.SignerKey = signerKey
+	kit.SignerSchemeID = bp.priv.Scheme().Name
+	kit.Digest = joinKitDigest(kit)
+
+	sig, err := bp.priv.Scheme().AuthScheme.Sign(bp.priv.Key, kit.Digest)
+	if err != nil {
+		return fmt.Errorf("unable to sign the join kit: %w", err)
+	}
+	kit.Signature = sig
+	return nil
+}
+
+// joinKitDigest returns the SHA-256 digest of all the fields of the kit covered by its signature
+func joinKitDigest(kit *drand.JoinKit) []byte {
+	h := sha256.New()
+	h.Write([]byte("drand-joinkit:" + kit.GetBeaconID() + ":"))
+	h.Write(kit.GetChainInfo().GetHash())
+	h.Write(binary.BigEndian.AppendUint64(nil, kit.GetCheckpointRound()))
+	h.Write(kit.GetCheckpointSignature())
+	h.Write(kit.GetCheckpointPreviousSignature())
+	for _, p := range kit.GetPeers() {
+		h.Write(binary.BigEndian.AppendUint32(nil, uint32(len(p))))
+		h.Write([]byte(p))
+	}
+	h.Write(binary.BigEndian.AppendUint64(nil, uint64(kit.GetCreatedAt())))
+	h.Write([]byte(kit.GetSignerAddress()))
+	return h.Sum(nil)
+}
+
+// VerifyJoinKit checks that the join kit is for the chain with the given hash, that its checkpoint is a valid beacon
+// of that chain and that the kit is signed by the key it carries. It returns the chain info and the checkpoint.
+// The signer key is only trusted as much as the operator who handed out the kit: the checkpoint itself is verified
+// against the distributed key of the chain.
+func VerifyJoinKit(kit *drand.JoinKit, chainHash []byte) (*chain2.Info, *common.Beacon, error) {
+	info, err := chain2.InfoFromProto(kit.GetChainInfo())
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid chain info in the join kit: %w", err)
+	}
+	if !bytes.Equal(info.Hash(), kit.GetChainInfo().GetHash()) {
+		return nil, nil, errors.New("the chain info of the join kit doesn't match its hash")
+	}
+	if len(chainHash) > 0 && !bytes.Equal(info.Hash(), chainHash) {
+		return nil, nil, fmt.Errorf("the join kit is for chain %s, not %x", info.HashString(), chainHash)
+	}
+
+	sch, err := crypto.SchemeFromName(info.Scheme)
+	if err != nil {
+		return nil, nil, err
+	}
+	checkpoint := &common.Beacon{
+		Round:       kit.GetCheckpointRound(),
+		Signature:   kit.GetCheckpointSignature(),
+		PreviousSig: kit.GetCheckpointPreviousSignature(),
+	}
+	if err := sch.VerifyBeacon(checkpoint, info.PublicKey); err != nil {
+		return nil, nil, fmt.Errorf("invalid checkpoint %d in the join kit: %w", checkpoint.Round, err)
+	}
+
+	signerScheme, err := crypto.SchemeFromName(kit.GetSignerSchemeID())
+	if err != nil {
+		return nil, nil, err
+	}
+	signerKey := signerScheme.KeyGroup.Point()
+	if err := signerKey.UnmarshalBinary(kit.GetSignerKey()); err != nil {
+		return nil, nil, fmt.Errorf("invalid signer key in the join kit: %w", err)
+	}
+	digest := joinKitDigest(kit)
+	if !bytes.Equal(digest, kit.GetDigest()) {
+		return nil, nil, errors.New("the digest of the join kit doesn't match its content")
+	}
+	if err := signerScheme.AuthScheme.Verify(signerKey, digest, kit.GetSignature()); err != nil {
+		return nil, nil, fmt.Errorf("invalid signature of the join kit: %w", err)
+	}
+
+	return info, checkpoint, nil
+}
@@ -0,0 +1,215 @@
+package core
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/key"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/internal/net"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// LeaveFileName is the file of the beacon folder keeping the departures announced to the node, its own included, so
+// that a node restarted after announcing its departure still stops signing partials and wipes its share
+const LeaveFileName = "leave.json"
+
+// leaveState is the content of the leave file
+type leaveState struct {
+	Leaving map[string]uint64 `json:"leaving,omitempty"`
+	Wipe    bool              `json:"wipe,omitempty"`
+}
+
+// Leave announces to all the members of the group that this node is leaving it after the given round, so that the
+// coordinator of the next reshare can exclude it. The node stops signing partials after that round, and if asked to,
+// securely wipes its share once the reshare completes.
+func (bp *BeaconProcess) Leave(ctx context.Context, in *drand.LeaveRequest) (*drand.LeaveStatus, error) {
+	ctx, span := tracer.NewSpan(ctx, "bp.Leave")
+	defer span.End()
+
+	bp.state.Lock()
+	group := bp.group
+	if group == nil || bp.beacon == nil {
+		bp.state.Unlock()
+		return nil, errors.New("this node isn't running a beacon, there is nothing to leave")
+	}
+	self := bp.priv.Public.Addr
+	if group.Find(bp.priv.Public) == nil {
+		bp.state.Unlock()
+		return nil, fmt.Errorf("%s is not part of the group", self)
+	}
+	currentRound := common.CurrentRound(bp.opts.clock.Now().Unix(), group.Period, group.GenesisTime)
+	lastRound := in.GetLastRound()
+	if lastRound < currentRound {
+		bp.state.Unlock()
+		return nil, fmt.Errorf("cannot stop signing partials at round %d, the network is already at round %d", lastRound, currentRound)
+	}
+	sig, err := bp.priv.Scheme().AuthScheme.Sign(bp.priv.Key, leaveMessage(bp.getBeaconID(), self, lastRound))
+	if err != nil {
+		bp.state.Unlock()
+		return nil, err
+	}
+	bp.beacon.StopPartialsAfter(lastRound)
+	bp.recordLeaver(self, lastRound)
+	bp.wipeOnLeave = in.GetWipe()
+	if err := bp.saveLeave(); err != nil {
+		bp.log.Warnw("Unable to save the departure, it won't survive a restart", "err", err)
+	}
+	bp.state.Unlock()
+
+	announcement := &drand.LeaveAnnouncement{
+		Address:   self,
+		LastRound: lastRound,
+		Signature: sig,
+		Metadata:  bp.newMetadata(),
+	}
+	for _, node := range group.Nodes {
+		if node.Address() == self {
+			continue
+		}
+		if err := bp.privGateway.AnnounceLeave(ctx, net.CreatePeer(node.Address()), announcement); err != nil {
+			// the coordinator of the reshare can still be told about the departure out of band
+			bp.log.Warnw("Unable to announce departure", "to", node.Address(), "err", err)
+		}
+	}
+
+	bp.events.record(bp.opts.clock.Now(), eventLeaveAnnounced, fmt.Sprintf("%s after round %d", self, lastRound))
+	bp.log.Infow("Announced departure from the group", "lastRound", lastRound, "wipe", in.GetWipe())
+	return &drand.LeaveStatus{Address: self, LastRound: lastRound}, nil
+}
+
+// AnnounceLeave records the departure announced by a member of the group, which the coordinator
+// of the next reshare can then list as a leaver.
+func (bp *BeaconProcess) AnnounceLeave(ctx context.Context, in *drand.LeaveAnnouncement) (*drand.Empty, error) {
+	_, span := tracer.NewSpan(ctx, "bp.AnnounceLeave")
+	defer span.End()
+
+	bp.state.Lock()
+	defer bp.state.Unlock()
+
+	if bp.group == nil {
+		return nil, errors.New("no group yet")
+	}
+	var leaver *key.Node
+	for _, n := range bp.group.Nodes {
+		if n.Address() == in.GetAddress() {
+			leaver = n
+			break
+		}
+	}
+	if leaver == nil {
+		return nil, fmt.Errorf("%s is not part of the group", in.GetAddress())
+	}
+
+	msg := leaveMessage(bp.getBeaconID(), in.GetAddress(), in.GetLastRound())
+	if err := bp.group.Scheme.AuthScheme.Verify(leaver.Key, msg, in.GetSignature()); err != nil {
+		return nil, fmt.Errorf("invalid leave announcement signature from %s: %w", in.GetAddress(), err)
+	}
+	bp.recordLeaver(in.GetAddress(), in.GetLastRound())
+	if err := bp.saveLeave(); err != nil {
+		bp.log.Warnw("Unable to save the departure, it won't survive a restart", "err", err)
+	}
+	bp.events.record(bp.opts.clock.Now(), eventLeaveAnnounced, fmt.Sprintf("%s after round %d", in.GetAddress(), in.GetLastRound()))
+
+	bp.log.Infow("Member announced its departure from the group", "leaver", in.GetAddress(), "lastRound", in.GetLastRound())
+	return &drand.Empty{Metadata: bp.newMetadata()}, nil
+}
+
+// recordLeaver must be called with the state lock held
+func (bp *BeaconProcess) recordLeaver(addr string, lastRound uint64) {
+	if bp.leaving == nil {
+		bp.leaving = make(map[string]uint64)
+	}
+	bp.leaving[addr] = lastRound
+}
+
+// forgetLeavers drops the announced departures of the nodes which aren't part of the given group anymore.
+// It must be called with the state lock held.
+func (bp *BeaconProcess) forgetLeavers(group *key.Group) {
+	forgotten := false
+	for addr := range bp.leaving {
+		if !groupHasAddress(group, addr) {
+			delete(bp.leaving, addr)
+			forgotten = true
+		}
+	}
+	if !forgotten {
+		return
+	}
+	if err := bp.saveLeave(); err != nil {
+		bp.log.Warnw("Unable to save the departures", "err", err)
+	}
+}
+
+// leaveFile returns the file keeping the departures announced to the beacon
+func (bp *BeaconProcess) leaveFile() string {
+	beaconID := common.GetCanonicalBeaconID(bp.getBeaconID())
+	return path.Join(bp.opts.BeaconFolderMB(beaconID), beaconID, LeaveFileName)
+}
+
+// saveLeave must be called with the state lock held
+func (bp *BeaconProcess) saveLeave() error {
+	if len(bp.leaving) == 0 {
+		err := os.Remove(bp.leaveFile())
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	buff, err := json.MarshalIndent(&leaveState{Leaving: bp.leaving, Wipe: bp.wipeOnLeave}, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(bp.leaveFile(), buff, 0o600)
+}
+
+// loadLeave registers again the departures announced before the daemon restarted. The departure of this node is
+// applied to its beacon handler when it is created. It must be called with the state lock held.
+func (bp *BeaconProcess) loadLeave() error {
+	buff, err := os.ReadFile(bp.leaveFile())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var state leaveState
+	if err := json.Unmarshal(buff, &state); err != nil {
+		return fmt.Errorf("invalid leave file: %w", err)
+	}
+	bp.leaving = state.Leaving
+	bp.wipeOnLeave = state.Wipe
+	return nil
+}
+
+// leavingStatus returns the departures announced to this node. The caller must hold the state lock.
+func (bp *BeaconProcess) leavingStatus() []*drand.LeaveStatus {
+	leaving := make([]*drand.LeaveStatus, 0, len(bp.leaving))
+	for addr, lastRound := range bp.leaving {
+		leaving = append(leaving, &drand.LeaveStatus{Address: addr, LastRound: lastRound})
+	}
+	sort.Slice(leaving, func(i, j int) bool {
+		return leaving[i].Address < leaving[j].Address
+	})
+	return leaving
+}
+
+func groupHasAddress(group *key.Group, addr string) bool {
+	for _, n := range group.Nodes {
+		if n.Address() == addr {
+			return true
+		}
+	}
+	return false
+}
+
+func leaveMessage(beaconID, addr string, lastRound uint64) []byte {
+	msg := []byte("drand-leave:" + beaconID + ":" + addr + ":")
+	return binary.BigEndian.AppendUint64(msg, lastRound)
+}
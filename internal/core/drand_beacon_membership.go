@@ -0,0 +1,88 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/internal/dkg"
+	"github.com/drand/drand/v2/internal/util"
+	pdkg "github.com/drand/drand/v2/protobuf/dkg"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// GroupMembership returns the current composition of the group, along with the last heartbeat signed over it. The
+// daemon adds the previous compositions of the group, which only the DKG process knows about.
+func (bp *BeaconProcess) GroupMembership(ctx context.Context, _ *drand.GroupMembershipRequest) (*drand.GroupMembershipResponse, error) {
+	return bp.groupMembership(ctx, nil)
+}
+
+// groupMembership returns the composition of the group over the given history of the group, sorted by epoch
+func (bp *BeaconProcess) groupMembership(ctx context.Context, history []*dkg.GroupVersion) (*drand.GroupMembershipResponse, error) {
+	_, span := tracer.NewSpan(ctx, "bp.GroupMembership")
+	defer span.End()
+
+	bp.state.RLock()
+	if bp.group == nil {
+		bp.state.RUnlock()
+		return nil, ErrNoGroupSetup
+	}
+	current := &dkg.GroupVersion{Group: bp.group}
+	currentHash := bp.group.Hash()
+	currentEpoch := bp.toEpoch(current)
+	bp.state.RUnlock()
+
+	resp := &drand.GroupMembershipResponse{Metadata: bp.newMetadata()}
+	for _, v := range history {
+		epoch := bp.toEpoch(v)
+		if bytes.Equal(epoch.GetGroupHash(), currentHash) {
+			// the history knows the epoch of the current group
+			currentEpoch = epoch
+		}
+		resp.History = append(resp.History, epoch)
+	}
+	if len(resp.History) == 0 || resp.History[len(resp.History)-1] != currentEpoch {
+		resp.History = append(resp.History, currentEpoch)
+	}
+	resp.Current = currentEpoch
+
+	bp.heartbeats.Lock()
+	latest := bp.heartbeats.latest
+	bp.heartbeats.Unlock()
+	if latest != nil && bytes.Equal(latest.GetGroupHash(), currentHash) {
+		resp.Heartbeat = proto.Clone(latest).(*drand.HeartbeatPacket)
+	}
+	return resp, nil
+}
+
+// toEpoch converts a version of the group to its public description. The epoch is 0 if it isn't known.
+func (bp *BeaconProcess) toEpoch(v *dkg.GroupVersion) *drand.GroupEpoch {
+	group := v.Group.ToProto(bp.version)
+	group.Metadata = nil
+	return &drand.GroupEpoch{
+		Epoch:           v.Epoch,
+		TransitionRound: v.TransitionRound(),
+		GroupHash:       v.Group.Hash(),
+		Group:           group,
+	}
+}
+
+// groupHistory returns the group of every DKG the node completed for the beacon, sorted by epoch
+func (dd *DrandDaemon) groupHistory(ctx context.Context, beaconID string) ([]*dkg.GroupVersion, error) {
+	resp, err := dd.dkg.GroupHistory(ctx, &pdkg.GroupHistoryRequest{BeaconID: beaconID})
+	if err != nil {
+		return nil, err
+	}
+	history := make([]*dkg.GroupVersion, 0, len(resp.GetVersions()))
+	for _, v := range resp.GetVersions() {
+		group, err := util.ParseGroupFileBytes(v.GetGroupFile())
+		if err != nil {
+			return nil, fmt.Errorf("invalid group file for epoch %d: %w", v.GetEpoch(), err)
+		}
+		history = append(history, &dkg.GroupVersion{Epoch: v.GetEpoch(), Group: group})
+	}
+	return history, nil
+}
@@ -0,0 +1,77 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	public "github.com/drand/drand/v2/common/chain"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// Metadata returns the given namespace of the metadata of the chain store of the beacon, in which the library
+// embedders and the plugins keep their own state next to the beacons rather than in side files. The namespaces
+// starting with public.InternalNamespacePrefix are used by the node itself.
+func (bp *BeaconProcess) Metadata(namespace string) (*public.Metadata, error) {
+	bp.state.RLock()
+	store := bp.dbStore
+	bp.state.RUnlock()
+	if store == nil {
+		return nil, errors.New("the chain store isn't loaded")
+	}
+	return public.NewMetadata(store, namespace)
+}
+
+// StoreMetadata reads, writes, deletes or lists the values of a namespace of the metadata of the chain store. The
+// internal namespaces can be read but not modified.
+func (bp *BeaconProcess) StoreMetadata(ctx context.Context, in *drand.StoreMetadataRequest) (*drand.StoreMetadataResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "bp.StoreMetadata")
+	defer span.End()
+
+	namespace := in.GetNamespace()
+	if err := public.ValidateNamespace(namespace); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	modify := in.GetPut() || in.GetDelete()
+	if in.GetPut() && in.GetDelete() {
+		return nil, status.Error(codes.InvalidArgument, "can't both put and delete a key")
+	}
+	if modify && in.GetKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "a key is required to put or delete a value")
+	}
+	if modify && strings.HasPrefix(namespace, public.InternalNamespacePrefix) {
+		return nil, status.Errorf(codes.PermissionDenied, "the %s* namespaces are read-only", public.InternalNamespacePrefix)
+	}
+
+	md, err := bp.Metadata(namespace)
+	if errors.Is(err, public.ErrMetadataUnsupported) {
+		return nil, status.Error(codes.Unimplemented, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	values := make(map[string][]byte)
+	switch {
+	case in.GetPut():
+		err = md.Put(ctx, in.GetKey(), in.GetValue())
+		values[in.GetKey()] = in.GetValue()
+	case in.GetDelete():
+		err = md.Delete(ctx, in.GetKey())
+	case in.GetKey() != "":
+		var value []byte
+		value, err = md.Get(ctx, in.GetKey())
+		if value != nil {
+			values[in.GetKey()] = value
+		}
+	default:
+		values, err = md.List(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &drand.StoreMetadataResponse{Values: values, Metadata: bp.newMetadata()}, nil
+}
@@ -0,0 +1,105 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/drand/drand/v2/common"
+	chain2 "github.com/drand/drand/v2/common/chain"
+	"github.com/drand/drand/v2/common/tracer"
+	pdkg "github.com/drand/drand/v2/protobuf/dkg"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// notarizationInstructions explains how to verify a notarization bundle without any drand software
+const notarizationInstructions = `This bundle attests the randomness of a drand round. To verify it:
+1. Check that the SHA-256 hash of the chain info matches the chain hash published by the network operators.
+2. Check the signature of the round against the public key of the chain info, using the scheme of the chain info:
+   the signed message is SHA-256(previous_signature || round as 8 big-endian bytes) for chained schemes, and
+   SHA-256(round as 8 big-endian bytes) for unchained ones. The randomness is SHA-256(signature).
+3. The group files list the members that were in charge of the network at each epoch up to the one which produced
+   the round; the distributed public key is the same in all of them.
+4. Recompute the digest as SHA-256 of "drand-notarization:" || beaconID || ":" || chain hash || round ||
+   signature || previous_signature || for each group (epoch as 4 big-endian bytes || SHA-256(group file)) ||
+   notarized_at as 8 big-endian bytes || notary_address, and check the notary signature of the digest against
+   the notary key, using the authentication scheme of the notary scheme.`
+
+// Notarize returns a bundle allowing to verify the randomness of the given round offline, signed by the longterm key
+// of this node. The groups are the versions of the group up to the one which produced the round.
+func (bp *BeaconProcess) Notarize(ctx context.Context, round uint64, epoch uint32, groups []*pdkg.GroupVersion) (
+	*drand.NotarizationBundle, error) {
+	ctx, span := tracer.NewSpan(ctx, "bp.Notarize")
+	defer span.End()
+
+	bp.state.RLock()
+	defer bp.state.RUnlock()
+
+	if bp.beacon == nil || bp.group == nil {
+		return nil, errors.New("this node isn't running a beacon, there is nothing to notarize")
+	}
+	b, err := bp.beacon.Store().Get(ctx, common.Round(round))
+	if err != nil {
+		return nil, fmt.Errorf("unable to get round %d: %w", round, err)
+	}
+
+	bundle := &drand.NotarizationBundle{
+		BeaconID:          common.GetCanonicalBeaconID(bp.getBeaconID()),
+		Round:             b.GetRound(),
+		Signature:         b.GetSignature(),
+		PreviousSignature: b.GetPreviousSignature(),
+		Randomness:        b.GetRandomness(),
+		ChainInfo:         chain2.NewChainInfo(bp.group).ToProto(nil),
+		Epoch:             epoch,
+		Instructions:      notarizationInstructions,
+	}
+	for _, v := range groups {
+		bundle.Groups = append(bundle.Groups, &drand.NotarizedGroup{Epoch: v.GetEpoch(), GroupFile: v.GetGroupFile()})
+	}
+	if err := bp.signNotarization(bundle); err != nil {
+		return nil, err
+	}
+	bundle.Metadata = bp.newMetadata()
+
+	return bundle, nil
+}
+
+// signNotarization fills the notary fields of the bundle and signs its digest with the longterm key of this node
+func (bp *BeaconProcess) signNotarization(bundle *drand.NotarizationBundle) error {
+	bundle.NotarizedAt = bp.opts.clock.Now().Unix()
+	bundle.NotaryAddress = bp.priv.Public.Addr
+	notaryKey, err := bp.priv.Public.Key.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	bundle.NotaryKey = notaryKey
+	bundle.NotarySchemeID = bp.priv.Scheme().Name
+	bundle.Digest = notarizationDigest(bundle)
+
+	sig, err := bp.priv.Scheme().AuthScheme.Sign(bp.priv.Key, bundle.Digest)
+	if err != nil {
+		return fmt.Errorf("unable to sign the notarization: %w", err)
+	}
+	bundle.NotarySignature = sig
+	return nil
+}
+
+// notarizationDigest returns the digest of the bundle as described in notarizationInstructions
+func notarizationDigest(bundle *drand.NotarizationBundle) []byte {
+	h := sha256.New()
+	h.Write([]byte("drand-notarization:" + bundle.GetBeaconID() + ":"))
+	h.Write(bundle.GetChainInfo().GetHash())
+	h.Write(binary.BigEndian.AppendUint64(nil, bundle.GetRound()))
+	h.Write(bundle.GetSignature())
+	h.Write(bundle.GetPreviousSignature())
+	for _, g := range bundle.GetGroups() {
+		groupHash := sha256.Sum256(g.GetGroupFile())
+		h.Write(binary.BigEndian.AppendUint32(nil, g.GetEpoch()))
+		h.Write(groupHash[:])
+	}
+	h.Write(binary.BigEndian.AppendUint64(nil, uint64(bundle.GetNotarizedAt())))
+	h.Write([]byte(bundle.GetNotaryAddress()))
+	return h.Sum(nil)
+}
@@ -0,0 +1,162 @@
+package core
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/key"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// AddressOverridesFileName is the file of the beacon folder keeping the addresses the operator set to dial the
+// members of the group at, by the public key of the members in hex
+const AddressOverridesFileName = "address_overrides.json"
+
+// AddressOverrides lists the addresses the members of the group are dialed at instead of the ones of the group file,
+// e.g. their internal addresses for the operators running split-horizon networking, after setting or removing the
+// one of a member if asked to. The overrides are kept by the public key of the members, so that they follow them
+// across reshares, and take precedence over the addresses the members announce.
+func (bp *BeaconProcess) AddressOverrides(ctx context.Context, in *drand.AddressOverridesRequest) (*drand.AddressOverridesResponse, error) {
+	_, span := tracer.NewSpan(ctx, "bp.AddressOverrides")
+	defer span.End()
+
+	bp.state.Lock()
+	defer bp.state.Unlock()
+
+	overrides, err := bp.loadAddressOverrides()
+	if err != nil {
+		return nil, err
+	}
+
+	if member := in.GetMember(); member != "" {
+		memberKey, err := overrideKey(bp.group, member)
+		if err != nil {
+			return nil, err
+		}
+		if in.GetRemove() {
+			delete(overrides, memberKey)
+			bp.log.Infow("Removed the address override of a member", "member", member)
+		} else {
+			addr, err := key.NormalizeAddress(in.GetAddress())
+			if err != nil {
+				return nil, fmt.Errorf("unable to dial %s there: %w", member, err)
+			}
+			overrides[memberKey] = addr
+			bp.log.Infow("Overrode the address of a member", "member", member, "address", addr)
+		}
+		if err := bp.saveAddressOverrides(overrides); err != nil {
+			return nil, err
+		}
+		bp.applyAddressOverrides(bp.group, overrides)
+	}
+
+	resp := &drand.AddressOverridesResponse{Metadata: bp.newMetadata()}
+	for memberKey, addr := range overrides {
+		override := &drand.AddressOverride{Key: memberKey, Address: addr}
+		if node := groupNodeByKey(bp.group, memberKey); node != nil {
+			override.MemberAddress = node.Address()
+		}
+		resp.Overrides = append(resp.Overrides, override)
+	}
+	sort.Slice(resp.Overrides, func(i, j int) bool {
+		return resp.Overrides[i].Key < resp.Overrides[j].Key
+	})
+	return resp, nil
+}
+
+// overrideKey returns the public key in hex of the member, given by its public key or its address in the group
+func overrideKey(group *key.Group, member string) (string, error) {
+	if group != nil {
+		for _, n := range group.Nodes {
+			if n.Address() == member {
+				return key.PointToString(n.Key), nil
+			}
+		}
+	}
+	member = strings.ToLower(member)
+	if _, err := hex.DecodeString(member); err != nil {
+		return "", fmt.Errorf("%s is neither the address of a member of the group nor a public key in hex", member)
+	}
+	return member, nil
+}
+
+func groupNodeByKey(group *key.Group, memberKey string) *key.Node {
+	if group == nil {
+		return nil
+	}
+	for _, n := range group.Nodes {
+		if key.PointToString(n.Key) == memberKey {
+			return n
+		}
+	}
+	return nil
+}
+
+// applyAddressOverrides makes the gateway dial the members of the group at the addresses of the overrides, and the
+// members which aren't overridden anymore at their address again. It must be called with the state lock held.
+func (bp *BeaconProcess) applyAddressOverrides(group *key.Group, overrides map[string]string) {
+	bp.releaseAddressOverrides()
+	for memberKey, to := range overrides {
+		if node := groupNodeByKey(group, memberKey); node != nil {
+			bp.privGateway.Override(bp.getBeaconID(), node.Address(), to)
+			bp.overridden = append(bp.overridden, node.Address())
+		}
+	}
+}
+
+// releaseAddressOverrides makes the gateway stop overriding the addresses of the members for the beacon. It must be
+// called with the state lock held.
+func (bp *BeaconProcess) releaseAddressOverrides() {
+	for _, addr := range bp.overridden {
+		bp.privGateway.Override(bp.getBeaconID(), addr, "")
+	}
+	bp.overridden = nil
+}
+
+// reloadAddressOverrides applies the overrides saved to the members of the group. It must be called with the state
+// lock held.
+func (bp *BeaconProcess) reloadAddressOverrides(group *key.Group) {
+	overrides, err := bp.loadAddressOverrides()
+	if err != nil {
+		bp.log.Warnw("Unable to load the address overrides", "err", err)
+		return
+	}
+	bp.applyAddressOverrides(group, overrides)
+}
+
+// addressOverridesFile returns the file keeping the address overrides of the beacon
+func (bp *BeaconProcess) addressOverridesFile() string {
+	beaconID := common.GetCanonicalBeaconID(bp.getBeaconID())
+	return path.Join(bp.opts.BeaconFolderMB(beaconID), beaconID, AddressOverridesFileName)
+}
+
+func (bp *BeaconProcess) loadAddressOverrides() (map[string]string, error) {
+	overrides := make(map[string]string)
+	buff, err := os.ReadFile(bp.addressOverridesFile())
+	if errors.Is(err, os.ErrNotExist) {
+		return overrides, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(buff, &overrides); err != nil {
+		return nil, fmt.Errorf("invalid address overrides file: %w", err)
+	}
+	return overrides, nil
+}
+
+func (bp *BeaconProcess) saveAddressOverrides(overrides map[string]string) error {
+	buff, err := json.MarshalIndent(overrides, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(bp.addressOverridesFile(), buff, 0o600)
+}
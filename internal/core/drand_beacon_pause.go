@@ -0,0 +1,86 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// pauseState is set while the node doesn't sign its partials. It is kept by the beacon process rather than by the
+// beacon handler so that a node stays paused when its beacon is restarted, until it is resumed.
+type pauseState struct {
+	since  time.Time
+	reason string
+}
+
+func (p *pauseState) paused() bool {
+	return !p.since.IsZero()
+}
+
+// PauseBeacon stops the node from signing and sending its partials, of the chain as well as of its sub-beacons and
+// heartbeats, while it keeps following the chain and serving it. It is refused if the group isn't expected to reach
+// the threshold without this node, unless forced.
+func (bp *BeaconProcess) PauseBeacon(ctx context.Context, in *drand.PauseBeaconRequest) (*drand.PauseStatus, error) {
+	ctx, span := tracer.NewSpan(ctx, "bp.PauseBeacon")
+	defer span.End()
+
+	bp.state.Lock()
+	defer bp.state.Unlock()
+
+	if bp.group == nil || bp.beacon == nil {
+		return nil, errors.New("this node isn't running a beacon, there is nothing to pause")
+	}
+	if bp.pause.paused() {
+		return bp.pauseStatus(), nil
+	}
+
+	health := bp.membersHealth(ctx)
+	// we don't contribute our partials while paused
+	health[0] = memberDown
+	expected, state := projectQuorum(health, bp.group.Threshold)
+	if state == quorumLost && !in.GetForce() {
+		return nil, fmt.Errorf("the group would then be expected to miss rounds, with %.1f partials expected for a "+
+			"threshold of %d: force it to pause anyway", expected, bp.group.Threshold)
+	}
+
+	bp.pause = pauseState{since: bp.opts.clock.Now(), reason: in.GetReason()}
+	bp.beacon.SetPaused(true)
+	bp.events.record(bp.pause.since, eventBeaconPaused, in.GetReason())
+	bp.log.Warnw("Paused, not signing partials anymore", "reason", in.GetReason(), "forced", in.GetForce(),
+		"quorum", state)
+	return bp.pauseStatus(), nil
+}
+
+// ResumeBeacon makes a paused node sign and send its partials again
+func (bp *BeaconProcess) ResumeBeacon(ctx context.Context, _ *drand.ResumeBeaconRequest) (*drand.PauseStatus, error) {
+	_, span := tracer.NewSpan(ctx, "bp.ResumeBeacon")
+	defer span.End()
+
+	bp.state.Lock()
+	defer bp.state.Unlock()
+
+	if !bp.pause.paused() {
+		return bp.pauseStatus(), nil
+	}
+	pausedFor := bp.opts.clock.Since(bp.pause.since)
+	bp.pause = pauseState{}
+	if bp.beacon != nil {
+		bp.beacon.SetPaused(false)
+	}
+	bp.events.record(bp.opts.clock.Now(), eventBeaconResumed, fmt.Sprintf("paused for %s", pausedFor))
+	bp.log.Infow("Resumed, signing partials again", "paused_for", pausedFor)
+	return bp.pauseStatus(), nil
+}
+
+// pauseStatus must be called with the state lock held
+func (bp *BeaconProcess) pauseStatus() *drand.PauseStatus {
+	status := &drand.PauseStatus{Paused: bp.pause.paused(), Reason: bp.pause.reason, Metadata: bp.newMetadata()}
+	if status.Paused {
+		status.Since = bp.pause.since.Unix()
+	}
+	return status
+}
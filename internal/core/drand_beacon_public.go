@@ -2,13 +2,20 @@ package core
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/drand/drand/v2/common"
 	chain2 "github.com/drand/drand/v2/common/chain"
 	"github.com/drand/drand/v2/common/tracer"
 	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/chain"
 	"github.com/drand/drand/v2/internal/chain/beacon"
 	"github.com/drand/drand/v2/internal/net"
 	"github.com/drand/drand/v2/protobuf/drand"
@@ -36,7 +43,8 @@ func (bp *BeaconProcess) PartialBeacon(ctx context.Context, in *drand.PartialBea
 }
 
 // PublicRand returns a public random beacon according to the request. If the Round
-// field is 0, then it returns the last one generated.
+// field is 0, then it returns the last one generated. A node which isn't part of the
+// group serves the copy of the chain pushed to it, if any.
 func (bp *BeaconProcess) PublicRand(ctx context.Context, in *drand.PublicRandRequest) (*drand.PublicRandResponse, error) {
 	ctx, span := tracer.NewSpan(ctx, "bp.PublicRand")
 	defer span.End()
@@ -46,21 +54,41 @@ func (bp *BeaconProcess) PublicRand(ctx context.Context, in *drand.PublicRandReq
 	bp.state.RLock()
 	defer bp.state.RUnlock()
 
-	if bp.beacon == nil || len(bp.chainHash) == 0 {
+	var store chain.Store
+	var period time.Duration
+	var genesis int64
+	if bp.beacon != nil && len(bp.chainHash) != 0 {
+		if in.GetSubBeacon() != "" {
+			return bp.subBeaconRand(ctx, in)
+		}
+		store = bp.beacon.Store()
+		if bp.group != nil {
+			period, genesis = bp.group.Period, bp.group.GenesisTime
+		}
+	} else if replica, info := bp.replicatedChain(); replica != nil && in.GetSubBeacon() == "" {
+		store, period, genesis = replica, info.Period, info.GenesisTime
+	} else {
 		return nil, errors.New("drand: beacon generation not started yet")
 	}
+
 	var beaconResp *common.Beacon
 	var err error
 	if in.GetRound() == 0 {
-		beaconResp, err = bp.beacon.Store().Last(ctx)
+		beaconResp, err = store.Last(ctx)
 	} else {
 		// fetch the correct entry or the next one if not found
-		beaconResp, err = bp.beacon.Store().Get(ctx, in.GetRound())
+		beaconResp, err = store.Get(ctx, common.Round(in.GetRound()))
 	}
 	if err != nil || beaconResp == nil {
 		bp.log.Debugw("", "public_rand", "unstored_beacon", "round", in.GetRound(), "from", addr)
 		return nil, fmt.Errorf("can't retrieve beacon %d: %w", in.GetRound(), err)
 	}
+	if in.GetRound() == 0 && period > 0 {
+		if err := bp.checkStaleness(beaconResp.Round, in.GetMaxStalePeriods(), period, genesis); err != nil {
+			bp.log.Warnw("", "public_rand", "stale_beacon", "round", beaconResp.Round, "from", addr)
+			return nil, err
+		}
+	}
 	bp.log.Debugw("", "public_rand", addr, "round", beaconResp.Round, "reply", beaconResp.String())
 
 	response := beaconToProto(beaconResp)
@@ -69,6 +97,101 @@ func (bp *BeaconProcess) PublicRand(ctx context.Context, in *drand.PublicRandReq
 	return response, nil
 }
 
+// PublicRandAt returns the beacon which was the latest one of the chain at the given time: the round due then. The
+// rounds are dated by the time they were due at, which is when they were produced unless the network was catching up.
+// A node which doesn't have the round yet answers that it is unavailable, rather than with an older round. A node
+// which isn't part of the group serves the chain it relays, or else the copy of the chain pushed to it, if any.
+func (bp *BeaconProcess) PublicRandAt(ctx context.Context, in *drand.PublicRandAtRequest) (*drand.PublicRandResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "bp.PublicRandAt")
+	defer span.End()
+
+	bp.state.RLock()
+	defer bp.state.RUnlock()
+
+	store, period, genesis := bp.servedChain()
+	if store == nil {
+		return nil, errors.New("drand: beacon generation not started yet")
+	}
+	at := in.GetTimestamp()
+	if at < genesis {
+		return nil, status.Errorf(codes.NotFound, "%d is before the genesis of the chain at %d", at, genesis)
+	}
+	if at > bp.opts.clock.Now().Unix() {
+		return nil, status.Errorf(codes.InvalidArgument, "%d is in the future", at)
+	}
+
+	round := common.CurrentRound(at, period, genesis)
+	b, err := chain.StoredAt(ctx, store, round)
+	if errors.Is(err, chain.ErrRoundNotReached) {
+		return nil, status.Errorf(codes.Unavailable, "the beacon at %d isn't stored yet: %v", at, err)
+	} else if err != nil {
+		return nil, status.Errorf(codes.NotFound, "can't retrieve the beacon at %d: %v", at, err)
+	}
+
+	response := beaconToProto(b)
+	response.Metadata = bp.newMetadata()
+	return response, nil
+}
+
+// servedChain returns the store of the chain the node serves, with its period and genesis: the chain it produces, or
+// else the one it relays, or else the copy of the chain pushed to it. The store is nil if it serves none. It must be
+// called with the state lock held.
+func (bp *BeaconProcess) servedChain() (chain.Store, time.Duration, int64) {
+	if bp.beacon != nil && len(bp.chainHash) != 0 && bp.group != nil {
+		return bp.beacon.Store(), bp.group.Period, bp.group.GenesisTime
+	}
+	if store, info := bp.relayedChain(); store != nil {
+		return store, info.Period, info.GenesisTime
+	}
+	if store, info := bp.replicatedChain(); store != nil {
+		return store, info.Period, info.GenesisTime
+	}
+	return nil, 0, 0
+}
+
+// PublicRandByRandomness returns the beacon of the given randomness, looked up in the index of the randomness kept
+// in the chain store
+func (bp *BeaconProcess) PublicRandByRandomness(ctx context.Context, in *drand.PublicRandByRandomnessRequest) (*drand.PublicRandResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "bp.PublicRandByRandomness")
+	defer span.End()
+
+	if len(in.GetRandomness()) != sha256.Size {
+		return nil, status.Errorf(codes.InvalidArgument, "the randomness is %d bytes long, not %d", len(in.GetRandomness()), sha256.Size)
+	}
+	bp.state.RLock()
+	index := bp.randomnessIndex
+	bp.state.RUnlock()
+	if index == nil {
+		return nil, status.Error(codes.Unimplemented, "the chain store of this node can't index the randomness")
+	}
+
+	b, err := index.Lookup(ctx, in.GetRandomness())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "can't retrieve the beacon of the randomness %x: %v", in.GetRandomness(), err)
+	}
+
+	response := beaconToProto(b)
+	response.Metadata = bp.newMetadata()
+	return response, nil
+}
+
+// checkStaleness returns an error if the given last round of the chain of the given period and genesis is older than
+// the given number of periods, or than the limit set on the node if it's 0
+func (bp *BeaconProcess) checkStaleness(last, maxStalePeriods uint64, period time.Duration, genesis int64) error {
+	if maxStalePeriods == 0 {
+		maxStalePeriods = bp.opts.MaxStalePeriods()
+	}
+	if maxStalePeriods == 0 {
+		return nil
+	}
+	expected := common.CurrentRound(bp.opts.clock.Now().Unix(), period, genesis)
+	if expected > last+maxStalePeriods {
+		return status.Errorf(codes.Unavailable, "stale beacon: the last round is %d while the network is at round %d",
+			last, expected)
+	}
+	return nil
+}
+
 // a proxy type so public streaming request can use the same logic as in private
 // / protocol syncing request, even though the types differ, so it prevents
 // changing the protobuf structs.
@@ -123,6 +246,13 @@ func (bp *BeaconProcess) ChainInfo(ctx context.Context, _ *drand.ChainInfoReques
 	chainHash := bp.chainHash
 	bp.state.RUnlock()
 	if group == nil || len(chainHash) == 0 {
+		// a node relaying the chain it follows, or replicating the chain pushed to it, serves its info as well
+		if _, info := bp.relayedChain(); info != nil {
+			return info.ToProto(bp.newMetadata()), nil
+		}
+		if _, info := bp.replicatedChain(); info != nil {
+			return info.ToProto(bp.newMetadata()), nil
+		}
 		return nil, ErrNoGroupSetup
 	}
 
@@ -132,15 +262,28 @@ func (bp *BeaconProcess) ChainInfo(ctx context.Context, _ *drand.ChainInfoReques
 }
 
 // SyncChain is an inter-node protocol that replies to a syncing request from a
-// given round
+// given round. A node which isn't part of the group serves the chain it relays,
+// or else the copy of the chain pushed to it, if any.
 func (bp *BeaconProcess) SyncChain(req *drand.SyncRequest, stream drand.Protocol_SyncChainServer) error {
+	logger := bp.requestLog(stream.Context()).Named("SyncChain")
+	if slices.Contains(req.GetRelayPath(), bp.priv.Public.Address()) {
+		return status.Errorf(codes.FailedPrecondition, "relaying the chain to %s would make a loop",
+			net.RemoteAddress(stream.Context()))
+	}
+
 	bp.state.RLock()
-	logger := bp.log.Named("SyncChain")
 	b := bp.beacon
 	c := bp.chainHash
 	if b == nil || len(c) == 0 {
-		logger.Errorw("Received a SyncRequest, but no beacon handler is set yet", "request", req)
 		bp.state.RUnlock()
+		if store, _ := bp.relayedChain(); store != nil {
+			defer bp.addDownstream(req.GetRelayPath())()
+			return beacon.SyncChain(logger, store, req, stream)
+		}
+		if store, _ := bp.replicatedChain(); store != nil {
+			return beacon.SyncChain(logger, store, req, stream)
+		}
+		logger.Errorw("Received a SyncRequest, but no beacon handler is set yet", "request", req)
 		return fmt.Errorf("no beacon handler available")
 	}
 	store := b.Store()
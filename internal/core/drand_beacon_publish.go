@@ -0,0 +1,91 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/v2/common"
+	public "github.com/drand/drand/v2/common/chain"
+	"github.com/drand/drand/v2/plugin"
+)
+
+// publishCallbackID prefixes the IDs of the callbacks publishing the new beacons, one per publisher
+const publishCallbackID = "publisher-"
+
+// publishTimeout bounds the time a publisher takes to publish a beacon
+const publishTimeout = 10 * time.Second
+
+// beaconPublishers are the registered publishers the new beacons are published with, each from the worker of its
+// callback so that a slow publisher doesn't delay the others. Its zero value is ready to use.
+type beaconPublishers struct {
+	sync.Mutex
+	running map[string]plugin.Publisher
+}
+
+// startPublishers publishes each new beacon with the publishers configured, until the beacon is stopped. A publisher
+// which can't be created is logged and skipped.
+func (bp *BeaconProcess) startPublishers() {
+	names := bp.opts.Publishers()
+	if len(names) == 0 {
+		return
+	}
+
+	bp.state.RLock()
+	b := bp.beacon
+	group := bp.group
+	bp.state.RUnlock()
+	if b == nil || group == nil {
+		return
+	}
+
+	bp.publishers.Lock()
+	defer bp.publishers.Unlock()
+	if bp.publishers.running != nil {
+		return
+	}
+	bp.publishers.running = make(map[string]plugin.Publisher, len(names))
+
+	info := public.NewChainInfo(group)
+	for _, name := range names {
+		factory, ok := plugin.LookupPublisher(name)
+		if !ok {
+			bp.log.Errorw("unknown publisher", "publisher", name)
+			continue
+		}
+		pub, err := factory(bp.log.Named(name), common.BeaconID(bp.getBeaconID()), info)
+		if err != nil {
+			bp.log.Errorw("unable to create publisher", "publisher", name, "err", err)
+			continue
+		}
+		bp.publishers.running[name] = pub
+
+		name := name
+		b.AddCallback(context.Background(), publishCallbackID+name, func(beacon *common.Beacon, closed bool) {
+			if closed {
+				return
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+			defer cancel()
+			if err := pub.Publish(ctx, beacon); err != nil {
+				bp.log.Warnw("unable to publish beacon", "publisher", name, "round", beacon.GetRound(), "err", err)
+			}
+		})
+	}
+}
+
+// stopPublishers stops publishing the new beacons and closes the publishers. It must be called with the state lock
+// held.
+func (bp *BeaconProcess) stopPublishers() {
+	bp.publishers.Lock()
+	defer bp.publishers.Unlock()
+	for name, pub := range bp.publishers.running {
+		if bp.beacon != nil {
+			bp.beacon.RemoveCallback(context.Background(), publishCallbackID+name)
+		}
+		if err := pub.Close(); err != nil {
+			bp.log.Warnw("unable to close publisher", "publisher", name, "err", err)
+		}
+	}
+	bp.publishers.running = nil
+}
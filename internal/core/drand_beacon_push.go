@@ -0,0 +1,306 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/drand/drand/v2/common"
+	public "github.com/drand/drand/v2/common/chain"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/chain"
+	"github.com/drand/drand/v2/internal/chain/beacon"
+	"github.com/drand/drand/v2/internal/metrics"
+	"github.com/drand/drand/v2/internal/net"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// pushCallbackID is the ID of the callback notifying the pushers of the new beacons
+const pushCallbackID = "push-replication"
+
+// pushReplication pushes the new beacons to the downstream nodes, each from its own goroutine, so that a slow or
+// unreachable node doesn't delay the others. Its zero value is ready to use.
+type pushReplication struct {
+	sync.Mutex
+	stop context.CancelFunc
+}
+
+// pushTarget is a downstream node the chain is pushed to
+type pushTarget struct {
+	peer net.Peer
+	// notify holds a notification when there are new beacons to push
+	notify chan struct{}
+	// acked is the last round stored by the node, once it acknowledged a push
+	acked uint64
+	known bool
+}
+
+// pushReplica is the copy of the chain a downstream node keeps from the beacons pushed to it, and serves. It is
+// opened with the first push and kept until the beacon process stops. Its zero value is ready to use.
+type pushReplica struct {
+	sync.Mutex
+	// store checks that the beacons are linked before writing them, and notifies the streams served of the new ones
+	store beacon.CallbackStore
+	info  *public.Info
+	sch   *crypto.Scheme
+}
+
+// startPushReplication pushes each new beacon to the downstream nodes configured, until the beacon is stopped.
+func (bp *BeaconProcess) startPushReplication() {
+	targets := bp.opts.PushTargets()
+	if len(targets) == 0 {
+		return
+	}
+
+	bp.state.RLock()
+	b := bp.beacon
+	group := bp.group
+	bp.state.RUnlock()
+	if b == nil || group == nil {
+		return
+	}
+
+	bp.pushes.Lock()
+	defer bp.pushes.Unlock()
+	if bp.pushes.stop != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	bp.pushes.stop = cancel
+
+	info := public.NewChainInfo(group).ToProto(nil)
+	pushTargets := make([]*pushTarget, 0, len(targets))
+	for _, addr := range targets {
+		// a single pending notification is enough, all the rounds missing are pushed at once
+		t := &pushTarget{peer: net.CreatePeer(addr), notify: make(chan struct{}, 1)}
+		t.notify <- struct{}{}
+		pushTargets = append(pushTargets, t)
+		go bp.pushLoop(ctx, t, b.Store(), info)
+	}
+	b.AddCallback(ctx, pushCallbackID, func(_ *common.Beacon, closed bool) {
+		if closed {
+			return
+		}
+		for _, t := range pushTargets {
+			select {
+			case t.notify <- struct{}{}:
+			default:
+			}
+		}
+	})
+}
+
+// stopPushReplication stops pushing the new beacons. It must be called with the state lock held.
+func (bp *BeaconProcess) stopPushReplication() {
+	bp.pushes.Lock()
+	defer bp.pushes.Unlock()
+	if bp.pushes.stop == nil {
+		return
+	}
+	bp.pushes.stop()
+	bp.pushes.stop = nil
+	if bp.beacon != nil {
+		bp.beacon.RemoveCallback(context.Background(), pushCallbackID)
+	}
+}
+
+// pushLoop pushes the rounds the target is missing each time it is notified of a new beacon, backing off on failure
+func (bp *BeaconProcess) pushLoop(ctx context.Context, t *pushTarget, store chain.Store, info *drand.ChainInfoPacket) {
+	var backoff time.Duration
+	var retry <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.notify:
+		case <-retry:
+		}
+		if !bp.featureEnabled(FeatureChainPush) {
+			// the rounds missed meanwhile are pushed once it is enabled again, with the next beacon
+			backoff, retry = 0, nil
+			continue
+		}
+
+		err := bp.pushMissing(ctx, t, store, info)
+		if err == nil {
+			backoff, retry = 0, nil
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		backoff = nextPushBackoff(backoff)
+		bp.log.Warnw("unable to push the chain", "to", t.peer.Address(), "retry_in", backoff, "err", err)
+		retry = bp.opts.clock.After(backoff)
+	}
+}
+
+// pushMissing pushes the rounds the target is missing up to our last one, in order, throttled as exports of the chain.
+// Until the target acknowledges a push, we don't know where it is: we push our last round and it tells us.
+func (bp *BeaconProcess) pushMissing(ctx context.Context, t *pushTarget, store chain.Store, info *drand.ChainInfoPacket) error {
+	last, err := store.Last(ctx)
+	if err != nil {
+		return err
+	}
+	next := last.Round
+	if t.known {
+		next = t.acked + 1
+	}
+	limiter := bp.opts.IOLimiter(IOClassExport)
+	for next <= last.Round {
+		b, err := store.Get(ctx, common.Round(next))
+		if err != nil {
+			return fmt.Errorf("unable to read round %d: %w", next, err)
+		}
+		if err := limiter.Wait(ctx, len(b.Signature)+len(b.PreviousSig)+8); err != nil {
+			return err
+		}
+		ack, err := bp.privGateway.PushBeacon(ctx, t.peer, &drand.PushedBeacon{
+			Beacon:   &drand.BeaconPacket{Round: b.Round, Signature: b.Signature, PreviousSignature: b.PreviousSig},
+			Info:     info,
+			Metadata: bp.newMetadata(),
+		})
+		if err != nil {
+			return err
+		}
+		stored := ack.GetLastRound()
+		t.acked, t.known = stored, true
+		metrics.PushedRound(bp.getBeaconID(), t.peer.Address(), stored)
+		if stored+1 == next {
+			// we would push the same round again
+			return fmt.Errorf("round %d wasn't stored", next)
+		}
+		next = stored + 1
+	}
+	return nil
+}
+
+func nextPushBackoff(previous time.Duration) time.Duration {
+	if previous == 0 {
+		return pushRetryBackoff
+	}
+	return min(2*previous, maxPushRetryBackoff)
+}
+
+// PushBeacon stores a beacon pushed by an upstream node if it follows the last one stored, and returns the last round
+// stored. The beacons are only accepted for the chains configured, and only once verified against the public key of
+// the chain, so that anyone pushing can't do more than sending a valid chain.
+func (bp *BeaconProcess) PushBeacon(ctx context.Context, in *drand.PushedBeacon) (*drand.PushBeaconAck, error) {
+	ctx, span := tracer.NewSpan(ctx, "bp.PushBeacon")
+	defer span.End()
+
+	info, err := public.InfoFromProto(in.GetInfo())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid chain info: %v", err)
+	}
+	if !common.CompareBeaconIDs(bp.getBeaconID(), info.ID) {
+		return nil, status.Errorf(codes.InvalidArgument, "the chain is for beacon %q", info.ID)
+	}
+	if !bp.featureEnabled(FeatureChainPush) {
+		return nil, status.Errorf(codes.PermissionDenied, "the %s feature is disabled", FeatureChainPush)
+	}
+	if !bp.acceptsPushes(info.Hash()) {
+		return nil, status.Errorf(codes.PermissionDenied, "pushes aren't accepted for chain %x", info.Hash())
+	}
+
+	bp.state.RLock()
+	running := bp.beacon != nil || bp.syncerCancel != nil
+	bp.state.RUnlock()
+	if running {
+		return nil, status.Errorf(codes.FailedPrecondition, "the node already runs or follows the chain")
+	}
+
+	bp.replica.Lock()
+	defer bp.replica.Unlock()
+	if err := bp.openPushReplica(ctx, info); err != nil {
+		return nil, err
+	}
+
+	last, err := bp.replica.store.Last(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b := &common.Beacon{
+		Round:       in.GetBeacon().GetRound(),
+		Signature:   in.GetBeacon().GetSignature(),
+		PreviousSig: in.GetBeacon().GetPreviousSignature(),
+	}
+	if b.Round == last.Round+1 {
+		if err := bp.replica.sch.VerifyBeacon(b, info.PublicKey); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid beacon %d: %v", b.Round, err)
+		}
+		if err := bp.replica.store.Put(ctx, b); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "unable to store beacon %d: %v", b.Round, err)
+		}
+		last = b
+	}
+	return &drand.PushBeaconAck{LastRound: last.Round, Metadata: bp.newMetadata()}, nil
+}
+
+func (bp *BeaconProcess) acceptsPushes(chainHash []byte) bool {
+	accepted, _ := bp.opts.AcceptedPushes()
+	for _, hash := range accepted {
+		if bytes.Equal(hash, chainHash) {
+			return true
+		}
+	}
+	return false
+}
+
+// openPushReplica opens the copy of the chain the pushed beacons are stored in, if it isn't yet. It must be called with
+// the replica lock held.
+func (bp *BeaconProcess) openPushReplica(ctx context.Context, info *public.Info) error {
+	if bp.replica.store != nil {
+		if !bp.replica.info.Equal(info) {
+			return status.Errorf(codes.FailedPrecondition, "already replicating chain %x", bp.replica.info.Hash())
+		}
+		return nil
+	}
+
+	sch, err := crypto.SchemeFromName(info.GetSchemeName())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid chain info: %v", err)
+	}
+	db, err := bp.createDBStore(chainStoreContext(ctx, sch))
+	if err != nil {
+		return fmt.Errorf("unable to create store: %w", err)
+	}
+	if err := db.Put(ctx, chain.GenesisBeacon(info.GenesisSeed)); err != nil {
+		db.Close()
+		return fmt.Errorf("unable to insert genesis block: %w", err)
+	}
+	ss, err := beacon.NewSchemeStore(ctx, db, sch)
+	if err != nil {
+		db.Close()
+		return err
+	}
+	bp.log.Infow("replicating the chain pushed to us", "chain_hash", info.HashString())
+	bp.replica.store, bp.replica.info, bp.replica.sch = beacon.NewCallbackStore(bp.log, ss), info, sch
+	return nil
+}
+
+// replicatedChain returns the copy of the chain pushed to us and its info, if any
+func (bp *BeaconProcess) replicatedChain() (beacon.CallbackStore, *public.Info) {
+	bp.replica.Lock()
+	defer bp.replica.Unlock()
+	return bp.replica.store, bp.replica.info
+}
+
+// closePushReplica closes the copy of the chain the pushed beacons are stored in, if open
+func (bp *BeaconProcess) closePushReplica() {
+	bp.replica.Lock()
+	defer bp.replica.Unlock()
+	if bp.replica.store == nil {
+		return
+	}
+	if err := bp.replica.store.Close(); err != nil {
+		bp.log.Warnw("unable to close the replica of the chain", "err", err)
+	}
+	bp.replica.store, bp.replica.info, bp.replica.sch = nil, nil, nil
+}
@@ -0,0 +1,169 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/internal/metrics"
+)
+
+// quorumWatchdogPeriod is how often the watchdog projects whether the group can still reach the threshold
+const quorumWatchdogPeriod = 5 * time.Second
+
+// The states of the quorum projected by the watchdog
+const (
+	// quorumOK means the group is expected to reach the threshold with at least one member to spare
+	quorumOK = "ok"
+	// quorumAtRisk means the group is expected to reach the threshold with no member to spare
+	quorumAtRisk = "at_risk"
+	// quorumLost means the group isn't expected to reach the threshold, and rounds are about to be missed
+	quorumLost = "lost"
+)
+
+// The health of a member as seen by the watchdog, from 0 when it isn't expected to contribute its partials to 1
+const (
+	memberHealthy  = 1.0
+	memberDegraded = 0.5
+	memberDown     = 0.0
+)
+
+// quorumWatchdog projects in the background whether the group can reach the threshold from the members reachable
+// and their health, so that operators are warned before the first missed round rather than after. Its zero value is
+// ready to use.
+type quorumWatchdog struct {
+	sync.Mutex
+	stop  context.CancelFunc
+	state string
+}
+
+// projectQuorum returns the number of partials the group is expected to produce from the health of its members, and
+// the state of the quorum given the threshold
+func projectQuorum(health []float64, threshold int) (expected float64, state string) {
+	for _, h := range health {
+		expected += h
+	}
+	switch {
+	case expected < float64(threshold):
+		return expected, quorumLost
+	case expected < float64(threshold+1):
+		return expected, quorumAtRisk
+	default:
+		return expected, quorumOK
+	}
+}
+
+// membersHealth returns the health of each member of the group, ourself included: a member is down when it wasn't
+// reachable at the last connectivity check, and degraded when we recently failed to send it our partials or when it
+// answers too slowly to reliably contribute within a round. We are degraded when the chain store lags behind and down
+// when the beacon isn't running or is paused. It must be called with the state lock held.
+func (bp *BeaconProcess) membersHealth(ctx context.Context) []float64 {
+	health := make([]float64, 0, bp.group.Len())
+
+	self := memberDown
+	if bp.beacon != nil && !bp.pause.paused() {
+		self = memberHealthy
+		last, err := bp.beacon.Store().Last(ctx)
+		expected := common.CurrentRound(bp.opts.clock.Now().Unix(), bp.group.Period, bp.group.GenesisTime)
+		if err != nil || expected > last.GetRound()+1 {
+			self = memberDegraded
+		}
+	}
+	health = append(health, self)
+
+	peers := bp.groupPeers()
+	results, _ := bp.connCache.get(peers)
+	for _, addr := range peers {
+		r, checked := results[addr]
+		switch {
+		case !checked:
+			// not checked yet, we don't raise the alarm without knowing
+			health = append(health, memberHealthy)
+		case !r.ok:
+			health = append(health, memberDown)
+		case bp.beacon != nil && bp.beacon.IsFailing(addr), r.rtt > bp.group.Period/2:
+			health = append(health, memberDegraded)
+		default:
+			health = append(health, memberHealthy)
+		}
+	}
+	return health
+}
+
+// checkQuorum projects whether the group can reach the threshold, publishes it and raises an event when it changes
+func (bp *BeaconProcess) checkQuorum(ctx context.Context) {
+	bp.state.RLock()
+	if bp.group == nil {
+		bp.state.RUnlock()
+		return
+	}
+	threshold := bp.group.Threshold
+	health := bp.membersHealth(ctx)
+	bp.state.RUnlock()
+
+	expected, state := projectQuorum(health, threshold)
+	metrics.QuorumProjection(bp.getBeaconID(), expected, state == quorumLost)
+
+	bp.quorum.Lock()
+	previous := bp.quorum.state
+	bp.quorum.state = state
+	bp.quorum.Unlock()
+	if state == previous {
+		return
+	}
+
+	detail := fmt.Sprintf("%.1f partials expected for a threshold of %d", expected, threshold)
+	switch state {
+	case quorumLost:
+		bp.log.Errorw("CRITICAL: the group isn't expected to reach the threshold, rounds are about to be missed",
+			"expected", expected, "threshold", threshold, "members", len(health))
+		bp.events.record(bp.opts.clock.Now(), eventQuorumLost, detail)
+	case quorumAtRisk:
+		bp.log.Warnw("the group is expected to reach the threshold with no member to spare",
+			"expected", expected, "threshold", threshold, "members", len(health))
+		bp.events.record(bp.opts.clock.Now(), eventQuorumAtRisk, detail)
+	default:
+		if previous != "" {
+			bp.log.Infow("the group is expected to reach the threshold again", "expected", expected, "threshold", threshold)
+			bp.events.record(bp.opts.clock.Now(), eventQuorumRestored, detail)
+		}
+	}
+}
+
+// startQuorumWatchdog projects whether the group can reach the threshold in the background, until the beacon is
+// stopped.
+func (bp *BeaconProcess) startQuorumWatchdog() {
+	bp.quorum.Lock()
+	defer bp.quorum.Unlock()
+	if bp.quorum.stop != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	bp.quorum.stop = cancel
+
+	go func() {
+		ticker := bp.opts.clock.NewTicker(quorumWatchdogPeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.Chan():
+				bp.checkQuorum(ctx)
+			}
+		}
+	}()
+}
+
+// stopQuorumWatchdog stops projecting the quorum
+func (bp *BeaconProcess) stopQuorumWatchdog() {
+	bp.quorum.Lock()
+	defer bp.quorum.Unlock()
+	if bp.quorum.stop != nil {
+		bp.quorum.stop()
+		bp.quorum.stop = nil
+	}
+	bp.quorum.state = ""
+}
@@ -0,0 +1,97 @@
+package core
+
+import (
+	"slices"
+	"sync"
+
+	public "github.com/drand/drand/v2/common/chain"
+	"github.com/drand/drand/v2/internal/chain/beacon"
+)
+
+// relayState is the chain a node follows from prioritized upstreams, which it serves in turn to the nodes following
+// it, so that relay trees can be built out of followers. Its zero value is ready to use.
+type relayState struct {
+	sync.Mutex
+	store beacon.CallbackStore
+	info  *public.Info
+	// downstream holds the relay paths of the nodes syncing the chain from us, by stream
+	downstream map[uint64][]string
+	nextID     uint64
+	// changed is notified when the relay path of the node grows
+	changed chan struct{}
+}
+
+// startRelay serves the chain followed to the nodes following us, and returns the channel notified when our relay
+// path grows
+func (bp *BeaconProcess) startRelay(store beacon.CallbackStore, info *public.Info) <-chan struct{} {
+	bp.relay.Lock()
+	defer bp.relay.Unlock()
+	bp.relay.store, bp.relay.info = store, info
+	bp.relay.downstream = make(map[uint64][]string)
+	bp.relay.changed = make(chan struct{}, 1)
+	return bp.relay.changed
+}
+
+// stopRelay stops serving the chain followed. The streams already serving it end once its store is closed.
+func (bp *BeaconProcess) stopRelay() {
+	bp.relay.Lock()
+	defer bp.relay.Unlock()
+	bp.relay.store, bp.relay.info = nil, nil
+	bp.relay.downstream, bp.relay.changed = nil, nil
+}
+
+// relayedChain returns the chain relayed by the node, nil if it doesn't relay any
+func (bp *BeaconProcess) relayedChain() (beacon.CallbackStore, *public.Info) {
+	bp.relay.Lock()
+	defer bp.relay.Unlock()
+	return bp.relay.store, bp.relay.info
+}
+
+// relayPath returns the addresses of the nodes the chain we follow is relayed to: ours, and the ones of the nodes
+// following us, directly or not
+func (bp *BeaconProcess) relayPath() []string {
+	bp.relay.Lock()
+	defer bp.relay.Unlock()
+	return bp.relayPathLocked()
+}
+
+// relayPathLocked returns the relay path of the node. It must be called with the relay lock held.
+func (bp *BeaconProcess) relayPathLocked() []string {
+	path := []string{bp.priv.Public.Address()}
+	for _, downstream := range bp.relay.downstream {
+		for _, addr := range downstream {
+			if !slices.Contains(path, addr) {
+				path = append(path, addr)
+			}
+		}
+	}
+	return path
+}
+
+// addDownstream records the relay path of a node syncing the chain from us, and returns the function to call once the
+// sync ends. Our own sync is restarted when the path brings new nodes, for our upstream to know about them.
+func (bp *BeaconProcess) addDownstream(path []string) func() {
+	bp.relay.Lock()
+	defer bp.relay.Unlock()
+	if bp.relay.downstream == nil {
+		return func() {}
+	}
+
+	before := len(bp.relayPathLocked())
+	id := bp.relay.nextID
+	bp.relay.nextID++
+	bp.relay.downstream[id] = path
+	if len(bp.relayPathLocked()) > before {
+		select {
+		case bp.relay.changed <- struct{}{}:
+		default:
+		}
+	}
+
+	downstream := bp.relay.downstream
+	return func() {
+		bp.relay.Lock()
+		defer bp.relay.Unlock()
+		delete(downstream, id)
+	}
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/prometheus/procfs"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/internal/chain"
+	"github.com/drand/drand/v2/internal/chain/boltdb"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// minCPUSampleInterval is the shortest interval the CPU usage of the process is computed over. The status requests
+// closer to each other get the usage of the previous interval.
+const minCPUSampleInterval = 5 * time.Second
+
+// processCPU is the last sample of the CPU time used by the process, shared by all the beacons of the daemon as
+// they run in the same process
+var processCPU struct {
+	sync.Mutex
+	at      time.Time
+	seconds float64
+	percent float64
+}
+
+// resourceUsage returns the usage of the resources of the machine by the daemon, and the size of the chain store
+// of the beacon. It must be called with the state lock held.
+func (bp *BeaconProcess) resourceUsage() *drand.ResourceUsage {
+	usage := &drand.ResourceUsage{Goroutines: uint32(runtime.NumGoroutine())}
+	if bp.opts.dbStorageEngine == chain.BoltDB {
+		dbPath := path.Join(bp.opts.DBFolder(common.GetCanonicalBeaconID(bp.beaconID)), boltdb.BoltFileName)
+		if info, err := os.Stat(dbPath); err == nil {
+			usage.StoreBytes = uint64(info.Size())
+		}
+	}
+
+	proc, err := procfs.Self()
+	if err != nil {
+		// there is no proc filesystem on this platform
+		return usage
+	}
+	if stat, err := proc.Stat(); err == nil {
+		usage.RssBytes = uint64(stat.ResidentMemory())
+		usage.CpuPercent = cpuPercent(time.Now(), stat)
+	}
+	if fds, err := proc.FileDescriptorsLen(); err == nil {
+		usage.OpenFds = uint32(fds)
+	}
+	if limits, err := proc.Limits(); err == nil && limits.OpenFiles != math.MaxUint64 {
+		usage.MaxFds = limits.OpenFiles
+	}
+	return usage
+}
+
+// cpuPercent returns the CPU time used by the process since the previous sample, or since it started for the first
+// one, in percent of a single core
+func cpuPercent(now time.Time, stat procfs.ProcStat) float64 {
+	processCPU.Lock()
+	defer processCPU.Unlock()
+
+	if processCPU.at.IsZero() {
+		started, err := stat.StartTime()
+		if err != nil {
+			processCPU.at, processCPU.seconds = now, stat.CPUTime()
+			return 0
+		}
+		processCPU.at = time.Unix(0, int64(started*float64(time.Second)))
+	}
+	if elapsed := now.Sub(processCPU.at); elapsed >= minCPUSampleInterval {
+		processCPU.percent = 100 * (stat.CPUTime() - processCPU.seconds) / elapsed.Seconds()
+		processCPU.at, processCPU.seconds = now, stat.CPUTime()
+	}
+	return processCPU.percent
+}
+
+// byteSize formats a number of bytes with the largest binary unit it is at least one of
+func byteSize(n uint64) string {
+	const unit = 1024
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+	size, i := float64(n), 0
+	for size >= unit && i < len(units)-1 {
+		size /= unit
+		i++
+	}
+	return fmt.Sprintf("%.1f %s", size, units[i])
+}
@@ -0,0 +1,144 @@
+package core
+
+import (
+	"context"
+	gonet "net"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/internal/net"
+)
+
+// loadCallbackID is the ID of the callback reporting the lateness of the new beacons to the load shedder
+const loadCallbackID = "load-shedding"
+
+// startLoadReporting reports how late each new beacon is produced to the load shedder of the node, which sheds the
+// low-priority public requests while the chain is late. Nothing is reported if the load isn't shed.
+func (bp *BeaconProcess) startLoadReporting() {
+	shedder := bp.opts.LoadShedder()
+	if shedder == nil {
+		return
+	}
+
+	bp.state.RLock()
+	b := bp.beacon
+	group := bp.group
+	bp.state.RUnlock()
+	if b == nil || group == nil {
+		return
+	}
+
+	beaconID := bp.getBeaconID()
+	b.AddCallback(context.Background(), loadCallbackID, func(beacon *common.Beacon, closed bool) {
+		if closed {
+			shedder.Forget(beaconID)
+			return
+		}
+		due := time.Unix(common.TimeOfRound(group.Period, group.GenesisTime, common.Round(beacon.GetRound())), 0)
+		shedder.Observe(beaconID, due, bp.opts.clock.Now(), group.Period)
+	})
+}
+
+// stopLoadReporting stops reporting the lateness of the beacons, which don't count towards shedding the load
+// anymore. It must be called with the state lock held.
+func (bp *BeaconProcess) stopLoadReporting() {
+	shedder := bp.opts.LoadShedder()
+	if shedder == nil {
+		return
+	}
+	if bp.beacon != nil {
+		bp.beacon.RemoveCallback(context.Background(), loadCallbackID)
+	}
+	shedder.Forget(bp.getBeaconID())
+}
+
+// memberIPsTTL is how long the addresses the members of the groups resolve to are kept
+const memberIPsTTL = time.Minute
+
+// memberLookupTimeout bounds the resolution of the host of a member
+const memberLookupTimeout = time.Second
+
+// memberIPs caches the IP addresses of the members of the groups of the node
+type memberIPs struct {
+	sync.Mutex
+	ips        map[string]bool
+	resolvedAt time.Time
+}
+
+// fromGroupMember tells whether the request comes from the IP address of a member of a group of the node, whose
+// syncs aren't shed
+func (dd *DrandDaemon) fromGroupMember(ctx context.Context) bool {
+	ip := remoteIP(net.RemoteAddress(ctx))
+	if ip == "" {
+		return false
+	}
+	return dd.groupMemberIPs()[ip]
+}
+
+// groupMemberIPs returns the IP addresses the hosts of the members of the groups resolve to, resolved again once
+// memberIPsTTL elapsed
+func (dd *DrandDaemon) groupMemberIPs() map[string]bool {
+	dd.memberIPs.Lock()
+	defer dd.memberIPs.Unlock()
+	now := dd.opts.clock.Now()
+	if dd.memberIPs.ips != nil && now.Sub(dd.memberIPs.resolvedAt) < memberIPsTTL {
+		return dd.memberIPs.ips
+	}
+
+	ips := make(map[string]bool)
+	for _, host := range dd.groupMemberHosts() {
+		if ip := gonet.ParseIP(host); ip != nil {
+			ips[ip.String()] = true
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), memberLookupTimeout)
+		addrs, err := gonet.DefaultResolver.LookupHost(ctx, host)
+		cancel()
+		if err != nil {
+			dd.log.Debugw("unable to resolve the host of a member", "host", host, "err", err)
+			continue
+		}
+		for _, addr := range addrs {
+			ips[remoteIP(addr)] = true
+		}
+	}
+	dd.memberIPs.ips, dd.memberIPs.resolvedAt = ips, now
+	return ips
+}
+
+// groupMemberHosts returns the hosts of the members of the groups of the beacons running on the node
+func (dd *DrandDaemon) groupMemberHosts() []string {
+	dd.state.RLock()
+	defer dd.state.RUnlock()
+	var hosts []string
+	for _, bp := range dd.beaconProcesses {
+		bp.state.RLock()
+		group := bp.group
+		bp.state.RUnlock()
+		if group == nil {
+			continue
+		}
+		for _, node := range group.Nodes {
+			host, _, err := gonet.SplitHostPort(node.Address())
+			if err != nil {
+				host = node.Address()
+			}
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// remoteIP returns the IP address of the given address, with or without a port, in its canonical form, empty if it
+// isn't one
+func remoteIP(addr string) string {
+	if host, _, err := gonet.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	ip := gonet.ParseIP(addr)
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
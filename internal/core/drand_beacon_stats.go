@@ -0,0 +1,240 @@
+package core
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/drand/drand/v2/common"
+	public "github.com/drand/drand/v2/common/chain"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/internal/chain"
+	chainerrors "github.com/drand/drand/v2/internal/chain/errors"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// statsNamespace is the namespace of the metadata of the chain store caching the randomness statistics
+const statsNamespace = public.InternalNamespacePrefix + "stats"
+
+const (
+	// maxCachedStats bounds the number of statistics cached, the oldest ones being evicted first
+	maxCachedStats = 64
+	// statsCacheTTL is how long cached statistics are served, so that the ones computed before a repair or a restore
+	// of the chain store don't live forever
+	statsCacheTTL = 24 * time.Hour
+	// legacyStatsPrefix starts the keys the statistics were cached at before the metadata had namespaces
+	legacyStatsPrefix = "randomness-stats:"
+)
+
+// RandomnessStats computes statistical summaries of the randomness of the given range of rounds. They don't prove
+// anything about the quality of the randomness, but give consumers an easy way to spot gross anomalies. Since the
+// stored rounds never change, the results are cached in the chain store when it supports metadata.
+func (bp *BeaconProcess) RandomnessStats(ctx context.Context, in *drand.RandomnessStatsRequest) (*drand.RandomnessStatsResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "bp.RandomnessStats")
+	defer span.End()
+
+	from, to := in.GetFrom(), in.GetTo()
+	if from == 0 {
+		// the genesis round has no randomness
+		from = 1
+	}
+	if to < from {
+		return nil, fmt.Errorf("invalid range of rounds [%d, %d]", from, to)
+	}
+
+	bp.state.RLock()
+	store := bp.dbStore
+	bp.state.RUnlock()
+	if store == nil {
+		return nil, errors.New("no chain store to compute statistics over")
+	}
+
+	last, err := store.Last(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if last.GetRound() < to {
+		return nil, fmt.Errorf("round %d isn't stored yet, the last stored round is %d", to, last.GetRound())
+	}
+
+	cacheKey := fmt.Sprintf("randomness:%d-%d", from, to)
+	cache, err := public.NewMetadata(store, statsNamespace)
+	canCache := err == nil
+	if canCache {
+		if resp := bp.cachedStats(ctx, cache, cacheKey); resp != nil {
+			resp.Metadata = bp.newMetadata()
+			return resp, nil
+		}
+	}
+
+	stats := new(randomnessStats)
+	limiter := bp.opts.IOLimiter(IOClassStats)
+	err = store.Cursor(ctx, func(ctx context.Context, c chain.Cursor) error {
+		b, err := c.Seek(ctx, common.Round(from))
+		for ; b != nil && b.GetRound() <= to; b, err = c.Next(ctx) {
+			if err != nil {
+				return err
+			}
+			if err := limiter.Wait(ctx, len(b.Signature)+len(b.PreviousSig)+8); err != nil {
+				return err
+			}
+			stats.add(b.GetRandomness())
+		}
+		return err
+	})
+	if err != nil && !errors.Is(err, chainerrors.ErrNoBeaconStored) {
+		return nil, err
+	}
+
+	resp := stats.toProto(from, to)
+	if canCache {
+		if err := bp.cacheStats(ctx, store, cache, cacheKey, resp); err != nil {
+			bp.log.Warnw("unable to cache randomness statistics", "from", from, "to", to, "err", err)
+		}
+	}
+	resp.Metadata = bp.newMetadata()
+	return resp, nil
+}
+
+// cachedStats returns the statistics cached at the given key, nil if they aren't cached or expired
+func (bp *BeaconProcess) cachedStats(ctx context.Context, cache *public.Metadata, key string) *drand.RandomnessStatsResponse {
+	cached, err := cache.Get(ctx, key)
+	if err != nil || cached == nil {
+		return nil
+	}
+	cachedAt, encoded, ok := decodeCachedStats(cached)
+	if !ok || bp.opts.clock.Now().Sub(cachedAt) > statsCacheTTL {
+		return nil
+	}
+	resp := new(drand.RandomnessStatsResponse)
+	if err := proto.Unmarshal(encoded, resp); err != nil {
+		bp.log.Warnw("ignoring corrupted cached randomness statistics", "key", key, "err", err)
+		return nil
+	}
+	resp.Cached = true
+	return resp
+}
+
+// cacheStats caches the statistics at the given key, evicting the expired statistics and the oldest ones beyond
+// maxCachedStats, as well as the ones cached by the previous versions
+func (bp *BeaconProcess) cacheStats(ctx context.Context, store chain.Store, cache *public.Metadata, key string,
+	resp *drand.RandomnessStatsResponse) error {
+	encoded, err := proto.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	now := bp.opts.clock.Now()
+	cached, err := cache.List(ctx)
+	if err != nil {
+		return err
+	}
+	type entry struct {
+		key      string
+		cachedAt time.Time
+	}
+	entries := make([]entry, 0, len(cached))
+	for k, v := range cached {
+		cachedAt, _, ok := decodeCachedStats(v)
+		if !ok || now.Sub(cachedAt) > statsCacheTTL {
+			if err := cache.Delete(ctx, k); err != nil {
+				return err
+			}
+			continue
+		}
+		if k != key {
+			entries = append(entries, entry{key: k, cachedAt: cachedAt})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].cachedAt.Before(entries[j].cachedAt)
+	})
+	for len(entries) >= maxCachedStats {
+		if err := cache.Delete(ctx, entries[0].key); err != nil {
+			return err
+		}
+		entries = entries[1:]
+	}
+
+	if ms, ok := store.(public.MetadataStore); ok {
+		legacy, err := ms.ListMetadata(ctx, legacyStatsPrefix)
+		if err != nil {
+			return err
+		}
+		for k := range legacy {
+			if err := ms.DeleteMetadata(ctx, k); err != nil {
+				return err
+			}
+		}
+	}
+
+	value := binary.BigEndian.AppendUint64(nil, uint64(now.Unix()))
+	return cache.Put(ctx, key, append(value, encoded...))
+}
+
+// decodeCachedStats splits a cached value into the time the statistics were cached at and their encoding
+func decodeCachedStats(value []byte) (time.Time, []byte, bool) {
+	if len(value) < 8 {
+		return time.Time{}, nil, false
+	}
+	return time.Unix(int64(binary.BigEndian.Uint64(value)), 0), value[8:], true
+}
+
+// randomnessStats accumulates the statistics of a stream of randomness, read most significant bit first
+type randomnessStats struct {
+	rounds     uint64
+	bits       uint64
+	ones       uint64
+	bytes      [256]uint64
+	runs       uint64
+	longestRun uint64
+	currentRun uint64
+	lastBit    byte
+}
+
+func (s *randomnessStats) add(randomness []byte) {
+	s.rounds++
+	for _, v := range randomness {
+		s.bytes[v]++
+		for i := 7; i >= 0; i-- {
+			bit := (v >> i) & 1
+			if s.bits == 0 || bit != s.lastBit {
+				s.runs++
+				s.currentRun = 0
+			}
+			s.bits++
+			s.ones += uint64(bit)
+			s.currentRun++
+			s.longestRun = max(s.longestRun, s.currentRun)
+			s.lastBit = bit
+		}
+	}
+}
+
+func (s *randomnessStats) toProto(from, to uint64) *drand.RandomnessStatsResponse {
+	resp := &drand.RandomnessStatsResponse{
+		From:       from,
+		To:         to,
+		Rounds:     s.rounds,
+		Bits:       s.bits,
+		Ones:       s.ones,
+		Runs:       s.runs,
+		LongestRun: s.longestRun,
+	}
+	if s.bits == 0 {
+		return resp
+	}
+
+	resp.BitBalance = float64(s.ones) / float64(s.bits)
+	resp.MeanRunLength = float64(s.bits) / float64(s.runs)
+	expected := float64(s.bits/8) / float64(len(s.bytes))
+	for _, observed := range s.bytes {
+		diff := float64(observed) - expected
+		resp.ByteChiSquare += diff * diff / expected
+	}
+	return resp
+}
@@ -0,0 +1,125 @@
+package core
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/internal/chain/beacon"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// statusStreamPoll is how often the status subscriptions check the state which doesn't record events when it
+// changes, e.g. the progress of the syncer
+const statusStreamPoll = time.Second
+
+// statusStreamCallbackID prefixes the ids of the chain store callbacks of the status subscriptions
+const statusStreamCallbackID = "status-stream-"
+
+// statusStreams numbers the status subscriptions, so that their callbacks don't replace each other
+var statusStreams atomic.Uint64
+
+// StatusStream pushes the status of the beacon process when it is subscribed to, then whenever it changes: an event
+// is recorded, e.g. the beacon starts or the DKG completes, a new beacon is stored, or the periodic check finds it
+// different. The status is also pushed at the interval of the request, if any, even if it didn't change. The usage
+// of the resources changes all the time, it is only refreshed when the status is pushed.
+func (bp *BeaconProcess) StatusStream(in *drand.StatusStreamRequest, stream drand.Control_StatusStreamServer) error {
+	ctx := stream.Context()
+	id := statusStreamCallbackID + strconv.FormatUint(statusStreams.Add(1), 10)
+
+	// a single pending notification is enough, the status is read again when it is consumed
+	stored := make(chan struct{}, 1)
+	var watched *beacon.Handler
+	defer func() {
+		if watched != nil {
+			watched.RemoveCallback(context.Background(), id)
+		}
+	}()
+
+	poll := bp.opts.clock.NewTicker(statusStreamPoll)
+	defer poll.Stop()
+	var interval <-chan time.Time
+	if in.GetIntervalSeconds() > 0 {
+		ticker := bp.opts.clock.NewTicker(time.Duration(in.GetIntervalSeconds()) * time.Second)
+		defer ticker.Stop()
+		interval = ticker.Chan()
+	}
+
+	var last *drand.StatusResponse
+	force := true
+	for {
+		// watched before reading the status, so that an event recorded in between isn't missed
+		changed := bp.events.watch()
+		current, b := bp.localStatus(ctx)
+		// the beacon handler is replaced when the node joins a group or reshares
+		if b != watched {
+			if watched != nil {
+				watched.RemoveCallback(ctx, id)
+			}
+			if b != nil {
+				b.AddCallback(ctx, id, func(_ *common.Beacon, closed bool) {
+					if closed {
+						return
+					}
+					select {
+					case stored <- struct{}{}:
+					default:
+					}
+				})
+			}
+			watched = b
+		}
+
+		if force || statusChanged(last, current) {
+			if err := stream.Send(current); err != nil {
+				return err
+			}
+			last = current
+		}
+		force = false
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changed:
+		case <-stored:
+		case <-poll.Chan():
+		case <-interval:
+			force = true
+		}
+	}
+}
+
+// statusChanged tells whether the status changed, other than the usage of the resources
+func statusChanged(last, current *drand.StatusResponse) bool {
+	if last == nil {
+		return true
+	}
+	last, current = proto.Clone(last).(*drand.StatusResponse), proto.Clone(current).(*drand.StatusResponse)
+	last.Resources, current.Resources = nil, nil
+	return !proto.Equal(last, current)
+}
+
+// localStatus returns the status of the beacon process with the cached connectivity to the group, so that it can be
+// read repeatedly without contacting the other nodes, and the beacon handler it was read from
+func (bp *BeaconProcess) localStatus(ctx context.Context) (*drand.StatusResponse, *beacon.Handler) {
+	bp.state.RLock()
+	packet := bp.status(ctx)
+	b := bp.beacon
+	var peers []string
+	if bp.beacon != nil && bp.group != nil {
+		peers = bp.groupPeers()
+	}
+	bp.state.RUnlock()
+
+	if conns, details, checkedAt := bp.cachedConnectivity(peers); len(conns) > 0 {
+		packet.Connections = conns
+		packet.ConnectionDetails = details
+		packet.ConnectionsCheckedAt = checkedAt.Unix()
+	}
+	return packet, b
+}
@@ -0,0 +1,400 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/drand/drand/v2/common"
+	public "github.com/drand/drand/v2/common/chain"
+	"github.com/drand/drand/v2/common/key"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/chain"
+	"github.com/drand/drand/v2/internal/chain/memdb"
+	"github.com/drand/drand/v2/internal/net"
+	"github.com/drand/drand/v2/protobuf/drand"
+	"github.com/drand/kyber/share"
+)
+
+// subBeaconHistory is the number of rounds of each sub-beacon kept
+const subBeaconHistory = 2048
+
+// subBeaconsNamespace is the namespace of the metadata of the chain store keeping the rounds of the sub-beacons, so
+// that they survive a restart
+const subBeaconsNamespace = public.InternalNamespacePrefix + "subbeacons"
+
+// SubBeacon is a randomness stream derived from the distributed key of the group, with its own period. Its messages
+// are domain separated from the ones of the main chain, so no extra DKG is needed to run it.
+type SubBeacon struct {
+	Name   string
+	Period time.Duration
+}
+
+// ParseSubBeacons parses sub-beacons given as "name=period", e.g. "hourly=1h"
+func ParseSubBeacons(specs []string) ([]SubBeacon, error) {
+	subBeacons := make([]SubBeacon, 0, len(specs))
+	seen := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		name, period, found := strings.Cut(spec, "=")
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid sub-beacon %q, expected name=period", spec)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("sub-beacon %q is defined twice", name)
+		}
+		d, err := time.ParseDuration(period)
+		if err != nil {
+			return nil, fmt.Errorf("invalid period for sub-beacon %q: %w", name, err)
+		}
+		if d < time.Second || d%time.Second != 0 {
+			return nil, fmt.Errorf("the period of sub-beacon %q must be a whole number of seconds", name)
+		}
+		seen[name] = true
+		subBeacons = append(subBeacons, SubBeacon{Name: name, Period: d})
+	}
+	return subBeacons, nil
+}
+
+// subBeaconMessage returns the message the group threshold signs for a round of a sub-beacon
+func subBeaconMessage(beaconID, name string, round uint64) []byte {
+	h := sha256.New()
+	h.Write([]byte("drand-sub-beacon:" + common.GetCanonicalBeaconID(beaconID) + ":" + name + ":"))
+	h.Write(binary.BigEndian.AppendUint64(nil, round))
+	return h.Sum(nil)
+}
+
+type subBeaconStream struct {
+	SubBeacon
+	store chain.Store
+	// the rounds kept in the chain store, nil when it can't keep metadata
+	history *public.Metadata
+	pending map[uint64]map[int][]byte
+}
+
+// subBeaconKey returns the key of the metadata keeping the given round of the sub-beacon
+func subBeaconKey(name string, round uint64) string {
+	return name + ":" + strconv.FormatUint(round, 10)
+}
+
+// subBeacons holds the streams derived from the distributed key. Its zero value is ready to use.
+type subBeacons struct {
+	sync.Mutex
+	streams map[string]*subBeaconStream
+	stop    context.CancelFunc
+}
+
+func (s *subBeacons) get(name string) (*subBeaconStream, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	stream, ok := s.streams[name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown sub-beacon %q", name)
+	}
+	return stream, nil
+}
+
+// startSubBeacons signs the rounds of the configured sub-beacons with the rest of the group, until the beacon is
+// stopped.
+func (bp *BeaconProcess) startSubBeacons() {
+	specs, err := ParseSubBeacons(bp.opts.subBeacons)
+	if err != nil {
+		bp.log.Errorw("Invalid sub-beacons", "err", err)
+		return
+	}
+	if len(specs) == 0 {
+		return
+	}
+
+	bp.subBeacons.Lock()
+	defer bp.subBeacons.Unlock()
+	if bp.subBeacons.stop != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	bp.subBeacons.stop = cancel
+
+	if bp.subBeacons.streams == nil {
+		bp.subBeacons.streams = make(map[string]*subBeaconStream, len(specs))
+	}
+	bp.state.RLock()
+	dbStore := bp.dbStore
+	bp.state.RUnlock()
+	var history *public.Metadata
+	if dbStore != nil {
+		history, _ = public.NewMetadata(dbStore, subBeaconsNamespace)
+	}
+	for _, spec := range specs {
+		stream, ok := bp.subBeacons.streams[spec.Name]
+		if !ok {
+			stream = &subBeaconStream{
+				SubBeacon: spec,
+				store:     memdb.NewStore(subBeaconHistory),
+				pending:   make(map[uint64]map[int][]byte),
+			}
+			bp.subBeacons.streams[spec.Name] = stream
+		}
+		if stream.history == nil && history != nil {
+			stream.history = history
+			if err := bp.restoreSubBeacon(ctx, stream); err != nil {
+				bp.log.Warnw("Unable to restore the rounds of the sub-beacon", "name", spec.Name, "err", err)
+			}
+		}
+		go bp.runSubBeacon(ctx, stream)
+	}
+}
+
+// restoreSubBeacon loads the rounds of the sub-beacon kept in the chain store, dropping the ones beyond its history
+func (bp *BeaconProcess) restoreSubBeacon(ctx context.Context, stream *subBeaconStream) error {
+	kept, err := stream.history.List(ctx)
+	if err != nil {
+		return err
+	}
+	rounds := make(map[uint64][]byte)
+	var last uint64
+	for k, sig := range kept {
+		i := strings.LastIndex(k, ":")
+		if i < 0 || k[:i] != stream.Name {
+			continue
+		}
+		round, err := strconv.ParseUint(k[i+1:], 10, 64)
+		if err != nil {
+			continue
+		}
+		rounds[round] = sig
+		last = max(last, round)
+	}
+	for round, sig := range rounds {
+		if round+subBeaconHistory <= last {
+			if err := stream.history.Delete(ctx, subBeaconKey(stream.Name, round)); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := stream.store.Put(ctx, &common.Beacon{Round: round, Signature: sig}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (bp *BeaconProcess) runSubBeacon(ctx context.Context, stream *subBeaconStream) {
+	for {
+		bp.state.RLock()
+		group := bp.group
+		bp.state.RUnlock()
+		if group == nil {
+			return
+		}
+
+		round, at := common.NextRound(bp.opts.clock.Now().Unix(), stream.Period, group.GenesisTime)
+		select {
+		case <-ctx.Done():
+			return
+		case <-bp.opts.clock.After(time.Unix(at, 0).Sub(bp.opts.clock.Now())):
+		}
+
+		if err := bp.signSubBeacon(ctx, stream, round); err != nil {
+			bp.log.Warnw("Unable to sign sub-beacon", "name", stream.Name, "round", round, "err", err)
+		}
+	}
+}
+
+// stopSubBeacons stops signing the rounds of the sub-beacons
+func (bp *BeaconProcess) stopSubBeacons() {
+	bp.subBeacons.Lock()
+	defer bp.subBeacons.Unlock()
+
+	if bp.subBeacons.stop != nil {
+		bp.subBeacons.stop()
+		bp.subBeacons.stop = nil
+	}
+}
+
+// signSubBeacon signs our partial of the given round of the sub-beacon and sends it to the other members of the group
+func (bp *BeaconProcess) signSubBeacon(ctx context.Context, stream *subBeaconStream, round uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "bp.signSubBeacon")
+	defer span.End()
+
+	bp.state.RLock()
+	if bp.pause.paused() {
+		bp.state.RUnlock()
+		bp.log.Debugw("not signing sub-beacon partial, this node is paused", "name", stream.Name, "round", round)
+		return nil
+	}
+	if bp.beacon == nil || bp.group == nil || bp.share == nil {
+		bp.state.RUnlock()
+		return errors.New("this node isn't running a beacon")
+	}
+	msg := subBeaconMessage(bp.getBeaconID(), stream.Name, round)
+	partial, err := bp.group.Scheme.ThresholdScheme.Sign(bp.share.PrivateShare(), msg)
+	if err != nil {
+		bp.state.RUnlock()
+		return err
+	}
+	bp.addSubBeaconPartial(ctx, stream, round, msg, partial, bp.group, bp.share.PubPoly())
+
+	packet := &drand.PartialSubBeaconPacket{
+		Name:       stream.Name,
+		Round:      round,
+		PartialSig: partial,
+		Metadata:   bp.newMetadata(),
+	}
+	nodes := bp.group.Nodes
+	self := bp.priv.Public.Address()
+	bp.state.RUnlock()
+
+	sendToGroup(nodes, self, func(peer net.Peer) {
+		if err := bp.privGateway.PartialSubBeacon(ctx, peer, packet); err != nil {
+			bp.log.Debugw("Unable to send sub-beacon partial", "to", peer.Address(), "name", stream.Name, "round", round, "err", err)
+		}
+	})
+	return nil
+}
+
+// PartialSubBeacon receives the partial signature of a round of a sub-beacon from another member of the group
+func (bp *BeaconProcess) PartialSubBeacon(ctx context.Context, in *drand.PartialSubBeaconPacket) (*drand.Empty, error) {
+	ctx, span := tracer.NewSpan(ctx, "bp.PartialSubBeacon")
+	defer span.End()
+
+	bp.state.RLock()
+	defer bp.state.RUnlock()
+
+	if bp.beacon == nil || bp.group == nil || bp.share == nil {
+		return nil, errors.New("DKG not finished yet")
+	}
+	stream, err := bp.subBeacons.get(in.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	// we accept the partials of the current round and, to account for clock drifts, of its neighbours
+	current := common.CurrentRound(bp.opts.clock.Now().Unix(), stream.Period, bp.group.GenesisTime)
+	if in.GetRound()+1 < current || in.GetRound() > current+1 {
+		return nil, fmt.Errorf("round %d of sub-beacon %q is too far from the current one %d", in.GetRound(), in.GetName(), current)
+	}
+
+	msg := subBeaconMessage(bp.getBeaconID(), stream.Name, in.GetRound())
+	pubPoly := bp.share.PubPoly()
+	if err := bp.group.Scheme.ThresholdScheme.VerifyPartial(pubPoly, msg, in.GetPartialSig()); err != nil {
+		return nil, fmt.Errorf("invalid sub-beacon partial signature: %w", err)
+	}
+	bp.addSubBeaconPartial(ctx, stream, in.GetRound(), msg, in.GetPartialSig(), bp.group, pubPoly)
+
+	return &drand.Empty{Metadata: bp.newMetadata()}, nil
+}
+
+// addSubBeaconPartial records a valid partial signature of a round of the sub-beacon, and stores the round once
+// there are enough of them
+func (bp *BeaconProcess) addSubBeaconPartial(ctx context.Context, stream *subBeaconStream, round uint64, msg, partial []byte,
+	group *key.Group, pubPoly *share.PubPoly) {
+	bp.subBeacons.Lock()
+	defer bp.subBeacons.Unlock()
+
+	if _, err := stream.store.Get(ctx, common.Round(round)); err == nil {
+		return
+	}
+	sch := group.Scheme.ThresholdScheme
+	idx, err := sch.IndexOf(partial)
+	if err != nil {
+		return
+	}
+	// we only keep the partials of the last few rounds
+	for r := range stream.pending {
+		if r+2 < round {
+			delete(stream.pending, r)
+		}
+	}
+	if stream.pending[round] == nil {
+		stream.pending[round] = make(map[int][]byte)
+	}
+	stream.pending[round][idx] = partial
+	if len(stream.pending[round]) < group.Threshold {
+		return
+	}
+
+	partials := make([][]byte, 0, len(stream.pending[round]))
+	for _, p := range stream.pending[round] {
+		partials = append(partials, p)
+	}
+	sig, err := sch.Recover(pubPoly, msg, partials, group.Threshold, group.Len())
+	if err == nil {
+		err = sch.VerifyRecovered(pubPoly.Commit(), msg, sig)
+	}
+	if err != nil {
+		bp.log.Errorw("Unable to aggregate sub-beacon", "name", stream.Name, "round", round, "err", err)
+		return
+	}
+	delete(stream.pending, round)
+	if err := stream.store.Put(ctx, &common.Beacon{Round: round, Signature: sig}); err != nil {
+		bp.log.Errorw("Unable to store sub-beacon", "name", stream.Name, "round", round, "err", err)
+		return
+	}
+	if stream.history != nil {
+		if err := stream.history.Put(ctx, subBeaconKey(stream.Name, round), sig); err != nil {
+			bp.log.Warnw("Unable to keep sub-beacon, it won't survive a restart", "name", stream.Name, "round", round, "err", err)
+		} else if round > subBeaconHistory {
+			_ = stream.history.Delete(ctx, subBeaconKey(stream.Name, round-subBeaconHistory))
+		}
+	}
+	bp.log.Debugw("Sub-beacon round signed", "name", stream.Name, "round", round)
+}
+
+// subBeaconRand returns the beacon of the sub-beacon requested, the last one if the round is 0
+func (bp *BeaconProcess) subBeaconRand(ctx context.Context, in *drand.PublicRandRequest) (*drand.PublicRandResponse, error) {
+	stream, err := bp.subBeacons.get(in.GetSubBeacon())
+	if err != nil {
+		return nil, err
+	}
+
+	var b *common.Beacon
+	if in.GetRound() == 0 {
+		b, err = stream.store.Last(ctx)
+	} else {
+		b, err = stream.store.Get(ctx, common.Round(in.GetRound()))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't retrieve round %d of sub-beacon %q: %w", in.GetRound(), stream.Name, err)
+	}
+
+	response := beaconToProto(b)
+	response.Randomness = crypto.RandomnessFromSignature(b.Signature)
+	response.Metadata = bp.newMetadata()
+	return response, nil
+}
+
+// SubBeacons lists the sub-beacons run by this node
+func (bp *BeaconProcess) SubBeacons(ctx context.Context, _ *drand.SubBeaconsRequest) (*drand.SubBeaconsResponse, error) {
+	_, span := tracer.NewSpan(ctx, "bp.SubBeacons")
+	defer span.End()
+
+	bp.state.RLock()
+	defer bp.state.RUnlock()
+	if bp.group == nil {
+		return nil, ErrNoGroupSetup
+	}
+
+	specs, err := ParseSubBeacons(bp.opts.subBeacons)
+	if err != nil {
+		return nil, err
+	}
+	resp := &drand.SubBeaconsResponse{Metadata: bp.newMetadata()}
+	for _, spec := range specs {
+		resp.SubBeacons = append(resp.SubBeacons, &drand.SubBeaconInfo{
+			Name:        spec.Name,
+			Period:      uint32(spec.Period.Seconds()),
+			GenesisTime: bp.group.GenesisTime,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,150 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/internal/chain"
+	chainerrors "github.com/drand/drand/v2/internal/chain/errors"
+	"github.com/drand/drand/v2/internal/iolimit"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// correctionEvents are the events through which the node corrected its copy of the chain
+var correctionEvents = map[string]bool{
+	eventForkDetected:  true,
+	eventStoreRepaired: true,
+}
+
+// ChainSummary returns the head of the chain, its parameters, the number of rounds stored over the last day against
+// the number of rounds due and the last correction of the chain, which is what block explorers display
+func (bp *BeaconProcess) ChainSummary(ctx context.Context, _ *drand.ChainSummaryRequest) (*drand.ChainSummaryResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "bp.ChainSummary")
+	defer span.End()
+
+	bp.state.RLock()
+	if bp.beacon == nil || bp.group == nil {
+		bp.state.RUnlock()
+		return nil, errors.New("drand: beacon generation not started yet")
+	}
+	store := bp.beacon.Store()
+	period := bp.group.Period
+	genesis := bp.group.GenesisTime
+	bp.state.RUnlock()
+
+	head, err := store.Last(ctx)
+	if err != nil {
+		return nil, err
+	}
+	total, err := store.Len(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := bp.opts.clock.Now()
+	// CurrentRound already counts the first round before the genesis
+	var expected, dayStart uint64
+	if now.Unix() >= genesis {
+		expected = common.CurrentRound(now.Unix(), period, genesis)
+	}
+	if since := now.Add(-24 * time.Hour).Unix(); since >= genesis {
+		dayStart = common.CurrentRound(since, period, genesis)
+	}
+	// the rounds after the head aren't stored yet
+	lastDay, err := bp.dayRounds.count(ctx, store, bp.opts.IOLimiter(IOClassStats), dayStart+1, min(expected, head.GetRound()))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &drand.ChainSummaryResponse{
+		HeadRound:             head.GetRound(),
+		HeadSignature:         head.GetSignature(),
+		HeadTime:              common.TimeOfRound(period, genesis, common.Round(head.GetRound())),
+		GenesisTime:           genesis,
+		Period:                uint32(period.Seconds()),
+		ExpectedRound:         expected,
+		TotalRounds:           uint64(total),
+		RoundsLastDay:         lastDay,
+		ExpectedRoundsLastDay: expected - dayStart,
+		Metadata:              bp.newMetadata(),
+	}
+	events := bp.events.recent()
+	for i := len(events) - 1; i >= 0; i-- {
+		if correctionEvents[events[i].GetKind()] {
+			resp.LastCorrection = &drand.ChainCorrection{
+				Time:   events[i].GetTime(),
+				Kind:   events[i].GetKind(),
+				Detail: events[i].GetDetail(),
+			}
+			break
+		}
+	}
+	return resp, nil
+}
+
+// roundCount is the number of rounds stored over a window of rounds, kept to count the rounds of the last day as the
+// window slides by only reading the rounds entering and leaving it. Its zero value is ready to use.
+type roundCount struct {
+	sync.Mutex
+	counted  bool
+	from, to uint64
+	stored   uint64
+}
+
+// count returns the number of rounds stored between the given rounds, both included. The rounds up to the last one
+// stored are expected to stay as they are once counted, as they do unless the chain is corrected.
+func (rc *roundCount) count(ctx context.Context, store chain.Store, limiter *iolimit.Limiter, from, to uint64) (uint64, error) {
+	if to < from {
+		return 0, nil
+	}
+	rc.Lock()
+	defer rc.Unlock()
+
+	if !rc.counted || from < rc.from || from > rc.to+1 || to < rc.to {
+		// the window doesn't overlap the one counted, or went back: it is counted again
+		rc.counted = false
+		stored, err := countRounds(ctx, store, limiter, from, to)
+		if err != nil {
+			return 0, err
+		}
+		rc.counted, rc.from, rc.to, rc.stored = true, from, to, stored
+		return stored, nil
+	}
+
+	left, err := countRounds(ctx, store, limiter, rc.from, from-1)
+	if err != nil {
+		return 0, err
+	}
+	entered, err := countRounds(ctx, store, limiter, rc.to+1, to)
+	if err != nil {
+		return 0, err
+	}
+	rc.from, rc.to, rc.stored = from, to, rc.stored+entered-min(left, rc.stored)
+	return rc.stored, nil
+}
+
+// countRounds returns the number of rounds stored between the given rounds, both included
+func countRounds(ctx context.Context, store chain.Store, limiter *iolimit.Limiter, from, to uint64) (uint64, error) {
+	var count uint64
+	err := store.Cursor(ctx, func(ctx context.Context, c chain.Cursor) error {
+		b, err := c.Seek(ctx, common.Round(from))
+		for ; b != nil && b.GetRound() <= to; b, err = c.Next(ctx) {
+			if err != nil {
+				return err
+			}
+			if err := limiter.Wait(ctx, len(b.Signature)+len(b.PreviousSig)+8); err != nil {
+				return err
+			}
+			count++
+		}
+		return err
+	})
+	if err != nil && !errors.Is(err, chainerrors.ErrNoBeaconStored) {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/v2/internal/chain/beacon"
+	"github.com/drand/drand/v2/internal/metrics"
+)
+
+// supervisor restarts the beacon loop when it fails with an unrecoverable error, instead of leaving the beacon dead
+// until the daemon restarts. Its zero value is ready to use.
+type supervisor struct {
+	sync.Mutex
+	stop context.CancelFunc
+	// the restarts since the beacon loop last ran long enough to be considered stable
+	restarts    int
+	lastRestart time.Time
+}
+
+// superviseBeacon watches the given beacon loop, replacing the one watched before, and restarts it with an
+// exponential backoff if it fails.
+func (bp *BeaconProcess) superviseBeacon(h *beacon.Handler) {
+	bp.supervisor.Lock()
+	defer bp.supervisor.Unlock()
+
+	if bp.supervisor.stop != nil {
+		bp.supervisor.stop()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	bp.supervisor.stop = cancel
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.Failed():
+		}
+		bp.restartBeacon(ctx, h, h.Err())
+	}()
+}
+
+// stopSupervisor stops watching the beacon loop, which is being stopped on purpose
+func (bp *BeaconProcess) stopSupervisor() {
+	bp.supervisor.Lock()
+	defer bp.supervisor.Unlock()
+
+	if bp.supervisor.stop != nil {
+		bp.supervisor.stop()
+		bp.supervisor.stop = nil
+	}
+}
+
+// restartBeacon replaces the failed beacon loop by a new one, which catches up with the network, until it starts or
+// the restarts are exhausted.
+func (bp *BeaconProcess) restartBeacon(ctx context.Context, failed *beacon.Handler, cause error) {
+	for {
+		metrics.BeaconRestart(bp.getBeaconID())
+		bp.events.record(bp.opts.clock.Now(), eventBeaconCrashed, cause.Error())
+
+		backoff, ok := bp.nextRestart()
+		if !ok {
+			bp.log.Errorw("the beacon loop failed too many times, giving up until the daemon restarts",
+				"restarts", DefaultMaxBeaconRestarts, "err", cause)
+			bp.events.record(bp.opts.clock.Now(), eventBeaconGaveUp, fmt.Sprintf("after %d restarts", DefaultMaxBeaconRestarts))
+			return
+		}
+		bp.log.Warnw("the beacon loop failed, restarting it", "err", cause, "in", backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-bp.opts.clock.After(backoff):
+		}
+
+		bp.state.Lock()
+		if bp.beacon != failed {
+			// it was stopped or replaced in the meantime
+			bp.state.Unlock()
+			return
+		}
+		if failed != nil {
+			failed.Stop(context.Background())
+		}
+		bp.beacon = nil
+		bp.state.Unlock()
+
+		// the catchup outlives this supervisor, which the new beacon loop replaces
+		err := bp.StartBeacon(context.Background(), true)
+		if err == nil {
+			bp.log.Infow("restarted the beacon loop")
+			return
+		}
+		cause = fmt.Errorf("unable to restart the beacon loop: %w", err)
+		failed = nil
+	}
+}
+
+// nextRestart returns how long to wait before the next restart, doubling from DefaultBeaconRestartBackoff up to
+// DefaultMaxBeaconRestartBackoff, and false once DefaultMaxBeaconRestarts restarts were made without the beacon
+// loop becoming stable in between.
+func (bp *BeaconProcess) nextRestart() (time.Duration, bool) {
+	bp.supervisor.Lock()
+	defer bp.supervisor.Unlock()
+
+	now := bp.opts.clock.Now()
+	if !bp.supervisor.lastRestart.IsZero() && now.Sub(bp.supervisor.lastRestart) > beaconStableAfter {
+		bp.supervisor.restarts = 0
+	}
+	if bp.supervisor.restarts >= DefaultMaxBeaconRestarts {
+		return 0, false
+	}
+	backoff := DefaultBeaconRestartBackoff << bp.supervisor.restarts
+	if backoff > DefaultMaxBeaconRestartBackoff {
+		backoff = DefaultMaxBeaconRestartBackoff
+	}
+	bp.supervisor.restarts++
+	bp.supervisor.lastRestart = now.Add(backoff)
+	return backoff, true
+}
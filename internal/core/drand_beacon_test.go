@@ -14,12 +14,14 @@ import (
 	kyberDKG "github.com/drand/kyber/share/dkg"
 
 	"github.com/jonboulle/clockwork"
+	"github.com/prometheus/procfs"
 	"github.com/stretchr/testify/require"
 
 	"github.com/drand/drand/v2/common"
 	"github.com/drand/drand/v2/common/testlogger"
 	"github.com/drand/drand/v2/crypto"
 	"github.com/drand/drand/v2/internal/chain"
+	"github.com/drand/drand/v2/internal/chain/boltdb"
 	"github.com/drand/drand/v2/internal/dkg"
 	"github.com/drand/drand/v2/internal/test"
 )
@@ -352,3 +354,19 @@ func TestMigrateOldGroupFileWithLeavers(t *testing.T) {
 	_, err := dt.RunReshare(t, time.Now().Add(10*time.Second), dt.nodes, []*MockNode{})
 	require.NoError(t, err)
 }
+
+func TestResourceUsage(t *testing.T) {
+	bp := &BeaconProcess{beaconID: "default", opts: &Config{configFolder: t.TempDir(), dbStorageEngine: chain.BoltDB}}
+	dbFolder := bp.opts.DBFolder("default")
+	require.NoError(t, os.MkdirAll(dbFolder, 0o700))
+	require.NoError(t, os.WriteFile(path.Join(dbFolder, boltdb.BoltFileName), make([]byte, 2048), 0o600))
+
+	usage := bp.resourceUsage()
+	require.Equal(t, uint64(2048), usage.StoreBytes)
+	require.Equal(t, "2.0 KiB", byteSize(usage.StoreBytes))
+	require.NotZero(t, usage.Goroutines)
+	if _, err := procfs.Self(); err == nil {
+		require.NotZero(t, usage.RssBytes)
+		require.NotZero(t, usage.OpenFds)
+	}
+}
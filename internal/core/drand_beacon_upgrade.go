@@ -0,0 +1,410 @@
+package core
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/key"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/internal/net"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// UpgradeFileName is the file of the beacon folder keeping the coordinated upgrade the node takes part in, so that it
+// survives a restart of the daemon
+const UpgradeFileName = "upgrade.json"
+
+// upgradePlan is the state of a coordinated upgrade: the coordinator proposes a window during which all the nodes
+// of the group restart, in batches small enough for the network to keep producing randomness.
+type upgradePlan struct {
+	Coordinator string `json:"coordinator"`
+	WindowStart int64  `json:"window_start"`
+	WindowEnd   int64  `json:"window_end"`
+	// the signature of the proposal by the coordinator, checked again when the plan is loaded
+	Signature []byte `json:"signature"`
+	// the slot the node restarts in, set once the upgrade has been accepted locally
+	RestartAt int64 `json:"restart_at,omitempty"`
+	SlotEnd   int64 `json:"slot_end,omitempty"`
+	// set once the daemon restarted for the upgrade
+	Restarted    bool            `json:"restarted,omitempty"`
+	Acknowledged map[string]bool `json:"acknowledged"`
+}
+
+func (u *upgradePlan) toProto() *drand.UpgradeStatus {
+	acknowledged := make([]string, 0, len(u.Acknowledged))
+	for addr := range u.Acknowledged {
+		acknowledged = append(acknowledged, addr)
+	}
+	sort.Strings(acknowledged)
+
+	return &drand.UpgradeStatus{
+		Coordinator:  u.Coordinator,
+		WindowStart:  u.WindowStart,
+		WindowEnd:    u.WindowEnd,
+		RestartAt:    u.RestartAt,
+		Acknowledged: acknowledged,
+	}
+}
+
+// over tells whether the window of the upgrade ended
+func (u *upgradePlan) over(now time.Time) bool {
+	return !time.Unix(u.WindowEnd, 0).After(now)
+}
+
+// StartUpgrade proposes an upgrade window to all the members of the group, with this node as the coordinator.
+// The coordinator accepts its own proposal, so the returned status contains its restart time.
+func (bp *BeaconProcess) StartUpgrade(ctx context.Context, in *drand.StartUpgradeRequest) (*drand.UpgradeStatus, error) {
+	ctx, span := tracer.NewSpan(ctx, "bp.StartUpgrade")
+	defer span.End()
+
+	start, end := time.Unix(in.GetWindowStart(), 0), time.Unix(in.GetWindowEnd(), 0)
+	if !start.After(bp.opts.clock.Now()) {
+		return nil, errors.New("the upgrade window must start in the future")
+	}
+	if !end.After(start) {
+		return nil, errors.New("the upgrade window must end after it starts")
+	}
+
+	bp.state.Lock()
+	group := bp.group
+	if group == nil {
+		bp.state.Unlock()
+		return nil, errors.New("no group yet")
+	}
+	self := bp.priv.Public.Addr
+	restartAt, slotEnd, err := upgradeRestartSlot(group, self, start, end)
+	if err != nil {
+		bp.state.Unlock()
+		return nil, err
+	}
+	sig, err := bp.signUpgrade(upgradeProposalMessage(bp.getBeaconID(), self, in.GetWindowStart(), in.GetWindowEnd()))
+	if err != nil {
+		bp.state.Unlock()
+		return nil, err
+	}
+	bp.upgrade = &upgradePlan{
+		Coordinator:  self,
+		WindowStart:  in.GetWindowStart(),
+		WindowEnd:    in.GetWindowEnd(),
+		Signature:    sig,
+		RestartAt:    restartAt.Unix(),
+		SlotEnd:      slotEnd.Unix(),
+		Acknowledged: map[string]bool{self: true},
+	}
+	if err := bp.saveUpgrade(); err != nil {
+		bp.log.Warnw("Unable to save the upgrade, it won't survive a restart", "err", err)
+	}
+	status := bp.upgrade.toProto()
+	bp.state.Unlock()
+
+	proposal := &drand.UpgradeProposal{
+		Coordinator: self,
+		WindowStart: in.GetWindowStart(),
+		WindowEnd:   in.GetWindowEnd(),
+		Signature:   sig,
+		Metadata:    bp.newMetadata(),
+	}
+	for _, node := range group.Nodes {
+		if node.Address() == self {
+			continue
+		}
+		if err := bp.privGateway.ProposeUpgrade(ctx, net.CreatePeer(node.Address()), proposal); err != nil {
+			// the operator can see who didn't acknowledge the upgrade in the status and reach out to them
+			bp.log.Warnw("Unable to send upgrade proposal", "to", node.Address(), "err", err)
+		}
+	}
+
+	bp.events.record(bp.opts.clock.Now(), eventUpgradeProposed, fmt.Sprintf("by %s, from %s to %s", self, start, end))
+	bp.log.Infow("Proposed coordinated upgrade", "windowStart", start, "windowEnd", end, "restartAt", restartAt)
+	return status, nil
+}
+
+// ProposeUpgrade receives the upgrade window proposed by a coordinator. It has to be accepted by the operator
+// with AcceptUpgrade before this node schedules its restart. The windows which already started are refused, so that
+// a proposal signed for a past upgrade can't be replayed.
+func (bp *BeaconProcess) ProposeUpgrade(ctx context.Context, in *drand.UpgradeProposal) (*drand.Empty, error) {
+	_, span := tracer.NewSpan(ctx, "bp.ProposeUpgrade")
+	defer span.End()
+
+	bp.state.Lock()
+	defer bp.state.Unlock()
+
+	msg := upgradeProposalMessage(bp.getBeaconID(), in.GetCoordinator(), in.GetWindowStart(), in.GetWindowEnd())
+	if err := bp.verifyUpgrade(in.GetCoordinator(), msg, in.GetSignature()); err != nil {
+		return nil, err
+	}
+	start, end := time.Unix(in.GetWindowStart(), 0), time.Unix(in.GetWindowEnd(), 0)
+	if !start.After(bp.opts.clock.Now()) {
+		return nil, errors.New("the upgrade window already started")
+	}
+	if !end.After(start) {
+		return nil, errors.New("the upgrade window must end after it starts")
+	}
+	if plan := bp.upgrade; plan != nil && plan.Coordinator == in.GetCoordinator() &&
+		plan.WindowStart == in.GetWindowStart() && plan.WindowEnd == in.GetWindowEnd() {
+		// the proposal was sent again, we keep our acceptance of it
+		return &drand.Empty{Metadata: bp.newMetadata()}, nil
+	}
+	bp.upgrade = &upgradePlan{
+		Coordinator:  in.GetCoordinator(),
+		WindowStart:  in.GetWindowStart(),
+		WindowEnd:    in.GetWindowEnd(),
+		Signature:    in.GetSignature(),
+		Acknowledged: make(map[string]bool),
+	}
+	if err := bp.saveUpgrade(); err != nil {
+		bp.log.Warnw("Unable to save the upgrade, it won't survive a restart", "err", err)
+	}
+
+	bp.events.record(bp.opts.clock.Now(), eventUpgradeProposed,
+		fmt.Sprintf("by %s, from %s to %s", in.GetCoordinator(), start, end))
+	bp.log.Infow("Received coordinated upgrade proposal, waiting for the operator to accept it",
+		"coordinator", in.GetCoordinator(), "windowStart", start, "windowEnd", end)
+	return &drand.Empty{Metadata: bp.newMetadata()}, nil
+}
+
+// AcceptUpgrade acknowledges the pending upgrade to its coordinator and returns the time at which this node
+// should restart.
+func (bp *BeaconProcess) AcceptUpgrade(ctx context.Context, _ *drand.AcceptUpgradeRequest) (*drand.UpgradeStatus, error) {
+	ctx, span := tracer.NewSpan(ctx, "bp.AcceptUpgrade")
+	defer span.End()
+
+	bp.state.Lock()
+	plan := bp.upgrade
+	if plan == nil {
+		bp.state.Unlock()
+		return nil, errors.New("no upgrade has been proposed")
+	}
+	if plan.over(bp.opts.clock.Now()) {
+		bp.state.Unlock()
+		return nil, errors.New("the window of the upgrade proposed is over")
+	}
+	self := bp.priv.Public.Addr
+	coordinator, windowStart, windowEnd := plan.Coordinator, plan.WindowStart, plan.WindowEnd
+	restartAt, slotEnd, err := upgradeRestartSlot(bp.group, self, time.Unix(windowStart, 0), time.Unix(windowEnd, 0))
+	if err != nil {
+		bp.state.Unlock()
+		return nil, err
+	}
+	sig, err := bp.signUpgrade(upgradeAckMessage(bp.getBeaconID(), self, coordinator, windowStart, windowEnd))
+	if err != nil {
+		bp.state.Unlock()
+		return nil, err
+	}
+	bp.state.Unlock()
+
+	ack := &drand.UpgradeAcknowledgement{
+		Address:     self,
+		WindowStart: windowStart,
+		WindowEnd:   windowEnd,
+		Signature:   sig,
+		Metadata:    bp.newMetadata(),
+	}
+	if err := bp.privGateway.AcknowledgeUpgrade(ctx, net.CreatePeer(coordinator), ack); err != nil {
+		return nil, fmt.Errorf("unable to acknowledge the upgrade to %s: %w", coordinator, err)
+	}
+
+	bp.state.Lock()
+	defer bp.state.Unlock()
+	// another proposal may have replaced the one we acknowledged in the meantime
+	if bp.upgrade != plan {
+		return nil, errors.New("the upgrade proposed changed while accepting it, accept the new one again")
+	}
+	plan.RestartAt = restartAt.Unix()
+	plan.SlotEnd = slotEnd.Unix()
+	plan.Acknowledged[self] = true
+	if err := bp.saveUpgrade(); err != nil {
+		bp.log.Warnw("Unable to save the upgrade, it won't survive a restart", "err", err)
+	}
+
+	bp.events.record(bp.opts.clock.Now(), eventUpgradeScheduled, fmt.Sprintf("restart at %s", restartAt))
+	bp.log.Infow("Accepted coordinated upgrade", "coordinator", coordinator, "restartAt", restartAt)
+	return plan.toProto(), nil
+}
+
+// AcknowledgeUpgrade records the acceptance of our upgrade proposal by a member of the group
+func (bp *BeaconProcess) AcknowledgeUpgrade(ctx context.Context, in *drand.UpgradeAcknowledgement) (*drand.Empty, error) {
+	_, span := tracer.NewSpan(ctx, "bp.AcknowledgeUpgrade")
+	defer span.End()
+
+	bp.state.Lock()
+	defer bp.state.Unlock()
+
+	plan := bp.upgrade
+	if plan == nil || plan.Coordinator != bp.priv.Public.Addr {
+		return nil, errors.New("this node is not coordinating any upgrade")
+	}
+	if in.GetWindowStart() != plan.WindowStart || in.GetWindowEnd() != plan.WindowEnd {
+		return nil, errors.New("acknowledgement for a different upgrade window")
+	}
+	msg := upgradeAckMessage(bp.getBeaconID(), in.GetAddress(), plan.Coordinator, in.GetWindowStart(), in.GetWindowEnd())
+	if err := bp.verifyUpgrade(in.GetAddress(), msg, in.GetSignature()); err != nil {
+		return nil, err
+	}
+	plan.Acknowledged[in.GetAddress()] = true
+	if err := bp.saveUpgrade(); err != nil {
+		bp.log.Warnw("Unable to save the upgrade, it won't survive a restart", "err", err)
+	}
+
+	bp.log.Infow("Upgrade acknowledged", "by", in.GetAddress(), "acknowledged", len(plan.Acknowledged), "groupSize", bp.group.Len())
+	return &drand.Empty{Metadata: bp.newMetadata()}, nil
+}
+
+// pendingRestart returns the slot this beacon restarts in for the upgrade it accepted, and whether it waits for an
+// upgrade to be accepted. The beacons which aren't part of an upgrade or already restarted for it return neither.
+func (bp *BeaconProcess) pendingRestart() (start, end time.Time, waiting bool) {
+	bp.state.RLock()
+	defer bp.state.RUnlock()
+
+	plan := bp.upgrade
+	if plan == nil || plan.Restarted || plan.over(bp.opts.clock.Now()) {
+		return time.Time{}, time.Time{}, false
+	}
+	if plan.RestartAt == 0 {
+		return time.Time{}, time.Time{}, true
+	}
+	return time.Unix(plan.RestartAt, 0), time.Unix(plan.SlotEnd, 0), false
+}
+
+// upgradeFile returns the file keeping the coordinated upgrade of the beacon
+func (bp *BeaconProcess) upgradeFile() string {
+	beaconID := common.GetCanonicalBeaconID(bp.getBeaconID())
+	return path.Join(bp.opts.BeaconFolderMB(beaconID), beaconID, UpgradeFileName)
+}
+
+// saveUpgrade must be called with the state lock held
+func (bp *BeaconProcess) saveUpgrade() error {
+	buff, err := json.MarshalIndent(bp.upgrade, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(bp.upgradeFile(), buff, 0o600)
+}
+
+// loadUpgrade registers again the coordinated upgrade the node took part in before the daemon restarted, checking
+// the signature of its coordinator against the group. The daemon restarting after the time of the restart of the
+// node means it restarted for the upgrade. The upgrades whose window is over are forgotten. It must be called with
+// the state lock held.
+func (bp *BeaconProcess) loadUpgrade() error {
+	buff, err := os.ReadFile(bp.upgradeFile())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var plan upgradePlan
+	if err := json.Unmarshal(buff, &plan); err != nil {
+		return fmt.Errorf("invalid upgrade file: %w", err)
+	}
+	now := bp.opts.clock.Now()
+	if plan.over(now) {
+		return os.Remove(bp.upgradeFile())
+	}
+	msg := upgradeProposalMessage(bp.getBeaconID(), plan.Coordinator, plan.WindowStart, plan.WindowEnd)
+	if err := bp.verifyUpgrade(plan.Coordinator, msg, plan.Signature); err != nil {
+		return err
+	}
+	if plan.Acknowledged == nil {
+		plan.Acknowledged = make(map[string]bool)
+	}
+	if plan.RestartAt != 0 && !plan.Restarted && !time.Unix(plan.RestartAt, 0).After(now) {
+		plan.Restarted = true
+		bp.log.Infow("Restarted for the coordinated upgrade", "coordinator", plan.Coordinator)
+		bp.upgrade = &plan
+		return bp.saveUpgrade()
+	}
+	bp.upgrade = &plan
+	return nil
+}
+
+// upgradeStatus returns the status of the current upgrade, if any. The caller must hold the state lock.
+func (bp *BeaconProcess) upgradeStatus() *drand.UpgradeStatus {
+	if bp.upgrade == nil || bp.upgrade.over(bp.opts.clock.Now()) {
+		return nil
+	}
+	return bp.upgrade.toProto()
+}
+
+func (bp *BeaconProcess) signUpgrade(msg []byte) ([]byte, error) {
+	return bp.priv.Scheme().AuthScheme.Sign(bp.priv.Key, msg)
+}
+
+// verifyUpgrade checks that the given upgrade message was signed by the member of our group at addr.
+// The caller must hold the state lock.
+func (bp *BeaconProcess) verifyUpgrade(addr string, msg, sig []byte) error {
+	if bp.group == nil {
+		return errors.New("no group yet")
+	}
+	var signer *key.Node
+	for _, n := range bp.group.Nodes {
+		if n.Address() == addr {
+			signer = n
+			break
+		}
+	}
+	if signer == nil {
+		return fmt.Errorf("%s is not part of the group", addr)
+	}
+
+	if err := bp.group.Scheme.AuthScheme.Verify(signer.Key, msg, sig); err != nil {
+		return fmt.Errorf("invalid upgrade signature from %s: %w", addr, err)
+	}
+	return nil
+}
+
+// upgradeProposalMessage is the message the coordinator signs to propose the upgrade window. The proposals and the
+// acknowledgements are signed under their own tag, so that an acknowledgement can't be replayed as a proposal.
+func upgradeProposalMessage(beaconID, coordinator string, windowStart, windowEnd int64) []byte {
+	return upgradeMessage("drand-upgrade-proposal:"+beaconID+":"+coordinator+":", windowStart, windowEnd)
+}
+
+// upgradeAckMessage is the message a member signs to acknowledge the upgrade window proposed by the coordinator
+func upgradeAckMessage(beaconID, addr, coordinator string, windowStart, windowEnd int64) []byte {
+	return upgradeMessage("drand-upgrade-ack:"+beaconID+":"+addr+":"+coordinator+":", windowStart, windowEnd)
+}
+
+func upgradeMessage(prefix string, windowStart, windowEnd int64) []byte {
+	msg := binary.BigEndian.AppendUint64([]byte(prefix), uint64(windowStart))
+	return binary.BigEndian.AppendUint64(msg, uint64(windowEnd))
+}
+
+// upgradeRestartSlot splits the upgrade window in slots, and assigns the nodes of the group to them by index so
+// that no more than n - threshold nodes are ever restarting at the same time. It returns the start and the end of
+// the slot of the node.
+func upgradeRestartSlot(group *key.Group, addr string, windowStart, windowEnd time.Time) (time.Time, time.Time, error) {
+	nodes := make([]*key.Node, len(group.Nodes))
+	copy(nodes, group.Nodes)
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Index < nodes[j].Index
+	})
+
+	position := -1
+	for i, n := range nodes {
+		if n.Address() == addr {
+			position = i
+			break
+		}
+	}
+	if position < 0 {
+		return time.Time{}, time.Time{}, fmt.Errorf("%s is not part of the group", addr)
+	}
+
+	batchSize := len(nodes) - group.Threshold
+	if batchSize < 1 {
+		// with n == t any restart halts the network, we can at least restart one node at a time
+		batchSize = 1
+	}
+	batches := (len(nodes) + batchSize - 1) / batchSize
+	slot := windowEnd.Sub(windowStart) / time.Duration(batches)
+
+	start := windowStart.Add(time.Duration(position/batchSize) * slot)
+	return start, start.Add(slot), nil
+}
@@ -0,0 +1,27 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// Home is the liveness endpoint of the v1 API, served only when the v1-compatible endpoints are enabled on the
+// private listener
+func (bp *BeaconProcess) Home(ctx context.Context, _ *drand.HomeRequest) (*drand.HomeResponse, error) {
+	_, span := tracer.NewSpan(ctx, "bp.Home")
+	defer span.End()
+
+	if !bp.opts.V1Compat(V1CompatPrivate) {
+		return nil, status.Error(codes.Unimplemented, "the v1 API isn't served by this node")
+	}
+	return &drand.HomeResponse{
+		Status:   fmt.Sprintf("drand up and running on %s", bp.priv.Public.Address()),
+		Metadata: bp.newMetadata(),
+	}, nil
+}
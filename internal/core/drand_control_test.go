@@ -1,16 +1,41 @@
 package core
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	gonet "net"
+	"os"
+	"path"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	clock "github.com/jonboulle/clockwork"
 	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
+	"github.com/drand/drand/v2/common"
+	chain2 "github.com/drand/drand/v2/common/chain"
 	"github.com/drand/drand/v2/common/key"
+	"github.com/drand/drand/v2/common/log"
 	"github.com/drand/drand/v2/common/testlogger"
 	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/chain"
+	"github.com/drand/drand/v2/internal/chain/beacon"
+	"github.com/drand/drand/v2/internal/chain/boltdb"
+	chainerrors "github.com/drand/drand/v2/internal/chain/errors"
+	"github.com/drand/drand/v2/internal/chain/memdb"
+	"github.com/drand/drand/v2/internal/dkg"
+	"github.com/drand/drand/v2/internal/iolimit"
+	"github.com/drand/drand/v2/internal/net"
+	"github.com/drand/drand/v2/protobuf/drand"
 	"github.com/drand/kyber"
+	"github.com/drand/kyber/share"
 	"github.com/drand/kyber/util/random"
 )
 
@@ -81,3 +106,1932 @@ func TestValidateGroupTransitionTime(t *testing.T) {
 	err := d.validateGroupTransition(&oldgrp, &newgrp)
 	require.ErrorContains(t, err, "control: new group with transition time in the past", "error validating group period")
 }
+
+func TestCompareBuildInfos(t *testing.T) {
+	build := func(minor uint32, commit string) *drand.BuildInfo {
+		return &drand.BuildInfo{NodeVersion: &drand.NodeVersion{Major: 2, Minor: minor}, Commit: commit}
+	}
+	local := build(1, "abc")
+	nodes := []*drand.NodeBuildInfo{
+		{Address: "same", BuildInfo: build(1, "abc")},
+		{Address: "other-commit", BuildInfo: build(1, "def")},
+		{Address: "older", BuildInfo: build(0, "abc")},
+		{Address: "newer", BuildInfo: build(2, "abc")},
+		{Address: "silent"},
+		{Address: "unreachable", Error: "connection refused"},
+	}
+
+	compareBuildInfos(local, nodes)
+
+	expected := map[string][2]bool{
+		// mismatch, outdated
+		"same":         {false, true},
+		"other-commit": {true, true},
+		"older":        {true, true},
+		"newer":        {true, false},
+		"silent":       {true, true},
+		"unreachable":  {false, false},
+	}
+	for _, n := range nodes {
+		require.Equal(t, expected[n.Address][0], n.Mismatch, n.Address)
+		require.Equal(t, expected[n.Address][1], n.Outdated, n.Address)
+	}
+}
+
+func TestCompareDSTs(t *testing.T) {
+	local := describeDST(crypto.NewPedersenBLSUnchainedG1())
+	require.False(t, local.GetCustom())
+	custom := crypto.NewPedersenBLSUnchainedG1()
+	custom.SigGroup = crypto.NewPedersenBLSUnchainedSwapped().SigGroup
+	nodes := []*drand.NodeDST{
+		{Address: "same", Dst: describeDST(crypto.NewPedersenBLSUnchainedG1())},
+		{Address: "custom", Dst: describeDST(custom)},
+		{Address: "other-scheme", Dst: describeDST(crypto.NewPedersenBLSUnchained())},
+		{Address: "silent"},
+		{Address: "unreachable", Error: "connection refused"},
+	}
+	require.True(t, nodes[1].GetDst().GetCustom())
+
+	compareDSTs(local, nodes)
+
+	expected := map[string]bool{
+		"same":         false,
+		"custom":       true,
+		"other-scheme": true,
+		"silent":       false,
+		"unreachable":  false,
+	}
+	for _, n := range nodes {
+		require.Equal(t, expected[n.Address], n.Mismatch, n.Address)
+	}
+}
+
+func TestUpgradeRestartTimeKeepsThresholdUp(t *testing.T) {
+	n, threshold := 7, 4
+	nodes := make([]*key.Node, n)
+	for i := range nodes {
+		// indexes needn't be in the same order as the nodes in the group file
+		nodes[i] = &key.Node{Identity: &key.Identity{Addr: fmt.Sprintf("node%d:1234", i)}, Index: uint32(n - 1 - i)}
+	}
+	group := &key.Group{Threshold: threshold, Nodes: nodes}
+	start := time.Unix(1700000000, 0)
+	end := start.Add(3 * time.Hour)
+
+	restarting := make(map[time.Time]int)
+	for _, node := range nodes {
+		at, slotEnd, err := upgradeRestartSlot(group, node.Address(), start, end)
+		require.NoError(t, err)
+		require.False(t, at.Before(start))
+		require.True(t, at.Before(end))
+		require.Equal(t, time.Hour, slotEnd.Sub(at))
+		restarting[at]++
+	}
+	// 3 batches of at most n - t = 3 nodes, one per hour
+	require.Len(t, restarting, 3)
+	for at, count := range restarting {
+		require.LessOrEqual(t, count, n-threshold, at)
+	}
+
+	first, _, err := upgradeRestartSlot(group, "node6:1234", start, end)
+	require.NoError(t, err)
+	require.Equal(t, start, first)
+	last, _, err := upgradeRestartSlot(group, "node0:1234", start, end)
+	require.NoError(t, err)
+	require.Equal(t, start.Add(2*time.Hour), last)
+
+	_, _, err = upgradeRestartSlot(group, "stranger:1234", start, end)
+	require.Error(t, err)
+}
+
+func TestUpgradePlanLifecycle(t *testing.T) {
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	coordinator, err := key.NewKeyPair("coordinator:1234", sch)
+	require.NoError(t, err)
+	kp, err := key.NewKeyPair("node:1234", sch)
+	require.NoError(t, err)
+	clk := clock.NewFakeClockAt(time.Unix(1000, 0))
+	folder := t.TempDir()
+	require.NoError(t, os.MkdirAll(path.Join(folder, common.MultiBeaconFolder, "default"), 0o700))
+	newBP := func() *BeaconProcess {
+		return &BeaconProcess{
+			log:      testlogger.New(t),
+			beaconID: "default",
+			priv:     kp,
+			opts:     &Config{clock: clk, configFolder: folder},
+			group: &key.Group{Scheme: sch, Threshold: 1, Nodes: []*key.Node{
+				{Identity: coordinator.Public, Index: 0}, {Identity: kp.Public, Index: 1},
+			}},
+		}
+	}
+	bp := newBP()
+	propose := func(start, end int64) error {
+		coord := &BeaconProcess{beaconID: "default", priv: coordinator}
+		sig, err := coord.signUpgrade(upgradeProposalMessage("default", "coordinator:1234", start, end))
+		require.NoError(t, err)
+		_, err = bp.ProposeUpgrade(context.Background(), &drand.UpgradeProposal{
+			Coordinator: "coordinator:1234", WindowStart: start, WindowEnd: end, Signature: sig,
+		})
+		return err
+	}
+
+	// a proposal signed for a window which already started can't be replayed
+	require.ErrorContains(t, propose(900, 2000), "already started")
+	require.NoError(t, propose(2000, 4000))
+	start, end, waiting := bp.pendingRestart()
+	require.True(t, waiting)
+	require.True(t, start.IsZero() && end.IsZero())
+
+	// the plan is accepted, as AcceptUpgrade does once acknowledged
+	bp.upgrade.RestartAt, bp.upgrade.SlotEnd = 3000, 4000
+	require.NoError(t, bp.saveUpgrade())
+
+	// the plan survives a restart of the daemon before the time of the restart of the node
+	restored := newBP()
+	require.NoError(t, restored.loadUpgrade())
+	start, end, waiting = restored.pendingRestart()
+	require.False(t, waiting)
+	require.Equal(t, time.Unix(3000, 0), start)
+	require.Equal(t, time.Unix(4000, 0), end)
+
+	// restarting after it means the node restarted for the upgrade, which isn't scheduled again
+	clk.Advance(2500 * time.Second)
+	restarted := newBP()
+	require.NoError(t, restarted.loadUpgrade())
+	require.True(t, restarted.upgrade.Restarted)
+	start, _, waiting = restarted.pendingRestart()
+	require.False(t, waiting)
+	require.True(t, start.IsZero())
+
+	// the plan is forgotten once its window is over
+	clk.Advance(time.Hour)
+	over := newBP()
+	require.NoError(t, over.loadUpgrade())
+	require.Nil(t, over.upgrade)
+	require.NoFileExists(t, over.upgradeFile())
+}
+
+func TestUpgradeRestartTimeOfTheDaemon(t *testing.T) {
+	clk := clock.NewFakeClockAt(time.Unix(1000, 0))
+	planned := func(plan *upgradePlan) *BeaconProcess {
+		return &BeaconProcess{upgrade: plan, opts: &Config{clock: clk}}
+	}
+	accepted := func(restartAt, slotEnd int64) *BeaconProcess {
+		return planned(&upgradePlan{WindowStart: 2000, WindowEnd: 5000, RestartAt: restartAt, SlotEnd: slotEnd})
+	}
+
+	// the daemon restarts in the slots of all its beacons
+	at, waiting, err := upgradeRestartTime(map[string]*BeaconProcess{
+		"default": accepted(2000, 3000), "fast": accepted(2500, 3500), "idle": planned(nil),
+	})
+	require.NoError(t, err)
+	require.Empty(t, waiting)
+	require.Equal(t, time.Unix(2500, 0), at)
+
+	// one beacon's upgrade doesn't restart the daemon while another one is still to accept its own
+	_, waiting, err = upgradeRestartTime(map[string]*BeaconProcess{
+		"default": accepted(2000, 3000), "fast": planned(&upgradePlan{WindowStart: 2000, WindowEnd: 5000}),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"fast"}, waiting)
+
+	_, _, err = upgradeRestartTime(map[string]*BeaconProcess{
+		"default": accepted(2000, 3000), "fast": accepted(3000, 4000),
+	})
+	require.ErrorContains(t, err, "don't overlap")
+
+	at, waiting, err = upgradeRestartTime(map[string]*BeaconProcess{"idle": planned(nil)})
+	require.NoError(t, err)
+	require.Empty(t, waiting)
+	require.True(t, at.IsZero())
+}
+
+func TestUpgradeMessagesAreSigned(t *testing.T) {
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	kp, err := key.NewKeyPair("node:1234", sch)
+	require.NoError(t, err)
+	bp := BeaconProcess{
+		log:      testlogger.New(t),
+		beaconID: "default",
+		priv:     kp,
+		group:    &key.Group{Scheme: sch, Threshold: 1, Nodes: []*key.Node{{Identity: kp.Public}}},
+	}
+
+	proposal := upgradeProposalMessage("default", "node:1234", 10, 20)
+	sig, err := bp.signUpgrade(proposal)
+	require.NoError(t, err)
+	require.NoError(t, bp.verifyUpgrade("node:1234", proposal, sig))
+	require.Error(t, bp.verifyUpgrade("node:1234", upgradeProposalMessage("default", "node:1234", 10, 21), sig))
+	require.Error(t, bp.verifyUpgrade("other:1234", proposal, sig))
+
+	// an acknowledgement can't be replayed as a proposal, nor sent to another coordinator
+	ack := upgradeAckMessage("default", "node:1234", "coordinator:1234", 10, 20)
+	sig, err = bp.signUpgrade(ack)
+	require.NoError(t, err)
+	require.NoError(t, bp.verifyUpgrade("node:1234", ack, sig))
+	require.Error(t, bp.verifyUpgrade("node:1234", proposal, sig))
+	require.Error(t, bp.verifyUpgrade("node:1234", upgradeAckMessage("default", "node:1234", "other:1234", 10, 20), sig))
+}
+
+func TestAnnounceLeave(t *testing.T) {
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	kp, err := key.NewKeyPair("node:1234", sch)
+	require.NoError(t, err)
+	folder := t.TempDir()
+	require.NoError(t, os.MkdirAll(path.Join(folder, common.MultiBeaconFolder, "default"), 0o700))
+	newBP := func() *BeaconProcess {
+		return &BeaconProcess{
+			log:      testlogger.New(t),
+			beaconID: "default",
+			priv:     kp,
+			group:    &key.Group{Scheme: sch, Threshold: 1, Nodes: []*key.Node{{Identity: kp.Public}}},
+			opts:     &Config{clock: clock.NewFakeClock(), configFolder: folder},
+		}
+	}
+	bp := newBP()
+
+	sig, err := sch.AuthScheme.Sign(kp.Key, leaveMessage("default", "node:1234", 42))
+	require.NoError(t, err)
+
+	_, err = bp.AnnounceLeave(context.Background(), &drand.LeaveAnnouncement{Address: "node:1234", LastRound: 43, Signature: sig})
+	require.Error(t, err)
+	require.Empty(t, bp.leavingStatus())
+
+	_, err = bp.AnnounceLeave(context.Background(), &drand.LeaveAnnouncement{Address: "node:1234", LastRound: 42, Signature: sig})
+	require.NoError(t, err)
+	require.Equal(t, []*drand.LeaveStatus{{Address: "node:1234", LastRound: 42}}, bp.leavingStatus())
+
+	// the departure survives a restart of the daemon
+	restarted := newBP()
+	require.NoError(t, restarted.loadLeave())
+	require.Equal(t, bp.leavingStatus(), restarted.leavingStatus())
+
+	// once a reshare excluding the leaver completes, it isn't listed anymore
+	bp.forgetLeavers(&key.Group{Scheme: sch, Threshold: 1})
+	require.Empty(t, bp.leavingStatus())
+	require.NoFileExists(t, bp.leaveFile())
+}
+
+type redirectingProtocolClient struct {
+	net.ProtocolClient
+	redirects map[string]string
+}
+
+func (c *redirectingProtocolClient) Redirect(_, from, to string) {
+	if from == to {
+		delete(c.redirects, from)
+		return
+	}
+	c.redirects[from] = to
+}
+
+func TestAnnounceAddress(t *testing.T) {
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	kp, err := key.NewKeyPair("node:1234", sch)
+	require.NoError(t, err)
+	beaconID := "default"
+	folder := t.TempDir()
+	require.NoError(t, os.MkdirAll(path.Join(folder, common.MultiBeaconFolder, beaconID), 0o700))
+	client := &redirectingProtocolClient{redirects: make(map[string]string)}
+	bp := BeaconProcess{
+		log:         testlogger.New(t),
+		beaconID:    beaconID,
+		priv:        kp,
+		group:       &key.Group{Scheme: sch, Threshold: 1, Nodes: []*key.Node{{Identity: kp.Public}}},
+		opts:        &Config{clock: clock.NewFakeClock(), configFolder: folder, featureFlags: []string{"address-persist=on"}},
+		privGateway: &net.PrivateGateway{ProtocolClient: client},
+	}
+	announce := func(newAddr string, sequence uint64) error {
+		sig, err := sch.AuthScheme.Sign(kp.Key, addressMessage(beaconID, "node:1234", newAddr, sequence))
+		require.NoError(t, err)
+		_, err = bp.AnnounceAddress(context.Background(), &drand.AddressAnnouncement{
+			Address: "node:1234", NewAddress: newAddr, Sequence: sequence, Signature: sig,
+		})
+		return err
+	}
+
+	require.NoError(t, announce("10.0.0.2:1234", 2))
+	require.Equal(t, map[string]string{"node:1234": "10.0.0.2:1234"}, client.redirects)
+
+	// the announcements older than the last one applied are refused
+	require.Error(t, announce("10.0.0.1:1234", 1))
+	require.Error(t, announce("10.0.0.1:1234", 2))
+	require.Equal(t, map[string]string{"node:1234": "10.0.0.2:1234"}, client.redirects)
+
+	// the signature covers the new address
+	sig, err := sch.AuthScheme.Sign(kp.Key, addressMessage(beaconID, "node:1234", "10.0.0.3:1234", 3))
+	require.NoError(t, err)
+	_, err = bp.AnnounceAddress(context.Background(), &drand.AddressAnnouncement{
+		Address: "node:1234", NewAddress: "evil:1234", Sequence: 3, Signature: sig,
+	})
+	require.Error(t, err)
+
+	// the addresses saved are applied again once restarted
+	restarted := &redirectingProtocolClient{redirects: make(map[string]string)}
+	bp.privGateway = &net.PrivateGateway{ProtocolClient: restarted}
+	bp.addresses = nil
+	require.NoError(t, bp.loadAddresses(bp.group))
+	require.Equal(t, map[string]string{"node:1234": "10.0.0.2:1234"}, restarted.redirects)
+
+	// once the member isn't part of the group anymore, it is reached at its address again
+	bp.forgetAddresses(&key.Group{Scheme: sch, Threshold: 1})
+	require.Empty(t, restarted.redirects)
+}
+
+type overridingProtocolClient struct {
+	net.ProtocolClient
+	overrides map[string]string
+}
+
+func (c *overridingProtocolClient) Override(_, from, to string) {
+	if to == "" {
+		delete(c.overrides, from)
+		return
+	}
+	c.overrides[from] = to
+}
+
+func TestAddressOverrides(t *testing.T) {
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	kp, err := key.NewKeyPair("node:1234", sch)
+	require.NoError(t, err)
+	other, err := key.NewKeyPair("other:1234", sch)
+	require.NoError(t, err)
+	beaconID := "default"
+	folder := t.TempDir()
+	require.NoError(t, os.MkdirAll(path.Join(folder, common.MultiBeaconFolder, beaconID), 0o700))
+	client := &overridingProtocolClient{overrides: make(map[string]string)}
+	group := &key.Group{Scheme: sch, Threshold: 1, Nodes: []*key.Node{{Identity: kp.Public}, {Identity: other.Public, Index: 1}}}
+	bp := BeaconProcess{
+		log:         testlogger.New(t),
+		beaconID:    beaconID,
+		priv:        kp,
+		group:       group,
+		opts:        &Config{clock: clock.NewFakeClock(), configFolder: folder},
+		privGateway: &net.PrivateGateway{ProtocolClient: client},
+	}
+	ctx := context.Background()
+	otherKey := key.PointToString(other.Public.Key)
+
+	resp, err := bp.AddressOverrides(ctx, &drand.AddressOverridesRequest{Member: "other:1234", Address: "10.0.0.2:1234"})
+	require.NoError(t, err)
+	require.Equal(t, []*drand.AddressOverride{{Key: otherKey, MemberAddress: "other:1234", Address: "10.0.0.2:1234"}},
+		resp.GetOverrides())
+	require.Equal(t, map[string]string{"other:1234": "10.0.0.2:1234"}, client.overrides)
+
+	_, err = bp.AddressOverrides(ctx, &drand.AddressOverridesRequest{Member: "unknown:1234", Address: "10.0.0.3:1234"})
+	require.Error(t, err)
+	_, err = bp.AddressOverrides(ctx, &drand.AddressOverridesRequest{Member: "other:1234", Address: "10.0.0.3"})
+	require.Error(t, err)
+
+	// the overrides follow the key of the member when its address in the group changes
+	moved, err := key.NewKeyPair("moved:1234", sch)
+	require.NoError(t, err)
+	moved.Public.Key = other.Public.Key
+	bp.reloadAddressOverrides(&key.Group{Scheme: sch, Threshold: 1, Nodes: []*key.Node{{Identity: kp.Public}, {Identity: moved.Public, Index: 1}}})
+	require.Equal(t, map[string]string{"moved:1234": "10.0.0.2:1234"}, client.overrides)
+
+	bp.reloadAddressOverrides(group)
+	resp, err = bp.AddressOverrides(ctx, &drand.AddressOverridesRequest{Member: otherKey, Remove: true})
+	require.NoError(t, err)
+	require.Empty(t, resp.GetOverrides())
+	require.Empty(t, client.overrides)
+}
+
+type countingProtocolClient struct {
+	net.ProtocolClient
+	checks atomic.Int32
+}
+
+func (c *countingProtocolClient) Check(_ context.Context, p net.Peer) error {
+	c.checks.Add(1)
+	if p.Address() == "down:1234" {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func TestStatusServesConnectivityFromCache(t *testing.T) {
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	kp, err := key.NewKeyPair("node:1234", sch)
+	require.NoError(t, err)
+	client := &countingProtocolClient{}
+	fakeClock := clock.NewFakeClockAt(time.Unix(1700000000, 0))
+	bp := BeaconProcess{
+		log:         testlogger.New(t),
+		priv:        kp,
+		opts:        &Config{clock: fakeClock},
+		privGateway: &net.PrivateGateway{ProtocolClient: client},
+	}
+	req := &drand.StatusRequest{CheckConn: []*drand.Address{{Address: "up:1234"}, {Address: "down:1234"}}}
+
+	resp, err := bp.Status(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{"up:1234": true, "down:1234": false}, resp.GetConnections())
+	require.Equal(t, fakeClock.Now().Unix(), resp.GetConnectionsCheckedAt())
+	require.Equal(t, int32(2), client.checks.Load())
+
+	// the second request is served from the cache
+	fakeClock.Advance(time.Minute)
+	resp, err = bp.Status(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{"up:1234": true, "down:1234": false}, resp.GetConnections())
+	require.Equal(t, fakeClock.Now().Add(-time.Minute).Unix(), resp.GetConnectionsCheckedAt())
+	require.Equal(t, int32(2), client.checks.Load())
+
+	req.ForceRefresh = true
+	resp, err = bp.Status(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, fakeClock.Now().Unix(), resp.GetConnectionsCheckedAt())
+	require.Equal(t, int32(4), client.checks.Load())
+
+	up := resp.GetConnectionDetails()["up:1234"]
+	require.True(t, up.GetOk())
+	require.Empty(t, up.GetErrorClass())
+	require.Equal(t, fakeClock.Now().Unix(), up.GetLastSuccess())
+	down := resp.GetConnectionDetails()["down:1234"]
+	require.False(t, down.GetOk())
+	require.Equal(t, connErrorRefused, down.GetErrorClass())
+	require.Equal(t, "connection refused", down.GetError())
+	require.Zero(t, down.GetLastSuccess())
+}
+
+func TestClassifyConnError(t *testing.T) {
+	tests := []struct {
+		err   error
+		class string
+	}{
+		{context.DeadlineExceeded, connErrorTimeout},
+		{status.Error(codes.DeadlineExceeded, "context deadline exceeded"), connErrorTimeout},
+		{&gonet.DNSError{Err: "no such host", Name: "node"}, connErrorDNS},
+		{status.Error(codes.Unavailable, "connection error: desc = \"transport: Error while dialing: "+
+			"dial tcp: lookup node on 127.0.0.53:53: no such host\""), connErrorDNS},
+		{status.Error(codes.Unavailable, "connection error: desc = \"transport: authentication handshake failed: "+
+			"x509: certificate signed by unknown authority\""), connErrorTLS},
+		{status.Error(codes.Unavailable, "connection error: desc = \"transport: Error while dialing: "+
+			"dial tcp 127.0.0.1:1234: connect: connection refused\""), connErrorRefused},
+		{errors.New("something else"), connErrorUnknown},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.class, classifyConnError(test.err), test.err.Error())
+	}
+}
+
+func TestRoundMessage(t *testing.T) {
+	for _, id := range []string{crypto.DefaultSchemeID, crypto.UnchainedSchemeID} {
+		sch, err := crypto.SchemeFromName(id)
+		require.NoError(t, err)
+		kp, err := key.NewKeyPair("node:1234", sch)
+		require.NoError(t, err)
+		bp := BeaconProcess{
+			log:   testlogger.New(t),
+			priv:  kp,
+			group: &key.Group{Scheme: sch},
+			opts:  &Config{clock: clock.NewFakeClock()},
+		}
+
+		previousSig := []byte("previous signature")
+		resp, err := bp.RoundMessage(context.Background(), &drand.RoundMessageRequest{Round: 42, PreviousSignature: previousSig})
+		require.NoError(t, err)
+		require.Equal(t, id, resp.GetSchemeID())
+
+		if id == crypto.DefaultSchemeID {
+			require.Equal(t, previousSig, resp.GetPreviousSignature())
+			require.Equal(t, sch.DigestBeacon(&common.Beacon{Round: 42, PreviousSig: previousSig}), resp.GetMessage())
+
+			// without a previous signature nor a chain to read it from, the message can't be computed
+			_, err = bp.RoundMessage(context.Background(), &drand.RoundMessageRequest{Round: 42})
+			require.Error(t, err)
+		} else {
+			require.Empty(t, resp.GetPreviousSignature())
+			require.Equal(t, sch.DigestBeacon(&common.Beacon{Round: 42}), resp.GetMessage())
+		}
+	}
+}
+
+func TestCheckStaleness(t *testing.T) {
+	fakeClock := clock.NewFakeClockAt(time.Unix(1000, 0))
+	bp := BeaconProcess{
+		log:  testlogger.New(t),
+		opts: &Config{clock: fakeClock},
+	}
+	period := 10 * time.Second
+	// the network is at round 101
+	require.NoError(t, bp.checkStaleness(10, 0, period, 0), "no limit by default")
+	require.NoError(t, bp.checkStaleness(99, 2, period, 0))
+	err := bp.checkStaleness(98, 2, period, 0)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+
+	bp.opts.maxStalePeriods = 5
+	require.Error(t, bp.checkStaleness(95, 0, period, 0))
+	require.NoError(t, bp.checkStaleness(95, 10, period, 0), "the limit of the request takes precedence")
+}
+
+func TestSignNotarization(t *testing.T) {
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	kp, err := key.NewKeyPair("node:1234", sch)
+	require.NoError(t, err)
+	bp := BeaconProcess{
+		log:  testlogger.New(t),
+		priv: kp,
+		opts: &Config{clock: clock.NewFakeClockAt(time.Unix(1000, 0))},
+	}
+
+	bundle := &drand.NotarizationBundle{
+		BeaconID:  "default",
+		Round:     42,
+		Signature: []byte("signature"),
+		ChainInfo: &drand.ChainInfoPacket{Hash: []byte("chain hash")},
+		Groups:    []*drand.NotarizedGroup{{Epoch: 1, GroupFile: []byte("group file")}},
+	}
+	require.NoError(t, bp.signNotarization(bundle))
+	require.Equal(t, int64(1000), bundle.GetNotarizedAt())
+	require.Equal(t, "node:1234", bundle.GetNotaryAddress())
+	require.Equal(t, sch.Name, bundle.GetNotarySchemeID())
+	require.Equal(t, notarizationDigest(bundle), bundle.GetDigest())
+	require.NoError(t, sch.AuthScheme.Verify(kp.Public.Key, bundle.GetDigest(), bundle.GetNotarySignature()))
+
+	// tampering with any of the notarized fields changes the digest
+	bundle.Groups[0].GroupFile = []byte("another group file")
+	require.NotEqual(t, bundle.GetDigest(), notarizationDigest(bundle))
+}
+
+func TestJoinKit(t *testing.T) {
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	kp, err := key.NewKeyPair("node:1234", sch)
+	require.NoError(t, err)
+	bp := BeaconProcess{
+		log:  testlogger.New(t),
+		priv: kp,
+		opts: &Config{clock: clock.NewFakeClockAt(time.Unix(1000, 0))},
+	}
+
+	// a chain with a single member is enough to sign a valid checkpoint
+	priPoly := share.NewPriPoly(sch.KeyGroup, 1, sch.KeyGroup.Scalar().Pick(random.New()), random.New())
+	pubPoly := priPoly.Commit(sch.KeyGroup.Point().Base())
+	info := &chain2.Info{
+		PublicKey:   pubPoly.Commit(),
+		ID:          "default",
+		Period:      3 * time.Second,
+		Scheme:      sch.Name,
+		GenesisTime: 100,
+		GenesisSeed: []byte("genesis seed"),
+	}
+	checkpoint := &common.Beacon{Round: 42, PreviousSig: []byte("previous signature")}
+	msg := sch.DigestBeacon(checkpoint)
+	partial, err := sch.ThresholdScheme.Sign(priPoly.Shares(1)[0], msg)
+	require.NoError(t, err)
+	checkpoint.Signature, err = sch.ThresholdScheme.Recover(pubPoly, msg, [][]byte{partial}, 1, 1)
+	require.NoError(t, err)
+
+	kit := &drand.JoinKit{
+		BeaconID:                    "default",
+		ChainInfo:                   info.ToProto(nil),
+		CheckpointRound:             checkpoint.Round,
+		CheckpointSignature:         checkpoint.Signature,
+		CheckpointPreviousSignature: checkpoint.PreviousSig,
+		Peers:                       []string{"node:1234", "node:5678"},
+	}
+	require.NoError(t, bp.signJoinKit(kit))
+	require.Equal(t, int64(1000), kit.GetCreatedAt())
+	require.Equal(t, "node:1234", kit.GetSignerAddress())
+
+	gotInfo, gotCheckpoint, err := VerifyJoinKit(kit, info.Hash())
+	require.NoError(t, err)
+	require.True(t, gotInfo.Equal(info))
+	require.True(t, gotCheckpoint.Equal(checkpoint))
+
+	_, _, err = VerifyJoinKit(kit, []byte("another chain"))
+	require.ErrorContains(t, err, "the join kit is for chain")
+
+	// the peers are covered by the signature
+	kit.Peers = append(kit.Peers, "attacker:1234")
+	_, _, err = VerifyJoinKit(kit, info.Hash())
+	require.ErrorContains(t, err, "digest")
+}
+
+func TestStartFromCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	l := testlogger.New(t)
+	store := memdb.NewStore(10)
+	for i := uint64(0); i <= 3; i++ {
+		require.NoError(t, store.Put(ctx, &common.Beacon{Round: i, Signature: []byte{byte(i)}}))
+	}
+
+	missingFrom, err := startFromCheckpoint(ctx, l, store, &common.Beacon{Round: 42, Signature: []byte("checkpoint")})
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), missingFrom)
+	last, err := store.Last(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), last.Round)
+
+	// a checkpoint we already went past is ignored
+	missingFrom, err = startFromCheckpoint(ctx, l, store, &common.Beacon{Round: 10, Signature: []byte("old checkpoint")})
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), missingFrom)
+	_, err = store.Get(ctx, 10)
+	require.Error(t, err)
+}
+
+func TestImportHistory(t *testing.T) {
+	ctx := context.Background()
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	bp := BeaconProcess{
+		log:  testlogger.New(t),
+		opts: &Config{clock: clock.NewFakeClockAt(time.Unix(1000, 0))},
+	}
+
+	priPoly := share.NewPriPoly(sch.KeyGroup, 1, sch.KeyGroup.Scalar().Pick(random.New()), random.New())
+	pubPoly := priPoly.Commit(sch.KeyGroup.Point().Base())
+	info := &chain2.Info{
+		PublicKey:   pubPoly.Commit(),
+		ID:          "default",
+		Period:      3 * time.Second,
+		Scheme:      sch.Name,
+		GenesisTime: 100,
+		GenesisSeed: []byte("genesis seed"),
+	}
+	history := []*common.Beacon{chain.GenesisBeacon(info.GenesisSeed)}
+	for r := uint64(1); r <= 8; r++ {
+		b := &common.Beacon{Round: r, PreviousSig: history[r-1].Signature}
+		msg := sch.DigestBeacon(b)
+		partial, err := sch.ThresholdScheme.Sign(priPoly.Shares(1)[0], msg)
+		require.NoError(t, err)
+		b.Signature, err = sch.ThresholdScheme.Recover(pubPoly, msg, [][]byte{partial}, 1, 1)
+		require.NoError(t, err)
+		history = append(history, b)
+	}
+	archive := func(genesis []byte, rounds ...*common.Beacon) string {
+		folder := t.TempDir()
+		store, err := boltdb.NewBoltStore(boltdb.IsATest(ctx), bp.log, folder, nil)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(ctx, chain.GenesisBeacon(genesis)))
+		for _, b := range rounds {
+			require.NoError(t, store.Put(ctx, b))
+		}
+		require.NoError(t, store.Close())
+		return path.Join(folder, boltdb.BoltFileName)
+	}
+
+	// none of the archives holds the full and correct history
+	tampered := *history[3]
+	tampered.Signature = history[4].Signature
+	full := archive(info.GenesisSeed, history[1:7]...)
+	corrupted := archive(info.GenesisSeed, history[1], history[2], &tampered, history[4])
+	recent := archive(info.GenesisSeed, history[5:]...)
+
+	store := memdb.NewStore(10)
+	require.NoError(t, store.Put(ctx, history[0]))
+	last, err := bp.importHistory(ctx, bp.log, store, info, sch, []string{corrupted, full, recent, corrupted}, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), last)
+	for r := uint64(1); r <= 8; r++ {
+		b, err := store.Get(ctx, common.Round(r))
+		require.NoError(t, err)
+		// the tampered round is kept out even if supplied by the most archives
+		require.Equal(t, history[r].Signature, b.Signature)
+	}
+	require.Equal(t, eventHistoryImported, bp.events.recent()[0].GetKind())
+
+	// an archive of another chain is refused
+	_, err = bp.importHistory(ctx, bp.log, memdb.NewStore(10), info, sch,
+		[]string{full, archive([]byte("another seed"), history[1])}, nil)
+	require.ErrorContains(t, err, "isn't one of the chain followed")
+}
+
+func TestRandomnessStats(t *testing.T) {
+	ctx := context.Background()
+	store := memdb.NewStore(10)
+	for i := uint64(0); i <= 4; i++ {
+		require.NoError(t, store.Put(ctx, &common.Beacon{Round: i, Signature: []byte{byte(i)}}))
+	}
+	// a value cached by the previous versions, outside of the namespace
+	require.NoError(t, store.PutMetadata(ctx, "randomness-stats:1-3", []byte("legacy")))
+	clk := clock.NewFakeClock()
+	bp := BeaconProcess{
+		log:     testlogger.New(t),
+		dbStore: store,
+		opts:    &Config{clock: clk},
+	}
+
+	stats, err := bp.RandomnessStats(ctx, &drand.RandomnessStatsRequest{From: 0, To: 3})
+	require.NoError(t, err)
+	require.False(t, stats.GetCached())
+	require.Equal(t, uint64(1), stats.GetFrom(), "the genesis round has no randomness")
+	require.Equal(t, uint64(3), stats.GetRounds())
+	require.Equal(t, uint64(3*256), stats.GetBits())
+	require.InDelta(t, 0.5, stats.GetBitBalance(), 0.1)
+
+	cached, err := bp.RandomnessStats(ctx, &drand.RandomnessStatsRequest{From: 1, To: 3})
+	require.NoError(t, err)
+	require.True(t, cached.GetCached())
+	require.Equal(t, stats.GetOnes(), cached.GetOnes())
+
+	_, err = bp.RandomnessStats(ctx, &drand.RandomnessStatsRequest{From: 1, To: 5})
+	require.Error(t, err, "round 5 isn't stored yet")
+
+	legacy, err := store.GetMetadata(ctx, "randomness-stats:1-3")
+	require.NoError(t, err)
+	require.Nil(t, legacy)
+
+	// the cached statistics expire
+	clk.Advance(statsCacheTTL + time.Second)
+	expired, err := bp.RandomnessStats(ctx, &drand.RandomnessStatsRequest{From: 1, To: 3})
+	require.NoError(t, err)
+	require.False(t, expired.GetCached())
+
+	// and only the most recent ones are kept
+	for i := 0; i < maxCachedStats+5; i++ {
+		clk.Advance(time.Second)
+		require.NoError(t, bp.cacheStats(ctx, store, mustMetadata(t, store, statsNamespace), fmt.Sprintf("test:%d", i),
+			&drand.RandomnessStatsResponse{}))
+	}
+	cached, err = bp.RandomnessStats(ctx, &drand.RandomnessStatsRequest{From: 1, To: 3})
+	require.NoError(t, err)
+	require.False(t, cached.GetCached(), "the oldest statistics are evicted")
+	all, err := mustMetadata(t, store, statsNamespace).List(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, maxCachedStats)
+	require.Contains(t, all, fmt.Sprintf("test:%d", maxCachedStats+4))
+}
+
+func mustMetadata(t *testing.T, store chain.Store, namespace string) *chain2.Metadata {
+	t.Helper()
+	m, err := chain2.NewMetadata(store, namespace)
+	require.NoError(t, err)
+	return m
+}
+
+func TestRandomnessStatsAccumulator(t *testing.T) {
+	s := new(randomnessStats)
+	s.add([]byte{0xf0, 0x0f})
+	resp := s.toProto(1, 1)
+	require.Equal(t, uint64(16), resp.GetBits())
+	require.Equal(t, uint64(8), resp.GetOnes())
+	require.Equal(t, 0.5, resp.GetBitBalance())
+	// 1111 00000000 1111
+	require.Equal(t, uint64(3), resp.GetRuns())
+	require.Equal(t, uint64(8), resp.GetLongestRun())
+}
+
+func TestHeartbeatAggregation(t *testing.T) {
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	n, thr := 3, 2
+	priPoly := share.NewPriPoly(sch.KeyGroup, thr, sch.KeyGroup.Scalar().Pick(random.New()), random.New())
+	pubPoly := priPoly.Commit(sch.KeyGroup.Point().Base())
+	group := &key.Group{Scheme: sch, Threshold: thr, Nodes: make([]*key.Node, n)}
+	bp := BeaconProcess{
+		log:  testlogger.New(t),
+		opts: &Config{clock: clock.NewFakeClock()},
+	}
+
+	_, err = bp.Heartbeat(context.Background(), &drand.HeartbeatRequest{})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	hb := &drand.HeartbeatPacket{Index: 7, TipRound: 42, TipSignature: []byte("tip"), GroupHash: []byte("group")}
+	msg := heartbeatMessage("default", hb)
+	for i, s := range priPoly.Shares(n)[:thr] {
+		partial, err := sch.ThresholdScheme.Sign(s, msg)
+		require.NoError(t, err)
+		bp.addHeartbeatPartial(hb, msg, partial, group, pubPoly)
+		if i < thr-1 {
+			_, err = bp.Heartbeat(context.Background(), &drand.HeartbeatRequest{})
+			require.Error(t, err, "not enough partials yet")
+		}
+	}
+
+	signed, err := bp.Heartbeat(context.Background(), &drand.HeartbeatRequest{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), signed.GetIndex())
+	require.NoError(t, sch.ThresholdScheme.VerifyRecovered(pubPoly.Commit(), msg, signed.GetSignature()))
+
+	// partials of older heartbeats are ignored once a newer one is signed
+	old := &drand.HeartbeatPacket{Index: 6}
+	partial, err := sch.ThresholdScheme.Sign(priPoly.Shares(n)[0], heartbeatMessage("default", old))
+	require.NoError(t, err)
+	bp.addHeartbeatPartial(old, heartbeatMessage("default", old), partial, group, pubPoly)
+	require.Nil(t, bp.heartbeats.pending)
+}
+
+func TestParseSubBeacons(t *testing.T) {
+	subBeacons, err := ParseSubBeacons([]string{"hourly=1h", "fast=3s"})
+	require.NoError(t, err)
+	require.Equal(t, []SubBeacon{{Name: "hourly", Period: time.Hour}, {Name: "fast", Period: 3 * time.Second}}, subBeacons)
+
+	for _, invalid := range [][]string{{"hourly"}, {"=1h"}, {"hourly=soon"}, {"fast=1500ms"}, {"a=1h", "a=2h"}} {
+		_, err := ParseSubBeacons(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestParseDerivedChains(t *testing.T) {
+	chains, err := ParseDerivedChains([]string{"weekly=every:201600", "daily=bucket:24h"})
+	require.NoError(t, err)
+	require.Len(t, chains, 2)
+	require.Equal(t, "weekly=every:201600", chains[0].String())
+	require.Equal(t, everyDerivation(201600), chains[0].derivation)
+	require.Equal(t, bucketDerivation(24*time.Hour), chains[1].derivation)
+
+	for _, invalid := range [][]string{{"weekly"}, {"=every:10"}, {"a:b=every:10"}, {"weekly=every:0"},
+		{"weekly=monthly:1"}, {"daily=bucket:1500ms"}, {"a=every:1", "a=every:2"}} {
+		_, err := ParseDerivedChains(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestDerivationWindows(t *testing.T) {
+	// the genesis is 100s after midnight UTC
+	group := &key.Group{Period: 30 * time.Second, GenesisTime: 10*86400 + 100}
+
+	from, to := everyDerivation(10).window(3, group)
+	require.Equal(t, []uint64{21, 30}, []uint64{from, to})
+
+	hourly := bucketDerivation(time.Hour)
+	require.NoError(t, hourly.validate(group))
+	require.Error(t, bucketDerivation(10*time.Second).validate(group))
+	from, to = hourly.window(1, group)
+	require.Equal(t, []uint64{1, 117}, []uint64{from, to})
+	from, to = hourly.window(2, group)
+	require.Equal(t, []uint64{118, 237}, []uint64{from, to})
+}
+
+func TestDeriveRounds(t *testing.T) {
+	ctx := context.Background()
+	group := &key.Group{Period: 3 * time.Second, GenesisTime: 1000}
+	source := memdb.NewStore(100)
+	for r := uint64(1); r <= 25; r++ {
+		require.NoError(t, source.Put(ctx, &common.Beacon{Round: r, Signature: []byte{byte(r)}}))
+	}
+	chains, err := ParseDerivedChains([]string{"tens=every:10"})
+	require.NoError(t, err)
+	chainHash := []byte("chain hash")
+	stream := &derivedStream{DerivedChain: chains[0], store: memdb.NewStore(10), digest: chainHash}
+	bp := BeaconProcess{log: testlogger.New(t), beaconID: "default"}
+
+	require.NoError(t, bp.deriveRounds(ctx, source, group, stream))
+	// round 3 would need rounds 21 to 30
+	require.Equal(t, uint64(2), stream.last)
+
+	first, err := stream.store.Get(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, chainHash, []byte(first.PreviousSig))
+	require.Equal(t, derivedDigest("default", "tens", 1, chainHash,
+		[][]byte{{1}, {2}, {3}, {4}, {5}, {6}, {7}, {8}, {9}, {10}}), []byte(first.Signature))
+	second, err := stream.store.Get(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, first.Signature, second.PreviousSig)
+
+	for r := uint64(26); r <= 30; r++ {
+		require.NoError(t, source.Put(ctx, &common.Beacon{Round: r, Signature: []byte{byte(r)}}))
+	}
+	require.NoError(t, bp.deriveRounds(ctx, source, group, stream))
+	require.Equal(t, uint64(3), stream.last)
+}
+
+func TestDerivedRoundsStored(t *testing.T) {
+	ctx := context.Background()
+	group := &key.Group{Period: 3 * time.Second, GenesisTime: 1000}
+	source := memdb.NewStore(100)
+	for r := uint64(1); r <= 25; r++ {
+		require.NoError(t, source.Put(ctx, &common.Beacon{Round: r, Signature: []byte{byte(r)}}))
+	}
+	chains, err := ParseDerivedChains([]string{"tens=every:10"})
+	require.NoError(t, err)
+	chainHash := []byte("chain hash")
+	file := path.Join(t.TempDir(), DerivedChainsFileName)
+	bp := BeaconProcess{log: testlogger.New(t), beaconID: "default"}
+
+	db, err := bolt.Open(file, 0o600, nil)
+	require.NoError(t, err)
+	stream, err := openDerivedStream(ctx, db, chains[0], chainHash)
+	require.NoError(t, err)
+	require.NoError(t, bp.deriveRounds(ctx, source, group, stream))
+	second, err := stream.store.Get(ctx, 2)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	// the derivation resumes from the last round stored
+	db, err = bolt.Open(file, 0o600, nil)
+	require.NoError(t, err)
+	stream, err = openDerivedStream(ctx, db, chains[0], chainHash)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), stream.last)
+	require.Equal(t, []byte(second.Signature), stream.digest)
+	first, err := stream.store.Get(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, chainHash, []byte(first.PreviousSig))
+
+	// the rounds derived for another chain are dropped
+	stream, err = openDerivedStream(ctx, db, chains[0], []byte("other chain hash"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), stream.last)
+	_, err = stream.store.Get(ctx, 1)
+	require.ErrorIs(t, err, chainerrors.ErrNoBeaconStored)
+	require.NoError(t, db.Close())
+}
+
+func TestParseIOLimits(t *testing.T) {
+	limits, err := ParseIOLimits([]string{"backup=1048576", "check=4096", "compact=2048", "export=1024"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{IOClassBackup: 1048576, IOClassCheck: 4096, IOClassCompact: 2048, IOClassExport: 1024}, limits)
+
+	for _, invalid := range [][]string{{"backup"}, {"compaction=1024"}, {"stats=fast"}, {"stats=0"}} {
+		_, err := ParseIOLimits(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestParseBeaconIsolation(t *testing.T) {
+	isolation, err := ParseBeaconIsolation([]string{
+		"evmnet:private-listen=0.0.0.0:4454,tls-cert=evmnet.crt,tls-key=evmnet.key,folder=/srv/evmnet/",
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]*BeaconIsolation{
+		"evmnet": {
+			BeaconID:      "evmnet",
+			PrivateListen: "0.0.0.0:4454",
+			TLSCert:       "evmnet.crt",
+			TLSKey:        "evmnet.key",
+			Folder:        "/srv/evmnet",
+		},
+	}, isolation)
+
+	for _, invalid := range [][]string{
+		{"evmnet"},
+		{"evmnet:"},
+		{":folder=/srv/default"},
+		{"evmnet:listen=0.0.0.0:4454"},
+		{"evmnet:folder="},
+		{"evmnet:tls-cert=evmnet.crt,private-listen=0.0.0.0:4454"},
+		{"evmnet:tls-cert=evmnet.crt,tls-key=evmnet.key"},
+		{"evmnet:folder=/srv/a", "evmnet:folder=/srv/b"},
+		{"a:private-listen=0.0.0.0:4454", "b:private-listen=0.0.0.0:4454"},
+		{"a:folder=/srv/a", "b:folder=/srv/a/"},
+	} {
+		_, err := ParseBeaconIsolation(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestParseFeatureFlags(t *testing.T) {
+	flags, err := ParseFeatureFlags([]string{"store-repair=off", "evmnet:store-repair=on", "evmnet:fork-cross-check=false"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]map[Feature]bool{
+		"":       {FeatureStoreRepair: false},
+		"evmnet": {FeatureStoreRepair: true, FeatureForkCrossCheck: false},
+	}, flags)
+
+	for _, invalid := range [][]string{{"store-repair"}, {"gossip=on"}, {"store-repair=maybe"}, {":store-repair=on"}} {
+		_, err := ParseFeatureFlags(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestFeatureFlags(t *testing.T) {
+	bp := BeaconProcess{
+		log:      testlogger.New(t),
+		beaconID: "evmnet",
+		opts:     &Config{clock: clock.NewFakeClock(), featureFlags: []string{"store-repair=off", "evmnet:fork-cross-check=off"}},
+	}
+	ctx := context.Background()
+	features := func(resp *drand.FeatureFlagsResponse) map[string]string {
+		m := make(map[string]string)
+		for _, f := range resp.GetFeatures() {
+			m[f.GetName()] = fmt.Sprintf("%t/%s", f.GetEnabled(), f.GetSource())
+		}
+		return m
+	}
+
+	resp, err := bp.FeatureFlags(ctx, &drand.FeatureFlagsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"store-repair": "false/config", "fork-cross-check": "false/config", "partial-journal": "true/default", "partial-audit": "false/default", "beacon-injection": "false/default", "address-persist": "false/default", "partial-retry": "true/default", "chain-push": "true/default"}, features(resp))
+
+	resp, err = bp.FeatureFlags(ctx, &drand.FeatureFlagsRequest{Name: "store-repair", Enabled: true})
+	require.NoError(t, err)
+	require.Equal(t, "true/runtime", features(resp)["store-repair"])
+	require.True(t, bp.featureEnabled(FeatureStoreRepair))
+
+	resp, err = bp.FeatureFlags(ctx, &drand.FeatureFlagsRequest{Name: "store-repair", Reset_: true})
+	require.NoError(t, err)
+	require.Equal(t, "false/config", features(resp)["store-repair"])
+
+	_, err = bp.FeatureFlags(ctx, &drand.FeatureFlagsRequest{Name: "gossip"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	bp.opts.setFeatureFlags(nil)
+	enabled, source := bp.feature(FeatureForkCrossCheck)
+	require.True(t, enabled)
+	require.Equal(t, featureSourceDefault, source)
+}
+
+func TestStoreRepairPutOffUntilEnabled(t *testing.T) {
+	ctx := context.Background()
+	l := testlogger.New(t)
+	dir := t.TempDir()
+	sch, err := crypto.SchemeFromName(crypto.DefaultSchemeID)
+	require.NoError(t, err)
+	pub := sch.KeyGroup.Point().Pick(random.New())
+
+	// a torn write left an invalid last round behind
+	bstore, err := boltdb.NewBoltStore(ctx, l, dir, nil)
+	require.NoError(t, err)
+	require.NoError(t, bstore.Put(ctx, chain.GenesisBeacon([]byte("seed"))))
+	require.NoError(t, bstore.Put(ctx, &common.Beacon{Round: 1, PreviousSig: []byte("seed"), Signature: []byte("torn")}))
+	require.NoError(t, os.WriteFile(path.Join(dir, beacon.FenceFileName), []byte("1"), 0o600))
+	fence, unclean, err := beacon.NewFencedStore(bstore, dir)
+	require.NoError(t, err)
+	require.True(t, unclean)
+	defer fence.Close()
+
+	bp := BeaconProcess{
+		log:     l,
+		opts:    &Config{clock: clock.NewFakeClock(), featureFlags: []string{"store-repair=off"}},
+		dbFence: fence,
+	}
+	require.NoError(t, bp.repairUncleanStore(ctx, fence, sch, pub))
+	last, err := fence.Last(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), last.Round)
+	require.True(t, fence.Unclean())
+
+	_, err = bp.FeatureFlags(ctx, &drand.FeatureFlagsRequest{Name: string(FeatureStoreRepair), Enabled: true})
+	require.NoError(t, err)
+	last, err = fence.Last(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), last.Round)
+	require.False(t, fence.Unclean())
+}
+
+func TestBeaconRestartBackoff(t *testing.T) {
+	fakeClock := clock.NewFakeClock()
+	bp := BeaconProcess{opts: &Config{clock: fakeClock}}
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second}
+	for _, e := range expected {
+		backoff, ok := bp.nextRestart()
+		require.True(t, ok)
+		require.Equal(t, e, backoff)
+		fakeClock.Advance(backoff)
+	}
+	_, ok := bp.nextRestart()
+	require.False(t, ok, "the restarts should be exhausted")
+
+	// a beacon loop running long enough after its last restart starts over
+	fakeClock.Advance(beaconStableAfter + time.Second)
+	backoff, ok := bp.nextRestart()
+	require.True(t, ok)
+	require.Equal(t, DefaultBeaconRestartBackoff, backoff)
+}
+
+func TestProjectQuorum(t *testing.T) {
+	expected, state := projectQuorum([]float64{memberHealthy, memberHealthy, memberHealthy, memberDegraded}, 3)
+	require.InDelta(t, 3.5, expected, 0.01)
+	require.Equal(t, quorumAtRisk, state)
+
+	_, state = projectQuorum([]float64{memberHealthy, memberHealthy, memberHealthy, memberHealthy}, 3)
+	require.Equal(t, quorumOK, state)
+
+	_, state = projectQuorum([]float64{memberHealthy, memberHealthy, memberDegraded, memberDown}, 3)
+	require.Equal(t, quorumLost, state)
+}
+
+func TestQuorumWatchdog(t *testing.T) {
+	fakeClock := clock.NewFakeClock()
+	nodes := make([]*key.Node, 0, 4)
+	for i, addr := range []string{"self:1", "peer:2", "peer:3", "peer:4"} {
+		nodes = append(nodes, &key.Node{Identity: &key.Identity{Addr: addr}, Index: uint32(i)})
+	}
+	bp := BeaconProcess{
+		log:   testlogger.New(t),
+		opts:  &Config{clock: fakeClock},
+		priv:  &key.Pair{Public: &key.Identity{Addr: "self:1"}},
+		group: &key.Group{Threshold: 3, Period: 3 * time.Second, Nodes: nodes},
+	}
+	ctx := context.Background()
+	kinds := func() []string {
+		var kinds []string
+		for _, e := range bp.events.recent() {
+			kinds = append(kinds, e.GetKind())
+		}
+		return kinds
+	}
+
+	// without a running beacon we can't contribute, the 3 peers are just enough
+	bp.checkQuorum(ctx)
+	require.Equal(t, []string{eventQuorumAtRisk}, kinds())
+
+	bp.connCache.put("peer:2", errors.New("connection refused"), 0, fakeClock.Now())
+	bp.checkQuorum(ctx)
+	require.Equal(t, []string{eventQuorumAtRisk, eventQuorumLost}, kinds())
+
+	// nothing changed, the alarm isn't raised again
+	bp.checkQuorum(ctx)
+	require.Len(t, kinds(), 2)
+
+	bp.connCache.put("peer:2", nil, time.Millisecond, fakeClock.Now())
+	bp.checkQuorum(ctx)
+	require.Equal(t, []string{eventQuorumAtRisk, eventQuorumLost, eventQuorumAtRisk}, kinds())
+}
+
+func TestAvailabilityReport(t *testing.T) {
+	fakeClock := clock.NewFakeClockAt(time.Unix(1_000_000, 0))
+	bp := BeaconProcess{
+		log:      testlogger.New(t),
+		beaconID: "evmnet",
+		opts:     &Config{clock: fakeClock, configFolder: t.TempDir()},
+	}
+	file := bp.statusHistoryFile()
+	require.NoError(t, os.MkdirAll(path.Dir(file), 0o700))
+
+	now := fakeClock.Now().Unix()
+	ok := &drand.StatusResponse{
+		Beacon:     &drand.BeaconStatus{IsRunning: true},
+		ChainStore: &drand.ChainStoreStatus{LastStored: 10, ExpectedLast: 10},
+	}
+	lagging := &drand.StatusResponse{
+		Beacon:     &drand.BeaconStatus{IsRunning: true},
+		ChainStore: &drand.ChainStoreStatus{LastStored: 6, ExpectedLast: 10},
+	}
+	require.NoError(t, appendStatusSamples(file, []statusSample{
+		// too old for the report
+		newStatusSample(now-int64((48*time.Hour).Seconds()), "a:1", nil, errors.New("unreachable")),
+		newStatusSample(now-300, "a:1", ok, nil),
+		newStatusSample(now-300, "b:2", lagging, nil),
+		newStatusSample(now-200, "a:1", ok, nil),
+		newStatusSample(now-200, "b:2", nil, errors.New("unreachable")),
+		newStatusSample(now-100, "a:1", ok, nil),
+		newStatusSample(now-100, "b:2", ok, nil),
+	}))
+
+	resp, err := bp.AvailabilityReport(context.Background(), &drand.AvailabilityReportRequest{})
+	require.NoError(t, err)
+	require.Equal(t, now, resp.GetTo())
+	require.Len(t, resp.GetMembers(), 2)
+	a, b := resp.GetMembers()[0], resp.GetMembers()[1]
+	require.Equal(t, "a:1", a.GetAddress())
+	require.EqualValues(t, 3, a.GetSamples())
+	require.InDelta(t, 100, a.GetUptimePercent(), 0.01)
+	require.Equal(t, "b:2", b.GetAddress())
+	require.EqualValues(t, 2, b.GetUpSamples())
+	require.InDelta(t, 66.67, b.GetUptimePercent(), 0.01)
+	require.InDelta(t, 2, b.GetAverageLag(), 0.01)
+	require.EqualValues(t, 4, b.GetMaxLag())
+
+	_, err = bp.AvailabilityReport(context.Background(), &drand.AvailabilityReportRequest{From: now, To: now - 1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// the samples older than the retention are dropped
+	require.NoError(t, pruneStatusHistory(file, now-int64(time.Hour.Seconds())))
+	count := 0
+	require.NoError(t, readStatusHistory(file, func(*statusSample) { count++ }))
+	require.Equal(t, 6, count)
+}
+
+func TestBeaconScope(t *testing.T) {
+	dd := &DrandDaemon{
+		chainHashes:      make(map[string]string),
+		beaconProcesses:  make(map[string]*BeaconProcess),
+		isolatedGateways: map[string]*net.PrivateGateway{"evmnet": {}},
+	}
+	evmnet := &drand.PublicRandRequest{Metadata: &drand.Metadata{BeaconID: "evmnet"}}
+	quicknet := &drand.PublicRandRequest{Metadata: &drand.Metadata{BeaconID: "quicknet"}}
+	// no metadata is about the default beacon
+	defaultBeacon := &drand.PublicRandRequest{}
+
+	require.NoError(t, dd.checkBeaconScope("evmnet", evmnet))
+	require.Equal(t, codes.PermissionDenied, status.Code(dd.checkBeaconScope("evmnet", quicknet)))
+	require.Equal(t, codes.PermissionDenied, status.Code(dd.checkBeaconScope("evmnet", defaultBeacon)))
+
+	require.Equal(t, codes.PermissionDenied, status.Code(dd.checkBeaconScope("", evmnet)))
+	require.NoError(t, dd.checkBeaconScope("", quicknet))
+	require.NoError(t, dd.checkBeaconScope("", defaultBeacon))
+
+	// the requests which aren't about a beacon are served everywhere
+	require.NoError(t, dd.checkBeaconScope("evmnet", &drand.ListBeaconIDsRequest{}))
+}
+
+func TestSubBeaconAggregation(t *testing.T) {
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	n, thr := 3, 2
+	priPoly := share.NewPriPoly(sch.KeyGroup, thr, sch.KeyGroup.Scalar().Pick(random.New()), random.New())
+	pubPoly := priPoly.Commit(sch.KeyGroup.Point().Base())
+	group := &key.Group{Scheme: sch, Threshold: thr, Nodes: make([]*key.Node, n)}
+	bp := BeaconProcess{
+		log:  testlogger.New(t),
+		opts: &Config{clock: clock.NewFakeClock(), subBeacons: []string{"hourly=1h"}},
+	}
+	bp.startSubBeacons()
+	// the streams don't run without a group, but are registered
+	bp.stopSubBeacons()
+	stream, err := bp.subBeacons.get("hourly")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	msg := subBeaconMessage("default", "hourly", 3)
+	require.NotEqual(t, sch.DigestBeacon(&common.Beacon{Round: 3}), msg, "the messages must be domain separated")
+	for _, s := range priPoly.Shares(n)[:thr] {
+		partial, err := sch.ThresholdScheme.Sign(s, msg)
+		require.NoError(t, err)
+		bp.addSubBeaconPartial(ctx, stream, 3, msg, partial, group, pubPoly)
+	}
+
+	resp, err := bp.subBeaconRand(ctx, &drand.PublicRandRequest{SubBeacon: "hourly"})
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), resp.GetRound())
+	require.NoError(t, sch.ThresholdScheme.VerifyRecovered(pubPoly.Commit(), msg, resp.GetSignature()))
+
+	_, err = bp.subBeaconRand(ctx, &drand.PublicRandRequest{SubBeacon: "unknown"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestSubBeaconHistorySurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	dbStore := memdb.NewStore(10)
+	history := mustMetadata(t, dbStore, subBeaconsNamespace)
+	// a round too old to be kept, and one of another sub-beacon
+	require.NoError(t, history.Put(ctx, subBeaconKey("hourly", 1), []byte("old")))
+	require.NoError(t, history.Put(ctx, subBeaconKey("daily", 7), []byte("daily")))
+	require.NoError(t, history.Put(ctx, subBeaconKey("hourly", subBeaconHistory+1), []byte("kept")))
+
+	bp := BeaconProcess{
+		log:     testlogger.New(t),
+		dbStore: dbStore,
+		opts:    &Config{clock: clock.NewFakeClock(), subBeacons: []string{"hourly=1h"}},
+	}
+	bp.startSubBeacons()
+	bp.stopSubBeacons()
+	stream, err := bp.subBeacons.get("hourly")
+	require.NoError(t, err)
+
+	last, err := stream.store.Last(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(subBeaconHistory+1), last.Round)
+	require.Equal(t, common.HexBytes("kept"), last.Signature)
+	_, err = stream.store.Get(ctx, 1)
+	require.Error(t, err)
+	old, err := history.Get(ctx, subBeaconKey("hourly", 1))
+	require.NoError(t, err)
+	require.Nil(t, old)
+	daily, err := history.Get(ctx, subBeaconKey("daily", 7))
+	require.NoError(t, err)
+	require.Equal(t, []byte("daily"), daily)
+}
+
+func TestPausedNodeSignsNoSubBeacon(t *testing.T) {
+	clk := clock.NewFakeClock()
+	bp := BeaconProcess{
+		log:   testlogger.New(t),
+		opts:  &Config{clock: clk, subBeacons: []string{"hourly=1h"}},
+		pause: pauseState{since: clk.Now(), reason: "maintenance"},
+	}
+	bp.startSubBeacons()
+	bp.stopSubBeacons()
+	stream, err := bp.subBeacons.get("hourly")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, bp.signSubBeacon(ctx, stream, 3))
+	require.Empty(t, stream.pending)
+	require.NoError(t, bp.signHeartbeat(ctx, 3))
+	require.Nil(t, bp.heartbeats.pending)
+}
+
+func TestComputeHealthScore(t *testing.T) {
+	tests := []struct {
+		name string
+		in   healthInputs
+		want uint32
+	}{
+		{"not running", healthInputs{lag: 0, peers: 3, reachable: 3}, 0},
+		{"healthy", healthInputs{running: true, peers: 3, reachable: 3, tolerated: 1}, 100},
+		{"alone", healthInputs{running: true}, 100},
+		{"lagging", healthInputs{running: true, lag: 4, peers: 3, reachable: 3, tolerated: 1}, 86},
+		{"far behind", healthInputs{running: true, lag: 100, peers: 3, reachable: 3, tolerated: 1}, 60},
+		{"unreachable peer", healthInputs{running: true, peers: 3, reachable: 2, tolerated: 1}, 90},
+		{"failing partials", healthInputs{running: true, peers: 4, reachable: 4, failing: 1, tolerated: 2}, 85},
+		{"failing beyond tolerance", healthInputs{running: true, peers: 3, reachable: 3, failing: 2, tolerated: 1}, 70},
+		{"failed dkg", healthInputs{running: true, peers: 3, reachable: 3, tolerated: 1, dkgFailed: true}, 80},
+		{"down", healthInputs{running: true, lag: 100, peers: 3, reachable: 0, failing: 3, tolerated: 1, dkgFailed: true}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, computeHealthScore(&tt.in))
+		})
+	}
+}
+
+func TestCountdown(t *testing.T) {
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	kp, err := key.NewKeyPair("node:1234", sch)
+	require.NoError(t, err)
+	clk := clock.NewFakeClockAt(time.Unix(1000, 0))
+	group := &key.Group{Scheme: sch, Threshold: 1, Period: 3 * time.Second, GenesisTime: 1030,
+		Nodes: []*key.Node{{Identity: kp.Public}}}
+	bp := &BeaconProcess{
+		log:      testlogger.New(t),
+		beaconID: "default",
+		priv:     kp,
+		opts:     &Config{clock: clk},
+	}
+
+	_, err = bp.Countdown(context.Background(), &drand.CountdownRequest{})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	bp.group = group
+	resp, err := bp.Countdown(context.Background(), &drand.CountdownRequest{})
+	require.NoError(t, err)
+	require.Equal(t, countdownGenesis, resp.GetTarget())
+	require.Equal(t, int64(1030), resp.GetTime())
+	require.Equal(t, int64(30), resp.GetSecondsLeft())
+	require.Equal(t, uint64(1), resp.GetRound())
+	require.Equal(t, uint32(1), resp.GetThreshold())
+	// the beacon isn't set up yet
+	require.Len(t, resp.GetNodes(), 1)
+	require.False(t, resp.GetNodes()[0].GetReady())
+	require.Equal(t, uint32(0), resp.GetReady())
+
+	group.TransitionTime = 1090
+	clk.Advance(time.Minute)
+	resp, err = bp.Countdown(context.Background(), &drand.CountdownRequest{})
+	require.NoError(t, err)
+	require.Equal(t, countdownTransition, resp.GetTarget())
+	require.Equal(t, int64(30), resp.GetSecondsLeft())
+	require.Equal(t, uint64(21), resp.GetRound())
+
+	clk.Advance(time.Minute)
+	resp, err = bp.Countdown(context.Background(), &drand.CountdownRequest{})
+	require.NoError(t, err)
+	require.Empty(t, resp.GetTarget())
+	require.Empty(t, resp.GetNodes())
+}
+
+func TestNotReadyReason(t *testing.T) {
+	running := &drand.BeaconStatus{Status: uint32(BeaconInited), IsRunning: true}
+	require.Empty(t, notReadyReason(running))
+	require.NotEmpty(t, notReadyReason(nil))
+	require.NotEmpty(t, notReadyReason(&drand.BeaconStatus{Status: uint32(BeaconInited)}))
+	require.NotEmpty(t, notReadyReason(&drand.BeaconStatus{Status: uint32(BeaconInited), IsRunning: true, IsStopped: true}))
+	require.Contains(t, notReadyReason(&drand.BeaconStatus{Status: uint32(BeaconInited), IsRunning: true, IsPaused: true,
+		PauseReason: "maintenance"}), "maintenance")
+}
+
+func TestListBeaconIDs(t *testing.T) {
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	l := testlogger.New(t)
+	conf := NewConfig(l, WithConfigFolder(t.TempDir()))
+
+	// a beacon configured on disk, with its keys but no group yet
+	stored, err := key.NewKeyPair("node:1234", sch)
+	require.NoError(t, err)
+	require.NoError(t, key.NewFileStore(conf.ConfigFolderMB(), "stored").SaveKeyPair(stored))
+
+	kp, err := key.NewKeyPair("node:1234", sch)
+	require.NoError(t, err)
+	group := &key.Group{Scheme: sch, Threshold: 1, Period: 3 * time.Second, GenesisTime: 1000, ID: "loaded",
+		PublicKey: &key.DistPublic{Coefficients: []kyber.Point{kp.Public.Key}}, Nodes: []*key.Node{{Identity: kp.Public}}}
+	bp := &BeaconProcess{log: l, beaconID: "loaded", priv: kp, group: group, opts: conf}
+	dd := &DrandDaemon{
+		log:             l,
+		opts:            conf,
+		chainHashes:     make(map[string]string),
+		beaconProcesses: map[string]*BeaconProcess{"loaded": bp},
+	}
+
+	// the public service only lists the loaded beacons
+	resp, err := dd.ListBeaconIDs(context.Background(), &drand.ListBeaconIDsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"loaded"}, resp.GetIds())
+	require.Empty(t, resp.GetBeacons())
+
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(),
+		&methodStream{method: "/" + drand.Control_ServiceDesc.ServiceName + "/ListBeaconIDs"})
+	resp, err = dd.ListBeaconIDs(ctx, &drand.ListBeaconIDsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"loaded"}, resp.GetIds())
+	require.Len(t, resp.GetBeacons(), 2)
+
+	loaded, onDisk := resp.GetBeacons()[0], resp.GetBeacons()[1]
+	require.Equal(t, "loaded", loaded.GetId())
+	require.True(t, loaded.GetLoaded())
+	require.False(t, loaded.GetRunning())
+	require.Equal(t, sch.Name, loaded.GetScheme())
+	require.Equal(t, chain2.NewChainInfo(group).Hash(), loaded.GetChainHash())
+
+	require.Equal(t, "stored", onDisk.GetId())
+	require.False(t, onDisk.GetLoaded())
+	require.Equal(t, sch.Name, onDisk.GetScheme())
+	require.Empty(t, onDisk.GetChainHash())
+
+	own, err := bp.ListBeaconIDs(context.Background(), &drand.ListBeaconIDsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"loaded"}, own.GetIds())
+	require.Equal(t, loaded.GetChainHash(), own.GetMetadatas()[0].GetChainHash())
+}
+
+// methodStream makes a context look like the one of a call to the given gRPC method
+type methodStream struct {
+	grpc.ServerTransportStream
+	method string
+}
+
+func (m *methodStream) Method() string {
+	return m.method
+}
+
+// fakeDKGProcess records the beacons whose DKG state is deleted, and whether their folder was still there
+type fakeDKGProcess struct {
+	DKGProcess
+	folder    func(beaconID string) string
+	removed   []string
+	leftovers []string
+	err       error
+}
+
+func (f *fakeDKGProcess) CheckRemoveBeacon(string) error {
+	return f.err
+}
+
+func (f *fakeDKGProcess) ExportBeacon(_, folder string) error {
+	return os.WriteFile(path.Join(folder, dkg.ExportedCurrentFileName), []byte("State = 4"), 0o600)
+}
+
+func (f *fakeDKGProcess) RemoveBeacon(beaconID string) error {
+	if f.err != nil {
+		return f.err
+	}
+	if _, err := os.Stat(f.folder(beaconID)); err == nil {
+		f.leftovers = append(f.leftovers, beaconID)
+	}
+	f.removed = append(f.removed, beaconID)
+	return nil
+}
+
+func TestRemoveBeaconID(t *testing.T) {
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	l := testlogger.New(t)
+	clk := clock.NewFakeClock()
+	conf := NewConfig(l, WithConfigFolder(t.TempDir()), WithClock(clk))
+	for _, id := range []string{"deprecated", "old", "busy"} {
+		kp, err := key.NewKeyPair("node:1234", sch)
+		require.NoError(t, err)
+		require.NoError(t, key.NewFileStore(conf.ConfigFolderMB(), common.BeaconID(id)).SaveKeyPair(kp))
+	}
+	dkgProcess := &fakeDKGProcess{folder: func(beaconID string) string {
+		return path.Join(conf.ConfigFolderMB(), beaconID)
+	}}
+	dd := &DrandDaemon{
+		log:             l,
+		opts:            conf,
+		dkg:             dkgProcess,
+		chainHashes:     make(map[string]string),
+		beaconProcesses: make(map[string]*BeaconProcess),
+	}
+	ctx := context.Background()
+	remove := func(beaconID string, archive, confirm bool) (*drand.RemoveBeaconIDResponse, error) {
+		return dd.RemoveBeaconID(ctx, &drand.RemoveBeaconIDRequest{
+			Metadata: &drand.Metadata{BeaconID: beaconID}, Archive: archive, Confirm: confirm,
+		})
+	}
+
+	_, err = remove("", false, true)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = remove("unknown", false, true)
+	require.Equal(t, codes.NotFound, status.Code(err))
+	// the id can't point outside of the folder of a beacon
+	for _, id := range []string{"..", ".", "../multibeacon", "old/key"} {
+		_, err = remove(id, false, true)
+		require.Equal(t, codes.InvalidArgument, status.Code(err), id)
+	}
+	require.FileExists(t, path.Join(conf.ConfigFolderMB(), "old", key.FolderName, "drand_id.private"))
+
+	// nothing is removed unless confirmed
+	folder := path.Join(conf.ConfigFolderMB(), "deprecated")
+	_, err = remove("deprecated", false, false)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.DirExists(t, folder)
+	require.Empty(t, dkgProcess.removed)
+
+	resp, err := remove("deprecated", false, true)
+	require.NoError(t, err)
+	require.Equal(t, folder, resp.GetFolder())
+	require.False(t, resp.GetUnloaded())
+	require.NoDirExists(t, folder)
+	require.Equal(t, []string{"deprecated"}, dkgProcess.removed)
+
+	resp, err = remove("old", true, true)
+	require.NoError(t, err)
+	archived := path.Join(conf.ConfigFolder(), ArchiveFolder, fmt.Sprintf("old-%d", clk.Now().Unix()))
+	require.Equal(t, archived, resp.GetArchivedTo())
+	require.NoDirExists(t, path.Join(conf.ConfigFolderMB(), "old"))
+	require.FileExists(t, path.Join(archived, key.FolderName, "drand_id.private"))
+	require.FileExists(t, path.Join(archived, dkg.ExportedCurrentFileName))
+	// the DKG state is only deleted once the folder is gone
+	require.Equal(t, []string{"deprecated", "old"}, dkgProcess.removed)
+	require.Empty(t, dkgProcess.leftovers)
+
+	// the beacon is kept when its DKG state can't be deleted, e.g. during a DKG
+	dkgProcess.err = errors.New("a DKG of beacon busy is in progress")
+	_, err = remove("busy", false, true)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.DirExists(t, path.Join(conf.ConfigFolderMB(), "busy"))
+
+	stores, err := beaconKeyStores(l, conf)
+	require.NoError(t, err)
+	require.Len(t, stores, 1)
+}
+
+type fakeEntropyServer struct {
+	drand.Control_EntropyStreamServer
+	chunks []*drand.EntropyChunk
+}
+
+func (f *fakeEntropyServer) Context() context.Context {
+	return context.Background()
+}
+
+func (f *fakeEntropyServer) Send(c *drand.EntropyChunk) error {
+	f.chunks = append(f.chunks, c)
+	return nil
+}
+
+func TestEntropyStream(t *testing.T) {
+	bp := &BeaconProcess{log: testlogger.New(t), beaconID: "default"}
+	server := new(fakeEntropyServer)
+
+	err := bp.EntropyStream(&drand.EntropyStreamRequest{BytesPerRound: crypto.MaxEntropyPerRound + 1}, server)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	err = bp.EntropyStream(&drand.EntropyStreamRequest{}, server)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	chainHash := make([]byte, 32)
+	stream := &entropyStream{Control_EntropyStreamServer: server, chainHash: chainHash, size: 48}
+	sig := []byte("signature of round 5")
+	require.NoError(t, stream.Send(&drand.BeaconPacket{Round: 5, Signature: sig}))
+	require.NoError(t, stream.Send(&drand.BeaconPacket{Round: 6, Signature: sig}))
+	require.Len(t, server.chunks, 2)
+
+	expected, err := crypto.DeriveEntropy(chainHash, 5, crypto.RandomnessFromSignature(sig), 48)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), server.chunks[0].GetRound())
+	require.Equal(t, expected, server.chunks[0].GetData())
+	// the round is part of the derivation, the same signature doesn't give the same bytes
+	require.NotEqual(t, server.chunks[0].GetData(), server.chunks[1].GetData())
+}
+
+type fakeStatusServer struct {
+	drand.Control_StatusStreamServer
+	ctx   context.Context
+	sends chan *drand.StatusResponse
+}
+
+func (f *fakeStatusServer) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeStatusServer) Send(s *drand.StatusResponse) error {
+	f.sends <- s
+	return nil
+}
+
+func TestStatusStream(t *testing.T) {
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	kp, err := key.NewKeyPair("node:1234", sch)
+	require.NoError(t, err)
+	clk := clock.NewFakeClockAt(time.Unix(1000, 0))
+	bp := &BeaconProcess{
+		log:      testlogger.New(t),
+		beaconID: "default",
+		priv:     kp,
+		opts:     &Config{clock: clk},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	server := &fakeStatusServer{ctx: ctx, sends: make(chan *drand.StatusResponse, 10)}
+	done := make(chan error, 1)
+	go func() {
+		done <- bp.StatusStream(&drand.StatusStreamRequest{IntervalSeconds: 5}, server)
+	}()
+
+	receive := func() *drand.StatusResponse {
+		select {
+		case s := <-server.sends:
+			return s
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "no status pushed")
+			return nil
+		}
+	}
+	// the status is pushed when subscribing
+	require.Empty(t, receive().GetLeaving())
+
+	bp.state.Lock()
+	bp.leaving = map[string]uint64{"node:5678": 10}
+	bp.state.Unlock()
+	bp.events.record(clk.Now(), eventLeaveAnnounced, "node:5678")
+	leaving := receive().GetLeaving()
+	require.Len(t, leaving, 1)
+	require.Equal(t, "node:5678", leaving[0].GetAddress())
+
+	// an event which didn't change the status isn't pushed
+	bp.events.record(clk.Now(), eventFeatureChanged, "")
+	select {
+	case <-server.sends:
+		require.FailNow(t, "unchanged status pushed")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// the status is pushed at the interval even if it didn't change
+	clk.BlockUntil(2)
+	clk.Advance(5 * time.Second)
+	require.Len(t, receive().GetLeaving(), 1)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestControlAuthToken(t *testing.T) {
+	l := testlogger.New(t)
+	folder := t.TempDir()
+
+	auth, err := loadControlAuth(l, NewConfig(l, WithConfigFolder(folder)))
+	require.NoError(t, err)
+	require.False(t, auth.Enabled())
+
+	// the token is created in the config folder on the first start, then reused
+	conf := NewConfig(l, WithConfigFolder(folder), WithControlAuth(true))
+	auth, err = loadControlAuth(l, conf)
+	require.NoError(t, err)
+	require.True(t, auth.Enabled())
+	file := path.Join(folder, DefaultControlTokenFile)
+	stat, err := os.Stat(file)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), stat.Mode().Perm())
+	created, err := os.ReadFile(file)
+	require.NoError(t, err)
+
+	dd := &DrandDaemon{opts: conf, log: l, controlAuth: auth}
+	resp, err := dd.RotateControlToken(context.Background(), &drand.RotateControlTokenRequest{})
+	require.NoError(t, err)
+	rotated, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.NotEqual(t, created, rotated)
+	require.Equal(t, resp.GetToken()+"\n", string(rotated))
+
+	// the response to an incident rotates it too
+	_, skipped, err := dd.rotateIncidentCredentials(context.Background())
+	require.NoError(t, err)
+	require.False(t, skipped)
+	again, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.NotEqual(t, rotated, again)
+
+	// a token given by the configuration takes precedence and can't be rotated
+	conf = NewConfig(l, WithConfigFolder(folder), WithControlToken("given"))
+	auth, err = loadControlAuth(l, conf)
+	require.NoError(t, err)
+	require.True(t, auth.Enabled())
+	dd = &DrandDaemon{opts: conf, log: l, controlAuth: auth}
+	_, err = dd.RotateControlToken(context.Background(), &drand.RotateControlTokenRequest{})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, skipped, err = dd.rotateIncidentCredentials(context.Background())
+	require.NoError(t, err)
+	require.True(t, skipped)
+}
+
+func TestReloadConfig(t *testing.T) {
+	l := log.New(nil, log.InfoLevel, true)
+	addr := "127.0.0.1:4444"
+	conf := NewConfig(l, WithPrivateListenAddress(addr), WithSyncSources(nil, []string{"10.0.0.1"}, nil))
+	dd := &DrandDaemon{opts: conf, log: l, beaconProcesses: map[string]*BeaconProcess{}}
+
+	_, err := dd.ReloadConfig(context.Background(), &drand.ReloadConfigRequest{})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	var next *Config
+	WithConfigReloader(func() (*Config, error) { return next, nil })(conf)
+
+	// an invalid configuration is refused as a whole
+	next = NewConfig(l, WithPrivateListenAddress(addr), WithSyncSources(nil, []string{"region:"}, nil))
+	_, err = dd.ReloadConfig(context.Background(), &drand.ReloadConfigRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	next = NewConfig(log.New(nil, log.DebugLevel, true), WithPrivateListenAddress(addr), WithDBStorageEngine(chain.MemDB),
+		WithSyncSources(nil, []string{"10.0.0.2"}, nil), WithMaxStalePeriods(3))
+	resp, err := dd.ReloadConfig(context.Background(), &drand.ReloadConfigRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []string{reloadLogLevel, reloadSyncSources, reloadMaxStalePeriods}, resp.GetChanged())
+	level, _ := log.GetLevel(l)
+	require.Equal(t, log.DebugLevel, level)
+	require.Equal(t, uint64(3), conf.MaxStalePeriods())
+	sources, err := conf.SyncSources()
+	require.NoError(t, err)
+	require.True(t, sources.Allowed("10.0.0.1:4444"))
+	require.False(t, sources.Allowed("10.0.0.2:4444"))
+
+	// nothing changes when reloading the same configuration again
+	resp, err = dd.ReloadConfig(context.Background(), &drand.ReloadConfigRequest{})
+	require.NoError(t, err)
+	require.Empty(t, resp.GetChanged())
+	require.Empty(t, resp.GetRestartRequired())
+
+	// the request timeout applies right away, the metrics and traces need a restart
+	next = NewConfig(log.New(nil, log.DebugLevel, true), WithPrivateListenAddress(addr), WithDBStorageEngine(chain.MemDB),
+		WithSyncSources(nil, []string{"10.0.0.2"}, nil), WithMaxStalePeriods(3), WithRequestTimeout(time.Second),
+		WithMetricsAddress("127.0.0.1:9999"), WithTracesEndpoint("127.0.0.1:4317"))
+	resp, err = dd.ReloadConfig(context.Background(), &drand.ReloadConfigRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []string{reloadRequestTimeout}, resp.GetChanged())
+	require.Equal(t, []string{restartMetrics, restartTraces}, resp.GetRestartRequired())
+	require.Equal(t, time.Second, conf.RequestTimeout())
+}
+
+func TestLogLevels(t *testing.T) {
+	l := log.New(nil, log.InfoLevel, true)
+	dd := &DrandDaemon{opts: NewConfig(l), log: l, version: common.GetAppVersion()}
+	ctx := context.Background()
+
+	resp, err := dd.LogLevels(ctx, &drand.LogLevelsRequest{Logger: "Follow", Level: "debug"})
+	require.NoError(t, err)
+	require.Equal(t, "info", resp.GetLevel())
+	require.Equal(t, map[string]string{"Follow": "debug"}, resp.GetNamed())
+
+	resp, err = dd.LogLevels(ctx, &drand.LogLevelsRequest{Level: "warn"})
+	require.NoError(t, err)
+	require.Equal(t, "warn", resp.GetLevel())
+	level, _ := log.GetLevel(l)
+	require.Equal(t, log.WarnLevel, level)
+
+	_, err = dd.LogLevels(ctx, &drand.LogLevelsRequest{Level: "verbose"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = dd.LogLevels(ctx, &drand.LogLevelsRequest{Remove: true})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	resp, err = dd.LogLevels(ctx, &drand.LogLevelsRequest{Logger: "Follow", Remove: true})
+	require.NoError(t, err)
+	require.Empty(t, resp.GetNamed())
+}
+
+func TestReshareForecast(t *testing.T) {
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	kp, err := key.NewKeyPair("node:1234", sch)
+	require.NoError(t, err)
+	beaconID := "default"
+	folder := t.TempDir()
+	require.NoError(t, os.MkdirAll(path.Join(folder, common.MultiBeaconFolder, beaconID), 0o700))
+	clk := clock.NewFakeClock()
+	bp := BeaconProcess{
+		log:      testlogger.New(t),
+		beaconID: beaconID,
+		priv:     kp,
+		group:    &key.Group{Scheme: sch, Threshold: 1, Nodes: []*key.Node{{Identity: kp.Public}}},
+		opts:     &Config{clock: clk, configFolder: folder},
+	}
+	ctx := context.Background()
+
+	_, err = bp.ForecastReshare(ctx, &drand.ForecastReshareRequest{At: clk.Now().Add(-time.Hour).Unix()})
+	require.Error(t, err)
+
+	at := clk.Now().Add(72 * time.Hour)
+	resp, err := bp.ForecastReshare(ctx, &drand.ForecastReshareRequest{At: at.Unix(), Note: "node:5678 joins"})
+	require.NoError(t, err)
+	require.Equal(t, "node:1234", resp.GetForecast().GetCoordinator())
+	require.Equal(t, at.Unix(), resp.GetForecast().GetAt())
+	require.Equal(t, "node:5678 joins", resp.GetForecast().GetNote())
+
+	// the announcements must be signed by the coordinator, and be more recent than the forecast registered
+	announce := func(f *reshareForecast) error {
+		_, err := bp.AnnounceReshareForecast(ctx, &drand.ReshareForecastAnnouncement{
+			Coordinator: f.Coordinator, At: f.At, Note: f.Note, Sequence: f.Sequence, Signature: f.Signature,
+		})
+		return err
+	}
+	stale := &reshareForecast{Coordinator: "node:1234", At: at.Unix() + 60, Sequence: 1}
+	stale.Signature, err = sch.AuthScheme.Sign(kp.Key, forecastMessage(beaconID, stale))
+	require.NoError(t, err)
+	require.Error(t, announce(stale))
+	forged := *stale
+	forged.Sequence = bp.forecast.Sequence + 1
+	require.Error(t, announce(&forged))
+	require.Equal(t, at.Unix(), bp.forecast.At)
+
+	// the sequence is bounded by our clock, so that a forecast can't outlive the next ones
+	ahead := &reshareForecast{Coordinator: "node:1234", At: at.Unix(),
+		Sequence: uint64(clk.Now().Add(maxForecastClockSkew + time.Second).UnixNano())}
+	ahead.Signature, err = sch.AuthScheme.Sign(kp.Key, forecastMessage(beaconID, ahead))
+	require.NoError(t, err)
+	require.ErrorContains(t, announce(ahead), "in the future")
+	ahead.Sequence = uint64(clk.Now().Add(time.Minute).UnixNano())
+	ahead.Signature, err = sch.AuthScheme.Sign(kp.Key, forecastMessage(beaconID, ahead))
+	require.NoError(t, err)
+	require.NoError(t, announce(ahead))
+
+	// a forecast made with a clock behind still replaces it
+	resp, err = bp.ForecastReshare(ctx, &drand.ForecastReshareRequest{At: at.Unix(), Note: "node:5678 joins"})
+	require.NoError(t, err)
+	require.Equal(t, ahead.Sequence+1, bp.forecast.Sequence)
+	require.Equal(t, "node:5678 joins", resp.GetForecast().GetNote())
+
+	// the operator is reminded once for each threshold crossed, the closest one only
+	reminders := func() int {
+		count := 0
+		for _, e := range bp.events.recent() {
+			if e.Kind == eventReshareApproaching {
+				count++
+			}
+		}
+		return count
+	}
+	bp.checkReshareForecast(ctx)
+	bp.checkReshareForecast(ctx)
+	require.Equal(t, 1, reminders())
+	clk.Advance(71*time.Hour + 30*time.Minute)
+	bp.checkReshareForecast(ctx)
+	bp.checkReshareForecast(ctx)
+	require.Equal(t, 2, reminders())
+
+	// the forecast is kept across restarts
+	saved := bp.forecast
+	bp.forecast = nil
+	require.NoError(t, bp.loadReshareForecast(bp.group))
+	require.Equal(t, saved, bp.forecast)
+
+	// it isn't reported anymore once its date passed
+	clk.Advance(time.Hour)
+	resp, err = bp.ForecastReshare(ctx, &drand.ForecastReshareRequest{})
+	require.NoError(t, err)
+	require.Nil(t, resp.GetForecast())
+}
+
+func TestRoundCountSlides(t *testing.T) {
+	ctx := context.Background()
+	store := memdb.NewStore(100)
+	for round := uint64(0); round <= 20; round++ {
+		if round == 5 {
+			continue
+		}
+		require.NoError(t, store.Put(ctx, &common.Beacon{Round: round, Signature: []byte{byte(round)}}))
+	}
+	// a nil limiter doesn't throttle
+	var limiter *iolimit.Limiter
+
+	var rc roundCount
+	count, err := rc.count(ctx, store, limiter, 1, 10)
+	require.NoError(t, err)
+	require.EqualValues(t, 9, count)
+	count, err = rc.count(ctx, store, limiter, 3, 15)
+	require.NoError(t, err)
+	require.EqualValues(t, 12, count)
+
+	// only the rounds entering and leaving the window are read again
+	require.NoError(t, store.Del(ctx, 10))
+	count, err = rc.count(ctx, store, limiter, 3, 15)
+	require.NoError(t, err)
+	require.EqualValues(t, 12, count)
+	count, err = rc.count(ctx, store, limiter, 4, 16)
+	require.NoError(t, err)
+	require.EqualValues(t, 12, count)
+
+	// a window going back is counted again
+	count, err = rc.count(ctx, store, limiter, 1, 10)
+	require.NoError(t, err)
+	require.EqualValues(t, 8, count)
+	count, err = rc.count(ctx, store, limiter, 12, 11)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestGroupMemberSyncsAreRecognized(t *testing.T) {
+	l := testlogger.New(t)
+	group := &key.Group{Nodes: []*key.Node{
+		{Identity: &key.Identity{Addr: "203.0.113.5:4444"}},
+		{Identity: &key.Identity{Addr: "localhost:5555"}, Index: 1},
+	}}
+	dd := &DrandDaemon{
+		beaconProcesses: map[string]*BeaconProcess{"default": {group: group}},
+		opts:            NewConfig(l),
+		log:             l,
+	}
+
+	from := func(ip string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{Addr: &gonet.TCPAddr{IP: gonet.ParseIP(ip), Port: 43210}})
+	}
+	require.True(t, dd.fromGroupMember(from("203.0.113.5")))
+	require.True(t, dd.fromGroupMember(from("127.0.0.1")), "the hosts of the members are resolved")
+	require.False(t, dd.fromGroupMember(from("203.0.113.6")))
+	require.False(t, dd.fromGroupMember(context.Background()))
+}
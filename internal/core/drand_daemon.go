@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"path"
 	"sync"
 
+	clock "github.com/jonboulle/clockwork"
 	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc"
 
 	pdkg "github.com/drand/drand/v2/protobuf/dkg"
 
@@ -17,11 +20,14 @@ import (
 	"github.com/drand/drand/v2/common/log"
 	"github.com/drand/drand/v2/common/tracer"
 	dhttp "github.com/drand/drand/v2/handler/http"
+	"github.com/drand/drand/v2/internal/crash"
 	"github.com/drand/drand/v2/internal/dkg"
 	"github.com/drand/drand/v2/internal/metrics"
 	"github.com/drand/drand/v2/internal/metrics/pprof"
 	"github.com/drand/drand/v2/internal/net"
+	"github.com/drand/drand/v2/internal/redis"
 	"github.com/drand/drand/v2/internal/util"
+	"github.com/drand/drand/v2/plugin"
 	"github.com/drand/drand/v2/protobuf/drand"
 )
 
@@ -32,7 +38,17 @@ type DrandDaemon struct {
 
 	privGateway *net.PrivateGateway
 	pubGateway  *net.PublicGateway
-	control     net.ControlListener
+	// the private gateways of the beacons isolated on their own listener, by beacon ID
+	isolatedGateways map[string]*net.PrivateGateway
+	// mirrors the public requests to a shadow daemon, nil if disabled
+	mirror *net.Mirror
+	// answers the UDP queries for the latest beacon, nil if disabled
+	udp *net.UDPResponder
+	// shares the latest beacons and the usage of the public API with the other instances serving it, nil if disabled
+	redis   *redis.Client
+	control net.ControlListener
+	// authenticates the calls to the control port, it accepts all of them if disabled
+	controlAuth *net.ControlAuth
 
 	dkg DKGProcess
 
@@ -45,6 +61,15 @@ type DrandDaemon struct {
 	state         sync.RWMutex
 	completedDKGs *util.FanOutChan[dkg.SharingOutput]
 	exitCh        chan bool
+	// runs the hooks of each stage of the shutdown once
+	shutdownStages map[plugin.Stage]*sync.Once
+
+	// stops the daemon when its turn to restart comes during a coordinated upgrade
+	upgradeTimer clock.Timer
+	// stops proposing to refresh the shares periodically, nil if it isn't
+	stopRefresh context.CancelFunc
+	// the addresses of the members of the groups, whose syncs aren't shed
+	memberIPs memberIPs
 
 	// version indicates the base code variant
 	version common.Version
@@ -52,9 +77,14 @@ type DrandDaemon struct {
 
 type DKGProcess interface {
 	DKGStatus(context context.Context, request *pdkg.DKGStatusRequest) (*pdkg.DKGStatusResponse, error)
+	GroupHistory(context context.Context, request *pdkg.GroupHistoryRequest) (*pdkg.GroupHistoryResponse, error)
+	GroupForRound(context context.Context, request *pdkg.GroupForRoundRequest) (*pdkg.GroupForRoundResponse, error)
 	Command(context context.Context, command *pdkg.DKGCommand) (*pdkg.EmptyDKGResponse, error)
 	Packet(context context.Context, packet *pdkg.GossipPacket) (*pdkg.EmptyDKGResponse, error)
 	Migrate(beaconID string, group *key.Group, share *key.Share) error
+	CheckRemoveBeacon(beaconID string) error
+	ExportBeacon(beaconID, folder string) error
+	RemoveBeacon(beaconID string) error
 	BroadcastDKG(context context.Context, packet *pdkg.DKGPacket) (*pdkg.EmptyDKGResponse, error)
 	Close()
 }
@@ -67,9 +97,14 @@ func NewDrandDaemon(ctx context.Context, c *Config) (*DrandDaemon, error) {
 	logger := c.Logger()
 
 	drandDaemon := &DrandDaemon{
-		opts:            c,
-		log:             logger,
-		exitCh:          make(chan bool, 1),
+		opts:   c,
+		log:    logger,
+		exitCh: make(chan bool, 1),
+		shutdownStages: map[plugin.Stage]*sync.Once{
+			plugin.StagePreStop:           new(sync.Once),
+			plugin.StagePostStoreFlush:    new(sync.Once),
+			plugin.StagePostListenerClose: new(sync.Once),
+		},
 		completedDKGs:   util.NewFanOutChan[dkg.SharingOutput](),
 		version:         common.GetAppVersion(),
 		beaconProcesses: make(map[string]*BeaconProcess),
@@ -100,6 +135,13 @@ func (dd *DrandDaemon) RemoteStatus(ctx context.Context, request *drand.RemoteSt
 	ctx, span := tracer.NewSpan(ctx, "dd.RemoteStatus")
 	defer span.End()
 
+	if err := dd.checkNodeListSize(len(request.GetAddresses())); err != nil {
+		return nil, err
+	}
+	if err := normalizeNodeList(request.GetAddresses()); err != nil {
+		return nil, err
+	}
+
 	beaconID, err := dd.readBeaconID(request.Metadata)
 	if err != nil {
 		return nil, err
@@ -113,6 +155,32 @@ func (dd *DrandDaemon) RemoteStatus(ctx context.Context, request *drand.RemoteSt
 	return bp.RemoteStatus(ctx, request)
 }
 
+// GroupBuildInfo collects the build info of all the nodes of the group and compares them with ours
+func (dd *DrandDaemon) GroupBuildInfo(ctx context.Context, request *drand.GroupBuildInfoRequest) (*drand.GroupBuildInfoResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.GroupBuildInfo")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(request.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.GroupBuildInfo(ctx, request)
+}
+
+// GroupDST collects the domain separation tags of all the nodes of the group and compares them with ours
+func (dd *DrandDaemon) GroupDST(ctx context.Context, request *drand.GroupDSTRequest) (*drand.GroupDSTResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.GroupDST")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(request.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.GroupDST(ctx, request)
+}
+
 func (dd *DrandDaemon) init(ctx context.Context) error {
 	ctx, span := tracer.NewSpan(ctx, "dd.init")
 	defer span.End()
@@ -129,6 +197,10 @@ func (dd *DrandDaemon) init(ctx context.Context) error {
 	if privAddr == "" {
 		return fmt.Errorf("private listen address cannot be empty")
 	}
+	if _, err := c.ShutdownOrder(); err != nil {
+		return err
+	}
+	crash.SetFolder(path.Join(c.ConfigFolder(), DefaultCrashFolder))
 
 	// we set our logger name to its node address
 	dd.log = dd.log.Named(privAddr)
@@ -144,6 +216,31 @@ func (dd *DrandDaemon) init(ctx context.Context) error {
 		span.RecordError(err)
 		return err
 	}
+	handler.SetMaxStalePeriods(c.MaxStalePeriods())
+	handler.SetPrefetch(c.PrefetchLead())
+	handler.SetV1Compat(c.V1Compat(V1CompatPublic))
+	handler.SetLoadShedder(c.LoadShedder())
+	quotas, err := c.Quotas()
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	handler.SetQuotas(quotas)
+	redisOpts, err := c.RedisOptions()
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if redisOpts != nil {
+		dd.redis = redis.New(*redisOpts)
+		handler.SetSharedCache(dd.redis)
+		if quotas != nil {
+			quotas.SetCounters(dd.redis)
+		}
+		dd.log.Infow("sharing the latest beacons and the usage of the API", "redis", redisOpts.Addr)
+	}
+	handler.SetCORS(c.CORS())
+	handler.SetSecurityHeaders(c.SecurityHeaders())
 
 	if pubAddr != "" {
 		if dd.pubGateway, err = net.NewRESTPublicGateway(ctx, pubAddr, handler.GetHTTPHandler()); err != nil {
@@ -154,14 +251,63 @@ func (dd *DrandDaemon) init(ctx context.Context) error {
 
 	// set up the gRPC clients
 	p := c.ControlPort()
-	controlListener, err := net.NewGRPCListener(lg, dd, p)
+	dd.controlAuth, err = loadControlAuth(lg, c)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	controlOpts := append(c.RequestLimits().ServerOptions(), dd.controlAuth.ServerOptions()...)
+	controlListener, err := net.NewGRPCListener(lg, dd, p, controlOpts...)
 	if err != nil {
 		return err
 	}
 	dd.control = controlListener
 
 	dd.handler = handler
-	dd.privGateway, err = net.NewGRPCPrivateGateway(ctx, privAddr, dd, c.grpcOpts...)
+	outbound, err := c.OutboundAddresses()
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if _, err := c.SubBeacons(); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if _, err := c.DerivedChains(); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if _, err := c.SyncSources(); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if _, err := ParseIOLimits(c.ioLimits); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if _, err := ParseFeatureFlags(c.featureFlags); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	grpcOpts := append(append([]grpc.DialOption{}, c.grpcOpts...), outbound.DialOptions()...)
+	if target := c.MirrorTarget(); target != "" {
+		dd.mirror = net.NewMirror(dd.log.Named("mirror"), target, DefaultMirrorMaxInFlight, mirrorTimeout, grpcOpts...)
+		dd.log.Infow("mirroring the public requests", "to", target)
+	}
+	if addr := c.UDPListenAddress(); addr != "" {
+		if dd.udp, err = net.NewUDPResponder(dd.log.Named("udp"), addr, udpQueryTimeout, dd.answerUDPQuery); err != nil {
+			span.RecordError(err)
+			return err
+		}
+	}
+	scopeOpts, err := dd.initIsolatedGateways(ctx, grpcOpts)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	srvOpts := append(append(append(append(scopeOpts, dd.controlAuth.PrivateServerOptions()...),
+		dd.loadShedderServerOptions()...), dd.quotasServerOptions()...), dd.mirrorServerOptions()...)
+	dd.privGateway, err = net.NewGRPCPrivateGatewayWithServerOptions(ctx, privAddr, dd, c.RequestLimits(), srvOpts, grpcOpts...)
 	if err != nil {
 		span.RecordError(err)
 		return err
@@ -175,6 +321,9 @@ func (dd *DrandDaemon) init(ctx context.Context) error {
 	dkgConfig := dkg.Config{
 		TimeBetweenDKGPhases: c.dkgPhaseTimeout,
 		KickoffGracePeriod:   c.dkgKickoffGracePeriod,
+		MinGenesisDelay:      c.minGenesisDelay,
+		AutoRefresh:          c.autoRefresh,
+		MinRefreshInterval:   c.minRefreshInterval,
 		SkipKeyVerification:  false,
 	}
 	dd.dkg = dkg.NewDKGProcess(dkgStore,
@@ -194,9 +343,17 @@ func (dd *DrandDaemon) init(ctx context.Context) error {
 		"storage_engine", c.dbStorageEngine)
 
 	dd.privGateway.StartAll()
+	for _, gw := range dd.isolatedGateways {
+		gw.StartAll()
+	}
 	if dd.pubGateway != nil {
 		dd.pubGateway.StartAll()
 	}
+	if dd.udp != nil {
+		dd.udp.Start()
+		dd.log.Infow("answering the UDP queries for the latest beacon", "on", dd.udp.Addr())
+	}
+	dd.startShareRefresh()
 
 	return nil
 }
@@ -209,12 +366,13 @@ func (dd *DrandDaemon) InstantiateBeaconProcess(ctx context.Context, beaconID st
 	beaconID = common.GetCanonicalBeaconID(beaconID)
 	// we add the BeaconID to our logger's name. Notice the BeaconID never changes.
 	logger := dd.log.Named(beaconID)
-	bp, err := NewBeaconProcess(ctx, logger, store, dd.completedDKGs, beaconID, dd.opts, dd.privGateway)
+	bp, err := NewBeaconProcess(ctx, logger, store, dd.completedDKGs, beaconID, dd.opts, dd.gatewayFor(beaconID))
 	if err != nil {
 		span.RecordError(err)
 		return nil, err
 	}
 	go bp.StartListeningForDKGUpdates(ctx)
+	crash.SetEvents(beaconID, bp.crashEvents)
 
 	dd.state.Lock()
 	dd.beaconProcesses[beaconID] = bp
@@ -232,6 +390,7 @@ func (dd *DrandDaemon) RemoveBeaconProcess(ctx context.Context, beaconID string,
 	defer span.End()
 
 	beaconID = common.GetCanonicalBeaconID(beaconID)
+	crash.SetEvents(beaconID, nil)
 
 	chainHash := ""
 	if bp.group != nil {
@@ -261,12 +420,12 @@ func (dd *DrandDaemon) AddBeaconHandler(ctx context.Context, beaconID string, bp
 	_, span := tracer.NewSpan(ctx, "dd.AddBeaconHandler")
 	defer span.End()
 
-	chainHash := chain2.NewChainInfo(bp.group).HashString()
+	chainHash := chain2.NewChainInfo(bp.group).ChainHash()
 
 	bh := dd.handler.RegisterNewBeaconHandler(&drandProxy{bp}, chainHash)
 
 	dd.state.Lock()
-	dd.chainHashes[chainHash] = beaconID
+	dd.chainHashes[chainHash.String()] = beaconID
 	dd.state.Unlock()
 
 	if common.IsDefaultBeaconID(beaconID) {
@@ -289,9 +448,9 @@ func (dd *DrandDaemon) RemoveBeaconHandler(ctx context.Context, beaconID string,
 	}
 
 	info := chain2.NewChainInfo(bp.group)
-	dd.handler.RemoveBeaconHandler(info.HashString())
+	dd.handler.RemoveBeaconHandler(info.ChainHash())
 	if common.IsDefaultBeaconID(beaconID) {
-		dd.handler.RemoveBeaconHandler(common.DefaultChainHash)
+		dd.handler.RemoveDefaultBeaconHandler()
 	}
 }
 
@@ -314,7 +473,7 @@ func (dd *DrandDaemon) LoadBeaconsFromDisk(ctx context.Context, metricsFlag stri
 	}
 
 	// Load possible existing stores
-	stores, err := key.NewFileStores(dd.opts.ConfigFolderMB())
+	stores, err := beaconKeyStores(dd.log, dd.opts)
 	if err != nil {
 		span.RecordError(err)
 		return err
@@ -337,6 +496,9 @@ func (dd *DrandDaemon) LoadBeaconsFromDisk(ctx context.Context, metricsFlag stri
 	if !startedAtLeastOne {
 		dd.log.Warnw("starting daemon with no active beacon")
 	}
+	if err := dd.scheduleUpgradeRestart(); err != nil {
+		dd.log.Errorw("unable to schedule the restart for the coordinated upgrade", "err", err)
+	}
 
 	// Start metrics server
 	_ = metrics.Start(dd.log, metricsFlag, pprof.WithProfile(), dd.privGateway.MetricsClient)
@@ -348,7 +510,7 @@ func (dd *DrandDaemon) LoadBeaconFromDisk(ctx context.Context, beaconID string)
 	ctx, span := tracer.NewSpan(ctx, "dd.LoadBeaconFromDisk")
 	defer span.End()
 
-	store := key.NewFileStore(dd.opts.ConfigFolderMB(), beaconID)
+	store := key.NewFileStore(dd.opts.BeaconFolderMB(beaconID), common.BeaconID(beaconID))
 	return dd.LoadBeaconFromStore(ctx, beaconID, store)
 }
 
@@ -5,12 +5,17 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/drand/drand/v2/common"
+	chain2 "github.com/drand/drand/v2/common/chain"
 	"github.com/drand/drand/v2/common/key"
 	"github.com/drand/drand/v2/common/tracer"
 	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/metrics"
+	"github.com/drand/drand/v2/plugin"
+	pdkg "github.com/drand/drand/v2/protobuf/dkg"
 	"github.com/drand/drand/v2/protobuf/drand"
 )
 
@@ -20,7 +25,7 @@ func (dd *DrandDaemon) PingPong(ctx context.Context, _ *drand.Ping) (*drand.Pong
 	_, span := tracer.NewSpan(ctx, "dd.PingPong")
 	defer span.End()
 	metadata := drand.NewMetadata(dd.version.ToProto())
-	return &drand.Pong{Metadata: metadata}, nil
+	return &drand.Pong{Metadata: metadata, BuildInfo: dd.version.BuildInfo()}, nil
 }
 
 // Status responds with the actual status of drand process
@@ -28,6 +33,10 @@ func (dd *DrandDaemon) Status(ctx context.Context, in *drand.StatusRequest) (*dr
 	ctx, span := tracer.NewSpan(ctx, "dd.Status")
 	defer span.End()
 
+	if err := dd.checkNodeListSize(len(in.GetCheckConn())); err != nil {
+		return nil, err
+	}
+
 	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
 	if err != nil {
 		return nil, err
@@ -45,6 +54,49 @@ func (dd *DrandDaemon) ListSchemes(ctx context.Context, _ *drand.ListSchemesRequ
 	return &drand.ListSchemesResponse{Ids: crypto.ListSchemes(), Metadata: metadata}, nil
 }
 
+// ListMetrics describes the metrics exported by the daemon, only the ones of a beacon if its ID is in the metadata
+func (dd *DrandDaemon) ListMetrics(ctx context.Context, in *drand.ListMetricsRequest) (*drand.ListMetricsResponse, error) {
+	_, span := tracer.NewSpan(ctx, "dd.ListMetrics")
+	defer span.End()
+
+	list, err := metrics.List(in.GetMetadata().GetBeaconID())
+	if err != nil {
+		return nil, err
+	}
+	resp := &drand.ListMetricsResponse{Metadata: drand.NewMetadata(dd.version.ToProto())}
+	for _, d := range list {
+		resp.Metrics = append(resp.Metrics, &drand.MetricDescription{
+			Name:   d.Name,
+			Help:   d.Help,
+			Type:   d.Type,
+			Labels: d.Labels,
+		})
+	}
+	return resp, nil
+}
+
+// APIUsage reports the requests to the public API of each client, none if the requests aren't limited by tier
+func (dd *DrandDaemon) APIUsage(ctx context.Context, _ *drand.APIUsageRequest) (*drand.APIUsageResponse, error) {
+	_, span := tracer.NewSpan(ctx, "dd.APIUsage")
+	defer span.End()
+
+	quotas, err := dd.opts.Quotas()
+	if err != nil {
+		return nil, err
+	}
+	resp := &drand.APIUsageResponse{Metadata: drand.NewMetadata(dd.version.ToProto())}
+	for _, u := range quotas.Usage() {
+		resp.Clients = append(resp.Clients, &drand.APIClientUsage{
+			Client:        u.Client,
+			Tier:          u.Tier,
+			RequestsToday: u.Today,
+			Requests:      u.Total,
+			Throttled:     u.Throttled,
+		})
+	}
+	return resp, nil
+}
+
 // PublicKey is a functionality of Control Service defined in protobuf/control
 // that requests the long term public key of the drand node running locally
 func (dd *DrandDaemon) PublicKey(ctx context.Context, in *drand.PublicKeyRequest) (*drand.PublicKeyResponse, error) {
@@ -77,8 +129,23 @@ func (dd *DrandDaemon) Shutdown(ctx context.Context, in *drand.ShutdownRequest)
 	ctx, span := tracer.NewSpan(ctx, "dd.Shutdown")
 	defer span.End()
 
+	drainTimeout := time.Duration(in.GetDrainTimeout()) * time.Second
+	var drainErrors map[string]string
+	// the client may go away, or time out, before the rounds are drained: the shutdown goes on regardless, the drain
+	// being bound by its own timeout only
+	ctx = context.WithoutCancel(ctx)
+
 	// If beacon id is empty, we will stop the entire node. Otherwise, we will stop the specific beacon process
 	if in.GetMetadata().GetBeaconID() == "" {
+		if in.GetDrain() {
+			dd.state.Lock()
+			bps := make([]*BeaconProcess, 0, len(dd.beaconProcesses))
+			for _, bp := range dd.beaconProcesses {
+				bps = append(bps, bp)
+			}
+			dd.state.Unlock()
+			drainErrors = dd.drainBeaconProcesses(ctx, bps, drainTimeout)
+		}
 		dd.Stop(ctx)
 	} else {
 		beaconID, err := dd.readBeaconID(in.GetMetadata())
@@ -91,6 +158,10 @@ func (dd *DrandDaemon) Shutdown(ctx context.Context, in *drand.ShutdownRequest)
 			return nil, err
 		}
 
+		if in.GetDrain() {
+			drainErrors = dd.drainBeaconProcesses(ctx, []*BeaconProcess{bp}, drainTimeout)
+		}
+
 		dd.RemoveBeaconHandler(ctx, beaconID, bp)
 
 		bp.Stop(ctx)
@@ -101,7 +172,7 @@ func (dd *DrandDaemon) Shutdown(ctx context.Context, in *drand.ShutdownRequest)
 
 	metadata := drand.NewMetadata(dd.version.ToProto())
 	metadata.BeaconID = in.GetMetadata().GetBeaconID()
-	return &drand.ShutdownResponse{Metadata: metadata}, nil
+	return &drand.ShutdownResponse{Metadata: metadata, DrainErrors: drainErrors}, nil
 }
 
 // LoadBeacon tells the DrandDaemon to load a new beacon into the memory
@@ -164,12 +235,34 @@ func (dd *DrandDaemon) StartCheckChain(in *drand.StartSyncRequest, stream drand.
 	return bp.StartCheckChain(in, stream)
 }
 
+// StatusStream pushes the status of a beacon process whenever it changes
+func (dd *DrandDaemon) StatusStream(in *drand.StatusStreamRequest, stream drand.Control_StatusStreamServer) error {
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return err
+	}
+
+	return bp.StatusStream(in, stream)
+}
+
+// EntropyStream streams bytes derived from the successive beacons of a chain
+func (dd *DrandDaemon) EntropyStream(in *drand.EntropyStreamRequest, stream drand.Control_EntropyStreamServer) error {
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return err
+	}
+
+	return bp.EntropyStream(in, stream)
+}
+
+// ListBeaconIDs responds with the ids of the beacons loaded by the daemon. On the control port only, it also describes
+// all the beacons configured in its multibeacon folder, whether they're loaded or not.
 func (dd *DrandDaemon) ListBeaconIDs(ctx context.Context, _ *drand.ListBeaconIDsRequest) (*drand.ListBeaconIDsResponse, error) {
 	_, span := tracer.NewSpan(ctx, "dd.ListBeaconIDs")
 	defer span.End()
 
-	dd.state.Lock()
-	defer dd.state.Unlock()
+	dd.state.RLock()
+	defer dd.state.RUnlock()
 
 	ids := make([]string, 0)
 	for id := range dd.beaconProcesses {
@@ -194,9 +287,52 @@ func (dd *DrandDaemon) ListBeaconIDs(ctx context.Context, _ *drand.ListBeaconIDs
 		})
 	}
 
+	// the public clients aren't told about the beacons which aren't loaded, nor make the daemon scan its folder
+	var beacons []*drand.BeaconIDInfo
+	if onControlPort(ctx) {
+		var err error
+		if beacons, err = dd.configuredBeacons(); err != nil {
+			return nil, err
+		}
+	}
+
 	dd.log.Debugw("responding to ListBeaconIDs request", "metas", metas, "ids", ids)
 
-	return &drand.ListBeaconIDsResponse{Ids: ids, Metadatas: metas}, nil
+	return &drand.ListBeaconIDsResponse{Ids: ids, Metadatas: metas, Beacons: beacons}, nil
+}
+
+// configuredBeacons describes the beacons loaded by the daemon and the ones configured in its key stores, sorted by
+// id. It must be called with the state lock held.
+func (dd *DrandDaemon) configuredBeacons() ([]*drand.BeaconIDInfo, error) {
+	stores, err := beaconKeyStores(dd.log, dd.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	beacons := make([]*drand.BeaconIDInfo, 0, len(stores))
+	for _, bp := range dd.beaconProcesses {
+		beacons = append(beacons, bp.beaconIDInfo())
+	}
+	for id, store := range stores {
+		if _, loaded := dd.beaconProcesses[id]; loaded {
+			continue
+		}
+		beacons = append(beacons, storedBeaconIDInfo(id, store))
+	}
+	sort.Slice(beacons, func(i, j int) bool { return beacons[i].Id < beacons[j].Id })
+	return beacons, nil
+}
+
+// storedBeaconIDInfo describes a beacon which isn't loaded, from what its key store holds
+func storedBeaconIDInfo(beaconID string, store key.Store) *drand.BeaconIDInfo {
+	info := &drand.BeaconIDInfo{Id: beaconID}
+	if group, err := store.LoadGroup(); err == nil && group != nil {
+		info.Scheme = group.Scheme.Name
+		info.ChainHash = chain2.NewChainInfo(group).Hash()
+	} else if pair, err := store.LoadKeyPair(); err == nil && pair != nil {
+		info.Scheme = pair.Scheme().Name
+	}
+	return info
 }
 
 func (dd *DrandDaemon) KeypairFor(beaconID string) (*key.Pair, error) {
@@ -208,7 +344,366 @@ func (dd *DrandDaemon) KeypairFor(beaconID string) (*key.Pair, error) {
 	return bp.priv, nil
 }
 
-// Stop simply stops all drand operations.
+// StartUpgrade proposes an upgrade window to the group, with this node as the coordinator
+func (dd *DrandDaemon) StartUpgrade(ctx context.Context, in *drand.StartUpgradeRequest) (*drand.UpgradeStatus, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.StartUpgrade")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := bp.StartUpgrade(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	if err := dd.scheduleUpgradeRestart(); err != nil {
+		return nil, fmt.Errorf("the upgrade was proposed but the daemon can't restart for it: %w", err)
+	}
+	return status, nil
+}
+
+// AcceptUpgrade acknowledges the upgrade proposed to this node and schedules its restart
+func (dd *DrandDaemon) AcceptUpgrade(ctx context.Context, in *drand.AcceptUpgradeRequest) (*drand.UpgradeStatus, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.AcceptUpgrade")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := bp.AcceptUpgrade(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	if err := dd.scheduleUpgradeRestart(); err != nil {
+		return nil, fmt.Errorf("the upgrade was accepted but the daemon can't restart for it: %w", err)
+	}
+	return status, nil
+}
+
+// Leave announces to the group that this node is leaving it after the given round
+func (dd *DrandDaemon) Leave(ctx context.Context, in *drand.LeaveRequest) (*drand.LeaveStatus, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.Leave")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.Leave(ctx, in)
+}
+
+// RoundMessage returns the message the node signs a partial over for the given round
+func (dd *DrandDaemon) RoundMessage(ctx context.Context, in *drand.RoundMessageRequest) (*drand.RoundMessageResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.RoundMessage")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.RoundMessage(ctx, in)
+}
+
+// Notarize returns a self-contained bundle allowing to verify the randomness of the given round offline, along with
+// the history of the group up to the epoch which produced it
+func (dd *DrandDaemon) Notarize(ctx context.Context, in *drand.NotarizeRequest) (*drand.NotarizationBundle, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.Notarize")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	beaconID := bp.getBeaconID()
+	active, err := dd.dkg.GroupForRound(ctx, &pdkg.GroupForRoundRequest{BeaconID: beaconID, Round: in.GetRound()})
+	if err != nil {
+		return nil, err
+	}
+	history, err := dd.dkg.GroupHistory(ctx, &pdkg.GroupHistoryRequest{BeaconID: beaconID})
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]*pdkg.GroupVersion, 0, len(history.GetVersions()))
+	for _, v := range history.GetVersions() {
+		if v.GetEpoch() <= active.GetEpoch() {
+			groups = append(groups, v)
+		}
+	}
+
+	return bp.Notarize(ctx, in.GetRound(), active.GetEpoch(), groups)
+}
+
+// MakeJoinKit returns a signed bundle allowing new followers to start following the chain from a recent checkpoint
+func (dd *DrandDaemon) MakeJoinKit(ctx context.Context, in *drand.JoinKitRequest) (*drand.JoinKit, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.MakeJoinKit")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.MakeJoinKit(ctx)
+}
+
+// FeatureFlags lists the feature flags of a beacon, after turning one on or off if asked to
+func (dd *DrandDaemon) FeatureFlags(ctx context.Context, in *drand.FeatureFlagsRequest) (*drand.FeatureFlagsResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.FeatureFlags")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.FeatureFlags(ctx, in)
+}
+
+// AvailabilityReport computes the availability of the members of the group of a beacon over a time window
+func (dd *DrandDaemon) AvailabilityReport(ctx context.Context, in *drand.AvailabilityReportRequest) (*drand.AvailabilityReportResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.AvailabilityReport")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.AvailabilityReport(ctx, in)
+}
+
+// PartialAudit returns the members whose partials were aggregated into each round of a range
+func (dd *DrandDaemon) PartialAudit(ctx context.Context, in *drand.PartialAuditRequest) (*drand.PartialAuditResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.PartialAudit")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.PartialAudit(ctx, in)
+}
+
+// Tombstones returns the beacons replaced in the chain store of a beacon when correcting its chain
+func (dd *DrandDaemon) Tombstones(ctx context.Context, in *drand.TombstonesRequest) (*drand.TombstonesResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.Tombstones")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.Tombstones(ctx, in)
+}
+
+// InjectBeacon stores a beacon obtained out-of-band in the chain store of a beacon, once verified
+func (dd *DrandDaemon) InjectBeacon(ctx context.Context, in *drand.InjectBeaconRequest) (*drand.InjectBeaconResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.InjectBeacon")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.InjectBeacon(ctx, in)
+}
+
+// StoreMetadata reads, writes, deletes or lists the values of a namespace of the metadata of the chain store of a beacon
+func (dd *DrandDaemon) StoreMetadata(ctx context.Context, in *drand.StoreMetadataRequest) (*drand.StoreMetadataResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.StoreMetadata")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.StoreMetadata(ctx, in)
+}
+
+// PauseBeacon stops a beacon from signing its partials, while it keeps following and serving the chain
+func (dd *DrandDaemon) PauseBeacon(ctx context.Context, in *drand.PauseBeaconRequest) (*drand.PauseStatus, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.PauseBeacon")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.PauseBeacon(ctx, in)
+}
+
+// ResumeBeacon makes a paused beacon sign its partials again
+func (dd *DrandDaemon) ResumeBeacon(ctx context.Context, in *drand.ResumeBeaconRequest) (*drand.PauseStatus, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.ResumeBeacon")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.ResumeBeacon(ctx, in)
+}
+
+// UpdateAddress announces to the group of a beacon that this node is now reachable at a new address
+func (dd *DrandDaemon) UpdateAddress(ctx context.Context, in *drand.UpdateAddressRequest) (*drand.UpdateAddressResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.UpdateAddress")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.UpdateAddress(ctx, in)
+}
+
+// AddressOverrides lists the addresses the members of the group of a beacon are dialed at instead of the ones of
+// the group file, after changing one if asked to
+func (dd *DrandDaemon) AddressOverrides(ctx context.Context, in *drand.AddressOverridesRequest) (*drand.AddressOverridesResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.AddressOverrides")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.AddressOverrides(ctx, in)
+}
+
+// ForecastReshare registers and announces the date of the next reshare of the group of a beacon, or returns it
+func (dd *DrandDaemon) ForecastReshare(ctx context.Context, in *drand.ForecastReshareRequest) (*drand.ForecastReshareResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.ForecastReshare")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.ForecastReshare(ctx, in)
+}
+
+// RoundAnnotations lists the notes the operators attached to the rounds of a beacon, after changing them if asked to
+func (dd *DrandDaemon) RoundAnnotations(ctx context.Context, in *drand.RoundAnnotationsRequest) (*drand.RoundAnnotationsResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.RoundAnnotations")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.RoundAnnotations(ctx, in)
+}
+
+// Countdown returns the time left until the genesis or the next transition of a beacon, with the readiness of the
+// members of its group
+func (dd *DrandDaemon) Countdown(ctx context.Context, in *drand.CountdownRequest) (*drand.CountdownResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.Countdown")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.Countdown(ctx, in)
+}
+
+// RandomnessStats computes statistical summaries of the randomness over a range of rounds
+func (dd *DrandDaemon) RandomnessStats(ctx context.Context, in *drand.RandomnessStatsRequest) (*drand.RandomnessStatsResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.RandomnessStats")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.RandomnessStats(ctx, in)
+}
+
+// scheduleUpgradeRestart schedules the restart of the daemon for the coordinated upgrades of its beacons. All of them
+// restart with the daemon, so it only restarts once all the beacons taking part in an upgrade accepted it, at a time
+// within the restart slot of each of them. The daemon is stopped at that time, relying on its supervisor to start it
+// again with the upgraded binary.
+func (dd *DrandDaemon) scheduleUpgradeRestart() error {
+	dd.state.Lock()
+	defer dd.state.Unlock()
+
+	if dd.upgradeTimer != nil {
+		dd.upgradeTimer.Stop()
+		dd.upgradeTimer = nil
+	}
+	at, waiting, err := upgradeRestartTime(dd.beaconProcesses)
+	if err != nil {
+		return err
+	}
+	if len(waiting) > 0 {
+		dd.log.Infow("Waiting for the coordinated upgrade to be accepted by all the beacons to restart", "beacons", waiting)
+		return nil
+	}
+	if at.IsZero() {
+		return nil
+	}
+	dd.log.Infow("Scheduled restart for the coordinated upgrade", "at", at)
+	dd.upgradeTimer = dd.opts.clock.AfterFunc(at.Sub(dd.opts.clock.Now()), func() {
+		dd.log.Infow("Stopping DrandDaemon for the coordinated upgrade")
+		dd.Stop(context.Background())
+	})
+	return nil
+}
+
+// upgradeRestartTime returns the time the daemon restarts at for the upgrades of the given beacons, the latest start
+// of their restart slots, zero if none is upgraded. The beacons waiting for an upgrade to be accepted are returned
+// instead, the daemon doesn't restart until they accepted it.
+func upgradeRestartTime(bps map[string]*BeaconProcess) (time.Time, []string, error) {
+	var start, end time.Time
+	var waiting []string
+	for id, bp := range bps {
+		slotStart, slotEnd, wait := bp.pendingRestart()
+		if wait {
+			waiting = append(waiting, id)
+			continue
+		}
+		if slotStart.IsZero() {
+			continue
+		}
+		if start.IsZero() || slotStart.After(start) {
+			start = slotStart
+		}
+		if end.IsZero() || slotEnd.Before(end) {
+			end = slotEnd
+		}
+	}
+	if len(waiting) > 0 {
+		sort.Strings(waiting)
+		return time.Time{}, waiting, nil
+	}
+	if !start.IsZero() && !start.Before(end) {
+		return time.Time{}, nil, fmt.Errorf("the restart slots of the beacons don't overlap, the latest starts at %s "+
+			"and the earliest ends at %s: the upgrade windows of the beacons must be aligned",
+			start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339))
+	}
+	return start, nil, nil
+}
+
+// Stop simply stops all drand operations, in the shutdown order of the config, running the shutdown hooks of the
+// plugins and of the embedder at each stage.
 func (dd *DrandDaemon) Stop(ctx context.Context) {
 	ctx, span := tracer.NewSpan(ctx, "dd.Stop")
 	defer span.End()
@@ -224,40 +719,34 @@ func (dd *DrandDaemon) Stop(ctx context.Context) {
 		dd.log.Infow("Stopping DrandDaemon")
 	}
 
-	dd.dkg.Close()
+	dd.runShutdownHooks(ctx, plugin.StagePreStop)
 
-	for _, bp := range dd.beaconProcesses {
-		dd.log.Debugw("Sending Stop to beaconProcesses", "id", bp.getBeaconID())
-		bp.Stop(ctx)
+	dd.state.Lock()
+	if dd.upgradeTimer != nil {
+		dd.upgradeTimer.Stop()
 	}
-
-	for _, bp := range dd.beaconProcesses {
-		dd.log.Debugw("waiting for beaconProcess to finish", "id", bp.getBeaconID())
-
-		//nolint:mnd // We want to wait for 5 seconds before sending a timeout for the beacon shutdown
-		t := time.NewTimer(5 * time.Second)
-		select {
-		case <-bp.WaitExit():
-			if !t.Stop() {
-				<-t.C
-			}
-		case <-t.C:
-			dd.log.Errorw("beacon process failed to terminate in 5 seconds, exiting forcefully", "id", bp.getBeaconID())
-			err := fmt.Errorf("beacon process %q failed to terminate in 5 seconds, exiting forcefully", bp.getBeaconID())
-			span.RecordError(err)
-		}
+	if dd.stopRefresh != nil {
+		dd.stopRefresh()
 	}
+	dd.state.Unlock()
 
-	dd.log.Debugw("all beacon processes exited successfully")
+	dd.dkg.Close()
 
-	if dd.pubGateway != nil {
-		dd.pubGateway.StopAll(ctx)
-		dd.log.Debugw("pubGateway stopped successfully")
+	// the order is validated when the daemon starts
+	order, _ := dd.opts.ShutdownOrder()
+	for _, step := range order {
+		switch step {
+		case ShutdownBeacons:
+			dd.stopBeaconProcesses(ctx)
+			dd.runShutdownHooks(ctx, plugin.StagePostStoreFlush)
+		case ShutdownListeners:
+			dd.closeListeners(ctx)
+			dd.runShutdownHooks(ctx, plugin.StagePostListenerClose)
+		case ShutdownServices:
+			dd.closeServices()
+		}
 	}
 
-	dd.privGateway.StopAll(ctx)
-	dd.log.Debugw("privGateway stopped successfully")
-
 	// We launch this in a goroutine to allow the stop connection to exit successfully.
 	// If we wouldn't launch it in a goroutine the Stop call itself would block the shutdown
 	// procedure and we'd be in a loop.
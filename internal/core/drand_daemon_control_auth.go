@@ -0,0 +1,112 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/drand/drand/v2/common/log"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/internal/net"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// loadControlAuth sets up the authentication of the calls to the control port, and the roles of its tokens
+func loadControlAuth(l log.Logger, c *Config) (*net.ControlAuth, error) {
+	if !c.ControlAuth() {
+		return net.NewControlAuth(""), nil
+	}
+	auth, err := loadAdminControlAuth(l, c)
+	if err != nil {
+		return nil, err
+	}
+	roles, tokens, err := c.ControlRoles()
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.SetRoles(roles, tokens); err != nil {
+		return nil, err
+	}
+	if len(tokens) > 0 {
+		l.Infow("Restricting the tokens of the control port to their roles", "tokens", len(tokens))
+	}
+	return auth, nil
+}
+
+// loadAdminControlAuth authenticates the calls with the admin token. With no token configured, the one kept in the
+// config folder is used, and created if there is none yet.
+func loadAdminControlAuth(l log.Logger, c *Config) (*net.ControlAuth, error) {
+	if token := c.ControlToken(); token != "" {
+		return net.NewControlAuth(token), nil
+	}
+
+	file := controlTokenFile(c)
+	buff, err := os.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if token := strings.TrimSpace(string(buff)); token != "" {
+		l.Infow("Authenticating the control port with the token of the config folder", "file", file)
+		return net.NewControlAuth(token), nil
+	}
+
+	token, err := net.NewControlToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := saveControlToken(file, token); err != nil {
+		return nil, err
+	}
+	l.Infow("Created the token authenticating the control port", "file", file)
+	return net.NewControlAuth(token), nil
+}
+
+func controlTokenFile(c *Config) string {
+	return path.Join(c.ConfigFolder(), DefaultControlTokenFile)
+}
+
+// saveControlToken writes the token readable by the user only. It is replaced at once, so that the clients never
+// read a partial token.
+func saveControlToken(file, token string) error {
+	if err := os.MkdirAll(path.Dir(file), 0o700); err != nil {
+		return err
+	}
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, []byte(token+"\n"), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
+
+// RotateControlToken replaces the admin token authenticating the calls to the control port with a fresh one, saved in
+// the config folder. The calls with the previous token are refused from then on, the tokens of the other roles are
+// kept.
+func (dd *DrandDaemon) RotateControlToken(ctx context.Context, _ *drand.RotateControlTokenRequest) (*drand.RotateControlTokenResponse, error) {
+	_, span := tracer.NewSpan(ctx, "dd.RotateControlToken")
+	defer span.End()
+
+	if dd.controlAuth == nil || !dd.controlAuth.Enabled() {
+		return nil, status.Error(codes.FailedPrecondition, "the control port isn't authenticated")
+	}
+	if dd.opts.ControlToken() != "" {
+		return nil, status.Error(codes.FailedPrecondition, "the token is given by the configuration, it can't be rotated")
+	}
+
+	token, err := net.NewControlToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := saveControlToken(controlTokenFile(dd.opts), token); err != nil {
+		span.RecordError(err)
+		return nil, errors.Join(errors.New("unable to save the new token, the previous one is still valid"), err)
+	}
+	dd.controlAuth.SetToken(token)
+	dd.log.Infow("Rotated the token authenticating the control port")
+
+	return &drand.RotateControlTokenResponse{Token: token, Metadata: drand.NewMetadata(dd.version.ToProto())}, nil
+}
@@ -18,6 +18,26 @@ func (dd *DrandDaemon) DKGStatus(ctx context.Context, request *drand.DKGStatusRe
 	return dd.dkg.DKGStatus(ctx, request)
 }
 
+func (dd *DrandDaemon) GroupHistory(ctx context.Context, request *drand.GroupHistoryRequest) (*drand.GroupHistoryResponse, error) {
+	beaconID := request.BeaconID
+
+	if !dd.beaconExists(beaconID) {
+		return nil, fmt.Errorf("beacon with ID %s is not running on this daemon", beaconID)
+	}
+
+	return dd.dkg.GroupHistory(ctx, request)
+}
+
+func (dd *DrandDaemon) GroupForRound(ctx context.Context, request *drand.GroupForRoundRequest) (*drand.GroupForRoundResponse, error) {
+	beaconID := request.BeaconID
+
+	if !dd.beaconExists(beaconID) {
+		return nil, fmt.Errorf("beacon with ID %s is not running on this daemon", beaconID)
+	}
+
+	return dd.dkg.GroupForRound(ctx, request)
+}
+
 func (dd *DrandDaemon) Command(ctx context.Context, command *drand.DKGCommand) (*drand.EmptyDKGResponse, error) {
 	if command.Metadata == nil {
 		return nil, errors.New("could not find command metadata to read beaconID")
@@ -3,7 +3,12 @@ package core
 import (
 	"fmt"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/key"
 	"github.com/drand/drand/v2/protobuf/drand"
 )
 
@@ -76,3 +81,61 @@ func (dd *DrandDaemon) getBeaconProcessFromRequest(metadata *drand.Metadata) (*B
 
 	return dd.getBeaconProcessByID(beaconID)
 }
+
+// checkNodeListSize rejects requests asking us to contact more nodes than allowed, before we start doing any work.
+func (dd *DrandDaemon) checkNodeListSize(n int) error {
+	if limit := dd.opts.MaxStatusNodes(); limit > 0 && n > limit {
+		return status.Errorf(codes.InvalidArgument, "too many nodes in request: %d > %d", n, limit)
+	}
+	return nil
+}
+
+// normalizeNodeList rewrites the addresses of a request in their normal form, refusing the request if any of them
+// is malformed rather than failing to dial it
+func normalizeNodeList(addrs []*drand.Address) error {
+	for _, addr := range addrs {
+		normalized, err := key.NormalizeAddress(addr.GetAddress())
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		addr.Address = normalized
+	}
+	return nil
+}
+
+// mirrorServerOptions returns the options of the private gRPC servers mirroring the public requests, none if they
+// aren't mirrored
+func (dd *DrandDaemon) mirrorServerOptions() []grpc.ServerOption {
+	if dd.mirror == nil {
+		return nil
+	}
+	return []grpc.ServerOption{grpc.ChainUnaryInterceptor(dd.mirror.UnaryServerInterceptor)}
+}
+
+// loadShedderServerOptions returns the options of the private gRPC servers shedding the low-priority public requests
+// while the node is late, none if the load isn't shed
+func (dd *DrandDaemon) loadShedderServerOptions() []grpc.ServerOption {
+	s := dd.opts.LoadShedder()
+	if s == nil {
+		return nil
+	}
+	s.ExemptMembers(dd.fromGroupMember)
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(s.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(s.StreamServerInterceptor),
+	}
+}
+
+// quotasServerOptions returns the options of the private gRPC servers limiting the public requests by the tier of
+// their API key, none if they aren't limited
+func (dd *DrandDaemon) quotasServerOptions() []grpc.ServerOption {
+	// the quotas are validated when the daemon starts
+	q, _ := dd.opts.Quotas()
+	if q == nil {
+		return nil
+	}
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(q.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(q.StreamServerInterceptor),
+	}
+}
@@ -0,0 +1,105 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// The steps of the response to an incident, in the order they are run
+const (
+	incidentPause    = "pause"
+	incidentSnapshot = "snapshot"
+	incidentRotate   = "rotate-control-credentials"
+	incidentAnnounce = "announce"
+)
+
+// Incident runs the response to a suspected compromise of the share of a beacon: it pauses the node first so that
+// no partial is signed anymore, saves a snapshot of the daemon for the investigation, rotates the token of the control
+// port, and announces to the group that the node leaves it after the current round, so that the coordinator of the
+// next reshare excludes it. The steps which fail don't stop the next ones, the response reports what each of them did.
+func (dd *DrandDaemon) Incident(ctx context.Context, in *drand.IncidentRequest) (*drand.IncidentResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.Incident")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+	beaconID := common.GetCanonicalBeaconID(bp.getBeaconID())
+	bp.log.Errorw("Responding to an incident", "reason", in.GetReason())
+
+	resp := &drand.IncidentResponse{Metadata: bp.newMetadata()}
+	step := func(name string, err error, detail string) {
+		if err != nil {
+			bp.log.Errorw("Incident response step failed", "step", name, "err", err)
+			resp.Steps = append(resp.Steps, &drand.IncidentStep{Name: name, Detail: err.Error()})
+			return
+		}
+		bp.log.Infow("Incident response step done", "step", name, "detail", detail)
+		resp.Steps = append(resp.Steps, &drand.IncidentStep{Name: name, Done: true, Detail: detail})
+	}
+
+	// the group may miss rounds, but a compromised share must not sign anything more
+	resp.Pause, err = bp.PauseBeacon(ctx, &drand.PauseBeaconRequest{Reason: "incident: " + in.GetReason(), Force: true})
+	step(incidentPause, err, "the node doesn't sign partials anymore")
+
+	resp.SnapshotFile, err = dd.saveIncidentSnapshot(ctx, beaconID)
+	step(incidentSnapshot, err, resp.SnapshotFile)
+
+	if detail, skipped, err := dd.rotateIncidentCredentials(ctx); skipped {
+		resp.Steps = append(resp.Steps, &drand.IncidentStep{Name: incidentRotate, Skipped: true, Detail: detail})
+	} else {
+		step(incidentRotate, err, detail)
+	}
+
+	// the next round, in case the current one ends in the meantime: the node is paused anyway
+	bp.state.RLock()
+	var lastRound uint64
+	if bp.group != nil {
+		lastRound = common.CurrentRound(bp.opts.clock.Now().Unix(), bp.group.Period, bp.group.GenesisTime) + 1
+	}
+	bp.state.RUnlock()
+	resp.Leave, err = bp.Leave(ctx, &drand.LeaveRequest{LastRound: lastRound})
+	step(incidentAnnounce, err, fmt.Sprintf("the group was told this node leaves it after round %d", lastRound))
+
+	return resp, nil
+}
+
+// rotateIncidentCredentials rotates the token of the control port, which may have leaked along with the share. The
+// new one is only written to the config folder. It is skipped when there is no token the daemon can rotate.
+func (dd *DrandDaemon) rotateIncidentCredentials(ctx context.Context) (detail string, skipped bool, err error) {
+	if dd.controlAuth == nil || !dd.controlAuth.Enabled() {
+		return "the control port isn't authenticated, restrict the access to its port or socket instead", true, nil
+	}
+	if dd.opts.ControlToken() != "" {
+		return "the token of the control port is given by the configuration, replace it there and restart", true, nil
+	}
+	if _, err := dd.RotateControlToken(ctx, &drand.RotateControlTokenRequest{}); err != nil {
+		return "", false, err
+	}
+	return "the previous token of the control port is refused, the new one is in " + controlTokenFile(dd.opts), false, nil
+}
+
+// saveIncidentSnapshot saves a snapshot of the daemon in the folder of the beacon and returns the file it was saved to
+func (dd *DrandDaemon) saveIncidentSnapshot(ctx context.Context, beaconID string) (string, error) {
+	snapshot, err := dd.Snapshot(ctx, &drand.SnapshotRequest{})
+	if err != nil {
+		return "", err
+	}
+	buff, err := json.MarshalIndent(snapshot, "", "    ")
+	if err != nil {
+		return "", err
+	}
+	file := path.Join(dd.opts.BeaconFolderMB(beaconID), beaconID, fmt.Sprintf("incident-%d.json", snapshot.GetTakenAt()))
+	if err := os.WriteFile(file, buff, 0o600); err != nil {
+		return "", fmt.Errorf("unable to save the snapshot: %w", err)
+	}
+	return file, nil
+}
@@ -0,0 +1,261 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/key"
+	"github.com/drand/drand/v2/common/log"
+	"github.com/drand/drand/v2/internal/net"
+	pdkg "github.com/drand/drand/v2/protobuf/dkg"
+)
+
+// BeaconIsolation holds the settings of a beacon isolated from the other beacons of the daemon, so that the chains
+// operated on behalf of different networks don't share a listener, a TLS certificate or a key folder.
+type BeaconIsolation struct {
+	BeaconID string
+	// PrivateListen is the address of the private listener serving only this beacon, which the other beacons'
+	// listener then refuses to serve. The beacon is served by the listener of the daemon if it's empty.
+	PrivateListen string
+	// TLSCert and TLSKey are the files of the certificate served by the private listener of the beacon. Without them
+	// it is plaintext, like the listener of the daemon behind a TLS terminating proxy.
+	TLSCert string
+	TLSKey  string
+	// Folder is the base folder holding the keys and the database of the beacon, like --folder does for the daemon.
+	// The beacon is in the folder of the daemon if it's empty.
+	Folder string
+}
+
+// The keys of the settings of an isolated beacon
+const (
+	isolationPrivateListen = "private-listen"
+	isolationTLSCert       = "tls-cert"
+	isolationTLSKey        = "tls-key"
+	isolationFolder        = "folder"
+)
+
+// ParseBeaconIsolation parses the settings of isolated beacons given as "beacon-id:key=value,...", e.g.
+// "evmnet:private-listen=0.0.0.0:4454,tls-cert=/etc/drand/evmnet.crt,tls-key=/etc/drand/evmnet.key,folder=/srv/evmnet".
+// No two beacons can share a listener or a folder.
+func ParseBeaconIsolation(specs []string) (map[string]*BeaconIsolation, error) {
+	isolation := make(map[string]*BeaconIsolation, len(specs))
+	listeners := make(map[string]string)
+	folders := make(map[string]string)
+	for _, spec := range specs {
+		id, settings, found := strings.Cut(spec, ":")
+		if !found || id == "" || settings == "" {
+			return nil, fmt.Errorf("invalid beacon isolation %q, expected beacon-id:key=value,...", spec)
+		}
+		id = common.GetCanonicalBeaconID(id)
+		if _, ok := isolation[id]; ok {
+			return nil, fmt.Errorf("beacon %q is isolated twice", id)
+		}
+
+		iso := &BeaconIsolation{BeaconID: id}
+		for _, setting := range strings.Split(settings, ",") {
+			k, v, found := strings.Cut(setting, "=")
+			if !found || v == "" {
+				return nil, fmt.Errorf("invalid setting %q for the isolation of beacon %q, expected key=value", setting, id)
+			}
+			switch k {
+			case isolationPrivateListen:
+				iso.PrivateListen = v
+			case isolationTLSCert:
+				iso.TLSCert = v
+			case isolationTLSKey:
+				iso.TLSKey = v
+			case isolationFolder:
+				iso.Folder = path.Clean(v)
+			default:
+				return nil, fmt.Errorf("unknown setting %q for the isolation of beacon %q, expected one of %s, %s, %s or %s",
+					k, id, isolationPrivateListen, isolationTLSCert, isolationTLSKey, isolationFolder)
+			}
+		}
+
+		if (iso.TLSCert == "") != (iso.TLSKey == "") {
+			return nil, fmt.Errorf("the isolation of beacon %q needs both %s and %s", id, isolationTLSCert, isolationTLSKey)
+		}
+		if iso.TLSCert != "" && iso.PrivateListen == "" {
+			return nil, fmt.Errorf("the TLS certificate of beacon %q needs its own %s", id, isolationPrivateListen)
+		}
+		if other, ok := listeners[iso.PrivateListen]; ok && iso.PrivateListen != "" {
+			return nil, fmt.Errorf("beacons %q and %q can't share the listener %s", other, id, iso.PrivateListen)
+		}
+		if other, ok := folders[iso.Folder]; ok && iso.Folder != "" {
+			return nil, fmt.Errorf("beacons %q and %q can't share the folder %s", other, id, iso.Folder)
+		}
+		listeners[iso.PrivateListen] = id
+		folders[iso.Folder] = id
+		isolation[id] = iso
+	}
+	return isolation, nil
+}
+
+// beaconKeyStores returns the key stores of the beacons of the daemon, by beacon ID. The beacons isolated in their
+// own folder are only looked for in it.
+func beaconKeyStores(l log.Logger, conf *Config) (map[string]key.Store, error) {
+	stores, err := key.NewFileStores(conf.ConfigFolderMB())
+	if err != nil {
+		return nil, err
+	}
+	isolation, err := conf.BeaconIsolation()
+	if err != nil {
+		return nil, err
+	}
+	for beaconID, iso := range isolation {
+		if iso.Folder == "" {
+			continue
+		}
+		delete(stores, beaconID)
+		folder := conf.BeaconFolderMB(beaconID)
+		if _, err := os.Stat(path.Join(folder, beaconID)); errors.Is(err, fs.ErrNotExist) {
+			l.Warnw("no keys in the folder of the isolated beacon", "beacon_id", beaconID, "folder", folder)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		stores[beaconID] = key.NewFileStore(folder, common.BeaconID(beaconID))
+	}
+	return stores, nil
+}
+
+// initIsolatedGateways creates the private gateways of the beacons isolated on their own listener. Each one has its
+// own connections to the other nodes, and the listener of the daemon stops serving these beacons.
+func (dd *DrandDaemon) initIsolatedGateways(ctx context.Context, grpcOpts []grpc.DialOption) ([]grpc.ServerOption, error) {
+	isolation, err := dd.opts.BeaconIsolation()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(isolation))
+	for id, iso := range isolation {
+		if iso.PrivateListen != "" {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	dd.isolatedGateways = make(map[string]*net.PrivateGateway, len(ids))
+	for _, id := range ids {
+		iso := isolation[id]
+		srvOpts := append([]grpc.ServerOption{
+			grpc.ChainUnaryInterceptor(dd.beaconScopeUnaryInterceptor(id)),
+			grpc.ChainStreamInterceptor(dd.beaconScopeStreamInterceptor(id)),
+		}, dd.controlAuth.PrivateServerOptions()...)
+		srvOpts = append(srvOpts, dd.loadShedderServerOptions()...)
+		srvOpts = append(srvOpts, dd.quotasServerOptions()...)
+		srvOpts = append(srvOpts, dd.mirrorServerOptions()...)
+		if iso.TLSCert != "" {
+			creds, err := credentials.NewServerTLSFromFile(iso.TLSCert, iso.TLSKey)
+			if err != nil {
+				return nil, fmt.Errorf("unable to load the TLS certificate of beacon %q: %w", id, err)
+			}
+			srvOpts = append(srvOpts, grpc.Creds(creds))
+		}
+		gw, err := net.NewGRPCPrivateGatewayWithServerOptions(ctx, iso.PrivateListen, dd, dd.opts.RequestLimits(), srvOpts, grpcOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to listen for beacon %q: %w", id, err)
+		}
+		dd.isolatedGateways[id] = gw
+		dd.log.Infow("beacon isolated on its own listener", "beacon_id", id, "private_listen", iso.PrivateListen,
+			"tls", iso.TLSCert != "")
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(dd.beaconScopeUnaryInterceptor("")),
+		grpc.ChainStreamInterceptor(dd.beaconScopeStreamInterceptor("")),
+	}, nil
+}
+
+// gatewayFor returns the private gateway of the given beacon, the one of the daemon unless it is isolated
+func (dd *DrandDaemon) gatewayFor(beaconID string) *net.PrivateGateway {
+	if gw, ok := dd.isolatedGateways[common.GetCanonicalBeaconID(beaconID)]; ok {
+		return gw
+	}
+	return dd.privGateway
+}
+
+// checkBeaconScope refuses the requests about a beacon that the listener doesn't serve: the listener of an isolated
+// beacon, whose ID is the scope, only serves that beacon, and the listener of the daemon, whose scope is empty, serves
+// all the beacons but the isolated ones. The requests which aren't about a beacon, such as the health checks, are
+// served by every listener.
+func (dd *DrandDaemon) checkBeaconScope(scope string, req interface{}) error {
+	id, ok := dd.requestBeaconID(req)
+	if !ok {
+		return nil
+	}
+	if scope != "" {
+		if id != scope {
+			return status.Errorf(codes.PermissionDenied, "this listener only serves beacon %q", scope)
+		}
+		return nil
+	}
+	if _, isolated := dd.isolatedGateways[id]; isolated {
+		return status.Errorf(codes.PermissionDenied, "beacon %q is served on its own listener", id)
+	}
+	return nil
+}
+
+// requestBeaconID returns the canonical ID of the beacon the request is about, if it is about one
+func (dd *DrandDaemon) requestBeaconID(req interface{}) (string, bool) {
+	switch r := req.(type) {
+	case MetadataGetter:
+		id, err := dd.readBeaconID(r.GetMetadata())
+		if err != nil {
+			// the handler refuses it with the right error
+			return common.GetCanonicalBeaconID(r.GetMetadata().GetBeaconID()), true
+		}
+		return common.GetCanonicalBeaconID(id), true
+	case interface{ GetMetadata() *pdkg.GossipMetadata }:
+		return common.GetCanonicalBeaconID(r.GetMetadata().GetBeaconID()), true
+	case interface{ GetBeaconID() string }:
+		return common.GetCanonicalBeaconID(r.GetBeaconID()), true
+	default:
+		return "", false
+	}
+}
+
+func (dd *DrandDaemon) beaconScopeUnaryInterceptor(scope string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := dd.checkBeaconScope(scope, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// beaconScopeStreamInterceptor checks the messages received on the streams, the beacon being given by the request
+// opening them
+func (dd *DrandDaemon) beaconScopeStreamInterceptor(scope string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &scopedServerStream{ServerStream: ss, check: func(m interface{}) error {
+			return dd.checkBeaconScope(scope, m)
+		}})
+	}
+}
+
+type scopedServerStream struct {
+	grpc.ServerStream
+	check func(m interface{}) error
+}
+
+func (s *scopedServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.check(m)
+}
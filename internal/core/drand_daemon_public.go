@@ -39,6 +39,34 @@ func (dd *DrandDaemon) PublicRand(ctx context.Context, in *drand.PublicRandReque
 	return bp.PublicRand(ctx, in)
 }
 
+// PublicRandAt returns the beacon which was the latest one of the chain at the given time
+func (dd *DrandDaemon) PublicRandAt(ctx context.Context, in *drand.PublicRandAtRequest) (*drand.PublicRandResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.PublicRandAt")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return bp.PublicRandAt(ctx, in)
+}
+
+// PublicRandByRandomness returns the beacon of the given randomness
+func (dd *DrandDaemon) PublicRandByRandomness(ctx context.Context, in *drand.PublicRandByRandomnessRequest) (*drand.PublicRandResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.PublicRandByRandomness")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return bp.PublicRandByRandomness(ctx, in)
+}
+
 // PublicRandStream exports a stream of new beacons as they are generated over gRPC
 func (dd *DrandDaemon) PublicRandStream(in *drand.PublicRandRequest, stream drand.Public_PublicRandStreamServer) error {
 	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
@@ -87,3 +115,202 @@ func (dd *DrandDaemon) GetIdentity(ctx context.Context, in *drand.IdentityReques
 
 	return bp.GetIdentity(ctx, in)
 }
+
+// ProposeUpgrade receives an upgrade window proposed by the coordinator of an upgrade
+func (dd *DrandDaemon) ProposeUpgrade(ctx context.Context, in *drand.UpgradeProposal) (*drand.Empty, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.ProposeUpgrade")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.ProposeUpgrade(ctx, in)
+}
+
+// AcknowledgeUpgrade receives the acceptance of an upgrade we're coordinating
+func (dd *DrandDaemon) AcknowledgeUpgrade(ctx context.Context, in *drand.UpgradeAcknowledgement) (*drand.Empty, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.AcknowledgeUpgrade")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.AcknowledgeUpgrade(ctx, in)
+}
+
+// AnnounceLeave receives the departure announced by a member of the group
+func (dd *DrandDaemon) AnnounceLeave(ctx context.Context, in *drand.LeaveAnnouncement) (*drand.Empty, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.AnnounceLeave")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.AnnounceLeave(ctx, in)
+}
+
+// AnnounceAddress receives the new address announced by a member of the group
+func (dd *DrandDaemon) AnnounceAddress(ctx context.Context, in *drand.AddressAnnouncement) (*drand.Empty, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.AnnounceAddress")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.AnnounceAddress(ctx, in)
+}
+
+// AnnounceReshareForecast receives the date of the next reshare announced by its coordinator
+func (dd *DrandDaemon) AnnounceReshareForecast(ctx context.Context, in *drand.ReshareForecastAnnouncement) (*drand.Empty, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.AnnounceReshareForecast")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.AnnounceReshareForecast(ctx, in)
+}
+
+// PartialHeartbeat receives the partial signature of a heartbeat from a member of the group
+func (dd *DrandDaemon) PartialHeartbeat(ctx context.Context, in *drand.PartialHeartbeatPacket) (*drand.Empty, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.PartialHeartbeat")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.PartialHeartbeat(ctx, in)
+}
+
+// Heartbeat returns the last heartbeat signed by the group
+func (dd *DrandDaemon) Heartbeat(ctx context.Context, in *drand.HeartbeatRequest) (*drand.HeartbeatPacket, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.Heartbeat")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.Heartbeat(ctx, in)
+}
+
+// PartialSubBeacon receives the partial signature of a round of a sub-beacon from a member of the group
+func (dd *DrandDaemon) PartialSubBeacon(ctx context.Context, in *drand.PartialSubBeaconPacket) (*drand.Empty, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.PartialSubBeacon")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.PartialSubBeacon(ctx, in)
+}
+
+// PushBeacon stores a beacon pushed by a node replicating its chain to us
+func (dd *DrandDaemon) PushBeacon(ctx context.Context, in *drand.PushedBeacon) (*drand.PushBeaconAck, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.PushBeacon")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.PushBeacon(ctx, in)
+}
+
+// GroupMembership returns the composition of the group of a beacon, and its previous compositions
+func (dd *DrandDaemon) GroupMembership(ctx context.Context, in *drand.GroupMembershipRequest) (*drand.GroupMembershipResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.GroupMembership")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := dd.groupHistory(ctx, bp.getBeaconID())
+	if err != nil {
+		// the current group is still worth serving
+		bp.log.Warnw("unable to load the history of the group", "err", err)
+		history = nil
+	}
+	return bp.groupMembership(ctx, history)
+}
+
+// ChainSummary summarizes the chain of a beacon for the block explorers
+func (dd *DrandDaemon) ChainSummary(ctx context.Context, in *drand.ChainSummaryRequest) (*drand.ChainSummaryResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.ChainSummary")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+	return bp.ChainSummary(ctx, in)
+}
+
+// Home answers the v1 liveness requests, if the v1-compatible endpoints are enabled on the private listener
+func (dd *DrandDaemon) Home(ctx context.Context, in *drand.HomeRequest) (*drand.HomeResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.Home")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+	return bp.Home(ctx, in)
+}
+
+// SubBeacons lists the sub-beacons derived from the distributed key of the group
+func (dd *DrandDaemon) SubBeacons(ctx context.Context, in *drand.SubBeaconsRequest) (*drand.SubBeaconsResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.SubBeacons")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.SubBeacons(ctx, in)
+}
+
+// DerivedChains lists the chains derived from the beacons of the chain
+func (dd *DrandDaemon) DerivedChains(ctx context.Context, in *drand.DerivedChainsRequest) (*drand.DerivedChainsResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.DerivedChains")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.DerivedChains(ctx, in)
+}
+
+// DerivedRand returns a round of a derived chain
+func (dd *DrandDaemon) DerivedRand(ctx context.Context, in *drand.DerivedRandRequest) (*drand.DerivedRandResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.DerivedRand")
+	defer span.End()
+
+	bp, err := dd.getBeaconProcessFromRequest(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return bp.DerivedRand(ctx, in)
+}
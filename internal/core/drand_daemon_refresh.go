@@ -0,0 +1,70 @@
+package core
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pdkg "github.com/drand/drand/v2/protobuf/dkg"
+)
+
+// startShareRefresh proposes to refresh the shares of the beacons running on the node at each refresh interval, until
+// the daemon is stopped. The members of the groups which opted in with WithAutoRefresh accept the refreshes without
+// operator action.
+func (dd *DrandDaemon) startShareRefresh() {
+	interval := dd.opts.ShareRefreshInterval()
+	if interval <= 0 {
+		return
+	}
+
+	dd.state.Lock()
+	defer dd.state.Unlock()
+	if dd.stopRefresh != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	dd.stopRefresh = cancel
+
+	dd.log.Infow("Refreshing the shares periodically", "interval", interval)
+	go func() {
+		ticker := dd.opts.clock.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.Chan():
+			}
+			dd.refreshShares(ctx)
+		}
+	}()
+}
+
+// refreshShares proposes to refresh the shares of each beacon running on the node
+func (dd *DrandDaemon) refreshShares(ctx context.Context) {
+	dd.state.RLock()
+	var beaconIDs []string
+	for beaconID, bp := range dd.beaconProcesses {
+		bp.state.RLock()
+		running := bp.beacon != nil
+		bp.state.RUnlock()
+		if running {
+			beaconIDs = append(beaconIDs, beaconID)
+		}
+	}
+	dd.state.RUnlock()
+
+	for _, beaconID := range beaconIDs {
+		_, err := dd.dkg.Command(ctx, &pdkg.DKGCommand{
+			Command: &pdkg.DKGCommand_Refresh{Refresh: &pdkg.RefreshOptions{
+				Timeout: timestamppb.New(dd.opts.clock.Now().Add(DefaultShareRefreshTimeout)),
+			}},
+			Metadata: &pdkg.CommandMetadata{BeaconID: beaconID},
+		})
+		if err != nil {
+			dd.log.Warnw("Unable to propose a refresh of the shares", "beacon_id", beaconID, "err", err)
+			continue
+		}
+		dd.log.Infow("Proposed a refresh of the shares", "beacon_id", beaconID)
+	}
+}
@@ -0,0 +1,221 @@
+package core
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/drand/drand/v2/common/log"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/internal/chain/beacon"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// The options ReloadConfig applies to the running daemon. The others only apply after a restart.
+const (
+	reloadLogLevel        = "log-level"
+	reloadSyncSources     = "sync-sources"
+	reloadMaxStalePeriods = "max-stale-periods"
+	reloadFeatureFlags    = "feature-flags"
+	reloadRequestTimeout  = "request-timeout"
+)
+
+// The options of the metrics and traces ReloadConfig reports as requiring a restart when they change, as their
+// exporters are set up once the daemon starts
+const (
+	restartMetrics             = "metrics"
+	restartOTLPMetrics         = "otlp-metrics"
+	restartOTLPMetricsInterval = "otlp-metrics-interval"
+	restartTraces              = "traces"
+	restartTracesProbability   = "traces-probability"
+)
+
+// ReloadConfig re-reads the configuration of the daemon and applies the log level, the sync sources, the staleness
+// of the latest beacon, the feature flags and the request timeout to the running beacons, without interrupting them.
+// The other options only apply after a restart, the ones of the metrics and traces which changed are reported as
+// such.
+func (dd *DrandDaemon) ReloadConfig(ctx context.Context, _ *drand.ReloadConfigRequest) (*drand.ReloadConfigResponse, error) {
+	_, span := tracer.NewSpan(ctx, "dd.ReloadConfig")
+	defer span.End()
+
+	if dd.opts.reloader == nil {
+		return nil, status.Error(codes.FailedPrecondition, "the daemon wasn't started from a config file, there is nothing to reload")
+	}
+	next, err := dd.opts.reloader()
+	if err != nil {
+		span.RecordError(err)
+		return nil, status.Errorf(codes.InvalidArgument, "unable to reload the configuration: %v", err)
+	}
+	if err := next.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid configuration: %v", err)
+	}
+
+	changed := dd.applyConfig(next)
+	restart := dd.opts.restartRequired(next)
+	dd.log.Infow("Reloaded the configuration", "changed", changed, "restart_required", restart)
+	return &drand.ReloadConfigResponse{
+		Changed:         changed,
+		RestartRequired: restart,
+		Metadata:        drand.NewMetadata(dd.version.ToProto()),
+	}, nil
+}
+
+// restartRequired lists the options of the metrics and traces which differ in the next configuration
+func (d *Config) restartRequired(next *Config) []string {
+	var restart []string
+	if d.metricsAddress != next.metricsAddress {
+		restart = append(restart, restartMetrics)
+	}
+	if d.otlpMetricsEndpoint != next.otlpMetricsEndpoint {
+		restart = append(restart, restartOTLPMetrics)
+	}
+	if d.otlpMetricsInterval != next.otlpMetricsInterval {
+		restart = append(restart, restartOTLPMetricsInterval)
+	}
+	if d.tracesEndpoint != next.tracesEndpoint {
+		restart = append(restart, restartTraces)
+	}
+	if d.tracesProbability != next.tracesProbability {
+		restart = append(restart, restartTracesProbability)
+	}
+	return restart
+}
+
+// applyConfig applies the options of the configuration which can change at runtime, and returns the ones which did
+func (dd *DrandDaemon) applyConfig(next *Config) []string {
+	var changed []string
+	if level, ok := log.GetLevel(next.logger); ok {
+		if current, ok := log.GetLevel(dd.opts.logger); ok && current != level && log.SetLevel(dd.opts.logger, level) {
+			changed = append(changed, reloadLogLevel)
+		}
+	}
+
+	dd.opts.reloadLock.Lock()
+	syncChanged := !slices.Equal(dd.opts.syncPrefer, next.syncPrefer) || !slices.Equal(dd.opts.syncDeny, next.syncDeny) ||
+		!slices.Equal(dd.opts.syncRegions, next.syncRegions)
+	if syncChanged {
+		dd.opts.syncPrefer, dd.opts.syncDeny, dd.opts.syncRegions = next.syncPrefer, next.syncDeny, next.syncRegions
+	}
+	staleChanged := dd.opts.maxStalePeriods != next.maxStalePeriods
+	dd.opts.maxStalePeriods = next.maxStalePeriods
+	featuresChanged := !slices.Equal(dd.opts.featureFlags, next.featureFlags)
+	dd.opts.setFeatureFlags(next.featureFlags)
+	// the gRPC servers read it on each call
+	timeoutChanged := dd.opts.requestTimeout != next.requestTimeout
+	dd.opts.requestTimeout = next.requestTimeout
+	dd.opts.reloadLock.Unlock()
+	if timeoutChanged {
+		changed = append(changed, reloadRequestTimeout)
+	}
+
+	dd.state.RLock()
+	defer dd.state.RUnlock()
+	if syncChanged {
+		// the rules were validated with the configuration
+		sources, _ := dd.opts.SyncSources()
+		for _, bp := range dd.beaconProcesses {
+			bp.setSyncSources(sources)
+		}
+		changed = append(changed, reloadSyncSources)
+	}
+	if staleChanged {
+		if dd.handler != nil {
+			dd.handler.SetMaxStalePeriods(next.maxStalePeriods)
+		}
+		changed = append(changed, reloadMaxStalePeriods)
+	}
+	if featuresChanged {
+		for _, bp := range dd.beaconProcesses {
+			bp.publishFeatures()
+			bp.runPendingRepair(context.Background())
+		}
+		changed = append(changed, reloadFeatureFlags)
+	}
+	return changed
+}
+
+// setSyncSources makes the running beacon and the chains followed sync from the peers allowed by the rules
+func (bp *BeaconProcess) setSyncSources(sources *beacon.SyncSources) {
+	bp.state.RLock()
+	if bp.beacon != nil {
+		bp.beacon.SetSyncSources(sources)
+	}
+	bp.state.RUnlock()
+	bp.following.setSources(sources)
+}
+
+// followSyncers are the sync managers of the chains followed, which aren't run by the beacon
+type followSyncers struct {
+	sync.Mutex
+	syncers map[*beacon.SyncManager]struct{}
+}
+
+// add makes the sync manager pick up the sync sources reloaded until it is removed
+func (f *followSyncers) add(s *beacon.SyncManager) {
+	f.Lock()
+	defer f.Unlock()
+	if f.syncers == nil {
+		f.syncers = make(map[*beacon.SyncManager]struct{})
+	}
+	f.syncers[s] = struct{}{}
+}
+
+func (f *followSyncers) remove(s *beacon.SyncManager) {
+	f.Lock()
+	defer f.Unlock()
+	delete(f.syncers, s)
+}
+
+func (f *followSyncers) setSources(sources *beacon.SyncSources) {
+	f.Lock()
+	defer f.Unlock()
+	for s := range f.syncers {
+		s.SetSources(sources)
+	}
+}
+
+// LogLevels lists the level of the logger of the daemon and the levels of its named loggers which have their own,
+// e.g. Follow or dkg, after changing one of them if asked to. The levels are reset to the configured one on restart.
+func (dd *DrandDaemon) LogLevels(ctx context.Context, in *drand.LogLevelsRequest) (*drand.LogLevelsResponse, error) {
+	_, span := tracer.NewSpan(ctx, "dd.LogLevels")
+	defer span.End()
+
+	logger := dd.opts.logger
+	if _, ok := log.GetLevel(logger); !ok {
+		return nil, status.Error(codes.FailedPrecondition, "the level of the logger of the daemon can't be changed")
+	}
+
+	switch {
+	case in.GetRemove():
+		if in.GetLogger() == "" {
+			return nil, status.Error(codes.InvalidArgument, "the named logger to remove the level of must be given")
+		}
+		log.ResetNamedLevel(logger, in.GetLogger())
+		dd.log.Infow("Reset the level of a named logger", "logger", in.GetLogger())
+	case in.GetLevel() != "":
+		level, err := log.ParseLevel(in.GetLevel())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid log level: %v", err)
+		}
+		if in.GetLogger() == "" {
+			log.SetLevel(logger, level)
+		} else {
+			log.SetNamedLevel(logger, in.GetLogger(), level)
+		}
+		dd.log.Infow("Changed the log level", "logger", in.GetLogger(), "level", log.LevelName(level))
+	}
+
+	level, _ := log.GetLevel(logger)
+	resp := &drand.LogLevelsResponse{
+		Level:    log.LevelName(level),
+		Named:    make(map[string]string),
+		Metadata: drand.NewMetadata(dd.version.ToProto()),
+	}
+	for name, l := range log.NamedLevels(logger) {
+		resp.Named[name] = log.LevelName(l)
+	}
+	return resp, nil
+}
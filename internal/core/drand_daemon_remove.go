@@ -0,0 +1,138 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/key"
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/internal/chain"
+	"github.com/drand/drand/v2/internal/chain/postgresdb/pgdb"
+	"github.com/drand/drand/v2/internal/fs"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// ArchiveFolder is the folder of the config folder the removed beacons are archived in
+const ArchiveFolder = "archive"
+
+// RemoveBeaconID unloads a beacon, if it is loaded, deletes or archives its folder, holding its keys and its chain
+// store, and then deletes its DKG state, so that it isn't configured on the node anymore. The private keys are
+// securely wiped when the folder is deleted, the DKG state is exported to the archive when it is archived. The chains
+// stored in PostgreSQL are deleted, or renamed as the archive.
+func (dd *DrandDaemon) RemoveBeaconID(ctx context.Context, in *drand.RemoveBeaconIDRequest) (*drand.RemoveBeaconIDResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.RemoveBeaconID")
+	defer span.End()
+
+	// the default beacon is only removed when explicitly asked for
+	if in.GetMetadata().GetBeaconID() == "" {
+		return nil, status.Error(codes.InvalidArgument, "the id of the beacon to remove must be given")
+	}
+	beaconID, err := dd.readBeaconID(in.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+	beaconID = common.GetCanonicalBeaconID(beaconID)
+	// the id names the folder deleted, it mustn't point anywhere else
+	if beaconID == "." || beaconID == ".." || strings.ContainsAny(beaconID, `/\`) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid beacon id %q", beaconID)
+	}
+
+	baseFolder := dd.opts.BeaconFolderMB(beaconID)
+	folder := path.Join(baseFolder, beaconID)
+	bp, loadErr := dd.getBeaconProcessByID(beaconID)
+	if loadErr != nil {
+		stores, err := beaconKeyStores(dd.log, dd.opts)
+		if err != nil {
+			return nil, err
+		}
+		if _, configured := stores[beaconID]; !configured {
+			return nil, status.Errorf(codes.NotFound, "beacon %s isn't configured on this node", beaconID)
+		}
+	}
+	if !in.GetConfirm() {
+		action := "deletes"
+		if in.GetArchive() {
+			action = "archives"
+		}
+		return nil, status.Errorf(codes.FailedPrecondition, "removing beacon %s %s %s and deletes its DKG state: "+
+			"it must be confirmed", beaconID, action, folder)
+	}
+
+	// checked before anything is stopped, the DKG state being only deleted once the beacon is gone
+	if err := dd.dkg.CheckRemoveBeacon(beaconID); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "unable to remove beacon %s: %v", beaconID, err)
+	}
+
+	resp := &drand.RemoveBeaconIDResponse{Folder: folder}
+	if loadErr == nil {
+		dd.RemoveBeaconHandler(ctx, beaconID, bp)
+		bp.Stop(ctx)
+		<-bp.WaitExit()
+		dd.RemoveBeaconProcess(ctx, beaconID, bp)
+		resp.Unloaded = true
+	}
+
+	if in.GetArchive() {
+		archived := path.Join(dd.opts.ConfigFolder(), ArchiveFolder,
+			fmt.Sprintf("%s-%d", beaconID, dd.opts.clock.Now().Unix()))
+		if err := os.MkdirAll(path.Dir(archived), 0o700); err != nil {
+			return nil, err
+		}
+		if err := os.Rename(folder, archived); err != nil {
+			return nil, fmt.Errorf("unable to archive the folder of beacon %s: %w", beaconID, err)
+		}
+		if err := dd.dkg.ExportBeacon(beaconID, archived); err != nil {
+			return nil, fmt.Errorf("unable to archive the DKG state of beacon %s: %w", beaconID, err)
+		}
+		if err := dd.removeChainStore(ctx, beaconID, path.Base(archived)); err != nil {
+			return nil, fmt.Errorf("unable to archive the chain of beacon %s: %w", beaconID, err)
+		}
+		resp.ArchivedTo = archived
+	} else {
+		for _, file := range key.PrivateFiles(baseFolder, common.BeaconID(beaconID)) {
+			if err := fs.SecureDelete(file); err != nil {
+				return nil, fmt.Errorf("unable to wipe the keys of beacon %s: %w", beaconID, err)
+			}
+		}
+		if err := os.RemoveAll(folder); err != nil {
+			return nil, fmt.Errorf("unable to delete the folder of beacon %s: %w", beaconID, err)
+		}
+		if err := dd.removeChainStore(ctx, beaconID, ""); err != nil {
+			return nil, fmt.Errorf("unable to delete the chain of beacon %s: %w", beaconID, err)
+		}
+	}
+
+	if err := dd.dkg.RemoveBeacon(beaconID); err != nil {
+		return nil, fmt.Errorf("unable to delete the DKG state of beacon %s: %w", beaconID, err)
+	}
+
+	dd.log.Infow("Beacon removed", "beacon_id", beaconID, "folder", folder, "archived_to", resp.GetArchivedTo(),
+		"unloaded", resp.GetUnloaded())
+	metadata := drand.NewMetadata(dd.version.ToProto())
+	metadata.BeaconID = beaconID
+	resp.Metadata = metadata
+	return resp, nil
+}
+
+// removeChainStore deletes the chain of the beacon stored outside of its folder, or moves it under the archived name
+// if one is given. Only the chains stored in PostgreSQL are.
+func (dd *DrandDaemon) removeChainStore(ctx context.Context, beaconID, archivedName string) error {
+	if dd.opts.dbStorageEngine != chain.PostgreSQL || dd.opts.pgConn == nil {
+		return nil
+	}
+	store, err := pgdb.NewStore(ctx, dd.log, dd.opts.pgConn, beaconID)
+	if err != nil {
+		return err
+	}
+	if archivedName != "" {
+		return store.RenameBeaconID(ctx, archivedName)
+	}
+	return store.DeleteBeaconID(ctx)
+}
@@ -0,0 +1,135 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/plugin"
+)
+
+// shutdownHookTimeout bounds each hook run during the shutdown, so that a stuck hook can't keep the daemon running
+const shutdownHookTimeout = 10 * time.Second
+
+// beaconStopTimeout is how long the shutdown waits for each beacon process to exit
+const beaconStopTimeout = 5 * time.Second
+
+// runShutdownHooks runs the hooks of the stage: the ones registered by the plugins, in the order of their names, then
+// the ones of the library embedder, in the order they were added. The hooks of a stage run once per daemon, even if
+// it is stopped again.
+func (dd *DrandDaemon) runShutdownHooks(ctx context.Context, stage plugin.Stage) {
+	once, ok := dd.shutdownStages[stage]
+	if !ok {
+		return
+	}
+	once.Do(func() {
+		ctx, span := tracer.NewSpan(ctx, "dd.runShutdownHooks")
+		defer span.End()
+
+		hooks := make([]plugin.NamedHook, 0)
+		for _, name := range plugin.Hooks(stage) {
+			if hook, ok := plugin.LookupHook(stage, name); ok {
+				hooks = append(hooks, plugin.NamedHook{Name: name, Hook: hook})
+			}
+		}
+		hooks = append(hooks, dd.opts.shutdownHooks.Hooks(stage)...)
+
+		for _, h := range hooks {
+			hookCtx, cancel := context.WithTimeout(ctx, shutdownHookTimeout)
+			err := h.Hook(hookCtx)
+			cancel()
+			if err != nil {
+				dd.log.Warnw("shutdown hook failed", "stage", stage, "hook", h.Name, "err", err)
+				span.RecordError(err)
+				continue
+			}
+			dd.log.Debugw("shutdown hook ran", "stage", stage, "hook", h.Name)
+		}
+	})
+}
+
+// stopBeaconProcesses stops the beacon processes, which closes their chain stores, and waits for them to exit
+func (dd *DrandDaemon) stopBeaconProcesses(ctx context.Context) {
+	ctx, span := tracer.NewSpan(ctx, "dd.stopBeaconProcesses")
+	defer span.End()
+
+	for _, bp := range dd.beaconProcesses {
+		dd.log.Debugw("Sending Stop to beaconProcesses", "id", bp.getBeaconID())
+		bp.Stop(ctx)
+	}
+
+	for _, bp := range dd.beaconProcesses {
+		dd.log.Debugw("waiting for beaconProcess to finish", "id", bp.getBeaconID())
+
+		t := time.NewTimer(beaconStopTimeout)
+		select {
+		case <-bp.WaitExit():
+			if !t.Stop() {
+				<-t.C
+			}
+		case <-t.C:
+			dd.log.Errorw("beacon process failed to terminate in time, exiting forcefully", "id", bp.getBeaconID(),
+				"timeout", beaconStopTimeout)
+			err := fmt.Errorf("beacon process %q failed to terminate in %s, exiting forcefully", bp.getBeaconID(),
+				beaconStopTimeout)
+			span.RecordError(err)
+		}
+	}
+
+	dd.log.Debugw("all beacon processes exited successfully")
+}
+
+// drainBeaconProcesses drains the given beacon processes in parallel, and returns why the ones which couldn't be
+// drained in time weren't
+func (dd *DrandDaemon) drainBeaconProcesses(ctx context.Context, bps []*BeaconProcess, timeout time.Duration) map[string]string {
+	ctx, span := tracer.NewSpan(ctx, "dd.drainBeaconProcesses")
+	defer span.End()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	failed := make(map[string]string)
+	for _, bp := range bps {
+		wg.Add(1)
+		go func(bp *BeaconProcess) {
+			defer wg.Done()
+			if err := bp.Drain(ctx, timeout); err != nil {
+				dd.log.Warnw("unable to drain the beacon process, stopping it anyway", "id", bp.getBeaconID(), "err", err)
+				mu.Lock()
+				failed[bp.getBeaconID()] = err.Error()
+				mu.Unlock()
+			}
+		}(bp)
+	}
+	wg.Wait()
+	return failed
+}
+
+// closeListeners closes the listeners of the public and private APIs
+func (dd *DrandDaemon) closeListeners(ctx context.Context) {
+	if dd.pubGateway != nil {
+		dd.pubGateway.StopAll(ctx)
+		dd.log.Debugw("pubGateway stopped successfully")
+	}
+
+	dd.privGateway.StopAll(ctx)
+	dd.log.Debugw("privGateway stopped successfully")
+	for id, gw := range dd.isolatedGateways {
+		gw.StopAll(ctx)
+		dd.log.Debugw("isolated privGateway stopped successfully", "beacon_id", id)
+	}
+}
+
+// closeServices closes the side services of the daemon
+func (dd *DrandDaemon) closeServices() {
+	if dd.mirror != nil {
+		dd.mirror.Close()
+	}
+	if dd.udp != nil {
+		dd.udp.Stop()
+	}
+	if dd.redis != nil {
+		dd.redis.Close()
+	}
+}
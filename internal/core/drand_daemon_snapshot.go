@@ -0,0 +1,120 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/internal/dkg"
+	pdkg "github.com/drand/drand/v2/protobuf/dkg"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// Snapshot collects the status, group file, chain tip, DKG state and recent events of all the beacons run by this
+// daemon. All the beacon processes are locked while collecting it, so that it reflects a single point in time
+// instead of being stitched together from outputs taken at different times.
+func (dd *DrandDaemon) Snapshot(ctx context.Context, _ *drand.SnapshotRequest) (*drand.SnapshotResponse, error) {
+	ctx, span := tracer.NewSpan(ctx, "dd.Snapshot")
+	defer span.End()
+
+	dd.state.RLock()
+	defer dd.state.RUnlock()
+
+	ids := make([]string, 0, len(dd.beaconProcesses))
+	for id := range dd.beaconProcesses {
+		ids = append(ids, id)
+	}
+	// we always lock the beacon processes in the same order
+	sort.Strings(ids)
+	for _, id := range ids {
+		dd.beaconProcesses[id].state.RLock()
+	}
+	defer func() {
+		for _, id := range ids {
+			dd.beaconProcesses[id].state.RUnlock()
+		}
+	}()
+
+	snapshot := &drand.SnapshotResponse{
+		TakenAt:   dd.opts.clock.Now().Unix(),
+		BuildInfo: dd.version.BuildInfo(),
+		Beacons:   make([]*drand.BeaconSnapshot, 0, len(ids)),
+	}
+	for _, id := range ids {
+		bs := dd.beaconProcesses[id].snapshot(ctx)
+		dkgStatus, err := dd.dkg.DKGStatus(ctx, &pdkg.DKGStatusRequest{BeaconID: id})
+		if err != nil {
+			bs.Errors = append(bs.Errors, fmt.Sprintf("dkg status: %s", err))
+		} else {
+			bs.Dkg = &drand.DKGSnapshot{
+				Complete: dkgSnapshotEntry(dkgStatus.GetComplete()),
+				Current:  dkgSnapshotEntry(dkgStatus.GetCurrent()),
+			}
+		}
+		snapshot.Beacons = append(snapshot.Beacons, bs)
+	}
+
+	return snapshot, nil
+}
+
+// snapshot collects the local state of the beacon process. The caller must hold the state lock.
+func (bp *BeaconProcess) snapshot(ctx context.Context) *drand.BeaconSnapshot {
+	bs := &drand.BeaconSnapshot{
+		BeaconID: bp.getBeaconID(),
+		Events:   bp.events.recent(),
+	}
+
+	// we don't contact the peers, the snapshot reports the connectivity as last checked in the background
+	bs.Status = bp.status(ctx)
+	if conns, details, checkedAt := bp.cachedConnectivity(bp.groupPeers()); len(conns) > 0 {
+		bs.Status.Connections = conns
+		bs.Status.ConnectionDetails = details
+		bs.Status.ConnectionsCheckedAt = checkedAt.Unix()
+	}
+
+	if bp.group != nil {
+		bs.Group = bp.group.ToProto(bp.version)
+	}
+
+	if bp.beacon != nil {
+		last, err := bp.beacon.Store().Last(ctx)
+		if err != nil {
+			bs.Errors = append(bs.Errors, fmt.Sprintf("chain tip: %s", err))
+		} else {
+			bs.ChainTip = &drand.ChainTip{
+				Round:             last.GetRound(),
+				Signature:         last.Signature,
+				PreviousSignature: last.PreviousSig,
+			}
+		}
+	}
+
+	return bs
+}
+
+func dkgSnapshotEntry(entry *pdkg.DKGEntry) *drand.DKGSnapshotEntry {
+	if entry == nil {
+		return nil
+	}
+	return &drand.DKGSnapshotEntry{
+		State:     dkg.Status(entry.GetState()).String(),
+		Epoch:     entry.GetEpoch(),
+		Threshold: entry.GetThreshold(),
+		Leader:    entry.GetLeader().GetAddress(),
+		Remaining: participantAddresses(entry.GetRemaining()),
+		Joining:   participantAddresses(entry.GetJoining()),
+		Leaving:   participantAddresses(entry.GetLeaving()),
+		Acceptors: participantAddresses(entry.GetAcceptors()),
+		Rejectors: participantAddresses(entry.GetRejectors()),
+		Timeout:   entry.GetTimeout().GetSeconds(),
+	}
+}
+
+func participantAddresses(participants []*pdkg.Participant) []string {
+	addresses := make([]string, len(participants))
+	for i, p := range participants {
+		addresses[i] = p.GetAddress()
+	}
+	return addresses
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/drand/drand/v2/internal/dkg"
+	pdkg "github.com/drand/drand/v2/protobuf/dkg"
+)
+
+func TestEventLogKeepsMostRecentEvents(t *testing.T) {
+	var events eventLog
+	require.Empty(t, events.recent())
+
+	start := time.Unix(1700000000, 0)
+	for i := 0; i < maxRecentEvents+10; i++ {
+		events.record(start.Add(time.Duration(i)*time.Second), eventDKGCompleted, fmt.Sprintf("epoch %d", i))
+	}
+
+	recent := events.recent()
+	require.Len(t, recent, maxRecentEvents)
+	require.Equal(t, "epoch 10", recent[0].Detail)
+	require.Equal(t, fmt.Sprintf("epoch %d", maxRecentEvents+9), recent[len(recent)-1].Detail)
+	require.Equal(t, start.Add(10*time.Second).Unix(), recent[0].Time)
+}
+
+func TestDKGSnapshotEntry(t *testing.T) {
+	require.Nil(t, dkgSnapshotEntry(nil))
+
+	entry := dkgSnapshotEntry(&pdkg.DKGEntry{
+		State:     uint32(dkg.Complete),
+		Epoch:     3,
+		Threshold: 2,
+		Timeout:   timestamppb.New(time.Unix(1700000000, 0)),
+		Leader:    &pdkg.Participant{Address: "a:1234"},
+		Remaining: []*pdkg.Participant{{Address: "a:1234"}, {Address: "b:1234"}},
+		Leaving:   []*pdkg.Participant{{Address: "c:1234"}},
+	})
+	require.Equal(t, dkg.Complete.String(), entry.State)
+	require.Equal(t, uint32(3), entry.Epoch)
+	require.Equal(t, "a:1234", entry.Leader)
+	require.Equal(t, []string{"a:1234", "b:1234"}, entry.Remaining)
+	require.Equal(t, []string{"c:1234"}, entry.Leaving)
+	require.Empty(t, entry.Joining)
+	require.Equal(t, int64(1700000000), entry.Timeout)
+}
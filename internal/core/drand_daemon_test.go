@@ -2,16 +2,26 @@ package core
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net"
+	"os"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/drand/drand/v2/common/testlogger"
 	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/chain"
+	"github.com/drand/drand/v2/internal/chain/memdb"
 	"github.com/drand/drand/v2/internal/test"
+	"github.com/drand/drand/v2/plugin"
+	"github.com/drand/drand/v2/protobuf/drand"
 )
 
 func TestNoPanicWhenDrandDaemonPortInUse(t *testing.T) {
@@ -85,3 +95,215 @@ func TestDrandDaemon_Stop(t *testing.T) {
 	require.False(t, ok, "If we block the exit of drandDaemon by waiting for all beacons to exit,"+
 		"then this should return false as we consume the value already")
 }
+
+func TestDrandDaemonShutdownHooks(t *testing.T) {
+	l := testlogger.New(t)
+	ctx := context.Background()
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	privs, _ := test.BatchIdentities(t, 1, sch, t.Name())
+
+	var stages []string
+	record := func(stage plugin.Stage) plugin.Hook {
+		return func(context.Context) error {
+			stages = append(stages, string(stage))
+			return nil
+		}
+	}
+	confOptions := []ConfigOption{
+		WithConfigFolder(t.TempDir()),
+		WithPrivateListenAddress("127.0.0.1:0"),
+		WithControlPort(test.FreePort()),
+		WithShutdownOrder([]string{ShutdownListeners, ShutdownBeacons, ShutdownServices}),
+	}
+	hooks := new(plugin.ShutdownHooks)
+	for _, stage := range []plugin.Stage{plugin.StagePreStop, plugin.StagePostStoreFlush, plugin.StagePostListenerClose} {
+		require.NoError(t, hooks.Add(stage, "record", record(stage)))
+	}
+	require.NoError(t, hooks.Add(plugin.StagePreStop, "failing", func(context.Context) error {
+		return errors.New("queue not flushed")
+	}))
+	require.Error(t, hooks.Add("post-mortem", "record", record("post-mortem")))
+	confOptions = append(confOptions, WithShutdownHooks(hooks))
+	confOptions = append(confOptions, WithTestDB(t, test.ComputeDBName())...)
+
+	dd, err := NewDrandDaemon(ctx, NewConfig(l, confOptions...))
+	require.NoError(t, err)
+	store := test.NewKeyStore()
+	require.NoError(t, store.SaveKeyPair(privs[0]))
+	_, err = dd.InstantiateBeaconProcess(ctx, t.Name(), store)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	dd.Stop(ctx)
+	<-dd.WaitExit()
+
+	// a failing hook doesn't stop the shutdown
+	require.Equal(t, []string{"pre-stop", "post-listener-close", "post-store-flush"}, stages)
+
+	// the hooks don't run again when the daemon is stopped again
+	dd.runShutdownHooks(ctx, plugin.StagePreStop)
+	dd.Stop(ctx)
+	require.Equal(t, []string{"pre-stop", "post-listener-close", "post-store-flush"}, stages)
+
+	_, err = NewConfig(l, WithShutdownOrder([]string{ShutdownBeacons, ShutdownBeacons, ShutdownServices})).ShutdownOrder()
+	require.Error(t, err)
+	_, err = NewConfig(l, WithShutdownOrder([]string{ShutdownBeacons, ShutdownListeners})).ShutdownOrder()
+	require.Error(t, err)
+	order, err := NewConfig(l).ShutdownOrder()
+	require.NoError(t, err)
+	require.Equal(t, DefaultShutdownOrder, order)
+}
+
+func TestDrandDaemonRejectsOversizedNodeLists(t *testing.T) {
+	l := testlogger.New(t)
+	dd := &DrandDaemon{
+		log:  l,
+		opts: NewConfig(l, WithMaxStatusNodes(2)),
+	}
+
+	nodes := []*drand.Address{{Address: "a:1"}, {Address: "b:2"}, {Address: "c:3"}}
+
+	_, err := dd.RemoteStatus(context.Background(), &drand.RemoteStatusRequest{Addresses: nodes})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = dd.Status(context.Background(), &drand.StatusRequest{CheckConn: nodes})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestBeaconProcessV1Home(t *testing.T) {
+	l := testlogger.New(t)
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	privs, _ := test.BatchIdentities(t, 1, sch, t.Name())
+
+	bp := &BeaconProcess{
+		log:      l,
+		opts:     NewConfig(l),
+		priv:     privs[0],
+		beaconID: t.Name(),
+	}
+	_, err = bp.Home(context.Background(), &drand.HomeRequest{})
+	require.Equal(t, codes.Unimplemented, status.Code(err), "the v1 endpoints are opt-in")
+
+	bp.opts = NewConfig(l, WithV1Compat([]string{V1CompatPrivate}))
+	resp, err := bp.Home(context.Background(), &drand.HomeRequest{})
+	require.NoError(t, err)
+	require.Contains(t, resp.GetStatus(), privs[0].Public.Address())
+	require.Equal(t, t.Name(), resp.GetMetadata().GetBeaconID())
+
+	require.False(t, bp.opts.V1Compat(V1CompatPublic))
+}
+
+func TestBeaconProcessStoreMetadata(t *testing.T) {
+	l := testlogger.New(t)
+	ctx := context.Background()
+	bp := &BeaconProcess{
+		log:      l,
+		opts:     NewConfig(l),
+		beaconID: t.Name(),
+		dbStore:  memdb.NewStore(10),
+	}
+
+	_, err := bp.StoreMetadata(ctx, &drand.StoreMetadataRequest{Namespace: "Bad/Namespace"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	resp, err := bp.StoreMetadata(ctx, &drand.StoreMetadataRequest{Namespace: "ext", Key: "cursor", Put: true, Value: []byte("42")})
+	require.NoError(t, err)
+	require.Equal(t, []byte("42"), resp.GetValues()["cursor"])
+
+	// the namespaces are isolated from each other
+	_, err = bp.StoreMetadata(ctx, &drand.StoreMetadataRequest{Namespace: "other", Key: "cursor", Put: true, Value: []byte("1")})
+	require.NoError(t, err)
+	resp, err = bp.StoreMetadata(ctx, &drand.StoreMetadataRequest{Namespace: "ext"})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"cursor": []byte("42")}, resp.GetValues())
+
+	_, err = bp.StoreMetadata(ctx, &drand.StoreMetadataRequest{Namespace: "ext", Key: "cursor", Delete: true})
+	require.NoError(t, err)
+	resp, err = bp.StoreMetadata(ctx, &drand.StoreMetadataRequest{Namespace: "ext", Key: "cursor"})
+	require.NoError(t, err)
+	require.Empty(t, resp.GetValues())
+
+	// the internal namespaces are read-only
+	_, err = bp.StoreMetadata(ctx, &drand.StoreMetadataRequest{Namespace: statsNamespace, Key: "k", Put: true})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = bp.StoreMetadata(ctx, &drand.StoreMetadataRequest{Namespace: statsNamespace})
+	require.NoError(t, err)
+}
+
+func TestBenchStore(t *testing.T) {
+	folder := t.TempDir()
+	conf := NewConfig(testlogger.New(t), WithConfigFolder(folder))
+	require.Equal(t, []chain.StorageType{chain.BoltDB, chain.MemDB}, BenchStores(conf))
+
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	for _, engine := range BenchStores(conf) {
+		result, err := BenchStore(context.Background(), conf, engine, sch, 50)
+		require.NoError(t, err, engine)
+		require.Equal(t, 50, result.Beacons)
+		require.Positive(t, result.WritesPerSecond())
+		require.Positive(t, result.ReadsPerSecond())
+		if engine == chain.MemDB {
+			require.Zero(t, result.BytesPerBeacon)
+		} else {
+			require.Positive(t, result.BytesPerBeacon)
+			require.Equal(t, 2*result.Projected(time.Minute, time.Hour), result.Projected(30*time.Second, time.Hour))
+		}
+	}
+
+	// the temporary stores are removed
+	entries, err := os.ReadDir(conf.ConfigFolderMB())
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	_, err = BenchStore(context.Background(), conf, chain.PostgreSQL, sch, 50)
+	require.ErrorContains(t, err, "--pg-dsn")
+}
+
+func TestBeaconProcessRoundAnnotations(t *testing.T) {
+	l := testlogger.New(t)
+	ctx := context.Background()
+	bp := &BeaconProcess{
+		log:      l,
+		opts:     NewConfig(l),
+		beaconID: t.Name(),
+		dbStore:  memdb.NewStore(10),
+	}
+
+	_, err := bp.RoundAnnotations(ctx, &drand.RoundAnnotationsRequest{Note: "no round"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = bp.RoundAnnotations(ctx, &drand.RoundAnnotationsRequest{Round: 1, Note: strings.Repeat("a", maxAnnotationLen+1)})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	for _, a := range []struct {
+		round uint64
+		note  string
+	}{{42, "used for lottery X"}, {7, "correction applied here"}, {42, "published in the results"}} {
+		resp, err := bp.RoundAnnotations(ctx, &drand.RoundAnnotationsRequest{Round: a.round, Note: a.note})
+		require.NoError(t, err)
+		require.Equal(t, a.note, resp.GetAnnotations()[len(resp.GetAnnotations())-1].GetNote())
+	}
+
+	// the rounds are listed in order, with their notes in the order they were attached
+	resp, err := bp.RoundAnnotations(ctx, &drand.RoundAnnotationsRequest{})
+	require.NoError(t, err)
+	var listed []string
+	for _, a := range resp.GetAnnotations() {
+		listed = append(listed, fmt.Sprintf("%d:%s", a.GetRound(), a.GetNote()))
+	}
+	require.Equal(t, []string{"7:correction applied here", "42:used for lottery X", "42:published in the results"}, listed)
+
+	resp, err = bp.RoundAnnotations(ctx, &drand.RoundAnnotationsRequest{Round: 42, Remove: true})
+	require.NoError(t, err)
+	require.Empty(t, resp.GetAnnotations())
+	resp, err = bp.RoundAnnotations(ctx, &drand.RoundAnnotationsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetAnnotations(), 1)
+
+	// the annotations can't be modified through the metadata API
+	_, err = bp.StoreMetadata(ctx, &drand.StoreMetadataRequest{Namespace: annotationsNamespace, Key: annotationKey(7), Delete: true})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// udpBeacon is the reply to a UDP query for the latest beacon
+type udpBeacon struct {
+	Round             uint64          `json:"round"`
+	Randomness        common.HexBytes `json:"randomness"`
+	Signature         common.HexBytes `json:"signature"`
+	PreviousSignature common.HexBytes `json:"previous_signature,omitempty"`
+}
+
+// udpError is the reply to a UDP query which can't be answered with a beacon
+type udpError struct {
+	Error string `json:"error"`
+}
+
+// answerUDPQuery answers a UDP query with the latest beacon of the chain it names, in JSON. The query holds the beacon
+// ID or the hex-encoded chain hash, the default beacon if empty, followed by the zeros padding it up to the size of
+// the reply.
+func (dd *DrandDaemon) answerUDPQuery(ctx context.Context, query []byte) []byte {
+	bp, err := dd.getBeaconProcessFromRequest(parseUDPQuery(query))
+	if err != nil {
+		return encodeUDPError(err)
+	}
+	resp, err := bp.PublicRand(ctx, &drand.PublicRandRequest{})
+	if err != nil {
+		return encodeUDPError(err)
+	}
+	reply, err := json.Marshal(&udpBeacon{
+		Round:             resp.GetRound(),
+		Randomness:        crypto.RandomnessFromSignature(resp.GetSignature()),
+		Signature:         resp.GetSignature(),
+		PreviousSignature: resp.GetPreviousSignature(),
+	})
+	if err != nil {
+		return encodeUDPError(err)
+	}
+	return reply
+}
+
+// parseUDPQuery returns the metadata naming the chain a UDP query asks for
+func parseUDPQuery(query []byte) *drand.Metadata {
+	name := string(bytes.TrimSpace(bytes.TrimRight(query, "\x00")))
+	if hash, err := hex.DecodeString(name); err == nil && len(hash) == sha256.Size {
+		return &drand.Metadata{ChainHash: hash}
+	}
+	return &drand.Metadata{BeaconID: name}
+}
+
+func encodeUDPError(err error) []byte {
+	reply, _ := json.Marshal(&udpError{Error: err.Error()})
+	return reply
+}
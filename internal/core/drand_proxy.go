@@ -33,8 +33,8 @@ func (d *drandProxy) String() string {
 }
 
 // Get returns randomness at a requested round
-func (d *drandProxy) Get(ctx context.Context, round uint64) (client.Result, error) {
-	resp, err := d.r.PublicRand(ctx, &drand.PublicRandRequest{Round: round})
+func (d *drandProxy) Get(ctx context.Context, round common.Round) (client.Result, error) {
+	resp, err := d.r.PublicRand(ctx, &drand.PublicRandRequest{Round: round.Uint64()})
 	if err != nil {
 		return nil, err
 	}
@@ -46,6 +46,30 @@ func (d *drandProxy) Get(ctx context.Context, round uint64) (client.Result, erro
 	return resp, err
 }
 
+// GetAt returns the randomness which was the latest one at the given time, for the time-travel queries of the HTTP API
+func (d *drandProxy) GetAt(ctx context.Context, at time.Time) (client.Result, error) {
+	resp, err := d.r.PublicRandAt(ctx, &drand.PublicRandAtRequest{Timestamp: at.Unix()})
+	if err != nil {
+		return nil, err
+	}
+	resp.Metadata = nil
+	resp.Randomness = crypto.RandomnessFromSignature(resp.GetSignature())
+
+	return resp, nil
+}
+
+// GetByRandomness returns the beacon of the given randomness, for the content-addressed queries of the HTTP API
+func (d *drandProxy) GetByRandomness(ctx context.Context, randomness []byte) (client.Result, error) {
+	resp, err := d.r.PublicRandByRandomness(ctx, &drand.PublicRandByRandomnessRequest{Randomness: randomness})
+	if err != nil {
+		return nil, err
+	}
+	resp.Metadata = nil
+	resp.Randomness = crypto.RandomnessFromSignature(resp.GetSignature())
+
+	return resp, nil
+}
+
 // Watch returns new randomness as it becomes available.
 func (d *drandProxy) Watch(ctx context.Context) <-chan client.Result {
 	proxy := newStreamProxy(ctx)
@@ -68,6 +92,15 @@ func (d *drandProxy) Info(ctx context.Context) (*chain2.Info, error) {
 	return chain2.InfoFromProto(info)
 }
 
+// Group returns the group file of the chain, for the v1 /group endpoint of the HTTP API
+func (d *drandProxy) Group(ctx context.Context) (*drand.GroupPacket, error) {
+	resp, err := d.r.GroupMembership(ctx, &drand.GroupMembershipRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetCurrent().GetGroup(), nil
+}
+
 // RoundAt will return the most recent round of randomness that will be available
 // at time for the current client.
 func (d *drandProxy) RoundAt(t time.Time) uint64 {
@@ -3,6 +3,7 @@ package core
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/drand/drand/v2/protobuf/drand"
 )
@@ -55,6 +56,7 @@ func StatusResponseToString(status *drand.StatusResponse) string {
 	beaconStatus := GetBeaconDescription(BeaconStatus(status.Beacon.Status))
 
 	output := new(strings.Builder)
+	fmt.Fprintf(output, "* Health score: %d/100 \n", status.HealthScore)
 	fmt.Fprintf(output, "* Dkg \n")
 	fmt.Fprintf(output, " - Status: %s \n", dkgStatus)
 	fmt.Fprintf(output, "DKG epoch: %d \n", status.Epoch)
@@ -67,12 +69,90 @@ func StatusResponseToString(status *drand.StatusResponse) string {
 	fmt.Fprintf(output, " - Started: %t \n", status.Beacon.IsStarted)
 	fmt.Fprintf(output, " - Serving: %t \n", status.Beacon.IsServing)
 	fmt.Fprintf(output, " - Running: %t \n", status.Beacon.IsRunning)
+	fmt.Fprintf(output, " - Paused: %t \n", status.Beacon.IsPaused)
+	if status.Beacon.IsPaused && status.Beacon.PauseReason != "" {
+		fmt.Fprintf(output, " - Pause reason: %s \n", status.Beacon.PauseReason)
+	}
+	if usage := status.GetShareUsage(); usage != nil {
+		fmt.Fprintf(output, "* Key share usage \n")
+		fmt.Fprintf(output, " - Partials signed: %d \n", usage.PartialsSigned)
+		fmt.Fprintf(output, " - Anomalies: %d \n", usage.Anomalies)
+	}
+	if dst := status.GetDst(); dst != nil {
+		fmt.Fprintf(output, "* Domain separation tag \n")
+		fmt.Fprintf(output, " - Scheme: %s \n", dst.Scheme)
+		fmt.Fprintf(output, " - DST: %s \n", dst.Dst)
+		fmt.Fprintf(output, " - Fingerprint: %s \n", dst.Fingerprint)
+		if dst.Custom {
+			fmt.Fprintf(output, " - Differs from the released builds! \n")
+		}
+	}
+	if upgrade := status.GetUpgrade(); upgrade != nil {
+		fmt.Fprintf(output, "* Coordinated upgrade \n")
+		fmt.Fprintf(output, " - Coordinator: %s \n", upgrade.Coordinator)
+		fmt.Fprintf(output, " - Window: %s to %s \n",
+			time.Unix(upgrade.WindowStart, 0).UTC().Format(time.RFC3339), time.Unix(upgrade.WindowEnd, 0).UTC().Format(time.RFC3339))
+		if upgrade.RestartAt == 0 {
+			fmt.Fprintf(output, " - Restart: not accepted yet \n")
+		} else {
+			fmt.Fprintf(output, " - Restart: %s \n", time.Unix(upgrade.RestartAt, 0).UTC().Format(time.RFC3339))
+		}
+		fmt.Fprintf(output, " - Acknowledged by: %s \n", strings.Join(upgrade.Acknowledged, ", "))
+	}
+	if forecast := status.GetReshareForecast(); forecast != nil {
+		at := time.Unix(forecast.At, 0).UTC()
+		fmt.Fprintf(output, "* Reshare forecast \n")
+		fmt.Fprintf(output, " - Coordinator: %s \n", forecast.Coordinator)
+		fmt.Fprintf(output, " - On: %s \n", at.Format(time.RFC3339))
+		if forecast.Note != "" {
+			fmt.Fprintf(output, " - Note: %s \n", forecast.Note)
+		}
+		if forecast.TlsExpiry != 0 {
+			expiry := time.Unix(forecast.TlsExpiry, 0).UTC()
+			if expiry.Before(at) {
+				fmt.Fprintf(output, " - TLS certificate: expires on %s, before the reshare! \n", expiry.Format(time.RFC3339))
+			} else {
+				fmt.Fprintf(output, " - TLS certificate: expires on %s \n", expiry.Format(time.RFC3339))
+			}
+		}
+	}
+	if res := status.GetResources(); res != nil {
+		fmt.Fprintf(output, "* Resources \n")
+		fmt.Fprintf(output, " - Memory: %s \n", byteSize(res.RssBytes))
+		fmt.Fprintf(output, " - CPU: %.1f%% \n", res.CpuPercent)
+		if res.MaxFds != 0 {
+			fmt.Fprintf(output, " - Open files: %d of %d \n", res.OpenFds, res.MaxFds)
+		} else {
+			fmt.Fprintf(output, " - Open files: %d \n", res.OpenFds)
+		}
+		fmt.Fprintf(output, " - Goroutines: %d \n", res.Goroutines)
+		if res.StoreBytes != 0 {
+			fmt.Fprintf(output, " - Store: %s \n", byteSize(res.StoreBytes))
+		}
+	}
+	if leaving := status.GetLeaving(); len(leaving) > 0 {
+		fmt.Fprintf(output, "* Leaving the group \n")
+		for _, l := range leaving {
+			fmt.Fprintf(output, " - %s after round %d \n", l.Address, l.LastRound)
+		}
+	}
 	if conns := status.GetConnections(); len(conns) > 0 {
-		fmt.Fprintf(output, "* Network visibility\n")
+		fmt.Fprintf(output, "* Network visibility (checked at %s)\n",
+			time.Unix(status.ConnectionsCheckedAt, 0).UTC().Format(time.RFC3339))
+		details := status.GetConnectionDetails()
 		for addr, ok := range conns {
-			if ok {
+			d, hasDetails := details[addr]
+			switch {
+			case ok && hasDetails:
+				fmt.Fprintf(output, " - %s -> OK (rtt %dms)\n", addr, d.RttMs)
+			case ok:
 				fmt.Fprintf(output, " - %s -> OK\n", addr)
-			} else {
+			case hasDetails && d.LastSuccess != 0:
+				fmt.Fprintf(output, " - %s -> X no connection: %s (last success %s)\n", addr, d.ErrorClass,
+					time.Unix(d.LastSuccess, 0).UTC().Format(time.RFC3339))
+			case hasDetails:
+				fmt.Fprintf(output, " - %s -> X no connection: %s (never reached)\n", addr, d.ErrorClass)
+			default:
 				fmt.Fprintf(output, " - %s -> X no connection\n", addr)
 			}
 		}
@@ -1,17 +1,24 @@
 package core
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	gonet "net"
 	"os"
 	"path"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/drand/drand/v2/common"
@@ -533,7 +540,283 @@ func TestAbortDKGAndStartANewOne(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// Test that a refresh of the shares is accepted and executed without operator action, and keeps the members and public
+// key of the group while giving new shares to its members
+func TestRunDKGRefresh(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping slow test in short mode.")
+	}
+	l := testlogger.New(t)
+	n := 4
+	beaconPeriod := 5 * time.Second
+	beaconID := test.GetBeaconIDFromEnv()
+	dt := NewDrandTestScenario(t, n, key.DefaultThreshold(n), beaconPeriod, beaconID, clockwork.NewFakeClockAt(time.Now()),
+		WithAutoRefresh(true), WithMinRefreshInterval(0))
+
+	group, err := dt.RunDKG(t)
+	require.NoError(t, err)
+	dt.SetMockClock(t, group.GenesisTime)
+	require.NoError(t, dt.WaitUntilRound(t, dt.nodes[0], 1))
+
+	shares := make([]*key.Share, n)
+	for i, node := range dt.nodes {
+		node.drand.state.RLock()
+		shares[i] = node.drand.share
+		node.drand.state.RUnlock()
+	}
+
+	leader := dt.nodes[0]
+	require.NoError(t, leader.dkgRunner.StartRefresh())
+
+	leaderClient, err := net.NewDKGControlClient(l, leader.drand.opts.controlPort)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		status, err := leaderClient.DKGStatus(context.Background(), &pdkg.DKGStatusRequest{BeaconID: beaconID})
+		return err == nil && status.Current.State == uint32(dkg.Executing)
+	}, 10*time.Second, 100*time.Millisecond, "the members should accept the refresh and the leader execute it")
+	dt.AdvanceMockClock(t, leader.daemon.opts.dkgKickoffGracePeriod)
+
+	refreshed, err := dt.WaitForDKG(t, leader, 2, 120)
+	require.NoError(t, err)
+	require.True(t, group.PublicKey.Key().Equal(refreshed.PublicKey.Key()))
+	require.Equal(t, public.NewChainInfo(group).Hash(), public.NewChainInfo(refreshed).Hash())
+	require.Equal(t, group.Threshold, refreshed.Threshold)
+	require.Len(t, refreshed.Nodes, n)
+
+	for i, node := range dt.nodes {
+		require.NoError(t, node.dkgRunner.WaitForDKG(node.drand.log, 2, 60))
+		node.drand.state.RLock()
+		share := node.drand.share
+		node.drand.state.RUnlock()
+		require.False(t, shares[i].PrivateShare().V.Equal(share.PrivateShare().V), "the share of %s wasn't refreshed", node.addr)
+	}
+
+	// the chain goes on with the new shares after the transition
+	dt.SetMockClock(t, refreshed.TransitionTime)
+	transition := common.CurrentRound(refreshed.TransitionTime, refreshed.Period, refreshed.GenesisTime)
+	dt.AdvanceMockClock(t, beaconPeriod)
+	require.NoError(t, dt.WaitUntilRound(t, dt.nodes[1], transition+1))
+}
+
+func TestPartialAudit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping slow test in short mode.")
+	}
+	n := 4
+	thr := key.DefaultThreshold(n)
+	beaconPeriod := 1 * time.Second
+	beaconID := test.GetBeaconIDFromEnv()
+	dt := NewDrandTestScenario(t, n, thr, beaconPeriod, beaconID, clockwork.NewFakeClockAt(time.Now()),
+		WithFeatureFlags([]string{"partial-audit=on"}))
+
+	group, err := dt.RunDKG(t)
+	require.NoError(t, err)
+	dt.SetMockClock(t, group.GenesisTime)
+	require.NoError(t, dt.WaitUntilRound(t, dt.nodes[0], 1))
+	dt.AdvanceMockClock(t, beaconPeriod)
+	require.NoError(t, dt.WaitUntilRound(t, dt.nodes[0], 2))
+
+	ctx := context.Background()
+	bp := dt.nodes[0].drand
+	var audit *drand.PartialAuditResponse
+	require.Eventually(t, func() bool {
+		audit, err = bp.PartialAudit(ctx, &drand.PartialAuditRequest{FromRound: 2, ToRound: 2})
+		return err == nil && len(audit.GetRounds()) == 1
+	}, 10*time.Second, 100*time.Millisecond)
+
+	round := audit.GetRounds()[0]
+	require.Equal(t, uint64(2), round.GetRound())
+	require.Len(t, round.GetIndexes(), thr)
+	require.Len(t, round.GetAddresses(), thr)
+	for i, idx := range round.GetIndexes() {
+		node := group.Node(idx)
+		require.NotNil(t, node)
+		require.Equal(t, node.Address(), round.GetAddresses()[i])
+	}
+
+	_, err = bp.PartialAudit(ctx, &drand.PartialAuditRequest{FromRound: 3, ToRound: 2})
+	require.Error(t, err)
+
+	// the range is returned a page at a time
+	defer func(max int) { MaxPartialAuditRounds = max }(MaxPartialAuditRounds)
+	MaxPartialAuditRounds = 1
+	audit, err = bp.PartialAudit(ctx, &drand.PartialAuditRequest{})
+	require.NoError(t, err)
+	require.Len(t, audit.GetRounds(), 1)
+	if audit.GetRounds()[0].GetRound() < 2 {
+		require.Equal(t, uint64(2), audit.GetNextRound())
+	} else {
+		require.Zero(t, audit.GetNextRound())
+	}
+}
+
+func TestInjectBeacon(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping slow test in short mode.")
+	}
+	n := 4
+	beaconPeriod := 1 * time.Second
+	beaconID := test.GetBeaconIDFromEnv()
+	dt := NewDrandTestScenario(t, n, key.DefaultThreshold(n), beaconPeriod, beaconID, clockwork.NewFakeClockAt(time.Now()))
+
+	group, err := dt.RunDKG(t)
+	require.NoError(t, err)
+	dt.SetMockClock(t, group.GenesisTime)
+	require.NoError(t, dt.WaitUntilRound(t, dt.nodes[0], 1))
+	for round := uint64(2); round <= 3; round++ {
+		dt.AdvanceMockClock(t, beaconPeriod)
+		require.NoError(t, dt.WaitUntilRound(t, dt.nodes[0], round))
+	}
+
+	ctx := context.Background()
+	bp := dt.nodes[0].drand
+	store := bp.beacon.Store()
+	b, err := store.Get(ctx, 2)
+	require.NoError(t, err)
+	req := func(b *common.Beacon) *drand.InjectBeaconRequest {
+		return &drand.InjectBeaconRequest{Round: b.Round, Signature: b.Signature, PreviousSignature: b.PreviousSig}
+	}
+
+	// the injection is guarded by a feature flag
+	_, err = bp.InjectBeacon(ctx, req(b))
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = bp.FeatureFlags(ctx, &drand.FeatureFlagsRequest{Name: string(FeatureBeaconInjection), Enabled: true})
+	require.NoError(t, err)
+
+	resp, err := bp.InjectBeacon(ctx, req(b))
+	require.NoError(t, err)
+	require.False(t, resp.GetStored())
+
+	// a gap is repaired with the beacon of another node
+	require.NoError(t, store.Del(ctx, 2))
+	_, err = store.Get(ctx, 2)
+	require.Error(t, err)
+	other, err := dt.nodes[1].drand.beacon.Store().Get(ctx, 2)
+	require.NoError(t, err)
+	resp, err = bp.InjectBeacon(ctx, req(other))
+	require.NoError(t, err)
+	require.True(t, resp.GetStored())
+	require.Equal(t, uint64(3), resp.GetLastRound())
+	stored, err := store.Get(ctx, 2)
+	require.NoError(t, err)
+	require.True(t, other.Equal(stored))
+	tombstones, err := bp.Tombstones(ctx, &drand.TombstonesRequest{})
+	require.NoError(t, err)
+	require.Empty(t, tombstones.GetTombstones(), "filling a gap doesn't replace anything")
+
+	// an invalid beacon replaced is kept in the tombstones: the last one, which no round links to in a trimmed store
+	last, err := dt.nodes[1].drand.beacon.Store().Get(ctx, 3)
+	require.NoError(t, err)
+	corrupted := &common.Beacon{Round: 3, Signature: bytes.Repeat([]byte{1}, len(last.Signature)), PreviousSig: last.PreviousSig}
+	require.NoError(t, bp.dbStore.Put(ctx, corrupted))
+	resp, err = bp.InjectBeacon(ctx, req(last))
+	require.NoError(t, err)
+	require.True(t, resp.GetStored())
+	tombstones, err = bp.Tombstones(ctx, &drand.TombstonesRequest{FromRound: 3, ToRound: 3})
+	require.NoError(t, err)
+	require.Len(t, tombstones.GetTombstones(), 1)
+	tombstone := tombstones.GetTombstones()[0]
+	require.Equal(t, []byte(corrupted.Signature), tombstone.GetSignature())
+	require.Equal(t, []byte(last.Signature), tombstone.GetReplacedBy())
+	require.Equal(t, "inject", tombstone.GetReason())
+	require.Equal(t, group.GenesisTime+2*int64(beaconPeriod.Seconds()), tombstone.GetRoundTime())
+
+	// the beacons which don't verify against the chain are refused
+	forged := &common.Beacon{Round: 4, Signature: b.Signature, PreviousSig: b.PreviousSig}
+	_, err = bp.InjectBeacon(ctx, req(forged))
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
 // Check they all have same chain info
+func TestGetCapabilities(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping slow test in short mode.")
+	}
+	ctx := context.Background()
+	n := 3
+	beaconID := test.GetBeaconIDFromEnv()
+	dt := NewDrandTestScenario(t, n, key.DefaultThreshold(n), time.Second, beaconID, clockwork.NewFakeClockAt(time.Now()))
+	group, err := dt.RunDKG(t)
+	require.NoError(t, err)
+
+	node := dt.nodes[0]
+	control, err := net.NewControlClient(node.drand.log, node.drand.opts.controlPort)
+	require.NoError(t, err)
+	caps, err := control.GetCapabilities(beaconID)
+	require.NoError(t, err)
+	require.Equal(t, group.Scheme.Name, caps.GetScheme())
+	require.Equal(t, slices.Contains(timelockSchemes, group.Scheme.Name), caps.GetTimelock())
+	require.ElementsMatch(t, crypto.ListSchemes(), caps.GetSchemes())
+	require.Equal(t, string(node.drand.opts.dbStorageEngine), caps.GetStorage())
+	require.Contains(t, caps.GetBeaconIds(), common.GetCanonicalBeaconID(beaconID))
+	require.Contains(t, caps.GetFeatures(), string(FeatureStoreRepair))
+	require.NotContains(t, caps.GetFeatures(), string(FeatureBeaconInjection))
+	require.Contains(t, caps.GetEndpoints(), endpointGossip)
+
+	// the peers get the same capabilities on the public service, without what only concerns the operator
+	client := net.NewGrpcClient(testlogger.New(t))
+	fromPeer, err := client.GetCapabilities(ctx, net.CreatePeer(node.addr),
+		&drand.CapabilitiesRequest{Metadata: &drand.Metadata{BeaconID: beaconID}})
+	require.NoError(t, err)
+	require.Equal(t, caps.GetScheme(), fromPeer.GetScheme())
+	require.Equal(t, caps.GetEndpoints(), fromPeer.GetEndpoints())
+	require.Empty(t, fromPeer.GetStorage())
+	require.Empty(t, fromPeer.GetFeatures())
+	require.Empty(t, fromPeer.GetBeaconIds())
+}
+
+func TestMinCompatibleVersion(t *testing.T) {
+	for _, v := range []common.Version{{Major: 2, Minor: 0, Patch: 3}, {Major: 2, Minor: 1}, {Major: 2, Minor: 4, Patch: 1}} {
+		minimum := minCompatibleVersion(v)
+		require.True(t, v.IsCompatible(minimum), "%v should be compatible with %v", v, minimum)
+		if minimum.Minor > 0 {
+			older := common.Version{Major: minimum.Major, Minor: minimum.Minor - 1, Patch: 99}
+			require.False(t, v.IsCompatible(older), "%v shouldn't be compatible with %v", v, older)
+		}
+	}
+	require.Equal(t, common.Version{Major: 1, Minor: 5, Patch: 8}, minCompatibleVersion(common.Version{Major: 2}))
+}
+
+func TestPublicRandByRandomness(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping slow test in short mode.")
+	}
+	ctx := context.Background()
+	n := 3
+	beaconID := test.GetBeaconIDFromEnv()
+	dt := NewDrandTestScenario(t, n, key.DefaultThreshold(n), time.Second, beaconID, clockwork.NewFakeClockAt(time.Now()))
+	group, err := dt.RunDKG(t)
+	require.NoError(t, err)
+
+	root := dt.nodes[0].drand
+	if root.randomnessIndex == nil {
+		t.Skip("the chain store can't index the randomness")
+	}
+
+	dt.SetMockClock(t, group.GenesisTime)
+	require.NoError(t, dt.WaitUntilChainIsServing(t, dt.nodes[0]))
+	for round := uint64(2); round <= 4; round++ {
+		dt.AdvanceMockClock(t, group.Period)
+		require.NoError(t, dt.WaitUntilRound(t, dt.nodes[0], round))
+	}
+
+	for round := uint64(1); round <= 4; round++ {
+		b, err := root.PublicRand(ctx, &drand.PublicRandRequest{Round: round})
+		require.NoError(t, err)
+		resp, err := root.PublicRandByRandomness(ctx, &drand.PublicRandByRandomnessRequest{
+			Randomness: crypto.RandomnessFromSignature(b.GetSignature()),
+		})
+		require.NoError(t, err)
+		require.Equal(t, round, resp.GetRound())
+		require.Equal(t, b.GetSignature(), resp.GetSignature())
+	}
+
+	_, err = root.PublicRandByRandomness(ctx, &drand.PublicRandByRandomnessRequest{Randomness: make([]byte, 32)})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = root.PublicRandByRandomness(ctx, &drand.PublicRandByRandomnessRequest{Randomness: []byte{1}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
 func TestDrandPublicChainInfo(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping slow test in short mode.")
@@ -586,6 +869,262 @@ func TestDrandPublicChainInfo(t *testing.T) {
 	}
 }
 
+func TestDrandGroupMembership(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping slow test in short mode.")
+	}
+
+	ctx := context.Background()
+	n := 4
+	thr := key.DefaultThreshold(n)
+	beaconID := test.GetBeaconIDFromEnv()
+
+	dt := NewDrandTestScenario(t, n, thr, time.Second, beaconID, clockwork.NewFakeClockAt(time.Now()))
+	group, err := dt.RunDKG(t)
+	require.NoError(t, err)
+
+	for _, node := range dt.nodes {
+		resp, err := node.daemon.GroupMembership(ctx, &drand.GroupMembershipRequest{Metadata: &drand.Metadata{BeaconID: beaconID}})
+		require.NoError(t, err)
+
+		current := resp.GetCurrent()
+		require.EqualValues(t, 1, current.GetEpoch())
+		require.Equal(t, group.Hash(), current.GetGroupHash())
+		require.EqualValues(t, thr, current.GetGroup().GetThreshold())
+		require.Len(t, current.GetGroup().GetNodes(), n)
+		require.Len(t, resp.GetHistory(), 1)
+		// no heartbeat is signed without a heartbeat period
+		require.Nil(t, resp.GetHeartbeat())
+	}
+}
+
+func TestDrandChainSummary(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping slow test in short mode.")
+	}
+
+	ctx := context.Background()
+	n := 4
+	thr := key.DefaultThreshold(n)
+	p := 1 * time.Second
+	beaconID := test.GetBeaconIDFromEnv()
+
+	dt := NewDrandTestScenario(t, n, thr, p, beaconID, clockwork.NewFakeClockAt(time.Now()))
+	group, err := dt.RunDKG(t)
+	require.NoError(t, err)
+
+	dt.SetMockClock(t, group.GenesisTime)
+	err = dt.WaitUntilChainIsServing(t, dt.nodes[0])
+	require.NoError(t, err)
+	dt.AdvanceMockClock(t, p)
+	err = dt.WaitUntilRound(t, dt.nodes[0], 2)
+	require.NoError(t, err)
+
+	root := dt.nodes[0].drand
+	root.events.record(dt.clock.Now(), eventStoreRepaired, "1 round deleted")
+	root.events.record(dt.clock.Now(), eventQuorumAtRisk, "")
+
+	resp, err := dt.nodes[0].daemon.ChainSummary(ctx, &drand.ChainSummaryRequest{Metadata: &drand.Metadata{BeaconID: beaconID}})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, resp.GetHeadRound(), uint64(2))
+	require.Equal(t, group.GenesisTime, resp.GetGenesisTime())
+	require.EqualValues(t, p.Seconds(), resp.GetPeriod())
+	require.Equal(t, common.TimeOfRound(p, group.GenesisTime, common.Round(resp.GetHeadRound())), resp.GetHeadTime())
+	require.Equal(t, resp.GetExpectedRound(), resp.GetExpectedRoundsLastDay())
+	// the genesis beacon is stored as well
+	require.Equal(t, resp.GetHeadRound()+1, resp.GetTotalRounds())
+	require.Equal(t, resp.GetHeadRound(), resp.GetRoundsLastDay())
+	require.Equal(t, eventStoreRepaired, resp.GetLastCorrection().GetKind())
+}
+
+func TestDrandUDPLatestBeacon(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping slow test in short mode.")
+	}
+
+	n := 4
+	thr := key.DefaultThreshold(n)
+	p := 1 * time.Second
+	beaconID := test.GetBeaconIDFromEnv()
+
+	dt := NewDrandTestScenario(t, n, thr, p, beaconID, clockwork.NewFakeClockAt(time.Now()), WithUDPListenAddress("127.0.0.1:0"))
+	group, err := dt.RunDKG(t)
+	require.NoError(t, err)
+
+	dt.SetMockClock(t, group.GenesisTime)
+	err = dt.WaitUntilChainIsServing(t, dt.nodes[0])
+	require.NoError(t, err)
+	err = dt.WaitUntilRound(t, dt.nodes[0], 1)
+	require.NoError(t, err)
+
+	conn, err := gonet.Dial("udp", dt.nodes[0].daemon.udp.Addr())
+	require.NoError(t, err)
+	defer conn.Close()
+	query := func(name string) []byte {
+		q := make([]byte, net.MaxUDPDatagram)
+		copy(q, name)
+		_, err := conn.Write(q)
+		require.NoError(t, err)
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		reply := make([]byte, net.MaxUDPDatagram)
+		n, err := conn.Read(reply)
+		require.NoError(t, err)
+		return reply[:n]
+	}
+
+	expected, err := dt.nodes[0].drand.PublicRand(context.Background(), &drand.PublicRandRequest{})
+	require.NoError(t, err)
+	for _, name := range []string{beaconID, hex.EncodeToString(dt.nodes[0].drand.chainHash)} {
+		var b udpBeacon
+		require.NoError(t, json.Unmarshal(query(name), &b))
+		require.Equal(t, expected.GetRound(), b.Round)
+		require.Equal(t, expected.GetSignature(), []byte(b.Signature))
+	}
+
+	var e udpError
+	require.NoError(t, json.Unmarshal(query("unknown"), &e))
+	require.NotEmpty(t, e.Error)
+}
+
+func TestDrandPushReplication(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping slow test in short mode.")
+	}
+
+	ctx := context.Background()
+	n := 4
+	thr := key.DefaultThreshold(n)
+	p := 1 * time.Second
+	beaconID := test.GetBeaconIDFromEnv()
+
+	dt := NewDrandTestScenario(t, n, thr, p, beaconID, clockwork.NewFakeClockAt(time.Now()))
+	// the downstream node isn't part of the group and never dials it
+	downstream := dt.SetupNewNodes(t, 1)[0]
+
+	group, err := dt.RunDKG(t)
+	require.NoError(t, err)
+	info := public.NewChainInfo(group)
+	downstream.daemon.opts.acceptPush = []string{info.HashString()}
+	// the chain hash is only known once the DKG is over, after the beacon started
+	dt.nodes[0].daemon.opts.reloadLock.Lock()
+	dt.nodes[0].daemon.opts.pushTargets = []string{downstream.addr}
+	dt.nodes[0].daemon.opts.reloadLock.Unlock()
+	dt.nodes[0].drand.startPushReplication()
+
+	dt.SetMockClock(t, group.GenesisTime)
+	err = dt.WaitUntilChainIsServing(t, dt.nodes[0])
+	require.NoError(t, err)
+	for round := uint64(2); round <= 3; round++ {
+		dt.AdvanceMockClock(t, p)
+		err = dt.WaitUntilRound(t, dt.nodes[0], round)
+		require.NoError(t, err)
+	}
+
+	replica := &downstream.drand.replica
+	require.Eventually(t, func() bool {
+		replica.Lock()
+		defer replica.Unlock()
+		if replica.store == nil {
+			return false
+		}
+		last, err := replica.store.Last(ctx)
+		return err == nil && last.Round >= 3
+	}, 10*time.Second, 50*time.Millisecond)
+
+	expected, err := dt.nodes[0].drand.beacon.Store().Get(ctx, 3)
+	require.NoError(t, err)
+	replica.Lock()
+	pushed, err := replica.store.Get(ctx, 3)
+	replica.Unlock()
+	require.NoError(t, err)
+	require.Equal(t, expected.Signature, pushed.Signature)
+
+	// the downstream node serves the copy of the chain pushed to it
+	rand, err := downstream.drand.PublicRand(ctx, &drand.PublicRandRequest{Round: 3})
+	require.NoError(t, err)
+	require.Equal(t, expected.Signature, common.HexBytes(rand.GetSignature()))
+	served, err := downstream.drand.ChainInfo(ctx, &drand.ChainInfoRequest{})
+	require.NoError(t, err)
+	require.Equal(t, info.Hash(), served.GetHash())
+
+	// the pushes for the chains which aren't accepted are refused
+	other := info.ToProto(nil)
+	other.GenesisTime++
+	_, err = downstream.drand.PushBeacon(ctx, &drand.PushedBeacon{Beacon: &drand.BeaconPacket{Round: 4}, Info: other})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// and so are all of them once the feature is turned off
+	_, err = downstream.drand.FeatureFlags(ctx, &drand.FeatureFlagsRequest{Name: string(FeatureChainPush)})
+	require.NoError(t, err)
+	_, err = downstream.drand.PushBeacon(ctx, &drand.PushedBeacon{Beacon: &drand.BeaconPacket{Round: 4}, Info: info.ToProto(nil)})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestDrandRelayFollow(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping slow test in short mode.")
+	}
+
+	ctx := context.Background()
+	n := 4
+	p := 1 * time.Second
+	beaconID := test.GetBeaconIDFromEnv()
+
+	dt := NewDrandTestScenario(t, n, key.DefaultThreshold(n), p, beaconID, clockwork.NewFakeClockAt(time.Now()))
+	followers := dt.SetupNewNodes(t, 2)
+	relay, leaf := followers[0], followers[1]
+
+	group, err := dt.RunDKG(t)
+	require.NoError(t, err)
+	hash := public.NewChainInfo(group).HashString()
+	dt.SetMockClock(t, group.GenesisTime)
+	err = dt.WaitUntilChainIsServing(t, dt.nodes[0])
+	require.NoError(t, err)
+
+	follow := func(node *MockNode, upstreams []string) {
+		client, err := net.NewControlClient(node.drand.log, node.drand.opts.controlPort)
+		require.NoError(t, err)
+		followCtx, cancel := context.WithCancel(ctx)
+		t.Cleanup(cancel)
+		_, _, err = client.StartFollowChain(followCtx, hash, upstreams, 0, beaconID, false, nil, false, true, nil)
+		require.NoError(t, err)
+	}
+	lastStored := func(node *MockNode) uint64 {
+		resp, err := node.drand.Status(ctx, &drand.StatusRequest{})
+		require.NoError(t, err)
+		return resp.GetChainStore().GetLastStored()
+	}
+	advanceUntil := func(node *MockNode, round uint64) {
+		for i := 0; lastStored(node) < round; i++ {
+			require.Less(t, i, 30, "round %d not reached by %s", round, node.addr)
+			dt.AdvanceMockClock(t, p)
+		}
+	}
+
+	// the relay prefers the first node of the group, the leaf follows the relay once it relays the chain
+	follow(relay, []string{dt.nodes[0].addr, dt.nodes[1].addr})
+	advanceUntil(relay, 2)
+	follow(leaf, []string{relay.addr})
+	advanceUntil(leaf, 4)
+	require.Contains(t, relay.drand.relayPath(), leaf.addr)
+
+	// the relay refuses to serve the chain to a node it would be relaying it to
+	client := net.NewGrpcClient(relay.drand.log)
+	beacons, err := client.SyncChain(ctx, net.CreatePeer(relay.addr), &drand.SyncRequest{
+		FromRound: 1,
+		Metadata:  &drand.Metadata{BeaconID: beaconID},
+		RelayPath: []string{leaf.addr, relay.addr},
+	})
+	require.NoError(t, err)
+	for b := range beacons {
+		t.Fatalf("received round %d through a loop", b.GetRound())
+	}
+
+	// the relay fails over to the next node of the group when the first one stops
+	dt.StopMockNode(dt.nodes[0].addr, false)
+	advanceUntil(leaf, lastStored(dt.nodes[1])+2)
+}
+
 // Test if we can correctly fetch the rounds after a DKG using the PublicRand RPC call
 //
 //nolint:funlen // This is a longer test function
@@ -892,21 +1431,32 @@ func TestDrandFollowChain(t *testing.T) {
 	// First try with an invalid hash info
 	t.Logf(" \t [-] Trying to follow with an invalid hash\n")
 	ctx, cancel = context.WithCancel(context.Background())
-	_, errCh, _ := newClient.StartFollowChain(ctx, "deadbeef", addrToFollow, 10000, beaconID)
-	expectChanFail(t, errCh)
+	unreachable := "127.0.0.1:" + test.FreePort()
+	_, errCh, _ := newClient.StartFollowChain(ctx, "deadbeef", append([]string{unreachable}, addrToFollow...), 10000,
+		beaconID, false, nil, false, false, nil)
+	select {
+	case err := <-errCh:
+		// the error tells why each peer failed
+		failed := net.FailedPeers(err)
+		require.Len(t, failed, 2, err)
+		require.Contains(t, failed[rootID.Address()], net.PeerBadHash)
+		require.Contains(t, failed[unreachable], net.PeerUnreachable)
+	case <-time.After(10 * time.Second):
+		t.Fatal("An error should have been received.")
+	}
 	cancel()
 
 	// testing with a non hex hash
 	t.Logf(" \t [-] Trying to follow with a non-hex hash\n")
 	ctx, cancel = context.WithCancel(context.Background())
-	_, _, err = newClient.StartFollowChain(ctx, "tutu", addrToFollow, 10000, beaconID)
+	_, _, err = newClient.StartFollowChain(ctx, "tutu", addrToFollow, 10000, beaconID, false, nil, false, false, nil)
 	require.Error(t, err)
 	cancel()
 
 	// testing with an invalid beaconID
 	t.Logf(" \t [-] Trying to follow with an invalid beaconID\n")
 	ctx, cancel = context.WithCancel(context.Background())
-	_, errCh, _ = newClient.StartFollowChain(ctx, hash, addrToFollow, 10000, "tutu")
+	_, errCh, _ = newClient.StartFollowChain(ctx, hash, addrToFollow, 10000, "tutu", false, nil, false, false, nil)
 	expectChanFail(t, errCh)
 	cancel()
 
@@ -915,7 +1465,7 @@ func TestDrandFollowChain(t *testing.T) {
 
 		t.Logf(" \t [+] Starting to follow chain with a valid hash. %d <= %d \n", upTo, exp)
 		t.Logf(" \t\t --> beaconID: %s ; hash-chain: %s", beaconID, hash)
-		progress, errCh, err := newClient.StartFollowChain(ctx, hash, addrToFollow, upTo, beaconID)
+		progress, errCh, err := newClient.StartFollowChain(ctx, hash, addrToFollow, upTo, beaconID, false, nil, false, false, nil)
 		require.NoError(t, err)
 
 		for goon := true; goon; {
@@ -970,6 +1520,62 @@ func TestDrandFollowChain(t *testing.T) {
 	fn(0, resp.GetRound())
 }
 
+// This test makes sure a follow gets its peers from the group of the chain, without the members which are down
+func TestFollowGroupPeers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping slow test in short mode.")
+	}
+	n, p := 4, 1*time.Second
+	beaconID := test.GetBeaconIDFromEnv()
+
+	dt := NewDrandTestScenario(t, n, key.DefaultThreshold(n), p, beaconID, clockwork.NewFakeClockAt(time.Now()))
+
+	group, err := dt.RunDKG(t)
+	require.NoError(t, err)
+	dt.SetMockClock(t, group.GenesisTime)
+	err = dt.WaitUntilChainIsServing(t, dt.nodes[0])
+	require.NoError(t, err)
+
+	rootID := dt.nodes[0].drand.priv.Public
+	// a member goes down
+	stopped := dt.nodes[n-1].addr
+	dt.nodes[n-1].daemon.Stop(context.Background())
+	<-dt.nodes[n-1].daemon.WaitExit()
+
+	newNode := dt.SetupNewNodes(t, 1)[0]
+	info := public.NewChainInfo(group)
+	unreachable := net.CreatePeer("127.0.0.1:" + test.FreePort())
+	ctx := context.Background()
+
+	peers, err := newNode.drand.followGroupPeers(ctx, []net.Peer{unreachable, rootID}, info)
+	require.NoError(t, err)
+	addrs := make([]string, 0, len(peers))
+	for _, peer := range peers {
+		addrs = append(addrs, peer.Address())
+	}
+	require.Len(t, addrs, n-1)
+	require.Contains(t, addrs, rootID.Address())
+	require.NotContains(t, addrs, stopped)
+
+	// the group of another chain isn't used
+	other := *info
+	other.GenesisTime++
+	_, err = newNode.drand.followGroupPeers(ctx, []net.Peer{rootID}, &other)
+	require.Error(t, err)
+	require.Contains(t, net.FailedPeers(err)[rootID.Address()], net.PeerBadHash)
+
+	// the sync is only restarted when the peers change
+	fp := newFollowPeers(peers)
+	reversed := make([]net.Peer, 0, len(peers))
+	for i := len(peers) - 1; i >= 0; i-- {
+		reversed = append(reversed, peers[i])
+	}
+	require.False(t, fp.set(reversed))
+	require.Empty(t, fp.updated)
+	require.True(t, fp.set(peers[1:]))
+	require.Len(t, fp.updated, 1)
+}
+
 // This test makes sure the "StartCheckChain" grpc method works fine
 //
 //nolint:funlen
@@ -1058,12 +1664,12 @@ func TestDrandCheckChain(t *testing.T) {
 	}
 
 	t.Logf(" \t\t --> Opened store. Getting 4th beacon\n")
-	beac, err := store.Get(ctx, upTo-1)
+	beac, err := store.Get(ctx, common.Round(upTo-1))
 	require.NoError(t, err)
 	require.Equal(t, upTo-1, beac.Round, "found %d vs expected %d", beac.Round, upTo-1)
 
 	t.Logf(" \t\t --> Deleting 4th beacon.\n")
-	err = store.Del(ctx, upTo-1)
+	err = store.Del(ctx, common.Round(upTo-1))
 	require.NoError(t, err)
 	err = store.Close()
 	require.NoError(t, err)
@@ -1214,7 +1820,7 @@ func TestModifyingGroupFileManuallyDoesNotSegfault(t *testing.T) {
 	priv := node.drand.priv
 
 	// set a persistent keystore, as the normal test ones are ephemeral
-	store := key.NewFileStore(dir, beaconID)
+	store := key.NewFileStore(dir, common.BeaconID(beaconID))
 	node.drand.store = store
 
 	// save the key pair, as this was done ephemerally inside `NewDrandTestScenario` >.>
@@ -1396,3 +2002,149 @@ func (d *DrandTestScenario) AddNodesWithOptions(t *testing.T, n int, beaconID st
 
 	return result
 }
+
+// This test makes sure a paused node keeps following the chain without signing partials, and that it can't be paused
+// when the group would then miss rounds unless forced
+func TestPauseBeacon(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping slow test in short mode.")
+	}
+	n, p := 4, 1*time.Second
+	beaconID := test.GetBeaconIDFromEnv()
+
+	dt := NewDrandTestScenario(t, n, key.DefaultThreshold(n), p, beaconID, clockwork.NewFakeClockAt(time.Now()))
+
+	group, err := dt.RunDKG(t)
+	require.NoError(t, err)
+	dt.SetMockClock(t, group.GenesisTime)
+	err = dt.WaitUntilChainIsServing(t, dt.nodes[0])
+	require.NoError(t, err)
+
+	node := dt.nodes[0]
+	client, err := net.NewControlClient(node.drand.log, node.drand.opts.controlPort)
+	require.NoError(t, err)
+
+	status, err := client.PauseBeacon(beaconID, "investigating", false)
+	require.NoError(t, err)
+	require.True(t, status.GetPaused())
+	require.Equal(t, "investigating", status.GetReason())
+
+	resp, err := client.Status(beaconID)
+	require.NoError(t, err)
+	require.True(t, resp.GetBeacon().GetIsPaused())
+	require.Equal(t, "investigating", resp.GetBeacon().GetPauseReason())
+
+	signed, _ := node.drand.beacon.ShareUsage()
+	for i := 0; i < 3; i++ {
+		dt.AdvanceMockClock(t, group.Period)
+		err := dt.WaitUntilRound(t, node, uint64(i+2))
+		require.NoError(t, err)
+	}
+	pausedSigned, _ := node.drand.beacon.ShareUsage()
+	require.Equal(t, signed, pausedSigned)
+
+	status, err = client.ResumeBeacon(beaconID)
+	require.NoError(t, err)
+	require.False(t, status.GetPaused())
+	dt.AdvanceMockClock(t, group.Period)
+	err = dt.WaitUntilRound(t, node, 5)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		resumedSigned, _ := node.drand.beacon.ShareUsage()
+		return resumedSigned > signed
+	}, 5*time.Second, 100*time.Millisecond)
+
+	var kinds []string
+	for _, e := range node.drand.events.recent() {
+		kinds = append(kinds, e.GetKind())
+	}
+	require.Contains(t, kinds, eventBeaconPaused)
+	require.Contains(t, kinds, eventBeaconResumed)
+
+	// with another member down, the group can't reach the threshold without this node
+	other := dt.nodes[1]
+	other.drand.connCache.put(dt.nodes[2].addr, errors.New("connection refused"), 0, other.clock.Now())
+	otherClient, err := net.NewControlClient(other.drand.log, other.drand.opts.controlPort)
+	require.NoError(t, err)
+	_, err = otherClient.PauseBeacon(beaconID, "", false)
+	require.Error(t, err)
+	status, err = otherClient.PauseBeacon(beaconID, "", true)
+	require.NoError(t, err)
+	require.True(t, status.GetPaused())
+}
+
+// This test makes sure the response to an incident pauses the node, saves a snapshot and announces to the group that
+// the node leaves it
+func TestIncident(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping slow test in short mode.")
+	}
+	n, p := 4, 1*time.Second
+	beaconID := test.GetBeaconIDFromEnv()
+
+	dt := NewDrandTestScenario(t, n, key.DefaultThreshold(n), p, beaconID, clockwork.NewFakeClockAt(time.Now()))
+
+	group, err := dt.RunDKG(t)
+	require.NoError(t, err)
+	dt.SetMockClock(t, group.GenesisTime)
+	err = dt.WaitUntilChainIsServing(t, dt.nodes[0])
+	require.NoError(t, err)
+
+	node := dt.nodes[0]
+	client, err := net.NewControlClient(node.drand.log, node.drand.opts.controlPort)
+	require.NoError(t, err)
+
+	resp, err := client.Incident(beaconID, "share found on a public server")
+	require.NoError(t, err)
+	steps := make(map[string]*drand.IncidentStep)
+	for _, step := range resp.GetSteps() {
+		steps[step.GetName()] = step
+	}
+	require.True(t, steps[incidentPause].GetDone(), steps[incidentPause].GetDetail())
+	require.True(t, steps[incidentSnapshot].GetDone(), steps[incidentSnapshot].GetDetail())
+	require.True(t, steps[incidentRotate].GetSkipped())
+	require.True(t, steps[incidentAnnounce].GetDone(), steps[incidentAnnounce].GetDetail())
+
+	require.True(t, resp.GetPause().GetPaused())
+	require.Equal(t, "incident: share found on a public server", resp.GetPause().GetReason())
+	require.FileExists(t, resp.GetSnapshotFile())
+
+	// the other members know the node is leaving
+	other, err := net.NewControlClient(dt.nodes[1].drand.log, dt.nodes[1].drand.opts.controlPort)
+	require.NoError(t, err)
+	status, err := other.Status(beaconID)
+	require.NoError(t, err)
+	require.Len(t, status.GetLeaving(), 1)
+	require.Equal(t, node.addr, status.GetLeaving()[0].GetAddress())
+}
+
+// Check that the build infos of the group are gathered from the reachable nodes even when one of them is down
+func TestGroupBuildInfoWithNodeDown(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping slow test in short mode.")
+	}
+	n, p := 4, 1*time.Second
+	beaconID := test.GetBeaconIDFromEnv()
+
+	dt := NewDrandTestScenario(t, n, key.DefaultThreshold(n), p, beaconID, clockwork.NewFakeClockAt(time.Now()))
+
+	_, err := dt.RunDKG(t)
+	require.NoError(t, err)
+
+	down := dt.nodes[n-1]
+	down.daemon.Stop(context.Background())
+	<-down.daemon.WaitExit()
+
+	resp, err := dt.nodes[0].drand.GroupBuildInfo(context.Background(), &drand.GroupBuildInfoRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetNodes(), n)
+	for _, node := range resp.GetNodes() {
+		if node.GetAddress() == down.addr {
+			require.NotEmpty(t, node.GetError())
+			continue
+		}
+		require.Empty(t, node.GetError())
+		require.False(t, node.GetMismatch())
+		require.Equal(t, resp.GetLocal().GetCommit(), node.GetBuildInfo().GetCommit())
+	}
+}
@@ -0,0 +1,348 @@
+package core
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	gonet "net"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/key"
+	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/chain"
+	"github.com/drand/drand/v2/internal/chain/boltdb"
+	"github.com/drand/drand/v2/internal/fs"
+	"github.com/drand/drand/v2/internal/metrics"
+	"github.com/drand/drand/v2/internal/net"
+)
+
+// DefaultNTPServer is the server the clock of the node is compared to by the self-test
+const DefaultNTPServer = "pool.ntp.org:123"
+
+// maxClockOffset is the largest offset from the NTP server tolerated by the self-test. Beyond it, the partials of
+// the node risk being sent too early or too late for the other nodes.
+const maxClockOffset = time.Second
+
+// SelfTestCheck is the outcome of one of the checks of the self-test
+type SelfTestCheck struct {
+	Name string
+	// BeaconID is empty for the checks of the daemon itself
+	BeaconID string
+	Detail   string
+	Skipped  bool
+	Err      error
+}
+
+// SelfTest checks that everything the daemon needs to produce rounds works before it is started, so that it fails
+// fast with an actionable error instead of degrading mid-round: the listeners can be bound, the clock is in sync with
+// the given NTP server, and for each beacon, or only the given one, the key pair signs and verifies, its scheme is
+// available, the store can be read and written and enough members of the group are reachable.
+// The clock check is skipped if ntpServer is empty.
+func SelfTest(ctx context.Context, conf *Config, beaconID, ntpServer string) ([]*SelfTestCheck, error) {
+	stores, err := beaconKeyStores(conf.Logger(), conf)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the beacons: %w", err)
+	}
+
+	checks := []*SelfTestCheck{
+		selfTestListeners(conf),
+		selfTestClock(ctx, ntpServer),
+	}
+
+	ids := make([]string, 0, len(stores))
+	for id := range stores {
+		if beaconID == "" || common.CompareBeaconIDs(id, beaconID) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		checks = append(checks, selfTestBeacon(ctx, conf, id, stores[id])...)
+	}
+	return checks, nil
+}
+
+// SelfTestError returns an error listing the failed checks, nil if none failed
+func SelfTestError(checks []*SelfTestCheck) error {
+	var errs []error
+	for _, c := range checks {
+		if c.Err == nil {
+			continue
+		}
+		if c.BeaconID != "" {
+			errs = append(errs, fmt.Errorf("%s (beacon %s): %w", c.Name, c.BeaconID, c.Err))
+		} else {
+			errs = append(errs, fmt.Errorf("%s: %w", c.Name, c.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func selfTestListeners(conf *Config) *SelfTestCheck {
+	check := &SelfTestCheck{Name: "listeners"}
+	addrs := []string{conf.PrivateListenAddress(""), conf.PublicListenAddress(""), conf.ControlPort()}
+	var bound []string
+	for _, addr := range addrs {
+		if addr == "" {
+			continue
+		}
+		if err := net.CheckListenable(addr); err != nil {
+			check.Err = fmt.Errorf("unable to listen on %s, is another daemon running or the port taken? %w", addr, err)
+			return check
+		}
+		bound = append(bound, addr)
+	}
+	if conf.PrivateListenAddress("") == "" {
+		check.Err = errors.New("the private listen address is empty, set it with --private-listen")
+		return check
+	}
+	check.Detail = "can listen on " + strings.Join(bound, ", ")
+	return check
+}
+
+func selfTestClock(ctx context.Context, ntpServer string) *SelfTestCheck {
+	check := &SelfTestCheck{Name: "clock"}
+	if ntpServer == "" {
+		check.Skipped, check.Detail = true, "no NTP server to compare to"
+		return check
+	}
+	offset, err := ntpOffset(ctx, ntpServer)
+	if err != nil {
+		// many deployments don't allow NTP queries to the outside, we can't tell whether the clock is off
+		check.Skipped, check.Detail = true, fmt.Sprintf("unable to query %s: %s", ntpServer, err)
+		return check
+	}
+	check.Detail = fmt.Sprintf("offset of %s from %s", offset, ntpServer)
+	if offset > maxClockOffset || offset < -maxClockOffset {
+		check.Err = fmt.Errorf("the clock is off by %s from %s, more than the %s tolerated: check the time "+
+			"synchronization of the host", offset, ntpServer, maxClockOffset)
+	}
+	return check
+}
+
+func selfTestBeacon(ctx context.Context, conf *Config, beaconID string, store key.Store) []*SelfTestCheck {
+	keypair := &SelfTestCheck{Name: "keypair", BeaconID: beaconID}
+	pair, err := store.LoadKeyPair()
+	if err != nil {
+		keypair.Err = fmt.Errorf("unable to load the key pair, generate it with `drand generate-keypair`: %w", err)
+		return []*SelfTestCheck{keypair}
+	}
+	keypair.Err = selfTestKeyPair(pair)
+	keypair.Detail = pair.Public.Address()
+
+	// the group is only there once the node took part in a DKG
+	group, err := store.LoadGroup()
+	if err != nil {
+		group = nil
+	}
+	return []*SelfTestCheck{
+		keypair,
+		selfTestScheme(beaconID, pair, group),
+		selfTestStore(ctx, conf, beaconID),
+		selfTestPeers(ctx, conf, beaconID, pair, group),
+	}
+}
+
+// selfTestKeyPair checks that the key pair signs and verifies, and that its self-signature is valid
+func selfTestKeyPair(pair *key.Pair) error {
+	if err := pair.Public.ValidSignature(); err != nil {
+		return fmt.Errorf("invalid self-signature of the public key, the key pair file might be corrupted: %w", err)
+	}
+	msg := []byte("drand-selftest:" + pair.Public.Address())
+	sig, err := pair.Scheme().AuthScheme.Sign(pair.Key, msg)
+	if err != nil {
+		return fmt.Errorf("unable to sign with the private key: %w", err)
+	}
+	if err := pair.Scheme().AuthScheme.Verify(pair.Public.Key, msg, sig); err != nil {
+		return fmt.Errorf("the private key doesn't match the public key: %w", err)
+	}
+	return nil
+}
+
+func selfTestScheme(beaconID string, pair *key.Pair, group *key.Group) *SelfTestCheck {
+	check := &SelfTestCheck{Name: "scheme", BeaconID: beaconID, Detail: pair.Scheme().Name}
+	if _, err := crypto.SchemeFromName(pair.Scheme().Name); err != nil {
+		check.Err = fmt.Errorf("the scheme of the key pair isn't available in this binary: %w", err)
+		return check
+	}
+	if group == nil {
+		return check
+	}
+	if _, err := crypto.SchemeFromName(group.Scheme.Name); err != nil {
+		check.Err = fmt.Errorf("the scheme of the group isn't available in this binary: %w", err)
+		return check
+	}
+	if group.Scheme.Name != pair.Scheme().Name {
+		check.Err = fmt.Errorf("the key pair uses %s but the group uses %s", pair.Scheme().Name, group.Scheme.Name)
+	}
+	return check
+}
+
+func selfTestStore(ctx context.Context, conf *Config, beaconID string) *SelfTestCheck {
+	check := &SelfTestCheck{Name: "store", BeaconID: beaconID, Detail: string(conf.dbStorageEngine)}
+	switch conf.dbStorageEngine {
+	case chain.BoltDB:
+		check.Err = selfTestBolt(ctx, conf, beaconID)
+	case chain.PostgreSQL:
+		if conf.pgConn == nil {
+			check.Err = errors.New("no connection to PostgreSQL, set it with --pg-dsn")
+		} else if err := conf.pgConn.PingContext(ctx); err != nil {
+			check.Err = fmt.Errorf("unable to reach PostgreSQL: %w", err)
+		}
+	default:
+		check.Skipped = true
+	}
+	return check
+}
+
+// selfTestBolt writes and reads a beacon in a temporary database next to the one of the beacon, so that the
+// filesystem it lives on is the one checked, without touching the chain
+func selfTestBolt(ctx context.Context, conf *Config, beaconID string) error {
+	dbFolder := conf.DBFolder(beaconID)
+	if fs.CreateSecureFolder(dbFolder) == "" {
+		return fmt.Errorf("unable to create the database folder %s", dbFolder)
+	}
+	tmp, err := os.MkdirTemp(dbFolder, "selftest-")
+	if err != nil {
+		return fmt.Errorf("unable to write in the database folder %s: %w", dbFolder, err)
+	}
+	defer os.RemoveAll(tmp)
+	// the bolt store reports its type under the name of the folder above its own, which isn't a beacon here
+	defer metrics.DrandStorageBackend.DeletePartialMatch(prometheus.Labels{"beacon_id": path.Base(tmp)})
+
+	folder := fs.CreateSecureFolder(path.Join(tmp, DefaultDBFolder))
+	store, err := boltdb.NewBoltStore(ctx, conf.logger, folder, conf.boltOpts)
+	if err != nil {
+		return fmt.Errorf("unable to open a database in %s: %w", dbFolder, err)
+	}
+	defer store.Close()
+
+	genesis := chain.GenesisBeacon([]byte("drand-selftest"))
+	if err := store.Put(ctx, genesis); err != nil {
+		return fmt.Errorf("unable to write to a database in %s: %w", dbFolder, err)
+	}
+	b, err := store.Get(ctx, common.Round(genesis.Round))
+	if err != nil {
+		return fmt.Errorf("unable to read from a database in %s: %w", dbFolder, err)
+	}
+	if !b.Equal(genesis) {
+		return fmt.Errorf("the beacon read from a database in %s isn't the one written", dbFolder)
+	}
+	return nil
+}
+
+// selfTestPeers checks that enough members of the group are reachable to reach the threshold
+func selfTestPeers(ctx context.Context, conf *Config, beaconID string, pair *key.Pair, group *key.Group) *SelfTestCheck {
+	check := &SelfTestCheck{Name: "peers", BeaconID: beaconID}
+	if group == nil {
+		check.Skipped, check.Detail = true, "no group yet"
+		return check
+	}
+	outbound, err := conf.OutboundAddresses()
+	if err != nil {
+		check.Err = err
+		return check
+	}
+	client := net.NewGrpcClient(conf.logger, outbound.DialOptions()...)
+	if s, ok := client.(net.Stoppable); ok {
+		defer s.Stop()
+	}
+
+	var lk sync.Mutex
+	var unreachable []string
+	reachable := 0
+	var wg sync.WaitGroup
+	for _, n := range group.Nodes {
+		if n.Address() == pair.Public.Address() {
+			reachable++
+			continue
+		}
+		wg.Add(1)
+		go func(addr string) {
+			defer wg.Done()
+			tctx, cancel := context.WithTimeout(ctx, callMaxTimeout)
+			defer cancel()
+			err := client.Check(tctx, net.CreatePeer(addr))
+
+			lk.Lock()
+			defer lk.Unlock()
+			if err != nil {
+				unreachable = append(unreachable, addr)
+				return
+			}
+			reachable++
+		}(n.Address())
+	}
+	wg.Wait()
+	sort.Strings(unreachable)
+
+	check.Detail = fmt.Sprintf("%d/%d members reachable, threshold %d", reachable, group.Len(), group.Threshold)
+	if len(unreachable) > 0 {
+		check.Detail += ", unreachable: " + strings.Join(unreachable, ", ")
+	}
+	if reachable < group.Threshold {
+		check.Err = fmt.Errorf("only %d members of the group are reachable, below the threshold of %d: check the "+
+			"firewall and the addresses of %s", reachable, group.Threshold, strings.Join(unreachable, ", "))
+	}
+	return check
+}
+
+// ntpEpochOffset is the number of seconds between the NTP epoch, 1900, and the Unix one
+const ntpEpochOffset = 2208988800
+
+// ntpOffset returns the offset of the local clock from the given NTP server, using a single SNTP query
+func ntpOffset(ctx context.Context, server string) (time.Duration, error) {
+	var d gonet.Dialer
+	conn, err := d.DialContext(ctx, "udp", server)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(callMaxTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return 0, err
+	}
+
+	//nolint:mnd // an SNTP packet is 48 bytes long
+	req := make([]byte, 48)
+	// leap indicator 0, version 4, client mode
+	req[0] = 0x23
+	sent := time.Now()
+	if _, err := conn.Write(req); err != nil {
+		return 0, err
+	}
+	resp := make([]byte, 48)
+	n, err := conn.Read(resp)
+	if err != nil {
+		return 0, err
+	}
+	received := time.Now()
+	//nolint:mnd // an SNTP packet is 48 bytes long
+	if n < 48 {
+		return 0, fmt.Errorf("short NTP response of %d bytes", n)
+	}
+
+	serverReceived := ntpTime(resp[32:40])
+	serverSent := ntpTime(resp[40:48])
+	return (serverReceived.Sub(sent) + serverSent.Sub(received)) / 2, nil
+}
+
+// ntpTime decodes an NTP timestamp, in seconds since 1900 and fractions of seconds
+func ntpTime(b []byte) time.Time {
+	secs := binary.BigEndian.Uint32(b[:4])
+	frac := binary.BigEndian.Uint32(b[4:])
+	nanos := (uint64(frac) * uint64(time.Second)) >> 32
+	return time.Unix(int64(secs)-ntpEpochOffset, int64(nanos))
+}
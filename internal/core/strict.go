@@ -0,0 +1,107 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/key"
+	"github.com/drand/drand/v2/crypto"
+)
+
+// StrictCheck runs the checks of the strict mode, which refuses to start the daemon with the weak or deprecated
+// configurations that are otherwise only warned about: a control port listening on TCP rather than on a unix socket,
+// a clock off from the given NTP server or which can't be checked, key files readable by other users, and beacons
+// running a deprecated scheme. The checks share their outcome type with the self-test.
+func StrictCheck(ctx context.Context, conf *Config, ntpServer string) ([]*SelfTestCheck, error) {
+	stores, err := beaconKeyStores(conf.Logger(), conf)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the beacons: %w", err)
+	}
+
+	checks := []*SelfTestCheck{
+		strictControl(conf),
+		strictClock(ctx, ntpServer),
+	}
+
+	ids := make([]string, 0, len(stores))
+	for id := range stores {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		checks = append(checks,
+			strictKeyFiles(conf, id),
+			strictScheme(id, stores[id]),
+		)
+	}
+	return checks, nil
+}
+
+// strictControl refuses a control port reachable over TCP, whose plaintext commands any local user can send
+func strictControl(conf *Config) *SelfTestCheck {
+	check := &SelfTestCheck{Name: "control", Detail: conf.ControlPort()}
+	if !strings.HasPrefix(conf.ControlPort(), "unix://") {
+		check.Err = fmt.Errorf("the control port %s listens on TCP in plaintext, use a unix socket with "+
+			"--control unix:///path/to/socket", conf.ControlPort())
+	}
+	return check
+}
+
+// strictClock refuses a clock off from the NTP server, like the self-test, but also one which can't be checked
+func strictClock(ctx context.Context, ntpServer string) *SelfTestCheck {
+	check := selfTestClock(ctx, ntpServer)
+	if check.Skipped {
+		check.Skipped = false
+		check.Err = fmt.Errorf("unable to check the clock (%s), set a reachable server with --ntp-server", check.Detail)
+	}
+	return check
+}
+
+// strictKeyFiles refuses the private key and share files readable or writable by other users than the one of the
+// daemon, since they are stored unencrypted
+func strictKeyFiles(conf *Config, beaconID string) *SelfTestCheck {
+	check := &SelfTestCheck{Name: "key-files", BeaconID: beaconID}
+	var weak []string
+	for _, file := range key.PrivateFiles(conf.BeaconFolderMB(beaconID), common.BeaconID(beaconID)) {
+		info, err := os.Stat(file)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			check.Err = err
+			return check
+		}
+		if info.Mode().Perm()&0o077 != 0 {
+			weak = append(weak, fmt.Sprintf("%s (%s)", file, info.Mode().Perm()))
+		}
+	}
+	if len(weak) > 0 {
+		check.Err = fmt.Errorf("the unencrypted private material is accessible to other users, restrict it with "+
+			"chmod 600: %s", strings.Join(weak, ", "))
+	}
+	return check
+}
+
+// strictScheme refuses the beacons whose key pair or group uses a deprecated scheme
+func strictScheme(beaconID string, store key.Store) *SelfTestCheck {
+	check := &SelfTestCheck{Name: "scheme", BeaconID: beaconID}
+	pair, err := store.LoadKeyPair()
+	if err != nil {
+		check.Skipped, check.Detail = true, "no key pair yet"
+		return check
+	}
+	scheme := pair.Scheme().Name
+	if group, err := store.LoadGroup(); err == nil {
+		scheme = group.Scheme.Name
+	}
+	check.Detail = scheme
+	if crypto.IsDeprecatedScheme(scheme) {
+		check.Err = fmt.Errorf("the scheme %s is deprecated, move beacon %s to a new network",
+			scheme, common.GetCanonicalBeaconID(beaconID))
+	}
+	return check
+}
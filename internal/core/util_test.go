@@ -21,6 +21,7 @@ import (
 	"github.com/drand/drand/v2/common/key"
 	"github.com/drand/drand/v2/common/testlogger"
 	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/dkg"
 	"github.com/drand/drand/v2/internal/net"
 	"github.com/drand/drand/v2/internal/test"
 	drand "github.com/drand/drand/v2/protobuf/dkg"
@@ -199,7 +200,7 @@ func (d *DrandTestScenario) GetBeacon(ctx context.Context, id string, round int,
 		if node.addr != id {
 			continue
 		}
-		return node.drand.beacon.Store().Get(ctx, uint64(round))
+		return node.drand.beacon.Store().Get(ctx, common.Round(round))
 	}
 	return nil, errors.New("that should not happen")
 }
@@ -359,40 +360,15 @@ func (d *DrandTestScenario) RunDKG(t *testing.T) (*key.Group, error) {
 		return nil, errors.New("cannot run a DKG with 0 nodes in the drand test scenario")
 	}
 
-	joiners := make([]*drand.Participant, len(d.nodes))
-	for i, node := range d.nodes {
-		identity := node.drand.priv.Public
-		pk, err := identity.Key.MarshalBinary()
-		if err != nil {
-			return nil, err
-		}
-		joiners[i] = &drand.Participant{
-			Address:   identity.Addr,
-			Key:       pk,
-			Signature: identity.Signature,
-		}
-	}
-
-	leader := d.nodes[0]
-	followers := d.nodes[1:]
-
-	timeout := 5 * time.Minute
-	err := leader.dkgRunner.StartNetwork(d.thr, int(d.period.Seconds()), d.scheme.Name, timeout, int(d.catchupPeriod.Seconds()), joiners)
-
+	joiners, err := dkg.TestParticipants(identities(d.nodes))
 	if err != nil {
 		return nil, err
 	}
 
-	t.Log("[RunDKG] JoinDKG on followers")
-	for _, follower := range followers {
-		err = follower.dkgRunner.JoinDKG()
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	t.Log("[RunDKG] StartExecution on leader")
-	err = leader.dkgRunner.StartExecution()
+	leader := d.nodes[0]
+	t.Log("[RunDKG] StartNetwork on leader, JoinDKG on followers, StartExecution on leader")
+	err = leader.dkgRunner.LeadFirstDKG(dkgRunners(d.nodes[1:]), d.thr, int(d.period.Seconds()), d.scheme.Name,
+		5*time.Minute, int(d.catchupPeriod.Seconds()), joiners)
 	if err != nil {
 		return nil, err
 	}
@@ -409,29 +385,38 @@ func (d *DrandTestScenario) RunDKG(t *testing.T) (*key.Group, error) {
 	return groupFile, nil
 }
 
+// identities returns the public identities of the nodes
+func identities(nodes []*MockNode) []*key.Identity {
+	ids := make([]*key.Identity, 0, len(nodes))
+	for _, node := range nodes {
+		ids = append(ids, node.drand.priv.Public)
+	}
+	return ids
+}
+
+// dkgRunners returns the DKG runners of the nodes
+func dkgRunners(nodes []*MockNode) []*dkg.TestRunner {
+	runners := make([]*dkg.TestRunner, 0, len(nodes))
+	for _, node := range nodes {
+		runners = append(runners, node.dkgRunner)
+	}
+	return runners
+}
+
 func (d *DrandTestScenario) RunFailingReshare() error {
 	if len(d.nodes) == 0 {
 		return errors.New("cannot run a DKG with 0 nodes in the drand test scenario")
 	}
 
-	remainers := make([]*drand.Participant, len(d.nodes))
-	for i, node := range d.nodes {
-		identity := node.drand.priv.Public
-		pk, err := identity.Key.MarshalBinary()
-		if err != nil {
-			return err
-		}
-		remainers[i] = &drand.Participant{
-			Address:   identity.Addr,
-			Key:       pk,
-			Signature: identity.Signature,
-		}
+	remainers, err := dkg.TestParticipants(identities(d.nodes))
+	if err != nil {
+		return err
 	}
 
 	leader := d.nodes[0]
 	followers := d.nodes[1:]
 
-	err := leader.dkgRunner.StartReshare(d.thr, 1, []*drand.Participant{}, remainers, []*drand.Participant{})
+	err = leader.dkgRunner.StartReshare(d.thr, 1, []*drand.Participant{}, remainers, []*drand.Participant{})
 	if err != nil {
 		return err
 	}
@@ -496,65 +481,19 @@ func (d *DrandTestScenario) RunReshareWithHooks(t *testing.T, remainingNodes, jo
 	// our first node will be the leader
 	leader := remainingNodes[0]
 
-	// map all the remainers to participants
-	remainers := make([]*drand.Participant, len(remainingNodes))
-	for i, node := range remainingNodes {
-		identity := node.drand.priv.Public
-		pk, err := identity.Key.MarshalBinary()
-		if err != nil {
-			return nil, err
-		}
-		remainers[i] = &drand.Participant{
-			Address:   identity.Addr,
-			Key:       pk,
-			Signature: identity.Signature,
-		}
-	}
-
-	// map all the joiners to participants
-	joiners := make([]*drand.Participant, len(joiningNodes))
-	for i, node := range joiningNodes {
-		identity := node.drand.priv.Public
-		pk, err := identity.Key.MarshalBinary()
-		if err != nil {
-			return nil, err
-		}
-
-		joiners[i] = &drand.Participant{
-			Address:   identity.Addr,
-			Key:       pk,
-			Signature: identity.Signature,
-		}
-	}
-
-	err := leader.dkgRunner.StartReshare(threshold, int(d.catchupPeriod.Seconds()), joiners, remainers, []*drand.Participant{})
+	remainers, err := dkg.TestParticipants(identities(remainingNodes))
 	if err != nil {
 		return nil, err
 	}
-
-	// all the remainers except the leader accept
-	for _, remainer := range remainingNodes[1:] {
-		err := remainer.dkgRunner.Accept()
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	// if there are any hooks now (such as errors to trigger) we trigger them
-	if hooks.postAcceptance != nil {
-		hooks.postAcceptance()
-	}
-
-	// all the joiners join
-	for _, joiner := range joiningNodes {
-		err = joiner.dkgRunner.JoinReshare(d.group)
-		if err != nil {
-			return nil, err
-		}
+	joiners, err := dkg.TestParticipants(identities(joiningNodes))
+	if err != nil {
+		return nil, err
 	}
 
-	// the leader kicks off the execution phase
-	err = leader.dkgRunner.StartExecution()
+	// all the remainers except the leader accept, then the hooks after the acceptance (such as errors to trigger) run,
+	// the joiners join, and the leader kicks off the execution phase
+	err = leader.dkgRunner.LeadReshare(dkgRunners(remainingNodes[1:]), dkgRunners(joiningNodes), d.group, threshold,
+		int(d.catchupPeriod.Seconds()), remainers, joiners, hooks.postAcceptance)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,206 @@
+// Package crash recovers the panics of the modules of the daemon, such as the beacon loop, the syncer, the DKG board
+// and the gateways, so that a bug in one of them is contained and reported instead of taking the whole daemon down
+// with a bare stack trace. Each panic recovered is logged, counted in the metrics and written as a crash report to
+// the folder set by the daemon, along with the recent events of the beacon and the context of the module.
+package crash
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/v2/common/log"
+	"github.com/drand/drand/v2/internal/metrics"
+)
+
+// MaxReports is the number of crash reports kept in the folder, the oldest ones are removed past it
+const MaxReports = 50
+
+// reportSuffix ends the names of the crash reports
+const reportSuffix = ".crash.json"
+
+// Report describes a panic recovered in a module of the daemon
+type Report struct {
+	Time     time.Time `json:"time"`
+	Module   string    `json:"module"`
+	BeaconID string    `json:"beacon_id,omitempty"`
+	Panic    string    `json:"panic"`
+	Stack    string    `json:"stack"`
+	// the context of the module when it panicked, e.g. the round it was at
+	Details map[string]string `json:"details,omitempty"`
+	// the recent events of the beacon, oldest first
+	Events []string `json:"events,omitempty"`
+}
+
+// Scope describes the module a panic is recovered in
+type Scope struct {
+	Module   string
+	BeaconID string
+	// Details returns the context of the module to attach to the report. It may be nil.
+	Details func() map[string]string
+}
+
+var state struct {
+	sync.Mutex
+	folder string
+	events map[string]func() []string
+}
+
+// SetFolder sets the folder the crash reports are written to. The reports are only logged if it is empty.
+func SetFolder(folder string) {
+	state.Lock()
+	defer state.Unlock()
+	state.folder = folder
+}
+
+// SetEvents attaches the recent events of the beacon, as returned by the function, to its crash reports. A nil
+// function detaches them.
+func SetEvents(beaconID string, events func() []string) {
+	state.Lock()
+	defer state.Unlock()
+	if events == nil {
+		delete(state.events, beaconID)
+		return
+	}
+	if state.events == nil {
+		state.events = make(map[string]func() []string)
+	}
+	state.events[beaconID] = events
+}
+
+// Recover recovers a panic of the scope, and writes its crash report. It must be deferred directly, and calls
+// onPanic, if not nil, with the panic as an error so that the module can fail gracefully.
+func Recover(l log.Logger, scope Scope, onPanic func(error)) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	err := Handle(l, scope, r)
+	if onPanic != nil {
+		onPanic(err)
+	}
+}
+
+// Handle reports a panic recovered in the scope, and returns it as an error
+func Handle(l log.Logger, scope Scope, r interface{}) error {
+	report := Report{
+		Time:     time.Now().UTC(),
+		Module:   scope.Module,
+		BeaconID: scope.BeaconID,
+		Panic:    fmt.Sprint(r),
+		Stack:    string(debug.Stack()),
+	}
+	if scope.Details != nil {
+		report.Details = safeDetails(scope.Details)
+	}
+
+	state.Lock()
+	folder := state.folder
+	events := state.events[scope.BeaconID]
+	state.Unlock()
+	if events != nil {
+		report.Events = events()
+	}
+
+	metrics.RecoveredPanic(scope.BeaconID, scope.Module)
+	err := fmt.Errorf("%s panicked: %v", scope.Module, r)
+	if l == nil {
+		l = log.DefaultLogger()
+	}
+	if folder == "" {
+		l.Errorw("recovered from a panic", "module", scope.Module, "beacon_id", scope.BeaconID, "panic", report.Panic,
+			"stack", report.Stack)
+		return err
+	}
+	file, werr := write(folder, &report)
+	if werr != nil {
+		l.Errorw("recovered from a panic, unable to write its crash report", "module", scope.Module,
+			"beacon_id", scope.BeaconID, "panic", report.Panic, "stack", report.Stack, "err", werr)
+		return err
+	}
+	l.Errorw("recovered from a panic", "module", scope.Module, "beacon_id", scope.BeaconID, "panic", report.Panic,
+		"report", file)
+	return err
+}
+
+// safeDetails returns the details of the module, without panicking again if the module is in a broken state
+func safeDetails(details func() map[string]string) (d map[string]string) {
+	defer func() {
+		if r := recover(); r != nil {
+			d = map[string]string{"details_error": fmt.Sprint(r)}
+		}
+	}()
+	return details()
+}
+
+func write(folder string, report *Report) (string, error) {
+	if err := os.MkdirAll(folder, 0o700); err != nil {
+		return "", err
+	}
+	buff, err := json.MarshalIndent(report, "", "    ")
+	if err != nil {
+		return "", err
+	}
+	name := report.Time.Format("20060102T150405.000000000Z") + "-" + report.Module + reportSuffix
+	file := filepath.Join(folder, name)
+	if err := os.WriteFile(file, buff, 0o600); err != nil {
+		return "", err
+	}
+	prune(folder)
+	return file, nil
+}
+
+// prune removes the oldest reports past MaxReports
+func prune(folder string) {
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return
+	}
+	var reports []string
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), reportSuffix) {
+			reports = append(reports, e.Name())
+		}
+	}
+	if len(reports) <= MaxReports {
+		return
+	}
+	// the names start with the time of the reports
+	sort.Strings(reports)
+	for _, name := range reports[:len(reports)-MaxReports] {
+		_ = os.Remove(filepath.Join(folder, name))
+	}
+}
+
+// Reports returns the crash reports of the folder, oldest first
+func Reports(folder string) ([]*Report, error) {
+	entries, err := os.ReadDir(folder)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var reports []*Report
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), reportSuffix) {
+			continue
+		}
+		buff, err := os.ReadFile(filepath.Join(folder, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		report := new(Report)
+		if err := json.Unmarshal(buff, report); err != nil {
+			return nil, fmt.Errorf("invalid crash report %s: %w", e.Name(), err)
+		}
+		reports = append(reports, report)
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Time.Before(reports[j].Time) })
+	return reports, nil
+}
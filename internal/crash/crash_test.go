@@ -0,0 +1,92 @@
+package crash
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecover(t *testing.T) {
+	folder := filepath.Join(t.TempDir(), "crashes")
+	SetFolder(folder)
+	defer SetFolder("")
+	SetEvents("default", func() []string { return []string{"beacon_started"} })
+	defer SetEvents("default", nil)
+
+	var recovered error
+	func() {
+		defer Recover(nil, Scope{Module: "beacon", BeaconID: "default", Details: func() map[string]string {
+			return map[string]string{"round": "12"}
+		}}, func(err error) { recovered = err })
+		panic("boom")
+	}()
+	require.Error(t, recovered)
+	require.Contains(t, recovered.Error(), "beacon panicked: boom")
+
+	reports, err := Reports(folder)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	r := reports[0]
+	require.Equal(t, "beacon", r.Module)
+	require.Equal(t, "default", r.BeaconID)
+	require.Equal(t, "boom", r.Panic)
+	require.Contains(t, r.Stack, "TestRecover")
+	require.Equal(t, map[string]string{"round": "12"}, r.Details)
+	require.Equal(t, []string{"beacon_started"}, r.Events)
+
+	// nothing is reported without a panic
+	func() {
+		defer Recover(nil, Scope{Module: "beacon"}, func(error) { t.Fatal("unexpected panic") })
+	}()
+	reports, err = Reports(folder)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+}
+
+func TestHandleBrokenDetails(t *testing.T) {
+	folder := t.TempDir()
+	SetFolder(folder)
+	defer SetFolder("")
+
+	err := Handle(nil, Scope{Module: "sync", Details: func() map[string]string {
+		panic(errors.New("broken"))
+	}}, "boom")
+	require.Error(t, err)
+
+	reports, err := Reports(folder)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	require.Equal(t, map[string]string{"details_error": "broken"}, reports[0].Details)
+}
+
+func TestHandleWithoutFolder(t *testing.T) {
+	SetFolder("")
+	require.Error(t, Handle(nil, Scope{Module: "gateway"}, "boom"))
+}
+
+func TestPrune(t *testing.T) {
+	folder := t.TempDir()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < MaxReports+5; i++ {
+		_, err := write(folder, &Report{Time: start.Add(time.Duration(i) * time.Second), Module: "dkg", Panic: fmt.Sprint(i)})
+		require.NoError(t, err)
+	}
+	// the other files of the folder are left alone
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "notes.txt"), []byte("keep"), 0o600))
+
+	reports, err := Reports(folder)
+	require.NoError(t, err)
+	require.Len(t, reports, MaxReports)
+	require.Equal(t, "5", reports[0].Panic)
+	require.Equal(t, fmt.Sprint(MaxReports+4), reports[len(reports)-1].Panic)
+	require.FileExists(t, filepath.Join(folder, "notes.txt"))
+
+	reports, err = Reports(filepath.Join(folder, "missing"))
+	require.NoError(t, err)
+	require.Empty(t, reports)
+}
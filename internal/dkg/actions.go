@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/drand/drand/v2/internal/crash"
 	"github.com/drand/drand/v2/internal/net"
 	"github.com/drand/drand/v2/internal/util"
 	drand "github.com/drand/drand/v2/protobuf/dkg"
@@ -60,12 +61,16 @@ func (d *Process) gossip(
 
 		// attempt to gossip with other peers
 		go func() {
+			defer wg.Done()
+			defer crash.Recover(d.log, crash.Scope{Module: "dkg", BeaconID: packet.GetMetadata().GetBeaconID()},
+				func(err error) {
+					errChan <- fmt.Errorf("error sending packet to %s: %w", p.Address, err)
+				})
 			err := sendToPeer(d.internalClient, p, packet)
 			if err != nil {
 				d.log.Warnw("tried gossiping a packet but failed", "addr", p.Address, "packet", packetSig[0:8], "err", err)
 				errChan <- fmt.Errorf("error sending packet to %s: %w", p.Address, err)
 			}
-			wg.Done()
 		}()
 	}
 
@@ -80,6 +80,8 @@ func (d *Process) Command(ctx context.Context, command *drand.DKGCommand) (*dran
 		afterState, packetToGossip, err = d.StartExecute(ctx, beaconID, me, currentState, c.Execute)
 	case *drand.DKGCommand_Abort:
 		afterState, packetToGossip, err = d.StartAbort(ctx, beaconID, currentState, c.Abort)
+	case *drand.DKGCommand_Refresh:
+		afterState, packetToGossip, err = d.StartRefresh(ctx, beaconID, me, currentState, c.Refresh)
 	default:
 		return nil, errors.New("unrecognized DKG command")
 	}
@@ -108,7 +110,7 @@ func (d *Process) Command(ctx context.Context, command *drand.DKGCommand) (*dran
 		// if it's a proposal, let's block until it finishes gossiping or a timeout,
 		// because we want to be sure everybody received it
 		// QUESTION: do we _really_ want to fail on errors? we will probably have to abort if that's the case
-		if command.GetInitial() != nil || command.GetResharing() != nil {
+		if command.GetInitial() != nil || command.GetResharing() != nil || command.GetRefresh() != nil {
 			allErrs := make([]error, 0, len(recipients))
 			// for will block on errs until errs is closed
 			for e := range errs {
@@ -135,7 +137,9 @@ func (d *Process) StartNetwork(
 
 	genesisTime := options.GenesisTime
 	if genesisTime == nil {
-		genesisTime = timestamppb.New(time.Now())
+		genesisTime = timestamppb.New(time.Now().Add(d.config.MinGenesisDelay))
+	} else if err := d.checkGenesisDelay(genesisTime.AsTime(), d.config.MinGenesisDelay); err != nil {
+		return nil, nil, err
 	}
 
 	// remap the CLI payload into one useful for applying to the DKG state
@@ -270,6 +274,53 @@ func (d *Process) StartProposal(
 		}
 	}
 
+	return d.proposeReshare(beaconID, me, currentState, options, false)
+}
+
+// StartRefresh proposes to re-randomize the shares of the current group: a reshare among the same members with the same
+// threshold, which keeps the public key of the group and makes the shares stolen before it useless.
+func (d *Process) StartRefresh(
+	ctx context.Context,
+	beaconID string,
+	me *drand.Participant,
+	currentState *DBState,
+	options *drand.RefreshOptions,
+) (*DBState, *drand.GossipPacket, error) {
+	_, span := tracer.NewSpan(ctx, "dkg.StartRefresh")
+	defer span.End()
+
+	if currentState.FinalGroup == nil {
+		return nil, nil, ErrRefreshBeforeFirstEpoch
+	}
+	if err := d.checkRefreshInterval(currentState.FinalGroup); err != nil {
+		return nil, nil, err
+	}
+
+	remaining := make([]*drand.Participant, len(currentState.FinalGroup.Nodes))
+	for i, node := range currentState.FinalGroup.Nodes {
+		p, err := util.PublicKeyAsParticipant(node.Identity)
+		if err != nil {
+			return nil, nil, err
+		}
+		remaining[i] = p
+	}
+
+	return d.proposeReshare(beaconID, me, currentState, &drand.ProposalOptions{
+		Timeout:              options.GetTimeout(),
+		Threshold:            currentState.Threshold,
+		CatchupPeriodSeconds: uint32(currentState.CatchupPeriod.Seconds()),
+		Remaining:            remaining,
+	}, true)
+}
+
+// proposeReshare applies the reshare proposal to the current state, and returns the packet gossiping it
+func (d *Process) proposeReshare(
+	beaconID string,
+	me *drand.Participant,
+	currentState *DBState,
+	options *drand.ProposalOptions,
+	refresh bool,
+) (*DBState, *drand.GossipPacket, error) {
 	terms := drand.ProposalTerms{
 		BeaconID:             beaconID,
 		Threshold:            options.Threshold,
@@ -284,6 +335,7 @@ func (d *Process) StartProposal(
 		Joining:              options.Joining,
 		Remaining:            options.Remaining,
 		Leaving:              options.Leaving,
+		Refresh:              refresh,
 	}
 	nextState, err := currentState.Proposing(me, &terms)
 	if err != nil {
@@ -330,6 +382,37 @@ func (d *Process) StartAbort(
 	}, nil
 }
 
+// checkRefreshInterval refuses to refresh the shares of the given group less than the minimum refresh interval after its
+// transition, so that a member can't make the group run DKGs back to back
+func (d *Process) checkRefreshInterval(group *key.Group) error {
+	if d.config.MinRefreshInterval <= 0 {
+		return nil
+	}
+	transition := time.Unix(group.TransitionTime, 0)
+	if group.TransitionTime == 0 {
+		transition = time.Unix(group.GenesisTime, 0)
+	}
+	if since := time.Since(transition); since < d.config.MinRefreshInterval {
+		return fmt.Errorf("%w: the current group took over %s ago, the minimum refresh interval is %s",
+			ErrRefreshTooSoon, since.Round(time.Second), d.config.MinRefreshInterval)
+	}
+	return nil
+}
+
+// checkGenesisDelay refuses to move a new network forward once its genesis is less than the given delay away, as the
+// nodes wouldn't have the time to run the DKG and start before it. The proposal must leave the minimum genesis delay,
+// the later steps only the time to kick off the DKG, as some of that delay has been spent collecting the acceptances.
+func (d *Process) checkGenesisDelay(genesis time.Time, delay time.Duration) error {
+	if d.config.MinGenesisDelay <= 0 {
+		return nil
+	}
+	if until := time.Until(genesis); until < delay {
+		return fmt.Errorf("%w: the genesis at %s is %s away, less than the %s required - propose a later genesis time",
+			ErrGenesisTooSoon, genesis.UTC().Format(time.RFC3339), until.Round(time.Second), delay)
+	}
+	return nil
+}
+
 func (d *Process) StartExecute(
 	ctx context.Context,
 	beaconID string,
@@ -340,6 +423,12 @@ func (d *Process) StartExecute(
 	_, span := tracer.NewSpan(ctx, "dkg.StartExecute")
 	defer span.End()
 
+	if state.Epoch == 1 {
+		if err := d.checkGenesisDelay(state.GenesisTime, d.config.KickoffGracePeriod); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	nextState, err := state.StartExecuting(me)
 	if err != nil {
 		return nil, nil, err
@@ -407,6 +496,12 @@ func (d *Process) StartAccept(
 	_, span := tracer.NewSpan(ctx, "dkg.StartAccept")
 	defer span.End()
 
+	if state.Epoch == 1 {
+		if err := d.checkGenesisDelay(state.GenesisTime, d.config.KickoffGracePeriod); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	nextState, err := state.Accepted(me)
 	if err != nil {
 		return nil, nil, err
@@ -522,6 +617,67 @@ func (d *Process) DKGStatus(ctx context.Context, request *drand.DKGStatusRequest
 	}, nil
 }
 
+// GroupHistory returns the TOML-encoded group file of every DKG completed for the given beacon, sorted by epoch
+func (d *Process) GroupHistory(ctx context.Context, request *drand.GroupHistoryRequest) (*drand.GroupHistoryResponse, error) {
+	_, span := tracer.NewSpan(ctx, "dkg.GroupHistory")
+	defer span.End()
+
+	history, err := d.store.GetGroupHistory(request.BeaconID)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]*drand.GroupVersion, len(history))
+	for i, v := range history {
+		versions[i] = &drand.GroupVersion{
+			Epoch:     v.Epoch,
+			GroupFile: []byte(v.Group.String()),
+		}
+	}
+
+	return &drand.GroupHistoryResponse{Versions: versions}, nil
+}
+
+// GroupForRound returns the group, and in particular the public key, that was in charge of producing the given round.
+// Verifiers of old rounds on reshared chains can use it to make sure they're using the right key material.
+func (d *Process) GroupForRound(ctx context.Context, request *drand.GroupForRoundRequest) (*drand.GroupForRoundResponse, error) {
+	_, span := tracer.NewSpan(ctx, "dkg.GroupForRound")
+	defer span.End()
+
+	history, err := d.store.GetGroupHistory(request.BeaconID)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := versionForRound(history, request.Round)
+	if err != nil {
+		return nil, err
+	}
+	if version == nil {
+		return nil, fmt.Errorf("no DKG has been completed for beacon %s", request.BeaconID)
+	}
+
+	group := version.Group
+	if group.PublicKey == nil {
+		return nil, fmt.Errorf("the group file of epoch %d has no public key", version.Epoch)
+	}
+	publicKey, err := group.PublicKey.Key().MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &drand.GroupForRoundResponse{
+		Epoch:           version.Epoch,
+		TransitionRound: version.TransitionRound(),
+		PublicKey:       publicKey,
+		SchemeID:        group.Scheme.Name,
+		Threshold:       uint32(group.Threshold),
+		Period:          uint32(group.Period.Seconds()),
+		GenesisSeed:     group.GetGenesisSeed(),
+		GroupFile:       []byte(group.String()),
+	}, nil
+}
+
 // identityForBeacon grabs the key.Pair from a BeaconProcess and marshals it to a drand.Participant
 func (d *Process) identityForBeacon(beaconID string) (*drand.Participant, error) {
 	identity, err := d.beaconIdentifier.KeypairFor(beaconID)
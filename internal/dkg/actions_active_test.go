@@ -6,6 +6,7 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"path"
 	"testing"
 	"time"
 
@@ -132,6 +133,105 @@ func TestInitialDKG(t *testing.T) {
 	}
 }
 
+func TestInitialDKGGenesisTooSoon(t *testing.T) {
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	myKeypair, err := key.NewKeyPair("somebody.com:443", sch)
+	require.NoError(t, err)
+	alice, err := util.PublicKeyAsParticipant(myKeypair.Public)
+	require.NoError(t, err)
+	beaconID := "someBeaconID"
+
+	identityProvider := MockIdentityProvider{}
+	store := MockStore{}
+	client := MockDKGClient{}
+	process := Process{
+		beaconIdentifier: &identityProvider,
+		store:            &store,
+		internalClient:   &client,
+		log:              log.New(nil, log.DebugLevel, true),
+		SeenPackets:      make(map[string]bool),
+		config:           Config{MinGenesisDelay: time.Hour},
+		close:            make(chan struct{}, 1),
+	}
+	store.On("GetCurrent", beaconID).Return(NewFreshState(beaconID), nil)
+	identityProvider.On("KeypairFor", beaconID).Return(myKeypair, nil)
+
+	_, err = process.Command(context.Background(), &drand.DKGCommand{Command: &drand.DKGCommand_Initial{
+		Initial: &drand.FirstProposalOptions{
+			Timeout:              timestamppb.New(time.Now().Add(1 * time.Hour)),
+			Threshold:            1,
+			PeriodSeconds:        10,
+			Scheme:               sch.Name,
+			CatchupPeriodSeconds: 10,
+			GenesisTime:          timestamppb.New(time.Now().Add(time.Minute)),
+			Joining:              []*drand.Participant{alice},
+		},
+	}, Metadata: &drand.CommandMetadata{
+		BeaconID: beaconID,
+	}})
+	require.ErrorIs(t, err, ErrGenesisTooSoon)
+	store.AssertNotCalled(t, "SaveCurrent", beaconID, mock.Anything)
+	client.AssertNumberOfCalls(t, "Packet", 0)
+
+	require.NoError(t, process.checkGenesisDelay(time.Now().Add(2*time.Hour), process.config.MinGenesisDelay))
+
+	// once proposed, the network only needs the time to kick off the DKG before its genesis
+	process.config.KickoffGracePeriod = 5 * time.Second
+	require.NoError(t, process.checkGenesisDelay(time.Now().Add(30*time.Minute), process.config.KickoffGracePeriod))
+	require.ErrorIs(t, process.checkGenesisDelay(time.Now(), process.config.KickoffGracePeriod), ErrGenesisTooSoon)
+
+	process.config.MinGenesisDelay = 0
+	require.NoError(t, process.checkGenesisDelay(time.Now(), process.config.KickoffGracePeriod))
+}
+
+func TestRemoveBeaconRefusedDuringDKG(t *testing.T) {
+	beaconID := "someBeaconID"
+	store := MockStore{}
+	process := Process{
+		store:       &store,
+		log:         log.New(nil, log.DebugLevel, true),
+		Executions:  make(map[string]Broadcast),
+		SeenPackets: make(map[string]bool),
+	}
+
+	executing := NewFreshState(beaconID)
+	executing.State = Executing
+	store.On("GetCurrent", beaconID).Return(executing, nil).Twice()
+	require.Error(t, process.CheckRemoveBeacon(beaconID))
+	require.Error(t, process.RemoveBeacon(beaconID))
+	store.AssertNotCalled(t, "NukeState", beaconID)
+
+	complete := NewFreshState(beaconID)
+	complete.State = Complete
+	store.On("GetCurrent", beaconID).Return(complete, nil).Once()
+	store.On("NukeState", beaconID).Return(nil)
+	require.NoError(t, process.RemoveBeacon(beaconID))
+	store.AssertCalled(t, "NukeState", beaconID)
+}
+
+func TestExportBeacon(t *testing.T) {
+	beaconID := "someBeaconID"
+	store := MockStore{}
+	process := Process{store: &store, log: log.New(nil, log.DebugLevel, true)}
+	folder := t.TempDir()
+
+	complete := NewFreshState(beaconID)
+	complete.State = Complete
+	complete.Epoch = 2
+	store.On("GetCurrent", beaconID).Return(complete, nil)
+	store.On("GetFinished", beaconID).Return(complete, nil)
+	require.NoError(t, process.ExportBeacon(beaconID, folder))
+
+	var exported DBStateTOML
+	_, err := toml.DecodeFile(path.Join(folder, ExportedFinishedFileName), &exported)
+	require.NoError(t, err)
+	state, err := exported.FromTOML()
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), state.Epoch)
+	require.FileExists(t, path.Join(folder, ExportedCurrentFileName))
+}
+
 func TestReshare(t *testing.T) {
 	sch, err := crypto.GetSchemeFromEnv()
 	require.NoError(t, err)
@@ -495,6 +595,14 @@ func (m *MockStore) SaveFinished(beaconID string, state *DBState) error {
 	return args.Error(0)
 }
 
+func (m *MockStore) GetGroupHistory(beaconID string) ([]*GroupVersion, error) {
+	args := m.Called(beaconID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*GroupVersion), args.Error(1)
+}
+
 func (m *MockStore) Close() error {
 	args := m.Called()
 	return args.Error(0)
@@ -505,6 +613,11 @@ func (m *MockStore) MigrateFromGroupfile(beaconID string, group *key.Group, shar
 	return args.Error(0)
 }
 
+func (m *MockStore) NukeState(beaconID string) error {
+	args := m.Called(beaconID)
+	return args.Error(0)
+}
+
 type MockDKGClient struct {
 	mock.Mock
 }
@@ -526,3 +639,18 @@ func (m *MockDKGClient) BroadcastDKG(_ context.Context, _ net.Peer, in *drand.DK
 	args := m.Called(in)
 	return nil, args.Error(0)
 }
+
+func TestRefreshTooSoonIsRefused(t *testing.T) {
+	process := Process{config: Config{MinRefreshInterval: time.Hour}}
+
+	group := &key.Group{GenesisTime: time.Now().Add(-2 * time.Hour).Unix()}
+	require.NoError(t, process.checkRefreshInterval(group))
+	group.TransitionTime = time.Now().Add(-time.Minute).Unix()
+	require.ErrorIs(t, process.checkRefreshInterval(group), ErrRefreshTooSoon)
+
+	_, _, err := process.StartRefresh(context.Background(), "default", nil, &DBState{FinalGroup: group}, &drand.RefreshOptions{})
+	require.ErrorIs(t, err, ErrRefreshTooSoon)
+
+	process.config.MinRefreshInterval = 0
+	require.NoError(t, process.checkRefreshInterval(group))
+}
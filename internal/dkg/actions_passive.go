@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/drand/drand/v2/common/tracer"
+	"github.com/drand/drand/v2/internal/crash"
 	"github.com/drand/drand/v2/internal/metrics"
 
 	"github.com/drand/drand/v2/internal/util"
@@ -63,9 +64,51 @@ func (d *Process) Packet(ctx context.Context, packet *drand.GossipPacket) (*dran
 		}
 	}
 
+	d.continueRefresh(beaconID, packet)
 	return &drand.EmptyDKGResponse{}, nil
 }
 
+// continueRefresh moves a refresh forward without operator action when the node opted in for it, as it changes neither
+// the members nor the threshold of the group: the remainers accept the proposal, and the leader starts the execution
+// once they all accepted it. The refreshes proposed less than the minimum refresh interval after the last one are left
+// to the operator. It must be called with the lock held, the commands run once it is released.
+func (d *Process) continueRefresh(beaconID string, packet *drand.GossipPacket) {
+	if !d.config.AutoRefresh {
+		return
+	}
+	current, err := d.store.GetCurrent(beaconID)
+	if err != nil || !current.Refresh {
+		return
+	}
+	finished, err := d.store.GetFinished(beaconID)
+	if err != nil || finished == nil || finished.FinalGroup == nil {
+		return
+	}
+	if err := d.checkRefreshInterval(finished.FinalGroup); err != nil {
+		d.log.Warnw("not accepting the refresh of the shares automatically", "beaconID", beaconID, "err", err)
+		return
+	}
+
+	var command *drand.DKGCommand
+	switch {
+	case packet.GetProposal() != nil && current.State == Proposed:
+		command = &drand.DKGCommand{Command: &drand.DKGCommand_Accept{Accept: &drand.AcceptOptions{}}}
+	case packet.GetAccept() != nil && current.State == Proposing &&
+		util.ContainsAll(util.Concat(current.Acceptors, []*drand.Participant{current.Leader}), current.Remaining):
+		command = &drand.DKGCommand{Command: &drand.DKGCommand_Execute{Execute: &drand.ExecutionOptions{}}}
+	default:
+		return
+	}
+	command.Metadata = &drand.CommandMetadata{BeaconID: beaconID}
+
+	go func() {
+		defer crash.Recover(d.log, crash.Scope{Module: "dkg", BeaconID: beaconID}, nil)
+		if _, err := d.Command(context.Background(), command); err != nil {
+			d.log.Errorw("unable to continue the refresh of the shares", "beaconID", beaconID, "command", commandType(command), "err", err)
+		}
+	}()
+}
+
 func (d *Process) applyPacketToState(beaconID string, packet *drand.GossipPacket) error {
 	me, err := d.identityForBeacon(beaconID)
 	if err != nil {
@@ -132,6 +175,8 @@ func commandType(command *drand.DKGCommand) string {
 		return "Executing"
 	case *drand.DKGCommand_Abort:
 		return "Aborting"
+	case *drand.DKGCommand_Refresh:
+		return "Refreshing"
 	default:
 		return "UnknownCommand"
 	}
@@ -152,6 +152,11 @@ func messageForSigning(beaconID string, packet *drand.GossipPacket, proposal *dr
 		ret.Write(p.GetSignature())
 	}
 
+	// only refreshes are marked, so that the messages of the other DKGs are the same as before refreshes existed
+	if proposal.GetRefresh() {
+		ret.WriteString("\nRefresh")
+	}
+
 	return ret.Bytes()
 }
 
@@ -171,5 +176,6 @@ func termsFromState(state *DBState) *drand.ProposalTerms {
 		Joining:              state.Joining,
 		Remaining:            state.Remaining,
 		Leaving:              state.Leaving,
+		Refresh:              state.Refresh,
 	}
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/drand/drand/v2/common/log"
 	"github.com/drand/drand/v2/common/tracer"
 	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/crash"
 	"github.com/drand/drand/v2/internal/net"
 	"github.com/drand/drand/v2/internal/util"
 	pdkg "github.com/drand/drand/v2/protobuf/dkg"
@@ -326,6 +327,7 @@ func (d *dispatcher) broadcast(ctx context.Context, p broadcastPacket) {
 func (d *dispatcher) broadcastDirect(ctx context.Context, p broadcastPacket) {
 	ctx, span := tracer.NewSpan(ctx, "d.broadcastDirect")
 	defer span.End()
+	defer crash.Recover(nil, crash.Scope{Module: "dkg-board"}, nil)
 
 	for _, i := range rand.Perm(len(d.senders)) {
 		d.senders[i].sendDirect(ctx, p)
@@ -368,6 +370,12 @@ func (s *sender) sendPacket(ctx context.Context, p broadcastPacket) {
 func (s *sender) run(ctx context.Context) {
 	ctx, span := tracer.NewSpanFromContext(context.Background(), ctx, "s.run")
 	defer span.End()
+	defer crash.Recover(s.l, crash.Scope{Module: "dkg-board", Details: func() map[string]string {
+		return map[string]string{"to": s.to.GetAddress()}
+	}}, func(error) {
+		// the packets queued for the peer are sent by a new worker
+		go s.run(ctx)
+	})
 
 	for newPacket := range s.newCh {
 		s.sendDirect(ctx, newPacket)
@@ -1,9 +1,14 @@
 package dkg
 
 import (
+	"fmt"
+	"os"
+	"path"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/drand/drand/v2/common"
 	"github.com/drand/drand/v2/common/key"
 	"github.com/drand/drand/v2/common/log"
 	"github.com/drand/drand/v2/internal/net"
@@ -40,6 +45,17 @@ type Config struct {
 
 	// whether or not to skip verifying the cryptographic material in the DKG... almost certainly should be false
 	SkipKeyVerification bool
+
+	// the minimum time between the first proposal of a network and its genesis, so that the nodes have the time to run
+	// the DKG and start before it. Zero disables the check.
+	MinGenesisDelay time.Duration
+
+	// whether the refreshes of the shares are accepted, and executed by their leader, without operator action
+	AutoRefresh bool
+
+	// the minimum time between the transition of the current group and a refresh of its shares, the refreshes proposed
+	// sooner being refused. Zero disables the check.
+	MinRefreshInterval time.Duration
 }
 
 type ExecutionOutput struct {
@@ -47,6 +63,42 @@ type ExecutionOutput struct {
 	KeyShare   *key.Share
 }
 
+// GroupVersion is the group file that resulted from the DKG of a given epoch
+type GroupVersion struct {
+	Epoch uint32
+	Group *key.Group
+}
+
+// TransitionRound returns the first round produced by this version of the group
+func (g *GroupVersion) TransitionRound() uint64 {
+	if g.Group.TransitionTime == 0 {
+		// groups from the first epoch (or migrated from v1) might not have any transition time set
+		return common.CurrentRound(g.Group.GenesisTime, g.Group.Period, g.Group.GenesisTime)
+	}
+	return common.CurrentRound(g.Group.TransitionTime, g.Group.Period, g.Group.GenesisTime)
+}
+
+// versionForRound returns the version of the group that was in charge of producing the given round, or nil if
+// the history is empty. The rounds preceding the oldest version recorded are refused, unless it is the group of the
+// first epoch which produced the chain from its genesis: the group in charge of them isn't known.
+func versionForRound(history []*GroupVersion, round uint64) (*GroupVersion, error) {
+	if len(history) == 0 {
+		return nil, nil
+	}
+	active := history[0]
+	if active.Epoch > 1 && round < active.TransitionRound() {
+		return nil, fmt.Errorf("round %d precedes the group history recorded, which starts at round %d with epoch %d",
+			round, active.TransitionRound(), active.Epoch)
+	}
+	for _, v := range history[1:] {
+		if v.TransitionRound() > round {
+			break
+		}
+		active = v
+	}
+	return active, nil
+}
+
 type SharingOutput struct {
 	BeaconID string
 	Old      *DBState
@@ -67,6 +119,9 @@ type Store interface {
 	// SaveFinished stores a completed, successful DKG and overwrites the current packet
 	SaveFinished(beaconID string, state *DBState) error
 
+	// GetGroupHistory returns the group file of every completed DKG, sorted by increasing epoch
+	GetGroupHistory(beaconID string) ([]*GroupVersion, error)
+
 	// Close closes and cleans up any database handles
 	Close() error
 
@@ -74,6 +129,9 @@ type Store interface {
 	// It will fail if DKG state already exists for the given beaconID
 	// Deprecated: will only exist in 2.0.0 for migration from v1.5.* to 2.0.0
 	MigrateFromGroupfile(beaconID string, groupFile *key.Group, share *key.Share) error
+
+	// NukeState deletes the current and finished DKG states of the beacon, and its group history
+	NukeState(beaconID string) error
 }
 
 // BeaconIdentifier is necessary because we need to get our identity on a per-beacon basis from the `DrandDaemon`
@@ -132,3 +190,68 @@ func (d *Process) Migrate(beaconID string, groupfile *key.Group, share *key.Shar
 	d.log.Debugw("Completed migration from group file")
 	return nil
 }
+
+// DKG state files ExportBeacon writes
+const (
+	ExportedCurrentFileName  = "dkg_current.toml"
+	ExportedFinishedFileName = "dkg_finished.toml"
+)
+
+// CheckRemoveBeacon returns why the DKG state of a beacon can't be removed, nil if it can
+func (d *Process) CheckRemoveBeacon(beaconID string) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.checkRemoveBeacon(beaconID)
+}
+
+func (d *Process) checkRemoveBeacon(beaconID string) error {
+	current, err := d.store.GetCurrent(beaconID)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(inProgressStates, current.State) {
+		return fmt.Errorf("a DKG of beacon %s is in progress, in state %s: abort it first", beaconID, current.State)
+	}
+	return nil
+}
+
+// ExportBeacon writes the current and the last finished DKG states of a beacon to the given folder, e.g. to archive
+// them along with the beacon before it is removed. They hold the share of the node.
+func (d *Process) ExportBeacon(beaconID, folder string) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	current, err := d.store.GetCurrent(beaconID)
+	if err != nil {
+		return err
+	}
+	if err := exportState(path.Join(folder, ExportedCurrentFileName), current); err != nil {
+		return err
+	}
+	finished, err := d.store.GetFinished(beaconID)
+	if err != nil || finished == nil {
+		return err
+	}
+	return exportState(path.Join(folder, ExportedFinishedFileName), finished)
+}
+
+func exportState(file string, state *DBState) error {
+	b, err := encodeState(state)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, b, 0o600)
+}
+
+// RemoveBeacon deletes the DKG state of a beacon removed from the node. It is refused while a DKG of the beacon is in
+// progress, which has to be aborted first.
+func (d *Process) RemoveBeacon(beaconID string) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if err := d.checkRemoveBeacon(beaconID); err != nil {
+		return err
+	}
+	delete(d.Executions, beaconID)
+	return d.store.NukeState(beaconID)
+}
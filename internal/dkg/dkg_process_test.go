@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/drand/drand/v2/common"
 	"github.com/drand/drand/v2/common/key"
 	"github.com/drand/drand/v2/common/log"
 	"github.com/drand/drand/v2/crypto"
@@ -526,6 +527,26 @@ func (p *stubbedDKGProcess) DKGStatus(
 	return p.delegate.DKGStatus(ctx, request)
 }
 
+func (p *stubbedDKGProcess) GroupHistory(
+	ctx context.Context,
+	request *dkg.GroupHistoryRequest,
+	_ ...grpc.CallOption,
+) (*dkg.GroupHistoryResponse, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.delegate.GroupHistory(ctx, request)
+}
+
+func (p *stubbedDKGProcess) GroupForRound(
+	ctx context.Context,
+	request *dkg.GroupForRoundRequest,
+	_ ...grpc.CallOption,
+) (*dkg.GroupForRoundResponse, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.delegate.GroupForRound(ctx, request)
+}
+
 func (p *stubbedDKGProcess) Command(ctx context.Context, command *dkg.DKGCommand, _ ...grpc.CallOption) (*dkg.EmptyDKGResponse, error) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
@@ -563,3 +584,40 @@ func (p *stubbedDKGProcess) BroadcastDKG(
 func (p *stubbedDKGProcess) Close() {
 	// no-op
 }
+
+func TestVersionForRound(t *testing.T) {
+	genesis := int64(1000)
+	period := 10 * time.Second
+	versionAt := func(epoch uint32, transitionRound uint64) *GroupVersion {
+		return &GroupVersion{
+			Epoch: epoch,
+			Group: &key.Group{
+				Period:         period,
+				GenesisTime:    genesis,
+				TransitionTime: common.TimeOfRound(period, genesis, common.Round(transitionRound)),
+			},
+		}
+	}
+	history := []*GroupVersion{versionAt(1, 1), versionAt(2, 50), versionAt(4, 120)}
+	// the first epoch may not have any transition time set
+	history[0].Group.TransitionTime = 0
+
+	none, err := versionForRound(nil, 10)
+	require.NoError(t, err)
+	require.Nil(t, none)
+	require.Equal(t, uint64(1), history[0].TransitionRound())
+	require.Equal(t, uint64(50), history[1].TransitionRound())
+
+	for round, epoch := range map[uint64]uint32{0: 1, 1: 1, 49: 1, 50: 2, 119: 2, 120: 4, 10000: 4} {
+		version, err := versionForRound(history, round)
+		require.NoError(t, err)
+		require.Equal(t, epoch, version.Epoch, "round %d", round)
+	}
+
+	// the group in charge of the rounds before the history recorded isn't known
+	_, err = versionForRound(history[1:], 49)
+	require.ErrorContains(t, err, "precedes the group history")
+	version, err := versionForRound(history[1:], 50)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), version.Epoch)
+}
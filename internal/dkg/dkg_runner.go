@@ -75,6 +75,18 @@ func (r *TestRunner) StartReshare(
 	return err
 }
 
+func (r *TestRunner) StartRefresh() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err := r.Client.Command(ctx, &drand.DKGCommand{Command: &drand.DKGCommand_Refresh{
+		Refresh: &drand.RefreshOptions{
+			Timeout: timestamppb.New(r.Clock.Now().Add(1 * time.Minute)),
+		}},
+		Metadata: &drand.CommandMetadata{BeaconID: r.BeaconID},
+	})
+	return err
+}
+
 func (r *TestRunner) StartExecution() error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -137,6 +149,77 @@ func (r *TestRunner) Abort() error {
 	return err
 }
 
+// TestParticipants returns the identities as the participants of a DKG
+func TestParticipants(ids []*key.Identity) ([]*drand.Participant, error) {
+	ps := make([]*drand.Participant, 0, len(ids))
+	for _, id := range ids {
+		pk, err := id.Key.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, &drand.Participant{
+			Address:   id.Addr,
+			Key:       pk,
+			Signature: id.Signature,
+		})
+	}
+	return ps, nil
+}
+
+// LeadFirstDKG proposes the first DKG of a network to the joiners, has the followers join it and starts its
+// execution. The nodes then run it once the kickoff grace period is over.
+func (r *TestRunner) LeadFirstDKG(
+	followers []*TestRunner,
+	threshold int,
+	period int,
+	schemeID string,
+	timeout time.Duration,
+	catchupPeriod int,
+	joiners []*drand.Participant,
+) error {
+	if err := r.StartNetwork(threshold, period, schemeID, timeout, catchupPeriod, joiners); err != nil {
+		return err
+	}
+	for _, follower := range followers {
+		if err := follower.JoinDKG(); err != nil {
+			return err
+		}
+	}
+	return r.StartExecution()
+}
+
+// LeadReshare proposes to reshare the old group, has the other remainers accept it, calls postAcceptance if it isn't
+// nil, then has the joiners join and starts its execution. The nodes then run it once the kickoff grace period is
+// over.
+func (r *TestRunner) LeadReshare(
+	remainers []*TestRunner,
+	joiners []*TestRunner,
+	oldGroup *key.Group,
+	threshold int,
+	catchupPeriod int,
+	remaining []*drand.Participant,
+	joining []*drand.Participant,
+	postAcceptance func(),
+) error {
+	if err := r.StartReshare(threshold, catchupPeriod, joining, remaining, []*drand.Participant{}); err != nil {
+		return err
+	}
+	for _, remainer := range remainers {
+		if err := remainer.Accept(); err != nil {
+			return err
+		}
+	}
+	if postAcceptance != nil {
+		postAcceptance()
+	}
+	for _, joiner := range joiners {
+		if err := joiner.JoinReshare(oldGroup); err != nil {
+			return err
+		}
+	}
+	return r.StartExecution()
+}
+
 var ErrTimeout = errors.New("DKG timed out")
 var ErrDKGFailed = errors.New("DKG failed")
 var ErrDKGAborted = errors.New("DKG aborted")
@@ -12,6 +12,7 @@ import (
 	"github.com/drand/drand/v2/common/key"
 	"github.com/drand/drand/v2/common/tracer"
 	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/crash"
 	"github.com/drand/drand/v2/internal/metrics"
 	"github.com/drand/drand/v2/internal/util"
 	drand "github.com/drand/drand/v2/protobuf/dkg"
@@ -30,6 +31,7 @@ func (d *Process) executeDKG(ctx context.Context, beaconID string, executionStar
 	d.log.Infow("DKG execution setup successful", "beaconID", beaconID)
 
 	go func(config *dkg.Config) {
+		defer crash.Recover(d.log, crash.Scope{Module: "dkg", BeaconID: beaconID}, nil)
 		// wait until the time set by the leader for kicking off the DKG to allow other nodes to get
 		// the requisite packets
 		select {
@@ -209,7 +211,8 @@ func (d *Process) startDKGExecution(
 		} else {
 			roundsUntilTransition := 10
 			currentRound := common.CurrentRound(time.Now().Unix(), current.BeaconPeriod, current.GenesisTime.Unix())
-			transitionTime = common.TimeOfRound(current.BeaconPeriod, current.GenesisTime.Unix(), currentRound+uint64(roundsUntilTransition))
+			transitionRound := common.Round(currentRound + uint64(roundsUntilTransition))
+			transitionTime = transitionRound.Time(current.BeaconPeriod, current.GenesisTime.Unix())
 		}
 		keypair, err := d.beaconIdentifier.KeypairFor(beaconID)
 		if err != nil {
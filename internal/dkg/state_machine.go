@@ -61,6 +61,16 @@ const (
 	Failed
 )
 
+// inProgressStates are the states of a DKG which isn't finished yet
+var inProgressStates = []Status{
+	Proposed,
+	Proposing,
+	Accepted,
+	Rejected,
+	Executing,
+	Joined,
+}
+
 var terminalStates = []Status{
 	Aborted,
 	TimedOut,
@@ -115,6 +125,8 @@ type DBState struct {
 	Remaining []*drand.Participant
 	Joining   []*drand.Participant
 	Leaving   []*drand.Participant
+	// Refresh is set when the DKG only re-randomizes the shares of the group
+	Refresh bool
 
 	Acceptors []*drand.Participant
 	Rejectors []*drand.Participant
@@ -149,6 +161,7 @@ func (d *DBState) Equals(e *DBState) bool {
 		reflect.DeepEqual(d.Remaining, e.Remaining) &&
 		reflect.DeepEqual(d.Joining, e.Joining) &&
 		reflect.DeepEqual(d.Leaving, e.Leaving) &&
+		d.Refresh == e.Refresh &&
 		reflect.DeepEqual(d.Acceptors, e.Acceptors) &&
 		reflect.DeepEqual(d.Rejectors, e.Rejectors) &&
 		d.FinalGroup.Equal(e.FinalGroup) &&
@@ -175,6 +188,7 @@ type DBStateTOML struct {
 	Remaining []*drand.Participant
 	Joining   []*drand.Participant
 	Leaving   []*drand.Participant
+	Refresh   bool
 
 	Acceptors []*drand.Participant
 	Rejectors []*drand.Participant
@@ -208,6 +222,7 @@ func (d *DBState) TOML() DBStateTOML {
 		Remaining:     d.Remaining,
 		Joining:       d.Joining,
 		Leaving:       d.Leaving,
+		Refresh:       d.Refresh,
 		Acceptors:     d.Acceptors,
 		Rejectors:     d.Rejectors,
 		FinalGroup:    finalGroup,
@@ -254,6 +269,7 @@ func (d *DBStateTOML) FromTOML() (*DBState, error) {
 		Remaining:     d.Remaining,
 		Joining:       d.Joining,
 		Leaving:       d.Leaving,
+		Refresh:       d.Refresh,
 		Acceptors:     d.Acceptors,
 		Rejectors:     d.Rejectors,
 		FinalGroup:    finalGroup,
@@ -343,6 +359,7 @@ func (d *DBState) Proposing(me *drand.Participant, terms *drand.ProposalTerms) (
 		Remaining:     util.Filter(terms.Remaining, util.NonEmpty),
 		Joining:       util.Filter(terms.Joining, util.NonEmpty),
 		Leaving:       util.Filter(terms.Leaving, util.NonEmpty),
+		Refresh:       terms.Refresh,
 	}, nil
 }
 
@@ -383,6 +400,7 @@ func (d *DBState) Proposed(me *drand.Participant, terms *drand.ProposalTerms, me
 		Remaining:     util.Filter(terms.Remaining, util.NonEmpty),
 		Joining:       util.Filter(terms.Joining, util.NonEmpty),
 		Leaving:       util.Filter(terms.Leaving, util.NonEmpty),
+		Refresh:       terms.Refresh,
 	}, nil
 }
 
@@ -663,6 +681,12 @@ var ErrFinalGroupCannotBeEmpty = errors.New("you cannot complete a DKG with a ni
 var ErrKeyShareCannotBeEmpty = errors.New("you cannot complete a DKG with a nil key share")
 var ErrReceivedAcceptance = errors.New("received acceptance but not during proposal phase")
 var ErrReceivedRejection = errors.New("received rejection but not during proposal phase")
+var ErrRefreshBeforeFirstEpoch = errors.New("there are no shares to refresh before the first epoch")
+var ErrRefreshCannotChangeMembers = errors.New("a refresh cannot have joiners or leavers - run a reshare instead")
+var ErrRefreshCannotChangeThreshold = errors.New("a refresh cannot change the threshold - run a reshare instead")
+var ErrRefreshTooSoon = errors.New("the shares were refreshed too recently")
+
+var ErrGenesisTooSoon = errors.New("the genesis is too soon for the nodes to run the DKG and start before it")
 
 // isValidStateChange details all the viable state changes
 //
@@ -710,6 +734,12 @@ func ValidateProposal(currentState *DBState, terms *drand.ProposalTerms) error {
 		return err
 	}
 
+	if terms.Refresh {
+		if err := validateRefreshTerms(currentState, terms); err != nil {
+			return err
+		}
+	}
+
 	// some terms (such as genesis seed) get set during the first epoch
 	// additionally, we can't have remainers, `GenesisTime` == `TransitionTime`, amongst other things
 	if terms.Epoch == 1 {
@@ -744,6 +774,10 @@ func validateForAllDKGs(currentState *DBState, terms *drand.ProposalTerms) error
 		return ErrInvalidScheme
 	}
 
+	if err := validateParticipantAddresses(terms); err != nil {
+		return err
+	}
+
 	err = validateJoinerSignatures(terms, sch)
 	if err != nil {
 		return err
@@ -765,6 +799,18 @@ func validateForAllDKGs(currentState *DBState, terms *drand.ProposalTerms) error
 	return validateEpoch(currentState, terms)
 }
 
+// validateParticipantAddresses checks the addresses of all the participants before any of them is dialed
+func validateParticipantAddresses(terms *drand.ProposalTerms) error {
+	for _, participants := range [][]*drand.Participant{terms.Joining, terms.Remaining, terms.Leaving} {
+		for _, p := range participants {
+			if err := key.ValidateAddress(p.GetAddress()); err != nil {
+				return fmt.Errorf("proposal participant: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
 func validateJoinerSignatures(terms *drand.ProposalTerms, targetSch *crypto.Scheme) error {
 	for _, participant := range terms.Joining {
 		id, err := key.IdentityFromProto(participant, targetSch)
@@ -838,6 +884,24 @@ func validateReshareTerms(currentState *DBState, terms *drand.ProposalTerms) err
 	return nil
 }
 
+// validateRefreshTerms checks that a refresh keeps the members and the threshold of the group, which makes it safe to
+// accept automatically. That the remainers are exactly the members of the group is checked for all reshares.
+func validateRefreshTerms(currentState *DBState, terms *drand.ProposalTerms) error {
+	if terms.Epoch == 1 || currentState.State == Fresh {
+		return ErrRefreshBeforeFirstEpoch
+	}
+
+	if len(terms.Joining) != 0 || len(terms.Leaving) != 0 {
+		return ErrRefreshCannotChangeMembers
+	}
+
+	if terms.Threshold != currentState.Threshold {
+		return ErrRefreshCannotChangeThreshold
+	}
+
+	return nil
+}
+
 func validateReshareForRemainers(currentState *DBState, terms *drand.ProposalTerms) error {
 	if !terms.GenesisTime.AsTime().Equal(currentState.GenesisTime) {
 		return ErrGenesisTimeNotEqual
@@ -347,6 +347,48 @@ func TestProposalValidation(t *testing.T) {
 			}(),
 			expected: ErrGenesisSeedCannotChange,
 		},
+		{
+			name:  "a refresh keeping the members and threshold returns no error",
+			state: current,
+			terms: func() *drand.ProposalTerms {
+				proposal := NewValidProposal(beaconID, 2, alice, bob)
+				proposal.Refresh = true
+				return proposal
+			}(),
+			expected: nil,
+		},
+		{
+			name:  "a refresh with joiners returns an error",
+			state: current,
+			terms: func() *drand.ProposalTerms {
+				proposal := NewValidProposal(beaconID, 2, alice, bob)
+				proposal.Joining = []*drand.Participant{carol}
+				proposal.Refresh = true
+				return proposal
+			}(),
+			expected: ErrRefreshCannotChangeMembers,
+		},
+		{
+			name:  "a refresh changing the threshold returns an error",
+			state: NewCompleteDKGEntry(t, beaconID, Complete, alice, bob, carol),
+			terms: func() *drand.ProposalTerms {
+				proposal := NewValidProposal(beaconID, 2, alice, bob, carol)
+				proposal.Threshold = 3
+				proposal.Refresh = true
+				return proposal
+			}(),
+			expected: ErrRefreshCannotChangeThreshold,
+		},
+		{
+			name:  "a refresh for the first epoch returns an error",
+			state: NewFreshState(beaconID),
+			terms: func() *drand.ProposalTerms {
+				proposal := NewInitialProposal(beaconID, alice, bob)
+				proposal.Refresh = true
+				return proposal
+			}(),
+			expected: ErrRefreshBeforeFirstEpoch,
+		},
 		{
 			name:  "for fresh joining after first epoch, genesis seed must be provided but can be anything",
 			state: NewFreshState(beaconID),
@@ -2,6 +2,7 @@ package dkg
 
 import (
 	bytes2 "bytes"
+	"encoding/binary"
 	"os"
 	"path"
 	"sync"
@@ -30,6 +31,9 @@ const DirPerm = 0755
 var stagedStateBucket = []byte("dkg")
 var finishedStateBucket = []byte("dkg_finished")
 
+// groupHistoryBucket holds a nested bucket per beaconID, mapping each completed epoch to its group file
+var groupHistoryBucket = []byte("dkg_group_history")
+
 func NewDKGStore(baseFolder string, options *bolt.Options) (*BoltStore, error) {
 	err := os.MkdirAll(baseFolder, DirPerm)
 	if err != nil {
@@ -48,6 +52,11 @@ func NewDKGStore(baseFolder string, options *bolt.Options) (*BoltStore, error) {
 		}
 
 		_, err = tx.CreateBucketIfNotExists(finishedStateBucket)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.CreateBucketIfNotExists(groupHistoryBucket)
 		return err
 	})
 	if err != nil {
@@ -135,9 +144,100 @@ func (s *BoltStore) SaveFinished(beaconID string, state *DBState) error {
 		if err != nil {
 			return err
 		}
-		return currentBucket.Put(bytesID, b)
+		err = currentBucket.Put(bytesID, b)
+		if err != nil {
+			return err
+		}
+
+		if state.State != Complete || state.FinalGroup == nil {
+			return nil
+		}
+		return putGroupVersion(tx, beaconID, state.Epoch, state.FinalGroup)
 	})
 }
+
+func putGroupVersion(tx *bolt.Tx, beaconID string, epoch uint32, group *key.Group) error {
+	historyBucket := tx.Bucket(groupHistoryBucket)
+	if historyBucket == nil {
+		return errors.Errorf("%s bucket was nil - this should never happen", groupHistoryBucket)
+	}
+	beaconBucket, err := historyBucket.CreateBucketIfNotExists([]byte(beaconID))
+	if err != nil {
+		return err
+	}
+
+	var buf bytes2.Buffer
+	if err := toml.NewEncoder(&buf).Encode(group.TOML()); err != nil {
+		return err
+	}
+	return beaconBucket.Put(epochKey(epoch), buf.Bytes())
+}
+
+// epochKey encodes epochs in big endian so that bolt iterates over them in increasing order
+func epochKey(epoch uint32) []byte {
+	k := make([]byte, 4)
+	binary.BigEndian.PutUint32(k, epoch)
+	return k
+}
+
+// GetGroupHistory returns the group files of every DKG completed for the given beaconID, sorted by epoch.
+// Nodes that completed DKGs before the history was recorded will only see their latest group file.
+func (s *BoltStore) GetGroupHistory(beaconID string) ([]*GroupVersion, error) {
+	var versions []*GroupVersion
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		historyBucket := tx.Bucket(groupHistoryBucket)
+		if historyBucket == nil {
+			return errors.Errorf("%s bucket was nil - this should never happen", groupHistoryBucket)
+		}
+		beaconBucket := historyBucket.Bucket([]byte(beaconID))
+		if beaconBucket == nil {
+			return nil
+		}
+
+		return beaconBucket.ForEach(func(k, v []byte) error {
+			group, err := decodeGroup(v)
+			if err != nil {
+				return err
+			}
+			versions = append(versions, &GroupVersion{
+				Epoch: binary.BigEndian.Uint32(k),
+				Group: group,
+			})
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	finished, err := s.GetFinished(beaconID)
+	if err != nil {
+		return nil, err
+	}
+	if finished == nil || finished.State != Complete || finished.FinalGroup == nil {
+		return versions, nil
+	}
+	if len(versions) == 0 || versions[len(versions)-1].Epoch < finished.Epoch {
+		versions = append(versions, &GroupVersion{Epoch: finished.Epoch, Group: finished.FinalGroup})
+	}
+
+	return versions, nil
+}
+
+func decodeGroup(b []byte) (*key.Group, error) {
+	t := key.GroupTOML{}
+	_, err := toml.NewDecoder(bytes2.NewReader(b)).Decode(&t)
+	if err != nil {
+		return nil, err
+	}
+	group := new(key.Group)
+	if err := group.FromTOML(&t); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
 func encodeState(state *DBState) ([]byte, error) {
 	var bytes []byte
 	b := bytes2.NewBuffer(bytes)
@@ -252,6 +352,15 @@ func (s *BoltStore) NukeState(beaconID string) error {
 			return err
 		}
 
-		return tx.Bucket(finishedStateBucket).Delete([]byte(beaconID))
+		err = tx.Bucket(finishedStateBucket).Delete([]byte(beaconID))
+		if err != nil {
+			return err
+		}
+
+		err = tx.Bucket(groupHistoryBucket).DeleteBucket([]byte(beaconID))
+		if errors.Is(err, bolt.ErrBucketNotFound) {
+			return nil
+		}
+		return err
 	})
 }
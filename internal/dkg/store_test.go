@@ -18,7 +18,7 @@ func TestStoredDKGCanBeRetrieved(t *testing.T) {
 		beaconID,
 		Executing,
 		NewParticipant("somebody"),
-		NewParticipant("somebody else"),
+		NewParticipant("somebody-else"),
 	)
 
 	// store the DKG details
@@ -56,7 +56,7 @@ func TestFetchingWrongBeaconIDReturnsFresh(t *testing.T) {
 		beaconID,
 		Executing,
 		NewParticipant("somebody"),
-		NewParticipant("somebody else"),
+		NewParticipant("somebody-else"),
 	)
 
 	// store the DKG details under one beaconId
@@ -96,7 +96,7 @@ func TestGetReturnsLatestCompletedIfNoneInProgress(t *testing.T) {
 		beaconID,
 		Complete,
 		NewParticipant("somebody"),
-		NewParticipant("somebody else"),
+		NewParticipant("somebody-else"),
 	)
 
 	// store the finished DKG details
@@ -126,7 +126,7 @@ func TestDeletingStateDeletesCurrentAndFinished(t *testing.T) {
 		beaconID,
 		Complete,
 		NewParticipant("somebody"),
-		NewParticipant("somebody else"),
+		NewParticipant("somebody-else"),
 	)
 
 	// store the DKG details
@@ -146,3 +146,44 @@ func TestDeletingStateDeletesCurrentAndFinished(t *testing.T) {
 	require.NoError(t, err)
 	require.Nil(t, finished)
 }
+
+func TestGroupHistoryKeepsEveryCompletedEpoch(t *testing.T) {
+	store, err := NewDKGStore(t.TempDir(), nil)
+	require.NoError(t, err)
+
+	beaconID := "myBeaconId"
+	first := NewCompleteDKGEntry(t, beaconID, Complete, NewParticipant("somebody"), NewParticipant("somebody-else"))
+	err = store.SaveFinished(beaconID, first)
+	require.NoError(t, err)
+
+	// a failed DKG doesn't create a new group file
+	failed := NewCompleteDKGEntry(t, beaconID, Failed, NewParticipant("somebody"))
+	failed.Epoch = 2
+	err = store.SaveFinished(beaconID, failed)
+	require.NoError(t, err)
+
+	second := NewCompleteDKGEntry(t, beaconID, Complete, NewParticipant("somebody"), NewParticipant("a-third-one"), NewParticipant("a-fourth-one"))
+	second.Epoch = 3
+	second.FinalGroup.Threshold = 3
+	err = store.SaveFinished(beaconID, second)
+	require.NoError(t, err)
+
+	history, err := store.GetGroupHistory(beaconID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, uint32(1), history[0].Epoch)
+	require.True(t, first.FinalGroup.Equal(history[0].Group))
+	require.Equal(t, uint32(3), history[1].Epoch)
+	require.True(t, second.FinalGroup.Equal(history[1].Group))
+
+	// other beacons have their own history
+	other, err := store.GetGroupHistory("another-beacon-id")
+	require.NoError(t, err)
+	require.Empty(t, other)
+
+	err = store.NukeState(beaconID)
+	require.NoError(t, err)
+	history, err = store.GetGroupHistory(beaconID)
+	require.NoError(t, err)
+	require.Empty(t, history)
+}
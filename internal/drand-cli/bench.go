@@ -0,0 +1,174 @@
+package drand
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"runtime"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/key"
+	"github.com/drand/drand/v2/common/log"
+	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/chain"
+	"github.com/drand/drand/v2/internal/core"
+)
+
+func benchVerifyCmd(c *cli.Context, l log.Logger) error {
+	schemes := crypto.ListSchemes()
+	if c.IsSet(schemeFlag.Name) {
+		schemes = []string{c.String(schemeFlag.Name)}
+	}
+	maxParallelism := runtime.NumCPU()
+	if c.IsSet(benchParallelismFlag.Name) {
+		maxParallelism = c.Int(benchParallelismFlag.Name)
+	}
+	if maxParallelism < 1 {
+		return fmt.Errorf("the parallelism must be at least 1, got %d", maxParallelism)
+	}
+	batchSizes := []int{1}
+	if batchSize := c.Int(benchBatchSizeFlag.Name); batchSize > 1 {
+		batchSizes = append(batchSizes, batchSize)
+	} else if batchSize < 1 {
+		return fmt.Errorf("the batch size must be at least 1, got %d", batchSize)
+	}
+
+	var results []*crypto.VerifyThroughput
+	if !c.Bool(jsonFlag.Name) {
+		fmt.Fprintf(c.App.Writer, "%-36s %-8s %-12s %-12s %-12s %s\n", "scheme", "batch", "parallelism", "beacons/s",
+			"per beacon", "speedup")
+	}
+	for _, id := range schemes {
+		sch, err := crypto.SchemeFromName(id)
+		if err != nil {
+			return err
+		}
+		l.Debugw("Signing the beacons to verify", "scheme", id, "beacons", c.Int(benchBeaconsFlag.Name))
+		bench, err := crypto.NewVerifyBench(sch, c.Int(benchBeaconsFlag.Name))
+		if err != nil {
+			return err
+		}
+
+		// the speedups are relative to verifying the beacons one at a time in a single goroutine
+		var single float64
+		for _, batchSize := range batchSizes {
+			for parallelism := 1; ; parallelism *= 2 {
+				if parallelism > maxParallelism {
+					parallelism = maxParallelism
+				}
+				result, err := bench.RunBatched(parallelism, batchSize)
+				if err != nil {
+					return fmt.Errorf("drand: can't verify the beacons of scheme %s: %w", id, err)
+				}
+				results = append(results, result)
+				if parallelism == 1 && batchSize == 1 {
+					single = result.PerSecond()
+				}
+				if !c.Bool(jsonFlag.Name) {
+					fmt.Fprintf(c.App.Writer, "%-36s %-8d %-12d %-12.0f %-12s x%.2f\n", id, batchSize, parallelism,
+						result.PerSecond(), (result.Elapsed / time.Duration(result.Beacons)).Round(time.Microsecond),
+						result.PerSecond()/single)
+				}
+				if parallelism == maxParallelism {
+					break
+				}
+			}
+		}
+	}
+
+	if c.Bool(jsonFlag.Name) {
+		return printJSON(c.App.Writer, results)
+	}
+	return nil
+}
+
+// benchStoreHorizons are the durations over which bench-store projects the disk usage of a chain
+var benchStoreHorizons = []struct {
+	name  string
+	after time.Duration
+}{
+	{"1 day", 24 * time.Hour},
+	{"30 days", 30 * 24 * time.Hour},
+	{"1 year", 365 * 24 * time.Hour},
+	{"10 years", 10 * 365 * 24 * time.Hour},
+}
+
+func benchStoreCmd(c *cli.Context, l log.Logger) error {
+	conf := contextToConfig(c, l)
+	engines := core.BenchStores(conf)
+	if c.IsSet(storageTypeFlag.Name) {
+		engines = []chain.StorageType{chain.StorageType(c.String(storageTypeFlag.Name))}
+	}
+
+	beaconID := getBeaconID(c)
+	sch, err := crypto.SchemeFromName(c.String(schemeFlag.Name))
+	if err != nil {
+		return err
+	}
+	period := c.Duration(benchPeriodFlag.Name)
+	// opening the key store of a beacon creates its folders, only do it if the node has one
+	if _, err := os.Stat(path.Join(conf.BeaconFolderMB(beaconID), beaconID)); err == nil {
+		store := key.NewFileStore(conf.BeaconFolderMB(beaconID), common.BeaconID(beaconID))
+		if group, err := store.LoadGroup(); err == nil && group != nil {
+			if !c.IsSet(schemeFlag.Name) {
+				sch = group.Scheme
+			}
+			if period == 0 {
+				period = group.Period
+			}
+		}
+	}
+	if period <= 0 {
+		return fmt.Errorf("no group for beacon %s, set the period of the chain with --%s", beaconID, benchPeriodFlag.Name)
+	}
+
+	var results []*core.StoreBench
+	for _, engine := range engines {
+		l.Debugw("Benchmarking the store", "engine", engine, "beacons", c.Int(benchStoreBeaconsFlag.Name))
+		result, err := core.BenchStore(c.Context, conf, engine, sch, c.Int(benchStoreBeaconsFlag.Name))
+		if err != nil {
+			return fmt.Errorf("drand: can't benchmark the %s store: %w", engine, err)
+		}
+		results = append(results, result)
+	}
+
+	if c.Bool(jsonFlag.Name) {
+		return printJSON(c.App.Writer, results)
+	}
+	fmt.Fprintf(c.App.Writer, "%-12s %-12s %-12s %s\n", "engine", "writes/s", "reads/s", "bytes/beacon")
+	for _, r := range results {
+		fmt.Fprintf(c.App.Writer, "%-12s %-12.0f %-12.0f %.0f\n", r.Engine, r.WritesPerSecond(), r.ReadsPerSecond(), r.BytesPerBeacon)
+	}
+	fmt.Fprintf(c.App.Writer, "\nProjected disk usage of a %s chain with a period of %s:\n", sch.Name, period)
+	fmt.Fprintf(c.App.Writer, "%-12s", "engine")
+	for _, h := range benchStoreHorizons {
+		fmt.Fprintf(c.App.Writer, " %-12s", h.name)
+	}
+	fmt.Fprintln(c.App.Writer)
+	for _, r := range results {
+		fmt.Fprintf(c.App.Writer, "%-12s", r.Engine)
+		for _, h := range benchStoreHorizons {
+			usage := "in memory"
+			if r.BytesPerBeacon > 0 {
+				usage = humanBytes(r.Projected(period, h.after))
+			}
+			fmt.Fprintf(c.App.Writer, " %-12s", usage)
+		}
+		fmt.Fprintln(c.App.Writer)
+	}
+	return nil
+}
+
+func humanBytes(n float64) string {
+	const unit = 1024
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+	i := 0
+	for n >= unit && i < len(units)-1 {
+		n /= unit
+		i++
+	}
+	return fmt.Sprintf("%.1f %s", n, units[i])
+}
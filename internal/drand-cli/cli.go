@@ -9,7 +9,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	gonet "net"
 	"os"
 	"path"
 	"path/filepath"
@@ -17,20 +16,26 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/BurntSushi/toml"
+	clock "github.com/jonboulle/clockwork"
 	"github.com/urfave/cli/v2"
 
 	"github.com/drand/drand/v2/common"
 	"github.com/drand/drand/v2/common/key"
 	"github.com/drand/drand/v2/common/log"
 	"github.com/drand/drand/v2/crypto"
+	dhttp "github.com/drand/drand/v2/handler/http"
 	"github.com/drand/drand/v2/internal/chain"
 	"github.com/drand/drand/v2/internal/chain/boltdb"
 	"github.com/drand/drand/v2/internal/core"
 	"github.com/drand/drand/v2/internal/core/migration"
 	"github.com/drand/drand/v2/internal/fs"
+	"github.com/drand/drand/v2/internal/iolimit"
+	"github.com/drand/drand/v2/internal/metrics"
 	"github.com/drand/drand/v2/internal/net"
+	"github.com/drand/drand/v2/plugin"
 	"github.com/drand/drand/v2/protobuf/drand"
 )
 
@@ -105,6 +110,20 @@ var tracesProbabilityFlag = &cli.Float64Flag{
 	Value:   0.05,
 }
 
+var otlpMetricsFlag = &cli.StringFlag{
+	Name: "otlp-metrics",
+	Usage: "Push the metrics to the specific OpenTelemetry compatible host:port collector, alongside the " +
+		"Prometheus endpoint. E.g. 127.0.0.1:4317",
+	EnvVars: []string{"DRAND_OTLP_METRICS"},
+}
+
+var otlpMetricsIntervalFlag = &cli.DurationFlag{
+	Name:    "otlp-metrics-interval",
+	Usage:   "The interval between two pushes of the metrics to the OpenTelemetry collector.",
+	EnvVars: []string{"DRAND_OTLP_METRICS_INTERVAL"},
+	Value:   metrics.DefaultOTLPInterval,
+}
+
 var privListenFlag = &cli.StringFlag{
 	Name:    "private-listen",
 	Usage:   "Set the listening (binding) address of the private API. Useful if you have some kind of proxy.",
@@ -117,6 +136,13 @@ var pubListenFlag = &cli.StringFlag{
 	EnvVars: []string{"DRAND_PUBLIC_LISTEN"},
 }
 
+var outboundAddressFlag = &cli.StringSliceFlag{
+	Name: "outbound-address",
+	Usage: "Set the local IP or network interface the connections to the other nodes originate from. " +
+		"Use <peer host:port>=<IP or interface> to set it for a given peer only. Can be repeated.",
+	EnvVars: []string{"DRAND_OUTBOUND_ADDRESS"},
+}
+
 var outFlag = &cli.StringFlag{
 	Name:    "out",
 	Usage:   "save the group file into a separate file instead of stdout",
@@ -128,6 +154,434 @@ var backupOutFlag = &cli.StringFlag{
 	Usage: "the filepath to save the backup to",
 }
 
+var cachedConnectivityFlag = &cli.BoolFlag{
+	Name: "cached",
+	Usage: "Make the nodes reply with the results of their last background connectivity check, up to a minute old, " +
+		"instead of checking their connectivity again.",
+}
+
+var snapshotOutFlag = &cli.StringFlag{
+	Name:  "out",
+	Usage: "save the snapshot into a separate file instead of stdout",
+}
+
+var roundMessageRoundFlag = &cli.Uint64Flag{
+	Name:     "round",
+	Usage:    "The round to compute the signed message of.",
+	Required: true,
+}
+
+var previousSigFlag = &cli.StringFlag{
+	Name: "previous-signature",
+	Usage: "The hex-encoded signature of the previous round, for chained schemes. " +
+		"The one stored by the daemon is used when it is not set.",
+}
+
+var notarizeRoundFlag = &cli.Uint64Flag{
+	Name:     "round",
+	Usage:    "The round to notarize.",
+	Required: true,
+}
+
+var notarizationOutFlag = &cli.StringFlag{
+	Name:  "out",
+	Usage: "save the notarization bundle into a separate file instead of stdout",
+}
+
+var joinKitOutFlag = &cli.StringFlag{
+	Name:  "out",
+	Usage: "save the join kit into a separate file instead of stdout",
+}
+
+var entropyBytesFlag = &cli.UintFlag{
+	Name:  "bytes-per-round",
+	Usage: "The number of bytes derived from each beacon, at most 8160.",
+	Value: 32,
+}
+
+var entropyFromFlag = &cli.Uint64Flag{
+	Name:  "from",
+	Usage: "The round to start the stream at. Defaults to the latest round.",
+}
+
+var entropyLimitFlag = &cli.Uint64Flag{
+	Name:  "limit",
+	Usage: "Stop after writing that many bytes. 0 streams until interrupted.",
+}
+
+var entropyRateFlag = &cli.Uint64Flag{
+	Name:  "rate",
+	Usage: "The highest number of bytes written per second. 0 writes them as they come.",
+}
+
+var statsFromFlag = &cli.Uint64Flag{
+	Name:  "from",
+	Usage: "The first round of the range to compute the statistics over.",
+	Value: 1,
+}
+
+var statsToFlag = &cli.Uint64Flag{
+	Name:     "to",
+	Usage:    "The last round of the range to compute the statistics over, included.",
+	Required: true,
+}
+
+var benchBeaconsFlag = &cli.IntFlag{
+	Name:  "beacons",
+	Usage: "The number of beacons verified for each scheme and parallelism.",
+	Value: 500,
+}
+
+var benchParallelismFlag = &cli.IntFlag{
+	Name:  "max-parallelism",
+	Usage: "The highest number of goroutines verifying beacons in parallel, doubled from 1. Defaults to the number of CPUs.",
+}
+
+var benchBatchSizeFlag = &cli.IntFlag{
+	Name:  "batch-size",
+	Usage: "The number of beacons verified at once by the batched runs, which follow the ones verifying them one at a time. 1 disables them.",
+	Value: 64,
+}
+
+var benchStoreBeaconsFlag = &cli.IntFlag{
+	Name:  "beacons",
+	Usage: "The number of synthetic beacons written to and read from each store.",
+	Value: 2000,
+}
+
+var benchPeriodFlag = &cli.DurationFlag{
+	Name:  "period",
+	Usage: "The period of the chain to project the disk usage for. Defaults to the one of the group of the beacon, if any.",
+}
+
+var featureEnableFlag = &cli.StringFlag{
+	Name:  "enable",
+	Usage: "Turn the feature on until the daemon restarts.",
+}
+
+var featureDisableFlag = &cli.StringFlag{
+	Name:  "disable",
+	Usage: "Turn the feature off until the daemon restarts.",
+}
+
+var featureResetFlag = &cli.StringFlag{
+	Name:  "reset",
+	Usage: "Turn the feature back to its configured value.",
+}
+
+var overrideMemberFlag = &cli.StringFlag{
+	Name:  "member",
+	Usage: "The member whose address to override, by its address in the group file or its public key in hex.",
+}
+
+var overrideAddressFlag = &cli.StringFlag{
+	Name:  "address",
+	Usage: "Dial the member at this address, as host:port, instead of its address in the group file.",
+}
+
+var overrideRemoveFlag = &cli.BoolFlag{
+	Name:  "remove",
+	Usage: "Dial the member at its address in the group file again.",
+}
+
+var loggerNameFlag = &cli.StringFlag{
+	Name:  "logger",
+	Usage: "The named logger to change the level of, e.g. Follow, CheckChain or dkg, instead of the one of the daemon.",
+}
+
+var loggerRemoveFlag = &cli.BoolFlag{
+	Name:  "remove",
+	Usage: "Make the named logger follow the level of the daemon again.",
+}
+
+var forecastAtFlag = &cli.TimestampFlag{
+	Name:   "at",
+	Usage:  "The date of the next reshare, in RFC3339, e.g. 2006-01-02T15:04:05Z.",
+	Layout: time.RFC3339,
+}
+
+var forecastInFlag = &cli.DurationFlag{
+	Name:  "in",
+	Usage: "How long from now the next reshare is, instead of its date.",
+}
+
+var forecastNoteFlag = &cli.StringFlag{
+	Name:  "note",
+	Usage: "A note for the members about the next reshare, e.g. the nodes joining the group.",
+}
+
+var forecastClearFlag = &cli.BoolFlag{
+	Name:  "clear",
+	Usage: "Clear the forecast of the next reshare instead.",
+}
+
+var featureFlag = &cli.StringSliceFlag{
+	Name: "feature",
+	Usage: "Turn a feature on or off, for all the beacons as feature=on|off or for one of them as " +
+		"beacon-id:feature=on|off. See `drand util features` for the features. Can be repeated.",
+	EnvVars: []string{"DRAND_FEATURE"},
+}
+
+var statusSampleIntervalFlag = &cli.DurationFlag{
+	Name: "status-sample-interval",
+	Usage: "Sample the status of the members of the groups at this interval, to compute their availability with " +
+		"`drand util availability`. 0 disables it.",
+	Value:   core.DefaultStatusSampleInterval,
+	EnvVars: []string{"DRAND_STATUS_SAMPLE_INTERVAL"},
+}
+
+var udpListenFlag = &cli.StringFlag{
+	Name: "udp-listen",
+	Usage: "Answer the single-datagram UDP queries for the latest beacon received on this address, for the consumers " +
+		"of the local network. A query holds the beacon ID or the chain hash, padded with zeros to at least the size of " +
+		"the reply. Disabled if empty.",
+	EnvVars: []string{"DRAND_UDP_LISTEN"},
+}
+
+var pushToFlag = &cli.StringSliceFlag{
+	Name: "push-to",
+	Usage: "Push each new beacon to the downstream node listening on this private address, for the topologies where " +
+		"it can't reach this node to sync. The node must accept the pushes with --accept-push. Can be repeated.",
+	EnvVars: []string{"DRAND_PUSH_TO"},
+}
+
+var acceptPushFlag = &cli.StringSliceFlag{
+	Name: "accept-push",
+	Usage: "Accept the beacons pushed by upstream nodes for the chain with this hex-encoded hash, and store them after " +
+		"verifying them against the public key of the chain. Can be repeated.",
+	EnvVars: []string{"DRAND_ACCEPT_PUSH"},
+}
+
+var shareRefreshIntervalFlag = &cli.DurationFlag{
+	Name: "share-refresh-interval",
+	Usage: "Propose to refresh the shares of the groups this node belongs to at this interval, re-randomizing them " +
+		"while keeping the members and public key of the groups, so that a stolen share becomes useless at the next " +
+		"refresh. Only the node coordinating the refreshes should set it. Disabled if 0.",
+	EnvVars: []string{"DRAND_SHARE_REFRESH_INTERVAL"},
+}
+
+var autoRefreshFlag = &cli.BoolFlag{
+	Name: "auto-refresh",
+	Usage: "Accept the refreshes of the shares proposed for the groups this node belongs to, and execute the ones it " +
+		"proposed once accepted, without operator action.",
+	EnvVars: []string{"DRAND_AUTO_REFRESH"},
+}
+
+var minRefreshIntervalFlag = &cli.DurationFlag{
+	Name: "min-refresh-interval",
+	Usage: "Refuse to refresh the shares of a group less than this interval after its last DKG took over. " +
+		"Disabled if 0.",
+	Value:   core.DefaultMinRefreshInterval,
+	EnvVars: []string{"DRAND_MIN_REFRESH_INTERVAL"},
+}
+
+var v1CompatFlag = &cli.StringSliceFlag{
+	Name: "v1-compat",
+	Usage: "Serve the endpoints of the legacy v1 public API on this listener, for the consumers which didn't migrate " +
+		"to v2: 'public' for the HTTP listener, 'private' for the gRPC one. Can be repeated.",
+	EnvVars: []string{"DRAND_V1_COMPAT"},
+}
+
+var shedLatencyFlag = &cli.DurationFlag{
+	Name: "shed-latency",
+	Usage: "Shed the low-priority public requests while a chain is produced later than this latency, answering them " +
+		"with a 503 and a Retry-After: the syncs of the chain first, then the requests for a given round once twice " +
+		"as late. The requests for the latest round are always served. Disabled if 0.",
+	EnvVars: []string{"DRAND_SHED_LATENCY"},
+}
+
+var apiTierFlag = &cli.StringSliceFlag{
+	Name: "api-tier",
+	Usage: "Limit the requests to the public API of the clients of a tier, given as name=rate[:burst[:daily]] in " +
+		"requests per second and per day, 0 for no limit. The requests without an API key get the 'anonymous' tier, " +
+		"per address, and aren't limited if there is no such tier. Can be repeated.",
+	EnvVars: []string{"DRAND_API_TIER"},
+}
+
+var apiKeyFlag = &cli.StringSliceFlag{
+	Name: "api-key",
+	Usage: "Register an API key for a client of the public API, given as client:tier:key. The clients give their " +
+		"key in the X-API-Key header, as a bearer token, or in the x-api-key gRPC metadata. Can be repeated.",
+	EnvVars: []string{"DRAND_API_KEY"},
+}
+
+var redisURLFlag = &cli.StringFlag{
+	Name: "redis-url",
+	Usage: "Share the latest beacons and the usage of the tiers of the public API with the other instances serving " +
+		"it behind a load balancer through this Redis, given as redis://[:password@]host[:port][/db], so that they " +
+		"serve the same latest round and enforce the limits of the tiers together. Disabled if empty.",
+	EnvVars: []string{"DRAND_REDIS_URL"},
+}
+
+var shutdownOrderFlag = &cli.StringSliceFlag{
+	Name: "shutdown-order",
+	Usage: "The order of the steps of the shutdown of the daemon: beacons, which stops the beacon processes and " +
+		"closes their chain stores, listeners, which closes the public and private listeners, and services, which " +
+		"closes the mirroring, UDP and Redis. Each step must be given once. Defaults to beacons,listeners,services.",
+	EnvVars: []string{"DRAND_SHUTDOWN_ORDER"},
+}
+
+var controlAuthFlag = &cli.BoolFlag{
+	Name: "control-auth",
+	Usage: "Require a bearer token on the calls to the control port: the one of --control-token, or else the one " +
+		"kept in the config folder, created on the first start.",
+	EnvVars: []string{"DRAND_CONTROL_AUTH"},
+}
+
+var controlTokenFlag = &cli.StringFlag{
+	Name: "control-token",
+	Usage: "The token authenticating the calls to the control port. The commands use the one kept in the config " +
+		"folder by default. Given to the daemon, it enables the authentication and can't be rotated.",
+	EnvVars: []string{"DRAND_CONTROL_TOKEN"},
+}
+
+var controlRoleFlag = &cli.StringSliceFlag{
+	Name: "control-role",
+	Usage: "Define a role of the control port, given as name=Method[,Method...] with the names of the RPCs its " +
+		"tokens may call, * for all of them. Changing a feature flag also requires SetFeatureFlags. The read-only " +
+		"role, allowed to inspect the daemon, exists unless redefined. Can be repeated.",
+	EnvVars: []string{"DRAND_CONTROL_ROLE"},
+}
+
+var controlRoleTokenFlag = &cli.StringSliceFlag{
+	Name: "control-role-token",
+	Usage: "Grant a token the RPCs of a role of the control port, given as role:token. The token of --control-token, " +
+		"or the one kept in the config folder, is the admin one, allowed to call all of them. Enables the " +
+		"authentication. Can be repeated.",
+	EnvVars: []string{"DRAND_CONTROL_ROLE_TOKEN"},
+}
+
+var minGenesisDelayFlag = &cli.DurationFlag{
+	Name: "min-genesis-delay",
+	Usage: "Refuse to propose or join a new network whose genesis is less than this delay away, so that all the " +
+		"nodes have the time to run the DKG and start before the genesis. Disabled if 0.",
+	EnvVars: []string{"DRAND_MIN_GENESIS_DELAY"},
+}
+
+var corsOriginFlag = &cli.StringSliceFlag{
+	Name: "cors-origin",
+	Usage: "Only let the web pages of this origin, such as https://example.com, read the responses of the public HTTP " +
+		"API. Can be repeated. Any origin is allowed by default, or if '*' is passed.",
+	EnvVars: []string{"DRAND_CORS_ORIGIN"},
+}
+
+var corsHeaderFlag = &cli.StringSliceFlag{
+	Name:    "cors-header",
+	Usage:   "Let the web pages send this request header to the public HTTP API. Can be repeated.",
+	EnvVars: []string{"DRAND_CORS_HEADER"},
+}
+
+var corsMaxAgeFlag = &cli.DurationFlag{
+	Name:    "cors-max-age",
+	Usage:   "Let the browsers cache the responses to the CORS preflight requests for this long.",
+	EnvVars: []string{"DRAND_CORS_MAX_AGE"},
+}
+
+var securityHeadersFlag = &cli.BoolFlag{
+	Name: "security-headers",
+	Usage: "Set the standard security headers on the responses of the public HTTP API: X-Content-Type-Options, " +
+		"X-Frame-Options, Referrer-Policy and Content-Security-Policy.",
+	EnvVars: []string{"DRAND_SECURITY_HEADERS"},
+}
+
+var hstsMaxAgeFlag = &cli.DurationFlag{
+	Name:    "hsts-max-age",
+	Usage:   "Set the Strict-Transport-Security header with this max-age, if the public HTTP API is only reachable over TLS.",
+	EnvVars: []string{"DRAND_HSTS_MAX_AGE"},
+}
+
+var signerFlag = &cli.StringFlag{
+	Name:    "signer",
+	Usage:   "Sign the partials with the signer compiled into the binary under this name, rather than with the shares.",
+	EnvVars: []string{"DRAND_SIGNER"},
+}
+
+var publisherFlag = &cli.StringSliceFlag{
+	Name:    "publisher",
+	Usage:   "Publish each new beacon with the publisher compiled into the binary under this name. Can be repeated.",
+	EnvVars: []string{"DRAND_PUBLISHERS"},
+}
+
+var availabilityWindowFlag = &cli.DurationFlag{
+	Name:  "window",
+	Usage: "The window the availability is computed over, ending now.",
+	Value: 24 * time.Hour,
+}
+
+var participationFromFlag = &cli.Uint64Flag{
+	Name:  "from",
+	Usage: "The first round to list the participation of.",
+}
+
+var participationToFlag = &cli.Uint64Flag{
+	Name:  "to",
+	Usage: "The last round to list the participation of, included. Up to the last round recorded if unset.",
+}
+
+var tombstonesFromFlag = &cli.Uint64Flag{
+	Name:  "from",
+	Usage: "The first round to list the beacons replaced of.",
+}
+
+var tombstonesToFlag = &cli.Uint64Flag{
+	Name:  "to",
+	Usage: "The last round to list the beacons replaced of, included. Up to the last round recorded if unset.",
+}
+
+var injectBeaconFlag = &cli.StringFlag{
+	Name: "beacon",
+	Usage: "The path to the beacon to inject, in the JSON format served by the public API, with its round, signature " +
+		"and previous signature.",
+	Required: true,
+}
+
+var metadataNamespaceFlag = &cli.StringFlag{
+	Name:     "namespace",
+	Usage:    "The namespace of the metadata of the chain store, e.g. the name of the extension owning it",
+	Required: true,
+}
+
+var metadataKeyFlag = &cli.StringFlag{
+	Name:  "key",
+	Usage: "The key to read, write or delete. All the keys of the namespace are listed if unset.",
+}
+
+var metadataValueFlag = &cli.StringFlag{
+	Name:  "value",
+	Usage: "Writes the given value to the key",
+}
+
+var metadataDeleteFlag = &cli.BoolFlag{
+	Name:  "delete",
+	Usage: "Deletes the key",
+}
+
+var annotationRoundFlag = &cli.Uint64Flag{
+	Name:  "round",
+	Usage: "The round to annotate, or to list the annotations of. The annotations of all the rounds are listed if unset.",
+}
+
+var annotationNoteFlag = &cli.StringFlag{
+	Name:  "note",
+	Usage: "Attaches the given note to the round, e.g. \"used for lottery X\"",
+}
+
+var annotationRemoveFlag = &cli.BoolFlag{
+	Name:  "remove",
+	Usage: "Removes the annotations of the round",
+}
+
+var attestationOutFlag = &cli.StringFlag{
+	Name:  "out",
+	Usage: "save the destruction attestation into a separate file instead of stdout",
+}
+
+var attestationGroupFlag = &cli.StringFlag{
+	Name:     "group",
+	Usage:    "The group file of the epoch in which the node held the destroyed share",
+	Required: true,
+}
+
 var periodFlag = &cli.StringFlag{
 	Name:    "period",
 	Usage:   "period to set when doing a setup",
@@ -210,6 +664,13 @@ var syncNodeFlag = &cli.StringFlag{
 	EnvVars:  []string{"DRAND_SYNC_NODES"},
 }
 
+var crossCheckFlag = &cli.BoolFlag{
+	Name: "cross-check",
+	Usage: "When following, check that all the sync nodes serve the same signatures for the new rounds, " +
+		"and report loudly any fork, which would mean the key of the network was compromised.",
+	EnvVars: []string{"DRAND_CROSS_CHECK"},
+}
+
 var followFlag = &cli.BoolFlag{
 	Name: "follow",
 	Usage: "Indicates whether we want to follow another daemon, if not we perform a check of our local DB. " +
@@ -217,6 +678,42 @@ var followFlag = &cli.BoolFlag{
 	EnvVars: []string{"DRAND_FOLLOW"},
 }
 
+var joinKitFlag = &cli.StringFlag{
+	Name: "join-kit",
+	Usage: "When following, start from the checkpoint of the given join kit instead of syncing from genesis. " +
+		"The peers of the kit are added to the sync nodes.",
+	EnvVars: []string{"DRAND_JOIN_KIT"},
+}
+
+var checkpointFlag = &cli.StringFlag{
+	Name: "checkpoint",
+	Usage: "When following, start from the given trusted beacon instead of syncing from genesis, " +
+		"as <ROUND>:<SIGNATURE>[:<PREVIOUS SIGNATURE>] with hex-encoded signatures.",
+	EnvVars: []string{"DRAND_CHECKPOINT"},
+}
+
+var fullVerificationFlag = &cli.BoolFlag{
+	Name:    "full-verification",
+	Usage:   "When following from a checkpoint, also sync and verify the rounds before it in the background.",
+	EnvVars: []string{"DRAND_FULL_VERIFICATION"},
+}
+
+var prioritizedFlag = &cli.BoolFlag{
+	Name: "prioritized",
+	Usage: "When following, follow the sync nodes in the order given: the first one when available, failing over " +
+		"to the next ones when it isn't and back once it is again. The chain is relayed to the nodes following this one, " +
+		"so that relay trees can be built out of followers.",
+	EnvVars: []string{"DRAND_PRIORITIZED"},
+}
+
+var fromArchiveFlag = &cli.StringSliceFlag{
+	Name: "from-archive",
+	Usage: "When following, import the history of the chain from the given backup of its database, as written by " +
+		"`drand util backup`, on the host of the daemon before syncing the rest from the sync nodes. Can be given " +
+		"several times: the rounds the archives disagree on are settled by the sync nodes, the majority being kept.",
+	EnvVars: []string{"DRAND_FROM_ARCHIVE"},
+}
+
 var upToFlag = &cli.IntFlag{
 	Name: "up-to",
 	Usage: "Specify a round at which the drand daemon will stop syncing the chain, " +
@@ -232,6 +729,17 @@ var schemeFlag = &cli.StringFlag{
 	EnvVars: []string{"DRAND_SCHEME"},
 }
 
+var mnemonicFlag = &cli.BoolFlag{
+	Name: "mnemonic",
+	Usage: "Derive the keypair from a new mnemonic, printed once generated. Write it down: the keypair can then be " +
+		"recovered from it with --recover if the key files are lost. The shares can't be recovered this way.",
+}
+
+var recoverFlag = &cli.BoolFlag{
+	Name:  "recover",
+	Usage: "Recover the keypair from its mnemonic, read from the standard input",
+}
+
 var jsonFlag = &cli.BoolFlag{
 	Name:    "json",
 	Usage:   "Set the output as json format",
@@ -251,6 +759,16 @@ var listIDsFlag = &cli.BoolFlag{
 	EnvVars: []string{"DRAND_LIST_IDS"},
 }
 
+var statusWatchFlag = &cli.BoolFlag{
+	Name:  "watch",
+	Usage: "Keep printing the status of the beacon whenever it changes, until interrupted.",
+}
+
+var statusIntervalFlag = &cli.DurationFlag{
+	Name:  "interval",
+	Usage: "With --watch, also print the status at this interval even if it didn't change. 0 only prints the changes.",
+}
+
 var allBeaconsFlag = &cli.BoolFlag{
 	Name:    "all",
 	Usage:   "Indicates if we have to interact with all beacons chains",
@@ -259,8 +777,9 @@ var allBeaconsFlag = &cli.BoolFlag{
 }
 
 var storageTypeFlag = &cli.StringFlag{
-	Name:    "db",
-	Usage:   "Which database engine to use. Supported values: bolt, postgres, or memdb.",
+	Name: "db",
+	Usage: "Which database engine to use. Supported values: bolt, postgres, memdb, or the name of a store driver " +
+		"compiled into the binary.",
 	Value:   "bolt",
 	EnvVars: []string{"DRAND_DB"},
 }
@@ -287,6 +806,231 @@ var memDBSizeFlag = &cli.IntFlag{
 	EnvVars: []string{"DRAND_MEMDB_SIZE"},
 }
 
+var maxRequestSizeFlag = &cli.IntFlag{
+	Name:    "max-request-size",
+	Usage:   "Maximum size in bytes of a message accepted by the control and private gRPC servers.",
+	Value:   core.DefaultMaxRequestSize,
+	EnvVars: []string{"DRAND_MAX_REQUEST_SIZE"},
+}
+
+var requestTimeoutFlag = &cli.DurationFlag{
+	Name:    "request-timeout",
+	Usage:   "Server-side deadline for unary calls on the control and private gRPC servers. Set to 0 to disable it.",
+	Value:   core.DefaultRequestTimeout,
+	EnvVars: []string{"DRAND_REQUEST_TIMEOUT"},
+}
+
+var upgradeStartFlag = &cli.DurationFlag{
+	Name:  "start-in",
+	Usage: "How long from now the upgrade window starts, leaving members time to accept it.",
+	Value: time.Hour,
+}
+
+var upgradeWindowFlag = &cli.DurationFlag{
+	Name:     "window",
+	Usage:    "The duration of the upgrade window, during which all the nodes restart.",
+	Required: true,
+}
+
+var leaveRoundFlag = &cli.Uint64Flag{
+	Name:     "round",
+	Usage:    "The last round this node signs partials for before leaving the group.",
+	Required: true,
+}
+
+var wipeFlag = &cli.BoolFlag{
+	Name:  "wipe",
+	Usage: "Securely wipe the share of this node once the reshare excluding it completes.",
+}
+
+var drainFlag = &cli.BoolFlag{
+	Name: "drain",
+	Usage: "Let the beacons finish the round in progress and store it before stopping, without signing the partials " +
+		"of the next rounds.",
+}
+
+var drainTimeoutFlag = &cli.DurationFlag{
+	Name:  "drain-timeout",
+	Usage: "The longest to wait for each beacon to drain. Defaults to two periods of the beacon.",
+}
+
+var archiveFlag = &cli.BoolFlag{
+	Name: "archive",
+	Usage: "Move the folder of the beacon, along with an export of its DKG state, to the archive folder of the node " +
+		"instead of deleting it.",
+}
+
+var confirmRemoveFlag = &cli.BoolFlag{
+	Name:  "confirm",
+	Usage: "Confirm the removal of the beacon, its keys and its chain store. Nothing is removed without it.",
+}
+
+var pauseReasonFlag = &cli.StringFlag{
+	Name:  "reason",
+	Usage: "Why the node is paused, reported in its status and events.",
+}
+
+var pauseForceFlag = &cli.BoolFlag{
+	Name:  "force",
+	Usage: "Pause the node even if the group is then expected to miss rounds.",
+}
+
+var incidentReasonFlag = &cli.StringFlag{
+	Name:     "reason",
+	Usage:    "What happened, reported in the status and events of the node.",
+	Required: true,
+}
+
+var incidentProposalFlag = &cli.StringFlag{
+	Name:  "proposal-out",
+	Usage: "Write the proposal of a reshare excluding this node to this file, for the coordinator of the group.",
+}
+
+var newAddressFlag = &cli.StringFlag{
+	Name:     "new-address",
+	Usage:    "The address this node is now reachable at, as host:port.",
+	Required: true,
+}
+
+var maxStatusNodesFlag = &cli.IntFlag{
+	Name:    "max-status-nodes",
+	Usage:   "Maximum number of nodes a single status or remote-status request can ask the daemon to contact.",
+	Value:   core.DefaultMaxStatusNodes,
+	EnvVars: []string{"DRAND_MAX_STATUS_NODES"},
+}
+
+var maxStalePeriodsFlag = &cli.Uint64Flag{
+	Name: "max-stale-periods",
+	Usage: "Refuse to serve the latest beacon on the public endpoints if it is older than that many periods, " +
+		"returning a stale error with the last round instead. 0 disables the check.",
+	EnvVars: []string{"DRAND_MAX_STALE_PERIODS"},
+}
+
+var prefetchLeadFlag = &cli.DurationFlag{
+	Name: "prefetch-lead",
+	Usage: "Watch the chains persistently on the HTTP API and serve their latest round from memory, sharing it " +
+		"as soon as it arrives, reconnecting a failed watch that long before the next round, e.g. 500ms. " +
+		"Meant for relays. 0 disables it.",
+	EnvVars: []string{"DRAND_PREFETCH_LEAD"},
+}
+
+var heartbeatPeriodFlag = &cli.DurationFlag{
+	Name: "heartbeat-period",
+	Usage: "Sign a heartbeat over the chain tip and the group with the other members at that period, e.g. 1h, " +
+		"served on the public API. All the members of the group must use the same period. 0 disables the heartbeats.",
+	EnvVars: []string{"DRAND_HEARTBEAT_PERIOD"},
+}
+
+var subBeaconFlag = &cli.StringSliceFlag{
+	Name: "sub-beacon",
+	Usage: "Sign the rounds of a randomness stream derived from the distributed key of the group, given as " +
+		"name=period, e.g. hourly=1h. Can be repeated. All the members of the group must run the same sub-beacons.",
+	EnvVars: []string{"DRAND_SUB_BEACON"},
+}
+
+var derivedChainFlag = &cli.StringSliceFlag{
+	Name: "derived-chain",
+	Usage: "Derive a chain from the beacons of the chain, given as name=kind:param: every:N for a digest of every N " +
+		"rounds, e.g. weekly=every:201600, or bucket:period for a digest of the rounds of every period since the " +
+		"UNIX epoch, e.g. daily=bucket:24h. Can be repeated. Each node derives its chains on its own.",
+	EnvVars: []string{"DRAND_DERIVED_CHAIN"},
+}
+
+var publicKeyCacheSizeFlag = &cli.IntFlag{
+	Name: "public-key-cache-size",
+	Usage: "Number of public keys of the members of the group kept precomputed to verify their partials. " +
+		"0 uses the default, which covers the whole group of every network currently running.",
+	EnvVars: []string{"DRAND_PUBLIC_KEY_CACHE_SIZE"},
+}
+
+var syncMaxInFlightFlag = &cli.IntFlag{
+	Name:    "sync-max-in-flight",
+	Usage:   "Number of beacons received from a peer that can wait to be stored while syncing. 0 uses the default.",
+	EnvVars: []string{"DRAND_SYNC_MAX_IN_FLIGHT"},
+}
+
+var syncMemoryBudgetFlag = &cli.Int64Flag{
+	Name: "sync-memory-budget",
+	Usage: "Memory, in bytes, that the beacons buffered while syncing can use. Syncs wait for memory to be " +
+		"released when it is exhausted. 0 uses the default.",
+	EnvVars: []string{"DRAND_SYNC_MEMORY_BUDGET"},
+}
+
+var syncPreferFlag = &cli.StringSliceFlag{
+	Name: "sync-prefer",
+	Usage: "Sync the chain preferably from the peers matching this rule, when following a chain and when catching up " +
+		"with the group: an address, with or without its port, a CIDR, or region:<name>. Can be repeated.",
+	EnvVars: []string{"DRAND_SYNC_PREFER"},
+}
+
+var syncDenyFlag = &cli.StringSliceFlag{
+	Name:    "sync-deny",
+	Usage:   "Never sync the chain from the peers matching this rule, given as for --sync-prefer. Can be repeated.",
+	EnvVars: []string{"DRAND_SYNC_DENY"},
+}
+
+var syncPeerRegionFlag = &cli.StringSliceFlag{
+	Name: "sync-peer-region",
+	Usage: "Label the peers matching an address or a CIDR with a region, for the region:<name> rules of --sync-prefer " +
+		"and --sync-deny, as <address|CIDR>=<region>. Can be repeated.",
+	EnvVars: []string{"DRAND_SYNC_PEER_REGION"},
+}
+
+var slowStoreThresholdFlag = &cli.DurationFlag{
+	Name: "slow-store-threshold",
+	Usage: "Log and count the operations of the chain store taking longer than this, to diagnose the latency " +
+		"added by the storage. 0 disables it.",
+	Value:   core.DefaultSlowStoreThreshold,
+	EnvVars: []string{"DRAND_SLOW_STORE_THRESHOLD"},
+}
+
+var selfTestFlag = &cli.BoolFlag{
+	Name: "selftest",
+	Usage: "Check the key pairs, schemes, stores, clock, listeners and the reachability of the group members " +
+		"before starting, and refuse to start if any check fails.",
+	EnvVars: []string{"DRAND_SELFTEST"},
+}
+
+var strictFlag = &cli.BoolFlag{
+	Name: "strict",
+	Usage: "Refuse to start with weak or deprecated configurations instead of warning about them: a control port " +
+		"on TCP rather than a unix socket, a clock off from the NTP server or which can't be checked, private key " +
+		"files accessible to other users, beacons on a deprecated scheme and deprecated flags.",
+	EnvVars: []string{"DRAND_STRICT"},
+}
+
+var ntpServerFlag = &cli.StringFlag{
+	Name: "ntp-server",
+	Usage: "NTP server the clock is compared to by the self-test and the strict mode. Set it empty to skip the " +
+		"clock check of the self-test.",
+	Value:   core.DefaultNTPServer,
+	EnvVars: []string{"DRAND_NTP_SERVER"},
+}
+
+var ioLimitFlag = &cli.StringSliceFlag{
+	Name: "io-limit",
+	Usage: "Throttle the disk I/O of a class of background tasks, given as class=bytes-per-second, e.g. " +
+		"backup=1048576. The classes are backup, check, stats, compact and export. Can be repeated.",
+	EnvVars: []string{"DRAND_IO_LIMIT"},
+}
+
+var mirrorToFlag = &cli.StringFlag{
+	Name: "mirror-to",
+	Usage: "Mirror the unary public gRPC requests received to the shadow daemon listening on this private address, " +
+		"in the background, and count whether its responses match ours in the mirrored_requests metric, to validate " +
+		"a new version under real traffic before switching to it.",
+	EnvVars: []string{"DRAND_MIRROR_TO"},
+}
+
+var isolateBeaconFlag = &cli.StringSliceFlag{
+	Name: "isolate-beacon",
+	Usage: "Give a beacon its own private listener, TLS certificate and folder for its keys and database, given as " +
+		"beacon-id:key=value,... with the keys private-listen, tls-cert, tls-key and folder, e.g. " +
+		"evmnet:private-listen=0.0.0.0:4454,tls-cert=evmnet.crt,tls-key=evmnet.key,folder=/srv/evmnet. " +
+		"The listener of the daemon then refuses to serve the beacon. Can be repeated.",
+	EnvVars: []string{"DRAND_ISOLATE_BEACON"},
+}
+
 // TODO: remove at some point in the future after migrating to v2
 var hiddenInsecureFlag = &cli.BoolFlag{
 	Name:    "tls-disable",
@@ -303,17 +1047,33 @@ var hiddenInsecureFlag = &cli.BoolFlag{
 	EnvVars: []string{"DRAND_TLS_DISABLE", "DRAND_INSECURE"},
 }
 
+// startFlags are the options of the daemon, which can also be given in a config file
+var startFlags = toArray(configFileFlag, folderFlag, controlFlag, privListenFlag, pubListenFlag, outboundAddressFlag,
+	metricsFlag, tracesFlag, tracesProbabilityFlag, otlpMetricsFlag, otlpMetricsIntervalFlag,
+	pushFlag, verboseFlag, oldGroupFlag,
+	skipValidationFlag, jsonFlag, beaconIDFlag,
+	storageTypeFlag, pgDSNFlag, memDBSizeFlag, hiddenInsecureFlag,
+	maxRequestSizeFlag, requestTimeoutFlag, maxStatusNodesFlag, maxStalePeriodsFlag, prefetchLeadFlag,
+	heartbeatPeriodFlag, subBeaconFlag, derivedChainFlag, publicKeyCacheSizeFlag, syncMaxInFlightFlag, syncMemoryBudgetFlag,
+	syncPreferFlag, syncDenyFlag, syncPeerRegionFlag, ioLimitFlag, slowStoreThresholdFlag, selfTestFlag, strictFlag, ntpServerFlag, isolateBeaconFlag,
+	mirrorToFlag, featureFlag, statusSampleIntervalFlag, udpListenFlag,
+	pushToFlag, acceptPushFlag, shareRefreshIntervalFlag, autoRefreshFlag, minRefreshIntervalFlag, v1CompatFlag, shedLatencyFlag,
+	apiTierFlag, apiKeyFlag, redisURLFlag, shutdownOrderFlag, minGenesisDelayFlag, controlAuthFlag, controlTokenFlag,
+	controlRoleFlag, controlRoleTokenFlag,
+	corsOriginFlag, corsHeaderFlag, corsMaxAgeFlag, securityHeadersFlag, hstsMaxAgeFlag, signerFlag, publisherFlag)
+
 var appCommands = []*cli.Command{
 	dkgCommand,
 	{
 		Name:  "start",
 		Usage: "Start the drand daemon.",
-		Flags: toArray(folderFlag, controlFlag, privListenFlag, pubListenFlag,
-			metricsFlag, tracesFlag, tracesProbabilityFlag,
-			pushFlag, verboseFlag, oldGroupFlag,
-			skipValidationFlag, jsonFlag, beaconIDFlag,
-			storageTypeFlag, pgDSNFlag, memDBSizeFlag, hiddenInsecureFlag),
+		Flags: startFlags,
 		Action: func(c *cli.Context) error {
+			// the options of the config file are loaded first, so that they apply to everything below
+			given := givenOptions(c)
+			if _, err := loadConfigFile(c); err != nil {
+				return err
+			}
 			l := log.New(nil, logLevel(c), logJSON(c))
 
 			// check multibeacon and complain migration wasn't done in v1 if not
@@ -328,13 +1088,13 @@ var appCommands = []*cli.Command{
 
 			// everything seems fine, we can start
 			banner(c.App.Writer)
-			return startCmd(c, l)
+			return startCmd(c, l, configReloader(c, given))
 		},
 	},
 	{
 		Name:  "stop",
 		Usage: "Stop the drand daemon.\n",
-		Flags: toArray(controlFlag, beaconIDFlag),
+		Flags: toArray(controlFlag, beaconIDFlag, drainFlag, drainTimeoutFlag),
 		Action: func(c *cli.Context) error {
 			banner(c.App.Writer)
 			l := log.New(nil, logLevel(c), logJSON(c)).
@@ -342,6 +1102,64 @@ var appCommands = []*cli.Command{
 			return stopDaemon(c, l)
 		},
 	},
+	{
+		Name: "leave",
+		Usage: "Announce to the group that this node is leaving it. The node stops signing partials after the given " +
+			"round, and the coordinator of the next reshare can list it as a leaver with " +
+			"'drand dkg generate-proposal --" + announcedLeaversFlag.Name + "'.\n",
+		Flags: toArray(controlFlag, beaconIDFlag, leaveRoundFlag, wipeFlag),
+		Action: func(c *cli.Context) error {
+			l := log.New(nil, logLevel(c), logJSON(c)).
+				Named("leaveCmd")
+			return leaveCmd(c, l)
+		},
+	},
+	{
+		Name: "pause",
+		Usage: "Stop this node from signing its partials, while it keeps following and serving the chain, e.g. while " +
+			"investigating a suspected compromise of its key. It is refused if the group is then expected to miss " +
+			"rounds, unless forced. The node stays paused until resumed or restarted.\n",
+		Flags: toArray(controlFlag, beaconIDFlag, pauseReasonFlag, pauseForceFlag),
+		Action: func(c *cli.Context) error {
+			l := log.New(nil, logLevel(c), logJSON(c)).
+				Named("pauseCmd")
+			return pauseCmd(c, l)
+		},
+	},
+	{
+		Name: "incident",
+		Usage: "Respond to a suspected compromise of the share of this node in one action: pause it, save a snapshot " +
+			"of the daemon, announce to the group that it leaves it, and write the proposal of a reshare excluding " +
+			"it. Each step is reported, the next ones are run even if one fails.\n",
+		Flags: toArray(controlFlag, beaconIDFlag, incidentReasonFlag, incidentProposalFlag),
+		Action: func(c *cli.Context) error {
+			l := log.New(nil, logLevel(c), logJSON(c)).
+				Named("incidentCmd")
+			return incidentCmd(c, l)
+		},
+	},
+	{
+		Name: "update-address",
+		Usage: "Announce to the group that this node is now reachable at a new address, e.g. after its IP changed. " +
+			"The members check the announcement against the identity key of this node and reach it there, while " +
+			"it stays known by its address in the group file until the next reshare.\n",
+		Flags: toArray(controlFlag, beaconIDFlag, newAddressFlag),
+		Action: func(c *cli.Context) error {
+			l := log.New(nil, logLevel(c), logJSON(c)).
+				Named("updateAddressCmd")
+			return updateAddressCmd(c, l)
+		},
+	},
+	{
+		Name:  "resume",
+		Usage: "Make a paused node sign its partials again.\n",
+		Flags: toArray(controlFlag, beaconIDFlag),
+		Action: func(c *cli.Context) error {
+			l := log.New(nil, logLevel(c), logJSON(c)).
+				Named("resumeCmd")
+			return resumeCmd(c, l)
+		},
+	},
 	{
 		Name:   "share",
 		Usage:  "The old command for running DKGs; this has been removed",
@@ -350,6 +1168,27 @@ var appCommands = []*cli.Command{
 			banner(c.App.Writer)
 			return deprecatedShareCommand(c)
 		},
+		Subcommands: []*cli.Command{
+			{
+				Name: "destroy",
+				Usage: "Overwrite and delete the share of this node, then emit an attestation of its destruction " +
+					"signed with the longterm key, which the other members can verify and record. " +
+					"The beacon must not be running.\n",
+				Flags: toArray(folderFlag, controlFlag, beaconIDFlag, attestationOutFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("shareDestroyCmd")
+					return shareDestroyCmd(c, l)
+				},
+			},
+			{
+				Name:      "verify-destruction",
+				Usage:     "Verify the attestation of the destruction of a share against the group the node was part of.\n",
+				ArgsUsage: "<attestation> is the file emitted by 'drand share destroy'",
+				Flags:     toArray(attestationGroupFlag),
+				Action:    verifyShareDestructionCmd,
+			},
+		},
 	},
 	{
 		Name:  "load",
@@ -361,12 +1200,24 @@ var appCommands = []*cli.Command{
 			return loadCmd(c, l)
 		},
 	},
+	{
+		Name: "remove-beacon",
+		Usage: "Unload a beacon and delete its folder, holding its keys and its chain store, and its DKG state, so " +
+			"that it isn't configured on the node anymore. The keys are securely wiped, unless archived.\n",
+		Flags: toArray(controlFlag, beaconIDFlag, archiveFlag, confirmRemoveFlag),
+		Action: func(c *cli.Context) error {
+			l := log.New(nil, logLevel(c), logJSON(c)).
+				Named("removeBeaconCmd")
+			return removeBeaconCmd(c, l)
+		},
+	},
 	{
 		Name: "sync",
 		Usage: "sync your local randomness chain with other nodes and validate your local beacon chain. To follow a " +
 			"remote node, it requires the use of the '" + followFlag.Name + "' flag.",
 		Flags: toArray(folderFlag, controlFlag, hashInfoNoReq, syncNodeFlag,
-			upToFlag, beaconIDFlag, followFlag),
+			upToFlag, beaconIDFlag, followFlag, crossCheckFlag, joinKitFlag, checkpointFlag, fullVerificationFlag,
+			prioritizedFlag, fromArchiveFlag),
 		Action: func(c *cli.Context) error {
 			l := log.New(nil, logLevel(c), logJSON(c)).
 				Named("syncCmd")
@@ -377,8 +1228,9 @@ var appCommands = []*cli.Command{
 		Name: "generate-keypair",
 		Usage: "Generate the longterm keypair (drand.private, drand.public) " +
 			"for this node, and load it on the drand daemon if it is up and running.\n",
-		ArgsUsage: "<address> is the address other nodes will be able to contact this node on (specified as 'private-listen' to the daemon)",
-		Flags:     toArray(controlFlag, folderFlag, hiddenInsecureFlag, beaconIDFlag, schemeFlag),
+		ArgsUsage: "<address> is the address other nodes will be able to contact this node on (specified as 'private-listen' to the daemon). " +
+			"It can also be a service name to resolve through DNS SRV records, e.g. srv://_drand._tcp.example.org",
+		Flags: toArray(controlFlag, folderFlag, hiddenInsecureFlag, beaconIDFlag, schemeFlag, mnemonicFlag, recoverFlag),
 		Action: func(c *cli.Context) error {
 			banner(c.App.Writer)
 			l := log.New(nil, logLevel(c), logJSON(c)).
@@ -406,32 +1258,307 @@ var appCommands = []*cli.Command{
 			return checkMigration(c, l)
 		},
 	},
+	{
+		Name:  "config",
+		Usage: "Validate the config files of the daemon, and print their schema.",
+		Subcommands: []*cli.Command{
+			{
+				Name: "check",
+				Usage: "Validate the options of the daemon given in the config file, the flags and the environment, " +
+					"and print the effective configuration.\n",
+				Flags: startFlags,
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("configCheckCmd")
+					return configCheckCmd(c, l)
+				},
+			},
+			{
+				Name:  "schema",
+				Usage: "Print the JSON schema of the config files of the daemon.\n",
+				Action: func(c *cli.Context) error {
+					return configSchemaCmd(c)
+				},
+			},
+		},
+	},
 	{
 		Name:  "util",
 		Usage: "Multiple commands of utility functions, such as reseting a state, checking the connection of a peer...",
 		Subcommands: []*cli.Command{
 			{
-				Name: "check",
-				Usage: "Check node at the given `ADDRESS` (you can put multiple ones)" +
-					" in the group for accessibility over the gRPC communication. You can " +
-					"also check a whole group's connectivity with the group flag.",
-				Flags: toArray(groupFlag, verboseFlag, beaconIDFlag, hiddenInsecureFlag),
+				Name: "check",
+				Usage: "Check node at the given `ADDRESS` (you can put multiple ones)" +
+					" in the group for accessibility over the gRPC communication. You can " +
+					"also check a whole group's connectivity with the group flag.",
+				Flags: toArray(groupFlag, verboseFlag, beaconIDFlag, hiddenInsecureFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("checkConnection")
+					return checkConnection(c, l)
+				},
+			},
+			{
+				Name: "remote-status",
+				Usage: "Ask for the statuses of remote nodes indicated by " +
+					"`ADDRESS1 ADDRESS2 ADDRESS3...`, including the network " +
+					"visibility over the rest of the addresses given.",
+				Flags: toArray(controlFlag, jsonFlag, beaconIDFlag, hiddenInsecureFlag, cachedConnectivityFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("remoteStatusCmd")
+					return remoteStatusCmd(c, l)
+				},
+			},
+			{
+				Name: "snapshot",
+				Usage: "Collect the status, group file, chain tip, DKG state and recent events of all the beacons " +
+					"of the daemon, taken at a single point in time, e.g. to attach them to a support request.\n",
+				Flags: toArray(controlFlag, snapshotOutFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("snapshotCmd")
+					return snapshotCmd(c, l)
+				},
+			},
+			{
+				Name: "round-message",
+				Usage: "Print the exact message the daemon signs a partial over for the given round, so that " +
+					"external signers and auditors can reproduce and authorize it.\n",
+				Flags: toArray(controlFlag, beaconIDFlag, roundMessageRoundFlag, previousSigFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("roundMessageCmd")
+					return roundMessageCmd(c, l)
+				},
+			},
+			{
+				Name: "notarize",
+				Usage: "Export a self-contained bundle allowing to verify the randomness of the given round offline, " +
+					"signed by the identity of the node, for legal or archival use.\n",
+				Flags: toArray(controlFlag, beaconIDFlag, notarizeRoundFlag, notarizationOutFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("notarizeCmd")
+					return notarizeCmd(c, l)
+				},
+			},
+			{
+				Name: "list-metrics",
+				Usage: "List the metrics exported by the daemon with their labels, only the ones of the given beacon " +
+					"if its id is set. The metrics of a beacon are also served at /metrics/beacon/<id>.\n",
+				Flags: toArray(controlFlag, beaconIDFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("listMetricsCmd")
+					return listMetricsCmd(c, l)
+				},
+			},
+			{
+				Name: "api-usage",
+				Usage: "Report the requests to the public API of each client, by API key, with the ones refused for " +
+					"being over the limits of their tier. Empty if the requests aren't limited, see --api-tier.\n",
+				Flags: toArray(controlFlag),
 				Action: func(c *cli.Context) error {
 					l := log.New(nil, logLevel(c), logJSON(c)).
-						Named("checkConnection")
-					return checkConnection(c, l)
+						Named("apiUsageCmd")
+					return apiUsageCmd(c, l)
 				},
 			},
 			{
-				Name: "remote-status",
-				Usage: "Ask for the statuses of remote nodes indicated by " +
-					"`ADDRESS1 ADDRESS2 ADDRESS3...`, including the network " +
-					"visibility over the rest of the addresses given.",
-				Flags: toArray(controlFlag, jsonFlag, beaconIDFlag, hiddenInsecureFlag),
+				Name: "features",
+				Usage: "List the feature flags of a beacon, after turning one on or off at runtime, or back to its " +
+					"configured value, if asked to. The changes are lost when the daemon restarts, use --feature to " +
+					"keep them.\n",
+				Flags: toArray(controlFlag, beaconIDFlag, featureEnableFlag, featureDisableFlag, featureResetFlag),
 				Action: func(c *cli.Context) error {
 					l := log.New(nil, logLevel(c), logJSON(c)).
-						Named("remoteStatusCmd")
-					return remoteStatusCmd(c, l)
+						Named("featuresCmd")
+					return featuresCmd(c, l)
+				},
+			},
+			{
+				Name: "address-overrides",
+				Usage: "List the addresses the members of the group are dialed at instead of the ones of the group " +
+					"file, e.g. their internal addresses on split-horizon networks, after setting or removing the one " +
+					"of a member if asked to. The overrides are kept across restarts and reshares.\n",
+				Flags: toArray(controlFlag, beaconIDFlag, overrideMemberFlag, overrideAddressFlag, overrideRemoveFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("addressOverridesCmd")
+					return addressOverridesCmd(c, l)
+				},
+			},
+			{
+				Name: "reshare-forecast",
+				Usage: "Show the date the coordinator of the group forecast the next reshare at, or register it with this " +
+					"node as the coordinator and announce it to the members, who are then reminded of it as it " +
+					"approaches and warned if their TLS certificate expires before it.\n",
+				Flags: toArray(controlFlag, beaconIDFlag, forecastAtFlag, forecastInFlag, forecastNoteFlag, forecastClearFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("reshareForecastCmd")
+					return reshareForecastCmd(c, l)
+				},
+			},
+			{
+				Name: "availability",
+				Usage: "Report the availability of each member of the group over a time window: the share of the " +
+					"status samples where it was up and the lag of its chain, from the samples collected by the daemon.\n",
+				Flags: toArray(controlFlag, beaconIDFlag, availabilityWindowFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("availabilityCmd")
+					return availabilityCmd(c, l)
+				},
+			},
+			{
+				Name: "participation",
+				Usage: "List the members whose partials were aggregated into each round of a range, as recorded by the " +
+					"daemon for the rounds it aggregated while the partial-audit feature was enabled.\n",
+				Flags: toArray(controlFlag, beaconIDFlag, participationFromFlag, participationToFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("participationCmd")
+					return participationCmd(c, l)
+				},
+			},
+			{
+				Name: "tombstones",
+				Usage: "List the beacons replaced in the store when correcting the chain, with their original bytes, " +
+					"the beacons replacing them and when they were replaced.\n",
+				Flags: toArray(controlFlag, beaconIDFlag, tombstonesFromFlag, tombstonesToFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("tombstonesCmd")
+					return tombstonesCmd(c, l)
+				},
+			},
+			{
+				Name: "inject-beacon",
+				Usage: "Store a beacon obtained out-of-band, e.g. from the store of another node, to repair a gap of the " +
+					"chain store when the peers are unreachable. The daemon verifies it against the chain first, and " +
+					"refuses it unless the beacon-injection feature is enabled.\n",
+				Flags: toArray(controlFlag, beaconIDFlag, injectBeaconFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("injectBeaconCmd")
+					return injectBeaconCmd(c, l)
+				},
+			},
+			{
+				Name: "list-ids",
+				Usage: "List the beacons configured on the node with their scheme, chain hash and whether they're " +
+					"running, including the ones the daemon didn't load.\n",
+				Flags: toArray(controlFlag, jsonFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("listIDsCmd")
+					return listIDsCmd(c, l)
+				},
+			},
+			{
+				Name: "metadata",
+				Usage: "Read, write, delete or list the values kept by an extension in a namespace of the metadata of " +
+					"the chain store. The namespaces starting with \"drand.\" are used by the node and are read-only.\n",
+				Flags: toArray(controlFlag, beaconIDFlag, metadataNamespaceFlag, metadataKeyFlag, metadataValueFlag,
+					metadataDeleteFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("metadataCmd")
+					return metadataCmd(c, l)
+				},
+			},
+			{
+				Name: "annotations",
+				Usage: "Attach notes to the rounds for the bookkeeping of the operators, e.g. the lottery a round was " +
+					"used for, remove them or list them. They are kept in the chain store, apart from the beacons.\n",
+				Flags: toArray(controlFlag, beaconIDFlag, annotationRoundFlag, annotationNoteFlag, annotationRemoveFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("annotationsCmd")
+					return annotationsCmd(c, l)
+				},
+			},
+			{
+				Name: "countdown",
+				Usage: "Show the time left until the genesis of the chain, or until the transition to the group of the " +
+					"last reshare, with the readiness of each member of the group.\n",
+				Flags: toArray(controlFlag, beaconIDFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("countdownCmd")
+					return countdownCmd(c, l)
+				},
+			},
+			{
+				Name: "entropy",
+				Usage: "Write to stdout a stream of raw bytes derived from the successive beacons, e.g. to feed " +
+					"rngd. The beacons are public: the bytes must be mixed with local entropy, never used alone as a secret.\n",
+				Flags: toArray(controlFlag, beaconIDFlag, entropyBytesFlag, entropyFromFlag, entropyLimitFlag, entropyRateFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("entropyCmd")
+					return entropyCmd(c, l)
+				},
+			},
+			{
+				Name: "make-joinkit",
+				Usage: "Export a single file signed by the identity of the node, with the chain info, a recent verified " +
+					"checkpoint and the addresses of the group members, to bootstrap new followers.\n",
+				Flags: toArray(controlFlag, beaconIDFlag, joinKitOutFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("makeJoinKitCmd")
+					return makeJoinKitCmd(c, l)
+				},
+			},
+			{
+				Name: "stats",
+				Usage: "Compute basic statistics of the randomness over a range of rounds: bit balance, chi-square of " +
+					"the byte frequencies and run lengths. They are only meant to spot gross anomalies.\n",
+				Flags: toArray(controlFlag, beaconIDFlag, statsFromFlag, statsToFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("statsCmd")
+					return statsCmd(c, l)
+				},
+			},
+			{
+				Name: "selftest",
+				Usage: "Check, before starting the daemon, that the key pairs sign and verify, their schemes are " +
+					"available, the stores can be read and written, the clock is in sync, the listeners can be bound " +
+					"and enough members of each group are reachable.\n",
+				Flags: toArray(folderFlag, controlFlag, privListenFlag, pubListenFlag, outboundAddressFlag,
+					beaconIDFlag, storageTypeFlag, pgDSNFlag, ntpServerFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("selfTestCmd")
+					return selfTestCmd(c, l)
+				},
+			},
+			{
+				Name: "bench-verify",
+				Usage: "Measure how many beacons of each scheme, or of the given one, the local hardware verifies per " +
+					"second, one at a time and then in batches, spread over an increasing number of goroutines, e.g. to " +
+					"size the machines following a chain.\n",
+				Flags: toArray(schemeFlag, benchBeaconsFlag, benchParallelismFlag, benchBatchSizeFlag, jsonFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("benchVerifyCmd")
+					return benchVerifyCmd(c, l)
+				},
+			},
+			{
+				Name: "bench-store",
+				Usage: "Measure how fast each storage engine, or the one given, writes and reads synthetic beacons on " +
+					"the local disk, and project the disk usage of the chain over time, e.g. to plan a deployment.\n",
+				Flags: toArray(folderFlag, beaconIDFlag, storageTypeFlag, pgDSNFlag, memDBSizeFlag, schemeFlag,
+					benchStoreBeaconsFlag, benchPeriodFlag, jsonFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("benchStoreCmd")
+					return benchStoreCmd(c, l)
 				},
 			},
 			{
@@ -454,16 +1581,111 @@ var appCommands = []*cli.Command{
 					return schemesCmd(c, l)
 				},
 			},
+			{
+				Name: "capabilities",
+				Usage: "List what the daemon supports for a beacon: its schemes, storage, compressors, protocol versions, " +
+					"optional endpoints and enabled features.\n",
+				Flags: toArray(controlFlag, beaconIDFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("capabilitiesCmd")
+					return capabilitiesCmd(c, l)
+				},
+			},
 			{
 				Name:  "status",
 				Usage: "Get the status of many modules of running the daemon\n",
-				Flags: toArray(controlFlag, jsonFlag, beaconIDFlag, allBeaconsFlag, listIDsFlag),
+				Flags: toArray(controlFlag, jsonFlag, beaconIDFlag, allBeaconsFlag, listIDsFlag, statusWatchFlag,
+					statusIntervalFlag),
 				Action: func(c *cli.Context) error {
 					l := log.New(nil, logLevel(c), logJSON(c)).
 						Named("statusCmd")
 					return statusCmd(c, l)
 				},
 			},
+			{
+				Name: "build-info",
+				Usage: "Collect the build info of all the nodes of the group, flagging the ones running " +
+					"a different or outdated build, e.g. before a coordinated upgrade or DKG.\n",
+				Flags: toArray(controlFlag, jsonFlag, beaconIDFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("buildInfoCmd")
+					return buildInfoCmd(c, l)
+				},
+			},
+			{
+				Name: "dst",
+				Usage: "Collect the domain separation tags the nodes of the group hash the beacons with, flagging the " +
+					"ones whose partials can't be verified by us, e.g. after a custom build.\n",
+				Flags: toArray(controlFlag, jsonFlag, beaconIDFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("dstCmd")
+					return dstCmd(c, l)
+				},
+			},
+			{
+				Name: "rotate-control-token",
+				Usage: "Replace the token authenticating the calls to the control port with a fresh one, saved in the " +
+					"config folder of the daemon.\n",
+				Flags: toArray(controlFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("rotateControlTokenCmd")
+					return rotateControlTokenCmd(c, l)
+				},
+			},
+			{
+				Name: "reload-config",
+				Usage: "Re-read the config file of the daemon and apply the log level, the sync sources, the maximum " +
+					"stale periods and the feature flags without a restart. The other options apply after a restart.\n",
+				Flags: toArray(controlFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("reloadConfigCmd")
+					return reloadConfigCmd(c, l)
+				},
+			},
+			{
+				Name: "log-level",
+				Usage: "List the log level of the daemon and of its named loggers, after changing the one of the daemon " +
+					"or of a named logger if a level is given. The levels apply until the daemon restarts.\n",
+				ArgsUsage: "[level] is the level to set: debug, info, warn or error",
+				Flags:     toArray(controlFlag, loggerNameFlag, loggerRemoveFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("logLevelCmd")
+					return logLevelCmd(c, l)
+				},
+			},
+			{
+				Name:  "upgrade",
+				Usage: "Coordinate the restart of all the nodes of the group, e.g. to upgrade them",
+				Subcommands: []*cli.Command{
+					{
+						Name: "propose",
+						Usage: "Propose an upgrade window to the group, with this node as the coordinator. Nodes " +
+							"restart in turn during that window, never more than n - threshold at the same time.",
+						Flags: toArray(controlFlag, beaconIDFlag, upgradeStartFlag, upgradeWindowFlag),
+						Action: func(c *cli.Context) error {
+							l := log.New(nil, logLevel(c), logJSON(c)).
+								Named("upgradeProposeCmd")
+							return upgradeProposeCmd(c, l)
+						},
+					},
+					{
+						Name:  "accept",
+						Usage: "Accept the upgrade proposed by the coordinator. The daemon stops during its slot of the window.",
+						Flags: toArray(controlFlag, beaconIDFlag),
+						Action: func(c *cli.Context) error {
+							l := log.New(nil, logLevel(c), logJSON(c)).
+								Named("upgradeAcceptCmd")
+							return upgradeAcceptCmd(c, l)
+						},
+					},
+				},
+			},
 			{
 				Name: "reset",
 				Usage: "Resets the local distributed information (share, group file and random beacons). " +
@@ -496,6 +1718,22 @@ var appCommands = []*cli.Command{
 					return checkMigration(c, l)
 				},
 			},
+			{
+				Name: "compact",
+				Usage: "Compacts the database of the beacons, reclaiming the space of the pages freed by deleted " +
+					"rounds and metadata. The daemon MUST be stopped.",
+				Flags: toArray(folderFlag, beaconIDFlag, allBeaconsFlag, ioLimitFlag),
+				Action: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("compactDBCmd")
+					return compactDBCmd(c, l)
+				},
+				Before: func(c *cli.Context) error {
+					l := log.New(nil, logLevel(c), logJSON(c)).
+						Named("compactDBCmd")
+					return checkMigration(c, l)
+				},
+			},
 			{
 				Name:  "backup",
 				Usage: "backs up the primary drand database to a secondary location.",
@@ -587,7 +1825,8 @@ func CLI() *cli.App {
 	// we need to copy the underlying flags to avoid races
 	verbFlag := *verboseFlag
 	foldFlag := *folderFlag
-	app.Flags = toArray(&verbFlag, &foldFlag)
+	tokenFlag := *controlTokenFlag
+	app.Flags = toArray(&verbFlag, &foldFlag, &tokenFlag)
 	return app
 }
 
@@ -687,7 +1926,8 @@ func keygenCmd(c *cli.Context, l log.Logger) error {
 
 	addr := args.First()
 	var validID = regexp.MustCompile(`:\d+$`)
-	if !validID.MatchString(addr) {
+	// the port of a SRV address comes from its DNS records
+	if !key.IsSRVAddress(addr) && !validID.MatchString(addr) {
 		fmt.Println("Invalid port:", addr)
 		addr = addr + ":" + askPort(c)
 	}
@@ -697,15 +1937,14 @@ func keygenCmd(c *cli.Context, l log.Logger) error {
 		return err
 	}
 
-	fmt.Println("Generating private / public key pair")
-	priv, err := key.NewKeyPair(addr, sch)
+	priv, err := newKeyPair(c, addr, sch)
 	if err != nil {
 		return err
 	}
 
 	config := contextToConfig(c, l)
 	beaconID := getBeaconID(c)
-	fileStore := key.NewFileStore(config.ConfigFolderMB(), beaconID)
+	fileStore := key.NewFileStore(config.ConfigFolderMB(), common.BeaconID(beaconID))
 
 	if _, err := fileStore.LoadKeyPair(); err == nil {
 		keyDirectory := path.Join(config.ConfigFolderMB(), beaconID)
@@ -735,6 +1974,33 @@ func keygenCmd(c *cli.Context, l log.Logger) error {
 	return nil
 }
 
+// newKeyPair generates a random keypair, or derives it from a mnemonic when asked to
+func newKeyPair(c *cli.Context, addr string, sch *crypto.Scheme) (*key.Pair, error) {
+	switch {
+	case c.Bool(mnemonicFlag.Name) && c.Bool(recoverFlag.Name):
+		return nil, fmt.Errorf("--%s and --%s are mutually exclusive", mnemonicFlag.Name, recoverFlag.Name)
+	case c.Bool(mnemonicFlag.Name):
+		mnemonic, err := key.NewMnemonic()
+		if err != nil {
+			return nil, err
+		}
+		fmt.Println("Generating private / public key pair from a new mnemonic")
+		fmt.Fprintf(c.App.Writer, "\nMnemonic of the keypair, write it down and keep it secret:\n\n\t%s\n\n", mnemonic)
+		return key.NewKeyPairFromMnemonic(mnemonic, addr, sch)
+	case c.Bool(recoverFlag.Name):
+		fmt.Fprintf(c.App.Writer, "Enter the mnemonic of the keypair: ")
+		mnemonic, err := bufio.NewReader(c.App.Reader).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		fmt.Println("Recovering private / public key pair from its mnemonic")
+		return key.NewKeyPairFromMnemonic(mnemonic, addr, sch)
+	default:
+		fmt.Println("Generating private / public key pair")
+		return key.NewKeyPair(addr, sch)
+	}
+}
+
 func groupOut(c *cli.Context, group *key.Group) error {
 	if c.IsSet("out") {
 		groupPath := c.String("out")
@@ -778,8 +2044,7 @@ func checkConnection(c *cli.Context, lg log.Logger) error {
 		beaconID = common.GetCanonicalBeaconID(group.ID)
 	} else if c.Args().Present() {
 		for _, serverAddr := range c.Args().Slice() {
-			_, _, err := gonet.SplitHostPort(serverAddr)
-			if err != nil {
+			if err := key.ValidateAddress(serverAddr); err != nil {
 				return fmt.Errorf("error for address %s: %w", serverAddr, err)
 			}
 			names = append(names, serverAddr)
@@ -907,7 +2172,7 @@ func deleteBeaconCmd(c *cli.Context, l log.Logger) error {
 			}
 
 			for round := startRound; round <= lastBeacon.Round; round++ {
-				err := store.Del(ctx, round)
+				err := store.Del(ctx, common.Round(round))
 				if err != nil {
 					return fmt.Errorf("beacon id [%s] - error deleting round %d: %w", beaconID, round, err)
 				}
@@ -922,6 +2187,28 @@ func deleteBeaconCmd(c *cli.Context, l log.Logger) error {
 	return err
 }
 
+// compactDBCmd compacts the bolt databases of the beacons of a stopped node
+func compactDBCmd(c *cli.Context, l log.Logger) error {
+	limits, err := core.ParseIOLimits(c.StringSlice(ioLimitFlag.Name))
+	if err != nil {
+		return err
+	}
+	ctx := iolimit.WithLimiter(c.Context, iolimit.NewLimiter(clock.NewRealClock(), limits[core.IOClassCompact]))
+
+	stores, err := getDBStoresPaths(c, l)
+	if err != nil {
+		return err
+	}
+	for beaconID, storePath := range stores {
+		before, after, err := boltdb.Compact(ctx, path.Join(storePath, core.DefaultDBFolder))
+		if err != nil {
+			return fmt.Errorf("beacon id [%s] - unable to compact the database: %w", beaconID, err)
+		}
+		fmt.Fprintf(c.App.Writer, "beacon id [%s] - database compacted from %d to %d bytes\n", beaconID, before, after)
+	}
+	return nil
+}
+
 func isVerbose(c *cli.Context) bool {
 	return c.IsSet(verboseFlag.Name)
 }
@@ -952,6 +2239,9 @@ func contextToConfig(c *cli.Context, l log.Logger) *core.Config {
 	if c.IsSet(privListenFlag.Name) {
 		opts = append(opts, core.WithPrivateListenAddress(c.String(privListenFlag.Name)))
 	}
+	if c.IsSet(outboundAddressFlag.Name) {
+		opts = append(opts, core.WithOutboundAddresses(c.StringSlice(outboundAddressFlag.Name)))
+	}
 
 	port := c.String(controlFlag.Name)
 	if port != "" {
@@ -966,6 +2256,18 @@ func contextToConfig(c *cli.Context, l log.Logger) *core.Config {
 		opts = append(opts, core.WithTracesEndpoint(c.String(tracesFlag.Name)))
 	}
 
+	if c.IsSet(metricsFlag.Name) {
+		opts = append(opts, core.WithMetricsAddress(c.String(metricsFlag.Name)))
+	}
+
+	if c.IsSet(otlpMetricsFlag.Name) {
+		opts = append(opts, core.WithOTLPMetricsEndpoint(c.String(otlpMetricsFlag.Name)))
+	}
+
+	if c.IsSet(otlpMetricsIntervalFlag.Name) {
+		opts = append(opts, core.WithOTLPMetricsInterval(c.Duration(otlpMetricsIntervalFlag.Name)))
+	}
+
 	if c.IsSet(tracesProbabilityFlag.Name) {
 		opts = append(opts, core.WithTracesProbability(c.Float64(tracesProbabilityFlag.Name)))
 	} else {
@@ -973,6 +2275,121 @@ func contextToConfig(c *cli.Context, l log.Logger) *core.Config {
 		opts = append(opts, core.WithTracesProbability(0.05))
 	}
 
+	if c.IsSet(maxRequestSizeFlag.Name) {
+		opts = append(opts, core.WithMaxRequestSize(c.Int(maxRequestSizeFlag.Name)))
+	}
+	if c.IsSet(requestTimeoutFlag.Name) {
+		opts = append(opts, core.WithRequestTimeout(c.Duration(requestTimeoutFlag.Name)))
+	}
+	if c.IsSet(maxStatusNodesFlag.Name) {
+		opts = append(opts, core.WithMaxStatusNodes(c.Int(maxStatusNodesFlag.Name)))
+	}
+	if c.IsSet(maxStalePeriodsFlag.Name) {
+		opts = append(opts, core.WithMaxStalePeriods(c.Uint64(maxStalePeriodsFlag.Name)))
+	}
+	if c.IsSet(prefetchLeadFlag.Name) {
+		opts = append(opts, core.WithPrefetchLead(c.Duration(prefetchLeadFlag.Name)))
+	}
+	if c.IsSet(heartbeatPeriodFlag.Name) {
+		opts = append(opts, core.WithHeartbeatPeriod(c.Duration(heartbeatPeriodFlag.Name)))
+	}
+	if c.IsSet(subBeaconFlag.Name) {
+		opts = append(opts, core.WithSubBeacons(c.StringSlice(subBeaconFlag.Name)))
+	}
+	if c.IsSet(derivedChainFlag.Name) {
+		opts = append(opts, core.WithDerivedChains(c.StringSlice(derivedChainFlag.Name)))
+	}
+	if c.IsSet(publicKeyCacheSizeFlag.Name) {
+		opts = append(opts, core.WithPublicKeyCacheSize(c.Int(publicKeyCacheSizeFlag.Name)))
+	}
+	if c.IsSet(syncMaxInFlightFlag.Name) {
+		opts = append(opts, core.WithSyncMaxInFlight(c.Int(syncMaxInFlightFlag.Name)))
+	}
+	if c.IsSet(syncMemoryBudgetFlag.Name) {
+		opts = append(opts, core.WithSyncMemoryBudget(c.Int64(syncMemoryBudgetFlag.Name)))
+	}
+	if c.IsSet(syncPreferFlag.Name) || c.IsSet(syncDenyFlag.Name) || c.IsSet(syncPeerRegionFlag.Name) {
+		opts = append(opts, core.WithSyncSources(c.StringSlice(syncPreferFlag.Name), c.StringSlice(syncDenyFlag.Name),
+			c.StringSlice(syncPeerRegionFlag.Name)))
+	}
+	if c.IsSet(slowStoreThresholdFlag.Name) {
+		opts = append(opts, core.WithSlowStoreThreshold(c.Duration(slowStoreThresholdFlag.Name)))
+	}
+	if c.IsSet(ioLimitFlag.Name) {
+		opts = append(opts, core.WithIOLimits(c.StringSlice(ioLimitFlag.Name)))
+	}
+	if c.IsSet(isolateBeaconFlag.Name) {
+		opts = append(opts, core.WithBeaconIsolation(c.StringSlice(isolateBeaconFlag.Name)))
+	}
+	if c.IsSet(mirrorToFlag.Name) {
+		opts = append(opts, core.WithMirrorTarget(c.String(mirrorToFlag.Name)))
+	}
+	if c.IsSet(featureFlag.Name) {
+		opts = append(opts, core.WithFeatureFlags(c.StringSlice(featureFlag.Name)))
+	}
+	if c.IsSet(statusSampleIntervalFlag.Name) {
+		opts = append(opts, core.WithStatusSampleInterval(c.Duration(statusSampleIntervalFlag.Name)))
+	}
+	if c.IsSet(udpListenFlag.Name) {
+		opts = append(opts, core.WithUDPListenAddress(c.String(udpListenFlag.Name)))
+	}
+	if c.IsSet(pushToFlag.Name) {
+		opts = append(opts, core.WithPushTargets(c.StringSlice(pushToFlag.Name)))
+	}
+	if c.IsSet(acceptPushFlag.Name) {
+		opts = append(opts, core.WithAcceptedPushes(c.StringSlice(acceptPushFlag.Name)))
+	}
+	if c.IsSet(shareRefreshIntervalFlag.Name) {
+		opts = append(opts, core.WithShareRefreshInterval(c.Duration(shareRefreshIntervalFlag.Name)))
+	}
+	if c.IsSet(autoRefreshFlag.Name) {
+		opts = append(opts, core.WithAutoRefresh(c.Bool(autoRefreshFlag.Name)))
+	}
+	if c.IsSet(minRefreshIntervalFlag.Name) {
+		opts = append(opts, core.WithMinRefreshInterval(c.Duration(minRefreshIntervalFlag.Name)))
+	}
+	if c.IsSet(v1CompatFlag.Name) {
+		opts = append(opts, core.WithV1Compat(c.StringSlice(v1CompatFlag.Name)))
+	}
+	if c.IsSet(shedLatencyFlag.Name) {
+		opts = append(opts, core.WithLoadShedding(c.Duration(shedLatencyFlag.Name)))
+	}
+	if c.IsSet(apiTierFlag.Name) || c.IsSet(apiKeyFlag.Name) {
+		opts = append(opts, core.WithAPIQuotas(c.StringSlice(apiTierFlag.Name), c.StringSlice(apiKeyFlag.Name)))
+	}
+	if c.IsSet(redisURLFlag.Name) {
+		opts = append(opts, core.WithRedis(c.String(redisURLFlag.Name)))
+	}
+	if c.IsSet(shutdownOrderFlag.Name) {
+		opts = append(opts, core.WithShutdownOrder(c.StringSlice(shutdownOrderFlag.Name)))
+	}
+	if c.IsSet(minGenesisDelayFlag.Name) {
+		opts = append(opts, core.WithMinGenesisDelay(c.Duration(minGenesisDelayFlag.Name)))
+	}
+	if c.IsSet(controlAuthFlag.Name) {
+		opts = append(opts, core.WithControlAuth(c.Bool(controlAuthFlag.Name)))
+	}
+	if c.IsSet(controlTokenFlag.Name) {
+		opts = append(opts, core.WithControlToken(c.String(controlTokenFlag.Name)))
+	}
+	if c.IsSet(controlRoleFlag.Name) || c.IsSet(controlRoleTokenFlag.Name) {
+		opts = append(opts, core.WithControlRoles(c.StringSlice(controlRoleFlag.Name),
+			c.StringSlice(controlRoleTokenFlag.Name)))
+	}
+	if c.IsSet(corsOriginFlag.Name) || c.IsSet(corsHeaderFlag.Name) || c.IsSet(corsMaxAgeFlag.Name) {
+		opts = append(opts, core.WithCORS(dhttp.CORS{
+			AllowedOrigins: c.StringSlice(corsOriginFlag.Name),
+			AllowedHeaders: c.StringSlice(corsHeaderFlag.Name),
+			MaxAge:         c.Duration(corsMaxAgeFlag.Name),
+		}))
+	}
+	if c.IsSet(securityHeadersFlag.Name) || c.IsSet(hstsMaxAgeFlag.Name) {
+		opts = append(opts, core.WithSecurityHeaders(dhttp.SecurityHeaders{
+			Enabled:    c.Bool(securityHeadersFlag.Name),
+			HSTSMaxAge: c.Duration(hstsMaxAgeFlag.Name),
+		}))
+	}
+
 	switch chain.StorageType(c.String(storageTypeFlag.Name)) {
 	case chain.BoltDB:
 		opts = append(opts, core.WithDBStorageEngine(chain.BoltDB))
@@ -990,6 +2407,17 @@ func contextToConfig(c *cli.Context, l log.Logger) *core.Config {
 		)
 	default:
 		// we have a default to "bolt" in storageTypeFlag, we don't set it if it's invalid so that users are alerted
+		if name := c.String(storageTypeFlag.Name); name != "" {
+			if _, ok := plugin.LookupStore(name); ok {
+				opts = append(opts, core.WithDBStorageEngine(chain.StorageType(name)))
+			}
+		}
+	}
+	if c.IsSet(signerFlag.Name) {
+		opts = append(opts, core.WithSigner(c.String(signerFlag.Name)))
+	}
+	if c.IsSet(publisherFlag.Name) {
+		opts = append(opts, core.WithPublishers(c.StringSlice(publisherFlag.Name)))
 	}
 
 	conf := core.NewConfig(l, opts...)
@@ -1053,7 +2481,7 @@ func getKeyStores(c *cli.Context, l log.Logger) (map[string]key.Store, error) {
 
 	beaconID := getBeaconID(c)
 
-	store := key.NewFileStore(conf.ConfigFolderMB(), beaconID)
+	store := key.NewFileStore(conf.ConfigFolderMB(), common.BeaconID(beaconID))
 	stores := map[string]key.Store{beaconID: store}
 
 	return stores, nil
@@ -1062,3 +2490,89 @@ func getKeyStores(c *cli.Context, l log.Logger) (map[string]key.Store, error) {
 func deprecatedShareCommand(_ *cli.Context) error {
 	return errors.New("the share command has been removed! Please use `drand dkg` instead")
 }
+
+func shareDestroyCmd(c *cli.Context, l log.Logger) error {
+	beaconID := getBeaconID(c)
+
+	// wiping the files of a running beacon would leave its share in memory
+	if client, err := controlClient(c, l); err == nil {
+		status, err := client.Status(beaconID)
+		if err == nil && status.GetBeacon().GetIsRunning() {
+			return fmt.Errorf("beacon id [%s] is still running, stop it before destroying its share", beaconID)
+		}
+	}
+
+	fmt.Fprintf(c.App.Writer, "You are about to irrevocably destroy your local share of beacon id [%s]. "+
+		"Are you sure you wish to perform this operation? [y/N]", beaconID)
+	reader := bufio.NewReader(c.App.Reader)
+
+	answer, err := reader.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("error reading: %w", err)
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if answer != "y" {
+		fmt.Fprintf(c.App.Writer, "drand: not destroying the share.")
+		return nil
+	}
+
+	stores, err := getKeyStores(c, l)
+	if err != nil {
+		return err
+	}
+	store := stores[beaconID]
+
+	pair, err := store.LoadKeyPair()
+	if err != nil {
+		return fmt.Errorf("beacon id [%s] - unable to load the longterm key pair: %w", beaconID, err)
+	}
+	share, err := store.LoadShare()
+	if err != nil {
+		return fmt.Errorf("beacon id [%s] - unable to load the share, is there one to destroy? %w", beaconID, err)
+	}
+	attestation, err := key.NewShareDestruction(common.BeaconID(beaconID), pair, share, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := store.WipeShare(); err != nil {
+		return fmt.Errorf("beacon id [%s] - %w", beaconID, err)
+	}
+	fmt.Fprintf(c.App.Writer, "\ndrand: beacon id [%s] - share destroyed\n", beaconID)
+
+	if c.IsSet(attestationOutFlag.Name) {
+		filePath := c.String(attestationOutFlag.Name)
+		if err := key.Save(filePath, attestation, false); err != nil {
+			return fmt.Errorf("drand: can't save the destruction attestation: %w", err)
+		}
+		fmt.Fprintf(c.App.Writer, "Destruction attestation saved at %s\n", filePath)
+		return nil
+	}
+	return toml.NewEncoder(c.App.Writer).Encode(attestation.TOML())
+}
+
+func verifyShareDestructionCmd(c *cli.Context) error {
+	if !c.Args().Present() {
+		return errors.New("the path to the destruction attestation is required")
+	}
+
+	attestation := new(key.ShareDestruction)
+	if err := key.Load(c.Args().First(), attestation); err != nil {
+		return fmt.Errorf("loading the destruction attestation failed: %w", err)
+	}
+	group := new(key.Group)
+	if err := key.Load(c.String(attestationGroupFlag.Name), group); err != nil {
+		return fmt.Errorf("loading group failed: %w", err)
+	}
+	if common.GetCanonicalBeaconID(group.ID) != common.GetCanonicalBeaconID(attestation.BeaconID) {
+		return fmt.Errorf("the attestation is for beacon id [%s], not [%s]", attestation.BeaconID, group.ID)
+	}
+	if err := attestation.Verify(group); err != nil {
+		return fmt.Errorf("invalid destruction attestation: %w", err)
+	}
+
+	fmt.Fprintf(c.App.Writer, "%s destroyed its share of beacon id [%s] (index %d) at %s\n", attestation.Address,
+		common.GetCanonicalBeaconID(attestation.BeaconID), attestation.Index,
+		time.Unix(attestation.Timestamp, 0).UTC().Format(time.RFC3339))
+	return nil
+}
@@ -0,0 +1,303 @@
+package drand
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+
+	"github.com/drand/drand/v2/common/log"
+	"github.com/drand/drand/v2/internal/core"
+	"github.com/drand/drand/v2/internal/secrets"
+)
+
+var configFileFlag = &cli.StringFlag{
+	Name: "config",
+	Usage: "Read the options of the daemon from this TOML or YAML file, whose keys are the names of the flags. " +
+		"The flags and the environment variables given take precedence over the file. " +
+		"See `drand config schema` for the schema of the file and `drand config check` to validate it. " +
+		"The values can reference environment variables as ${NAME}, or be secrets such as " +
+		"secretref://vault/secret/data/drand#pg-password, secretref://file//run/secrets/name or secretref://env/NAME, " +
+		"resolved at startup.",
+	EnvVars: []string{"DRAND_CONFIG"},
+}
+
+// loadConfigFile sets the flags of the command which weren't given on the command line or through the environment to
+// the values of the config file, if there is one. Unknown options and invalid values are refused.
+// The references to environment variables and secrets in the values are resolved, and the options holding one are
+// returned so that they are never printed.
+func loadConfigFile(c *cli.Context) (map[string]bool, error) {
+	if !c.IsSet(configFileFlag.Name) {
+		return nil, nil
+	}
+	file := c.String(configFileFlag.Name)
+	options, err := readConfigFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	referenced := make(map[string]bool)
+	for _, name := range names {
+		flag := configFlag(c.Command.Flags, name)
+		if flag == nil {
+			return nil, fmt.Errorf("unknown option %q in the config file %s", name, file)
+		}
+		if c.IsSet(name) {
+			continue
+		}
+		values, err := configValues(options[name])
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %q in the config file %s: %w", name, file, err)
+		}
+		for _, v := range values {
+			if secrets.IsReference(v) {
+				referenced[flag.Names()[0]] = true
+				if v, err = secrets.Resolve(c.Context, v); err != nil {
+					return nil, fmt.Errorf("unable to resolve %q in the config file %s: %w", name, file, err)
+				}
+			}
+			if err := c.Set(name, v); err != nil {
+				return nil, fmt.Errorf("invalid value for %q in the config file %s: %w", name, file, err)
+			}
+		}
+	}
+	return referenced, nil
+}
+
+func readConfigFile(file string) (map[string]interface{}, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the config file: %w", err)
+	}
+	options := make(map[string]interface{})
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &options)
+	case ".toml":
+		err = toml.Unmarshal(content, &options)
+	default:
+		return nil, fmt.Errorf("unknown format of the config file %s, use a .toml, .yaml or .yml file", file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", file, err)
+	}
+	return options, nil
+}
+
+// configFlag returns the flag of the option with the given name, nil if there is none. The config file itself can't
+// be given in a config file.
+func configFlag(flags []cli.Flag, name string) cli.Flag {
+	for _, f := range flags {
+		if f == configFileFlag {
+			continue
+		}
+		for _, n := range f.Names() {
+			if n == name {
+				return f
+			}
+		}
+	}
+	return nil
+}
+
+// configValues returns the values of an option as given on the command line, a list giving one value per item
+func configValues(v interface{}) ([]string, error) {
+	switch v := v.(type) {
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, fmt.Sprint(item))
+		}
+		return values, nil
+	case map[string]interface{}:
+		return nil, errors.New("tables aren't supported")
+	default:
+		return []string{fmt.Sprint(v)}, nil
+	}
+}
+
+// configOptions returns the options which can be given in a config file, sorted by name
+func configOptions(flags []cli.Flag) []cli.Flag {
+	options := make([]cli.Flag, 0, len(flags))
+	for _, f := range flags {
+		if f == configFileFlag {
+			continue
+		}
+		if v, ok := f.(cli.VisibleFlag); ok && !v.IsVisible() {
+			continue
+		}
+		options = append(options, f)
+	}
+	sort.Slice(options, func(i, j int) bool { return options[i].Names()[0] < options[j].Names()[0] })
+	return options
+}
+
+// configValue returns the effective value of the option
+func configValue(c *cli.Context, f cli.Flag) interface{} {
+	name := f.Names()[0]
+	switch f.(type) {
+	case *cli.BoolFlag:
+		return c.Bool(name)
+	case *cli.IntFlag, *cli.Int64Flag, *cli.Uint64Flag, *cli.Float64Flag:
+		return c.Value(name)
+	case *cli.DurationFlag:
+		return c.Duration(name).String()
+	case *cli.StringSliceFlag:
+		return c.StringSlice(name)
+	default:
+		return c.String(name)
+	}
+}
+
+// configType returns the JSON schema type of the values of the option
+func configType(f cli.Flag) string {
+	switch f.(type) {
+	case *cli.BoolFlag:
+		return "boolean"
+	case *cli.IntFlag, *cli.Int64Flag, *cli.Uint64Flag:
+		return "integer"
+	case *cli.Float64Flag:
+		return "number"
+	case *cli.StringSliceFlag:
+		return "array"
+	default:
+		// durations are given as strings such as 1m30s
+		return "string"
+	}
+}
+
+// redactedValue is printed instead of the values resolved from a reference, which can be secrets
+const redactedValue = "<redacted>"
+
+func configCheckCmd(c *cli.Context, l log.Logger) error {
+	referenced, err := loadConfigFile(c)
+	if err != nil {
+		return err
+	}
+	conf, err := checkedConfig(c, l)
+	if err != nil {
+		return err
+	}
+	if err := conf.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	effective := make(map[string]interface{})
+	for _, f := range configOptions(c.Command.Flags) {
+		if referenced[f.Names()[0]] {
+			effective[f.Names()[0]] = redactedValue
+			continue
+		}
+		effective[f.Names()[0]] = configValue(c, f)
+	}
+	fmt.Fprintln(c.App.Writer, "# the configuration is valid, its effective options are:")
+	return toml.NewEncoder(c.App.Writer).Encode(effective)
+}
+
+// checkedConfig builds the configuration of the daemon, turning the panics of the options refusing invalid values
+// into errors
+func checkedConfig(c *cli.Context, l log.Logger) (conf *core.Config, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid configuration: %v", r)
+		}
+	}()
+	return contextToConfig(c, l), nil
+}
+
+func configSchemaCmd(c *cli.Context) error {
+	properties := make(map[string]interface{})
+	for _, f := range configOptions(startFlags) {
+		property := map[string]interface{}{"type": configType(f)}
+		if configType(f) == "array" {
+			property["items"] = map[string]string{"type": "string"}
+		}
+		if d, ok := f.(cli.DocGenerationFlag); ok {
+			property["description"] = d.GetUsage()
+			if env := d.GetEnvVars(); len(env) > 0 {
+				property["description"] = fmt.Sprintf("%s Overridden by $%s.", d.GetUsage(), env[0])
+			}
+		}
+		properties[f.Names()[0]] = property
+	}
+	schema := map[string]interface{}{
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"title":                "drand daemon configuration",
+		"description":          "Options of `drand start`, given with --config in a TOML or YAML file.",
+		"type":                 "object",
+		"additionalProperties": false,
+		"properties":           properties,
+	}
+	enc := json.NewEncoder(c.App.Writer)
+	enc.SetIndent("", "  ")
+	return enc.Encode(schema)
+}
+
+// givenOptions returns the options given on the command line or through the environment, the config file included,
+// before the file is loaded, so that they keep their precedence over the file when it is reloaded
+func givenOptions(c *cli.Context) map[string][]string {
+	given := make(map[string][]string)
+	for _, f := range c.Command.Flags {
+		name := f.Names()[0]
+		if !c.IsSet(name) {
+			continue
+		}
+		if _, ok := f.(*cli.StringSliceFlag); ok {
+			given[name] = c.StringSlice(name)
+			continue
+		}
+		given[name] = []string{fmt.Sprint(configValue(c, f))}
+	}
+	return given
+}
+
+// configReloader re-reads the config file the daemon was started with, nil if there is none. The options given on
+// the command line or through the environment keep their precedence over the file.
+func configReloader(c *cli.Context, given map[string][]string) core.ConfigReloader {
+	if !c.IsSet(configFileFlag.Name) {
+		return nil
+	}
+	return func() (*core.Config, error) {
+		set := flag.NewFlagSet(c.Command.Name, flag.ContinueOnError)
+		for _, f := range c.Command.Flags {
+			if err := f.Apply(set); err != nil {
+				return nil, err
+			}
+		}
+		var parent *cli.Context
+		if lineage := c.Lineage(); len(lineage) > 1 {
+			parent = lineage[1]
+		}
+		reloaded := cli.NewContext(c.App, set, parent)
+		reloaded.Command = c.Command
+		for name, values := range given {
+			// the environment is applied with the flags
+			if reloaded.IsSet(name) {
+				continue
+			}
+			for _, v := range values {
+				if err := reloaded.Set(name, v); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if _, err := loadConfigFile(reloaded); err != nil {
+			return nil, err
+		}
+		return checkedConfig(reloaded, log.New(nil, logLevel(reloaded), logJSON(reloaded)))
+	}
+}
@@ -6,6 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path"
+	"slices"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -13,7 +17,11 @@ import (
 	"github.com/briandowns/spinner"
 	json "github.com/nikkolasg/hexjson"
 	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/drand/drand/v2/common"
 	"github.com/drand/drand/v2/common/chain"
 	"github.com/drand/drand/v2/common/key"
 	"github.com/drand/drand/v2/common/log"
@@ -42,6 +50,29 @@ func loadCmd(c *cli.Context, l log.Logger) error {
 	return nil
 }
 
+func removeBeaconCmd(c *cli.Context, l log.Logger) error {
+	if !c.IsSet(beaconIDFlag.Name) {
+		return fmt.Errorf("the id of the beacon to remove must be given with --%s", beaconIDFlag.Name)
+	}
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+
+	beaconID := getBeaconID(c)
+	resp, err := client.RemoveBeaconID(beaconID, c.Bool(archiveFlag.Name), c.Bool(confirmRemoveFlag.Name))
+	if err != nil {
+		return fmt.Errorf("could not remove the beacon [%s]: %w", beaconID, err)
+	}
+
+	if resp.GetArchivedTo() != "" {
+		fmt.Fprintf(c.App.Writer, "Beacon [%s] was removed, its folder was archived to %s.\n", beaconID, resp.GetArchivedTo())
+	} else {
+		fmt.Fprintf(c.App.Writer, "Beacon [%s] was removed, its folder %s was deleted.\n", beaconID, resp.GetFolder())
+	}
+	return nil
+}
+
 func remoteStatusCmd(c *cli.Context, l log.Logger) error {
 	client, err := controlClient(c, l)
 	if err != nil {
@@ -58,7 +89,7 @@ func remoteStatusCmd(c *cli.Context, l log.Logger) error {
 		}
 	}
 
-	resp, err := client.RemoteStatus(c.Context, addresses, beaconID)
+	resp, err := client.RemoteStatus(c.Context, addresses, beaconID, !c.Bool(cachedConnectivityFlag.Name))
 	if err != nil {
 		return err
 	}
@@ -109,6 +140,745 @@ func pingpongCmd(c *cli.Context, l log.Logger) error {
 	return nil
 }
 
+func buildInfoCmd(c *cli.Context, l log.Logger) error {
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.GroupBuildInfo(getBeaconID(c))
+	if err != nil {
+		return fmt.Errorf("drand: can't get the build info of the group ... %w", err)
+	}
+
+	if c.IsSet(jsonFlag.Name) {
+		str, err := json.Marshal(resp)
+		if err != nil {
+			return fmt.Errorf("cannot marshal the response ... %w", err)
+		}
+		fmt.Fprintf(c.App.Writer, "%s \n", string(str))
+		return nil
+	}
+
+	fmt.Fprintf(c.App.Writer, "Local build: %s\n", formatBuildInfo(resp.GetLocal()))
+	for _, n := range resp.GetNodes() {
+		switch {
+		case n.GetError() != "":
+			fmt.Fprintf(c.App.Writer, " - %s -> X unreachable: %s\n", n.GetAddress(), n.GetError())
+		case n.GetBuildInfo() == nil:
+			fmt.Fprintf(c.App.Writer, " - %s -> X no build info reported, likely outdated\n", n.GetAddress())
+		case n.GetOutdated():
+			fmt.Fprintf(c.App.Writer, " - %s -> X outdated: %s\n", n.GetAddress(), formatBuildInfo(n.GetBuildInfo()))
+		case n.GetMismatch():
+			fmt.Fprintf(c.App.Writer, " - %s -> ! mismatch: %s\n", n.GetAddress(), formatBuildInfo(n.GetBuildInfo()))
+		default:
+			fmt.Fprintf(c.App.Writer, " - %s -> OK\n", n.GetAddress())
+		}
+	}
+	return nil
+}
+
+func dstCmd(c *cli.Context, l log.Logger) error {
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.GroupDST(getBeaconID(c))
+	if err != nil {
+		return fmt.Errorf("drand: can't get the domain separation tags of the group ... %w", err)
+	}
+
+	if c.IsSet(jsonFlag.Name) {
+		str, err := json.Marshal(resp)
+		if err != nil {
+			return fmt.Errorf("cannot marshal the response ... %w", err)
+		}
+		fmt.Fprintf(c.App.Writer, "%s \n", string(str))
+		return nil
+	}
+
+	fmt.Fprintf(c.App.Writer, "Local: %s\n", formatDST(resp.GetLocal()))
+	for _, n := range resp.GetNodes() {
+		switch {
+		case n.GetError() != "":
+			fmt.Fprintf(c.App.Writer, " - %s -> X unreachable: %s\n", n.GetAddress(), n.GetError())
+		case n.GetDst() == nil:
+			fmt.Fprintf(c.App.Writer, " - %s -> ? no domain separation tag reported, likely outdated\n", n.GetAddress())
+		case n.GetMismatch():
+			fmt.Fprintf(c.App.Writer, " - %s -> X mismatch: %s\n", n.GetAddress(), formatDST(n.GetDst()))
+		default:
+			fmt.Fprintf(c.App.Writer, " - %s -> OK\n", n.GetAddress())
+		}
+	}
+	return nil
+}
+
+func rotateControlTokenCmd(c *cli.Context, l log.Logger) error {
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.RotateControlToken()
+	if err != nil {
+		return fmt.Errorf("drand: can't rotate the token of the control port ... %w", err)
+	}
+	fmt.Fprintf(c.App.Writer, "The token of the control port was rotated and saved in the config folder of the daemon, "+
+		"the previous one is refused from now on:\n%s\n", resp.GetToken())
+	return nil
+}
+
+func reloadConfigCmd(c *cli.Context, l log.Logger) error {
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.ReloadConfig()
+	if err != nil {
+		return fmt.Errorf("drand: can't reload the configuration ... %w", err)
+	}
+	if len(resp.GetChanged()) == 0 {
+		fmt.Fprintln(c.App.Writer, "The configuration was reloaded, none of the options which apply without a restart changed")
+	} else {
+		fmt.Fprintf(c.App.Writer, "The configuration was reloaded, changed: %s\n", strings.Join(resp.GetChanged(), ", "))
+	}
+	if len(resp.GetRestartRequired()) > 0 {
+		fmt.Fprintf(c.App.Writer, "Restart required to apply: %s\n", strings.Join(resp.GetRestartRequired(), ", "))
+	}
+	return nil
+}
+
+func logLevelCmd(c *cli.Context, l log.Logger) error {
+	logger, remove := c.String(loggerNameFlag.Name), c.Bool(loggerRemoveFlag.Name)
+	level := c.Args().First()
+	switch {
+	case remove && logger == "":
+		return fmt.Errorf("the --%s to remove the level of must be given", loggerNameFlag.Name)
+	case remove && level != "":
+		return fmt.Errorf("no level can be given with --%s", loggerRemoveFlag.Name)
+	case !remove && logger != "" && level == "":
+		return fmt.Errorf("the level of the %s logger must be given", logger)
+	}
+
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+	resp, err := client.LogLevels(logger, level, remove)
+	if err != nil {
+		return fmt.Errorf("drand: can't change the log level ... %w", err)
+	}
+	resp.Metadata = nil
+	return printJSON(c.App.Writer, resp)
+}
+
+func formatDST(dst *control.SchemeDST) string {
+	s := fmt.Sprintf("%s with %q (fingerprint %s)", dst.GetScheme(), dst.GetDst(), dst.GetFingerprint())
+	if dst.GetCustom() {
+		s += ", differs from the released builds"
+	}
+	return s
+}
+
+func formatBuildInfo(info *control.BuildInfo) string {
+	v := info.GetNodeVersion()
+	return fmt.Sprintf("%d.%d.%d (commit %q, date %q, tags %q, %s)",
+		v.GetMajor(), v.GetMinor(), v.GetPatch(), info.GetCommit(), info.GetBuildDate(), info.GetBuildTags(), info.GetGoVersion())
+}
+
+func upgradeProposeCmd(c *cli.Context, l log.Logger) error {
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now().Add(c.Duration(upgradeStartFlag.Name))
+	status, err := client.StartUpgrade(getBeaconID(c), start, start.Add(c.Duration(upgradeWindowFlag.Name)))
+	if err != nil {
+		return fmt.Errorf("drand: can't propose the upgrade ... %w", err)
+	}
+	fmt.Fprintf(c.App.Writer, "Upgrade proposed to the group, this node will restart at %s\n",
+		time.Unix(status.GetRestartAt(), 0).UTC().Format(time.RFC3339))
+	fmt.Fprintf(c.App.Writer, "Follow the acknowledgements of the members with `drand util status`\n")
+	return nil
+}
+
+func upgradeAcceptCmd(c *cli.Context, l log.Logger) error {
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+
+	status, err := client.AcceptUpgrade(getBeaconID(c))
+	if err != nil {
+		return fmt.Errorf("drand: can't accept the upgrade ... %w", err)
+	}
+	fmt.Fprintf(c.App.Writer, "Upgrade accepted, the daemon will stop at %s for its binary to be upgraded\n",
+		time.Unix(status.GetRestartAt(), 0).UTC().Format(time.RFC3339))
+	return nil
+}
+
+func leaveCmd(c *cli.Context, l log.Logger) error {
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+
+	status, err := client.Leave(getBeaconID(c), c.Uint64(leaveRoundFlag.Name), c.Bool(wipeFlag.Name))
+	if err != nil {
+		return fmt.Errorf("drand: can't leave the group ... %w", err)
+	}
+	fmt.Fprintf(c.App.Writer, "Departure announced to the group, this node stops signing partials after round %d.\n",
+		status.GetLastRound())
+	fmt.Fprintf(c.App.Writer, "The coordinator can now propose a reshare with %s as a leaver.\n", status.GetAddress())
+	if c.Bool(wipeFlag.Name) {
+		fmt.Fprintln(c.App.Writer, "The share of this node will be securely wiped once the reshare completes.")
+	}
+	return nil
+}
+
+func pauseCmd(c *cli.Context, l log.Logger) error {
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+
+	status, err := client.PauseBeacon(getBeaconID(c), c.String(pauseReasonFlag.Name), c.Bool(pauseForceFlag.Name))
+	if err != nil {
+		return fmt.Errorf("drand: can't pause the node ... %w", err)
+	}
+	fmt.Fprintf(c.App.Writer, "Paused since %s, this node doesn't sign its partials until resumed.\n",
+		time.Unix(status.GetSince(), 0).UTC().Format(time.RFC3339))
+	return nil
+}
+
+func resumeCmd(c *cli.Context, l log.Logger) error {
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.ResumeBeacon(getBeaconID(c)); err != nil {
+		return fmt.Errorf("drand: can't resume the node ... %w", err)
+	}
+	fmt.Fprintln(c.App.Writer, "Resumed, this node signs its partials again.")
+	return nil
+}
+
+func incidentCmd(c *cli.Context, l log.Logger) error {
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+
+	beaconID := getBeaconID(c)
+	resp, err := client.Incident(beaconID, c.String(incidentReasonFlag.Name))
+	if err != nil {
+		return fmt.Errorf("drand: can't respond to the incident ... %w", err)
+	}
+	failed := false
+	for _, step := range resp.GetSteps() {
+		state := "done"
+		switch {
+		case step.GetSkipped():
+			state = "skipped"
+		case !step.GetDone():
+			state = "NOT DONE"
+			failed = true
+		}
+		fmt.Fprintf(c.App.Writer, "%s: %s - %s\n", step.GetName(), state, step.GetDetail())
+	}
+
+	if out := c.String(incidentProposalFlag.Name); out != "" {
+		identity, err := client.PublicKey(beaconID)
+		if err == nil {
+			err = writeExclusionProposal(client, beaconID, identity.GetAddr(), out)
+		}
+		if err != nil {
+			fmt.Fprintf(c.App.Writer, "reshare-proposal: NOT DONE - %v\n", err)
+			failed = true
+		} else {
+			fmt.Fprintf(c.App.Writer, "reshare-proposal: done - %s, to send to the coordinator of the group\n", out)
+		}
+	}
+	if failed {
+		return errors.New("drand: some steps of the incident response weren't done, run them by hand")
+	}
+	return nil
+}
+
+func updateAddressCmd(c *cli.Context, l log.Logger) error {
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+
+	newAddress := c.String(newAddressFlag.Name)
+	resp, err := client.UpdateAddress(getBeaconID(c), newAddress)
+	if err != nil {
+		return fmt.Errorf("drand: can't announce the new address ... %w", err)
+	}
+	for _, addr := range resp.GetReached() {
+		fmt.Fprintf(c.App.Writer, "%s: reaches this node at %s now\n", addr, newAddress)
+	}
+	for addr, reason := range resp.GetFailed() {
+		fmt.Fprintf(c.App.Writer, "%s: NOT REACHED - %s\n", addr, reason)
+	}
+	if len(resp.GetFailed()) > 0 {
+		return errors.New("drand: some members of the group weren't told about the new address, run it again " +
+			"once they are reachable")
+	}
+	return nil
+}
+
+func roundMessageCmd(c *cli.Context, l log.Logger) error {
+	previousSig, err := hex.DecodeString(c.String(previousSigFlag.Name))
+	if err != nil {
+		return fmt.Errorf("invalid previous signature: %w", err)
+	}
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+
+	msg, err := client.RoundMessage(getBeaconID(c), c.Uint64(roundMessageRoundFlag.Name), previousSig)
+	if err != nil {
+		return fmt.Errorf("drand: can't get the message of round %d ... %w", c.Uint64(roundMessageRoundFlag.Name), err)
+	}
+	msg.Metadata = nil
+	return printJSON(c.App.Writer, msg)
+}
+
+func statsCmd(c *cli.Context, l log.Logger) error {
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+
+	from, to := c.Uint64(statsFromFlag.Name), c.Uint64(statsToFlag.Name)
+	stats, err := client.RandomnessStats(getBeaconID(c), from, to)
+	if err != nil {
+		return fmt.Errorf("drand: can't compute the statistics of rounds %d to %d ... %w", from, to, err)
+	}
+	stats.Metadata = nil
+	return printJSON(c.App.Writer, stats)
+}
+
+func notarizeCmd(c *cli.Context, l log.Logger) error {
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+
+	round := c.Uint64(notarizeRoundFlag.Name)
+	bundle, err := client.Notarize(getBeaconID(c), round)
+	if err != nil {
+		return fmt.Errorf("drand: can't notarize round %d ... %w", round, err)
+	}
+	bundle.Metadata = nil
+	if !c.IsSet(notarizationOutFlag.Name) {
+		return printJSON(c.App.Writer, bundle)
+	}
+
+	filePath := c.String(notarizationOutFlag.Name)
+	fd, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("drand: can't save the notarization bundle: %w", err)
+	}
+	defer fd.Close()
+	if err := printJSON(fd, bundle); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.Writer, "Notarization of round %d saved at %s\n", round, filePath)
+	return nil
+}
+
+func listMetricsCmd(c *cli.Context, l log.Logger) error {
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+
+	beaconID := ""
+	if c.IsSet(beaconIDFlag.Name) {
+		beaconID = getBeaconID(c)
+	}
+	list, err := client.ListMetrics(beaconID)
+	if err != nil {
+		return fmt.Errorf("drand: can't list the metrics ... %w", err)
+	}
+	list.Metadata = nil
+	return printJSON(c.App.Writer, list)
+}
+
+func apiUsageCmd(c *cli.Context, l log.Logger) error {
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+
+	usage, err := client.APIUsage()
+	if err != nil {
+		return fmt.Errorf("drand: can't get the usage of the API ... %w", err)
+	}
+	usage.Metadata = nil
+	return printJSON(c.App.Writer, usage)
+}
+
+func featuresCmd(c *cli.Context, l log.Logger) error {
+	name, enabled, reset := "", false, false
+	set := 0
+	if c.IsSet(featureEnableFlag.Name) {
+		name, enabled = c.String(featureEnableFlag.Name), true
+		set++
+	}
+	if c.IsSet(featureDisableFlag.Name) {
+		name = c.String(featureDisableFlag.Name)
+		set++
+	}
+	if c.IsSet(featureResetFlag.Name) {
+		name, reset = c.String(featureResetFlag.Name), true
+		set++
+	}
+	if set > 1 {
+		return fmt.Errorf("only one of --%s, --%s and --%s can be given",
+			featureEnableFlag.Name, featureDisableFlag.Name, featureResetFlag.Name)
+	}
+
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+	features, err := client.FeatureFlags(getBeaconID(c), name, enabled, reset)
+	if err != nil {
+		return fmt.Errorf("drand: can't get the feature flags ... %w", err)
+	}
+	features.Metadata = nil
+	return printJSON(c.App.Writer, features)
+}
+
+func addressOverridesCmd(c *cli.Context, l log.Logger) error {
+	member, address := c.String(overrideMemberFlag.Name), c.String(overrideAddressFlag.Name)
+	remove := c.Bool(overrideRemoveFlag.Name)
+	switch {
+	case member == "" && (address != "" || remove):
+		return fmt.Errorf("the --%s to change must be given", overrideMemberFlag.Name)
+	case member != "" && (address == "") == !remove:
+		return fmt.Errorf("either --%s or --%s must be given", overrideAddressFlag.Name, overrideRemoveFlag.Name)
+	}
+
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+	overrides, err := client.AddressOverrides(getBeaconID(c), member, address, remove)
+	if err != nil {
+		return fmt.Errorf("drand: can't get the address overrides ... %w", err)
+	}
+	overrides.Metadata = nil
+	return printJSON(c.App.Writer, overrides)
+}
+
+func reshareForecastCmd(c *cli.Context, l log.Logger) error {
+	var at time.Time
+	switch {
+	case c.IsSet(forecastAtFlag.Name) && c.IsSet(forecastInFlag.Name):
+		return fmt.Errorf("only one of --%s and --%s can be given", forecastAtFlag.Name, forecastInFlag.Name)
+	case c.IsSet(forecastAtFlag.Name):
+		at = *c.Timestamp(forecastAtFlag.Name)
+	case c.IsSet(forecastInFlag.Name):
+		at = time.Now().Add(c.Duration(forecastInFlag.Name))
+	}
+	clearForecast := c.Bool(forecastClearFlag.Name)
+	if clearForecast && !at.IsZero() {
+		return fmt.Errorf("--%s can't be given with a date", forecastClearFlag.Name)
+	}
+
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+	resp, err := client.ForecastReshare(getBeaconID(c), at, c.String(forecastNoteFlag.Name), clearForecast)
+	if err != nil {
+		return fmt.Errorf("drand: can't forecast the reshare ... %w", err)
+	}
+	resp.Metadata = nil
+	return printJSON(c.App.Writer, resp)
+}
+
+func availabilityCmd(c *cli.Context, l log.Logger) error {
+	window := c.Duration(availabilityWindowFlag.Name)
+	if window <= 0 {
+		return fmt.Errorf("the --%s must be positive", availabilityWindowFlag.Name)
+	}
+
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	report, err := client.AvailabilityReport(getBeaconID(c), now.Add(-window), now)
+	if err != nil {
+		return fmt.Errorf("drand: can't get the availability report ... %w", err)
+	}
+	report.Metadata = nil
+	return printJSON(c.App.Writer, report)
+}
+
+func participationCmd(c *cli.Context, l log.Logger) error {
+	from, to := c.Uint64(participationFromFlag.Name), c.Uint64(participationToFlag.Name)
+	if to != 0 && from > to {
+		return fmt.Errorf("the --%s round must not be after the --%s one", participationFromFlag.Name, participationToFlag.Name)
+	}
+
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+	// the node returns the range a page at a time
+	audit := &control.PartialAuditResponse{}
+	for {
+		page, err := client.PartialAudit(getBeaconID(c), from, to)
+		if err != nil {
+			return fmt.Errorf("drand: can't get the participation ... %w", err)
+		}
+		audit.Rounds = append(audit.Rounds, page.GetRounds()...)
+		if page.GetNextRound() == 0 {
+			break
+		}
+		from = page.GetNextRound()
+	}
+	return printJSON(c.App.Writer, audit)
+}
+
+func tombstonesCmd(c *cli.Context, l log.Logger) error {
+	from, to := c.Uint64(tombstonesFromFlag.Name), c.Uint64(tombstonesToFlag.Name)
+	if to != 0 && from > to {
+		return fmt.Errorf("the --%s round must not be after the --%s one", tombstonesFromFlag.Name, tombstonesToFlag.Name)
+	}
+
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+	tombstones, err := client.Tombstones(getBeaconID(c), from, to)
+	if err != nil {
+		return fmt.Errorf("drand: can't get the tombstones ... %w", err)
+	}
+	tombstones.Metadata = nil
+	return printJSON(c.App.Writer, tombstones)
+}
+
+func injectBeaconCmd(c *cli.Context, l log.Logger) error {
+	buff, err := os.ReadFile(c.String(injectBeaconFlag.Name))
+	if err != nil {
+		return fmt.Errorf("drand: can't read the beacon: %w", err)
+	}
+	b := new(common.Beacon)
+	if err := b.Unmarshal(buff); err != nil {
+		return fmt.Errorf("drand: invalid beacon: %w", err)
+	}
+
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+	resp, err := client.InjectBeacon(getBeaconID(c), b)
+	if err != nil {
+		return fmt.Errorf("drand: can't inject beacon %d ... %w", b.Round, err)
+	}
+	if !resp.GetStored() {
+		fmt.Fprintf(c.App.Writer, "Beacon %d was already stored, last round stored: %d\n", b.Round, resp.GetLastRound())
+		return nil
+	}
+	fmt.Fprintf(c.App.Writer, "Beacon %d injected, last round stored: %d\n", b.Round, resp.GetLastRound())
+	return nil
+}
+
+func metadataCmd(c *cli.Context, l log.Logger) error {
+	req := &control.StoreMetadataRequest{
+		Namespace: c.String(metadataNamespaceFlag.Name),
+		Key:       c.String(metadataKeyFlag.Name),
+		Put:       c.IsSet(metadataValueFlag.Name),
+		Value:     []byte(c.String(metadataValueFlag.Name)),
+		Delete:    c.Bool(metadataDeleteFlag.Name),
+	}
+
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+	resp, err := client.StoreMetadata(getBeaconID(c), req)
+	if err != nil {
+		return fmt.Errorf("drand: can't access the metadata of namespace %s ... %w", req.Namespace, err)
+	}
+	resp.Metadata = nil
+	return printJSON(c.App.Writer, resp)
+}
+
+func annotationsCmd(c *cli.Context, l log.Logger) error {
+	req := &control.RoundAnnotationsRequest{
+		Round:  c.Uint64(annotationRoundFlag.Name),
+		Note:   c.String(annotationNoteFlag.Name),
+		Remove: c.Bool(annotationRemoveFlag.Name),
+	}
+
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+	resp, err := client.RoundAnnotations(getBeaconID(c), req)
+	if err != nil {
+		return fmt.Errorf("drand: can't access the annotations of the rounds ... %w", err)
+	}
+	resp.Metadata = nil
+	return printJSON(c.App.Writer, resp)
+}
+
+func listIDsCmd(c *cli.Context, l log.Logger) error {
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+	resp, err := client.ListBeaconIDs()
+	if err != nil {
+		return fmt.Errorf("drand: can't list the beacon ids of the node ... %w", err)
+	}
+	if c.IsSet(jsonFlag.Name) {
+		return printJSON(c.App.Writer, resp.GetBeacons())
+	}
+
+	for _, b := range resp.GetBeacons() {
+		scheme, chainHash, state := "-", "-", "not loaded"
+		if b.GetScheme() != "" {
+			scheme = b.GetScheme()
+		}
+		if len(b.GetChainHash()) > 0 {
+			chainHash = hex.EncodeToString(b.GetChainHash())
+		}
+		switch {
+		case b.GetRunning():
+			state = "running"
+		case b.GetLoaded():
+			state = "loaded"
+		}
+		fmt.Fprintf(c.App.Writer, "%s\t%s\t%s\t%s\n", b.GetId(), scheme, chainHash, state)
+	}
+	return nil
+}
+
+func countdownCmd(c *cli.Context, l log.Logger) error {
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Countdown(getBeaconID(c))
+	if err != nil {
+		return fmt.Errorf("drand: can't get the countdown ... %w", err)
+	}
+	resp.Metadata = nil
+	return printJSON(c.App.Writer, resp)
+}
+
+func entropyCmd(c *cli.Context, l log.Logger) error {
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(c.Context)
+	defer cancel()
+	stream, err := client.EntropyStream(ctx, getBeaconID(c), &control.EntropyStreamRequest{
+		BytesPerRound: uint32(c.Uint(entropyBytesFlag.Name)),
+		FromRound:     c.Uint64(entropyFromFlag.Name),
+	})
+	if err != nil {
+		return fmt.Errorf("drand: can't stream the entropy ... %w", err)
+	}
+
+	limit := c.Uint64(entropyLimitFlag.Name)
+	rate := c.Uint64(entropyRateFlag.Name)
+	start := time.Now()
+	written := uint64(0)
+	for limit == 0 || written < limit {
+		chunk, err := stream.Recv()
+		if err != nil {
+			return fmt.Errorf("drand: entropy stream interrupted ... %w", err)
+		}
+		data := chunk.GetData()
+		if limit != 0 && uint64(len(data)) > limit-written {
+			data = data[:limit-written]
+		}
+		if _, err := c.App.Writer.Write(data); err != nil {
+			return err
+		}
+		written += uint64(len(data))
+		if rate != 0 {
+			// pace the writes so that the average rate since the start stays below the given one
+			due := start.Add(time.Duration(float64(written) / float64(rate) * float64(time.Second)))
+			time.Sleep(time.Until(due))
+		}
+	}
+	return nil
+}
+
+func makeJoinKitCmd(c *cli.Context, l log.Logger) error {
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+
+	kit, err := client.MakeJoinKit(getBeaconID(c))
+	if err != nil {
+		return fmt.Errorf("drand: can't make the join kit ... %w", err)
+	}
+	kit.Metadata = nil
+	if !c.IsSet(joinKitOutFlag.Name) {
+		return printJSON(c.App.Writer, kit)
+	}
+
+	filePath := c.String(joinKitOutFlag.Name)
+	fd, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("drand: can't save the join kit: %w", err)
+	}
+	defer fd.Close()
+	if err := printJSON(fd, kit); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.Writer, "Join kit with checkpoint %d saved at %s\n", kit.GetCheckpointRound(), filePath)
+	return nil
+}
+
+func snapshotCmd(c *cli.Context, l log.Logger) error {
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := client.Snapshot()
+	if err != nil {
+		return fmt.Errorf("drand: can't take a snapshot of the daemon ... %w", err)
+	}
+	if !c.IsSet(snapshotOutFlag.Name) {
+		return printJSON(c.App.Writer, snapshot)
+	}
+
+	filePath := c.String(snapshotOutFlag.Name)
+	fd, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("drand: can't save the snapshot: %w", err)
+	}
+	defer fd.Close()
+	if err := printJSON(fd, snapshot); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.Writer, "Snapshot of %d beacon(s) saved at %s\n", len(snapshot.GetBeacons()), filePath)
+	return nil
+}
+
 func remotePingToNode(l log.Logger, addr string) error {
 	peer := net.CreatePeer(addr)
 	client := net.NewGrpcClient(l)
@@ -135,6 +905,14 @@ func statusCmd(c *cli.Context, l log.Logger) error {
 			beaconIDFlag.Name, allBeaconsFlag.Name, listIDsFlag.Name)
 	}
 
+	if c.IsSet(statusWatchFlag.Name) {
+		if allIDs || listIDs {
+			return fmt.Errorf("drand: can't use --%s with --%s or --%s flags at the same time",
+				statusWatchFlag.Name, allBeaconsFlag.Name, listIDsFlag.Name)
+		}
+		return watchStatusCmd(c, client)
+	}
+
 	beaconIDsList := &control.ListBeaconIDsResponse{}
 	if allIDs || listIDs {
 		beaconIDsList, err = client.ListBeaconIDs()
@@ -186,6 +964,48 @@ func statusCmd(c *cli.Context, l log.Logger) error {
 	return nil
 }
 
+// watchStatusCmd prints the status of the beacon each time the daemon pushes it, until interrupted
+func watchStatusCmd(c *cli.Context, client *net.ControlClient) error {
+	id := getBeaconID(c)
+	stream, err := client.StatusStream(c.Context, id, c.Duration(statusIntervalFlag.Name))
+	if err != nil {
+		return fmt.Errorf("drand: can't watch the status of the network with id [%s]... %w", id, err)
+	}
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) || status.Code(err) == codes.Canceled {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("drand: status stream of the network with id [%s] interrupted ... %w", id, err)
+		}
+
+		if c.IsSet(jsonFlag.Name) {
+			str, err := json.Marshal(resp)
+			if err != nil {
+				return fmt.Errorf("cannot marshal the response ... %w", err)
+			}
+			fmt.Fprintf(c.App.Writer, "%s\n", string(str))
+			continue
+		}
+		fmt.Fprintf(c.App.Writer, "[%s] the status of network with id [%s] is: \n", time.Now().Format(time.RFC3339), id)
+		fmt.Fprintf(c.App.Writer, "%s \n", core.StatusResponseToString(resp))
+	}
+}
+
+func capabilitiesCmd(c *cli.Context, l log.Logger) error {
+	client, err := controlClient(c, l)
+	if err != nil {
+		return err
+	}
+
+	caps, err := client.GetCapabilities(getBeaconID(c))
+	if err != nil {
+		return fmt.Errorf("drand: can't get the capabilities ... %w", err)
+	}
+	caps.Metadata = nil
+	return printJSON(c.App.Writer, caps)
+}
+
 func schemesCmd(c *cli.Context, l log.Logger) error {
 	client, err := controlClient(c, l)
 	if err != nil {
@@ -290,9 +1110,31 @@ func controlPort(c *cli.Context) string {
 	return port
 }
 
+// controlTokenOption authenticates the calls to the control port with the token of the flag, or else the one kept in
+// the config folder by the daemon, if any
+func controlTokenOption(c *cli.Context) (grpc.DialOption, error) {
+	token := c.String(controlTokenFlag.Name)
+	if token == "" {
+		folder := c.String(folderFlag.Name)
+		if folder == "" {
+			folder = core.DefaultConfigFolder()
+		}
+		buff, err := os.ReadFile(path.Join(folder, core.DefaultControlTokenFile))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("can't read the token of the control port: %w", err)
+		}
+		token = strings.TrimSpace(string(buff))
+	}
+	return net.WithControlToken(token), nil
+}
+
 func controlClient(c *cli.Context, l log.Logger) (*net.ControlClient, error) {
 	port := controlPort(c)
-	client, err := net.NewControlClient(l, port)
+	tokenOpt, err := controlTokenOption(c)
+	if err != nil {
+		return nil, err
+	}
+	client, err := net.NewControlClient(l, port, tokenOpt)
 	if err != nil {
 		return nil, fmt.Errorf("can't instantiate control client: %w", err)
 	}
@@ -433,6 +1275,60 @@ func syncCmd(c *cli.Context, l log.Logger) error {
 	return checkCmd(c, l)
 }
 
+// checkpointFromContext returns the checkpoint to follow from, taken from the join kit or the checkpoint flag, along
+// with the peers recommended by the join kit. The join kit is verified against the chain hash.
+func checkpointFromContext(c *cli.Context) (*control.Checkpoint, []string, error) {
+	if c.IsSet(joinKitFlag.Name) && c.IsSet(checkpointFlag.Name) {
+		return nil, nil, fmt.Errorf("only one of --%s and --%s can be used", joinKitFlag.Name, checkpointFlag.Name)
+	}
+
+	if c.IsSet(joinKitFlag.Name) {
+		buff, err := os.ReadFile(c.String(joinKitFlag.Name))
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to read the join kit: %w", err)
+		}
+		kit := new(control.JoinKit)
+		if err := json.Unmarshal(buff, kit); err != nil {
+			return nil, nil, fmt.Errorf("invalid join kit: %w", err)
+		}
+		hash, err := hex.DecodeString(c.String(hashInfoReq.Name))
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid chain hash: %w", err)
+		}
+		if _, _, err := core.VerifyJoinKit(kit, hash); err != nil {
+			return nil, nil, err
+		}
+		return &control.Checkpoint{
+			Round:             kit.GetCheckpointRound(),
+			Signature:         kit.GetCheckpointSignature(),
+			PreviousSignature: kit.GetCheckpointPreviousSignature(),
+		}, kit.GetPeers(), nil
+	}
+
+	if !c.IsSet(checkpointFlag.Name) {
+		return nil, nil, nil
+	}
+	parts := strings.Split(c.String(checkpointFlag.Name), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return nil, nil, fmt.Errorf("invalid checkpoint %q: expected <ROUND>:<SIGNATURE>[:<PREVIOUS SIGNATURE>]",
+			c.String(checkpointFlag.Name))
+	}
+	round, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid checkpoint round: %w", err)
+	}
+	checkpoint := &control.Checkpoint{Round: round}
+	if checkpoint.Signature, err = hex.DecodeString(parts[1]); err != nil {
+		return nil, nil, fmt.Errorf("invalid checkpoint signature: %w", err)
+	}
+	if len(parts) == 3 {
+		if checkpoint.PreviousSignature, err = hex.DecodeString(parts[2]); err != nil {
+			return nil, nil, fmt.Errorf("invalid checkpoint previous signature: %w", err)
+		}
+	}
+	return checkpoint, nil, nil
+}
+
 func followSync(c *cli.Context, l log.Logger) error {
 	ctrlClient, err := controlClient(c, l)
 	if err != nil {
@@ -441,8 +1337,19 @@ func followSync(c *cli.Context, l log.Logger) error {
 	defer ctrlClient.Close()
 
 	addrs := strings.Split(c.String(syncNodeFlag.Name), ",")
+	checkpoint, peers, err := checkpointFromContext(c)
+	if err != nil {
+		return err
+	}
+	for _, p := range peers {
+		if !slices.Contains(addrs, p) {
+			addrs = append(addrs, p)
+		}
+	}
 	channel, errCh, err := ctrlClient.StartFollowChain(c.Context, c.String(hashInfoReq.Name),
-		addrs, uint64(c.Int(upToFlag.Name)), getBeaconID(c))
+		addrs, uint64(c.Int(upToFlag.Name)), getBeaconID(c), c.Bool(crossCheckFlag.Name),
+		checkpoint, c.Bool(fullVerificationFlag.Name), c.Bool(prioritizedFlag.Name),
+		c.StringSlice(fromArchiveFlag.Name))
 
 	if err != nil {
 		return fmt.Errorf("error asking to follow chain: %w", err)
@@ -483,7 +1390,55 @@ func followSync(c *cli.Context, l log.Logger) error {
 					"server closed stream with", err)
 				return nil
 			}
+			logFailedPeers(l, err)
 			return fmt.Errorf("errror on following the chain: %w", err)
 		}
 	}
 }
+
+// logFailedPeers logs why each peer failed, when the daemon couldn't get what it needed from any of them
+func logFailedPeers(l log.Logger, err error) {
+	failed := net.FailedPeers(err)
+	peers := make([]string, 0, len(failed))
+	for peer := range failed {
+		peers = append(peers, peer)
+	}
+	slices.Sort(peers)
+	for _, peer := range peers {
+		l.Errorw("Peer failed", "peer", peer, "reason", failed[peer])
+	}
+}
+
+func selfTestCmd(c *cli.Context, l log.Logger) error {
+	conf := contextToConfig(c, l)
+
+	beaconID := ""
+	if c.IsSet(beaconIDFlag.Name) {
+		beaconID = getBeaconID(c)
+	}
+	checks, err := core.SelfTest(c.Context, conf, beaconID, c.String(ntpServerFlag.Name))
+	if err != nil {
+		return err
+	}
+	for _, check := range checks {
+		result := "ok"
+		switch {
+		case check.Err != nil:
+			result = "FAILED"
+		case check.Skipped:
+			result = "skipped"
+		}
+		name := check.Name
+		if check.BeaconID != "" {
+			name = check.BeaconID + "/" + check.Name
+		}
+		fmt.Fprintf(c.App.Writer, "%-8s %-20s %s\n", result, name, check.Detail)
+		if check.Err != nil {
+			fmt.Fprintf(c.App.Writer, "         %s\n", check.Err)
+		}
+	}
+	if err := core.SelfTestError(checks); err != nil {
+		return fmt.Errorf("the self-test failed: %w", err)
+	}
+	return nil
+}
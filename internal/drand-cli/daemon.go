@@ -9,15 +9,56 @@ import (
 	"github.com/drand/drand/v2/common/log"
 	"github.com/drand/drand/v2/common/tracer"
 	"github.com/drand/drand/v2/internal/core"
+	"github.com/drand/drand/v2/internal/metrics"
+	control "github.com/drand/drand/v2/protobuf/drand"
 )
 
-func startCmd(c *cli.Context, l log.Logger) error {
+func startCmd(c *cli.Context, l log.Logger, reloader core.ConfigReloader) error {
 	conf := contextToConfig(c, l)
+	if reloader != nil {
+		core.WithConfigReloader(reloader)(conf)
+	}
 	ctx := c.Context
 
+	if c.Bool(selfTestFlag.Name) {
+		checks, err := core.SelfTest(ctx, conf, c.String(beaconIDFlag.Name), c.String(ntpServerFlag.Name))
+		if err != nil {
+			return err
+		}
+		for _, check := range checks {
+			l.Infow("self-test", "check", check.Name, "beacon_id", check.BeaconID, "skipped", check.Skipped,
+				"detail", check.Detail, "err", check.Err)
+		}
+		if err := core.SelfTestError(checks); err != nil {
+			return fmt.Errorf("the self-test failed, not starting: %w", err)
+		}
+	}
+
+	if c.Bool(strictFlag.Name) {
+		if c.IsSet(hiddenInsecureFlag.Name) {
+			return fmt.Errorf("the --%s flag is deprecated, remove it to start in strict mode", hiddenInsecureFlag.Name)
+		}
+		checks, err := core.StrictCheck(ctx, conf, c.String(ntpServerFlag.Name))
+		if err != nil {
+			return err
+		}
+		if err := core.SelfTestError(checks); err != nil {
+			return fmt.Errorf("weak configuration, not starting in strict mode: %w", err)
+		}
+		l.Infow("strict mode checks passed")
+	}
+
 	trace, tracerShutdown := tracer.InitTracer("drand", conf.TracesEndpoint(), conf.TracesProbability())
 	defer tracerShutdown(ctx)
 
+	if endpoint := conf.OTLPMetricsEndpoint(); endpoint != "" {
+		metricsShutdown, err := metrics.StartOTLP(ctx, l, "drand", endpoint, conf.OTLPMetricsInterval())
+		if err != nil {
+			return fmt.Errorf("can't export the metrics to %s: %w", endpoint, err)
+		}
+		defer metricsShutdown(ctx)
+	}
+
 	ctx, span := trace.Start(ctx, "startCmd")
 
 	// Create and start drand daemon
@@ -58,17 +99,25 @@ func stopDaemon(c *cli.Context, lg log.Logger) error {
 	}
 	defer ctrlClient.Close()
 
+	shutdown := func(beaconID string) (*control.ShutdownResponse, error) {
+		if c.Bool(drainFlag.Name) {
+			return ctrlClient.GracefulShutdown(beaconID, c.Duration(drainTimeoutFlag.Name))
+		}
+		return ctrlClient.Shutdown(beaconID)
+	}
+
 	isBeaconIDSet := c.IsSet(beaconIDFlag.Name)
+	var resp *control.ShutdownResponse
 	if isBeaconIDSet {
 		beaconID := getBeaconID(c)
-		_, err = ctrlClient.Shutdown(beaconID)
+		resp, err = shutdown(beaconID)
 
 		if err != nil {
 			return fmt.Errorf("error stopping beacon process [%s]: %w", beaconID, err)
 		}
 		fmt.Fprintf(c.App.Writer, "beacon process [%s] stopped correctly. Bye.\n", beaconID)
 	} else {
-		_, err = ctrlClient.Shutdown("")
+		resp, err = shutdown("")
 
 		if err != nil {
 			return fmt.Errorf("error stopping drand daemon: %w", err)
@@ -76,5 +125,8 @@ func stopDaemon(c *cli.Context, lg log.Logger) error {
 		fmt.Fprintf(c.App.Writer, "drand daemon stopped correctly. Bye.\n")
 	}
 
+	for id, reason := range resp.GetDrainErrors() {
+		fmt.Fprintf(c.App.Writer, "beacon process [%s] wasn't drained before stopping: %s\n", id, reason)
+	}
 	return nil
 }
@@ -73,6 +73,17 @@ var dkgCommand = &cli.Command{
 				return dkgReshare(c, l)
 			},
 		},
+		{
+			Name: "refresh",
+			Usage: "Re-randomizes the shares of the group, keeping its members, threshold and public key, so that the " +
+				"shares stolen before become useless. The members accept it and it starts without further action.",
+			Flags: toArray(
+				beaconIDFlag,
+				controlFlag,
+				dkgTimeoutFlag,
+			),
+			Action: refreshDKG,
+		},
 		{
 			Name: "join",
 			Flags: toArray(
@@ -132,6 +143,13 @@ var dkgCommand = &cli.Command{
 				beaconIDFlag,
 				controlFlag,
 				leaverFlag,
+				announcedLeaversFlag,
+				thresholdFlag,
+				periodFlag,
+				catchupPeriodFlag,
+				schemeFlag,
+				genesisTimeFlag,
+				dkgTimeoutFlag,
 			),
 			Action: func(c *cli.Context) error {
 				l := log.New(nil, logLevel(c), logJSON(c)).
@@ -139,6 +157,62 @@ var dkgCommand = &cli.Command{
 				return generateProposalCmd(c, l)
 			},
 		},
+		{
+			Name:  "sign-proposal",
+			Usage: "Signs a proposal file with the key of this node, for the coordinator to check that all the participants agree on it",
+			Flags: toArray(
+				proposalFlag,
+				proposalOutputFlag,
+				beaconIDFlag,
+				folderFlag,
+			),
+			Action: func(c *cli.Context) error {
+				l := log.New(nil, logLevel(c), logJSON(c)).
+					Named("dkgSignProposal")
+				return signProposalCmd(c, l)
+			},
+		},
+		{
+			Name: "verify-proposal",
+			Usage: "Verifies the signatures of the participants over a proposal file, failing unless all the joiners and " +
+				"remainers signed it, and writes the proposal with their signatures attached",
+			Flags: toArray(
+				proposalFlag,
+				proposalSignatureFlag,
+				proposalOutputFlag,
+			),
+			Action: verifyProposalCmd,
+		},
+		{
+			Name:  "history",
+			Usage: "Lists the group files resulting from every DKG this node completed",
+			Flags: toArray(
+				beaconIDFlag,
+				controlFlag,
+			),
+			Action: viewGroupHistory,
+		},
+		{
+			Name:  "diff",
+			Usage: "Shows the changes between the group files of two epochs, by default the last two",
+			Flags: toArray(
+				beaconIDFlag,
+				controlFlag,
+				diffFromFlag,
+				diffToFlag,
+			),
+			Action: viewGroupDiff,
+		},
+		{
+			Name:  "group-at",
+			Usage: "Shows the group and public key that were in charge of producing the given round",
+			Flags: toArray(
+				beaconIDFlag,
+				controlFlag,
+				groupRoundFlag,
+			),
+			Action: viewGroupForRound,
+		},
 		{
 			Name: "nuke",
 			Flags: toArray(
@@ -168,6 +242,11 @@ var leaverFlag = &cli.StringSliceFlag{
 		"To use TLS, prefix their address with 'https://'",
 }
 
+var announcedLeaversFlag = &cli.BoolFlag{
+	Name:  "announced-leavers",
+	Usage: "add the nodes which announced their departure with 'drand leave' as leavers to the DKG proposal",
+}
+
 var proposalOutputFlag = &cli.StringFlag{
 	Name:  "out",
 	Usage: "the location you wish to save the proposal file to",
@@ -180,6 +259,22 @@ var formatFlag = &cli.StringFlag{
 	EnvVars: []string{"DRAND_STATUS_FORMAT"},
 }
 
+var diffFromFlag = &cli.UintFlag{
+	Name:  "from",
+	Usage: "The epoch of the group file to compare from. Defaults to the epoch preceding --to",
+}
+
+var diffToFlag = &cli.UintFlag{
+	Name:  "to",
+	Usage: "The epoch of the group file to compare to. Defaults to the latest epoch",
+}
+
+var groupRoundFlag = &cli.Uint64Flag{
+	Name:     "round",
+	Usage:    "The round for which to look up the group",
+	Required: true,
+}
+
 var genesisTimeFlag = &cli.StringFlag{
 	Name:  "genesis-delay",
 	Usage: "The duration from now until the network should start creating randomness",
@@ -194,7 +289,11 @@ var dkgTimeoutFlag = &cli.StringFlag{
 //nolint:dupl//not worth extracting a few lines
 func dkgInit(c *cli.Context, l log.Logger) error {
 	controlPort := withDefault(c.String(controlFlag.Name), core.DefaultControlPort)
-	client, err := net.NewDKGControlClient(l, controlPort)
+	tokenOpt, err := controlTokenOption(c)
+	if err != nil {
+		return err
+	}
+	client, err := net.NewDKGControlClient(l, controlPort, tokenOpt)
 	if err != nil {
 		return err
 	}
@@ -224,7 +323,11 @@ func dkgInit(c *cli.Context, l log.Logger) error {
 //nolint:dupl//not worth extracting a few lines
 func dkgReshare(c *cli.Context, l log.Logger) error {
 	controlPort := withDefault(c.String(controlFlag.Name), core.DefaultControlPort)
-	client, err := net.NewDKGControlClient(l, controlPort)
+	tokenOpt, err := controlTokenOption(c)
+	if err != nil {
+		return err
+	}
+	client, err := net.NewDKGControlClient(l, controlPort, tokenOpt)
 	if err != nil {
 		return err
 	}
@@ -259,7 +362,19 @@ func withDefault(first, second string) string {
 }
 
 func parseInitialProposal(c *cli.Context) (*drand.FirstProposalOptions, error) {
-	requiredFlags := []*cli.StringFlag{proposalFlag, periodFlag, schemeFlag, catchupPeriodFlag, genesisTimeFlag}
+	if !c.IsSet(proposalFlag.Name) {
+		return nil, fmt.Errorf("%s flag is required for initial proposals", proposalFlag.Name)
+	}
+	proposalFile, err := ParseProposalFile(c.String(proposalFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+	// the terms signed with the proposal stand in for the flags not given
+	if err := proposalFile.applyTerms(c); err != nil {
+		return nil, err
+	}
+
+	requiredFlags := []*cli.StringFlag{periodFlag, schemeFlag, catchupPeriodFlag, genesisTimeFlag}
 
 	for _, flag := range requiredFlags {
 		if !c.IsSet(flag.Name) {
@@ -272,11 +387,6 @@ func parseInitialProposal(c *cli.Context) (*drand.FirstProposalOptions, error) {
 		return nil, fmt.Errorf("%s flag is required for initial proposals", thresholdFlag.Name)
 	}
 
-	proposalFile, err := ParseProposalFile(c.String(proposalFlag.Name))
-	if err != nil {
-		return nil, err
-	}
-
 	err = validateInitialProposal(proposalFile)
 	if err != nil {
 		return nil, err
@@ -322,16 +432,23 @@ func parseProposal(c *cli.Context) (*drand.ProposalOptions, error) {
 		return nil, fmt.Errorf("%s flag is required ", proposalFlag.Name)
 	}
 
-	if !c.IsSet(thresholdFlag.Name) {
-		return nil, fmt.Errorf("%s flag is required", thresholdFlag.Name)
-	}
-
 	// parse a proposal file from the path specified
 	proposalFilePath := c.String(proposalFlag.Name)
 	proposalFile, err := ParseProposalFile(proposalFilePath)
 	if err != nil {
 		return nil, err
 	}
+	if terms := proposalFile.Terms; terms != nil && (terms.Period != "" || terms.Scheme != "" || terms.GenesisDelay != "") {
+		return nil, errors.New("the terms of the proposal set a period, a scheme or a genesis delay, which can only be set for initial proposals")
+	}
+	// the terms signed with the proposal stand in for the flags not given
+	if err := proposalFile.applyTerms(c); err != nil {
+		return nil, err
+	}
+
+	if !c.IsSet(thresholdFlag.Name) {
+		return nil, fmt.Errorf("%s flag is required", thresholdFlag.Name)
+	}
 
 	if len(proposalFile.Remaining) == 0 {
 		return nil, fmt.Errorf("you must provider remainers for a proposal")
@@ -368,7 +485,11 @@ func joinNetwork(c *cli.Context) error {
 		groupFile = fileContents
 	}
 
-	client, err := net.NewDKGControlClient(l, controlPort)
+	tokenOpt, err := controlTokenOption(c)
+	if err != nil {
+		return err
+	}
+	client, err := net.NewDKGControlClient(l, controlPort, tokenOpt)
 	if err != nil {
 		return err
 	}
@@ -455,12 +576,36 @@ func abortDKG(c *cli.Context) error {
 	return err
 }
 
+func refreshDKG(c *cli.Context) error {
+	timeout := time.Now().Add(core.DefaultDKGTimeout)
+	if c.IsSet(dkgTimeoutFlag.Name) {
+		timeout = time.Now().Add(c.Duration(dkgTimeoutFlag.Name))
+	}
+	err := runSimpleAction(c, func(beaconID string, client drand.DKGControlClient) error {
+		_, err := client.Command(c.Context, &drand.DKGCommand{
+			Command: &drand.DKGCommand_Refresh{Refresh: &drand.RefreshOptions{Timeout: timestamppb.New(timeout)}},
+			Metadata: &drand.CommandMetadata{
+				BeaconID: beaconID,
+			},
+		})
+		return err
+	})
+	if err == nil {
+		fmt.Println("Refresh of the shares proposed successfully!")
+	}
+	return err
+}
+
 func runSimpleAction(c *cli.Context, action func(beaconID string, client drand.DKGControlClient) error) error {
 	l := log.FromContextOrDefault(c.Context)
 	beaconID := withDefault(c.String(beaconIDFlag.Name), common.DefaultBeaconID)
 	controlPort := withDefault(c.String(controlFlag.Name), core.DefaultControlPort)
 
-	client, err := net.NewDKGControlClient(l, controlPort)
+	tokenOpt, err := controlTokenOption(c)
+	if err != nil {
+		return err
+	}
+	client, err := net.NewDKGControlClient(l, controlPort, tokenOpt)
 	if err != nil {
 		return err
 	}
@@ -483,7 +628,11 @@ func viewStatus(c *cli.Context) error {
 		controlPort = core.DefaultControlPort
 	}
 
-	client, err := net.NewDKGControlClient(l, controlPort)
+	tokenOpt, err := controlTokenOption(c)
+	if err != nil {
+		return err
+	}
+	client, err := net.NewDKGControlClient(l, controlPort, tokenOpt)
 	if err != nil {
 		return err
 	}
@@ -715,6 +864,17 @@ func generateProposalCmd(c *cli.Context, l log.Logger) error {
 	joiners := c.StringSlice(joinerFlag.Name)
 	remainers := c.StringSlice(remainerFlag.Name)
 	leavers := c.StringSlice(leaverFlag.Name)
+	if c.Bool(announcedLeaversFlag.Name) && !freshStart {
+		status, err := client.Status(beaconID)
+		if err != nil {
+			return err
+		}
+		for _, leaving := range status.GetLeaving() {
+			if !slices.Contains(leavers, leaving.GetAddress()) {
+				leavers = append(leavers, leaving.GetAddress())
+			}
+		}
+	}
 
 	if freshStart {
 		if len(remainers) > 0 {
@@ -787,6 +947,7 @@ func generateProposalCmd(c *cli.Context, l log.Logger) error {
 		}
 		proposalFile.Leaving = append(proposalFile.Leaving, p)
 	}
+	proposalFile.Terms = proposalTerms(c, beaconID)
 
 	// finally we write the proposal toml file to the output location
 	filepath := c.String(proposalOutputFlag.Name)
@@ -804,6 +965,64 @@ func generateProposalCmd(c *cli.Context, l log.Logger) error {
 	return nil
 }
 
+// proposalTerms returns the terms of the DKG given to generate-proposal, to be signed along with the proposal, nil if
+// none was given
+func proposalTerms(c *cli.Context, beaconID string) *ProposalTerms {
+	terms := &ProposalTerms{}
+	set := false
+	for _, term := range []struct {
+		flag  string
+		value *string
+	}{
+		{periodFlag.Name, &terms.Period},
+		{catchupPeriodFlag.Name, &terms.CatchupPeriod},
+		{schemeFlag.Name, &terms.Scheme},
+		{genesisTimeFlag.Name, &terms.GenesisDelay},
+		{dkgTimeoutFlag.Name, &terms.Timeout},
+	} {
+		if c.IsSet(term.flag) {
+			*term.value = c.String(term.flag)
+			set = true
+		}
+	}
+	if c.IsSet(thresholdFlag.Name) {
+		terms.Threshold = c.Int(thresholdFlag.Name)
+		set = true
+	}
+	if !set {
+		return nil
+	}
+	terms.BeaconID = beaconID
+	return terms
+}
+
+// writeExclusionProposal writes to the given file a proposal for a reshare excluding the node at the given address,
+// with all the other members of the current group remaining. Their keys are taken from the group file.
+func writeExclusionProposal(client *net.ControlClient, beaconID, excluded, filepath string) error {
+	groupFile, err := client.GroupFile(beaconID)
+	if err != nil {
+		return err
+	}
+	proposalFile := ProposalFile{}
+	for _, node := range groupFile.GetNodes() {
+		if node.GetPublic().GetAddress() == excluded {
+			proposalFile.Leaving = append(proposalFile.Leaving, util.ToParticipant(node))
+			continue
+		}
+		proposalFile.Remaining = append(proposalFile.Remaining, util.ToParticipant(node))
+	}
+	if len(proposalFile.Leaving) == 0 {
+		return fmt.Errorf("%s is not part of the group", excluded)
+	}
+
+	file, err := os.Create(filepath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return toml.NewEncoder(file).Encode(proposalFile.TOML())
+}
+
 func keyFromGroupFile(address string, groupFile *proto.GroupPacket) (*drand.Participant, error) {
 	for _, node := range groupFile.Nodes {
 		if node.Public.Address != address {
@@ -844,3 +1063,133 @@ func fetchPublicKey(beaconID string, l log.Logger, address string, targetSch *cr
 
 	return part, nil
 }
+
+type groupVersion struct {
+	epoch uint32
+	group *key.Group
+}
+
+func fetchGroupHistory(beaconID string, client drand.DKGControlClient) ([]groupVersion, error) {
+	res, err := client.GroupHistory(context.Background(), &drand.GroupHistoryRequest{BeaconID: beaconID})
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]groupVersion, len(res.Versions))
+	for i, v := range res.Versions {
+		gt := key.GroupTOML{}
+		if _, err := toml.Decode(string(v.GroupFile), &gt); err != nil {
+			return nil, fmt.Errorf("decoding group file of epoch %d: %w", v.Epoch, err)
+		}
+		group := new(key.Group)
+		if err := group.FromTOML(&gt); err != nil {
+			return nil, fmt.Errorf("decoding group file of epoch %d: %w", v.Epoch, err)
+		}
+		versions[i] = groupVersion{epoch: v.Epoch, group: group}
+	}
+	return versions, nil
+}
+
+func viewGroupHistory(c *cli.Context) error {
+	return runSimpleAction(c, func(beaconID string, client drand.DKGControlClient) error {
+		versions, err := fetchGroupHistory(beaconID, client)
+		if err != nil {
+			return err
+		}
+		if len(versions) == 0 {
+			return fmt.Errorf("no DKG has been completed for beacon %s", beaconID)
+		}
+
+		tw := table.NewWriter()
+		tw.AppendHeader(table.Row{"Epoch", "TransitionTime", "Nodes", "Threshold", "Hash"})
+		for _, v := range versions {
+			tw.AppendRow(table.Row{
+				v.epoch,
+				time.Unix(v.group.TransitionTime, 0).UTC().Format(time.RFC3339),
+				v.group.Len(),
+				v.group.Threshold,
+				hex.EncodeToString(v.group.Hash()),
+			})
+		}
+		_, err = fmt.Fprintln(c.App.Writer, tw.Render())
+		return err
+	})
+}
+
+func viewGroupDiff(c *cli.Context) error {
+	return runSimpleAction(c, func(beaconID string, client drand.DKGControlClient) error {
+		versions, err := fetchGroupHistory(beaconID, client)
+		if err != nil {
+			return err
+		}
+		if len(versions) == 0 {
+			return fmt.Errorf("no DKG has been completed for beacon %s", beaconID)
+		}
+
+		toIndex := len(versions) - 1
+		if c.IsSet(diffToFlag.Name) {
+			toIndex = slices.IndexFunc(versions, func(v groupVersion) bool { return v.epoch == uint32(c.Uint(diffToFlag.Name)) })
+			if toIndex < 0 {
+				return fmt.Errorf("no group file found for epoch %d", c.Uint(diffToFlag.Name))
+			}
+		}
+		fromIndex := toIndex - 1
+		if c.IsSet(diffFromFlag.Name) {
+			fromIndex = slices.IndexFunc(versions, func(v groupVersion) bool { return v.epoch == uint32(c.Uint(diffFromFlag.Name)) })
+			if fromIndex < 0 {
+				return fmt.Errorf("no group file found for epoch %d", c.Uint(diffFromFlag.Name))
+			}
+		}
+		if fromIndex < 0 {
+			return fmt.Errorf("there is no group file before epoch %d to compare with", versions[toIndex].epoch)
+		}
+
+		from, to := versions[fromIndex], versions[toIndex]
+		diff := from.group.Diff(to.group)
+
+		tw := table.NewWriter()
+		tw.AppendHeader(table.Row{"Field", fmt.Sprintf("Epoch %d", from.epoch), fmt.Sprintf("Epoch %d", to.epoch)})
+		tw.AppendRow(table.Row{"Nodes", from.group.Len(), to.group.Len()})
+		tw.AppendRow(table.Row{"Threshold", diff.OldThreshold, diff.NewThreshold})
+		tw.AppendRow(table.Row{"CatchupPeriod", diff.OldCatchupPeriod, diff.NewCatchupPeriod})
+		tw.AppendRow(table.Row{"Removed", formatNodes(diff.Removed), ""})
+		tw.AppendRow(table.Row{"Added", "", formatNodes(diff.Added)})
+		_, err = fmt.Fprintln(c.App.Writer, tw.Render())
+		return err
+	})
+}
+
+func formatNodes(nodes []*key.Node) string {
+	addresses := make([]string, len(nodes))
+	for i, n := range nodes {
+		addresses[i] = n.Address()
+	}
+	if len(addresses) == 0 {
+		return "[]"
+	}
+	return formatFinalGroup(addresses)
+}
+
+func viewGroupForRound(c *cli.Context) error {
+	return runSimpleAction(c, func(beaconID string, client drand.DKGControlClient) error {
+		res, err := client.GroupForRound(context.Background(), &drand.GroupForRoundRequest{
+			BeaconID: beaconID,
+			Round:    c.Uint64(groupRoundFlag.Name),
+		})
+		if err != nil {
+			return err
+		}
+
+		tw := table.NewWriter()
+		tw.AppendHeader(table.Row{"Field", "Value"})
+		tw.AppendRow(table.Row{"Epoch", res.Epoch})
+		tw.AppendRow(table.Row{"TransitionRound", res.TransitionRound})
+		tw.AppendRow(table.Row{"SchemeID", res.SchemeID})
+		tw.AppendRow(table.Row{"Threshold", res.Threshold})
+		tw.AppendRow(table.Row{"Period", time.Duration(res.Period) * time.Second})
+		tw.AppendRow(table.Row{"GenesisSeed", hex.EncodeToString(res.GenesisSeed)})
+		tw.AppendRow(table.Row{"PublicKey", hex.EncodeToString(res.PublicKey)})
+		_, err = fmt.Fprintln(c.App.Writer, tw.Render())
+		return err
+	})
+}
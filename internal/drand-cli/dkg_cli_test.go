@@ -2,15 +2,23 @@ package drand
 
 import (
 	"encoding/hex"
+	"fmt"
+	"path"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/drand/drand/v2/common"
 	"github.com/drand/drand/v2/common/key"
+	"github.com/drand/drand/v2/common/testlogger"
 	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/core"
 	"github.com/drand/drand/v2/internal/dkg"
+	"github.com/drand/drand/v2/internal/test"
+	"github.com/drand/drand/v2/internal/util"
 	drand "github.com/drand/drand/v2/protobuf/dkg"
 )
 
@@ -65,3 +73,112 @@ func NewParticipant(name string) *drand.Participant {
 		Key:     pk,
 	}
 }
+
+func TestProposalSigning(t *testing.T) {
+	sch, err := crypto.GetSchemeFromEnv()
+	require.NoError(t, err)
+	beaconID := test.GetBeaconIDFromEnv()
+	dir := t.TempDir()
+
+	// each participant has its own key in its own folder
+	proposal := &ProposalFile{}
+	folders := make([]string, 3)
+	for i := range folders {
+		pair, err := key.NewKeyPair(fmt.Sprintf("127.0.0.1:%d", 8080+i), sch)
+		require.NoError(t, err)
+		folders[i] = path.Join(dir, fmt.Sprintf("node%d", i))
+		config := core.NewConfig(testlogger.New(t), core.WithConfigFolder(folders[i]))
+		require.NoError(t, key.NewFileStore(config.ConfigFolderMB(), common.BeaconID(beaconID)).SaveKeyPair(pair))
+		participant, err := util.PublicKeyAsParticipant(pair.Public)
+		require.NoError(t, err)
+		if i < 2 {
+			proposal.Joining = append(proposal.Joining, participant)
+		} else {
+			proposal.Leaving = append(proposal.Leaving, participant)
+		}
+	}
+	proposalPath := path.Join(dir, "proposal.toml")
+	require.NoError(t, writeTOMLFile(proposalPath, proposal.TOML()))
+
+	sign := func(proposalPath string, i int) string {
+		out := path.Join(dir, fmt.Sprintf("signature%d-%s", i, path.Base(proposalPath)))
+		require.NoError(t, CLI().Run([]string{"drand", "dkg", "sign-proposal", "--proposal", proposalPath,
+			"--folder", folders[i], "--id", beaconID, "--out", out}))
+		return out
+	}
+	verify := func(proposalPath string, signatures ...string) error {
+		args := []string{"drand", "dkg", "verify-proposal", "--proposal", proposalPath}
+		for _, sig := range signatures {
+			args = append(args, "--signature", sig)
+		}
+		return CLI().Run(append(args, "--out", path.Join(dir, "signed.toml")))
+	}
+
+	first := sign(proposalPath, 0)
+	require.ErrorContains(t, verify(proposalPath, first), "the proposal isn't signed by 127.0.0.1:8081")
+
+	// the leavers don't have to sign
+	second := sign(proposalPath, 1)
+	require.NoError(t, verify(proposalPath, first, second))
+	signed, err := ParseProposalFile(path.Join(dir, "signed.toml"))
+	require.NoError(t, err)
+	require.Len(t, signed.Signatures, 2)
+	require.Equal(t, proposal.Hash(), signed.Hash())
+	require.NoError(t, verify(path.Join(dir, "signed.toml")))
+
+	// the signatures are only valid for the proposal signed
+	proposal.Joining = proposal.Joining[:1]
+	otherPath := path.Join(dir, "other.toml")
+	require.NoError(t, writeTOMLFile(otherPath, proposal.TOML()))
+	require.ErrorContains(t, verify(otherPath, first), "127.0.0.1:8080 signed another proposal")
+	require.ErrorContains(t, verify(otherPath, sign(otherPath, 0), second), "127.0.0.1:8081 isn't a participant")
+	require.NoError(t, verify(otherPath, sign(otherPath, 0)))
+}
+
+func TestProposalTerms(t *testing.T) {
+	dir := t.TempDir()
+	proposal := &ProposalFile{Joining: []*drand.Participant{NewParticipant("127.0.0.1:8080")}}
+	withoutTerms := proposal.Hash()
+	proposal.Terms = &ProposalTerms{Threshold: 1, Period: "3s", CatchupPeriod: "1s", Scheme: crypto.DefaultSchemeID, GenesisDelay: "30s"}
+	require.NotEqual(t, withoutTerms, proposal.Hash())
+	signed := proposal.Hash()
+	proposal.Terms.Period = "5s"
+	require.NotEqual(t, signed, proposal.Hash(), "each term is signed")
+	proposal.Terms.Period = "3s"
+
+	proposalPath := path.Join(dir, "proposal.toml")
+	require.NoError(t, writeTOMLFile(proposalPath, proposal.TOML()))
+	parsed, err := ParseProposalFile(proposalPath)
+	require.NoError(t, err)
+	require.Equal(t, signed, parsed.Hash())
+
+	run := func(args ...string) (*drand.FirstProposalOptions, error) {
+		var options *drand.FirstProposalOptions
+		app := &cli.App{
+			Flags: toArray(beaconIDFlag, schemeFlag, periodFlag, thresholdFlag, catchupPeriodFlag, proposalFlag,
+				dkgTimeoutFlag, genesisTimeFlag),
+			Action: func(c *cli.Context) error {
+				var err error
+				options, err = parseInitialProposal(c)
+				return err
+			},
+		}
+		err := app.Run(append([]string{"drand", "--proposal", proposalPath}, args...))
+		return options, err
+	}
+
+	// the terms stand in for the flags not given
+	options, err := run()
+	require.NoError(t, err)
+	require.EqualValues(t, 1, options.GetThreshold())
+	require.EqualValues(t, 3, options.GetPeriodSeconds())
+	require.EqualValues(t, 1, options.GetCatchupPeriodSeconds())
+	require.Equal(t, crypto.DefaultSchemeID, options.GetScheme())
+
+	_, err = run("--period", "3000ms")
+	require.NoError(t, err)
+	_, err = run("--period", "5s")
+	require.ErrorContains(t, err, "isn't the one of the proposal")
+	_, err = run("--threshold", "2")
+	require.ErrorContains(t, err, "isn't the one of the proposal")
+}
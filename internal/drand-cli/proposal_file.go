@@ -12,12 +12,31 @@ type ProposalFileFormat struct {
 	Joining   []*TomlParticipant
 	Leaving   []*TomlParticipant
 	Remaining []*TomlParticipant
+	// Terms are the parameters of the DKG proposed, if they are part of the proposal
+	Terms *ProposalTerms `toml:",omitempty"`
+	// Signatures are the signatures of the participants over the proposal, once assembled
+	Signatures []*ProposalSignature `toml:",omitempty"`
 }
 
 type ProposalFile struct {
-	Joining   []*drand.Participant
-	Leaving   []*drand.Participant
-	Remaining []*drand.Participant
+	Joining    []*drand.Participant
+	Leaving    []*drand.Participant
+	Remaining  []*drand.Participant
+	Terms      *ProposalTerms
+	Signatures []*ProposalSignature
+}
+
+// ProposalTerms are the parameters of the DKG a proposal is for, so that the participants signing the proposal agree
+// on them as well as on its participants. The durations are given as for the flags of the proposal, the terms left
+// empty are up to the leader.
+type ProposalTerms struct {
+	BeaconID      string `toml:",omitempty"`
+	Threshold     int    `toml:",omitempty"`
+	Period        string `toml:",omitempty"`
+	CatchupPeriod string `toml:",omitempty"`
+	Scheme        string `toml:",omitempty"`
+	GenesisDelay  string `toml:",omitempty"`
+	Timeout       string `toml:",omitempty"`
 }
 
 func ParseProposalFile(filepath string) (*ProposalFile, error) {
@@ -29,9 +48,11 @@ func ParseProposalFile(filepath string) (*ProposalFile, error) {
 	}
 
 	return &ProposalFile{
-		Joining:   proposalFile.Joiners(),
-		Leaving:   proposalFile.Leavers(),
-		Remaining: proposalFile.Remainers(),
+		Joining:    proposalFile.Joiners(),
+		Leaving:    proposalFile.Leavers(),
+		Remaining:  proposalFile.Remainers(),
+		Terms:      proposalFile.Terms,
+		Signatures: proposalFile.Signatures,
 	}, nil
 }
 
@@ -96,6 +117,8 @@ func (p *ProposalFile) TOML() ProposalFileFormat {
 		out.Leaving = append(out.Leaving, &p)
 	}
 
+	out.Terms = p.Terms
+	out.Signatures = p.Signatures
 	return out
 }
 
@@ -0,0 +1,322 @@
+package drand
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/urfave/cli/v2"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/key"
+	"github.com/drand/drand/v2/common/log"
+	"github.com/drand/drand/v2/crypto"
+	drand "github.com/drand/drand/v2/protobuf/dkg"
+)
+
+// proposalSignatureDomain separates the signatures over proposals from any other signature made with the same key
+const proposalSignatureDomain = "drand-proposal:"
+
+var proposalSignatureFlag = &cli.StringSliceFlag{
+	Name:  "signature",
+	Usage: "the path to a signature file produced by a participant with 'sign-proposal'. You can pass it multiple times.",
+}
+
+// ProposalSignature is the detached signature of a participant over a proposal file, so that the participants can
+// agree on the inputs of a DKG before it starts, without having to trust whoever distributes the proposal
+type ProposalSignature struct {
+	Address    string
+	SchemeName string
+	// Proposal is the hash of the proposal signed
+	Proposal  string
+	Signature string
+}
+
+// Hash returns the digest of the proposal that its participants sign, covering its participants and its terms. It
+// doesn't depend on the order of the participants in the file, nor on the signatures already attached to it.
+func (p *ProposalFile) Hash() []byte {
+	h := sha256.New()
+	sections := []struct {
+		name         string
+		participants []*drand.Participant
+	}{{"joining", p.Joining}, {"remaining", p.Remaining}, {"leaving", p.Leaving}}
+
+	for _, section := range sections {
+		participants := slices.Clone(section.participants)
+		slices.SortFunc(participants, func(a, b *drand.Participant) int {
+			return cmp.Compare(a.GetAddress(), b.GetAddress())
+		})
+		_, _ = h.Write([]byte(section.name))
+		_ = binary.Write(h, binary.BigEndian, uint32(len(participants)))
+		for _, participant := range participants {
+			for _, field := range [][]byte{[]byte(participant.GetAddress()), participant.GetKey(), participant.GetSignature()} {
+				_ = binary.Write(h, binary.BigEndian, uint32(len(field)))
+				_, _ = h.Write(field)
+			}
+		}
+	}
+
+	terms := p.Terms
+	if terms == nil {
+		terms = &ProposalTerms{}
+	}
+	_, _ = h.Write([]byte("terms"))
+	for _, term := range []string{terms.BeaconID, strconv.Itoa(terms.Threshold), terms.Period, terms.CatchupPeriod,
+		terms.Scheme, terms.GenesisDelay, terms.Timeout} {
+		_ = binary.Write(h, binary.BigEndian, uint32(len(term)))
+		_, _ = h.Write([]byte(term))
+	}
+	return h.Sum(nil)
+}
+
+// applyTerms checks that the flags of the proposal agree with the terms of the proposal file, if any, and sets the
+// flags which weren't given to the terms, so that the DKG proposed is the one the participants signed
+func (p *ProposalFile) applyTerms(c *cli.Context) error {
+	terms := p.Terms
+	if terms == nil {
+		return nil
+	}
+	if terms.BeaconID != "" && !common.CompareBeaconIDs(terms.BeaconID, c.String(beaconIDFlag.Name)) {
+		return fmt.Errorf("the proposal is for beacon %q, not %q", terms.BeaconID, c.String(beaconIDFlag.Name))
+	}
+
+	for _, term := range []struct {
+		flag     string
+		value    string
+		duration bool
+	}{
+		{thresholdFlag.Name, thresholdTerm(terms.Threshold), false},
+		{periodFlag.Name, terms.Period, true},
+		{catchupPeriodFlag.Name, terms.CatchupPeriod, true},
+		{schemeFlag.Name, terms.Scheme, false},
+		{genesisTimeFlag.Name, terms.GenesisDelay, true},
+		{dkgTimeoutFlag.Name, terms.Timeout, true},
+	} {
+		if term.value == "" {
+			continue
+		}
+		if !c.IsSet(term.flag) {
+			if err := c.Set(term.flag, term.value); err != nil {
+				return fmt.Errorf("invalid %s in the terms of the proposal: %w", term.flag, err)
+			}
+			continue
+		}
+		given := c.String(term.flag)
+		if term.flag == thresholdFlag.Name {
+			given = strconv.Itoa(c.Int(term.flag))
+		}
+		if !sameTerm(given, term.value, term.duration) {
+			return fmt.Errorf("the %s given, %s, isn't the one of the proposal, %s", term.flag, given, term.value)
+		}
+	}
+	return nil
+}
+
+func thresholdTerm(threshold int) string {
+	if threshold == 0 {
+		return ""
+	}
+	return strconv.Itoa(threshold)
+}
+
+func sameTerm(given, term string, duration bool) bool {
+	if !duration {
+		return given == term
+	}
+	a, errA := time.ParseDuration(given)
+	b, errB := time.ParseDuration(term)
+	return errA == nil && errB == nil && a == b
+}
+
+// participant returns the participant of the proposal with the given address, nil if there is none
+func (p *ProposalFile) participant(address string) *drand.Participant {
+	for _, participants := range [][]*drand.Participant{p.Joining, p.Remaining, p.Leaving} {
+		for _, participant := range participants {
+			if participant.GetAddress() == address {
+				return participant
+			}
+		}
+	}
+	return nil
+}
+
+func proposalSignatureMessage(sch *crypto.Scheme, proposal []byte) []byte {
+	// we prepend the scheme name to avoid scheme confusion, as for the identities
+	return append([]byte(proposalSignatureDomain+sch.Name), proposal...)
+}
+
+// SignProposal signs the proposal with the long-term key of one of its participants
+func SignProposal(pair *key.Pair, p *ProposalFile) (*ProposalSignature, error) {
+	participant := p.participant(pair.Public.Address())
+	if participant == nil {
+		return nil, fmt.Errorf("%s isn't a participant of the proposal", pair.Public.Address())
+	}
+	ownKey, err := pair.Public.Key.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(ownKey, participant.GetKey()) {
+		return nil, fmt.Errorf("the proposal has another public key for %s than ours", pair.Public.Address())
+	}
+
+	sch := pair.Public.Scheme
+	hash := p.Hash()
+	signature, err := sch.AuthScheme.Sign(pair.Key, proposalSignatureMessage(sch, hash))
+	if err != nil {
+		return nil, err
+	}
+	return &ProposalSignature{
+		Address:    pair.Public.Address(),
+		SchemeName: sch.Name,
+		Proposal:   hex.EncodeToString(hash),
+		Signature:  hex.EncodeToString(signature),
+	}, nil
+}
+
+// VerifyProposalSignature checks that the signature is the one of a participant of the proposal over it
+func VerifyProposalSignature(p *ProposalFile, sig *ProposalSignature) error {
+	participant := p.participant(sig.Address)
+	if participant == nil {
+		return fmt.Errorf("%s isn't a participant of the proposal", sig.Address)
+	}
+	if sig.Proposal != hex.EncodeToString(p.Hash()) {
+		return fmt.Errorf("%s signed another proposal", sig.Address)
+	}
+	sch, err := crypto.SchemeFromName(sig.SchemeName)
+	if err != nil {
+		return fmt.Errorf("invalid signature of %s: %w", sig.Address, err)
+	}
+	// the identity of the participant binds its key to the scheme
+	id, err := key.IdentityFromProto(participant, sch)
+	if err != nil {
+		return fmt.Errorf("invalid key for %s: %w", sig.Address, err)
+	}
+	if err := id.ValidSignature(); err != nil {
+		return fmt.Errorf("invalid key for %s: %w", sig.Address, err)
+	}
+	signature, err := hex.DecodeString(sig.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature of %s: %w", sig.Address, err)
+	}
+	if err := sch.AuthScheme.Verify(id.Key, proposalSignatureMessage(sch, p.Hash()), signature); err != nil {
+		return fmt.Errorf("invalid signature of %s: %w", sig.Address, err)
+	}
+	return nil
+}
+
+func signProposalCmd(c *cli.Context, l log.Logger) error {
+	if !c.IsSet(proposalFlag.Name) {
+		return errors.New("you must pass the proposal file to sign")
+	}
+	if !c.IsSet(proposalOutputFlag.Name) {
+		return errors.New("you must pass an output filepath for the signature")
+	}
+
+	proposal, err := ParseProposalFile(c.String(proposalFlag.Name))
+	if err != nil {
+		return err
+	}
+	conf := contextToConfig(c, l)
+	pair, err := key.NewFileStore(conf.ConfigFolderMB(), common.BeaconID(getBeaconID(c))).LoadKeyPair()
+	if err != nil {
+		return fmt.Errorf("unable to load the longterm key pair: %w", err)
+	}
+	sig, err := SignProposal(pair, proposal)
+	if err != nil {
+		return err
+	}
+
+	if err := writeTOMLFile(c.String(proposalOutputFlag.Name), sig); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.Writer, "Proposal %s signed, signature written to %s\n", sig.Proposal, c.String(proposalOutputFlag.Name))
+	return nil
+}
+
+// verifyProposalCmd checks the signatures of a proposal, the ones given as files and the ones already attached to it,
+// and fails unless all the joiners and remainers signed it. Once they did, the proposal can be written with all their
+// signatures attached.
+func verifyProposalCmd(c *cli.Context) error {
+	if !c.IsSet(proposalFlag.Name) {
+		return errors.New("you must pass the proposal file to verify")
+	}
+	proposal, err := ParseProposalFile(c.String(proposalFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	signatures := proposal.Signatures
+	for _, path := range c.StringSlice(proposalSignatureFlag.Name) {
+		sig := new(ProposalSignature)
+		if _, err := toml.DecodeFile(path, sig); err != nil {
+			return fmt.Errorf("unable to read signature %s: %w", path, err)
+		}
+		signatures = append(signatures, sig)
+	}
+
+	signed := make(map[string]*ProposalSignature)
+	var invalid []string
+	for _, sig := range signatures {
+		if err := VerifyProposalSignature(proposal, sig); err != nil {
+			invalid = append(invalid, err.Error())
+			continue
+		}
+		signed[sig.Address] = sig
+	}
+
+	fmt.Fprintf(c.App.Writer, "Proposal %x\n", proposal.Hash())
+	var missing []string
+	assembled := *proposal
+	assembled.Signatures = nil
+	participants := append(append(slices.Clone(proposal.Joining), proposal.Remaining...), proposal.Leaving...)
+	for _, participant := range participants {
+		sig, ok := signed[participant.GetAddress()]
+		if !ok {
+			fmt.Fprintf(c.App.Writer, "\t ☒ %s\n", participant.GetAddress())
+			// the leavers may not be around anymore to sign
+			if !slices.Contains(proposal.Leaving, participant) {
+				missing = append(missing, participant.GetAddress())
+			}
+			continue
+		}
+		fmt.Fprintf(c.App.Writer, "\t ☑ %s\n", participant.GetAddress())
+		assembled.Signatures = append(assembled.Signatures, sig)
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid signatures: %s", strings.Join(invalid, "; "))
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("the proposal isn't signed by %s", strings.Join(missing, ", "))
+	}
+
+	if c.IsSet(proposalOutputFlag.Name) {
+		if err := writeTOMLFile(c.String(proposalOutputFlag.Name), assembled.TOML()); err != nil {
+			return err
+		}
+		fmt.Fprintf(c.App.Writer, "Signed proposal written to %s\n", c.String(proposalOutputFlag.Name))
+	}
+	return nil
+}
+
+func writeTOMLFile(path string, v any) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := toml.NewEncoder(file).Encode(v); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
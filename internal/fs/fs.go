@@ -3,6 +3,7 @@ package fs
 
 import (
 	"bufio"
+	"crypto/rand"
 	"fmt"
 	"io"
 	"os"
@@ -221,3 +222,32 @@ func TestWrite(dir string) error {
 	os.Remove(tempFile.Name())
 	return nil
 }
+
+// SecureDelete overwrites the content of the given file with random bytes and flushes it to disk before removing
+// it, so that its content can't be recovered by reading the freed blocks. A missing file is not an error.
+func SecureDelete(filePath string) error {
+	info, err := os.Stat(filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	fd, err := os.OpenFile(filePath, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	if _, err := io.CopyN(fd, rand.Reader, info.Size()); err != nil {
+		fd.Close()
+		return fmt.Errorf("unable to overwrite %q: %w", filePath, err)
+	}
+	if err := fd.Sync(); err != nil {
+		fd.Close()
+		return fmt.Errorf("unable to overwrite %q: %w", filePath, err)
+	}
+	if err := fd.Close(); err != nil {
+		return err
+	}
+	return os.Remove(filePath)
+}
@@ -1,6 +1,7 @@
 package fs
 
 import (
+	"os"
 	"path"
 	"testing"
 
@@ -79,3 +80,16 @@ func TestCopyFolder(t *testing.T) {
 		}
 	}
 }
+
+func TestSecureDelete(t *testing.T) {
+	file := path.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(file, []byte("a very secret share"), 0600))
+
+	require.NoError(t, SecureDelete(file))
+	b, err := Exists(file)
+	require.NoError(t, err)
+	require.False(t, b)
+
+	// deleting a file which doesn't exist is a no-op
+	require.NoError(t, SecureDelete(file))
+}
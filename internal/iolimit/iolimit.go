@@ -0,0 +1,102 @@
+// Package iolimit throttles the disk I/O of background tasks so that they can't starve the storage of new rounds
+package iolimit
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	clock "github.com/jonboulle/clockwork"
+)
+
+// Limiter is a token bucket allowing a number of bytes per second, with bursts of up to one second worth of bytes.
+// A nil Limiter doesn't limit anything.
+type Limiter struct {
+	sync.Mutex
+	clock  clock.Clock
+	rate   int64
+	tokens int64
+	last   time.Time
+}
+
+// NewLimiter returns a limiter allowing the given number of bytes per second, or nil if the rate isn't positive.
+func NewLimiter(c clock.Clock, bytesPerSecond int64) *Limiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	return &Limiter{
+		clock:  c,
+		rate:   bytesPerSecond,
+		tokens: bytesPerSecond,
+		last:   c.Now(),
+	}
+}
+
+// Wait blocks until n bytes can be read or written, or until the context is done.
+func (l *Limiter) Wait(ctx context.Context, n int) error {
+	if l == nil {
+		return nil
+	}
+	for {
+		l.Lock()
+		now := l.clock.Now()
+		l.tokens += int64(now.Sub(l.last).Seconds() * float64(l.rate))
+		if l.tokens > l.rate {
+			l.tokens = l.rate
+		}
+		l.last = now
+		// operations bigger than the burst go through as soon as the bucket is full
+		if l.tokens >= int64(n) || l.tokens == l.rate {
+			l.tokens -= int64(n)
+			l.Unlock()
+			return nil
+		}
+		missing := int64(n) - l.tokens
+		if missing > l.rate {
+			missing = l.rate - l.tokens
+		}
+		wait := time.Duration(float64(missing) / float64(l.rate) * float64(time.Second))
+		l.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-l.clock.After(wait):
+		}
+	}
+}
+
+// Writer returns a writer throttling the writes to w with the limiter.
+func (l *Limiter) Writer(ctx context.Context, w io.Writer) io.Writer {
+	if l == nil {
+		return w
+	}
+	return &writer{ctx: ctx, w: w, l: l}
+}
+
+type writer struct {
+	ctx context.Context
+	w   io.Writer
+	l   *Limiter
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if err := w.l.Wait(w.ctx, len(p)); err != nil {
+		return 0, err
+	}
+	return w.w.Write(p)
+}
+
+type limiterKey struct{}
+
+// WithLimiter returns a context carrying the given limiter, for the tasks that read from the store.
+func WithLimiter(ctx context.Context, l *Limiter) context.Context {
+	return context.WithValue(ctx, limiterKey{}, l)
+}
+
+// FromContext returns the limiter carried by the context, nil if there is none.
+func FromContext(ctx context.Context) *Limiter {
+	l, _ := ctx.Value(limiterKey{}).(*Limiter)
+	return l
+}
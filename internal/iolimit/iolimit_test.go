@@ -0,0 +1,55 @@
+package iolimit
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	clock "github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiterThrottles(t *testing.T) {
+	ctx := context.Background()
+	fakeClock := clock.NewFakeClockAt(time.Unix(1700000000, 0))
+	l := NewLimiter(fakeClock, 100)
+
+	// the first second worth of bytes goes through
+	require.NoError(t, l.Wait(ctx, 100))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- l.Wait(ctx, 50)
+	}()
+	fakeClock.BlockUntil(1)
+	select {
+	case <-done:
+		t.Fatal("the limiter let bytes through beyond its rate")
+	default:
+	}
+	fakeClock.Advance(500 * time.Millisecond)
+	require.NoError(t, <-done)
+
+	// canceling the context stops waiting
+	canceled, cancel := context.WithCancel(ctx)
+	go func() {
+		done <- l.Wait(canceled, 50)
+	}()
+	fakeClock.BlockUntil(1)
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestNilLimiter(t *testing.T) {
+	var l *Limiter
+	require.Nil(t, NewLimiter(clock.NewFakeClock(), 0))
+	require.NoError(t, l.Wait(context.Background(), 1<<30))
+
+	var buf bytes.Buffer
+	w := l.Writer(context.Background(), &buf)
+	_, err := w.Write([]byte("backup"))
+	require.NoError(t, err)
+	require.Equal(t, "backup", buf.String())
+	require.Nil(t, FromContext(context.Background()))
+}
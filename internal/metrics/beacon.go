@@ -0,0 +1,111 @@
+package metrics
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+
+	common2 "github.com/drand/drand/v2/common"
+)
+
+// BeaconIDLabel is the label carried by all the metrics specific to a beacon
+const BeaconIDLabel = "beacon_id"
+
+// beaconLabel returns the value of the beacon ID label, so that the default beacon is always reported the same way
+func beaconLabel(beaconID string) string {
+	return common2.GetCanonicalBeaconID(beaconID)
+}
+
+// beaconGatherer gathers the group metrics of a single beacon, so that each chain of a multibeacon daemon can be
+// scraped on its own without its series colliding with the ones of the other chains
+type beaconGatherer struct {
+	beaconID string
+	from     prometheus.Gatherer
+}
+
+// BeaconGatherer returns the registry of the metrics specific to the given beacon ID
+func BeaconGatherer(beaconID string) prometheus.Gatherer {
+	return &beaconGatherer{beaconID: beaconLabel(beaconID), from: GroupMetrics}
+}
+
+func (g *beaconGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.from.Gather()
+	if err != nil {
+		return nil, err
+	}
+	filtered := make([]*dto.MetricFamily, 0, len(families))
+	for _, f := range families {
+		var series []*dto.Metric
+		for _, m := range f.GetMetric() {
+			if hasBeaconID(m, g.beaconID) {
+				series = append(series, m)
+			}
+		}
+		if len(series) > 0 {
+			f.Metric = series
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered, nil
+}
+
+func hasBeaconID(m *dto.Metric, beaconID string) bool {
+	for _, l := range m.GetLabel() {
+		if l.GetName() == BeaconIDLabel {
+			return l.GetValue() == beaconID
+		}
+	}
+	return false
+}
+
+// beaconHandler serves the metrics of the beacon whose ID is given in the URI, in the form /metrics/beacon/<id>
+func beaconHandler(w http.ResponseWriter, r *http.Request) {
+	beaconID := strings.Trim(strings.TrimPrefix(r.URL.Path, "/metrics/beacon/"), "/")
+	g := BeaconGatherer(beaconID)
+	promhttp.HandlerFor(g, promhttp.HandlerOpts{}).ServeHTTP(w, r)
+}
+
+// Description describes a metric currently exported by the daemon
+type Description struct {
+	Name   string
+	Help   string
+	Type   string
+	Labels []string
+}
+
+// List describes the group metrics currently exported, only the ones of the given beacon if it isn't empty
+func List(beaconID string) ([]Description, error) {
+	var g prometheus.Gatherer = GroupMetrics
+	if beaconID != "" {
+		g = BeaconGatherer(beaconID)
+	}
+	families, err := g.Gather()
+	if err != nil {
+		return nil, err
+	}
+
+	descriptions := make([]Description, 0, len(families))
+	for _, f := range families {
+		labels := make(map[string]bool)
+		for _, m := range f.GetMetric() {
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = true
+			}
+		}
+		d := Description{
+			Name: f.GetName(),
+			Help: f.GetHelp(),
+			Type: strings.ToLower(f.GetType().String()),
+		}
+		for l := range labels {
+			d.Labels = append(d.Labels, l)
+		}
+		sort.Strings(d.Labels)
+		descriptions = append(descriptions, d)
+	}
+	return descriptions, nil
+}
@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBeaconGathererOnlyKeepsTheSeriesOfTheBeacon(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	rounds := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "rounds", Help: "rounds"}, []string{BeaconIDLabel})
+	peers := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "peers", Help: "peers"}, []string{"address"})
+	reg.MustRegister(rounds, peers)
+
+	rounds.WithLabelValues(beaconLabel("")).Set(1)
+	rounds.WithLabelValues("quicknet").Set(2)
+	peers.WithLabelValues("node:1234").Set(3)
+
+	families, err := (&beaconGatherer{beaconID: beaconLabel(""), from: reg}).Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	require.Equal(t, "rounds", families[0].GetName())
+	require.Len(t, families[0].GetMetric(), 1)
+	require.Equal(t, "default", families[0].GetMetric()[0].GetLabel()[0].GetValue())
+	require.Equal(t, 1.0, families[0].GetMetric()[0].GetGauge().GetValue())
+}
@@ -221,7 +221,7 @@ var (
 	DrandStorageBackend = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "drand_node_db",
 		Help: "The database type the node is running with. 1=bolt-trimmed, 2=postgres, 3=memdb, 4=bolt-untrimmed",
-	}, []string{"beaconID", "db_type"})
+	}, []string{"beacon_id", "db_type"})
 
 	// OutgoingConnectionState (Group) tracks the state of an outgoing connection, using the states from
 	// https://github.com/grpc/grpc-go/blob/8075dd35d2738b352c4355b4b353dc1e9183bea7/connectivity/connectivity.go#L51-L62
@@ -245,7 +245,136 @@ var (
 		Name: "error_sending_partial",
 		Help: "Number of errors sending partial beacons to nodes. A good proxy for whether nodes are up or down. " +
 			"1 = Error occurred, 0 = No error occurred",
-	}, []string{"beaconID", "address"})
+	}, []string{"beacon_id", "address"})
+
+	partialsSigned = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "partials_signed",
+		Help: "Number of partial beacons signed with the local key share",
+	}, []string{"beacon_id"})
+
+	partialSigningAnomalies = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "partial_signing_anomalies",
+		Help: "Number of partial beacons signed for a round that had already been signed less than a period before",
+	}, []string{"beacon_id"})
+
+	partialsRecovered = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "partials_recovered",
+		Help: "Number of partial beacons delivered to a node by pushing them again after a failure",
+	}, []string{"beacon_id", "address"})
+
+	partialsRetryExpired = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "partials_retry_expired",
+		Help: "Number of partial beacons which couldn't be delivered to a node before the end of their round",
+	}, []string{"beacon_id", "address"})
+
+	partialsRetryDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "partials_retry_dropped",
+		Help: "Number of partial beacons dropped from the retry queue of a node to make room for newer ones",
+	}, []string{"beacon_id", "address"})
+
+	forksDetected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "forks_detected",
+		Help: "Number of rounds for which different valid signatures were served by the nodes followed",
+	}, []string{"beacon_id"})
+
+	partialsShed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "partials_shed",
+		Help: "Number of valid partial beacons dropped because the aggregation inbox was full",
+	}, []string{"beacon_id", "queue"})
+
+	publicKeyCacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "public_key_cache_lookups",
+		Help: "Number of lookups of member public keys to verify partials, by result (hit or miss)",
+	}, []string{"beacon_id", "result"})
+
+	syncMemoryBudget = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sync_memory_budget_bytes",
+		Help: "Memory the beacons buffered while syncing can use",
+	}, []string{"beacon_id"})
+
+	syncMemoryUsed = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sync_memory_used_bytes",
+		Help: "Memory reserved by the streams of beacons currently syncing",
+	}, []string{"beacon_id"})
+
+	syncInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sync_in_flight_beacons",
+		Help: "Number of beacons received from a peer and waiting to be stored by the last sync",
+	}, []string{"beacon_id"})
+
+	syncThrottled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "sync_throttled",
+		Help: "Number of syncs which had to wait for memory to be released before starting",
+	}, []string{"beacon_id"})
+
+	healthScore = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "health_score",
+		Help: "Health of the beacon from 0 to 100, from its lag, connectivity, missed partials and DKG state",
+	}, []string{"beacon_id"})
+
+	slowStoreOperations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "slow_store_operations",
+		Help: "Number of operations of the chain store which took longer than the slow store threshold",
+	}, []string{"beacon_id", "backend", "op"})
+
+	featureEnabled = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "feature_enabled",
+		Help: "Whether a feature flag is enabled (1) or not (0) for the beacon",
+	}, []string{"beacon_id", "feature"})
+
+	quorumExpectedPartials = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "quorum_expected_partials",
+		Help: "Number of partials the group is expected to produce per round, from the members reachable and their health",
+	}, []string{"beacon_id"})
+
+	quorumLost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "quorum_lost",
+		Help: "Whether the group is projected to fall below the threshold (1) or not (0)",
+	}, []string{"beacon_id"})
+
+	beaconRestarts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "beacon_restarts",
+		Help: "Number of times the beacon loop failed and was restarted by its supervisor",
+	}, []string{"beacon_id"})
+
+	pushedRound = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "pushed_round",
+		Help: "Last round acknowledged by each downstream node the chain is pushed to",
+	}, []string{"beacon_id", "target"})
+
+	mirroredRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mirrored_requests",
+		Help: "Number of public requests mirrored to the shadow daemon, by whether its response matched ours",
+	}, []string{"method", "result"})
+
+	shedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "shed_requests",
+		Help: "Number of public requests shed while the node was late producing the rounds, by traffic class",
+	}, []string{"class"})
+
+	apiRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "api_requests",
+		Help: "Number of requests to the public API subject to the quotas, by client and outcome",
+	}, []string{"client", "outcome"})
+
+	recoveredPanics = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "recovered_panics",
+		Help: "Number of panics recovered in the modules of the daemon, each with a crash report written to disk",
+	}, []string{"beacon_id", "module"})
+
+	rpcLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_rpc_duration_seconds",
+		Help:    "Duration of the gRPC calls handled (server) or made (client) by the node, streams included",
+		Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"side", "method", "peer", "code"})
+
+	rpcInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "grpc_rpc_in_flight",
+		Help: "Number of gRPC calls currently handled (server) or made (client) by the node",
+	}, []string{"side", "method", "peer"})
+
+	// dkgStates is the last DKG state reported for each beacon ID
+	dkgStates sync.Map
 
 	metricsBound sync.Once
 )
@@ -282,6 +411,31 @@ func bindMetrics(l log.Logger) {
 		DrandStartTimestamp,
 		DrandStorageBackend,
 		ErrorSendingPartialCounter,
+		partialsSigned,
+		partialSigningAnomalies,
+		partialsRecovered,
+		partialsRetryExpired,
+		partialsRetryDropped,
+		forksDetected,
+		partialsShed,
+		publicKeyCacheLookups,
+		syncMemoryBudget,
+		syncMemoryUsed,
+		syncInFlight,
+		syncThrottled,
+		healthScore,
+		rpcLatency,
+		rpcInFlight,
+		slowStoreOperations,
+		mirroredRequests,
+		featureEnabled,
+		beaconRestarts,
+		quorumExpectedPartials,
+		quorumLost,
+		pushedRound,
+		shedRequests,
+		apiRequests,
+		recoveredPanics,
 	}
 	for _, c := range group {
 		if err := GroupMetrics.Register(c); err != nil {
@@ -373,6 +527,7 @@ func Start(logger log.Logger, metricsBind string, pprof http.Handler, cli Client
 
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhttp.HandlerFor(PrivateMetrics, promhttp.HandlerOpts{Registry: PrivateMetrics}))
+	mux.HandleFunc("/metrics/beacon/", beaconHandler)
 
 	mux.Handle("/peer/", newRemotePeerHandler(logger, cli))
 
@@ -447,16 +602,157 @@ func DKGStateChange(beaconID string, epoch uint32, leader bool, state uint32) {
 	if leader {
 		leading = 1.0
 	}
-	dkgEpoch.WithLabelValues(beaconID).Set(float64(epoch))
-	dkgState.WithLabelValues(beaconID).Set(float64(state))
-	dkgStateTimestamp.WithLabelValues(beaconID).SetToCurrentTime()
-	dkgLeader.WithLabelValues(beaconID).Set(leading)
+	dkgEpoch.WithLabelValues(beaconLabel(beaconID)).Set(float64(epoch))
+	dkgState.WithLabelValues(beaconLabel(beaconID)).Set(float64(state))
+	dkgStateTimestamp.WithLabelValues(beaconLabel(beaconID)).SetToCurrentTime()
+	dkgLeader.WithLabelValues(beaconLabel(beaconID)).Set(leading)
+	dkgStates.Store(beaconLabel(beaconID), state)
+}
+
+// DKGState returns the last DKG state reported for the given beacon ID, if any
+func DKGState(beaconID string) (uint32, bool) {
+	state, ok := dkgStates.Load(beaconLabel(beaconID))
+	if !ok {
+		return 0, false
+	}
+	return state.(uint32), true
 }
 
 func ErrorSendingPartial(beaconID, address string) {
-	ErrorSendingPartialCounter.WithLabelValues(beaconID, address).Set(1)
+	ErrorSendingPartialCounter.WithLabelValues(beaconLabel(beaconID), address).Set(1)
 }
 
 func SuccessfulPartial(beaconID, address string) {
-	ErrorSendingPartialCounter.WithLabelValues(beaconID, address).Set(0)
+	ErrorSendingPartialCounter.WithLabelValues(beaconLabel(beaconID), address).Set(0)
+}
+
+// PartialRecovered records a partial delivered to a node by pushing it again after a failure
+func PartialRecovered(beaconID, address string) {
+	SuccessfulPartial(beaconID, address)
+	partialsRecovered.WithLabelValues(beaconLabel(beaconID), address).Inc()
+}
+
+// ForkDetected records a round for which different valid signatures were served
+func ForkDetected(beaconID string) {
+	forksDetected.WithLabelValues(beaconLabel(beaconID)).Inc()
+}
+
+// PartialShed records a valid partial dropped from the given queue of the aggregation inbox
+func PartialShed(beaconID, queue string) {
+	partialsShed.WithLabelValues(beaconLabel(beaconID), queue).Inc()
+}
+
+// PublicKeyCacheLookup records a lookup of a member public key to verify a partial
+func PublicKeyCacheLookup(beaconID string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	publicKeyCacheLookups.WithLabelValues(beaconLabel(beaconID), result).Inc()
+}
+
+// SyncMemoryBudget sets the memory the beacons buffered while syncing can use
+func SyncMemoryBudget(beaconID string, bytes int64) {
+	syncMemoryBudget.WithLabelValues(beaconLabel(beaconID)).Set(float64(bytes))
+}
+
+// SyncMemoryUsed sets the memory reserved by the streams of beacons currently syncing
+func SyncMemoryUsed(beaconID string, bytes int64) {
+	syncMemoryUsed.WithLabelValues(beaconLabel(beaconID)).Set(float64(bytes))
+}
+
+// SyncInFlight sets the number of beacons received from a peer and waiting to be stored
+func SyncInFlight(beaconID string, beacons int) {
+	syncInFlight.WithLabelValues(beaconLabel(beaconID)).Set(float64(beacons))
+}
+
+// SyncThrottled records a sync waiting for memory to be released before starting
+func SyncThrottled(beaconID string) {
+	syncThrottled.WithLabelValues(beaconLabel(beaconID)).Inc()
+}
+
+// HealthScore sets the health score of the beacon, from 0 to 100
+func HealthScore(beaconID string, score uint32) {
+	healthScore.WithLabelValues(beaconLabel(beaconID)).Set(float64(score))
+}
+
+// SlowStoreOperation records an operation of the chain store which took longer than the slow store threshold
+func SlowStoreOperation(beaconID, backend, op string) {
+	slowStoreOperations.WithLabelValues(beaconLabel(beaconID), backend, op).Inc()
+}
+
+// FeatureEnabled records whether the feature flag is enabled for the beacon
+func FeatureEnabled(beaconID, feature string, enabled bool) {
+	value := 0.0
+	if enabled {
+		value = 1
+	}
+	featureEnabled.WithLabelValues(beaconLabel(beaconID), feature).Set(value)
+}
+
+// BeaconRestart records a failure of the beacon loop, which its supervisor restarts
+func BeaconRestart(beaconID string) {
+	beaconRestarts.WithLabelValues(beaconLabel(beaconID)).Inc()
+}
+
+// QuorumProjection records the number of partials the group is expected to produce and whether that is below the
+// threshold
+func QuorumProjection(beaconID string, expected float64, lost bool) {
+	quorumExpectedPartials.WithLabelValues(beaconLabel(beaconID)).Set(expected)
+	value := 0.0
+	if lost {
+		value = 1
+	}
+	quorumLost.WithLabelValues(beaconLabel(beaconID)).Set(value)
+}
+
+// PushedRound records the last round acknowledged by a downstream node the chain is pushed to
+func PushedRound(beaconID, target string, round uint64) {
+	pushedRound.WithLabelValues(beaconLabel(beaconID), target).Set(float64(round))
+}
+
+// MirroredRequest records the result of a public request mirrored to the shadow daemon
+func MirroredRequest(method, result string) {
+	mirroredRequests.WithLabelValues(method, result).Inc()
+}
+
+// ShedRequest records a public request of the given traffic class shed by the node
+func ShedRequest(class string) {
+	shedRequests.WithLabelValues(class).Inc()
+}
+
+// APIRequest records a request to the public API of the given client, anonymous if it has no API key, and whether
+// it was served, rate limited, over its daily quota or unauthenticated
+func APIRequest(client, outcome string) {
+	apiRequests.WithLabelValues(client, outcome).Inc()
+}
+
+// RecoveredPanic records a panic recovered in a module of the daemon, for a beacon if it is specific to one
+func RecoveredPanic(beaconID, module string) {
+	if beaconID != "" {
+		beaconID = beaconLabel(beaconID)
+	}
+	recoveredPanics.WithLabelValues(beaconID, module).Inc()
+}
+
+// RPCStarted records a gRPC call starting on the given side, server or client, of the connection with the peer.
+// The returned function must be called with the status code of the call once it is over.
+func RPCStarted(side, method, peer string) func(code string) {
+	start := time.Now()
+	inFlight := rpcInFlight.WithLabelValues(side, method, peer)
+	inFlight.Inc()
+	return func(code string) {
+		inFlight.Dec()
+		rpcLatency.WithLabelValues(side, method, peer, code).Observe(time.Since(start).Seconds())
+	}
+}
+
+// PartialRetryExpired records a partial which couldn't be delivered to a node before the end of its round
+func PartialRetryExpired(beaconID, address string) {
+	partialsRetryExpired.WithLabelValues(beaconLabel(beaconID), address).Inc()
+}
+
+// PartialRetryDropped records a partial dropped from the retry queue of a node, full of more recent ones
+func PartialRetryDropped(beaconID, address string) {
+	partialsRetryDropped.WithLabelValues(beaconLabel(beaconID), address).Inc()
 }
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	promBridge "go.opentelemetry.io/contrib/bridges/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.18.0"
+
+	"github.com/drand/drand/v2/common/log"
+)
+
+// DefaultOTLPInterval is the default interval between two exports of the metrics to an OpenTelemetry collector
+const DefaultOTLPInterval = 30 * time.Second
+
+// StartOTLP periodically pushes all the metrics served at /metrics to the OpenTelemetry collector listening on the
+// given host:port, using OTLP over gRPC. It works whether or not the Prometheus endpoint is started, and the
+// returned function flushes the metrics one last time and stops the export.
+func StartOTLP(ctx context.Context, l log.Logger, appName, endpoint string, interval time.Duration) (func(context.Context), error) {
+	metricsBound.Do(func() {
+		bindMetrics(l)
+	})
+	if interval <= 0 {
+		interval = DefaultOTLPInterval
+	}
+
+	exporter, err := otlpmetricgrpc.New(ctx,
+		otlpmetricgrpc.WithInsecure(), // same as the traces exporter
+		otlpmetricgrpc.WithEndpoint(endpoint),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating new metrics exporter: %w", err)
+	}
+	resources, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceNameKey.String(appName),
+			attribute.String("library.language", "go"),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating new metrics exporter: %w", err)
+	}
+
+	// the existing Prometheus metrics are bridged, so that both exporters always report the same values
+	reader := sdkmetric.NewPeriodicReader(exporter,
+		sdkmetric.WithInterval(interval),
+		sdkmetric.WithProducer(promBridge.NewMetricProducer(promBridge.WithGatherer(PrivateMetrics))),
+	)
+	provider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(reader),
+		sdkmetric.WithResource(resources),
+	)
+	l.Infow("exporting metrics with OTLP", "endpoint", endpoint, "interval", interval)
+
+	return func(ctx context.Context) {
+		if err := provider.Shutdown(ctx); err != nil {
+			l.Warnw("failed to shutdown the OTLP metrics exporter", "err", err)
+		}
+	}, nil
+}
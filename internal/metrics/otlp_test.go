@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	collector "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/grpc"
+
+	"github.com/drand/drand/v2/common/testlogger"
+)
+
+// fakeCollector is an OpenTelemetry collector reporting the names of the metrics it receives
+type fakeCollector struct {
+	collector.UnimplementedMetricsServiceServer
+	names chan string
+}
+
+func (f *fakeCollector) Export(_ context.Context, req *collector.ExportMetricsServiceRequest) (
+	*collector.ExportMetricsServiceResponse, error) {
+	for _, rm := range req.GetResourceMetrics() {
+		for _, sm := range rm.GetScopeMetrics() {
+			for _, m := range sm.GetMetrics() {
+				select {
+				case f.names <- m.GetName():
+				default:
+				}
+			}
+		}
+	}
+	return &collector.ExportMetricsServiceResponse{}, nil
+}
+
+func TestStartOTLPExportsPrometheusMetrics(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	fake := &fakeCollector{names: make(chan string, 1000)}
+	server := grpc.NewServer()
+	collector.RegisterMetricsServiceServer(server, fake)
+	go func() { _ = server.Serve(l) }()
+	defer server.Stop()
+
+	ctx := context.Background()
+	shutdown, err := StartOTLP(ctx, testlogger.New(t), "drand-test", l.Addr().String(), 50*time.Millisecond)
+	require.NoError(t, err)
+	defer shutdown(ctx)
+	ForkDetected("otlp-test")
+
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case name := <-fake.names:
+			if name == "forks_detected" {
+				return
+			}
+		case <-timeout:
+			t.Fatal("the metrics weren't exported to the collector")
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/drand/drand/v2/common/log"
+)
+
+// ShareUsageMonitor keeps track of the partial signatures created with the local key share.
+// A node is expected to sign each round once, or at most once per period while the network is stalled and the
+// same round gets broadcast again. Signing rounds faster than that hints at replayed requests or a buggy peer
+// asking us to re-sign, so it is reported as an anomaly.
+type ShareUsageMonitor struct {
+	lock         sync.Mutex
+	log          log.Logger
+	beaconID     string
+	period       time.Duration
+	signed       uint64
+	anomalies    uint64
+	lastRound    uint64
+	lastSignedAt time.Time
+}
+
+func NewShareUsageMonitor(beaconID string, l log.Logger, period time.Duration) *ShareUsageMonitor {
+	return &ShareUsageMonitor{
+		log:      l,
+		beaconID: beaconID,
+		period:   period,
+	}
+}
+
+// Signed records that the local share signed a partial for the given round at the given time.
+// It returns true if that signature was anomalous.
+func (s *ShareUsageMonitor) Signed(round uint64, at time.Time) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.signed++
+	partialsSigned.WithLabelValues(beaconLabel(s.beaconID)).Inc()
+
+	// we allow for some jitter of the ticker when the same round gets signed once per period
+	anomalous := s.signed > 1 && round <= s.lastRound && at.Sub(s.lastSignedAt) < s.period/2
+	if anomalous {
+		s.anomalies++
+		partialSigningAnomalies.WithLabelValues(beaconLabel(s.beaconID)).Inc()
+		s.log.Warnw("local share signed a round more than once per period",
+			"beaconID", s.beaconID,
+			"round", round,
+			"lastRound", s.lastRound,
+			"sinceLastSignature", at.Sub(s.lastSignedAt),
+			"anomalies", s.anomalies,
+		)
+	}
+
+	if round > s.lastRound {
+		s.lastRound = round
+	}
+	s.lastSignedAt = at
+	return anomalous
+}
+
+// Counts returns the number of partials signed and how many of them were anomalous
+func (s *ShareUsageMonitor) Counts() (signed, anomalies uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.signed, s.anomalies
+}
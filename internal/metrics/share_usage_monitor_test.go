@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/drand/drand/v2/common/log"
+)
+
+func TestShareUsageMonitorFlagsResigning(t *testing.T) {
+	period := 3 * time.Second
+	monitor := NewShareUsageMonitor("some-beacon", log.New(nil, log.DebugLevel, true), period)
+	start := time.Unix(1700000000, 0)
+
+	// one round per period is the nominal behaviour
+	require.False(t, monitor.Signed(1, start))
+	require.False(t, monitor.Signed(2, start.Add(period)))
+	// catching up signs successive rounds faster than the period
+	require.False(t, monitor.Signed(3, start.Add(period+100*time.Millisecond)))
+	// re-broadcasting the same round on the next tick when the network is stalled
+	require.False(t, monitor.Signed(3, start.Add(2*period+100*time.Millisecond)))
+
+	// but signing the same round again right away isn't
+	require.True(t, monitor.Signed(3, start.Add(2*period+200*time.Millisecond)))
+	// nor is going back to an older round
+	require.True(t, monitor.Signed(1, start.Add(2*period+300*time.Millisecond)))
+
+	signed, anomalies := monitor.Counts()
+	require.Equal(t, uint64(6), signed)
+	require.Equal(t, uint64(2), anomalies)
+}
@@ -16,9 +16,13 @@ type ThresholdMonitor struct {
 	groupSize         int
 	threshold         int
 	failedConnections map[string]bool
-	ctx               context.Context
-	cancel            func()
-	period            time.Duration
+	// lastFailures is the number of nodes that failed during the last complete period
+	lastFailures int
+	// lastFailedConnections are the nodes that failed during the last complete period
+	lastFailedConnections map[string]bool
+	ctx                   context.Context
+	cancel                func()
+	period                time.Duration
 }
 
 func NewThresholdMonitor(beaconID string, l log.Logger, groupSize, threshold int) *ThresholdMonitor {
@@ -85,6 +89,8 @@ func (t *ThresholdMonitor) Start() {
 				t.lock.RUnlock()
 
 				t.lock.Lock()
+				t.lastFailures = len(t.failedConnections)
+				t.lastFailedConnections = t.failedConnections
 				t.failedConnections = make(map[string]bool)
 				t.lock.Unlock()
 
@@ -105,6 +111,21 @@ func (t *ThresholdMonitor) ReportFailure(beaconID, addr string) {
 	t.lock.Unlock()
 }
 
+// FailingNodes returns the number of nodes we failed to send partials to during the current or the last period,
+// whichever is the highest
+func (t *ThresholdMonitor) FailingNodes() int {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return max(len(t.failedConnections), t.lastFailures)
+}
+
+// IsFailing returns whether we failed to send partials to the given node during the current or the last period
+func (t *ThresholdMonitor) IsFailing(addr string) bool {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.failedConnections[addr] || t.lastFailedConnections[addr]
+}
+
 func (t *ThresholdMonitor) Update(newThreshold, groupSize int) {
 	t.lock.Lock()
 	t.threshold = newThreshold
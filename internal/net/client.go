@@ -27,6 +27,20 @@ type Stoppable interface {
 	Stop()
 }
 
+// Redirectable is implemented by the clients which can reach a peer at another address than the one it is known by,
+// once it announced that its address changed. The redirects are kept by beacon, since the connections are shared by
+// the beacons of the daemon.
+type Redirectable interface {
+	Redirect(beaconID, from, to string)
+}
+
+// Overridable is implemented by the clients which can dial a peer at another address than the one it is known by,
+// as set by the operator. The overrides are kept by beacon, since the connections are shared by the beacons of the
+// daemon.
+type Overridable interface {
+	Override(beaconID, from, to string)
+}
+
 // CallOption is simply a wrapper around the grpc options
 type CallOption = grpc.CallOption
 
@@ -37,6 +51,14 @@ type ProtocolClient interface {
 	SyncChain(ctx context.Context, p Peer, in *drand.SyncRequest, opts ...CallOption) (chan *drand.BeaconPacket, error)
 	PartialBeacon(ctx context.Context, p Peer, in *drand.PartialBeaconPacket, opts ...CallOption) error
 	Status(context.Context, Peer, *drand.StatusRequest, ...grpc.CallOption) (*drand.StatusResponse, error)
+	ProposeUpgrade(ctx context.Context, p Peer, in *drand.UpgradeProposal, opts ...CallOption) error
+	AcknowledgeUpgrade(ctx context.Context, p Peer, in *drand.UpgradeAcknowledgement, opts ...CallOption) error
+	AnnounceLeave(ctx context.Context, p Peer, in *drand.LeaveAnnouncement, opts ...CallOption) error
+	AnnounceAddress(ctx context.Context, p Peer, in *drand.AddressAnnouncement, opts ...CallOption) error
+	AnnounceReshareForecast(ctx context.Context, p Peer, in *drand.ReshareForecastAnnouncement, opts ...CallOption) error
+	PartialHeartbeat(ctx context.Context, p Peer, in *drand.PartialHeartbeatPacket, opts ...CallOption) error
+	PartialSubBeacon(ctx context.Context, p Peer, in *drand.PartialSubBeaconPacket, opts ...CallOption) error
+	PushBeacon(ctx context.Context, p Peer, in *drand.PushedBeacon, opts ...CallOption) (*drand.PushBeaconAck, error)
 	Check(ctx context.Context, p Peer) error
 }
 
@@ -47,6 +69,8 @@ type PublicClient interface {
 	PublicRand(ctx context.Context, p Peer, in *drand.PublicRandRequest) (*drand.PublicRandResponse, error)
 	ChainInfo(ctx context.Context, p Peer, in *drand.ChainInfoRequest) (*drand.ChainInfoPacket, error)
 	ListBeaconIDs(ctx context.Context, p Peer) (*drand.ListBeaconIDsResponse, error)
+	GetCapabilities(ctx context.Context, p Peer, in *drand.CapabilitiesRequest) (*drand.Capabilities, error)
+	GroupMembership(ctx context.Context, p Peer, in *drand.GroupMembershipRequest) (*drand.GroupMembershipResponse, error)
 }
 
 type MetricsClient interface {
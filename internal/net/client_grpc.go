@@ -16,6 +16,8 @@ import (
 
 	"github.com/drand/drand/v2/common/log"
 	"github.com/drand/drand/v2/common/tracer"
+	dcontext "github.com/drand/drand/v2/internal/context"
+	"github.com/drand/drand/v2/internal/metrics"
 	"github.com/drand/drand/v2/protobuf/drand"
 )
 
@@ -26,7 +28,13 @@ var _ Client = (*grpcClient)(nil)
 // using gRPC as its underlying mechanism
 type grpcClient struct {
 	sync.RWMutex
-	conns         map[string]*grpc.ClientConn
+	conns map[string]*grpc.ClientConn
+	// the addresses the peers announced they are reachable at, by the address they are known by and by the beacon
+	// they were announced to
+	redirects map[string]map[string]string
+	// the addresses the operator set to dial the peers at, by the address they are known by and by the beacon they
+	// were set for, which take precedence over the redirects
+	overrides     map[string]map[string]string
 	opts          []grpc.DialOption
 	timeout       time.Duration
 	healthTimeout time.Duration
@@ -42,6 +50,8 @@ func NewGrpcClient(l log.Logger, opts ...grpc.DialOption) Client {
 	client := grpcClient{
 		opts:          opts,
 		conns:         make(map[string]*grpc.ClientConn),
+		redirects:     make(map[string]map[string]string),
+		overrides:     make(map[string]map[string]string),
 		timeout:       defaultConnTimeout,
 		healthTimeout: defaultHealthTimeout,
 		log:           l,
@@ -161,11 +171,11 @@ func (g *grpcClient) PartialBeacon(ctx context.Context, p Peer, in *drand.Partia
 	return err
 }
 
-// MaxSyncBuffer is the maximum number of queued rounds when syncing
+// MaxSyncBuffer is the maximum number of queued rounds when syncing, unless the context sets another one
 const MaxSyncBuffer = 500
 
 func (g *grpcClient) SyncChain(ctx context.Context, p Peer, in *drand.SyncRequest, _ ...CallOption) (chan *drand.BeaconPacket, error) {
-	resp := make(chan *drand.BeaconPacket, MaxSyncBuffer)
+	resp := make(chan *drand.BeaconPacket, dcontext.SyncBufferFromContext(ctx, MaxSyncBuffer))
 	c, err := g.conn(p)
 	if err != nil {
 		return nil, err
@@ -215,6 +225,36 @@ func (g *grpcClient) Status(ctx context.Context, p Peer, in *drand.StatusRequest
 	return resp, err
 }
 
+func (g *grpcClient) ProposeUpgrade(ctx context.Context, p Peer, in *drand.UpgradeProposal, opts ...CallOption) error {
+	ctx, span := tracer.NewSpan(ctx, "client.ProposeUpgrade")
+	defer span.End()
+
+	c, err := g.conn(p)
+	if err != nil {
+		return err
+	}
+	client := drand.NewProtocolClient(c)
+	ctx, cancel := g.getTimeoutContext(ctx)
+	defer cancel()
+	_, err = client.ProposeUpgrade(ctx, in, opts...)
+	return err
+}
+
+func (g *grpcClient) AcknowledgeUpgrade(ctx context.Context, p Peer, in *drand.UpgradeAcknowledgement, opts ...CallOption) error {
+	ctx, span := tracer.NewSpan(ctx, "client.AcknowledgeUpgrade")
+	defer span.End()
+
+	c, err := g.conn(p)
+	if err != nil {
+		return err
+	}
+	client := drand.NewProtocolClient(c)
+	ctx, cancel := g.getTimeoutContext(ctx)
+	defer cancel()
+	_, err = client.AcknowledgeUpgrade(ctx, in, opts...)
+	return err
+}
+
 func (g *grpcClient) Stop() {
 	g.Lock()
 	defer g.Unlock()
@@ -224,6 +264,110 @@ func (g *grpcClient) Stop() {
 	g.conns = make(map[string]*grpc.ClientConn)
 }
 
+// Redirect makes the client reach the peer known by the address from at the address to for the given beacon, or at
+// the address it is known by again if they are the same. The connection to the peer is closed if the address it is
+// dialed at changed, to be dialed again at its new address.
+func (g *grpcClient) Redirect(beaconID, from, to string) {
+	g.Lock()
+	defer g.Unlock()
+	before := g.target(from)
+	setAddress(g.redirects, beaconID, from, to, from == to)
+	if g.target(from) != before {
+		g.closeConn(from)
+	}
+}
+
+// Override makes the client dial the peer known by the address from at the address to for the given beacon,
+// whatever the peer announced, or stop overriding its address if to is empty. The connection to the peer is closed if
+// the address it is dialed at changed, to be dialed again.
+func (g *grpcClient) Override(beaconID, from, to string) {
+	g.Lock()
+	defer g.Unlock()
+	before := g.target(from)
+	setAddress(g.overrides, beaconID, from, to, to == "")
+	if g.target(from) != before {
+		g.closeConn(from)
+	}
+}
+
+// setAddress sets the address to of the peer known by the address from for the given beacon in the table, or
+// removes it
+func setAddress(table map[string]map[string]string, beaconID, from, to string, remove bool) {
+	if remove {
+		delete(table[from], beaconID)
+		if len(table[from]) == 0 {
+			delete(table, from)
+		}
+		return
+	}
+	if table[from] == nil {
+		table[from] = make(map[string]string)
+	}
+	table[from][beaconID] = to
+}
+
+// tableAddress returns the address of the peer known by the address in the table, if any. The connections being
+// shared by the beacons, the one set for the first beacon in order is used when the beacons disagree.
+func tableAddress(table map[string]map[string]string, addr string) (string, bool) {
+	first, to := "", ""
+	for beaconID, a := range table[addr] {
+		if to == "" || beaconID < first {
+			first, to = beaconID, a
+		}
+	}
+	return to, to != ""
+}
+
+// closeConn closes the connection to the peer, if any. It must be called with the lock held.
+func (g *grpcClient) closeConn(addr string) {
+	if c, ok := g.conns[addr]; ok {
+		go c.Close()
+		delete(g.conns, addr)
+		metrics.OutgoingConnections.Set(float64(len(g.conns)))
+	}
+}
+
+// target returns the address to dial the peer known by the address at. It must be called with the lock held.
+func (g *grpcClient) target(addr string) string {
+	if to, ok := tableAddress(g.overrides, addr); ok {
+		return to
+	}
+	if to, ok := tableAddress(g.redirects, addr); ok {
+		return to
+	}
+	return addr
+}
+
+func (g *grpcClient) AnnounceAddress(ctx context.Context, p Peer, in *drand.AddressAnnouncement, opts ...CallOption) error {
+	ctx, span := tracer.NewSpan(ctx, "client.AnnounceAddress")
+	defer span.End()
+
+	c, err := g.conn(p)
+	if err != nil {
+		return err
+	}
+	client := drand.NewProtocolClient(c)
+	ctx, cancel := g.getTimeoutContext(ctx)
+	defer cancel()
+	_, err = client.AnnounceAddress(ctx, in, opts...)
+	return err
+}
+
+func (g *grpcClient) AnnounceReshareForecast(ctx context.Context, p Peer, in *drand.ReshareForecastAnnouncement, opts ...CallOption) error {
+	ctx, span := tracer.NewSpan(ctx, "client.AnnounceReshareForecast")
+	defer span.End()
+
+	c, err := g.conn(p)
+	if err != nil {
+		return err
+	}
+	client := drand.NewProtocolClient(c)
+	ctx, cancel := g.getTimeoutContext(ctx)
+	defer cancel()
+	_, err = client.AnnounceReshareForecast(ctx, in, opts...)
+	return err
+}
+
 func (g *grpcClient) GetMetrics(ctx context.Context, addr string) (string, error) {
 	g.log.Debugw("GetMetrics grpcClient called", "target_addr", addr)
 	p := CreatePeer(addr)
@@ -253,6 +397,30 @@ func (g *grpcClient) ListBeaconIDs(ctx context.Context, p Peer) (*drand.ListBeac
 	return client.ListBeaconIDs(ctx, &drand.ListBeaconIDsRequest{})
 }
 
+// GetCapabilities asks the peer what it supports, to adapt to it instead of probing it
+func (g *grpcClient) GetCapabilities(ctx context.Context, p Peer, in *drand.CapabilitiesRequest) (*drand.Capabilities, error) {
+	c, err := g.conn(p)
+	if err != nil {
+		return nil, err
+	}
+
+	client := drand.NewPublicClient(c)
+	return client.GetCapabilities(ctx, in)
+}
+
+// GroupMembership asks the peer for the composition of the group running the chain
+func (g *grpcClient) GroupMembership(ctx context.Context, p Peer, in *drand.GroupMembershipRequest) (*drand.GroupMembershipResponse, error) {
+	c, err := g.conn(p)
+	if err != nil {
+		return nil, err
+	}
+
+	client := drand.NewPublicClient(c)
+	ctx, cancel := g.getTimeoutContext(ctx)
+	defer cancel()
+	return client.GroupMembership(ctx, in)
+}
+
 func (g *grpcClient) Check(ctx context.Context, p Peer) error {
 	c, err := g.conn(p)
 	if err != nil {
@@ -274,3 +442,62 @@ func (g *grpcClient) Check(ctx context.Context, p Peer) error {
 
 	return nil
 }
+
+func (g *grpcClient) AnnounceLeave(ctx context.Context, p Peer, in *drand.LeaveAnnouncement, opts ...CallOption) error {
+	ctx, span := tracer.NewSpan(ctx, "client.AnnounceLeave")
+	defer span.End()
+
+	c, err := g.conn(p)
+	if err != nil {
+		return err
+	}
+	client := drand.NewProtocolClient(c)
+	ctx, cancel := g.getTimeoutContext(ctx)
+	defer cancel()
+	_, err = client.AnnounceLeave(ctx, in, opts...)
+	return err
+}
+
+func (g *grpcClient) PartialHeartbeat(ctx context.Context, p Peer, in *drand.PartialHeartbeatPacket, opts ...CallOption) error {
+	ctx, span := tracer.NewSpan(ctx, "client.PartialHeartbeat")
+	defer span.End()
+
+	c, err := g.conn(p)
+	if err != nil {
+		return err
+	}
+	client := drand.NewProtocolClient(c)
+	ctx, cancel := g.getTimeoutContext(ctx)
+	defer cancel()
+	_, err = client.PartialHeartbeat(ctx, in, opts...)
+	return err
+}
+
+func (g *grpcClient) PartialSubBeacon(ctx context.Context, p Peer, in *drand.PartialSubBeaconPacket, opts ...CallOption) error {
+	ctx, span := tracer.NewSpan(ctx, "client.PartialSubBeacon")
+	defer span.End()
+
+	c, err := g.conn(p)
+	if err != nil {
+		return err
+	}
+	client := drand.NewProtocolClient(c)
+	ctx, cancel := g.getTimeoutContext(ctx)
+	defer cancel()
+	_, err = client.PartialSubBeacon(ctx, in, opts...)
+	return err
+}
+
+func (g *grpcClient) PushBeacon(ctx context.Context, p Peer, in *drand.PushedBeacon, opts ...CallOption) (*drand.PushBeaconAck, error) {
+	ctx, span := tracer.NewSpan(ctx, "client.PushBeacon")
+	defer span.End()
+
+	c, err := g.conn(p)
+	if err != nil {
+		return nil, err
+	}
+	client := drand.NewProtocolClient(c)
+	ctx, cancel := g.getTimeoutContext(ctx)
+	defer cancel()
+	return client.PushBeacon(ctx, in, opts...)
+}
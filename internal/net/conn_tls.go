@@ -3,7 +3,10 @@
 package net
 
 import (
+	"context"
 	"crypto/tls"
+	"errors"
+	"time"
 
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
@@ -43,11 +46,19 @@ func (g *grpcClient) conn(p Peer) (*grpc.ClientConn, error) {
 			[]grpc.DialOption{
 				grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 				grpc.WithTransportCredentials(credentials.NewTLS(config)),
+				grpc.WithChainUnaryInterceptor(RequestIDUnaryClientInterceptor, LatencyUnaryClientInterceptor),
+				grpc.WithChainStreamInterceptor(RequestIDStreamClientInterceptor, LatencyStreamClientInterceptor),
 			},
 			g.opts...,
 		)
 
-		c, err = grpc.NewClient(p.Address(), opts...)
+		target := g.target(p.Address())
+		if target != p.Address() {
+			// the peer is still authenticated by the address it is known by
+			opts = append(opts, grpc.WithAuthority(p.Address()))
+			g.log.Debugw("dialing the peer at another address", "peer", p.Address(), "at", target)
+		}
+		c, err = grpc.NewClient(target, opts...)
 		if err != nil {
 			g.log.Errorw("error initiating a new TLS grpc conn", "to", p.Address(), "err", err)
 			// We increase the GroupDialFailures counter when both failed
@@ -65,3 +76,19 @@ func (g *grpcClient) conn(p Peer) (*grpc.ClientConn, error) {
 	}
 	return c, err
 }
+
+// CertificateExpiry returns when the TLS certificate served at the given address expires
+func CertificateExpiry(ctx context.Context, addr string) (time.Time, error) {
+	dialer := &tls.Dialer{Config: &tls.Config{MinVersion: tls.VersionTLS12}}
+	c, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer c.Close()
+
+	certs := c.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return time.Time{}, errors.New("no certificate served")
+	}
+	return certs[0].NotAfter, nil
+}
@@ -28,8 +28,12 @@ type ControlListener struct {
 
 // NewGRPCListener registers the pairing between a ControlServer and a grpc server. Note that this is using a
 // regular, non-TLS listener, this is assuming local connection from control client to control server.
-func NewGRPCListener(l log.Logger, s Service, controlAddr string) (ControlListener, error) {
-	grpcServer := grpc.NewServer()
+func NewGRPCListener(l log.Logger, s Service, controlAddr string, opts ...grpc.ServerOption) (ControlListener, error) {
+	opts = append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(RequestIDUnaryServerInterceptor, RecoveryUnaryServerInterceptor(l)),
+		grpc.ChainStreamInterceptor(RequestIDStreamServerInterceptor, RecoveryStreamServerInterceptor(l)),
+	}, opts...)
+	grpcServer := grpc.NewServer(opts...)
 	lis, err := newListener(controlAddr)
 	if err != nil {
 		l.Errorw("", "grpc listener", "failure", "err", err)
@@ -83,14 +87,14 @@ type ControlClient struct {
 }
 
 // NewControlClient creates a client capable of issuing proto commands to a
-// 127.0.0.1 running drand node.
-func NewControlClient(l log.Logger, addr string) (*ControlClient, error) {
+// 127.0.0.1 running drand node. The options are added to the default ones, e.g. WithControlToken.
+func NewControlClient(l log.Logger, addr string, opts ...grpc.DialOption) (*ControlClient, error) {
 	network, host := listenAddrFor(addr)
 	if network != grpcDefaultIPNetwork {
 		host = fmt.Sprintf("%s://%s", network, host)
 	}
 
-	conn, err := grpc.NewClient(host, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(host, append(controlDialOptions(), opts...)...)
 	if err != nil {
 		l.Errorw("", "proto client", "connect failure", "err", err)
 		return nil, err
@@ -106,6 +110,15 @@ func NewControlClient(l log.Logger, addr string) (*ControlClient, error) {
 	}, nil
 }
 
+// controlDialOptions returns the options of the connections of the control clients to the daemon
+func controlDialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(RequestIDUnaryClientInterceptor),
+		grpc.WithChainStreamInterceptor(RequestIDStreamClientInterceptor),
+	}
+}
+
 func (c *ControlClient) Close() error {
 	if c == nil || c.log == nil || c.conn == nil {
 		return nil
@@ -116,14 +129,16 @@ func (c *ControlClient) Close() error {
 
 func (c *ControlClient) RemoteStatus(ct context.Context,
 	addresses []*proto.Address,
-	beaconID string) (map[string]*proto.StatusResponse, error) {
+	beaconID string,
+	forceRefresh bool) (map[string]*proto.StatusResponse, error) {
 	metadata := proto.Metadata{
 		NodeVersion: c.version.ToProto(), BeaconID: beaconID,
 	}
 
 	packet := proto.RemoteStatusRequest{
-		Metadata:  &metadata,
-		Addresses: addresses,
+		Metadata:     &metadata,
+		Addresses:    addresses,
+		ForceRefresh: forceRefresh,
 	}
 
 	resp, err := c.client.RemoteStatus(ct, &packet)
@@ -133,6 +148,193 @@ func (c *ControlClient) RemoteStatus(ct context.Context,
 	return resp.GetStatuses(), nil
 }
 
+// GroupBuildInfo asks the daemon to collect and compare the build info of all the nodes of the group
+func (c *ControlClient) GroupBuildInfo(beaconID string) (*proto.GroupBuildInfoResponse, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	return c.client.GroupBuildInfo(context.Background(), &proto.GroupBuildInfoRequest{Metadata: metadata})
+}
+
+// GroupDST asks the daemon to collect and compare the domain separation tags of all the nodes of the group
+func (c *ControlClient) GroupDST(beaconID string) (*proto.GroupDSTResponse, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	return c.client.GroupDST(context.Background(), &proto.GroupDSTRequest{Metadata: metadata})
+}
+
+// RotateControlToken asks the daemon for a fresh token authenticating the calls to its control port. The client must
+// be recreated with it for the next calls.
+func (c *ControlClient) RotateControlToken() (*proto.RotateControlTokenResponse, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	return c.client.RotateControlToken(context.Background(), &proto.RotateControlTokenRequest{Metadata: metadata})
+}
+
+// ReloadConfig asks the daemon to re-read its config file and apply the options which can change without a restart
+func (c *ControlClient) ReloadConfig() (*proto.ReloadConfigResponse, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	return c.client.ReloadConfig(context.Background(), &proto.ReloadConfigRequest{Metadata: metadata})
+}
+
+// LogLevels lists the log levels of the daemon after changing the level of the given named logger, or of the logger
+// of the daemon if logger is empty, to level if it isn't empty. The named logger goes back to the level of the
+// daemon if remove is set.
+func (c *ControlClient) LogLevels(logger, level string, remove bool) (*proto.LogLevelsResponse, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	return c.client.LogLevels(context.Background(), &proto.LogLevelsRequest{
+		Level:    level,
+		Logger:   logger,
+		Remove:   remove,
+		Metadata: metadata,
+	})
+}
+
+// StartUpgrade proposes an upgrade window to the group, with the daemon as the coordinator
+func (c *ControlClient) StartUpgrade(beaconID string, windowStart, windowEnd time.Time) (*proto.UpgradeStatus, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	return c.client.StartUpgrade(context.Background(), &proto.StartUpgradeRequest{
+		Metadata:    metadata,
+		WindowStart: windowStart.Unix(),
+		WindowEnd:   windowEnd.Unix(),
+	})
+}
+
+// AcceptUpgrade accepts the upgrade proposed to the daemon, which will then restart during its slot
+func (c *ControlClient) AcceptUpgrade(beaconID string) (*proto.UpgradeStatus, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	return c.client.AcceptUpgrade(context.Background(), &proto.AcceptUpgradeRequest{Metadata: metadata})
+}
+
+// Leave announces the departure of the daemon from the group after the given round
+func (c *ControlClient) Leave(beaconID string, lastRound uint64, wipe bool) (*proto.LeaveStatus, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	return c.client.Leave(context.Background(), &proto.LeaveRequest{
+		Metadata:  metadata,
+		LastRound: lastRound,
+		Wipe:      wipe,
+	})
+}
+
+// PauseBeacon stops the daemon from signing its partials, for the given reason. It is refused if the group is then
+// expected to miss rounds, unless forced.
+func (c *ControlClient) PauseBeacon(beaconID, reason string, force bool) (*proto.PauseStatus, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	return c.client.PauseBeacon(context.Background(), &proto.PauseBeaconRequest{
+		Metadata: metadata,
+		Reason:   reason,
+		Force:    force,
+	})
+}
+
+// ResumeBeacon makes a paused daemon sign its partials again
+func (c *ControlClient) ResumeBeacon(beaconID string) (*proto.PauseStatus, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	return c.client.ResumeBeacon(context.Background(), &proto.ResumeBeaconRequest{Metadata: metadata})
+}
+
+// Incident asks the daemon to run the response to a suspected compromise of its share
+func (c *ControlClient) Incident(beaconID, reason string) (*proto.IncidentResponse, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	return c.client.Incident(context.Background(), &proto.IncidentRequest{Metadata: metadata, Reason: reason})
+}
+
+// UpdateAddress asks the daemon to announce to its group that it is now reachable at the new address
+func (c *ControlClient) UpdateAddress(beaconID, newAddress string) (*proto.UpdateAddressResponse, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	return c.client.UpdateAddress(context.Background(), &proto.UpdateAddressRequest{
+		Metadata:   metadata,
+		NewAddress: newAddress,
+	})
+}
+
+// AddressOverrides lists the addresses the members of the group of the given beacon are dialed at instead of the
+// ones of the group file. If member isn't empty, its override is set to the address first, or removed if remove is
+// set.
+func (c *ControlClient) AddressOverrides(beaconID, member, address string, remove bool) (*proto.AddressOverridesResponse, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	return c.client.AddressOverrides(context.Background(), &proto.AddressOverridesRequest{
+		Member:   member,
+		Address:  address,
+		Remove:   remove,
+		Metadata: metadata,
+	})
+}
+
+// ForecastReshare registers the date the daemon, as the coordinator, intends to run the next reshare of the group
+// of the given beacon at, or clears it if clearForecast is set. A zero date only gets the current forecast.
+func (c *ControlClient) ForecastReshare(beaconID string, at time.Time, note string, clearForecast bool) (*proto.ForecastReshareResponse, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	in := &proto.ForecastReshareRequest{Note: note, Clear: clearForecast, Metadata: metadata}
+	if !at.IsZero() {
+		in.At = at.Unix()
+	}
+	return c.client.ForecastReshare(context.Background(), in)
+}
+
+// Snapshot collects the state of all the beacons run by the daemon at a single point in time
+func (c *ControlClient) Snapshot() (*proto.SnapshotResponse, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+
+	return c.client.Snapshot(context.Background(), &proto.SnapshotRequest{Metadata: metadata})
+}
+
+// RoundMessage returns the message the daemon signs a partial over for the given round. The previous signature
+// is only used by chained schemes, the daemon reads it from its chain store when it's empty.
+func (c *ControlClient) RoundMessage(beaconID string, round uint64, previousSig []byte) (*proto.RoundMessageResponse, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	return c.client.RoundMessage(context.Background(), &proto.RoundMessageRequest{
+		Metadata:          metadata,
+		Round:             round,
+		PreviousSignature: previousSig,
+	})
+}
+
+// Notarize returns a self-contained bundle allowing to verify the randomness of the given round offline, signed
+// by the daemon
+func (c *ControlClient) Notarize(beaconID string, round uint64) (*proto.NotarizationBundle, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	return c.client.Notarize(context.Background(), &proto.NotarizeRequest{Metadata: metadata, Round: round})
+}
+
+// MakeJoinKit returns a bundle signed by the daemon, allowing new followers to start from a recent checkpoint
+func (c *ControlClient) MakeJoinKit(beaconID string) (*proto.JoinKit, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	return c.client.MakeJoinKit(context.Background(), &proto.JoinKitRequest{Metadata: metadata})
+}
+
+// RandomnessStats returns statistical summaries of the randomness of the rounds between from and to, included
+func (c *ControlClient) RandomnessStats(beaconID string, from, to uint64) (*proto.RandomnessStatsResponse, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	return c.client.RandomnessStats(context.Background(), &proto.RandomnessStatsRequest{Metadata: metadata, From: from, To: to})
+}
+
 // Ping the drand daemon to check if it's up and running
 func (c *ControlClient) Ping() error {
 	metadata := proto.NewMetadata(c.version.ToProto())
@@ -150,11 +352,20 @@ func (c *ControlClient) LoadBeacon(beaconID string) (*proto.LoadBeaconResponse,
 	return c.client.LoadBeacon(context.Background(), &proto.LoadBeaconRequest{Metadata: &metadata})
 }
 
-// ListBeaconIDs returns a list of all beacon ids
-func (c *ControlClient) ListBeaconIDs() (*proto.ListBeaconIDsResponse, error) {
-	publicClient := proto.NewPublicClient(c.conn)
+// RemoveBeaconID unloads the beacon and deletes, or archives, its folder and its DKG state. Nothing is removed unless
+// confirmed.
+func (c *ControlClient) RemoveBeaconID(beaconID string, archive, confirm bool) (*proto.RemoveBeaconIDResponse, error) {
+	metadata := proto.Metadata{NodeVersion: c.version.ToProto(), BeaconID: beaconID}
+	return c.client.RemoveBeaconID(context.Background(), &proto.RemoveBeaconIDRequest{
+		Metadata: &metadata,
+		Confirm:  confirm,
+		Archive:  archive,
+	})
+}
 
-	return publicClient.ListBeaconIDs(context.Background(), &proto.ListBeaconIDsRequest{})
+// ListBeaconIDs returns the beacons configured on the node, running or not
+func (c *ControlClient) ListBeaconIDs() (*proto.ListBeaconIDsResponse, error) {
+	return c.client.ListBeaconIDs(context.Background(), &proto.ListBeaconIDsRequest{})
 }
 
 // Status gets the current daemon status
@@ -166,11 +377,153 @@ func (c *ControlClient) Status(beaconID string) (*proto.StatusResponse, error) {
 	return c.client.Status(context.Background(), &proto.StatusRequest{Metadata: &metadata})
 }
 
+// StatusStream subscribes to the status of the given beacon, which is pushed whenever it changes and every interval if
+// it isn't 0, until the context is done
+func (c *ControlClient) StatusStream(ctx context.Context, beaconID string, interval time.Duration) (proto.Control_StatusStreamClient, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	return c.client.StatusStream(ctx, &proto.StatusStreamRequest{
+		IntervalSeconds: uint32(interval / time.Second),
+		Metadata:        metadata,
+	})
+}
+
+// ListMetrics describes the metrics exported by the daemon, only the ones of the given beacon if it isn't empty
+func (c *ControlClient) ListMetrics(beaconID string) (*proto.ListMetricsResponse, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	return c.client.ListMetrics(context.Background(), &proto.ListMetricsRequest{Metadata: metadata})
+}
+
+// APIUsage reports the requests to the public API of each client of the daemon
+func (c *ControlClient) APIUsage() (*proto.APIUsageResponse, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	return c.client.APIUsage(context.Background(), &proto.APIUsageRequest{Metadata: metadata})
+}
+
+// FeatureFlags lists the feature flags of the given beacon. If name isn't empty, the feature is turned on or off
+// first, or back to its configured value if reset is set.
+func (c *ControlClient) FeatureFlags(beaconID, name string, enabled, reset bool) (*proto.FeatureFlagsResponse, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	return c.client.FeatureFlags(context.Background(), &proto.FeatureFlagsRequest{
+		Name:     name,
+		Enabled:  enabled,
+		Reset_:   reset,
+		Metadata: metadata,
+	})
+}
+
+// AvailabilityReport returns the availability of the members of the group of the given beacon between the given
+// times, the last day by default
+func (c *ControlClient) AvailabilityReport(beaconID string, from, to time.Time) (*proto.AvailabilityReportResponse, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	req := &proto.AvailabilityReportRequest{Metadata: metadata}
+	if !from.IsZero() {
+		req.From = from.Unix()
+	}
+	if !to.IsZero() {
+		req.To = to.Unix()
+	}
+	return c.client.AvailabilityReport(context.Background(), req)
+}
+
+// PartialAudit returns the members whose partials were aggregated into each round between the given rounds, up to the
+// last round recorded if to is 0
+func (c *ControlClient) PartialAudit(beaconID string, from, to uint64) (*proto.PartialAuditResponse, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	return c.client.PartialAudit(context.Background(), &proto.PartialAuditRequest{
+		FromRound: from,
+		ToRound:   to,
+		Metadata:  metadata,
+	})
+}
+
+// Tombstones returns the beacons replaced when correcting the chain between the given rounds, up to the last round
+// recorded if to is 0
+func (c *ControlClient) Tombstones(beaconID string, from, to uint64) (*proto.TombstonesResponse, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	return c.client.Tombstones(context.Background(), &proto.TombstonesRequest{
+		FromRound: from,
+		ToRound:   to,
+		Metadata:  metadata,
+	})
+}
+
+// InjectBeacon asks the node to store a beacon obtained out-of-band, once verified against its chain
+func (c *ControlClient) InjectBeacon(beaconID string, b *common.Beacon) (*proto.InjectBeaconResponse, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	return c.client.InjectBeacon(context.Background(), &proto.InjectBeaconRequest{
+		Round:             b.Round,
+		Signature:         b.Signature,
+		PreviousSignature: b.PreviousSig,
+		Metadata:          metadata,
+	})
+}
+
+// StoreMetadata reads, writes, deletes or lists the values of a namespace of the metadata of the chain store of a
+// beacon, depending on the request
+func (c *ControlClient) StoreMetadata(beaconID string, in *proto.StoreMetadataRequest) (*proto.StoreMetadataResponse, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+	in.Metadata = metadata
+
+	return c.client.StoreMetadata(context.Background(), in)
+}
+
+// RoundAnnotations lists the notes attached to the rounds of a beacon, after attaching a note to a round or removing
+// its annotations, depending on the request
+func (c *ControlClient) RoundAnnotations(beaconID string, in *proto.RoundAnnotationsRequest) (*proto.RoundAnnotationsResponse, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+	in.Metadata = metadata
+
+	return c.client.RoundAnnotations(context.Background(), in)
+}
+
+// Countdown asks the daemon for the time left until the genesis or the next transition of the beacon, with the
+// readiness of the members of its group
+func (c *ControlClient) Countdown(beaconID string) (*proto.CountdownResponse, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	return c.client.Countdown(context.Background(), &proto.CountdownRequest{Metadata: metadata})
+}
+
+// EntropyStream streams the bytes derived from the successive beacons of the chain, until the context is done
+func (c *ControlClient) EntropyStream(ctx context.Context, beaconID string,
+	in *proto.EntropyStreamRequest) (proto.Control_EntropyStreamClient, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+	in.Metadata = metadata
+
+	return c.client.EntropyStream(ctx, in)
+}
+
 // ListSchemes responds with the list of ids for the available schemes
 func (c *ControlClient) ListSchemes() (*proto.ListSchemesResponse, error) {
 	return c.client.ListSchemes(context.Background(), &proto.ListSchemesRequest{})
 }
 
+// GetCapabilities lists what the node supports for the given beacon
+func (c *ControlClient) GetCapabilities(beaconID string) (*proto.Capabilities, error) {
+	metadata := proto.NewMetadata(c.version.ToProto())
+	metadata.BeaconID = beaconID
+
+	return c.client.GetCapabilities(context.Background(), &proto.CapabilitiesRequest{Metadata: metadata})
+}
+
 // PublicKey returns the public key of the remote node
 func (c *ControlClient) PublicKey(beaconID string) (*proto.PublicKeyResponse, error) {
 	metadata := proto.Metadata{NodeVersion: c.version.ToProto(), BeaconID: beaconID}
@@ -201,6 +554,19 @@ func (c *ControlClient) Shutdown(beaconID string) (*proto.ShutdownResponse, erro
 	return c.client.Shutdown(ctx, &proto.ShutdownRequest{Metadata: &metadata})
 }
 
+// GracefulShutdown stops the node, or the beacon process if a beacon ID is given, once its beacons finished the round
+// in progress. The beacons are stopped anyway after the timeout, which defaults to two periods of each beacon when 0.
+func (c *ControlClient) GracefulShutdown(beaconID string, timeout time.Duration) (*proto.ShutdownResponse, error) {
+	metadata := proto.Metadata{NodeVersion: c.version.ToProto(), BeaconID: beaconID}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	return c.client.Shutdown(ctx, &proto.ShutdownRequest{
+		Metadata:     &metadata,
+		Drain:        true,
+		DrainTimeout: uint32(timeout.Round(time.Second) / time.Second),
+	})
+}
+
 const progressSyncQueue = 100
 
 // StartCheckChain initiates the check chain process
@@ -271,12 +637,19 @@ func (c *ControlClient) StartCheckChain(cc context.Context,
 	return outCh, errCh, nil
 }
 
-// StartFollowChain initiates the client catching up on an existing chain it is not part of
+// StartFollowChain initiates the client catching up on an existing chain it is not part of. When a checkpoint is
+// given, only the rounds after it are synced and verified, unless fullVerification is set in which case the rounds
+// before it are verified in the background.
 func (c *ControlClient) StartFollowChain(cc context.Context,
 	hashStr string,
 	nodes []string,
 	upTo uint64,
-	beaconID string) (outCh chan *proto.SyncProgress, errCh chan error, e error) {
+	beaconID string,
+	crossCheck bool,
+	checkpoint *proto.Checkpoint,
+	fullVerification bool,
+	prioritized bool,
+	archives []string) (outCh chan *proto.SyncProgress, errCh chan error, e error) {
 	// we need to make sure the beaconID is set and also the chain hash to check integrity of the chain info
 	metadata := proto.NewMetadata(c.version.ToProto())
 	if beaconID == "" {
@@ -295,9 +668,14 @@ func (c *ControlClient) StartFollowChain(cc context.Context,
 	metadata.ChainHash = hash
 	c.log.Infow("Launching a follow request", "nodes", nodes, "upTo", upTo, "hash", hashStr, "beaconID", beaconID)
 	stream, err := c.client.StartFollowChain(cc, &proto.StartSyncRequest{
-		Nodes:    nodes,
-		UpTo:     upTo,
-		Metadata: metadata,
+		Nodes:            nodes,
+		UpTo:             upTo,
+		Metadata:         metadata,
+		CrossCheck:       crossCheck,
+		Checkpoint:       checkpoint,
+		FullVerification: fullVerification,
+		Prioritized:      prioritized,
+		Archives:         archives,
 	})
 	if err != nil {
 		c.log.Errorw("Error while following chain", "err", err)
@@ -334,3 +712,13 @@ func (c *ControlClient) BackupDB(outFile, beaconID string) error {
 	_, err := c.client.BackupDatabase(context.Background(), &proto.BackupDBRequest{OutputFile: outFile, Metadata: &metadata})
 	return err
 }
+
+// CheckListenable returns an error if the given address, in any of the forms accepted by the listeners, can't be
+// listened on, e.g. because it's already in use.
+func CheckListenable(addr string) error {
+	lis, err := newListener(addr)
+	if err != nil {
+		return err
+	}
+	return lis.Close()
+}
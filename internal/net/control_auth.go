@@ -0,0 +1,181 @@
+package net
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pdkg "github.com/drand/drand/v2/protobuf/dkg"
+)
+
+// controlAuthKey is the gRPC metadata key carrying the bearer token of the calls to the control port
+const controlAuthKey = "authorization"
+
+const bearerPrefix = "Bearer "
+
+// ControlAuth authenticates the calls to the control port with a bearer token, so that the daemons run in shared
+// environments can't be controlled by any local process. Its zero value, or an empty token, accepts all the calls.
+// The token is the one of the admin role, allowed to call all the RPCs, the tokens of the other roles are only
+// allowed to call theirs.
+type ControlAuth struct {
+	sync.RWMutex
+	token  string
+	policy *controlPolicy
+}
+
+// NewControlAuth returns the authentication of the control port with the given token
+func NewControlAuth(token string) *ControlAuth {
+	return &ControlAuth{token: token}
+}
+
+// SetRoles grants the RPCs of their role to the tokens, on top of the admin one. The read-only role, allowed to
+// inspect the daemon, exists unless redefined.
+func (a *ControlAuth) SetRoles(roles []ControlRole, tokens []ControlRoleToken) error {
+	policy, err := newControlPolicy(roles, tokens)
+	if err != nil {
+		return err
+	}
+	a.Lock()
+	defer a.Unlock()
+	a.policy = policy
+	return nil
+}
+
+// NewControlToken returns a fresh random token
+func NewControlToken() (string, error) {
+	var token [32]byte
+	if _, err := rand.Read(token[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token[:]), nil
+}
+
+// Enabled returns whether the calls are authenticated
+func (a *ControlAuth) Enabled() bool {
+	a.RLock()
+	defer a.RUnlock()
+	return a.token != ""
+}
+
+// SetToken replaces the token the calls must carry, the calls with the previous one are refused from then on
+func (a *ControlAuth) SetToken(token string) {
+	a.Lock()
+	defer a.Unlock()
+	a.token = token
+}
+
+// ServerOptions returns the interceptors refusing the calls without the token
+func (a *ControlAuth) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler) (interface{}, error) {
+			if err := a.check(ctx, info.FullMethod, req); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+			handler grpc.StreamHandler) error {
+			if err := a.check(ss.Context(), info.FullMethod, nil); err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		}),
+	}
+}
+
+// peerDKGMethods are the RPCs of the DKG service the members of a group call on each other's private listener, which
+// don't carry a token of the control port
+var peerDKGMethods = map[string]bool{"Packet": true, "BroadcastDKG": true}
+
+// PrivateServerOptions returns the interceptors of the private listener, which also serves the DKG service: its RPCs
+// other than the ones the members call on each other require the token of the control port, and are refused if the
+// control port isn't authenticated since they must then be sent to it
+func (a *ControlAuth) PrivateServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler) (interface{}, error) {
+			if err := a.checkPrivate(ctx, info.FullMethod, req); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+			handler grpc.StreamHandler) error {
+			if err := a.checkPrivate(ss.Context(), info.FullMethod, nil); err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		}),
+	}
+}
+
+// checkPrivate authenticates the calls to the DKG service on the private listener which don't come from the members
+func (a *ControlAuth) checkPrivate(ctx context.Context, fullMethod string, req interface{}) error {
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if service != pdkg.DKGControl_ServiceDesc.ServiceName || peerDKGMethods[method] {
+		return nil
+	}
+	if !a.Enabled() {
+		return status.Errorf(codes.PermissionDenied, "%s is only served on the control port", fullMethod)
+	}
+	return a.check(ctx, fullMethod, req)
+}
+
+// check authenticates the call, and authorizes it for the role of its token. The request is nil for the streams.
+func (a *ControlAuth) check(ctx context.Context, fullMethod string, req interface{}) error {
+	a.RLock()
+	token, policy := a.token, a.policy
+	a.RUnlock()
+	if token == "" {
+		return nil
+	}
+
+	values := metadata.ValueFromIncomingContext(ctx, controlAuthKey)
+	if len(values) == 0 || !strings.HasPrefix(values[0], bearerPrefix) {
+		return status.Error(codes.Unauthenticated, "the control port requires a token")
+	}
+	given := []byte(strings.TrimPrefix(values[0], bearerPrefix))
+	if subtle.ConstantTimeCompare(given, []byte(token)) == 1 {
+		return nil
+	}
+	if policy != nil {
+		for _, t := range policy.tokens {
+			if subtle.ConstantTimeCompare(given, []byte(t.Token)) != 1 {
+				continue
+			}
+			if !policy.allowed(t.Role, fullMethod, req) {
+				return status.Errorf(codes.PermissionDenied, "the %s role isn't allowed to call %s", t.Role, fullMethod)
+			}
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "invalid token for the control port")
+}
+
+// WithControlToken authenticates the calls of a control client with the given token. An empty token sends none.
+func WithControlToken(token string) grpc.DialOption {
+	return grpc.WithPerRPCCredentials(controlToken(token))
+}
+
+// controlToken sends the bearer token along the calls. The control port is a local one, served without TLS.
+type controlToken string
+
+func (t controlToken) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	if t == "" {
+		return nil, nil
+	}
+	return map[string]string{controlAuthKey: bearerPrefix + string(t)}, nil
+}
+
+func (t controlToken) RequireTransportSecurity() bool {
+	return false
+}
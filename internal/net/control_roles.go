@@ -0,0 +1,146 @@
+package net
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// AdminRole is the role of the main token of the control port, allowed to call all the RPCs. It can't be redefined.
+const AdminRole = "admin"
+
+// ReadOnlyRole is the role, unless redefined, of the tokens only allowed to inspect the daemon
+const ReadOnlyRole = "read-only"
+
+// allMethods allows a role to call all the RPCs
+const allMethods = "*"
+
+// readOnlyMethods are the RPCs of the control port that don't modify the daemon, its chains nor its groups, and
+// don't reveal its secrets. FeatureFlags only lists the flags for them, changing one requires SetFeatureFlags.
+var readOnlyMethods = []string{
+	"PingPong", "Status", "StatusStream", "ListSchemes", "ListBeaconIDs", "GetCapabilities", "PublicKey",
+	"ChainInfo", "GroupFile", "RemoteStatus", "GroupBuildInfo", "GroupDST", "RandomnessStats", "ListMetrics",
+	"FeatureFlags", "AvailabilityReport", "PartialAudit", "Countdown", "DKGStatus", "GroupHistory", "GroupForRound",
+}
+
+// setFeatureFlags is the permission, granted like an RPC, required by the FeatureFlags calls changing a flag
+const setFeatureFlags = "SetFeatureFlags"
+
+// writePermission returns the permission the request requires on top of its RPC when it modifies the daemon, or an
+// empty string. The streams aren't inspected, none of them modifies the daemon.
+func writePermission(req interface{}) string {
+	if r, ok := req.(*drand.FeatureFlagsRequest); ok && r.GetName() != "" {
+		return setFeatureFlags
+	}
+	return ""
+}
+
+// ControlRole is a set of RPCs of the control port the tokens of the role are allowed to call, by their method name,
+// e.g. Status, or * for all of them
+type ControlRole struct {
+	Name    string
+	Methods []string
+}
+
+// ParseControlRole parses a role given as name=Method[,Method...]
+func ParseControlRole(spec string) (ControlRole, error) {
+	name, methods, ok := strings.Cut(spec, "=")
+	if !ok || name == "" || methods == "" {
+		return ControlRole{}, fmt.Errorf("invalid control role %q, expected name=Method[,Method...]", spec)
+	}
+	if name == AdminRole {
+		return ControlRole{}, fmt.Errorf("the %s role can't be redefined", AdminRole)
+	}
+	role := ControlRole{Name: name}
+	for _, m := range strings.Split(methods, ",") {
+		m = strings.TrimSpace(m)
+		if m == "" {
+			return ControlRole{}, fmt.Errorf("empty method in control role %q", spec)
+		}
+		role.Methods = append(role.Methods, m)
+	}
+	return role, nil
+}
+
+// ControlRoleToken is a token of the control port granting the RPCs of a role
+type ControlRoleToken struct {
+	Role  string
+	Token string
+}
+
+// ParseControlRoleToken parses a token given as role:token
+func ParseControlRoleToken(spec string) (ControlRoleToken, error) {
+	role, token, ok := strings.Cut(spec, ":")
+	if !ok || role == "" || token == "" {
+		return ControlRoleToken{}, errors.New("invalid control role token, expected role:token")
+	}
+	return ControlRoleToken{Role: role, Token: token}, nil
+}
+
+// controlPolicy grants the RPCs of their role to the tokens
+type controlPolicy struct {
+	// the RPCs allowed to each role, by method name
+	roles  map[string]map[string]bool
+	tokens []ControlRoleToken
+}
+
+// newControlPolicy returns the policy of the roles, which come on top of the admin and read-only ones
+func newControlPolicy(roles []ControlRole, tokens []ControlRoleToken) (*controlPolicy, error) {
+	p := &controlPolicy{roles: map[string]map[string]bool{
+		AdminRole:    {allMethods: true},
+		ReadOnlyRole: methodSet(readOnlyMethods),
+	}}
+	for _, r := range roles {
+		if r.Name == AdminRole {
+			return nil, fmt.Errorf("the %s role can't be redefined", AdminRole)
+		}
+		p.roles[r.Name] = methodSet(r.Methods)
+	}
+
+	seen := make(map[string]bool, len(tokens))
+	for _, t := range tokens {
+		if _, ok := p.roles[t.Role]; !ok {
+			return nil, fmt.Errorf("unknown control role %q, known: %s", t.Role, strings.Join(p.roleNames(), ", "))
+		}
+		if seen[t.Token] {
+			return nil, fmt.Errorf("the token of the control role %q is given twice", t.Role)
+		}
+		seen[t.Token] = true
+	}
+	p.tokens = tokens
+	return p, nil
+}
+
+func methodSet(methods []string) map[string]bool {
+	set := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		set[m] = true
+	}
+	return set
+}
+
+func (p *controlPolicy) roleNames() []string {
+	names := make([]string, 0, len(p.roles))
+	for name := range p.roles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// allowed returns whether the role may call the RPC of the full gRPC method name, e.g. /drand.Control/Status, with
+// the request, which may require a permission on top of the RPC, see writePermission
+func (p *controlPolicy) allowed(role, fullMethod string, req interface{}) bool {
+	methods := p.roles[role]
+	if methods[allMethods] {
+		return true
+	}
+	if !methods[fullMethod[strings.LastIndex(fullMethod, "/")+1:]] {
+		return false
+	}
+	write := writePermission(req)
+	return write == "" || methods[write]
+}
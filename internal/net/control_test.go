@@ -1,12 +1,18 @@
 package net
 
 import (
+	"context"
 	"testing"
 
+	"github.com/stretchr/testify/require"
 	"golang.org/x/net/nettest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/drand/drand/v2/common/testlogger"
 	testnet "github.com/drand/drand/v2/internal/test/net"
+	pdkg "github.com/drand/drand/v2/protobuf/dkg"
 )
 
 // testable reports whether we support unix or not
@@ -37,3 +43,149 @@ func TestControlUnix(t *testing.T) {
 	service.lis.Close()
 	client.conn.Close()
 }
+
+func TestControlAuth(t *testing.T) {
+	if !testable() {
+		t.Skip("Platform does not support unix.")
+	}
+
+	lg := testlogger.New(t)
+	addr := "unix://" + t.TempDir() + "/sock"
+	token, err := NewControlToken()
+	require.NoError(t, err)
+	auth := NewControlAuth(token)
+	service, err := NewGRPCListener(lg, &testnet.EmptyServer{}, addr, auth.ServerOptions()...)
+	require.NoError(t, err)
+	go service.Start()
+	defer service.Stop()
+
+	clientWith := func(token string) *ControlClient {
+		client, err := NewControlClient(lg, addr, WithControlToken(token))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = client.Close() })
+		return client
+	}
+
+	require.Equal(t, codes.Unauthenticated, status.Code(clientWith("").Ping()))
+	require.Equal(t, codes.Unauthenticated, status.Code(clientWith("wrong").Ping()))
+	require.NoError(t, clientWith(token).Ping())
+
+	// the streams are authenticated too
+	stream, err := clientWith("").StatusStream(context.Background(), "", 0)
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// the previous token is refused once rotated
+	rotated, err := NewControlToken()
+	require.NoError(t, err)
+	auth.SetToken(rotated)
+	require.Equal(t, codes.Unauthenticated, status.Code(clientWith(token).Ping()))
+	require.NoError(t, clientWith(rotated).Ping())
+
+	// no token is required once disabled
+	auth.SetToken("")
+	require.NoError(t, clientWith("").Ping())
+}
+
+func TestControlAuthPrivateDKG(t *testing.T) {
+	command := "/" + pdkg.DKGControl_ServiceDesc.ServiceName + "/Command"
+	packet := "/" + pdkg.DKGControl_ServiceDesc.ServiceName + "/Packet"
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(controlAuthKey, bearerPrefix+token))
+	}
+
+	// the commands must go to the control port while it isn't authenticated
+	auth := NewControlAuth("")
+	require.Equal(t, codes.PermissionDenied, status.Code(auth.checkPrivate(context.Background(), command, nil)))
+	require.NoError(t, auth.checkPrivate(context.Background(), packet, nil))
+	require.NoError(t, auth.checkPrivate(context.Background(), "/drand.Public/PublicRand", nil))
+
+	auth.SetToken("admin-token")
+	require.Equal(t, codes.Unauthenticated, status.Code(auth.checkPrivate(context.Background(), command, nil)))
+	require.Equal(t, codes.Unauthenticated, status.Code(auth.checkPrivate(withToken("wrong"), command, nil)))
+	require.NoError(t, auth.checkPrivate(withToken("admin-token"), command, nil))
+	// the members don't send a token to each other
+	require.NoError(t, auth.checkPrivate(context.Background(), packet, nil))
+}
+
+func TestControlRoles(t *testing.T) {
+	if !testable() {
+		t.Skip("Platform does not support unix.")
+	}
+
+	lg := testlogger.New(t)
+	addr := "unix://" + t.TempDir() + "/sock"
+	auth := NewControlAuth("admin-token")
+	backup, err := ParseControlRole("backup=BackupDatabase")
+	require.NoError(t, err)
+	flags, err := ParseControlRole("flags=FeatureFlags,SetFeatureFlags")
+	require.NoError(t, err)
+	require.NoError(t, auth.SetRoles([]ControlRole{backup, flags}, []ControlRoleToken{
+		{Role: ReadOnlyRole, Token: "reader-token"},
+		{Role: "backup", Token: "backup-token"},
+		{Role: "flags", Token: "flags-token"},
+	}))
+	service, err := NewGRPCListener(lg, &testnet.EmptyServer{}, addr, auth.ServerOptions()...)
+	require.NoError(t, err)
+	go service.Start()
+	defer service.Stop()
+
+	clientWith := func(token string) *ControlClient {
+		client, err := NewControlClient(lg, addr, WithControlToken(token))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = client.Close() })
+		return client
+	}
+	backupCode := func(token string) codes.Code {
+		return status.Code(clientWith(token).BackupDB(t.TempDir()+"/backup", ""))
+	}
+	flagCode := func(token, name string) codes.Code {
+		_, err := clientWith(token).FeatureFlags("", name, true, false)
+		return status.Code(err)
+	}
+
+	// the admin token may call all the RPCs
+	require.NoError(t, clientWith("admin-token").Ping())
+	require.NotEqual(t, codes.PermissionDenied, backupCode("admin-token"))
+
+	// the read-only role may inspect the daemon, not back it up
+	require.NoError(t, clientWith("reader-token").Ping())
+	require.Equal(t, codes.PermissionDenied, backupCode("reader-token"))
+	// it may list the feature flags, not toggle them
+	require.NotEqual(t, codes.PermissionDenied, flagCode("reader-token", ""))
+	require.Equal(t, codes.PermissionDenied, flagCode("reader-token", "beacon-injection"))
+	require.NotEqual(t, codes.PermissionDenied, flagCode("flags-token", "beacon-injection"))
+	require.NotEqual(t, codes.PermissionDenied, flagCode("admin-token", "beacon-injection"))
+
+	// the roles are only allowed their RPCs
+	require.Equal(t, codes.PermissionDenied, status.Code(clientWith("backup-token").Ping()))
+	require.NotEqual(t, codes.PermissionDenied, backupCode("backup-token"))
+
+	require.Equal(t, codes.Unauthenticated, status.Code(clientWith("wrong").Ping()))
+}
+
+func TestControlRolesConfig(t *testing.T) {
+	_, err := ParseControlRole("admin=Status")
+	require.Error(t, err)
+	_, err = ParseControlRole("empty=")
+	require.Error(t, err)
+	_, err = ParseControlRoleToken("no-token")
+	require.Error(t, err)
+
+	auth := NewControlAuth("token")
+	require.Error(t, auth.SetRoles(nil, []ControlRoleToken{{Role: "unknown", Token: "t"}}))
+	require.Error(t, auth.SetRoles(nil, []ControlRoleToken{
+		{Role: ReadOnlyRole, Token: "t"},
+		{Role: AdminRole, Token: "t"},
+	}))
+
+	// the read-only role can be redefined
+	reader, err := ParseControlRole("read-only=Status, ChainInfo")
+	require.NoError(t, err)
+	policy, err := newControlPolicy([]ControlRole{reader}, nil)
+	require.NoError(t, err)
+	require.True(t, policy.allowed(ReadOnlyRole, "/drand.Control/ChainInfo", nil))
+	require.False(t, policy.allowed(ReadOnlyRole, "/drand.Control/GroupFile", nil))
+	require.True(t, policy.allowed(AdminRole, "/drand.DKGControl/Command", nil))
+}
@@ -37,6 +37,22 @@ func (g *PrivateGateway) StopAll(ctx context.Context) {
 	g.Listener.Stop(ctx)
 }
 
+// Redirect makes the gateway reach the peer known by the address from at the address to for the given beacon, or at
+// the address it is known by again if they are the same
+func (g *PrivateGateway) Redirect(beaconID, from, to string) {
+	if r, ok := g.ProtocolClient.(Redirectable); ok {
+		r.Redirect(beaconID, from, to)
+	}
+}
+
+// Override makes the gateway dial the peer known by the address from at the address to for the given beacon, or stop
+// overriding its address if to is empty
+func (g *PrivateGateway) Override(beaconID, from, to string) {
+	if o, ok := g.ProtocolClient.(Overridable); ok {
+		o.Override(beaconID, from, to)
+	}
+}
+
 // Listener is the active listener for incoming requests.
 type Listener interface {
 	Start()
@@ -56,12 +72,21 @@ type Service interface {
 
 // NewGRPCPrivateGateway returns a grpc gateway listening on "listen" for the
 // public methods, listening on "port" for the control methods, using the given
-// Service s with the given options.
-func NewGRPCPrivateGateway(ctx context.Context, listen string, s Service, opts ...grpc.DialOption) (*PrivateGateway, error) {
+// Service s with the given options. The limits are enforced on every incoming request.
+func NewGRPCPrivateGateway(ctx context.Context, listen string, s Service, limits RequestLimits, opts ...grpc.DialOption) (*PrivateGateway, error) {
+	return NewGRPCPrivateGatewayWithServerOptions(ctx, listen, s, limits, nil, opts...)
+}
+
+// NewGRPCPrivateGatewayWithServerOptions is NewGRPCPrivateGateway with additional options for the gRPC server, such as
+// its own TLS credentials or interceptors chained after the default ones.
+func NewGRPCPrivateGatewayWithServerOptions(ctx context.Context, listen string, s Service, limits RequestLimits,
+	extraSrvOpts []grpc.ServerOption, opts ...grpc.DialOption) (*PrivateGateway, error) {
 	lg := log.FromContextOrDefault(ctx)
 
 	//nolint:mnd // we set the timeout to something smallish but not too small
-	l, err := NewGRPCListenerForPrivate(ctx, listen, s, grpc.ConnectionTimeout(7*time.Second))
+	srvOpts := append(limits.ServerOptions(), grpc.ConnectionTimeout(7*time.Second))
+	srvOpts = append(srvOpts, extraSrvOpts...)
+	l, err := NewGRPCListenerForPrivate(ctx, listen, s, srvOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -72,3 +72,46 @@ func TestListener(t *testing.T) {
 	expected := &proto.PublicRandResponse{Round: randServer.round}
 	require.Equal(t, expected.GetRound(), resp.GetRound())
 }
+
+func TestGatewayOverride(t *testing.T) {
+	lg := testlogger.New(t)
+	ctx := log.ToContext(context.Background(), lg)
+	randServer := &testRandomnessServer{round: 42}
+
+	lisGRPC, err := NewGRPCListenerForPrivate(ctx, "127.0.0.1:", randServer)
+	require.NoError(t, err)
+	go lisGRPC.Start()
+	defer lisGRPC.Stop(ctx)
+	time.Sleep(100 * time.Millisecond)
+
+	client := NewGrpcClient(lg)
+	gateway := &PrivateGateway{ProtocolClient: client, PublicClient: client}
+	// the peer is known by an address it isn't reachable at anymore
+	peer := &testPeer{"127.0.0.1:1"}
+	shortCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	_, err = client.PublicRand(shortCtx, peer, &proto.PublicRandRequest{})
+	require.Error(t, err)
+
+	gateway.Redirect("default", peer.Address(), lisGRPC.Addr())
+	resp, err := client.PublicRand(ctx, peer, &proto.PublicRandRequest{})
+	require.NoError(t, err)
+	require.Equal(t, randServer.round, resp.GetRound())
+
+	// the overrides take precedence over the redirects
+	gateway.Override("default", peer.Address(), "127.0.0.1:1")
+	shortCtx, cancel = context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	_, err = client.PublicRand(shortCtx, peer, &proto.PublicRandRequest{})
+	require.Error(t, err)
+	// another beacon dropping its override of the peer doesn't drop the one of the first
+	gateway.Override("other", peer.Address(), "")
+	shortCtx, cancel = context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	_, err = client.PublicRand(shortCtx, peer, &proto.PublicRandRequest{})
+	require.Error(t, err)
+	gateway.Override("default", peer.Address(), "")
+	resp, err = client.PublicRand(ctx, peer, &proto.PublicRandRequest{})
+	require.NoError(t, err)
+	require.Equal(t, randServer.round, resp.GetRound())
+}
@@ -0,0 +1,59 @@
+package net
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// RequestLimits bounds the resources a single incoming request can consume on one of our gRPC servers.
+type RequestLimits struct {
+	// MaxRecvMsgSize is the maximum size in bytes of a message the server accepts. Zero keeps the gRPC default.
+	MaxRecvMsgSize int
+	// Timeout is the server-side deadline applied to unary calls. Zero disables it.
+	// Streaming calls, such as following a chain, are long-lived by design and are not subject to it.
+	Timeout time.Duration
+	// CurrentTimeout, if set, is called on each unary call in place of Timeout, so that the timeout can change while
+	// the server runs. A zero duration disables it for the call.
+	CurrentTimeout func() time.Duration
+	// LongRunning are the full names of the unary methods which are not subject to the timeout either, because
+	// they are expected to last longer, such as backing up the database or draining the rounds on a shutdown.
+	LongRunning []string
+}
+
+// ServerOptions returns the grpc.ServerOption enforcing these limits.
+func (r RequestLimits) ServerOptions() []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if r.MaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(r.MaxRecvMsgSize))
+	}
+	switch {
+	case r.CurrentTimeout != nil:
+		opts = append(opts, grpc.ChainUnaryInterceptor(CurrentDeadlineUnaryInterceptor(r.CurrentTimeout, r.LongRunning...)))
+	case r.Timeout > 0:
+		opts = append(opts, grpc.ChainUnaryInterceptor(DeadlineUnaryInterceptor(r.Timeout, r.LongRunning...)))
+	}
+	return opts
+}
+
+// DeadlineUnaryInterceptor makes sure every unary call is handled within the given time, on top of any deadline
+// the client might already have set on its side. The exempted methods are only bound by the deadline of the client.
+func DeadlineUnaryInterceptor(timeout time.Duration, exempted ...string) grpc.UnaryServerInterceptor {
+	return CurrentDeadlineUnaryInterceptor(func() time.Duration { return timeout }, exempted...)
+}
+
+// CurrentDeadlineUnaryInterceptor is DeadlineUnaryInterceptor with the timeout read on each call, a zero one
+// leaving the call bound by the deadline of the client only
+func CurrentDeadlineUnaryInterceptor(timeout func() time.Duration, exempted ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		d := timeout()
+		if d <= 0 || slices.Contains(exempted, info.FullMethod) {
+			return handler(ctx, req)
+		}
+		ctx, cancel := context.WithTimeout(ctx, d)
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,60 @@
+package net
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestDeadlineUnaryInterceptor(t *testing.T) {
+	interceptor := DeadlineUnaryInterceptor(50 * time.Millisecond)
+
+	handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		_, hasDeadline := ctx.Deadline()
+		require.True(t, hasDeadline)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the long-running methods are only bound by the deadline of the client
+	exempted := DeadlineUnaryInterceptor(50*time.Millisecond, "/drand.Control/BackupDatabase")
+	resp, err := exempted(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/drand.Control/BackupDatabase"},
+		func(ctx context.Context, _ interface{}) (interface{}, error) {
+			_, hasDeadline := ctx.Deadline()
+			return hasDeadline, nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, false, resp)
+}
+
+func TestRequestLimitsServerOptions(t *testing.T) {
+	require.Empty(t, RequestLimits{}.ServerOptions())
+	require.Len(t, RequestLimits{MaxRecvMsgSize: 1024}.ServerOptions(), 1)
+	require.Len(t, RequestLimits{MaxRecvMsgSize: 1024, Timeout: time.Second}.ServerOptions(), 2)
+	require.Len(t, RequestLimits{CurrentTimeout: func() time.Duration { return 0 }}.ServerOptions(), 1)
+}
+
+func TestCurrentDeadlineUnaryInterceptor(t *testing.T) {
+	timeout := time.Duration(0)
+	interceptor := CurrentDeadlineUnaryInterceptor(func() time.Duration { return timeout })
+	hasDeadline := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		_, ok := ctx.Deadline()
+		return ok, nil
+	}
+
+	// the timeout changes while the server runs, zero disabling it
+	resp, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, hasDeadline)
+	require.NoError(t, err)
+	require.Equal(t, false, resp)
+
+	timeout = time.Minute
+	resp, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, hasDeadline)
+	require.NoError(t, err)
+	require.Equal(t, true, resp)
+}
@@ -8,7 +8,6 @@ import (
 	"time"
 
 	grpcmiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
-	grpcrecovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	grpcprometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
@@ -48,15 +47,19 @@ func NewGRPCListenerForPrivate(ctx context.Context, bindingAddr string, s Servic
 		grpc.StreamInterceptor(
 			grpcmiddleware.ChainStreamServer(
 				grpcprometheus.StreamServerInterceptor,
+				RequestIDStreamServerInterceptor,
+				LatencyStreamServerInterceptor,
 				s.NodeVersionStreamValidator,
-				grpcrecovery.StreamServerInterceptor(), // TODO (dlsniper): This turns panics into grpc errors. Do we want that?
+				RecoveryStreamServerInterceptor(l),
 			),
 		),
 		grpc.UnaryInterceptor(
 			grpcmiddleware.ChainUnaryServer(
 				grpcprometheus.UnaryServerInterceptor,
+				RequestIDUnaryServerInterceptor,
+				LatencyUnaryServerInterceptor,
 				s.NodeVersionValidator,
-				grpcrecovery.UnaryServerInterceptor(), // TODO (dlsniper): This turns panics into grpc errors. Do we want that?
+				RecoveryUnaryServerInterceptor(l),
 			),
 		),
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
@@ -112,7 +115,7 @@ func NewRESTListenerForPublic(ctx context.Context, bindingAddr string, handler h
 	g.restServer = &http.Server{
 		Addr:              bindingAddr,
 		ReadHeaderTimeout: 3 * time.Second,
-		Handler:           handler,
+		Handler:           RecoveryHandler(l, handler),
 	}
 
 	return g, nil
@@ -0,0 +1,181 @@
+package net
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/drand/drand/v2/common/log"
+	"github.com/drand/drand/v2/internal/metrics"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// The results of the mirrored requests
+const (
+	MirrorMatch    = "match"
+	MirrorMismatch = "mismatch"
+	MirrorError    = "error"
+	MirrorDropped  = "dropped"
+)
+
+// publicMethodPrefix is the prefix of the methods of the public service, the only ones mirrored
+var publicMethodPrefix = "/" + drand.Public_ServiceDesc.ServiceName + "/"
+
+// metadataName is the name of the metadata message attached to the responses
+var metadataName = (&drand.Metadata{}).ProtoReflect().Descriptor().FullName()
+
+// Mirror duplicates the unary public requests received by the node to a shadow daemon, typically running a newer
+// build, and compares its responses with ours, so that a new version can be validated under real traffic before
+// switching to it. The requests are mirrored once they are answered, in the background: the shadow daemon never
+// delays nor changes the responses. Streams aren't mirrored.
+type Mirror struct {
+	l       log.Logger
+	client  *grpcClient
+	target  Peer
+	slots   chan struct{}
+	timeout time.Duration
+}
+
+// NewMirror returns a mirror of the public requests to the daemon listening on the given private address, with at
+// most maxInFlight requests waiting for it: the requests beyond are dropped rather than queued.
+func NewMirror(l log.Logger, target string, maxInFlight int, timeout time.Duration, opts ...grpc.DialOption) *Mirror {
+	return &Mirror{
+		l:       l,
+		client:  NewGrpcClient(l, opts...).(*grpcClient),
+		target:  CreatePeer(target),
+		slots:   make(chan struct{}, maxInFlight),
+		timeout: timeout,
+	}
+}
+
+// UnaryServerInterceptor mirrors the public requests once the node handled them
+func (m *Mirror) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	theirs := mirrorReply(info.FullMethod)
+	if theirs == nil {
+		return resp, err
+	}
+	in, ok := req.(proto.Message)
+	if !ok {
+		return resp, err
+	}
+	var ours proto.Message
+	if err == nil {
+		ours, _ = resp.(proto.Message)
+	}
+
+	select {
+	case m.slots <- struct{}{}:
+	default:
+		metrics.MirroredRequest(info.FullMethod, MirrorDropped)
+		return resp, err
+	}
+	// the request and our response can't be used by the handlers anymore, but are copied in case they're pooled
+	go m.mirror(info.FullMethod, proto.Clone(in), cloneMessage(ours), theirs)
+	return resp, err
+}
+
+// mirror sends the request to the shadow daemon and compares its response with ours, which is nil if we failed.
+// The latest round may have changed in between, which is counted as a mismatch.
+func (m *Mirror) mirror(method string, req, ours, theirs proto.Message) {
+	defer func() { <-m.slots }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+	conn, err := m.client.conn(m.target)
+	if err != nil {
+		m.l.Debugw("unable to mirror a request", "method", method, "err", err)
+		metrics.MirroredRequest(method, MirrorError)
+		return
+	}
+	err = conn.Invoke(ctx, method, req, theirs)
+
+	result := mirrorResult(ours, theirs, err)
+	switch result {
+	case MirrorError:
+		m.l.Debugw("the shadow daemon failed a mirrored request", "method", method, "err", err)
+	case MirrorMismatch:
+		m.l.Debugw("the shadow daemon answered a mirrored request differently", "method", method,
+			"request", req, "ours", ours, "theirs", theirs)
+	}
+	metrics.MirroredRequest(method, result)
+}
+
+// mirrorResult compares our response, nil if we failed, with the one of the shadow daemon, or the error it returned
+func mirrorResult(ours, theirs proto.Message, err error) string {
+	switch {
+	case err != nil && ours == nil:
+		// we both failed, the errors themselves may differ with the version
+		return MirrorMatch
+	case err != nil:
+		return MirrorError
+	case ours == nil || !proto.Equal(withoutMetadata(ours), withoutMetadata(theirs)):
+		return MirrorMismatch
+	default:
+		return MirrorMatch
+	}
+}
+
+// Close closes the connection to the shadow daemon
+func (m *Mirror) Close() {
+	m.client.Stop()
+}
+
+// mirrorReply returns an empty response of the unary public method, nil if it isn't one
+func mirrorReply(method string) proto.Message {
+	name, found := strings.CutPrefix(method, publicMethodPrefix)
+	if !found {
+		return nil
+	}
+	switch name {
+	case "PublicRand":
+		return new(drand.PublicRandResponse)
+	case "ChainInfo":
+		return new(drand.ChainInfoPacket)
+	case "ListBeaconIDs":
+		return new(drand.ListBeaconIDsResponse)
+	case "Heartbeat":
+		return new(drand.HeartbeatPacket)
+	case "SubBeacons":
+		return new(drand.SubBeaconsResponse)
+	case "DerivedChains":
+		return new(drand.DerivedChainsResponse)
+	case "DerivedRand":
+		return new(drand.DerivedRandResponse)
+	case "GroupMembership":
+		return new(drand.GroupMembershipResponse)
+	case "ChainSummary":
+		return new(drand.ChainSummaryResponse)
+	default:
+		return nil
+	}
+}
+
+// withoutMetadata returns a copy of the response without its metadata, such as the version of the node, which the
+// shadow daemon is expected to answer differently
+func withoutMetadata(m proto.Message) proto.Message {
+	m = proto.Clone(m)
+	r := m.ProtoReflect()
+	var metadata []protoreflect.FieldDescriptor
+	r.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if fd.Message() != nil && fd.Message().FullName() == metadataName {
+			metadata = append(metadata, fd)
+		}
+		return true
+	})
+	for _, fd := range metadata {
+		r.Clear(fd)
+	}
+	return m
+}
+
+func cloneMessage(m proto.Message) proto.Message {
+	if m == nil {
+		return nil
+	}
+	return proto.Clone(m)
+}
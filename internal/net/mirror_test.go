@@ -0,0 +1,55 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/drand/drand/v2/common/testlogger"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+func TestMirrorReply(t *testing.T) {
+	require.IsType(t, &drand.PublicRandResponse{}, mirrorReply("/drand.Public/PublicRand"))
+	require.IsType(t, &drand.ChainInfoPacket{}, mirrorReply("/drand.Public/ChainInfo"))
+	// streams and the other services aren't mirrored
+	require.Nil(t, mirrorReply("/drand.Public/PublicRandStream"))
+	require.Nil(t, mirrorReply("/drand.Protocol/PartialBeacon"))
+}
+
+func TestMirrorResult(t *testing.T) {
+	ours := &drand.PublicRandResponse{Round: 3, Signature: []byte{1}}
+	require.Equal(t, MirrorMatch, mirrorResult(ours, &drand.PublicRandResponse{Round: 3, Signature: []byte{1}}, nil))
+	require.Equal(t, MirrorMismatch, mirrorResult(ours, &drand.PublicRandResponse{Round: 4, Signature: []byte{1}}, nil))
+	require.Equal(t, MirrorMismatch, mirrorResult(nil, &drand.PublicRandResponse{Round: 3}, nil))
+	require.Equal(t, MirrorError, mirrorResult(ours, &drand.PublicRandResponse{}, errors.New("unavailable")))
+	require.Equal(t, MirrorMatch, mirrorResult(nil, &drand.PublicRandResponse{}, errors.New("not found")))
+
+	// the metadata, such as the version of the daemon, don't count
+	ours.Metadata = &drand.Metadata{NodeVersion: &drand.NodeVersion{Major: 2}}
+	theirs := &drand.PublicRandResponse{Round: 3, Signature: []byte{1}, Metadata: &drand.Metadata{NodeVersion: &drand.NodeVersion{Major: 3}}}
+	require.Equal(t, MirrorMatch, mirrorResult(ours, theirs, nil))
+	require.NotNil(t, theirs.Metadata, "the responses compared are left untouched")
+	require.Equal(t, MirrorMatch, mirrorResult(
+		&drand.ListBeaconIDsResponse{Ids: []string{"default"}, Metadatas: []*drand.Metadata{{BeaconID: "default"}}},
+		&drand.ListBeaconIDsResponse{Ids: []string{"default"}}, nil))
+}
+
+func TestMirrorDoesNotDelayResponses(t *testing.T) {
+	m := NewMirror(testlogger.New(t), "127.0.0.1:1", 1, time.Second)
+	defer m.Close()
+	// the only slot is taken, so the request is dropped
+	m.slots <- struct{}{}
+
+	want := &drand.PublicRandResponse{Round: 7}
+	handler := func(context.Context, interface{}) (interface{}, error) { return want, nil }
+	info := &grpc.UnaryServerInfo{FullMethod: "/drand.Public/PublicRand"}
+	resp, err := m.UnaryServerInterceptor(context.Background(), &drand.PublicRandRequest{Round: 7}, info, handler)
+	require.NoError(t, err)
+	require.Same(t, want, resp)
+	require.Len(t, m.slots, 1)
+}
@@ -0,0 +1,106 @@
+package net
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"golang.org/x/net/proxy"
+	"google.golang.org/grpc"
+)
+
+// OutboundAddresses selects the local address the connections to the other nodes originate from, for the
+// multi-homed operators whose peers only accept connections from a specific egress IP. The local addresses are
+// either IPs or names of network interfaces, in which case the first address of the interface is used.
+type OutboundAddresses struct {
+	// Default is used for the peers without a specific local address. The system picks one when it's empty.
+	Default string
+	// PerPeer maps the host:port the peers are dialed at to the local address to use for them
+	PerPeer map[string]string
+}
+
+// ParseOutboundAddresses parses the given entries, which are either "<local address>" to set the default local
+// address, or "<peer host:port>=<local address>" to set the one of a given peer.
+func ParseOutboundAddresses(entries []string) (OutboundAddresses, error) {
+	o := OutboundAddresses{PerPeer: make(map[string]string)}
+	for _, entry := range entries {
+		peer, local, perPeer := strings.Cut(entry, "=")
+		if !perPeer {
+			local = peer
+		}
+		if _, err := localIP(local); err != nil {
+			return OutboundAddresses{}, err
+		}
+		if !perPeer {
+			o.Default = local
+			continue
+		}
+		if _, _, err := net.SplitHostPort(peer); err != nil {
+			return OutboundAddresses{}, fmt.Errorf("invalid peer address in outbound address %q: %w", entry, err)
+		}
+		o.PerPeer[peer] = local
+	}
+	return o, nil
+}
+
+// DialOptions returns the grpc.DialOption making the outbound connections originate from these addresses.
+// The proxy configured through the environment, if any, is still used.
+func (o OutboundAddresses) DialOptions() []grpc.DialOption {
+	if o.Default == "" && len(o.PerPeer) == 0 {
+		return nil
+	}
+	return []grpc.DialOption{grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+		local, ok := o.PerPeer[addr]
+		if !ok {
+			local = o.Default
+		}
+		forward := &net.Dialer{}
+		if local != "" {
+			// the interfaces can change while we run, so they are resolved on every dial
+			ip, err := localIP(local)
+			if err != nil {
+				return nil, err
+			}
+			forward.LocalAddr = &net.TCPAddr{IP: ip}
+		}
+
+		dialer := proxy.FromEnvironmentUsing(forward)
+		if cd, ok := dialer.(proxy.ContextDialer); ok {
+			return cd.DialContext(ctx, "tcp", addr)
+		}
+		return dialer.Dial("tcp", addr)
+	})}
+}
+
+// localIP returns the given IP, or the first address of the given network interface, preferring IPv4
+func localIP(local string) (net.IP, error) {
+	if ip := net.ParseIP(local); ip != nil {
+		return ip, nil
+	}
+	iface, err := net.InterfaceByName(local)
+	if err != nil {
+		return nil, fmt.Errorf("outbound address %q is neither an IP nor a network interface: %w", local, err)
+	}
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil, err
+	}
+	var found net.IP
+	for _, a := range addrs {
+		ipNet, ok := a.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		if ipNet.IP.To4() != nil {
+			return ipNet.IP, nil
+		}
+		if found == nil {
+			found = ipNet.IP
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("network interface %q has no address", local)
+	}
+	return found, nil
+}
@@ -0,0 +1,40 @@
+package net
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOutboundAddresses(t *testing.T) {
+	o, err := ParseOutboundAddresses([]string{"10.0.0.1", "node1.example.org:4444=192.168.1.2"})
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.1", o.Default)
+	require.Equal(t, map[string]string{"node1.example.org:4444": "192.168.1.2"}, o.PerPeer)
+	require.Len(t, o.DialOptions(), 1)
+
+	o, err = ParseOutboundAddresses(nil)
+	require.NoError(t, err)
+	require.Empty(t, o.DialOptions())
+
+	_, err = ParseOutboundAddresses([]string{"not-an-interface-or-ip"})
+	require.Error(t, err)
+	_, err = ParseOutboundAddresses([]string{"node1.example.org=10.0.0.1"})
+	require.Error(t, err)
+}
+
+func TestLocalIPFromInterface(t *testing.T) {
+	ifaces, err := net.Interfaces()
+	require.NoError(t, err)
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagLoopback == 0 {
+			continue
+		}
+		ip, err := localIP(iface.Name)
+		require.NoError(t, err)
+		require.True(t, ip.IsLoopback())
+		return
+	}
+	t.Skip("no loopback interface")
+}
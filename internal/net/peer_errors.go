@@ -0,0 +1,128 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The reasons a peer failed to serve a request, as reported by PeerErrors
+const (
+	PeerTimeout     = "timeout"
+	PeerUnreachable = "unreachable"
+	PeerVersion     = "version"
+	PeerBadHash     = "bad_hash"
+	PeerBadBeaconID = "bad_beacon_id"
+	PeerInvalid     = "invalid_response"
+	PeerError       = "error"
+)
+
+// peerErrorsReason is the reason of the ErrorInfo detail carrying the failures of the peers over gRPC
+const peerErrorsReason = "PEERS_FAILED"
+
+// PeerFailure is why a request to a peer failed
+type PeerFailure struct {
+	Peer   string
+	Reason string
+	Err    error
+}
+
+func (f *PeerFailure) Error() string {
+	return fmt.Sprintf("%s (%s): %v", f.Peer, f.Reason, f.Err)
+}
+
+func (f *PeerFailure) Unwrap() error {
+	return f.Err
+}
+
+// PeerErrors is returned when a request failed with all the peers it was sent to, with why each of them failed, so
+// that the operator knows which peers to look at rather than only the last error. Over gRPC, the failures are sent
+// in the details of the status and read back with FailedPeers.
+type PeerErrors struct {
+	// Op is what was requested, e.g. "get the chain info"
+	Op       string
+	Failures []*PeerFailure
+}
+
+// Add records the failure of a peer
+func (e *PeerErrors) Add(peer, reason string, err error) {
+	e.Failures = append(e.Failures, &PeerFailure{Peer: peer, Reason: reason, Err: err})
+}
+
+func (e *PeerErrors) Error() string {
+	if len(e.Failures) == 0 {
+		return fmt.Sprintf("unable to %s: no peers", e.Op)
+	}
+	failures := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		failures = append(failures, f.Error())
+	}
+	return fmt.Sprintf("unable to %s from any of the %d peers: %s", e.Op, len(e.Failures), strings.Join(failures, "; "))
+}
+
+func (e *PeerErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		errs = append(errs, f)
+	}
+	return errs
+}
+
+// GRPCStatus returns the status sent to the clients, with the failure of each peer in an ErrorInfo detail
+func (e *PeerErrors) GRPCStatus() *status.Status {
+	info := &errdetails.ErrorInfo{Reason: peerErrorsReason, Domain: "drand", Metadata: make(map[string]string)}
+	for _, f := range e.Failures {
+		info.Metadata[f.Peer] = fmt.Sprintf("%s: %v", f.Reason, f.Err)
+	}
+	st := status.New(codes.Unavailable, e.Error())
+	if withInfo, err := st.WithDetails(info); err == nil {
+		return withInfo
+	}
+	return st
+}
+
+// FailedPeers returns why each peer failed, by address, from a PeerErrors or the status of a gRPC call which failed
+// with one. It is empty if the error isn't about peers.
+func FailedPeers(err error) map[string]string {
+	var perr *PeerErrors
+	if errors.As(err, &perr) {
+		failed := make(map[string]string, len(perr.Failures))
+		for _, f := range perr.Failures {
+			failed[f.Peer] = fmt.Sprintf("%s: %v", f.Reason, f.Err)
+		}
+		return failed
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetReason() == peerErrorsReason {
+			return info.GetMetadata()
+		}
+	}
+	return nil
+}
+
+// PeerReason classifies the error returned by a call to a peer
+func PeerReason(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return PeerTimeout
+	}
+	switch st, _ := status.FromError(err); st.Code() {
+	case codes.DeadlineExceeded:
+		return PeerTimeout
+	case codes.Unavailable:
+		return PeerUnreachable
+	case codes.PermissionDenied:
+		if strings.Contains(st.Message(), "Incompatible node version") {
+			return PeerVersion
+		}
+	}
+	return PeerError
+}
@@ -0,0 +1,46 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPeerErrors(t *testing.T) {
+	timeout := status.Error(codes.DeadlineExceeded, "too slow")
+	require.Equal(t, PeerTimeout, PeerReason(timeout))
+	require.Equal(t, PeerTimeout, PeerReason(fmt.Errorf("dialing: %w", context.DeadlineExceeded)))
+	require.Equal(t, PeerUnreachable, PeerReason(status.Error(codes.Unavailable, "connection refused")))
+	require.Equal(t, PeerVersion, PeerReason(status.Error(codes.PermissionDenied, "Incompatible node version. Current: 2.1.0")))
+	require.Equal(t, PeerError, PeerReason(errors.New("boom")))
+
+	errs := &PeerErrors{Op: "get the chain info"}
+	require.EqualError(t, errs, "unable to get the chain info: no peers")
+	errs.Add("a:1", PeerTimeout, timeout)
+	errs.Add("b:2", PeerBadHash, errors.New("chain hash mismatch"))
+	require.Contains(t, errs.Error(), "from any of the 2 peers")
+	require.Contains(t, errs.Error(), "a:1 (timeout)")
+	require.Contains(t, errs.Error(), "b:2 (bad_hash): chain hash mismatch")
+	require.ErrorIs(t, fmt.Errorf("following: %w", errs), timeout)
+
+	expected := map[string]string{
+		"a:1": "timeout: rpc error: code = DeadlineExceeded desc = too slow",
+		"b:2": "bad_hash: chain hash mismatch",
+	}
+	require.Equal(t, expected, FailedPeers(fmt.Errorf("following: %w", errs)))
+
+	// the failures are read back from the status received by the clients, with the details added to it
+	st := status.Convert(errs)
+	require.Equal(t, codes.Unavailable, st.Code())
+	received := withRequestIDDetail(st.Err(), "some-id")
+	require.Equal(t, expected, FailedPeers(received))
+	require.Equal(t, "some-id", RequestIDFromError(received))
+
+	require.Empty(t, FailedPeers(status.Error(codes.Internal, "not about peers")))
+	require.Empty(t, FailedPeers(errors.New("not about peers")))
+}
@@ -0,0 +1,453 @@
+package net
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	gonet "net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	clock "github.com/jonboulle/clockwork"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/drand/drand/v2/internal/metrics"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// APIKeyHeader is the HTTP header, and the gRPC metadata key, the clients of the public API give their API key in
+const APIKeyHeader = "x-api-key"
+
+// AnonymousTier is the tier of the requests without an API key. They aren't limited if there is no such tier.
+const AnonymousTier = "anonymous"
+
+// sharedCountersTimeout bounds the accounting of a request in the counters shared with the other instances
+const sharedCountersTimeout = 200 * time.Millisecond
+
+// MaxAnonymousBuckets is the most anonymous clients whose rate is tracked at once, the least recently seen ones being
+// dropped past it
+var MaxAnonymousBuckets = 16384
+
+// anonymousIPv6Prefix is the length of the prefixes the anonymous IPv6 clients are limited by: a single host usually
+// gets a whole /64, and could otherwise switch addresses within it to escape the limits
+const anonymousIPv6Prefix = 64
+
+var (
+	// ErrUnknownAPIKey is returned for the requests with an API key which isn't registered
+	ErrUnknownAPIKey = errors.New("unknown API key")
+	// ErrRateLimited is returned for the requests over the rate of their tier
+	ErrRateLimited = errors.New("too many requests, retry later")
+	// ErrQuotaExceeded is returned for the requests over the daily quota of their tier
+	ErrQuotaExceeded = errors.New("daily quota exceeded, retry tomorrow")
+)
+
+// publicServicePrefix prefixes the methods of the public gRPC service, the only ones subject to the quotas
+var publicServicePrefix = "/" + drand.Public_ServiceDesc.ServiceName + "/"
+
+// QuotaTier limits the requests of the clients of the public API
+type QuotaTier struct {
+	Name string
+	// Rate is the sustained number of requests per second, 0 if unlimited
+	Rate float64
+	// Burst is the number of requests which can be sent at once, the rate rounded up if 0
+	Burst int
+	// Daily is the number of requests per UTC day, 0 if unlimited
+	Daily uint64
+}
+
+// ParseQuotaTier parses a tier given as "name=rate[:burst[:daily]]", e.g. "gold=50:100:1000000"
+func ParseQuotaTier(spec string) (QuotaTier, error) {
+	name, limits, ok := strings.Cut(spec, "=")
+	if !ok || name == "" {
+		return QuotaTier{}, fmt.Errorf("invalid quota tier %q, expected name=rate[:burst[:daily]]", spec)
+	}
+	parts := strings.Split(limits, ":")
+	if len(parts) > 3 {
+		return QuotaTier{}, fmt.Errorf("invalid quota tier %q, expected name=rate[:burst[:daily]]", spec)
+	}
+	tier := QuotaTier{Name: name}
+	var err error
+	if tier.Rate, err = strconv.ParseFloat(parts[0], 64); err != nil || tier.Rate < 0 {
+		return QuotaTier{}, fmt.Errorf("invalid rate in quota tier %q", spec)
+	}
+	if len(parts) > 1 {
+		if tier.Burst, err = strconv.Atoi(parts[1]); err != nil || tier.Burst < 0 {
+			return QuotaTier{}, fmt.Errorf("invalid burst in quota tier %q", spec)
+		}
+	}
+	if len(parts) > 2 {
+		if tier.Daily, err = strconv.ParseUint(parts[2], 10, 64); err != nil {
+			return QuotaTier{}, fmt.Errorf("invalid daily quota in quota tier %q", spec)
+		}
+	}
+	if tier.Burst == 0 {
+		tier.Burst = int(tier.Rate + 0.999)
+	}
+	return tier, nil
+}
+
+// APIKey registers a client of the public API with a tier
+type APIKey struct {
+	Client string
+	Tier   string
+	Key    string
+}
+
+// ParseAPIKey parses an API key given as "client:tier:key"
+func ParseAPIKey(spec string) (APIKey, error) {
+	parts := strings.SplitN(spec, ":", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return APIKey{}, errors.New("invalid API key, expected client:tier:key")
+	}
+	if parts[0] == AnonymousTier {
+		return APIKey{}, fmt.Errorf("the client of an API key can't be named %s", AnonymousTier)
+	}
+	return APIKey{Client: parts[0], Tier: parts[1], Key: parts[2]}, nil
+}
+
+// quotaUsage is the usage of a client, or of an anonymous address for the rate of the anonymous tier
+type quotaUsage struct {
+	tokens float64
+	last   time.Time
+	// the UTC day the daily count is for, as days since the epoch
+	day       int64
+	today     uint64
+	total     uint64
+	throttled uint64
+}
+
+// QuotaUsage reports the requests of a client of the public API
+type QuotaUsage struct {
+	Client    string
+	Tier      string
+	Today     uint64
+	Total     uint64
+	Throttled uint64
+}
+
+// Quotas limits the requests to the public API by tier: the clients with an API key get the rate and daily quota
+// of the tier of their key, and the anonymous ones the ones of the anonymous tier, per address, if there is one.
+// A nil Quotas doesn't limit anything.
+type Quotas struct {
+	sync.Mutex
+	clock clock.Clock
+	tiers map[string]QuotaTier
+	// the clients by their API key
+	keys    map[string]APIKey
+	clients map[string]*quotaUsage
+	// the anonymous usage, and the rate of each anonymous address, the most recently seen first
+	anonymous *quotaUsage
+	addresses map[string]*list.Element
+	lru       *list.List
+	// the counters shared with the other instances serving the API, nil if the usage is only counted locally
+	counters QuotaCounters
+}
+
+// QuotaCounters are counters shared by the instances serving the API behind a load balancer, so that the limits of
+// the tiers apply to their requests as a whole
+type QuotaCounters interface {
+	// Incr increments the counter of the key and returns its value. A new counter expires after the ttl.
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
+}
+
+// SetCounters counts the usage in the counters shared with the other instances serving the API rather than locally.
+// The requests are counted locally while the shared counters can't be reached.
+func (q *Quotas) SetCounters(counters QuotaCounters) {
+	q.Lock()
+	defer q.Unlock()
+	q.counters = counters
+}
+
+// NewQuotas returns the quotas of the given tiers for the given API keys, which must all have a known tier
+func NewQuotas(cl clock.Clock, tiers []QuotaTier, keys []APIKey) (*Quotas, error) {
+	q := &Quotas{
+		clock:     cl,
+		tiers:     make(map[string]QuotaTier, len(tiers)),
+		keys:      make(map[string]APIKey, len(keys)),
+		clients:   make(map[string]*quotaUsage, len(keys)),
+		anonymous: new(quotaUsage),
+		addresses: make(map[string]*list.Element),
+		lru:       list.New(),
+	}
+	for _, t := range tiers {
+		if _, ok := q.tiers[t.Name]; ok {
+			return nil, fmt.Errorf("quota tier %s given twice", t.Name)
+		}
+		q.tiers[t.Name] = t
+	}
+	for _, k := range keys {
+		if _, ok := q.tiers[k.Tier]; !ok {
+			return nil, fmt.Errorf("unknown quota tier %s of the API key of %s", k.Tier, k.Client)
+		}
+		if _, ok := q.keys[k.Key]; ok {
+			return nil, fmt.Errorf("the API key of %s is given twice", k.Client)
+		}
+		q.keys[k.Key] = k
+		if _, ok := q.clients[k.Client]; !ok {
+			q.clients[k.Client] = new(quotaUsage)
+		}
+	}
+	return q, nil
+}
+
+// Allow accounts for a request with the given API key, empty if none, from the given address. It returns the client
+// the request is accounted to, and an error if it must be refused, with after how long to retry it when limited.
+func (q *Quotas) Allow(apiKey, addr string) (string, time.Duration, error) {
+	if q == nil {
+		return AnonymousTier, 0, nil
+	}
+	q.Lock()
+	now := q.clock.Now()
+	client, tierName, usage := AnonymousTier, AnonymousTier, q.anonymous
+	if apiKey != "" {
+		k, ok := q.keys[apiKey]
+		if !ok {
+			q.Unlock()
+			metrics.APIRequest("unknown", "unauthenticated")
+			return "", 0, ErrUnknownAPIKey
+		}
+		client, tierName, usage = k.Client, k.Tier, q.clients[k.Client]
+	}
+	tier, limited := q.tiers[tierName]
+	counters := q.counters
+	q.Unlock()
+
+	// the anonymous requests are limited per address, the others per client
+	id := client
+	if apiKey == "" {
+		id = AnonymousTier + "/" + anonymousClient(addr)
+	}
+	var retryAfter time.Duration
+	var err error
+	shared := false
+	if limited && counters != nil {
+		retryAfter, shared, err = allowShared(counters, id, tier, now)
+	}
+
+	q.Lock()
+	defer q.Unlock()
+	day := now.UTC().Unix() / 86400
+	usage.newDay(day)
+	if limited && !shared {
+		retryAfter, err = q.allowLocal(usage, apiKey == "", addr, tier, now)
+	}
+	switch {
+	case errors.Is(err, ErrQuotaExceeded):
+		usage.throttled++
+		metrics.APIRequest(client, "over_quota")
+		return client, retryAfter, err
+	case err != nil:
+		usage.throttled++
+		metrics.APIRequest(client, "rate_limited")
+		return client, retryAfter, err
+	}
+	usage.today++
+	usage.total++
+	metrics.APIRequest(client, "served")
+	return client, 0, nil
+}
+
+// allowLocal checks the request against the usage counted by this instance. It must be called with the lock held.
+func (q *Quotas) allowLocal(usage *quotaUsage, anonymous bool, addr string, tier QuotaTier, now time.Time) (time.Duration, error) {
+	day := now.UTC().Unix() / 86400
+	limit := usage
+	if anonymous {
+		limit = q.anonymousBucket(addr)
+		limit.newDay(day)
+	}
+	if tier.Daily > 0 && limit.today >= tier.Daily {
+		return time.Unix((day+1)*86400, 0).Sub(now), ErrQuotaExceeded
+	}
+	if wait := limit.take(now, tier); wait > 0 {
+		return wait, ErrRateLimited
+	}
+	if limit != usage {
+		limit.today++
+	}
+	return 0, nil
+}
+
+// allowShared checks the request against the counters shared with the other instances serving the API: the rate
+// over windows of a second, allowing the burst of the tier in each, and the daily quota. It returns false if the
+// counters can't be reached, for the request to be checked against the local usage instead.
+func allowShared(counters QuotaCounters, id string, tier QuotaTier, now time.Time) (time.Duration, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sharedCountersTimeout)
+	defer cancel()
+
+	if tier.Rate > 0 {
+		second := now.Unix()
+		n, err := counters.Incr(ctx, fmt.Sprintf("drand:quota:rate:%s:%d", id, second), 2*time.Second)
+		if err != nil {
+			return 0, false, nil
+		}
+		if n > int64(tier.Burst) {
+			return time.Unix(second+1, 0).Sub(now), true, ErrRateLimited
+		}
+	}
+	if tier.Daily > 0 {
+		day := now.UTC().Unix() / 86400
+		n, err := counters.Incr(ctx, fmt.Sprintf("drand:quota:daily:%s:%d", id, day), 25*time.Hour)
+		if err != nil {
+			return 0, false, nil
+		}
+		if n > int64(tier.Daily) {
+			return time.Unix((day+1)*86400, 0).Sub(now), true, ErrQuotaExceeded
+		}
+	}
+	return 0, true, nil
+}
+
+// anonymousBucketEntry is the bucket of an anonymous client in the LRU list
+type anonymousBucketEntry struct {
+	client string
+	usage  *quotaUsage
+}
+
+// anonymousClient returns what the anonymous requests from the address are limited by: its IPv4 address, or the /64
+// prefix of its IPv6 address
+func anonymousClient(addr string) string {
+	host := addr
+	if h, _, err := gonet.SplitHostPort(addr); err == nil {
+		host = h
+	}
+	ip := gonet.ParseIP(host)
+	if ip == nil || ip.To4() != nil {
+		return host
+	}
+	prefix := &gonet.IPNet{IP: ip.Mask(gonet.CIDRMask(anonymousIPv6Prefix, 128)), Mask: gonet.CIDRMask(anonymousIPv6Prefix, 128)}
+	return prefix.String()
+}
+
+// anonymousBucket returns the bucket limiting the rate of the anonymous address, dropping the least recently seen
+// ones past MaxAnonymousBuckets so that they don't pile up. It must be called with the lock held.
+func (q *Quotas) anonymousBucket(addr string) *quotaUsage {
+	client := anonymousClient(addr)
+	if e, ok := q.addresses[client]; ok {
+		q.lru.MoveToFront(e)
+		return e.Value.(*anonymousBucketEntry).usage
+	}
+	for q.lru.Len() >= MaxAnonymousBuckets && q.lru.Len() > 0 {
+		oldest := q.lru.Back()
+		q.lru.Remove(oldest)
+		delete(q.addresses, oldest.Value.(*anonymousBucketEntry).client)
+	}
+	b := new(quotaUsage)
+	q.addresses[client] = q.lru.PushFront(&anonymousBucketEntry{client: client, usage: b})
+	return b
+}
+
+func (u *quotaUsage) newDay(day int64) {
+	if u.day != day {
+		u.day, u.today = day, 0
+	}
+}
+
+// take takes a token from the bucket, refilled at the rate of the tier, and returns how long to wait for one if
+// there is none left
+func (u *quotaUsage) take(now time.Time, tier QuotaTier) time.Duration {
+	if tier.Rate == 0 {
+		return 0
+	}
+	if u.last.IsZero() {
+		u.tokens = float64(tier.Burst)
+	} else if elapsed := now.Sub(u.last).Seconds(); elapsed > 0 {
+		u.tokens += elapsed * tier.Rate
+		if u.tokens > float64(tier.Burst) {
+			u.tokens = float64(tier.Burst)
+		}
+	}
+	u.last = now
+	if u.tokens < 1 {
+		return time.Duration((1 - u.tokens) / tier.Rate * float64(time.Second))
+	}
+	u.tokens--
+	return 0
+}
+
+// Usage returns the usage of each client, the anonymous ones last
+func (q *Quotas) Usage() []QuotaUsage {
+	if q == nil {
+		return nil
+	}
+	q.Lock()
+	defer q.Unlock()
+
+	day := q.clock.Now().UTC().Unix() / 86400
+	report := func(client, tier string, u *quotaUsage) QuotaUsage {
+		today := u.today
+		if u.day != day {
+			today = 0
+		}
+		return QuotaUsage{Client: client, Tier: tier, Today: today, Total: u.total, Throttled: u.throttled}
+	}
+	tiers := make(map[string]string, len(q.clients))
+	for _, k := range q.keys {
+		tiers[k.Client] = k.Tier
+	}
+	usage := make([]QuotaUsage, 0, len(q.clients)+1)
+	for client, u := range q.clients {
+		usage = append(usage, report(client, tiers[client], u))
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		return usage[i].Client < usage[j].Client
+	})
+	return append(usage, report(AnonymousTier, AnonymousTier, q.anonymous))
+}
+
+// QuotaCode returns the gRPC code of the error returned by Allow
+func QuotaCode(err error) codes.Code {
+	if errors.Is(err, ErrUnknownAPIKey) {
+		return codes.Unauthenticated
+	}
+	return codes.ResourceExhausted
+}
+
+func (q *Quotas) allowCall(ctx context.Context) (time.Duration, error) {
+	var apiKey, addr string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if keys := md.Get(APIKeyHeader); len(keys) > 0 {
+			apiKey = keys[0]
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		addr = p.Addr.String()
+	}
+	_, retryAfter, err := q.Allow(apiKey, addr)
+	return retryAfter, err
+}
+
+// UnaryServerInterceptor limits the calls to the public service by the tier of their API key
+func (q *Quotas) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !strings.HasPrefix(info.FullMethod, publicServicePrefix) {
+		return handler(ctx, req)
+	}
+	if retryAfter, err := q.allowCall(ctx); err != nil {
+		if retryAfter > 0 {
+			_ = grpc.SetTrailer(ctx, metadata.Pairs(RetryAfterKey, RetryAfterSeconds(retryAfter)))
+		}
+		return nil, status.Error(QuotaCode(err), err.Error())
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor limits the streams of the public service by the tier of their API key
+func (q *Quotas) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !strings.HasPrefix(info.FullMethod, publicServicePrefix) {
+		return handler(srv, ss)
+	}
+	if retryAfter, err := q.allowCall(ss.Context()); err != nil {
+		if retryAfter > 0 {
+			ss.SetTrailer(metadata.Pairs(RetryAfterKey, RetryAfterSeconds(retryAfter)))
+		}
+		return status.Error(QuotaCode(err), err.Error())
+	}
+	return handler(srv, ss)
+}
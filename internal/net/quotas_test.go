@@ -0,0 +1,185 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	clock "github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuotaTier(t *testing.T) {
+	tier, err := ParseQuotaTier("gold=50:100:1000000")
+	require.NoError(t, err)
+	require.Equal(t, QuotaTier{Name: "gold", Rate: 50, Burst: 100, Daily: 1000000}, tier)
+
+	tier, err = ParseQuotaTier("anonymous=0.5")
+	require.NoError(t, err)
+	require.Equal(t, QuotaTier{Name: "anonymous", Rate: 0.5, Burst: 1}, tier)
+
+	for _, spec := range []string{"gold", "=1", "gold=fast", "gold=-1", "gold=1:x", "gold=1:1:-1", "gold=1:1:1:1"} {
+		_, err := ParseQuotaTier(spec)
+		require.Error(t, err, spec)
+	}
+
+	k, err := ParseAPIKey("acme:gold:s3cr3t:with:colons")
+	require.NoError(t, err)
+	require.Equal(t, APIKey{Client: "acme", Tier: "gold", Key: "s3cr3t:with:colons"}, k)
+	for _, spec := range []string{"acme:gold", "acme::key", "anonymous:gold:key"} {
+		_, err := ParseAPIKey(spec)
+		require.Error(t, err, spec)
+	}
+
+	_, err = NewQuotas(clock.NewFakeClock(), nil, []APIKey{{Client: "acme", Tier: "gold", Key: "k"}})
+	require.Error(t, err, "unknown tier")
+}
+
+func TestQuotas(t *testing.T) {
+	clk := clock.NewFakeClockAt(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	q, err := NewQuotas(clk,
+		[]QuotaTier{{Name: "gold", Rate: 10, Burst: 10, Daily: 25}, {Name: AnonymousTier, Rate: 1, Burst: 2}},
+		[]APIKey{{Client: "acme", Tier: "gold", Key: "acme-key"}})
+	require.NoError(t, err)
+
+	// the burst of the tier, then its rate
+	for i := 0; i < 10; i++ {
+		client, _, err := q.Allow("acme-key", "10.0.0.1:1234")
+		require.NoError(t, err)
+		require.Equal(t, "acme", client)
+	}
+	_, retryAfter, err := q.Allow("acme-key", "10.0.0.1:1234")
+	require.ErrorIs(t, err, ErrRateLimited)
+	require.Equal(t, 100*time.Millisecond, retryAfter)
+
+	// then the daily quota
+	clk.Advance(time.Second)
+	for i := 0; i < 10; i++ {
+		_, _, err := q.Allow("acme-key", "")
+		require.NoError(t, err)
+	}
+	clk.Advance(time.Second)
+	for i := 0; i < 5; i++ {
+		_, _, err := q.Allow("acme-key", "")
+		require.NoError(t, err)
+	}
+	_, retryAfter, err = q.Allow("acme-key", "")
+	require.ErrorIs(t, err, ErrQuotaExceeded)
+	require.Equal(t, 12*time.Hour-2*time.Second, retryAfter)
+
+	_, _, err = q.Allow("unknown-key", "")
+	require.ErrorIs(t, err, ErrUnknownAPIKey)
+
+	// the anonymous requests are limited per address
+	for i := 0; i < 2; i++ {
+		_, _, err := q.Allow("", "10.0.0.1:1234")
+		require.NoError(t, err)
+	}
+	_, _, err = q.Allow("", "10.0.0.1:5678")
+	require.ErrorIs(t, err, ErrRateLimited)
+	_, _, err = q.Allow("", "10.0.0.2:1234")
+	require.NoError(t, err)
+	// and the IPv6 ones per /64 prefix
+	for i := 0; i < 2; i++ {
+		_, _, err := q.Allow("", "[2001:db8::1]:1234")
+		require.NoError(t, err)
+	}
+	_, _, err = q.Allow("", "[2001:db8::2]:1234")
+	require.ErrorIs(t, err, ErrRateLimited)
+
+	// the next day
+	clk.Advance(12 * time.Hour)
+	_, _, err = q.Allow("acme-key", "")
+	require.NoError(t, err)
+
+	require.Equal(t, []QuotaUsage{
+		{Client: "acme", Tier: "gold", Today: 1, Total: 26, Throttled: 2},
+		{Client: AnonymousTier, Tier: AnonymousTier, Today: 0, Total: 5, Throttled: 2},
+	}, q.Usage())
+
+	var nilQuotas *Quotas
+	_, _, err = nilQuotas.Allow("any", "")
+	require.NoError(t, err)
+}
+
+type fakeCounters struct {
+	counts map[string]int64
+	err    error
+}
+
+func (f *fakeCounters) Incr(_ context.Context, key string, _ time.Duration) (int64, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	f.counts[key]++
+	return f.counts[key], nil
+}
+
+func TestQuotasSharedCounters(t *testing.T) {
+	clk := clock.NewFakeClockAt(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	tiers := []QuotaTier{{Name: "gold", Rate: 10, Burst: 3, Daily: 5}}
+	keys := []APIKey{{Client: "acme", Tier: "gold", Key: "acme-key"}}
+	counters := &fakeCounters{counts: make(map[string]int64)}
+
+	// two instances sharing the counters
+	var instances []*Quotas
+	for i := 0; i < 2; i++ {
+		q, err := NewQuotas(clk, tiers, keys)
+		require.NoError(t, err)
+		q.SetCounters(counters)
+		instances = append(instances, q)
+	}
+
+	for i := 0; i < 3; i++ {
+		_, _, err := instances[i%2].Allow("acme-key", "")
+		require.NoError(t, err)
+	}
+	_, retryAfter, err := instances[1].Allow("acme-key", "")
+	require.ErrorIs(t, err, ErrRateLimited)
+	require.Equal(t, time.Second, retryAfter)
+
+	clk.Advance(time.Second)
+	_, _, err = instances[0].Allow("acme-key", "")
+	require.NoError(t, err)
+	_, _, err = instances[1].Allow("acme-key", "")
+	require.NoError(t, err)
+	_, _, err = instances[0].Allow("acme-key", "")
+	require.ErrorIs(t, err, ErrQuotaExceeded)
+
+	// the usage is counted locally while the counters can't be reached
+	counters.err = errors.New("connection refused")
+	clk.Advance(24 * time.Hour)
+	for i := 0; i < 3; i++ {
+		_, _, err := instances[0].Allow("acme-key", "")
+		require.NoError(t, err)
+	}
+	_, _, err = instances[0].Allow("acme-key", "")
+	require.ErrorIs(t, err, ErrRateLimited)
+}
+
+func TestQuotasAnonymousBucketsCapped(t *testing.T) {
+	defer func(max int) { MaxAnonymousBuckets = max }(MaxAnonymousBuckets)
+	MaxAnonymousBuckets = 2
+
+	clk := clock.NewFakeClockAt(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	q, err := NewQuotas(clk, []QuotaTier{{Name: AnonymousTier, Rate: 1, Burst: 1}}, nil)
+	require.NoError(t, err)
+
+	_, _, err = q.Allow("", "10.0.0.1:1234")
+	require.NoError(t, err)
+	_, _, err = q.Allow("", "10.0.0.2:1234")
+	require.NoError(t, err)
+	_, _, err = q.Allow("", "10.0.0.1:1234")
+	require.ErrorIs(t, err, ErrRateLimited)
+
+	// the least recently seen address is dropped to make room for a new one
+	_, _, err = q.Allow("", "10.0.0.3:1234")
+	require.NoError(t, err)
+	require.Len(t, q.addresses, 2)
+	require.Contains(t, q.addresses, "10.0.0.1")
+	require.NotContains(t, q.addresses, "10.0.0.2")
+
+	require.Equal(t, "2001:db8:0:1::/64", anonymousClient("[2001:db8:0:1:aaaa::1]:443"))
+	require.Equal(t, "10.0.0.1", anonymousClient("10.0.0.1:443"))
+}
@@ -0,0 +1,76 @@
+package net
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/log"
+	"github.com/drand/drand/v2/internal/crash"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// gatewayModule is the module the panics of the handlers of the gateways are reported under
+const gatewayModule = "gateway"
+
+// RecoveryUnaryServerInterceptor turns the panics of the unary handlers into an internal error, and writes their
+// crash report
+func RecoveryUnaryServerInterceptor(l log.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(l, info.FullMethod, req, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// RecoveryStreamServerInterceptor turns the panics of the stream handlers into an internal error, and writes their
+// crash report
+func RecoveryStreamServerInterceptor(l log.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(l, info.FullMethod, nil, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// RecoveryHandler answers the requests whose handler panicked with an internal error, and writes their crash report
+func RecoveryHandler(l log.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			// the handler aborts the response on purpose with this one, the server deals with it
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+			_ = crash.Handle(l, crash.Scope{Module: gatewayModule, Details: func() map[string]string {
+				return map[string]string{"method": r.Method, "path": r.URL.Path}
+			}}, rec)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+func recovered(l log.Logger, method string, req interface{}, r interface{}) error {
+	scope := crash.Scope{Module: gatewayModule, Details: func() map[string]string {
+		return map[string]string{"method": method}
+	}}
+	if m, ok := req.(interface{ GetMetadata() *drand.Metadata }); ok && m.GetMetadata() != nil {
+		scope.BeaconID = common.GetCanonicalBeaconID(m.GetMetadata().GetBeaconID())
+	}
+	err := crash.Handle(l, scope, r)
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,57 @@
+package net
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/drand/drand/v2/common/testlogger"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+func TestRecoveryInterceptors(t *testing.T) {
+	l := testlogger.New(t)
+
+	unary := RecoveryUnaryServerInterceptor(l)
+	req := &drand.PublicRandRequest{Round: 1, Metadata: &drand.Metadata{BeaconID: "default"}}
+	_, err := unary(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: "/drand.Public/PublicRand"},
+		func(context.Context, interface{}) (interface{}, error) {
+			panic("boom")
+		})
+	require.Equal(t, codes.Internal, status.Code(err))
+	require.Contains(t, err.Error(), "boom")
+
+	resp, err := unary(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: "/drand.Public/PublicRand"},
+		func(context.Context, interface{}) (interface{}, error) {
+			return "ok", nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, "ok", resp)
+
+	stream := RecoveryStreamServerInterceptor(l)
+	err = stream(nil, nil, &grpc.StreamServerInfo{FullMethod: "/drand.Public/PublicRandStream"},
+		func(interface{}, grpc.ServerStream) error {
+			panic("boom")
+		})
+	require.Equal(t, codes.Internal, status.Code(err))
+
+	handler := RecoveryHandler(l, http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/public/latest", http.NoBody))
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+
+	aborting := RecoveryHandler(l, http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	require.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		aborting.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	})
+}
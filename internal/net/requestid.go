@@ -0,0 +1,149 @@
+package net
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/drand/drand/v2/common/log"
+)
+
+// RequestIDKey is the gRPC metadata key carrying the ID of a request, which is propagated to the calls made to other
+// nodes to serve it, so that a request can be traced across the nodes from one identifier
+const RequestIDKey = "x-request-id"
+
+// maxRequestIDLen bounds the size of the request IDs received, which end up in our logs
+const maxRequestIDLen = 64
+
+type requestIDKey struct{}
+
+// NewRequestID returns a fresh random request ID
+func NewRequestID() string {
+	var id [8]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
+// WithRequestID returns a context carrying the request ID, which is sent along the gRPC calls made with it and is
+// attached to the lines logged by its logger
+func WithRequestID(ctx context.Context, id string) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey{}, id)
+	return log.ToContext(ctx, log.FromContextOrDefault(ctx).With("request_id", id))
+}
+
+// RequestIDFromContext returns the ID of the request being served with the context, empty if there is none
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// RequestIDFromError returns the ID of the failed request, as set in the details of the error by the node which
+// served it, empty if there is none
+func RequestIDFromError(err error) string {
+	st, ok := status.FromError(err)
+	if !ok {
+		return ""
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RequestInfo); ok {
+			return info.GetRequestId()
+		}
+	}
+	return ""
+}
+
+// incomingRequestID returns the request ID sent by the client, or a fresh one if it sent none or an invalid one
+func incomingRequestID(ctx context.Context) string {
+	if id := metadata.ValueFromIncomingContext(ctx, RequestIDKey); len(id) > 0 && validRequestID(id[0]) {
+		return id[0]
+	}
+	return NewRequestID()
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for _, c := range id {
+		if c < '!' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// serveWithRequestID returns the context to serve a request with, carrying its ID
+func serveWithRequestID(ctx context.Context) (context.Context, string) {
+	id := incomingRequestID(ctx)
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("request_id", id))
+	return WithRequestID(ctx, id), id
+}
+
+// withRequestIDDetail adds the request ID to the details of the error returned to the client
+func withRequestIDDetail(err error, id string) error {
+	if err == nil || RequestIDFromError(err) != "" {
+		return err
+	}
+	withID, detailErr := status.Convert(err).WithDetails(&errdetails.RequestInfo{RequestId: id})
+	if detailErr != nil {
+		return err
+	}
+	return withID.Err()
+}
+
+// RequestIDUnaryServerInterceptor serves each request with the ID sent by the client, or a fresh one, which is
+// returned in the headers of the response and in the details of the errors
+func RequestIDUnaryServerInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, id := serveWithRequestID(ctx)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, id))
+	resp, err := handler(ctx, req)
+	return resp, withRequestIDDetail(err, id)
+}
+
+// RequestIDStreamServerInterceptor serves each stream with the ID sent by the client, or a fresh one
+func RequestIDStreamServerInterceptor(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, id := serveWithRequestID(ss.Context())
+	_ = ss.SetHeader(metadata.Pairs(RequestIDKey, id))
+	err := handler(srv, &requestIDServerStream{ServerStream: ss, ctx: ctx})
+	return withRequestIDDetail(err, id)
+}
+
+type requestIDServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *requestIDServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// outgoingRequestID sends the ID of the request being served along the calls made to serve it, or a fresh one for
+// the calls made on our own
+func outgoingRequestID(ctx context.Context) context.Context {
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(RequestIDKey)) > 0 {
+		return ctx
+	}
+	id := RequestIDFromContext(ctx)
+	if id == "" {
+		id = NewRequestID()
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("request_id", id))
+	return metadata.AppendToOutgoingContext(ctx, RequestIDKey, id)
+}
+
+// RequestIDUnaryClientInterceptor propagates the request ID to the unary calls made
+func RequestIDUnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(outgoingRequestID(ctx), method, req, reply, cc, opts...)
+}
+
+// RequestIDStreamClientInterceptor propagates the request ID to the streams opened
+func RequestIDStreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(outgoingRequestID(ctx), desc, cc, method, opts...)
+}
@@ -0,0 +1,59 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestRequestIDServerInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/drand.Control/RemoteStatus"}
+	var served string
+	handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		served = RequestIDFromContext(ctx)
+		return nil, status.Error(codes.Unavailable, "unreachable")
+	}
+
+	// the ID of the client is kept
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDKey, "abc123"))
+	_, err := RequestIDUnaryServerInterceptor(ctx, nil, info, handler)
+	require.Equal(t, "abc123", served)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Equal(t, "abc123", RequestIDFromError(err))
+	require.Equal(t, "abc123", RequestIDFromError(errors.Join(errors.New("remote status"), err)), "the ID survives wrapping")
+
+	// a fresh one is generated when the client sent none or an invalid one
+	for _, md := range []metadata.MD{{}, metadata.Pairs(RequestIDKey, "bad id"), metadata.Pairs(RequestIDKey, strings.Repeat("a", 65))} {
+		_, err = RequestIDUnaryServerInterceptor(metadata.NewIncomingContext(context.Background(), md), nil, info, handler)
+		require.Len(t, served, 16)
+		require.Equal(t, served, RequestIDFromError(err))
+	}
+
+	require.Empty(t, RequestIDFromError(errors.New("not a gRPC error")))
+}
+
+func TestRequestIDClientInterceptor(t *testing.T) {
+	var sent []string
+	invoker := func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		sent = md.Get(RequestIDKey)
+		return nil
+	}
+
+	// the ID of the request being served is propagated to the calls made for it
+	ctx := WithRequestID(context.Background(), "abc123")
+	require.NoError(t, RequestIDUnaryClientInterceptor(ctx, "/drand.Protocol/Status", nil, nil, nil, invoker))
+	require.Equal(t, []string{"abc123"}, sent)
+
+	// the calls made on our own get a fresh one
+	require.NoError(t, RequestIDUnaryClientInterceptor(context.Background(), "/drand.Protocol/Status", nil, nil, nil, invoker))
+	require.Len(t, sent, 1)
+	require.Len(t, sent[0], 16)
+}
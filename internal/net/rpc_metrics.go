@@ -0,0 +1,108 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/drand/drand/v2/internal/metrics"
+)
+
+// The sides of a connection the gRPC calls are recorded on
+const (
+	rpcServerSide = "server"
+	rpcClientSide = "client"
+)
+
+// LatencyUnaryServerInterceptor records the latency of the unary calls handled by the server, such as the partial
+// beacons pushed by the other nodes, labeled by method and peer.
+func LatencyUnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	done := metrics.RPCStarted(rpcServerSide, info.FullMethod, remotePeer(ctx))
+	resp, err := handler(ctx, req)
+	done(status.Code(err).String())
+	return resp, err
+}
+
+// LatencyStreamServerInterceptor records the duration of the streams served, such as the syncs of the other nodes,
+// labeled by method and peer.
+func LatencyStreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	done := metrics.RPCStarted(rpcServerSide, info.FullMethod, remotePeer(ss.Context()))
+	err := handler(srv, ss)
+	done(status.Code(err).String())
+	return err
+}
+
+// LatencyUnaryClientInterceptor records the latency of the unary calls made to other nodes, labeled by method and peer.
+func LatencyUnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	done := metrics.RPCStarted(rpcClientSide, method, cc.Target())
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	done(status.Code(err).String())
+	return err
+}
+
+// LatencyStreamClientInterceptor records the duration of the streams opened to other nodes, from their opening to
+// the end of their last message, labeled by method and peer.
+func LatencyStreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	done := metrics.RPCStarted(rpcClientSide, method, cc.Target())
+	cs, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		done(status.Code(err).String())
+		return nil, err
+	}
+	s := &latencyClientStream{ClientStream: cs, done: done}
+	// a stream abandoned by canceling its context isn't received from anymore, it ends there
+	s.stop = context.AfterFunc(ctx, func() {
+		s.finish(status.FromContextError(ctx.Err()).Code().String())
+	})
+	return s, nil
+}
+
+// latencyClientStream reports the end of the stream the first time receiving from it fails, io.EOF being its
+// regular end, or when its context is canceled, whichever comes first
+type latencyClientStream struct {
+	grpc.ClientStream
+	once sync.Once
+	done func(code string)
+	stop func() bool
+}
+
+func (s *latencyClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			s.finish(status.Code(nil).String())
+		} else {
+			s.finish(status.Code(err).String())
+		}
+	}
+	return err
+}
+
+// finish reports the end of the stream, only once
+func (s *latencyClientStream) finish(code string) {
+	s.once.Do(func() {
+		if s.stop != nil {
+			s.stop()
+		}
+		s.done(code)
+	})
+}
+
+// remotePeer returns the host of the peer of the call, without its port which changes with every connection
+func remotePeer(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
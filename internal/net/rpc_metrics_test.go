@@ -0,0 +1,68 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+)
+
+func TestRemotePeer(t *testing.T) {
+	require.Equal(t, "unknown", remotePeer(context.Background()))
+
+	addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 43210}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+	require.Equal(t, "10.0.0.1", remotePeer(ctx))
+}
+
+type fakeClientStream struct {
+	grpc.ClientStream
+	errs []error
+}
+
+func (f *fakeClientStream) RecvMsg(interface{}) error {
+	err := f.errs[0]
+	f.errs = f.errs[1:]
+	return err
+}
+
+func TestLatencyClientStreamReportsOnce(t *testing.T) {
+	var codes []string
+	s := &latencyClientStream{
+		ClientStream: &fakeClientStream{errs: []error{nil, io.EOF, errors.New("closed")}},
+		done:         func(code string) { codes = append(codes, code) },
+	}
+
+	require.NoError(t, s.RecvMsg(nil))
+	require.Empty(t, codes)
+	require.ErrorIs(t, s.RecvMsg(nil), io.EOF)
+	require.Error(t, s.RecvMsg(nil))
+	require.Equal(t, []string{"OK"}, codes)
+}
+
+func TestLatencyClientStreamReportsCanceledStreams(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	reported := make(chan string, 2)
+	cs, err := LatencyStreamClientInterceptor(ctx, &grpc.StreamDesc{}, &grpc.ClientConn{}, "/drand.Protocol/SyncChain",
+		func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+			return &fakeClientStream{errs: []error{io.EOF}}, nil
+		})
+	require.NoError(t, err)
+	s := cs.(*latencyClientStream)
+	done := s.done
+	s.done = func(code string) {
+		done(code)
+		reported <- code
+	}
+
+	// the stream is abandoned without receiving its end
+	cancel()
+	require.Equal(t, "Canceled", <-reported)
+	require.ErrorIs(t, s.RecvMsg(nil), io.EOF)
+	require.Empty(t, reported)
+}
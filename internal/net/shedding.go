@@ -0,0 +1,200 @@
+package net
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	clock "github.com/jonboulle/clockwork"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/drand/drand/v2/internal/metrics"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// RetryAfterKey is the gRPC trailer telling the clients of a shed request after how many seconds to retry it, as
+// the Retry-After header does over HTTP
+const RetryAfterKey = "retry-after"
+
+// TrafficClass is the priority of a public request when the node sheds load
+type TrafficClass int
+
+const (
+	// TrafficHistory are the range queries over the history of the chain, shed first
+	TrafficHistory TrafficClass = iota
+	// TrafficRound are the requests for a given round rather than the latest one
+	TrafficRound
+	// TrafficLatest are the requests for the latest round, and all the others, never shed
+	TrafficLatest
+)
+
+func (c TrafficClass) String() string {
+	switch c {
+	case TrafficHistory:
+		return "history"
+	case TrafficRound:
+		return "round"
+	default:
+		return "latest"
+	}
+}
+
+// syncChainMethod is the range query served to the nodes syncing the chain
+var syncChainMethod = "/" + drand.Protocol_ServiceDesc.ServiceName + "/SyncChain"
+
+// publicRandMethod serves the latest round or a given one
+var publicRandMethod = "/" + drand.Public_ServiceDesc.ServiceName + "/PublicRand"
+
+// publicRandAtMethod serves the round which was the latest one at a given time
+var publicRandAtMethod = "/" + drand.Public_ServiceDesc.ServiceName + "/PublicRandAt"
+
+// publicRandByRandomnessMethod serves the round of a given randomness
+var publicRandByRandomnessMethod = "/" + drand.Public_ServiceDesc.ServiceName + "/PublicRandByRandomness"
+
+// chainLateness is how late a chain produced its last round
+type chainLateness struct {
+	lateness time.Duration
+	nextDue  time.Time
+	period   time.Duration
+}
+
+// LoadShedder sheds the low-priority public requests while the node is late producing the rounds of its chains,
+// so that serving them doesn't compete with the signing on shared hosts. The range queries are shed as soon as a
+// chain is later than the threshold, the requests for a given round once it is twice as late, and the requests for
+// the latest round are always served. A nil LoadShedder never sheds anything.
+type LoadShedder struct {
+	sync.Mutex
+	clock     clock.Clock
+	threshold time.Duration
+	chains    map[string]*chainLateness
+	// tells whether a sync comes from a member of a group of the node, if set
+	member func(ctx context.Context) bool
+}
+
+// NewLoadShedder returns a load shedder starting to shed once a chain is later than the threshold
+func NewLoadShedder(cl clock.Clock, threshold time.Duration) *LoadShedder {
+	return &LoadShedder{
+		clock:     cl,
+		threshold: threshold,
+		chains:    make(map[string]*chainLateness),
+	}
+}
+
+// Observe records that the round of the chain due at the given time was produced at the given one. The next round
+// is due a period later: the chain is considered as late as it stalls past it.
+func (s *LoadShedder) Observe(chain string, due, produced time.Time, period time.Duration) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.chains[chain] = &chainLateness{
+		lateness: produced.Sub(due),
+		nextDue:  due.Add(period),
+		period:   period,
+	}
+}
+
+// ExemptMembers never sheds the syncs of the peers the given function recognizes as members of a group of the node:
+// a member catching up on the chain is what makes it on time again.
+func (s *LoadShedder) ExemptMembers(member func(ctx context.Context) bool) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.member = member
+}
+
+func (s *LoadShedder) fromMember(ctx context.Context) bool {
+	s.Lock()
+	member := s.member
+	s.Unlock()
+	return member != nil && member(ctx)
+}
+
+// Forget stops accounting for the lateness of the chain, once it isn't produced by the node anymore
+func (s *LoadShedder) Forget(chain string) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	delete(s.chains, chain)
+}
+
+// Shed returns whether the requests of the given class must be shed, and after how long the clients should retry
+// them: the next round of the latest chain, by which the node may have caught up.
+func (s *LoadShedder) Shed(class TrafficClass) (bool, time.Duration) {
+	if s == nil || class == TrafficLatest {
+		return false, 0
+	}
+	s.Lock()
+	defer s.Unlock()
+
+	now := s.clock.Now()
+	var worst time.Duration
+	var retryAfter time.Duration
+	for _, c := range s.chains {
+		lateness := c.lateness
+		if stalled := now.Sub(c.nextDue); stalled > lateness {
+			lateness = stalled
+		}
+		if lateness > worst {
+			worst = lateness
+			retryAfter = c.period
+		}
+	}
+
+	limit := s.threshold
+	if class == TrafficRound {
+		limit *= 2
+	}
+	if worst <= limit {
+		return false, 0
+	}
+	metrics.ShedRequest(class.String())
+	return true, retryAfter
+}
+
+// RetryAfterSeconds formats the delay after which to retry a shed request in whole seconds, at least one
+func RetryAfterSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Max(1, math.Ceil(d.Seconds()))))
+}
+
+// UnaryServerInterceptor sheds the requests for a given round, the one at a given time or the one of a given
+// randomness, while the node is late
+func (s *LoadShedder) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	switch info.FullMethod {
+	case publicRandMethod:
+		if in, ok := req.(*drand.PublicRandRequest); !ok || in.GetRound() == 0 {
+			return handler(ctx, req)
+		}
+	case publicRandAtMethod, publicRandByRandomnessMethod:
+	default:
+		return handler(ctx, req)
+	}
+	if shed, retryAfter := s.Shed(TrafficRound); shed {
+		_ = grpc.SetTrailer(ctx, metadata.Pairs(RetryAfterKey, RetryAfterSeconds(retryAfter)))
+		return nil, status.Error(codes.Unavailable, "the node is shedding load, retry later")
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor sheds the syncs of the chain while the node is late, except the ones of the members of its
+// groups
+func (s *LoadShedder) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if info.FullMethod != syncChainMethod || s.fromMember(ss.Context()) {
+		return handler(srv, ss)
+	}
+	if shed, retryAfter := s.Shed(TrafficHistory); shed {
+		ss.SetTrailer(metadata.Pairs(RetryAfterKey, RetryAfterSeconds(retryAfter)))
+		return status.Error(codes.Unavailable, "the node is shedding load, retry later")
+	}
+	return handler(srv, ss)
+}
@@ -0,0 +1,126 @@
+package net
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	clock "github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+func TestLoadShedderClasses(t *testing.T) {
+	clk := clock.NewFakeClock()
+	s := NewLoadShedder(clk, time.Second)
+	period := 3 * time.Second
+
+	shed := func(class TrafficClass) bool {
+		shed, _ := s.Shed(class)
+		return shed
+	}
+
+	// on time
+	s.Observe("default", clk.Now(), clk.Now().Add(100*time.Millisecond), period)
+	require.False(t, shed(TrafficHistory))
+	require.False(t, shed(TrafficRound))
+
+	// late: the range queries go first
+	s.Observe("other", clk.Now(), clk.Now().Add(1500*time.Millisecond), period)
+	require.True(t, shed(TrafficHistory))
+	require.False(t, shed(TrafficRound))
+	require.False(t, shed(TrafficLatest))
+
+	// twice as late: the requests for a given round too, the latest ones are always served
+	s.Observe("other", clk.Now(), clk.Now().Add(2500*time.Millisecond), period)
+	require.True(t, shed(TrafficHistory))
+	require.True(t, shed(TrafficRound))
+	require.False(t, shed(TrafficLatest))
+	_, retryAfter := s.Shed(TrafficRound)
+	require.Equal(t, period, retryAfter)
+
+	// back on time
+	s.Forget("other")
+	require.False(t, shed(TrafficHistory))
+
+	// a chain stalling past its next round is as late as it stalls
+	clk.Advance(period + 2500*time.Millisecond)
+	require.True(t, shed(TrafficRound))
+
+	var nilShedder *LoadShedder
+	shedNil, _ := nilShedder.Shed(TrafficHistory)
+	require.False(t, shedNil)
+}
+
+func TestLoadShedderInterceptors(t *testing.T) {
+	clk := clock.NewFakeClock()
+	s := NewLoadShedder(clk, time.Second)
+	s.Observe("default", clk.Now(), clk.Now().Add(5*time.Second), 3*time.Second)
+
+	handled := false
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		handled = true
+		return &drand.PublicRandResponse{}, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: publicRandMethod}
+
+	_, err := s.UnaryServerInterceptor(context.Background(), &drand.PublicRandRequest{Round: 7}, info, handler)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.False(t, handled)
+
+	_, err = s.UnaryServerInterceptor(context.Background(), &drand.PublicRandRequest{}, info, handler)
+	require.NoError(t, err)
+	require.True(t, handled, "the latest round is always served")
+
+	handled = false
+	_, err = s.UnaryServerInterceptor(context.Background(), &drand.ChainInfoRequest{},
+		&grpc.UnaryServerInfo{FullMethod: "/drand.Public/ChainInfo"}, handler)
+	require.NoError(t, err)
+	require.True(t, handled)
+
+	require.Equal(t, "3", RetryAfterSeconds(3*time.Second))
+	require.Equal(t, "1", RetryAfterSeconds(0))
+}
+
+// syncStream is the server side of a sync, from the peer of its context
+type syncStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *syncStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *syncStream) SetTrailer(metadata.MD) {}
+
+func TestLoadShedderExemptsMembers(t *testing.T) {
+	clk := clock.NewFakeClock()
+	s := NewLoadShedder(clk, time.Second)
+	s.Observe("default", clk.Now(), clk.Now().Add(5*time.Second), 3*time.Second)
+
+	type peerKey struct{}
+	member := context.WithValue(context.Background(), peerKey{}, true)
+	s.ExemptMembers(func(ctx context.Context) bool {
+		return ctx.Value(peerKey{}) != nil
+	})
+
+	handled := false
+	handler := func(interface{}, grpc.ServerStream) error {
+		handled = true
+		return nil
+	}
+	info := &grpc.StreamServerInfo{FullMethod: syncChainMethod}
+
+	err := s.StreamServerInterceptor(nil, &syncStream{ctx: context.Background()}, info, handler)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.False(t, handled)
+
+	require.NoError(t, s.StreamServerInterceptor(nil, &syncStream{ctx: member}, info, handler))
+	require.True(t, handled, "the members catching up are never shed")
+}
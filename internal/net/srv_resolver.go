@@ -0,0 +1,122 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/resolver"
+
+	"github.com/drand/drand/v2/common/key"
+)
+
+// srvRefreshPeriod is how often the SRV records of a peer are resolved again, so that the connections follow
+// the changes of the targets behind its service name
+const srvRefreshPeriod = time.Minute
+
+func init() {
+	resolver.Register(&srvResolverBuilder{lookup: net.DefaultResolver.LookupSRV, refresh: srvRefreshPeriod})
+}
+
+// srvResolverBuilder resolves the node addresses of the form srv://<service name> to the host:port targets
+// listed in the DNS SRV records of the service name, by order of priority.
+type srvResolverBuilder struct {
+	lookup  func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+	refresh time.Duration
+}
+
+func (b *srvResolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	name := target.URL.Host
+	if name == "" {
+		name = target.Endpoint()
+	}
+	if name == "" {
+		return nil, errors.New("missing service name in SRV address")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &srvResolver{
+		name:       name,
+		cc:         cc,
+		lookup:     b.lookup,
+		resolveNow: make(chan struct{}, 1),
+		cancel:     cancel,
+	}
+	r.wg.Add(1)
+	go r.watch(ctx, b.refresh)
+	return r, nil
+}
+
+func (b *srvResolverBuilder) Scheme() string {
+	return key.SRVScheme
+}
+
+type srvResolver struct {
+	name       string
+	cc         resolver.ClientConn
+	lookup     func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+	resolveNow chan struct{}
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+}
+
+func (r *srvResolver) watch(ctx context.Context, refresh time.Duration) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(refresh)
+	defer ticker.Stop()
+	for {
+		r.resolve(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.resolveNow:
+		}
+	}
+}
+
+func (r *srvResolver) resolve(ctx context.Context) {
+	_, records, err := r.lookup(ctx, "", "", r.name)
+	if err != nil {
+		r.cc.ReportError(fmt.Errorf("resolving SRV records of %s: %w", r.name, err))
+		return
+	}
+	if len(records) == 0 {
+		r.cc.ReportError(fmt.Errorf("no SRV records for %s", r.name))
+		return
+	}
+
+	// the records are sorted by priority, and the targets are tried in that order
+	addrs := make([]resolver.Address, 0, len(records))
+	for _, rec := range records {
+		host := strings.TrimSuffix(rec.Target, ".")
+		addrs = append(addrs, resolver.Address{
+			Addr: net.JoinHostPort(host, strconv.Itoa(int(rec.Port))),
+			// the certificates are issued for the targets, not for the service name
+			ServerName: host,
+		})
+	}
+	if err := r.cc.UpdateState(resolver.State{Addresses: addrs}); err != nil {
+		r.cc.ReportError(err)
+	}
+}
+
+// ResolveNow is called by gRPC when a connection fails, to resolve the service name again without waiting
+func (r *srvResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.resolveNow <- struct{}{}:
+	default:
+	}
+}
+
+func (r *srvResolver) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
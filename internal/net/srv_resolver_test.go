@@ -0,0 +1,72 @@
+package net
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/resolver"
+)
+
+type fakeClientConn struct {
+	resolver.ClientConn
+	states chan resolver.State
+	errs   chan error
+}
+
+func (f *fakeClientConn) UpdateState(s resolver.State) error {
+	f.states <- s
+	return nil
+}
+
+func (f *fakeClientConn) ReportError(err error) {
+	f.errs <- err
+}
+
+func TestSRVResolverFollowsRecords(t *testing.T) {
+	var mu sync.Mutex
+	records := []*net.SRV{
+		{Target: "node1.example.org.", Port: 4444, Priority: 1},
+		{Target: "node2.example.org.", Port: 4445, Priority: 2},
+	}
+	lookup := func(_ context.Context, _, _, name string) (string, []*net.SRV, error) {
+		if name != "_drand._tcp.example.org" {
+			return "", nil, &net.DNSError{Err: "no such host", Name: name}
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		return "", records, nil
+	}
+
+	b := &srvResolverBuilder{lookup: lookup, refresh: time.Hour}
+	require.Equal(t, "srv", b.Scheme())
+	u, err := url.Parse("srv://_drand._tcp.example.org")
+	require.NoError(t, err)
+	cc := &fakeClientConn{states: make(chan resolver.State, 1), errs: make(chan error, 1)}
+	r, err := b.Build(resolver.Target{URL: *u}, cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+	defer r.Close()
+
+	state := <-cc.states
+	require.Equal(t, []resolver.Address{
+		{Addr: "node1.example.org:4444", ServerName: "node1.example.org"},
+		{Addr: "node2.example.org:4445", ServerName: "node2.example.org"},
+	}, state.Addresses)
+
+	mu.Lock()
+	records = []*net.SRV{{Target: "node3.example.org.", Port: 4446}}
+	mu.Unlock()
+	r.ResolveNow(resolver.ResolveNowOptions{})
+	state = <-cc.states
+	require.Equal(t, []resolver.Address{{Addr: "node3.example.org:4446", ServerName: "node3.example.org"}}, state.Addresses)
+
+	mu.Lock()
+	records = nil
+	mu.Unlock()
+	r.ResolveNow(resolver.ResolveNowOptions{})
+	require.Error(t, <-cc.errs)
+}
@@ -0,0 +1,98 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/v2/common/log"
+)
+
+// MaxUDPDatagram is the size of the largest query a UDPResponder reads, and of the largest reply it sends
+const MaxUDPDatagram = 1232
+
+// UDPHandler answers a query received by a UDPResponder with a single datagram, nil to not answer
+type UDPHandler func(ctx context.Context, query []byte) []byte
+
+// UDPResponder answers single-datagram queries with single-datagram replies, without any connection nor session.
+// A reply is never larger than its query, otherwise it is dropped: clients pad their queries to the size of the reply
+// they expect, so that spoofing the source of small queries can't turn the responder into an amplifier.
+// The queries are answered one at a time.
+type UDPResponder struct {
+	l       log.Logger
+	conn    net.PacketConn
+	handler UDPHandler
+	timeout time.Duration
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   sync.WaitGroup
+}
+
+// NewUDPResponder binds the given address, on which the queries are answered by the handler within the given
+// timeout once Start is called
+func NewUDPResponder(l log.Logger, addr string, timeout time.Duration, handler UDPHandler) (*UDPResponder, error) {
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &UDPResponder{
+		l:       l,
+		conn:    conn,
+		handler: handler,
+		timeout: timeout,
+		ctx:     ctx,
+		cancel:  cancel,
+	}, nil
+}
+
+// Addr returns the address the responder is bound to
+func (u *UDPResponder) Addr() string {
+	return u.conn.LocalAddr().String()
+}
+
+// Start answers the queries in the background until Stop is called
+func (u *UDPResponder) Start() {
+	u.done.Add(1)
+	go func() {
+		defer u.done.Done()
+		buf := make([]byte, MaxUDPDatagram)
+		for {
+			n, from, err := u.conn.ReadFrom(buf)
+			if errors.Is(err, net.ErrClosed) {
+				return
+			} else if err != nil {
+				u.l.Debugw("unable to read a UDP query", "err", err)
+				continue
+			}
+			u.answer(buf[:n], from)
+		}
+	}()
+}
+
+func (u *UDPResponder) answer(query []byte, from net.Addr) {
+	ctx, cancel := context.WithTimeout(u.ctx, u.timeout)
+	defer cancel()
+
+	reply := u.handler(ctx, query)
+	if reply == nil {
+		return
+	}
+	if len(reply) > len(query) {
+		u.l.Debugw("dropping a UDP reply larger than its query", "from", from, "query", len(query), "reply", len(reply))
+		return
+	}
+	if _, err := u.conn.WriteTo(reply, from); err != nil {
+		u.l.Debugw("unable to send a UDP reply", "to", from, "err", err)
+	}
+}
+
+// Stop stops answering the queries and releases the address
+func (u *UDPResponder) Stop() {
+	u.cancel()
+	_ = u.conn.Close()
+	u.done.Wait()
+}
@@ -0,0 +1,52 @@
+package net
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/drand/drand/v2/common/testlogger"
+)
+
+func TestUDPResponder(t *testing.T) {
+	reply := []byte("the latest beacon")
+	u, err := NewUDPResponder(testlogger.New(t), "127.0.0.1:0", time.Second, func(_ context.Context, query []byte) []byte {
+		if bytes.HasPrefix(query, []byte("ignored")) {
+			return nil
+		}
+		return reply
+	})
+	require.NoError(t, err)
+	u.Start()
+	defer u.Stop()
+
+	conn, err := net.Dial("udp", u.Addr())
+	require.NoError(t, err)
+	defer conn.Close()
+	query := func(q []byte) ([]byte, error) {
+		if _, err := conn.Write(q); err != nil {
+			return nil, err
+		}
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(200*time.Millisecond)))
+		buf := make([]byte, MaxUDPDatagram)
+		n, err := conn.Read(buf)
+		return buf[:n], err
+	}
+
+	resp, err := query(append([]byte("latest"), make([]byte, 64)...))
+	require.NoError(t, err)
+	require.Equal(t, reply, resp)
+
+	// a reply larger than the query would amplify it
+	_, err = query([]byte("latest"))
+	require.True(t, errors.Is(err, os.ErrDeadlineExceeded))
+
+	_, err = query(append([]byte("ignored"), make([]byte, 64)...))
+	require.True(t, errors.Is(err, os.ErrDeadlineExceeded))
+}
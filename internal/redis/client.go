@@ -0,0 +1,121 @@
+// Package redis gives access, through go-redis, to the few commands drand needs to share state between the instances
+// serving the public API behind a load balancer.
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// DefaultTimeout bounds each command sent to Redis, so that a slow Redis doesn't slow down the requests it serves
+const DefaultTimeout = 500 * time.Millisecond
+
+// maxIdleConns is how many connections are kept open between the commands
+const maxIdleConns = 8
+
+// Options are the parameters to reach Redis at
+type Options struct {
+	Addr     string
+	Password string
+	DB       int
+	Timeout  time.Duration
+}
+
+// ParseURL parses the options given as redis://[:password@]host[:port][/db]
+func ParseURL(rawURL string) (Options, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Options{}, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+	if u.Scheme != "redis" {
+		return Options{}, fmt.Errorf("invalid Redis URL %q: the scheme must be redis", u.Redacted())
+	}
+	if u.Hostname() == "" {
+		return Options{}, fmt.Errorf("invalid Redis URL %q: no host", u.Redacted())
+	}
+	opts := Options{Addr: u.Host, Timeout: DefaultTimeout}
+	if u.Port() == "" {
+		opts.Addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.User != nil {
+		opts.Password, _ = u.User.Password()
+	}
+	if db := strings.Trim(u.Path, "/"); db != "" {
+		if opts.DB, err = strconv.Atoi(db); err != nil || opts.DB < 0 {
+			return Options{}, fmt.Errorf("invalid Redis URL %q: invalid database %q", u.Redacted(), db)
+		}
+	}
+	return opts, nil
+}
+
+// incrScript increments a counter and sets its expiry when it is created, atomically, so that a counter can't be left
+// without an expiry
+var incrScript = goredis.NewScript(`
+local n = redis.call("INCR", KEYS[1])
+if n == 1 and tonumber(ARGV[1]) > 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return n
+`)
+
+// Client sends commands to Redis over a small pool of connections. It is safe for concurrent use.
+type Client struct {
+	rdb *goredis.Client
+}
+
+// New returns a client of the Redis of the options. The connections are only opened when the commands are sent.
+func New(opts Options) *Client {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	return &Client{rdb: goredis.NewClient(&goredis.Options{
+		Addr:         opts.Addr,
+		Password:     opts.Password,
+		DB:           opts.DB,
+		DialTimeout:  opts.Timeout,
+		ReadTimeout:  opts.Timeout,
+		WriteTimeout: opts.Timeout,
+		MaxIdleConns: maxIdleConns,
+		// the commands used are the same in both versions of the protocol, the older one works with any Redis
+		Protocol:         2,
+		DisableIndentity: true,
+	})}
+}
+
+// Get returns the value of the key, nil if there is none
+func (c *Client) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.rdb.Get(ctx, key).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return nil, nil
+	}
+	return value, err
+}
+
+// Set sets the value of the key, expiring after the ttl if it isn't zero
+func (c *Client) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.rdb.Set(ctx, key, value, ttl).Err()
+}
+
+// SetNX sets the value of the key if it isn't set yet, expiring after the ttl if it isn't zero. It returns whether
+// the value was set.
+func (c *Client) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	return c.rdb.SetNX(ctx, key, value, ttl).Result()
+}
+
+// Incr increments the counter of the key and returns its value. A new counter expires after the ttl.
+func (c *Client) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	return incrScript.Run(ctx, c.rdb, []string{key}, ttl.Milliseconds()).Int64()
+}
+
+// Close closes the connections to Redis. The client can't be used anymore.
+func (c *Client) Close() error {
+	return c.rdb.Close()
+}
@@ -0,0 +1,69 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseURL(t *testing.T) {
+	opts, err := ParseURL("redis://:s3cr3t@cache.internal/2")
+	require.NoError(t, err)
+	require.Equal(t, Options{Addr: "cache.internal:6379", Password: "s3cr3t", DB: 2, Timeout: DefaultTimeout}, opts)
+
+	opts, err = ParseURL("redis://127.0.0.1:7000")
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:7000", opts.Addr)
+
+	for _, u := range []string{"http://cache:6379", "redis://", "redis://cache/db", "redis://cache/-1"} {
+		_, err := ParseURL(u)
+		require.Error(t, err, u)
+	}
+}
+
+func TestClient(t *testing.T) {
+	m := miniredis.RunT(t)
+	m.RequireAuth("s3cr3t")
+	ctx := context.Background()
+
+	c := New(Options{Addr: m.Addr(), Password: "wrong"})
+	_, err := c.Get(ctx, "key")
+	require.Error(t, err)
+	c.Close()
+
+	c = New(Options{Addr: m.Addr(), Password: "s3cr3t", DB: 1})
+	defer c.Close()
+
+	value, err := c.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	require.NoError(t, c.Set(ctx, "key", []byte("value\r\nwith a new line"), 3*time.Second))
+	value, err = c.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, "value\r\nwith a new line", string(value))
+
+	set, err := c.SetNX(ctx, "key", []byte("other"), time.Second)
+	require.NoError(t, err)
+	require.False(t, set)
+	set, err = c.SetNX(ctx, "new", []byte("other"), time.Second)
+	require.NoError(t, err)
+	require.True(t, set)
+
+	for i := int64(1); i <= 3; i++ {
+		n, err := c.Incr(ctx, "counter", time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, i, n)
+	}
+	m.Select(1)
+	require.Equal(t, 3*time.Second, m.TTL("key"))
+	// the expiry of a counter is set when it is created, and not extended by the increments
+	require.Equal(t, time.Minute, m.TTL("counter"))
+	m.FastForward(time.Minute)
+	n, err := c.Incr(ctx, "counter", time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+}
@@ -0,0 +1,129 @@
+// Package secrets resolves the references to environment variables and to secret managers found in configuration
+// values, so that passwords and tokens don't have to be written in plaintext in the config files.
+//
+// A value can contain ${NAME} references to environment variables, expanded in place, or be a whole
+// secretref://<provider>/<path> reference, replaced by the secret the provider returns for the path.
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// RefPrefix is the prefix of the values referencing a secret held by a provider
+const RefPrefix = "secretref://"
+
+// Provider returns the secrets of a secret manager
+type Provider interface {
+	// Secret returns the secret at the given path, which is everything after secretref://<provider>/
+	Secret(ctx context.Context, path string) (string, error)
+}
+
+// ProviderFunc turns a function into a Provider
+type ProviderFunc func(ctx context.Context, path string) (string, error)
+
+// Secret calls the function
+func (f ProviderFunc) Secret(ctx context.Context, path string) (string, error) {
+	return f(ctx, path)
+}
+
+var (
+	providersLock sync.RWMutex
+	providers     = map[string]Provider{
+		"env":   ProviderFunc(envSecret),
+		"file":  ProviderFunc(fileSecret),
+		"vault": NewVaultProvider(nil),
+	}
+)
+
+// Register makes the provider available under the given name, replacing the one registered under that name if any
+func Register(name string, p Provider) {
+	providersLock.Lock()
+	defer providersLock.Unlock()
+	providers[name] = p
+}
+
+// Providers returns the names of the registered providers, sorted
+func Providers() []string {
+	providersLock.RLock()
+	defer providersLock.RUnlock()
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// envRef matches the ${NAME} references to environment variables, a $ alone being kept as is
+var envRef = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// IsReference returns whether the value references an environment variable or a secret
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, RefPrefix) || envRef.MatchString(value)
+}
+
+// Resolve returns the value with its references resolved. Referencing an environment variable which isn't set or a
+// secret which can't be fetched is an error rather than an empty value, so that a daemon doesn't start with an empty
+// password.
+func Resolve(ctx context.Context, value string) (string, error) {
+	if strings.HasPrefix(value, RefPrefix) {
+		return resolveRef(ctx, strings.TrimPrefix(value, RefPrefix))
+	}
+
+	var err error
+	resolved := envRef.ReplaceAllStringFunc(value, func(ref string) string {
+		name := envRef.FindStringSubmatch(ref)[1]
+		v, ok := os.LookupEnv(name)
+		if !ok && err == nil {
+			err = fmt.Errorf("environment variable %s isn't set", name)
+		}
+		return v
+	})
+	if err != nil {
+		return "", err
+	}
+	return resolved, nil
+}
+
+func resolveRef(ctx context.Context, ref string) (string, error) {
+	name, path, ok := strings.Cut(ref, "/")
+	if !ok || path == "" {
+		return "", fmt.Errorf("invalid secret reference %s%s, expected %s<provider>/<path>", RefPrefix, ref, RefPrefix)
+	}
+	providersLock.RLock()
+	p, ok := providers[name]
+	providersLock.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("unknown secret provider %q, known ones are %s", name, strings.Join(Providers(), ", "))
+	}
+	secret, err := p.Secret(ctx, path)
+	if err != nil {
+		return "", fmt.Errorf("unable to get the secret %s%s: %w", RefPrefix, ref, err)
+	}
+	return secret, nil
+}
+
+// envSecret returns the environment variable of the given name, for secretref://env/NAME
+func envSecret(_ context.Context, name string) (string, error) {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s isn't set", name)
+	}
+	return v, nil
+}
+
+// fileSecret returns the content of the file at the given absolute path without its trailing newline, for
+// secretref://file//run/secrets/name as mounted by docker or kubernetes
+func fileSecret(_ context.Context, path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
@@ -0,0 +1,100 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// The environment variables configuring the access to vault, the same as the ones of the vault CLI
+const (
+	VaultAddrEnv      = "VAULT_ADDR"
+	VaultTokenEnv     = "VAULT_TOKEN"
+	VaultNamespaceEnv = "VAULT_NAMESPACE"
+)
+
+const vaultTimeout = 10 * time.Second
+
+// vaultProvider reads the secrets from the key/value engine of a HashiCorp Vault server, as
+// secretref://vault/<mount>/data/<secret>#<field> for the version 2 of the engine or
+// secretref://vault/<mount>/<secret>#<field> for the version 1.
+type vaultProvider struct {
+	client *http.Client
+}
+
+// NewVaultProvider returns a provider reading the secrets from the vault server at $VAULT_ADDR with the token in
+// $VAULT_TOKEN, using the given client or a default one if it's nil.
+func NewVaultProvider(client *http.Client) Provider {
+	if client == nil {
+		client = &http.Client{Timeout: vaultTimeout}
+	}
+	return &vaultProvider{client: client}
+}
+
+func (v *vaultProvider) Secret(ctx context.Context, path string) (string, error) {
+	secret, field, ok := strings.Cut(path, "#")
+	if !ok || field == "" {
+		return "", errors.New("the field of the vault secret is missing, use <path>#<field>")
+	}
+	addr := os.Getenv(VaultAddrEnv)
+	if addr == "" {
+		return "", fmt.Errorf("%s isn't set", VaultAddrEnv)
+	}
+	token := os.Getenv(VaultTokenEnv)
+	if token == "" {
+		return "", fmt.Errorf("%s isn't set", VaultTokenEnv)
+	}
+
+	url := strings.TrimRight(addr, "/") + "/v1/" + strings.TrimLeft(secret, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if ns := os.Getenv(VaultNamespaceEnv); ns != "" {
+		req.Header.Set("X-Vault-Namespace", ns)
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		// the body of the errors of vault never contains the secret
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("vault returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var content struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&content); err != nil {
+		return "", fmt.Errorf("invalid response from vault: %w", err)
+	}
+	fields := content.Data
+	// the version 2 of the key/value engine nests the fields with the metadata of the secret
+	if nested, ok := fields["data"]; ok {
+		if _, isMeta := fields["metadata"]; isMeta {
+			fields = nil
+			if err := json.Unmarshal(nested, &fields); err != nil {
+				return "", fmt.Errorf("invalid response from vault: %w", err)
+			}
+		}
+	}
+	raw, ok := fields[field]
+	if !ok {
+		return "", fmt.Errorf("no field %q in the vault secret %s", field, secret)
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		// numbers and booleans are given as they are
+		return string(raw), nil
+	}
+	return value, nil
+}
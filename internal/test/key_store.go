@@ -60,3 +60,8 @@ func (k *KeyStore) Reset() error {
 	k.share = nil
 	return nil
 }
+
+func (k *KeyStore) WipeShare() error {
+	k.share = nil
+	return nil
+}
@@ -97,6 +97,10 @@ func (s *EmptyServer) LoadBeacon(context.Context, *drand.LoadBeaconRequest) (*dr
 	return nil, nil
 }
 
+func (s *EmptyServer) RemoveBeaconID(_ context.Context, _ *drand.RemoveBeaconIDRequest) (*drand.RemoveBeaconIDResponse, error) {
+	return nil, nil
+}
+
 // RemoteStatus is an empty implementation
 func (s *EmptyServer) RemoteStatus(context.Context, *drand.RemoteStatusRequest) (*drand.RemoteStatusResponse, error) {
 	return nil, nil
@@ -129,6 +133,210 @@ func (s *EmptyServer) DKGStatus(_ context.Context, _ *pdkg.DKGStatusRequest) (*p
 	return nil, nil
 }
 
+func (s *EmptyServer) GroupHistory(_ context.Context, _ *pdkg.GroupHistoryRequest) (*pdkg.GroupHistoryResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) GroupForRound(_ context.Context, _ *pdkg.GroupForRoundRequest) (*pdkg.GroupForRoundResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) GroupBuildInfo(_ context.Context, _ *drand.GroupBuildInfoRequest) (*drand.GroupBuildInfoResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) GroupDST(_ context.Context, _ *drand.GroupDSTRequest) (*drand.GroupDSTResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) RotateControlToken(_ context.Context, _ *drand.RotateControlTokenRequest) (*drand.RotateControlTokenResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) ReloadConfig(_ context.Context, _ *drand.ReloadConfigRequest) (*drand.ReloadConfigResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) ProposeUpgrade(_ context.Context, _ *drand.UpgradeProposal) (*drand.Empty, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) AcknowledgeUpgrade(_ context.Context, _ *drand.UpgradeAcknowledgement) (*drand.Empty, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) PartialHeartbeat(_ context.Context, _ *drand.PartialHeartbeatPacket) (*drand.Empty, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) Heartbeat(_ context.Context, _ *drand.HeartbeatRequest) (*drand.HeartbeatPacket, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) PartialSubBeacon(_ context.Context, _ *drand.PartialSubBeaconPacket) (*drand.Empty, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) PushBeacon(_ context.Context, _ *drand.PushedBeacon) (*drand.PushBeaconAck, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) GroupMembership(_ context.Context, _ *drand.GroupMembershipRequest) (*drand.GroupMembershipResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) ChainSummary(_ context.Context, _ *drand.ChainSummaryRequest) (*drand.ChainSummaryResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) Home(_ context.Context, _ *drand.HomeRequest) (*drand.HomeResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) SubBeacons(_ context.Context, _ *drand.SubBeaconsRequest) (*drand.SubBeaconsResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) DerivedChains(_ context.Context, _ *drand.DerivedChainsRequest) (*drand.DerivedChainsResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) DerivedRand(_ context.Context, _ *drand.DerivedRandRequest) (*drand.DerivedRandResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) AnnounceLeave(_ context.Context, _ *drand.LeaveAnnouncement) (*drand.Empty, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) AnnounceAddress(_ context.Context, _ *drand.AddressAnnouncement) (*drand.Empty, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) AnnounceReshareForecast(_ context.Context, _ *drand.ReshareForecastAnnouncement) (*drand.Empty, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) Leave(_ context.Context, _ *drand.LeaveRequest) (*drand.LeaveStatus, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) Snapshot(_ context.Context, _ *drand.SnapshotRequest) (*drand.SnapshotResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) RandomnessStats(_ context.Context, _ *drand.RandomnessStatsRequest) (*drand.RandomnessStatsResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) Notarize(_ context.Context, _ *drand.NotarizeRequest) (*drand.NotarizationBundle, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) MakeJoinKit(_ context.Context, _ *drand.JoinKitRequest) (*drand.JoinKit, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) ListMetrics(_ context.Context, _ *drand.ListMetricsRequest) (*drand.ListMetricsResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) FeatureFlags(_ context.Context, _ *drand.FeatureFlagsRequest) (*drand.FeatureFlagsResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) AvailabilityReport(_ context.Context, _ *drand.AvailabilityReportRequest) (*drand.AvailabilityReportResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) PartialAudit(_ context.Context, _ *drand.PartialAuditRequest) (*drand.PartialAuditResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) InjectBeacon(_ context.Context, _ *drand.InjectBeaconRequest) (*drand.InjectBeaconResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) StoreMetadata(_ context.Context, _ *drand.StoreMetadataRequest) (*drand.StoreMetadataResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) GetCapabilities(_ context.Context, _ *drand.CapabilitiesRequest) (*drand.Capabilities, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) PublicRandAt(_ context.Context, _ *drand.PublicRandAtRequest) (*drand.PublicRandResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) Tombstones(_ context.Context, _ *drand.TombstonesRequest) (*drand.TombstonesResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) PauseBeacon(_ context.Context, _ *drand.PauseBeaconRequest) (*drand.PauseStatus, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) ResumeBeacon(_ context.Context, _ *drand.ResumeBeaconRequest) (*drand.PauseStatus, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) Incident(_ context.Context, _ *drand.IncidentRequest) (*drand.IncidentResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) UpdateAddress(_ context.Context, _ *drand.UpdateAddressRequest) (*drand.UpdateAddressResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) AddressOverrides(_ context.Context, _ *drand.AddressOverridesRequest) (*drand.AddressOverridesResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) LogLevels(_ context.Context, _ *drand.LogLevelsRequest) (*drand.LogLevelsResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) ForecastReshare(_ context.Context, _ *drand.ForecastReshareRequest) (*drand.ForecastReshareResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) APIUsage(_ context.Context, _ *drand.APIUsageRequest) (*drand.APIUsageResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) PublicRandByRandomness(_ context.Context, _ *drand.PublicRandByRandomnessRequest) (*drand.PublicRandResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) RoundAnnotations(_ context.Context, _ *drand.RoundAnnotationsRequest) (*drand.RoundAnnotationsResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) Countdown(_ context.Context, _ *drand.CountdownRequest) (*drand.CountdownResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) StatusStream(_ *drand.StatusStreamRequest, _ drand.Control_StatusStreamServer) error {
+	return nil
+}
+
+func (s *EmptyServer) EntropyStream(_ *drand.EntropyStreamRequest, _ drand.Control_EntropyStreamServer) error {
+	return nil
+}
+
+func (s *EmptyServer) RoundMessage(_ context.Context, _ *drand.RoundMessageRequest) (*drand.RoundMessageResponse, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) StartUpgrade(_ context.Context, _ *drand.StartUpgradeRequest) (*drand.UpgradeStatus, error) {
+	return nil, nil
+}
+
+func (s *EmptyServer) AcceptUpgrade(_ context.Context, _ *drand.AcceptUpgradeRequest) (*drand.UpgradeStatus, error) {
+	return nil, nil
+}
+
 func (s *EmptyServer) Migrate(_ context.Context, _ *drand.Empty) (*drand.Empty, error) {
 	return nil, nil
 }
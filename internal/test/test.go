@@ -144,7 +144,7 @@ func BatchIdentities(t *testing.T, n int, sch *crypto.Scheme, beaconID string) (
 	}
 
 	dp := &key.DistPublic{Coefficients: dpub}
-	group := key.LoadGroup(ListFromPrivates(privs), 1, dp, 30*time.Second, 0, sch, beaconID)
+	group := key.LoadGroup(ListFromPrivates(privs), 1, dp, 30*time.Second, 0, sch, commonutils.BeaconID(beaconID))
 	group.Threshold = thr
 	return privs, group
 }
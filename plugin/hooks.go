@@ -0,0 +1,106 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Stage is a step of the shutdown of the daemon, at which the hooks registered for it run
+type Stage string
+
+// The stages of the shutdown, in the order they are reached with the default shutdown order of the daemon
+const (
+	// StagePreStop is reached before anything is stopped, while the node still produces and serves the beacons, e.g.
+	// for a publisher to flush its queue while the chain store is still open
+	StagePreStop Stage = "pre-stop"
+	// StagePostStoreFlush is reached once the beacon processes stopped and their chain stores are flushed and closed
+	StagePostStoreFlush Stage = "post-store-flush"
+	// StagePostListenerClose is reached once the listeners of the public and private APIs are closed
+	StagePostListenerClose Stage = "post-listener-close"
+)
+
+// Hook runs at a stage of the shutdown of the daemon. Its error is logged, it doesn't stop the shutdown.
+type Hook func(ctx context.Context) error
+
+var hooks = map[Stage]*registry[Hook]{
+	StagePreStop:           newRegistry[Hook]("pre-stop hook"),
+	StagePostStoreFlush:    newRegistry[Hook]("post-store-flush hook"),
+	StagePostListenerClose: newRegistry[Hook]("post-listener-close hook"),
+}
+
+// ValidateStage checks that the stage is one of the stages of the shutdown
+func ValidateStage(stage Stage) error {
+	if _, ok := hooks[stage]; !ok {
+		return fmt.Errorf("unknown shutdown stage %q, expected %s, %s or %s", stage,
+			StagePreStop, StagePostStoreFlush, StagePostListenerClose)
+	}
+	return nil
+}
+
+// RegisterHook makes the hook run at the given stage of the shutdown of the daemon. The hooks of a stage run in the
+// order of their names.
+func RegisterHook(stage Stage, name string, hook Hook) {
+	if err := ValidateStage(stage); err != nil {
+		panic("plugin: " + err.Error())
+	}
+	hooks[stage].register(name, hook)
+}
+
+// LookupHook returns the hook registered under the given name for the stage
+func LookupHook(stage Stage, name string) (Hook, bool) {
+	r, ok := hooks[stage]
+	if !ok {
+		return nil, false
+	}
+	return r.lookup(name)
+}
+
+// Hooks returns the names of the hooks registered for the stage, sorted
+func Hooks(stage Stage) []string {
+	r, ok := hooks[stage]
+	if !ok {
+		return nil
+	}
+	return r.names()
+}
+
+// NamedHook is a hook of a library embedder with the name it is logged under
+type NamedHook struct {
+	Name string
+	Hook Hook
+}
+
+// ShutdownHooks are the hooks of a library embedder, which run on its own daemon only, after the ones registered for
+// the stage with RegisterHook. The zero value is ready to use.
+type ShutdownHooks struct {
+	sync.Mutex
+	stages map[Stage][]NamedHook
+}
+
+// Add makes the hook run at the given stage of the shutdown of the daemon, after the hooks added before it
+func (s *ShutdownHooks) Add(stage Stage, name string, hook Hook) error {
+	if err := ValidateStage(stage); err != nil {
+		return err
+	}
+	if hook == nil {
+		return fmt.Errorf("shutdown hook %q is nil", name)
+	}
+	s.Lock()
+	defer s.Unlock()
+	if s.stages == nil {
+		s.stages = make(map[Stage][]NamedHook)
+	}
+	s.stages[stage] = append(s.stages[stage], NamedHook{Name: name, Hook: hook})
+	return nil
+}
+
+// Hooks returns the hooks added for the stage, in the order they were added
+func (s *ShutdownHooks) Hooks(stage Stage) []NamedHook {
+	if s == nil {
+		return nil
+	}
+	s.Lock()
+	defer s.Unlock()
+	return append([]NamedHook(nil), s.stages[stage]...)
+}
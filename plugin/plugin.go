@@ -0,0 +1,146 @@
+// Package plugin registers the extensions compiled into the drand binary: the drivers of the chain stores, the
+// signers of the partials, the publishers of the beacons and the hooks run during the shutdown of the daemon. An
+// extension registers itself from the init function of its package, which the main package imports for its side
+// effects only, so that a fork adds an integration without patching the core of the node:
+//
+//	import _ "github.com/drand/drand/v2/plugin/s3publisher"
+//
+// The extensions are then selected by name in the configuration of the daemon.
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/drand/drand/v2/common"
+	public "github.com/drand/drand/v2/common/chain"
+	"github.com/drand/drand/v2/common/log"
+	"github.com/drand/drand/v2/crypto/vault"
+)
+
+// The built-in storage engines, which the store drivers can't be registered as
+const (
+	builtinBolt     = "bolt"
+	builtinPostgres = "postgres"
+	builtinMemDB    = "memdb"
+)
+
+// StoreDriver opens the chain store of a beacon. The folder is the one the node dedicates to the beacon, in which
+// the driver may keep its files.
+type StoreDriver func(ctx context.Context, l log.Logger, beaconID common.BeaconID, folder string) (public.Store, error)
+
+// SignerFactory returns the signer of the partials of a beacon, which signs them in place of the share of the node,
+// e.g. with a key kept by a hardware module.
+type SignerFactory func(l log.Logger, beaconID common.BeaconID) (vault.PartialSigner, error)
+
+// Publisher publishes each new beacon of a chain, once stored, to a system outside of drand
+type Publisher interface {
+	// Publish publishes the beacon. A failure is logged, the beacon isn't published again.
+	Publish(ctx context.Context, b *common.Beacon) error
+	// Close releases the resources of the publisher, once the beacon process stops
+	Close() error
+}
+
+// PublisherFactory returns the publisher of the beacons of the given chain
+type PublisherFactory func(l log.Logger, beaconID common.BeaconID, info *public.Info) (Publisher, error)
+
+// registry maps the names of the extensions of a kind to their constructors
+type registry[T any] struct {
+	sync.RWMutex
+	kind    string
+	entries map[string]T
+}
+
+func newRegistry[T any](kind string) *registry[T] {
+	return &registry[T]{kind: kind, entries: make(map[string]T)}
+}
+
+// register panics on an empty or duplicate name, like the drivers of database/sql, since it is a programming error
+// caught on startup
+func (r *registry[T]) register(name string, entry T, reserved ...string) {
+	r.Lock()
+	defer r.Unlock()
+	if name == "" {
+		panic(fmt.Sprintf("plugin: %s registered without a name", r.kind))
+	}
+	for _, res := range reserved {
+		if name == res {
+			panic(fmt.Sprintf("plugin: %s %q is built in", r.kind, name))
+		}
+	}
+	if _, exists := r.entries[name]; exists {
+		panic(fmt.Sprintf("plugin: %s %q registered twice", r.kind, name))
+	}
+	r.entries[name] = entry
+}
+
+func (r *registry[T]) lookup(name string) (T, bool) {
+	r.RLock()
+	defer r.RUnlock()
+	entry, ok := r.entries[name]
+	return entry, ok
+}
+
+func (r *registry[T]) names() []string {
+	r.RLock()
+	defer r.RUnlock()
+	names := make([]string, 0, len(r.entries))
+	for name := range r.entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var (
+	stores     = newRegistry[StoreDriver]("store driver")
+	signers    = newRegistry[SignerFactory]("signer")
+	publishers = newRegistry[PublisherFactory]("publisher")
+)
+
+// RegisterStore makes a store driver available under the given name, which can't be the one of a built-in engine
+func RegisterStore(name string, driver StoreDriver) {
+	stores.register(name, driver, builtinBolt, builtinPostgres, builtinMemDB)
+}
+
+// LookupStore returns the store driver registered under the given name
+func LookupStore(name string) (StoreDriver, bool) {
+	return stores.lookup(name)
+}
+
+// Stores returns the names of the store drivers registered, sorted
+func Stores() []string {
+	return stores.names()
+}
+
+// RegisterSigner makes a signer available under the given name
+func RegisterSigner(name string, factory SignerFactory) {
+	signers.register(name, factory)
+}
+
+// LookupSigner returns the signer registered under the given name
+func LookupSigner(name string) (SignerFactory, bool) {
+	return signers.lookup(name)
+}
+
+// Signers returns the names of the signers registered, sorted
+func Signers() []string {
+	return signers.names()
+}
+
+// RegisterPublisher makes a publisher available under the given name
+func RegisterPublisher(name string, factory PublisherFactory) {
+	publishers.register(name, factory)
+}
+
+// LookupPublisher returns the publisher registered under the given name
+func LookupPublisher(name string) (PublisherFactory, bool) {
+	return publishers.lookup(name)
+}
+
+// Publishers returns the names of the publishers registered, sorted
+func Publishers() []string {
+	return publishers.names()
+}
@@ -0,0 +1,83 @@
+package plugin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/drand/drand/v2/common"
+	public "github.com/drand/drand/v2/common/chain"
+	"github.com/drand/drand/v2/common/log"
+	"github.com/drand/drand/v2/internal/chain"
+	"github.com/drand/drand/v2/internal/chain/memdb"
+)
+
+type nopPublisher struct{}
+
+func (nopPublisher) Publish(context.Context, *common.Beacon) error { return nil }
+func (nopPublisher) Close() error                                  { return nil }
+
+func TestRegistry(t *testing.T) {
+	RegisterStore("test-store", func(context.Context, log.Logger, common.BeaconID, string) (chain.Store, error) {
+		return memdb.NewStore(10), nil
+	})
+	RegisterPublisher("test-b", func(log.Logger, common.BeaconID, *public.Info) (Publisher, error) {
+		return nopPublisher{}, nil
+	})
+	RegisterPublisher("test-a", func(log.Logger, common.BeaconID, *public.Info) (Publisher, error) {
+		return nopPublisher{}, nil
+	})
+
+	driver, ok := LookupStore("test-store")
+	require.True(t, ok)
+	store, err := driver(context.Background(), nil, "default", t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+	_, ok = LookupSigner("test-store")
+	require.False(t, ok, "each kind of extension has its own names")
+
+	require.Equal(t, []string{"test-a", "test-b"}, Publishers())
+
+	require.Panics(t, func() {
+		RegisterPublisher("test-a", func(log.Logger, common.BeaconID, *public.Info) (Publisher, error) { return nil, nil })
+	}, "a name is registered once")
+	require.Panics(t, func() {
+		RegisterStore(string(chain.BoltDB), func(context.Context, log.Logger, common.BeaconID, string) (chain.Store, error) {
+			return nil, nil
+		})
+	}, "the built-in engines can't be replaced")
+	require.Panics(t, func() {
+		RegisterSigner("", nil)
+	})
+	require.Equal(t, []string{builtinBolt, builtinPostgres, builtinMemDB},
+		[]string{string(chain.BoltDB), string(chain.PostgreSQL), string(chain.MemDB)})
+}
+
+func TestHooks(t *testing.T) {
+	var ran []string
+	for _, name := range []string{"test-flush", "test-audit"} {
+		name := name
+		RegisterHook(StagePreStop, name, func(context.Context) error {
+			ran = append(ran, name)
+			return nil
+		})
+	}
+
+	require.Equal(t, []string{"test-audit", "test-flush"}, Hooks(StagePreStop))
+	require.Empty(t, Hooks(StagePostStoreFlush), "each stage has its own hooks")
+	for _, name := range Hooks(StagePreStop) {
+		hook, ok := LookupHook(StagePreStop, name)
+		require.True(t, ok)
+		require.NoError(t, hook(context.Background()))
+	}
+	require.Equal(t, []string{"test-audit", "test-flush"}, ran)
+
+	require.Error(t, ValidateStage("pre-start"))
+	require.Panics(t, func() {
+		RegisterHook("pre-start", "test-hook", func(context.Context) error { return nil })
+	})
+	require.Panics(t, func() {
+		RegisterHook(StagePreStop, "test-flush", func(context.Context) error { return nil })
+	}, "a name is registered once per stage")
+}
@@ -74,6 +74,7 @@ type DKGCommand struct {
 	//	*DKGCommand_Reject
 	//	*DKGCommand_Execute
 	//	*DKGCommand_Abort
+	//	*DKGCommand_Refresh
 	Command isDKGCommand_Command `protobuf_oneof:"Command"`
 }
 
@@ -172,6 +173,13 @@ func (x *DKGCommand) GetAbort() *AbortOptions {
 	return nil
 }
 
+func (x *DKGCommand) GetRefresh() *RefreshOptions {
+	if x, ok := x.GetCommand().(*DKGCommand_Refresh); ok {
+		return x.Refresh
+	}
+	return nil
+}
+
 type isDKGCommand_Command interface {
 	isDKGCommand_Command()
 }
@@ -204,6 +212,10 @@ type DKGCommand_Abort struct {
 	Abort *AbortOptions `protobuf:"bytes,9,opt,name=abort,proto3,oneof"`
 }
 
+type DKGCommand_Refresh struct {
+	Refresh *RefreshOptions `protobuf:"bytes,10,opt,name=refresh,proto3,oneof"`
+}
+
 func (*DKGCommand_Initial) isDKGCommand_Command() {}
 
 func (*DKGCommand_Resharing) isDKGCommand_Command() {}
@@ -218,6 +230,8 @@ func (*DKGCommand_Execute) isDKGCommand_Command() {}
 
 func (*DKGCommand_Abort) isDKGCommand_Command() {}
 
+func (*DKGCommand_Refresh) isDKGCommand_Command() {}
+
 type CommandMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -693,6 +707,54 @@ func (*AbortOptions) Descriptor() ([]byte, []int) {
 	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{7}
 }
 
+// RefreshOptions re-randomizes the shares of the current group, keeping its members, threshold and public key
+type RefreshOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timeout *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (x *RefreshOptions) Reset() {
+	*x = RefreshOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dkg_dkg_control_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RefreshOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshOptions) ProtoMessage() {}
+
+func (x *RefreshOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_dkg_dkg_control_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshOptions.ProtoReflect.Descriptor instead.
+func (*RefreshOptions) Descriptor() ([]byte, []int) {
+	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RefreshOptions) GetTimeout() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
 type ExecutionOptions struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -702,7 +764,7 @@ type ExecutionOptions struct {
 func (x *ExecutionOptions) Reset() {
 	*x = ExecutionOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dkg_dkg_control_proto_msgTypes[8]
+		mi := &file_dkg_dkg_control_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -715,7 +777,7 @@ func (x *ExecutionOptions) String() string {
 func (*ExecutionOptions) ProtoMessage() {}
 
 func (x *ExecutionOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_dkg_dkg_control_proto_msgTypes[8]
+	mi := &file_dkg_dkg_control_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -728,7 +790,7 @@ func (x *ExecutionOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecutionOptions.ProtoReflect.Descriptor instead.
 func (*ExecutionOptions) Descriptor() ([]byte, []int) {
-	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{8}
+	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{9}
 }
 
 type JoinOptions struct {
@@ -742,7 +804,7 @@ type JoinOptions struct {
 func (x *JoinOptions) Reset() {
 	*x = JoinOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dkg_dkg_control_proto_msgTypes[9]
+		mi := &file_dkg_dkg_control_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -755,7 +817,7 @@ func (x *JoinOptions) String() string {
 func (*JoinOptions) ProtoMessage() {}
 
 func (x *JoinOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_dkg_dkg_control_proto_msgTypes[9]
+	mi := &file_dkg_dkg_control_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -768,7 +830,7 @@ func (x *JoinOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JoinOptions.ProtoReflect.Descriptor instead.
 func (*JoinOptions) Descriptor() ([]byte, []int) {
-	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{9}
+	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *JoinOptions) GetGroupFile() []byte {
@@ -787,7 +849,7 @@ type AcceptOptions struct {
 func (x *AcceptOptions) Reset() {
 	*x = AcceptOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dkg_dkg_control_proto_msgTypes[10]
+		mi := &file_dkg_dkg_control_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -800,7 +862,7 @@ func (x *AcceptOptions) String() string {
 func (*AcceptOptions) ProtoMessage() {}
 
 func (x *AcceptOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_dkg_dkg_control_proto_msgTypes[10]
+	mi := &file_dkg_dkg_control_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -813,7 +875,7 @@ func (x *AcceptOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AcceptOptions.ProtoReflect.Descriptor instead.
 func (*AcceptOptions) Descriptor() ([]byte, []int) {
-	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{10}
+	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{11}
 }
 
 type RejectOptions struct {
@@ -825,7 +887,7 @@ type RejectOptions struct {
 func (x *RejectOptions) Reset() {
 	*x = RejectOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dkg_dkg_control_proto_msgTypes[11]
+		mi := &file_dkg_dkg_control_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -838,7 +900,7 @@ func (x *RejectOptions) String() string {
 func (*RejectOptions) ProtoMessage() {}
 
 func (x *RejectOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_dkg_dkg_control_proto_msgTypes[11]
+	mi := &file_dkg_dkg_control_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -851,7 +913,7 @@ func (x *RejectOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RejectOptions.ProtoReflect.Descriptor instead.
 func (*RejectOptions) Descriptor() ([]byte, []int) {
-	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{11}
+	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{12}
 }
 
 type ProposalTerms struct {
@@ -872,12 +934,14 @@ type ProposalTerms struct {
 	Joining              []*Participant         `protobuf:"bytes,11,rep,name=joining,proto3" json:"joining,omitempty"`
 	Remaining            []*Participant         `protobuf:"bytes,12,rep,name=remaining,proto3" json:"remaining,omitempty"`
 	Leaving              []*Participant         `protobuf:"bytes,13,rep,name=leaving,proto3" json:"leaving,omitempty"`
+	// a refresh keeps the members and threshold of the group, so the remainers accept it without operator action
+	Refresh bool `protobuf:"varint,14,opt,name=refresh,proto3" json:"refresh,omitempty"`
 }
 
 func (x *ProposalTerms) Reset() {
 	*x = ProposalTerms{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dkg_dkg_control_proto_msgTypes[12]
+		mi := &file_dkg_dkg_control_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -890,7 +954,7 @@ func (x *ProposalTerms) String() string {
 func (*ProposalTerms) ProtoMessage() {}
 
 func (x *ProposalTerms) ProtoReflect() protoreflect.Message {
-	mi := &file_dkg_dkg_control_proto_msgTypes[12]
+	mi := &file_dkg_dkg_control_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -903,7 +967,7 @@ func (x *ProposalTerms) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProposalTerms.ProtoReflect.Descriptor instead.
 func (*ProposalTerms) Descriptor() ([]byte, []int) {
-	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{12}
+	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ProposalTerms) GetBeaconID() string {
@@ -997,6 +1061,13 @@ func (x *ProposalTerms) GetLeaving() []*Participant {
 	return nil
 }
 
+func (x *ProposalTerms) GetRefresh() bool {
+	if x != nil {
+		return x.Refresh
+	}
+	return false
+}
+
 // this is in sync with the Identity one in common.proto
 type Participant struct {
 	state         protoimpl.MessageState
@@ -1012,7 +1083,7 @@ type Participant struct {
 func (x *Participant) Reset() {
 	*x = Participant{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dkg_dkg_control_proto_msgTypes[13]
+		mi := &file_dkg_dkg_control_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1025,7 +1096,7 @@ func (x *Participant) String() string {
 func (*Participant) ProtoMessage() {}
 
 func (x *Participant) ProtoReflect() protoreflect.Message {
-	mi := &file_dkg_dkg_control_proto_msgTypes[13]
+	mi := &file_dkg_dkg_control_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1038,7 +1109,7 @@ func (x *Participant) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Participant.ProtoReflect.Descriptor instead.
 func (*Participant) Descriptor() ([]byte, []int) {
-	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{13}
+	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *Participant) GetAddress() string {
@@ -1073,7 +1144,7 @@ type AcceptProposal struct {
 func (x *AcceptProposal) Reset() {
 	*x = AcceptProposal{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dkg_dkg_control_proto_msgTypes[14]
+		mi := &file_dkg_dkg_control_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1086,7 +1157,7 @@ func (x *AcceptProposal) String() string {
 func (*AcceptProposal) ProtoMessage() {}
 
 func (x *AcceptProposal) ProtoReflect() protoreflect.Message {
-	mi := &file_dkg_dkg_control_proto_msgTypes[14]
+	mi := &file_dkg_dkg_control_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1099,7 +1170,7 @@ func (x *AcceptProposal) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AcceptProposal.ProtoReflect.Descriptor instead.
 func (*AcceptProposal) Descriptor() ([]byte, []int) {
-	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{14}
+	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *AcceptProposal) GetAcceptor() *Participant {
@@ -1130,7 +1201,7 @@ type RejectProposal struct {
 func (x *RejectProposal) Reset() {
 	*x = RejectProposal{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dkg_dkg_control_proto_msgTypes[15]
+		mi := &file_dkg_dkg_control_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1143,7 +1214,7 @@ func (x *RejectProposal) String() string {
 func (*RejectProposal) ProtoMessage() {}
 
 func (x *RejectProposal) ProtoReflect() protoreflect.Message {
-	mi := &file_dkg_dkg_control_proto_msgTypes[15]
+	mi := &file_dkg_dkg_control_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1156,7 +1227,7 @@ func (x *RejectProposal) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RejectProposal.ProtoReflect.Descriptor instead.
 func (*RejectProposal) Descriptor() ([]byte, []int) {
-	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{15}
+	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *RejectProposal) GetRejector() *Participant {
@@ -1205,7 +1276,7 @@ type AbortDKG struct {
 func (x *AbortDKG) Reset() {
 	*x = AbortDKG{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dkg_dkg_control_proto_msgTypes[16]
+		mi := &file_dkg_dkg_control_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1218,7 +1289,7 @@ func (x *AbortDKG) String() string {
 func (*AbortDKG) ProtoMessage() {}
 
 func (x *AbortDKG) ProtoReflect() protoreflect.Message {
-	mi := &file_dkg_dkg_control_proto_msgTypes[16]
+	mi := &file_dkg_dkg_control_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1231,7 +1302,7 @@ func (x *AbortDKG) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AbortDKG.ProtoReflect.Descriptor instead.
 func (*AbortDKG) Descriptor() ([]byte, []int) {
-	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{16}
+	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *AbortDKG) GetReason() string {
@@ -1252,7 +1323,7 @@ type StartExecution struct {
 func (x *StartExecution) Reset() {
 	*x = StartExecution{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dkg_dkg_control_proto_msgTypes[17]
+		mi := &file_dkg_dkg_control_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1265,7 +1336,7 @@ func (x *StartExecution) String() string {
 func (*StartExecution) ProtoMessage() {}
 
 func (x *StartExecution) ProtoReflect() protoreflect.Message {
-	mi := &file_dkg_dkg_control_proto_msgTypes[17]
+	mi := &file_dkg_dkg_control_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1278,7 +1349,7 @@ func (x *StartExecution) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartExecution.ProtoReflect.Descriptor instead.
 func (*StartExecution) Descriptor() ([]byte, []int) {
-	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{17}
+	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *StartExecution) GetTime() *timestamppb.Timestamp {
@@ -1299,7 +1370,7 @@ type DKGStatusRequest struct {
 func (x *DKGStatusRequest) Reset() {
 	*x = DKGStatusRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dkg_dkg_control_proto_msgTypes[18]
+		mi := &file_dkg_dkg_control_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1312,7 +1383,7 @@ func (x *DKGStatusRequest) String() string {
 func (*DKGStatusRequest) ProtoMessage() {}
 
 func (x *DKGStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_dkg_dkg_control_proto_msgTypes[18]
+	mi := &file_dkg_dkg_control_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1325,7 +1396,7 @@ func (x *DKGStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DKGStatusRequest.ProtoReflect.Descriptor instead.
 func (*DKGStatusRequest) Descriptor() ([]byte, []int) {
-	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{18}
+	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *DKGStatusRequest) GetBeaconID() string {
@@ -1347,7 +1418,7 @@ type DKGStatusResponse struct {
 func (x *DKGStatusResponse) Reset() {
 	*x = DKGStatusResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dkg_dkg_control_proto_msgTypes[19]
+		mi := &file_dkg_dkg_control_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1360,7 +1431,7 @@ func (x *DKGStatusResponse) String() string {
 func (*DKGStatusResponse) ProtoMessage() {}
 
 func (x *DKGStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_dkg_dkg_control_proto_msgTypes[19]
+	mi := &file_dkg_dkg_control_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1373,7 +1444,7 @@ func (x *DKGStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DKGStatusResponse.ProtoReflect.Descriptor instead.
 func (*DKGStatusResponse) Descriptor() ([]byte, []int) {
-	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{19}
+	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *DKGStatusResponse) GetComplete() *DKGEntry {
@@ -1414,7 +1485,7 @@ type DKGEntry struct {
 func (x *DKGEntry) Reset() {
 	*x = DKGEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dkg_dkg_control_proto_msgTypes[20]
+		mi := &file_dkg_dkg_control_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1427,7 +1498,7 @@ func (x *DKGEntry) String() string {
 func (*DKGEntry) ProtoMessage() {}
 
 func (x *DKGEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_dkg_dkg_control_proto_msgTypes[20]
+	mi := &file_dkg_dkg_control_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1440,7 +1511,7 @@ func (x *DKGEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DKGEntry.ProtoReflect.Descriptor instead.
 func (*DKGEntry) Descriptor() ([]byte, []int) {
-	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{20}
+	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *DKGEntry) GetBeaconID() string {
@@ -1554,7 +1625,7 @@ type DKGPacket struct {
 func (x *DKGPacket) Reset() {
 	*x = DKGPacket{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dkg_dkg_control_proto_msgTypes[21]
+		mi := &file_dkg_dkg_control_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1567,7 +1638,7 @@ func (x *DKGPacket) String() string {
 func (*DKGPacket) ProtoMessage() {}
 
 func (x *DKGPacket) ProtoReflect() protoreflect.Message {
-	mi := &file_dkg_dkg_control_proto_msgTypes[21]
+	mi := &file_dkg_dkg_control_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1580,7 +1651,7 @@ func (x *DKGPacket) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DKGPacket.ProtoReflect.Descriptor instead.
 func (*DKGPacket) Descriptor() ([]byte, []int) {
-	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{21}
+	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *DKGPacket) GetDkg() *Packet {
@@ -1590,6 +1661,319 @@ func (x *DKGPacket) GetDkg() *Packet {
 	return nil
 }
 
+type GroupHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BeaconID string `protobuf:"bytes,1,opt,name=beaconID,proto3" json:"beaconID,omitempty"`
+}
+
+func (x *GroupHistoryRequest) Reset() {
+	*x = GroupHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dkg_dkg_control_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupHistoryRequest) ProtoMessage() {}
+
+func (x *GroupHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dkg_dkg_control_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GroupHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GroupHistoryRequest) GetBeaconID() string {
+	if x != nil {
+		return x.BeaconID
+	}
+	return ""
+}
+
+type GroupHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// versions are sorted by increasing epoch
+	Versions []*GroupVersion `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+}
+
+func (x *GroupHistoryResponse) Reset() {
+	*x = GroupHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dkg_dkg_control_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupHistoryResponse) ProtoMessage() {}
+
+func (x *GroupHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dkg_dkg_control_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GroupHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GroupHistoryResponse) GetVersions() []*GroupVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+// GroupVersion is the group file that resulted from the DKG of a given epoch
+type GroupVersion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Epoch uint32 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// the TOML-encoded group file
+	GroupFile []byte `protobuf:"bytes,2,opt,name=groupFile,proto3" json:"groupFile,omitempty"`
+}
+
+func (x *GroupVersion) Reset() {
+	*x = GroupVersion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dkg_dkg_control_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupVersion) ProtoMessage() {}
+
+func (x *GroupVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_dkg_dkg_control_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupVersion.ProtoReflect.Descriptor instead.
+func (*GroupVersion) Descriptor() ([]byte, []int) {
+	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GroupVersion) GetEpoch() uint32 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *GroupVersion) GetGroupFile() []byte {
+	if x != nil {
+		return x.GroupFile
+	}
+	return nil
+}
+
+type GroupForRoundRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BeaconID string `protobuf:"bytes,1,opt,name=beaconID,proto3" json:"beaconID,omitempty"`
+	Round    uint64 `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+}
+
+func (x *GroupForRoundRequest) Reset() {
+	*x = GroupForRoundRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dkg_dkg_control_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupForRoundRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupForRoundRequest) ProtoMessage() {}
+
+func (x *GroupForRoundRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dkg_dkg_control_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupForRoundRequest.ProtoReflect.Descriptor instead.
+func (*GroupForRoundRequest) Descriptor() ([]byte, []int) {
+	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GroupForRoundRequest) GetBeaconID() string {
+	if x != nil {
+		return x.BeaconID
+	}
+	return ""
+}
+
+func (x *GroupForRoundRequest) GetRound() uint64 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+type GroupForRoundResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Epoch uint32 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// the first round produced by this group
+	TransitionRound uint64 `protobuf:"varint,2,opt,name=transitionRound,proto3" json:"transitionRound,omitempty"`
+	// the distributed public key to use to verify the beacons produced by this group
+	PublicKey   []byte `protobuf:"bytes,3,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	SchemeID    string `protobuf:"bytes,4,opt,name=schemeID,proto3" json:"schemeID,omitempty"`
+	Threshold   uint32 `protobuf:"varint,5,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Period      uint32 `protobuf:"varint,6,opt,name=period,proto3" json:"period,omitempty"`
+	GenesisSeed []byte `protobuf:"bytes,7,opt,name=genesisSeed,proto3" json:"genesisSeed,omitempty"`
+	// the TOML-encoded group file
+	GroupFile []byte `protobuf:"bytes,8,opt,name=groupFile,proto3" json:"groupFile,omitempty"`
+}
+
+func (x *GroupForRoundResponse) Reset() {
+	*x = GroupForRoundResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dkg_dkg_control_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupForRoundResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupForRoundResponse) ProtoMessage() {}
+
+func (x *GroupForRoundResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dkg_dkg_control_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupForRoundResponse.ProtoReflect.Descriptor instead.
+func (*GroupForRoundResponse) Descriptor() ([]byte, []int) {
+	return file_dkg_dkg_control_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GroupForRoundResponse) GetEpoch() uint32 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *GroupForRoundResponse) GetTransitionRound() uint64 {
+	if x != nil {
+		return x.TransitionRound
+	}
+	return 0
+}
+
+func (x *GroupForRoundResponse) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *GroupForRoundResponse) GetSchemeID() string {
+	if x != nil {
+		return x.SchemeID
+	}
+	return ""
+}
+
+func (x *GroupForRoundResponse) GetThreshold() uint32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *GroupForRoundResponse) GetPeriod() uint32 {
+	if x != nil {
+		return x.Period
+	}
+	return 0
+}
+
+func (x *GroupForRoundResponse) GetGenesisSeed() []byte {
+	if x != nil {
+		return x.GenesisSeed
+	}
+	return nil
+}
+
+func (x *GroupForRoundResponse) GetGroupFile() []byte {
+	if x != nil {
+		return x.GroupFile
+	}
+	return nil
+}
+
 var File_dkg_dkg_control_proto protoreflect.FileDescriptor
 
 var file_dkg_dkg_control_proto_rawDesc = []byte{
@@ -1599,7 +1983,7 @@ var file_dkg_dkg_control_proto_rawDesc = []byte{
 	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0d, 0x64,
 	0x6b, 0x67, 0x2f, 0x64, 0x6b, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x12, 0x0a, 0x10,
 	0x45, 0x6d, 0x70, 0x74, 0x79, 0x44, 0x4b, 0x47, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x98, 0x03, 0x0a, 0x0a, 0x44, 0x4b, 0x47, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12,
+	0x22, 0xc9, 0x03, 0x0a, 0x0a, 0x44, 0x4b, 0x47, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12,
 	0x30, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x4d,
 	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
@@ -1624,214 +2008,265 @@ var file_dkg_dkg_control_proto_rawDesc = []byte{
 	0x65, 0x63, 0x75, 0x74, 0x65, 0x12, 0x29, 0x0a, 0x05, 0x61, 0x62, 0x6f, 0x72, 0x74, 0x18, 0x09,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x41, 0x62, 0x6f, 0x72, 0x74,
 	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x48, 0x00, 0x52, 0x05, 0x61, 0x62, 0x6f, 0x72, 0x74,
-	0x42, 0x09, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x22, 0x2d, 0x0a, 0x0f, 0x43,
-	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1a,
-	0x0a, 0x08, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x22, 0xd5, 0x02, 0x0a, 0x0c, 0x47,
-	0x6f, 0x73, 0x73, 0x69, 0x70, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x2f, 0x0a, 0x08, 0x6d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
-	0x64, 0x6b, 0x67, 0x2e, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x30, 0x0a, 0x08,
-	0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
-	0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x54, 0x65, 0x72,
-	0x6d, 0x73, 0x48, 0x00, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12, 0x2d,
-	0x0a, 0x06, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
-	0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x50, 0x72, 0x6f, 0x70, 0x6f,
-	0x73, 0x61, 0x6c, 0x48, 0x00, 0x52, 0x06, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x12, 0x2d, 0x0a,
-	0x06, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
-	0x64, 0x6b, 0x67, 0x2e, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73,
-	0x61, 0x6c, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x2f, 0x0a, 0x07,
-	0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
-	0x64, 0x6b, 0x67, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69,
-	0x6f, 0x6e, 0x48, 0x00, 0x52, 0x07, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x12, 0x25, 0x0a,
-	0x05, 0x61, 0x62, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x64,
-	0x6b, 0x67, 0x2e, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x44, 0x4b, 0x47, 0x48, 0x00, 0x52, 0x05, 0x61,
-	0x62, 0x6f, 0x72, 0x74, 0x12, 0x22, 0x0a, 0x03, 0x64, 0x6b, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0e, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x44, 0x4b, 0x47, 0x50, 0x61, 0x63, 0x6b, 0x65,
-	0x74, 0x48, 0x00, 0x52, 0x03, 0x64, 0x6b, 0x67, 0x42, 0x08, 0x0a, 0x06, 0x50, 0x61, 0x63, 0x6b,
-	0x65, 0x74, 0x22, 0x64, 0x0a, 0x0e, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x4d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44,
-	0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69,
-	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73,
-	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0xca, 0x02, 0x0a, 0x14, 0x46, 0x69, 0x72,
-	0x73, 0x74, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x12, 0x34, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07,
-	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73,
-	0x68, 0x6f, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65,
-	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x5f,
-	0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x70,
-	0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06,
-	0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63,
-	0x68, 0x65, 0x6d, 0x65, 0x12, 0x34, 0x0a, 0x16, 0x63, 0x61, 0x74, 0x63, 0x68, 0x75, 0x70, 0x5f,
-	0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x63, 0x61, 0x74, 0x63, 0x68, 0x75, 0x70, 0x50, 0x65, 0x72,
-	0x69, 0x6f, 0x64, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x3d, 0x0a, 0x0c, 0x67, 0x65,
-	0x6e, 0x65, 0x73, 0x69, 0x73, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x67, 0x65,
-	0x6e, 0x65, 0x73, 0x69, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x6a, 0x6f, 0x69,
-	0x6e, 0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64, 0x6b, 0x67,
-	0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x52, 0x07, 0x6a, 0x6f,
-	0x69, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0xa3, 0x02, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73,
-	0x61, 0x6c, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x34, 0x0a, 0x07, 0x74, 0x69, 0x6d,
-	0x65, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12,
-	0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x34, 0x0a,
-	0x16, 0x63, 0x61, 0x74, 0x63, 0x68, 0x75, 0x70, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x5f,
-	0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x63,
-	0x61, 0x74, 0x63, 0x68, 0x75, 0x70, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x65, 0x63, 0x6f,
-	0x6e, 0x64, 0x73, 0x12, 0x2a, 0x0a, 0x07, 0x6a, 0x6f, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x04,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69,
-	0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x52, 0x07, 0x6a, 0x6f, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x12,
-	0x2a, 0x0a, 0x07, 0x6c, 0x65, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x10, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61,
-	0x6e, 0x74, 0x52, 0x07, 0x6c, 0x65, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x12, 0x2e, 0x0a, 0x09, 0x72,
-	0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
-	0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74,
-	0x52, 0x09, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0x0e, 0x0a, 0x0c, 0x41,
-	0x62, 0x6f, 0x72, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x12, 0x0a, 0x10, 0x45,
-	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22,
-	0x2b, 0x0a, 0x0b, 0x4a, 0x6f, 0x69, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c,
-	0x0a, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x46, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x46, 0x69, 0x6c, 0x65, 0x22, 0x0f, 0x0a, 0x0d,
-	0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x0f, 0x0a,
-	0x0d, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xaf,
-	0x04, 0x0a, 0x0d, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x54, 0x65, 0x72, 0x6d, 0x73,
-	0x12, 0x1a, 0x0a, 0x08, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05,
-	0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x65, 0x70, 0x6f,
-	0x63, 0x68, 0x12, 0x28, 0x0a, 0x06, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69,
-	0x70, 0x61, 0x6e, 0x74, 0x52, 0x06, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09,
-	0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x74, 0x69,
-	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
-	0x12, 0x34, 0x0a, 0x16, 0x63, 0x61, 0x74, 0x63, 0x68, 0x75, 0x70, 0x5f, 0x70, 0x65, 0x72, 0x69,
-	0x6f, 0x64, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x14, 0x63, 0x61, 0x74, 0x63, 0x68, 0x75, 0x70, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53,
-	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e,
-	0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x50, 0x65, 0x72,
-	0x69, 0x6f, 0x64, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x63,
-	0x68, 0x65, 0x6d, 0x65, 0x49, 0x44, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x63,
-	0x68, 0x65, 0x6d, 0x65, 0x49, 0x44, 0x12, 0x3d, 0x0a, 0x0c, 0x67, 0x65, 0x6e, 0x65, 0x73, 0x69,
-	0x73, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x67, 0x65, 0x6e, 0x65, 0x73, 0x69,
-	0x73, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73,
-	0x5f, 0x73, 0x65, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x67, 0x65, 0x6e,
-	0x65, 0x73, 0x69, 0x73, 0x53, 0x65, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x07, 0x6a, 0x6f, 0x69, 0x6e,
-	0x69, 0x6e, 0x67, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64, 0x6b, 0x67, 0x2e,
-	0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x52, 0x07, 0x6a, 0x6f, 0x69,
-	0x6e, 0x69, 0x6e, 0x67, 0x12, 0x2e, 0x0a, 0x09, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e,
-	0x67, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61,
-	0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x52, 0x09, 0x72, 0x65, 0x6d, 0x61, 0x69,
-	0x6e, 0x69, 0x6e, 0x67, 0x12, 0x2a, 0x0a, 0x07, 0x6c, 0x65, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x18,
-	0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x72, 0x74,
-	0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x52, 0x07, 0x6c, 0x65, 0x61, 0x76, 0x69, 0x6e, 0x67,
-	0x22, 0x57, 0x0a, 0x0b, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x12,
-	0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x12, 0x2f, 0x0a, 0x07, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x48, 0x00, 0x52, 0x07, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73,
+	0x68, 0x42, 0x09, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x22, 0x2d, 0x0a, 0x0f,
+	0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12,
+	0x1a, 0x0a, 0x08, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x22, 0xd5, 0x02, 0x0a, 0x0c,
+	0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x2f, 0x0a, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x30, 0x0a,
+	0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x54, 0x65,
+	0x72, 0x6d, 0x73, 0x48, 0x00, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12,
+	0x2d, 0x0a, 0x06, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x50, 0x72, 0x6f, 0x70,
+	0x6f, 0x73, 0x61, 0x6c, 0x48, 0x00, 0x52, 0x06, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x12, 0x2d,
+	0x0a, 0x06, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x70, 0x6f,
+	0x73, 0x61, 0x6c, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x2f, 0x0a,
+	0x07, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x07, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x12, 0x25,
+	0x0a, 0x05, 0x61, 0x62, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
+	0x64, 0x6b, 0x67, 0x2e, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x44, 0x4b, 0x47, 0x48, 0x00, 0x52, 0x05,
+	0x61, 0x62, 0x6f, 0x72, 0x74, 0x12, 0x22, 0x0a, 0x03, 0x64, 0x6b, 0x67, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x44, 0x4b, 0x47, 0x50, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x48, 0x00, 0x52, 0x03, 0x64, 0x6b, 0x67, 0x42, 0x08, 0x0a, 0x06, 0x50, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x22, 0x64, 0x0a, 0x0e, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49,
+	0x44, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73,
 	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09,
-	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x3e, 0x0a, 0x0e, 0x41, 0x63, 0x63,
-	0x65, 0x70, 0x74, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12, 0x2c, 0x0a, 0x08, 0x61,
-	0x63, 0x63, 0x65, 0x70, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
-	0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x52,
-	0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x6f, 0x72, 0x22, 0xc3, 0x01, 0x0a, 0x0e, 0x52, 0x65,
-	0x6a, 0x65, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12, 0x2c, 0x0a, 0x08,
-	0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
-	0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74,
-	0x52, 0x08, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
-	0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73,
-	0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x70, 0x72,
-	0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x68, 0x61, 0x73,
-	0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75,
-	0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x48, 0x61, 0x73, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72,
-	0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x48, 0x61, 0x73, 0x68, 0x22,
-	0x22, 0x0a, 0x08, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x44, 0x4b, 0x47, 0x12, 0x16, 0x0a, 0x06, 0x72,
-	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61,
-	0x73, 0x6f, 0x6e, 0x22, 0x40, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x45, 0x78, 0x65, 0x63,
-	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0xca, 0x02, 0x0a, 0x14, 0x46, 0x69,
+	0x72, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x34, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
-	0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x2e, 0x0a, 0x10, 0x44, 0x4b, 0x47, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x65, 0x61,
+	0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65,
+	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x68, 0x72,
+	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64,
+	0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d,
+	0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x34, 0x0a, 0x16, 0x63, 0x61, 0x74, 0x63, 0x68, 0x75, 0x70,
+	0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x63, 0x61, 0x74, 0x63, 0x68, 0x75, 0x70, 0x50, 0x65,
+	0x72, 0x69, 0x6f, 0x64, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x3d, 0x0a, 0x0c, 0x67,
+	0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x67,
+	0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x6a, 0x6f,
+	0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64, 0x6b,
+	0x67, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x52, 0x07, 0x6a,
+	0x6f, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0xa3, 0x02, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x70, 0x6f,
+	0x73, 0x61, 0x6c, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x34, 0x0a, 0x07, 0x74, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x34,
+	0x0a, 0x16, 0x63, 0x61, 0x74, 0x63, 0x68, 0x75, 0x70, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64,
+	0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14,
+	0x63, 0x61, 0x74, 0x63, 0x68, 0x75, 0x70, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x12, 0x2a, 0x0a, 0x07, 0x6a, 0x6f, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x72, 0x74,
+	0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x52, 0x07, 0x6a, 0x6f, 0x69, 0x6e, 0x69, 0x6e, 0x67,
+	0x12, 0x2a, 0x0a, 0x07, 0x6c, 0x65, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70,
+	0x61, 0x6e, 0x74, 0x52, 0x07, 0x6c, 0x65, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x12, 0x2e, 0x0a, 0x09,
+	0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e,
+	0x74, 0x52, 0x09, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0x0e, 0x0a, 0x0c,
+	0x41, 0x62, 0x6f, 0x72, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x46, 0x0a, 0x0e,
+	0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x34,
+	0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x22, 0x12, 0x0a, 0x10, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x2b, 0x0a, 0x0b, 0x4a, 0x6f, 0x69, 0x6e,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x46, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x46, 0x69, 0x6c, 0x65, 0x22, 0x0f, 0x0a, 0x0d, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x0f, 0x0a, 0x0d, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xc9, 0x04, 0x0a, 0x0d, 0x50, 0x72, 0x6f, 0x70,
+	0x6f, 0x73, 0x61, 0x6c, 0x54, 0x65, 0x72, 0x6d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x65, 0x61,
 	0x63, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x65, 0x61,
-	0x63, 0x6f, 0x6e, 0x49, 0x44, 0x22, 0x67, 0x0a, 0x11, 0x44, 0x4b, 0x47, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x08, 0x63, 0x6f,
-	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x64,
-	0x6b, 0x67, 0x2e, 0x44, 0x4b, 0x47, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x63, 0x6f, 0x6d,
-	0x70, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x27, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x44, 0x4b, 0x47,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x22, 0xba,
-	0x04, 0x0a, 0x08, 0x44, 0x4b, 0x47, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x62,
-	0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62,
-	0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a,
-	0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x65, 0x70,
-	0x6f, 0x63, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c,
-	0x64, 0x12, 0x34, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07,
-	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x3d, 0x0a, 0x0c, 0x67, 0x65, 0x6e, 0x65, 0x73,
-	0x69, 0x73, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x67, 0x65, 0x6e, 0x65, 0x73,
-	0x69, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x65, 0x6e, 0x65, 0x73, 0x69,
-	0x73, 0x5f, 0x73, 0x65, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x67, 0x65,
-	0x6e, 0x65, 0x73, 0x69, 0x73, 0x53, 0x65, 0x65, 0x64, 0x12, 0x28, 0x0a, 0x06, 0x6c, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64, 0x6b, 0x67, 0x2e,
-	0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x52, 0x06, 0x6c, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x12, 0x2e, 0x0a, 0x09, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67,
-	0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x72,
-	0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x52, 0x09, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e,
-	0x69, 0x6e, 0x67, 0x12, 0x2a, 0x0a, 0x07, 0x6a, 0x6f, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x0a,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69,
-	0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x52, 0x07, 0x6a, 0x6f, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x12,
-	0x2a, 0x0a, 0x07, 0x6c, 0x65, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b,
+	0x63, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x28, 0x0a, 0x06, 0x6c,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64, 0x6b,
+	0x67, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x52, 0x06, 0x6c,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f,
+	0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68,
+	0x6f, 0x6c, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x34, 0x0a, 0x16, 0x63, 0x61, 0x74,
+	0x63, 0x68, 0x75, 0x70, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x5f, 0x73, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x63, 0x61, 0x74, 0x63, 0x68,
+	0x75, 0x70, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12,
+	0x32, 0x0a, 0x15, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64,
+	0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13,
+	0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x49, 0x44, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x49, 0x44, 0x12,
+	0x3d, 0x0a, 0x0c, 0x67, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x0b, 0x67, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x21,
+	0x0a, 0x0c, 0x67, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x5f, 0x73, 0x65, 0x65, 0x64, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x67, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x53, 0x65, 0x65,
+	0x64, 0x12, 0x2a, 0x0a, 0x07, 0x6a, 0x6f, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x0b, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69,
+	0x70, 0x61, 0x6e, 0x74, 0x52, 0x07, 0x6a, 0x6f, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x2e, 0x0a,
+	0x09, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b,
 	0x32, 0x10, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61,
-	0x6e, 0x74, 0x52, 0x07, 0x6c, 0x65, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x12, 0x2e, 0x0a, 0x09, 0x61,
-	0x63, 0x63, 0x65, 0x70, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
-	0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74,
-	0x52, 0x09, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x2e, 0x0a, 0x09, 0x72,
-	0x65, 0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x6e, 0x74, 0x52, 0x09, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x2a, 0x0a,
+	0x07, 0x6c, 0x65, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
 	0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74,
-	0x52, 0x09, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x66,
-	0x69, 0x6e, 0x61, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x0a, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x22, 0x2a, 0x0a, 0x09, 0x44,
-	0x4b, 0x47, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x03, 0x64, 0x6b, 0x67, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x63, 0x6b,
-	0x65, 0x74, 0x52, 0x03, 0x64, 0x6b, 0x67, 0x32, 0xee, 0x01, 0x0a, 0x0a, 0x44, 0x4b, 0x47, 0x43,
-	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x12, 0x33, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
-	0x64, 0x12, 0x0f, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x44, 0x4b, 0x47, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
-	0x6e, 0x64, 0x1a, 0x15, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x44, 0x4b,
-	0x47, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x06, 0x50,
-	0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x11, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x47, 0x6f, 0x73, 0x73,
-	0x69, 0x70, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x1a, 0x15, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x44, 0x4b, 0x47, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x3c, 0x0a, 0x09, 0x44, 0x4b, 0x47, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x15,
-	0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x44, 0x4b, 0x47, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x44, 0x4b, 0x47, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x37, 0x0a, 0x0c, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x44, 0x4b, 0x47, 0x12,
-	0x0e, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x44, 0x4b, 0x47, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x1a,
-	0x15, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x44, 0x4b, 0x47, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x28, 0x5a, 0x26, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2f, 0x64, 0x72, 0x61,
-	0x6e, 0x64, 0x2f, 0x76, 0x32, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64,
-	0x6b, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x07, 0x6c, 0x65, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x66,
+	0x72, 0x65, 0x73, 0x68, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x66, 0x72,
+	0x65, 0x73, 0x68, 0x22, 0x57, 0x0a, 0x0b, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61,
+	0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x3e, 0x0a, 0x0e,
+	0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12, 0x2c,
+	0x0a, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61,
+	0x6e, 0x74, 0x52, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x6f, 0x72, 0x22, 0xc3, 0x01, 0x0a,
+	0x0e, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12,
+	0x2c, 0x0a, 0x08, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70,
+	0x61, 0x6e, 0x74, 0x52, 0x08, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x2e, 0x0a,
+	0x13, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x70, 0x72, 0x65, 0x76,
+	0x69, 0x6f, 0x75, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x48, 0x61, 0x73, 0x68, 0x12, 0x23, 0x0a,
+	0x0d, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x48, 0x61,
+	0x73, 0x68, 0x22, 0x22, 0x0a, 0x08, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x44, 0x4b, 0x47, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x40, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x45,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x2e, 0x0a, 0x10, 0x44, 0x4b, 0x47, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x22, 0x67, 0x0a, 0x11, 0x44, 0x4b, 0x47, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a,
+	0x08, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x44, 0x4b, 0x47, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08,
+	0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x27, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x64, 0x6b, 0x67, 0x2e,
+	0x44, 0x4b, 0x47, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x22, 0xba, 0x04, 0x0a, 0x08, 0x44, 0x4b, 0x47, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x1a,
+	0x0a, 0x08, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68,
+	0x6f, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73,
+	0x68, 0x6f, 0x6c, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x3d, 0x0a, 0x0c, 0x67, 0x65,
+	0x6e, 0x65, 0x73, 0x69, 0x73, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x67, 0x65,
+	0x6e, 0x65, 0x73, 0x69, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x65, 0x6e,
+	0x65, 0x73, 0x69, 0x73, 0x5f, 0x73, 0x65, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0b, 0x67, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x53, 0x65, 0x65, 0x64, 0x12, 0x28, 0x0a, 0x06,
+	0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64,
+	0x6b, 0x67, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x52, 0x06,
+	0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x2e, 0x0a, 0x09, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e,
+	0x69, 0x6e, 0x67, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64, 0x6b, 0x67, 0x2e,
+	0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x52, 0x09, 0x72, 0x65, 0x6d,
+	0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x2a, 0x0a, 0x07, 0x6a, 0x6f, 0x69, 0x6e, 0x69, 0x6e,
+	0x67, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61,
+	0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x52, 0x07, 0x6a, 0x6f, 0x69, 0x6e, 0x69,
+	0x6e, 0x67, 0x12, 0x2a, 0x0a, 0x07, 0x6c, 0x65, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x18, 0x0b, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63,
+	0x69, 0x70, 0x61, 0x6e, 0x74, 0x52, 0x07, 0x6c, 0x65, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x12, 0x2e,
+	0x0a, 0x09, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70,
+	0x61, 0x6e, 0x74, 0x52, 0x09, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x2e,
+	0x0a, 0x09, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70,
+	0x61, 0x6e, 0x74, 0x52, 0x09, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x1e,
+	0x0a, 0x0a, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x0e, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0a, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x22, 0x2a,
+	0x0a, 0x09, 0x44, 0x4b, 0x47, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x03, 0x64,
+	0x6b, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x03, 0x64, 0x6b, 0x67, 0x22, 0x31, 0x0a, 0x13, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x22, 0x45, 0x0a,
+	0x14, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x08, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x22, 0x42, 0x0a, 0x0c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x46, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x46, 0x69, 0x6c, 0x65, 0x22, 0x48, 0x0a, 0x14, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x46, 0x6f, 0x72, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05,
+	0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x75,
+	0x6e, 0x64, 0x22, 0x87, 0x02, 0x0a, 0x15, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x46, 0x6f, 0x72, 0x52,
+	0x6f, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x65, 0x70, 0x6f,
+	0x63, 0x68, 0x12, 0x28, 0x0a, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x09,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x65, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x65, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68,
+	0x6f, 0x6c, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73,
+	0x68, 0x6f, 0x6c, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x20, 0x0a, 0x0b,
+	0x67, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x53, 0x65, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0b, 0x67, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x53, 0x65, 0x65, 0x64, 0x12, 0x1c,
+	0x0a, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x46, 0x69, 0x6c, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x46, 0x69, 0x6c, 0x65, 0x32, 0xff, 0x02, 0x0a,
+	0x0a, 0x44, 0x4b, 0x47, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x12, 0x33, 0x0a, 0x07, 0x43,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x0f, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x44, 0x4b, 0x47,
+	0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x1a, 0x15, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x44, 0x4b, 0x47, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x34, 0x0a, 0x06, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x11, 0x2e, 0x64, 0x6b, 0x67,
+	0x2e, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x1a, 0x15, 0x2e,
+	0x64, 0x6b, 0x67, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x44, 0x4b, 0x47, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x09, 0x44, 0x4b, 0x47, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x15, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x44, 0x4b, 0x47, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x64, 0x6b, 0x67,
+	0x2e, 0x44, 0x4b, 0x47, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x0c, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73,
+	0x74, 0x44, 0x4b, 0x47, 0x12, 0x0e, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x44, 0x4b, 0x47, 0x50, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x1a, 0x15, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x44, 0x4b, 0x47, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a,
+	0x0c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x18, 0x2e,
+	0x64, 0x6b, 0x67, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0d, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x46, 0x6f, 0x72,
+	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x19, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x46, 0x6f, 0x72, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1a, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x46, 0x6f, 0x72, 0x52,
+	0x6f, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x28,
+	0x5a, 0x26, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x72, 0x61,
+	0x6e, 0x64, 0x2f, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2f, 0x76, 0x32, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x6b, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1846,7 +2281,7 @@ func file_dkg_dkg_control_proto_rawDescGZIP() []byte {
 	return file_dkg_dkg_control_proto_rawDescData
 }
 
-var file_dkg_dkg_control_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_dkg_dkg_control_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_dkg_dkg_control_proto_goTypes = []interface{}{
 	(*EmptyDKGResponse)(nil),      // 0: dkg.EmptyDKGResponse
 	(*DKGCommand)(nil),            // 1: dkg.DKGCommand
@@ -1856,79 +2291,92 @@ var file_dkg_dkg_control_proto_goTypes = []interface{}{
 	(*FirstProposalOptions)(nil),  // 5: dkg.FirstProposalOptions
 	(*ProposalOptions)(nil),       // 6: dkg.ProposalOptions
 	(*AbortOptions)(nil),          // 7: dkg.AbortOptions
-	(*ExecutionOptions)(nil),      // 8: dkg.ExecutionOptions
-	(*JoinOptions)(nil),           // 9: dkg.JoinOptions
-	(*AcceptOptions)(nil),         // 10: dkg.AcceptOptions
-	(*RejectOptions)(nil),         // 11: dkg.RejectOptions
-	(*ProposalTerms)(nil),         // 12: dkg.ProposalTerms
-	(*Participant)(nil),           // 13: dkg.Participant
-	(*AcceptProposal)(nil),        // 14: dkg.AcceptProposal
-	(*RejectProposal)(nil),        // 15: dkg.RejectProposal
-	(*AbortDKG)(nil),              // 16: dkg.AbortDKG
-	(*StartExecution)(nil),        // 17: dkg.StartExecution
-	(*DKGStatusRequest)(nil),      // 18: dkg.DKGStatusRequest
-	(*DKGStatusResponse)(nil),     // 19: dkg.DKGStatusResponse
-	(*DKGEntry)(nil),              // 20: dkg.DKGEntry
-	(*DKGPacket)(nil),             // 21: dkg.DKGPacket
-	(*timestamppb.Timestamp)(nil), // 22: google.protobuf.Timestamp
-	(*Packet)(nil),                // 23: dkg.Packet
+	(*RefreshOptions)(nil),        // 8: dkg.RefreshOptions
+	(*ExecutionOptions)(nil),      // 9: dkg.ExecutionOptions
+	(*JoinOptions)(nil),           // 10: dkg.JoinOptions
+	(*AcceptOptions)(nil),         // 11: dkg.AcceptOptions
+	(*RejectOptions)(nil),         // 12: dkg.RejectOptions
+	(*ProposalTerms)(nil),         // 13: dkg.ProposalTerms
+	(*Participant)(nil),           // 14: dkg.Participant
+	(*AcceptProposal)(nil),        // 15: dkg.AcceptProposal
+	(*RejectProposal)(nil),        // 16: dkg.RejectProposal
+	(*AbortDKG)(nil),              // 17: dkg.AbortDKG
+	(*StartExecution)(nil),        // 18: dkg.StartExecution
+	(*DKGStatusRequest)(nil),      // 19: dkg.DKGStatusRequest
+	(*DKGStatusResponse)(nil),     // 20: dkg.DKGStatusResponse
+	(*DKGEntry)(nil),              // 21: dkg.DKGEntry
+	(*DKGPacket)(nil),             // 22: dkg.DKGPacket
+	(*GroupHistoryRequest)(nil),   // 23: dkg.GroupHistoryRequest
+	(*GroupHistoryResponse)(nil),  // 24: dkg.GroupHistoryResponse
+	(*GroupVersion)(nil),          // 25: dkg.GroupVersion
+	(*GroupForRoundRequest)(nil),  // 26: dkg.GroupForRoundRequest
+	(*GroupForRoundResponse)(nil), // 27: dkg.GroupForRoundResponse
+	(*timestamppb.Timestamp)(nil), // 28: google.protobuf.Timestamp
+	(*Packet)(nil),                // 29: dkg.Packet
 }
 var file_dkg_dkg_control_proto_depIdxs = []int32{
 	2,  // 0: dkg.DKGCommand.metadata:type_name -> dkg.CommandMetadata
 	5,  // 1: dkg.DKGCommand.initial:type_name -> dkg.FirstProposalOptions
 	6,  // 2: dkg.DKGCommand.resharing:type_name -> dkg.ProposalOptions
-	9,  // 3: dkg.DKGCommand.join:type_name -> dkg.JoinOptions
-	10, // 4: dkg.DKGCommand.accept:type_name -> dkg.AcceptOptions
-	11, // 5: dkg.DKGCommand.reject:type_name -> dkg.RejectOptions
-	8,  // 6: dkg.DKGCommand.execute:type_name -> dkg.ExecutionOptions
+	10, // 3: dkg.DKGCommand.join:type_name -> dkg.JoinOptions
+	11, // 4: dkg.DKGCommand.accept:type_name -> dkg.AcceptOptions
+	12, // 5: dkg.DKGCommand.reject:type_name -> dkg.RejectOptions
+	9,  // 6: dkg.DKGCommand.execute:type_name -> dkg.ExecutionOptions
 	7,  // 7: dkg.DKGCommand.abort:type_name -> dkg.AbortOptions
-	4,  // 8: dkg.GossipPacket.metadata:type_name -> dkg.GossipMetadata
-	12, // 9: dkg.GossipPacket.proposal:type_name -> dkg.ProposalTerms
-	14, // 10: dkg.GossipPacket.accept:type_name -> dkg.AcceptProposal
-	15, // 11: dkg.GossipPacket.reject:type_name -> dkg.RejectProposal
-	17, // 12: dkg.GossipPacket.execute:type_name -> dkg.StartExecution
-	16, // 13: dkg.GossipPacket.abort:type_name -> dkg.AbortDKG
-	21, // 14: dkg.GossipPacket.dkg:type_name -> dkg.DKGPacket
-	22, // 15: dkg.FirstProposalOptions.timeout:type_name -> google.protobuf.Timestamp
-	22, // 16: dkg.FirstProposalOptions.genesis_time:type_name -> google.protobuf.Timestamp
-	13, // 17: dkg.FirstProposalOptions.joining:type_name -> dkg.Participant
-	22, // 18: dkg.ProposalOptions.timeout:type_name -> google.protobuf.Timestamp
-	13, // 19: dkg.ProposalOptions.joining:type_name -> dkg.Participant
-	13, // 20: dkg.ProposalOptions.leaving:type_name -> dkg.Participant
-	13, // 21: dkg.ProposalOptions.remaining:type_name -> dkg.Participant
-	13, // 22: dkg.ProposalTerms.leader:type_name -> dkg.Participant
-	22, // 23: dkg.ProposalTerms.timeout:type_name -> google.protobuf.Timestamp
-	22, // 24: dkg.ProposalTerms.genesis_time:type_name -> google.protobuf.Timestamp
-	13, // 25: dkg.ProposalTerms.joining:type_name -> dkg.Participant
-	13, // 26: dkg.ProposalTerms.remaining:type_name -> dkg.Participant
-	13, // 27: dkg.ProposalTerms.leaving:type_name -> dkg.Participant
-	13, // 28: dkg.AcceptProposal.acceptor:type_name -> dkg.Participant
-	13, // 29: dkg.RejectProposal.rejector:type_name -> dkg.Participant
-	22, // 30: dkg.StartExecution.time:type_name -> google.protobuf.Timestamp
-	20, // 31: dkg.DKGStatusResponse.complete:type_name -> dkg.DKGEntry
-	20, // 32: dkg.DKGStatusResponse.current:type_name -> dkg.DKGEntry
-	22, // 33: dkg.DKGEntry.timeout:type_name -> google.protobuf.Timestamp
-	22, // 34: dkg.DKGEntry.genesis_time:type_name -> google.protobuf.Timestamp
-	13, // 35: dkg.DKGEntry.leader:type_name -> dkg.Participant
-	13, // 36: dkg.DKGEntry.remaining:type_name -> dkg.Participant
-	13, // 37: dkg.DKGEntry.joining:type_name -> dkg.Participant
-	13, // 38: dkg.DKGEntry.leaving:type_name -> dkg.Participant
-	13, // 39: dkg.DKGEntry.acceptors:type_name -> dkg.Participant
-	13, // 40: dkg.DKGEntry.rejectors:type_name -> dkg.Participant
-	23, // 41: dkg.DKGPacket.dkg:type_name -> dkg.Packet
-	1,  // 42: dkg.DKGControl.Command:input_type -> dkg.DKGCommand
-	3,  // 43: dkg.DKGControl.Packet:input_type -> dkg.GossipPacket
-	18, // 44: dkg.DKGControl.DKGStatus:input_type -> dkg.DKGStatusRequest
-	21, // 45: dkg.DKGControl.BroadcastDKG:input_type -> dkg.DKGPacket
-	0,  // 46: dkg.DKGControl.Command:output_type -> dkg.EmptyDKGResponse
-	0,  // 47: dkg.DKGControl.Packet:output_type -> dkg.EmptyDKGResponse
-	19, // 48: dkg.DKGControl.DKGStatus:output_type -> dkg.DKGStatusResponse
-	0,  // 49: dkg.DKGControl.BroadcastDKG:output_type -> dkg.EmptyDKGResponse
-	46, // [46:50] is the sub-list for method output_type
-	42, // [42:46] is the sub-list for method input_type
-	42, // [42:42] is the sub-list for extension type_name
-	42, // [42:42] is the sub-list for extension extendee
-	0,  // [0:42] is the sub-list for field type_name
+	8,  // 8: dkg.DKGCommand.refresh:type_name -> dkg.RefreshOptions
+	4,  // 9: dkg.GossipPacket.metadata:type_name -> dkg.GossipMetadata
+	13, // 10: dkg.GossipPacket.proposal:type_name -> dkg.ProposalTerms
+	15, // 11: dkg.GossipPacket.accept:type_name -> dkg.AcceptProposal
+	16, // 12: dkg.GossipPacket.reject:type_name -> dkg.RejectProposal
+	18, // 13: dkg.GossipPacket.execute:type_name -> dkg.StartExecution
+	17, // 14: dkg.GossipPacket.abort:type_name -> dkg.AbortDKG
+	22, // 15: dkg.GossipPacket.dkg:type_name -> dkg.DKGPacket
+	28, // 16: dkg.FirstProposalOptions.timeout:type_name -> google.protobuf.Timestamp
+	28, // 17: dkg.FirstProposalOptions.genesis_time:type_name -> google.protobuf.Timestamp
+	14, // 18: dkg.FirstProposalOptions.joining:type_name -> dkg.Participant
+	28, // 19: dkg.ProposalOptions.timeout:type_name -> google.protobuf.Timestamp
+	14, // 20: dkg.ProposalOptions.joining:type_name -> dkg.Participant
+	14, // 21: dkg.ProposalOptions.leaving:type_name -> dkg.Participant
+	14, // 22: dkg.ProposalOptions.remaining:type_name -> dkg.Participant
+	28, // 23: dkg.RefreshOptions.timeout:type_name -> google.protobuf.Timestamp
+	14, // 24: dkg.ProposalTerms.leader:type_name -> dkg.Participant
+	28, // 25: dkg.ProposalTerms.timeout:type_name -> google.protobuf.Timestamp
+	28, // 26: dkg.ProposalTerms.genesis_time:type_name -> google.protobuf.Timestamp
+	14, // 27: dkg.ProposalTerms.joining:type_name -> dkg.Participant
+	14, // 28: dkg.ProposalTerms.remaining:type_name -> dkg.Participant
+	14, // 29: dkg.ProposalTerms.leaving:type_name -> dkg.Participant
+	14, // 30: dkg.AcceptProposal.acceptor:type_name -> dkg.Participant
+	14, // 31: dkg.RejectProposal.rejector:type_name -> dkg.Participant
+	28, // 32: dkg.StartExecution.time:type_name -> google.protobuf.Timestamp
+	21, // 33: dkg.DKGStatusResponse.complete:type_name -> dkg.DKGEntry
+	21, // 34: dkg.DKGStatusResponse.current:type_name -> dkg.DKGEntry
+	28, // 35: dkg.DKGEntry.timeout:type_name -> google.protobuf.Timestamp
+	28, // 36: dkg.DKGEntry.genesis_time:type_name -> google.protobuf.Timestamp
+	14, // 37: dkg.DKGEntry.leader:type_name -> dkg.Participant
+	14, // 38: dkg.DKGEntry.remaining:type_name -> dkg.Participant
+	14, // 39: dkg.DKGEntry.joining:type_name -> dkg.Participant
+	14, // 40: dkg.DKGEntry.leaving:type_name -> dkg.Participant
+	14, // 41: dkg.DKGEntry.acceptors:type_name -> dkg.Participant
+	14, // 42: dkg.DKGEntry.rejectors:type_name -> dkg.Participant
+	29, // 43: dkg.DKGPacket.dkg:type_name -> dkg.Packet
+	25, // 44: dkg.GroupHistoryResponse.versions:type_name -> dkg.GroupVersion
+	1,  // 45: dkg.DKGControl.Command:input_type -> dkg.DKGCommand
+	3,  // 46: dkg.DKGControl.Packet:input_type -> dkg.GossipPacket
+	19, // 47: dkg.DKGControl.DKGStatus:input_type -> dkg.DKGStatusRequest
+	22, // 48: dkg.DKGControl.BroadcastDKG:input_type -> dkg.DKGPacket
+	23, // 49: dkg.DKGControl.GroupHistory:input_type -> dkg.GroupHistoryRequest
+	26, // 50: dkg.DKGControl.GroupForRound:input_type -> dkg.GroupForRoundRequest
+	0,  // 51: dkg.DKGControl.Command:output_type -> dkg.EmptyDKGResponse
+	0,  // 52: dkg.DKGControl.Packet:output_type -> dkg.EmptyDKGResponse
+	20, // 53: dkg.DKGControl.DKGStatus:output_type -> dkg.DKGStatusResponse
+	0,  // 54: dkg.DKGControl.BroadcastDKG:output_type -> dkg.EmptyDKGResponse
+	24, // 55: dkg.DKGControl.GroupHistory:output_type -> dkg.GroupHistoryResponse
+	27, // 56: dkg.DKGControl.GroupForRound:output_type -> dkg.GroupForRoundResponse
+	51, // [51:57] is the sub-list for method output_type
+	45, // [45:51] is the sub-list for method input_type
+	45, // [45:45] is the sub-list for extension type_name
+	45, // [45:45] is the sub-list for extension extendee
+	0,  // [0:45] is the sub-list for field type_name
 }
 
 func init() { file_dkg_dkg_control_proto_init() }
@@ -2035,7 +2483,7 @@ func file_dkg_dkg_control_proto_init() {
 			}
 		}
 		file_dkg_dkg_control_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExecutionOptions); i {
+			switch v := v.(*RefreshOptions); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2047,7 +2495,7 @@ func file_dkg_dkg_control_proto_init() {
 			}
 		}
 		file_dkg_dkg_control_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JoinOptions); i {
+			switch v := v.(*ExecutionOptions); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2059,7 +2507,7 @@ func file_dkg_dkg_control_proto_init() {
 			}
 		}
 		file_dkg_dkg_control_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AcceptOptions); i {
+			switch v := v.(*JoinOptions); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2071,7 +2519,7 @@ func file_dkg_dkg_control_proto_init() {
 			}
 		}
 		file_dkg_dkg_control_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RejectOptions); i {
+			switch v := v.(*AcceptOptions); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2083,7 +2531,7 @@ func file_dkg_dkg_control_proto_init() {
 			}
 		}
 		file_dkg_dkg_control_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProposalTerms); i {
+			switch v := v.(*RejectOptions); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2095,7 +2543,7 @@ func file_dkg_dkg_control_proto_init() {
 			}
 		}
 		file_dkg_dkg_control_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Participant); i {
+			switch v := v.(*ProposalTerms); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2107,7 +2555,7 @@ func file_dkg_dkg_control_proto_init() {
 			}
 		}
 		file_dkg_dkg_control_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AcceptProposal); i {
+			switch v := v.(*Participant); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2119,7 +2567,7 @@ func file_dkg_dkg_control_proto_init() {
 			}
 		}
 		file_dkg_dkg_control_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RejectProposal); i {
+			switch v := v.(*AcceptProposal); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2131,7 +2579,7 @@ func file_dkg_dkg_control_proto_init() {
 			}
 		}
 		file_dkg_dkg_control_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AbortDKG); i {
+			switch v := v.(*RejectProposal); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2143,7 +2591,7 @@ func file_dkg_dkg_control_proto_init() {
 			}
 		}
 		file_dkg_dkg_control_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StartExecution); i {
+			switch v := v.(*AbortDKG); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2155,7 +2603,7 @@ func file_dkg_dkg_control_proto_init() {
 			}
 		}
 		file_dkg_dkg_control_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DKGStatusRequest); i {
+			switch v := v.(*StartExecution); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2167,7 +2615,7 @@ func file_dkg_dkg_control_proto_init() {
 			}
 		}
 		file_dkg_dkg_control_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DKGStatusResponse); i {
+			switch v := v.(*DKGStatusRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2179,7 +2627,7 @@ func file_dkg_dkg_control_proto_init() {
 			}
 		}
 		file_dkg_dkg_control_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DKGEntry); i {
+			switch v := v.(*DKGStatusResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2191,6 +2639,18 @@ func file_dkg_dkg_control_proto_init() {
 			}
 		}
 		file_dkg_dkg_control_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DKGEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dkg_dkg_control_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DKGPacket); i {
 			case 0:
 				return &v.state
@@ -2202,6 +2662,66 @@ func file_dkg_dkg_control_proto_init() {
 				return nil
 			}
 		}
+		file_dkg_dkg_control_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dkg_dkg_control_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dkg_dkg_control_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupVersion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dkg_dkg_control_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupForRoundRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dkg_dkg_control_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupForRoundResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_dkg_dkg_control_proto_msgTypes[1].OneofWrappers = []interface{}{
 		(*DKGCommand_Initial)(nil),
@@ -2211,6 +2731,7 @@ func file_dkg_dkg_control_proto_init() {
 		(*DKGCommand_Reject)(nil),
 		(*DKGCommand_Execute)(nil),
 		(*DKGCommand_Abort)(nil),
+		(*DKGCommand_Refresh)(nil),
 	}
 	file_dkg_dkg_control_proto_msgTypes[3].OneofWrappers = []interface{}{
 		(*GossipPacket_Proposal)(nil),
@@ -2226,7 +2747,7 @@ func file_dkg_dkg_control_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_dkg_dkg_control_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   22,
+			NumMessages:   28,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,10 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	DKGControl_Command_FullMethodName      = "/dkg.DKGControl/Command"
-	DKGControl_Packet_FullMethodName       = "/dkg.DKGControl/Packet"
-	DKGControl_DKGStatus_FullMethodName    = "/dkg.DKGControl/DKGStatus"
-	DKGControl_BroadcastDKG_FullMethodName = "/dkg.DKGControl/BroadcastDKG"
+	DKGControl_Command_FullMethodName       = "/dkg.DKGControl/Command"
+	DKGControl_Packet_FullMethodName        = "/dkg.DKGControl/Packet"
+	DKGControl_DKGStatus_FullMethodName     = "/dkg.DKGControl/DKGStatus"
+	DKGControl_BroadcastDKG_FullMethodName  = "/dkg.DKGControl/BroadcastDKG"
+	DKGControl_GroupHistory_FullMethodName  = "/dkg.DKGControl/GroupHistory"
+	DKGControl_GroupForRound_FullMethodName = "/dkg.DKGControl/GroupForRound"
 )
 
 // DKGControlClient is the client API for DKGControl service.
@@ -33,6 +35,10 @@ type DKGControlClient interface {
 	Packet(ctx context.Context, in *GossipPacket, opts ...grpc.CallOption) (*EmptyDKGResponse, error)
 	DKGStatus(ctx context.Context, in *DKGStatusRequest, opts ...grpc.CallOption) (*DKGStatusResponse, error)
 	BroadcastDKG(ctx context.Context, in *DKGPacket, opts ...grpc.CallOption) (*EmptyDKGResponse, error)
+	// GroupHistory returns every group file this node has completed a DKG for, by epoch
+	GroupHistory(ctx context.Context, in *GroupHistoryRequest, opts ...grpc.CallOption) (*GroupHistoryResponse, error)
+	// GroupForRound returns the group that was in charge of producing the given round
+	GroupForRound(ctx context.Context, in *GroupForRoundRequest, opts ...grpc.CallOption) (*GroupForRoundResponse, error)
 }
 
 type dKGControlClient struct {
@@ -79,6 +85,24 @@ func (c *dKGControlClient) BroadcastDKG(ctx context.Context, in *DKGPacket, opts
 	return out, nil
 }
 
+func (c *dKGControlClient) GroupHistory(ctx context.Context, in *GroupHistoryRequest, opts ...grpc.CallOption) (*GroupHistoryResponse, error) {
+	out := new(GroupHistoryResponse)
+	err := c.cc.Invoke(ctx, DKGControl_GroupHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKGControlClient) GroupForRound(ctx context.Context, in *GroupForRoundRequest, opts ...grpc.CallOption) (*GroupForRoundResponse, error) {
+	out := new(GroupForRoundResponse)
+	err := c.cc.Invoke(ctx, DKGControl_GroupForRound_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DKGControlServer is the server API for DKGControl service.
 // All implementations should embed UnimplementedDKGControlServer
 // for forward compatibility
@@ -87,6 +111,10 @@ type DKGControlServer interface {
 	Packet(context.Context, *GossipPacket) (*EmptyDKGResponse, error)
 	DKGStatus(context.Context, *DKGStatusRequest) (*DKGStatusResponse, error)
 	BroadcastDKG(context.Context, *DKGPacket) (*EmptyDKGResponse, error)
+	// GroupHistory returns every group file this node has completed a DKG for, by epoch
+	GroupHistory(context.Context, *GroupHistoryRequest) (*GroupHistoryResponse, error)
+	// GroupForRound returns the group that was in charge of producing the given round
+	GroupForRound(context.Context, *GroupForRoundRequest) (*GroupForRoundResponse, error)
 }
 
 // UnimplementedDKGControlServer should be embedded to have forward compatible implementations.
@@ -105,6 +133,12 @@ func (UnimplementedDKGControlServer) DKGStatus(context.Context, *DKGStatusReques
 func (UnimplementedDKGControlServer) BroadcastDKG(context.Context, *DKGPacket) (*EmptyDKGResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BroadcastDKG not implemented")
 }
+func (UnimplementedDKGControlServer) GroupHistory(context.Context, *GroupHistoryRequest) (*GroupHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GroupHistory not implemented")
+}
+func (UnimplementedDKGControlServer) GroupForRound(context.Context, *GroupForRoundRequest) (*GroupForRoundResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GroupForRound not implemented")
+}
 
 // UnsafeDKGControlServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to DKGControlServer will
@@ -189,6 +223,42 @@ func _DKGControl_BroadcastDKG_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DKGControl_GroupHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKGControlServer).GroupHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DKGControl_GroupHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKGControlServer).GroupHistory(ctx, req.(*GroupHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKGControl_GroupForRound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupForRoundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKGControlServer).GroupForRound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DKGControl_GroupForRound_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKGControlServer).GroupForRound(ctx, req.(*GroupForRoundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DKGControl_ServiceDesc is the grpc.ServiceDesc for DKGControl service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -212,6 +282,14 @@ var DKGControl_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BroadcastDKG",
 			Handler:    _DKGControl_BroadcastDKG_Handler,
 		},
+		{
+			MethodName: "GroupHistory",
+			Handler:    _DKGControl_GroupHistory_Handler,
+		},
+		{
+			MethodName: "GroupForRound",
+			Handler:    _DKGControl_GroupForRound_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "dkg/dkg_control.proto",
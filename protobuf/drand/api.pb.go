@@ -36,6 +36,11 @@ type PublicRandRequest struct {
 	// the response will contain the last.
 	Round    uint64    `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
 	Metadata *Metadata `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// when asking for the last beacon, refuse to serve one older than that
+	// many periods. If 0, the limit set on the node, if any, applies.
+	MaxStalePeriods uint64 `protobuf:"varint,3,opt,name=max_stale_periods,json=maxStalePeriods,proto3" json:"max_stale_periods,omitempty"`
+	// the name of the sub-beacon to get the beacon of, instead of the main chain
+	SubBeacon string `protobuf:"bytes,4,opt,name=sub_beacon,json=subBeacon,proto3" json:"sub_beacon,omitempty"`
 }
 
 func (x *PublicRandRequest) Reset() {
@@ -84,6 +89,20 @@ func (x *PublicRandRequest) GetMetadata() *Metadata {
 	return nil
 }
 
+func (x *PublicRandRequest) GetMaxStalePeriods() uint64 {
+	if x != nil {
+		return x.MaxStalePeriods
+	}
+	return 0
+}
+
+func (x *PublicRandRequest) GetSubBeacon() string {
+	if x != nil {
+		return x.SubBeacon
+	}
+	return ""
+}
+
 // PublicRandResponse holds a signature which is the random value. It can be
 // verified thanks to the distributed public key of the nodes that have ran the
 // DKG protocol and is unbiasable. The randomness can be verified using the BLS
@@ -172,6 +191,120 @@ func (x *PublicRandResponse) GetMetadata() *Metadata {
 	return nil
 }
 
+// PublicRandAtRequest requests the beacon which was the latest one at a time in the past
+type PublicRandAtRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the UNIX time, in seconds
+	Timestamp int64     `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Metadata  *Metadata `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *PublicRandAtRequest) Reset() {
+	*x = PublicRandAtRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_api_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PublicRandAtRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicRandAtRequest) ProtoMessage() {}
+
+func (x *PublicRandAtRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_api_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicRandAtRequest.ProtoReflect.Descriptor instead.
+func (*PublicRandAtRequest) Descriptor() ([]byte, []int) {
+	return file_drand_api_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PublicRandAtRequest) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *PublicRandAtRequest) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// PublicRandByRandomnessRequest requests the beacon of a randomness
+type PublicRandByRandomnessRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the randomness, i.e. the SHA-256 hash of the signature of the beacon
+	Randomness []byte    `protobuf:"bytes,1,opt,name=randomness,proto3" json:"randomness,omitempty"`
+	Metadata   *Metadata `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *PublicRandByRandomnessRequest) Reset() {
+	*x = PublicRandByRandomnessRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_api_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PublicRandByRandomnessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicRandByRandomnessRequest) ProtoMessage() {}
+
+func (x *PublicRandByRandomnessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_api_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicRandByRandomnessRequest.ProtoReflect.Descriptor instead.
+func (*PublicRandByRandomnessRequest) Descriptor() ([]byte, []int) {
+	return file_drand_api_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PublicRandByRandomnessRequest) GetRandomness() []byte {
+	if x != nil {
+		return x.Randomness
+	}
+	return nil
+}
+
+func (x *PublicRandByRandomnessRequest) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
 type ListBeaconIDsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -181,7 +314,7 @@ type ListBeaconIDsRequest struct {
 func (x *ListBeaconIDsRequest) Reset() {
 	*x = ListBeaconIDsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_drand_api_proto_msgTypes[2]
+		mi := &file_drand_api_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -194,7 +327,7 @@ func (x *ListBeaconIDsRequest) String() string {
 func (*ListBeaconIDsRequest) ProtoMessage() {}
 
 func (x *ListBeaconIDsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_drand_api_proto_msgTypes[2]
+	mi := &file_drand_api_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -207,7 +340,7 @@ func (x *ListBeaconIDsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListBeaconIDsRequest.ProtoReflect.Descriptor instead.
 func (*ListBeaconIDsRequest) Descriptor() ([]byte, []int) {
-	return file_drand_api_proto_rawDescGZIP(), []int{2}
+	return file_drand_api_proto_rawDescGZIP(), []int{4}
 }
 
 type ListBeaconIDsResponse struct {
@@ -215,14 +348,17 @@ type ListBeaconIDsResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// the ids of the beacons loaded on the node
 	Ids       []string    `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
 	Metadatas []*Metadata `protobuf:"bytes,2,rep,name=metadatas,proto3" json:"metadatas,omitempty"`
+	// the beacons configured on the node, whether they're loaded or not, sorted by id
+	Beacons []*BeaconIDInfo `protobuf:"bytes,3,rep,name=beacons,proto3" json:"beacons,omitempty"`
 }
 
 func (x *ListBeaconIDsResponse) Reset() {
 	*x = ListBeaconIDsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_drand_api_proto_msgTypes[3]
+		mi := &file_drand_api_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -235,7 +371,7 @@ func (x *ListBeaconIDsResponse) String() string {
 func (*ListBeaconIDsResponse) ProtoMessage() {}
 
 func (x *ListBeaconIDsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_drand_api_proto_msgTypes[3]
+	mi := &file_drand_api_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -248,7 +384,7 @@ func (x *ListBeaconIDsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListBeaconIDsResponse.ProtoReflect.Descriptor instead.
 func (*ListBeaconIDsResponse) Descriptor() ([]byte, []int) {
-	return file_drand_api_proto_rawDescGZIP(), []int{3}
+	return file_drand_api_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ListBeaconIDsResponse) GetIds() []string {
@@ -265,146 +401,1774 @@ func (x *ListBeaconIDsResponse) GetMetadatas() []*Metadata {
 	return nil
 }
 
-var File_drand_api_proto protoreflect.FileDescriptor
+func (x *ListBeaconIDsResponse) GetBeacons() []*BeaconIDInfo {
+	if x != nil {
+		return x.Beacons
+	}
+	return nil
+}
 
-var file_drand_api_proto_rawDesc = []byte{
-	0x0a, 0x0f, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2f, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x12, 0x05, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x1a, 0x12, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2f,
-	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x56, 0x0a, 0x11,
-	0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x2b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e,
-	0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0x22, 0xc8, 0x01, 0x0a, 0x12, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52,
-	0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72,
-	0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e,
-	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12,
-	0x2d, 0x0a, 0x12, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x73, 0x69, 0x67, 0x6e,
-	0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x70, 0x72, 0x65,
-	0x76, 0x69, 0x6f, 0x75, 0x73, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x22,
-	0x0a, 0x0a, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x0c, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0a, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x6e, 0x65,
-	0x73, 0x73, 0x12, 0x2b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22,
-	0x16, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x58, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x42,
-	0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69,
-	0x64, 0x73, 0x12, 0x2d, 0x0a, 0x09, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x73, 0x18,
-	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x09, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0x73, 0x32, 0xa2, 0x02, 0x0a, 0x06, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x12, 0x41, 0x0a, 0x0a,
-	0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x12, 0x18, 0x2e, 0x64, 0x72, 0x61,
-	0x6e, 0x64, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x75, 0x62,
-	0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x49, 0x0a, 0x10, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x53, 0x74, 0x72,
-	0x65, 0x61, 0x6d, 0x12, 0x18, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x75, 0x62, 0x6c,
-	0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
-	0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x3c, 0x0a, 0x09, 0x43, 0x68,
-	0x61, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x17, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e,
-	0x43, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x16, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x6e,
-	0x66, 0x6f, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x4c, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74,
-	0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x73, 0x12, 0x1b, 0x2e, 0x64, 0x72, 0x61, 0x6e,
-	0x64, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4c,
-	0x69, 0x73, 0x74, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x2a, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2f, 0x64, 0x72, 0x61, 0x6e, 0x64,
-	0x2f, 0x76, 0x32, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x72, 0x61,
-	0x6e, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+// BeaconIDInfo describes a beacon configured on a node
+type BeaconIDInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// the scheme of the chain, or of the keys of the node before the first DKG
+	Scheme string `protobuf:"bytes,2,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	// empty until the first DKG of the beacon completed
+	ChainHash []byte `protobuf:"bytes,3,opt,name=chain_hash,json=chainHash,proto3" json:"chain_hash,omitempty"`
+	// the beacon process is loaded by the daemon
+	Loaded bool `protobuf:"varint,4,opt,name=loaded,proto3" json:"loaded,omitempty"`
+	// the node produces the beacons of the chain
+	Running bool `protobuf:"varint,5,opt,name=running,proto3" json:"running,omitempty"`
 }
 
-var (
-	file_drand_api_proto_rawDescOnce sync.Once
-	file_drand_api_proto_rawDescData = file_drand_api_proto_rawDesc
-)
+func (x *BeaconIDInfo) Reset() {
+	*x = BeaconIDInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_api_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_drand_api_proto_rawDescGZIP() []byte {
-	file_drand_api_proto_rawDescOnce.Do(func() {
-		file_drand_api_proto_rawDescData = protoimpl.X.CompressGZIP(file_drand_api_proto_rawDescData)
-	})
-	return file_drand_api_proto_rawDescData
+func (x *BeaconIDInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_drand_api_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
-var file_drand_api_proto_goTypes = []interface{}{
-	(*PublicRandRequest)(nil),     // 0: drand.PublicRandRequest
-	(*PublicRandResponse)(nil),    // 1: drand.PublicRandResponse
-	(*ListBeaconIDsRequest)(nil),  // 2: drand.ListBeaconIDsRequest
-	(*ListBeaconIDsResponse)(nil), // 3: drand.ListBeaconIDsResponse
-	(*Metadata)(nil),              // 4: drand.Metadata
-	(*ChainInfoRequest)(nil),      // 5: drand.ChainInfoRequest
-	(*ChainInfoPacket)(nil),       // 6: drand.ChainInfoPacket
+func (*BeaconIDInfo) ProtoMessage() {}
+
+func (x *BeaconIDInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_api_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_drand_api_proto_depIdxs = []int32{
-	4, // 0: drand.PublicRandRequest.metadata:type_name -> drand.Metadata
-	4, // 1: drand.PublicRandResponse.metadata:type_name -> drand.Metadata
-	4, // 2: drand.ListBeaconIDsResponse.metadatas:type_name -> drand.Metadata
-	0, // 3: drand.Public.PublicRand:input_type -> drand.PublicRandRequest
-	0, // 4: drand.Public.PublicRandStream:input_type -> drand.PublicRandRequest
-	5, // 5: drand.Public.ChainInfo:input_type -> drand.ChainInfoRequest
-	2, // 6: drand.Public.ListBeaconIDs:input_type -> drand.ListBeaconIDsRequest
-	1, // 7: drand.Public.PublicRand:output_type -> drand.PublicRandResponse
-	1, // 8: drand.Public.PublicRandStream:output_type -> drand.PublicRandResponse
-	6, // 9: drand.Public.ChainInfo:output_type -> drand.ChainInfoPacket
-	3, // 10: drand.Public.ListBeaconIDs:output_type -> drand.ListBeaconIDsResponse
-	7, // [7:11] is the sub-list for method output_type
-	3, // [3:7] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+
+// Deprecated: Use BeaconIDInfo.ProtoReflect.Descriptor instead.
+func (*BeaconIDInfo) Descriptor() ([]byte, []int) {
+	return file_drand_api_proto_rawDescGZIP(), []int{6}
 }
 
-func init() { file_drand_api_proto_init() }
-func file_drand_api_proto_init() {
-	if File_drand_api_proto != nil {
-		return
+func (x *BeaconIDInfo) GetId() string {
+	if x != nil {
+		return x.Id
 	}
-	file_drand_common_proto_init()
-	if !protoimpl.UnsafeEnabled {
-		file_drand_api_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PublicRandRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_drand_api_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PublicRandResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return ""
+}
+
+func (x *BeaconIDInfo) GetScheme() string {
+	if x != nil {
+		return x.Scheme
+	}
+	return ""
+}
+
+func (x *BeaconIDInfo) GetChainHash() []byte {
+	if x != nil {
+		return x.ChainHash
+	}
+	return nil
+}
+
+func (x *BeaconIDInfo) GetLoaded() bool {
+	if x != nil {
+		return x.Loaded
+	}
+	return false
+}
+
+func (x *BeaconIDInfo) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+type HeartbeatRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata *Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *HeartbeatRequest) Reset() {
+	*x = HeartbeatRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_api_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatRequest) ProtoMessage() {}
+
+func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_api_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_drand_api_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListBeaconIDsRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_drand_api_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *HeartbeatRequest) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// HeartbeatPacket is a low-frequency message threshold-signed by the group over its operational metadata. The
+// signature can be verified with the distributed public key over the message
+// sha256("drand-heartbeat:" || beaconID || ":" || index || tip_round || tip_signature || group_hash), with the
+// integers encoded as 8 big-endian bytes.
+type HeartbeatPacket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the number of heartbeat periods elapsed since the genesis of the chain
+	Index uint64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// the UNIX time of the heartbeat
+	Time         int64     `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	TipRound     uint64    `protobuf:"varint,3,opt,name=tip_round,json=tipRound,proto3" json:"tip_round,omitempty"`
+	TipSignature []byte    `protobuf:"bytes,4,opt,name=tip_signature,json=tipSignature,proto3" json:"tip_signature,omitempty"`
+	GroupHash    []byte    `protobuf:"bytes,5,opt,name=group_hash,json=groupHash,proto3" json:"group_hash,omitempty"`
+	Signature    []byte    `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	Metadata     *Metadata `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *HeartbeatPacket) Reset() {
+	*x = HeartbeatPacket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_api_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HeartbeatPacket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatPacket) ProtoMessage() {}
+
+func (x *HeartbeatPacket) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_api_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_drand_api_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatPacket.ProtoReflect.Descriptor instead.
+func (*HeartbeatPacket) Descriptor() ([]byte, []int) {
+	return file_drand_api_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *HeartbeatPacket) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *HeartbeatPacket) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *HeartbeatPacket) GetTipRound() uint64 {
+	if x != nil {
+		return x.TipRound
+	}
+	return 0
+}
+
+func (x *HeartbeatPacket) GetTipSignature() []byte {
+	if x != nil {
+		return x.TipSignature
+	}
+	return nil
+}
+
+func (x *HeartbeatPacket) GetGroupHash() []byte {
+	if x != nil {
+		return x.GroupHash
+	}
+	return nil
+}
+
+func (x *HeartbeatPacket) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *HeartbeatPacket) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type SubBeaconsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata *Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *SubBeaconsRequest) Reset() {
+	*x = SubBeaconsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_api_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubBeaconsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubBeaconsRequest) ProtoMessage() {}
+
+func (x *SubBeaconsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_api_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubBeaconsRequest.ProtoReflect.Descriptor instead.
+func (*SubBeaconsRequest) Descriptor() ([]byte, []int) {
+	return file_drand_api_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SubBeaconsRequest) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// SubBeaconInfo describes a randomness stream derived from the distributed key of the group. Its rounds are
+// unchained: the signature of a round can be verified with the distributed public key of the chain over the message
+// sha256("drand-sub-beacon:" || beaconID || ":" || name || ":" || round), with the round encoded as 8 big-endian
+// bytes, using the scheme of the chain.
+type SubBeaconInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// period in seconds
+	Period uint32 `protobuf:"varint,2,opt,name=period,proto3" json:"period,omitempty"`
+	// the time of the first round, which is the genesis time of the chain
+	GenesisTime int64 `protobuf:"varint,3,opt,name=genesis_time,json=genesisTime,proto3" json:"genesis_time,omitempty"`
+}
+
+func (x *SubBeaconInfo) Reset() {
+	*x = SubBeaconInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_api_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubBeaconInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubBeaconInfo) ProtoMessage() {}
+
+func (x *SubBeaconInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_api_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubBeaconInfo.ProtoReflect.Descriptor instead.
+func (*SubBeaconInfo) Descriptor() ([]byte, []int) {
+	return file_drand_api_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SubBeaconInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SubBeaconInfo) GetPeriod() uint32 {
+	if x != nil {
+		return x.Period
+	}
+	return 0
+}
+
+func (x *SubBeaconInfo) GetGenesisTime() int64 {
+	if x != nil {
+		return x.GenesisTime
+	}
+	return 0
+}
+
+type SubBeaconsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubBeacons []*SubBeaconInfo `protobuf:"bytes,1,rep,name=sub_beacons,json=subBeacons,proto3" json:"sub_beacons,omitempty"`
+	Metadata   *Metadata        `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *SubBeaconsResponse) Reset() {
+	*x = SubBeaconsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_api_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubBeaconsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubBeaconsResponse) ProtoMessage() {}
+
+func (x *SubBeaconsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_api_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubBeaconsResponse.ProtoReflect.Descriptor instead.
+func (*SubBeaconsResponse) Descriptor() ([]byte, []int) {
+	return file_drand_api_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SubBeaconsResponse) GetSubBeacons() []*SubBeaconInfo {
+	if x != nil {
+		return x.SubBeacons
+	}
+	return nil
+}
+
+func (x *SubBeaconsResponse) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type DerivedChainsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata *Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *DerivedChainsRequest) Reset() {
+	*x = DerivedChainsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_api_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DerivedChainsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DerivedChainsRequest) ProtoMessage() {}
+
+func (x *DerivedChainsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_api_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DerivedChainsRequest.ProtoReflect.Descriptor instead.
+func (*DerivedChainsRequest) Descriptor() ([]byte, []int) {
+	return file_drand_api_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DerivedChainsRequest) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// DerivedChainInfo describes a chain derived from the beacons of a chain. Each of its rounds covers a window of
+// consecutive rounds of the chain, and its digest is
+// sha256("drand-derived:" || beaconID || ":" || name || ":" || round || previous_digest || signatures), with the round
+// encoded as 8 big-endian bytes and the signatures of the rounds of the window in order. The previous digest of the
+// first round is the hash of the chain, so that anyone can verify a derived round from the beacons of the chain.
+type DerivedChainInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// the kind of the derivation: "every", a round every that many rounds of the chain, or "bucket", a round every
+	// period of time, the windows being aligned on the multiples of the period since the UNIX epoch, e.g. UTC days
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// the parameter of the derivation: the number of rounds of the windows, or their period, e.g. 24h
+	Param string `protobuf:"bytes,3,opt,name=param,proto3" json:"param,omitempty"`
+	// the hash of the chain it is derived from, which is the previous digest of its first round
+	ChainHash []byte `protobuf:"bytes,4,opt,name=chain_hash,json=chainHash,proto3" json:"chain_hash,omitempty"`
+	// the last round derived so far, 0 if none
+	LastRound uint64 `protobuf:"varint,5,opt,name=last_round,json=lastRound,proto3" json:"last_round,omitempty"`
+}
+
+func (x *DerivedChainInfo) Reset() {
+	*x = DerivedChainInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_api_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DerivedChainInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DerivedChainInfo) ProtoMessage() {}
+
+func (x *DerivedChainInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_api_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DerivedChainInfo.ProtoReflect.Descriptor instead.
+func (*DerivedChainInfo) Descriptor() ([]byte, []int) {
+	return file_drand_api_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DerivedChainInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DerivedChainInfo) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DerivedChainInfo) GetParam() string {
+	if x != nil {
+		return x.Param
+	}
+	return ""
+}
+
+func (x *DerivedChainInfo) GetChainHash() []byte {
+	if x != nil {
+		return x.ChainHash
+	}
+	return nil
+}
+
+func (x *DerivedChainInfo) GetLastRound() uint64 {
+	if x != nil {
+		return x.LastRound
+	}
+	return 0
+}
+
+type DerivedChainsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DerivedChains []*DerivedChainInfo `protobuf:"bytes,1,rep,name=derived_chains,json=derivedChains,proto3" json:"derived_chains,omitempty"`
+	Metadata      *Metadata           `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *DerivedChainsResponse) Reset() {
+	*x = DerivedChainsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_api_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DerivedChainsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DerivedChainsResponse) ProtoMessage() {}
+
+func (x *DerivedChainsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_api_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DerivedChainsResponse.ProtoReflect.Descriptor instead.
+func (*DerivedChainsResponse) Descriptor() ([]byte, []int) {
+	return file_drand_api_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DerivedChainsResponse) GetDerivedChains() []*DerivedChainInfo {
+	if x != nil {
+		return x.DerivedChains
+	}
+	return nil
+}
+
+func (x *DerivedChainsResponse) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type DerivedRandRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the name of the derived chain
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// the round of the derived chain, the last one if 0
+	Round    uint64    `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Metadata *Metadata `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *DerivedRandRequest) Reset() {
+	*x = DerivedRandRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_api_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DerivedRandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DerivedRandRequest) ProtoMessage() {}
+
+func (x *DerivedRandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_api_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DerivedRandRequest.ProtoReflect.Descriptor instead.
+func (*DerivedRandRequest) Descriptor() ([]byte, []int) {
+	return file_drand_api_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DerivedRandRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DerivedRandRequest) GetRound() uint64 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *DerivedRandRequest) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// DerivedRandResponse is a round of a derived chain, see DerivedChainInfo for how to verify it
+type DerivedRandResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name           string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Round          uint64 `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Digest         []byte `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`
+	PreviousDigest []byte `protobuf:"bytes,4,opt,name=previous_digest,json=previousDigest,proto3" json:"previous_digest,omitempty"`
+	// the first and last rounds of the chain the digest was computed from
+	FromRound uint64    `protobuf:"varint,5,opt,name=from_round,json=fromRound,proto3" json:"from_round,omitempty"`
+	ToRound   uint64    `protobuf:"varint,6,opt,name=to_round,json=toRound,proto3" json:"to_round,omitempty"`
+	Metadata  *Metadata `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *DerivedRandResponse) Reset() {
+	*x = DerivedRandResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_api_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DerivedRandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DerivedRandResponse) ProtoMessage() {}
+
+func (x *DerivedRandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_api_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DerivedRandResponse.ProtoReflect.Descriptor instead.
+func (*DerivedRandResponse) Descriptor() ([]byte, []int) {
+	return file_drand_api_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DerivedRandResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DerivedRandResponse) GetRound() uint64 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *DerivedRandResponse) GetDigest() []byte {
+	if x != nil {
+		return x.Digest
+	}
+	return nil
+}
+
+func (x *DerivedRandResponse) GetPreviousDigest() []byte {
+	if x != nil {
+		return x.PreviousDigest
+	}
+	return nil
+}
+
+func (x *DerivedRandResponse) GetFromRound() uint64 {
+	if x != nil {
+		return x.FromRound
+	}
+	return 0
+}
+
+func (x *DerivedRandResponse) GetToRound() uint64 {
+	if x != nil {
+		return x.ToRound
+	}
+	return 0
+}
+
+func (x *DerivedRandResponse) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type GroupMembershipRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata *Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *GroupMembershipRequest) Reset() {
+	*x = GroupMembershipRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_api_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupMembershipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupMembershipRequest) ProtoMessage() {}
+
+func (x *GroupMembershipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_api_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupMembershipRequest.ProtoReflect.Descriptor instead.
+func (*GroupMembershipRequest) Descriptor() ([]byte, []int) {
+	return file_drand_api_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GroupMembershipRequest) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// GroupEpoch is the composition of the group after the DKG of an epoch
+type GroupEpoch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Epoch uint32 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// the first round produced by this group
+	TransitionRound uint64 `protobuf:"varint,2,opt,name=transition_round,json=transitionRound,proto3" json:"transition_round,omitempty"`
+	// the hash of the group, which the heartbeats commit to
+	GroupHash []byte `protobuf:"bytes,3,opt,name=group_hash,json=groupHash,proto3" json:"group_hash,omitempty"`
+	// the group file, with the addresses and public keys of the members and the threshold
+	Group *GroupPacket `protobuf:"bytes,4,opt,name=group,proto3" json:"group,omitempty"`
+}
+
+func (x *GroupEpoch) Reset() {
+	*x = GroupEpoch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_api_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupEpoch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupEpoch) ProtoMessage() {}
+
+func (x *GroupEpoch) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_api_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupEpoch.ProtoReflect.Descriptor instead.
+func (*GroupEpoch) Descriptor() ([]byte, []int) {
+	return file_drand_api_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GroupEpoch) GetEpoch() uint32 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *GroupEpoch) GetTransitionRound() uint64 {
+	if x != nil {
+		return x.TransitionRound
+	}
+	return 0
+}
+
+func (x *GroupEpoch) GetGroupHash() []byte {
+	if x != nil {
+		return x.GroupHash
+	}
+	return nil
+}
+
+func (x *GroupEpoch) GetGroup() *GroupPacket {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+type GroupMembershipResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Current *GroupEpoch `protobuf:"bytes,1,opt,name=current,proto3" json:"current,omitempty"`
+	// the compositions of the group known to the node, by increasing epoch, the current one included. Nodes which
+	// completed their DKGs before the history was recorded only know the current one.
+	History []*GroupEpoch `protobuf:"bytes,2,rep,name=history,proto3" json:"history,omitempty"`
+	// the last heartbeat signed by the group, if it commits to the current group: its threshold signature over the
+	// group hash attests the composition of the group
+	Heartbeat *HeartbeatPacket `protobuf:"bytes,3,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`
+	Metadata  *Metadata        `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *GroupMembershipResponse) Reset() {
+	*x = GroupMembershipResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_api_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupMembershipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupMembershipResponse) ProtoMessage() {}
+
+func (x *GroupMembershipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_api_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupMembershipResponse.ProtoReflect.Descriptor instead.
+func (*GroupMembershipResponse) Descriptor() ([]byte, []int) {
+	return file_drand_api_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GroupMembershipResponse) GetCurrent() *GroupEpoch {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *GroupMembershipResponse) GetHistory() []*GroupEpoch {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *GroupMembershipResponse) GetHeartbeat() *HeartbeatPacket {
+	if x != nil {
+		return x.Heartbeat
+	}
+	return nil
+}
+
+func (x *GroupMembershipResponse) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type ChainSummaryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata *Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *ChainSummaryRequest) Reset() {
+	*x = ChainSummaryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_api_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChainSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChainSummaryRequest) ProtoMessage() {}
+
+func (x *ChainSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_api_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChainSummaryRequest.ProtoReflect.Descriptor instead.
+func (*ChainSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_drand_api_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ChainSummaryRequest) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// ChainCorrection is an event through which the node corrected its copy of the chain, such as the repair of its store
+type ChainCorrection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time   int64  `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	Kind   string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Detail string `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+}
+
+func (x *ChainCorrection) Reset() {
+	*x = ChainCorrection{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_api_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChainCorrection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChainCorrection) ProtoMessage() {}
+
+func (x *ChainCorrection) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_api_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChainCorrection.ProtoReflect.Descriptor instead.
+func (*ChainCorrection) Descriptor() ([]byte, []int) {
+	return file_drand_api_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ChainCorrection) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *ChainCorrection) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ChainCorrection) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+type ChainSummaryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	HeadRound     uint64 `protobuf:"varint,1,opt,name=head_round,json=headRound,proto3" json:"head_round,omitempty"`
+	HeadSignature []byte `protobuf:"bytes,2,opt,name=head_signature,json=headSignature,proto3" json:"head_signature,omitempty"`
+	// the time the head round was due
+	HeadTime    int64 `protobuf:"varint,3,opt,name=head_time,json=headTime,proto3" json:"head_time,omitempty"`
+	GenesisTime int64 `protobuf:"varint,4,opt,name=genesis_time,json=genesisTime,proto3" json:"genesis_time,omitempty"`
+	// the period of the chain, in seconds
+	Period uint32 `protobuf:"varint,5,opt,name=period,proto3" json:"period,omitempty"`
+	// the round the chain should be at according to the clock of the node
+	ExpectedRound uint64 `protobuf:"varint,6,opt,name=expected_round,json=expectedRound,proto3" json:"expected_round,omitempty"`
+	// the number of rounds stored by the node
+	TotalRounds uint64 `protobuf:"varint,7,opt,name=total_rounds,json=totalRounds,proto3" json:"total_rounds,omitempty"`
+	// the number of rounds stored for the last day, out of the number of rounds due in that day
+	RoundsLastDay         uint64 `protobuf:"varint,8,opt,name=rounds_last_day,json=roundsLastDay,proto3" json:"rounds_last_day,omitempty"`
+	ExpectedRoundsLastDay uint64 `protobuf:"varint,9,opt,name=expected_rounds_last_day,json=expectedRoundsLastDay,proto3" json:"expected_rounds_last_day,omitempty"`
+	// the last correction of the chain since the node started, if any
+	LastCorrection *ChainCorrection `protobuf:"bytes,10,opt,name=last_correction,json=lastCorrection,proto3" json:"last_correction,omitempty"`
+	Metadata       *Metadata        `protobuf:"bytes,11,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *ChainSummaryResponse) Reset() {
+	*x = ChainSummaryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_api_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChainSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChainSummaryResponse) ProtoMessage() {}
+
+func (x *ChainSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_api_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChainSummaryResponse.ProtoReflect.Descriptor instead.
+func (*ChainSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_drand_api_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ChainSummaryResponse) GetHeadRound() uint64 {
+	if x != nil {
+		return x.HeadRound
+	}
+	return 0
+}
+
+func (x *ChainSummaryResponse) GetHeadSignature() []byte {
+	if x != nil {
+		return x.HeadSignature
+	}
+	return nil
+}
+
+func (x *ChainSummaryResponse) GetHeadTime() int64 {
+	if x != nil {
+		return x.HeadTime
+	}
+	return 0
+}
+
+func (x *ChainSummaryResponse) GetGenesisTime() int64 {
+	if x != nil {
+		return x.GenesisTime
+	}
+	return 0
+}
+
+func (x *ChainSummaryResponse) GetPeriod() uint32 {
+	if x != nil {
+		return x.Period
+	}
+	return 0
+}
+
+func (x *ChainSummaryResponse) GetExpectedRound() uint64 {
+	if x != nil {
+		return x.ExpectedRound
+	}
+	return 0
+}
+
+func (x *ChainSummaryResponse) GetTotalRounds() uint64 {
+	if x != nil {
+		return x.TotalRounds
+	}
+	return 0
+}
+
+func (x *ChainSummaryResponse) GetRoundsLastDay() uint64 {
+	if x != nil {
+		return x.RoundsLastDay
+	}
+	return 0
+}
+
+func (x *ChainSummaryResponse) GetExpectedRoundsLastDay() uint64 {
+	if x != nil {
+		return x.ExpectedRoundsLastDay
+	}
+	return 0
+}
+
+func (x *ChainSummaryResponse) GetLastCorrection() *ChainCorrection {
+	if x != nil {
+		return x.LastCorrection
+	}
+	return nil
+}
+
+func (x *ChainSummaryResponse) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type HomeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata *Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *HomeRequest) Reset() {
+	*x = HomeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_api_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HomeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HomeRequest) ProtoMessage() {}
+
+func (x *HomeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_api_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HomeRequest.ProtoReflect.Descriptor instead.
+func (*HomeRequest) Descriptor() ([]byte, []int) {
+	return file_drand_api_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *HomeRequest) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type HomeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status   string    `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Metadata *Metadata `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *HomeResponse) Reset() {
+	*x = HomeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_api_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HomeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HomeResponse) ProtoMessage() {}
+
+func (x *HomeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_api_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HomeResponse.ProtoReflect.Descriptor instead.
+func (*HomeResponse) Descriptor() ([]byte, []int) {
+	return file_drand_api_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *HomeResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *HomeResponse) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+var File_drand_api_proto protoreflect.FileDescriptor
+
+var file_drand_api_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2f, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x05, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x1a, 0x12, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2f,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa1, 0x01, 0x0a,
+	0x11, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x2b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61,
+	0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x2a, 0x0a, 0x11, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x74, 0x61,
+	0x6c, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0f, 0x6d, 0x61, 0x78, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x5f, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x62, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e,
+	0x22, 0xc8, 0x01, 0x0a, 0x12, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x70,
+	0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75,
+	0x73, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x22, 0x0a, 0x0a, 0x72, 0x61,
+	0x6e, 0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x02,
+	0x18, 0x01, 0x52, 0x0a, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x2b,
+	0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x60, 0x0a, 0x13, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x41, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x12, 0x2b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x6c, 0x0a,
+	0x1d, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x42, 0x79, 0x52, 0x61, 0x6e,
+	0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e,
+	0x0a, 0x0a, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0a, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x2b,
+	0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x16, 0x0a, 0x14, 0x4c,
+	0x69, 0x73, 0x74, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x87, 0x01, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x65, 0x61, 0x63,
+	0x6f, 0x6e, 0x49, 0x44, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a,
+	0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x12,
+	0x2d, 0x0a, 0x09, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x52, 0x09, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x73, 0x12, 0x2d,
+	0x0a, 0x07, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x73, 0x22, 0x87, 0x01,
+	0x0a, 0x0c, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x48, 0x61, 0x73, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0x3f, 0x0a, 0x10, 0x48, 0x65, 0x61, 0x72, 0x74,
+	0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x08, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0xe7, 0x01, 0x0a, 0x0f, 0x48, 0x65, 0x61,
+	0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x70, 0x5f, 0x72, 0x6f,
+	0x75, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x74, 0x69, 0x70, 0x52, 0x6f,
+	0x75, 0x6e, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x69, 0x70, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x74, 0x69, 0x70, 0x53,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x2b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x22, 0x40, 0x0a, 0x11, 0x53, 0x75, 0x62, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e,
+	0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x22, 0x5e, 0x0a, 0x0d, 0x53, 0x75, 0x62, 0x42, 0x65, 0x61, 0x63, 0x6f,
+	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x72,
+	0x69, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f,
+	0x64, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x67, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73,
+	0x54, 0x69, 0x6d, 0x65, 0x22, 0x78, 0x0a, 0x12, 0x53, 0x75, 0x62, 0x42, 0x65, 0x61, 0x63, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x0b, 0x73, 0x75,
+	0x62, 0x5f, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x53, 0x75, 0x62, 0x42, 0x65, 0x61, 0x63, 0x6f,
+	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e,
+	0x73, 0x12, 0x2b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x43,
+	0x0a, 0x14, 0x44, 0x65, 0x72, 0x69, 0x76, 0x65, 0x64, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64,
+	0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x22, 0x8e, 0x01, 0x0a, 0x10, 0x44, 0x65, 0x72, 0x69, 0x76, 0x65, 0x64, 0x43,
+	0x68, 0x61, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x72, 0x6f,
+	0x75, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x52,
+	0x6f, 0x75, 0x6e, 0x64, 0x22, 0x84, 0x01, 0x0a, 0x15, 0x44, 0x65, 0x72, 0x69, 0x76, 0x65, 0x64,
+	0x43, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e,
+	0x0a, 0x0e, 0x64, 0x65, 0x72, 0x69, 0x76, 0x65, 0x64, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x44,
+	0x65, 0x72, 0x69, 0x76, 0x65, 0x64, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x0d, 0x64, 0x65, 0x72, 0x69, 0x76, 0x65, 0x64, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x2b,
+	0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x6b, 0x0a, 0x12, 0x44,
+	0x65, 0x72, 0x69, 0x76, 0x65, 0x64, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x2b, 0x0a, 0x08, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0xe7, 0x01, 0x0a, 0x13, 0x44, 0x65, 0x72,
+	0x69, 0x76, 0x65, 0x64, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x64,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x70, 0x72, 0x65,
+	0x76, 0x69, 0x6f, 0x75, 0x73, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x66,
+	0x72, 0x6f, 0x6d, 0x5f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x66, 0x72, 0x6f, 0x6d, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x6f,
+	0x5f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x74, 0x6f,
+	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x2b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x22, 0x45, 0x0a, 0x16, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x96, 0x01, 0x0a, 0x0a, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x29,
+	0x0a, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x6f, 0x75,
+	0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x48, 0x61, 0x73, 0x68, 0x12, 0x28, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x05, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x22, 0xd6, 0x01, 0x0a, 0x17, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b,
+	0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x45, 0x70, 0x6f,
+	0x63, 0x68, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x07, 0x68,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x64,
+	0x72, 0x61, 0x6e, 0x64, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x52,
+	0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x34, 0x0a, 0x09, 0x68, 0x65, 0x61, 0x72,
+	0x74, 0x62, 0x65, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x64, 0x72,
+	0x61, 0x6e, 0x64, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x50, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x52, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x2b,
+	0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x42, 0x0a, 0x13, 0x43,
+	0x68, 0x61, 0x69, 0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x2b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22,
+	0x51, 0x0a, 0x0f, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x22, 0xcd, 0x03, 0x0a, 0x14, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x75, 0x6d, 0x6d,
+	0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x68,
+	0x65, 0x61, 0x64, 0x5f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x68, 0x65, 0x61, 0x64, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x68, 0x65,
+	0x61, 0x64, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0d, 0x68, 0x65, 0x61, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x65, 0x61, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x68, 0x65, 0x61, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x21,
+	0x0a, 0x0c, 0x67, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x67, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x78, 0x70,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0d, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x6f, 0x75, 0x6e, 0x64,
+	0x12, 0x21, 0x0a, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x6f, 0x75,
+	0x6e, 0x64, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x5f, 0x6c, 0x61,
+	0x73, 0x74, 0x5f, 0x64, 0x61, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x72, 0x6f,
+	0x75, 0x6e, 0x64, 0x73, 0x4c, 0x61, 0x73, 0x74, 0x44, 0x61, 0x79, 0x12, 0x37, 0x0a, 0x18, 0x65,
+	0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x5f, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x64, 0x61, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x15, 0x65,
+	0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x4c, 0x61, 0x73,
+	0x74, 0x44, 0x61, 0x79, 0x12, 0x3f, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x72,
+	0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x43, 0x6f, 0x72, 0x72, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x43, 0x6f, 0x72, 0x72, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x22, 0x3a, 0x0a, 0x0b, 0x48, 0x6f, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x2b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x53,
+	0x0a, 0x0c, 0x48, 0x6f, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64,
+	0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x32, 0xfb, 0x07, 0x0a, 0x06, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x12, 0x41,
+	0x0a, 0x0a, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x12, 0x18, 0x2e, 0x64,
+	0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x49, 0x0a, 0x10, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x18, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x19, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61,
+	0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x3c, 0x0a, 0x09,
+	0x43, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x17, 0x2e, 0x64, 0x72, 0x61, 0x6e,
+	0x64, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e,
+	0x49, 0x6e, 0x66, 0x6f, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x4c, 0x0a, 0x0d, 0x4c, 0x69,
+	0x73, 0x74, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x73, 0x12, 0x1b, 0x2e, 0x64, 0x72,
+	0x61, 0x6e, 0x64, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x49, 0x44, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x72,
+	0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x17, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x48, 0x65,
+	0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
+	0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
+	0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0a, 0x53, 0x75, 0x62, 0x42,
+	0x65, 0x61, 0x63, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x53,
+	0x75, 0x62, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x19, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x53, 0x75, 0x62, 0x42, 0x65, 0x61, 0x63,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a,
+	0x0f, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70,
+	0x12, 0x1d, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1e, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d,
+	0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x49, 0x0a, 0x0c, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72,
+	0x79, 0x12, 0x1a, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53,
+	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
+	0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61,
+	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x04,
+	0x48, 0x6f, 0x6d, 0x65, 0x12, 0x12, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x48, 0x6f, 0x6d,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64,
+	0x2e, 0x48, 0x6f, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x44, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x12, 0x1a, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x43, 0x61, 0x70, 0x61, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13,
+	0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0c, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52,
+	0x61, 0x6e, 0x64, 0x41, 0x74, 0x12, 0x1a, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x41, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x52, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5b,
+	0x0a, 0x16, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x42, 0x79, 0x52, 0x61,
+	0x6e, 0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x24, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64,
+	0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x42, 0x79, 0x52, 0x61, 0x6e,
+	0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e,
+	0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x0d, 0x44,
+	0x65, 0x72, 0x69, 0x76, 0x65, 0x64, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x1b, 0x2e, 0x64,
+	0x72, 0x61, 0x6e, 0x64, 0x2e, 0x44, 0x65, 0x72, 0x69, 0x76, 0x65, 0x64, 0x43, 0x68, 0x61, 0x69,
+	0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x64, 0x72, 0x61, 0x6e,
+	0x64, 0x2e, 0x44, 0x65, 0x72, 0x69, 0x76, 0x65, 0x64, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x0b, 0x44, 0x65, 0x72,
+	0x69, 0x76, 0x65, 0x64, 0x52, 0x61, 0x6e, 0x64, 0x12, 0x19, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64,
+	0x2e, 0x44, 0x65, 0x72, 0x69, 0x76, 0x65, 0x64, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x44, 0x65, 0x72, 0x69,
+	0x76, 0x65, 0x64, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x42, 0x2a, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x64, 0x72, 0x61, 0x6e, 0x64, 0x2f, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2f, 0x76, 0x32, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_drand_api_proto_rawDescOnce sync.Once
+	file_drand_api_proto_rawDescData = file_drand_api_proto_rawDesc
+)
+
+func file_drand_api_proto_rawDescGZIP() []byte {
+	file_drand_api_proto_rawDescOnce.Do(func() {
+		file_drand_api_proto_rawDescData = protoimpl.X.CompressGZIP(file_drand_api_proto_rawDescData)
+	})
+	return file_drand_api_proto_rawDescData
+}
+
+var file_drand_api_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_drand_api_proto_goTypes = []interface{}{
+	(*PublicRandRequest)(nil),             // 0: drand.PublicRandRequest
+	(*PublicRandResponse)(nil),            // 1: drand.PublicRandResponse
+	(*PublicRandAtRequest)(nil),           // 2: drand.PublicRandAtRequest
+	(*PublicRandByRandomnessRequest)(nil), // 3: drand.PublicRandByRandomnessRequest
+	(*ListBeaconIDsRequest)(nil),          // 4: drand.ListBeaconIDsRequest
+	(*ListBeaconIDsResponse)(nil),         // 5: drand.ListBeaconIDsResponse
+	(*BeaconIDInfo)(nil),                  // 6: drand.BeaconIDInfo
+	(*HeartbeatRequest)(nil),              // 7: drand.HeartbeatRequest
+	(*HeartbeatPacket)(nil),               // 8: drand.HeartbeatPacket
+	(*SubBeaconsRequest)(nil),             // 9: drand.SubBeaconsRequest
+	(*SubBeaconInfo)(nil),                 // 10: drand.SubBeaconInfo
+	(*SubBeaconsResponse)(nil),            // 11: drand.SubBeaconsResponse
+	(*DerivedChainsRequest)(nil),          // 12: drand.DerivedChainsRequest
+	(*DerivedChainInfo)(nil),              // 13: drand.DerivedChainInfo
+	(*DerivedChainsResponse)(nil),         // 14: drand.DerivedChainsResponse
+	(*DerivedRandRequest)(nil),            // 15: drand.DerivedRandRequest
+	(*DerivedRandResponse)(nil),           // 16: drand.DerivedRandResponse
+	(*GroupMembershipRequest)(nil),        // 17: drand.GroupMembershipRequest
+	(*GroupEpoch)(nil),                    // 18: drand.GroupEpoch
+	(*GroupMembershipResponse)(nil),       // 19: drand.GroupMembershipResponse
+	(*ChainSummaryRequest)(nil),           // 20: drand.ChainSummaryRequest
+	(*ChainCorrection)(nil),               // 21: drand.ChainCorrection
+	(*ChainSummaryResponse)(nil),          // 22: drand.ChainSummaryResponse
+	(*HomeRequest)(nil),                   // 23: drand.HomeRequest
+	(*HomeResponse)(nil),                  // 24: drand.HomeResponse
+	(*Metadata)(nil),                      // 25: drand.Metadata
+	(*GroupPacket)(nil),                   // 26: drand.GroupPacket
+	(*ChainInfoRequest)(nil),              // 27: drand.ChainInfoRequest
+	(*CapabilitiesRequest)(nil),           // 28: drand.CapabilitiesRequest
+	(*ChainInfoPacket)(nil),               // 29: drand.ChainInfoPacket
+	(*Capabilities)(nil),                  // 30: drand.Capabilities
+}
+var file_drand_api_proto_depIdxs = []int32{
+	25, // 0: drand.PublicRandRequest.metadata:type_name -> drand.Metadata
+	25, // 1: drand.PublicRandResponse.metadata:type_name -> drand.Metadata
+	25, // 2: drand.PublicRandAtRequest.metadata:type_name -> drand.Metadata
+	25, // 3: drand.PublicRandByRandomnessRequest.metadata:type_name -> drand.Metadata
+	25, // 4: drand.ListBeaconIDsResponse.metadatas:type_name -> drand.Metadata
+	6,  // 5: drand.ListBeaconIDsResponse.beacons:type_name -> drand.BeaconIDInfo
+	25, // 6: drand.HeartbeatRequest.metadata:type_name -> drand.Metadata
+	25, // 7: drand.HeartbeatPacket.metadata:type_name -> drand.Metadata
+	25, // 8: drand.SubBeaconsRequest.metadata:type_name -> drand.Metadata
+	10, // 9: drand.SubBeaconsResponse.sub_beacons:type_name -> drand.SubBeaconInfo
+	25, // 10: drand.SubBeaconsResponse.metadata:type_name -> drand.Metadata
+	25, // 11: drand.DerivedChainsRequest.metadata:type_name -> drand.Metadata
+	13, // 12: drand.DerivedChainsResponse.derived_chains:type_name -> drand.DerivedChainInfo
+	25, // 13: drand.DerivedChainsResponse.metadata:type_name -> drand.Metadata
+	25, // 14: drand.DerivedRandRequest.metadata:type_name -> drand.Metadata
+	25, // 15: drand.DerivedRandResponse.metadata:type_name -> drand.Metadata
+	25, // 16: drand.GroupMembershipRequest.metadata:type_name -> drand.Metadata
+	26, // 17: drand.GroupEpoch.group:type_name -> drand.GroupPacket
+	18, // 18: drand.GroupMembershipResponse.current:type_name -> drand.GroupEpoch
+	18, // 19: drand.GroupMembershipResponse.history:type_name -> drand.GroupEpoch
+	8,  // 20: drand.GroupMembershipResponse.heartbeat:type_name -> drand.HeartbeatPacket
+	25, // 21: drand.GroupMembershipResponse.metadata:type_name -> drand.Metadata
+	25, // 22: drand.ChainSummaryRequest.metadata:type_name -> drand.Metadata
+	21, // 23: drand.ChainSummaryResponse.last_correction:type_name -> drand.ChainCorrection
+	25, // 24: drand.ChainSummaryResponse.metadata:type_name -> drand.Metadata
+	25, // 25: drand.HomeRequest.metadata:type_name -> drand.Metadata
+	25, // 26: drand.HomeResponse.metadata:type_name -> drand.Metadata
+	0,  // 27: drand.Public.PublicRand:input_type -> drand.PublicRandRequest
+	0,  // 28: drand.Public.PublicRandStream:input_type -> drand.PublicRandRequest
+	27, // 29: drand.Public.ChainInfo:input_type -> drand.ChainInfoRequest
+	4,  // 30: drand.Public.ListBeaconIDs:input_type -> drand.ListBeaconIDsRequest
+	7,  // 31: drand.Public.Heartbeat:input_type -> drand.HeartbeatRequest
+	9,  // 32: drand.Public.SubBeacons:input_type -> drand.SubBeaconsRequest
+	17, // 33: drand.Public.GroupMembership:input_type -> drand.GroupMembershipRequest
+	20, // 34: drand.Public.ChainSummary:input_type -> drand.ChainSummaryRequest
+	23, // 35: drand.Public.Home:input_type -> drand.HomeRequest
+	28, // 36: drand.Public.GetCapabilities:input_type -> drand.CapabilitiesRequest
+	2,  // 37: drand.Public.PublicRandAt:input_type -> drand.PublicRandAtRequest
+	3,  // 38: drand.Public.PublicRandByRandomness:input_type -> drand.PublicRandByRandomnessRequest
+	12, // 39: drand.Public.DerivedChains:input_type -> drand.DerivedChainsRequest
+	15, // 40: drand.Public.DerivedRand:input_type -> drand.DerivedRandRequest
+	1,  // 41: drand.Public.PublicRand:output_type -> drand.PublicRandResponse
+	1,  // 42: drand.Public.PublicRandStream:output_type -> drand.PublicRandResponse
+	29, // 43: drand.Public.ChainInfo:output_type -> drand.ChainInfoPacket
+	5,  // 44: drand.Public.ListBeaconIDs:output_type -> drand.ListBeaconIDsResponse
+	8,  // 45: drand.Public.Heartbeat:output_type -> drand.HeartbeatPacket
+	11, // 46: drand.Public.SubBeacons:output_type -> drand.SubBeaconsResponse
+	19, // 47: drand.Public.GroupMembership:output_type -> drand.GroupMembershipResponse
+	22, // 48: drand.Public.ChainSummary:output_type -> drand.ChainSummaryResponse
+	24, // 49: drand.Public.Home:output_type -> drand.HomeResponse
+	30, // 50: drand.Public.GetCapabilities:output_type -> drand.Capabilities
+	1,  // 51: drand.Public.PublicRandAt:output_type -> drand.PublicRandResponse
+	1,  // 52: drand.Public.PublicRandByRandomness:output_type -> drand.PublicRandResponse
+	14, // 53: drand.Public.DerivedChains:output_type -> drand.DerivedChainsResponse
+	16, // 54: drand.Public.DerivedRand:output_type -> drand.DerivedRandResponse
+	41, // [41:55] is the sub-list for method output_type
+	27, // [27:41] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
+}
+
+func init() { file_drand_api_proto_init() }
+func file_drand_api_proto_init() {
+	if File_drand_api_proto != nil {
+		return
+	}
+	file_drand_common_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_drand_api_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PublicRandRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PublicRandResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PublicRandAtRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PublicRandByRandomnessRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListBeaconIDsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListBeaconIDsResponse); i {
 			case 0:
 				return &v.state
@@ -416,6 +2180,234 @@ func file_drand_api_proto_init() {
 				return nil
 			}
 		}
+		file_drand_api_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BeaconIDInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HeartbeatRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HeartbeatPacket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubBeaconsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubBeaconInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubBeaconsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DerivedChainsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DerivedChainInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DerivedChainsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DerivedRandRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DerivedRandResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupMembershipRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupEpoch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupMembershipResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChainSummaryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChainCorrection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChainSummaryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HomeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HomeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -423,7 +2415,7 @@ func file_drand_api_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_drand_api_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
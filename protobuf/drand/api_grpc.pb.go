@@ -24,10 +24,20 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Public_PublicRand_FullMethodName       = "/drand.Public/PublicRand"
-	Public_PublicRandStream_FullMethodName = "/drand.Public/PublicRandStream"
-	Public_ChainInfo_FullMethodName        = "/drand.Public/ChainInfo"
-	Public_ListBeaconIDs_FullMethodName    = "/drand.Public/ListBeaconIDs"
+	Public_PublicRand_FullMethodName             = "/drand.Public/PublicRand"
+	Public_PublicRandStream_FullMethodName       = "/drand.Public/PublicRandStream"
+	Public_ChainInfo_FullMethodName              = "/drand.Public/ChainInfo"
+	Public_ListBeaconIDs_FullMethodName          = "/drand.Public/ListBeaconIDs"
+	Public_Heartbeat_FullMethodName              = "/drand.Public/Heartbeat"
+	Public_SubBeacons_FullMethodName             = "/drand.Public/SubBeacons"
+	Public_GroupMembership_FullMethodName        = "/drand.Public/GroupMembership"
+	Public_ChainSummary_FullMethodName           = "/drand.Public/ChainSummary"
+	Public_Home_FullMethodName                   = "/drand.Public/Home"
+	Public_GetCapabilities_FullMethodName        = "/drand.Public/GetCapabilities"
+	Public_PublicRandAt_FullMethodName           = "/drand.Public/PublicRandAt"
+	Public_PublicRandByRandomness_FullMethodName = "/drand.Public/PublicRandByRandomness"
+	Public_DerivedChains_FullMethodName          = "/drand.Public/DerivedChains"
+	Public_DerivedRand_FullMethodName            = "/drand.Public/DerivedRand"
 )
 
 // PublicClient is the client API for Public service.
@@ -43,6 +53,35 @@ type PublicClient interface {
 	ChainInfo(ctx context.Context, in *ChainInfoRequest, opts ...grpc.CallOption) (*ChainInfoPacket, error)
 	// ListBeaconIDs responds with the list of Beacon IDs running on that node
 	ListBeaconIDs(ctx context.Context, in *ListBeaconIDsRequest, opts ...grpc.CallOption) (*ListBeaconIDsResponse, error)
+	// Heartbeat returns the last heartbeat signed by the group, attesting that a threshold of its members
+	// were live and agreed on the chain tip at that time
+	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatPacket, error)
+	// SubBeacons lists the randomness streams derived from the distributed key of the group, whose beacons are
+	// served by PublicRand when their name is set in the request
+	SubBeacons(ctx context.Context, in *SubBeaconsRequest, opts ...grpc.CallOption) (*SubBeaconsResponse, error)
+	// GroupMembership returns the composition of the group running the chain and its previous compositions, along
+	// with the last heartbeat signed by the group over it when there is one
+	GroupMembership(ctx context.Context, in *GroupMembershipRequest, opts ...grpc.CallOption) (*GroupMembershipResponse, error)
+	// ChainSummary returns the head of the chain, its parameters and how well it kept up over the last day, all at
+	// once for the block explorers
+	ChainSummary(ctx context.Context, in *ChainSummaryRequest, opts ...grpc.CallOption) (*ChainSummaryResponse, error)
+	// Home is the v1 liveness endpoint, only served by the nodes exposing the v1-compatible endpoints on their
+	// private listener
+	Home(ctx context.Context, in *HomeRequest, opts ...grpc.CallOption) (*HomeResponse, error)
+	// GetCapabilities lists what the node supports for a beacon: its schemes, storage, compressors, protocol versions
+	// and optional endpoints, so that the clients adapt to it instead of probing it
+	GetCapabilities(ctx context.Context, in *CapabilitiesRequest, opts ...grpc.CallOption) (*Capabilities, error)
+	// PublicRandAt returns the beacon which was the latest one of the chain at the given time, to audit the randomness
+	// that was available then
+	PublicRandAt(ctx context.Context, in *PublicRandAtRequest, opts ...grpc.CallOption) (*PublicRandResponse, error)
+	// PublicRandByRandomness returns the beacon of the given randomness, so that the systems which only recorded the
+	// randomness they used can get back the beacon to verify it
+	PublicRandByRandomness(ctx context.Context, in *PublicRandByRandomnessRequest, opts ...grpc.CallOption) (*PublicRandResponse, error)
+	// DerivedChains lists the chains the node derives from the beacons of the chain, e.g. a digest every Nth round,
+	// to serve lower-frequency randomness
+	DerivedChains(ctx context.Context, in *DerivedChainsRequest, opts ...grpc.CallOption) (*DerivedChainsResponse, error)
+	// DerivedRand returns a round of a derived chain, with the rounds of the chain it was computed from
+	DerivedRand(ctx context.Context, in *DerivedRandRequest, opts ...grpc.CallOption) (*DerivedRandResponse, error)
 }
 
 type publicClient struct {
@@ -112,6 +151,96 @@ func (c *publicClient) ListBeaconIDs(ctx context.Context, in *ListBeaconIDsReque
 	return out, nil
 }
 
+func (c *publicClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatPacket, error) {
+	out := new(HeartbeatPacket)
+	err := c.cc.Invoke(ctx, Public_Heartbeat_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicClient) SubBeacons(ctx context.Context, in *SubBeaconsRequest, opts ...grpc.CallOption) (*SubBeaconsResponse, error) {
+	out := new(SubBeaconsResponse)
+	err := c.cc.Invoke(ctx, Public_SubBeacons_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicClient) GroupMembership(ctx context.Context, in *GroupMembershipRequest, opts ...grpc.CallOption) (*GroupMembershipResponse, error) {
+	out := new(GroupMembershipResponse)
+	err := c.cc.Invoke(ctx, Public_GroupMembership_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicClient) ChainSummary(ctx context.Context, in *ChainSummaryRequest, opts ...grpc.CallOption) (*ChainSummaryResponse, error) {
+	out := new(ChainSummaryResponse)
+	err := c.cc.Invoke(ctx, Public_ChainSummary_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicClient) Home(ctx context.Context, in *HomeRequest, opts ...grpc.CallOption) (*HomeResponse, error) {
+	out := new(HomeResponse)
+	err := c.cc.Invoke(ctx, Public_Home_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicClient) GetCapabilities(ctx context.Context, in *CapabilitiesRequest, opts ...grpc.CallOption) (*Capabilities, error) {
+	out := new(Capabilities)
+	err := c.cc.Invoke(ctx, Public_GetCapabilities_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicClient) PublicRandAt(ctx context.Context, in *PublicRandAtRequest, opts ...grpc.CallOption) (*PublicRandResponse, error) {
+	out := new(PublicRandResponse)
+	err := c.cc.Invoke(ctx, Public_PublicRandAt_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicClient) PublicRandByRandomness(ctx context.Context, in *PublicRandByRandomnessRequest, opts ...grpc.CallOption) (*PublicRandResponse, error) {
+	out := new(PublicRandResponse)
+	err := c.cc.Invoke(ctx, Public_PublicRandByRandomness_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicClient) DerivedChains(ctx context.Context, in *DerivedChainsRequest, opts ...grpc.CallOption) (*DerivedChainsResponse, error) {
+	out := new(DerivedChainsResponse)
+	err := c.cc.Invoke(ctx, Public_DerivedChains_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicClient) DerivedRand(ctx context.Context, in *DerivedRandRequest, opts ...grpc.CallOption) (*DerivedRandResponse, error) {
+	out := new(DerivedRandResponse)
+	err := c.cc.Invoke(ctx, Public_DerivedRand_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PublicServer is the server API for Public service.
 // All implementations should embed UnimplementedPublicServer
 // for forward compatibility
@@ -125,6 +254,35 @@ type PublicServer interface {
 	ChainInfo(context.Context, *ChainInfoRequest) (*ChainInfoPacket, error)
 	// ListBeaconIDs responds with the list of Beacon IDs running on that node
 	ListBeaconIDs(context.Context, *ListBeaconIDsRequest) (*ListBeaconIDsResponse, error)
+	// Heartbeat returns the last heartbeat signed by the group, attesting that a threshold of its members
+	// were live and agreed on the chain tip at that time
+	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatPacket, error)
+	// SubBeacons lists the randomness streams derived from the distributed key of the group, whose beacons are
+	// served by PublicRand when their name is set in the request
+	SubBeacons(context.Context, *SubBeaconsRequest) (*SubBeaconsResponse, error)
+	// GroupMembership returns the composition of the group running the chain and its previous compositions, along
+	// with the last heartbeat signed by the group over it when there is one
+	GroupMembership(context.Context, *GroupMembershipRequest) (*GroupMembershipResponse, error)
+	// ChainSummary returns the head of the chain, its parameters and how well it kept up over the last day, all at
+	// once for the block explorers
+	ChainSummary(context.Context, *ChainSummaryRequest) (*ChainSummaryResponse, error)
+	// Home is the v1 liveness endpoint, only served by the nodes exposing the v1-compatible endpoints on their
+	// private listener
+	Home(context.Context, *HomeRequest) (*HomeResponse, error)
+	// GetCapabilities lists what the node supports for a beacon: its schemes, storage, compressors, protocol versions
+	// and optional endpoints, so that the clients adapt to it instead of probing it
+	GetCapabilities(context.Context, *CapabilitiesRequest) (*Capabilities, error)
+	// PublicRandAt returns the beacon which was the latest one of the chain at the given time, to audit the randomness
+	// that was available then
+	PublicRandAt(context.Context, *PublicRandAtRequest) (*PublicRandResponse, error)
+	// PublicRandByRandomness returns the beacon of the given randomness, so that the systems which only recorded the
+	// randomness they used can get back the beacon to verify it
+	PublicRandByRandomness(context.Context, *PublicRandByRandomnessRequest) (*PublicRandResponse, error)
+	// DerivedChains lists the chains the node derives from the beacons of the chain, e.g. a digest every Nth round,
+	// to serve lower-frequency randomness
+	DerivedChains(context.Context, *DerivedChainsRequest) (*DerivedChainsResponse, error)
+	// DerivedRand returns a round of a derived chain, with the rounds of the chain it was computed from
+	DerivedRand(context.Context, *DerivedRandRequest) (*DerivedRandResponse, error)
 }
 
 // UnimplementedPublicServer should be embedded to have forward compatible implementations.
@@ -143,6 +301,36 @@ func (UnimplementedPublicServer) ChainInfo(context.Context, *ChainInfoRequest) (
 func (UnimplementedPublicServer) ListBeaconIDs(context.Context, *ListBeaconIDsRequest) (*ListBeaconIDsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListBeaconIDs not implemented")
 }
+func (UnimplementedPublicServer) Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatPacket, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedPublicServer) SubBeacons(context.Context, *SubBeaconsRequest) (*SubBeaconsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubBeacons not implemented")
+}
+func (UnimplementedPublicServer) GroupMembership(context.Context, *GroupMembershipRequest) (*GroupMembershipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GroupMembership not implemented")
+}
+func (UnimplementedPublicServer) ChainSummary(context.Context, *ChainSummaryRequest) (*ChainSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChainSummary not implemented")
+}
+func (UnimplementedPublicServer) Home(context.Context, *HomeRequest) (*HomeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Home not implemented")
+}
+func (UnimplementedPublicServer) GetCapabilities(context.Context, *CapabilitiesRequest) (*Capabilities, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapabilities not implemented")
+}
+func (UnimplementedPublicServer) PublicRandAt(context.Context, *PublicRandAtRequest) (*PublicRandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublicRandAt not implemented")
+}
+func (UnimplementedPublicServer) PublicRandByRandomness(context.Context, *PublicRandByRandomnessRequest) (*PublicRandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublicRandByRandomness not implemented")
+}
+func (UnimplementedPublicServer) DerivedChains(context.Context, *DerivedChainsRequest) (*DerivedChainsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DerivedChains not implemented")
+}
+func (UnimplementedPublicServer) DerivedRand(context.Context, *DerivedRandRequest) (*DerivedRandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DerivedRand not implemented")
+}
 
 // UnsafePublicServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to PublicServer will
@@ -230,6 +418,186 @@ func _Public_ListBeaconIDs_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Public_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Public_Heartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Public_SubBeacons_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubBeaconsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicServer).SubBeacons(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Public_SubBeacons_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicServer).SubBeacons(ctx, req.(*SubBeaconsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Public_GroupMembership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupMembershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicServer).GroupMembership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Public_GroupMembership_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicServer).GroupMembership(ctx, req.(*GroupMembershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Public_ChainSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChainSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicServer).ChainSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Public_ChainSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicServer).ChainSummary(ctx, req.(*ChainSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Public_Home_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HomeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicServer).Home(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Public_Home_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicServer).Home(ctx, req.(*HomeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Public_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Public_GetCapabilities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicServer).GetCapabilities(ctx, req.(*CapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Public_PublicRandAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublicRandAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicServer).PublicRandAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Public_PublicRandAt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicServer).PublicRandAt(ctx, req.(*PublicRandAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Public_PublicRandByRandomness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublicRandByRandomnessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicServer).PublicRandByRandomness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Public_PublicRandByRandomness_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicServer).PublicRandByRandomness(ctx, req.(*PublicRandByRandomnessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Public_DerivedChains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DerivedChainsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicServer).DerivedChains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Public_DerivedChains_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicServer).DerivedChains(ctx, req.(*DerivedChainsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Public_DerivedRand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DerivedRandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicServer).DerivedRand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Public_DerivedRand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicServer).DerivedRand(ctx, req.(*DerivedRandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Public_ServiceDesc is the grpc.ServiceDesc for Public service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -249,6 +617,46 @@ var Public_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListBeaconIDs",
 			Handler:    _Public_ListBeaconIDs_Handler,
 		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _Public_Heartbeat_Handler,
+		},
+		{
+			MethodName: "SubBeacons",
+			Handler:    _Public_SubBeacons_Handler,
+		},
+		{
+			MethodName: "GroupMembership",
+			Handler:    _Public_GroupMembership_Handler,
+		},
+		{
+			MethodName: "ChainSummary",
+			Handler:    _Public_ChainSummary_Handler,
+		},
+		{
+			MethodName: "Home",
+			Handler:    _Public_Home_Handler,
+		},
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _Public_GetCapabilities_Handler,
+		},
+		{
+			MethodName: "PublicRandAt",
+			Handler:    _Public_PublicRandAt_Handler,
+		},
+		{
+			MethodName: "PublicRandByRandomness",
+			Handler:    _Public_PublicRandByRandomness_Handler,
+		},
+		{
+			MethodName: "DerivedChains",
+			Handler:    _Public_DerivedChains_Handler,
+		},
+		{
+			MethodName: "DerivedRand",
+			Handler:    _Public_DerivedRand_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
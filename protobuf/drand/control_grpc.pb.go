@@ -23,18 +23,52 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Control_PingPong_FullMethodName         = "/drand.Control/PingPong"
-	Control_Status_FullMethodName           = "/drand.Control/Status"
-	Control_ListSchemes_FullMethodName      = "/drand.Control/ListSchemes"
-	Control_PublicKey_FullMethodName        = "/drand.Control/PublicKey"
-	Control_ChainInfo_FullMethodName        = "/drand.Control/ChainInfo"
-	Control_GroupFile_FullMethodName        = "/drand.Control/GroupFile"
-	Control_Shutdown_FullMethodName         = "/drand.Control/Shutdown"
-	Control_LoadBeacon_FullMethodName       = "/drand.Control/LoadBeacon"
-	Control_StartFollowChain_FullMethodName = "/drand.Control/StartFollowChain"
-	Control_StartCheckChain_FullMethodName  = "/drand.Control/StartCheckChain"
-	Control_BackupDatabase_FullMethodName   = "/drand.Control/BackupDatabase"
-	Control_RemoteStatus_FullMethodName     = "/drand.Control/RemoteStatus"
+	Control_PingPong_FullMethodName           = "/drand.Control/PingPong"
+	Control_Status_FullMethodName             = "/drand.Control/Status"
+	Control_StatusStream_FullMethodName       = "/drand.Control/StatusStream"
+	Control_ListSchemes_FullMethodName        = "/drand.Control/ListSchemes"
+	Control_ListBeaconIDs_FullMethodName      = "/drand.Control/ListBeaconIDs"
+	Control_GetCapabilities_FullMethodName    = "/drand.Control/GetCapabilities"
+	Control_PublicKey_FullMethodName          = "/drand.Control/PublicKey"
+	Control_ChainInfo_FullMethodName          = "/drand.Control/ChainInfo"
+	Control_GroupFile_FullMethodName          = "/drand.Control/GroupFile"
+	Control_Shutdown_FullMethodName           = "/drand.Control/Shutdown"
+	Control_LoadBeacon_FullMethodName         = "/drand.Control/LoadBeacon"
+	Control_RemoveBeaconID_FullMethodName     = "/drand.Control/RemoveBeaconID"
+	Control_StartFollowChain_FullMethodName   = "/drand.Control/StartFollowChain"
+	Control_StartCheckChain_FullMethodName    = "/drand.Control/StartCheckChain"
+	Control_BackupDatabase_FullMethodName     = "/drand.Control/BackupDatabase"
+	Control_RemoteStatus_FullMethodName       = "/drand.Control/RemoteStatus"
+	Control_GroupBuildInfo_FullMethodName     = "/drand.Control/GroupBuildInfo"
+	Control_GroupDST_FullMethodName           = "/drand.Control/GroupDST"
+	Control_RotateControlToken_FullMethodName = "/drand.Control/RotateControlToken"
+	Control_ReloadConfig_FullMethodName       = "/drand.Control/ReloadConfig"
+	Control_LogLevels_FullMethodName          = "/drand.Control/LogLevels"
+	Control_StartUpgrade_FullMethodName       = "/drand.Control/StartUpgrade"
+	Control_AcceptUpgrade_FullMethodName      = "/drand.Control/AcceptUpgrade"
+	Control_Leave_FullMethodName              = "/drand.Control/Leave"
+	Control_Snapshot_FullMethodName           = "/drand.Control/Snapshot"
+	Control_RoundMessage_FullMethodName       = "/drand.Control/RoundMessage"
+	Control_Notarize_FullMethodName           = "/drand.Control/Notarize"
+	Control_RandomnessStats_FullMethodName    = "/drand.Control/RandomnessStats"
+	Control_MakeJoinKit_FullMethodName        = "/drand.Control/MakeJoinKit"
+	Control_ListMetrics_FullMethodName        = "/drand.Control/ListMetrics"
+	Control_FeatureFlags_FullMethodName       = "/drand.Control/FeatureFlags"
+	Control_AvailabilityReport_FullMethodName = "/drand.Control/AvailabilityReport"
+	Control_PartialAudit_FullMethodName       = "/drand.Control/PartialAudit"
+	Control_InjectBeacon_FullMethodName       = "/drand.Control/InjectBeacon"
+	Control_Tombstones_FullMethodName         = "/drand.Control/Tombstones"
+	Control_StoreMetadata_FullMethodName      = "/drand.Control/StoreMetadata"
+	Control_PauseBeacon_FullMethodName        = "/drand.Control/PauseBeacon"
+	Control_ResumeBeacon_FullMethodName       = "/drand.Control/ResumeBeacon"
+	Control_Incident_FullMethodName           = "/drand.Control/Incident"
+	Control_UpdateAddress_FullMethodName      = "/drand.Control/UpdateAddress"
+	Control_AddressOverrides_FullMethodName   = "/drand.Control/AddressOverrides"
+	Control_ForecastReshare_FullMethodName    = "/drand.Control/ForecastReshare"
+	Control_APIUsage_FullMethodName           = "/drand.Control/APIUsage"
+	Control_RoundAnnotations_FullMethodName   = "/drand.Control/RoundAnnotations"
+	Control_Countdown_FullMethodName          = "/drand.Control/Countdown"
+	Control_EntropyStream_FullMethodName      = "/drand.Control/EntropyStream"
 )
 
 // ControlClient is the client API for Control service.
@@ -45,8 +79,15 @@ type ControlClient interface {
 	PingPong(ctx context.Context, in *Ping, opts ...grpc.CallOption) (*Pong, error)
 	// Status responds with the actual status of drand process
 	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// StatusStream pushes the status of the drand process whenever the state of
+	// the beacon, the DKG or the chain store changes
+	StatusStream(ctx context.Context, in *StatusStreamRequest, opts ...grpc.CallOption) (Control_StatusStreamClient, error)
 	// ListSchemes responds with the list of ids for the available schemes
 	ListSchemes(ctx context.Context, in *ListSchemesRequest, opts ...grpc.CallOption) (*ListSchemesResponse, error)
+	// ListBeaconIDs responds with the beacons configured on the node, running or not
+	ListBeaconIDs(ctx context.Context, in *ListBeaconIDsRequest, opts ...grpc.CallOption) (*ListBeaconIDsResponse, error)
+	// GetCapabilities lists what the node supports for a beacon
+	GetCapabilities(ctx context.Context, in *CapabilitiesRequest, opts ...grpc.CallOption) (*Capabilities, error)
 	// PublicKey returns the longterm public key of the drand node
 	PublicKey(ctx context.Context, in *PublicKeyRequest, opts ...grpc.CallOption) (*PublicKeyResponse, error)
 	// ChainInfo returns the chain info for the chain hash or beacon id requested in the metadata
@@ -55,11 +96,98 @@ type ControlClient interface {
 	GroupFile(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*GroupPacket, error)
 	Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error)
 	LoadBeacon(ctx context.Context, in *LoadBeaconRequest, opts ...grpc.CallOption) (*LoadBeaconResponse, error)
+	// RemoveBeaconID unloads the beacon given in the metadata and deletes, or archives, its folder holding its keys and
+	// chain store, as well as its DKG state, so that it isn't configured on the node anymore. It must be confirmed.
+	RemoveBeaconID(ctx context.Context, in *RemoveBeaconIDRequest, opts ...grpc.CallOption) (*RemoveBeaconIDResponse, error)
 	StartFollowChain(ctx context.Context, in *StartSyncRequest, opts ...grpc.CallOption) (Control_StartFollowChainClient, error)
 	StartCheckChain(ctx context.Context, in *StartSyncRequest, opts ...grpc.CallOption) (Control_StartCheckChainClient, error)
 	BackupDatabase(ctx context.Context, in *BackupDBRequest, opts ...grpc.CallOption) (*BackupDBResponse, error)
 	// RemoteStatus request the status of some remote drand nodes
 	RemoteStatus(ctx context.Context, in *RemoteStatusRequest, opts ...grpc.CallOption) (*RemoteStatusResponse, error)
+	// GroupBuildInfo collects the build info of all the nodes of the group and compares them with ours
+	GroupBuildInfo(ctx context.Context, in *GroupBuildInfoRequest, opts ...grpc.CallOption) (*GroupBuildInfoResponse, error)
+	// GroupDST collects the domain separation tags of all the nodes of the group and compares them with ours
+	GroupDST(ctx context.Context, in *GroupDSTRequest, opts ...grpc.CallOption) (*GroupDSTResponse, error)
+	// RotateControlToken replaces the token authenticating the calls to the
+	// control port with a fresh one, which is saved in the config folder
+	RotateControlToken(ctx context.Context, in *RotateControlTokenRequest, opts ...grpc.CallOption) (*RotateControlTokenResponse, error)
+	// ReloadConfig re-reads the config file of the daemon and applies the
+	// options that can change without a restart
+	ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error)
+	// LogLevels lists the level of the logger of the daemon and the levels of its named loggers which have their own,
+	// after changing one of them if asked to
+	LogLevels(ctx context.Context, in *LogLevelsRequest, opts ...grpc.CallOption) (*LogLevelsResponse, error)
+	// StartUpgrade proposes an upgrade window to the group, with this node as the coordinator
+	StartUpgrade(ctx context.Context, in *StartUpgradeRequest, opts ...grpc.CallOption) (*UpgradeStatus, error)
+	// AcceptUpgrade acknowledges the upgrade proposed to this node and schedules its restart
+	AcceptUpgrade(ctx context.Context, in *AcceptUpgradeRequest, opts ...grpc.CallOption) (*UpgradeStatus, error)
+	// Leave announces to the group that this node is leaving it and stops signing partials after the given round
+	Leave(ctx context.Context, in *LeaveRequest, opts ...grpc.CallOption) (*LeaveStatus, error)
+	// Snapshot collects the state of all the beacons run by this daemon at a single point in time
+	Snapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (*SnapshotResponse, error)
+	// RoundMessage returns the exact message the node signs a partial over for the given round, so that external
+	// signers and auditors can reproduce and authorize it independently
+	RoundMessage(ctx context.Context, in *RoundMessageRequest, opts ...grpc.CallOption) (*RoundMessageResponse, error)
+	// Notarize returns a self-contained bundle allowing to verify the randomness of the given round offline,
+	// signed by the identity of the node, for legal or archival use
+	Notarize(ctx context.Context, in *NotarizeRequest, opts ...grpc.CallOption) (*NotarizationBundle, error)
+	// RandomnessStats computes basic statistical summaries of the randomness over a range of rounds, as a
+	// consumer-confidence tool. The results are cached in the chain store when it supports it.
+	RandomnessStats(ctx context.Context, in *RandomnessStatsRequest, opts ...grpc.CallOption) (*RandomnessStatsResponse, error)
+	// MakeJoinKit returns a bundle, signed by the identity of the node, allowing new followers to start from a
+	// recent verified checkpoint instead of syncing and verifying the chain from genesis
+	MakeJoinKit(ctx context.Context, in *JoinKitRequest, opts ...grpc.CallOption) (*JoinKit, error)
+	// ListMetrics describes the metrics exported by the daemon, only the ones of the beacon of the metadata if set
+	ListMetrics(ctx context.Context, in *ListMetricsRequest, opts ...grpc.CallOption) (*ListMetricsResponse, error)
+	// FeatureFlags lists the feature flags of the beacon of the metadata, after turning one on or off at runtime if
+	// asked to
+	FeatureFlags(ctx context.Context, in *FeatureFlagsRequest, opts ...grpc.CallOption) (*FeatureFlagsResponse, error)
+	// AvailabilityReport computes the availability of each member of the group over a time window, from the status
+	// samples the node collects periodically
+	AvailabilityReport(ctx context.Context, in *AvailabilityReportRequest, opts ...grpc.CallOption) (*AvailabilityReportResponse, error)
+	// PartialAudit returns the members whose partials were aggregated into each round of a range, as recorded by the
+	// node while the partial-audit feature is enabled
+	PartialAudit(ctx context.Context, in *PartialAuditRequest, opts ...grpc.CallOption) (*PartialAuditResponse, error)
+	// InjectBeacon stores a beacon obtained out-of-band, e.g. from the store of another node, once verified against the
+	// chain, to repair a gap when the peers are unreachable. It is refused unless the beacon-injection feature is enabled.
+	InjectBeacon(ctx context.Context, in *InjectBeaconRequest, opts ...grpc.CallOption) (*InjectBeaconResponse, error)
+	// Tombstones returns the beacons replaced in the store of a beacon when correcting its chain, as kept by the node
+	// with the beacons replacing them
+	Tombstones(ctx context.Context, in *TombstonesRequest, opts ...grpc.CallOption) (*TombstonesResponse, error)
+	// StoreMetadata reads, writes or lists the values kept by an extension in a namespace of the metadata of the chain
+	// store of a beacon. The namespaces starting with "drand." are used by the node and are read-only.
+	StoreMetadata(ctx context.Context, in *StoreMetadataRequest, opts ...grpc.CallOption) (*StoreMetadataResponse, error)
+	// PauseBeacon stops the node from signing and sending its partials, of the chain as well as of its sub-beacons and
+	// heartbeats, while it keeps following the chain and serving it, e.g. while investigating a suspected compromise of
+	// its key. It is refused if the group would then be expected to miss rounds, unless forced.
+	PauseBeacon(ctx context.Context, in *PauseBeaconRequest, opts ...grpc.CallOption) (*PauseStatus, error)
+	// ResumeBeacon makes a paused node sign and send its partials again
+	ResumeBeacon(ctx context.Context, in *ResumeBeaconRequest, opts ...grpc.CallOption) (*PauseStatus, error)
+	// Incident runs the response to a suspected compromise of the share of the node in one action: it pauses the node,
+	// saves a snapshot of the daemon, and announces to the group that the node leaves it so that the coordinator of
+	// the next reshare excludes it. It reports each step, including those which couldn't be run.
+	Incident(ctx context.Context, in *IncidentRequest, opts ...grpc.CallOption) (*IncidentResponse, error)
+	// UpdateAddress announces to the members of the group that the node is now reachable at a new address, signed with
+	// its longterm key, so that they reach it there without a new group file
+	UpdateAddress(ctx context.Context, in *UpdateAddressRequest, opts ...grpc.CallOption) (*UpdateAddressResponse, error)
+	// AddressOverrides lists the addresses the members of the group of the beacon are dialed at instead of the ones
+	// of the group file, after setting or removing the one of a member if asked to. They are kept across restarts.
+	AddressOverrides(ctx context.Context, in *AddressOverridesRequest, opts ...grpc.CallOption) (*AddressOverridesResponse, error)
+	// ForecastReshare registers the date this node, as the coordinator, intends to run the next reshare of the group
+	// at and announces it to the members, or clears it if asked to. It returns the current forecast otherwise.
+	ForecastReshare(ctx context.Context, in *ForecastReshareRequest, opts ...grpc.CallOption) (*ForecastReshareResponse, error)
+	// APIUsage reports the requests to the public API of each client, by API key, when the requests are limited by tier
+	APIUsage(ctx context.Context, in *APIUsageRequest, opts ...grpc.CallOption) (*APIUsageResponse, error)
+	// RoundAnnotations lists the notes the operators attached to the rounds of a beacon, e.g. the lottery a round was
+	// used for, after attaching or removing some if asked to. They are kept in the metadata of the chain store, apart
+	// from the beacons, and never leave the node.
+	RoundAnnotations(ctx context.Context, in *RoundAnnotationsRequest, opts ...grpc.CallOption) (*RoundAnnotationsResponse, error)
+	// Countdown returns the time left until the genesis of the chain, or until the transition to the group of the last
+	// reshare, with the readiness of each member of the group, so that a launch can be followed by all the operators
+	Countdown(ctx context.Context, in *CountdownRequest, opts ...grpc.CallOption) (*CountdownResponse, error)
+	// EntropyStream streams bytes derived from the successive beacons of the chain with HKDF-SHA256, starting with the
+	// latest one, for the consumers of entropy. The bytes are public, anyone can derive them from the beacons.
+	EntropyStream(ctx context.Context, in *EntropyStreamRequest, opts ...grpc.CallOption) (Control_EntropyStreamClient, error)
 }
 
 type controlClient struct {
@@ -88,6 +216,38 @@ func (c *controlClient) Status(ctx context.Context, in *StatusRequest, opts ...g
 	return out, nil
 }
 
+func (c *controlClient) StatusStream(ctx context.Context, in *StatusStreamRequest, opts ...grpc.CallOption) (Control_StatusStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Control_ServiceDesc.Streams[0], Control_StatusStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &controlStatusStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Control_StatusStreamClient interface {
+	Recv() (*StatusResponse, error)
+	grpc.ClientStream
+}
+
+type controlStatusStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *controlStatusStreamClient) Recv() (*StatusResponse, error) {
+	m := new(StatusResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *controlClient) ListSchemes(ctx context.Context, in *ListSchemesRequest, opts ...grpc.CallOption) (*ListSchemesResponse, error) {
 	out := new(ListSchemesResponse)
 	err := c.cc.Invoke(ctx, Control_ListSchemes_FullMethodName, in, out, opts...)
@@ -97,6 +257,24 @@ func (c *controlClient) ListSchemes(ctx context.Context, in *ListSchemesRequest,
 	return out, nil
 }
 
+func (c *controlClient) ListBeaconIDs(ctx context.Context, in *ListBeaconIDsRequest, opts ...grpc.CallOption) (*ListBeaconIDsResponse, error) {
+	out := new(ListBeaconIDsResponse)
+	err := c.cc.Invoke(ctx, Control_ListBeaconIDs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) GetCapabilities(ctx context.Context, in *CapabilitiesRequest, opts ...grpc.CallOption) (*Capabilities, error) {
+	out := new(Capabilities)
+	err := c.cc.Invoke(ctx, Control_GetCapabilities_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *controlClient) PublicKey(ctx context.Context, in *PublicKeyRequest, opts ...grpc.CallOption) (*PublicKeyResponse, error) {
 	out := new(PublicKeyResponse)
 	err := c.cc.Invoke(ctx, Control_PublicKey_FullMethodName, in, out, opts...)
@@ -142,8 +320,17 @@ func (c *controlClient) LoadBeacon(ctx context.Context, in *LoadBeaconRequest, o
 	return out, nil
 }
 
+func (c *controlClient) RemoveBeaconID(ctx context.Context, in *RemoveBeaconIDRequest, opts ...grpc.CallOption) (*RemoveBeaconIDResponse, error) {
+	out := new(RemoveBeaconIDResponse)
+	err := c.cc.Invoke(ctx, Control_RemoveBeaconID_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *controlClient) StartFollowChain(ctx context.Context, in *StartSyncRequest, opts ...grpc.CallOption) (Control_StartFollowChainClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Control_ServiceDesc.Streams[0], Control_StartFollowChain_FullMethodName, opts...)
+	stream, err := c.cc.NewStream(ctx, &Control_ServiceDesc.Streams[1], Control_StartFollowChain_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -175,7 +362,7 @@ func (x *controlStartFollowChainClient) Recv() (*SyncProgress, error) {
 }
 
 func (c *controlClient) StartCheckChain(ctx context.Context, in *StartSyncRequest, opts ...grpc.CallOption) (Control_StartCheckChainClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Control_ServiceDesc.Streams[1], Control_StartCheckChain_FullMethodName, opts...)
+	stream, err := c.cc.NewStream(ctx, &Control_ServiceDesc.Streams[2], Control_StartCheckChain_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -224,6 +411,299 @@ func (c *controlClient) RemoteStatus(ctx context.Context, in *RemoteStatusReques
 	return out, nil
 }
 
+func (c *controlClient) GroupBuildInfo(ctx context.Context, in *GroupBuildInfoRequest, opts ...grpc.CallOption) (*GroupBuildInfoResponse, error) {
+	out := new(GroupBuildInfoResponse)
+	err := c.cc.Invoke(ctx, Control_GroupBuildInfo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) GroupDST(ctx context.Context, in *GroupDSTRequest, opts ...grpc.CallOption) (*GroupDSTResponse, error) {
+	out := new(GroupDSTResponse)
+	err := c.cc.Invoke(ctx, Control_GroupDST_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) RotateControlToken(ctx context.Context, in *RotateControlTokenRequest, opts ...grpc.CallOption) (*RotateControlTokenResponse, error) {
+	out := new(RotateControlTokenResponse)
+	err := c.cc.Invoke(ctx, Control_RotateControlToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error) {
+	out := new(ReloadConfigResponse)
+	err := c.cc.Invoke(ctx, Control_ReloadConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) LogLevels(ctx context.Context, in *LogLevelsRequest, opts ...grpc.CallOption) (*LogLevelsResponse, error) {
+	out := new(LogLevelsResponse)
+	err := c.cc.Invoke(ctx, Control_LogLevels_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) StartUpgrade(ctx context.Context, in *StartUpgradeRequest, opts ...grpc.CallOption) (*UpgradeStatus, error) {
+	out := new(UpgradeStatus)
+	err := c.cc.Invoke(ctx, Control_StartUpgrade_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) AcceptUpgrade(ctx context.Context, in *AcceptUpgradeRequest, opts ...grpc.CallOption) (*UpgradeStatus, error) {
+	out := new(UpgradeStatus)
+	err := c.cc.Invoke(ctx, Control_AcceptUpgrade_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) Leave(ctx context.Context, in *LeaveRequest, opts ...grpc.CallOption) (*LeaveStatus, error) {
+	out := new(LeaveStatus)
+	err := c.cc.Invoke(ctx, Control_Leave_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) Snapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (*SnapshotResponse, error) {
+	out := new(SnapshotResponse)
+	err := c.cc.Invoke(ctx, Control_Snapshot_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) RoundMessage(ctx context.Context, in *RoundMessageRequest, opts ...grpc.CallOption) (*RoundMessageResponse, error) {
+	out := new(RoundMessageResponse)
+	err := c.cc.Invoke(ctx, Control_RoundMessage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) Notarize(ctx context.Context, in *NotarizeRequest, opts ...grpc.CallOption) (*NotarizationBundle, error) {
+	out := new(NotarizationBundle)
+	err := c.cc.Invoke(ctx, Control_Notarize_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) RandomnessStats(ctx context.Context, in *RandomnessStatsRequest, opts ...grpc.CallOption) (*RandomnessStatsResponse, error) {
+	out := new(RandomnessStatsResponse)
+	err := c.cc.Invoke(ctx, Control_RandomnessStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) MakeJoinKit(ctx context.Context, in *JoinKitRequest, opts ...grpc.CallOption) (*JoinKit, error) {
+	out := new(JoinKit)
+	err := c.cc.Invoke(ctx, Control_MakeJoinKit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) ListMetrics(ctx context.Context, in *ListMetricsRequest, opts ...grpc.CallOption) (*ListMetricsResponse, error) {
+	out := new(ListMetricsResponse)
+	err := c.cc.Invoke(ctx, Control_ListMetrics_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) FeatureFlags(ctx context.Context, in *FeatureFlagsRequest, opts ...grpc.CallOption) (*FeatureFlagsResponse, error) {
+	out := new(FeatureFlagsResponse)
+	err := c.cc.Invoke(ctx, Control_FeatureFlags_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) AvailabilityReport(ctx context.Context, in *AvailabilityReportRequest, opts ...grpc.CallOption) (*AvailabilityReportResponse, error) {
+	out := new(AvailabilityReportResponse)
+	err := c.cc.Invoke(ctx, Control_AvailabilityReport_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) PartialAudit(ctx context.Context, in *PartialAuditRequest, opts ...grpc.CallOption) (*PartialAuditResponse, error) {
+	out := new(PartialAuditResponse)
+	err := c.cc.Invoke(ctx, Control_PartialAudit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) InjectBeacon(ctx context.Context, in *InjectBeaconRequest, opts ...grpc.CallOption) (*InjectBeaconResponse, error) {
+	out := new(InjectBeaconResponse)
+	err := c.cc.Invoke(ctx, Control_InjectBeacon_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) Tombstones(ctx context.Context, in *TombstonesRequest, opts ...grpc.CallOption) (*TombstonesResponse, error) {
+	out := new(TombstonesResponse)
+	err := c.cc.Invoke(ctx, Control_Tombstones_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) StoreMetadata(ctx context.Context, in *StoreMetadataRequest, opts ...grpc.CallOption) (*StoreMetadataResponse, error) {
+	out := new(StoreMetadataResponse)
+	err := c.cc.Invoke(ctx, Control_StoreMetadata_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) PauseBeacon(ctx context.Context, in *PauseBeaconRequest, opts ...grpc.CallOption) (*PauseStatus, error) {
+	out := new(PauseStatus)
+	err := c.cc.Invoke(ctx, Control_PauseBeacon_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) ResumeBeacon(ctx context.Context, in *ResumeBeaconRequest, opts ...grpc.CallOption) (*PauseStatus, error) {
+	out := new(PauseStatus)
+	err := c.cc.Invoke(ctx, Control_ResumeBeacon_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) Incident(ctx context.Context, in *IncidentRequest, opts ...grpc.CallOption) (*IncidentResponse, error) {
+	out := new(IncidentResponse)
+	err := c.cc.Invoke(ctx, Control_Incident_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) UpdateAddress(ctx context.Context, in *UpdateAddressRequest, opts ...grpc.CallOption) (*UpdateAddressResponse, error) {
+	out := new(UpdateAddressResponse)
+	err := c.cc.Invoke(ctx, Control_UpdateAddress_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) AddressOverrides(ctx context.Context, in *AddressOverridesRequest, opts ...grpc.CallOption) (*AddressOverridesResponse, error) {
+	out := new(AddressOverridesResponse)
+	err := c.cc.Invoke(ctx, Control_AddressOverrides_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) ForecastReshare(ctx context.Context, in *ForecastReshareRequest, opts ...grpc.CallOption) (*ForecastReshareResponse, error) {
+	out := new(ForecastReshareResponse)
+	err := c.cc.Invoke(ctx, Control_ForecastReshare_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) APIUsage(ctx context.Context, in *APIUsageRequest, opts ...grpc.CallOption) (*APIUsageResponse, error) {
+	out := new(APIUsageResponse)
+	err := c.cc.Invoke(ctx, Control_APIUsage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) RoundAnnotations(ctx context.Context, in *RoundAnnotationsRequest, opts ...grpc.CallOption) (*RoundAnnotationsResponse, error) {
+	out := new(RoundAnnotationsResponse)
+	err := c.cc.Invoke(ctx, Control_RoundAnnotations_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) Countdown(ctx context.Context, in *CountdownRequest, opts ...grpc.CallOption) (*CountdownResponse, error) {
+	out := new(CountdownResponse)
+	err := c.cc.Invoke(ctx, Control_Countdown_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) EntropyStream(ctx context.Context, in *EntropyStreamRequest, opts ...grpc.CallOption) (Control_EntropyStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Control_ServiceDesc.Streams[3], Control_EntropyStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &controlEntropyStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Control_EntropyStreamClient interface {
+	Recv() (*EntropyChunk, error)
+	grpc.ClientStream
+}
+
+type controlEntropyStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *controlEntropyStreamClient) Recv() (*EntropyChunk, error) {
+	m := new(EntropyChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ControlServer is the server API for Control service.
 // All implementations should embed UnimplementedControlServer
 // for forward compatibility
@@ -232,8 +712,15 @@ type ControlServer interface {
 	PingPong(context.Context, *Ping) (*Pong, error)
 	// Status responds with the actual status of drand process
 	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+	// StatusStream pushes the status of the drand process whenever the state of
+	// the beacon, the DKG or the chain store changes
+	StatusStream(*StatusStreamRequest, Control_StatusStreamServer) error
 	// ListSchemes responds with the list of ids for the available schemes
 	ListSchemes(context.Context, *ListSchemesRequest) (*ListSchemesResponse, error)
+	// ListBeaconIDs responds with the beacons configured on the node, running or not
+	ListBeaconIDs(context.Context, *ListBeaconIDsRequest) (*ListBeaconIDsResponse, error)
+	// GetCapabilities lists what the node supports for a beacon
+	GetCapabilities(context.Context, *CapabilitiesRequest) (*Capabilities, error)
 	// PublicKey returns the longterm public key of the drand node
 	PublicKey(context.Context, *PublicKeyRequest) (*PublicKeyResponse, error)
 	// ChainInfo returns the chain info for the chain hash or beacon id requested in the metadata
@@ -242,11 +729,98 @@ type ControlServer interface {
 	GroupFile(context.Context, *GroupRequest) (*GroupPacket, error)
 	Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error)
 	LoadBeacon(context.Context, *LoadBeaconRequest) (*LoadBeaconResponse, error)
+	// RemoveBeaconID unloads the beacon given in the metadata and deletes, or archives, its folder holding its keys and
+	// chain store, as well as its DKG state, so that it isn't configured on the node anymore. It must be confirmed.
+	RemoveBeaconID(context.Context, *RemoveBeaconIDRequest) (*RemoveBeaconIDResponse, error)
 	StartFollowChain(*StartSyncRequest, Control_StartFollowChainServer) error
 	StartCheckChain(*StartSyncRequest, Control_StartCheckChainServer) error
 	BackupDatabase(context.Context, *BackupDBRequest) (*BackupDBResponse, error)
 	// RemoteStatus request the status of some remote drand nodes
 	RemoteStatus(context.Context, *RemoteStatusRequest) (*RemoteStatusResponse, error)
+	// GroupBuildInfo collects the build info of all the nodes of the group and compares them with ours
+	GroupBuildInfo(context.Context, *GroupBuildInfoRequest) (*GroupBuildInfoResponse, error)
+	// GroupDST collects the domain separation tags of all the nodes of the group and compares them with ours
+	GroupDST(context.Context, *GroupDSTRequest) (*GroupDSTResponse, error)
+	// RotateControlToken replaces the token authenticating the calls to the
+	// control port with a fresh one, which is saved in the config folder
+	RotateControlToken(context.Context, *RotateControlTokenRequest) (*RotateControlTokenResponse, error)
+	// ReloadConfig re-reads the config file of the daemon and applies the
+	// options that can change without a restart
+	ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error)
+	// LogLevels lists the level of the logger of the daemon and the levels of its named loggers which have their own,
+	// after changing one of them if asked to
+	LogLevels(context.Context, *LogLevelsRequest) (*LogLevelsResponse, error)
+	// StartUpgrade proposes an upgrade window to the group, with this node as the coordinator
+	StartUpgrade(context.Context, *StartUpgradeRequest) (*UpgradeStatus, error)
+	// AcceptUpgrade acknowledges the upgrade proposed to this node and schedules its restart
+	AcceptUpgrade(context.Context, *AcceptUpgradeRequest) (*UpgradeStatus, error)
+	// Leave announces to the group that this node is leaving it and stops signing partials after the given round
+	Leave(context.Context, *LeaveRequest) (*LeaveStatus, error)
+	// Snapshot collects the state of all the beacons run by this daemon at a single point in time
+	Snapshot(context.Context, *SnapshotRequest) (*SnapshotResponse, error)
+	// RoundMessage returns the exact message the node signs a partial over for the given round, so that external
+	// signers and auditors can reproduce and authorize it independently
+	RoundMessage(context.Context, *RoundMessageRequest) (*RoundMessageResponse, error)
+	// Notarize returns a self-contained bundle allowing to verify the randomness of the given round offline,
+	// signed by the identity of the node, for legal or archival use
+	Notarize(context.Context, *NotarizeRequest) (*NotarizationBundle, error)
+	// RandomnessStats computes basic statistical summaries of the randomness over a range of rounds, as a
+	// consumer-confidence tool. The results are cached in the chain store when it supports it.
+	RandomnessStats(context.Context, *RandomnessStatsRequest) (*RandomnessStatsResponse, error)
+	// MakeJoinKit returns a bundle, signed by the identity of the node, allowing new followers to start from a
+	// recent verified checkpoint instead of syncing and verifying the chain from genesis
+	MakeJoinKit(context.Context, *JoinKitRequest) (*JoinKit, error)
+	// ListMetrics describes the metrics exported by the daemon, only the ones of the beacon of the metadata if set
+	ListMetrics(context.Context, *ListMetricsRequest) (*ListMetricsResponse, error)
+	// FeatureFlags lists the feature flags of the beacon of the metadata, after turning one on or off at runtime if
+	// asked to
+	FeatureFlags(context.Context, *FeatureFlagsRequest) (*FeatureFlagsResponse, error)
+	// AvailabilityReport computes the availability of each member of the group over a time window, from the status
+	// samples the node collects periodically
+	AvailabilityReport(context.Context, *AvailabilityReportRequest) (*AvailabilityReportResponse, error)
+	// PartialAudit returns the members whose partials were aggregated into each round of a range, as recorded by the
+	// node while the partial-audit feature is enabled
+	PartialAudit(context.Context, *PartialAuditRequest) (*PartialAuditResponse, error)
+	// InjectBeacon stores a beacon obtained out-of-band, e.g. from the store of another node, once verified against the
+	// chain, to repair a gap when the peers are unreachable. It is refused unless the beacon-injection feature is enabled.
+	InjectBeacon(context.Context, *InjectBeaconRequest) (*InjectBeaconResponse, error)
+	// Tombstones returns the beacons replaced in the store of a beacon when correcting its chain, as kept by the node
+	// with the beacons replacing them
+	Tombstones(context.Context, *TombstonesRequest) (*TombstonesResponse, error)
+	// StoreMetadata reads, writes or lists the values kept by an extension in a namespace of the metadata of the chain
+	// store of a beacon. The namespaces starting with "drand." are used by the node and are read-only.
+	StoreMetadata(context.Context, *StoreMetadataRequest) (*StoreMetadataResponse, error)
+	// PauseBeacon stops the node from signing and sending its partials, of the chain as well as of its sub-beacons and
+	// heartbeats, while it keeps following the chain and serving it, e.g. while investigating a suspected compromise of
+	// its key. It is refused if the group would then be expected to miss rounds, unless forced.
+	PauseBeacon(context.Context, *PauseBeaconRequest) (*PauseStatus, error)
+	// ResumeBeacon makes a paused node sign and send its partials again
+	ResumeBeacon(context.Context, *ResumeBeaconRequest) (*PauseStatus, error)
+	// Incident runs the response to a suspected compromise of the share of the node in one action: it pauses the node,
+	// saves a snapshot of the daemon, and announces to the group that the node leaves it so that the coordinator of
+	// the next reshare excludes it. It reports each step, including those which couldn't be run.
+	Incident(context.Context, *IncidentRequest) (*IncidentResponse, error)
+	// UpdateAddress announces to the members of the group that the node is now reachable at a new address, signed with
+	// its longterm key, so that they reach it there without a new group file
+	UpdateAddress(context.Context, *UpdateAddressRequest) (*UpdateAddressResponse, error)
+	// AddressOverrides lists the addresses the members of the group of the beacon are dialed at instead of the ones
+	// of the group file, after setting or removing the one of a member if asked to. They are kept across restarts.
+	AddressOverrides(context.Context, *AddressOverridesRequest) (*AddressOverridesResponse, error)
+	// ForecastReshare registers the date this node, as the coordinator, intends to run the next reshare of the group
+	// at and announces it to the members, or clears it if asked to. It returns the current forecast otherwise.
+	ForecastReshare(context.Context, *ForecastReshareRequest) (*ForecastReshareResponse, error)
+	// APIUsage reports the requests to the public API of each client, by API key, when the requests are limited by tier
+	APIUsage(context.Context, *APIUsageRequest) (*APIUsageResponse, error)
+	// RoundAnnotations lists the notes the operators attached to the rounds of a beacon, e.g. the lottery a round was
+	// used for, after attaching or removing some if asked to. They are kept in the metadata of the chain store, apart
+	// from the beacons, and never leave the node.
+	RoundAnnotations(context.Context, *RoundAnnotationsRequest) (*RoundAnnotationsResponse, error)
+	// Countdown returns the time left until the genesis of the chain, or until the transition to the group of the last
+	// reshare, with the readiness of each member of the group, so that a launch can be followed by all the operators
+	Countdown(context.Context, *CountdownRequest) (*CountdownResponse, error)
+	// EntropyStream streams bytes derived from the successive beacons of the chain with HKDF-SHA256, starting with the
+	// latest one, for the consumers of entropy. The bytes are public, anyone can derive them from the beacons.
+	EntropyStream(*EntropyStreamRequest, Control_EntropyStreamServer) error
 }
 
 // UnimplementedControlServer should be embedded to have forward compatible implementations.
@@ -259,9 +833,18 @@ func (UnimplementedControlServer) PingPong(context.Context, *Ping) (*Pong, error
 func (UnimplementedControlServer) Status(context.Context, *StatusRequest) (*StatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
 }
+func (UnimplementedControlServer) StatusStream(*StatusStreamRequest, Control_StatusStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method StatusStream not implemented")
+}
 func (UnimplementedControlServer) ListSchemes(context.Context, *ListSchemesRequest) (*ListSchemesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListSchemes not implemented")
 }
+func (UnimplementedControlServer) ListBeaconIDs(context.Context, *ListBeaconIDsRequest) (*ListBeaconIDsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBeaconIDs not implemented")
+}
+func (UnimplementedControlServer) GetCapabilities(context.Context, *CapabilitiesRequest) (*Capabilities, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapabilities not implemented")
+}
 func (UnimplementedControlServer) PublicKey(context.Context, *PublicKeyRequest) (*PublicKeyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PublicKey not implemented")
 }
@@ -277,6 +860,9 @@ func (UnimplementedControlServer) Shutdown(context.Context, *ShutdownRequest) (*
 func (UnimplementedControlServer) LoadBeacon(context.Context, *LoadBeaconRequest) (*LoadBeaconResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LoadBeacon not implemented")
 }
+func (UnimplementedControlServer) RemoveBeaconID(context.Context, *RemoveBeaconIDRequest) (*RemoveBeaconIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveBeaconID not implemented")
+}
 func (UnimplementedControlServer) StartFollowChain(*StartSyncRequest, Control_StartFollowChainServer) error {
 	return status.Errorf(codes.Unimplemented, "method StartFollowChain not implemented")
 }
@@ -289,240 +875,948 @@ func (UnimplementedControlServer) BackupDatabase(context.Context, *BackupDBReque
 func (UnimplementedControlServer) RemoteStatus(context.Context, *RemoteStatusRequest) (*RemoteStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoteStatus not implemented")
 }
-
-// UnsafeControlServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to ControlServer will
-// result in compilation errors.
-type UnsafeControlServer interface {
-	mustEmbedUnimplementedControlServer()
+func (UnimplementedControlServer) GroupBuildInfo(context.Context, *GroupBuildInfoRequest) (*GroupBuildInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GroupBuildInfo not implemented")
 }
-
-func RegisterControlServer(s grpc.ServiceRegistrar, srv ControlServer) {
-	s.RegisterService(&Control_ServiceDesc, srv)
+func (UnimplementedControlServer) GroupDST(context.Context, *GroupDSTRequest) (*GroupDSTResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GroupDST not implemented")
+}
+func (UnimplementedControlServer) RotateControlToken(context.Context, *RotateControlTokenRequest) (*RotateControlTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateControlToken not implemented")
+}
+func (UnimplementedControlServer) ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadConfig not implemented")
+}
+func (UnimplementedControlServer) LogLevels(context.Context, *LogLevelsRequest) (*LogLevelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogLevels not implemented")
+}
+func (UnimplementedControlServer) StartUpgrade(context.Context, *StartUpgradeRequest) (*UpgradeStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartUpgrade not implemented")
+}
+func (UnimplementedControlServer) AcceptUpgrade(context.Context, *AcceptUpgradeRequest) (*UpgradeStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptUpgrade not implemented")
+}
+func (UnimplementedControlServer) Leave(context.Context, *LeaveRequest) (*LeaveStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Leave not implemented")
+}
+func (UnimplementedControlServer) Snapshot(context.Context, *SnapshotRequest) (*SnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Snapshot not implemented")
+}
+func (UnimplementedControlServer) RoundMessage(context.Context, *RoundMessageRequest) (*RoundMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RoundMessage not implemented")
+}
+func (UnimplementedControlServer) Notarize(context.Context, *NotarizeRequest) (*NotarizationBundle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Notarize not implemented")
+}
+func (UnimplementedControlServer) RandomnessStats(context.Context, *RandomnessStatsRequest) (*RandomnessStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RandomnessStats not implemented")
+}
+func (UnimplementedControlServer) MakeJoinKit(context.Context, *JoinKitRequest) (*JoinKit, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MakeJoinKit not implemented")
+}
+func (UnimplementedControlServer) ListMetrics(context.Context, *ListMetricsRequest) (*ListMetricsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMetrics not implemented")
+}
+func (UnimplementedControlServer) FeatureFlags(context.Context, *FeatureFlagsRequest) (*FeatureFlagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FeatureFlags not implemented")
+}
+func (UnimplementedControlServer) AvailabilityReport(context.Context, *AvailabilityReportRequest) (*AvailabilityReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AvailabilityReport not implemented")
+}
+func (UnimplementedControlServer) PartialAudit(context.Context, *PartialAuditRequest) (*PartialAuditResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PartialAudit not implemented")
+}
+func (UnimplementedControlServer) InjectBeacon(context.Context, *InjectBeaconRequest) (*InjectBeaconResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InjectBeacon not implemented")
+}
+func (UnimplementedControlServer) Tombstones(context.Context, *TombstonesRequest) (*TombstonesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Tombstones not implemented")
+}
+func (UnimplementedControlServer) StoreMetadata(context.Context, *StoreMetadataRequest) (*StoreMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StoreMetadata not implemented")
+}
+func (UnimplementedControlServer) PauseBeacon(context.Context, *PauseBeaconRequest) (*PauseStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseBeacon not implemented")
+}
+func (UnimplementedControlServer) ResumeBeacon(context.Context, *ResumeBeaconRequest) (*PauseStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeBeacon not implemented")
+}
+func (UnimplementedControlServer) Incident(context.Context, *IncidentRequest) (*IncidentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Incident not implemented")
+}
+func (UnimplementedControlServer) UpdateAddress(context.Context, *UpdateAddressRequest) (*UpdateAddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAddress not implemented")
+}
+func (UnimplementedControlServer) AddressOverrides(context.Context, *AddressOverridesRequest) (*AddressOverridesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddressOverrides not implemented")
+}
+func (UnimplementedControlServer) ForecastReshare(context.Context, *ForecastReshareRequest) (*ForecastReshareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForecastReshare not implemented")
+}
+func (UnimplementedControlServer) APIUsage(context.Context, *APIUsageRequest) (*APIUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method APIUsage not implemented")
+}
+func (UnimplementedControlServer) RoundAnnotations(context.Context, *RoundAnnotationsRequest) (*RoundAnnotationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RoundAnnotations not implemented")
+}
+func (UnimplementedControlServer) Countdown(context.Context, *CountdownRequest) (*CountdownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Countdown not implemented")
+}
+func (UnimplementedControlServer) EntropyStream(*EntropyStreamRequest, Control_EntropyStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method EntropyStream not implemented")
+}
+
+// UnsafeControlServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ControlServer will
+// result in compilation errors.
+type UnsafeControlServer interface {
+	mustEmbedUnimplementedControlServer()
+}
+
+func RegisterControlServer(s grpc.ServiceRegistrar, srv ControlServer) {
+	s.RegisterService(&Control_ServiceDesc, srv)
+}
+
+func _Control_PingPong_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Ping)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).PingPong(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_PingPong_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).PingPong(ctx, req.(*Ping))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_Status_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).Status(ctx, req.(*StatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_StatusStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StatusStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ControlServer).StatusStream(m, &controlStatusStreamServer{stream})
+}
+
+type Control_StatusStreamServer interface {
+	Send(*StatusResponse) error
+	grpc.ServerStream
+}
+
+type controlStatusStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *controlStatusStreamServer) Send(m *StatusResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Control_ListSchemes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSchemesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).ListSchemes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_ListSchemes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).ListSchemes(ctx, req.(*ListSchemesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_ListBeaconIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBeaconIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).ListBeaconIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_ListBeaconIDs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).ListBeaconIDs(ctx, req.(*ListBeaconIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_GetCapabilities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).GetCapabilities(ctx, req.(*CapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_PublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).PublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_PublicKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).PublicKey(ctx, req.(*PublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_ChainInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChainInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).ChainInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_ChainInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).ChainInfo(ctx, req.(*ChainInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_GroupFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).GroupFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_GroupFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).GroupFile(ctx, req.(*GroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShutdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).Shutdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_Shutdown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).Shutdown(ctx, req.(*ShutdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_LoadBeacon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadBeaconRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).LoadBeacon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_LoadBeacon_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).LoadBeacon(ctx, req.(*LoadBeaconRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_RemoveBeaconID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveBeaconIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).RemoveBeaconID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_RemoveBeaconID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).RemoveBeaconID(ctx, req.(*RemoveBeaconIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_StartFollowChain_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StartSyncRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ControlServer).StartFollowChain(m, &controlStartFollowChainServer{stream})
+}
+
+type Control_StartFollowChainServer interface {
+	Send(*SyncProgress) error
+	grpc.ServerStream
+}
+
+type controlStartFollowChainServer struct {
+	grpc.ServerStream
+}
+
+func (x *controlStartFollowChainServer) Send(m *SyncProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Control_StartCheckChain_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StartSyncRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ControlServer).StartCheckChain(m, &controlStartCheckChainServer{stream})
+}
+
+type Control_StartCheckChainServer interface {
+	Send(*SyncProgress) error
+	grpc.ServerStream
+}
+
+type controlStartCheckChainServer struct {
+	grpc.ServerStream
+}
+
+func (x *controlStartCheckChainServer) Send(m *SyncProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Control_BackupDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupDBRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).BackupDatabase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_BackupDatabase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).BackupDatabase(ctx, req.(*BackupDBRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_RemoteStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoteStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).RemoteStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_RemoteStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).RemoteStatus(ctx, req.(*RemoteStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_GroupBuildInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupBuildInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).GroupBuildInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_GroupBuildInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).GroupBuildInfo(ctx, req.(*GroupBuildInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_GroupDST_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupDSTRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).GroupDST(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_GroupDST_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).GroupDST(ctx, req.(*GroupDSTRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_RotateControlToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateControlTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).RotateControlToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_RotateControlToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).RotateControlToken(ctx, req.(*RotateControlTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_ReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).ReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_ReloadConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).ReloadConfig(ctx, req.(*ReloadConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_LogLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogLevelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).LogLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_LogLevels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).LogLevels(ctx, req.(*LogLevelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_StartUpgrade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartUpgradeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).StartUpgrade(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_StartUpgrade_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).StartUpgrade(ctx, req.(*StartUpgradeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_AcceptUpgrade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptUpgradeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).AcceptUpgrade(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_AcceptUpgrade_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).AcceptUpgrade(ctx, req.(*AcceptUpgradeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_Leave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).Leave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_Leave_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).Leave(ctx, req.(*LeaveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_Snapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).Snapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_Snapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).Snapshot(ctx, req.(*SnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_RoundMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoundMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).RoundMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_RoundMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).RoundMessage(ctx, req.(*RoundMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_Notarize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotarizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).Notarize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_Notarize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).Notarize(ctx, req.(*NotarizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Control_PingPong_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Ping)
+func _Control_RandomnessStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RandomnessStatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlServer).PingPong(ctx, in)
+		return srv.(ControlServer).RandomnessStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Control_PingPong_FullMethodName,
+		FullMethod: Control_RandomnessStats_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServer).PingPong(ctx, req.(*Ping))
+		return srv.(ControlServer).RandomnessStats(ctx, req.(*RandomnessStatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Control_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StatusRequest)
+func _Control_MakeJoinKit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinKitRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlServer).Status(ctx, in)
+		return srv.(ControlServer).MakeJoinKit(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Control_Status_FullMethodName,
+		FullMethod: Control_MakeJoinKit_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServer).Status(ctx, req.(*StatusRequest))
+		return srv.(ControlServer).MakeJoinKit(ctx, req.(*JoinKitRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Control_ListSchemes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListSchemesRequest)
+func _Control_ListMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMetricsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlServer).ListSchemes(ctx, in)
+		return srv.(ControlServer).ListMetrics(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Control_ListSchemes_FullMethodName,
+		FullMethod: Control_ListMetrics_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServer).ListSchemes(ctx, req.(*ListSchemesRequest))
+		return srv.(ControlServer).ListMetrics(ctx, req.(*ListMetricsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Control_PublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PublicKeyRequest)
+func _Control_FeatureFlags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FeatureFlagsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlServer).PublicKey(ctx, in)
+		return srv.(ControlServer).FeatureFlags(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Control_PublicKey_FullMethodName,
+		FullMethod: Control_FeatureFlags_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServer).PublicKey(ctx, req.(*PublicKeyRequest))
+		return srv.(ControlServer).FeatureFlags(ctx, req.(*FeatureFlagsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Control_ChainInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChainInfoRequest)
+func _Control_AvailabilityReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AvailabilityReportRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlServer).ChainInfo(ctx, in)
+		return srv.(ControlServer).AvailabilityReport(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Control_ChainInfo_FullMethodName,
+		FullMethod: Control_AvailabilityReport_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServer).ChainInfo(ctx, req.(*ChainInfoRequest))
+		return srv.(ControlServer).AvailabilityReport(ctx, req.(*AvailabilityReportRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Control_GroupFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GroupRequest)
+func _Control_PartialAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PartialAuditRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlServer).GroupFile(ctx, in)
+		return srv.(ControlServer).PartialAudit(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Control_GroupFile_FullMethodName,
+		FullMethod: Control_PartialAudit_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServer).GroupFile(ctx, req.(*GroupRequest))
+		return srv.(ControlServer).PartialAudit(ctx, req.(*PartialAuditRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Control_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ShutdownRequest)
+func _Control_InjectBeacon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InjectBeaconRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlServer).Shutdown(ctx, in)
+		return srv.(ControlServer).InjectBeacon(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Control_Shutdown_FullMethodName,
+		FullMethod: Control_InjectBeacon_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServer).Shutdown(ctx, req.(*ShutdownRequest))
+		return srv.(ControlServer).InjectBeacon(ctx, req.(*InjectBeaconRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Control_LoadBeacon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LoadBeaconRequest)
+func _Control_Tombstones_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TombstonesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlServer).LoadBeacon(ctx, in)
+		return srv.(ControlServer).Tombstones(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Control_LoadBeacon_FullMethodName,
+		FullMethod: Control_Tombstones_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServer).LoadBeacon(ctx, req.(*LoadBeaconRequest))
+		return srv.(ControlServer).Tombstones(ctx, req.(*TombstonesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Control_StartFollowChain_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(StartSyncRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _Control_StoreMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(ControlServer).StartFollowChain(m, &controlStartFollowChainServer{stream})
+	if interceptor == nil {
+		return srv.(ControlServer).StoreMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_StoreMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).StoreMetadata(ctx, req.(*StoreMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type Control_StartFollowChainServer interface {
-	Send(*SyncProgress) error
-	grpc.ServerStream
+func _Control_PauseBeacon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseBeaconRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).PauseBeacon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_PauseBeacon_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).PauseBeacon(ctx, req.(*PauseBeaconRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type controlStartFollowChainServer struct {
-	grpc.ServerStream
+func _Control_ResumeBeacon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeBeaconRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).ResumeBeacon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_ResumeBeacon_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).ResumeBeacon(ctx, req.(*ResumeBeaconRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *controlStartFollowChainServer) Send(m *SyncProgress) error {
-	return x.ServerStream.SendMsg(m)
+func _Control_Incident_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IncidentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).Incident(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_Incident_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).Incident(ctx, req.(*IncidentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Control_StartCheckChain_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(StartSyncRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _Control_UpdateAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(ControlServer).StartCheckChain(m, &controlStartCheckChainServer{stream})
+	if interceptor == nil {
+		return srv.(ControlServer).UpdateAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_UpdateAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).UpdateAddress(ctx, req.(*UpdateAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type Control_StartCheckChainServer interface {
-	Send(*SyncProgress) error
-	grpc.ServerStream
+func _Control_AddressOverrides_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressOverridesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).AddressOverrides(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_AddressOverrides_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).AddressOverrides(ctx, req.(*AddressOverridesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type controlStartCheckChainServer struct {
-	grpc.ServerStream
+func _Control_ForecastReshare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForecastReshareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).ForecastReshare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_ForecastReshare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).ForecastReshare(ctx, req.(*ForecastReshareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *controlStartCheckChainServer) Send(m *SyncProgress) error {
-	return x.ServerStream.SendMsg(m)
+func _Control_APIUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APIUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).APIUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_APIUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).APIUsage(ctx, req.(*APIUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Control_BackupDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BackupDBRequest)
+func _Control_RoundAnnotations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoundAnnotationsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlServer).BackupDatabase(ctx, in)
+		return srv.(ControlServer).RoundAnnotations(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Control_BackupDatabase_FullMethodName,
+		FullMethod: Control_RoundAnnotations_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServer).BackupDatabase(ctx, req.(*BackupDBRequest))
+		return srv.(ControlServer).RoundAnnotations(ctx, req.(*RoundAnnotationsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Control_RemoteStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoteStatusRequest)
+func _Control_Countdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountdownRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlServer).RemoteStatus(ctx, in)
+		return srv.(ControlServer).Countdown(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Control_RemoteStatus_FullMethodName,
+		FullMethod: Control_Countdown_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServer).RemoteStatus(ctx, req.(*RemoteStatusRequest))
+		return srv.(ControlServer).Countdown(ctx, req.(*CountdownRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Control_EntropyStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EntropyStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ControlServer).EntropyStream(m, &controlEntropyStreamServer{stream})
+}
+
+type Control_EntropyStreamServer interface {
+	Send(*EntropyChunk) error
+	grpc.ServerStream
+}
+
+type controlEntropyStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *controlEntropyStreamServer) Send(m *EntropyChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Control_ServiceDesc is the grpc.ServiceDesc for Control service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -542,6 +1836,14 @@ var Control_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListSchemes",
 			Handler:    _Control_ListSchemes_Handler,
 		},
+		{
+			MethodName: "ListBeaconIDs",
+			Handler:    _Control_ListBeaconIDs_Handler,
+		},
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _Control_GetCapabilities_Handler,
+		},
 		{
 			MethodName: "PublicKey",
 			Handler:    _Control_PublicKey_Handler,
@@ -562,6 +1864,10 @@ var Control_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LoadBeacon",
 			Handler:    _Control_LoadBeacon_Handler,
 		},
+		{
+			MethodName: "RemoveBeaconID",
+			Handler:    _Control_RemoveBeaconID_Handler,
+		},
 		{
 			MethodName: "BackupDatabase",
 			Handler:    _Control_BackupDatabase_Handler,
@@ -570,8 +1876,129 @@ var Control_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoteStatus",
 			Handler:    _Control_RemoteStatus_Handler,
 		},
+		{
+			MethodName: "GroupBuildInfo",
+			Handler:    _Control_GroupBuildInfo_Handler,
+		},
+		{
+			MethodName: "GroupDST",
+			Handler:    _Control_GroupDST_Handler,
+		},
+		{
+			MethodName: "RotateControlToken",
+			Handler:    _Control_RotateControlToken_Handler,
+		},
+		{
+			MethodName: "ReloadConfig",
+			Handler:    _Control_ReloadConfig_Handler,
+		},
+		{
+			MethodName: "LogLevels",
+			Handler:    _Control_LogLevels_Handler,
+		},
+		{
+			MethodName: "StartUpgrade",
+			Handler:    _Control_StartUpgrade_Handler,
+		},
+		{
+			MethodName: "AcceptUpgrade",
+			Handler:    _Control_AcceptUpgrade_Handler,
+		},
+		{
+			MethodName: "Leave",
+			Handler:    _Control_Leave_Handler,
+		},
+		{
+			MethodName: "Snapshot",
+			Handler:    _Control_Snapshot_Handler,
+		},
+		{
+			MethodName: "RoundMessage",
+			Handler:    _Control_RoundMessage_Handler,
+		},
+		{
+			MethodName: "Notarize",
+			Handler:    _Control_Notarize_Handler,
+		},
+		{
+			MethodName: "RandomnessStats",
+			Handler:    _Control_RandomnessStats_Handler,
+		},
+		{
+			MethodName: "MakeJoinKit",
+			Handler:    _Control_MakeJoinKit_Handler,
+		},
+		{
+			MethodName: "ListMetrics",
+			Handler:    _Control_ListMetrics_Handler,
+		},
+		{
+			MethodName: "FeatureFlags",
+			Handler:    _Control_FeatureFlags_Handler,
+		},
+		{
+			MethodName: "AvailabilityReport",
+			Handler:    _Control_AvailabilityReport_Handler,
+		},
+		{
+			MethodName: "PartialAudit",
+			Handler:    _Control_PartialAudit_Handler,
+		},
+		{
+			MethodName: "InjectBeacon",
+			Handler:    _Control_InjectBeacon_Handler,
+		},
+		{
+			MethodName: "Tombstones",
+			Handler:    _Control_Tombstones_Handler,
+		},
+		{
+			MethodName: "StoreMetadata",
+			Handler:    _Control_StoreMetadata_Handler,
+		},
+		{
+			MethodName: "PauseBeacon",
+			Handler:    _Control_PauseBeacon_Handler,
+		},
+		{
+			MethodName: "ResumeBeacon",
+			Handler:    _Control_ResumeBeacon_Handler,
+		},
+		{
+			MethodName: "Incident",
+			Handler:    _Control_Incident_Handler,
+		},
+		{
+			MethodName: "UpdateAddress",
+			Handler:    _Control_UpdateAddress_Handler,
+		},
+		{
+			MethodName: "AddressOverrides",
+			Handler:    _Control_AddressOverrides_Handler,
+		},
+		{
+			MethodName: "ForecastReshare",
+			Handler:    _Control_ForecastReshare_Handler,
+		},
+		{
+			MethodName: "APIUsage",
+			Handler:    _Control_APIUsage_Handler,
+		},
+		{
+			MethodName: "RoundAnnotations",
+			Handler:    _Control_RoundAnnotations_Handler,
+		},
+		{
+			MethodName: "Countdown",
+			Handler:    _Control_Countdown_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StatusStream",
+			Handler:       _Control_StatusStream_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "StartFollowChain",
 			Handler:       _Control_StartFollowChain_Handler,
@@ -582,6 +2009,11 @@ var Control_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Control_StartCheckChain_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "EntropyStream",
+			Handler:       _Control_EntropyStream_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "drand/control.proto",
 }
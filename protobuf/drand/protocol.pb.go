@@ -178,6 +178,7 @@ type PartialBeaconPacket struct {
 	// partial signature - a threshold of them needs to be aggregated to produce
 	// the final beacon at the given round.
 	PartialSig []byte `protobuf:"bytes,3,opt,name=partial_sig,json=partialSig,proto3" json:"partial_sig,omitempty"`
+	//
 	Metadata *Metadata `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
 }
 
@@ -249,7 +250,13 @@ type SyncRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	FromRound uint64 `protobuf:"varint,1,opt,name=from_round,json=fromRound,proto3" json:"from_round,omitempty"`
+	//
 	Metadata *Metadata `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// relay_path holds the addresses of the nodes the chain is relayed to
+	// through this request: the node following and the ones following it in
+	// turn. A node refuses to serve a request whose path contains it, since
+	// it would be relaying its own chain in a loop.
+	RelayPath []string `protobuf:"bytes,3,rep,name=relay_path,json=relayPath,proto3" json:"relay_path,omitempty"`
 }
 
 func (x *SyncRequest) Reset() {
@@ -298,34 +305,608 @@ func (x *SyncRequest) GetMetadata() *Metadata {
 	return nil
 }
 
+func (x *SyncRequest) GetRelayPath() []string {
+	if x != nil {
+		return x.RelayPath
+	}
+	return nil
+}
+
 type BeaconPacket struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PreviousSignature []byte    `protobuf:"bytes,1,opt,name=previous_signature,json=previousSignature,proto3" json:"previous_signature,omitempty"`
-	Round             uint64    `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
-	Signature         []byte    `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
-	Metadata          *Metadata `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	PreviousSignature []byte    `protobuf:"bytes,1,opt,name=previous_signature,json=previousSignature,proto3" json:"previous_signature,omitempty"`
+	Round             uint64    `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Signature         []byte    `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	Metadata          *Metadata `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *BeaconPacket) Reset() {
+	*x = BeaconPacket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_protocol_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BeaconPacket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeaconPacket) ProtoMessage() {}
+
+func (x *BeaconPacket) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_protocol_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeaconPacket.ProtoReflect.Descriptor instead.
+func (*BeaconPacket) Descriptor() ([]byte, []int) {
+	return file_drand_protocol_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BeaconPacket) GetPreviousSignature() []byte {
+	if x != nil {
+		return x.PreviousSignature
+	}
+	return nil
+}
+
+func (x *BeaconPacket) GetRound() uint64 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *BeaconPacket) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *BeaconPacket) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// UpgradeProposal is the restart window proposed by the coordinator of an upgrade, signed with its longterm key
+type UpgradeProposal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Coordinator string    `protobuf:"bytes,1,opt,name=coordinator,proto3" json:"coordinator,omitempty"`
+	WindowStart int64     `protobuf:"varint,2,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"`
+	WindowEnd   int64     `protobuf:"varint,3,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`
+	Signature   []byte    `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	Metadata    *Metadata `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *UpgradeProposal) Reset() {
+	*x = UpgradeProposal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_protocol_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpgradeProposal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpgradeProposal) ProtoMessage() {}
+
+func (x *UpgradeProposal) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_protocol_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpgradeProposal.ProtoReflect.Descriptor instead.
+func (*UpgradeProposal) Descriptor() ([]byte, []int) {
+	return file_drand_protocol_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpgradeProposal) GetCoordinator() string {
+	if x != nil {
+		return x.Coordinator
+	}
+	return ""
+}
+
+func (x *UpgradeProposal) GetWindowStart() int64 {
+	if x != nil {
+		return x.WindowStart
+	}
+	return 0
+}
+
+func (x *UpgradeProposal) GetWindowEnd() int64 {
+	if x != nil {
+		return x.WindowEnd
+	}
+	return 0
+}
+
+func (x *UpgradeProposal) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *UpgradeProposal) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// UpgradeAcknowledgement is the signed acceptance of an UpgradeProposal by a member of the group
+type UpgradeAcknowledgement struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address     string    `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	WindowStart int64     `protobuf:"varint,2,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"`
+	WindowEnd   int64     `protobuf:"varint,3,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`
+	Signature   []byte    `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	Metadata    *Metadata `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *UpgradeAcknowledgement) Reset() {
+	*x = UpgradeAcknowledgement{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_protocol_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpgradeAcknowledgement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpgradeAcknowledgement) ProtoMessage() {}
+
+func (x *UpgradeAcknowledgement) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_protocol_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpgradeAcknowledgement.ProtoReflect.Descriptor instead.
+func (*UpgradeAcknowledgement) Descriptor() ([]byte, []int) {
+	return file_drand_protocol_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpgradeAcknowledgement) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *UpgradeAcknowledgement) GetWindowStart() int64 {
+	if x != nil {
+		return x.WindowStart
+	}
+	return 0
+}
+
+func (x *UpgradeAcknowledgement) GetWindowEnd() int64 {
+	if x != nil {
+		return x.WindowEnd
+	}
+	return 0
+}
+
+func (x *UpgradeAcknowledgement) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *UpgradeAcknowledgement) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// LeaveAnnouncement is the intent of a node to leave the group after the given round, signed with its longterm key
+type LeaveAnnouncement struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address   string    `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	LastRound uint64    `protobuf:"varint,2,opt,name=last_round,json=lastRound,proto3" json:"last_round,omitempty"`
+	Signature []byte    `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	Metadata  *Metadata `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *LeaveAnnouncement) Reset() {
+	*x = LeaveAnnouncement{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_protocol_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeaveAnnouncement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveAnnouncement) ProtoMessage() {}
+
+func (x *LeaveAnnouncement) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_protocol_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveAnnouncement.ProtoReflect.Descriptor instead.
+func (*LeaveAnnouncement) Descriptor() ([]byte, []int) {
+	return file_drand_protocol_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LeaveAnnouncement) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *LeaveAnnouncement) GetLastRound() uint64 {
+	if x != nil {
+		return x.LastRound
+	}
+	return 0
+}
+
+func (x *LeaveAnnouncement) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *LeaveAnnouncement) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// AddressAnnouncement is the new address a member of the group, known by its address in the group file, can be
+// reached at, signed with its longterm key. The sequence orders the announcements of a member, the highest applies.
+type AddressAnnouncement struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address    string    `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	NewAddress string    `protobuf:"bytes,2,opt,name=new_address,json=newAddress,proto3" json:"new_address,omitempty"`
+	Sequence   uint64    `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Signature  []byte    `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	Metadata   *Metadata `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *AddressAnnouncement) Reset() {
+	*x = AddressAnnouncement{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_protocol_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddressAnnouncement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressAnnouncement) ProtoMessage() {}
+
+func (x *AddressAnnouncement) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_protocol_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressAnnouncement.ProtoReflect.Descriptor instead.
+func (*AddressAnnouncement) Descriptor() ([]byte, []int) {
+	return file_drand_protocol_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AddressAnnouncement) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *AddressAnnouncement) GetNewAddress() string {
+	if x != nil {
+		return x.NewAddress
+	}
+	return ""
+}
+
+func (x *AddressAnnouncement) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *AddressAnnouncement) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *AddressAnnouncement) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// ReshareForecastAnnouncement is the date of the next reshare, signed by its coordinator. A date of 0 clears the
+// forecast.
+type ReshareForecastAnnouncement struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Coordinator string    `protobuf:"bytes,1,opt,name=coordinator,proto3" json:"coordinator,omitempty"`
+	At          int64     `protobuf:"varint,2,opt,name=at,proto3" json:"at,omitempty"`
+	Note        string    `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	Sequence    uint64    `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Signature   []byte    `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	Metadata    *Metadata `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *ReshareForecastAnnouncement) Reset() {
+	*x = ReshareForecastAnnouncement{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_protocol_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReshareForecastAnnouncement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReshareForecastAnnouncement) ProtoMessage() {}
+
+func (x *ReshareForecastAnnouncement) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_protocol_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReshareForecastAnnouncement.ProtoReflect.Descriptor instead.
+func (*ReshareForecastAnnouncement) Descriptor() ([]byte, []int) {
+	return file_drand_protocol_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ReshareForecastAnnouncement) GetCoordinator() string {
+	if x != nil {
+		return x.Coordinator
+	}
+	return ""
+}
+
+func (x *ReshareForecastAnnouncement) GetAt() int64 {
+	if x != nil {
+		return x.At
+	}
+	return 0
+}
+
+func (x *ReshareForecastAnnouncement) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *ReshareForecastAnnouncement) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *ReshareForecastAnnouncement) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *ReshareForecastAnnouncement) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// PartialHeartbeatPacket is the partial signature of a heartbeat by a member of the group. A threshold of them
+// is aggregated into a HeartbeatPacket.
+type PartialHeartbeatPacket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the number of heartbeat periods elapsed since the genesis of the chain
+	Index uint64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// the chain tip the heartbeat commits to
+	TipRound     uint64 `protobuf:"varint,2,opt,name=tip_round,json=tipRound,proto3" json:"tip_round,omitempty"`
+	TipSignature []byte `protobuf:"bytes,3,opt,name=tip_signature,json=tipSignature,proto3" json:"tip_signature,omitempty"`
+	// the hash of the group file, committing to the list of members
+	GroupHash  []byte    `protobuf:"bytes,4,opt,name=group_hash,json=groupHash,proto3" json:"group_hash,omitempty"`
+	PartialSig []byte    `protobuf:"bytes,5,opt,name=partial_sig,json=partialSig,proto3" json:"partial_sig,omitempty"`
+	Metadata   *Metadata `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *PartialHeartbeatPacket) Reset() {
+	*x = PartialHeartbeatPacket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_protocol_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PartialHeartbeatPacket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartialHeartbeatPacket) ProtoMessage() {}
+
+func (x *PartialHeartbeatPacket) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_protocol_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartialHeartbeatPacket.ProtoReflect.Descriptor instead.
+func (*PartialHeartbeatPacket) Descriptor() ([]byte, []int) {
+	return file_drand_protocol_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PartialHeartbeatPacket) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *PartialHeartbeatPacket) GetTipRound() uint64 {
+	if x != nil {
+		return x.TipRound
+	}
+	return 0
+}
+
+func (x *PartialHeartbeatPacket) GetTipSignature() []byte {
+	if x != nil {
+		return x.TipSignature
+	}
+	return nil
+}
+
+func (x *PartialHeartbeatPacket) GetGroupHash() []byte {
+	if x != nil {
+		return x.GroupHash
+	}
+	return nil
+}
+
+func (x *PartialHeartbeatPacket) GetPartialSig() []byte {
+	if x != nil {
+		return x.PartialSig
+	}
+	return nil
+}
+
+func (x *PartialHeartbeatPacket) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// PartialSubBeaconPacket is the partial signature of a round of a sub-beacon, a randomness stream derived from the
+// distributed key of the group with its own period
+type PartialSubBeaconPacket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Round      uint64    `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	PartialSig []byte    `protobuf:"bytes,3,opt,name=partial_sig,json=partialSig,proto3" json:"partial_sig,omitempty"`
+	Metadata   *Metadata `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
 }
 
-func (x *BeaconPacket) Reset() {
-	*x = BeaconPacket{}
+func (x *PartialSubBeaconPacket) Reset() {
+	*x = PartialSubBeaconPacket{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_drand_protocol_proto_msgTypes[4]
+		mi := &file_drand_protocol_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *BeaconPacket) String() string {
+func (x *PartialSubBeaconPacket) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BeaconPacket) ProtoMessage() {}
+func (*PartialSubBeaconPacket) ProtoMessage() {}
 
-func (x *BeaconPacket) ProtoReflect() protoreflect.Message {
-	mi := &file_drand_protocol_proto_msgTypes[4]
+func (x *PartialSubBeaconPacket) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_protocol_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -336,33 +917,155 @@ func (x *BeaconPacket) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BeaconPacket.ProtoReflect.Descriptor instead.
-func (*BeaconPacket) Descriptor() ([]byte, []int) {
-	return file_drand_protocol_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use PartialSubBeaconPacket.ProtoReflect.Descriptor instead.
+func (*PartialSubBeaconPacket) Descriptor() ([]byte, []int) {
+	return file_drand_protocol_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *BeaconPacket) GetPreviousSignature() []byte {
+func (x *PartialSubBeaconPacket) GetName() string {
 	if x != nil {
-		return x.PreviousSignature
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *BeaconPacket) GetRound() uint64 {
+func (x *PartialSubBeaconPacket) GetRound() uint64 {
 	if x != nil {
 		return x.Round
 	}
 	return 0
 }
 
-func (x *BeaconPacket) GetSignature() []byte {
+func (x *PartialSubBeaconPacket) GetPartialSig() []byte {
 	if x != nil {
-		return x.Signature
+		return x.PartialSig
 	}
 	return nil
 }
 
-func (x *BeaconPacket) GetMetadata() *Metadata {
+func (x *PartialSubBeaconPacket) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// PushedBeacon is a beacon pushed to a downstream node, along with the info of its chain so that the downstream node
+// can verify it without reaching the node pushing it
+type PushedBeacon struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Beacon   *BeaconPacket    `protobuf:"bytes,1,opt,name=beacon,proto3" json:"beacon,omitempty"`
+	Info     *ChainInfoPacket `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	Metadata *Metadata        `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *PushedBeacon) Reset() {
+	*x = PushedBeacon{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_protocol_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushedBeacon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushedBeacon) ProtoMessage() {}
+
+func (x *PushedBeacon) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_protocol_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushedBeacon.ProtoReflect.Descriptor instead.
+func (*PushedBeacon) Descriptor() ([]byte, []int) {
+	return file_drand_protocol_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PushedBeacon) GetBeacon() *BeaconPacket {
+	if x != nil {
+		return x.Beacon
+	}
+	return nil
+}
+
+func (x *PushedBeacon) GetInfo() *ChainInfoPacket {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *PushedBeacon) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// PushBeaconAck is the last round stored by the downstream node once the push is handled, from which the node pushing
+// the chain resumes: a round which doesn't follow the last one stored isn't stored
+type PushBeaconAck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LastRound uint64    `protobuf:"varint,1,opt,name=last_round,json=lastRound,proto3" json:"last_round,omitempty"`
+	Metadata  *Metadata `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *PushBeaconAck) Reset() {
+	*x = PushBeaconAck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_protocol_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushBeaconAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushBeaconAck) ProtoMessage() {}
+
+func (x *PushBeaconAck) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_protocol_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushBeaconAck.ProtoReflect.Descriptor instead.
+func (*PushBeaconAck) Descriptor() ([]byte, []int) {
+	return file_drand_protocol_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PushBeaconAck) GetLastRound() uint64 {
+	if x != nil {
+		return x.LastRound
+	}
+	return 0
+}
+
+func (x *PushBeaconAck) GetMetadata() *Metadata {
 	if x != nil {
 		return x.Metadata
 	}
@@ -401,42 +1104,172 @@ var file_drand_protocol_proto_rawDesc = []byte{
 	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x53, 0x69,
 	0x67, 0x12, 0x2b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20,
 	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x59,
+	0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x78,
 	0x0a, 0x0b, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
 	0x0a, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x04, 0x52, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x2b, 0x0a, 0x08,
 	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
 	0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
-	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x9e, 0x01, 0x0a, 0x0c, 0x42, 0x65,
-	0x61, 0x63, 0x6f, 0x6e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x2d, 0x0a, 0x12, 0x70, 0x72,
-	0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73,
-	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75,
-	0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12,
-	0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x2b, 0x0a,
-	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x32, 0xf6, 0x01, 0x0a, 0x08, 0x50,
-	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x3e, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x49, 0x64,
-	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x16, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x49,
-	0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
-	0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x0d, 0x50, 0x61, 0x72, 0x74, 0x69,
-	0x61, 0x6c, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x12, 0x1a, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64,
-	0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x50, 0x61,
-	0x63, 0x6b, 0x65, 0x74, 0x1a, 0x0c, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x12, 0x36, 0x0a, 0x09, 0x53, 0x79, 0x6e, 0x63, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x12,
-	0x12, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x42, 0x65, 0x61, 0x63,
-	0x6f, 0x6e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x30, 0x01, 0x12, 0x37, 0x0a, 0x06, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x64, 0x72, 0x61,
-	0x6e, 0x64, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x42, 0x2a, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2f, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2f, 0x76, 0x32,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x6c,
+	0x61, 0x79, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x72,
+	0x65, 0x6c, 0x61, 0x79, 0x50, 0x61, 0x74, 0x68, 0x22, 0x9e, 0x01, 0x0a, 0x0c, 0x42, 0x65, 0x61,
+	0x63, 0x6f, 0x6e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x2d, 0x0a, 0x12, 0x70, 0x72, 0x65,
+	0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x53,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x2b, 0x0a, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0xc0, 0x01, 0x0a, 0x0f, 0x55, 0x70,
+	0x67, 0x72, 0x61, 0x64, 0x65, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12, 0x20, 0x0a,
+	0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12,
+	0x21, 0x0a, 0x0c, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x65, 0x6e, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x45, 0x6e,
+	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12,
+	0x2b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0xbf, 0x01, 0x0a,
+	0x16, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x41, 0x63, 0x6b, 0x6e, 0x6f, 0x77, 0x6c, 0x65,
+	0x64, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x65,
+	0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77,
+	0x45, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x12, 0x2b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x97,
+	0x01, 0x0a, 0x11, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x2b, 0x0a, 0x08, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0xb7, 0x01, 0x0a, 0x13, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65,
+	0x77, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x6e, 0x65, 0x77, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x2b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x22, 0xca, 0x01, 0x0a, 0x1b, 0x52, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x46, 0x6f,
+	0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x6f,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e,
+	0x61, 0x74, 0x6f, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x02, 0x61, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x12, 0x2b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22,
+	0xdd, 0x01, 0x0a, 0x16, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x48, 0x65, 0x61, 0x72, 0x74,
+	0x62, 0x65, 0x61, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x70, 0x5f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x74, 0x69, 0x70, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x23, 0x0a,
+	0x0d, 0x74, 0x69, 0x70, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x74, 0x69, 0x70, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x48, 0x61, 0x73,
+	0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x73, 0x69, 0x67,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x53,
+	0x69, 0x67, 0x12, 0x2b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22,
+	0x90, 0x01, 0x0a, 0x16, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x53, 0x75, 0x62, 0x42, 0x65,
+	0x61, 0x63, 0x6f, 0x6e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72,
+	0x6f, 0x75, 0x6e, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x5f,
+	0x73, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x69,
+	0x61, 0x6c, 0x53, 0x69, 0x67, 0x12, 0x2b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x22, 0x94, 0x01, 0x0a, 0x0c, 0x50, 0x75, 0x73, 0x68, 0x65, 0x64, 0x42, 0x65, 0x61,
+	0x63, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x06, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x42, 0x65, 0x61, 0x63,
+	0x6f, 0x6e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x06, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e,
+	0x12, 0x2a, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f,
+	0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x2b, 0x0a, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x5b, 0x0a, 0x0d, 0x50, 0x75, 0x73,
+	0x68, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x41, 0x63, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61,
+	0x73, 0x74, 0x5f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x6c, 0x61, 0x73, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x2b, 0x0a, 0x08, 0x6d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x72,
+	0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x32, 0xef, 0x05, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x12, 0x3e, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x12, 0x16, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x64, 0x72, 0x61,
+	0x6e, 0x64, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x0d, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x42, 0x65,
+	0x61, 0x63, 0x6f, 0x6e, 0x12, 0x1a, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x61, 0x72,
+	0x74, 0x69, 0x61, 0x6c, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x1a, 0x0c, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x36,
+	0x0a, 0x09, 0x53, 0x79, 0x6e, 0x63, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x12, 0x2e, 0x64, 0x72,
+	0x61, 0x6e, 0x64, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x13, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x50, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x30, 0x01, 0x12, 0x37, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x14, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x36, 0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64,
+	0x65, 0x12, 0x16, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64,
+	0x65, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x1a, 0x0c, 0x2e, 0x64, 0x72, 0x61, 0x6e,
+	0x64, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x41, 0x0a, 0x12, 0x41, 0x63, 0x6b, 0x6e, 0x6f,
+	0x77, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x12, 0x1d, 0x2e,
+	0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x41, 0x63, 0x6b,
+	0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x1a, 0x0c, 0x2e, 0x64,
+	0x72, 0x61, 0x6e, 0x64, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x37, 0x0a, 0x0d, 0x41, 0x6e,
+	0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x12, 0x18, 0x2e, 0x64, 0x72,
+	0x61, 0x6e, 0x64, 0x2e, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x1a, 0x0c, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x12, 0x3b, 0x0a, 0x0f, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x1a, 0x0c, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x12, 0x4b, 0x0a, 0x17, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x12, 0x22, 0x2e, 0x64, 0x72,
+	0x61, 0x6e, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x46, 0x6f, 0x72, 0x65, 0x63,
+	0x61, 0x73, 0x74, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x1a,
+	0x0c, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3f, 0x0a,
+	0x10, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61,
+	0x74, 0x12, 0x1d, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61,
+	0x6c, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x1a, 0x0c, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3f,
+	0x0a, 0x10, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x53, 0x75, 0x62, 0x42, 0x65, 0x61, 0x63,
+	0x6f, 0x6e, 0x12, 0x1d, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69,
+	0x61, 0x6c, 0x53, 0x75, 0x62, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x50, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x1a, 0x0c, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
+	0x37, 0x0a, 0x0a, 0x50, 0x75, 0x73, 0x68, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x12, 0x13, 0x2e,
+	0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x65, 0x64, 0x42, 0x65, 0x61, 0x63,
+	0x6f, 0x6e, 0x1a, 0x14, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x42,
+	0x65, 0x61, 0x63, 0x6f, 0x6e, 0x41, 0x63, 0x6b, 0x42, 0x2a, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2f, 0x64, 0x72, 0x61,
+	0x6e, 0x64, 0x2f, 0x76, 0x32, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64,
+	0x72, 0x61, 0x6e, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -451,37 +1284,74 @@ func file_drand_protocol_proto_rawDescGZIP() []byte {
 	return file_drand_protocol_proto_rawDescData
 }
 
-var file_drand_protocol_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_drand_protocol_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_drand_protocol_proto_goTypes = []interface{}{
-	(*IdentityRequest)(nil),     // 0: drand.IdentityRequest
-	(*IdentityResponse)(nil),    // 1: drand.IdentityResponse
-	(*PartialBeaconPacket)(nil), // 2: drand.PartialBeaconPacket
-	(*SyncRequest)(nil),         // 3: drand.SyncRequest
-	(*BeaconPacket)(nil),        // 4: drand.BeaconPacket
-	(*Metadata)(nil),            // 5: drand.Metadata
-	(*StatusRequest)(nil),       // 6: drand.StatusRequest
-	(*Empty)(nil),               // 7: drand.Empty
-	(*StatusResponse)(nil),      // 8: drand.StatusResponse
+	(*IdentityRequest)(nil),             // 0: drand.IdentityRequest
+	(*IdentityResponse)(nil),            // 1: drand.IdentityResponse
+	(*PartialBeaconPacket)(nil),         // 2: drand.PartialBeaconPacket
+	(*SyncRequest)(nil),                 // 3: drand.SyncRequest
+	(*BeaconPacket)(nil),                // 4: drand.BeaconPacket
+	(*UpgradeProposal)(nil),             // 5: drand.UpgradeProposal
+	(*UpgradeAcknowledgement)(nil),      // 6: drand.UpgradeAcknowledgement
+	(*LeaveAnnouncement)(nil),           // 7: drand.LeaveAnnouncement
+	(*AddressAnnouncement)(nil),         // 8: drand.AddressAnnouncement
+	(*ReshareForecastAnnouncement)(nil), // 9: drand.ReshareForecastAnnouncement
+	(*PartialHeartbeatPacket)(nil),      // 10: drand.PartialHeartbeatPacket
+	(*PartialSubBeaconPacket)(nil),      // 11: drand.PartialSubBeaconPacket
+	(*PushedBeacon)(nil),                // 12: drand.PushedBeacon
+	(*PushBeaconAck)(nil),               // 13: drand.PushBeaconAck
+	(*Metadata)(nil),                    // 14: drand.Metadata
+	(*ChainInfoPacket)(nil),             // 15: drand.ChainInfoPacket
+	(*StatusRequest)(nil),               // 16: drand.StatusRequest
+	(*Empty)(nil),                       // 17: drand.Empty
+	(*StatusResponse)(nil),              // 18: drand.StatusResponse
 }
 var file_drand_protocol_proto_depIdxs = []int32{
-	5, // 0: drand.IdentityRequest.metadata:type_name -> drand.Metadata
-	5, // 1: drand.IdentityResponse.metadata:type_name -> drand.Metadata
-	5, // 2: drand.PartialBeaconPacket.metadata:type_name -> drand.Metadata
-	5, // 3: drand.SyncRequest.metadata:type_name -> drand.Metadata
-	5, // 4: drand.BeaconPacket.metadata:type_name -> drand.Metadata
-	0, // 5: drand.Protocol.GetIdentity:input_type -> drand.IdentityRequest
-	2, // 6: drand.Protocol.PartialBeacon:input_type -> drand.PartialBeaconPacket
-	3, // 7: drand.Protocol.SyncChain:input_type -> drand.SyncRequest
-	6, // 8: drand.Protocol.Status:input_type -> drand.StatusRequest
-	1, // 9: drand.Protocol.GetIdentity:output_type -> drand.IdentityResponse
-	7, // 10: drand.Protocol.PartialBeacon:output_type -> drand.Empty
-	4, // 11: drand.Protocol.SyncChain:output_type -> drand.BeaconPacket
-	8, // 12: drand.Protocol.Status:output_type -> drand.StatusResponse
-	9, // [9:13] is the sub-list for method output_type
-	5, // [5:9] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	14, // 0: drand.IdentityRequest.metadata:type_name -> drand.Metadata
+	14, // 1: drand.IdentityResponse.metadata:type_name -> drand.Metadata
+	14, // 2: drand.PartialBeaconPacket.metadata:type_name -> drand.Metadata
+	14, // 3: drand.SyncRequest.metadata:type_name -> drand.Metadata
+	14, // 4: drand.BeaconPacket.metadata:type_name -> drand.Metadata
+	14, // 5: drand.UpgradeProposal.metadata:type_name -> drand.Metadata
+	14, // 6: drand.UpgradeAcknowledgement.metadata:type_name -> drand.Metadata
+	14, // 7: drand.LeaveAnnouncement.metadata:type_name -> drand.Metadata
+	14, // 8: drand.AddressAnnouncement.metadata:type_name -> drand.Metadata
+	14, // 9: drand.ReshareForecastAnnouncement.metadata:type_name -> drand.Metadata
+	14, // 10: drand.PartialHeartbeatPacket.metadata:type_name -> drand.Metadata
+	14, // 11: drand.PartialSubBeaconPacket.metadata:type_name -> drand.Metadata
+	4,  // 12: drand.PushedBeacon.beacon:type_name -> drand.BeaconPacket
+	15, // 13: drand.PushedBeacon.info:type_name -> drand.ChainInfoPacket
+	14, // 14: drand.PushedBeacon.metadata:type_name -> drand.Metadata
+	14, // 15: drand.PushBeaconAck.metadata:type_name -> drand.Metadata
+	0,  // 16: drand.Protocol.GetIdentity:input_type -> drand.IdentityRequest
+	2,  // 17: drand.Protocol.PartialBeacon:input_type -> drand.PartialBeaconPacket
+	3,  // 18: drand.Protocol.SyncChain:input_type -> drand.SyncRequest
+	16, // 19: drand.Protocol.Status:input_type -> drand.StatusRequest
+	5,  // 20: drand.Protocol.ProposeUpgrade:input_type -> drand.UpgradeProposal
+	6,  // 21: drand.Protocol.AcknowledgeUpgrade:input_type -> drand.UpgradeAcknowledgement
+	7,  // 22: drand.Protocol.AnnounceLeave:input_type -> drand.LeaveAnnouncement
+	8,  // 23: drand.Protocol.AnnounceAddress:input_type -> drand.AddressAnnouncement
+	9,  // 24: drand.Protocol.AnnounceReshareForecast:input_type -> drand.ReshareForecastAnnouncement
+	10, // 25: drand.Protocol.PartialHeartbeat:input_type -> drand.PartialHeartbeatPacket
+	11, // 26: drand.Protocol.PartialSubBeacon:input_type -> drand.PartialSubBeaconPacket
+	12, // 27: drand.Protocol.PushBeacon:input_type -> drand.PushedBeacon
+	1,  // 28: drand.Protocol.GetIdentity:output_type -> drand.IdentityResponse
+	17, // 29: drand.Protocol.PartialBeacon:output_type -> drand.Empty
+	4,  // 30: drand.Protocol.SyncChain:output_type -> drand.BeaconPacket
+	18, // 31: drand.Protocol.Status:output_type -> drand.StatusResponse
+	17, // 32: drand.Protocol.ProposeUpgrade:output_type -> drand.Empty
+	17, // 33: drand.Protocol.AcknowledgeUpgrade:output_type -> drand.Empty
+	17, // 34: drand.Protocol.AnnounceLeave:output_type -> drand.Empty
+	17, // 35: drand.Protocol.AnnounceAddress:output_type -> drand.Empty
+	17, // 36: drand.Protocol.AnnounceReshareForecast:output_type -> drand.Empty
+	17, // 37: drand.Protocol.PartialHeartbeat:output_type -> drand.Empty
+	17, // 38: drand.Protocol.PartialSubBeacon:output_type -> drand.Empty
+	13, // 39: drand.Protocol.PushBeacon:output_type -> drand.PushBeaconAck
+	28, // [28:40] is the sub-list for method output_type
+	16, // [16:28] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_drand_protocol_proto_init() }
@@ -551,6 +1421,114 @@ func file_drand_protocol_proto_init() {
 				return nil
 			}
 		}
+		file_drand_protocol_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpgradeProposal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_protocol_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpgradeAcknowledgement); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_protocol_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LeaveAnnouncement); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_protocol_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddressAnnouncement); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_protocol_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReshareForecastAnnouncement); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_protocol_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PartialHeartbeatPacket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_protocol_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PartialSubBeaconPacket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_protocol_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushedBeacon); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_protocol_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushBeaconAck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -558,7 +1536,7 @@ func file_drand_protocol_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_drand_protocol_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
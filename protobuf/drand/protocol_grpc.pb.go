@@ -24,10 +24,18 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Protocol_GetIdentity_FullMethodName   = "/drand.Protocol/GetIdentity"
-	Protocol_PartialBeacon_FullMethodName = "/drand.Protocol/PartialBeacon"
-	Protocol_SyncChain_FullMethodName     = "/drand.Protocol/SyncChain"
-	Protocol_Status_FullMethodName        = "/drand.Protocol/Status"
+	Protocol_GetIdentity_FullMethodName             = "/drand.Protocol/GetIdentity"
+	Protocol_PartialBeacon_FullMethodName           = "/drand.Protocol/PartialBeacon"
+	Protocol_SyncChain_FullMethodName               = "/drand.Protocol/SyncChain"
+	Protocol_Status_FullMethodName                  = "/drand.Protocol/Status"
+	Protocol_ProposeUpgrade_FullMethodName          = "/drand.Protocol/ProposeUpgrade"
+	Protocol_AcknowledgeUpgrade_FullMethodName      = "/drand.Protocol/AcknowledgeUpgrade"
+	Protocol_AnnounceLeave_FullMethodName           = "/drand.Protocol/AnnounceLeave"
+	Protocol_AnnounceAddress_FullMethodName         = "/drand.Protocol/AnnounceAddress"
+	Protocol_AnnounceReshareForecast_FullMethodName = "/drand.Protocol/AnnounceReshareForecast"
+	Protocol_PartialHeartbeat_FullMethodName        = "/drand.Protocol/PartialHeartbeat"
+	Protocol_PartialSubBeacon_FullMethodName        = "/drand.Protocol/PartialSubBeacon"
+	Protocol_PushBeacon_FullMethodName              = "/drand.Protocol/PushBeacon"
 )
 
 // ProtocolClient is the client API for Protocol service.
@@ -42,6 +50,22 @@ type ProtocolClient interface {
 	SyncChain(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (Protocol_SyncChainClient, error)
 	// Status responds with the actual status of drand process
 	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// ProposeUpgrade is sent by the coordinator of an upgrade to the members of the group
+	ProposeUpgrade(ctx context.Context, in *UpgradeProposal, opts ...grpc.CallOption) (*Empty, error)
+	// AcknowledgeUpgrade is sent back to the coordinator by the members accepting an upgrade
+	AcknowledgeUpgrade(ctx context.Context, in *UpgradeAcknowledgement, opts ...grpc.CallOption) (*Empty, error)
+	// AnnounceLeave is sent by a node leaving the group to all its members
+	AnnounceLeave(ctx context.Context, in *LeaveAnnouncement, opts ...grpc.CallOption) (*Empty, error)
+	// AnnounceAddress is sent by a node whose address changed to all the members of the group
+	AnnounceAddress(ctx context.Context, in *AddressAnnouncement, opts ...grpc.CallOption) (*Empty, error)
+	// AnnounceReshareForecast is sent by the coordinator of the next reshare to all the members of the group
+	AnnounceReshareForecast(ctx context.Context, in *ReshareForecastAnnouncement, opts ...grpc.CallOption) (*Empty, error)
+	// PartialHeartbeat sends its partial signature of a heartbeat to another node
+	PartialHeartbeat(ctx context.Context, in *PartialHeartbeatPacket, opts ...grpc.CallOption) (*Empty, error)
+	// PartialSubBeacon sends its partial signature of a round of a sub-beacon to another node
+	PartialSubBeacon(ctx context.Context, in *PartialSubBeaconPacket, opts ...grpc.CallOption) (*Empty, error)
+	// PushBeacon replicates a beacon to a downstream node which can't reach the node producing the chain
+	PushBeacon(ctx context.Context, in *PushedBeacon, opts ...grpc.CallOption) (*PushBeaconAck, error)
 }
 
 type protocolClient struct {
@@ -111,6 +135,78 @@ func (c *protocolClient) Status(ctx context.Context, in *StatusRequest, opts ...
 	return out, nil
 }
 
+func (c *protocolClient) ProposeUpgrade(ctx context.Context, in *UpgradeProposal, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Protocol_ProposeUpgrade_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *protocolClient) AcknowledgeUpgrade(ctx context.Context, in *UpgradeAcknowledgement, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Protocol_AcknowledgeUpgrade_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *protocolClient) AnnounceLeave(ctx context.Context, in *LeaveAnnouncement, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Protocol_AnnounceLeave_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *protocolClient) AnnounceAddress(ctx context.Context, in *AddressAnnouncement, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Protocol_AnnounceAddress_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *protocolClient) AnnounceReshareForecast(ctx context.Context, in *ReshareForecastAnnouncement, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Protocol_AnnounceReshareForecast_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *protocolClient) PartialHeartbeat(ctx context.Context, in *PartialHeartbeatPacket, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Protocol_PartialHeartbeat_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *protocolClient) PartialSubBeacon(ctx context.Context, in *PartialSubBeaconPacket, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Protocol_PartialSubBeacon_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *protocolClient) PushBeacon(ctx context.Context, in *PushedBeacon, opts ...grpc.CallOption) (*PushBeaconAck, error) {
+	out := new(PushBeaconAck)
+	err := c.cc.Invoke(ctx, Protocol_PushBeacon_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProtocolServer is the server API for Protocol service.
 // All implementations should embed UnimplementedProtocolServer
 // for forward compatibility
@@ -123,6 +219,22 @@ type ProtocolServer interface {
 	SyncChain(*SyncRequest, Protocol_SyncChainServer) error
 	// Status responds with the actual status of drand process
 	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+	// ProposeUpgrade is sent by the coordinator of an upgrade to the members of the group
+	ProposeUpgrade(context.Context, *UpgradeProposal) (*Empty, error)
+	// AcknowledgeUpgrade is sent back to the coordinator by the members accepting an upgrade
+	AcknowledgeUpgrade(context.Context, *UpgradeAcknowledgement) (*Empty, error)
+	// AnnounceLeave is sent by a node leaving the group to all its members
+	AnnounceLeave(context.Context, *LeaveAnnouncement) (*Empty, error)
+	// AnnounceAddress is sent by a node whose address changed to all the members of the group
+	AnnounceAddress(context.Context, *AddressAnnouncement) (*Empty, error)
+	// AnnounceReshareForecast is sent by the coordinator of the next reshare to all the members of the group
+	AnnounceReshareForecast(context.Context, *ReshareForecastAnnouncement) (*Empty, error)
+	// PartialHeartbeat sends its partial signature of a heartbeat to another node
+	PartialHeartbeat(context.Context, *PartialHeartbeatPacket) (*Empty, error)
+	// PartialSubBeacon sends its partial signature of a round of a sub-beacon to another node
+	PartialSubBeacon(context.Context, *PartialSubBeaconPacket) (*Empty, error)
+	// PushBeacon replicates a beacon to a downstream node which can't reach the node producing the chain
+	PushBeacon(context.Context, *PushedBeacon) (*PushBeaconAck, error)
 }
 
 // UnimplementedProtocolServer should be embedded to have forward compatible implementations.
@@ -141,6 +253,30 @@ func (UnimplementedProtocolServer) SyncChain(*SyncRequest, Protocol_SyncChainSer
 func (UnimplementedProtocolServer) Status(context.Context, *StatusRequest) (*StatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
 }
+func (UnimplementedProtocolServer) ProposeUpgrade(context.Context, *UpgradeProposal) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProposeUpgrade not implemented")
+}
+func (UnimplementedProtocolServer) AcknowledgeUpgrade(context.Context, *UpgradeAcknowledgement) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcknowledgeUpgrade not implemented")
+}
+func (UnimplementedProtocolServer) AnnounceLeave(context.Context, *LeaveAnnouncement) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnnounceLeave not implemented")
+}
+func (UnimplementedProtocolServer) AnnounceAddress(context.Context, *AddressAnnouncement) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnnounceAddress not implemented")
+}
+func (UnimplementedProtocolServer) AnnounceReshareForecast(context.Context, *ReshareForecastAnnouncement) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnnounceReshareForecast not implemented")
+}
+func (UnimplementedProtocolServer) PartialHeartbeat(context.Context, *PartialHeartbeatPacket) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PartialHeartbeat not implemented")
+}
+func (UnimplementedProtocolServer) PartialSubBeacon(context.Context, *PartialSubBeaconPacket) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PartialSubBeacon not implemented")
+}
+func (UnimplementedProtocolServer) PushBeacon(context.Context, *PushedBeacon) (*PushBeaconAck, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushBeacon not implemented")
+}
 
 // UnsafeProtocolServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to ProtocolServer will
@@ -228,6 +364,150 @@ func _Protocol_Status_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Protocol_ProposeUpgrade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpgradeProposal)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProtocolServer).ProposeUpgrade(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Protocol_ProposeUpgrade_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProtocolServer).ProposeUpgrade(ctx, req.(*UpgradeProposal))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Protocol_AcknowledgeUpgrade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpgradeAcknowledgement)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProtocolServer).AcknowledgeUpgrade(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Protocol_AcknowledgeUpgrade_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProtocolServer).AcknowledgeUpgrade(ctx, req.(*UpgradeAcknowledgement))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Protocol_AnnounceLeave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveAnnouncement)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProtocolServer).AnnounceLeave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Protocol_AnnounceLeave_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProtocolServer).AnnounceLeave(ctx, req.(*LeaveAnnouncement))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Protocol_AnnounceAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressAnnouncement)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProtocolServer).AnnounceAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Protocol_AnnounceAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProtocolServer).AnnounceAddress(ctx, req.(*AddressAnnouncement))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Protocol_AnnounceReshareForecast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReshareForecastAnnouncement)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProtocolServer).AnnounceReshareForecast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Protocol_AnnounceReshareForecast_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProtocolServer).AnnounceReshareForecast(ctx, req.(*ReshareForecastAnnouncement))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Protocol_PartialHeartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PartialHeartbeatPacket)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProtocolServer).PartialHeartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Protocol_PartialHeartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProtocolServer).PartialHeartbeat(ctx, req.(*PartialHeartbeatPacket))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Protocol_PartialSubBeacon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PartialSubBeaconPacket)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProtocolServer).PartialSubBeacon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Protocol_PartialSubBeacon_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProtocolServer).PartialSubBeacon(ctx, req.(*PartialSubBeaconPacket))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Protocol_PushBeacon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushedBeacon)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProtocolServer).PushBeacon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Protocol_PushBeacon_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProtocolServer).PushBeacon(ctx, req.(*PushedBeacon))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Protocol_ServiceDesc is the grpc.ServiceDesc for Protocol service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -247,6 +527,38 @@ var Protocol_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Status",
 			Handler:    _Protocol_Status_Handler,
 		},
+		{
+			MethodName: "ProposeUpgrade",
+			Handler:    _Protocol_ProposeUpgrade_Handler,
+		},
+		{
+			MethodName: "AcknowledgeUpgrade",
+			Handler:    _Protocol_AcknowledgeUpgrade_Handler,
+		},
+		{
+			MethodName: "AnnounceLeave",
+			Handler:    _Protocol_AnnounceLeave_Handler,
+		},
+		{
+			MethodName: "AnnounceAddress",
+			Handler:    _Protocol_AnnounceAddress_Handler,
+		},
+		{
+			MethodName: "AnnounceReshareForecast",
+			Handler:    _Protocol_AnnounceReshareForecast_Handler,
+		},
+		{
+			MethodName: "PartialHeartbeat",
+			Handler:    _Protocol_PartialHeartbeat_Handler,
+		},
+		{
+			MethodName: "PartialSubBeacon",
+			Handler:    _Protocol_PartialSubBeacon_Handler,
+		},
+		{
+			MethodName: "PushBeacon",
+			Handler:    _Protocol_PushBeacon_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
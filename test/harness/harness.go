@@ -0,0 +1,469 @@
+// Package harness runs drand networks in process for end-to-end tests: it spins up daemons sharing a fake clock,
+// runs their DKG and reshares, stops and restarts their beacons and waits for them to reach rounds. It is the
+// orchestration the tests of the core use, exported so that the forks can run the same suites against their modified
+// cores.
+package harness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	clock "github.com/jonboulle/clockwork"
+	"google.golang.org/grpc"
+
+	"github.com/drand/drand/v2/common"
+	"github.com/drand/drand/v2/common/key"
+	"github.com/drand/drand/v2/common/log"
+	"github.com/drand/drand/v2/common/testlogger"
+	"github.com/drand/drand/v2/crypto"
+	"github.com/drand/drand/v2/internal/chain"
+	"github.com/drand/drand/v2/internal/core"
+	"github.com/drand/drand/v2/internal/dkg"
+	"github.com/drand/drand/v2/internal/net"
+	"github.com/drand/drand/v2/internal/test"
+	"github.com/drand/drand/v2/plugin"
+	pdkg "github.com/drand/drand/v2/protobuf/dkg"
+	"github.com/drand/drand/v2/protobuf/drand"
+)
+
+// dkgTimeout is how long the DKG proposals of the network are valid
+const dkgTimeout = 5 * time.Minute
+
+// waitTimeout bounds the waits for the nodes to complete a DKG or reach a round, in real time
+const waitTimeout = 2 * time.Minute
+
+// pollPeriod is how often the nodes are asked for their progress while waiting for them
+const pollPeriod = 100 * time.Millisecond
+
+// Node is a drand daemon of the network, running its beacon
+type Node struct {
+	// Addr is the private address of the node, identifying it in the group
+	Addr string
+	// ControlPort is the control port of the daemon, to issue the commands of the CLI to it
+	ControlPort string
+	Daemon      *core.DrandDaemon
+	Beacon      *core.BeaconProcess
+	Keypair     *key.Pair
+
+	log log.Logger
+	dkg *dkg.TestRunner
+}
+
+// Network is a set of nodes running a beacon chain together in process. The nodes share a fake clock, so the rounds
+// are only produced when the clock is advanced.
+type Network struct {
+	t        testing.TB
+	log      log.Logger
+	dir      string
+	clock    clock.FakeClock
+	scheme   *crypto.Scheme
+	beaconID string
+	period   time.Duration
+	catchup  time.Duration
+	thr      int
+	opts     []core.ConfigOption
+
+	// the nodes created, the ones created for a reshare are added to them
+	nodes []*Node
+	// the group of the last DKG or reshare, nil before the first one, and its epoch
+	group *key.Group
+	epoch uint32
+}
+
+// Option configures the network
+type Option func(*Network)
+
+// WithBeaconID runs the network under the given beacon ID instead of the default one
+func WithBeaconID(id string) Option {
+	return func(n *Network) {
+		n.beaconID = common.GetCanonicalBeaconID(id)
+	}
+}
+
+// WithScheme runs the network with the given scheme instead of the one of the SCHEME_ID environment variable
+func WithScheme(sch *crypto.Scheme) Option {
+	return func(n *Network) {
+		n.scheme = sch
+	}
+}
+
+// WithCatchupPeriod sets the catchup period of the network, 0 by default
+func WithCatchupPeriod(period time.Duration) Option {
+	return func(n *Network) {
+		n.catchup = period
+	}
+}
+
+// WithConfig adds the options to the configuration of all the daemons, after the ones of the harness, e.g. to store
+// the chains in another database than bolt
+func WithConfig(opts ...NodeOption) Option {
+	return func(n *Network) {
+		n.opts = append(n.opts, configOptions(opts)...)
+	}
+}
+
+// NodeOption configures the daemon of a node
+type NodeOption func(*nodeConfig)
+
+// nodeConfig holds the options of the configuration of a daemon, which are internal to the core
+type nodeConfig struct {
+	opts []core.ConfigOption
+}
+
+func configOptions(opts []NodeOption) []core.ConfigOption {
+	var c nodeConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c.opts
+}
+
+// WithStorageEngine stores the chains with the given engine, one of bolt and memdb or the name of a store driver
+// registered with plugin.RegisterStore, instead of bolt
+func WithStorageEngine(engine string) NodeOption {
+	return func(c *nodeConfig) {
+		c.opts = append(c.opts, core.WithDBStorageEngine(chain.StorageType(engine)))
+	}
+}
+
+// WithPostgres stores the chains in the PostgreSQL database of the given DSN
+func WithPostgres(dsn string) NodeOption {
+	return func(c *nodeConfig) {
+		c.opts = append(c.opts, core.WithDBStorageEngine(chain.PostgreSQL), core.WithPgDSN(dsn))
+	}
+}
+
+// WithMemDBSize sets how many beacons the chains stored with the memdb engine keep
+func WithMemDBSize(size int) NodeOption {
+	return func(c *nodeConfig) {
+		c.opts = append(c.opts, core.WithMemDBSize(size))
+	}
+}
+
+// WithSigner signs the partials with the signer registered under the given name with plugin.RegisterSigner, in
+// place of the shares of the nodes
+func WithSigner(name string) NodeOption {
+	return func(c *nodeConfig) {
+		c.opts = append(c.opts, core.WithSigner(name))
+	}
+}
+
+// WithPublishers publishes the new beacons with the publishers registered under the given names with
+// plugin.RegisterPublisher
+func WithPublishers(names ...string) NodeOption {
+	return func(c *nodeConfig) {
+		c.opts = append(c.opts, core.WithPublishers(names))
+	}
+}
+
+// WithShutdownHooks runs the hooks during the shutdown of each daemon, after the ones registered with
+// plugin.RegisterHook
+func WithShutdownHooks(hooks *plugin.ShutdownHooks) Option {
+	return func(n *Network) {
+		n.opts = append(n.opts, core.WithShutdownHooks(hooks))
+	}
+}
+
+// New creates a network of n nodes, ready to run a DKG for the given threshold that will launch a beacon with the
+// given period. The daemons are stopped at the end of the test.
+func New(t testing.TB, n, thr int, period time.Duration, opts ...Option) *Network {
+	sch, err := crypto.GetSchemeFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	nw := &Network{
+		t:        t,
+		log:      testlogger.New(t),
+		dir:      t.TempDir(),
+		clock:    clock.NewFakeClockAt(time.Now()),
+		scheme:   sch,
+		beaconID: common.GetCanonicalBeaconID(""),
+		period:   period,
+		thr:      thr,
+	}
+	for _, opt := range opts {
+		opt(nw)
+	}
+
+	nw.addNodes(n, []core.ConfigOption{core.WithCallOption(grpc.WaitForReady(true))})
+	return nw
+}
+
+// AddNodes creates n more nodes, e.g. to join the group in a reshare, with the given options added to the ones of the
+// network
+func (nw *Network) AddNodes(n int, opts ...NodeOption) []*Node {
+	return nw.addNodes(n, configOptions(opts))
+}
+
+func (nw *Network) addNodes(n int, opts []core.ConfigOption) []*Node {
+	nodes := make([]*Node, 0, n)
+	for i := 0; i < n; i++ {
+		index := len(nw.nodes)
+		node, err := nw.newNode(index, opts)
+		if err != nil {
+			nw.t.Fatalf("creating node %d: %v", index, err)
+		}
+		nw.nodes = append(nw.nodes, node)
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func (nw *Network) newNode(index int, opts []core.ConfigOption) (*Node, error) {
+	ctx := context.Background()
+	pair, err := key.NewKeyPair(test.FreeBind("127.0.0.1"), nw.scheme)
+	if err != nil {
+		return nil, err
+	}
+	store := test.NewKeyStore()
+	if err := store.SaveKeyPair(pair); err != nil {
+		return nil, err
+	}
+
+	folder := path.Join(nw.dir, fmt.Sprintf("drand-%d", index))
+	if err := os.MkdirAll(folder, 0o700); err != nil {
+		return nil, err
+	}
+	controlPort := test.FreePort()
+	confOptions := []core.ConfigOption{
+		core.WithConfigFolder(folder),
+		core.WithClock(nw.clock),
+		core.WithDBStorageEngine(chain.BoltDB),
+		core.WithDkgKickoffGracePeriod(1 * time.Second),
+		core.WithDkgPhaseTimeout(5 * time.Second),
+		core.WithPrivateListenAddress(pair.Public.Address()),
+		core.WithControlPort(controlPort),
+		core.WithNamedLogger(fmt.Sprintf("[node %d]", index)),
+		core.WithMemDBSize(100),
+	}
+	// the options given last overwrite the defaults
+	confOptions = append(confOptions, nw.opts...)
+	confOptions = append(confOptions, opts...)
+
+	daemon, err := core.NewDrandDaemon(ctx, core.NewConfig(nw.log, confOptions...))
+	if err != nil {
+		return nil, err
+	}
+	nw.t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		daemon.Stop(ctx)
+	})
+	bp, err := daemon.InstantiateBeaconProcess(ctx, nw.beaconID, store)
+	if err != nil {
+		return nil, err
+	}
+
+	dkgClient, err := net.NewDKGControlClient(nw.log, controlPort)
+	if err != nil {
+		return nil, err
+	}
+	return &Node{
+		Addr:        pair.Public.Address(),
+		ControlPort: controlPort,
+		Daemon:      daemon,
+		Beacon:      bp,
+		Keypair:     pair,
+		log:         nw.log.Named(pair.Public.Address()),
+		dkg:         &dkg.TestRunner{BeaconID: nw.beaconID, Client: dkgClient, Clock: nw.clock},
+	}, nil
+}
+
+// Nodes returns all the nodes created, in the order they were
+func (nw *Network) Nodes() []*Node {
+	return nw.nodes
+}
+
+// Node returns the node with the given address, nil if there is none
+func (nw *Network) Node(addr string) *Node {
+	for _, n := range nw.nodes {
+		if n.Addr == addr {
+			return n
+		}
+	}
+	return nil
+}
+
+// Group returns the group of the last DKG or reshare, nil before the first one
+func (nw *Network) Group() *key.Group {
+	return nw.group
+}
+
+// Members returns the nodes of the group of the last DKG or reshare
+func (nw *Network) Members() []*Node {
+	if nw.group == nil {
+		return nil
+	}
+	members := make([]*Node, 0, len(nw.group.Nodes))
+	for _, gn := range nw.group.Nodes {
+		if n := nw.Node(gn.Address()); n != nil {
+			members = append(members, n)
+		}
+	}
+	return members
+}
+
+// Now returns the time of the clock of the network
+func (nw *Network) Now() time.Time {
+	return nw.clock.Now()
+}
+
+// AdvanceClock advances the clock of the network, and gives the nodes the time to react to it
+func (nw *Network) AdvanceClock(d time.Duration) {
+	nw.clock.Advance(d)
+	// the nodes produce their partials and aggregate them in real time
+	time.Sleep(time.Second)
+}
+
+// SetClock advances the clock of the network to the given UNIX time, if it isn't past it already
+func (nw *Network) SetClock(unix int64) {
+	if now := nw.clock.Now().Unix(); now < unix {
+		nw.AdvanceClock(time.Duration(unix-now) * time.Second)
+	}
+}
+
+// RunDKG runs the first DKG of the network with all its nodes, the first one leading it, and returns its group once
+// the leader completed it
+func (nw *Network) RunDKG() (*key.Group, error) {
+	if len(nw.nodes) == 0 {
+		return nil, errors.New("cannot run a DKG without nodes")
+	}
+	joiners, err := participants(nw.nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	leader := nw.nodes[0]
+	err = leader.dkg.LeadFirstDKG(runners(nw.nodes[1:]), nw.thr, int(nw.period.Seconds()), nw.scheme.Name, dkgTimeout,
+		int(nw.catchup.Seconds()), joiners)
+	if err != nil {
+		return nil, err
+	}
+	return nw.waitForDKG(leader, nw.epoch+1)
+}
+
+// RunReshare reshares the group to the remaining and joining nodes with the given threshold, the first remaining
+// node leading it, and returns the new group once the leader completed it
+func (nw *Network) RunReshare(remaining, joining []*Node, thr int) (*key.Group, error) {
+	if len(remaining) == 0 {
+		return nil, errors.New("cannot run a reshare without remaining nodes")
+	}
+	if nw.group == nil {
+		return nil, errors.New("cannot reshare before the first DKG")
+	}
+	remainers, err := participants(remaining)
+	if err != nil {
+		return nil, err
+	}
+	joiners, err := participants(joining)
+	if err != nil {
+		return nil, err
+	}
+
+	leader := remaining[0]
+	err = leader.dkg.LeadReshare(runners(remaining[1:]), runners(joining), nw.group, thr, int(nw.catchup.Seconds()),
+		remainers, joiners, nil)
+	if err != nil {
+		return nil, err
+	}
+	return nw.waitForDKG(leader, nw.epoch+1)
+}
+
+// waitForDKG waits for the leader to complete the DKG of the epoch, and keeps its group as the group of the network
+func (nw *Network) waitForDKG(leader *Node, epoch uint32) (*key.Group, error) {
+	if err := leader.dkg.WaitForDKG(leader.log, epoch, int(waitTimeout.Seconds())); err != nil {
+		return nil, fmt.Errorf("waiting for the DKG of epoch %d: %w", epoch, err)
+	}
+	packet, err := leader.Beacon.GroupFile(context.Background(), &drand.GroupRequest{})
+	if err != nil {
+		return nil, err
+	}
+	group, err := key.GroupFromProto(packet, nw.scheme)
+	if err != nil {
+		return nil, err
+	}
+	nw.group = group
+	nw.epoch = epoch
+	nw.t.Logf("[harness] DKG of epoch %d completed, genesis at %d, transition at %d", epoch, group.GenesisTime,
+		group.TransitionTime)
+	return group, nil
+}
+
+// participants returns the nodes as participants of a DKG
+func participants(nodes []*Node) ([]*pdkg.Participant, error) {
+	ids := make([]*key.Identity, 0, len(nodes))
+	for _, n := range nodes {
+		ids = append(ids, n.Keypair.Public)
+	}
+	return dkg.TestParticipants(ids)
+}
+
+// runners returns the DKG runners of the nodes
+func runners(nodes []*Node) []*dkg.TestRunner {
+	rs := make([]*dkg.TestRunner, 0, len(nodes))
+	for _, n := range nodes {
+		rs = append(rs, n.dkg)
+	}
+	return rs
+}
+
+// StopNode stops the beacon of the node, as if it was killed, and waits for it to exit. Its daemon keeps running so
+// that the beacon can be started again with StartNode.
+func (nw *Network) StopNode(node *Node) {
+	node.Beacon.Stop(context.Background())
+	<-node.Beacon.WaitExit()
+	nw.t.Logf("[harness] stopped %s", node.Addr)
+}
+
+// StartNode starts the beacon of the node again after StopNode, catching up with the chain of the group if asked to
+func (nw *Network) StartNode(node *Node, catchup bool) error {
+	if err := node.Beacon.StartBeacon(context.Background(), catchup); err != nil {
+		return err
+	}
+	nw.t.Logf("[harness] started %s", node.Addr)
+	return nil
+}
+
+// WaitUntilRound waits for the node to have stored the round, or fails past the timeout of the harness
+func (nw *Network) WaitUntilRound(node *Node, round uint64) error {
+	return nw.waitStatus(node, func(s *drand.StatusResponse) bool {
+		return !s.GetChainStore().GetIsEmpty() && s.GetChainStore().GetLastStored() >= round
+	}, fmt.Sprintf("round %d", round))
+}
+
+// WaitUntilServing waits for the node to serve its chain, or fails past the timeout of the harness
+func (nw *Network) WaitUntilServing(node *Node) error {
+	return nw.waitStatus(node, func(s *drand.StatusResponse) bool {
+		return s.GetBeacon().GetIsServing()
+	}, "serving")
+}
+
+// waitStatus polls the status of the node until it satisfies the condition
+func (nw *Network) waitStatus(node *Node, cond func(*drand.StatusResponse) bool, what string) error {
+	client, err := net.NewControlClient(node.log, node.ControlPort)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	deadline := time.Now().Add(waitTimeout)
+	for {
+		s, err := client.Status(nw.beaconID)
+		if err == nil && cond(s) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			if err != nil {
+				return fmt.Errorf("node %s never reached %s: %w", node.Addr, what, err)
+			}
+			return fmt.Errorf("node %s never reached %s, it is at round %d", node.Addr, what,
+				s.GetChainStore().GetLastStored())
+		}
+		time.Sleep(pollPeriod)
+	}
+}
@@ -0,0 +1,50 @@
+package harness_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/drand/drand/v2/common/key"
+	"github.com/drand/drand/v2/internal/test"
+	"github.com/drand/drand/v2/test/harness"
+)
+
+func TestNetwork(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping slow test in short mode.")
+	}
+
+	n := 3
+	period := 3 * time.Second
+	nw := harness.New(t, n, key.DefaultThreshold(n), period, harness.WithBeaconID(test.GetBeaconIDFromEnv()))
+
+	group, err := nw.RunDKG()
+	require.NoError(t, err)
+	require.Len(t, group.Nodes, n)
+	require.Len(t, nw.Members(), n)
+
+	nw.SetClock(group.GenesisTime)
+	for _, node := range nw.Nodes() {
+		require.NoError(t, nw.WaitUntilRound(node, 1))
+	}
+
+	// the group keeps producing beacons without one of its nodes, which catches up once restarted
+	stopped := nw.Nodes()[n-1]
+	nw.StopNode(stopped)
+	nw.AdvanceClock(period)
+	require.NoError(t, nw.WaitUntilRound(nw.Nodes()[0], 2))
+
+	require.NoError(t, nw.StartNode(stopped, true))
+	nw.AdvanceClock(period)
+	require.NoError(t, nw.WaitUntilRound(stopped, 3))
+}
+
+func TestNetworkNodeOptions(t *testing.T) {
+	nw := harness.New(t, 1, 1, time.Second,
+		harness.WithConfig(harness.WithStorageEngine("memdb"), harness.WithMemDBSize(10)))
+	joining := nw.AddNodes(1, harness.WithStorageEngine("bolt"))
+	require.Len(t, nw.Nodes(), 2)
+	require.Equal(t, joining[0], nw.Node(joining[0].Addr))
+}
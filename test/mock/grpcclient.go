@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/drand/drand/v2/common"
 	"github.com/drand/drand/v2/common/chain"
 	"github.com/drand/drand/v2/common/client"
 	"github.com/drand/drand/v2/crypto"
@@ -19,9 +20,9 @@ func NewGrpcClient(s *Server) *GrpcClient {
 	return &GrpcClient{s: s}
 }
 
-func (c *GrpcClient) Get(ctx context.Context, round uint64) (client.Result, error) {
+func (c *GrpcClient) Get(ctx context.Context, round common.Round) (client.Result, error) {
 	rand, err := c.s.PublicRand(ctx, &drand.PublicRandRequest{
-		Round:    round,
+		Round:    round.Uint64(),
 		Metadata: nil,
 	})
 	if err != nil {
@@ -102,6 +102,23 @@ func (s *Server) PublicRand(_ context.Context, in *drand.PublicRandRequest) (*dr
 	return &resp, nil
 }
 
+// SignedBeacon returns a valid beacon of the chain of the server for any round, without changing the rounds it serves
+func (s *Server) SignedBeacon(round uint64) *drand.PublicRandResponse {
+	s.l.Lock()
+	defer s.l.Unlock()
+	b := &drand.PublicRandResponse{Round: round}
+	if s.chainInfo.SchemeID == crypto.DefaultSchemeID {
+		b.PreviousSignature = decodeHex(s.d.PreviousSignature)
+	}
+	sig, err := s.d.Scheme.AuthScheme.Sign(s.d.secret, s.d.Scheme.DigestBeacon(b))
+	if err != nil {
+		panic(err)
+	}
+	b.Signature = sig
+	b.Randomness = crypto.RandomnessFromSignature(sig)
+	return b
+}
+
 // PublicRandStream is part of the public drand service.
 func (s *Server) PublicRandStream(_ *drand.PublicRandRequest, stream drand.Public_PublicRandStreamServer) error {
 	streamDone := make(chan error, 1)
@@ -346,6 +363,14 @@ func (s *Server) DKGStatus(_ context.Context, _ *pdkg.DKGStatusRequest) (*pdkg.D
 	return nil, errors.New("unimplemented for mock server")
 }
 
+func (s *Server) GroupHistory(_ context.Context, _ *pdkg.GroupHistoryRequest) (*pdkg.GroupHistoryResponse, error) {
+	return nil, errors.New("unimplemented for mock server")
+}
+
+func (s *Server) GroupForRound(_ context.Context, _ *pdkg.GroupForRoundRequest) (*pdkg.GroupForRoundResponse, error) {
+	return nil, errors.New("unimplemented for mock server")
+}
+
 func (s *Server) Metrics(_ context.Context, _ *drand.MetricsRequest) (*drand.MetricsResponse, error) {
 	return nil, errors.New("unimplemented for mock server")
 }